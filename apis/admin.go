@@ -22,7 +22,8 @@ func bindAdminApi(app core.App, rg *echo.Group) {
 	subGroup.POST("/confirm-password-reset", api.confirmPasswordReset)
 	subGroup.POST("/auth-refresh", api.authRefresh, RequireAdminAuth())
 	subGroup.GET("", api.list, RequireAdminAuth())
-	subGroup.POST("", api.create, RequireAdminAuthOnlyIfAny(app))
+	subGroup.GET("/stats", api.stats, RequireAdminAuth())
+	subGroup.POST("", api.create, RequireAdminAuthOnlyIfAny(app), requireAdminSignup(app))
 	subGroup.GET("/:id", api.view, RequireAdminAuth())
 	subGroup.PATCH("/:id", api.update, RequireAdminAuth())
 	subGroup.DELETE("/:id", api.delete, RequireAdminAuth())
@@ -54,6 +55,8 @@ func (api *adminApi) authResponse(c echo.Context, admin *models.Admin, finalizer
 			return nil
 		}
 
+		setAuthCookie(api.app, e.HttpContext, e.Token, int(api.app.Settings().AdminAuthToken.Duration))
+
 		return e.HttpContext.JSON(200, map[string]any{
 			"token": e.Token,
 			"admin": e.Admin,
@@ -84,6 +87,11 @@ func (api *adminApi) authWithPassword(c echo.Context) error {
 		return NewBadRequestError("An error occurred while loading the submitted data.", err)
 	}
 
+	lockoutKeys := passwordAuthLockoutKeys(c, adminsLockoutScope, form.Identity)
+	if err := checkAuthLockout(api.app, lockoutKeys); err != nil {
+		return err
+	}
+
 	event := new(core.AdminAuthWithPasswordEvent)
 	event.HttpContext = c
 	event.Password = form.Password
@@ -95,10 +103,30 @@ func (api *adminApi) authWithPassword(c echo.Context) error {
 
 			return api.app.OnAdminBeforeAuthWithPasswordRequest().Trigger(event, func(e *core.AdminAuthWithPasswordEvent) error {
 				if err := next(e.Admin); err != nil {
+					failure := &core.SecurityEvent{
+						Type:      core.SecurityEventAuthFailure,
+						ActorType: core.SecurityActorAdmin,
+						Data:      map[string]any{"identity": e.Identity},
+					}
+					if e.Admin != nil {
+						failure.ActorId = e.Admin.Id
+					}
+					emitSecurityEvent(api.app, e.HttpContext, failure)
+					api.app.AuthLockouts().RegisterFailure(lockoutKeys...)
+
 					return NewBadRequestError("Failed to authenticate.", err)
 				}
 
+				api.app.AuthLockouts().Reset(lockoutKeys[0])
+
 				return api.app.OnAdminAfterAuthWithPasswordRequest().Trigger(event, func(e *core.AdminAuthWithPasswordEvent) error {
+					emitSecurityEvent(api.app, e.HttpContext, &core.SecurityEvent{
+						Type:      core.SecurityEventAdminLogin,
+						ActorType: core.SecurityActorAdmin,
+						ActorId:   e.Admin.Id,
+						Data:      map[string]any{"email": e.Admin.Email},
+					})
+
 					return api.authResponse(e.HttpContext, e.Admin)
 				})
 			})
@@ -0,0 +1,76 @@
+package apis
+
+import (
+	"os"
+	"sync"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+// AdminSetupTokenHeader is the name of the request header that must hold
+// the admin setup token when creating the initial admin account.
+const AdminSetupTokenHeader = "X-Setup-Token"
+
+// AdminSetupTokenEnv is the name of the optional environment variable
+// with a predefined admin setup token (eg. for automated deployments).
+//
+// If not set, a random token is generated and printed on app serve.
+const AdminSetupTokenEnv = "PB_SETUP_TOKEN"
+
+const storeKeyAdminSetupToken = "@adminSetupToken"
+
+var adminSetupTokenMux sync.Mutex
+
+// AdminSetupToken returns the token required to create the initial
+// admin account of the provided app.
+//
+// The token is loaded from the AdminSetupTokenEnv environment variable
+// or, if missing, it is randomly generated once per app instance.
+func AdminSetupToken(app core.App) string {
+	if token := os.Getenv(AdminSetupTokenEnv); token != "" {
+		return token
+	}
+
+	adminSetupTokenMux.Lock()
+	defer adminSetupTokenMux.Unlock()
+
+	token, _ := app.Store().Get(storeKeyAdminSetupToken).(string)
+	if token == "" {
+		token = security.RandomString(32)
+		app.Store().Set(storeKeyAdminSetupToken, token)
+	}
+
+	return token
+}
+
+// requireAdminSignup middleware guards the admin create endpoint.
+//
+// Guests are allowed to create only the initial admin account and only
+// by providing a valid setup token. Authorized admins are allowed to
+// create new admins unless the admin signup is disabled from the settings.
+//
+// This middleware is expected to be used after [RequireAdminAuthOnlyIfAny].
+func requireAdminSignup(app core.App) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			admin, _ := c.Get(ContextAdminKey).(*models.Admin)
+			if admin != nil {
+				if app.Settings().AdminSignup.Disabled {
+					return NewForbiddenError("The creation of new admins is disabled.", nil)
+				}
+
+				return next(c)
+			}
+
+			token := c.Request().Header.Get(AdminSetupTokenHeader)
+			if token == "" || !security.Equal(token, AdminSetupToken(app)) {
+				return NewUnauthorizedError("Missing or invalid admin setup token.", nil)
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package apis
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/list"
+)
+
+// adminStatsMaxDays is the max allowed number of days of a single stats request.
+const adminStatsMaxDays = 366
+
+// adminStatsDefaultDays is the default number of days of a stats request.
+const adminStatsDefaultDays = 30
+
+// adminStatsMetricErrorRate is a derived metric with the percentage
+// of the failed requests (calculated from the requests and errors stats).
+const adminStatsMetricErrorRate = "errorRate"
+
+// adminStatsItem defines a single admin stats metric value.
+type adminStatsItem struct {
+	Date  string  `json:"date"`
+	Key   string  `json:"key"`
+	Value float64 `json:"value"`
+}
+
+// stats returns the daily stats rollups between the from and to dates
+// (default to the last 30 days) grouped by their metric.
+func (api *adminApi) stats(c echo.Context) error {
+	now := time.Now().UTC()
+
+	to := now
+	if raw := c.QueryParam("to"); raw != "" {
+		parsed, err := time.Parse(core.StatsDateLayout, raw)
+		if err != nil {
+			return NewBadRequestError("Invalid to date (expected YYYY-MM-DD).", err)
+		}
+		to = parsed
+	}
+
+	from := to.AddDate(0, 0, -(adminStatsDefaultDays - 1))
+	if raw := c.QueryParam("from"); raw != "" {
+		parsed, err := time.Parse(core.StatsDateLayout, raw)
+		if err != nil {
+			return NewBadRequestError("Invalid from date (expected YYYY-MM-DD).", err)
+		}
+		from = parsed
+	}
+
+	if from.After(to) {
+		return NewBadRequestError("The from date must be before the to date.", nil)
+	}
+
+	if to.Sub(from) >= adminStatsMaxDays*24*time.Hour {
+		return NewBadRequestError(fmt.Sprintf("The stats period must be max %d days.", adminStatsMaxDays), nil)
+	}
+
+	requestedMetrics := splitQueryList(c.QueryParam("metrics"))
+
+	metrics := requestedMetrics
+	if list.ExistInSlice(adminStatsMetricErrorRate, requestedMetrics) {
+		// the error rate is derived from the requests and errors stats
+		metrics = append(metrics, models.StatsMetricRequests, models.StatsMetricErrors)
+	}
+
+	items, err := api.app.LogsDao().FindStatsDaily(
+		from.Format(core.StatsDateLayout),
+		to.Format(core.StatsDateLayout),
+		metrics...,
+	)
+	if err != nil {
+		return NewBadRequestError("Failed to load the stats.", err)
+	}
+
+	result := map[string]any{
+		"from":                  from.Format(core.StatsDateLayout),
+		"to":                    to.Format(core.StatsDateLayout),
+		"activeRealtimeClients": len(api.app.SubscriptionsBroker().Clients()),
+	}
+
+	grouped := map[string][]*adminStatsItem{}
+	failed := map[string]float64{}
+
+	for _, item := range items {
+		grouped[item.Metric] = append(grouped[item.Metric], &adminStatsItem{
+			Date:  item.Date,
+			Key:   item.Key,
+			Value: item.Value,
+		})
+
+		if item.Metric == models.StatsMetricErrors {
+			failed[item.Date+"|"+item.Key] = item.Value
+		}
+	}
+
+	for _, item := range grouped[models.StatsMetricRequests] {
+		var rate float64
+		if item.Value > 0 {
+			rate = failed[item.Date+"|"+item.Key] * 100 / item.Value
+		}
+
+		grouped[adminStatsMetricErrorRate] = append(grouped[adminStatsMetricErrorRate], &adminStatsItem{
+			Date:  item.Date,
+			Key:   item.Key,
+			Value: rate,
+		})
+	}
+
+	for metric, metricItems := range grouped {
+		if len(requestedMetrics) > 0 && !list.ExistInSlice(metric, requestedMetrics) {
+			continue
+		}
+		result[metric] = metricItems
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
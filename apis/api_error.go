@@ -1,13 +1,19 @@
 package apis
 
 import (
+	"encoding/json"
 	"net/http"
 	"strings"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/models/settings"
 	"github.com/pocketbase/pocketbase/tools/inflector"
 )
 
+// MIMEApplicationProblemJSON is the content type of the RFC 9457 api error responses.
+const MIMEApplicationProblemJSON = "application/problem+json"
+
 // ApiError defines the struct for a basic api error response.
 type ApiError struct {
 	Code    int            `json:"code"`
@@ -16,6 +22,9 @@ type ApiError struct {
 
 	// stores unformatted error data (could be an internal error, text, etc.)
 	rawData any
+
+	// explicit machine-readable error code
+	errorCode string
 }
 
 // Error makes it compatible with the `error` interface.
@@ -28,6 +37,34 @@ func (e *ApiError) RawData() any {
 	return e.rawData
 }
 
+// WithErrorCode sets an explicit machine-readable error code
+// (see also RegisterErrorCode) and returns the same error instance.
+func (e *ApiError) WithErrorCode(code string) *ApiError {
+	e.errorCode = code
+
+	return e
+}
+
+// ErrorCode returns the machine-readable error code of the current error.
+//
+// If no explicit code was set with WithErrorCode, the code is resolved
+// from the error data (for validation errors) and the response status.
+func (e *ApiError) ErrorCode() string {
+	if e.errorCode != "" {
+		return e.errorCode
+	}
+
+	if len(e.Data) > 0 {
+		if hasErrorItemCode(e.Data, "validation_file_size_limit") {
+			return ErrorCodeFileTooLarge
+		}
+
+		return ErrorCodeValidationFailed
+	}
+
+	return defaultErrorCode(e.Code)
+}
+
 // NewNotFoundError creates and returns 404 `ApiError`.
 func NewNotFoundError(message string, data any) *ApiError {
 	if message == "" {
@@ -74,30 +111,96 @@ func NewApiError(status int, message string, data any) *ApiError {
 	}
 }
 
+// writeApiError sends the provided api error in the specified
+// settings.ApiErrorsConfig format.
+func writeApiError(c echo.Context, apiErr *ApiError, format string) error {
+	c.Response().Header().Set(ErrorCodeHeader, apiErr.ErrorCode())
+
+	// @see https://github.com/labstack/echo/issues/608
+	if c.Request().Method == http.MethodHead {
+		return c.NoContent(apiErr.Code)
+	}
+
+	switch format {
+	case settings.ApiErrorsFormatExtended:
+		return c.JSON(apiErr.Code, struct {
+			Code      int            `json:"code"`
+			ErrorCode string         `json:"errorCode"`
+			Message   string         `json:"message"`
+			Data      map[string]any `json:"data"`
+		}{apiErr.Code, apiErr.ErrorCode(), apiErr.Message, apiErr.Data})
+	case settings.ApiErrorsFormatProblem:
+		raw, err := json.Marshal(map[string]any{
+			"type":   "about:blank",
+			"title":  http.StatusText(apiErr.Code),
+			"status": apiErr.Code,
+			"detail": apiErr.Message,
+			"code":   apiErr.ErrorCode(),
+			"errors": apiErr.Data,
+		})
+		if err != nil {
+			return err
+		}
+
+		return c.Blob(apiErr.Code, MIMEApplicationProblemJSON, raw)
+	default:
+		return c.JSON(apiErr.Code, apiErr)
+	}
+}
+
+// hasErrorItemCode checks recursively whether the provided
+// safe errors data has an error item with the specified code.
+func hasErrorItemCode(data map[string]any, code string) bool {
+	for _, v := range data {
+		switch item := v.(type) {
+		case map[string]string:
+			if item["code"] == code {
+				return true
+			}
+		case map[string]any:
+			if hasErrorItemCode(item, code) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// errorTranslator defines a function that returns the
+// translation of the provided error code or message (if any).
+type errorTranslator func(key string) (string, bool)
+
 func safeErrorsData(data any) map[string]any {
+	return translatedErrorsData(data, nil)
+}
+
+// translatedErrorsData is similar to safeErrorsData but additionally
+// translates the error item messages with the provided translator.
+func translatedErrorsData(data any, tr errorTranslator) map[string]any {
 	switch v := data.(type) {
 	case validation.Errors:
-		return resolveSafeErrorsData[error](v)
+		return resolveSafeErrorsData[error](v, tr)
 	case map[string]validation.Error:
-		return resolveSafeErrorsData[validation.Error](v)
+		return resolveSafeErrorsData[validation.Error](v, tr)
 	case map[string]error:
-		return resolveSafeErrorsData[error](v)
+		return resolveSafeErrorsData[error](v, tr)
 	case map[string]any:
-		return resolveSafeErrorsData[any](v)
+		return resolveSafeErrorsData[any](v, tr)
 	default:
 		return map[string]any{} // not nil to ensure that is json serialized as object
 	}
 }
 
-func resolveSafeErrorsData[T any](data map[string]T) map[string]any {
+func resolveSafeErrorsData[T any](data map[string]T, tr errorTranslator) map[string]any {
 	result := map[string]any{}
 
 	for name, err := range data {
 		if isNestedError(err) {
-			result[name] = safeErrorsData(err)
+			result[name] = translatedErrorsData(err, tr)
 			continue
 		}
-		result[name] = resolveSafeErrorItem(err)
+		result[name] = resolveSafeErrorItem(err, tr)
 	}
 
 	return result
@@ -114,17 +217,28 @@ func isNestedError(err any) bool {
 
 // resolveSafeErrorItem extracts from each validation error its
 // public safe error code and message.
-func resolveSafeErrorItem(err any) map[string]string {
+func resolveSafeErrorItem(err any, tr errorTranslator) map[string]string {
 	// default public safe error values
 	code := "validation_invalid_value"
 	msg := "Invalid value."
 
 	// only validation errors are public safe
-	if obj, ok := err.(validation.Error); ok {
+	obj, isValidationErr := err.(validation.Error)
+	if isValidationErr {
 		code = obj.Code()
 		msg = inflector.Sentenize(obj.Error())
 	}
 
+	if tr != nil {
+		if translated, ok := tr(code); ok {
+			if isValidationErr {
+				// render the translated message template with the original error params
+				translated = validation.NewError(code, translated).SetParams(obj.Params()).Error()
+			}
+			msg = inflector.Sentenize(translated)
+		}
+	}
+
 	return map[string]string{
 		"code":    code,
 		"message": msg,
@@ -148,3 +148,47 @@ func TestNewUnauthorizedError(t *testing.T) {
 		}
 	}
 }
+
+func TestApiErrorErrorCode(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		err      *apis.ApiError
+		expected string
+	}{
+		{"400 without data", apis.NewBadRequestError("", nil), "bad_request"},
+		{"401", apis.NewUnauthorizedError("", nil), "unauthorized"},
+		{"403", apis.NewForbiddenError("", nil), "forbidden"},
+		{"404", apis.NewNotFoundError("", nil), "not_found"},
+		{"413", apis.NewApiError(413, "", nil), "request_too_large"},
+		{"500", apis.NewApiError(500, "", nil), "internal_error"},
+		{"unknown status", apis.NewApiError(300, "", nil), "unknown_error"},
+		{
+			"validation errors",
+			apis.NewBadRequestError("", validation.Errors{"title": validation.ErrRequired}),
+			"validation_failed",
+		},
+		{
+			"nested file size validation error",
+			apis.NewBadRequestError("", validation.Errors{
+				"title": validation.ErrRequired,
+				"files": validation.Errors{
+					"0": validation.NewError("validation_file_size_limit", "too large"),
+				},
+			}),
+			"file_too_large",
+		},
+		{
+			"explicit code",
+			apis.NewForbiddenError("", validation.Errors{"title": validation.ErrRequired}).WithErrorCode("rule_forbidden"),
+			"rule_forbidden",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			if code := s.err.ErrorCode(); code != s.expected {
+				t.Fatalf("Expected error code %q, got %q", s.expected, code)
+			}
+		})
+	}
+}
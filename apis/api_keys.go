@@ -0,0 +1,116 @@
+package apis
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+// bindApiKeysApi registers the api keys management endpoints and the corresponding handlers.
+func bindApiKeysApi(app core.App, rg *echo.Group) {
+	api := apiKeysApi{app: app}
+
+	subGroup := rg.Group("/api-keys", ActivityLogger(app), RequireAdminAuth())
+	subGroup.GET("", api.list)
+	subGroup.POST("", api.create)
+	subGroup.GET("/:id", api.view)
+	subGroup.PATCH("/:id", api.update)
+	subGroup.DELETE("/:id", api.delete)
+}
+
+type apiKeysApi struct {
+	app core.App
+}
+
+// apiKeyCreateResult defines the api key create response.
+type apiKeyCreateResult struct {
+	*models.ApiKey
+
+	// Key is the plain api key (it is returned only once on create).
+	Key string `json:"key"`
+}
+
+func (api *apiKeysApi) list(c echo.Context) error {
+	fieldResolver := search.NewSimpleFieldResolver(
+		"id", "created", "updated", "name", "adminId", "keyHint", "expires", "lastUsed",
+	)
+
+	apiKeys := []*models.ApiKey{}
+
+	result, err := search.NewProvider(fieldResolver).
+		Query(api.app.Dao().ApiKeyQuery()).
+		ParseAndExec(c.QueryParams().Encode(), &apiKeys)
+
+	if err != nil {
+		return NewBadRequestError("", err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (api *apiKeysApi) view(c echo.Context) error {
+	apiKey, err := api.app.Dao().FindApiKeyById(c.PathParam("id"))
+	if err != nil || apiKey == nil {
+		return NewNotFoundError("", err)
+	}
+
+	return c.JSON(http.StatusOK, apiKey)
+}
+
+func (api *apiKeysApi) create(c echo.Context) error {
+	admin, _ := c.Get(ContextAdminKey).(*models.Admin)
+	if admin == nil {
+		return NewUnauthorizedError("The request requires valid admin authorization token to be set.", nil)
+	}
+
+	key := models.NewApiKeySecret()
+
+	apiKey := &models.ApiKey{AdminId: admin.Id}
+	apiKey.SetKey(key)
+
+	form := forms.NewApiKeyUpsert(api.app, apiKey)
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("Failed to load the submitted data due to invalid formatting.", err)
+	}
+
+	if err := form.Submit(); err != nil {
+		return NewBadRequestError("Failed to create api key.", err)
+	}
+
+	return c.JSON(http.StatusOK, &apiKeyCreateResult{ApiKey: apiKey, Key: key})
+}
+
+func (api *apiKeysApi) update(c echo.Context) error {
+	apiKey, err := api.app.Dao().FindApiKeyById(c.PathParam("id"))
+	if err != nil || apiKey == nil {
+		return NewNotFoundError("", err)
+	}
+
+	form := forms.NewApiKeyUpsert(api.app, apiKey)
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("Failed to load the submitted data due to invalid formatting.", err)
+	}
+
+	if err := form.Submit(); err != nil {
+		return NewBadRequestError("Failed to update api key.", err)
+	}
+
+	return c.JSON(http.StatusOK, apiKey)
+}
+
+func (api *apiKeysApi) delete(c echo.Context) error {
+	apiKey, err := api.app.Dao().FindApiKeyById(c.PathParam("id"))
+	if err != nil || apiKey == nil {
+		return NewNotFoundError("", err)
+	}
+
+	if err := api.app.Dao().DeleteApiKey(apiKey); err != nil {
+		return NewBadRequestError("Failed to delete api key.", err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
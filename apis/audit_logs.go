@@ -0,0 +1,416 @@
+package apis
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/search"
+	"github.com/pocketbase/pocketbase/tools/types"
+	"github.com/spf13/cast"
+)
+
+// auditLogsMaskedValue is the value stored in the audit logs
+// in place of the sensitive record fields (eg. encrypted text fields).
+const auditLogsMaskedValue = "***"
+
+// auditLogsOriginalKey is the request context key with the state
+// of the currently updated collection or settings before the change.
+const auditLogsOriginalKey = "auditLogsOriginal"
+
+// bindAuditLogsApi registers the audit logs api endpoints
+// and the records, collections and settings changes audit trail hooks.
+func bindAuditLogsApi(app core.App, rg *echo.Group) {
+	api := auditLogsApi{app: app}
+
+	subGroup := rg.Group("/logs/audit", RequireAdminAuth())
+	subGroup.GET("", api.list)
+	subGroup.GET("/:id", api.view)
+
+	api.bindHooks()
+}
+
+type auditLogsApi struct {
+	app core.App
+
+	// actors keeps the request initiator of the currently
+	// processed records (*models.Record -> auditActor)
+	actors sync.Map
+}
+
+type auditActor struct {
+	Type string
+	Id   string
+}
+
+var auditLogFilterFields = []string{
+	"rowid", "id", "created", "updated",
+	"action", "actorType", "actorId",
+	"collectionId", "collectionName", "recordId",
+	`^before\.[\w\.\:]*\w+$`,
+	`^after\.[\w\.\:]*\w+$`,
+}
+
+func (api *auditLogsApi) list(c echo.Context) error {
+	fieldResolver := search.NewSimpleFieldResolver(auditLogFilterFields...)
+
+	result, err := search.NewProvider(fieldResolver).
+		Query(api.app.LogsDao().AuditLogQuery()).
+		ParseAndExec(c.QueryParams().Encode(), &[]*models.AuditLog{})
+
+	if err != nil {
+		return NewBadRequestError("", err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (api *auditLogsApi) view(c echo.Context) error {
+	id := c.PathParam("id")
+	if id == "" {
+		return NewNotFoundError("", nil)
+	}
+
+	log, err := api.app.LogsDao().FindAuditLogById(id)
+	if err != nil || log == nil {
+		return NewNotFoundError("", err)
+	}
+
+	return c.JSON(http.StatusOK, log)
+}
+
+// bindHooks registers the request hooks that resolve the mutation
+// actor and the model hooks that store the audit log entries.
+func (api *auditLogsApi) bindHooks() {
+	api.app.OnRecordBeforeCreateRequest().PreAdd(func(e *core.RecordCreateEvent) error {
+		api.trackActor(e.HttpContext, e.Record)
+		return nil
+	})
+
+	api.app.OnRecordBeforeUpdateRequest().PreAdd(func(e *core.RecordUpdateEvent) error {
+		api.trackActor(e.HttpContext, e.Record)
+		return nil
+	})
+
+	api.app.OnRecordBeforeDeleteRequest().PreAdd(func(e *core.RecordDeleteEvent) error {
+		api.trackActor(e.HttpContext, e.Record)
+		return nil
+	})
+
+	api.app.OnModelAfterCreate().Add(func(e *core.ModelEvent) error {
+		if record, ok := e.Model.(*models.Record); ok {
+			api.save(models.AuditActionCreate, record, nil, recordAuditData(record))
+		}
+		return nil
+	})
+
+	api.app.OnModelAfterUpdate().Add(func(e *core.ModelEvent) error {
+		if record, ok := e.Model.(*models.Record); ok {
+			before, after := recordAuditDiff(record)
+			if len(after) > 0 {
+				api.save(models.AuditActionUpdate, record, before, after)
+			}
+		}
+		return nil
+	})
+
+	api.app.OnModelAfterDelete().Add(func(e *core.ModelEvent) error {
+		if record, ok := e.Model.(*models.Record); ok {
+			api.save(models.AuditActionDelete, record, recordAuditData(record), nil)
+		}
+		return nil
+	})
+
+	// collections
+	// ---
+	api.app.OnCollectionAfterCreateRequest().Add(func(e *core.CollectionCreateEvent) error {
+		api.saveCollectionChange(e.HttpContext, models.AuditActionCollectionCreate, e.Collection, nil, collectionAuditData(e.Collection))
+		return nil
+	})
+
+	api.app.OnCollectionBeforeUpdateRequest().PreAdd(func(e *core.CollectionUpdateEvent) error {
+		// the event collection is already loaded with the submitted data
+		if original, err := api.app.Dao().FindCollectionByNameOrId(e.Collection.Id); err == nil {
+			e.HttpContext.Set(auditLogsOriginalKey, collectionAuditData(original))
+		}
+		return nil
+	})
+
+	api.app.OnCollectionAfterUpdateRequest().Add(func(e *core.CollectionUpdateEvent) error {
+		original, _ := e.HttpContext.Get(auditLogsOriginalKey).(map[string]any)
+		before, after := auditDataDiff(original, collectionAuditData(e.Collection))
+		if len(after) > 0 {
+			api.saveCollectionChange(e.HttpContext, models.AuditActionCollectionUpdate, e.Collection, before, after)
+		}
+		return nil
+	})
+
+	api.app.OnCollectionAfterDeleteRequest().Add(func(e *core.CollectionDeleteEvent) error {
+		api.saveCollectionChange(e.HttpContext, models.AuditActionCollectionDelete, e.Collection, collectionAuditData(e.Collection), nil)
+		return nil
+	})
+
+	// settings
+	// ---
+	api.app.OnSettingsBeforeUpdateRequest().PreAdd(func(e *core.SettingsUpdateEvent) error {
+		e.HttpContext.Set(auditLogsOriginalKey, settingsAuditData(api.app.Settings()))
+		return nil
+	})
+
+	api.app.OnSettingsAfterUpdateRequest().Add(func(e *core.SettingsUpdateEvent) error {
+		original, _ := e.HttpContext.Get(auditLogsOriginalKey).(map[string]any)
+		before, after := auditDataDiff(original, settingsAuditData(api.app.Settings()))
+		if len(after) > 0 && api.app.Settings().AuditLogs.Enabled {
+			saveAuditLog(api.app, newAuditLog(e.HttpContext, models.AuditActionSettingsUpdate, before, after))
+		}
+		return nil
+	})
+}
+
+// requestAuditActor resolves the initiator of the provided request.
+func requestAuditActor(c echo.Context) auditActor {
+	if admin, _ := c.Get(ContextAdminKey).(*models.Admin); admin != nil {
+		return auditActor{Type: models.AuditActorAdmin, Id: admin.Id}
+	}
+
+	if authRecord, _ := c.Get(ContextAuthRecordKey).(*models.Record); authRecord != nil {
+		return auditActor{Type: models.AuditActorAuthRecord, Id: authRecord.Id}
+	}
+
+	return auditActor{Type: models.AuditActorGuest}
+}
+
+// newAuditLog creates a new audit log entry initiated by the provided request.
+func newAuditLog(c echo.Context, action string, before, after map[string]any) *models.AuditLog {
+	actor := requestAuditActor(c)
+
+	entry := &models.AuditLog{
+		Action:    action,
+		ActorType: actor.Type,
+		ActorId:   actor.Id,
+		Before:    types.JsonMap{},
+		After:     types.JsonMap{},
+	}
+
+	for k, v := range before {
+		entry.Before[k] = v
+	}
+	for k, v := range after {
+		entry.After[k] = v
+	}
+
+	return entry
+}
+
+// saveCollectionChange stores a new audit log entry for the provided collection change
+// (the ExcludeCollections setting is applicable only for the records mutations).
+func (api *auditLogsApi) saveCollectionChange(c echo.Context, action string, collection *models.Collection, before, after map[string]any) {
+	if !api.app.Settings().AuditLogs.Enabled {
+		return
+	}
+
+	entry := newAuditLog(c, action, before, after)
+	entry.CollectionId = collection.Id
+	entry.CollectionName = collection.Name
+
+	saveAuditLog(api.app, entry)
+}
+
+// trackActor stores the request initiator of the provided record
+// until the request response is written.
+func (api *auditLogsApi) trackActor(c echo.Context, record *models.Record) {
+	if !api.app.Settings().AuditLogs.Enabled {
+		return
+	}
+
+	api.actors.Store(record, requestAuditActor(c))
+
+	c.Response().After(func() {
+		api.actors.Delete(record)
+	})
+}
+
+// save stores a new audit log entry for the provided record mutation
+// (if the audit logs are enabled for the record collection).
+func (api *auditLogsApi) save(action string, record *models.Record, before, after map[string]any) {
+	config := api.app.Settings().AuditLogs
+	if !config.Enabled || list.ExistInSlice(record.Collection().Name, config.ExcludeCollections) {
+		return
+	}
+
+	actor := auditActor{Type: models.AuditActorSystem}
+	if v, ok := api.actors.Load(record); ok {
+		actor = v.(auditActor)
+	}
+
+	entry := &models.AuditLog{
+		Action:         action,
+		ActorType:      actor.Type,
+		ActorId:        actor.Id,
+		CollectionId:   record.Collection().Id,
+		CollectionName: record.Collection().Name,
+		RecordId:       record.Id,
+		Before:         types.JsonMap{},
+		After:          types.JsonMap{},
+	}
+
+	for k, v := range before {
+		entry.Before[k] = v
+	}
+	for k, v := range after {
+		entry.After[k] = v
+	}
+	maskAuditData(record.Collection(), entry.Before)
+	maskAuditData(record.Collection(), entry.After)
+
+	saveAuditLog(api.app, entry)
+}
+
+// saveAuditLog persists the provided audit log entry and
+// deletes the expired ones (if MaxDays is configured).
+func saveAuditLog(app core.App, entry *models.AuditLog) {
+	config := app.Settings().AuditLogs
+
+	if err := app.LogsDao().Save(entry); err != nil {
+		app.Logger().Error(
+			"Failed to save the audit log",
+			slog.String("action", entry.Action),
+			slog.String("collectionId", entry.CollectionId),
+			slog.String("recordId", entry.RecordId),
+			slog.String("error", err.Error()),
+		)
+		return
+	}
+
+	if config.MaxDays <= 0 {
+		return
+	}
+
+	// delete the expired audit logs at most once per day
+	now := time.Now()
+	lastDeletedAt := cast.ToTime(app.Store().Get("lastAuditLogsDeletedAt"))
+	if now.Sub(lastDeletedAt) < 24*time.Hour {
+		return
+	}
+
+	if err := app.LogsDao().DeleteOldAuditLogs(now.AddDate(0, 0, -config.MaxDays)); err != nil {
+		app.Logger().Error("Failed to delete the expired audit logs", slog.String("error", err.Error()))
+	} else {
+		app.Store().Set("lastAuditLogsDeletedAt", now)
+	}
+}
+
+// recordAuditData returns the audited record fields state.
+func recordAuditData(record *models.Record) map[string]any {
+	data := record.SchemaData()
+	data[schema.FieldNameId] = record.Id
+	data[schema.FieldNameCreated] = record.Created
+
+	if record.Collection().IsAuth() {
+		data[schema.FieldNameUsername] = record.Username()
+		data[schema.FieldNameEmail] = record.Email()
+		data[schema.FieldNameEmailVisibility] = record.EmailVisibility()
+		data[schema.FieldNameVerified] = record.Verified()
+	}
+
+	return data
+}
+
+// recordAuditDiff returns only the changed audited fields
+// between the original and the current record state.
+func recordAuditDiff(record *models.Record) (before, after map[string]any) {
+	original := record.OriginalCopy()
+
+	oldData := recordAuditData(original)
+	newData := recordAuditData(record)
+
+	before, after = auditDataDiff(oldData, newData)
+
+	// only the fact that the password was changed is tracked
+	if record.Collection().IsAuth() && original.PasswordHash() != record.PasswordHash() {
+		before["password"] = auditLogsMaskedValue
+		after["password"] = auditLogsMaskedValue
+	}
+
+	return before, after
+}
+
+// maskAuditData replaces the sensitive field values
+// (aka. the encrypted text fields) of the provided audit data.
+func maskAuditData(collection *models.Collection, data map[string]any) {
+	for _, field := range collection.Schema.Fields() {
+		options, ok := field.Options.(*schema.TextOptions)
+		if !ok || !options.Encrypted {
+			continue
+		}
+
+		if v, ok := data[field.Name]; ok && v != "" {
+			data[field.Name] = auditLogsMaskedValue
+		}
+	}
+}
+
+// collectionAuditData returns the audited collection state
+// (its serialized fields without the timestamps).
+func collectionAuditData(collection *models.Collection) map[string]any {
+	return auditDataFromModel(collection, "created", "updated")
+}
+
+// settingsAuditData returns the audited (aka. redacted) app settings state.
+func settingsAuditData(s *settings.Settings) map[string]any {
+	redacted, err := s.RedactClone()
+	if err != nil {
+		return map[string]any{}
+	}
+
+	return auditDataFromModel(redacted)
+}
+
+// auditDataFromModel returns the top level json fields of the
+// provided value, excluding the specified ones.
+func auditDataFromModel(v any, exclude ...string) map[string]any {
+	data := map[string]any{}
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return data
+	}
+
+	json.Unmarshal(raw, &data)
+
+	for _, key := range exclude {
+		delete(data, key)
+	}
+
+	return data
+}
+
+// auditDataDiff returns only the changed top level fields
+// between the provided old and new audit data.
+func auditDataDiff(oldData, newData map[string]any) (before, after map[string]any) {
+	before = map[string]any{}
+	after = map[string]any{}
+
+	for key, newValue := range newData {
+		oldValue := oldData[key]
+
+		oldRaw, _ := json.Marshal(oldValue)
+		newRaw, _ := json.Marshal(newValue)
+		if string(oldRaw) == string(newRaw) {
+			continue
+		}
+
+		before[key] = oldValue
+		after[key] = newValue
+	}
+
+	return before, after
+}
@@ -0,0 +1,145 @@
+package apis
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/pocketbase/pocketbase/tokens"
+	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/spf13/cast"
+)
+
+// HeaderCsrfToken is the request header that must hold the CSRF token
+// of the unsafe requests authenticated with the auth cookie.
+const HeaderCsrfToken = "X-CSRF-Token"
+
+// bindAuthCookieApi registers the auth cookie api endpoints and the corresponding handlers.
+func bindAuthCookieApi(app core.App, rg *echo.Group) {
+	api := authCookieApi{app: app}
+
+	subGroup := rg.Group("/auth-cookie", ActivityLogger(app), api.requireEnabled)
+	subGroup.GET("/csrf", api.csrf)
+	subGroup.DELETE("", api.clear)
+}
+
+type authCookieApi struct {
+	app core.App
+}
+
+func (api *authCookieApi) requireEnabled(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if !api.app.Settings().AuthCookie.Enabled {
+			return NewBadRequestError("The auth cookie mode is not enabled.", nil)
+		}
+
+		return next(c)
+	}
+}
+
+// csrf returns the CSRF token bound to the current auth cookie.
+func (api *authCookieApi) csrf(c echo.Context) error {
+	isCookieAuth, _ := c.Get(ContextAuthCookieKey).(bool)
+	if !isCookieAuth {
+		return NewUnauthorizedError("Missing or invalid auth cookie.", nil)
+	}
+
+	cookie, err := c.Cookie(api.app.Settings().AuthCookie.Name)
+	if err != nil {
+		return NewUnauthorizedError("Missing or invalid auth cookie.", err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"token": csrfToken(api.app, cookie.Value),
+	})
+}
+
+// clear removes the auth cookie (aka. logout).
+func (api *authCookieApi) clear(c echo.Context) error {
+	setAuthCookie(api.app, c, "", -1)
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// csrfProtection creates a middleware that rejects the unsafe requests
+// authenticated with the auth cookie and without a valid CSRF token header.
+func csrfProtection(app core.App) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			isCookieAuth, _ := c.Get(ContextAuthCookieKey).(bool)
+			if !isCookieAuth {
+				return next(c)
+			}
+
+			switch c.Request().Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				return next(c)
+			}
+
+			cookie, err := c.Cookie(app.Settings().AuthCookie.Name)
+			if err != nil {
+				return NewForbiddenError("Missing or invalid CSRF token.", err)
+			}
+
+			token := c.Request().Header.Get(HeaderCsrfToken)
+			if token == "" || !security.Equal(token, csrfToken(app, cookie.Value)) {
+				return NewForbiddenError("Missing or invalid CSRF token.", nil)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// csrfToken returns the CSRF token bound to the provided auth token.
+//
+// The token is signed with the related auth token secret and it
+// doesn't need to be stored (it changes together with the auth token).
+func csrfToken(app core.App, authToken string) string {
+	secret := app.Settings().RecordAuthToken.Secret
+
+	claims, _ := security.ParseUnverifiedJWT(authToken)
+	if cast.ToString(claims["type"]) == tokens.TypeAdmin {
+		secret = app.Settings().AdminAuthToken.Secret
+	}
+
+	return security.HS256("csrf."+authToken, secret)
+}
+
+// setAuthCookie sets the httpOnly auth cookie with the provided
+// token (if the auth cookie mode is enabled).
+//
+// Negative maxAge removes the cookie.
+func setAuthCookie(app core.App, c echo.Context, token string, maxAge int) {
+	config := app.Settings().AuthCookie
+	if !config.Enabled {
+		return
+	}
+
+	cookie := &http.Cookie{
+		Name:     config.Name,
+		Value:    token,
+		Path:     "/",
+		Domain:   config.Domain,
+		MaxAge:   maxAge,
+		Secure:   config.Secure,
+		HttpOnly: true,
+	}
+
+	if maxAge > 0 {
+		cookie.Expires = time.Now().Add(time.Duration(maxAge) * time.Second)
+	}
+
+	switch config.SameSite {
+	case settings.AuthCookieSameSiteStrict:
+		cookie.SameSite = http.SameSiteStrictMode
+	case settings.AuthCookieSameSiteNone:
+		cookie.SameSite = http.SameSiteNoneMode
+	default:
+		cookie.SameSite = http.SameSiteLaxMode
+	}
+
+	c.SetCookie(cookie)
+}
@@ -0,0 +1,73 @@
+package apis
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+)
+
+// adminsLockoutScope is the lockout identity scope of the admins password auth.
+const adminsLockoutScope = "_admins"
+
+// bindAuthLockoutApi registers the auth lockouts management api endpoints.
+func bindAuthLockoutApi(app core.App, rg *echo.Group) {
+	api := authLockoutApi{app: app}
+
+	subGroup := rg.Group("/lockouts", ActivityLogger(app), RequireAdminAuth())
+	subGroup.GET("", api.list)
+	subGroup.DELETE("", api.clearAll)
+	subGroup.DELETE("/:key", api.clear)
+}
+
+type authLockoutApi struct {
+	app core.App
+}
+
+// passwordAuthLockoutKeys returns the lockout keys (identity and ip)
+// of the current password auth request.
+//
+// Only the first (identity) key failures are reset on successful auth
+// to prevent unlocking the ip with a single known valid account.
+func passwordAuthLockoutKeys(c echo.Context, scope string, identity string) []string {
+	return []string{
+		core.AuthLockoutIdentityKey(scope, identity),
+		core.AuthLockoutIpKey(c.RealIP()),
+	}
+}
+
+// checkAuthLockout returns a "429 Too Many Requests" error
+// if some of the provided lockout keys is currently locked.
+func checkAuthLockout(app core.App, keys []string) error {
+	if app.AuthLockouts().IsLocked(keys...) {
+		return NewApiError(http.StatusTooManyRequests, "Too many failed login attempts. Please try again later.", nil)
+	}
+
+	return nil
+}
+
+// list returns all currently locked identities and ips.
+func (api *authLockoutApi) list(c echo.Context) error {
+	return c.JSON(http.StatusOK, api.app.AuthLockouts().List())
+}
+
+// clear clears the failed attempts of a single lockout key
+// (eg. "ip:127.0.0.1" or "identity:_admins:test@example.com").
+func (api *authLockoutApi) clear(c echo.Context) error {
+	key := c.PathParam("key")
+	if unescaped, err := url.PathUnescape(key); err == nil {
+		key = unescaped
+	}
+
+	api.app.AuthLockouts().Reset(key)
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// clearAll clears all lockouts.
+func (api *authLockoutApi) clearAll(c echo.Context) error {
+	api.app.AuthLockouts().ResetAll()
+
+	return c.NoContent(http.StatusNoContent)
+}
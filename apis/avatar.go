@@ -0,0 +1,132 @@
+package apis
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"html"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/spf13/cast"
+)
+
+const (
+	avatarPlaceholderDefaultSize = 128
+	avatarPlaceholderMinSize     = 16
+	avatarPlaceholderMaxSize     = 1024
+)
+
+// bindAvatarApi registers the avatar api endpoints and the corresponding handlers.
+func bindAvatarApi(app core.App, rg *echo.Group) {
+	api := avatarApi{app: app}
+
+	subGroup := rg.Group("/avatars", ActivityLogger(app))
+	subGroup.GET("/:collection/:recordId/:field", api.view, LoadCollectionContext(api.app))
+}
+
+type avatarApi struct {
+	app core.App
+}
+
+// view redirects to the standard size thumb of the record avatar
+// or, if the avatar is not set, serves a deterministic initial-letter
+// SVG placeholder.
+func (api *avatarApi) view(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("", nil)
+	}
+
+	field := collection.Schema.GetFieldByName(c.PathParam("field"))
+	if field == nil || field.Type != schema.FieldTypeAvatar {
+		return NewNotFoundError("", nil)
+	}
+	options, _ := field.Options.(*schema.AvatarOptions)
+	if options == nil {
+		return NewBadRequestError("", errors.New("Failed to load avatar options."))
+	}
+
+	record, err := api.app.Dao().FindRecordById(collection.Id, c.PathParam("recordId"))
+	if err != nil {
+		return NewNotFoundError("", err)
+	}
+
+	size := avatarPlaceholderDefaultSize
+	if raw := c.QueryParam("size"); raw != "" {
+		size = min(max(cast.ToInt(raw), avatarPlaceholderMinSize), avatarPlaceholderMaxSize)
+	}
+
+	if filename := record.GetString(field.Name); filename != "" {
+		// pick the closest standard size that is not smaller than the requested one
+		sizes := options.AvatarSizes()
+		thumb := sizes[len(sizes)-1]
+		for _, s := range sizes {
+			if s >= size && s < thumb {
+				thumb = s
+			}
+		}
+
+		query := url.Values{}
+		query.Set("thumb", strconv.Itoa(thumb)+"x"+strconv.Itoa(thumb))
+		if token := c.QueryParam("token"); token != "" {
+			query.Set("token", token)
+		}
+
+		return c.Redirect(http.StatusTemporaryRedirect, fmt.Sprintf(
+			"/api/files/%s/%s/%s?%s",
+			url.PathEscape(collection.Name),
+			url.PathEscape(record.Id),
+			url.PathEscape(filename),
+			query.Encode(),
+		))
+	}
+
+	if ok, _ := api.app.Dao().CanAccessRecord(record, RequestInfo(c), collection.ViewRule); !ok {
+		return NewForbiddenError("Insufficient permissions to access the avatar resource.", nil)
+	}
+
+	c.Response().Header().Set("Cache-Control", "max-age=86400")
+
+	return c.Blob(http.StatusOK, "image/svg+xml", avatarPlaceholder(record, options, size))
+}
+
+// avatarPlaceholder generates a deterministic square SVG image with
+// the record initial letter on a background derived from the record id.
+func avatarPlaceholder(record *models.Record, options *schema.AvatarOptions, size int) []byte {
+	var label string
+	if options.PlaceholderField != "" {
+		label = record.GetString(options.PlaceholderField)
+	}
+	if label == "" && record.Collection().IsAuth() {
+		label = record.Username()
+	}
+	if label == "" {
+		label = record.Id
+	}
+
+	initial, _ := utf8.DecodeRuneInString(strings.TrimSpace(label))
+	if initial == utf8.RuneError || !unicode.IsPrint(initial) {
+		initial = '?'
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(record.Id))
+	hue := h.Sum32() % 360
+
+	return []byte(fmt.Sprintf(
+		`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 100 100">`+
+			`<rect width="100" height="100" fill="hsl(%d, 55%%, 45%%)"/>`+
+			`<text x="50" y="50" dy=".35em" text-anchor="middle" font-family="sans-serif" font-size="50" fill="#fff">%s</text>`+
+			`</svg>`,
+		size, size, hue, html.EscapeString(string(unicode.ToUpper(initial))),
+	))
+}
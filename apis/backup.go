@@ -118,6 +118,7 @@ func (api *backupApi) download(c echo.Context) error {
 	_, err := api.app.Dao().FindAdminByToken(
 		fileToken,
 		api.app.Settings().AdminFileToken.Secret,
+		api.app.Settings().AdminFileToken.PreviousSecrets()...,
 	)
 	if err != nil {
 		return NewForbiddenError("Insufficient permissions to access the resource.", err)
@@ -2,6 +2,7 @@
 package apis
 
 import (
+	"crypto/subtle"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -9,6 +10,7 @@ import (
 	"log/slog"
 	"net/http"
 	"net/url"
+	"path"
 	"path/filepath"
 	"strings"
 	"time"
@@ -16,16 +18,19 @@ import (
 	"github.com/labstack/echo/v5"
 	"github.com/labstack/echo/v5/middleware"
 	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models/settings"
 	"github.com/pocketbase/pocketbase/tools/rest"
 	"github.com/pocketbase/pocketbase/ui"
 	"github.com/spf13/cast"
 )
 
-const trailedAdminPath = "/_/"
-
 // InitApi creates a configured echo instance with registered
 // system and app specific routes and middlewares.
 func InitApi(app core.App) (*echo.Echo, error) {
+	return initApi(app, ServeConfig{})
+}
+
+func initApi(app core.App, config ServeConfig) (*echo.Echo, error) {
 	e := echo.New()
 	e.Debug = false
 	e.JSONSerializer = &rest.Serializer{
@@ -41,6 +46,8 @@ func InitApi(app core.App) (*echo.Echo, error) {
 	})
 
 	// default middlewares
+	e.Pre(requestIdMiddleware())
+	e.Pre(stripBasePath(app))
 	e.Pre(middleware.RemoveTrailingSlashWithConfig(middleware.RemoveTrailingSlashConfig{
 		Skipper: func(c echo.Context) bool {
 			// enable by default only for the API routes
@@ -48,8 +55,12 @@ func InitApi(app core.App) (*echo.Echo, error) {
 		},
 	}))
 	e.Pre(LoadAuthContext(app))
+	e.Pre(csrfProtection(app))
+	e.Pre(requestSigning(app))
 	e.Use(middleware.Recover())
 	e.Use(middleware.Secure())
+	e.Use(compression(app))
+	e.Use(requestRecorder(app))
 	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			c.Set(ContextExecStartKey, time.Now())
@@ -95,12 +106,11 @@ func InitApi(app core.App) (*echo.Echo, error) {
 				return nil
 			}
 
-			// @see https://github.com/labstack/echo/issues/608
-			if e.HttpContext.Request().Method == http.MethodHead {
-				return e.HttpContext.NoContent(apiErr.Code)
-			}
-
-			return e.HttpContext.JSON(apiErr.Code, apiErr)
+			return writeApiError(
+				e.HttpContext,
+				localizeApiError(app, e.HttpContext, apiErr),
+				app.Settings().ApiErrors.Format,
+			)
 		})
 
 		if hookErr == nil {
@@ -113,20 +123,56 @@ func InitApi(app core.App) (*echo.Echo, error) {
 	}
 
 	// admin ui routes
-	bindStaticAdminUI(app, e)
+	if !config.DisableAdminUI {
+		bindStaticAdminUI(app, e)
+	}
 
 	// default routes
-	api := e.Group("/api", eagerRequestInfoCache(app))
+	api := e.Group("/api", geoRestriction(app), eagerRequestInfoCache(app))
 	bindSettingsApi(app, api)
 	bindAdminApi(app, api)
+	bindApiKeysApi(app, api)
+	bindRolesApi(app, api)
 	bindCollectionApi(app, api)
 	bindRecordCrudApi(app, api)
+	bindRecordTreeApi(app, api)
+	bindRecordAggregateApi(app, api)
+	bindRecordCountApi(app, api)
+	bindRecordDuplicatesApi(app, api)
+	bindRecordLedgerApi(app, api)
+	bindRecordTrashApi(app, api)
+	bindRecordCommentApi(app, api)
+	bindRecordReactionApi(app, api)
+	bindRecordFollowApi(app, api)
+	bindRecordHistoryApi(app, api)
+	bindRecordUploadApi(app, api)
+	bindBatchApi(app, api)
+	bindGraphQLApi(app, api)
 	bindRecordAuthApi(app, api)
+	bindRecordMfaApi(app, api)
+	bindRecordPasskeyApi(app, api)
+	bindRecordDeviceAuthApi(app, api)
+	bindRecordWalletAuthApi(app, api)
+	bindRecordRefreshTokenApi(app, api)
+	bindRecordSessionApi(app, api)
+	bindRecordImpersonateApi(app, api)
+	bindRecordExternalAuthProxyApi(app, api)
+	bindRecordSamlApi(app, api)
+	bindAuthCookieApi(app, api)
 	bindFileApi(app, api)
+	bindAvatarApi(app, api)
 	bindRealtimeApi(app, api)
 	bindLogsApi(app, api)
+	bindAuditLogsApi(app, api)
 	bindHealthApi(app, api)
+	bindInstanceApi(app, api)
+	bindAuthLockoutApi(app, api)
 	bindBackupApi(app, api)
+	bindMigrationsApi(app, api)
+	bindRulesDebugApi(app, api)
+	bindRecordSyncApi(app, api)
+	bindErrorCodesApi(app, api)
+	bindDebugApi(app, api)
 
 	// catch all any route
 	api.Any("/*", func(c echo.Context) error {
@@ -168,13 +214,19 @@ func StaticDirectoryHandler(fileSystem fs.FS, indexFallback bool) echo.HandlerFu
 }
 
 // bindStaticAdminUI registers the endpoints that serves the static admin UI.
+//
+// The admin UI mount path is resolved from the app settings
+// on initialization (aka. changing it requires app restart).
 func bindStaticAdminUI(app core.App, e *echo.Echo) error {
+	trailedAdminPath := app.Settings().AdminUI.TrailedPath()
+
 	// redirect to trailing slash to ensure that relative urls will still work properly
 	e.GET(
 		strings.TrimRight(trailedAdminPath, "/"),
 		func(c echo.Context) error {
-			return c.Redirect(http.StatusTemporaryRedirect, strings.TrimLeft(trailedAdminPath, "/"))
+			return c.Redirect(http.StatusTemporaryRedirect, path.Base(strings.TrimRight(trailedAdminPath, "/"))+"/")
 		},
+		adminUIGuard(app),
 	)
 
 	// serves static files from the /ui/dist directory
@@ -182,15 +234,39 @@ func bindStaticAdminUI(app core.App, e *echo.Echo) error {
 	e.GET(
 		trailedAdminPath+"*",
 		echo.StaticDirectoryHandler(ui.DistDirFS, false),
-		installerRedirect(app),
-		uiCacheControl(),
+		adminUIGuard(app),
+		installerRedirect(app, trailedAdminPath),
+		uiCacheControl(trailedAdminPath),
 		middleware.Gzip(),
 	)
 
 	return nil
 }
 
-func uiCacheControl() echo.MiddlewareFunc {
+// adminUIGuard responds with 404 if the admin UI is disabled or if the
+// request is missing the configured admin UI secret header.
+func adminUIGuard(app core.App) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			config := app.Settings().AdminUI
+
+			if config.Disabled {
+				return echo.ErrNotFound
+			}
+
+			if config.Secret != "" {
+				secret := c.Request().Header.Get(settings.AdminUISecretHeader)
+				if subtle.ConstantTimeCompare([]byte(secret), []byte(config.Secret)) != 1 {
+					return echo.ErrNotFound
+				}
+			}
+
+			return next(c)
+		}
+	}
+}
+
+func uiCacheControl(trailedAdminPath string) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			// add default Cache-Control header for all Admin UI resources
@@ -219,7 +295,7 @@ func updateHasAdminsCache(app core.App) error {
 
 // installerRedirect redirects the user to the installer admin UI page
 // when the application needs some preliminary configurations to be done.
-func installerRedirect(app core.App) echo.MiddlewareFunc {
+func installerRedirect(app core.App, trailedAdminPath string) echo.MiddlewareFunc {
 	// keep hasAdminsCacheKey value up-to-date
 	app.OnAdminAfterCreateRequest().Add(func(data *core.AdminCreateEvent) error {
 		return updateHasAdminsCache(app)
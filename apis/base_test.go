@@ -10,6 +10,7 @@ import (
 
 	"github.com/labstack/echo/v5"
 	"github.com/pocketbase/pocketbase/apis"
+	"github.com/pocketbase/pocketbase/models/settings"
 	"github.com/pocketbase/pocketbase/tests"
 	"github.com/spf13/cast"
 )
@@ -52,6 +53,96 @@ func Test404(t *testing.T) {
 	}
 }
 
+func TestAdminUI(t *testing.T) {
+	newTestAppWithAdminUI := func(config settings.AdminUIConfig) func(t *testing.T) *tests.TestApp {
+		return func(t *testing.T) *tests.TestApp {
+			app, err := tests.NewTestApp()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			app.Settings().AdminUI = config
+
+			return app
+		}
+	}
+
+	scenarios := []tests.ApiScenario{
+		{
+			Name:            "default path",
+			Method:          http.MethodGet,
+			Url:             "/_/",
+			ExpectedStatus:  200,
+			ExpectedContent: []string{"<!DOCTYPE html>"},
+		},
+		{
+			Name:            "disabled admin UI",
+			Method:          http.MethodGet,
+			Url:             "/_/",
+			TestAppFactory:  newTestAppWithAdminUI(settings.AdminUIConfig{Disabled: true}),
+			ExpectedStatus:  404,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:            "custom path",
+			Method:          http.MethodGet,
+			Url:             "/_internal/admin/",
+			TestAppFactory:  newTestAppWithAdminUI(settings.AdminUIConfig{Path: "/_internal/admin"}),
+			ExpectedStatus:  200,
+			ExpectedContent: []string{"<!DOCTYPE html>"},
+		},
+		{
+			Name:            "custom path (old default path)",
+			Method:          http.MethodGet,
+			Url:             "/_/",
+			TestAppFactory:  newTestAppWithAdminUI(settings.AdminUIConfig{Path: "/_internal/admin"}),
+			ExpectedStatus:  404,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:           "custom path without trailing slash",
+			Method:         http.MethodGet,
+			Url:            "/_internal/admin",
+			TestAppFactory: newTestAppWithAdminUI(settings.AdminUIConfig{Path: "/_internal/admin"}),
+			ExpectedStatus: 307,
+		},
+		{
+			Name:            "missing secret header",
+			Method:          http.MethodGet,
+			Url:             "/_/",
+			TestAppFactory:  newTestAppWithAdminUI(settings.AdminUIConfig{Path: "/_/", Secret: "12345678"}),
+			ExpectedStatus:  404,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "invalid secret header",
+			Method: http.MethodGet,
+			Url:    "/_/",
+			RequestHeaders: map[string]string{
+				settings.AdminUISecretHeader: "invalid",
+			},
+			TestAppFactory:  newTestAppWithAdminUI(settings.AdminUIConfig{Path: "/_/", Secret: "12345678"}),
+			ExpectedStatus:  404,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "valid secret header",
+			Method: http.MethodGet,
+			Url:    "/_/",
+			RequestHeaders: map[string]string{
+				settings.AdminUISecretHeader: "12345678",
+			},
+			TestAppFactory:  newTestAppWithAdminUI(settings.AdminUIConfig{Path: "/_/", Secret: "12345678"}),
+			ExpectedStatus:  200,
+			ExpectedContent: []string{"<!DOCTYPE html>"},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
+
 func TestCustomRoutesAndErrorsHandling(t *testing.T) {
 	scenarios := []tests.ApiScenario{
 		{
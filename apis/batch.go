@@ -0,0 +1,131 @@
+package apis
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/resolvers"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+// bindBatchApi registers the batch api endpoint and the corresponding handlers.
+func bindBatchApi(app core.App, rg *echo.Group) {
+	api := batchApi{app: app}
+
+	subGroup := rg.Group("/batch", ActivityLogger(app))
+	subGroup.POST("", api.submit)
+}
+
+type batchApi struct {
+	app core.App
+}
+
+// submit executes the submitted record create, update and delete
+// operations in a single transaction.
+//
+// Each operation is checked against the corresponding collection
+// API rule (the @request.data.* fields are resolved from the operation data).
+//
+// Note that the record request hooks (eg. OnRecordBeforeCreateRequest)
+// are not triggered for the batch operations.
+func (api *batchApi) submit(c echo.Context) error {
+	requestInfo := RequestInfo(c)
+
+	form := forms.NewBatchRequest(api.app)
+	form.SetFullManageAccess(requestInfo.Admin != nil)
+
+	// load request
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("Failed to load the submitted data due to invalid formatting.", err)
+	}
+
+	if requestInfo.Admin == nil {
+		form.SetAccessFunc(func(txDao *daos.Dao, item *forms.BatchRequestItem, record *models.Record) error {
+			return checkBatchRule(txDao, requestInfo, item, record)
+		})
+	}
+
+	results, err := form.Submit()
+	if err != nil {
+		return NewBadRequestError("Failed to process the batch request.", err)
+	}
+
+	for _, result := range results {
+		if result.Record == nil {
+			continue
+		}
+
+		if err := EnrichRecord(c, api.app.Dao(), result.Record); err != nil {
+			api.app.Logger().Debug(
+				"Failed to enrich batch record",
+				slog.String("id", result.Record.Id),
+				slog.String("collectionName", result.Record.Collection().Name),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+
+	return c.JSON(http.StatusOK, results)
+}
+
+// checkBatchRule checks whether the provided batch operation
+// record satisfies its collection API rule.
+func checkBatchRule(
+	txDao *daos.Dao,
+	requestInfo *models.RequestInfo,
+	item *forms.BatchRequestItem,
+	record *models.Record,
+) error {
+	collection := record.Collection()
+
+	var rule *string
+	switch item.Action {
+	case forms.BatchActionCreate:
+		rule = collection.CreateRule
+	case forms.BatchActionUpdate:
+		rule = collection.UpdateRule
+	case forms.BatchActionDelete:
+		rule = collection.DeleteRule
+	}
+
+	if rule == nil {
+		return errors.New("only admins can perform this action")
+	}
+
+	if *rule == "" {
+		return nil // public
+	}
+
+	// resolve the @request.data.* fields from the operation data
+	// (the create modifiers are applied to a blank record since at this point it is already persisted)
+	info := *requestInfo
+	if item.Action == forms.BatchActionCreate {
+		info.Data = models.NewRecord(collection).ReplaceModifers(item.Data)
+	} else {
+		info.Data = record.ReplaceModifers(item.Data)
+	}
+
+	ruleFunc := func(q *dbx.SelectQuery) error {
+		resolver := resolvers.NewRecordFieldResolver(txDao, collection, &info, true)
+		expr, err := search.FilterData(*rule).BuildExpr(resolver)
+		if err != nil {
+			return err
+		}
+		resolver.UpdateQuery(q)
+		q.AndWhere(expr)
+		return nil
+	}
+
+	if found, err := txDao.FindRecordById(collection.Id, record.Id, ruleFunc); err != nil || found == nil {
+		return errors.New("the operation doesn't satisfy the collection " + item.Action + " rule")
+	}
+
+	return nil
+}
@@ -20,6 +20,7 @@ func bindCollectionApi(app core.App, rg *echo.Group) {
 	subGroup.GET("/:collection", api.view)
 	subGroup.PATCH("/:collection", api.update)
 	subGroup.DELETE("/:collection", api.delete)
+	subGroup.POST("/:collection/sanitize", api.sanitize)
 	subGroup.PUT("/import", api.bulkImport)
 }
 
@@ -117,6 +118,10 @@ func (api *collectionApi) update(c echo.Context) error {
 		return NewNotFoundError("", err)
 	}
 
+	// note: snapshot the rules before binding the request because
+	// the form rule pointers are shared with the collection ones
+	oldRules := collectionRules(collection)
+
 	form := forms.NewCollectionUpsert(api.app, collection)
 
 	// load request
@@ -138,6 +143,16 @@ func (api *collectionApi) update(c echo.Context) error {
 					return NewBadRequestError("Failed to update the collection.", err)
 				}
 
+				if changes := collectionRulesChanges(oldRules, collectionRules(e.Collection)); len(changes) > 0 {
+					emitSecurityEvent(api.app, e.HttpContext, &core.SecurityEvent{
+						Type: core.SecurityEventRuleChange,
+						Data: map[string]any{
+							"collection": e.Collection.Name,
+							"changes":    changes,
+						},
+					})
+				}
+
 				return api.app.OnCollectionAfterUpdateRequest().Trigger(event, func(e *core.CollectionUpdateEvent) error {
 					if e.HttpContext.Response().Committed {
 						return nil
@@ -208,3 +223,24 @@ func (api *collectionApi) bulkImport(c echo.Context) error {
 		}
 	})
 }
+
+func (api *collectionApi) sanitize(c echo.Context) error {
+	collection, err := api.app.Dao().FindCollectionByNameOrId(c.PathParam("collection"))
+	if err != nil || collection == nil {
+		return NewNotFoundError("", err)
+	}
+
+	form := forms.NewRecordsSanitize(api.app, collection)
+
+	// load request
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("Failed to load the submitted data due to invalid formatting.", err)
+	}
+
+	updated, err := form.Submit()
+	if err != nil {
+		return NewBadRequestError("Failed to sanitize the collection records.", err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]int{"updated": updated})
+}
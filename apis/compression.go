@@ -0,0 +1,348 @@
+package apis
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models/settings"
+)
+
+// ContextSkipCompressionKey is the request context key used
+// to disable the response compression for a single request.
+const ContextSkipCompressionKey string = "skipCompression"
+
+// CompressionEncoderFunc defines a function that creates a new
+// response compression writer with the specified level
+// (0 means the encoder default level).
+//
+// The returned writer could optionally implement Flush() error
+// in order to support streamed responses.
+type CompressionEncoderFunc func(w io.Writer, level int) (io.WriteCloser, error)
+
+var compressionEncodersMu sync.RWMutex
+
+var compressionEncoders = map[string]CompressionEncoderFunc{
+	settings.CompressionEncodingGzip: func(w io.Writer, level int) (io.WriteCloser, error) {
+		if level == 0 {
+			level = gzip.DefaultCompression
+		}
+		return gzip.NewWriterLevel(w, level)
+	},
+	settings.CompressionEncodingDeflate: func(w io.Writer, level int) (io.WriteCloser, error) {
+		if level == 0 {
+			level = flate.DefaultCompression
+		}
+		return flate.NewWriter(w, level)
+	},
+}
+
+// RegisterCompressionEncoder registers a new (or replaces an existing)
+// response compression encoder, eg. for brotli:
+//
+//	apis.RegisterCompressionEncoder("br", func(w io.Writer, level int) (io.WriteCloser, error) {
+//		if level == 0 {
+//			level = brotli.DefaultCompression
+//		}
+//		return brotli.NewWriterLevel(w, level), nil
+//	})
+func RegisterCompressionEncoder(encoding string, fn CompressionEncoderFunc) {
+	compressionEncodersMu.Lock()
+	defer compressionEncodersMu.Unlock()
+
+	compressionEncoders[encoding] = fn
+}
+
+func findCompressionEncoder(encoding string) CompressionEncoderFunc {
+	compressionEncodersMu.RLock()
+	defer compressionEncodersMu.RUnlock()
+
+	return compressionEncoders[encoding]
+}
+
+// SkipCompression middleware disables the response compression
+// for the route it is applied to (eg. for SSE).
+func SkipCompression() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set(ContextSkipCompressionKey, true)
+
+			return next(c)
+		}
+	}
+}
+
+// compression middleware compresses the responses based on
+// the request Accept-Encoding header and the app compression settings.
+func compression(app core.App) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			config := app.Settings().Compression
+
+			if !config.Enabled ||
+				c.Request().Method == http.MethodHead ||
+				// partial content responses are not compressed
+				c.Request().Header.Get("Range") != "" ||
+				config.IsExcludedRoute(c.Request().URL.Path) {
+				return next(c)
+			}
+
+			encoding, encoderFunc := negotiateCompression(c.Request().Header.Get(echo.HeaderAcceptEncoding), config.Encodings)
+			if encoderFunc == nil {
+				return next(c)
+			}
+
+			c.Response().Header().Add(echo.HeaderVary, echo.HeaderAcceptEncoding)
+
+			cw := &compressResponseWriter{
+				ResponseWriter: c.Response().Writer,
+				c:              c,
+				config:         config,
+				encoding:       encoding,
+				encoderFunc:    encoderFunc,
+			}
+
+			c.Response().Writer = cw
+			defer func() {
+				if err := cw.Close(); err != nil {
+					app.Logger().Debug("Failed to close the compression writer", slog.String("error", err.Error()))
+				}
+
+				// restore the original writer so that the error handler
+				// could still write to the response (if not committed)
+				c.Response().Writer = cw.ResponseWriter
+			}()
+
+			return next(c)
+		}
+	}
+}
+
+// negotiateCompression returns the first allowed encoding (in the order of
+// the allowed list) that is accepted by the client and has a registered encoder.
+func negotiateCompression(acceptEncoding string, allowed []string) (string, CompressionEncoderFunc) {
+	if acceptEncoding == "" {
+		return "", nil
+	}
+
+	accepted := map[string]bool{}
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(part, ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+				q = parsed
+			}
+		}
+
+		accepted[name] = q > 0
+	}
+
+	for _, encoding := range allowed {
+		isAccepted, ok := accepted[encoding]
+		if !ok {
+			isAccepted = accepted["*"]
+		}
+		if !isAccepted {
+			continue
+		}
+
+		if fn := findCompressionEncoder(encoding); fn != nil {
+			return encoding, fn
+		}
+	}
+
+	return "", nil
+}
+
+// -------------------------------------------------------------------
+
+var (
+	_ http.Flusher  = (*compressResponseWriter)(nil)
+	_ http.Hijacker = (*compressResponseWriter)(nil)
+)
+
+// compressResponseWriter buffers the response body until the configured
+// min size is reached and then decides whether to compress it or not.
+type compressResponseWriter struct {
+	http.ResponseWriter
+
+	c           echo.Context
+	config      settings.CompressionConfig
+	encoding    string
+	encoderFunc CompressionEncoderFunc
+
+	encoder  io.WriteCloser
+	buf      []byte
+	status   int
+	decided  bool
+	compress bool
+}
+
+// WriteHeader implements [http.ResponseWriter.WriteHeader] and
+// delays the status write until the compression decision is made.
+func (w *compressResponseWriter) WriteHeader(status int) {
+	if w.decided {
+		w.ResponseWriter.WriteHeader(status)
+		return
+	}
+
+	w.status = status
+
+	// responses without body
+	if status < 200 || status == http.StatusNoContent || status == http.StatusNotModified {
+		w.decide(false)
+	}
+}
+
+// Write implements [http.ResponseWriter.Write].
+func (w *compressResponseWriter) Write(b []byte) (int, error) {
+	if !w.decided {
+		if w.Header().Get(echo.HeaderContentType) == "" {
+			w.Header().Set(echo.HeaderContentType, http.DetectContentType(b))
+		}
+
+		if !w.isCompressible() {
+			if err := w.decide(false); err != nil {
+				return 0, err
+			}
+		} else {
+			w.buf = append(w.buf, b...)
+
+			if len(w.buf) < w.config.MinSize {
+				return len(b), nil
+			}
+
+			if err := w.decide(true); err != nil {
+				return 0, err
+			}
+
+			return len(b), nil
+		}
+	}
+
+	if w.compress {
+		return w.encoder.Write(b)
+	}
+
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush implements [http.Flusher].
+//
+// For streamed responses the compression is enabled
+// regardless of the min size.
+func (w *compressResponseWriter) Flush() {
+	if !w.decided {
+		w.decide(w.isCompressible())
+	}
+
+	if w.compress {
+		if f, ok := w.encoder.(interface{ Flush() error }); ok {
+			f.Flush()
+		}
+	}
+
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack implements [http.Hijacker].
+func (w *compressResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if h, ok := w.ResponseWriter.(http.Hijacker); ok {
+		return h.Hijack()
+	}
+
+	return nil, nil, errors.New("the underlying response writer doesn't implement http.Hijacker")
+}
+
+// Unwrap returns the underlying response writer (used by [http.ResponseController]).
+func (w *compressResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Close writes the pending buffered data (if any)
+// and closes the compression encoder.
+func (w *compressResponseWriter) Close() error {
+	if !w.decided {
+		if w.status == 0 && len(w.buf) == 0 {
+			return nil // nothing was written
+		}
+
+		// below the min size
+		if err := w.decide(false); err != nil {
+			return err
+		}
+	}
+
+	if w.compress {
+		return w.encoder.Close()
+	}
+
+	return nil
+}
+
+func (w *compressResponseWriter) isCompressible() bool {
+	if skip, _ := w.c.Get(ContextSkipCompressionKey).(bool); skip {
+		return false
+	}
+
+	// already encoded (eg. by a route specific middleware)
+	if w.Header().Get(echo.HeaderContentEncoding) != "" {
+		return false
+	}
+
+	return w.config.IsCompressibleContentType(w.Header().Get(echo.HeaderContentType))
+}
+
+// decide writes the delayed response status and the buffered data
+// (compressed or not depending on the compress argument).
+func (w *compressResponseWriter) decide(compress bool) error {
+	w.decided = true
+	w.compress = compress
+
+	if compress {
+		encoder, err := w.encoderFunc(w.ResponseWriter, w.config.Level)
+		if err != nil {
+			return err
+		}
+		w.encoder = encoder
+
+		w.Header().Set(echo.HeaderContentEncoding, w.encoding)
+		w.Header().Del(echo.HeaderContentLength)
+	}
+
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+
+	if len(w.buf) == 0 {
+		return nil
+	}
+
+	buf := w.buf
+	w.buf = nil
+
+	var err error
+	if compress {
+		_, err = w.encoder.Write(buf)
+	} else {
+		_, err = w.ResponseWriter.Write(buf)
+	}
+
+	return err
+}
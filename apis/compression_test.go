@@ -0,0 +1,223 @@
+package apis_test
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/apis"
+	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestCompression(t *testing.T) {
+	// fake "br" encoder for testing the custom encoders registration
+	apis.RegisterCompressionEncoder(settings.CompressionEncodingBrotli, func(w io.Writer, level int) (io.WriteCloser, error) {
+		return gzip.NewWriter(w), nil
+	})
+
+	newTestApp := func(modify func(config *settings.CompressionConfig)) func(t *testing.T) *tests.TestApp {
+		return func(t *testing.T) *tests.TestApp {
+			app, err := tests.NewTestApp()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			config := &app.Settings().Compression
+			config.Enabled = true
+			config.MinSize = 10
+			if modify != nil {
+				modify(config)
+			}
+
+			return app
+		}
+	}
+
+	expectEncoding := func(encoding string, expectedContent string) func(t *testing.T, app *tests.TestApp, res *http.Response) {
+		return func(t *testing.T, app *tests.TestApp, res *http.Response) {
+			if v := res.Header.Get("Content-Encoding"); v != encoding {
+				t.Fatalf("Expected Content-Encoding %q, got %q", encoding, v)
+			}
+
+			var reader io.Reader = res.Body
+			switch encoding {
+			case "gzip", "br":
+				gr, err := gzip.NewReader(res.Body)
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer gr.Close()
+				reader = gr
+			case "deflate":
+				fr := flate.NewReader(res.Body)
+				defer fr.Close()
+				reader = fr
+			}
+
+			body, err := io.ReadAll(reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !strings.Contains(string(body), expectedContent) {
+				t.Fatalf("Expected %q in the response body, got \n%s", expectedContent, body)
+			}
+		}
+	}
+
+	listUrl := "/api/collections/demo2/records"
+
+	scenarios := []tests.ApiScenario{
+		{
+			Name:   "disabled compression",
+			Method: http.MethodGet,
+			Url:    listUrl,
+			RequestHeaders: map[string]string{
+				"Accept-Encoding": "gzip",
+			},
+			AfterTestFunc:   expectEncoding("", `"page":1`),
+			ExpectedStatus:  200,
+			ExpectedContent: []string{`"page":1`},
+			ExpectedEvents:  map[string]int{"OnRecordsListRequest": 1},
+		},
+		{
+			Name:           "gzip",
+			Method:         http.MethodGet,
+			Url:            listUrl,
+			TestAppFactory: newTestApp(nil),
+			RequestHeaders: map[string]string{
+				"Accept-Encoding": "gzip, deflate",
+			},
+			AfterTestFunc:      expectEncoding("gzip", `"page":1`),
+			ExpectedStatus:     200,
+			NotExpectedContent: []string{`"page":1`},
+			ExpectedEvents:     map[string]int{"OnRecordsListRequest": 1},
+		},
+		{
+			Name:   "deflate",
+			Method: http.MethodGet,
+			Url:    listUrl,
+			TestAppFactory: newTestApp(func(config *settings.CompressionConfig) {
+				config.Level = 9
+			}),
+			RequestHeaders: map[string]string{
+				"Accept-Encoding": "deflate",
+			},
+			AfterTestFunc:      expectEncoding("deflate", `"page":1`),
+			ExpectedStatus:     200,
+			NotExpectedContent: []string{`"page":1`},
+			ExpectedEvents:     map[string]int{"OnRecordsListRequest": 1},
+		},
+		{
+			Name:   "custom registered encoder with higher preference",
+			Method: http.MethodGet,
+			Url:    listUrl,
+			TestAppFactory: newTestApp(func(config *settings.CompressionConfig) {
+				config.Encodings = []string{"br", "gzip"}
+			}),
+			RequestHeaders: map[string]string{
+				"Accept-Encoding": "gzip, br",
+			},
+			AfterTestFunc:      expectEncoding("br", `"page":1`),
+			ExpectedStatus:     200,
+			NotExpectedContent: []string{`"page":1`},
+			ExpectedEvents:     map[string]int{"OnRecordsListRequest": 1},
+		},
+		{
+			Name:           "rejected encoding (q=0)",
+			Method:         http.MethodGet,
+			Url:            listUrl,
+			TestAppFactory: newTestApp(nil),
+			RequestHeaders: map[string]string{
+				"Accept-Encoding": "gzip;q=0, identity",
+			},
+			AfterTestFunc:   expectEncoding("", `"page":1`),
+			ExpectedStatus:  200,
+			ExpectedContent: []string{`"page":1`},
+			ExpectedEvents:  map[string]int{"OnRecordsListRequest": 1},
+		},
+		{
+			Name:   "below the min size",
+			Method: http.MethodGet,
+			Url:    listUrl,
+			TestAppFactory: newTestApp(func(config *settings.CompressionConfig) {
+				config.MinSize = 1 << 20
+			}),
+			RequestHeaders: map[string]string{
+				"Accept-Encoding": "gzip",
+			},
+			AfterTestFunc:   expectEncoding("", `"page":1`),
+			ExpectedStatus:  200,
+			ExpectedContent: []string{`"page":1`},
+			ExpectedEvents:  map[string]int{"OnRecordsListRequest": 1},
+		},
+		{
+			Name:   "excluded route",
+			Method: http.MethodGet,
+			Url:    listUrl,
+			TestAppFactory: newTestApp(func(config *settings.CompressionConfig) {
+				config.ExcludedRoutes = []string{"/api/collections/*"}
+			}),
+			RequestHeaders: map[string]string{
+				"Accept-Encoding": "gzip",
+			},
+			AfterTestFunc:   expectEncoding("", `"page":1`),
+			ExpectedStatus:  200,
+			ExpectedContent: []string{`"page":1`},
+			ExpectedEvents:  map[string]int{"OnRecordsListRequest": 1},
+		},
+		{
+			Name:   "non compressible content type",
+			Method: http.MethodGet,
+			Url:    listUrl,
+			TestAppFactory: newTestApp(func(config *settings.CompressionConfig) {
+				config.ContentTypes = []string{"text/*"}
+			}),
+			RequestHeaders: map[string]string{
+				"Accept-Encoding": "gzip",
+			},
+			AfterTestFunc:   expectEncoding("", `"page":1`),
+			ExpectedStatus:  200,
+			ExpectedContent: []string{`"page":1`},
+			ExpectedEvents:  map[string]int{"OnRecordsListRequest": 1},
+		},
+		{
+			Name:           "route with disabled compression",
+			Method:         http.MethodGet,
+			Url:            "/my/test",
+			TestAppFactory: newTestApp(nil),
+			RequestHeaders: map[string]string{
+				"Accept-Encoding": "gzip",
+			},
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				e.GET("/my/test", func(c echo.Context) error {
+					return c.String(200, strings.Repeat("test", 100))
+				}, apis.SkipCompression())
+			},
+			AfterTestFunc:   expectEncoding("", "testtest"),
+			ExpectedStatus:  200,
+			ExpectedContent: []string{"testtest"},
+		},
+		{
+			Name:           "error response",
+			Method:         http.MethodGet,
+			Url:            "/api/missing",
+			TestAppFactory: newTestApp(nil),
+			RequestHeaders: map[string]string{
+				"Accept-Encoding": "gzip",
+			},
+			AfterTestFunc:   expectEncoding("", `"data":{}`),
+			ExpectedStatus:  404,
+			ExpectedContent: []string{`"data":{}`},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
@@ -0,0 +1,149 @@
+package apis
+
+import (
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	runtimePprof "runtime/pprof"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+)
+
+// bindDebugApi registers the runtime diagnostics api endpoints.
+//
+// The endpoints are available only for admins and only
+// when the debug settings option is enabled, eg.:
+//
+//	curl -H "Authorization: ADMIN_TOKEN" -o heap.pprof http://127.0.0.1:8090/api/debug/pprof/heap
+//	go tool pprof heap.pprof
+func bindDebugApi(app core.App, rg *echo.Group) {
+	api := debugApi{app: app, startTime: time.Now()}
+
+	subGroup := rg.Group("/debug", requireDebugEnabled(app), RequireAdminAuth())
+	subGroup.GET("/memstats", api.memStats)
+	subGroup.GET("/goroutines", api.goroutines)
+	subGroup.GET("/pprof", api.pprofIndex)
+	subGroup.GET("/pprof/cmdline", echo.WrapHandler(http.HandlerFunc(pprof.Cmdline)))
+	subGroup.GET("/pprof/profile", echo.WrapHandler(http.HandlerFunc(pprof.Profile)))
+	subGroup.GET("/pprof/symbol", echo.WrapHandler(http.HandlerFunc(pprof.Symbol)))
+	subGroup.POST("/pprof/symbol", echo.WrapHandler(http.HandlerFunc(pprof.Symbol)))
+	subGroup.GET("/pprof/trace", echo.WrapHandler(http.HandlerFunc(pprof.Trace)))
+	subGroup.GET("/pprof/:name", api.pprofProfile)
+}
+
+// requireDebugEnabled responds with 404 if the debug endpoints are disabled
+// (in order to not reveal their existence).
+func requireDebugEnabled(app core.App) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !app.Settings().Debug.Enabled {
+				return NewNotFoundError("", nil)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+type debugApi struct {
+	app       core.App
+	startTime time.Time
+}
+
+type debugMemStats struct {
+	Alloc         uint64  `json:"alloc"`
+	TotalAlloc    uint64  `json:"totalAlloc"`
+	Sys           uint64  `json:"sys"`
+	Mallocs       uint64  `json:"mallocs"`
+	Frees         uint64  `json:"frees"`
+	HeapAlloc     uint64  `json:"heapAlloc"`
+	HeapSys       uint64  `json:"heapSys"`
+	HeapIdle      uint64  `json:"heapIdle"`
+	HeapInuse     uint64  `json:"heapInuse"`
+	HeapReleased  uint64  `json:"heapReleased"`
+	HeapObjects   uint64  `json:"heapObjects"`
+	StackInuse    uint64  `json:"stackInuse"`
+	StackSys      uint64  `json:"stackSys"`
+	NumGC         uint32  `json:"numGC"`
+	LastGC        uint64  `json:"lastGC"`
+	PauseTotalNs  uint64  `json:"pauseTotalNs"`
+	GCCPUFraction float64 `json:"gcCPUFraction"`
+}
+
+// memStats returns the current Go runtime memory and scheduler stats.
+func (api *debugApi) memStats(c echo.Context) error {
+	m := new(runtime.MemStats)
+	runtime.ReadMemStats(m)
+
+	return c.JSON(http.StatusOK, map[string]any{
+		"goVersion":  runtime.Version(),
+		"uptime":     int64(time.Since(api.startTime).Seconds()),
+		"goroutines": runtime.NumGoroutine(),
+		"cpus":       runtime.NumCPU(),
+		"maxProcs":   runtime.GOMAXPROCS(0),
+		"memory": debugMemStats{
+			Alloc:         m.Alloc,
+			TotalAlloc:    m.TotalAlloc,
+			Sys:           m.Sys,
+			Mallocs:       m.Mallocs,
+			Frees:         m.Frees,
+			HeapAlloc:     m.HeapAlloc,
+			HeapSys:       m.HeapSys,
+			HeapIdle:      m.HeapIdle,
+			HeapInuse:     m.HeapInuse,
+			HeapReleased:  m.HeapReleased,
+			HeapObjects:   m.HeapObjects,
+			StackInuse:    m.StackInuse,
+			StackSys:      m.StackSys,
+			NumGC:         m.NumGC,
+			LastGC:        m.LastGC,
+			PauseTotalNs:  m.PauseTotalNs,
+			GCCPUFraction: m.GCCPUFraction,
+		},
+	})
+}
+
+// goroutines writes a plain text dump with the stack traces of all goroutines.
+func (api *debugApi) goroutines(c echo.Context) error {
+	c.Response().Header().Set(echo.HeaderContentType, echo.MIMETextPlainCharsetUTF8)
+	c.Response().WriteHeader(http.StatusOK)
+
+	return runtimePprof.Lookup("goroutine").WriteTo(c.Response(), 2)
+}
+
+// pprofIndex returns the list with the available pprof profiles.
+func (api *debugApi) pprofIndex(c echo.Context) error {
+	profiles := runtimePprof.Profiles()
+
+	result := make([]map[string]any, 0, len(profiles)+4)
+
+	for _, p := range profiles {
+		result = append(result, map[string]any{
+			"name":  p.Name(),
+			"count": p.Count(),
+		})
+	}
+
+	for _, name := range []string{"cmdline", "profile", "symbol", "trace"} {
+		result = append(result, map[string]any{
+			"name": name,
+		})
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// pprofProfile serves a single named runtime profile (eg. heap, goroutine, block, etc.).
+func (api *debugApi) pprofProfile(c echo.Context) error {
+	name := c.PathParam("name")
+
+	if runtimePprof.Lookup(name) == nil {
+		return NewNotFoundError("", nil)
+	}
+
+	pprof.Handler(name).ServeHTTP(c.Response(), c.Request())
+
+	return nil
+}
@@ -0,0 +1,140 @@
+package apis
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+)
+
+// ErrorCodeHeader is the response header with the machine-readable api error code.
+const ErrorCodeHeader = "X-Error-Code"
+
+// Builtin api error codes.
+const (
+	ErrorCodeBadRequest       = "bad_request"
+	ErrorCodeValidationFailed = "validation_failed"
+	ErrorCodeFileTooLarge     = "file_too_large"
+	ErrorCodeUnauthorized     = "unauthorized"
+	ErrorCodeForbidden        = "forbidden"
+	ErrorCodeRuleForbidden    = "rule_forbidden"
+	ErrorCodeNotFound         = "not_found"
+	ErrorCodeMethodNotAllowed = "method_not_allowed"
+	ErrorCodeTooLarge         = "request_too_large"
+	ErrorCodeTooManyRequests  = "too_many_requests"
+	ErrorCodeInternal         = "internal_error"
+	ErrorCodeUnknown          = "unknown_error"
+
+	// device authorization grant token exchange errors (RFC 8628)
+	ErrorCodeAuthorizationPending = "authorization_pending"
+	ErrorCodeSlowDown             = "slow_down"
+	ErrorCodeExpiredToken         = "expired_token"
+	ErrorCodeAccessDenied         = "access_denied"
+)
+
+// ErrorCodeInfo describes a single api error code catalog entry.
+type ErrorCodeInfo struct {
+	Code        string `json:"code"`
+	Status      int    `json:"status"`
+	Description string `json:"description"`
+}
+
+var errorCodesMu sync.RWMutex
+
+var errorCodes = map[string]ErrorCodeInfo{}
+
+func init() {
+	builtin := []ErrorCodeInfo{
+		{ErrorCodeBadRequest, http.StatusBadRequest, "The request couldn't be processed."},
+		{ErrorCodeValidationFailed, http.StatusBadRequest, "One or more submitted fields are invalid (see the error data for the field codes)."},
+		{ErrorCodeFileTooLarge, http.StatusBadRequest, "One or more uploaded files exceed the allowed max size."},
+		{ErrorCodeUnauthorized, http.StatusUnauthorized, "Missing or invalid authentication."},
+		{ErrorCodeForbidden, http.StatusForbidden, "The authenticated client is not allowed to perform the request."},
+		{ErrorCodeRuleForbidden, http.StatusForbidden, "The collection API rule doesn't allow the request."},
+		{ErrorCodeNotFound, http.StatusNotFound, "The requested resource wasn't found."},
+		{ErrorCodeMethodNotAllowed, http.StatusMethodNotAllowed, "The request method is not supported by the resource."},
+		{ErrorCodeTooLarge, http.StatusRequestEntityTooLarge, "The request body is too large."},
+		{ErrorCodeTooManyRequests, http.StatusTooManyRequests, "Too many requests."},
+		{ErrorCodeInternal, http.StatusInternalServerError, "Unexpected server error."},
+		{ErrorCodeUnknown, 0, "Fallback code for an error without a more specific code."},
+		{ErrorCodeAuthorizationPending, http.StatusBadRequest, "The device authorization request is still pending."},
+		{ErrorCodeSlowDown, http.StatusBadRequest, "The device code is polled too frequently."},
+		{ErrorCodeExpiredToken, http.StatusBadRequest, "The device code has expired."},
+		{ErrorCodeAccessDenied, http.StatusBadRequest, "The device authorization request was denied."},
+	}
+
+	for _, info := range builtin {
+		errorCodes[info.Code] = info
+	}
+}
+
+// RegisterErrorCode registers a new (or replaces an existing) api error code
+// catalog entry so that it could be listed by the error codes api, eg.:
+//
+//	apis.RegisterErrorCode(apis.ErrorCodeInfo{
+//		Code:        "order_closed",
+//		Status:      400,
+//		Description: "The order is already closed.",
+//	})
+//
+//	...
+//
+//	return apis.NewBadRequestError("The order is closed.", nil).WithErrorCode("order_closed")
+func RegisterErrorCode(info ErrorCodeInfo) {
+	errorCodesMu.Lock()
+	defer errorCodesMu.Unlock()
+
+	errorCodes[info.Code] = info
+}
+
+// ErrorCodes returns the list with all registered api error codes sorted by code.
+func ErrorCodes() []ErrorCodeInfo {
+	errorCodesMu.RLock()
+	defer errorCodesMu.RUnlock()
+
+	result := make([]ErrorCodeInfo, 0, len(errorCodes))
+	for _, info := range errorCodes {
+		result = append(result, info)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Code < result[j].Code
+	})
+
+	return result
+}
+
+// defaultErrorCode returns the generic error code for the specified response status.
+func defaultErrorCode(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return ErrorCodeBadRequest
+	case http.StatusUnauthorized:
+		return ErrorCodeUnauthorized
+	case http.StatusForbidden:
+		return ErrorCodeForbidden
+	case http.StatusNotFound:
+		return ErrorCodeNotFound
+	case http.StatusMethodNotAllowed:
+		return ErrorCodeMethodNotAllowed
+	case http.StatusRequestEntityTooLarge:
+		return ErrorCodeTooLarge
+	case http.StatusTooManyRequests:
+		return ErrorCodeTooManyRequests
+	}
+
+	if status >= 500 {
+		return ErrorCodeInternal
+	}
+
+	return ErrorCodeUnknown
+}
+
+// bindErrorCodesApi registers the api error codes catalog endpoint.
+func bindErrorCodesApi(app core.App, rg *echo.Group) {
+	rg.GET("/error-codes", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, ErrorCodes())
+	})
+}
@@ -0,0 +1,122 @@
+package apis_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/apis"
+	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestRegisterErrorCode(t *testing.T) {
+	apis.RegisterErrorCode(apis.ErrorCodeInfo{
+		Code:        "test_custom_code",
+		Status:      409,
+		Description: "test",
+	})
+
+	var found bool
+	for _, info := range apis.ErrorCodes() {
+		if info.Code == "test_custom_code" {
+			found = info.Status == 409 && info.Description == "test"
+			break
+		}
+	}
+
+	if !found {
+		t.Fatal("Expected the registered error code to be in the catalog")
+	}
+}
+
+func TestErrorCodesApi(t *testing.T) {
+	scenarios := []tests.ApiScenario{
+		{
+			Name:           "list error codes",
+			Method:         http.MethodGet,
+			Url:            "/api/error-codes",
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`{"code":"bad_request","status":400,`,
+				`{"code":"rule_forbidden","status":403,`,
+				`{"code":"validation_failed","status":400,`,
+				`{"code":"file_too_large","status":400,`,
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
+
+func TestApiErrorFormats(t *testing.T) {
+	setFormat := func(format string) func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+		return func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+			app.Settings().ApiErrors.Format = format
+		}
+	}
+
+	checkHeaders := func(code string, contentType string) func(t *testing.T, app *tests.TestApp, res *http.Response) {
+		return func(t *testing.T, app *tests.TestApp, res *http.Response) {
+			if v := res.Header.Get(apis.ErrorCodeHeader); v != code {
+				t.Fatalf("Expected %s header %q, got %q", apis.ErrorCodeHeader, code, v)
+			}
+
+			if v := res.Header.Get("Content-Type"); v != contentType {
+				t.Fatalf("Expected content type %q, got %q", contentType, v)
+			}
+		}
+	}
+
+	scenarios := []tests.ApiScenario{
+		{
+			Name:            "default format",
+			Method:          http.MethodGet,
+			Url:             "/api/missing",
+			BeforeTestFunc:  setFormat(settings.ApiErrorsFormatDefault),
+			ExpectedStatus:  404,
+			ExpectedContent: []string{`{"code":404,"message":"Not Found.","data":{}}`},
+			AfterTestFunc:   checkHeaders("not_found", "application/json; charset=UTF-8"),
+		},
+		{
+			Name:            "extended format",
+			Method:          http.MethodGet,
+			Url:             "/api/missing",
+			BeforeTestFunc:  setFormat(settings.ApiErrorsFormatExtended),
+			ExpectedStatus:  404,
+			ExpectedContent: []string{`{"code":404,"errorCode":"not_found","message":"Not Found.","data":{}}`},
+			AfterTestFunc:   checkHeaders("not_found", "application/json; charset=UTF-8"),
+		},
+		{
+			Name:           "problem format",
+			Method:         http.MethodGet,
+			Url:            "/api/missing",
+			BeforeTestFunc: setFormat(settings.ApiErrorsFormatProblem),
+			ExpectedStatus: 404,
+			ExpectedContent: []string{
+				`"type":"about:blank"`,
+				`"title":"Not Found"`,
+				`"status":404`,
+				`"detail":"Not Found."`,
+				`"code":"not_found"`,
+				`"errors":{}`,
+			},
+			AfterTestFunc: checkHeaders("not_found", apis.MIMEApplicationProblemJSON),
+		},
+		{
+			Name:            "rule forbidden error code",
+			Method:          http.MethodGet,
+			Url:             "/api/collections/demo1/records",
+			BeforeTestFunc:  setFormat(settings.ApiErrorsFormatExtended),
+			ExpectedStatus:  403,
+			ExpectedContent: []string{`"errorCode":"rule_forbidden"`},
+			AfterTestFunc:   checkHeaders("rule_forbidden", "application/json; charset=UTF-8"),
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
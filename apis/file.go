@@ -13,7 +13,6 @@ import (
 	"github.com/labstack/echo/v5"
 	"github.com/pocketbase/pocketbase/core"
 	"github.com/pocketbase/pocketbase/models"
-	"github.com/pocketbase/pocketbase/models/schema"
 	"github.com/pocketbase/pocketbase/tokens"
 	"github.com/pocketbase/pocketbase/tools/filesystem"
 	"github.com/pocketbase/pocketbase/tools/list"
@@ -109,8 +108,8 @@ func (api *fileApi) download(c echo.Context) error {
 		return NewNotFoundError("", nil)
 	}
 
-	options, ok := fileField.Options.(*schema.FileOptions)
-	if !ok {
+	options := fileField.FileOptions()
+	if options == nil {
 		return NewBadRequestError("", errors.New("Failed to load file options."))
 	}
 
@@ -231,6 +230,7 @@ func (api *fileApi) findAdminOrAuthRecordByFileToken(fileToken string) (models.M
 		admin, err := api.app.Dao().FindAdminByToken(
 			fileToken,
 			api.app.Settings().AdminFileToken.Secret,
+			api.app.Settings().AdminFileToken.PreviousSecrets()...,
 		)
 		if err == nil && admin != nil {
 			return admin, nil
@@ -239,6 +239,7 @@ func (api *fileApi) findAdminOrAuthRecordByFileToken(fileToken string) (models.M
 		record, err := api.app.Dao().FindAuthRecordByToken(
 			fileToken,
 			api.app.Settings().RecordFileToken.Secret,
+			api.app.Settings().RecordFileToken.PreviousSecrets()...,
 		)
 		if err == nil && record != nil {
 			return record, nil
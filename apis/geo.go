@@ -0,0 +1,175 @@
+package apis
+
+import (
+	"log/slog"
+	"net"
+	"strings"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/geoip"
+	"github.com/pocketbase/pocketbase/tools/store"
+)
+
+// ContextRequestGeoKey is the request context key that holds the
+// resolved request geolocation data (see [RequestGeo]).
+const ContextRequestGeoKey = "requestGeo"
+
+// cache with the loaded MaxMind DB readers (keyed by their file path)
+var geoReaders = store.New[*geoip.Reader](nil)
+
+// RequestGeo returns the geolocation data of the request ip address
+// resolved using the MaxMind DB configured in the app settings.
+//
+// The returned map could have the following keys:
+//   - "country" - the ISO 3166-1 country code (eg. "BG")
+//   - "continent" - the continent code (eg. "EU")
+//
+// Returns nil if there is no configured MaxMind DB or the ip location is unknown.
+func RequestGeo(app core.App, c echo.Context) map[string]any {
+	if v, ok := c.Get(ContextRequestGeoKey).(map[string]any); ok {
+		return v
+	}
+
+	reader := loadGeoReader(app)
+	if reader == nil {
+		return nil
+	}
+
+	result := lookupGeo(reader, c.RealIP())
+
+	c.Set(ContextRequestGeoKey, result)
+
+	return result
+}
+
+func lookupGeo(reader *geoip.Reader, ip string) map[string]any {
+	raw, err := reader.Lookup(net.ParseIP(ip))
+	if err != nil || raw == nil {
+		return nil
+	}
+
+	record, _ := raw.(map[string]any)
+	result := map[string]any{}
+
+	for _, key := range []string{"country", "registered_country"} {
+		country, _ := record[key].(map[string]any)
+		if code, ok := country["iso_code"].(string); ok && code != "" {
+			result["country"] = code
+			break
+		}
+	}
+
+	if continent, ok := record["continent"].(map[string]any); ok {
+		if code, ok := continent["code"].(string); ok && code != "" {
+			result["continent"] = code
+		}
+	}
+
+	return result
+}
+
+// loadGeoReader returns the cached MaxMind DB reader based on the current
+// app settings (or nil if not configured or failed to load).
+func loadGeoReader(app core.App) *geoip.Reader {
+	path := app.Settings().Geo.MaxMindDbPath
+	if path == "" {
+		return nil
+	}
+
+	if geoReaders.Has(path) {
+		return geoReaders.Get(path)
+	}
+
+	reader, err := geoip.Open(path)
+	if err != nil {
+		app.Logger().Error(
+			"Failed to load the MaxMind DB",
+			slog.String("path", path),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	// cache also the failed result to avoid reading the file on every request
+	geoReaders.Set(path, reader)
+
+	return reader
+}
+
+// geoRestriction creates a middleware that blocks the requests from
+// countries not allowed by the app settings geo restrictions.
+//
+// Admin and health check requests are not restricted.
+//
+// Note that all restricted requests are blocked if the
+// configured MaxMind DB is missing or failed to load.
+func geoRestriction(app core.App) echo.MiddlewareFunc {
+	if config := app.Settings().Geo; len(config.AllowedCountries) > 0 || len(config.BlockedCountries) > 0 {
+		if loadGeoReader(app) == nil {
+			app.Logger().Warn(
+				"The geo restrictions are configured but the MaxMind DB is not loaded, so all restricted requests will be blocked",
+				slog.String("path", config.MaxMindDbPath),
+			)
+		}
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			config := app.Settings().Geo
+
+			if len(config.AllowedCountries) == 0 && len(config.BlockedCountries) == 0 {
+				return next(c) // no restrictions
+			}
+
+			// note: compare with the matched route path because the request
+			// url could have a base path, trailing slash, different casing, etc.
+			if c.Get(ContextAdminKey) != nil || c.Path() == healthRoutePath {
+				return next(c)
+			}
+
+			if len(config.Collections) > 0 && !isGeoRestrictedCollection(app, c.PathParam("collection"), config.Collections) {
+				return next(c)
+			}
+
+			// fail closed because the restrictions cannot be applied without a geo db
+			var country string
+			if loadGeoReader(app) != nil {
+				country, _ = RequestGeo(app, c)["country"].(string)
+
+				if config.IsCountryAllowed(country) {
+					return next(c)
+				}
+			}
+
+			app.Logger().Warn(
+				"Geo restricted request",
+				slog.String("type", "geo_blocked"),
+				slog.String("country", country),
+				slog.String("ip", c.RealIP()),
+				slog.String("method", c.Request().Method),
+				slog.String("url", c.Request().URL.RequestURI()),
+			)
+
+			return NewForbiddenError("The requested resource is not available in your location.", nil)
+		}
+	}
+}
+
+func isGeoRestrictedCollection(app core.App, collectionNameOrId string, restricted []string) bool {
+	if collectionNameOrId == "" {
+		return false
+	}
+
+	collection, err := app.Dao().FindCollectionByNameOrId(collectionNameOrId)
+	if err != nil {
+		return false
+	}
+
+	for _, v := range restricted {
+		if v == collection.Id || strings.EqualFold(v, collection.Name) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,806 @@
+package apis
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/resolvers"
+	"github.com/pocketbase/pocketbase/tools/graphql"
+	"github.com/pocketbase/pocketbase/tools/inflector"
+	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/search"
+	"github.com/spf13/cast"
+)
+
+// graphqlNameRegex is the pattern that every GraphQL name must match.
+var graphqlNameRegex = regexp.MustCompile(`^[_A-Za-z][_0-9A-Za-z]*$`)
+
+// graphqlReservedTypes is a list with the type names that cannot
+// be used for the collection types.
+var graphqlReservedTypes = []string{"Query", "Mutation", "Subscription", "JSON", "ID", "String", "Int", "Float", "Boolean"}
+
+// bindGraphQLApi registers the GraphQL api endpoints and the corresponding handlers.
+//
+// The endpoints are available only if enabled in the app settings.
+func bindGraphQLApi(app core.App, rg *echo.Group) {
+	api := graphqlApi{app: app}
+
+	subGroup := rg.Group("/graphql", api.requireEnabled, ActivityLogger(app))
+	subGroup.POST("", api.execute)
+	subGroup.GET("/schema", api.schema, RequireAdminAuth())
+}
+
+type graphqlApi struct {
+	app core.App
+}
+
+type graphqlRequest struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+type graphqlError struct {
+	Message    string         `json:"message"`
+	Path       []string       `json:"path,omitempty"`
+	Extensions map[string]any `json:"extensions,omitempty"`
+}
+
+type graphqlResponse struct {
+	Data   map[string]any  `json:"data,omitempty"`
+	Errors []*graphqlError `json:"errors,omitempty"`
+}
+
+func (api *graphqlApi) requireEnabled(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if !api.app.Settings().GraphQL.Enabled {
+			return NewNotFoundError("", nil)
+		}
+
+		return next(c)
+	}
+}
+
+// schema returns the GraphQL schema (in SDL format) generated from the app collections.
+func (api *graphqlApi) schema(c echo.Context) error {
+	collections, err := api.findCollections()
+	if err != nil {
+		return NewBadRequestError("Failed to load the collections.", err)
+	}
+
+	return c.String(http.StatusOK, graphqlSchema(collections))
+}
+
+// execute executes a single GraphQL query or mutation operation.
+//
+// For each collection the following root fields are available
+// (see the schema endpoint for the full type definitions):
+//   - Query.{name}(id) - returns a single collection record (honors the ViewRule)
+//   - Query.{name}List(filter, sort, page, perPage) - returns a paginated list of records (honors the ListRule)
+//   - Mutation.create{Name}(data) - creates a new record (honors the CreateRule)
+//   - Mutation.update{Name}(id, data) - updates an existing record (honors the UpdateRule)
+//   - Mutation.delete{Name}(id) - deletes an existing record (honors the DeleteRule)
+//
+// The relation fields with a selection set are expanded (the related
+// collection ViewRule is applied) and the mutations are executed
+// similar to the batch api operations, aka. without triggering
+// the record request hooks.
+func (api *graphqlApi) execute(c echo.Context) error {
+	req := &graphqlRequest{}
+	if err := c.Bind(req); err != nil {
+		return graphqlRequestError(c, "Failed to load the submitted data due to invalid formatting.")
+	}
+
+	doc, err := graphql.Parse(req.Query)
+	if err != nil {
+		return graphqlRequestError(c, "Invalid query: "+err.Error())
+	}
+
+	op, err := doc.Operation(req.OperationName)
+	if err != nil {
+		return graphqlRequestError(c, err.Error())
+	}
+
+	if op.Type == graphql.OperationSubscription {
+		return graphqlRequestError(c, "Subscriptions are not supported. Use the realtime api instead.")
+	}
+
+	if maxDepth := api.app.Settings().GraphQL.MaxDepth; op.Depth() > maxDepth {
+		return graphqlRequestError(c, fmt.Sprintf("The query exceeds the max allowed depth of %d.", maxDepth))
+	}
+
+	variables, err := op.ResolveVariables(req.Variables)
+	if err != nil {
+		return graphqlRequestError(c, err.Error())
+	}
+
+	collections, err := api.findCollections()
+	if err != nil {
+		return graphqlRequestError(c, "Failed to load the collections.")
+	}
+
+	executor := &graphqlExecutor{
+		dao:         api.app.Dao(),
+		app:         api.app,
+		requestInfo: RequestInfo(c),
+		variables:   variables,
+		collections: collections,
+	}
+
+	return c.JSON(http.StatusOK, executor.run(op))
+}
+
+// findCollections returns all collections that could be exposed in the GraphQL schema.
+func (api *graphqlApi) findCollections() ([]*models.Collection, error) {
+	all := []*models.Collection{}
+	if err := api.app.Dao().CollectionQuery().OrderBy("name ASC").All(&all); err != nil {
+		return nil, err
+	}
+
+	result := make([]*models.Collection, 0, len(all))
+
+	for _, c := range all {
+		if !graphqlNameRegex.MatchString(c.Name) || strings.HasPrefix(c.Name, "__") {
+			continue
+		}
+
+		typeName := graphqlTypeName(c)
+		if typeName == "" || list.ExistInSlice(typeName, graphqlReservedTypes) {
+			continue
+		}
+
+		result = append(result, c)
+	}
+
+	return result, nil
+}
+
+func graphqlRequestError(c echo.Context, message string) error {
+	return c.JSON(http.StatusBadRequest, &graphqlResponse{
+		Errors: []*graphqlError{{Message: message}},
+	})
+}
+
+// -------------------------------------------------------------------
+
+type graphqlExecutor struct {
+	app         core.App
+	dao         *daos.Dao
+	requestInfo *models.RequestInfo
+	variables   map[string]any
+	collections []*models.Collection
+}
+
+func (e *graphqlExecutor) run(op *graphql.Operation) *graphqlResponse {
+	response := &graphqlResponse{Data: map[string]any{}}
+
+	rootType := "Query"
+	if op.Type == graphql.OperationMutation {
+		rootType = "Mutation"
+	}
+
+	for _, field := range op.SelectionSet {
+		key := field.ResponseKey()
+
+		var value any
+		var err error
+
+		switch {
+		case field.Name == "__typename":
+			value = rootType
+		case field.Name == "__schema" || field.Name == "__type":
+			err = errors.New("Introspection is not supported. Use the /api/graphql/schema endpoint instead.")
+		case op.Type == graphql.OperationMutation:
+			value, err = e.resolveMutation(field)
+		default:
+			value, err = e.resolveQuery(field)
+		}
+
+		response.Data[key] = value
+
+		if err != nil {
+			response.Errors = append(response.Errors, newGraphqlError(err, key))
+		}
+	}
+
+	return response
+}
+
+func (e *graphqlExecutor) resolveQuery(field *graphql.Field) (any, error) {
+	for _, collection := range e.collections {
+		switch field.Name {
+		case collection.Name:
+			return e.view(collection, field)
+		case collection.Name + "List":
+			return e.list(collection, field)
+		}
+	}
+
+	return nil, fmt.Errorf("Cannot query field %q on type %q.", field.Name, "Query")
+}
+
+func (e *graphqlExecutor) resolveMutation(field *graphql.Field) (any, error) {
+	for _, collection := range e.collections {
+		if collection.IsView() {
+			continue
+		}
+
+		typeName := graphqlTypeName(collection)
+
+		switch field.Name {
+		case "create" + typeName:
+			return e.mutate(collection, field, forms.BatchActionCreate)
+		case "update" + typeName:
+			return e.mutate(collection, field, forms.BatchActionUpdate)
+		case "delete" + typeName:
+			return e.mutate(collection, field, forms.BatchActionDelete)
+		}
+	}
+
+	return nil, fmt.Errorf("Cannot query field %q on type %q.", field.Name, "Mutation")
+}
+
+func (e *graphqlExecutor) view(collection *models.Collection, field *graphql.Field) (any, error) {
+	if len(field.SelectionSet) == 0 {
+		return nil, fmt.Errorf("Field %q must have a selection of subfields.", field.Name)
+	}
+
+	id := cast.ToString(field.ArgumentValues(e.variables)["id"])
+	if id == "" {
+		return nil, errors.New(`Missing required argument "id".`)
+	}
+
+	if e.requestInfo.Admin == nil && collection.ViewRule == nil {
+		return nil, NewForbiddenError("Only admins can perform this action.", nil).WithErrorCode(ErrorCodeRuleForbidden)
+	}
+
+	ruleFunc := func(q *dbx.SelectQuery) error {
+		if e.requestInfo.Admin == nil && collection.ViewRule != nil && *collection.ViewRule != "" {
+			resolver := resolvers.NewRecordFieldResolver(e.dao, collection, e.requestInfo, true)
+			expr, err := search.FilterData(*collection.ViewRule).BuildExpr(resolver)
+			if err != nil {
+				return err
+			}
+			resolver.UpdateQuery(q)
+			q.AndWhere(expr)
+		}
+		return nil
+	}
+
+	record, err := e.dao.FindRecordById(collection.Id, id, ruleFunc)
+	if err != nil || record == nil {
+		return nil, nil // not found
+	}
+
+	return e.resolveRecord(record, field.SelectionSet)
+}
+
+func (e *graphqlExecutor) list(collection *models.Collection, field *graphql.Field) (any, error) {
+	if len(field.SelectionSet) == 0 {
+		return nil, fmt.Errorf("Field %q must have a selection of subfields.", field.Name)
+	}
+
+	if e.requestInfo.Admin == nil && collection.ListRule == nil {
+		return nil, NewForbiddenError("Only admins can perform this action.", nil).WithErrorCode(ErrorCodeRuleForbidden)
+	}
+
+	args := field.ArgumentValues(e.variables)
+	filter := cast.ToString(args["filter"])
+	sortExpr := cast.ToString(args["sort"])
+
+	// forbid users and guests to query special filter/sort fields
+	info := *e.requestInfo
+	info.Query = map[string]any{search.FilterQueryParam: filter, search.SortQueryParam: sortExpr}
+	if err := checkForAdminOnlyRuleFields(&info); err != nil {
+		return nil, err
+	}
+
+	fieldsResolver := resolvers.NewRecordFieldResolver(
+		e.dao,
+		collection,
+		e.requestInfo,
+		// hidden fields are searchable only by admins
+		e.requestInfo.Admin != nil,
+	)
+
+	provider := search.NewProvider(fieldsResolver).
+		Query(e.dao.RecordQuery(collection)).
+		Page(cast.ToInt(args["page"])).
+		PerPage(cast.ToInt(args["perPage"]))
+
+	if e.requestInfo.Admin == nil {
+		if collection.ListRule != nil {
+			provider.AddFilter(search.FilterData(*collection.ListRule))
+		}
+
+		if err := checkListFilterComplexity(collection, filter); err != nil {
+			return nil, err
+		}
+
+		provider.PerPageLimit(collection.ListMaxPerPage())
+	}
+
+	provider.AddFilter(search.FilterData(filter))
+
+	if sortExpr == "" {
+		sortExpr = collection.ListDefaultSort()
+	}
+	for _, sortField := range search.ParseSortFromString(sortExpr) {
+		if sortField.Name != "" {
+			provider.AddSort(sortField)
+		}
+	}
+
+	// skip the total counter query if not requested
+	var itemsSelection []*graphql.Field
+	skipTotal := true
+	for _, f := range field.SelectionSet {
+		switch f.Name {
+		case "totalItems", "totalPages":
+			skipTotal = false
+		case "items":
+			itemsSelection = append(itemsSelection, f.SelectionSet...)
+		}
+	}
+	provider.SkipTotal(skipTotal)
+
+	records := []*models.Record{}
+
+	result, err := provider.Exec(&records)
+	if err != nil {
+		return nil, NewBadRequestError("", err)
+	}
+
+	if err := e.enrich(collection, records, itemsSelection); err != nil {
+		return nil, err
+	}
+
+	typeName := graphqlTypeName(collection) + "List"
+
+	out := make(map[string]any, len(field.SelectionSet))
+
+	for _, f := range field.SelectionSet {
+		key := f.ResponseKey()
+
+		switch f.Name {
+		case "__typename":
+			out[key] = typeName
+		case "page":
+			out[key] = result.Page
+		case "perPage":
+			out[key] = result.PerPage
+		case "totalItems":
+			out[key] = result.TotalItems
+		case "totalPages":
+			out[key] = result.TotalPages
+		case "items":
+			if len(f.SelectionSet) == 0 {
+				return nil, fmt.Errorf("Field %q must have a selection of subfields.", f.Name)
+			}
+
+			items := make([]any, len(records))
+			for i, record := range records {
+				items[i], err = e.serializeRecord(record, f.SelectionSet)
+				if err != nil {
+					return nil, err
+				}
+			}
+			out[key] = items
+		default:
+			return nil, fmt.Errorf("Cannot query field %q on type %q.", f.Name, typeName)
+		}
+	}
+
+	return out, nil
+}
+
+func (e *graphqlExecutor) mutate(collection *models.Collection, field *graphql.Field, action string) (any, error) {
+	args := field.ArgumentValues(e.variables)
+
+	item := &forms.BatchRequestItem{
+		Action:     action,
+		Collection: collection.Id,
+		Id:         cast.ToString(args["id"]),
+	}
+
+	if action != forms.BatchActionDelete {
+		if len(field.SelectionSet) == 0 {
+			return nil, fmt.Errorf("Field %q must have a selection of subfields.", field.Name)
+		}
+
+		data, ok := args["data"].(map[string]any)
+		if !ok {
+			return nil, errors.New(`The "data" argument must be an object.`)
+		}
+		item.Data = data
+	} else if len(field.SelectionSet) > 0 {
+		return nil, fmt.Errorf("Field %q must not have a selection since type \"Boolean\" has no subfields.", field.Name)
+	}
+
+	form := forms.NewBatchRequest(e.app)
+	form.SetFullManageAccess(e.requestInfo.Admin != nil)
+	form.Requests = []*forms.BatchRequestItem{item}
+
+	if e.requestInfo.Admin == nil {
+		form.SetAccessFunc(func(txDao *daos.Dao, item *forms.BatchRequestItem, record *models.Record) error {
+			return checkBatchRule(txDao, e.requestInfo, item, record)
+		})
+	}
+
+	results, err := form.Submit()
+	if err != nil {
+		return nil, NewBadRequestError("Failed to "+action+" record.", unwrapBatchItemError(err))
+	}
+
+	if action == forms.BatchActionDelete {
+		return true, nil
+	}
+
+	return e.resolveRecord(results[0].Record, field.SelectionSet)
+}
+
+func (e *graphqlExecutor) resolveRecord(record *models.Record, fields []*graphql.Field) (any, error) {
+	if err := e.enrich(record.Collection(), []*models.Record{record}, fields); err != nil {
+		return nil, err
+	}
+
+	return e.serializeRecord(record, fields)
+}
+
+// enrich prepares the records for export by applying the same
+// enrichments as the regular records api (including expanding
+// the relation fields that have a selection set).
+func (e *graphqlExecutor) enrich(collection *models.Collection, records []*models.Record, fields []*graphql.Field) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	expands := e.selectionExpands(collection, fields, "")
+
+	return enrichRecords(e.dao, e.requestInfo, records, "", expands)
+}
+
+// selectionExpands returns the expand paths of the relation fields with a selection set.
+func (e *graphqlExecutor) selectionExpands(collection *models.Collection, fields []*graphql.Field, prefix string) []string {
+	var result []string
+
+	for _, f := range fields {
+		if len(f.SelectionSet) == 0 {
+			continue
+		}
+
+		schemaField := collection.Schema.GetFieldByName(f.Name)
+		if schemaField == nil || schemaField.Type != schema.FieldTypeRelation {
+			continue
+		}
+
+		path := prefix + f.Name
+		result = append(result, path)
+
+		options, _ := schemaField.Options.(*schema.RelationOptions)
+		if options == nil {
+			continue
+		}
+
+		if relCollection := e.findCollection(options.CollectionId); relCollection != nil {
+			result = append(result, e.selectionExpands(relCollection, f.SelectionSet, path+".")...)
+		}
+	}
+
+	return result
+}
+
+func (e *graphqlExecutor) findCollection(id string) *models.Collection {
+	for _, c := range e.collections {
+		if c.Id == id {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// serializeRecord exports the selected fields of an already enriched record.
+func (e *graphqlExecutor) serializeRecord(record *models.Record, fields []*graphql.Field) (map[string]any, error) {
+	collection := record.Collection()
+	typeName := graphqlTypeName(collection)
+	export := record.PublicExport()
+	expand := record.Expand()
+
+	out := make(map[string]any, len(fields))
+
+	for _, f := range fields {
+		key := f.ResponseKey()
+
+		if f.Name == "__typename" {
+			out[key] = typeName
+			continue
+		}
+
+		if !list.ExistInSlice(f.Name, graphqlRecordFields(collection)) {
+			return nil, fmt.Errorf("Cannot query field %q on type %q.", f.Name, typeName)
+		}
+
+		schemaField := collection.Schema.GetFieldByName(f.Name)
+
+		// non-relation field
+		if schemaField == nil || schemaField.Type != schema.FieldTypeRelation {
+			if len(f.SelectionSet) > 0 {
+				return nil, fmt.Errorf("Field %q must not have a selection since it has no subfields.", f.Name)
+			}
+
+			out[key] = export[f.Name]
+			continue
+		}
+
+		// relation field
+		if len(f.SelectionSet) == 0 {
+			return nil, fmt.Errorf("Field %q must have a selection of subfields.", f.Name)
+		}
+
+		if _, ok := export[f.Name]; !ok {
+			out[key] = nil // hidden
+			continue
+		}
+
+		options, _ := schemaField.Options.(*schema.RelationOptions)
+		isMultiple := options != nil && options.IsMultiple()
+
+		switch v := expand[f.Name].(type) {
+		case *models.Record:
+			rel, err := e.serializeRecord(v, f.SelectionSet)
+			if err != nil {
+				return nil, err
+			}
+			if isMultiple {
+				out[key] = []any{rel}
+			} else {
+				out[key] = rel
+			}
+		case []*models.Record:
+			items := make([]any, len(v))
+			for i, r := range v {
+				rel, err := e.serializeRecord(r, f.SelectionSet)
+				if err != nil {
+					return nil, err
+				}
+				items[i] = rel
+			}
+			out[key] = items
+		default:
+			if isMultiple {
+				out[key] = []any{}
+			} else {
+				out[key] = nil
+			}
+		}
+	}
+
+	return out, nil
+}
+
+// unwrapBatchItemError extracts the field errors of the single
+// batch operation from the batch form errors (if any).
+func unwrapBatchItemError(err error) error {
+	var errs validation.Errors
+	if errors.As(err, &errs) {
+		if items, ok := errs["requests"].(validation.Errors); ok {
+			if fieldErrs, ok := items["0"].(validation.Errors); ok {
+				return fieldErrs
+			}
+		}
+	}
+
+	return err
+}
+
+func newGraphqlError(err error, key string) *graphqlError {
+	result := &graphqlError{Message: err.Error(), Path: []string{key}}
+
+	var apiErr *ApiError
+	if errors.As(err, &apiErr) {
+		result.Message = apiErr.Message
+		result.Extensions = map[string]any{
+			"code": apiErr.Code,
+			"data": apiErr.Data,
+		}
+	}
+
+	return result
+}
+
+// -------------------------------------------------------------------
+
+// graphqlTypeName returns the GraphQL object type name of the collection records.
+func graphqlTypeName(collection *models.Collection) string {
+	return inflector.Pascalcase(collection.Name)
+}
+
+// graphqlRecordFields returns the names of the collection record type fields.
+func graphqlRecordFields(collection *models.Collection) []string {
+	result := []string{
+		schema.FieldNameId,
+		schema.FieldNameCollectionId,
+		schema.FieldNameCollectionName,
+	}
+
+	if !collection.IsView() {
+		result = append(result, schema.FieldNameCreated, schema.FieldNameUpdated)
+	}
+
+	if collection.IsAuth() {
+		result = append(
+			result,
+			schema.FieldNameUsername,
+			schema.FieldNameEmail,
+			schema.FieldNameEmailVisibility,
+			schema.FieldNameVerified,
+		)
+	}
+
+	for _, f := range collection.Schema.Fields() {
+		result = append(result, f.Name)
+	}
+
+	return result
+}
+
+// graphqlSchema generates the GraphQL schema (in SDL format) from the provided collections.
+func graphqlSchema(collections []*models.Collection) string {
+	collections = append([]*models.Collection{}, collections...)
+	sort.SliceStable(collections, func(i, j int) bool {
+		return collections[i].Name < collections[j].Name
+	})
+
+	typeNames := make(map[string]string, len(collections)) // collection id -> type name
+	for _, c := range collections {
+		typeNames[c.Id] = graphqlTypeName(c)
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("scalar JSON\n\n")
+
+	// query
+	sb.WriteString("type Query {\n")
+	for _, c := range collections {
+		typeName := typeNames[c.Id]
+		sb.WriteString(fmt.Sprintf("  %s(id: ID!): %s\n", c.Name, typeName))
+		sb.WriteString(fmt.Sprintf("  %sList(filter: String, sort: String, page: Int, perPage: Int): %sList!\n", c.Name, typeName))
+	}
+	sb.WriteString("}\n")
+
+	// mutation
+	var mutations strings.Builder
+	for _, c := range collections {
+		if c.IsView() {
+			continue
+		}
+		typeName := typeNames[c.Id]
+		mutations.WriteString(fmt.Sprintf("  create%s(data: %sInput!): %s\n", typeName, typeName, typeName))
+		mutations.WriteString(fmt.Sprintf("  update%s(id: ID!, data: %sInput!): %s\n", typeName, typeName, typeName))
+		mutations.WriteString(fmt.Sprintf("  delete%s(id: ID!): Boolean!\n", typeName))
+	}
+	if mutations.Len() > 0 {
+		sb.WriteString("\ntype Mutation {\n")
+		sb.WriteString(mutations.String())
+		sb.WriteString("}\n")
+	}
+
+	// collection types
+	for _, c := range collections {
+		typeName := typeNames[c.Id]
+
+		sb.WriteString(fmt.Sprintf("\ntype %s {\n", typeName))
+		sb.WriteString("  id: ID!\n")
+		sb.WriteString("  collectionId: String!\n")
+		sb.WriteString("  collectionName: String!\n")
+		if !c.IsView() {
+			sb.WriteString("  created: String!\n")
+			sb.WriteString("  updated: String!\n")
+		}
+		if c.IsAuth() {
+			sb.WriteString("  username: String\n")
+			sb.WriteString("  email: String\n")
+			sb.WriteString("  emailVisibility: Boolean\n")
+			sb.WriteString("  verified: Boolean\n")
+		}
+		for _, f := range c.Schema.Fields() {
+			if !graphqlNameRegex.MatchString(f.Name) {
+				continue
+			}
+			sb.WriteString(fmt.Sprintf("  %s: %s\n", f.Name, graphqlFieldType(f, typeNames, false)))
+		}
+		sb.WriteString("}\n")
+
+		sb.WriteString(fmt.Sprintf("\ntype %sList {\n", typeName))
+		sb.WriteString("  page: Int!\n")
+		sb.WriteString("  perPage: Int!\n")
+		sb.WriteString("  totalItems: Int!\n")
+		sb.WriteString("  totalPages: Int!\n")
+		sb.WriteString(fmt.Sprintf("  items: [%s!]!\n", typeName))
+		sb.WriteString("}\n")
+
+		if c.IsView() {
+			continue
+		}
+
+		sb.WriteString(fmt.Sprintf("\ninput %sInput {\n", typeName))
+		sb.WriteString("  id: ID\n")
+		if c.IsAuth() {
+			sb.WriteString("  username: String\n")
+			sb.WriteString("  email: String\n")
+			sb.WriteString("  emailVisibility: Boolean\n")
+			sb.WriteString("  verified: Boolean\n")
+			sb.WriteString("  password: String\n")
+			sb.WriteString("  passwordConfirm: String\n")
+			sb.WriteString("  oldPassword: String\n")
+		}
+		for _, f := range c.Schema.Fields() {
+			// files cannot be uploaded with json data
+			if !graphqlNameRegex.MatchString(f.Name) || f.Type == schema.FieldTypeFile || f.Type == schema.FieldTypeAvatar {
+				continue
+			}
+			sb.WriteString(fmt.Sprintf("  %s: %s\n", f.Name, graphqlFieldType(f, typeNames, true)))
+		}
+		sb.WriteString("}\n")
+	}
+
+	return sb.String()
+}
+
+// graphqlFieldType returns the GraphQL type of the provided schema field.
+func graphqlFieldType(field *schema.SchemaField, typeNames map[string]string, input bool) string {
+	field.InitOptions()
+
+	multiple := false
+	if opt, ok := field.Options.(schema.MultiValuer); ok {
+		multiple = opt.IsMultiple()
+	}
+
+	var typ string
+
+	switch field.Type {
+	case schema.FieldTypeNumber, schema.FieldTypePosition:
+		typ = "Float"
+	case schema.FieldTypeCurrency:
+		typ = "Int"
+	case schema.FieldTypeBool:
+		typ = "Boolean"
+	case schema.FieldTypeJson, schema.FieldTypeMarkdown, schema.FieldTypePhone:
+		typ = "JSON"
+	case schema.FieldTypeList:
+		typ = "String"
+		if options, _ := field.Options.(*schema.ListOptions); options != nil && options.ElementType == schema.ListElementTypeNumber {
+			typ = "Float"
+		}
+	case schema.FieldTypeRelation:
+		typ = "ID"
+		if !input {
+			options, _ := field.Options.(*schema.RelationOptions)
+			if name := typeNames[options.CollectionId]; name != "" {
+				typ = name
+			}
+		}
+	default:
+		typ = "String"
+	}
+
+	if multiple {
+		return "[" + typ + "!]"
+	}
+
+	return typ
+}
@@ -2,16 +2,20 @@ package apis
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/labstack/echo/v5"
 	"github.com/pocketbase/pocketbase/core"
 )
 
+// healthRoutePath is the path of the registered health check route.
+const healthRoutePath = "/api/health"
+
 // bindHealthApi registers the health api endpoint.
 func bindHealthApi(app core.App, rg *echo.Group) {
 	api := healthApi{app: app}
 
-	subGroup := rg.Group("/health")
+	subGroup := rg.Group(strings.TrimPrefix(healthRoutePath, "/api"))
 	subGroup.GET("", api.healthCheck)
 }
 
@@ -0,0 +1,88 @@
+package apis
+
+import (
+	"errors"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/i18n"
+)
+
+// LocalesDirName is the name of the app data dir subdirectory with the
+// custom locale files (eg. "pb_data/locales/de.json") that extend or
+// override the bundled api error translations.
+const LocalesDirName = "locales"
+
+const storeKeyTranslator = "@apiTranslator"
+
+var translatorMux sync.Mutex
+
+// Translator returns the api errors translator of the provided app.
+//
+// The translator is initialized on first use with the bundled locales
+// and the custom locale files from the app data dir (changes in the
+// locale files require app restart).
+func Translator(app core.App) *i18n.Translator {
+	translatorMux.Lock()
+	defer translatorMux.Unlock()
+
+	if tr, ok := app.Store().Get(storeKeyTranslator).(*i18n.Translator); ok {
+		return tr
+	}
+
+	tr := i18n.New()
+
+	dir := filepath.Join(app.DataDir(), LocalesDirName)
+	if err := tr.Load(os.DirFS(dir), "."); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		app.Logger().Error(
+			"Failed to load the custom locale files",
+			slog.String("dir", dir),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	app.Store().Set(storeKeyTranslator, tr)
+
+	return tr
+}
+
+// localizeApiError returns a copy of the provided api error with message
+// and validation errors translated based on the request Accept-Language header.
+//
+// The original error is returned if there is no matching locale.
+func localizeApiError(app core.App, c echo.Context, apiErr *ApiError) *ApiError {
+	acceptLanguage := c.Request().Header.Get("Accept-Language")
+	if acceptLanguage == "" {
+		return apiErr
+	}
+
+	tr := Translator(app)
+
+	locale := tr.Match(acceptLanguage)
+	if locale == "" {
+		return apiErr
+	}
+
+	c.Response().Header().Set("Content-Language", locale)
+
+	translate := func(key string) (string, bool) {
+		return tr.Translate(locale, key)
+	}
+
+	localized := *apiErr
+
+	if translated, ok := translate(apiErr.Message); ok {
+		localized.Message = translated
+	}
+
+	if data := translatedErrorsData(apiErr.rawData, translate); len(data) > 0 {
+		localized.Data = data
+	}
+
+	return &localized
+}
@@ -0,0 +1,139 @@
+package apis_test
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/apis"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestLocalizedApiErrors(t *testing.T) {
+	checkContentLanguage := func(expected string) func(t *testing.T, app *tests.TestApp, res *http.Response) {
+		return func(t *testing.T, app *tests.TestApp, res *http.Response) {
+			if v := res.Header.Get("Content-Language"); v != expected {
+				t.Fatalf("Expected Content-Language %q, got %q", expected, v)
+			}
+		}
+	}
+
+	scenarios := []tests.ApiScenario{
+		{
+			Name:           "without Accept-Language",
+			Method:         http.MethodPost,
+			Url:            "/api/collections/users/request-password-reset",
+			Body:           strings.NewReader(``),
+			ExpectedStatus: 400,
+			ExpectedContent: []string{
+				`"message":"An error occurred while validating the form."`,
+				`"data":{"email":{"code":"validation_required","message":"Cannot be blank."}}`,
+			},
+			AfterTestFunc: checkContentLanguage(""),
+		},
+		{
+			Name:   "unsupported Accept-Language",
+			Method: http.MethodPost,
+			Url:    "/api/collections/users/request-password-reset",
+			Body:   strings.NewReader(``),
+			RequestHeaders: map[string]string{
+				"Accept-Language": "ja",
+			},
+			ExpectedStatus: 400,
+			ExpectedContent: []string{
+				`"message":"An error occurred while validating the form."`,
+				`"data":{"email":{"code":"validation_required","message":"Cannot be blank."}}`,
+			},
+			AfterTestFunc: checkContentLanguage(""),
+		},
+		{
+			Name:   "english with lower priority fallback",
+			Method: http.MethodPost,
+			Url:    "/api/collections/users/request-password-reset",
+			Body:   strings.NewReader(``),
+			RequestHeaders: map[string]string{
+				"Accept-Language": "en-US,de;q=0.5",
+			},
+			ExpectedStatus: 400,
+			ExpectedContent: []string{
+				`"message":"An error occurred while validating the form."`,
+				`"data":{"email":{"code":"validation_required","message":"Cannot be blank."}}`,
+			},
+			AfterTestFunc: checkContentLanguage("en"),
+		},
+		{
+			Name:   "bundled locale",
+			Method: http.MethodPost,
+			Url:    "/api/collections/users/request-password-reset",
+			Body:   strings.NewReader(``),
+			RequestHeaders: map[string]string{
+				"Accept-Language": "de-DE,de;q=0.9,en;q=0.5",
+			},
+			ExpectedStatus: 400,
+			ExpectedContent: []string{
+				`"message":"Bei der Validierung des Formulars ist ein Fehler aufgetreten."`,
+				`"data":{"email":{"code":"validation_required","message":"Darf nicht leer sein."}}`,
+			},
+			AfterTestFunc: checkContentLanguage("de"),
+		},
+		{
+			Name:   "translated message with params",
+			Method: http.MethodGet,
+			Url:    "/params-error",
+			RequestHeaders: map[string]string{
+				"Accept-Language": "fr",
+			},
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				e.AddRoute(echo.Route{
+					Method: http.MethodGet,
+					Path:   "/params-error",
+					Handler: func(c echo.Context) error {
+						return apis.NewBadRequestError("Failed to create record.", validation.Errors{
+							"title": validation.Length(3, 5).Validate("ab"),
+						})
+					},
+				})
+			},
+			ExpectedStatus: 400,
+			ExpectedContent: []string{
+				`"message":"Impossible de créer l'enregistrement."`,
+				`"data":{"title":{"code":"validation_length_out_of_range","message":"La longueur doit être comprise entre 3 et 5."}}`,
+			},
+			AfterTestFunc: checkContentLanguage("fr"),
+		},
+		{
+			Name:   "custom data dir locale",
+			Method: http.MethodPost,
+			Url:    "/api/collections/users/request-password-reset",
+			Body:   strings.NewReader(``),
+			RequestHeaders: map[string]string{
+				"Accept-Language": "de",
+			},
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				dir := filepath.Join(app.DataDir(), apis.LocalesDirName)
+				if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+					t.Fatal(err)
+				}
+
+				err := os.WriteFile(filepath.Join(dir, "de.json"), []byte(`{"validation_required":"Pflichtfeld."}`), 0644)
+				if err != nil {
+					t.Fatal(err)
+				}
+			},
+			ExpectedStatus: 400,
+			ExpectedContent: []string{
+				`"message":"Bei der Validierung des Formulars ist ein Fehler aufgetreten."`,
+				`"data":{"email":{"code":"validation_required","message":"Pflichtfeld."}}`,
+			},
+			AfterTestFunc: checkContentLanguage("de"),
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
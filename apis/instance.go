@@ -0,0 +1,60 @@
+package apis
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+)
+
+// bindInstanceApi registers the instance metadata api endpoint.
+func bindInstanceApi(app core.App, rg *echo.Group) {
+	api := instanceApi{app: app}
+
+	subGroup := rg.Group("/instance")
+	subGroup.GET("", api.view, requireInstanceAccess(app))
+}
+
+type instanceApi struct {
+	app core.App
+}
+
+type instanceResponse struct {
+	Name        string `json:"name"`
+	Environment string `json:"environment"`
+	Region      string `json:"region"`
+	Version     string `json:"version"`
+}
+
+// requireInstanceAccess middleware requires admin or record auth
+// unless the settings Instance.Public option is enabled.
+func requireInstanceAccess(app core.App) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		authNext := RequireAdminOrRecordAuth()(next)
+
+		return func(c echo.Context) error {
+			if app.Settings().Instance.Public {
+				return next(c)
+			}
+
+			return authNext(c)
+		}
+	}
+}
+
+// view returns the instance metadata (name, environment, region and version).
+func (api *instanceApi) view(c echo.Context) error {
+	settings := api.app.Settings()
+
+	name := settings.Instance.Name
+	if name == "" {
+		name = settings.Meta.AppName
+	}
+
+	return c.JSON(http.StatusOK, instanceResponse{
+		Name:        name,
+		Environment: settings.Instance.Environment,
+		Region:      settings.Instance.Region,
+		Version:     api.app.Version(),
+	})
+}
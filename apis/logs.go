@@ -18,6 +18,7 @@ func bindLogsApi(app core.App, rg *echo.Group) {
 	subGroup.GET("", api.list)
 	subGroup.GET("/stats", api.stats)
 	subGroup.GET("/:id", api.view)
+	subGroup.POST("/:id/replay", api.replay)
 }
 
 type logsApi struct {
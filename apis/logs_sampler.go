@@ -0,0 +1,68 @@
+package apis
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/pocketbase/pocketbase/models/settings"
+)
+
+// contextLogsBodyKey is the request context key of the captured
+// logs group request body (see ActivityLogger).
+const contextLogsBodyKey = "logsBody"
+
+// loggedRequestBody holds the scrubbed request body of a logs group request.
+type loggedRequestBody struct {
+	body      string
+	truncated bool
+}
+
+// defaultLogsSampler is the requests sampler shared by all logs groups.
+var defaultLogsSampler = newLogsSampler()
+
+// logsSampler tracks the number of requests per second of the logs
+// groups to decide whether a group request should be logged.
+type logsSampler struct {
+	mux      sync.Mutex
+	counters map[string]*logsSamplerCounter
+}
+
+type logsSamplerCounter struct {
+	second int64
+	total  int
+}
+
+func newLogsSampler() *logsSampler {
+	return &logsSampler{counters: map[string]*logsSamplerCounter{}}
+}
+
+// sample registers a new group request and reports whether it should be logged.
+func (s *logsSampler) sample(group *settings.LogsGroupConfig) bool {
+	if group.SampleRate <= 0 || group.SampleRate >= 1 {
+		return true // no sampling
+	}
+
+	now := time.Now().Unix()
+
+	s.mux.Lock()
+	counter, ok := s.counters[group.Name]
+	if !ok {
+		counter = &logsSamplerCounter{}
+		s.counters[group.Name] = counter
+	}
+	if counter.second != now {
+		counter.second = now
+		counter.total = 0
+	}
+	counter.total++
+	total := counter.total
+	s.mux.Unlock()
+
+	// not under load yet
+	if group.SampleThreshold > 0 && total <= group.SampleThreshold {
+		return true
+	}
+
+	return rand.Float64() < group.SampleRate
+}
@@ -1,32 +1,62 @@
 package apis
 
 import (
+	"context"
 	"fmt"
 	"log/slog"
 	"net"
 	"net/http"
 	"net/url"
+	"regexp"
 	"strings"
 	"time"
 
 	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/dbx"
 	"github.com/pocketbase/pocketbase/core"
 	"github.com/pocketbase/pocketbase/models"
 	"github.com/pocketbase/pocketbase/tokens"
 	"github.com/pocketbase/pocketbase/tools/list"
 	"github.com/pocketbase/pocketbase/tools/routine"
 	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/pocketbase/pocketbase/tools/types"
 	"github.com/spf13/cast"
 )
 
 // Common request context keys used by the middlewares and api handlers.
 const (
-	ContextAdminKey      string = "admin"
-	ContextAuthRecordKey string = "authRecord"
-	ContextCollectionKey string = "collection"
-	ContextExecStartKey  string = "execStart"
+	ContextAdminKey        string = "admin"
+	ContextAuthRecordKey   string = "authRecord"
+	ContextApiKeyKey       string = "apiKey"
+	ContextAuthCookieKey   string = "authCookie"
+	ContextAuthSessionKey  string = "authSession"
+	ContextImpersonatorKey string = "impersonator"
+	ContextCollectionKey   string = "collection"
+	ContextExecStartKey    string = "execStart"
+	ContextRequestIdKey    string = "requestId"
 )
 
+// RequestIdHeader is the name of the request and response header
+// that holds the request correlation id.
+const RequestIdHeader = "X-Request-Id"
+
+// contextApiKeyAdminKey is the request context key of the api key creator admin.
+const contextApiKeyAdminKey = "apiKeyAdmin"
+
+// apiKeyLastUsedThreshold is the min interval between two api key lastUsed updates.
+const apiKeyLastUsedThreshold = time.Minute
+
+// authSessionLastSeenThreshold is the min interval between two auth session lastSeen updates.
+const authSessionLastSeenThreshold = time.Minute
+
+// maxRequestIdLength is the max allowed length of a client provided request id.
+const maxRequestIdLength = 100
+
+var requestIdRegex = regexp.MustCompile(`^[\w\-\.:]+$`)
+
+// requestIdCtxKey is the [context.Context] key of the request id.
+type requestIdCtxKey struct{}
+
 // RequireGuestOnly middleware requires a request to NOT have a valid
 // Authorization header.
 //
@@ -124,6 +154,50 @@ func RequireAdminAuth() echo.MiddlewareFunc {
 	}
 }
 
+// RequireAdminOrApiKeyAuth middleware requires a request to have
+// a valid admin Authorization header or an api key whose scopes
+// allow the specified action on the loaded context collection.
+//
+// The api key requests are performed on behalf of the key creator admin.
+func RequireAdminOrApiKeyAuth(action string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return LoadApiKeyScopeContext(action)(func(c echo.Context) error {
+			admin, _ := c.Get(ContextAdminKey).(*models.Admin)
+			if admin == nil {
+				return NewUnauthorizedError("The request requires valid admin authorization token or api key to be set.", nil)
+			}
+
+			return next(c)
+		})
+	}
+}
+
+// LoadApiKeyScopeContext middleware loads the creator admin of the
+// request api key (if any) into the request context if the key scopes
+// allow the specified action on the loaded context collection.
+//
+// Requests with an api key that is not allowed to perform
+// the action are rejected with 403.
+func LoadApiKeyScopeContext(action string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			apiKey, _ := c.Get(ContextApiKeyKey).(*models.ApiKey)
+			if apiKey == nil {
+				return next(c)
+			}
+
+			collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+			if !apiKey.Allows(collection, action) {
+				return NewForbiddenError("The api key is not allowed to perform this action.", nil)
+			}
+
+			c.Set(ContextAdminKey, c.Get(contextApiKeyAdminKey))
+
+			return next(c)
+		}
+	}
+}
+
 // RequireAdminAuthOnlyIfAny middleware requires a request to have
 // a valid admin Authorization header ONLY if the application has
 // at least 1 existing Admin model.
@@ -213,11 +287,24 @@ func RequireAdminOrOwnerAuth(ownerIdParam string) echo.MiddlewareFunc {
 // and loads the token related record or admin instance into the
 // request's context.
 //
+// If the auth cookie mode is enabled and the Authorization header is
+// missing, the token is loaded from the auth cookie instead.
+//
 // This middleware is expected to be already registered by default for all routes.
 func LoadAuthContext(app core.App) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			token := c.Request().Header.Get("Authorization")
+
+			// fallback to the optional httpOnly auth cookie
+			var isCookieAuth bool
+			if token == "" && app.Settings().AuthCookie.Enabled {
+				if cookie, err := c.Cookie(app.Settings().AuthCookie.Name); err == nil {
+					token = cookie.Value
+					isCookieAuth = true
+				}
+			}
+
 			if token == "" {
 				return next(c)
 			}
@@ -226,6 +313,12 @@ func LoadAuthContext(app core.App) echo.MiddlewareFunc {
 			// compatibility with the defaults of some HTTP clients
 			token = strings.TrimPrefix(token, "Bearer ")
 
+			if strings.HasPrefix(token, models.ApiKeyPrefix) {
+				loadApiKey(app, c, token)
+
+				return next(c)
+			}
+
 			claims, _ := security.ParseUnverifiedJWT(token)
 			tokenType := cast.ToString(claims["type"])
 
@@ -234,17 +327,32 @@ func LoadAuthContext(app core.App) echo.MiddlewareFunc {
 				admin, err := app.Dao().FindAdminByToken(
 					token,
 					app.Settings().AdminAuthToken.Secret,
+					app.Settings().AdminAuthToken.PreviousSecrets()...,
 				)
 				if err == nil && admin != nil {
 					c.Set(ContextAdminKey, admin)
+					c.Set(ContextAuthCookieKey, isCookieAuth)
 				}
 			case tokens.TypeAuthRecord:
 				record, err := app.Dao().FindAuthRecordByToken(
 					token,
 					app.Settings().RecordAuthToken.Secret,
+					app.Settings().RecordAuthToken.PreviousSecrets()...,
 				)
-				if err == nil && record != nil {
+				// the soft deleted auth records are not allowed to authenticate
+				if err == nil && record != nil && !record.IsSoftDeleted() {
+					// the session bound tokens are valid only while their session is not revoked
+					if sid := cast.ToString(claims["sid"]); sid != "" && !loadAuthSession(app, c, record, sid) {
+						break
+					}
+
 					c.Set(ContextAuthRecordKey, record)
+					c.Set(ContextAuthCookieKey, isCookieAuth)
+
+					// the id of the admin that issued the impersonation token
+					if impersonator := cast.ToString(claims["impersonator"]); impersonator != "" {
+						c.Set(ContextImpersonatorKey, impersonator)
+					}
 				}
 			}
 
@@ -253,6 +361,73 @@ func LoadAuthContext(app core.App) echo.MiddlewareFunc {
 	}
 }
 
+// loadApiKey loads the valid and not expired api key matching
+// the provided plain key into the request context.
+//
+// Note that the api key creator admin is loaded into the request
+// context only after checking the key scopes (see [LoadApiKeyScopeContext()]).
+func loadApiKey(app core.App, c echo.Context, key string) {
+	apiKey, err := app.Dao().FindApiKeyByKey(key)
+	if err != nil || apiKey == nil || apiKey.IsExpired() {
+		return
+	}
+
+	admin, err := app.Dao().FindAdminById(apiKey.AdminId)
+	if err != nil || admin == nil {
+		return
+	}
+
+	c.Set(ContextApiKeyKey, apiKey)
+	c.Set(contextApiKeyAdminKey, admin)
+
+	// throttle the lastUsed updates
+	now := time.Now()
+	if apiKey.LastUsed.Time().Add(apiKeyLastUsedThreshold).Before(now) {
+		apiKey.LastUsed, _ = types.ParseDateTime(now)
+
+		_, err := app.Dao().DB().Update(
+			apiKey.TableName(),
+			dbx.Params{"lastUsed": apiKey.LastUsed.String()},
+			dbx.HashExp{"id": apiKey.Id},
+		).Execute()
+		if err != nil {
+			app.Logger().Debug("Failed to update the api key lastUsed date", slog.String("error", err.Error()))
+		}
+	}
+}
+
+// loadAuthSession loads the tracked session with the provided id
+// of the specified auth record into the request context.
+//
+// Returns false if the session is missing (aka. it was revoked).
+func loadAuthSession(app core.App, c echo.Context, record *models.Record, sessionId string) bool {
+	session, err := app.Dao().FindAuthSessionById(sessionId)
+	if err != nil || session == nil ||
+		session.RecordId != record.Id ||
+		session.CollectionId != record.Collection().Id {
+		return false
+	}
+
+	c.Set(ContextAuthSessionKey, session)
+
+	// throttle the lastSeen updates
+	now := time.Now()
+	if session.LastSeen.Time().Add(authSessionLastSeenThreshold).Before(now) {
+		session.LastSeen, _ = types.ParseDateTime(now)
+
+		_, err := app.Dao().DB().Update(
+			session.TableName(),
+			dbx.Params{"lastSeen": session.LastSeen.String()},
+			dbx.HashExp{"id": session.Id},
+		).Execute()
+		if err != nil {
+			app.Logger().Debug("Failed to update the auth session lastSeen date", slog.String("error", err.Error()))
+		}
+	}
+
+	return true
+}
+
 // LoadCollectionContext middleware finds the collection with related
 // path identifier and loads it into the request context.
 //
@@ -281,11 +456,33 @@ func LoadCollectionContext(app core.App, optCollectionTypes ...string) echo.Midd
 // ActivityLogger middleware takes care to save the request information
 // into the logs database.
 //
+// The request path could be further configured with a logs group
+// (see app.Settings().Logs.Groups) to exclude, sample or store the
+// request body of the related requests.
+//
 // The middleware does nothing if the app logs retention period is zero
 // (aka. app.Settings().Logs.MaxDays = 0).
 func ActivityLogger(app core.App) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
+			logsSettings := app.Settings().Logs
+
+			// capture the request body before the handler consumes it
+			if logsSettings.MaxDays > 0 {
+				group := logsSettings.FindGroup(c.Request().URL.Path)
+				if group != nil && group.LogBody && !group.Disabled {
+					body, truncated, err := peekRequestBody(c.Request(), group.MaxBodySize)
+					if err != nil {
+						return NewBadRequestError("Failed to read the request body.", err)
+					}
+
+					c.Set(contextLogsBodyKey, &loggedRequestBody{
+						body:      scrubRecordedBody(c.Request().Header.Get(echo.HeaderContentType), body),
+						truncated: truncated,
+					})
+				}
+			}
+
 			if err := next(c); err != nil {
 				return err
 			}
@@ -312,11 +509,16 @@ func logRequest(app core.App, c echo.Context, err *ApiError) {
 		attrs = append(attrs, slog.Float64("execTime", float64(time.Since(started))/float64(time.Millisecond)))
 	}
 
+	logsSettings := app.Settings().Logs
+
 	httpRequest := c.Request()
 	httpResponse := c.Response()
 	method := strings.ToUpper(httpRequest.Method)
 	status := httpResponse.Status
 	requestUri := httpRequest.URL.RequestURI()
+	if logsSettings.StripQueryParams {
+		requestUri = httpRequest.URL.EscapedPath()
+	}
 
 	// parse the request error
 	if err != nil {
@@ -328,29 +530,64 @@ func logRequest(app core.App, c echo.Context, err *ApiError) {
 		)
 	}
 
+	if group := logsSettings.FindGroup(httpRequest.URL.Path); group != nil {
+		if group.Disabled {
+			return
+		}
+
+		// the failed requests are always logged
+		if status < 400 && !defaultLogsSampler.sample(group) {
+			return
+		}
+
+		attrs = append(attrs, slog.String("category", group.Name))
+
+		if logged, _ := c.Get(contextLogsBodyKey).(*loggedRequestBody); logged != nil {
+			attrs = append(
+				attrs,
+				slog.String("body", logged.body),
+				slog.Bool("bodyTruncated", logged.truncated),
+			)
+		}
+	}
+
 	requestAuth := models.RequestAuthGuest
-	if c.Get(ContextAuthRecordKey) != nil {
+	if apiKey, _ := c.Get(ContextApiKeyKey).(*models.ApiKey); apiKey != nil {
+		requestAuth = models.RequestAuthApiKey
+		attrs = append(attrs, slog.String("apiKeyId", apiKey.Id))
+	} else if c.Get(ContextAuthRecordKey) != nil {
 		requestAuth = models.RequestAuthRecord
 	} else if c.Get(ContextAdminKey) != nil {
 		requestAuth = models.RequestAuthAdmin
 	}
 
+	if requestId := RequestId(c); requestId != "" {
+		attrs = append(attrs, slog.String("requestId", requestId))
+	}
+
 	attrs = append(
 		attrs,
 		slog.String("url", requestUri),
 		slog.String("method", method),
 		slog.Int("status", status),
 		slog.String("auth", requestAuth),
-		slog.String("referer", httpRequest.Referer()),
-		slog.String("userAgent", httpRequest.UserAgent()),
 	)
 
-	if app.Settings().Logs.LogIp {
+	if !logsSettings.StripReferer {
+		attrs = append(attrs, slog.String("referer", httpRequest.Referer()))
+	}
+
+	if !logsSettings.StripUserAgent {
+		attrs = append(attrs, slog.String("userAgent", httpRequest.UserAgent()))
+	}
+
+	if logsSettings.LogIp {
 		ip, _, _ := net.SplitHostPort(httpRequest.RemoteAddr)
+		secret := app.Settings().RecordAuthToken.Secret
 		attrs = append(
 			attrs,
-			slog.String("userIp", realUserIp(httpRequest, ip)),
-			slog.String("remoteIp", ip),
+			slog.String("userIp", logsSettings.AnonymizeIp(realUserIp(httpRequest, ip), secret)),
+			slog.String("remoteIp", logsSettings.AnonymizeIp(ip, secret)),
 		)
 	}
 
@@ -407,6 +644,10 @@ func realUserIp(r *http.Request, fallbackIp string) string {
 func eagerRequestInfoCache(app core.App) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
+			// resolve the request geolocation (if configured)
+			// so that it could be used as part of the request info
+			RequestGeo(app, c)
+
 			switch c.Request().Method {
 			// currently we are eagerly caching only the requests with body
 			case "POST", "PUT", "PATCH", "DELETE":
@@ -417,3 +658,91 @@ func eagerRequestInfoCache(app core.App) echo.MiddlewareFunc {
 		}
 	}
 }
+
+// stripBasePath removes the configured settings base path prefix (if any)
+// from the request url path so that the app could be deployed behind
+// a path based reverse proxy that doesn't strip the prefix on its own.
+//
+// Requests without the base path prefix are left untouched.
+func stripBasePath(app core.App) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			basePath := app.Settings().Meta.NormalizedBasePath()
+			if basePath == "" {
+				return next(c)
+			}
+
+			r := c.Request()
+
+			if stripped, ok := trimPathPrefix(r.URL.Path, basePath); ok {
+				r.URL.Path = stripped
+
+				if r.URL.RawPath != "" {
+					r.URL.RawPath, _ = trimPathPrefix(r.URL.RawPath, basePath)
+				}
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// trimPathPrefix trims the prefix from the provided url path
+// only if it matches a whole path segment (eg. "/pb" but not "/pbx").
+func trimPathPrefix(urlPath string, prefix string) (string, bool) {
+	if urlPath == prefix {
+		return "/", true
+	}
+
+	if strings.HasPrefix(urlPath, prefix+"/") {
+		return urlPath[len(prefix):], true
+	}
+
+	return urlPath, false
+}
+
+// requestIdMiddleware assigns a correlation id to each request.
+//
+// A valid client (or reverse proxy) provided RequestIdHeader value is reused,
+// otherwise a new random id is generated.
+//
+// The id is stored in the echo context (see [RequestId]), in the request
+// [context.Context] (see [RequestIdFromContext]) and is sent back
+// with the RequestIdHeader response header.
+func requestIdMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			requestId := c.Request().Header.Get(RequestIdHeader)
+			if len(requestId) > maxRequestIdLength || !requestIdRegex.MatchString(requestId) {
+				requestId = security.RandomString(20)
+			}
+
+			c.Set(ContextRequestIdKey, requestId)
+			c.SetRequest(c.Request().WithContext(
+				context.WithValue(c.Request().Context(), requestIdCtxKey{}, requestId),
+			))
+			c.Response().Header().Set(RequestIdHeader, requestId)
+
+			return next(c)
+		}
+	}
+}
+
+// RequestId returns the correlation id of the current request
+// (or empty string if the request id middleware wasn't applied).
+func RequestId(c echo.Context) string {
+	requestId, _ := c.Get(ContextRequestIdKey).(string)
+
+	return requestId
+}
+
+// RequestIdFromContext returns the request correlation id stored
+// in the provided context (or empty string if missing).
+//
+// It could be used in places where only the request [context.Context]
+// is available (eg. e.HttpContext.Request().Context()).
+func RequestIdFromContext(ctx context.Context) string {
+	requestId, _ := ctx.Value(requestIdCtxKey{}).(string)
+
+	return requestId
+}
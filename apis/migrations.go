@@ -0,0 +1,37 @@
+package apis
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/migrations"
+	"github.com/pocketbase/pocketbase/tools/migrate"
+)
+
+// bindMigrationsApi registers the migrations api endpoints and the corresponding handlers.
+func bindMigrationsApi(app core.App, rg *echo.Group) {
+	api := migrationsApi{app: app}
+
+	subGroup := rg.Group("/migrations", ActivityLogger(app), RequireAdminAuth())
+	subGroup.POST("/up", api.up)
+}
+
+type migrationsApi struct {
+	app core.App
+}
+
+// up applies all pending app migrations and returns the list with the applied files.
+func (api *migrationsApi) up(c echo.Context) error {
+	runner, err := migrate.NewRunner(api.app.DB(), migrations.AppMigrations)
+	if err != nil {
+		return NewBadRequestError("Failed to initialize the migrations runner.", err)
+	}
+
+	applied, err := runner.Up()
+	if err != nil {
+		return NewBadRequestError("Failed to apply the migrations.", err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{"applied": applied})
+}
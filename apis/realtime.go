@@ -27,7 +27,7 @@ func bindRealtimeApi(app core.App, rg *echo.Group) {
 	api := realtimeApi{app: app}
 
 	subGroup := rg.Group("/realtime")
-	subGroup.GET("", api.connect)
+	subGroup.GET("", api.connect, SkipCompression())
 	subGroup.POST("", api.setSubscriptions, ActivityLogger(app))
 
 	api.bindEvents()
@@ -307,6 +307,11 @@ func (api *realtimeApi) bindEvents() {
 		return nil
 	})
 
+	// broadcast the records comments changes
+	api.app.OnModelAfterCreate().PreAdd(api.commentEventHandler("create"))
+	api.app.OnModelAfterUpdate().PreAdd(api.commentEventHandler("update"))
+	api.app.OnModelAfterDelete().Add(api.commentEventHandler("delete"))
+
 	api.app.OnModelBeforeDelete().Add(func(e *core.ModelEvent) error {
 		if record := api.resolveRecord(e.Model); record != nil {
 			if err := api.broadcastRecord("delete", record, true); err != nil {
@@ -420,6 +425,15 @@ func (api *realtimeApi) broadcastRecord(action string, record *models.Record, dr
 					continue
 				}
 
+				if err := api.app.Dao().DecryptRecords([]*models.Record{cleanRecord}); err != nil {
+					api.app.Logger().Debug(
+						"[broadcastRecord] decrypt error",
+						slog.String("id", cleanRecord.Id),
+						slog.String("collectionName", cleanRecord.Collection().Name),
+						slog.String("error", err.Error()),
+					)
+				}
+
 				rawExpand := cast.ToString(options.Query[expandQueryParam])
 				if rawExpand != "" {
 					expandErrs := api.app.Dao().ExpandRecord(cleanRecord, strings.Split(rawExpand, ","), expandFetch(api.app.Dao(), requestInfo))
@@ -435,6 +449,18 @@ func (api *realtimeApi) broadcastRecord(action string, record *models.Record, dr
 					}
 				}
 
+				rawProfile := cast.ToString(options.Query[profileQueryParam])
+				if err := applyRecordsProfile(api.app.Dao(), []*models.Record{cleanRecord}, requestInfo, rawProfile); err != nil {
+					api.app.Logger().Debug(
+						"[broadcastRecord] profile error",
+						slog.String("id", cleanRecord.Id),
+						slog.String("collectionName", cleanRecord.Collection().Name),
+						slog.String("sub", sub),
+						slog.String("profile", rawProfile),
+						slog.String("error", err.Error()),
+					)
+				}
+
 				// ignore the auth record email visibility checks
 				// for auth owner, admin or manager
 				if collection.IsAuth() {
@@ -502,6 +528,32 @@ func (api *realtimeApi) broadcastRecord(action string, record *models.Record, dr
 		}
 	}
 
+	return api.broadcastFeedRecords(action, record, dryCache)
+}
+
+// broadcastFeedRecords broadcasts the record change to the subscribers
+// of the feed views that have the record collection as source.
+func (api *realtimeApi) broadcastFeedRecords(action string, record *models.Record, dryCache bool) error {
+	if record.Collection().IsView() {
+		return nil // views cannot be feed sources
+	}
+
+	feeds, err := api.app.Dao().FindFeedCollectionsBySource(record.Collection())
+	if err != nil {
+		return err
+	}
+
+	for _, feed := range feeds {
+		feedRecord, err := api.app.Dao().FindRecordById(feed.Id, record.Id)
+		if err != nil {
+			continue
+		}
+
+		if err := api.broadcastRecord(action, feedRecord, dryCache); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -531,6 +583,93 @@ func (api *realtimeApi) broadcastDryCachedRecord(action string, record *models.R
 	return nil
 }
 
+func (api *realtimeApi) commentEventHandler(action string) func(e *core.ModelEvent) error {
+	return func(e *core.ModelEvent) error {
+		comment, ok := e.Model.(*models.Comment)
+		if !ok || comment == nil {
+			return nil
+		}
+
+		if err := api.broadcastComment(action, comment); err != nil {
+			api.app.Logger().Debug(
+				"Failed to broadcast comment "+action,
+				slog.String("id", comment.Id),
+				slog.String("recordId", comment.RecordId),
+				slog.String("collectionId", comment.CollectionId),
+				slog.String("error", err.Error()),
+			)
+		}
+
+		return nil
+	}
+}
+
+// commentData represents the broadcasted comment subscription message data.
+type commentData struct {
+	Comment *models.Comment `json:"comment"`
+	Action  string          `json:"action"`
+}
+
+// broadcastComment sends the comment change to all clients subscribed
+// to the "COLLECTION/RECORD_ID/comments" topic that satisfy the
+// commented record collection view rule.
+func (api *realtimeApi) broadcastComment(action string, comment *models.Comment) error {
+	clients := api.app.SubscriptionsBroker().Clients()
+	if len(clients) == 0 {
+		return nil // no subscribers
+	}
+
+	collection, err := api.app.Dao().FindCollectionByNameOrId(comment.CollectionId)
+	if err != nil {
+		return err
+	}
+
+	record, err := api.app.Dao().FindRecordById(collection.Id, comment.RecordId)
+	if err != nil {
+		return err
+	}
+
+	dataBytes, err := json.Marshal(&commentData{Action: action, Comment: comment})
+	if err != nil {
+		return err
+	}
+
+	prefixes := []string{
+		collection.Name + "/" + record.Id + "/comments?",
+		collection.Id + "/" + record.Id + "/comments?",
+	}
+
+	for _, client := range clients {
+		client := client
+
+		for sub, options := range client.Subscriptions(prefixes...) {
+			// mock request data
+			requestInfo := &models.RequestInfo{
+				Method:  "GET",
+				Query:   options.Query,
+				Headers: options.Headers,
+			}
+			requestInfo.Admin, _ = client.Get(ContextAdminKey).(*models.Admin)
+			requestInfo.AuthRecord, _ = client.Get(ContextAuthRecordKey).(*models.Record)
+
+			if ok, _ := api.app.Dao().CanAccessRecord(record, requestInfo, collection.ViewRule); !ok {
+				continue
+			}
+
+			msg := subscriptions.Message{
+				Name: sub,
+				Data: dataBytes,
+			}
+
+			routine.FireAndForget(func() {
+				client.Send(msg)
+			})
+		}
+	}
+
+	return nil
+}
+
 type getter interface {
 	Get(string) any
 }
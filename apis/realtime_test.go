@@ -16,6 +16,7 @@ import (
 	"github.com/pocketbase/pocketbase/tests"
 	"github.com/pocketbase/pocketbase/tools/hook"
 	"github.com/pocketbase/pocketbase/tools/subscriptions"
+	"github.com/pocketbase/pocketbase/tools/types"
 )
 
 func TestRealtimeConnect(t *testing.T) {
@@ -451,3 +452,53 @@ func TestRealtimeCustomAuthModelUpdateEvent(t *testing.T) {
 		t.Fatalf("Expected authRecord with email %q, got %q", customUser.Email, clientAuthRecord.Email())
 	}
 }
+
+func TestRealtimeFeedRecordEvent(t *testing.T) {
+	testApp, _ := tests.NewTestApp()
+	defer testApp.Cleanup()
+
+	apis.InitApi(testApp)
+
+	feed := &models.Collection{
+		Name:     "feed",
+		Type:     models.CollectionTypeView,
+		ListRule: types.Pointer(""),
+	}
+	feed.SetOptions(models.CollectionViewOptions{
+		Feed: []models.CollectionFeedSource{
+			{Collection: "demo2", Type: "post", Title: "title"},
+		},
+	})
+	if err := testApp.Dao().SaveCollection(feed); err != nil {
+		t.Fatal(err)
+	}
+
+	record, err := testApp.Dao().FindRecordById("demo2", "llvuca81nly1qls")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := subscriptions.NewDefaultClient()
+	client.Subscribe("feed/*")
+	testApp.SubscriptionsBroker().Register(client)
+
+	e := new(core.ModelEvent)
+	e.Dao = testApp.Dao()
+	e.Model = record
+	testApp.OnModelAfterUpdate().Trigger(e)
+
+	select {
+	case msg := <-client.Channel():
+		if msg.Name != "feed/*" {
+			t.Fatalf("Expected feed/* message, got %q", msg.Name)
+		}
+
+		for _, expected := range []string{`"action":"update"`, `"id":"llvuca81nly1qls"`, `"type":"post"`} {
+			if !strings.Contains(string(msg.Data), expected) {
+				t.Fatalf("Expected %s in the message data, got %s", expected, msg.Data)
+			}
+		}
+	case <-time.After(1 * time.Second):
+		t.Fatal("Expected feed record broadcast message")
+	}
+}
@@ -0,0 +1,145 @@
+package apis
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/resolvers"
+	"github.com/pocketbase/pocketbase/tools/search"
+	"github.com/spf13/cast"
+)
+
+// bindRecordAggregateApi registers the collection records aggregation
+// api endpoint and the corresponding handler.
+func bindRecordAggregateApi(app core.App, rg *echo.Group) {
+	api := recordAggregateApi{app: app}
+
+	subGroup := rg.Group(
+		"/collections/:collection",
+		ActivityLogger(app),
+		LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth),
+	)
+	subGroup.GET("/aggregate", api.aggregate)
+}
+
+type recordAggregateApi struct {
+	app core.App
+}
+
+// recordsAggregateResult defines the records aggregation response.
+type recordsAggregateResult struct {
+	Items []*daos.RecordsAggregateItem `json:"items"`
+}
+
+// aggregate groups the collection records matching the list rule
+// and the optional filter and returns the calculated group aggregates.
+func (api *recordAggregateApi) aggregate(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("", "Missing collection context.")
+	}
+
+	requestInfo := RequestInfo(c)
+
+	// forbid users and guests to query special filter fields
+	if err := checkForAdminOnlyRuleFields(requestInfo); err != nil {
+		return err
+	}
+
+	if requestInfo.Admin == nil && collection.ListRule == nil {
+		// only admins can access if the rule is nil
+		return NewForbiddenError("Only admins can perform this action.", nil).WithErrorCode(ErrorCodeRuleForbidden)
+	}
+
+	filter := c.QueryParam(search.FilterQueryParam)
+
+	if requestInfo.Admin == nil {
+		if err := checkListFilterComplexity(collection, filter); err != nil {
+			return err
+		}
+	}
+
+	options := daos.RecordsAggregateOptions{
+		GroupBy: splitQueryList(c.QueryParam("groupBy")),
+		Sum:     splitQueryList(c.QueryParam(daos.AggregateSum)),
+		Avg:     splitQueryList(c.QueryParam(daos.AggregateAvg)),
+		Min:     splitQueryList(c.QueryParam(daos.AggregateMin)),
+		Max:     splitQueryList(c.QueryParam(daos.AggregateMax)),
+		Limit:   cast.ToInt(c.QueryParam("limit")),
+	}
+
+	if options.Limit > search.MaxPerPage {
+		options.Limit = search.MaxPerPage
+	}
+
+	for _, sortField := range search.ParseSortFromString(c.QueryParam(search.SortQueryParam)) {
+		if sortField.Name == "" {
+			continue
+		}
+		options.Sort = append(options.Sort, daos.AggregateSortField{
+			Name: sortField.Name,
+			Desc: sortField.Direction == search.SortDesc,
+		})
+	}
+
+	filterFunc := func(q *dbx.SelectQuery) error {
+		resolver := resolvers.NewRecordFieldResolver(
+			api.app.Dao(),
+			collection,
+			requestInfo,
+			// hidden fields are searchable only by admins
+			requestInfo.Admin != nil,
+		)
+
+		exprs := []dbx.Expression{}
+
+		if requestInfo.Admin == nil && collection.ListRule != nil && *collection.ListRule != "" {
+			expr, err := search.FilterData(*collection.ListRule).BuildExpr(resolver)
+			if err != nil {
+				return err
+			}
+			exprs = append(exprs, expr)
+		}
+
+		if filter != "" {
+			expr, err := search.FilterData(filter).BuildExpr(resolver)
+			if err != nil {
+				return err
+			}
+			exprs = append(exprs, expr)
+		}
+
+		if len(exprs) > 0 {
+			resolver.UpdateQuery(q)
+			q.AndWhere(dbx.And(exprs...))
+		}
+
+		return nil
+	}
+
+	items, err := api.app.Dao().AggregateRecords(collection, options, filterFunc)
+	if err != nil {
+		return NewBadRequestError("Failed to aggregate the records.", err)
+	}
+
+	return c.JSON(http.StatusOK, recordsAggregateResult{Items: items})
+}
+
+// splitQueryList splits a comma separated query parameter list
+// (the empty items are skipped).
+func splitQueryList(raw string) []string {
+	result := []string{}
+
+	for _, name := range strings.Split(raw, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			result = append(result, name)
+		}
+	}
+
+	return result
+}
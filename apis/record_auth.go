@@ -41,6 +41,9 @@ func bindRecordAuthApi(app core.App, rg *echo.Group) {
 	subGroup.POST("/auth-refresh", api.authRefresh, RequireSameContextRecordAuth())
 	subGroup.POST("/auth-with-oauth2", api.authWithOAuth2)
 	subGroup.POST("/auth-with-password", api.authWithPassword)
+	subGroup.POST("/auth-with-ldap", api.authWithLdap)
+	subGroup.POST("/request-otp", api.requestOtp)
+	subGroup.POST("/auth-with-otp", api.authWithOtp)
 	subGroup.POST("/request-password-reset", api.requestPasswordReset)
 	subGroup.POST("/confirm-password-reset", api.confirmPasswordReset)
 	subGroup.POST("/request-verification", api.requestVerification)
@@ -49,6 +52,7 @@ func bindRecordAuthApi(app core.App, rg *echo.Group) {
 	subGroup.POST("/confirm-email-change", api.confirmEmailChange)
 	subGroup.GET("/records/:id/external-auths", api.listExternalAuths, RequireAdminOrOwnerAuth("id"))
 	subGroup.DELETE("/records/:id/external-auths/:provider", api.unlinkExternalAuth, RequireAdminOrOwnerAuth("id"))
+	subGroup.POST("/records/:id/merge", api.merge, RequireAdminAuth())
 }
 
 type recordAuthApi struct {
@@ -61,6 +65,10 @@ func (api *recordAuthApi) authRefresh(c echo.Context) error {
 		return NewNotFoundError("Missing auth record context.", nil)
 	}
 
+	if impersonator, _ := c.Get(ContextImpersonatorKey).(string); impersonator != "" {
+		return NewForbiddenError("The impersonation tokens cannot be refreshed.", nil)
+	}
+
 	event := new(core.RecordAuthRefreshEvent)
 	event.HttpContext = c
 	event.Collection = record.Collection()
@@ -92,16 +100,32 @@ func (api *recordAuthApi) authMethods(c echo.Context) error {
 	}
 
 	authOptions := collection.AuthOptions()
+	ldapConfig := api.app.Settings().Ldap
+	samlConfig := api.app.Settings().Saml
 
 	result := struct {
 		AuthProviders    []providerInfo `json:"authProviders"`
 		UsernamePassword bool           `json:"usernamePassword"`
 		EmailPassword    bool           `json:"emailPassword"`
 		OnlyVerified     bool           `json:"onlyVerified"`
+		Passkey          bool           `json:"passkey"`
+		Ldap             bool           `json:"ldap"`
+		Saml             bool           `json:"saml"`
+		Otp              bool           `json:"otp"`
+		Device           bool           `json:"device"`
+		Wallet           bool           `json:"wallet"`
+		RefreshTokens    bool           `json:"refreshTokens"`
 	}{
 		UsernamePassword: authOptions.AllowUsernameAuth,
 		EmailPassword:    authOptions.AllowEmailAuth,
 		OnlyVerified:     authOptions.OnlyVerified,
+		Passkey:          authOptions.AllowPasskeyAuth,
+		Ldap:             ldapConfig.Enabled && ldapConfig.IsCollection(collection.Id, collection.Name),
+		Saml:             samlConfig.Enabled && samlConfig.IsCollection(collection.Id, collection.Name),
+		Otp:              authOptions.AllowOtpAuth,
+		Device:           authOptions.AllowDeviceAuth,
+		Wallet:           authOptions.AllowWalletAuth,
+		RefreshTokens:    authOptions.AllowRefreshTokens,
 		AuthProviders:    []providerInfo{},
 	}
 
@@ -109,19 +133,10 @@ func (api *recordAuthApi) authMethods(c echo.Context) error {
 		return c.JSON(http.StatusOK, result)
 	}
 
-	nameConfigMap := api.app.Settings().NamedAuthProviderConfigs()
-	for name, config := range nameConfigMap {
-		if !config.Enabled {
-			continue
-		}
-
-		provider, err := auth.NewProviderByName(name)
+	settings := api.app.Settings()
+	for _, name := range settings.EnabledAuthProviderNames() {
+		provider, err := settings.NewAuthProvider(name)
 		if err != nil {
-			api.app.Logger().Debug("Missing or invalid provier name", slog.String("name", name))
-			continue // skip provider
-		}
-
-		if err := config.SetupProvider(provider); err != nil {
 			api.app.Logger().Debug(
 				"Failed to setup provider",
 				slog.String("name", name),
@@ -287,6 +302,11 @@ func (api *recordAuthApi) authWithPassword(c echo.Context) error {
 		return NewBadRequestError("An error occurred while loading the submitted data.", readErr)
 	}
 
+	lockoutKeys := passwordAuthLockoutKeys(c, collection.Id, form.Identity)
+	if err := checkAuthLockout(api.app, lockoutKeys); err != nil {
+		return err
+	}
+
 	event := new(core.RecordAuthWithPasswordEvent)
 	event.HttpContext = c
 	event.Collection = collection
@@ -299,9 +319,29 @@ func (api *recordAuthApi) authWithPassword(c echo.Context) error {
 
 			return api.app.OnRecordBeforeAuthWithPasswordRequest().Trigger(event, func(e *core.RecordAuthWithPasswordEvent) error {
 				if err := next(e.Record); err != nil {
+					if errors.Is(err, forms.ErrMfaSetupRequired) {
+						return mfaSetupRequiredResponse(api.app, e.HttpContext, e.Record)
+					}
+
+					failure := &core.SecurityEvent{
+						Type:      core.SecurityEventAuthFailure,
+						ActorType: core.SecurityActorAuthRecord,
+						Data: map[string]any{
+							"identity":   e.Identity,
+							"collection": e.Collection.Name,
+						},
+					}
+					if e.Record != nil {
+						failure.ActorId = e.Record.Id
+					}
+					emitSecurityEvent(api.app, e.HttpContext, failure)
+					api.app.AuthLockouts().RegisterFailure(lockoutKeys...)
+
 					return NewBadRequestError("Failed to authenticate.", err)
 				}
 
+				api.app.AuthLockouts().Reset(lockoutKeys[0])
+
 				return api.app.OnRecordAfterAuthWithPasswordRequest().Trigger(event, func(e *core.RecordAuthWithPasswordEvent) error {
 					return RecordAuthResponse(api.app, e.HttpContext, e.Record, nil)
 				})
@@ -312,6 +352,119 @@ func (api *recordAuthApi) authWithPassword(c echo.Context) error {
 	return submitErr
 }
 
+func (api *recordAuthApi) authWithLdap(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("Missing collection context.", nil)
+	}
+
+	ldapConfig := api.app.Settings().Ldap
+	if !ldapConfig.Enabled || !ldapConfig.IsCollection(collection.Id, collection.Name) {
+		return NewBadRequestError("The collection is not configured to allow LDAP authentication.", nil)
+	}
+
+	form := forms.NewRecordLdapLogin(api.app, collection)
+	if readErr := c.Bind(form); readErr != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", readErr)
+	}
+
+	record, submitErr := form.Submit()
+	if submitErr != nil {
+		failure := &core.SecurityEvent{
+			Type:      core.SecurityEventAuthFailure,
+			ActorType: core.SecurityActorAuthRecord,
+			Data: map[string]any{
+				"method":     "ldap",
+				"identity":   form.Identity,
+				"collection": collection.Name,
+			},
+		}
+		emitSecurityEvent(api.app, c, failure)
+
+		return NewBadRequestError("Failed to authenticate.", submitErr)
+	}
+
+	return RecordAuthResponse(api.app, c, record, nil)
+}
+
+func (api *recordAuthApi) requestOtp(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("Missing collection context.", nil)
+	}
+
+	if !collection.AuthOptions().AllowOtpAuth {
+		return NewBadRequestError("The collection is not configured to allow OTP authentication.", nil)
+	}
+
+	form := forms.NewRecordOtpRequest(api.app, collection)
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	if err := form.Validate(); err != nil {
+		return NewBadRequestError("An error occurred while validating the form.", err)
+	}
+
+	otp, submitErr := form.Submit()
+	if submitErr != nil {
+		api.app.Logger().Debug(
+			"Failed to send one-time password",
+			slog.String("error", submitErr.Error()),
+		)
+
+		// return a random otp id as a measure against emails enumeration
+		otp = &models.Otp{}
+		otp.RefreshId()
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"otpId": otp.Id})
+}
+
+func (api *recordAuthApi) authWithOtp(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("Missing collection context.", nil)
+	}
+
+	if !collection.AuthOptions().AllowOtpAuth {
+		return NewBadRequestError("The collection is not configured to allow OTP authentication.", nil)
+	}
+
+	form := forms.NewRecordOtpLogin(api.app, collection)
+	if readErr := c.Bind(form); readErr != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", readErr)
+	}
+
+	var authRecord *models.Record
+
+	record, submitErr := form.Submit(func(next forms.InterceptorNextFunc[*models.Record]) forms.InterceptorNextFunc[*models.Record] {
+		return func(record *models.Record) error {
+			authRecord = record
+			return next(record)
+		}
+	})
+	if submitErr != nil {
+		if errors.Is(submitErr, forms.ErrMfaSetupRequired) && authRecord != nil {
+			return mfaSetupRequiredResponse(api.app, c, authRecord)
+		}
+
+		failure := &core.SecurityEvent{
+			Type:      core.SecurityEventAuthFailure,
+			ActorType: core.SecurityActorAuthRecord,
+			Data: map[string]any{
+				"method":     "otp",
+				"collection": collection.Name,
+			},
+		}
+		emitSecurityEvent(api.app, c, failure)
+
+		return NewBadRequestError("Failed to authenticate.", submitErr)
+	}
+
+	return RecordAuthResponse(api.app, c, record, nil)
+}
+
 func (api *recordAuthApi) requestPasswordReset(c echo.Context) error {
 	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
 	if collection == nil {
@@ -688,5 +841,51 @@ func (api *recordAuthApi) oauth2SubscriptionRedirect(c echo.Context) error {
 
 	client.Send(msg)
 
-	return c.Redirect(http.StatusTemporaryRedirect, "../_/#/auth/oauth2-redirect")
+	adminUI := api.app.Settings().AdminUI
+	if adminUI.Disabled {
+		// the admin UI redirect page is not available -> just close the OAuth2 popup
+		return c.HTML(http.StatusOK, oauth2RedirectClosePage)
+	}
+
+	return c.Redirect(http.StatusTemporaryRedirect, ".."+adminUI.TrailedPath()+"#/auth/oauth2-redirect")
+}
+
+// oauth2RedirectClosePage is a minimal page that closes the OAuth2 popup window
+// (used when the admin UI is disabled).
+const oauth2RedirectClosePage = `<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>OAuth2 redirect</title></head>
+<body>
+<p>You can close this window.</p>
+<script>window.close();</script>
+</body>
+</html>`
+
+func (api *recordAuthApi) merge(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("Missing collection context.", nil)
+	}
+
+	id := c.PathParam("id")
+	if id == "" {
+		return NewNotFoundError("", nil)
+	}
+
+	record, err := api.app.Dao().FindRecordById(collection.Id, id)
+	if err != nil || record == nil {
+		return NewNotFoundError("", err)
+	}
+
+	form := forms.NewRecordMerge(api.app, record)
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	report, err := form.Submit()
+	if err != nil {
+		return NewBadRequestError("Failed to merge the auth records.", err)
+	}
+
+	return c.JSON(http.StatusOK, report)
 }
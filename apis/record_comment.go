@@ -0,0 +1,192 @@
+package apis
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/resolvers"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+// bindRecordCommentApi registers the record comments api endpoints
+// and the corresponding handlers.
+//
+// The comments access is inherited from the commented record:
+// reading requires the collection view rule and writing - the collection update rule.
+func bindRecordCommentApi(app core.App, rg *echo.Group) {
+	api := recordCommentApi{app: app}
+
+	subGroup := rg.Group(
+		"/collections/:collection/records/:id/comments",
+		ActivityLogger(app),
+		LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth),
+	)
+	subGroup.GET("", api.list)
+	subGroup.POST("", api.create, RequireAdminOrRecordAuth())
+	subGroup.PATCH("/:commentId", api.update, RequireAdminOrRecordAuth())
+	subGroup.DELETE("/:commentId", api.delete, RequireAdminOrRecordAuth())
+}
+
+type recordCommentApi struct {
+	app core.App
+}
+
+var commentFilterFields = []string{
+	"rowid", "id", "created", "updated",
+	"parentId", "authorType", "authorCollectionId", "authorId", "message",
+}
+
+func (api *recordCommentApi) list(c echo.Context) error {
+	record, err := api.findRecord(c, false)
+	if err != nil {
+		return err
+	}
+
+	fieldResolver := search.NewSimpleFieldResolver(commentFilterFields...)
+
+	provider := search.NewProvider(fieldResolver).
+		Query(api.app.Dao().RecordCommentsQuery(record))
+
+	if c.QueryParam(search.SortQueryParam) == "" {
+		provider.Sort([]search.SortField{{Name: "created", Direction: search.SortAsc}})
+	}
+
+	result, err := provider.ParseAndExec(c.QueryParams().Encode(), &[]*models.Comment{})
+	if err != nil {
+		return NewBadRequestError("", err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (api *recordCommentApi) create(c echo.Context) error {
+	record, err := api.findRecord(c, true)
+	if err != nil {
+		return err
+	}
+
+	comment := &models.Comment{
+		CollectionId: record.Collection().Id,
+		RecordId:     record.Id,
+	}
+
+	requestInfo := RequestInfo(c)
+	if requestInfo.Admin != nil {
+		comment.AuthorType = models.CommentAuthorTypeAdmin
+		comment.AuthorId = requestInfo.Admin.Id
+	} else {
+		comment.AuthorType = models.CommentAuthorTypeAuthRecord
+		comment.AuthorCollectionId = requestInfo.AuthRecord.Collection().Id
+		comment.AuthorId = requestInfo.AuthRecord.Id
+	}
+
+	return api.submit(c, comment)
+}
+
+func (api *recordCommentApi) update(c echo.Context) error {
+	comment, err := api.findOwnComment(c)
+	if err != nil {
+		return err
+	}
+
+	return api.submit(c, comment)
+}
+
+func (api *recordCommentApi) delete(c echo.Context) error {
+	comment, err := api.findOwnComment(c)
+	if err != nil {
+		return err
+	}
+
+	if err := api.app.Dao().DeleteComment(comment); err != nil {
+		return NewBadRequestError("Failed to delete comment.", err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (api *recordCommentApi) submit(c echo.Context, comment *models.Comment) error {
+	form := forms.NewCommentUpsert(api.app, comment)
+
+	// load request
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("Failed to load the submitted data due to invalid formatting.", err)
+	}
+
+	if err := form.Submit(); err != nil {
+		return NewBadRequestError("Failed to save comment.", err)
+	}
+
+	return c.JSON(http.StatusOK, comment)
+}
+
+// findOwnComment loads the requested comment ensuring that it is
+// created by the current request auth model (or an admin) and
+// that the commented record could be updated.
+func (api *recordCommentApi) findOwnComment(c echo.Context) (*models.Comment, error) {
+	record, err := api.findRecord(c, true)
+	if err != nil {
+		return nil, err
+	}
+
+	comment, _ := api.app.Dao().FindCommentById(c.PathParam("commentId"))
+	if comment == nil || comment.CollectionId != record.Collection().Id || comment.RecordId != record.Id {
+		return nil, NewNotFoundError("", nil)
+	}
+
+	requestInfo := RequestInfo(c)
+	if requestInfo.Admin == nil && !comment.IsAuthoredBy(nil, requestInfo.AuthRecord) {
+		return nil, NewForbiddenError("Only the comment author can perform this action.", nil).WithErrorCode(ErrorCodeRuleForbidden)
+	}
+
+	return comment, nil
+}
+
+// findRecord loads the commented record applying the collection
+// update rule for write operations and the view rule otherwise.
+func (api *recordCommentApi) findRecord(c echo.Context, write bool) (*models.Record, error) {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return nil, NewNotFoundError("", "Missing collection context.")
+	}
+
+	rule := collection.ViewRule
+	if write {
+		rule = collection.UpdateRule
+	}
+
+	if !collection.CommentsEnabled() {
+		return nil, NewBadRequestError("The collection comments are not enabled.", nil)
+	}
+
+	requestInfo := RequestInfo(c)
+
+	if requestInfo.Admin == nil && rule == nil {
+		// only admins can access if the rule is nil
+		return nil, NewForbiddenError("Only admins can perform this action.", nil).WithErrorCode(ErrorCodeRuleForbidden)
+	}
+
+	ruleFunc := func(q *dbx.SelectQuery) error {
+		if requestInfo.Admin == nil && rule != nil && *rule != "" {
+			resolver := resolvers.NewRecordFieldResolver(api.app.Dao(), collection, requestInfo, true)
+			expr, err := search.FilterData(*rule).BuildExpr(resolver)
+			if err != nil {
+				return err
+			}
+			resolver.UpdateQuery(q)
+			q.AndWhere(expr)
+		}
+		return nil
+	}
+
+	record, fetchErr := api.app.Dao().FindRecordById(collection.Id, c.PathParam("id"), ruleFunc)
+	if fetchErr != nil || record == nil {
+		return nil, NewNotFoundError("", fetchErr)
+	}
+
+	return record, nil
+}
@@ -0,0 +1,202 @@
+package apis
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/resolvers"
+	"github.com/pocketbase/pocketbase/tools/search"
+	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/pocketbase/pocketbase/tools/store"
+	"github.com/pocketbase/pocketbase/tools/types"
+	"github.com/spf13/cast"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// recordsCountDefaultMaxAge is the default max staleness (in seconds) of the cached records counts.
+	recordsCountDefaultMaxAge = 60
+
+	// recordsCountMaxAgeLimit is the max allowed staleness (in seconds) of the cached records counts.
+	recordsCountMaxAgeLimit = 3600
+
+	// recordsCountMaxCacheEntries is the number of the cached records
+	// counts before trying to prune the outdated ones.
+	recordsCountMaxCacheEntries = 1000
+)
+
+// bindRecordCountApi registers the collection records approximate
+// count api endpoint and the corresponding handler.
+func bindRecordCountApi(app core.App, rg *echo.Group) {
+	api := recordCountApi{
+		app:     app,
+		cache:   store.New[*recordsCountResult](nil),
+		pending: new(singleflight.Group),
+	}
+
+	subGroup := rg.Group(
+		"/collections/:collection",
+		ActivityLogger(app),
+		LoadCollectionContext(app),
+	)
+	subGroup.GET("/count", api.count)
+}
+
+type recordCountApi struct {
+	app core.App
+
+	// cache holds the last counts of the recently requested queries.
+	cache *store.Store[*recordsCountResult]
+
+	// pending represents a group of currently running count queries
+	// (used to prevent concurrent scans for the same query).
+	pending *singleflight.Group
+}
+
+// recordsCountResult defines the records approximate count response.
+type recordsCountResult struct {
+	Count int `json:"count"`
+
+	// Approximate indicates whether the count was served from the cache
+	// and may not reflect the changes made after CountedAt.
+	Approximate bool `json:"approximate"`
+
+	CountedAt types.DateTime `json:"countedAt"`
+}
+
+// count returns the number of the collection records matching the list rule
+// and the optional filter.
+//
+// The counts are cached and reused for up to "maxAge" seconds
+// (default to 60) so that frequently reloaded dashboards could show
+// large counts without a full COUNT scan on every request.
+func (api *recordCountApi) count(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("", "Missing collection context.")
+	}
+
+	requestInfo := RequestInfo(c)
+
+	// forbid users and guests to query special filter fields
+	if err := checkForAdminOnlyRuleFields(requestInfo); err != nil {
+		return err
+	}
+
+	if requestInfo.Admin == nil && collection.ListRule == nil {
+		// only admins can access if the rule is nil
+		return NewForbiddenError("Only admins can perform this action.", nil).WithErrorCode(ErrorCodeRuleForbidden)
+	}
+
+	filter := c.QueryParam(search.FilterQueryParam)
+
+	if requestInfo.Admin == nil {
+		if err := checkListFilterComplexity(collection, filter); err != nil {
+			return err
+		}
+	}
+
+	maxAge := recordsCountDefaultMaxAge
+	if raw := c.QueryParam("maxAge"); raw != "" {
+		maxAge = cast.ToInt(raw)
+		if maxAge <= 0 || maxAge > recordsCountMaxAgeLimit {
+			return NewBadRequestError("The maxAge parameter must be between 1 and 3600 seconds.", nil)
+		}
+	}
+
+	resolver := resolvers.NewRecordFieldResolver(
+		api.app.Dao(),
+		collection,
+		requestInfo,
+		// hidden fields are searchable only by admins
+		requestInfo.Admin != nil,
+	)
+
+	query := api.app.Dao().RecordQuery(collection)
+
+	excludeSoftDeleted(query, collection)
+
+	exprs := []dbx.Expression{}
+
+	if requestInfo.Admin == nil && collection.ListRule != nil && *collection.ListRule != "" {
+		expr, err := search.FilterData(*collection.ListRule).BuildExpr(resolver)
+		if err != nil {
+			return NewBadRequestError("", err)
+		}
+		exprs = append(exprs, expr)
+	}
+
+	if filter != "" {
+		expr, err := search.FilterData(filter).BuildExpr(resolver)
+		if err != nil {
+			return NewBadRequestError("Invalid filter.", err)
+		}
+		exprs = append(exprs, expr)
+	}
+
+	if len(exprs) > 0 {
+		resolver.UpdateQuery(query)
+		query.AndWhere(dbx.And(exprs...))
+	}
+
+	// the joined relations could produce duplicated rows
+	query.Distinct(false).Select("COUNT(DISTINCT [[" + collection.Name + ".id]])")
+
+	// the rule and filter placeholders are part of the cache key
+	// (eg. the same filter of 2 different auth records are cached separately)
+	built := query.Build()
+	rawParams, _ := json.Marshal(built.Params())
+	cacheKey := security.SHA256(collection.Id + built.SQL() + string(rawParams))
+
+	if cached := api.cache.Get(cacheKey); cached != nil &&
+		cached.CountedAt.Time().Add(time.Duration(maxAge)*time.Second).After(time.Now()) {
+		result := *cached
+		result.Approximate = true
+
+		return c.JSON(http.StatusOK, result)
+	}
+
+	counted, err, _ := api.pending.Do(cacheKey, func() (any, error) {
+		result := &recordsCountResult{CountedAt: types.NowDateTime()}
+
+		if err := built.Row(&result.Count); err != nil {
+			return nil, err
+		}
+
+		api.cacheResult(cacheKey, result)
+
+		return result, nil
+	})
+	if err != nil {
+		return NewBadRequestError("Failed to count the records.", err)
+	}
+
+	return c.JSON(http.StatusOK, counted)
+}
+
+// cacheResult stores the provided count result and prunes
+// the outdated entries if the cache limit is reached.
+func (api *recordCountApi) cacheResult(key string, result *recordsCountResult) {
+	if api.cache.SetIfLessThanLimit(key, result, recordsCountMaxCacheEntries) {
+		return
+	}
+
+	expiredBefore := time.Now().Add(-recordsCountMaxAgeLimit * time.Second)
+
+	for k, v := range api.cache.GetAll() {
+		if v.CountedAt.Time().Before(expiredBefore) {
+			api.cache.Remove(k)
+		}
+	}
+
+	if !api.cache.SetIfLessThanLimit(key, result, recordsCountMaxCacheEntries) {
+		// still full with fresh entries
+		api.cache.RemoveAll()
+		api.cache.Set(key, result)
+	}
+}
@@ -1,9 +1,13 @@
 package apis
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 
 	"github.com/labstack/echo/v5"
 	"github.com/pocketbase/dbx"
@@ -12,7 +16,10 @@ import (
 	"github.com/pocketbase/pocketbase/forms"
 	"github.com/pocketbase/pocketbase/models"
 	"github.com/pocketbase/pocketbase/resolvers"
+	"github.com/pocketbase/pocketbase/tools/list"
 	"github.com/pocketbase/pocketbase/tools/search"
+	"github.com/pocketbase/pocketbase/tools/types"
+	"github.com/spf13/cast"
 )
 
 // bindRecordCrudApi registers the record crud api endpoints and
@@ -25,17 +32,49 @@ func bindRecordCrudApi(app core.App, rg *echo.Group) {
 		ActivityLogger(app),
 	)
 
-	subGroup.GET("/records", api.list, LoadCollectionContext(app))
-	subGroup.GET("/records/:id", api.view, LoadCollectionContext(app))
-	subGroup.POST("/records", api.create, LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth))
-	subGroup.PATCH("/records/:id", api.update, LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth))
-	subGroup.DELETE("/records/:id", api.delete, LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth))
+	subGroup.GET("/records", api.list, LoadCollectionContext(app), LoadApiKeyScopeContext(models.ApiKeyActionList))
+	subGroup.GET("/records/:id", api.view, LoadCollectionContext(app), LoadApiKeyScopeContext(models.ApiKeyActionView))
+	subGroup.POST("/records", api.create, LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth), LoadApiKeyScopeContext(models.ApiKeyActionCreate))
+	subGroup.PATCH("/records/:id", api.update, LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth), LoadApiKeyScopeContext(models.ApiKeyActionUpdate))
+	subGroup.POST("/records/:id/reorder", api.reorder, LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth), LoadApiKeyScopeContext(models.ApiKeyActionUpdate))
+	subGroup.PATCH("/records/:id/increment", api.increment, LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth), LoadApiKeyScopeContext(models.ApiKeyActionUpdate))
+	subGroup.DELETE("/records", api.bulkDelete, LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth), LoadApiKeyScopeContext(models.ApiKeyActionDelete))
+	subGroup.DELETE("/records/:id", api.delete, LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth), LoadApiKeyScopeContext(models.ApiKeyActionDelete))
 }
 
+// includeDeletedQueryParam is the admin only records list query
+// parameter that includes the soft deleted records in the result.
+const includeDeletedQueryParam = "includeDeleted"
+
+// upsertQueryParam is the create records query parameter that enables
+// the create-or-update mode ("true" or comma separated upsert key fields).
+const upsertQueryParam = "upsert"
+
 type recordApi struct {
 	app core.App
 }
 
+// recordsDeltaResult defines the records list response for the
+// incremental changes pull (aka. when the "since" query parameter is set).
+type recordsDeltaResult struct {
+	*search.Result
+
+	// Deleted is a list with the ids of the records deleted after "since".
+	Deleted []string `json:"deleted"`
+
+	// Cursor is the value that should be used as "since" for the next pull.
+	Cursor types.DateTime `json:"cursor"`
+}
+
+// recordsBulkDeleteResult defines the records bulk delete response.
+type recordsBulkDeleteResult struct {
+	// Total is the number of the deleted records.
+	Total int `json:"total"`
+
+	// Deleted is a list with the ids of the deleted records.
+	Deleted []string `json:"deleted"`
+}
+
 func (api *recordApi) list(c echo.Context) error {
 	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
 	if collection == nil {
@@ -49,9 +88,11 @@ func (api *recordApi) list(c echo.Context) error {
 		return err
 	}
 
+	newRuleLog(api.app, c, collection, "list").logList(collection.ListRule)
+
 	if requestInfo.Admin == nil && collection.ListRule == nil {
 		// only admins can access if the rule is nil
-		return NewForbiddenError("Only admins can perform this action.", nil)
+		return NewForbiddenError("Only admins can perform this action.", nil).WithErrorCode(ErrorCodeRuleForbidden)
 	}
 
 	fieldsResolver := resolvers.NewRecordFieldResolver(
@@ -62,20 +103,165 @@ func (api *recordApi) list(c echo.Context) error {
 		requestInfo.Admin != nil,
 	)
 
-	searchProvider := search.NewProvider(fieldsResolver).
-		Query(api.app.Dao().RecordQuery(collection))
+	query := api.app.Dao().RecordQuery(collection)
+
+	// exclude the soft deleted records
+	// (admins could include them with the "includeDeleted" query parameter)
+	if requestInfo.Admin == nil || !cast.ToBool(c.QueryParam(includeDeletedQueryParam)) {
+		excludeSoftDeleted(query, collection)
+	}
+
+	searchProvider := search.NewProvider(fieldsResolver).Query(query)
 
 	if requestInfo.Admin == nil && collection.ListRule != nil {
 		searchProvider.AddFilter(search.FilterData(*collection.ListRule))
 	}
 
+	// enforce the collection list limits
+	if requestInfo.Admin == nil {
+		if err := checkListFilterComplexity(collection, c.QueryParam(search.FilterQueryParam)); err != nil {
+			return err
+		}
+
+		searchProvider.PerPageLimit(collection.ListMaxPerPage())
+	}
+
+	// incremental changes pull
+	var delta *recordsDeltaResult
+	if rawSince := c.QueryParam("since"); rawSince != "" {
+		if collection.IsView() {
+			return NewBadRequestError("The since parameter is not supported for view collections.", nil)
+		}
+
+		since, err := types.ParseDateTime(rawSince)
+		if err != nil || since.IsZero() {
+			return NewBadRequestError("Invalid since datetime value.", err)
+		}
+
+		// capture the cursor before the queries to ensure that
+		// concurrent changes will be included in the next pull
+		delta = &recordsDeltaResult{Cursor: types.NowDateTime()}
+
+		delta.Deleted, err = api.app.Dao().FindRecordTombstones(collection, since)
+		if err != nil {
+			return NewBadRequestError("Failed to fetch the deleted records.", err)
+		}
+
+		softDeleted, err := api.app.Dao().FindSoftDeletedRecordIds(collection, since)
+		if err != nil {
+			return NewBadRequestError("Failed to fetch the soft deleted records.", err)
+		}
+		delta.Deleted = append(delta.Deleted, softDeleted...)
+
+		query.AndWhere(dbx.NewExp(
+			fmt.Sprintf("[[%s.updated]] > {:since}", collection.Name),
+			dbx.Params{"since": since.String()},
+		))
+
+		if c.QueryParam(search.SortQueryParam) == "" {
+			searchProvider.AddSort(search.SortField{Name: "updated", Direction: search.SortAsc})
+			searchProvider.AddSort(search.SortField{Name: "id", Direction: search.SortAsc})
+		}
+	} else if c.QueryParam(search.SortQueryParam) == "" {
+		for _, sortField := range search.ParseSortFromString(collection.ListDefaultSort()) {
+			if sortField.Name != "" {
+				searchProvider.AddSort(sortField)
+			}
+		}
+	}
+
+	// cursor based pagination
+	urlQuery := c.QueryParams()
+	var cursorPager *recordsCursorPager
+	if urlQuery.Has(cursorQueryParam) {
+		if collection.IsView() {
+			return NewBadRequestError("The cursor parameter is not supported for view collections.", nil)
+		}
+
+		if delta != nil {
+			return NewBadRequestError("The cursor parameter cannot be combined with the since parameter.", nil)
+		}
+
+		if isNdjsonRequest(c) {
+			return NewBadRequestError("The cursor parameter is not supported with NDJSON streaming.", nil)
+		}
+
+		sortFields := search.ParseSortFromString(c.QueryParam(search.SortQueryParam))
+		if len(sortFields) == 0 {
+			sortFields = search.ParseSortFromString(collection.ListDefaultSort())
+		}
+
+		var err error
+
+		cursorPager, err = newRecordsCursorPager(
+			collection,
+			sortFields,
+			c.QueryParam(search.FilterQueryParam),
+			requestInfo.Admin != nil,
+		)
+		if err != nil {
+			return NewBadRequestError("", err)
+		}
+
+		cursorExpr, err := cursorPager.Expr(c.QueryParam(cursorQueryParam))
+		if err != nil {
+			return NewBadRequestError("", err)
+		}
+		if cursorExpr != nil {
+			query.AndWhere(cursorExpr)
+		}
+
+		// the sort and the page position are controlled by the cursor
+		// (the total counts are also skipped since they are the main OFFSET bottleneck)
+		searchProvider.Sort(cursorPager.Sort()).SkipTotal(true)
+
+		urlQuery = url.Values{}
+		for k, v := range c.QueryParams() {
+			switch k {
+			case search.SortQueryParam, search.PageQueryParam, search.SkipTotalQueryParam:
+			default:
+				urlQuery[k] = v
+			}
+		}
+	}
+
+	// full-text search
+	if urlQuery.Has(fullTextQueryParam) {
+		if len(collection.FullTextFields()) == 0 {
+			return NewBadRequestError("The collection doesn't have a full-text search index.", nil)
+		}
+
+		// rank the results only if there is no explicit sort or cursor/delta order
+		sortByRank := cursorPager == nil && delta == nil && c.QueryParam(search.SortQueryParam) == ""
+
+		applyFullTextSearch(query, collection, c.QueryParam(fullTextQueryParam), sortByRank)
+	}
+
+	if isNdjsonRequest(c) {
+		if delta != nil {
+			return NewBadRequestError("The since parameter is not supported with NDJSON streaming.", nil)
+		}
+
+		return api.streamList(c, collection, query, searchProvider)
+	}
+
 	records := []*models.Record{}
 
-	result, err := searchProvider.ParseAndExec(c.QueryParams().Encode(), &records)
+	result, err := searchProvider.ParseAndExec(urlQuery.Encode(), &records)
 	if err != nil {
 		return NewBadRequestError("", err)
 	}
 
+	// resolve the continuation token before the hooks
+	// and enrichments could modify the found records
+	var nextCursor string
+	if cursorPager != nil && len(records) > 0 && len(records) >= result.PerPage {
+		nextCursor, err = cursorPager.Next(records[len(records)-1])
+		if err != nil {
+			return NewBadRequestError("Failed to generate the next cursor.", err)
+		}
+	}
+
 	event := new(core.RecordsListEvent)
 	event.HttpContext = c
 	event.Collection = collection
@@ -91,6 +277,18 @@ func (api *recordApi) list(c echo.Context) error {
 			api.app.Logger().Debug("Failed to enrich list records", slog.String("error", err.Error()))
 		}
 
+		if delta != nil {
+			delta.Result = e.Result
+			return e.HttpContext.JSON(http.StatusOK, delta)
+		}
+
+		if cursorPager != nil {
+			return e.HttpContext.JSON(http.StatusOK, &recordsCursorResult{
+				Result:     e.Result,
+				NextCursor: nextCursor,
+			})
+		}
+
 		return e.HttpContext.JSON(http.StatusOK, e.Result)
 	})
 }
@@ -108,12 +306,18 @@ func (api *recordApi) view(c echo.Context) error {
 
 	requestInfo := RequestInfo(c)
 
+	ruleLog := newRuleLog(api.app, c, collection, "view")
+
 	if requestInfo.Admin == nil && collection.ViewRule == nil {
 		// only admins can access if the rule is nil
-		return NewForbiddenError("Only admins can perform this action.", nil)
+		ruleLog.logFetch(api.app.Dao(), nil, recordId, false)
+		return NewForbiddenError("Only admins can perform this action.", nil).WithErrorCode(ErrorCodeRuleForbidden)
 	}
 
 	ruleFunc := func(q *dbx.SelectQuery) error {
+		if requestInfo.Admin == nil {
+			excludeSoftDeleted(q, collection)
+		}
 		if requestInfo.Admin == nil && collection.ViewRule != nil && *collection.ViewRule != "" {
 			resolver := resolvers.NewRecordFieldResolver(api.app.Dao(), collection, requestInfo, true)
 			expr, err := search.FilterData(*collection.ViewRule).BuildExpr(resolver)
@@ -127,6 +331,7 @@ func (api *recordApi) view(c echo.Context) error {
 	}
 
 	record, fetchErr := api.app.Dao().FindRecordById(collection.Id, recordId, ruleFunc)
+	ruleLog.logFetch(api.app.Dao(), collection.ViewRule, recordId, fetchErr == nil && record != nil)
 	if fetchErr != nil || record == nil {
 		return NewNotFoundError("", fetchErr)
 	}
@@ -162,9 +367,35 @@ func (api *recordApi) create(c echo.Context) error {
 
 	requestInfo := RequestInfo(c)
 
+	// create-or-update (see forms.FindUpsertRecord)
+	if rawUpsert := c.QueryParam(upsertQueryParam); rawUpsert != "" {
+		enabled, boolErr := strconv.ParseBool(rawUpsert)
+
+		// comma separated upsert key fields
+		var keyFields []string
+		if boolErr != nil {
+			keyFields = list.ToUniqueStringSlice(strings.Split(strings.ReplaceAll(rawUpsert, " ", ""), ","))
+			enabled = true
+		}
+
+		if enabled {
+			existing, err := forms.FindUpsertRecord(api.app.Dao(), collection, requestInfo.Data, keyFields...)
+			if err != nil {
+				return NewBadRequestError("Failed to resolve the upsert record.", err)
+			}
+
+			if existing != nil {
+				return api.updateRecord(c, collection, existing.Id)
+			}
+		}
+	}
+
+	ruleLog := newRuleLog(api.app, c, collection, "create")
+
 	if requestInfo.Admin == nil && collection.CreateRule == nil {
 		// only admins can access if the rule is nil
-		return NewForbiddenError("Only admins can perform this action.", nil)
+		ruleLog.logFetch(api.app.Dao(), nil, "", false)
+		return NewForbiddenError("Only admins can perform this action.", nil).WithErrorCode(ErrorCodeRuleForbidden)
 	}
 
 	hasFullManageAccess := requestInfo.Admin != nil
@@ -202,6 +433,7 @@ func (api *recordApi) create(c echo.Context) error {
 
 		testErr := testForm.DrySubmit(func(txDao *daos.Dao) error {
 			foundRecord, err := txDao.FindRecordById(collection.Id, testRecord.Id, createRuleFunc)
+			ruleLog.logFetch(txDao, collection.CreateRule, testRecord.Id, err == nil)
 			if err != nil {
 				return fmt.Errorf("DrySubmit create rule failure: %w", err)
 			}
@@ -271,11 +503,20 @@ func (api *recordApi) update(c echo.Context) error {
 		return NewNotFoundError("", nil)
 	}
 
+	return api.updateRecord(c, collection, recordId)
+}
+
+// updateRecord updates the specified collection record with the request data
+// (it is also used by the create handler in upsert mode).
+func (api *recordApi) updateRecord(c echo.Context, collection *models.Collection, recordId string) error {
 	requestInfo := RequestInfo(c)
 
+	ruleLog := newRuleLog(api.app, c, collection, "update")
+
 	if requestInfo.Admin == nil && collection.UpdateRule == nil {
 		// only admins can access if the rule is nil
-		return NewForbiddenError("Only admins can perform this action.", nil)
+		ruleLog.logFetch(api.app.Dao(), nil, recordId, false)
+		return NewForbiddenError("Only admins can perform this action.", nil).WithErrorCode(ErrorCodeRuleForbidden)
 	}
 
 	// eager fetch the record so that the modifier field values are replaced
@@ -289,6 +530,9 @@ func (api *recordApi) update(c echo.Context) error {
 	}
 
 	ruleFunc := func(q *dbx.SelectQuery) error {
+		if requestInfo.Admin == nil {
+			excludeSoftDeleted(q, collection)
+		}
 		if requestInfo.Admin == nil && collection.UpdateRule != nil && *collection.UpdateRule != "" {
 			resolver := resolvers.NewRecordFieldResolver(api.app.Dao(), collection, requestInfo, true)
 			expr, err := search.FilterData(*collection.UpdateRule).BuildExpr(resolver)
@@ -303,6 +547,7 @@ func (api *recordApi) update(c echo.Context) error {
 
 	// fetch record
 	record, fetchErr := api.app.Dao().FindRecordById(collection.Id, recordId, ruleFunc)
+	ruleLog.logFetch(api.app.Dao(), collection.UpdateRule, recordId, fetchErr == nil && record != nil)
 	if fetchErr != nil || record == nil {
 		return NewNotFoundError("", fetchErr)
 	}
@@ -352,6 +597,181 @@ func (api *recordApi) update(c echo.Context) error {
 	})
 }
 
+// reorder atomically moves a single record before or after
+// another record from the same position field scope.
+func (api *recordApi) reorder(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("", "Missing collection context.")
+	}
+
+	requestInfo := RequestInfo(c)
+
+	if requestInfo.Admin == nil && collection.UpdateRule == nil {
+		// only admins can access if the rule is nil
+		return NewForbiddenError("Only admins can perform this action.", nil).WithErrorCode(ErrorCodeRuleForbidden)
+	}
+
+	// note: the UpdateRule is checked after loading the request
+	// because it depends on the new position value
+	record, fetchErr := api.app.Dao().FindRecordById(collection.Id, c.PathParam("id"), func(q *dbx.SelectQuery) error {
+		if requestInfo.Admin == nil {
+			excludeSoftDeleted(q, collection)
+		}
+		return nil
+	})
+	if fetchErr != nil || record == nil {
+		return NewNotFoundError("", fetchErr)
+	}
+
+	form := forms.NewRecordReorder(api.app, record)
+
+	// load request
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("Failed to load the submitted data due to invalid formatting.", err)
+	}
+
+	if requestInfo.Admin == nil {
+		// expose the new position as "@request.data.<field>"
+		// (on invalid form the rule is checked without data so that
+		// the validation errors are reported only for accessible records)
+		ruleData := map[string]any{}
+		position, positionErr := form.MovedPosition()
+		if positionErr == nil {
+			ruleData[form.Field] = position
+		}
+
+		if err := api.checkUpdateRuleData(collection, record, requestInfo, ruleData); err != nil {
+			return NewNotFoundError("", err)
+		}
+
+		if positionErr != nil {
+			return NewBadRequestError("Failed to reorder record.", positionErr)
+		}
+	}
+
+	if err := form.Submit(); err != nil {
+		return NewBadRequestError("Failed to reorder record.", err)
+	}
+
+	if err := EnrichRecord(c, api.app.Dao(), record); err != nil {
+		api.app.Logger().Debug(
+			"Failed to enrich reordered record",
+			slog.String("id", record.Id),
+			slog.String("collectionName", collection.Name),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	return c.JSON(http.StatusOK, record)
+}
+
+// checkUpdateRuleData checks whether the record satisfies the collection
+// UpdateRule when the provided data is exposed as "@request.data.*".
+//
+// It is used by the field update actions (eg. reorder, increment) whose request
+// body doesn't match the written record fields so that the UpdateRule
+// field guards apply the same way as in a regular record update.
+func (api *recordApi) checkUpdateRuleData(
+	collection *models.Collection,
+	record *models.Record,
+	requestInfo *models.RequestInfo,
+	data map[string]any,
+) error {
+	if requestInfo.Admin != nil {
+		return nil // no rules for admins
+	}
+
+	if collection.UpdateRule == nil {
+		return errors.New("only admins can perform this action")
+	}
+
+	if *collection.UpdateRule == "" {
+		return nil // public
+	}
+
+	info := *requestInfo
+	info.Data = data
+
+	resolver := resolvers.NewRecordFieldResolver(api.app.Dao(), collection, &info, true)
+
+	expr, err := search.FilterData(*collection.UpdateRule).BuildExpr(resolver)
+	if err != nil {
+		return err
+	}
+
+	_, err = api.app.Dao().FindRecordById(collection.Id, record.Id, func(q *dbx.SelectQuery) error {
+		resolver.UpdateQuery(q)
+		q.AndWhere(expr)
+		return nil
+	})
+
+	return err
+}
+
+// increment atomically increments (or decrements)
+// one or more number fields of a single record.
+func (api *recordApi) increment(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("", "Missing collection context.")
+	}
+
+	requestInfo := RequestInfo(c)
+
+	if requestInfo.Admin == nil && collection.UpdateRule == nil {
+		// only admins can access if the rule is nil
+		return NewForbiddenError("Only admins can perform this action.", nil).WithErrorCode(ErrorCodeRuleForbidden)
+	}
+
+	// note: the UpdateRule is checked after loading the request
+	// because it depends on the incremented field values
+	record, fetchErr := api.app.Dao().FindRecordById(collection.Id, c.PathParam("id"), func(q *dbx.SelectQuery) error {
+		if requestInfo.Admin == nil {
+			excludeSoftDeleted(q, collection)
+		}
+		return nil
+	})
+	if fetchErr != nil || record == nil {
+		return NewNotFoundError("", fetchErr)
+	}
+
+	form := forms.NewRecordIncrement(api.app, record)
+
+	// load request
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("Failed to load the submitted data due to invalid formatting.", err)
+	}
+
+	if requestInfo.Admin == nil {
+		// expose the incremented values as "@request.data.<field>"
+		// (the same as the "<field>+" modifier of a regular update)
+		modifiers := make(map[string]any, len(form.Fields))
+		for name, delta := range form.Fields {
+			modifiers[name+"+"] = delta
+		}
+
+		if err := api.checkUpdateRuleData(collection, record, requestInfo, record.ReplaceModifers(modifiers)); err != nil {
+			return NewNotFoundError("", err)
+		}
+	}
+
+	if err := form.Submit(); err != nil {
+		return NewBadRequestError("Failed to increment record.", err)
+	}
+
+	if err := EnrichRecord(c, api.app.Dao(), record); err != nil {
+		api.app.Logger().Debug(
+			"Failed to enrich incremented record",
+			slog.String("id", record.Id),
+			slog.String("collectionName", collection.Name),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	return c.JSON(http.StatusOK, record)
+}
+
 func (api *recordApi) delete(c echo.Context) error {
 	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
 	if collection == nil {
@@ -365,12 +785,18 @@ func (api *recordApi) delete(c echo.Context) error {
 
 	requestInfo := RequestInfo(c)
 
+	ruleLog := newRuleLog(api.app, c, collection, "delete")
+
 	if requestInfo.Admin == nil && collection.DeleteRule == nil {
 		// only admins can access if the rule is nil
-		return NewForbiddenError("Only admins can perform this action.", nil)
+		ruleLog.logFetch(api.app.Dao(), nil, recordId, false)
+		return NewForbiddenError("Only admins can perform this action.", nil).WithErrorCode(ErrorCodeRuleForbidden)
 	}
 
 	ruleFunc := func(q *dbx.SelectQuery) error {
+		// the soft deleted records could be purged only via the trash api
+		excludeSoftDeleted(q, collection)
+
 		if requestInfo.Admin == nil && collection.DeleteRule != nil && *collection.DeleteRule != "" {
 			resolver := resolvers.NewRecordFieldResolver(api.app.Dao(), collection, requestInfo, true)
 			expr, err := search.FilterData(*collection.DeleteRule).BuildExpr(resolver)
@@ -384,6 +810,7 @@ func (api *recordApi) delete(c echo.Context) error {
 	}
 
 	record, fetchErr := api.app.Dao().FindRecordById(collection.Id, recordId, ruleFunc)
+	ruleLog.logFetch(api.app.Dao(), collection.DeleteRule, recordId, fetchErr == nil && record != nil)
 	if fetchErr != nil || record == nil {
 		return NewNotFoundError("", fetchErr)
 	}
@@ -394,8 +821,12 @@ func (api *recordApi) delete(c echo.Context) error {
 	event.Record = record
 
 	return api.app.OnRecordBeforeDeleteRequest().Trigger(event, func(e *core.RecordDeleteEvent) error {
-		// delete the record
-		if err := api.app.Dao().DeleteRecord(e.Record); err != nil {
+		// delete (or only mark as deleted) the record
+		if e.Collection.SoftDeleteField() != "" {
+			if err := api.app.Dao().SoftDeleteRecord(e.Record); err != nil {
+				return NewBadRequestError("Failed to delete record.", err)
+			}
+		} else if err := api.app.Dao().DeleteRecord(e.Record); err != nil {
 			return NewBadRequestError("Failed to delete record. Make sure that the record is not part of a required relation reference.", err)
 		}
 
@@ -408,3 +839,149 @@ func (api *recordApi) delete(c echo.Context) error {
 		})
 	})
 }
+
+// bulkDelete deletes all records matching the required "filter" query parameter
+// (and the collection delete rule) in a single transaction.
+//
+// The request is rejected without deleting anything if the filter matches
+// more records than the configured bulk delete max affected limit.
+func (api *recordApi) bulkDelete(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("", "Missing collection context.")
+	}
+
+	requestInfo := RequestInfo(c)
+
+	// forbid users and guests to query special filter fields
+	if err := checkForAdminOnlyRuleFields(requestInfo); err != nil {
+		return err
+	}
+
+	if requestInfo.Admin == nil && collection.DeleteRule == nil {
+		// only admins can access if the rule is nil
+		return NewForbiddenError("Only admins can perform this action.", nil).WithErrorCode(ErrorCodeRuleForbidden)
+	}
+
+	filter := search.FilterData(c.QueryParam(search.FilterQueryParam))
+	if filter == "" {
+		return NewBadRequestError("Missing required filter query parameter.", nil)
+	}
+
+	resolver := resolvers.NewRecordFieldResolver(
+		api.app.Dao(),
+		collection,
+		requestInfo,
+		// hidden fields are searchable only by admins
+		requestInfo.Admin != nil,
+	)
+
+	query := api.app.Dao().RecordQuery(collection)
+
+	excludeSoftDeleted(query, collection)
+
+	expr, err := filter.BuildExpr(resolver)
+	if err != nil {
+		return NewBadRequestError("Invalid filter expression.", err)
+	}
+	query.AndWhere(expr)
+
+	if err := resolver.UpdateQuery(query); err != nil {
+		return NewBadRequestError("Invalid filter expression.", err)
+	}
+
+	if requestInfo.Admin == nil && *collection.DeleteRule != "" {
+		// the delete rule is resolved in a separate subquery with the same
+		// resolver setup as the single record delete (aka. allowing hidden fields)
+		ruleQuery := api.app.Dao().RecordQuery(collection).
+			Select(collection.Name + ".id").
+			Distinct(true)
+
+		ruleResolver := resolvers.NewRecordFieldResolver(api.app.Dao(), collection, requestInfo, true)
+		ruleExpr, err := search.FilterData(*collection.DeleteRule).BuildExpr(ruleResolver)
+		if err != nil {
+			return NewBadRequestError("Invalid delete rule expression.", err)
+		}
+		ruleQuery.AndWhere(ruleExpr)
+
+		if err := ruleResolver.UpdateQuery(ruleQuery); err != nil {
+			return NewBadRequestError("Invalid delete rule expression.", err)
+		}
+
+		builtRuleQuery := ruleQuery.Build()
+		query.AndWhere(dbx.NewExp(
+			"[["+collection.Name+".id]] IN ("+builtRuleQuery.SQL()+")",
+			builtRuleQuery.Params(),
+		))
+	}
+
+	maxAffected := api.app.Settings().BulkDelete.MaxAffected
+
+	records := []*models.Record{}
+	if err := query.Limit(int64(maxAffected) + 1).All(&records); err != nil {
+		return NewBadRequestError("Failed to fetch the filtered records.", err)
+	}
+
+	if len(records) > maxAffected {
+		return NewBadRequestError(
+			fmt.Sprintf("The filter matches more than the max allowed %d records.", maxAffected),
+			nil,
+		)
+	}
+
+	result := &recordsBulkDeleteResult{Deleted: make([]string, 0, len(records))}
+
+	err = api.app.Dao().RunInTransaction(func(txDao *daos.Dao) error {
+		for _, record := range records {
+			var err error
+			if collection.SoftDeleteField() != "" {
+				err = txDao.SoftDeleteRecord(record)
+			} else {
+				err = txDao.DeleteRecord(record)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to delete record %q: %w", record.Id, err)
+			}
+			result.Deleted = append(result.Deleted, record.Id)
+		}
+		return nil
+	})
+	if err != nil {
+		return NewBadRequestError("Failed to delete records. Make sure that the records are not part of a required relation reference.", err)
+	}
+
+	result.Total = len(result.Deleted)
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// excludeSoftDeleted excludes the soft deleted collection records
+// from the provided query (if the collection soft delete is enabled).
+func excludeSoftDeleted(q *dbx.SelectQuery, collection *models.Collection) {
+	if expr := daos.NotSoftDeletedExpr(collection); expr != nil {
+		q.AndWhere(expr)
+	}
+}
+
+// checkListFilterComplexity checks whether the complexity score of the
+// provided list filter exceeds the collection ListMaxFilterComplexity option.
+func checkListFilterComplexity(collection *models.Collection, filter string) error {
+	max := collection.ListMaxFilterComplexity()
+	if max <= 0 || filter == "" {
+		return nil // no limit
+	}
+
+	complexity, err := search.FilterData(filter).Complexity()
+	if err != nil {
+		return NewBadRequestError("Invalid filter expression.", err)
+	}
+
+	if complexity > max {
+		return NewBadRequestError(
+			fmt.Sprintf("The filter expression is too complex (complexity %d, max %d).", complexity, max),
+			nil,
+		)
+	}
+
+	return nil
+}
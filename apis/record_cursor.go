@@ -0,0 +1,233 @@
+package apis
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tools/search"
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+// cursorQueryParam is the records list query parameter that enables
+// the cursor based pagination (an empty value fetches the first page).
+const cursorQueryParam = "cursor"
+
+// recordsCursorResult defines the records list response for the
+// cursor based pagination (aka. when the "cursor" query parameter is set).
+type recordsCursorResult struct {
+	*search.Result
+
+	// NextCursor is the value that should be used as "cursor" to fetch
+	// the next page (empty if there are no more items).
+	NextCursor string `json:"nextCursor"`
+}
+
+// recordsCursor is the decoded form of the opaque records list continuation token.
+type recordsCursor struct {
+	// Sort is the normalized sort expression of the paginated list.
+	Sort string `json:"s"`
+
+	// Filter is a short hash of the list filter.
+	Filter string `json:"f"`
+
+	// Values are the sort fields values of the last record from the previous page.
+	Values []any `json:"v"`
+}
+
+// recordsCursorPager applies the keyset (aka. "seek") pagination to a records list.
+type recordsCursorPager struct {
+	collection *models.Collection
+	sort       []search.SortField
+	filterHash string
+}
+
+// newRecordsCursorPager validates the provided list sort fields
+// and returns a new records cursor pager.
+//
+// The record "id" is always appended as the last sort field
+// to guarantee a stable order of the rows with equal sort values.
+func newRecordsCursorPager(
+	collection *models.Collection,
+	sort []search.SortField,
+	filter string,
+	isAdmin bool,
+) (*recordsCursorPager, error) {
+	pager := &recordsCursorPager{
+		collection: collection,
+		filterHash: security.SHA256(filter)[:16],
+	}
+
+	hasId := false
+
+	for _, field := range sort {
+		if field.Name == "" {
+			continue
+		}
+
+		if !isCursorSortField(collection, field.Name, isAdmin) {
+			return nil, fmt.Errorf("sort field %q is not supported with the cursor pagination", field.Name)
+		}
+
+		pager.sort = append(pager.sort, field)
+
+		if field.Name == schema.FieldNameId {
+			hasId = true
+			break // the rest of the fields will never be compared
+		}
+	}
+
+	if !hasId {
+		pager.sort = append(pager.sort, search.SortField{Name: schema.FieldNameId, Direction: search.SortAsc})
+	}
+
+	return pager, nil
+}
+
+// isCursorSortField checks whether the specified field could be used
+// for the keyset pagination, aka. whether it is a non-nullable
+// single value column of the collection table.
+func isCursorSortField(collection *models.Collection, name string, isAdmin bool) bool {
+	switch name {
+	case schema.FieldNameId, schema.FieldNameCreated, schema.FieldNameUpdated:
+		return true
+	case schema.FieldNameUsername:
+		return collection.IsAuth()
+	case schema.FieldNameEmail:
+		// the sort values are exposed in the cursor
+		return collection.IsAuth() && isAdmin
+	}
+
+	field := collection.Schema.GetFieldByName(name)
+	if field == nil {
+		return false
+	}
+
+	switch field.Type {
+	case schema.FieldTypeJson, schema.FieldTypeMarkdown:
+		return false
+	}
+
+	field.InitOptions()
+	if opt, ok := field.Options.(schema.MultiValuer); ok && opt.IsMultiple() {
+		return false
+	}
+
+	return true
+}
+
+// Sort returns the normalized pager sort fields.
+func (p *recordsCursorPager) Sort() []search.SortField {
+	return p.sort
+}
+
+// Expr decodes the provided raw cursor and returns the seek expression
+// that matches the records after the cursor position.
+//
+// Returns nil expression for empty raw cursor.
+func (p *recordsCursorPager) Expr(rawCursor string) (dbx.Expression, error) {
+	if rawCursor == "" {
+		return nil, nil
+	}
+
+	cursor, err := p.decode(rawCursor)
+	if err != nil {
+		return nil, err
+	}
+
+	// (a > :a) OR (a = :a AND b > :b) OR ...
+	ors := make([]dbx.Expression, 0, len(p.sort))
+
+	for i, field := range p.sort {
+		ands := make([]dbx.Expression, 0, i+1)
+
+		for j := 0; j < i; j++ {
+			ands = append(ands, dbx.NewExp(
+				fmt.Sprintf("[[%s.%s]] = {:cursor%d}", p.collection.Name, p.sort[j].Name, j),
+				dbx.Params{fmt.Sprintf("cursor%d", j): cursor.Values[j]},
+			))
+		}
+
+		op := ">"
+		if field.Direction == search.SortDesc {
+			op = "<"
+		}
+
+		ands = append(ands, dbx.NewExp(
+			fmt.Sprintf("[[%s.%s]] %s {:cursor%d}", p.collection.Name, field.Name, op, i),
+			dbx.Params{fmt.Sprintf("cursor%d", i): cursor.Values[i]},
+		))
+
+		ors = append(ors, dbx.And(ands...))
+	}
+
+	return dbx.Enclose(dbx.Or(ors...)), nil
+}
+
+// Next returns the continuation token for the page after the provided record.
+func (p *recordsCursorPager) Next(last *models.Record) (string, error) {
+	cursor := recordsCursor{
+		Sort:   p.sortString(),
+		Filter: p.filterHash,
+		Values: make([]any, len(p.sort)),
+	}
+
+	for i, field := range p.sort {
+		cursor.Values[i] = last.Get(field.Name)
+	}
+
+	raw, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+func (p *recordsCursorPager) decode(rawCursor string) (*recordsCursor, error) {
+	invalidErr := errors.New("invalid or expired cursor")
+
+	raw, err := base64.RawURLEncoding.DecodeString(rawCursor)
+	if err != nil {
+		return nil, invalidErr
+	}
+
+	cursor := &recordsCursor{}
+	if err := json.Unmarshal(raw, cursor); err != nil {
+		return nil, invalidErr
+	}
+
+	// the cursor must be used with the same list sort and filter
+	if cursor.Sort != p.sortString() || cursor.Filter != p.filterHash || len(cursor.Values) != len(p.sort) {
+		return nil, invalidErr
+	}
+
+	for _, v := range cursor.Values {
+		switch v.(type) {
+		case string, float64, bool:
+		default:
+			return nil, invalidErr
+		}
+	}
+
+	return cursor, nil
+}
+
+func (p *recordsCursorPager) sortString() string {
+	parts := make([]string, len(p.sort))
+
+	for i, field := range p.sort {
+		if field.Direction == search.SortDesc {
+			parts[i] = "-" + field.Name
+		} else {
+			parts[i] = field.Name
+		}
+	}
+
+	return strings.Join(parts, ",")
+}
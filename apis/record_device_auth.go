@@ -0,0 +1,144 @@
+package apis
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+// bindRecordDeviceAuthApi registers the auth record OAuth2 device
+// authorization grant (RFC 8628) api endpoints and the corresponding handlers.
+func bindRecordDeviceAuthApi(app core.App, rg *echo.Group) {
+	api := recordDeviceAuthApi{app: app}
+
+	subGroup := rg.Group(
+		"/collections/:collection",
+		ActivityLogger(app),
+		LoadCollectionContext(app, models.CollectionTypeAuth),
+	)
+	subGroup.POST("/request-device-code", api.requestCode, requireDeviceAuthEnabled())
+	subGroup.POST("/verify-device-code", api.verifyCode, requireDeviceAuthEnabled(), RequireSameContextRecordAuth())
+	subGroup.POST("/auth-with-device-code", api.authWithCode, requireDeviceAuthEnabled())
+}
+
+type recordDeviceAuthApi struct {
+	app core.App
+}
+
+// requireDeviceAuthEnabled middleware requires the loaded context
+// auth collection to have enabled the device authorization grant.
+func requireDeviceAuthEnabled() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+			if collection == nil || !collection.AuthOptions().AllowDeviceAuth {
+				return NewBadRequestError("The collection is not configured to allow device authorization.", nil)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// requestCode issues a new device and user codes pair.
+//
+// The device displays the user code and verification uri to the
+// user and polls the auth-with-device-code endpoint with the device
+// code until the user approves (or denies) the request.
+func (api *recordDeviceAuthApi) requestCode(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+
+	code := security.RandomString(40)
+
+	deviceCode := &models.DeviceCode{
+		CollectionId: collection.Id,
+		Status:       models.DeviceCodeStatusPending,
+	}
+	deviceCode.SetCode(code)
+
+	txErr := api.app.Dao().RunInTransaction(func(txDao *daos.Dao) error {
+		// cleanup the expired device codes
+		if err := txDao.DeleteOldDeviceCodes(time.Now().Add(-models.DeviceCodeDuration * time.Second)); err != nil {
+			return err
+		}
+
+		// regenerate on the unlikely user code collision
+		for {
+			deviceCode.UserCode = models.NewUserCode()
+			if _, err := txDao.FindDeviceCodeByUserCode(collection.Id, deviceCode.UserCode); err != nil {
+				break
+			}
+		}
+
+		return txDao.SaveDeviceCode(deviceCode)
+	})
+	if txErr != nil {
+		return NewBadRequestError("Failed to create device code.", txErr)
+	}
+
+	userCode := models.FormatUserCode(deviceCode.UserCode)
+
+	verificationUri := strings.TrimRight(api.app.Settings().Meta.AppUrl, "/") + "/_/#/auth/device"
+
+	return c.JSON(http.StatusOK, map[string]any{
+		"deviceCode":              code,
+		"userCode":                userCode,
+		"verificationUri":         verificationUri,
+		"verificationUriComplete": verificationUri + "?code=" + userCode,
+		"expiresIn":               models.DeviceCodeDuration,
+		"interval":                models.DeviceCodePollInterval,
+	})
+}
+
+// verifyCode approves (or denies) the device code with the submitted
+// user code on behalf of the current auth record.
+func (api *recordDeviceAuthApi) verifyCode(c echo.Context) error {
+	record, _ := c.Get(ContextAuthRecordKey).(*models.Record)
+
+	form := forms.NewRecordDeviceVerify(api.app, record)
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	if _, err := form.Submit(); err != nil {
+		return NewBadRequestError("Failed to verify the device code.", err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// authWithCode exchanges an approved device code for an auth token.
+func (api *recordDeviceAuthApi) authWithCode(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+
+	form := forms.NewRecordDeviceLogin(api.app, collection)
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	record, submitErr := form.Submit()
+	if submitErr != nil {
+		switch {
+		case errors.Is(submitErr, forms.ErrDeviceAuthPending):
+			return NewBadRequestError(submitErr.Error(), nil).WithErrorCode(ErrorCodeAuthorizationPending)
+		case errors.Is(submitErr, forms.ErrDeviceAuthSlowDown):
+			return NewBadRequestError(submitErr.Error(), nil).WithErrorCode(ErrorCodeSlowDown)
+		case errors.Is(submitErr, forms.ErrDeviceAuthExpired):
+			return NewBadRequestError(submitErr.Error(), nil).WithErrorCode(ErrorCodeExpiredToken)
+		case errors.Is(submitErr, forms.ErrDeviceAuthDenied):
+			return NewBadRequestError(submitErr.Error(), nil).WithErrorCode(ErrorCodeAccessDenied)
+		default:
+			return NewBadRequestError("Failed to authenticate.", submitErr)
+		}
+	}
+
+	return RecordAuthResponse(api.app, c, record, nil)
+}
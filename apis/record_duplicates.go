@@ -0,0 +1,80 @@
+package apis
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// bindRecordDuplicatesApi registers the admin only records
+// de-duplication api endpoints and the corresponding handlers.
+func bindRecordDuplicatesApi(app core.App, rg *echo.Group) {
+	api := recordDuplicatesApi{app: app}
+
+	subGroup := rg.Group(
+		"/collections/:collection/duplicates",
+		ActivityLogger(app),
+		RequireAdminAuth(),
+		LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth),
+	)
+	subGroup.POST("", api.find)
+	subGroup.POST("/:id/merge", api.merge)
+}
+
+type recordDuplicatesApi struct {
+	app core.App
+}
+
+// recordDuplicatesResult defines the records duplicates review list response.
+type recordDuplicatesResult struct {
+	Items []*daos.RecordDuplicatesItem `json:"items"`
+}
+
+// find returns the probable duplicate record pairs of the collection.
+func (api *recordDuplicatesApi) find(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("", "Missing collection context.")
+	}
+
+	form := forms.NewRecordDuplicatesFind(api.app, collection)
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	items, err := form.Submit()
+	if err != nil {
+		return NewBadRequestError("Failed to find the duplicate records.", err)
+	}
+
+	return c.JSON(http.StatusOK, recordDuplicatesResult{Items: items})
+}
+
+// merge merges the submitted source record into the path record.
+func (api *recordDuplicatesApi) merge(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("", "Missing collection context.")
+	}
+
+	record, err := api.app.Dao().FindRecordById(collection.Id, c.PathParam("id"))
+	if err != nil || record == nil {
+		return NewNotFoundError("", err)
+	}
+
+	form := forms.NewRecordMerge(api.app, record)
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	report, err := form.Submit()
+	if err != nil {
+		return NewBadRequestError("Failed to merge the records.", err)
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
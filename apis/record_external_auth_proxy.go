@@ -0,0 +1,173 @@
+package apis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"golang.org/x/oauth2"
+)
+
+// bindRecordExternalAuthProxyApi registers the linked external auth
+// provider api proxy endpoint and the corresponding handler.
+func bindRecordExternalAuthProxyApi(app core.App, rg *echo.Group) {
+	api := recordExternalAuthProxyApi{app: app}
+
+	subGroup := rg.Group(
+		"/collections/:collection",
+		ActivityLogger(app),
+		LoadCollectionContext(app, models.CollectionTypeAuth),
+	)
+	subGroup.Any("/records/:id/external-auths/:provider/proxy", api.proxy, RequireAdminOrOwnerAuth("id"))
+}
+
+type recordExternalAuthProxyApi struct {
+	app core.App
+}
+
+// proxy forwards the current request to the "url" query parameter
+// using the stored OAuth2 token of the record linked external auth.
+//
+// Only urls under the provider config ProxyUrls are allowed.
+func (api *recordExternalAuthProxyApi) proxy(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("Missing collection context.", nil)
+	}
+
+	id := c.PathParam("id")
+	provider := c.PathParam("provider")
+	if id == "" || provider == "" {
+		return NewNotFoundError("", nil)
+	}
+
+	config, ok := api.app.Settings().FindAuthProviderConfig(provider)
+	if !ok || !config.Enabled || !config.StoreTokens {
+		return NewBadRequestError("The provider is not configured to store the OAuth2 tokens.", nil)
+	}
+
+	targetUrl := c.QueryParam("url")
+	if !config.IsProxyUrlAllowed(targetUrl) {
+		return NewBadRequestError("The url is not allowed to be proxied.", nil)
+	}
+
+	record, err := api.app.Dao().FindRecordById(collection.Id, id)
+	if err != nil || record == nil {
+		return NewNotFoundError("", err)
+	}
+
+	externalAuth, err := api.app.Dao().FindExternalAuthByRecordAndProvider(record, provider)
+	if err != nil {
+		return NewNotFoundError("Missing external auth provider relation.", err)
+	}
+
+	client, err := ExternalAuthClient(api.app, externalAuth)
+	if err != nil {
+		return NewBadRequestError("Failed to initialize the provider client.", err)
+	}
+
+	// don't follow redirects outside of the allowed urls
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) >= 10 || !config.IsProxyUrlAllowed(req.URL.String()) {
+			return http.ErrUseLastResponse
+		}
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(c.Request().Context(), c.Request().Method, targetUrl, c.Request().Body)
+	if err != nil {
+		return NewBadRequestError("Failed to create the provider request.", err)
+	}
+
+	for _, header := range []string{"Accept", "Content-Type"} {
+		if v := c.Request().Header.Get(header); v != "" {
+			req.Header.Set(header, v)
+		}
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return NewBadRequestError("Failed to send the provider request.", err)
+	}
+	defer res.Body.Close()
+
+	contentType := res.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = echo.MIMEOctetStream
+	}
+
+	return c.Stream(res.StatusCode, contentType, res.Body)
+}
+
+// ExternalAuthClient returns an http client that authorizes its
+// requests with the stored OAuth2 token of the provided external auth.
+//
+// The token is refreshed (and persisted) if expired. If the token
+// refresh is rejected by the provider (eg. the access was revoked),
+// the stored token is cleared and an error is returned.
+//
+// The provider must be configured with the StoreTokens option.
+func ExternalAuthClient(app core.App, externalAuth *models.ExternalAuth) (*http.Client, error) {
+	encryptionKey := os.Getenv(app.EncryptionEnv())
+
+	token, err := externalAuth.Token(encryptionKey)
+	if err != nil {
+		return nil, err
+	}
+	if token == nil {
+		return nil, errors.New("missing stored OAuth2 token")
+	}
+
+	provider, err := app.Settings().NewAuthProvider(externalAuth.Provider)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &oauth2.Config{
+		ClientID:     provider.ClientId(),
+		ClientSecret: provider.ClientSecret(),
+		Scopes:       provider.Scopes(),
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  provider.AuthUrl(),
+			TokenURL: provider.TokenUrl(),
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	newToken, err := config.TokenSource(ctx, token).Token()
+	if err != nil {
+		var retrieveErr *oauth2.RetrieveError
+		if errors.As(err, &retrieveErr) {
+			externalAuth.SetToken(nil, encryptionKey)
+			if saveErr := app.Dao().SaveExternalAuth(externalAuth); saveErr != nil {
+				return nil, saveErr
+			}
+			return nil, fmt.Errorf("the OAuth2 token was revoked or expired: %w", err)
+		}
+		return nil, err
+	}
+
+	if newToken.AccessToken != token.AccessToken {
+		if newToken.RefreshToken == "" {
+			newToken.RefreshToken = token.RefreshToken
+		}
+
+		if err := externalAuth.SetToken(newToken, encryptionKey); err != nil {
+			return nil, err
+		}
+
+		if err := app.Dao().SaveExternalAuth(externalAuth); err != nil {
+			return nil, err
+		}
+	}
+
+	return oauth2.NewClient(context.Background(), oauth2.StaticTokenSource(newToken)), nil
+}
@@ -0,0 +1,134 @@
+package apis
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/resolvers"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+// bindRecordFollowApi registers the record follow api endpoints
+// and the corresponding handlers.
+//
+// The followed records could be filtered in the list rules and
+// filters with the "@following(field)" macro.
+func bindRecordFollowApi(app core.App, rg *echo.Group) {
+	api := recordFollowApi{app: app}
+
+	subGroup := rg.Group(
+		"/collections/:collection/records/:id/follow",
+		ActivityLogger(app),
+		LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth),
+	)
+	subGroup.GET("", api.view)
+	subGroup.POST("", api.follow, RequireRecordAuth())
+	subGroup.DELETE("", api.unfollow, RequireRecordAuth())
+}
+
+type recordFollowApi struct {
+	app core.App
+}
+
+// recordFollowStatus defines the record follow api response.
+type recordFollowStatus struct {
+	// Following indicates whether the current auth record follows the record.
+	Following bool `json:"following"`
+
+	// Followers is the total number of the record followers.
+	Followers int `json:"followers"`
+}
+
+func (api *recordFollowApi) view(c echo.Context) error {
+	target, err := api.findTarget(c)
+	if err != nil {
+		return err
+	}
+
+	return api.respondWithStatus(c, target)
+}
+
+func (api *recordFollowApi) follow(c echo.Context) error {
+	target, err := api.findTarget(c)
+	if err != nil {
+		return err
+	}
+
+	follower, _ := c.Get(ContextAuthRecordKey).(*models.Record)
+
+	if err := api.app.Dao().FollowRecord(follower, target); err != nil {
+		return NewBadRequestError("Failed to follow record.", err)
+	}
+
+	return api.respondWithStatus(c, target)
+}
+
+func (api *recordFollowApi) unfollow(c echo.Context) error {
+	target, err := api.findTarget(c)
+	if err != nil {
+		return err
+	}
+
+	follower, _ := c.Get(ContextAuthRecordKey).(*models.Record)
+
+	if err := api.app.Dao().UnfollowRecord(follower, target); err != nil {
+		return NewBadRequestError("Failed to unfollow record.", err)
+	}
+
+	return api.respondWithStatus(c, target)
+}
+
+func (api *recordFollowApi) respondWithStatus(c echo.Context, target *models.Record) error {
+	status := &recordFollowStatus{}
+
+	if follower, _ := c.Get(ContextAuthRecordKey).(*models.Record); follower != nil {
+		follow, _ := api.app.Dao().FindFollow(follower, target)
+		status.Following = follow != nil
+	}
+
+	total, err := api.app.Dao().CountFollowers(target)
+	if err != nil {
+		return NewBadRequestError("Failed to count the record followers.", err)
+	}
+	status.Followers = total
+
+	return c.JSON(http.StatusOK, status)
+}
+
+// findTarget loads the followed record applying the collection view rule.
+func (api *recordFollowApi) findTarget(c echo.Context) (*models.Record, error) {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return nil, NewNotFoundError("", "Missing collection context.")
+	}
+
+	requestInfo := RequestInfo(c)
+
+	if requestInfo.Admin == nil && collection.ViewRule == nil {
+		// only admins can access if the rule is nil
+		return nil, NewForbiddenError("Only admins can perform this action.", nil).WithErrorCode(ErrorCodeRuleForbidden)
+	}
+
+	ruleFunc := func(q *dbx.SelectQuery) error {
+		if requestInfo.Admin == nil && collection.ViewRule != nil && *collection.ViewRule != "" {
+			resolver := resolvers.NewRecordFieldResolver(api.app.Dao(), collection, requestInfo, true)
+			expr, err := search.FilterData(*collection.ViewRule).BuildExpr(resolver)
+			if err != nil {
+				return err
+			}
+			resolver.UpdateQuery(q)
+			q.AndWhere(expr)
+		}
+		return nil
+	}
+
+	record, fetchErr := api.app.Dao().FindRecordById(collection.Id, c.PathParam("id"), ruleFunc)
+	if fetchErr != nil || record == nil {
+		return nil, NewNotFoundError("", fetchErr)
+	}
+
+	return record, nil
+}
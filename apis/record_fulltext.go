@@ -0,0 +1,42 @@
+package apis
+
+import (
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+// fullTextQueryParam is the records list query parameter
+// with the text to match against the collection full-text index.
+const fullTextQueryParam = "search"
+
+// applyFullTextSearch limits the provided records query only to the
+// collection records matching the full-text search text.
+//
+// If sortByRank is set, the matching records are ordered by their
+// relevance (the rest of the query sort fields are applied after it).
+//
+// The query is left unchanged if the text doesn't contain any word.
+func applyFullTextSearch(query *dbx.SelectQuery, collection *models.Collection, text string, sortByRank bool) {
+	matchQuery := search.FullTextMatchQuery(text)
+	if matchQuery == "" {
+		return
+	}
+
+	ftsTable := daos.FullTextTableName(collection.Id)
+
+	query.InnerJoin(
+		"{{"+ftsTable+"}}",
+		dbx.NewExp("[["+ftsTable+".recordId]] = [["+collection.Name+".id]]"),
+	)
+
+	query.AndWhere(dbx.NewExp("{{"+ftsTable+"}} MATCH {:fullTextQuery}", dbx.Params{
+		"fullTextQuery": matchQuery,
+	}))
+
+	if sortByRank {
+		// the lower the rank the better the match
+		query.OrderBy("[[" + ftsTable + ".rank]] ASC")
+	}
+}
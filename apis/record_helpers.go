@@ -45,6 +45,7 @@ func RequestInfo(c echo.Context) *models.RequestInfo {
 
 	result := &models.RequestInfo{
 		Method:  c.Request().Method,
+		Ip:      c.RealIP(),
 		Query:   map[string]any{},
 		Data:    map[string]any{},
 		Headers: map[string]any{},
@@ -60,6 +61,7 @@ func RequestInfo(c echo.Context) *models.RequestInfo {
 
 	result.AuthRecord, _ = c.Get(ContextAuthRecordKey).(*models.Record)
 	result.Admin, _ = c.Get(ContextAdminKey).(*models.Admin)
+	result.Geo, _ = c.Get(ContextRequestGeoKey).(map[string]any)
 	echo.BindQueryParams(c, &result.Query)
 	rest.BindBody(c, &result.Data)
 
@@ -77,11 +79,24 @@ func RecordAuthResponse(
 	meta any,
 	finalizers ...func(token string) error,
 ) error {
+	if authRecord.IsSoftDeleted() {
+		return NewForbiddenError("The account has been deleted.", nil)
+	}
+
 	if !authRecord.Verified() && authRecord.Collection().AuthOptions().OnlyVerified {
 		return NewForbiddenError("Please verify your email first.", nil)
 	}
 
-	token, tokenErr := tokens.NewRecordAuthToken(app, authRecord)
+	var sessionId string
+	if authRecord.Collection().AuthOptions().TrackSessions {
+		session, err := resolveAuthSession(app, c, authRecord)
+		if err != nil {
+			return NewBadRequestError("Failed to create auth session.", err)
+		}
+		sessionId = session.Id
+	}
+
+	token, tokenErr := tokens.NewRecordSessionAuthToken(app, authRecord, sessionId)
 	if tokenErr != nil {
 		return NewBadRequestError("Failed to create auth token.", tokenErr)
 	}
@@ -101,13 +116,18 @@ func RecordAuthResponse(
 		// allow always returning the email address of the authenticated account
 		e.Record.IgnoreEmailVisibility(true)
 
+		// create a copy of the cached request data and adjust it to the current auth record
+		requestInfo := *RequestInfo(e.HttpContext)
+		requestInfo.Admin = nil
+		requestInfo.AuthRecord = e.Record
+
+		if err := applyRecordsProfile(app.Dao(), []*models.Record{e.Record}, &requestInfo, c.QueryParam(profileQueryParam)); err != nil {
+			app.Logger().Debug("[RecordAuthResponse] Failed to apply the record profile", slog.String("error", err.Error()))
+		}
+
 		// expand record relations
 		expands := strings.Split(c.QueryParam(expandQueryParam), ",")
 		if len(expands) > 0 {
-			// create a copy of the cached request data and adjust it to the current auth record
-			requestInfo := *RequestInfo(e.HttpContext)
-			requestInfo.Admin = nil
-			requestInfo.AuthRecord = e.Record
 			failed := app.Dao().ExpandRecord(
 				e.Record,
 				expands,
@@ -133,6 +153,16 @@ func RecordAuthResponse(
 			}
 		}
 
+		if e.Collection.AuthOptions().AllowRefreshTokens {
+			refreshToken, err := createRefreshToken(app.Dao(), e.Record)
+			if err != nil {
+				return NewBadRequestError("Failed to create refresh token.", err)
+			}
+			result["refreshToken"] = refreshToken
+		}
+
+		setAuthCookie(app, e.HttpContext, e.Token, int(tokens.RecordAuthTokenDuration(app, e.Collection)))
+
 		return e.HttpContext.JSON(http.StatusOK, result)
 	})
 }
@@ -141,6 +171,8 @@ func RecordAuthResponse(
 //   - expands relations (if defaultExpands and/or ?expand query param is set)
 //   - ensures that the emails of the auth record and its expanded auth relations
 //     are visibe only for the current logged admin, record owner or record with manage access
+//   - restricts the exported fields based on the collection profiles (if any)
+//   - decrypts the encrypted tenant fields (if any)
 func EnrichRecord(c echo.Context, dao *daos.Dao, record *models.Record, defaultExpands ...string) error {
 	return EnrichRecords(c, dao, []*models.Record{record}, defaultExpands...)
 }
@@ -149,17 +181,37 @@ func EnrichRecord(c echo.Context, dao *daos.Dao, record *models.Record, defaultE
 //   - expands relations (if defaultExpands and/or ?expand query param is set)
 //   - ensures that the emails of the auth records and their expanded auth relations
 //     are visibe only for the current logged admin, record owner or record with manage access
+//   - restricts the exported fields based on the collection profiles (if any)
+//   - decrypts the encrypted tenant fields (if any)
 func EnrichRecords(c echo.Context, dao *daos.Dao, records []*models.Record, defaultExpands ...string) error {
-	requestInfo := RequestInfo(c)
+	expands := defaultExpands
+	if param := c.QueryParam(expandQueryParam); param != "" {
+		expands = append(expands, strings.Split(param, ",")...)
+	}
+
+	return enrichRecords(dao, RequestInfo(c), records, c.QueryParam(profileQueryParam), expands)
+}
+
+// enrichRecords is the request context independent version of [EnrichRecords].
+func enrichRecords(
+	dao *daos.Dao,
+	requestInfo *models.RequestInfo,
+	records []*models.Record,
+	profile string,
+	expands []string,
+) error {
+	if err := dao.DecryptRecords(records); err != nil {
+		return fmt.Errorf("Failed to decrypt the records: %w", err)
+	}
 
 	if err := autoIgnoreAuthRecordsEmailVisibility(dao, records, requestInfo); err != nil {
 		return fmt.Errorf("Failed to resolve email visibility: %w", err)
 	}
 
-	expands := defaultExpands
-	if param := c.QueryParam(expandQueryParam); param != "" {
-		expands = append(expands, strings.Split(param, ",")...)
+	if err := applyRecordsProfile(dao, records, requestInfo, profile); err != nil {
+		return fmt.Errorf("Failed to apply the records profile: %w", err)
 	}
+
 	if len(expands) == 0 {
 		return nil // nothing to expand
 	}
@@ -201,7 +253,9 @@ func expandFetch(
 		})
 
 		if err == nil && len(records) > 0 {
+			dao.DecryptRecords(records)
 			autoIgnoreAuthRecordsEmailVisibility(dao, records, requestInfo)
+			applyRecordsProfile(dao, records, requestInfo, "")
 		}
 
 		return records, err
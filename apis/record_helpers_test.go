@@ -29,6 +29,8 @@ func TestRequestInfo(t *testing.T) {
 	dummyAdmin.Id = "id2"
 	c.Set(apis.ContextAdminKey, dummyAdmin)
 
+	c.Set(apis.ContextRequestGeoKey, map[string]any{"country": "BG"})
+
 	result := apis.RequestInfo(c)
 
 	if result == nil {
@@ -39,6 +41,14 @@ func TestRequestInfo(t *testing.T) {
 		t.Fatalf("Expected Method %v, got %v", http.MethodPost, result.Method)
 	}
 
+	if result.Ip != "192.0.2.1" {
+		t.Fatalf("Expected Ip %v, got %v", "192.0.2.1", result.Ip)
+	}
+
+	if result.Geo["country"] != "BG" {
+		t.Fatalf("Expected Geo country %v, got %v", "BG", result.Geo)
+	}
+
 	rawHeaders, _ := json.Marshal(result.Headers)
 	expectedHeaders := `{"content_type":"application/json","x_token_test":"123"}`
 	if v := string(rawHeaders); v != expectedHeaders {
@@ -0,0 +1,247 @@
+package apis
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/resolvers"
+	"github.com/pocketbase/pocketbase/tools/search"
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+// bindRecordHistoryApi registers the record versions api endpoints
+// and the corresponding handlers.
+//
+// Listing the versions of an existing record requires the collection
+// view rule (the versions of the deleted records are visible only to admins)
+// and the version snapshots are serialized in the same way as the record.
+// Restoring a version is allowed only for admins.
+func bindRecordHistoryApi(app core.App, rg *echo.Group) {
+	api := recordHistoryApi{app: app}
+
+	subGroup := rg.Group(
+		"/collections/:collection/records/:id/versions",
+		ActivityLogger(app),
+		LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth),
+	)
+	subGroup.GET("", api.list)
+	subGroup.POST("/:versionId/restore", api.restore, RequireAdminAuth())
+}
+
+type recordHistoryApi struct {
+	app core.App
+}
+
+var recordVersionFilterFields = []string{
+	"rowid", "id", "created", "updated", "action",
+}
+
+func (api *recordHistoryApi) list(c echo.Context) error {
+	collection, err := api.collection(c)
+	if err != nil {
+		return err
+	}
+
+	requestInfo := RequestInfo(c)
+
+	recordId := c.PathParam("id")
+
+	var current *models.Record
+
+	if requestInfo.Admin == nil {
+		if collection.ViewRule == nil {
+			// only admins can access if the rule is nil
+			return NewForbiddenError("Only admins can perform this action.", nil).WithErrorCode(ErrorCodeRuleForbidden)
+		}
+
+		ruleFunc := func(q *dbx.SelectQuery) error {
+			if *collection.ViewRule == "" {
+				return nil
+			}
+			resolver := resolvers.NewRecordFieldResolver(api.app.Dao(), collection, requestInfo, true)
+			expr, err := search.FilterData(*collection.ViewRule).BuildExpr(resolver)
+			if err != nil {
+				return err
+			}
+			resolver.UpdateQuery(q)
+			q.AndWhere(expr)
+			return nil
+		}
+
+		record, fetchErr := api.app.Dao().FindRecordById(collection.Id, recordId, ruleFunc)
+		if fetchErr != nil || record == nil {
+			return NewNotFoundError("", fetchErr)
+		}
+		current = record
+	} else {
+		// the versions of the deleted records are also available for admins
+		current, _ = api.app.Dao().FindRecordById(collection.Id, recordId)
+	}
+
+	fieldResolver := search.NewSimpleFieldResolver(recordVersionFilterFields...)
+
+	provider := search.NewProvider(fieldResolver).
+		Query(api.app.Dao().RecordVersionsQuery(collection, recordId))
+
+	if c.QueryParam(search.SortQueryParam) == "" {
+		provider.Sort([]search.SortField{
+			{Name: "created", Direction: search.SortDesc},
+			{Name: "rowid", Direction: search.SortDesc},
+		})
+	}
+
+	versions := []*models.RecordVersion{}
+
+	result, err := provider.ParseAndExec(c.QueryParams().Encode(), &versions)
+	if err != nil {
+		return NewBadRequestError("", err)
+	}
+
+	if err := api.exportVersionsData(c, collection, current, versions); err != nil {
+		return NewBadRequestError("Failed to export the record versions.", err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// exportVersionsData replaces the raw data of the provided versions with
+// the public export of their snapshot records so that they are serialized
+// in the same way as the current record (aka. decrypted encrypted fields,
+// applied collection profile and email visibility).
+//
+// The profile and email visibility of the snapshots are resolved based on
+// the current record state (if the record was deleted only the decryption is applied).
+func (api *recordHistoryApi) exportVersionsData(
+	c echo.Context,
+	collection *models.Collection,
+	current *models.Record,
+	versions []*models.RecordVersion,
+) error {
+	if len(versions) == 0 {
+		return nil
+	}
+
+	var visibleFields []string
+	showEmail := true
+
+	if current != nil {
+		if err := enrichRecords(api.app.Dao(), RequestInfo(c), []*models.Record{current}, c.QueryParam(profileQueryParam), nil); err != nil {
+			return err
+		}
+
+		visibleFields = current.VisibleFields()
+
+		_, showEmail = current.PublicExport()[schema.FieldNameEmail]
+	}
+
+	snapshots := make([]*models.Record, len(versions))
+	for i, version := range versions {
+		snapshot := models.NewRecord(collection)
+		snapshot.Load(version.Data)
+		snapshot.Id = version.RecordId
+		snapshot.IgnoreEmailVisibility(true)
+		snapshot.SetVisibleFields(visibleFields)
+		snapshots[i] = snapshot
+	}
+
+	if err := api.app.Dao().DecryptRecords(snapshots); err != nil {
+		return err
+	}
+
+	for i, snapshot := range snapshots {
+		data := snapshot.PublicExport()
+
+		if !showEmail {
+			delete(data, schema.FieldNameEmail)
+		}
+
+		versions[i].Data = data
+	}
+
+	return nil
+}
+
+// restore replaces the record data with the one from the requested
+// version (the record is recreated if it was deleted).
+//
+// The current record state is stored as a new version,
+// so the restore operation itself could be reverted.
+func (api *recordHistoryApi) restore(c echo.Context) error {
+	collection, err := api.collection(c)
+	if err != nil {
+		return err
+	}
+
+	recordId := c.PathParam("id")
+
+	version, _ := api.app.Dao().FindRecordVersionById(c.PathParam("versionId"))
+	if version == nil || version.CollectionId != collection.Id || version.RecordId != recordId {
+		return NewNotFoundError("", nil)
+	}
+
+	record, _ := api.app.Dao().FindRecordById(collection.Id, recordId)
+	if record == nil {
+		record = models.NewRecord(collection)
+		record.Id = recordId
+		record.MarkAsNew()
+		record.Set(schema.FieldNameCreated, version.Data[schema.FieldNameCreated])
+
+		// the auth credentials are not part of the snapshot
+		if collection.IsAuth() {
+			if err := record.SetPassword(security.RandomString(30)); err != nil {
+				return NewBadRequestError("Failed to restore the record.", err)
+			}
+		}
+	}
+
+	for key, value := range version.Data {
+		switch key {
+		case schema.FieldNameId, schema.FieldNameCreated, schema.FieldNameUpdated:
+			continue
+		}
+
+		// skip the fields removed after the version creation
+		if collection.Schema.GetFieldByName(key) == nil && !(collection.IsAuth() && isAuthSnapshotField(key)) {
+			continue
+		}
+
+		record.Set(key, value)
+	}
+
+	if err := api.app.Dao().SaveRecord(record); err != nil {
+		return NewBadRequestError("Failed to restore the record.", err)
+	}
+
+	return c.JSON(http.StatusOK, record)
+}
+
+func (api *recordHistoryApi) collection(c echo.Context) (*models.Collection, error) {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return nil, NewNotFoundError("", "Missing collection context.")
+	}
+
+	if !collection.HistoryEnabled() {
+		return nil, NewBadRequestError("The collection history is not enabled.", nil)
+	}
+
+	return collection, nil
+}
+
+// isAuthSnapshotField checks whether the key is one of the
+// auth record system fields stored in the record versions.
+func isAuthSnapshotField(key string) bool {
+	switch key {
+	case schema.FieldNameUsername,
+		schema.FieldNameEmail,
+		schema.FieldNameEmailVisibility,
+		schema.FieldNameVerified:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,69 @@
+package apis
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// bindRecordImpersonateApi registers the auth record
+// impersonation api endpoint and its handler.
+func bindRecordImpersonateApi(app core.App, rg *echo.Group) {
+	api := recordImpersonateApi{app: app}
+
+	rg.POST(
+		"/collections/:collection/records/:id/impersonate",
+		api.impersonate,
+		ActivityLogger(app),
+		RequireAdminAuth(),
+		LoadCollectionContext(app, models.CollectionTypeAuth),
+	)
+}
+
+type recordImpersonateApi struct {
+	app core.App
+}
+
+// impersonate issues a short-lived and non-refreshable auth token
+// for the requested auth record (eg. to allow the support staff
+// reproducing user specific issues).
+func (api *recordImpersonateApi) impersonate(c echo.Context) error {
+	admin, _ := c.Get(ContextAdminKey).(*models.Admin)
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+
+	record, err := api.app.Dao().FindRecordById(collection.Id, c.PathParam("id"))
+	if err != nil || record == nil {
+		return NewNotFoundError("", err)
+	}
+
+	form := forms.NewRecordImpersonate(api.app, admin, record)
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	token, err := form.Submit()
+	if err != nil {
+		return NewBadRequestError("Failed to impersonate the auth record.", err)
+	}
+
+	if api.app.Settings().AuditLogs.Enabled {
+		entry := newAuditLog(c, models.AuditActionImpersonate, nil, map[string]any{
+			"duration": form.Duration,
+		})
+		entry.CollectionId = collection.Id
+		entry.CollectionName = collection.Name
+		entry.RecordId = record.Id
+
+		saveAuditLog(api.app, entry)
+	}
+
+	record.IgnoreEmailVisibility(true)
+
+	return c.JSON(http.StatusOK, map[string]any{
+		"token":  token,
+		"record": record,
+	})
+}
@@ -0,0 +1,46 @@
+package apis
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// bindRecordLedgerApi registers the admin only ledger
+// collections api endpoints and the corresponding handlers.
+func bindRecordLedgerApi(app core.App, rg *echo.Group) {
+	api := recordLedgerApi{app: app}
+
+	subGroup := rg.Group(
+		"/collections/:collection/ledger",
+		ActivityLogger(app),
+		RequireAdminAuth(),
+		LoadCollectionContext(app, models.CollectionTypeBase),
+	)
+	subGroup.GET("/verify", api.verify)
+}
+
+type recordLedgerApi struct {
+	app core.App
+}
+
+// verify recomputes and checks the collection ledger hash chain.
+func (api *recordLedgerApi) verify(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("", "Missing collection context.")
+	}
+
+	if !collection.LedgerEnabled() {
+		return NewBadRequestError("The collection is not a ledger.", nil)
+	}
+
+	result, err := api.app.Dao().VerifyLedger(collection)
+	if err != nil {
+		return NewBadRequestError("Failed to verify the collection ledger.", err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,187 @@
+package apis
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tokens"
+	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/spf13/cast"
+)
+
+// bindRecordMfaApi registers the auth record multi-factor
+// authentication api endpoints and the corresponding handlers.
+func bindRecordMfaApi(app core.App, rg *echo.Group) {
+	api := recordMfaApi{app: app}
+
+	subGroup := rg.Group(
+		"/collections/:collection/mfa",
+		ActivityLogger(app),
+		LoadCollectionContext(app, models.CollectionTypeAuth),
+		requireMfaEnabled(),
+		requireMfaRecordAuth(app),
+	)
+	subGroup.GET("", api.list)
+	subGroup.POST("/totp/setup", api.totpSetup)
+	subGroup.POST("/totp/enable", api.totpEnable)
+	subGroup.POST("/totp/disable", api.totpDisable)
+	subGroup.POST("/totp/recovery-codes", api.totpRecoveryCodes)
+}
+
+type recordMfaApi struct {
+	app core.App
+}
+
+// requireMfaEnabled middleware requires the loaded context
+// auth collection to have enabled MFA.
+func requireMfaEnabled() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+			if collection == nil || collection.AuthOptions().MfaMode == models.MfaModeDisabled {
+				return NewBadRequestError("The collection MFA is not enabled.", nil)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// requireMfaRecordAuth middleware requires a request from an auth record
+// of the context collection, authenticated either with a regular auth
+// token or with a MFA setup token (see [tokens.NewRecordMfaSetupToken]).
+func requireMfaRecordAuth(app core.App) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			record, _ := c.Get(ContextAuthRecordKey).(*models.Record)
+
+			if record == nil {
+				token := strings.TrimPrefix(c.Request().Header.Get("Authorization"), "Bearer ")
+				claims, _ := security.ParseUnverifiedJWT(token)
+
+				if cast.ToString(claims["type"]) == tokens.TypeMfaSetup {
+					record, _ = app.Dao().FindAuthRecordByToken(
+						token,
+						app.Settings().RecordAuthToken.Secret,
+						app.Settings().RecordAuthToken.PreviousSecrets()...,
+					)
+					if record != nil && !record.IsSoftDeleted() {
+						c.Set(ContextAuthRecordKey, record)
+					}
+				}
+			}
+
+			return RequireSameContextRecordAuth()(next)(c)
+		}
+	}
+}
+
+// mfaSetupRequiredResponse writes the password authentication response
+// of an auth record that is required to enroll an MFA factor first.
+func mfaSetupRequiredResponse(app core.App, c echo.Context, authRecord *models.Record) error {
+	token, err := tokens.NewRecordMfaSetupToken(app, authRecord)
+	if err != nil {
+		return NewBadRequestError("Failed to create MFA setup token.", err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{
+		"mfaSetupRequired": true,
+		"mfaSetupToken":    token,
+	})
+}
+
+// list returns the MFA factors of the current auth record.
+func (api *recordMfaApi) list(c echo.Context) error {
+	record, _ := c.Get(ContextAuthRecordKey).(*models.Record)
+
+	factors := []*models.MfaFactor{}
+
+	err := api.app.Dao().MfaFactorQuery().
+		AndWhere(dbx.HashExp{
+			"collectionId": record.Collection().Id,
+			"recordId":     record.Id,
+		}).
+		OrderBy("created ASC").
+		All(&factors)
+	if err != nil {
+		return NewBadRequestError("Failed to load the MFA factors.", err)
+	}
+
+	return c.JSON(http.StatusOK, factors)
+}
+
+// totpSetup generates a new pending TOTP factor for the current auth record.
+func (api *recordMfaApi) totpSetup(c echo.Context) error {
+	record, _ := c.Get(ContextAuthRecordKey).(*models.Record)
+
+	factor, err := forms.NewRecordTOTPSetup(api.app, record).Submit()
+	if err != nil {
+		return NewBadRequestError("Failed to setup the TOTP factor.", err)
+	}
+
+	account := record.Email()
+	if account == "" {
+		account = record.Username()
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{
+		"secret": factor.Secret,
+		"uri":    security.TOTPURI(api.app.Settings().Meta.AppName, account, factor.Secret),
+	})
+}
+
+// totpEnable confirms the current auth record pending TOTP factor
+// and returns its recovery codes.
+func (api *recordMfaApi) totpEnable(c echo.Context) error {
+	record, _ := c.Get(ContextAuthRecordKey).(*models.Record)
+
+	form := forms.NewRecordTOTPEnable(api.app, record)
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	codes, err := form.Submit()
+	if err != nil {
+		return NewBadRequestError("Failed to enable the TOTP factor.", err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{"recoveryCodes": codes})
+}
+
+// totpDisable deletes the current auth record enabled TOTP factor.
+func (api *recordMfaApi) totpDisable(c echo.Context) error {
+	record, _ := c.Get(ContextAuthRecordKey).(*models.Record)
+
+	form := forms.NewRecordTOTPDisable(api.app, record)
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	if err := form.Submit(); err != nil {
+		return NewBadRequestError("Failed to disable the TOTP factor.", err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// totpRecoveryCodes regenerates the current auth record TOTP factor recovery codes.
+func (api *recordMfaApi) totpRecoveryCodes(c echo.Context) error {
+	record, _ := c.Get(ContextAuthRecordKey).(*models.Record)
+
+	form := forms.NewRecordTOTPRecoveryCodes(api.app, record)
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	codes, err := form.Submit()
+	if err != nil {
+		return NewBadRequestError("Failed to regenerate the recovery codes.", err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{"recoveryCodes": codes})
+}
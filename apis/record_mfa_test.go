@@ -0,0 +1,498 @@
+package apis_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tokens"
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+// the TOTP secret of the users oap640cot4yru2s test factor
+const testTOTPSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// enableMfa changes the users collection MFA mode.
+func enableMfa(t *testing.T, app *tests.TestApp, mode string) {
+	_, err := tests.UpdateAuthOptions(app, "users", func(options *models.CollectionAuthOptions) {
+		options.MfaMode = mode
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// currentTOTPCode returns the current TOTP code of the test factor secret.
+func currentTOTPCode(t *testing.T) string {
+	code, err := security.TOTPCode(testTOTPSecret, security.TOTPStep(time.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return code
+}
+
+func TestRecordMfaAuthWithPassword(t *testing.T) {
+	t.Parallel()
+
+	code := currentTOTPCode(t)
+
+	scenarios := []tests.ApiScenario{
+		{
+			Name:   "disabled mfa",
+			Method: http.MethodPost,
+			Url:    "/api/collections/users/auth-with-password",
+			Body: strings.NewReader(`{
+				"identity":"test2@example.com",
+				"password":"1234567890"
+			}`),
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"token":"`,
+				`"id":"oap640cot4yru2s"`,
+			},
+			ExpectedEvents: map[string]int{
+				"OnRecordBeforeAuthWithPasswordRequest": 1,
+				"OnRecordAfterAuthWithPasswordRequest":  1,
+				"OnRecordAuthRequest":                   1,
+			},
+		},
+		{
+			Name:   "optional mfa without enabled factor",
+			Method: http.MethodPost,
+			Url:    "/api/collections/users/auth-with-password",
+			Body: strings.NewReader(`{
+				"identity":"test@example.com",
+				"password":"1234567890"
+			}`),
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				enableMfa(t, app, models.MfaModeOptional)
+			},
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"token":"`,
+				`"id":"4q1xlclmfloku33"`,
+			},
+			ExpectedEvents: map[string]int{
+				"OnRecordBeforeAuthWithPasswordRequest": 1,
+				"OnRecordAfterAuthWithPasswordRequest":  1,
+				"OnRecordAuthRequest":                   1,
+			},
+		},
+		{
+			Name:   "optional mfa with enabled factor and missing otp",
+			Method: http.MethodPost,
+			Url:    "/api/collections/users/auth-with-password",
+			Body: strings.NewReader(`{
+				"identity":"test2@example.com",
+				"password":"1234567890"
+			}`),
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				enableMfa(t, app, models.MfaModeOptional)
+			},
+			ExpectedStatus: 400,
+			ExpectedContent: []string{
+				`"otp":{"code":"validation_mfa_required"`,
+			},
+			NotExpectedContent: []string{`"token":"`},
+			ExpectedEvents: map[string]int{
+				"OnRecordBeforeAuthWithPasswordRequest": 1,
+			},
+		},
+		{
+			Name:   "optional mfa with enabled factor and invalid otp",
+			Method: http.MethodPost,
+			Url:    "/api/collections/users/auth-with-password",
+			Body: strings.NewReader(`{
+				"identity":"test2@example.com",
+				"password":"1234567890",
+				"otp":"000000"
+			}`),
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				enableMfa(t, app, models.MfaModeOptional)
+			},
+			ExpectedStatus: 400,
+			ExpectedContent: []string{
+				`"otp":{"code":"validation_invalid_mfa_code"`,
+			},
+			NotExpectedContent: []string{`"token":"`},
+			ExpectedEvents: map[string]int{
+				"OnRecordBeforeAuthWithPasswordRequest": 1,
+			},
+		},
+		{
+			Name:   "optional mfa with enabled factor and valid totp code",
+			Method: http.MethodPost,
+			Url:    "/api/collections/users/auth-with-password",
+			Body: strings.NewReader(`{
+				"identity":"test2@example.com",
+				"password":"1234567890",
+				"otp":"` + code + `"
+			}`),
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				enableMfa(t, app, models.MfaModeOptional)
+			},
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"token":"`,
+				`"id":"oap640cot4yru2s"`,
+			},
+			ExpectedEvents: map[string]int{
+				"OnModelBeforeUpdate":                   1,
+				"OnModelAfterUpdate":                    1,
+				"OnRecordBeforeAuthWithPasswordRequest": 1,
+				"OnRecordAfterAuthWithPasswordRequest":  1,
+				"OnRecordAuthRequest":                   1,
+			},
+			AfterTestFunc: func(t *testing.T, app *tests.TestApp, res *http.Response) {
+				factor, err := app.Dao().FindMfaFactorById("t0tpf4ct0r00001")
+				if err != nil {
+					t.Fatal(err)
+				}
+				if factor.LastUsedStep == 0 {
+					t.Fatal("Expected the factor last used step to be updated")
+				}
+			},
+		},
+		{
+			Name:   "optional mfa with enabled factor and valid recovery code",
+			Method: http.MethodPost,
+			Url:    "/api/collections/users/auth-with-password",
+			Body: strings.NewReader(`{
+				"identity":"test2@example.com",
+				"password":"1234567890",
+				"otp":"RECOV-ERY1234"
+			}`),
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				enableMfa(t, app, models.MfaModeOptional)
+			},
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"token":"`,
+				`"id":"oap640cot4yru2s"`,
+			},
+			ExpectedEvents: map[string]int{
+				"OnModelBeforeUpdate":                   1,
+				"OnModelAfterUpdate":                    1,
+				"OnRecordBeforeAuthWithPasswordRequest": 1,
+				"OnRecordAfterAuthWithPasswordRequest":  1,
+				"OnRecordAuthRequest":                   1,
+			},
+			AfterTestFunc: func(t *testing.T, app *tests.TestApp, res *http.Response) {
+				factor, err := app.Dao().FindMfaFactorById("t0tpf4ct0r00001")
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(factor.RecoveryCodes) != 0 {
+					t.Fatalf("Expected the used recovery code to be removed, got %v", factor.RecoveryCodes)
+				}
+			},
+		},
+		{
+			Name:   "required mfa without enabled factor",
+			Method: http.MethodPost,
+			Url:    "/api/collections/users/auth-with-password",
+			Body: strings.NewReader(`{
+				"identity":"test@example.com",
+				"password":"1234567890"
+			}`),
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				enableMfa(t, app, models.MfaModeRequired)
+			},
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"mfaSetupRequired":true`,
+				`"mfaSetupToken":"`,
+			},
+			NotExpectedContent: []string{
+				`"token":"`,
+				`"record":`,
+			},
+			ExpectedEvents: map[string]int{
+				"OnRecordBeforeAuthWithPasswordRequest": 1,
+			},
+		},
+		{
+			Name:   "required mfa with enabled factor and invalid password",
+			Method: http.MethodPost,
+			Url:    "/api/collections/users/auth-with-password",
+			Body: strings.NewReader(`{
+				"identity":"test2@example.com",
+				"password":"invalid",
+				"otp":"` + code + `"
+			}`),
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				enableMfa(t, app, models.MfaModeRequired)
+			},
+			ExpectedStatus:  400,
+			ExpectedContent: []string{`"data":{}`},
+			ExpectedEvents: map[string]int{
+				"OnRecordBeforeAuthWithPasswordRequest": 1,
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
+
+func TestRecordMfaTotp(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	user1, err := app.Dao().FindRecordById("users", "4q1xlclmfloku33")
+	if err != nil {
+		t.Fatal(err)
+	}
+	user1Token, err := tokens.NewRecordAuthToken(app, user1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	user1SetupToken, err := tokens.NewRecordMfaSetupToken(app, user1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user2, err := app.Dao().FindRecordById("users", "oap640cot4yru2s")
+	if err != nil {
+		t.Fatal(err)
+	}
+	user2Token, err := tokens.NewRecordAuthToken(app, user2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	code := currentTOTPCode(t)
+
+	optionalMfa := func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+		enableMfa(t, app, models.MfaModeOptional)
+	}
+
+	// creates a pending TOTP factor with the test secret for user1
+	pendingFactor := func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+		enableMfa(t, app, models.MfaModeOptional)
+
+		factor := &models.MfaFactor{
+			CollectionId: user1.Collection().Id,
+			RecordId:     user1.Id,
+			Type:         models.MfaFactorTypeTOTP,
+			Secret:       testTOTPSecret,
+		}
+		if err := app.Dao().WithoutHooks().SaveMfaFactor(factor); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	scenarios := []tests.ApiScenario{
+		{
+			Name:            "disabled collection mfa",
+			Method:          http.MethodGet,
+			Url:             "/api/collections/users/mfa",
+			RequestHeaders:  map[string]string{"Authorization": user2Token},
+			ExpectedStatus:  400,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:            "list as guest",
+			Method:          http.MethodGet,
+			Url:             "/api/collections/users/mfa",
+			BeforeTestFunc:  optionalMfa,
+			ExpectedStatus:  401,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:           "list as auth record",
+			Method:         http.MethodGet,
+			Url:            "/api/collections/users/mfa",
+			RequestHeaders: map[string]string{"Authorization": user2Token},
+			BeforeTestFunc: optionalMfa,
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"id":"t0tpf4ct0r00001"`,
+				`"type":"totp"`,
+				`"enabled":true`,
+			},
+			NotExpectedContent: []string{
+				`"secret"`,
+				`"recoveryCodes"`,
+			},
+		},
+		{
+			Name:            "setup with already enabled factor",
+			Method:          http.MethodPost,
+			Url:             "/api/collections/users/mfa/totp/setup",
+			RequestHeaders:  map[string]string{"Authorization": user2Token},
+			BeforeTestFunc:  optionalMfa,
+			ExpectedStatus:  400,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:           "setup as auth record",
+			Method:         http.MethodPost,
+			Url:            "/api/collections/users/mfa/totp/setup",
+			RequestHeaders: map[string]string{"Authorization": user1Token},
+			BeforeTestFunc: optionalMfa,
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"secret":"`,
+				`"uri":"otpauth://totp/`,
+			},
+			ExpectedEvents: map[string]int{
+				"OnModelBeforeCreate": 1,
+				"OnModelAfterCreate":  1,
+			},
+			AfterTestFunc: func(t *testing.T, app *tests.TestApp, res *http.Response) {
+				factor, err := app.Dao().FindMfaFactor(user1, models.MfaFactorTypeTOTP)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if factor.Enabled {
+					t.Fatal("Expected pending (not enabled) factor")
+				}
+			},
+		},
+		{
+			Name:           "setup with mfa setup token",
+			Method:         http.MethodPost,
+			Url:            "/api/collections/users/mfa/totp/setup",
+			RequestHeaders: map[string]string{"Authorization": user1SetupToken},
+			BeforeTestFunc: optionalMfa,
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"secret":"`,
+				`"uri":"otpauth://totp/`,
+			},
+			ExpectedEvents: map[string]int{
+				"OnModelBeforeCreate": 1,
+				"OnModelAfterCreate":  1,
+			},
+		},
+		{
+			Name:            "mfa setup token is not a regular auth token",
+			Method:          http.MethodPost,
+			Url:             "/api/collections/users/auth-refresh",
+			RequestHeaders:  map[string]string{"Authorization": user1SetupToken},
+			BeforeTestFunc:  optionalMfa,
+			ExpectedStatus:  401,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:            "enable without setup",
+			Method:          http.MethodPost,
+			Url:             "/api/collections/users/mfa/totp/enable",
+			Body:            strings.NewReader(`{"code":"` + code + `"}`),
+			RequestHeaders:  map[string]string{"Authorization": user1Token},
+			BeforeTestFunc:  optionalMfa,
+			ExpectedStatus:  400,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:           "enable with invalid code",
+			Method:         http.MethodPost,
+			Url:            "/api/collections/users/mfa/totp/enable",
+			Body:           strings.NewReader(`{"code":"000000"}`),
+			RequestHeaders: map[string]string{"Authorization": user1Token},
+			BeforeTestFunc: pendingFactor,
+			ExpectedStatus: 400,
+			ExpectedContent: []string{
+				`"code":{"code":"validation_invalid_mfa_code"`,
+			},
+		},
+		{
+			Name:           "enable with valid code",
+			Method:         http.MethodPost,
+			Url:            "/api/collections/users/mfa/totp/enable",
+			Body:           strings.NewReader(`{"code":"` + code + `"}`),
+			RequestHeaders: map[string]string{"Authorization": user1Token},
+			BeforeTestFunc: pendingFactor,
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"recoveryCodes":["`,
+			},
+			ExpectedEvents: map[string]int{
+				"OnModelBeforeUpdate": 1,
+				"OnModelAfterUpdate":  1,
+			},
+			AfterTestFunc: func(t *testing.T, app *tests.TestApp, res *http.Response) {
+				factor, err := app.Dao().FindMfaFactor(user1, models.MfaFactorTypeTOTP)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !factor.Enabled || len(factor.RecoveryCodes) != 10 {
+					t.Fatalf("Expected enabled factor with 10 recovery codes, got %v", factor)
+				}
+			},
+		},
+		{
+			Name:           "disable with invalid code",
+			Method:         http.MethodPost,
+			Url:            "/api/collections/users/mfa/totp/disable",
+			Body:           strings.NewReader(`{"code":"000000"}`),
+			RequestHeaders: map[string]string{"Authorization": user2Token},
+			BeforeTestFunc: optionalMfa,
+			ExpectedStatus: 400,
+			ExpectedContent: []string{
+				`"code":{"code":"validation_invalid_mfa_code"`,
+			},
+		},
+		{
+			Name:           "disable with recovery code",
+			Method:         http.MethodPost,
+			Url:            "/api/collections/users/mfa/totp/disable",
+			Body:           strings.NewReader(`{"code":"recov-ery1234"}`),
+			RequestHeaders: map[string]string{"Authorization": user2Token},
+			BeforeTestFunc: optionalMfa,
+			ExpectedStatus: 204,
+			ExpectedEvents: map[string]int{
+				"OnModelBeforeUpdate": 1,
+				"OnModelAfterUpdate":  1,
+				"OnModelBeforeDelete": 1,
+				"OnModelAfterDelete":  1,
+			},
+			AfterTestFunc: func(t *testing.T, app *tests.TestApp, res *http.Response) {
+				if _, err := app.Dao().FindMfaFactor(user2, models.MfaFactorTypeTOTP); err == nil {
+					t.Fatal("Expected the factor to be deleted")
+				}
+			},
+		},
+		{
+			Name:           "recovery codes regeneration with recovery code",
+			Method:         http.MethodPost,
+			Url:            "/api/collections/users/mfa/totp/recovery-codes",
+			Body:           strings.NewReader(`{"code":"recovery1234"}`),
+			RequestHeaders: map[string]string{"Authorization": user2Token},
+			BeforeTestFunc: optionalMfa,
+			ExpectedStatus: 400,
+			ExpectedContent: []string{
+				`"code":{"code":"validation_length_invalid"`,
+			},
+		},
+		{
+			Name:           "recovery codes regeneration with totp code",
+			Method:         http.MethodPost,
+			Url:            "/api/collections/users/mfa/totp/recovery-codes",
+			Body:           strings.NewReader(`{"code":"` + code + `"}`),
+			RequestHeaders: map[string]string{"Authorization": user2Token},
+			BeforeTestFunc: optionalMfa,
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"recoveryCodes":["`,
+			},
+			ExpectedEvents: map[string]int{
+				"OnModelBeforeUpdate": 1,
+				"OnModelAfterUpdate":  1,
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
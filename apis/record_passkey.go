@@ -0,0 +1,225 @@
+package apis
+
+import (
+	"encoding/base64"
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tokens"
+	"github.com/pocketbase/pocketbase/tools/webauthn"
+)
+
+// bindRecordPasskeyApi registers the auth record passkeys (aka. WebAuthn
+// credentials) api endpoints and the corresponding handlers.
+func bindRecordPasskeyApi(app core.App, rg *echo.Group) {
+	api := recordPasskeyApi{app: app}
+
+	subGroup := rg.Group(
+		"/collections/:collection",
+		ActivityLogger(app),
+		LoadCollectionContext(app, models.CollectionTypeAuth),
+	)
+	subGroup.POST("/request-passkey-registration", api.requestRegistration, requirePasskeyAuthEnabled(), RequireSameContextRecordAuth())
+	subGroup.POST("/confirm-passkey-registration", api.confirmRegistration, requirePasskeyAuthEnabled(), RequireSameContextRecordAuth())
+	subGroup.POST("/request-passkey-auth", api.requestAuth, requirePasskeyAuthEnabled())
+	subGroup.POST("/auth-with-passkey", api.authWithPasskey, requirePasskeyAuthEnabled())
+	subGroup.GET("/records/:id/passkeys", api.list, RequireAdminOrOwnerAuth("id"))
+	subGroup.DELETE("/records/:id/passkeys/:passkeyId", api.delete, RequireAdminOrOwnerAuth("id"))
+}
+
+type recordPasskeyApi struct {
+	app core.App
+}
+
+// passkeyChallengeTimeout is the client ceremony timeout in milliseconds.
+const passkeyChallengeTimeout = tokens.PasskeyChallengeTokenDuration * 1000
+
+// requirePasskeyAuthEnabled middleware requires the loaded
+// context auth collection to have enabled passkeys.
+func requirePasskeyAuthEnabled() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+			if collection == nil || !collection.AuthOptions().AllowPasskeyAuth {
+				return NewBadRequestError("The collection is not configured to allow passkeys.", nil)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// requestRegistration returns the "navigator.credentials.create()"
+// options and challenge token for a new passkey of the current auth record.
+func (api *recordPasskeyApi) requestRegistration(c echo.Context) error {
+	record, _ := c.Get(ContextAuthRecordKey).(*models.Record)
+	collection := record.Collection()
+
+	challenge := webauthn.NewChallenge()
+
+	token, err := tokens.NewPasskeyChallengeToken(api.app, collection, record, challenge)
+	if err != nil {
+		return NewBadRequestError("Failed to create passkey challenge token.", err)
+	}
+
+	existing, err := api.app.Dao().FindAllPasskeysByRecord(record)
+	if err != nil {
+		return NewBadRequestError("Failed to load the existing passkeys.", err)
+	}
+
+	excludeCredentials := make([]map[string]any, 0, len(existing))
+	for _, passkey := range existing {
+		excludeCredentials = append(excludeCredentials, map[string]any{
+			"type": "public-key",
+			"id":   passkey.CredentialId,
+		})
+	}
+
+	pubKeyCredParams := make([]map[string]any, 0, len(webauthn.SupportedAlgorithms))
+	for _, alg := range webauthn.SupportedAlgorithms {
+		pubKeyCredParams = append(pubKeyCredParams, map[string]any{
+			"type": "public-key",
+			"alg":  alg,
+		})
+	}
+
+	userName := record.Email()
+	if userName == "" {
+		userName = record.Username()
+	}
+
+	config := forms.PasskeyConfig(api.app, collection)
+
+	return c.JSON(http.StatusOK, map[string]any{
+		"token": token,
+		"options": map[string]any{
+			"challenge": challenge,
+			"rp": map[string]any{
+				"id":   config.RPId,
+				"name": api.app.Settings().Meta.AppName,
+			},
+			"user": map[string]any{
+				"id":          base64.RawURLEncoding.EncodeToString([]byte(record.Id)),
+				"name":        userName,
+				"displayName": userName,
+			},
+			"pubKeyCredParams":   pubKeyCredParams,
+			"excludeCredentials": excludeCredentials,
+			"timeout":            passkeyChallengeTimeout,
+			"attestation":        "none",
+			"authenticatorSelection": map[string]any{
+				"residentKey":        "required",
+				"requireResidentKey": true,
+				"userVerification":   "preferred",
+			},
+		},
+	})
+}
+
+// confirmRegistration verifies and stores a new passkey of the current auth record.
+func (api *recordPasskeyApi) confirmRegistration(c echo.Context) error {
+	record, _ := c.Get(ContextAuthRecordKey).(*models.Record)
+
+	form := forms.NewRecordPasskeyRegister(api.app, record)
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	passkey, err := form.Submit()
+	if err != nil {
+		return NewBadRequestError("Failed to register the passkey.", err)
+	}
+
+	return c.JSON(http.StatusOK, passkey)
+}
+
+// requestAuth returns the "navigator.credentials.get()"
+// options and challenge token for a passkey authentication.
+func (api *recordPasskeyApi) requestAuth(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+
+	challenge := webauthn.NewChallenge()
+
+	token, err := tokens.NewPasskeyChallengeToken(api.app, collection, nil, challenge)
+	if err != nil {
+		return NewBadRequestError("Failed to create passkey challenge token.", err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{
+		"token": token,
+		"options": map[string]any{
+			"challenge":        challenge,
+			"rpId":             forms.PasskeyConfig(api.app, collection).RPId,
+			"timeout":          passkeyChallengeTimeout,
+			"userVerification": "preferred",
+		},
+	})
+}
+
+// authWithPasskey authenticates an auth record with one of its passkeys.
+func (api *recordPasskeyApi) authWithPasskey(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+
+	form := forms.NewRecordPasskeyLogin(api.app, collection)
+	if readErr := c.Bind(form); readErr != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", readErr)
+	}
+
+	record, submitErr := form.Submit()
+	if submitErr != nil {
+		failure := &core.SecurityEvent{
+			Type:      core.SecurityEventAuthFailure,
+			ActorType: core.SecurityActorAuthRecord,
+			Data: map[string]any{
+				"method":     "passkey",
+				"collection": collection.Name,
+			},
+		}
+		emitSecurityEvent(api.app, c, failure)
+
+		return NewBadRequestError("Failed to authenticate.", submitErr)
+	}
+
+	return RecordAuthResponse(api.app, c, record, nil)
+}
+
+// list returns the passkeys of the specified auth record.
+func (api *recordPasskeyApi) list(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+
+	record, err := api.app.Dao().FindRecordById(collection.Id, c.PathParam("id"))
+	if err != nil || record == nil {
+		return NewNotFoundError("", err)
+	}
+
+	passkeys, err := api.app.Dao().FindAllPasskeysByRecord(record)
+	if err != nil {
+		return NewBadRequestError("Failed to fetch the passkeys of the specified auth record.", err)
+	}
+
+	return c.JSON(http.StatusOK, passkeys)
+}
+
+// delete removes a single passkey of the specified auth record.
+func (api *recordPasskeyApi) delete(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+
+	record, err := api.app.Dao().FindRecordById(collection.Id, c.PathParam("id"))
+	if err != nil || record == nil {
+		return NewNotFoundError("", err)
+	}
+
+	passkey, err := api.app.Dao().FindPasskeyById(c.PathParam("passkeyId"))
+	if err != nil || passkey.CollectionId != collection.Id || passkey.RecordId != record.Id {
+		return NewNotFoundError("", err)
+	}
+
+	if err := api.app.Dao().DeletePasskey(passkey); err != nil {
+		return NewBadRequestError("Failed to delete the passkey.", err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
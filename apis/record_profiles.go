@@ -0,0 +1,165 @@
+package apis
+
+import (
+	"fmt"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/resolvers"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+const profileQueryParam = "profile"
+
+// applyRecordsProfile restricts the exported fields of the provided records
+// based on the serialization profiles of their collection.
+//
+// If requestedProfile is set, it is applied to the records that satisfy
+// its rule. For the rest of the records (or when no profile is requested)
+// the first profile whose rule is satisfied is applied. Records that don't
+// satisfy any of the profiles are exported only with their system fields.
+//
+// Admins are not restricted unless they explicitly request a profile.
+//
+// On error all records are restricted to their system fields.
+//
+// Note: Expects all records to be from the same collection!
+func applyRecordsProfile(
+	dao *daos.Dao,
+	records []*models.Record,
+	requestInfo *models.RequestInfo,
+	requestedProfile string,
+) error {
+	err := resolveRecordsProfile(dao, records, requestInfo, requestedProfile)
+	if err != nil {
+		for _, r := range records {
+			r.SetVisibleFields([]string{})
+		}
+	}
+
+	return err
+}
+
+func resolveRecordsProfile(
+	dao *daos.Dao,
+	records []*models.Record,
+	requestInfo *models.RequestInfo,
+	requestedProfile string,
+) error {
+	if len(records) == 0 {
+		return nil // nothing to apply
+	}
+
+	collection := records[0].Collection()
+
+	profiles := collection.Profiles()
+	if len(profiles) == 0 {
+		return nil // no profiles
+	}
+
+	isAdmin := requestInfo != nil && requestInfo.Admin != nil
+
+	if requestedProfile != "" {
+		// move the requested profile at the front so that it is checked first
+		ordered := make([]models.CollectionProfile, 0, len(profiles))
+		for _, p := range profiles {
+			if p.Name == requestedProfile {
+				ordered = append([]models.CollectionProfile{p}, ordered...)
+			} else {
+				ordered = append(ordered, p)
+			}
+		}
+
+		if ordered[0].Name != requestedProfile {
+			return fmt.Errorf("unknown profile %q", requestedProfile)
+		}
+
+		profiles = ordered
+
+		if isAdmin {
+			for _, r := range records {
+				r.SetVisibleFields(profiles[0].Fields)
+			}
+			return nil
+		}
+	} else if isAdmin {
+		return nil // admins can see everything
+	}
+
+	pending := make(map[string]*models.Record, len(records))
+	for _, r := range records {
+		pending[r.Id] = r
+	}
+
+	for _, profile := range profiles {
+		if len(pending) == 0 {
+			break
+		}
+
+		matchedIds, err := profileMatchingRecordIds(dao, collection, pending, profile.Rule, requestInfo)
+		if err != nil {
+			return err
+		}
+
+		for _, id := range matchedIds {
+			if r, ok := pending[id]; ok {
+				r.SetVisibleFields(profile.Fields)
+				delete(pending, id)
+			}
+		}
+	}
+
+	// no matching profile
+	for _, r := range pending {
+		r.SetVisibleFields([]string{})
+	}
+
+	return nil
+}
+
+// profileMatchingRecordIds returns the ids of the provided records that satisfy the profile rule.
+func profileMatchingRecordIds(
+	dao *daos.Dao,
+	collection *models.Collection,
+	records map[string]*models.Record,
+	rule *string,
+	requestInfo *models.RequestInfo,
+) ([]string, error) {
+	if rule == nil {
+		return nil, nil // admins only
+	}
+
+	if *rule == "" {
+		ids := make([]string, 0, len(records))
+		for id := range records {
+			ids = append(ids, id)
+		}
+		return ids, nil
+	}
+
+	recordIds := make([]any, 0, len(records))
+	for id := range records {
+		recordIds = append(recordIds, id)
+	}
+
+	result := []string{}
+
+	query := dao.RecordQuery(collection).
+		Select(dao.DB().QuoteSimpleColumnName(collection.Name) + ".id").
+		AndWhere(dbx.In(dao.DB().QuoteSimpleColumnName(collection.Name)+".id", recordIds...))
+
+	resolver := resolvers.NewRecordFieldResolver(dao, collection, requestInfo, true)
+	expr, err := search.FilterData(*rule).BuildExpr(resolver)
+	if err != nil {
+		return nil, err
+	}
+	resolver.UpdateQuery(query)
+	query.AndWhere(expr)
+
+	if err := query.Column(&result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,164 @@
+package apis
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/resolvers"
+	"github.com/pocketbase/pocketbase/tools/search"
+	"github.com/spf13/cast"
+)
+
+// bindRecordReactionApi registers the record reactions api endpoints
+// and the corresponding handlers.
+func bindRecordReactionApi(app core.App, rg *echo.Group) {
+	api := recordReactionApi{app: app}
+
+	subGroup := rg.Group(
+		"/collections/:collection/records/:id/reactions",
+		ActivityLogger(app),
+		LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth),
+	)
+	subGroup.GET("", api.view)
+	subGroup.POST("/:type", api.toggle, RequireRecordAuth())
+}
+
+type recordReactionApi struct {
+	app core.App
+}
+
+// recordReactions defines the record reactions api response.
+type recordReactions struct {
+	// Counts is the number of reactions per type.
+	Counts map[string]int `json:"counts"`
+
+	// Own is the list of the current auth record reaction types.
+	Own []string `json:"own"`
+}
+
+type recordReactionToggle struct {
+	recordReactions
+
+	// Active indicates whether the toggled reaction exists after the toggle.
+	Active bool `json:"active"`
+}
+
+func (api *recordReactionApi) view(c echo.Context) error {
+	record, err := api.findRecord(c)
+	if err != nil {
+		return err
+	}
+
+	result, err := api.reactions(c, record)
+	if err != nil {
+		return NewBadRequestError("Failed to load the record reactions.", err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (api *recordReactionApi) toggle(c echo.Context) error {
+	record, err := api.findRecord(c)
+	if err != nil {
+		return err
+	}
+
+	reactionType := c.PathParam("type")
+	if !slices.Contains(record.Collection().ReactionTypes(), reactionType) {
+		return NewBadRequestError("Invalid reaction type.", nil)
+	}
+
+	authRecord, _ := c.Get(ContextAuthRecordKey).(*models.Record)
+
+	active, err := api.app.Dao().ToggleRecordReaction(record, authRecord, reactionType)
+	if err != nil {
+		return NewBadRequestError("Failed to toggle the record reaction.", err)
+	}
+
+	// reload to get the updated counters
+	record, err = api.app.Dao().FindRecordById(record.Collection().Id, record.Id)
+	if err != nil {
+		return NewBadRequestError("Failed to load the record reactions.", err)
+	}
+
+	reactions, err := api.reactions(c, record)
+	if err != nil {
+		return NewBadRequestError("Failed to load the record reactions.", err)
+	}
+
+	return c.JSON(http.StatusOK, &recordReactionToggle{
+		recordReactions: *reactions,
+		Active:          active,
+	})
+}
+
+func (api *recordReactionApi) reactions(c echo.Context, record *models.Record) (*recordReactions, error) {
+	result := &recordReactions{
+		Counts: map[string]int{},
+		Own:    []string{},
+	}
+
+	if raw := record.GetString(record.Collection().ReactionsField()); raw != "" {
+		counts := map[string]any{}
+		if err := json.Unmarshal([]byte(raw), &counts); err != nil {
+			return nil, err
+		}
+		for k, v := range counts {
+			result.Counts[k] = cast.ToInt(v)
+		}
+	}
+
+	if authRecord, _ := c.Get(ContextAuthRecordKey).(*models.Record); authRecord != nil {
+		own, err := api.app.Dao().FindUserReactionTypes(record, authRecord)
+		if err != nil {
+			return nil, err
+		}
+		result.Own = own
+	}
+
+	return result, nil
+}
+
+// findRecord loads the reacted record applying the collection view rule.
+func (api *recordReactionApi) findRecord(c echo.Context) (*models.Record, error) {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return nil, NewNotFoundError("", "Missing collection context.")
+	}
+
+	if len(collection.ReactionTypes()) == 0 {
+		return nil, NewBadRequestError("The collection reactions are not enabled.", nil)
+	}
+
+	requestInfo := RequestInfo(c)
+
+	if requestInfo.Admin == nil && collection.ViewRule == nil {
+		// only admins can access if the rule is nil
+		return nil, NewForbiddenError("Only admins can perform this action.", nil).WithErrorCode(ErrorCodeRuleForbidden)
+	}
+
+	ruleFunc := func(q *dbx.SelectQuery) error {
+		if requestInfo.Admin == nil && collection.ViewRule != nil && *collection.ViewRule != "" {
+			resolver := resolvers.NewRecordFieldResolver(api.app.Dao(), collection, requestInfo, true)
+			expr, err := search.FilterData(*collection.ViewRule).BuildExpr(resolver)
+			if err != nil {
+				return err
+			}
+			resolver.UpdateQuery(q)
+			q.AndWhere(expr)
+		}
+		return nil
+	}
+
+	record, fetchErr := api.app.Dao().FindRecordById(collection.Id, c.PathParam("id"), ruleFunc)
+	if fetchErr != nil || record == nil {
+		return nil, NewNotFoundError("", fetchErr)
+	}
+
+	return record, nil
+}
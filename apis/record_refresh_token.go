@@ -0,0 +1,137 @@
+package apis
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+// bindRecordRefreshTokenApi registers the auth record refresh
+// tokens api endpoints and the corresponding handlers.
+func bindRecordRefreshTokenApi(app core.App, rg *echo.Group) {
+	api := recordRefreshTokenApi{app: app}
+
+	subGroup := rg.Group(
+		"/collections/:collection",
+		ActivityLogger(app),
+		LoadCollectionContext(app, models.CollectionTypeAuth),
+	)
+	subGroup.POST("/auth-refresh-token", api.authWithRefreshToken, requireRefreshTokensEnabled())
+	subGroup.POST("/revoke-refresh-token", api.revoke, requireRefreshTokensEnabled())
+	subGroup.DELETE("/records/:id/refresh-tokens", api.revokeAll, RequireAdminOrOwnerAuth("id"))
+}
+
+type recordRefreshTokenApi struct {
+	app core.App
+}
+
+// requireRefreshTokensEnabled middleware requires the loaded
+// context auth collection to have enabled refresh tokens.
+func requireRefreshTokensEnabled() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+			if collection == nil || !collection.AuthOptions().AllowRefreshTokens {
+				return NewBadRequestError("The collection is not configured to allow refresh tokens.", nil)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// createRefreshToken persists a new refresh token for the provided
+// auth record and returns its plain value.
+func createRefreshToken(dao *daos.Dao, authRecord *models.Record) (string, error) {
+	collection := authRecord.Collection()
+
+	token := security.RandomString(50)
+
+	refreshToken := &models.RefreshToken{
+		CollectionId: collection.Id,
+		RecordId:     authRecord.Id,
+	}
+	refreshToken.SetToken(token)
+	refreshToken.SetRecordKey(authRecord.TokenKey())
+
+	txErr := dao.RunInTransaction(func(txDao *daos.Dao) error {
+		// cleanup the expired refresh tokens
+		if err := txDao.DeleteOldRefreshTokens(collection.Id, time.Now().Add(-collection.RefreshTokenDuration())); err != nil {
+			return err
+		}
+
+		return txDao.SaveRefreshToken(refreshToken)
+	})
+	if txErr != nil {
+		return "", txErr
+	}
+
+	return token, nil
+}
+
+// authWithRefreshToken exchanges a refresh token for a new
+// access token and a new (rotated) refresh token.
+func (api *recordRefreshTokenApi) authWithRefreshToken(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+
+	form := forms.NewRecordRefreshTokenLogin(api.app, collection)
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	record, submitErr := form.Submit()
+	if submitErr != nil {
+		return NewBadRequestError("Failed to authenticate.", submitErr)
+	}
+
+	return RecordAuthResponse(api.app, c, record, nil)
+}
+
+// revoke deletes a single refresh token (eg. on logout).
+//
+// Always returns 204 to prevent the refresh tokens enumeration.
+func (api *recordRefreshTokenApi) revoke(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+
+	data := struct {
+		RefreshToken string `form:"refreshToken" json:"refreshToken"`
+	}{}
+	if err := c.Bind(&data); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	if data.RefreshToken == "" {
+		return NewBadRequestError("Missing refresh token.", nil)
+	}
+
+	refreshToken, err := api.app.Dao().FindRefreshTokenByToken(collection.Id, data.RefreshToken)
+	if err == nil {
+		if err := api.app.Dao().DeleteRefreshToken(refreshToken); err != nil {
+			return NewBadRequestError("Failed to revoke the refresh token.", err)
+		}
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// revokeAll deletes all refresh tokens of the specified auth record.
+func (api *recordRefreshTokenApi) revokeAll(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+
+	record, err := api.app.Dao().FindRecordById(collection.Id, c.PathParam("id"))
+	if err != nil || record == nil {
+		return NewNotFoundError("", err)
+	}
+
+	if err := api.app.Dao().DeleteAllRefreshTokensByRecord(record); err != nil {
+		return NewBadRequestError("Failed to revoke the refresh tokens of the specified auth record.", err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,222 @@
+package apis
+
+import (
+	"log/slog"
+	"math/rand"
+	"strings"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/resolvers"
+	"github.com/pocketbase/pocketbase/tools/routine"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+// RuleLogType is the logs data type of the API rule evaluation outcome entries.
+const RuleLogType = "rule"
+
+// API rule evaluation outcomes.
+const (
+	ruleOutcomeAllowed  = "allowed"
+	ruleOutcomeDenied   = "denied"
+	ruleOutcomeFiltered = "filtered"
+	ruleOutcomeAdmins   = "adminsOnly"
+	ruleOutcomeNotFound = "notFound"
+)
+
+// ruleLog writes the API rule evaluation outcome of a single
+// non-admin record request as debug log entry (see [RuleLogType]).
+//
+// A nil *ruleLog is valid and all of its methods are no-op.
+type ruleLog struct {
+	app        core.App
+	c          echo.Context
+	collection *models.Collection
+	action     string
+}
+
+// newRuleLog returns a new rule outcome logger for the current request
+// or nil if the request is from an admin or it wasn't sampled
+// (see [models.Collection.RuleLogSampleRate()]).
+func newRuleLog(app core.App, c echo.Context, collection *models.Collection, action string) *ruleLog {
+	rate := collection.RuleLogSampleRate()
+	if rate <= 0 || (rate < 1 && rand.Float64() >= rate) {
+		return nil
+	}
+
+	if RequestInfo(c).Admin != nil {
+		return nil
+	}
+
+	return &ruleLog{
+		app:        app,
+		c:          c,
+		collection: collection,
+		action:     action,
+	}
+}
+
+// logList logs the outcome of a list rule (aka. filter) evaluation.
+func (l *ruleLog) logList(rule *string) {
+	if l == nil {
+		return
+	}
+
+	switch {
+	case rule == nil:
+		l.write(ruleOutcomeAdmins, rule, "", "")
+	case *rule == "":
+		l.write(ruleOutcomeAllowed, rule, "", "")
+	default:
+		l.write(ruleOutcomeFiltered, rule, "", "")
+	}
+}
+
+// logFetch logs the outcome of a single record rule evaluation.
+//
+// If the record wasn't found with the rule applied, it is fetched
+// again without it and the rule top-level "&&" clauses are evaluated
+// one by one to find the first clause that denies the access.
+func (l *ruleLog) logFetch(dao *daos.Dao, rule *string, recordId string, found bool) {
+	if l == nil {
+		return
+	}
+
+	if rule == nil {
+		l.write(ruleOutcomeAdmins, rule, "", recordId)
+		return
+	}
+
+	if found {
+		l.write(ruleOutcomeAllowed, rule, "", recordId)
+		return
+	}
+
+	if _, err := dao.FindRecordById(l.collection.Id, recordId); err != nil {
+		l.write(ruleOutcomeNotFound, rule, "", recordId)
+		return
+	}
+
+	l.write(ruleOutcomeDenied, rule, l.findDenyingClause(dao, *rule, recordId), recordId)
+}
+
+// findDenyingClause returns the first top-level clause of the
+// provided rule that doesn't match the specified record.
+//
+// Returns empty string if all clauses match individually
+// (eg. the record was excluded for a different reason).
+func (l *ruleLog) findDenyingClause(dao *daos.Dao, rule string, recordId string) string {
+	requestInfo := RequestInfo(l.c)
+
+	for _, clause := range splitRuleClauses(rule) {
+		_, err := dao.FindRecordById(l.collection.Id, recordId, func(q *dbx.SelectQuery) error {
+			resolver := resolvers.NewRecordFieldResolver(dao, l.collection, requestInfo, true)
+			expr, err := search.FilterData(clause).BuildExpr(resolver)
+			if err != nil {
+				return err
+			}
+			resolver.UpdateQuery(q)
+			q.AndWhere(expr)
+			return nil
+		})
+		if err != nil {
+			return clause
+		}
+	}
+
+	return ""
+}
+
+func (l *ruleLog) write(outcome string, rule *string, clause string, recordId string) {
+	requestInfo := RequestInfo(l.c)
+
+	authId := ""
+	if requestInfo.AuthRecord != nil {
+		authId = requestInfo.AuthRecord.Id
+	}
+
+	var ruleValue any
+	if rule != nil {
+		ruleValue = *rule
+	}
+
+	request := l.c.Request()
+
+	// note: the log is saved directly (instead of using the app logger)
+	// so that the collection opt-in has precedence over the logs min level
+	log := &models.Log{
+		Message: "API rule " + outcome + " " + l.action + " " + l.collection.Name,
+		Level:   int(slog.LevelDebug),
+		Data: map[string]any{
+			"type":           RuleLogType,
+			"requestId":      RequestId(l.c),
+			"method":         strings.ToUpper(request.Method),
+			"url":            request.URL.RequestURI(),
+			"collectionName": l.collection.Name,
+			"action":         l.action,
+			"outcome":        outcome,
+			"rule":           ruleValue,
+			"clause":         clause,
+			"recordId":       recordId,
+			"auth":           authId,
+		},
+	}
+
+	// don't block on the log write
+	routine.FireAndForget(func() {
+		if err := l.app.LogsDao().SaveLog(log); err != nil {
+			l.app.Logger().Debug("Failed to save API rule log", slog.String("error", err.Error()))
+		}
+	})
+}
+
+// splitRuleClauses splits the provided rule expression
+// by its top-level "&&" operators (the quoted strings and
+// the parenthesized groups are left untouched).
+//
+// Rules with top-level "||" operator are returned as single clause.
+func splitRuleClauses(rule string) []string {
+	var clauses []string
+
+	var depth int
+	var quote rune
+	var start int
+
+	runes := []rune(rule)
+
+	for i := 0; i < len(runes); i++ {
+		ch := runes[i]
+
+		switch {
+		case quote != 0:
+			if ch == '\\' {
+				i++ // skip the escaped char
+			} else if ch == quote {
+				quote = 0
+			}
+		case ch == '\'' || ch == '"':
+			quote = ch
+		case ch == '(':
+			depth++
+		case ch == ')':
+			depth--
+		case ch == '|' && depth == 0 && i+1 < len(runes) && runes[i+1] == '|':
+			return []string{strings.TrimSpace(rule)}
+		case ch == '&' && depth == 0 && i+1 < len(runes) && runes[i+1] == '&':
+			if clause := strings.TrimSpace(string(runes[start:i])); clause != "" {
+				clauses = append(clauses, clause)
+			}
+			i++
+			start = i + 1
+		}
+	}
+
+	if clause := strings.TrimSpace(string(runes[start:])); clause != "" {
+		clauses = append(clauses, clause)
+	}
+
+	return clauses
+}
@@ -0,0 +1,160 @@
+package apis
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tokens"
+)
+
+// bindRecordSamlApi registers the auth record SAML 2.0 service provider
+// api endpoints and the corresponding handlers.
+func bindRecordSamlApi(app core.App, rg *echo.Group) {
+	api := recordSamlApi{app: app}
+
+	subGroup := rg.Group(
+		"/collections/:collection",
+		ActivityLogger(app),
+		LoadCollectionContext(app, models.CollectionTypeAuth),
+		requireSamlAuthEnabled(app),
+	)
+	subGroup.GET("/saml-metadata", api.metadata)
+	subGroup.GET("/saml-login", api.login)
+	subGroup.POST("/auth-with-saml", api.authWithSaml)
+}
+
+type recordSamlApi struct {
+	app core.App
+}
+
+// requireSamlAuthEnabled middleware requires the app SAML settings
+// to be enabled for the loaded context auth collection.
+func requireSamlAuthEnabled(app core.App) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+
+			config := app.Settings().Saml
+			if collection == nil || !config.Enabled || !config.IsCollection(collection.Id, collection.Name) {
+				return NewBadRequestError("The collection is not configured to allow SAML authentication.", nil)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// metadata returns the service provider metadata XML document
+// that should be registered in the identity provider.
+func (api *recordSamlApi) metadata(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+
+	sp, err := forms.SamlServiceProvider(api.app, collection)
+	if err != nil {
+		return NewBadRequestError("Invalid SAML identity provider configuration.", err)
+	}
+
+	raw, err := sp.Metadata()
+	if err != nil {
+		return NewBadRequestError("Failed to generate the SAML metadata.", err)
+	}
+
+	return c.Blob(http.StatusOK, "application/samlmetadata+xml", raw)
+}
+
+// login redirects to the identity provider SSO url
+// (aka. service provider initiated login).
+func (api *recordSamlApi) login(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+
+	sp, err := forms.SamlServiceProvider(api.app, collection)
+	if err != nil {
+		return NewBadRequestError("Invalid SAML identity provider configuration.", err)
+	}
+
+	redirectUrl, err := sp.AuthnRequestUrl(c.QueryParam("relayState"))
+	if err != nil {
+		return NewBadRequestError("Failed to create the SAML authentication request.", err)
+	}
+
+	return c.Redirect(http.StatusTemporaryRedirect, redirectUrl)
+}
+
+// authWithSaml is the service provider assertion consumer service (ACS) handler.
+//
+// If the SAML settings have a redirect url, the auth token is forwarded
+// to it as url fragment, otherwise the regular auth response is returned.
+func (api *recordSamlApi) authWithSaml(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+
+	form := forms.NewRecordSamlLogin(api.app, collection)
+	if readErr := c.Bind(form); readErr != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", readErr)
+	}
+
+	record, submitErr := form.Submit()
+	if submitErr != nil {
+		failure := &core.SecurityEvent{
+			Type:      core.SecurityEventAuthFailure,
+			ActorType: core.SecurityActorAuthRecord,
+			Data: map[string]any{
+				"method":     "saml",
+				"collection": collection.Name,
+			},
+		}
+		emitSecurityEvent(api.app, c, failure)
+
+		return NewBadRequestError("Failed to authenticate.", submitErr)
+	}
+
+	redirectUrl := api.app.Settings().Saml.RedirectUrl
+	if redirectUrl == "" {
+		return RecordAuthResponse(api.app, c, record, nil)
+	}
+
+	return api.redirectAuthResponse(c, record, redirectUrl, c.FormValue("RelayState"))
+}
+
+// redirectAuthResponse is similar to [RecordAuthResponse] but instead of
+// writing the auth data as json it redirects to the provided url with
+// the generated auth token (and the optional relay state) as fragment.
+func (api *recordSamlApi) redirectAuthResponse(c echo.Context, authRecord *models.Record, redirectUrl string, relayState string) error {
+	if authRecord.IsSoftDeleted() {
+		return NewForbiddenError("The account has been deleted.", nil)
+	}
+
+	if !authRecord.Verified() && authRecord.Collection().AuthOptions().OnlyVerified {
+		return NewForbiddenError("Please verify your email first.", nil)
+	}
+
+	token, tokenErr := tokens.NewRecordAuthToken(api.app, authRecord)
+	if tokenErr != nil {
+		return NewBadRequestError("Failed to create auth token.", tokenErr)
+	}
+
+	event := new(core.RecordAuthEvent)
+	event.HttpContext = c
+	event.Collection = authRecord.Collection()
+	event.Record = authRecord
+	event.Token = token
+
+	return api.app.OnRecordAuthRequest().Trigger(event, func(e *core.RecordAuthEvent) error {
+		if e.HttpContext.Response().Committed {
+			return nil
+		}
+
+		fragment := url.Values{}
+		fragment.Set("token", e.Token)
+		if relayState != "" {
+			fragment.Set("state", relayState)
+		}
+
+		setAuthCookie(api.app, e.HttpContext, e.Token, int(tokens.RecordAuthTokenDuration(api.app, e.Collection)))
+
+		return e.HttpContext.Redirect(http.StatusSeeOther, redirectUrl+"#"+fragment.Encode())
+	})
+}
@@ -0,0 +1,140 @@
+package apis_test
+
+import (
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/tests"
+	dsig "github.com/russellhaering/goxmldsig"
+)
+
+func TestRecordSamlApi(t *testing.T) {
+	_, cert, err := dsig.RandomKeyStoreForTest().GetKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	metadata := `<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" xmlns:ds="http://www.w3.org/2000/09/xmldsig#" entityID="https://idp.example.com">` +
+		`<md:IDPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">` +
+		`<md:KeyDescriptor use="signing"><ds:KeyInfo><ds:X509Data><ds:X509Certificate>` + base64.StdEncoding.EncodeToString(cert) + `</ds:X509Certificate></ds:X509Data></ds:KeyInfo></md:KeyDescriptor>` +
+		`<md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect" Location="https://idp.example.com/sso"/>` +
+		`</md:IDPSSODescriptor></md:EntityDescriptor>`
+
+	enableSaml := func(app *tests.TestApp) {
+		app.Settings().Meta.AppUrl = "https://pb.example.com"
+		app.Settings().Saml.Enabled = true
+		app.Settings().Saml.Collection = "users"
+		app.Settings().Saml.IdpMetadata = metadata
+	}
+
+	scenarios := []tests.ApiScenario{
+		{
+			Name:            "metadata with disabled saml auth",
+			Method:          http.MethodGet,
+			Url:             "/api/collections/users/saml-metadata",
+			ExpectedStatus:  400,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "metadata of a non auth collection",
+			Method: http.MethodGet,
+			Url:    "/api/collections/demo1/saml-metadata",
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				enableSaml(app)
+			},
+			ExpectedStatus:  400,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "metadata of a different auth collection",
+			Method: http.MethodGet,
+			Url:    "/api/collections/clients/saml-metadata",
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				enableSaml(app)
+			},
+			ExpectedStatus:  400,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "metadata with enabled saml auth",
+			Method: http.MethodGet,
+			Url:    "/api/collections/users/saml-metadata",
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				enableSaml(app)
+			},
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`entityID="https://pb.example.com/api/collections/users/saml-metadata"`,
+				`Location="https://pb.example.com/api/collections/users/auth-with-saml"`,
+			},
+		},
+		{
+			Name:            "login with disabled saml auth",
+			Method:          http.MethodGet,
+			Url:             "/api/collections/users/saml-login",
+			ExpectedStatus:  400,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "login with enabled saml auth",
+			Method: http.MethodGet,
+			Url:    "/api/collections/users/saml-login?relayState=test",
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				enableSaml(app)
+			},
+			ExpectedStatus: 307,
+			AfterTestFunc: func(t *testing.T, app *tests.TestApp, res *http.Response) {
+				location := res.Header.Get("Location")
+				if !strings.HasPrefix(location, "https://idp.example.com/sso?") || !strings.Contains(location, "SAMLRequest=") {
+					t.Fatalf("Expected redirect to the identity provider, got %q", location)
+				}
+				if !strings.Contains(location, "RelayState=test") {
+					t.Fatalf("Expected the relay state to be forwarded, got %q", location)
+				}
+			},
+		},
+		{
+			Name:            "auth with disabled saml auth",
+			Method:          http.MethodPost,
+			Url:             "/api/collections/users/auth-with-saml",
+			Body:            strings.NewReader(`{"SAMLResponse":"test"}`),
+			ExpectedStatus:  400,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "auth with empty saml response",
+			Method: http.MethodPost,
+			Url:    "/api/collections/users/auth-with-saml",
+			Body:   strings.NewReader(`{"SAMLResponse":""}`),
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				enableSaml(app)
+			},
+			ExpectedStatus:  400,
+			ExpectedContent: []string{`"SAMLResponse":{`},
+		},
+		{
+			Name:   "auth with invalid saml response",
+			Method: http.MethodPost,
+			Url:    "/api/collections/users/auth-with-saml",
+			Body:   strings.NewReader("SAMLResponse=" + base64.StdEncoding.EncodeToString([]byte("<invalid"))),
+			RequestHeaders: map[string]string{
+				"Content-Type": "application/x-www-form-urlencoded",
+			},
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				enableSaml(app)
+			},
+			ExpectedStatus:  400,
+			ExpectedContent: []string{`"data":{}`},
+			NotExpectedContent: []string{
+				`"token"`,
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
@@ -0,0 +1,166 @@
+package apis
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tokens"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// bindRecordSessionApi registers the auth record sessions
+// management api endpoints and the corresponding handlers.
+func bindRecordSessionApi(app core.App, rg *echo.Group) {
+	api := recordSessionApi{app: app}
+
+	subGroup := rg.Group(
+		"/collections/:collection/records/:id/sessions",
+		ActivityLogger(app),
+		LoadCollectionContext(app, models.CollectionTypeAuth),
+		RequireAdminOrOwnerAuth("id"),
+		requireSessionsEnabled(),
+	)
+	subGroup.GET("", api.list)
+	subGroup.DELETE("", api.revokeAll)
+	subGroup.DELETE("/:sessionId", api.revoke)
+}
+
+type recordSessionApi struct {
+	app core.App
+}
+
+// requireSessionsEnabled middleware requires the loaded context
+// auth collection to have enabled the sessions tracking.
+func requireSessionsEnabled() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+			if collection == nil || !collection.AuthOptions().TrackSessions {
+				return NewBadRequestError("The collection is not configured to track sessions.", nil)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// resolveAuthSession returns the tracked session that the newly issued
+// auth token of the provided auth record should be bound to.
+//
+// The current request session is reused if it belongs to the same
+// auth record (eg. on auth refresh), otherwise a new session is created.
+func resolveAuthSession(app core.App, c echo.Context, authRecord *models.Record) (*models.AuthSession, error) {
+	collection := authRecord.Collection()
+
+	now, _ := types.ParseDateTime(time.Now())
+
+	current, _ := c.Get(ContextAuthSessionKey).(*models.AuthSession)
+	if current != nil && current.RecordId == authRecord.Id && current.CollectionId == collection.Id {
+		current.LastSeen = now
+		if err := app.Dao().SaveAuthSession(current); err != nil {
+			return nil, err
+		}
+		return current, nil
+	}
+
+	session := &models.AuthSession{
+		CollectionId: collection.Id,
+		RecordId:     authRecord.Id,
+		UserAgent:    c.Request().UserAgent(),
+		Ip:           c.RealIP(),
+		LastSeen:     now,
+	}
+
+	tokenDuration := time.Duration(tokens.RecordAuthTokenDuration(app, collection)) * time.Second
+
+	txErr := app.Dao().RunInTransaction(func(txDao *daos.Dao) error {
+		// cleanup the sessions whose tokens have already expired
+		if err := txDao.DeleteOldAuthSessions(collection.Id, time.Now().Add(-tokenDuration)); err != nil {
+			return err
+		}
+
+		return txDao.SaveAuthSession(session)
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	return session, nil
+}
+
+// list returns all tracked sessions of the specified auth record.
+func (api *recordSessionApi) list(c echo.Context) error {
+	record, err := api.findRecord(c)
+	if err != nil {
+		return err
+	}
+
+	sessions, err := api.app.Dao().FindAllAuthSessionsByRecord(record)
+	if err != nil {
+		return NewBadRequestError("Failed to load the auth record sessions.", err)
+	}
+
+	return c.JSON(http.StatusOK, sessions)
+}
+
+// revoke deletes a single session of the specified auth record
+// invalidating all auth tokens bound to it.
+func (api *recordSessionApi) revoke(c echo.Context) error {
+	record, err := api.findRecord(c)
+	if err != nil {
+		return err
+	}
+
+	session, err := api.app.Dao().FindAuthSessionById(c.PathParam("sessionId"))
+	if err != nil || session == nil || session.RecordId != record.Id || session.CollectionId != record.Collection().Id {
+		return NewNotFoundError("", err)
+	}
+
+	if err := api.app.Dao().DeleteAuthSession(session); err != nil {
+		return NewBadRequestError("Failed to revoke the session.", err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// revokeAll deletes all sessions of the specified auth record and
+// refreshes its token key invalidating all of its issued auth tokens
+// (including the ones that are not bound to a session).
+func (api *recordSessionApi) revokeAll(c echo.Context) error {
+	record, err := api.findRecord(c)
+	if err != nil {
+		return err
+	}
+
+	txErr := api.app.Dao().RunInTransaction(func(txDao *daos.Dao) error {
+		if err := txDao.DeleteAllAuthSessionsByRecord(record); err != nil {
+			return err
+		}
+
+		if err := record.RefreshTokenKey(); err != nil {
+			return err
+		}
+
+		return txDao.SaveRecord(record)
+	})
+	if txErr != nil {
+		return NewBadRequestError("Failed to revoke the sessions of the specified auth record.", txErr)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (api *recordSessionApi) findRecord(c echo.Context) (*models.Record, error) {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+
+	record, err := api.app.Dao().FindRecordById(collection.Id, c.PathParam("id"))
+	if err != nil || record == nil {
+		return nil, NewNotFoundError("", err)
+	}
+
+	return record, nil
+}
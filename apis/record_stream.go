@@ -0,0 +1,144 @@
+package apis
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/search"
+	"github.com/spf13/cast"
+)
+
+// MIMEApplicationNdjson is the newline delimited JSON content type.
+const MIMEApplicationNdjson = "application/x-ndjson"
+
+// ndjsonChunkSize is the max number of records that are
+// enriched and written to the response at once.
+const ndjsonChunkSize = 100
+
+// isNdjsonRequest checks whether the request prefers a NDJSON response.
+func isNdjsonRequest(c echo.Context) bool {
+	return strings.Contains(c.Request().Header.Get(echo.HeaderAccept), MIMEApplicationNdjson)
+}
+
+// streamList streams the records list page as NDJSON (aka. one record per line)
+// while reading the rows from the db instead of buffering the whole page.
+//
+// The records are processed in chunks of up to ndjsonChunkSize records and
+// the OnRecordsListRequest hook is triggered for each chunk
+// (the event Result.TotalItems and Result.TotalPages are always -1).
+func (api *recordApi) streamList(
+	c echo.Context,
+	collection *models.Collection,
+	query *dbx.SelectQuery,
+	searchProvider *search.Provider,
+) error {
+	if err := searchProvider.Parse(c.QueryParams().Encode()); err != nil {
+		return NewBadRequestError("", err)
+	}
+
+	// explicitly bind the query to the request context to prevent
+	// the default query timeout from interrupting the rows iteration
+	query.WithContext(c.Request().Context())
+
+	// normalized the same way as in the search provider
+	page := max(1, cast.ToInt(c.QueryParam(search.PageQueryParam)))
+	maxPerPage := search.MaxPerPage
+	if RequestInfo(c).Admin == nil {
+		maxPerPage = collection.ListMaxPerPage()
+	}
+	perPage := cast.ToInt(c.QueryParam(search.PerPageQueryParam))
+	if perPage <= 0 {
+		perPage = search.DefaultPerPage
+	}
+	if perPage > maxPerPage {
+		perPage = maxPerPage
+	}
+
+	chunk := make([]*models.Record, 0, ndjsonChunkSize)
+
+	writeChunk := func() error {
+		event := new(core.RecordsListEvent)
+		event.HttpContext = c
+		event.Collection = collection
+		event.Records = chunk
+		event.Result = &search.Result{
+			Page:       page,
+			PerPage:    perPage,
+			TotalItems: -1,
+			TotalPages: -1,
+			Items:      chunk,
+		}
+
+		chunk = make([]*models.Record, 0, ndjsonChunkSize)
+
+		return api.app.OnRecordsListRequest().Trigger(event, func(e *core.RecordsListEvent) error {
+			if err := EnrichRecords(e.HttpContext, api.app.Dao(), e.Records); err != nil {
+				api.app.Logger().Debug("Failed to enrich list records", slog.String("error", err.Error()))
+			}
+
+			return writeNdjson(e.HttpContext, e.Records)
+		})
+	}
+
+	_, err := searchProvider.ExecRows(func(rows *dbx.Rows) error {
+		row := dbx.NullStringMap{}
+		if err := rows.ScanMap(row); err != nil {
+			return err
+		}
+
+		chunk = append(chunk, models.NewRecordFromNullStringMap(collection, row))
+
+		if len(chunk) >= ndjsonChunkSize {
+			return writeChunk()
+		}
+
+		return nil
+	})
+	if err == nil && len(chunk) > 0 {
+		err = writeChunk()
+	}
+
+	if err != nil {
+		if !c.Response().Committed {
+			return NewBadRequestError("", err)
+		}
+
+		// the response status is already sent -> just log and abort the stream
+		api.app.Logger().Debug("Failed to stream the list records", slog.String("error", err.Error()))
+
+		return nil
+	}
+
+	// empty result
+	if !c.Response().Committed {
+		c.Response().Header().Set(echo.HeaderContentType, MIMEApplicationNdjson)
+		c.Response().WriteHeader(http.StatusOK)
+	}
+
+	return nil
+}
+
+// writeNdjson writes the provided records to the response as
+// newline delimited json and flushes the response.
+func writeNdjson(c echo.Context, records []*models.Record) error {
+	if !c.Response().Committed {
+		c.Response().Header().Set(echo.HeaderContentType, MIMEApplicationNdjson)
+		c.Response().WriteHeader(http.StatusOK)
+	}
+
+	// the json serializer encoder appends a new line after each value
+	for _, record := range records {
+		if err := c.Echo().JSONSerializer.Serialize(c, record, ""); err != nil {
+			return err
+		}
+	}
+
+	c.Response().Flush()
+
+	return nil
+}
@@ -0,0 +1,369 @@
+package apis
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/resolvers"
+	"github.com/pocketbase/pocketbase/tools/search"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// Record sync mutation result statuses.
+const (
+	// SyncStatusApplied indicates that the client mutation was persisted.
+	SyncStatusApplied = "applied"
+
+	// SyncStatusConflict indicates that the client mutation was
+	// discarded in favor of the current server state.
+	SyncStatusConflict = "conflict"
+
+	// SyncStatusRejected indicates that the client mutation failed
+	// (eg. due to validation error or insufficient permissions).
+	SyncStatusRejected = "rejected"
+)
+
+// maxSyncMutations is the max allowed number of mutations in a single sync request.
+const maxSyncMutations = 500
+
+// bindRecordSyncApi registers the record sync api endpoint.
+func bindRecordSyncApi(app core.App, rg *echo.Group) {
+	api := recordSyncApi{app: app}
+
+	subGroup := rg.Group(
+		"/collections/:collection",
+		ActivityLogger(app),
+	)
+
+	subGroup.POST("/sync", api.sync, LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth))
+}
+
+type recordSyncApi struct {
+	app core.App
+}
+
+// recordSyncMutation defines a single offline client mutation.
+type recordSyncMutation struct {
+	// Id is the client generated record id.
+	Id string `json:"id"`
+
+	// Deleted marks the mutation as delete (aka. tombstone).
+	Deleted bool `json:"deleted"`
+
+	// Data is the record data to create or update.
+	Data map[string]any `json:"data"`
+
+	// BaseUpdated is the server "updated" value of the record version
+	// that the client change is based on (empty for new records).
+	BaseUpdated string `json:"baseUpdated"`
+
+	// Modified is the client side datetime of the change
+	// (used by the "lastWriteWins" strategy).
+	Modified string `json:"modified"`
+}
+
+type recordSyncResult struct {
+	Id      string         `json:"id"`
+	Status  string         `json:"status"`
+	Deleted bool           `json:"deleted"`
+	Record  *models.Record `json:"record"`
+	Error   *ApiError      `json:"error"`
+}
+
+// sync applies a batch of offline client mutations and returns
+// the authoritative state of each of the mutated records.
+//
+// The mutations are applied sequentially and independently from each other.
+func (api *recordSyncApi) sync(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("", "Missing collection context.")
+	}
+
+	body := struct {
+		Mutations []recordSyncMutation `json:"mutations"`
+	}{}
+	if err := c.Bind(&body); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	if len(body.Mutations) == 0 {
+		return NewBadRequestError("Missing sync mutations.", nil)
+	}
+
+	if len(body.Mutations) > maxSyncMutations {
+		return NewBadRequestError(fmt.Sprintf("The sync request can have at most %d mutations.", maxSyncMutations), nil)
+	}
+
+	results := make([]*recordSyncResult, len(body.Mutations))
+
+	for i, mutation := range body.Mutations {
+		result := api.applyMutation(c, collection, mutation)
+
+		if result.Record != nil {
+			if err := EnrichRecord(c, api.app.Dao(), result.Record); err != nil {
+				api.app.Logger().Debug(
+					"Failed to enrich sync record",
+					slog.String("id", result.Record.Id),
+					slog.String("collectionName", collection.Name),
+					slog.String("error", err.Error()),
+				)
+			}
+		}
+
+		results[i] = result
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{
+		"results":    results,
+		"serverTime": types.NowDateTime(),
+	})
+}
+
+func (api *recordSyncApi) applyMutation(
+	c echo.Context,
+	collection *models.Collection,
+	mutation recordSyncMutation,
+) *recordSyncResult {
+	result := &recordSyncResult{Id: mutation.Id}
+
+	reject := func(err *ApiError) *recordSyncResult {
+		result.Status = SyncStatusRejected
+		result.Error = err
+		return result
+	}
+
+	if mutation.Id == "" {
+		return reject(NewBadRequestError("Missing client generated record id.", nil))
+	}
+
+	// shallow copy to avoid modifying the cached request info
+	requestInfo := *RequestInfo(c)
+	requestInfo.Data = mutation.Data
+	if requestInfo.Data == nil {
+		requestInfo.Data = map[string]any{}
+	}
+
+	existing, err := api.app.Dao().FindRecordById(collection.Id, mutation.Id)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return reject(NewBadRequestError("Failed to fetch the record.", err))
+	}
+
+	// new record
+	// ---
+	if existing == nil {
+		if mutation.Deleted {
+			// nothing to delete
+			result.Status = SyncStatusApplied
+			result.Deleted = true
+			return result
+		}
+
+		record, apiErr := api.create(collection, &requestInfo, mutation)
+		if apiErr != nil {
+			return reject(apiErr)
+		}
+
+		result.Status = SyncStatusApplied
+		result.Record = record
+		return result
+	}
+
+	// existing record
+	// ---
+
+	// ensure that the authoritative record state is returned
+	// only to clients that are allowed to view it
+	if requestInfo.Admin == nil {
+		if collection.ViewRule == nil {
+			return reject(NewForbiddenError("Only admins can perform this action.", nil).WithErrorCode(ErrorCodeRuleForbidden))
+		}
+
+		if ok, _ := api.app.Dao().CanAccessRecord(existing, RequestInfo(c), collection.ViewRule); !ok {
+			return reject(NewForbiddenError("You are not allowed to sync the record.", nil).WithErrorCode(ErrorCodeRuleForbidden))
+		}
+	}
+
+	serverUpdated := existing.GetDateTime(schema.FieldNameUpdated)
+
+	if mutation.BaseUpdated != serverUpdated.String() {
+		event := &core.RecordSyncConflictEvent{
+			HttpContext: c,
+			Record:      existing,
+			Data:        requestInfo.Data,
+			Deleted:     mutation.Deleted,
+		}
+		event.Collection = collection
+
+		switch collection.SyncStrategy() {
+		case models.SyncStrategyLastWriteWins:
+			modified, _ := types.ParseDateTime(mutation.Modified)
+			event.AcceptClient = !modified.IsZero() && modified.Time().After(serverUpdated.Time())
+		case models.SyncStrategyCustom:
+			if err := api.app.OnRecordSyncConflict().Trigger(event); err != nil {
+				return reject(NewBadRequestError("Failed to resolve the sync conflict.", err))
+			}
+		}
+
+		if !event.AcceptClient {
+			result.Status = SyncStatusConflict
+			result.Record = existing
+			return result
+		}
+
+		mutation.Deleted = event.Deleted
+		requestInfo.Data = event.Data
+	}
+
+	if mutation.Deleted {
+		if apiErr := api.delete(existing, &requestInfo); apiErr != nil {
+			return reject(apiErr)
+		}
+
+		result.Status = SyncStatusApplied
+		result.Deleted = true
+		return result
+	}
+
+	if apiErr := api.update(existing, &requestInfo); apiErr != nil {
+		return reject(apiErr)
+	}
+
+	result.Status = SyncStatusApplied
+	result.Record = existing
+	return result
+}
+
+func (api *recordSyncApi) create(
+	collection *models.Collection,
+	requestInfo *models.RequestInfo,
+	mutation recordSyncMutation,
+) (*models.Record, *ApiError) {
+	if requestInfo.Admin == nil && collection.CreateRule == nil {
+		return nil, NewForbiddenError("Only admins can perform this action.", nil).WithErrorCode(ErrorCodeRuleForbidden)
+	}
+
+	data := make(map[string]any, len(requestInfo.Data)+1)
+	for k, v := range requestInfo.Data {
+		data[k] = v
+	}
+	data[schema.FieldNameId] = mutation.Id
+
+	hasFullManageAccess := requestInfo.Admin != nil
+
+	// temporary save the record and check it against the create rule
+	if requestInfo.Admin == nil {
+		testRecord := models.NewRecord(collection)
+
+		if requestInfo.HasModifierDataKeys() {
+			requestInfo.Data = testRecord.ReplaceModifers(requestInfo.Data)
+		}
+
+		testForm := forms.NewRecordUpsert(api.app, testRecord)
+		testForm.SetFullManageAccess(true)
+		if err := testForm.LoadData(data); err != nil {
+			return nil, NewBadRequestError("Failed to load the submitted data.", err)
+		}
+
+		createRuleFunc := func(q *dbx.SelectQuery) error {
+			if *collection.CreateRule == "" {
+				return nil // no create rule to resolve
+			}
+
+			resolver := resolvers.NewRecordFieldResolver(api.app.Dao(), collection, requestInfo, true)
+			expr, err := search.FilterData(*collection.CreateRule).BuildExpr(resolver)
+			if err != nil {
+				return err
+			}
+			resolver.UpdateQuery(q)
+			q.AndWhere(expr)
+			return nil
+		}
+
+		testErr := testForm.DrySubmit(func(txDao *daos.Dao) error {
+			foundRecord, err := txDao.FindRecordById(collection.Id, testRecord.Id, createRuleFunc)
+			if err != nil {
+				return fmt.Errorf("DrySubmit create rule failure: %w", err)
+			}
+			hasFullManageAccess = hasAuthManageAccess(txDao, foundRecord, requestInfo)
+			return nil
+		})
+		if testErr != nil {
+			return nil, NewBadRequestError("Failed to create record.", testErr)
+		}
+	}
+
+	record := models.NewRecord(collection)
+
+	form := forms.NewRecordUpsert(api.app, record)
+	form.SetFullManageAccess(hasFullManageAccess)
+
+	if err := form.LoadData(data); err != nil {
+		return nil, NewBadRequestError("Failed to load the submitted data.", err)
+	}
+
+	if err := form.Submit(); err != nil {
+		return nil, NewBadRequestError("Failed to create record.", err)
+	}
+
+	return record, nil
+}
+
+func (api *recordSyncApi) update(record *models.Record, requestInfo *models.RequestInfo) *ApiError {
+	collection := record.Collection()
+
+	if requestInfo.Admin == nil && collection.UpdateRule == nil {
+		return NewForbiddenError("Only admins can perform this action.", nil).WithErrorCode(ErrorCodeRuleForbidden)
+	}
+
+	data := requestInfo.Data
+
+	if requestInfo.HasModifierDataKeys() {
+		requestInfo.Data = record.ReplaceModifers(requestInfo.Data)
+	}
+
+	if ok, err := api.app.Dao().CanAccessRecord(record, requestInfo, collection.UpdateRule); !ok {
+		return NewForbiddenError("You are not allowed to update the record.", err).WithErrorCode(ErrorCodeRuleForbidden)
+	}
+
+	form := forms.NewRecordUpsert(api.app, record)
+	form.SetFullManageAccess(requestInfo.Admin != nil || hasAuthManageAccess(api.app.Dao(), record, requestInfo))
+
+	if err := form.LoadData(data); err != nil {
+		return NewBadRequestError("Failed to load the submitted data.", err)
+	}
+
+	if err := form.Submit(); err != nil {
+		return NewBadRequestError("Failed to update record.", err)
+	}
+
+	return nil
+}
+
+func (api *recordSyncApi) delete(record *models.Record, requestInfo *models.RequestInfo) *ApiError {
+	collection := record.Collection()
+
+	if requestInfo.Admin == nil && collection.DeleteRule == nil {
+		return NewForbiddenError("Only admins can perform this action.", nil).WithErrorCode(ErrorCodeRuleForbidden)
+	}
+
+	if ok, err := api.app.Dao().CanAccessRecord(record, requestInfo, collection.DeleteRule); !ok {
+		return NewForbiddenError("You are not allowed to delete the record.", err).WithErrorCode(ErrorCodeRuleForbidden)
+	}
+
+	if err := api.app.Dao().DeleteRecord(record); err != nil {
+		return NewBadRequestError("Failed to delete record. Make sure that the record is not part of a required relation reference.", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,169 @@
+package apis
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/resolvers"
+	"github.com/pocketbase/pocketbase/tools/search"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// bindRecordTrashApi registers the admin only soft deleted
+// records api endpoints and the corresponding handlers.
+func bindRecordTrashApi(app core.App, rg *echo.Group) {
+	api := recordTrashApi{app: app}
+
+	subGroup := rg.Group(
+		"/collections/:collection/trash",
+		ActivityLogger(app),
+		RequireAdminAuth(),
+		LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth),
+		requireSoftDelete(),
+	)
+	subGroup.GET("", api.list)
+	subGroup.DELETE("", api.purgeAll)
+	subGroup.POST("/:id/restore", api.restore)
+	subGroup.DELETE("/:id", api.purge)
+}
+
+type recordTrashApi struct {
+	app core.App
+}
+
+// recordsPurgeResult defines the soft deleted records purge response.
+type recordsPurgeResult struct {
+	Total   int      `json:"total"`
+	Deleted []string `json:"deleted"`
+}
+
+// requireSoftDelete middleware requires the loaded context
+// collection to have enabled soft delete.
+func requireSoftDelete() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+			if collection == nil || collection.SoftDeleteField() == "" {
+				return NewBadRequestError("The collection soft delete is not enabled.", nil)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// list returns a paginated list with the soft deleted collection records.
+func (api *recordTrashApi) list(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("", "Missing collection context.")
+	}
+
+	fieldsResolver := resolvers.NewRecordFieldResolver(
+		api.app.Dao(),
+		collection,
+		RequestInfo(c),
+		true,
+	)
+
+	query := api.app.Dao().RecordQuery(collection).AndWhere(daos.SoftDeletedExpr(collection))
+
+	searchProvider := search.NewProvider(fieldsResolver).Query(query)
+
+	if c.QueryParam(search.SortQueryParam) == "" {
+		searchProvider.AddSort(search.SortField{Name: collection.SoftDeleteField(), Direction: search.SortDesc})
+	}
+
+	records := []*models.Record{}
+
+	result, err := searchProvider.ParseAndExec(c.QueryParams().Encode(), &records)
+	if err != nil {
+		return NewBadRequestError("", err)
+	}
+
+	if err := EnrichRecords(c, api.app.Dao(), records); err != nil {
+		api.app.Logger().Debug("Failed to enrich trash records", slog.String("error", err.Error()))
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// restore unmarks a single soft deleted record.
+func (api *recordTrashApi) restore(c echo.Context) error {
+	record, err := api.findSoftDeleted(c)
+	if err != nil {
+		return err
+	}
+
+	if err := api.app.Dao().RestoreSoftDeletedRecord(record); err != nil {
+		return NewBadRequestError("Failed to restore the record.", err)
+	}
+
+	if err := EnrichRecord(c, api.app.Dao(), record); err != nil {
+		api.app.Logger().Debug(
+			"Failed to enrich restored record",
+			slog.String("id", record.Id),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	return c.JSON(http.StatusOK, record)
+}
+
+// purge permanently deletes a single soft deleted record.
+func (api *recordTrashApi) purge(c echo.Context) error {
+	record, err := api.findSoftDeleted(c)
+	if err != nil {
+		return err
+	}
+
+	if err := api.app.Dao().DeleteRecord(record); err != nil {
+		return NewBadRequestError("Failed to delete record. Make sure that the record is not part of a required relation reference.", err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// purgeAll permanently deletes all soft deleted collection records
+// (or only the ones deleted before the optional "before" datetime).
+func (api *recordTrashApi) purgeAll(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("", "Missing collection context.")
+	}
+
+	var before types.DateTime
+	if raw := c.QueryParam("before"); raw != "" {
+		var err error
+		before, err = types.ParseDateTime(raw)
+		if err != nil || before.IsZero() {
+			return NewBadRequestError("Invalid before datetime value.", err)
+		}
+	}
+
+	deleted, err := api.app.Dao().PurgeSoftDeletedRecords(collection, before)
+	if err != nil {
+		return NewBadRequestError("Failed to delete records. Make sure that the records are not part of a required relation reference.", err)
+	}
+
+	return c.JSON(http.StatusOK, recordsPurgeResult{Total: len(deleted), Deleted: deleted})
+}
+
+// findSoftDeleted loads the soft deleted record of the path id param.
+func (api *recordTrashApi) findSoftDeleted(c echo.Context) (*models.Record, error) {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return nil, NewNotFoundError("", "Missing collection context.")
+	}
+
+	record, err := api.app.Dao().FindRecordById(collection.Id, c.PathParam("id"))
+	if err != nil || record == nil || !record.IsSoftDeleted() {
+		return nil, NewNotFoundError("", err)
+	}
+
+	return record, nil
+}
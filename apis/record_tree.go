@@ -0,0 +1,115 @@
+package apis
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/resolvers"
+	"github.com/pocketbase/pocketbase/tools/search"
+	"github.com/spf13/cast"
+)
+
+// bindRecordTreeApi registers the tree collection records api endpoints
+// and the corresponding handlers.
+func bindRecordTreeApi(app core.App, rg *echo.Group) {
+	api := recordTreeApi{app: app}
+
+	subGroup := rg.Group(
+		"/collections/:collection/records/:id",
+		ActivityLogger(app),
+		LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth),
+	)
+	subGroup.GET("/ancestors", api.ancestors)
+	subGroup.GET("/descendants", api.descendants)
+}
+
+type recordTreeApi struct {
+	app core.App
+}
+
+// ancestors returns the ancestors of a single tree record
+// ordered from the tree root to the record direct parent.
+func (api *recordTreeApi) ancestors(c echo.Context) error {
+	return api.handle(c, func(record *models.Record, maxDepth int, ruleFunc func(q *dbx.SelectQuery) error) ([]*models.Record, error) {
+		return api.app.Dao().FindRecordAncestors(record, maxDepth, ruleFunc)
+	})
+}
+
+// descendants returns the subtree of a single tree record
+// ordered by the depth of the descendant records.
+func (api *recordTreeApi) descendants(c echo.Context) error {
+	return api.handle(c, func(record *models.Record, maxDepth int, ruleFunc func(q *dbx.SelectQuery) error) ([]*models.Record, error) {
+		return api.app.Dao().FindRecordDescendants(record, maxDepth, ruleFunc)
+	})
+}
+
+type treeFindFunc func(record *models.Record, maxDepth int, ruleFunc func(q *dbx.SelectQuery) error) ([]*models.Record, error)
+
+func (api *recordTreeApi) handle(c echo.Context, findFunc treeFindFunc) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("", "Missing collection context.")
+	}
+
+	if collection.TreeField() == "" {
+		return NewBadRequestError("The collection is not a tree.", nil)
+	}
+
+	requestInfo := RequestInfo(c)
+
+	if requestInfo.Admin == nil && collection.ViewRule == nil {
+		// only admins can access if the rule is nil
+		return NewForbiddenError("Only admins can perform this action.", nil).WithErrorCode(ErrorCodeRuleForbidden)
+	}
+
+	ruleFunc := treeRuleFunc(api.app.Dao(), collection, requestInfo)
+
+	record, fetchErr := api.app.Dao().FindRecordById(collection.Id, c.PathParam("id"), ruleFunc)
+	if fetchErr != nil || record == nil {
+		return NewNotFoundError("", fetchErr)
+	}
+
+	// the requested depth could only narrow the collection max depth
+	maxDepth := collection.TreeMaxDepth()
+	if depth := cast.ToInt(c.QueryParam("depth")); depth > 0 && depth < maxDepth {
+		maxDepth = depth
+	}
+
+	records, err := findFunc(record, maxDepth, ruleFunc)
+	if err != nil {
+		return NewBadRequestError("Failed to load the tree records.", err)
+	}
+
+	if err := EnrichRecords(c, api.app.Dao(), records); err != nil {
+		api.app.Logger().Debug(
+			"Failed to enrich tree records",
+			slog.String("id", record.Id),
+			slog.String("collectionName", collection.Name),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	return c.JSON(http.StatusOK, records)
+}
+
+// treeRuleFunc returns a query filter function that applies
+// the collection view rule for the current request.
+func treeRuleFunc(dao *daos.Dao, collection *models.Collection, requestInfo *models.RequestInfo) func(q *dbx.SelectQuery) error {
+	return func(q *dbx.SelectQuery) error {
+		if requestInfo.Admin == nil && collection.ViewRule != nil && *collection.ViewRule != "" {
+			resolver := resolvers.NewRecordFieldResolver(dao, collection, requestInfo, true)
+			expr, err := search.FilterData(*collection.ViewRule).BuildExpr(resolver)
+			if err != nil {
+				return err
+			}
+			resolver.UpdateQuery(q)
+			q.AndWhere(expr)
+		}
+		return nil
+	}
+}
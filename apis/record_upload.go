@@ -0,0 +1,125 @@
+package apis
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/resolvers"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+// bindRecordUploadApi registers the record files direct storage
+// (aka. pre-signed) upload api endpoints and the corresponding handlers.
+//
+// Both the upload request and finalize require the collection update rule.
+func bindRecordUploadApi(app core.App, rg *echo.Group) {
+	api := recordUploadApi{app: app}
+
+	subGroup := rg.Group(
+		"/collections/:collection/records/:id",
+		ActivityLogger(app),
+		LoadCollectionContext(app, models.CollectionTypeBase, models.CollectionTypeAuth),
+	)
+	subGroup.POST("/presign-upload", api.presign)
+	subGroup.POST("/finalize-upload", api.finalize)
+}
+
+type recordUploadApi struct {
+	app core.App
+}
+
+// presign returns a new pre-signed storage upload url
+// and upload token for a single record file field.
+func (api *recordUploadApi) presign(c echo.Context) error {
+	record, err := api.findRecord(c)
+	if err != nil {
+		return err
+	}
+
+	form := forms.NewRecordPresignedUploadRequest(api.app, record)
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	if err := form.Validate(); err != nil {
+		return NewBadRequestError("An error occurred while validating the submitted data.", err)
+	}
+
+	upload, err := form.Submit()
+	if err != nil {
+		return NewBadRequestError("Failed to generate a pre-signed upload url. Make sure that the S3 storage is enabled.", err)
+	}
+
+	return c.JSON(http.StatusOK, upload)
+}
+
+// finalize verifies the uploaded file and attaches it to the record.
+func (api *recordUploadApi) finalize(c echo.Context) error {
+	record, err := api.findRecord(c)
+	if err != nil {
+		return err
+	}
+
+	form := forms.NewRecordPresignedUploadFinalize(api.app, record)
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	if err := form.Submit(); err != nil {
+		return NewBadRequestError("Failed to finalize the upload.", err)
+	}
+
+	if err := EnrichRecord(c, api.app.Dao(), record); err != nil {
+		api.app.Logger().Debug(
+			"Failed to enrich the upload record",
+			slog.String("id", record.Id),
+			slog.String("collectionName", record.Collection().Name),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	return c.JSON(http.StatusOK, record)
+}
+
+// findRecord loads the upload record applying the collection update rule.
+func (api *recordUploadApi) findRecord(c echo.Context) (*models.Record, error) {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return nil, NewNotFoundError("", "Missing collection context.")
+	}
+
+	requestInfo := RequestInfo(c)
+
+	if requestInfo.Admin == nil && collection.UpdateRule == nil {
+		// only admins can access if the rule is nil
+		return nil, NewForbiddenError("Only admins can perform this action.", nil).WithErrorCode(ErrorCodeRuleForbidden)
+	}
+
+	ruleFunc := func(q *dbx.SelectQuery) error {
+		if requestInfo.Admin == nil {
+			excludeSoftDeleted(q, collection)
+		}
+		if requestInfo.Admin == nil && collection.UpdateRule != nil && *collection.UpdateRule != "" {
+			resolver := resolvers.NewRecordFieldResolver(api.app.Dao(), collection, requestInfo, true)
+			expr, err := search.FilterData(*collection.UpdateRule).BuildExpr(resolver)
+			if err != nil {
+				return err
+			}
+			resolver.UpdateQuery(q)
+			q.AndWhere(expr)
+		}
+		return nil
+	}
+
+	record, fetchErr := api.app.Dao().FindRecordById(collection.Id, c.PathParam("id"), ruleFunc)
+	if fetchErr != nil || record == nil {
+		return nil, NewNotFoundError("", fetchErr)
+	}
+
+	return record, nil
+}
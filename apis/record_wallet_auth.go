@@ -0,0 +1,111 @@
+package apis
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+// bindRecordWalletAuthApi registers the auth record Sign-In with
+// Ethereum (EIP-4361) api endpoints and the corresponding handlers.
+func bindRecordWalletAuthApi(app core.App, rg *echo.Group) {
+	api := recordWalletAuthApi{app: app}
+
+	subGroup := rg.Group(
+		"/collections/:collection",
+		ActivityLogger(app),
+		LoadCollectionContext(app, models.CollectionTypeAuth),
+	)
+	subGroup.POST("/request-wallet-nonce", api.requestNonce, requireWalletAuthEnabled())
+	subGroup.POST("/auth-with-wallet", api.authWithWallet, requireWalletAuthEnabled())
+}
+
+type recordWalletAuthApi struct {
+	app core.App
+}
+
+// requireWalletAuthEnabled middleware requires the loaded context
+// auth collection to have enabled the wallet authentication.
+func requireWalletAuthEnabled() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+			if collection == nil || !collection.AuthOptions().AllowWalletAuth {
+				return NewBadRequestError("The collection is not configured to allow wallet authentication.", nil)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// requestNonce issues a new single use nonce that the client
+// must include in the EIP-4361 message signed by the wallet.
+func (api *recordWalletAuthApi) requestNonce(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+
+	nonce := &models.WalletNonce{
+		CollectionId: collection.Id,
+		Nonce:        security.RandomString(32),
+	}
+
+	txErr := api.app.Dao().RunInTransaction(func(txDao *daos.Dao) error {
+		// cleanup the expired nonces
+		if err := txDao.DeleteOldWalletNonces(time.Now().Add(-models.WalletNonceDuration * time.Second)); err != nil {
+			return err
+		}
+
+		return txDao.SaveWalletNonce(nonce)
+	})
+	if txErr != nil {
+		return NewBadRequestError("Failed to create wallet nonce.", txErr)
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{
+		"nonce":     nonce.Nonce,
+		"domains":   forms.WalletDomains(api.app, collection),
+		"expiresIn": models.WalletNonceDuration,
+	})
+}
+
+// authWithWallet verifies the submitted signed EIP-4361 message
+// and authenticates the auth record linked to the wallet address
+// (a new auth record is created if missing).
+func (api *recordWalletAuthApi) authWithWallet(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+
+	var fallbackAuthRecord *models.Record
+
+	loggedAuthRecord, _ := c.Get(ContextAuthRecordKey).(*models.Record)
+	if loggedAuthRecord != nil && loggedAuthRecord.Collection().Id == collection.Id {
+		fallbackAuthRecord = loggedAuthRecord
+	}
+
+	form := forms.NewRecordWalletLogin(api.app, collection, fallbackAuthRecord)
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	record, submitErr := form.Submit()
+	if submitErr != nil {
+		failure := &core.SecurityEvent{
+			Type:      core.SecurityEventAuthFailure,
+			ActorType: core.SecurityActorAuthRecord,
+			Data: map[string]any{
+				"method":     "wallet",
+				"collection": collection.Name,
+			},
+		}
+		emitSecurityEvent(api.app, c, failure)
+
+		return NewBadRequestError("Failed to authenticate.", submitErr)
+	}
+
+	return RecordAuthResponse(api.app, c, record, nil)
+}
@@ -0,0 +1,246 @@
+package apis_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+// newWalletAuthTestApp creates a new test app with enabled users
+// collection wallet auth and a single "32891756aBcDeF01" nonce.
+//
+// If linkedRecordId is set, the test wallet address is linked to it.
+func newWalletAuthTestApp(t *testing.T, linkedRecordId string) *tests.TestApp {
+	app, err := tests.NewTestAppWithAuthOptions("users", func(options *models.CollectionAuthOptions) {
+		options.AllowWalletAuth = true
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dao := app.Dao().WithoutHooks()
+
+	collection, err := dao.FindCollectionByNameOrId("users")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nonce := &models.WalletNonce{
+		CollectionId: collection.Id,
+		Nonce:        "32891756aBcDeF01",
+	}
+	if err := dao.SaveWalletNonce(nonce); err != nil {
+		t.Fatal(err)
+	}
+
+	if linkedRecordId != "" {
+		rel := &models.ExternalAuth{
+			CollectionId: collection.Id,
+			RecordId:     linkedRecordId,
+			Provider:     forms.WalletProvider,
+			ProviderId:   "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf",
+		}
+		if err := dao.SaveExternalAuth(rel); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return app
+}
+
+// walletAuthBody returns the json encoded auth-with-wallet request body.
+func walletAuthBody(message string, signature string) *strings.Reader {
+	raw, _ := json.Marshal(map[string]string{
+		"message":   message,
+		"signature": signature,
+	})
+
+	return strings.NewReader(string(raw))
+}
+
+func TestRecordWalletAuthRequestNonce(t *testing.T) {
+	scenarios := []tests.ApiScenario{
+		{
+			Name:            "disabled wallet auth",
+			Method:          http.MethodPost,
+			Url:             "/api/collections/users/request-wallet-nonce",
+			ExpectedStatus:  400,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "non auth collection",
+			Method: http.MethodPost,
+			Url:    "/api/collections/demo1/request-wallet-nonce",
+			TestAppFactory: func(t *testing.T) *tests.TestApp {
+				return newWalletAuthTestApp(t, "")
+			},
+			ExpectedStatus:  400,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "enabled wallet auth",
+			Method: http.MethodPost,
+			Url:    "/api/collections/users/request-wallet-nonce",
+			TestAppFactory: func(t *testing.T) *tests.TestApp {
+				return newWalletAuthTestApp(t, "")
+			},
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"nonce":"`,
+				`"domains":["localhost:8090"]`,
+				`"expiresIn":300`,
+			},
+			ExpectedEvents: map[string]int{
+				"OnModelBeforeCreate": 1,
+				"OnModelAfterCreate":  1,
+			},
+			AfterTestFunc: func(t *testing.T, app *tests.TestApp, res *http.Response) {
+				total := 0
+				err := app.Dao().WalletNonceQuery().Select("count(*)").Row(&total)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if total != 2 {
+					t.Fatalf("Expected 2 wallet nonces, got %d", total)
+				}
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
+
+func TestRecordWalletAuthWithWallet(t *testing.T) {
+	// EIP-4361 message signed with the 0x1 private key
+	message := "localhost:8090 wants you to sign in with your Ethereum account:\n0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf\n\nSign in to the test app.\n\nURI: http://localhost:8090\nVersion: 1\nChain ID: 1\nNonce: 32891756aBcDeF01\nIssued At: 2023-12-01T10:00:00Z"
+	signature := "0xa33a3e0146175b80b553f83def4d77e75cf8627599c4a9c80d7988846897c55c14bce9ea27b18fea8b88a567ba8438ad5bfd72b8607bd34b3ee972d149dba5fe1b"
+
+	scenarios := []tests.ApiScenario{
+		{
+			Name:            "disabled wallet auth",
+			Method:          http.MethodPost,
+			Url:             "/api/collections/users/auth-with-wallet",
+			Body:            walletAuthBody(message, signature),
+			ExpectedStatus:  400,
+			ExpectedContent: []string{`"data":{}`},
+		},
+		{
+			Name:   "empty body params",
+			Method: http.MethodPost,
+			Url:    "/api/collections/users/auth-with-wallet",
+			Body:   strings.NewReader(`{}`),
+			TestAppFactory: func(t *testing.T) *tests.TestApp {
+				return newWalletAuthTestApp(t, "")
+			},
+			ExpectedStatus: 400,
+			ExpectedContent: []string{
+				`"message":{`,
+				`"signature":{`,
+			},
+		},
+		{
+			Name:   "not allowed message domain",
+			Method: http.MethodPost,
+			Url:    "/api/collections/users/auth-with-wallet",
+			Body:   walletAuthBody(strings.Replace(message, "localhost:8090 wants", "example.com wants", 1), signature),
+			TestAppFactory: func(t *testing.T) *tests.TestApp {
+				return newWalletAuthTestApp(t, "")
+			},
+			ExpectedStatus: 400,
+			ExpectedContent: []string{
+				`"message":{"code":"validation_invalid_wallet_domain"`,
+			},
+			NotExpectedContent: []string{
+				`"token"`,
+			},
+		},
+		{
+			Name:   "invalid signature",
+			Method: http.MethodPost,
+			Url:    "/api/collections/users/auth-with-wallet",
+			Body:   walletAuthBody(strings.Replace(message, "Sign in to the test app.", "Sign in to another app.", 1), signature),
+			TestAppFactory: func(t *testing.T) *tests.TestApp {
+				return newWalletAuthTestApp(t, "")
+			},
+			ExpectedStatus: 400,
+			ExpectedContent: []string{
+				`"signature":{"code":"validation_invalid_wallet_signature"`,
+			},
+			NotExpectedContent: []string{
+				`"token"`,
+			},
+			ExpectedEvents: map[string]int{
+				"OnModelBeforeDelete": 1,
+				"OnModelAfterDelete":  1,
+			},
+		},
+		{
+			Name:   "new wallet",
+			Method: http.MethodPost,
+			Url:    "/api/collections/users/auth-with-wallet",
+			Body:   walletAuthBody(message, signature),
+			TestAppFactory: func(t *testing.T) *tests.TestApp {
+				return newWalletAuthTestApp(t, "")
+			},
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"record":{`,
+				`"token":"`,
+				`"verified":false`,
+			},
+			ExpectedEvents: map[string]int{
+				"OnModelBeforeDelete": 1,
+				"OnModelAfterDelete":  1,
+				"OnModelBeforeCreate": 2,
+				"OnModelAfterCreate":  2,
+				"OnRecordAuthRequest": 1,
+			},
+			AfterTestFunc: func(t *testing.T, app *tests.TestApp, res *http.Response) {
+				rel, err := app.Dao().FindFirstExternalAuthByExpr(dbx.HashExp{
+					"provider":   forms.WalletProvider,
+					"providerId": "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf",
+				})
+				if err != nil || rel == nil {
+					t.Fatalf("Expected the wallet to be linked, got %v", err)
+				}
+			},
+		},
+		{
+			Name:   "linked wallet",
+			Method: http.MethodPost,
+			Url:    "/api/collections/users/auth-with-wallet",
+			Body:   walletAuthBody(message, signature),
+			TestAppFactory: func(t *testing.T) *tests.TestApp {
+				return newWalletAuthTestApp(t, "4q1xlclmfloku33")
+			},
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"record":{`,
+				`"token":"`,
+				`"id":"4q1xlclmfloku33"`,
+			},
+			ExpectedEvents: map[string]int{
+				"OnModelBeforeDelete": 1,
+				"OnModelAfterDelete":  1,
+				"OnRecordAuthRequest": 1,
+			},
+			AfterTestFunc: func(t *testing.T, app *tests.TestApp, res *http.Response) {
+				if _, err := app.Dao().FindWalletNonce("_pb_users_auth_", "32891756aBcDeF01"); err == nil {
+					t.Fatal("Expected the used wallet nonce to be deleted")
+				}
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
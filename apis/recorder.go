@@ -0,0 +1,385 @@
+package apis
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/pocketbase/pocketbase/tools/routine"
+	"github.com/spf13/cast"
+)
+
+// RecordingLogType is the data.type value of the request recording logs.
+const RecordingLogType = "recording"
+
+// recorderSensitiveHeaders is a list with the lowercased request and
+// response headers whose values are always masked in the recordings.
+var recorderSensitiveHeaders = []string{
+	"authorization",
+	"proxy-authorization",
+	"cookie",
+	"set-cookie",
+	strings.ToLower(settings.AdminUISecretHeader),
+}
+
+// recorderSensitiveKeyRegex matches the header names and the json/form body
+// keys whose values are masked in the recordings.
+var recorderSensitiveKeyRegex = regexp.MustCompile(`(?i)(password|token|secret|signature|api[\-_]?key|otp)`)
+
+type replayContextKey struct{}
+
+// requestRecorder middleware stores the request/response pairs of the
+// configured recorder routes in the logs db (with masked secrets).
+func requestRecorder(app core.App) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			config := app.Settings().Recorder
+
+			if !config.Enabled ||
+				// don't record the replayed requests
+				c.Request().Context().Value(replayContextKey{}) != nil ||
+				!config.IsRecordedRoute(c.Request().URL.Path) {
+				return next(c)
+			}
+
+			request := c.Request()
+
+			requestBody, requestBodyTruncated, err := peekRequestBody(request, config.MaxBodySize)
+			if err != nil {
+				return NewBadRequestError("Failed to read the request body.", err)
+			}
+
+			rw := &recorderResponseWriter{
+				ResponseWriter: c.Response().Writer,
+				maxBodySize:    config.MaxBodySize,
+			}
+			c.Response().Writer = rw
+
+			err = next(c)
+
+			// write the error response while the recorder writer is still attached
+			if err != nil {
+				c.Error(err)
+			}
+
+			c.Response().Writer = rw.ResponseWriter
+
+			requestUri := request.RequestURI
+			if requestUri == "" {
+				requestUri = request.URL.RequestURI()
+			}
+
+			log := &models.Log{
+				Message: "Recorded " + strings.ToUpper(request.Method) + " " + request.URL.Path,
+				Level:   int(slog.LevelInfo),
+				Data: map[string]any{
+					"type":      RecordingLogType,
+					"requestId": RequestId(c),
+					"request": map[string]any{
+						"method":        strings.ToUpper(request.Method),
+						"url":           scrubRecordedUrl(requestUri),
+						"headers":       scrubRecordedHeaders(request.Header),
+						"body":          scrubRecordedBody(request.Header.Get(echo.HeaderContentType), requestBody),
+						"bodyTruncated": requestBodyTruncated,
+					},
+					"response": map[string]any{
+						"status":        c.Response().Status,
+						"headers":       scrubRecordedHeaders(c.Response().Header()),
+						"body":          scrubRecordedBody(c.Response().Header().Get(echo.HeaderContentType), rw.body.Bytes()),
+						"bodyTruncated": rw.truncated,
+					},
+				},
+			}
+
+			// don't block on the recording write
+			routine.FireAndForget(func() {
+				if err := app.LogsDao().SaveLog(log); err != nil {
+					app.Logger().Debug("Failed to save request recording", slog.String("error", err.Error()))
+				}
+			})
+
+			return nil
+		}
+	}
+}
+
+// peekRequestBody reads up to maxSize bytes from the request body
+// and restores the request body so that it could be read again.
+func peekRequestBody(r *http.Request, maxSize int) ([]byte, bool, error) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, false, nil
+	}
+
+	peeked, err := io.ReadAll(io.LimitReader(r.Body, int64(maxSize)+1))
+	if err != nil {
+		return nil, false, err
+	}
+
+	r.Body = &struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(peeked), r.Body), r.Body}
+
+	if len(peeked) > maxSize {
+		return peeked[:maxSize], true, nil
+	}
+
+	return peeked, false, nil
+}
+
+func isRecorderSensitiveHeader(name string) bool {
+	name = strings.ToLower(name)
+
+	for _, h := range recorderSensitiveHeaders {
+		if name == h {
+			return true
+		}
+	}
+
+	return recorderSensitiveKeyRegex.MatchString(name)
+}
+
+// scrubRecordedHeaders flattens the provided headers and masks the sensitive ones.
+func scrubRecordedHeaders(headers http.Header) map[string]string {
+	result := make(map[string]string, len(headers))
+
+	for name, values := range headers {
+		if isRecorderSensitiveHeader(name) {
+			result[name] = settings.SecretMask
+		} else {
+			result[name] = strings.Join(values, ", ")
+		}
+	}
+
+	return result
+}
+
+// scrubRecordedUrl returns the provided request uri
+// with masked sensitive query parameters.
+func scrubRecordedUrl(requestUri string) string {
+	u, err := url.ParseRequestURI(requestUri)
+	if err != nil || u.RawQuery == "" {
+		return requestUri
+	}
+
+	values, err := url.ParseQuery(u.RawQuery)
+	if err != nil {
+		// don't risk storing an unparsable (and possibly sensitive) query
+		u.RawQuery = ""
+		return u.RequestURI()
+	}
+
+	var masked bool
+	for key := range values {
+		if recorderSensitiveKeyRegex.MatchString(key) {
+			values.Set(key, settings.SecretMask)
+			masked = true
+		}
+	}
+
+	if masked {
+		u.RawQuery = values.Encode()
+	}
+
+	return u.RequestURI()
+}
+
+// scrubRecordedBody returns the string representation of the provided body
+// with masked sensitive json and form fields.
+//
+// Multipart and binary bodies are not stored.
+func scrubRecordedBody(contentType string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	switch {
+	case strings.HasPrefix(contentType, echo.MIMEApplicationJSON):
+		var data any
+		if err := json.Unmarshal(body, &data); err != nil {
+			break // eg. truncated
+		}
+
+		if scrubbed, err := json.Marshal(scrubRecordedValue(data)); err == nil {
+			return string(scrubbed)
+		}
+	case strings.HasPrefix(contentType, echo.MIMEApplicationForm):
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			break
+		}
+
+		for key := range values {
+			if recorderSensitiveKeyRegex.MatchString(key) {
+				values.Set(key, settings.SecretMask)
+			}
+		}
+
+		return values.Encode()
+	case strings.HasPrefix(contentType, echo.MIMEMultipartForm):
+		return ""
+	}
+
+	if !utf8.Valid(body) {
+		return ""
+	}
+
+	return string(body)
+}
+
+func scrubRecordedValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			if recorderSensitiveKeyRegex.MatchString(key) {
+				v[key] = settings.SecretMask
+			} else {
+				v[key] = scrubRecordedValue(item)
+			}
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = scrubRecordedValue(item)
+		}
+	}
+
+	return value
+}
+
+// -------------------------------------------------------------------
+
+var (
+	_ http.Flusher  = (*recorderResponseWriter)(nil)
+	_ http.Hijacker = (*recorderResponseWriter)(nil)
+)
+
+// recorderResponseWriter captures up to maxBodySize response body bytes.
+type recorderResponseWriter struct {
+	http.ResponseWriter
+
+	maxBodySize int
+	body        bytes.Buffer
+	truncated   bool
+}
+
+// Write implements [http.ResponseWriter.Write].
+func (w *recorderResponseWriter) Write(b []byte) (int, error) {
+	if remaining := w.maxBodySize - w.body.Len(); remaining < len(b) {
+		w.body.Write(b[:max(remaining, 0)])
+		w.truncated = true
+	} else {
+		w.body.Write(b)
+	}
+
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush implements [http.Flusher].
+func (w *recorderResponseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack implements [http.Hijacker].
+func (w *recorderResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if h, ok := w.ResponseWriter.(http.Hijacker); ok {
+		return h.Hijack()
+	}
+
+	return nil, nil, errors.New("the underlying response writer doesn't implement http.Hijacker")
+}
+
+// Unwrap returns the underlying response writer (used by [http.ResponseController]).
+func (w *recorderResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// -------------------------------------------------------------------
+
+type replayRequestForm struct {
+	// Headers are additional request headers to send with the replayed request
+	// (eg. a fresh Authorization token since the recorded one is masked).
+	Headers map[string]string `json:"headers"`
+}
+
+// replay re-executes a recorded request against the current app routes
+// and returns the recorded and the new response.
+func (api *logsApi) replay(c echo.Context) error {
+	log, err := api.app.LogsDao().FindLogById(c.PathParam("id"))
+	if err != nil || log == nil {
+		return NewNotFoundError("", err)
+	}
+
+	if cast.ToString(log.Data.Get("type")) != RecordingLogType {
+		return NewBadRequestError("The log is not a request recording.", nil)
+	}
+
+	form := new(replayRequestForm)
+	if c.Request().ContentLength > 0 {
+		if err := c.Bind(form); err != nil {
+			return NewBadRequestError("An error occurred while loading the submitted data.", err)
+		}
+	}
+
+	recorded := cast.ToStringMap(log.Data.Get("request"))
+	recordedHeaders := cast.ToStringMapString(recorded["headers"])
+	recordedBody := cast.ToString(recorded["body"])
+
+	if cast.ToBool(recorded["bodyTruncated"]) ||
+		(recordedBody == "" && strings.HasPrefix(recordedHeaders[echo.HeaderContentType], echo.MIMEMultipartForm)) {
+		return NewBadRequestError("The recorded request body is incomplete and can't be replayed.", nil)
+	}
+
+	ctx := context.WithValue(c.Request().Context(), replayContextKey{}, true)
+
+	req, err := http.NewRequestWithContext(
+		ctx,
+		cast.ToString(recorded["method"]),
+		cast.ToString(recorded["url"]),
+		strings.NewReader(recordedBody),
+	)
+	if err != nil {
+		return NewBadRequestError("Failed to rebuild the recorded request.", err)
+	}
+	req.RemoteAddr = c.Request().RemoteAddr
+	req.RequestURI = cast.ToString(recorded["url"])
+
+	for name, value := range recordedHeaders {
+		if value == settings.SecretMask || strings.EqualFold(name, echo.HeaderContentLength) {
+			continue // masked or no longer valid
+		}
+		req.Header.Set(name, value)
+	}
+	for name, value := range form.Headers {
+		req.Header.Set(name, value)
+	}
+
+	rec := httptest.NewRecorder()
+
+	c.Echo().ServeHTTP(rec, req)
+
+	return c.JSON(http.StatusOK, map[string]any{
+		"recorded": log.Data.Get("response"),
+		"replayed": map[string]any{
+			"status":  rec.Code,
+			"headers": scrubRecordedHeaders(rec.Header()),
+			"body":    scrubRecordedBody(rec.Header().Get(echo.HeaderContentType), rec.Body.Bytes()),
+		},
+	})
+}
@@ -0,0 +1,95 @@
+package apis
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// RequestScope is a typed helper around the current request state
+// intended to simplify writing custom routes, eg.:
+//
+//	e.Router.GET("/hello", func(c echo.Context) error {
+//		scope := apis.NewRequestScope(app, c)
+//
+//		scope.Logger().Info("hello", "admin", scope.Admin() != nil)
+//
+//		record, err := scope.Dao().FindRecordById("posts", "RECORD_ID")
+//		...
+//	})
+type RequestScope struct {
+	app core.App
+	c   echo.Context
+}
+
+// NewRequestScope creates a new RequestScope for the provided request.
+func NewRequestScope(app core.App, c echo.Context) *RequestScope {
+	return &RequestScope{app: app, c: c}
+}
+
+// App returns the current app instance.
+func (s *RequestScope) App() core.App {
+	return s.app
+}
+
+// Echo returns the underlying echo request context.
+func (s *RequestScope) Echo() echo.Context {
+	return s.c
+}
+
+// Context returns the request [context.Context]
+// (it is canceled when the client connection closes).
+func (s *RequestScope) Context() context.Context {
+	return s.c.Request().Context()
+}
+
+// RequestId returns the current request correlation id.
+func (s *RequestScope) RequestId() string {
+	return RequestId(s.c)
+}
+
+// RequestInfo returns the cached request info data (query params, body, auth state, etc.).
+func (s *RequestScope) RequestInfo() *models.RequestInfo {
+	return RequestInfo(s.c)
+}
+
+// Admin returns the authenticated admin (if any).
+func (s *RequestScope) Admin() *models.Admin {
+	admin, _ := s.c.Get(ContextAdminKey).(*models.Admin)
+
+	return admin
+}
+
+// AuthRecord returns the authenticated auth record (if any).
+func (s *RequestScope) AuthRecord() *models.Record {
+	record, _ := s.c.Get(ContextAuthRecordKey).(*models.Record)
+
+	return record
+}
+
+// ApiKey returns the api key of the current request (if any).
+func (s *RequestScope) ApiKey() *models.ApiKey {
+	apiKey, _ := s.c.Get(ContextApiKeyKey).(*models.ApiKey)
+
+	return apiKey
+}
+
+// IsGuest reports whether the current request is not authenticated.
+func (s *RequestScope) IsGuest() bool {
+	return s.Admin() == nil && s.AuthRecord() == nil
+}
+
+// Dao returns a new app Dao bound to the request context, aka. its
+// running queries are canceled when the request is canceled or its deadline exceeds.
+func (s *RequestScope) Dao() *daos.Dao {
+	return s.app.Dao().WithContext(s.Context())
+}
+
+// Logger returns the app logger with the current request id attached.
+func (s *RequestScope) Logger() *slog.Logger {
+	return s.app.Logger().With(slog.String("requestId", s.RequestId()))
+}
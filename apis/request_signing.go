@@ -0,0 +1,173 @@
+package apis
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+const (
+	// HeaderRequestSignature is the request header that holds the
+	// hex encoded HMAC-SHA256 signature of the signed request.
+	HeaderRequestSignature = "X-Signature"
+
+	// HeaderRequestSignatureTimestamp is the request header that holds
+	// the unix timestamp (in seconds) of the signed request.
+	HeaderRequestSignatureTimestamp = "X-Signature-Timestamp"
+)
+
+// maxUsedSignatures is the max number of the remembered (aka. not expired)
+// request signatures. New signed requests are rejected until some of
+// the remembered signatures expire.
+const maxUsedSignatures = 10000
+
+// maxSignedRequestBodySize is the max allowed body size (in bytes) of a signed request.
+const maxSignedRequestBodySize = 5 << 20
+
+// RequestSignature returns the hex encoded HMAC-SHA256 signature of
+// the "timestamp.method.requestURI.body" string using the provided secret.
+//
+// requestURI is the request path with the query string as it is sent
+// by the client (eg. "/api/hooks/test?a=1").
+//
+// Server-to-server callers are expected to send the result in the
+// [HeaderRequestSignature] header together with the used timestamp in
+// the [HeaderRequestSignatureTimestamp] header.
+func RequestSignature(secret string, timestamp string, method string, requestURI string, body []byte) string {
+	return security.HS256(timestamp+"."+strings.ToUpper(method)+"."+requestURI+"."+string(body), secret)
+}
+
+// requestSigning creates a middleware that verifies the signature of the
+// requests to the routes listed in the app settings request signing config.
+//
+// Requests with an invalid or already used signature, or with a timestamp
+// outside of the allowed time window are rejected with 401.
+// Requests with body larger than 5MB are rejected with 413.
+func requestSigning(app core.App) echo.MiddlewareFunc {
+	// signatures of the already verified requests (used to prevent replays)
+	usedSignatures := &signaturesStore{data: map[string]time.Time{}}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			config := app.Settings().RequestSigning
+
+			if len(config.Routes) == 0 || !config.IsSignedRoute(c.Request().URL.Path) {
+				return next(c)
+			}
+
+			if err := verifyRequestSignature(c, config.Secret, config.MaxAge, usedSignatures); err != nil {
+				var maxBytesErr *http.MaxBytesError
+				if errors.As(err, &maxBytesErr) {
+					return NewApiError(http.StatusRequestEntityTooLarge, "The request body is too large.", nil)
+				}
+
+				app.Logger().Warn(
+					"Invalid request signature",
+					slog.String("type", "signature_invalid"),
+					slog.String("error", err.Error()),
+					slog.String("ip", c.RealIP()),
+					slog.String("method", c.Request().Method),
+					slog.String("url", c.Request().URL.RequestURI()),
+				)
+
+				return NewUnauthorizedError("Missing or invalid request signature.", nil)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+func verifyRequestSignature(c echo.Context, secret string, maxAge int, usedSignatures *signaturesStore) error {
+	rawTimestamp := c.Request().Header.Get(HeaderRequestSignatureTimestamp)
+	signature := strings.ToLower(strings.TrimPrefix(c.Request().Header.Get(HeaderRequestSignature), "sha256="))
+
+	if rawTimestamp == "" || signature == "" {
+		return errors.New("missing signature headers")
+	}
+
+	timestamp, err := strconv.ParseInt(rawTimestamp, 10, 64)
+	if err != nil {
+		return errors.New("invalid signature timestamp")
+	}
+
+	now := time.Now()
+	if math.Abs(float64(now.Unix()-timestamp)) > float64(maxAge) {
+		return errors.New("expired signature timestamp")
+	}
+
+	req := c.Request()
+
+	var body []byte
+	if req.Body != nil {
+		body, err = io.ReadAll(http.MaxBytesReader(c.Response(), req.Body, maxSignedRequestBodySize))
+		if err != nil {
+			return err
+		}
+		req.Body.Close()
+
+		// restore the body for the next handlers
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	// use the original request target because the
+	// pre middlewares could have modified the url path
+	requestURI := req.RequestURI
+	if requestURI == "" {
+		requestURI = req.URL.RequestURI()
+	}
+
+	if !security.Equal(RequestSignature(secret, rawTimestamp, req.Method, requestURI, body), signature) {
+		return errors.New("signature mismatch")
+	}
+
+	// the signature could be reused only after its timestamp becomes invalid
+	expires := time.Unix(timestamp, 0).Add(time.Duration(maxAge) * time.Second)
+
+	return usedSignatures.add(signature, expires, now)
+}
+
+// signaturesStore is a bounded store of the used request signatures
+// and their expiration time.
+type signaturesStore struct {
+	mux  sync.Mutex
+	data map[string]time.Time
+}
+
+// add prunes the expired signatures and remembers the provided one.
+//
+// It returns an error if the signature is already used
+// or the store has reached the [maxUsedSignatures] limit.
+func (s *signaturesStore) add(signature string, expires time.Time, now time.Time) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	for k, v := range s.data {
+		if v.Before(now) {
+			delete(s.data, k)
+		}
+	}
+
+	if _, ok := s.data[signature]; ok {
+		return errors.New("already used signature")
+	}
+
+	if len(s.data) >= maxUsedSignatures {
+		return errors.New("too many signed requests")
+	}
+
+	s.data[signature] = expires
+
+	return nil
+}
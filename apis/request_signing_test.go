@@ -0,0 +1,226 @@
+package apis_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/apis"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestRequestSigning(t *testing.T) {
+	secret := strings.Repeat("a", 30)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	expiredTimestamp := strconv.FormatInt(time.Now().Add(-10*time.Minute).Unix(), 10)
+
+	enableSigning := func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+		app.Settings().RequestSigning.Secret = secret
+		app.Settings().RequestSigning.MaxAge = 60
+		app.Settings().RequestSigning.Routes = []string{"/api/health", "/api/signed"}
+	}
+
+	// signedRoute enables the signing and registers a
+	// test route that responds with the request body
+	signedRoute := func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+		enableSigning(t, app, e)
+
+		e.POST("/api/signed", func(c echo.Context) error {
+			body, err := io.ReadAll(c.Request().Body)
+			if err != nil {
+				return err
+			}
+
+			return c.String(http.StatusOK, "body:"+string(body))
+		})
+	}
+
+	largeBody := strings.Repeat("a", 5<<20+1)
+
+	scenarios := []tests.ApiScenario{
+		{
+			Name:           "no signed routes",
+			Method:         http.MethodGet,
+			Url:            "/api/health",
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"code":200`,
+			},
+		},
+		{
+			Name:   "not signed route",
+			Method: http.MethodGet,
+			Url:    "/api/health",
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				enableSigning(t, app, e)
+				app.Settings().RequestSigning.Routes = []string{"/api/hooks/*"}
+			},
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"code":200`,
+			},
+		},
+		{
+			Name:           "signed route with missing signature headers",
+			Method:         http.MethodGet,
+			Url:            "/api/health",
+			BeforeTestFunc: enableSigning,
+			ExpectedStatus: 401,
+			ExpectedContent: []string{
+				`"data":{}`,
+			},
+		},
+		{
+			Name:   "signed route with invalid signature",
+			Method: http.MethodGet,
+			Url:    "/api/health",
+			RequestHeaders: map[string]string{
+				apis.HeaderRequestSignatureTimestamp: timestamp,
+				apis.HeaderRequestSignature:          apis.RequestSignature("invalid", timestamp, http.MethodGet, "/api/health", nil),
+			},
+			BeforeTestFunc: enableSigning,
+			ExpectedStatus: 401,
+			ExpectedContent: []string{
+				`"data":{}`,
+			},
+		},
+		{
+			Name:   "signed route with expired timestamp",
+			Method: http.MethodGet,
+			Url:    "/api/health",
+			RequestHeaders: map[string]string{
+				apis.HeaderRequestSignatureTimestamp: expiredTimestamp,
+				apis.HeaderRequestSignature:          apis.RequestSignature(secret, expiredTimestamp, http.MethodGet, "/api/health", nil),
+			},
+			BeforeTestFunc: enableSigning,
+			ExpectedStatus: 401,
+			ExpectedContent: []string{
+				`"data":{}`,
+			},
+		},
+		{
+			Name:   "signed route with valid signature",
+			Method: http.MethodGet,
+			Url:    "/api/health",
+			RequestHeaders: map[string]string{
+				apis.HeaderRequestSignatureTimestamp: timestamp,
+				apis.HeaderRequestSignature:          "sha256=" + apis.RequestSignature(secret, timestamp, http.MethodGet, "/api/health", nil),
+			},
+			BeforeTestFunc: enableSigning,
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				`"code":200`,
+			},
+		},
+		{
+			Name:   "signed route with signature for another url",
+			Method: http.MethodGet,
+			Url:    "/api/health?a=1",
+			RequestHeaders: map[string]string{
+				apis.HeaderRequestSignatureTimestamp: timestamp,
+				apis.HeaderRequestSignature:          apis.RequestSignature(secret, timestamp, http.MethodGet, "/api/health", nil),
+			},
+			BeforeTestFunc: enableSigning,
+			ExpectedStatus: 401,
+			ExpectedContent: []string{
+				`"data":{}`,
+			},
+		},
+		{
+			Name:   "signed route with signature for another method",
+			Method: http.MethodPost,
+			Url:    "/api/signed",
+			Body:   strings.NewReader("test"),
+			RequestHeaders: map[string]string{
+				apis.HeaderRequestSignatureTimestamp: timestamp,
+				apis.HeaderRequestSignature:          apis.RequestSignature(secret, timestamp, http.MethodPut, "/api/signed", []byte("test")),
+			},
+			BeforeTestFunc: signedRoute,
+			ExpectedStatus: 401,
+			ExpectedContent: []string{
+				`"data":{}`,
+			},
+		},
+		{
+			Name:   "signed route with signature for another body",
+			Method: http.MethodPost,
+			Url:    "/api/signed",
+			Body:   strings.NewReader("test"),
+			RequestHeaders: map[string]string{
+				apis.HeaderRequestSignatureTimestamp: timestamp,
+				apis.HeaderRequestSignature:          apis.RequestSignature(secret, timestamp, http.MethodPost, "/api/signed", []byte("other")),
+			},
+			BeforeTestFunc: signedRoute,
+			ExpectedStatus: 401,
+			ExpectedContent: []string{
+				`"data":{}`,
+			},
+		},
+		{
+			Name:   "signed route with valid body signature",
+			Method: http.MethodPost,
+			Url:    "/api/signed?a=1",
+			Body:   strings.NewReader("test"),
+			RequestHeaders: map[string]string{
+				apis.HeaderRequestSignatureTimestamp: timestamp,
+				apis.HeaderRequestSignature:          apis.RequestSignature(secret, timestamp, http.MethodPost, "/api/signed?a=1", []byte("test")),
+			},
+			BeforeTestFunc: signedRoute,
+			ExpectedStatus: 200,
+			ExpectedContent: []string{
+				"body:test",
+			},
+		},
+		{
+			Name:   "signed route with too large body",
+			Method: http.MethodPost,
+			Url:    "/api/signed",
+			Body:   strings.NewReader(largeBody),
+			RequestHeaders: map[string]string{
+				apis.HeaderRequestSignatureTimestamp: timestamp,
+				apis.HeaderRequestSignature:          apis.RequestSignature(secret, timestamp, http.MethodPost, "/api/signed", []byte(largeBody)),
+			},
+			BeforeTestFunc: signedRoute,
+			ExpectedStatus: 413,
+			ExpectedContent: []string{
+				`"data":{}`,
+			},
+		},
+		{
+			Name:   "signed route with replayed signature",
+			Method: http.MethodGet,
+			Url:    "/api/health",
+			RequestHeaders: map[string]string{
+				apis.HeaderRequestSignatureTimestamp: timestamp,
+				apis.HeaderRequestSignature:          apis.RequestSignature(secret, timestamp, http.MethodGet, "/api/health", nil),
+			},
+			BeforeTestFunc: func(t *testing.T, app *tests.TestApp, e *echo.Echo) {
+				enableSigning(t, app, e)
+
+				// send the same request once
+				req := httptest.NewRequest(http.MethodGet, "/api/health", nil)
+				req.Header.Set(apis.HeaderRequestSignatureTimestamp, timestamp)
+				req.Header.Set(apis.HeaderRequestSignature, apis.RequestSignature(secret, timestamp, http.MethodGet, "/api/health", nil))
+				rec := httptest.NewRecorder()
+				e.ServeHTTP(rec, req)
+
+				if rec.Code != 200 {
+					t.Fatalf("Expected the first request to succeed, got %d", rec.Code)
+				}
+			},
+			ExpectedStatus: 401,
+			ExpectedContent: []string{
+				`"data":{}`,
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario.Test(t)
+	}
+}
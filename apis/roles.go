@@ -0,0 +1,192 @@
+package apis
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+// bindRolesApi registers the roles management and the auth record
+// role assignments api endpoints and the corresponding handlers.
+//
+// The assigned roles could be checked in the collection API rules
+// with the "@request.auth.roles" filter field.
+func bindRolesApi(app core.App, rg *echo.Group) {
+	api := rolesApi{app: app}
+
+	subGroup := rg.Group("/roles", ActivityLogger(app), RequireAdminAuth())
+	subGroup.GET("", api.list)
+	subGroup.POST("", api.create)
+	subGroup.GET("/:role", api.view)
+	subGroup.PATCH("/:role", api.update)
+	subGroup.DELETE("/:role", api.delete)
+
+	recordGroup := rg.Group(
+		"/collections/:collection/records/:id/roles",
+		ActivityLogger(app),
+		LoadCollectionContext(app, models.CollectionTypeAuth),
+	)
+	recordGroup.GET("", api.listRecordRoles, RequireAdminOrOwnerAuth("id"))
+	recordGroup.POST("", api.assignRecordRole, RequireAdminAuth())
+	recordGroup.DELETE("/:role", api.unassignRecordRole, RequireAdminAuth())
+}
+
+type rolesApi struct {
+	app core.App
+}
+
+// recordRoleAssignForm defines the record role assign request body.
+type recordRoleAssignForm struct {
+	// Role is the name or id of the role to assign.
+	Role string `form:"role" json:"role"`
+}
+
+func (api *rolesApi) list(c echo.Context) error {
+	fieldResolver := search.NewSimpleFieldResolver(
+		"id", "created", "updated", "name", "description",
+	)
+
+	roles := []*models.Role{}
+
+	result, err := search.NewProvider(fieldResolver).
+		Query(api.app.Dao().RoleQuery()).
+		ParseAndExec(c.QueryParams().Encode(), &roles)
+
+	if err != nil {
+		return NewBadRequestError("", err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (api *rolesApi) view(c echo.Context) error {
+	role, err := api.app.Dao().FindRoleByNameOrId(c.PathParam("role"))
+	if err != nil || role == nil {
+		return NewNotFoundError("", err)
+	}
+
+	return c.JSON(http.StatusOK, role)
+}
+
+func (api *rolesApi) create(c echo.Context) error {
+	role := &models.Role{}
+
+	form := forms.NewRoleUpsert(api.app, role)
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("Failed to load the submitted data due to invalid formatting.", err)
+	}
+
+	if err := form.Submit(); err != nil {
+		return NewBadRequestError("Failed to create role.", err)
+	}
+
+	return c.JSON(http.StatusOK, role)
+}
+
+func (api *rolesApi) update(c echo.Context) error {
+	role, err := api.app.Dao().FindRoleByNameOrId(c.PathParam("role"))
+	if err != nil || role == nil {
+		return NewNotFoundError("", err)
+	}
+
+	form := forms.NewRoleUpsert(api.app, role)
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("Failed to load the submitted data due to invalid formatting.", err)
+	}
+
+	if err := form.Submit(); err != nil {
+		return NewBadRequestError("Failed to update role.", err)
+	}
+
+	return c.JSON(http.StatusOK, role)
+}
+
+func (api *rolesApi) delete(c echo.Context) error {
+	role, err := api.app.Dao().FindRoleByNameOrId(c.PathParam("role"))
+	if err != nil || role == nil {
+		return NewNotFoundError("", err)
+	}
+
+	if err := api.app.Dao().DeleteRole(role); err != nil {
+		return NewBadRequestError("Failed to delete role.", err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (api *rolesApi) listRecordRoles(c echo.Context) error {
+	record, err := api.findRecord(c)
+	if err != nil {
+		return err
+	}
+
+	return api.respondWithRecordRoles(c, record)
+}
+
+func (api *rolesApi) assignRecordRole(c echo.Context) error {
+	record, err := api.findRecord(c)
+	if err != nil {
+		return err
+	}
+
+	form := &recordRoleAssignForm{}
+	if err := c.Bind(form); err != nil {
+		return NewBadRequestError("Failed to load the submitted data due to invalid formatting.", err)
+	}
+
+	role, err := api.app.Dao().FindRoleByNameOrId(form.Role)
+	if err != nil || role == nil {
+		return NewBadRequestError("Missing or invalid role.", err)
+	}
+
+	if err := api.app.Dao().AssignRecordRole(record, role); err != nil {
+		return NewBadRequestError("Failed to assign role.", err)
+	}
+
+	return api.respondWithRecordRoles(c, record)
+}
+
+func (api *rolesApi) unassignRecordRole(c echo.Context) error {
+	record, err := api.findRecord(c)
+	if err != nil {
+		return err
+	}
+
+	role, err := api.app.Dao().FindRoleByNameOrId(c.PathParam("role"))
+	if err != nil || role == nil {
+		return NewNotFoundError("", err)
+	}
+
+	if err := api.app.Dao().UnassignRecordRole(record, role); err != nil {
+		return NewBadRequestError("Failed to unassign role.", err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (api *rolesApi) findRecord(c echo.Context) (*models.Record, error) {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return nil, NewNotFoundError("Missing collection context.", nil)
+	}
+
+	record, err := api.app.Dao().FindRecordById(collection.Id, c.PathParam("id"))
+	if err != nil || record == nil {
+		return nil, NewNotFoundError("", err)
+	}
+
+	return record, nil
+}
+
+func (api *rolesApi) respondWithRecordRoles(c echo.Context, record *models.Record) error {
+	roles, err := api.app.Dao().FindRecordRoles(record)
+	if err != nil {
+		return NewBadRequestError("Failed to load the record roles.", err)
+	}
+
+	return c.JSON(http.StatusOK, roles)
+}
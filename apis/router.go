@@ -0,0 +1,119 @@
+package apis
+
+import (
+	"errors"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/tools/router"
+)
+
+// echoRoutable defines the common route registration methods
+// of *echo.Echo and *echo.Group.
+type echoRoutable interface {
+	Use(middlewares ...echo.MiddlewareFunc)
+	Group(prefix string, middlewares ...echo.MiddlewareFunc) *echo.Group
+	Add(method string, path string, handler echo.HandlerFunc, middlewares ...echo.MiddlewareFunc) echo.RouteInfo
+}
+
+var _ router.Router = (*echoRouter)(nil)
+
+// echoRouter is a [router.Router] implementation backed by echo.
+type echoRouter struct {
+	target echoRoutable
+}
+
+// NewEchoRouter wraps the provided echo instance as framework agnostic [router.Router].
+//
+// This allows registering custom routes that doesn't depend directly on echo
+// and that will continue to work after the HTTP layer migration, eg.:
+//
+//	app.OnBeforeServe().Add(func(e *core.ServeEvent) error {
+//		router.GET(e.HttpRouter, "/hello/:name", func(e *router.Event) error {
+//			return e.String(200, "Hello "+e.PathParam("name"))
+//		})
+//		return nil
+//	})
+func NewEchoRouter(e *echo.Echo) router.Router {
+	return &echoRouter{target: e}
+}
+
+// Use implements [router.Router.Use].
+func (r *echoRouter) Use(middlewares ...router.Middleware) {
+	r.target.Use(wrapMiddlewares(middlewares)...)
+}
+
+// Group implements [router.Router.Group].
+func (r *echoRouter) Group(prefix string, middlewares ...router.Middleware) router.Router {
+	return &echoRouter{target: r.target.Group(prefix, wrapMiddlewares(middlewares)...)}
+}
+
+// Add implements [router.Router.Add].
+func (r *echoRouter) Add(method string, path string, handler router.HandlerFunc, middlewares ...router.Middleware) {
+	r.target.Add(method, path, WrapHandler(handler), wrapMiddlewares(middlewares)...)
+}
+
+// newRouterEvent creates a new [router.Event] from the provided echo context.
+//
+// The echo context is used as event store so that the values
+// set by the echo middlewares are accessible and vice versa.
+func newRouterEvent(c echo.Context) *router.Event {
+	return router.NewEvent(c.Response(), c.Request(), c.PathParam, c)
+}
+
+// WrapHandler converts the provided [router.HandlerFunc] into echo.HandlerFunc.
+func WrapHandler(handler router.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return handler(newRouterEvent(c))
+	}
+}
+
+// WrapMiddleware converts the provided [router.Middleware] into echo.MiddlewareFunc.
+func WrapMiddleware(middleware router.Middleware) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			return middleware(func(e *router.Event) error {
+				// sync the request in case it was replaced by the middleware (eg. r.WithContext)
+				c.SetRequest(e.Request)
+
+				return next(c)
+			})(newRouterEvent(c))
+		}
+	}
+}
+
+func wrapMiddlewares(middlewares []router.Middleware) []echo.MiddlewareFunc {
+	result := make([]echo.MiddlewareFunc, len(middlewares))
+
+	for i, m := range middlewares {
+		result[i] = WrapMiddleware(m)
+	}
+
+	return result
+}
+
+// EchoContext returns the echo context associated with the provided
+// routing event (or nil if the event is not served by the echo router).
+//
+// NB! This is a compatibility shim for the transition period and
+// it will be removed once the HTTP layer is migrated off echo.
+func EchoContext(e *router.Event) echo.Context {
+	c, _ := e.Store().(echo.Context)
+
+	return c
+}
+
+// EchoHandler converts an existing echo.HandlerFunc into [router.HandlerFunc]
+// allowing to register the old style route handlers with the new [router.Router].
+//
+// NB! This is a compatibility shim for the transition period and
+// it will be removed once the HTTP layer is migrated off echo.
+func EchoHandler(handler echo.HandlerFunc) router.HandlerFunc {
+	return func(e *router.Event) error {
+		c := EchoContext(e)
+		if c == nil {
+			return errors.New("echo handlers can be used only with the echo router")
+		}
+
+		return handler(c)
+	}
+}
@@ -0,0 +1,236 @@
+package apis
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+// Supported rules debug actions.
+const (
+	RuleDebugActionList   = "list"
+	RuleDebugActionView   = "view"
+	RuleDebugActionCreate = "create"
+	RuleDebugActionUpdate = "update"
+	RuleDebugActionDelete = "delete"
+)
+
+// bindRulesDebugApi registers the collection rules debug api endpoint.
+func bindRulesDebugApi(app core.App, rg *echo.Group) {
+	api := rulesDebugApi{app: app}
+
+	subGroup := rg.Group(
+		"/collections/:collection/rules",
+		ActivityLogger(app),
+		RequireAdminAuth(),
+		LoadCollectionContext(app),
+	)
+	subGroup.POST("/debug", api.debug)
+}
+
+type rulesDebugApi struct {
+	app core.App
+}
+
+// ruleDebugRequest describes a hypothetical request which rule to evaluate.
+type ruleDebugRequest struct {
+	Action         string         `json:"action"`
+	RecordId       string         `json:"recordId"`
+	AuthCollection string         `json:"authCollection"`
+	AuthRecordId   string         `json:"authRecordId"`
+	Data           map[string]any `json:"data"`
+	Query          map[string]any `json:"query"`
+	Headers        map[string]any `json:"headers"`
+}
+
+// ruleDebugResult describes the step by step evaluation result of a collection rule.
+type ruleDebugResult struct {
+	Action  string            `json:"action"`
+	Rule    *string           `json:"rule"`
+	Allowed bool              `json:"allowed"`
+	Reason  string            `json:"reason"`
+	Clauses []ruleDebugClause `json:"clauses"`
+}
+
+type ruleDebugClause struct {
+	search.FilterClause
+
+	Passed bool   `json:"passed"`
+	Error  string `json:"error,omitempty"`
+}
+
+// debug evaluates the collection rule of the submitted action
+// against a hypothetical request and reports the result of each
+// top level rule clause.
+//
+// The evaluation is always dry - no records are created or changed.
+func (api *rulesDebugApi) debug(c echo.Context) error {
+	collection, _ := c.Get(ContextCollectionKey).(*models.Collection)
+	if collection == nil {
+		return NewNotFoundError("", "Missing collection context.")
+	}
+
+	body := &ruleDebugRequest{}
+	if err := c.Bind(body); err != nil {
+		return NewBadRequestError("An error occurred while loading the submitted data.", err)
+	}
+
+	var rule *string
+	switch body.Action {
+	case RuleDebugActionList:
+		rule = collection.ListRule
+	case RuleDebugActionView:
+		rule = collection.ViewRule
+	case RuleDebugActionCreate:
+		rule = collection.CreateRule
+	case RuleDebugActionUpdate:
+		rule = collection.UpdateRule
+	case RuleDebugActionDelete:
+		rule = collection.DeleteRule
+	default:
+		return NewBadRequestError("Invalid action - must be one of list, view, create, update or delete.", nil)
+	}
+
+	if collection.IsView() && body.Action != RuleDebugActionList && body.Action != RuleDebugActionView {
+		return NewBadRequestError("View collections support only the list and view actions.", nil)
+	}
+
+	if body.Action != RuleDebugActionCreate && body.RecordId == "" {
+		return NewBadRequestError("Missing recordId to evaluate the rule against.", nil)
+	}
+
+	requestInfo := &models.RequestInfo{
+		Method:  ruleDebugMethods[body.Action],
+		Query:   body.Query,
+		Data:    body.Data,
+		Headers: body.Headers,
+	}
+	if requestInfo.Query == nil {
+		requestInfo.Query = map[string]any{}
+	}
+	if requestInfo.Data == nil {
+		requestInfo.Data = map[string]any{}
+	}
+	if requestInfo.Headers == nil {
+		requestInfo.Headers = map[string]any{}
+	}
+
+	if body.AuthRecordId != "" {
+		authRecord, err := api.app.Dao().FindRecordById(body.AuthCollection, body.AuthRecordId)
+		if err != nil || !authRecord.Collection().IsAuth() {
+			return NewBadRequestError("Missing or invalid auth record.", err)
+		}
+		requestInfo.AuthRecord = authRecord
+	}
+
+	result := &ruleDebugResult{
+		Action:  body.Action,
+		Rule:    rule,
+		Clauses: []ruleDebugClause{},
+	}
+
+	if rule == nil {
+		result.Reason = "Only admins can perform this action (the rule is null)."
+		return c.JSON(http.StatusOK, result)
+	}
+
+	if *rule == "" {
+		result.Allowed = true
+		result.Reason = "Everyone can perform this action (the rule is empty)."
+		return c.JSON(http.StatusOK, result)
+	}
+
+	// create rules are resolved against the submitted data saved in a rolled back transaction
+	if body.Action == RuleDebugActionCreate {
+		testRecord := models.NewRecord(collection)
+
+		// replace modifiers fields so that the resolved value is always
+		// available when accessing requestInfo.Data using just the field name
+		if requestInfo.HasModifierDataKeys() {
+			requestInfo.Data = testRecord.ReplaceModifers(requestInfo.Data)
+		}
+
+		testForm := forms.NewRecordUpsert(api.app, testRecord)
+		testForm.SetFullManageAccess(true)
+		if err := testForm.LoadData(body.Data); err != nil {
+			return NewBadRequestError("Failed to load the submitted data.", err)
+		}
+
+		testErr := testForm.DrySubmit(func(txDao *daos.Dao) error {
+			record, err := txDao.FindRecordById(collection.Id, testRecord.Id)
+			if err != nil {
+				return err
+			}
+
+			evaluateRuleDebug(txDao, record, requestInfo, *rule, result)
+
+			return nil
+		})
+		if testErr != nil {
+			return NewBadRequestError("Failed to evaluate the create rule.", testErr)
+		}
+
+		return c.JSON(http.StatusOK, result)
+	}
+
+	record, err := api.app.Dao().FindRecordById(collection.Id, body.RecordId)
+	if err != nil {
+		return NewNotFoundError("", err)
+	}
+
+	if body.Action == RuleDebugActionUpdate && requestInfo.HasModifierDataKeys() {
+		requestInfo.Data = record.ReplaceModifers(requestInfo.Data)
+	}
+
+	evaluateRuleDebug(api.app.Dao(), record, requestInfo, *rule, result)
+
+	return c.JSON(http.StatusOK, result)
+}
+
+var ruleDebugMethods = map[string]string{
+	RuleDebugActionList:   http.MethodGet,
+	RuleDebugActionView:   http.MethodGet,
+	RuleDebugActionCreate: http.MethodPost,
+	RuleDebugActionUpdate: http.MethodPatch,
+	RuleDebugActionDelete: http.MethodDelete,
+}
+
+// evaluateRuleDebug evaluates the full rule and each of its top
+// level clauses against the provided record and populates result.
+func evaluateRuleDebug(
+	dao *daos.Dao,
+	record *models.Record,
+	requestInfo *models.RequestInfo,
+	rule string,
+	result *ruleDebugResult,
+) {
+	for _, clause := range search.FilterData(rule).Clauses() {
+		item := ruleDebugClause{FilterClause: clause}
+
+		passed, err := dao.CanAccessRecord(record, requestInfo, &clause.Expr)
+		if err != nil {
+			item.Error = err.Error()
+		}
+		item.Passed = passed
+
+		result.Clauses = append(result.Clauses, item)
+	}
+
+	allowed, err := dao.CanAccessRecord(record, requestInfo, &rule)
+	if err != nil {
+		result.Reason = "Failed to resolve the rule: " + err.Error()
+		return
+	}
+
+	result.Allowed = allowed
+	if allowed {
+		result.Reason = "The rule is satisfied."
+	} else {
+		result.Reason = "The rule is not satisfied (see the failed clauses)."
+	}
+}
@@ -0,0 +1,104 @@
+package apis
+
+import (
+	"log/slog"
+
+	"github.com/labstack/echo/v5"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/spf13/cast"
+)
+
+// emitSecurityEvent triggers the app OnSecurityEvent hook with the
+// provided event populated with the request ip and geolocation.
+//
+// If not explicitly set, the event actor is resolved from the request auth state.
+func emitSecurityEvent(app core.App, c echo.Context, event *core.SecurityEvent) {
+	event.Ip = c.RealIP()
+
+	if geo := RequestGeo(app, c); geo != nil {
+		event.Country = cast.ToString(geo["country"])
+	}
+
+	if event.ActorType == "" {
+		if admin, _ := c.Get(ContextAdminKey).(*models.Admin); admin != nil {
+			event.ActorType = core.SecurityActorAdmin
+			event.ActorId = admin.Id
+		} else if record, _ := c.Get(ContextAuthRecordKey).(*models.Record); record != nil {
+			event.ActorType = core.SecurityActorAuthRecord
+			event.ActorId = record.Id
+		}
+	}
+
+	if event.Data == nil {
+		event.Data = map[string]any{}
+	}
+
+	if err := app.OnSecurityEvent().Trigger(event); err != nil {
+		app.Logger().Debug(
+			"Failed to trigger security event",
+			slog.String("type", event.Type),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// collectionRules returns a snapshot of the collection API rules
+// (the values are copied to allow comparing them after update).
+func collectionRules(collection *models.Collection) map[string]*string {
+	rules := map[string]*string{
+		"listRule":   collection.ListRule,
+		"viewRule":   collection.ViewRule,
+		"createRule": collection.CreateRule,
+		"updateRule": collection.UpdateRule,
+		"deleteRule": collection.DeleteRule,
+	}
+
+	if collection.IsAuth() {
+		rules["manageRule"] = collection.AuthOptions().ManageRule
+	}
+
+	for name, rule := range rules {
+		if rule != nil {
+			v := *rule
+			rules[name] = &v
+		}
+	}
+
+	return rules
+}
+
+// collectionRulesChanges returns the changed rules between the
+// provided collection rules snapshots (keyed by the rule name).
+func collectionRulesChanges(oldRules, newRules map[string]*string) map[string]any {
+	changes := map[string]any{}
+
+	for name, newRule := range newRules {
+		oldRule := oldRules[name]
+
+		if (oldRule == nil) == (newRule == nil) && (oldRule == nil || *oldRule == *newRule) {
+			continue
+		}
+
+		changes[name] = map[string]any{
+			"old": oldRule,
+			"new": newRule,
+		}
+	}
+
+	return changes
+}
+
+// tokenSecrets returns a snapshot of the settings token secrets
+// (keyed by their settings field name).
+func tokenSecrets(s *settings.Settings) map[string]string {
+	configs := s.NamedTokenConfigs()
+
+	secrets := make(map[string]string, len(configs))
+	for name, config := range configs {
+		secrets[name] = config.Secret
+	}
+
+	return secrets
+}
@@ -6,6 +6,7 @@ import (
 	"log"
 	"net"
 	"net/http"
+	"os"
 	"path/filepath"
 	"strings"
 	"sync"
@@ -45,6 +46,10 @@ type ServeConfig struct {
 
 	// AllowedOrigins is an optional list of CORS origins (default to "*").
 	AllowedOrigins []string
+
+	// DisableAdminUI disables serving the bundled admin UI
+	// regardless of the app settings.
+	DisableAdminUI bool
 }
 
 // Serve starts a new app web server.
@@ -77,7 +82,7 @@ func Serve(app core.App, config ServeConfig) (*http.Server, error) {
 		color.Yellow("=====================================")
 	}
 
-	router, err := InitApi(app)
+	router, err := initApi(app, config)
 	if err != nil {
 		return nil, err
 	}
@@ -164,6 +169,7 @@ func Serve(app core.App, config ServeConfig) (*http.Server, error) {
 	serveEvent := &core.ServeEvent{
 		App:         app,
 		Router:      router,
+		HttpRouter:  NewEchoRouter(router),
 		Server:      server,
 		CertManager: certManager,
 	}
@@ -193,9 +199,28 @@ func Serve(app core.App, config ServeConfig) (*http.Server, error) {
 			color.CyanString("%s://%s", schema, addr),
 		)
 
+		basePath := app.Settings().Meta.NormalizedBasePath()
+
 		regular := color.New()
-		regular.Printf("├─ REST API: %s\n", color.CyanString("%s://%s/api/", schema, addr))
-		regular.Printf("└─ Admin UI: %s\n", color.CyanString("%s://%s/_/", schema, addr))
+		regular.Printf("├─ REST API: %s\n", color.CyanString("%s://%s%s/api/", schema, addr, basePath))
+		if config.DisableAdminUI || app.Settings().AdminUI.Disabled {
+			regular.Printf("└─ Admin UI: %s\n", color.YellowString("disabled"))
+		} else {
+			regular.Printf("└─ Admin UI: %s\n", color.CyanString("%s://%s%s%s", schema, addr, basePath, app.Settings().AdminUI.TrailedPath()))
+		}
+
+		// print the initial admin setup token
+		// (skip for env tokens since they are already known to the operator)
+		if totalAdmins, _ := app.Dao().TotalAdmins(); totalAdmins == 0 && os.Getenv(AdminSetupTokenEnv) == "" {
+			token := AdminSetupToken(app)
+			regular.Printf("\n(!) No admin account found. Use the following setup token to create the initial admin:\n")
+			regular.Printf("├─ Setup token: %s\n", color.YellowString(token))
+			if config.DisableAdminUI || app.Settings().AdminUI.Disabled {
+				regular.Printf("└─ API: %s\n", color.CyanString("POST %s://%s%s/api/admins (with %s header)", schema, addr, basePath, AdminSetupTokenHeader))
+			} else {
+				regular.Printf("└─ Installer: %s\n", color.CyanString("%s://%s%s%s?installer=%s#", schema, addr, basePath, app.Settings().AdminUI.TrailedPath(), token))
+			}
+		}
 	}
 
 	// WaitGroup to block until server.ShutDown() returns because Serve and similar methods exit immediately.
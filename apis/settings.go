@@ -17,6 +17,7 @@ func bindSettingsApi(app core.App, rg *echo.Group) {
 	subGroup := rg.Group("/settings", ActivityLogger(app), RequireAdminAuth())
 	subGroup.GET("", api.list)
 	subGroup.PATCH("", api.set)
+	subGroup.POST("/reload", api.reload)
 	subGroup.POST("/test/s3", api.testS3)
 	subGroup.POST("/test/email", api.testEmail)
 	subGroup.POST("/apple/generate-client-secret", api.generateAppleClientSecret)
@@ -26,6 +27,16 @@ type settingsApi struct {
 	app core.App
 }
 
+// reload reloads the stored app settings and re-resolves the env and
+// file referenced settings secrets without restarting the app.
+func (api *settingsApi) reload(c echo.Context) error {
+	if err := api.app.ReloadSettings(); err != nil {
+		return NewBadRequestError("Failed to reload the app settings.", err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
 func (api *settingsApi) list(c echo.Context) error {
 	settings, err := api.app.Settings().RedactClone()
 	if err != nil {
@@ -57,6 +68,8 @@ func (api *settingsApi) set(c echo.Context) error {
 	event.HttpContext = c
 	event.OldSettings = api.app.Settings()
 
+	oldSecrets := tokenSecrets(api.app.Settings())
+
 	// update the settings
 	return form.Submit(func(next forms.InterceptorNextFunc[*settings.Settings]) forms.InterceptorNextFunc[*settings.Settings] {
 		return func(s *settings.Settings) error {
@@ -67,6 +80,15 @@ func (api *settingsApi) set(c echo.Context) error {
 					return NewBadRequestError("An error occurred while submitting the form.", err)
 				}
 
+				for name, secret := range tokenSecrets(api.app.Settings()) {
+					if secret != oldSecrets[name] {
+						emitSecurityEvent(api.app, e.HttpContext, &core.SecurityEvent{
+							Type: core.SecurityEventTokenSecretRotation,
+							Data: map[string]any{"token": name},
+						})
+					}
+				}
+
 				return api.app.OnSettingsAfterUpdateRequest().Trigger(event, func(e *core.SettingsUpdateEvent) error {
 					if e.HttpContext.Response().Committed {
 						return nil
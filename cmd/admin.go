@@ -3,6 +3,8 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
 
 	"github.com/fatih/color"
 	"github.com/go-ozzo/ozzo-validation/v4/is"
@@ -12,13 +14,14 @@ import (
 )
 
 // NewAdminCommand creates and returns new command for managing
-// admin accounts (create, update, delete).
+// admin accounts (list, create, update, delete).
 func NewAdminCommand(app core.App) *cobra.Command {
 	command := &cobra.Command{
 		Use:   "admin",
 		Short: "Manages admin accounts",
 	}
 
+	command.AddCommand(adminListCommand(app))
 	command.AddCommand(adminCreateCommand(app))
 	command.AddCommand(adminUpdateCommand(app))
 	command.AddCommand(adminDeleteCommand(app))
@@ -26,6 +29,44 @@ func NewAdminCommand(app core.App) *cobra.Command {
 	return command
 }
 
+func adminListCommand(app core.App) *cobra.Command {
+	command := &cobra.Command{
+		Use:     "list",
+		Example: "admin list",
+		Short:   "Prints the existing admin accounts as JSON",
+		// prevents printing the error log twice
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(command *cobra.Command, args []string) error {
+			remote, err := NewRemoteClient(command)
+			if err != nil {
+				return err
+			}
+
+			if remote != nil {
+				result := struct {
+					Items []map[string]any `json:"items"`
+				}{}
+				if err := remote.Send(http.MethodGet, "/api/admins?perPage=500&sort=created", nil, &result); err != nil {
+					return fmt.Errorf("Failed to fetch the remote admins: %v", err)
+				}
+				return printJson(command, result.Items)
+			}
+
+			admins := []*models.Admin{}
+			if err := app.Dao().AdminQuery().OrderBy("created ASC").All(&admins); err != nil {
+				return fmt.Errorf("Failed to fetch the admins: %v", err)
+			}
+
+			return printJson(command, admins)
+		},
+	}
+
+	AddRemoteFlags(command)
+
+	return command
+}
+
 func adminCreateCommand(app core.App) *cobra.Command {
 	command := &cobra.Command{
 		Use:     "create",
@@ -116,6 +157,15 @@ func adminDeleteCommand(app core.App) *cobra.Command {
 				return errors.New("Invalid or missing email address.")
 			}
 
+			remote, err := NewRemoteClient(command)
+			if err != nil {
+				return err
+			}
+
+			if remote != nil {
+				return remoteAdminDelete(remote, args[0])
+			}
+
 			admin, err := app.Dao().FindAdminByEmail(args[0])
 			if err != nil {
 				color.Yellow("Admin %s is already deleted.", args[0])
@@ -131,5 +181,32 @@ func adminDeleteCommand(app core.App) *cobra.Command {
 		},
 	}
 
+	AddRemoteFlags(command)
+
 	return command
 }
+
+func remoteAdminDelete(remote *RemoteClient, email string) error {
+	result := struct {
+		Items []struct {
+			Id string `json:"id"`
+		} `json:"items"`
+	}{}
+
+	filter := url.QueryEscape(fmt.Sprintf("email=%q", email))
+	if err := remote.Send(http.MethodGet, "/api/admins?perPage=1&filter="+filter, nil, &result); err != nil {
+		return fmt.Errorf("Failed to fetch the remote admin: %v", err)
+	}
+
+	if len(result.Items) == 0 {
+		color.Yellow("Admin %s is already deleted.", email)
+		return nil
+	}
+
+	if err := remote.Send(http.MethodDelete, "/api/admins/"+url.PathEscape(result.Items[0].Id), nil, nil); err != nil {
+		return fmt.Errorf("Failed to delete admin %s: %v", email, err)
+	}
+
+	color.Green("Successfully deleted admin %s!", email)
+	return nil
+}
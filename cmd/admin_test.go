@@ -1,6 +1,10 @@
 package cmd_test
 
 import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/pocketbase/pocketbase/cmd"
@@ -217,3 +221,82 @@ func TestAdminDeleteCommand(t *testing.T) {
 		}
 	}
 }
+
+func TestAdminListCommand(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	out := new(bytes.Buffer)
+
+	command := cmd.NewAdminCommand(app)
+	command.SetOut(out)
+	command.SetArgs([]string{"list"})
+
+	if err := command.Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		`"email": "test@example.com"`,
+		`"email": "test2@example.com"`,
+		`"email": "test3@example.com"`,
+	}
+	for _, str := range expected {
+		if !strings.Contains(out.String(), str) {
+			t.Errorf("Missing %q in\n%s", str, out.String())
+		}
+	}
+
+	if strings.Contains(out.String(), "passwordHash") {
+		t.Errorf("Expected the password hash to not be exported, got\n%s", out.String())
+	}
+}
+
+func TestAdminDeleteCommandRemote(t *testing.T) {
+	var deleted string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "test_token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(`{"items":[{"id":"test_id"}]}`))
+		case http.MethodDelete:
+			deleted = r.URL.Path
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	// missing token
+	{
+		t.Setenv("PB_ADMIN_TOKEN", "")
+
+		command := cmd.NewAdminCommand(app)
+		command.SetArgs([]string{"delete", "test@example.com", "--remote", server.URL})
+		if err := command.Execute(); err == nil {
+			t.Fatal("Expected missing token error, got nil")
+		}
+	}
+
+	command := cmd.NewAdminCommand(app)
+	command.SetArgs([]string{"delete", "test@example.com", "--remote", server.URL, "--token", "test_token"})
+	if err := command.Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	if deleted != "/api/admins/test_id" {
+		t.Fatalf("Expected the remote admin to be deleted, got %q", deleted)
+	}
+
+	// the local admin should remain untouched
+	if _, err := app.Dao().FindAdminByEmail("test@example.com"); err != nil {
+		t.Fatalf("Expected the local admin to exist, got %v", err)
+	}
+}
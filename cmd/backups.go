@@ -0,0 +1,217 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/types"
+	"github.com/spf13/cobra"
+)
+
+// NewBackupsCommand creates and returns new command for managing the app backups.
+func NewBackupsCommand(app core.App) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "backups",
+		Short: "Manages the app backups",
+	}
+
+	command.AddCommand(backupsListCommand(app))
+	command.AddCommand(backupsCreateCommand(app))
+	command.AddCommand(backupsRestoreCommand(app))
+	command.AddCommand(backupsDeleteCommand(app))
+
+	AddRemoteFlags(command)
+
+	return command
+}
+
+func backupsListCommand(app core.App) *cobra.Command {
+	command := &cobra.Command{
+		Use:     "list",
+		Example: "backups list",
+		Short:   "Prints the available backups as JSON",
+		// prevents printing the error log twice
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(command *cobra.Command, args []string) error {
+			remote, err := NewRemoteClient(command)
+			if err != nil {
+				return err
+			}
+
+			if remote != nil {
+				result := []map[string]any{}
+				if err := remote.Send(http.MethodGet, "/api/backups", nil, &result); err != nil {
+					return fmt.Errorf("Failed to fetch the remote backups: %v", err)
+				}
+				return printJson(command, result)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			fsys, err := app.NewBackupsFilesystem()
+			if err != nil {
+				return fmt.Errorf("Failed to load the backups filesystem: %v", err)
+			}
+			defer fsys.Close()
+
+			fsys.SetContext(ctx)
+
+			backups, err := fsys.List("")
+			if err != nil {
+				return fmt.Errorf("Failed to retrieve the backups: %v", err)
+			}
+
+			result := make([]models.BackupFileInfo, len(backups))
+			for i, obj := range backups {
+				modified, _ := types.ParseDateTime(obj.ModTime)
+
+				result[i] = models.BackupFileInfo{
+					Key:      obj.Key,
+					Size:     obj.Size,
+					Modified: modified,
+				}
+			}
+
+			return printJson(command, result)
+		},
+	}
+
+	return command
+}
+
+func backupsCreateCommand(app core.App) *cobra.Command {
+	var dataOnly bool
+	var masked bool
+
+	command := &cobra.Command{
+		Use:     "create",
+		Example: "backups create my_backup.zip --data-only --masked",
+		Short:   "Creates a new app backup (the name is autogenerated if not set)",
+		// prevents printing the error log twice
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(command *cobra.Command, args []string) error {
+			var name string
+			if len(args) > 0 {
+				name = args[0]
+			}
+
+			remote, err := NewRemoteClient(command)
+			if err != nil {
+				return err
+			}
+
+			if remote != nil {
+				body := map[string]any{"name": name, "dataOnly": dataOnly, "masked": masked}
+				if err := remote.Send(http.MethodPost, "/api/backups", body, nil); err != nil {
+					return fmt.Errorf("Failed to create the remote backup: %v", err)
+				}
+			} else {
+				form := forms.NewBackupCreate(app)
+				form.Name = name
+				form.DataOnly = dataOnly
+				form.Masked = masked
+
+				if err := form.Submit(); err != nil {
+					return fmt.Errorf("Failed to create the backup: %v", err)
+				}
+			}
+
+			color.Green("Successfully created the backup!")
+			return nil
+		},
+	}
+
+	command.Flags().BoolVar(&dataOnly, "data-only", false, "create a lightweight backup containing only the collections records")
+	command.Flags().BoolVar(&masked, "masked", false, "mask the sensitive records data (emails, names, tokens, etc.) of the data-only backup")
+
+	return command
+}
+
+func backupsRestoreCommand(app core.App) *cobra.Command {
+	command := &cobra.Command{
+		Use:     "restore",
+		Example: "backups restore my_backup.zip --remote=https://example.com",
+		Short:   "Restores the specified backup of a remote instance",
+		// prevents printing the error log twice
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(command *cobra.Command, args []string) error {
+			if len(args) == 0 || args[0] == "" {
+				return errors.New("Missing backup key argument.")
+			}
+
+			remote, err := NewRemoteClient(command)
+			if err != nil {
+				return err
+			}
+
+			// the local restore requires an app restart and it is
+			// available only from the running app (eg. the Admin UI)
+			if remote == nil {
+				return errors.New("The backups restore command is supported only with --remote.")
+			}
+
+			if err := remote.Send(http.MethodPost, "/api/backups/"+url.PathEscape(args[0])+"/restore", nil, nil); err != nil {
+				return fmt.Errorf("Failed to restore the remote backup: %v", err)
+			}
+
+			color.Green("Successfully initialized the restore of backup %q!", args[0])
+			return nil
+		},
+	}
+
+	return command
+}
+
+func backupsDeleteCommand(app core.App) *cobra.Command {
+	command := &cobra.Command{
+		Use:     "delete",
+		Example: "backups delete my_backup.zip",
+		Short:   "Deletes the specified backup",
+		// prevents printing the error log twice
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(command *cobra.Command, args []string) error {
+			if len(args) == 0 || args[0] == "" {
+				return errors.New("Missing backup key argument.")
+			}
+
+			remote, err := NewRemoteClient(command)
+			if err != nil {
+				return err
+			}
+
+			if remote != nil {
+				if err := remote.Send(http.MethodDelete, "/api/backups/"+url.PathEscape(args[0]), nil, nil); err != nil {
+					return fmt.Errorf("Failed to delete the remote backup: %v", err)
+				}
+			} else {
+				fsys, err := app.NewBackupsFilesystem()
+				if err != nil {
+					return fmt.Errorf("Failed to load the backups filesystem: %v", err)
+				}
+				defer fsys.Close()
+
+				if err := fsys.Delete(args[0]); err != nil {
+					return fmt.Errorf("Invalid or already deleted backup file %q.", args[0])
+				}
+			}
+
+			color.Green("Successfully deleted backup %q!", args[0])
+			return nil
+		},
+	}
+
+	return command
+}
@@ -0,0 +1,103 @@
+package cmd_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/cmd"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestBackupsCommand(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	// create
+	{
+		command := cmd.NewBackupsCommand(app)
+		command.SetArgs([]string{"create", "test_dump.zip", "--data-only"})
+		if err := command.Execute(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// create masked without data-only
+	{
+		command := cmd.NewBackupsCommand(app)
+		command.SetArgs([]string{"create", "test_masked.zip", "--masked"})
+		if err := command.Execute(); err == nil {
+			t.Fatal("Expected masked full backup error, got nil")
+		}
+	}
+
+	// list
+	{
+		var out bytes.Buffer
+
+		command := cmd.NewBackupsCommand(app)
+		command.SetOut(&out)
+		command.SetArgs([]string{"list"})
+		if err := command.Execute(); err != nil {
+			t.Fatal(err)
+		}
+
+		if !strings.Contains(out.String(), `"key": "test_dump.zip"`) {
+			t.Fatalf("Expected the created backup to be listed, got\n%s", out.String())
+		}
+	}
+
+	// local restore
+	{
+		command := cmd.NewBackupsCommand(app)
+		command.SetArgs([]string{"restore", "test_dump.zip"})
+		if err := command.Execute(); err == nil {
+			t.Fatal("Expected unsupported local restore error, got nil")
+		}
+	}
+
+	// delete
+	{
+		command := cmd.NewBackupsCommand(app)
+		command.SetArgs([]string{"delete", "test_dump.zip"})
+		if err := command.Execute(); err != nil {
+			t.Fatal(err)
+		}
+
+		command = cmd.NewBackupsCommand(app)
+		command.SetArgs([]string{"delete", "test_dump.zip"})
+		if err := command.Execute(); err == nil {
+			t.Fatal("Expected already deleted backup error, got nil")
+		}
+	}
+}
+
+func TestBackupsCommandRemote(t *testing.T) {
+	var restored string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "test_token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		restored = r.URL.Path
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	command := cmd.NewBackupsCommand(app)
+	command.SetArgs([]string{"restore", "test.zip", "--remote", server.URL, "--token", "test_token"})
+	if err := command.Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	if restored != "/api/backups/test.zip/restore" {
+		t.Fatalf("Expected the restore endpoint to be called, got %q", restored)
+	}
+}
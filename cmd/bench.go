@@ -0,0 +1,634 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"text/tabwriter"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/apis"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/pocketbase/pocketbase/tools/types"
+	"github.com/spf13/cobra"
+)
+
+// Names of the synthetic collections created by the bench command.
+const (
+	benchAuthorsCollection = "bench_authors"
+	benchPostsCollection   = "bench_posts"
+)
+
+const benchIdAlphabet = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+// BenchOptions defines the bench command load options.
+type BenchOptions struct {
+	// Requests is the number of requests per scenario.
+	Requests int
+
+	// Concurrency is the number of parallel workers per scenario.
+	Concurrency int
+
+	// Seed is the number of the seeded synthetic post records.
+	Seed int
+
+	// RealtimeClients is the number of the realtime subscribers.
+	RealtimeClients int
+
+	// Keep disables the deletion of the synthetic collections after the run.
+	Keep bool
+}
+
+// BenchReport defines the bench command results.
+type BenchReport struct {
+	Scenarios  []*BenchScenarioResult `json:"scenarios"`
+	Contention BenchContention        `json:"contention"`
+}
+
+// BenchScenarioResult defines the results of a single bench scenario
+// (all durations are in milliseconds).
+type BenchScenarioResult struct {
+	Name     string  `json:"name"`
+	Requests int     `json:"requests"`
+	Errors   int     `json:"errors"`
+	Duration float64 `json:"duration"`
+	Rps      float64 `json:"rps"`
+	P50      float64 `json:"p50"`
+	P90      float64 `json:"p90"`
+	P99      float64 `json:"p99"`
+	Max      float64 `json:"max"`
+}
+
+// BenchContention defines the SQLite connections pool contention
+// stats collected during the bench run (durations are in milliseconds).
+type BenchContention struct {
+	ConcurrentWaitCount    int64   `json:"concurrentWaitCount"`
+	ConcurrentWaitTime     float64 `json:"concurrentWaitTime"`
+	NonconcurrentWaitCount int64   `json:"nonconcurrentWaitCount"`
+	NonconcurrentWaitTime  float64 `json:"nonconcurrentWaitTime"`
+}
+
+// NewBenchCommand creates and returns new command for running
+// standardized load scenarios against the local app instance.
+func NewBenchCommand(app core.App) *cobra.Command {
+	options := BenchOptions{}
+	var printAsJson bool
+
+	command := &cobra.Command{
+		Use:     "bench",
+		Example: "bench --requests=2000 --concurrency=20",
+		Short:   "Runs CRUD, expand and realtime load scenarios against the local instance",
+		Long: "Runs CRUD, expand and realtime load scenarios against the local instance.\n\n" +
+			"The command seeds temporary " + benchAuthorsCollection + " and " + benchPostsCollection +
+			" collections in the app data dir\n(they are deleted after the run unless --keep is set).\n" +
+			"Avoid running it on a production instance.",
+		// prevents printing the error log twice
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(command *cobra.Command, args []string) error {
+			report, err := RunBench(app, options)
+			if err != nil {
+				return err
+			}
+
+			if printAsJson {
+				return printJson(command, report)
+			}
+
+			printBenchReport(command.OutOrStdout(), report)
+
+			return nil
+		},
+	}
+
+	command.Flags().IntVar(&options.Requests, "requests", 1000, "the number of requests per scenario")
+	command.Flags().IntVar(&options.Concurrency, "concurrency", 10, "the number of parallel workers per scenario")
+	command.Flags().IntVar(&options.Seed, "seed", 1000, "the number of seeded synthetic records")
+	command.Flags().IntVar(&options.RealtimeClients, "realtimeClients", 10, "the number of realtime subscribers")
+	command.Flags().BoolVar(&options.Keep, "keep", false, "keep the synthetic collections after the run")
+	command.Flags().BoolVar(&printAsJson, "json", false, "print the report as JSON")
+
+	return command
+}
+
+// RunBench seeds the synthetic bench collections and runs the
+// standardized load scenarios against an in-process app server.
+func RunBench(app core.App, options BenchOptions) (*BenchReport, error) {
+	options.Requests = max(1, options.Requests)
+	options.Concurrency = max(1, options.Concurrency)
+	options.Seed = max(1, options.Seed)
+
+	authors, posts, err := seedBenchCollections(app.Dao(), options.Seed)
+	if err != nil {
+		return nil, err
+	}
+	if !options.Keep {
+		defer func() {
+			app.Dao().DeleteCollection(posts)
+			app.Dao().DeleteCollection(authors)
+		}()
+	}
+
+	router, err := apis.InitApi(app)
+	if err != nil {
+		return nil, err
+	}
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	b := &benchRunner{
+		baseUrl: server.URL + "/api/collections/" + benchPostsCollection + "/records",
+		client: &http.Client{
+			Timeout: 30 * time.Second,
+			Transport: &http.Transport{
+				MaxIdleConnsPerHost: options.Concurrency + options.RealtimeClients,
+			},
+		},
+		options: options,
+	}
+
+	if err := app.Dao().RecordQuery(authors).Select("id").Column(&b.authorIds); err != nil {
+		return nil, err
+	}
+	if err := app.Dao().RecordQuery(posts).Select("id").Column(&b.postIds); err != nil {
+		return nil, err
+	}
+
+	concurrentStats := benchDBStats(app.Dao().ConcurrentDB())
+	nonconcurrentStats := benchDBStats(app.Dao().NonconcurrentDB())
+
+	report := &BenchReport{}
+
+	createdIds := make([]string, options.Requests)
+	report.Scenarios = append(report.Scenarios, b.run("create", func(i int) error {
+		createdIds[i] = security.PseudorandomStringWithAlphabet(15, benchIdAlphabet)
+		return b.send(http.MethodPost, b.baseUrl, b.postData(createdIds[i]))
+	}))
+
+	report.Scenarios = append(report.Scenarios, b.run("view", func(i int) error {
+		return b.send(http.MethodGet, b.baseUrl+"/"+b.randomPostId(), nil)
+	}))
+
+	report.Scenarios = append(report.Scenarios, b.run("list", func(i int) error {
+		return b.send(http.MethodGet, b.baseUrl+"?perPage=50&sort=-views&filter=views%3E100", nil)
+	}))
+
+	report.Scenarios = append(report.Scenarios, b.run("list+expand", func(i int) error {
+		return b.send(http.MethodGet, b.baseUrl+"?perPage=50&expand=author", nil)
+	}))
+
+	report.Scenarios = append(report.Scenarios, b.run("update", func(i int) error {
+		return b.send(http.MethodPatch, b.baseUrl+"/"+b.randomPostId(), map[string]any{"views": rand.Intn(1000)})
+	}))
+
+	report.Scenarios = append(report.Scenarios, b.run("delete", func(i int) error {
+		return b.send(http.MethodDelete, b.baseUrl+"/"+createdIds[i], nil)
+	}))
+
+	if options.RealtimeClients > 0 {
+		result, err := b.runRealtime(server.URL)
+		if err != nil {
+			return nil, err
+		}
+		report.Scenarios = append(report.Scenarios, result)
+	}
+
+	report.Contention = BenchContention{
+		ConcurrentWaitCount:    benchDBStats(app.Dao().ConcurrentDB()).WaitCount - concurrentStats.WaitCount,
+		ConcurrentWaitTime:     toMilliseconds(benchDBStats(app.Dao().ConcurrentDB()).WaitDuration - concurrentStats.WaitDuration),
+		NonconcurrentWaitCount: benchDBStats(app.Dao().NonconcurrentDB()).WaitCount - nonconcurrentStats.WaitCount,
+		NonconcurrentWaitTime:  toMilliseconds(benchDBStats(app.Dao().NonconcurrentDB()).WaitDuration - nonconcurrentStats.WaitDuration),
+	}
+
+	return report, nil
+}
+
+// seedBenchCollections creates the synthetic bench collections
+// and populates them with random records.
+func seedBenchCollections(dao *daos.Dao, total int) (authors *models.Collection, posts *models.Collection, err error) {
+	for _, name := range []string{benchAuthorsCollection, benchPostsCollection} {
+		if existing, _ := dao.FindCollectionByNameOrId(name); existing != nil {
+			return nil, nil, fmt.Errorf("Collection %q already exists (probably from a previous --keep run).", name)
+		}
+	}
+
+	err = dao.RunInTransaction(func(txDao *daos.Dao) error {
+		authors = &models.Collection{
+			Name:       benchAuthorsCollection,
+			Type:       models.CollectionTypeBase,
+			ListRule:   types.Pointer(""),
+			ViewRule:   types.Pointer(""),
+			CreateRule: types.Pointer(""),
+			UpdateRule: types.Pointer(""),
+			DeleteRule: types.Pointer(""),
+			Schema: schema.NewSchema(
+				&schema.SchemaField{Name: "name", Type: schema.FieldTypeText},
+			),
+		}
+		if err := txDao.SaveCollection(authors); err != nil {
+			return err
+		}
+
+		posts = &models.Collection{
+			Name:       benchPostsCollection,
+			Type:       models.CollectionTypeBase,
+			ListRule:   types.Pointer(""),
+			ViewRule:   types.Pointer(""),
+			CreateRule: types.Pointer(""),
+			UpdateRule: types.Pointer(""),
+			DeleteRule: types.Pointer(""),
+			Schema: schema.NewSchema(
+				&schema.SchemaField{Name: "title", Type: schema.FieldTypeText},
+				&schema.SchemaField{Name: "views", Type: schema.FieldTypeNumber},
+				&schema.SchemaField{
+					Name: "author",
+					Type: schema.FieldTypeRelation,
+					Options: &schema.RelationOptions{
+						CollectionId: authors.Id,
+						MaxSelect:    types.Pointer(1),
+					},
+				},
+			),
+		}
+		if err := txDao.SaveCollection(posts); err != nil {
+			return err
+		}
+
+		authorIds := make([]string, max(1, total/10))
+		for i := range authorIds {
+			author := models.NewRecord(authors)
+			author.Set("name", fmt.Sprintf("author %d", i))
+			if err := txDao.WithoutHooks().SaveRecord(author); err != nil {
+				return err
+			}
+			authorIds[i] = author.Id
+		}
+
+		for i := 0; i < total; i++ {
+			post := models.NewRecord(posts)
+			post.Set("title", fmt.Sprintf("post %d", i))
+			post.Set("views", rand.Intn(1000))
+			post.Set("author", authorIds[rand.Intn(len(authorIds))])
+			if err := txDao.WithoutHooks().SaveRecord(post); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return authors, posts, err
+}
+
+// -------------------------------------------------------------------
+
+type benchRunner struct {
+	baseUrl   string
+	client    *http.Client
+	options   BenchOptions
+	authorIds []string
+	postIds   []string
+}
+
+func (b *benchRunner) randomPostId() string {
+	return b.postIds[rand.Intn(len(b.postIds))]
+}
+
+func (b *benchRunner) postData(id string) map[string]any {
+	return map[string]any{
+		"id":     id,
+		"title":  "bench " + id,
+		"views":  rand.Intn(1000),
+		"author": b.authorIds[rand.Intn(len(b.authorIds))],
+	}
+}
+
+// send sends a single json request and returns an error on non 2xx response.
+func (b *benchRunner) send(method string, url string, body any) error {
+	var reqBody io.Reader
+	if body != nil {
+		raw, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(raw)
+	}
+
+	req, err := http.NewRequest(method, url, reqBody)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := b.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("unexpected %s %s response status %d", method, url, res.StatusCode)
+	}
+
+	return nil
+}
+
+// run executes the provided scenario func options.Requests times
+// with options.Concurrency workers and collects its latencies.
+func (b *benchRunner) run(name string, fn func(i int) error) *BenchScenarioResult {
+	latencies := make([]time.Duration, b.options.Requests)
+
+	var next int64 = -1
+	var errorsCount int64
+
+	var wg sync.WaitGroup
+	wg.Add(b.options.Concurrency)
+
+	start := time.Now()
+
+	for w := 0; w < b.options.Concurrency; w++ {
+		go func() {
+			defer wg.Done()
+
+			for {
+				i := int(atomic.AddInt64(&next, 1))
+				if i >= b.options.Requests {
+					return
+				}
+
+				requestStart := time.Now()
+				if err := fn(i); err != nil {
+					atomic.AddInt64(&errorsCount, 1)
+				}
+				latencies[i] = time.Since(requestStart)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return newBenchScenarioResult(name, latencies, int(errorsCount), time.Since(start))
+}
+
+// runRealtime measures the record create to realtime message delivery
+// latency for options.RealtimeClients subscribers.
+func (b *benchRunner) runRealtime(serverUrl string) (*BenchScenarioResult, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	messages := max(1, b.options.Requests/10)
+
+	var mu sync.Mutex
+	startTimes := map[string]time.Time{}
+	latencies := make([]time.Duration, 0, messages*b.options.RealtimeClients)
+
+	var connected sync.WaitGroup
+	var received sync.WaitGroup
+	connected.Add(b.options.RealtimeClients)
+	received.Add(messages * b.options.RealtimeClients)
+
+	connectErrs := make(chan error, b.options.RealtimeClients)
+
+	for i := 0; i < b.options.RealtimeClients; i++ {
+		go func() {
+			err := b.subscribe(ctx, serverUrl, connected.Done, func(recordId string) {
+				mu.Lock()
+				startTime, ok := startTimes[recordId]
+				if ok {
+					latencies = append(latencies, time.Since(startTime))
+				}
+				mu.Unlock()
+
+				if ok {
+					received.Done()
+				}
+			})
+			if err != nil && ctx.Err() == nil {
+				connectErrs <- err
+			}
+		}()
+	}
+
+	connected.Wait()
+
+	select {
+	case err := <-connectErrs:
+		return nil, fmt.Errorf("Failed to establish a realtime connection: %w", err)
+	default:
+	}
+
+	start := time.Now()
+
+	var errorsCount int
+	for i := 0; i < messages; i++ {
+		id := security.PseudorandomStringWithAlphabet(15, benchIdAlphabet)
+
+		mu.Lock()
+		startTimes[id] = time.Now()
+		mu.Unlock()
+
+		if err := b.send(http.MethodPost, b.baseUrl, b.postData(id)); err != nil {
+			errorsCount++
+			received.Add(-b.options.RealtimeClients)
+		}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		received.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(30 * time.Second):
+		return nil, errors.New("Timeout waiting for the realtime messages delivery.")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	result := newBenchScenarioResult("realtime", latencies, errorsCount, time.Since(start))
+	result.Requests = messages
+
+	return result, nil
+}
+
+// subscribe establishes a new realtime connection, subscribes to the bench
+// posts collection and calls onRecord for each received record message.
+func (b *benchRunner) subscribe(ctx context.Context, serverUrl string, onConnect func(), onRecord func(recordId string)) error {
+	var connectOnce sync.Once
+	defer connectOnce.Do(onConnect)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, serverUrl+"/api/realtime", nil)
+	if err != nil {
+		return err
+	}
+
+	// use a dedicated client without timeout for the long-lived connection
+	res, err := (&http.Client{}).Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	reader := bufio.NewReader(res.Body)
+
+	var event, data string
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return err
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		if v, ok := strings.CutPrefix(line, "event:"); ok {
+			event = v
+			continue
+		}
+
+		if v, ok := strings.CutPrefix(line, "data:"); ok {
+			data = v
+			continue
+		}
+
+		if line != "" {
+			continue
+		}
+
+		// end of message
+		switch event {
+		case "PB_CONNECT":
+			connectData := struct {
+				ClientId string `json:"clientId"`
+			}{}
+			if err := json.Unmarshal([]byte(data), &connectData); err != nil {
+				return err
+			}
+
+			err := b.send(http.MethodPost, serverUrl+"/api/realtime", map[string]any{
+				"clientId":      connectData.ClientId,
+				"subscriptions": []string{benchPostsCollection},
+			})
+			if err != nil {
+				return err
+			}
+
+			connectOnce.Do(onConnect)
+		case benchPostsCollection:
+			message := struct {
+				Action string `json:"action"`
+				Record struct {
+					Id string `json:"id"`
+				} `json:"record"`
+			}{}
+			if err := json.Unmarshal([]byte(data), &message); err == nil && message.Action == "create" {
+				onRecord(message.Record.Id)
+			}
+		}
+
+		event, data = "", ""
+	}
+}
+
+// -------------------------------------------------------------------
+
+func newBenchScenarioResult(name string, latencies []time.Duration, errorsCount int, total time.Duration) *BenchScenarioResult {
+	sorted := make([]time.Duration, len(latencies))
+	copy(sorted, latencies)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+
+	result := &BenchScenarioResult{
+		Name:     name,
+		Requests: len(latencies),
+		Errors:   errorsCount,
+		Duration: toMilliseconds(total),
+	}
+
+	if total > 0 {
+		result.Rps = float64(len(latencies)) / total.Seconds()
+	}
+
+	if len(sorted) > 0 {
+		result.P50 = toMilliseconds(percentile(sorted, 0.5))
+		result.P90 = toMilliseconds(percentile(sorted, 0.9))
+		result.P99 = toMilliseconds(percentile(sorted, 0.99))
+		result.Max = toMilliseconds(sorted[len(sorted)-1])
+	}
+
+	return result
+}
+
+// percentile returns the nearest-rank percentile of the sorted durations.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(float64(len(sorted))*p+0.5) - 1
+
+	return sorted[min(max(rank, 0), len(sorted)-1)]
+}
+
+func toMilliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+func benchDBStats(builder dbx.Builder) sql.DBStats {
+	if db, ok := builder.(*dbx.DB); ok {
+		return db.DB().Stats()
+	}
+
+	return sql.DBStats{}
+}
+
+func printBenchReport(w io.Writer, report *BenchReport) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "SCENARIO\tREQUESTS\tERRORS\tRPS\tP50 (ms)\tP90 (ms)\tP99 (ms)\tMAX (ms)")
+	for _, s := range report.Scenarios {
+		fmt.Fprintf(
+			tw,
+			"%s\t%d\t%d\t%.1f\t%.2f\t%.2f\t%.2f\t%.2f\n",
+			s.Name, s.Requests, s.Errors, s.Rps, s.P50, s.P90, s.P99, s.Max,
+		)
+	}
+	tw.Flush()
+
+	fmt.Fprintln(w)
+	fmt.Fprintf(
+		w,
+		"SQLite pool contention: concurrent %d waits (%.2fms), nonconcurrent %d waits (%.2fms)\n",
+		report.Contention.ConcurrentWaitCount,
+		report.Contention.ConcurrentWaitTime,
+		report.Contention.NonconcurrentWaitCount,
+		report.Contention.NonconcurrentWaitTime,
+	)
+
+	for _, s := range report.Scenarios {
+		if s.Errors > 0 {
+			color.Yellow("Scenario %q had %d failed requests.", s.Name, s.Errors)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package cmd_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/cmd"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestBenchCommand(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	var out bytes.Buffer
+
+	command := cmd.NewBenchCommand(app)
+	command.SetOut(&out)
+	command.SetArgs([]string{
+		"--requests", "20",
+		"--concurrency", "2",
+		"--seed", "10",
+		"--realtimeClients", "2",
+		"--json",
+	})
+	if err := command.Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	expectations := []string{
+		`"name": "create"`,
+		`"name": "view"`,
+		`"name": "list"`,
+		`"name": "list+expand"`,
+		`"name": "update"`,
+		`"name": "delete"`,
+		`"name": "realtime"`,
+		`"contention":`,
+	}
+	for _, e := range expectations {
+		if !strings.Contains(out.String(), e) {
+			t.Fatalf("Expected %q in\n%s", e, out.String())
+		}
+	}
+	if total := strings.Count(out.String(), `"errors": 0`); total != 7 {
+		t.Fatalf("Expected no failed requests in all 7 scenarios, got\n%s", out.String())
+	}
+
+	// the synthetic collections should be deleted after the run
+	for _, name := range []string{"bench_authors", "bench_posts"} {
+		if c, _ := app.Dao().FindCollectionByNameOrId(name); c != nil {
+			t.Fatalf("Expected collection %q to be deleted", name)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/spf13/cobra"
+)
+
+// NewCollectionsCommand creates and returns new command for
+// exporting and importing the app collections configuration.
+func NewCollectionsCommand(app core.App) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "collections",
+		Short: "Exports and imports the app collections",
+	}
+
+	command.AddCommand(collectionsExportCommand(app))
+	command.AddCommand(collectionsImportCommand(app))
+
+	AddRemoteFlags(command)
+
+	return command
+}
+
+func collectionsExportCommand(app core.App) *cobra.Command {
+	var file string
+
+	command := &cobra.Command{
+		Use:     "export",
+		Example: "collections export --file=pb_schema.json",
+		Short:   "Exports the app collections as JSON",
+		// prevents printing the error log twice
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(command *cobra.Command, args []string) error {
+			remote, err := NewRemoteClient(command)
+			if err != nil {
+				return err
+			}
+
+			var collections any
+
+			if remote != nil {
+				result := struct {
+					Items []map[string]any `json:"items"`
+				}{}
+				if err := remote.Send(http.MethodGet, "/api/collections?perPage=500&sort=created", nil, &result); err != nil {
+					return fmt.Errorf("Failed to fetch the remote collections: %v", err)
+				}
+				collections = result.Items
+			} else {
+				items := []*models.Collection{}
+				if err := app.Dao().CollectionQuery().OrderBy("created ASC").All(&items); err != nil {
+					return fmt.Errorf("Failed to fetch the collections: %v", err)
+				}
+				collections = items
+			}
+
+			if file == "" {
+				return printJson(command, collections)
+			}
+
+			raw, err := json.MarshalIndent(collections, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			if err := os.WriteFile(file, raw, 0644); err != nil {
+				return fmt.Errorf("Failed to write %s: %v", file, err)
+			}
+
+			color.Green("Successfully exported the collections to %s!", file)
+			return nil
+		},
+	}
+
+	command.Flags().StringVar(&file, "file", "", "the output file (prints to stdout if not set)")
+
+	return command
+}
+
+func collectionsImportCommand(app core.App) *cobra.Command {
+	var deleteMissing bool
+
+	command := &cobra.Command{
+		Use:     "import",
+		Example: "collections import pb_schema.json --delete-missing",
+		Short:   "Imports collections from a JSON file (replacing the existing ones with the same id)",
+		// prevents printing the error log twice
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(command *cobra.Command, args []string) error {
+			if len(args) == 0 || args[0] == "" {
+				return errors.New("Missing the collections JSON file argument.")
+			}
+
+			raw, err := os.ReadFile(args[0])
+			if err != nil {
+				return fmt.Errorf("Failed to read %s: %v", args[0], err)
+			}
+
+			remote, err := NewRemoteClient(command)
+			if err != nil {
+				return err
+			}
+
+			if remote != nil {
+				body := map[string]any{
+					"collections":   json.RawMessage(raw),
+					"deleteMissing": deleteMissing,
+				}
+				if err := remote.Send(http.MethodPut, "/api/collections/import", body, nil); err != nil {
+					return fmt.Errorf("Failed to import the collections: %v", err)
+				}
+			} else {
+				form := forms.NewCollectionsImport(app)
+				form.DeleteMissing = deleteMissing
+
+				if err := json.Unmarshal(raw, &form.Collections); err != nil {
+					return fmt.Errorf("Invalid collections JSON: %v", err)
+				}
+
+				if err := form.Submit(); err != nil {
+					return fmt.Errorf("Failed to import the collections: %v", err)
+				}
+			}
+
+			color.Green("Successfully imported the collections from %s!", args[0])
+			return nil
+		},
+	}
+
+	command.Flags().BoolVar(&deleteMissing, "delete-missing", false, "delete the existing collections that are not present in the imported file")
+
+	return command
+}
@@ -0,0 +1,60 @@
+package cmd_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/cmd"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestCollectionsExportAndImportCommands(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	file := filepath.Join(t.TempDir(), "pb_schema.json")
+
+	export := cmd.NewCollectionsCommand(app)
+	export.SetArgs([]string{"export", "--file", file})
+	if err := export.Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, str := range []string{`"name": "demo1"`, `"name": "users"`} {
+		if !strings.Contains(string(raw), str) {
+			t.Fatalf("Missing %q in the exported collections", str)
+		}
+	}
+
+	// missing file argument
+	{
+		command := cmd.NewCollectionsCommand(app)
+		command.SetArgs([]string{"import"})
+		if err := command.Execute(); err == nil {
+			t.Fatal("Expected error, got nil")
+		}
+	}
+
+	// modify and reimport
+	changed := strings.Replace(string(raw), `"name": "demo3"`, `"name": "demo3_renamed"`, 1)
+	if err := os.WriteFile(file, []byte(changed), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	command := cmd.NewCollectionsCommand(app)
+	command.SetArgs([]string{"import", file})
+	if err := command.Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Dao().FindCollectionByNameOrId("demo3_renamed"); err != nil {
+		t.Fatalf("Expected the imported collection to be renamed, got %v", err)
+	}
+}
@@ -0,0 +1,228 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tools/inflector"
+	"github.com/spf13/cobra"
+)
+
+// NewGenerateCommand creates and returns new command for generating
+// code helpers based on the current app state (eg. typed record models).
+func NewGenerateCommand(app core.App) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "generate",
+		Short: "Generates code helpers based on the app collections",
+	}
+
+	command.AddCommand(generateModelsCommand(app))
+
+	return command
+}
+
+func generateModelsCommand(app core.App) *cobra.Command {
+	var dir string
+	var pkg string
+
+	command := &cobra.Command{
+		Use:     "models",
+		Example: "generate models --dir=./pbmodels --package=pbmodels",
+		Short:   "Generates typed Go structs wrapping models.Record for each collection",
+		// prevents printing the error log twice
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(command *cobra.Command, args []string) error {
+			if !token.IsIdentifier(pkg) {
+				return fmt.Errorf("Invalid package name %q.", pkg)
+			}
+
+			collections := []*models.Collection{}
+			if err := app.Dao().CollectionQuery().OrderBy("name ASC").All(&collections); err != nil {
+				return fmt.Errorf("Failed to load the app collections: %v", err)
+			}
+
+			if len(collections) == 0 {
+				return errors.New("There are no collections to generate models for.")
+			}
+
+			files, err := GenerateModels(collections, pkg)
+			if err != nil {
+				return err
+			}
+
+			if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+				return fmt.Errorf("Failed to create the output directory: %v", err)
+			}
+
+			for name, content := range files {
+				if err := os.WriteFile(filepath.Join(dir, name), content, 0644); err != nil {
+					return fmt.Errorf("Failed to write %s: %v", name, err)
+				}
+			}
+
+			color.Green("Successfully generated %d model(s) in %s!", len(files), dir)
+			return nil
+		},
+	}
+
+	command.Flags().StringVar(&dir, "dir", "./pbmodels", "the output directory of the generated files")
+	command.Flags().StringVar(&pkg, "package", "pbmodels", "the package name of the generated files")
+
+	return command
+}
+
+// GenerateModels generates a typed models.Record proxy struct for
+// each of the provided collections.
+//
+// Returns a map with the generated file names and their formatted Go source.
+func GenerateModels(collections []*models.Collection, pkg string) (map[string][]byte, error) {
+	// resolve the proxy struct names
+	structNames := make(map[string]string, len(collections))
+	for _, c := range collections {
+		name := inflector.Pascalcase(c.Name)
+		if name == "" || !token.IsIdentifier(name) {
+			name = "Collection" + name
+		}
+		structNames[c.Id] = name
+	}
+
+	result := make(map[string][]byte, len(collections))
+
+	for _, c := range collections {
+		src, err := generateModel(c, structNames, pkg)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to generate %q model: %v", c.Name, err)
+		}
+
+		result[inflector.Snakecase(c.Name)+".go"] = src
+	}
+
+	return result, nil
+}
+
+func generateModel(collection *models.Collection, structNames map[string]string, pkg string) ([]byte, error) {
+	name := structNames[collection.Id]
+
+	body := new(bytes.Buffer)
+	usesTypes := false
+
+	for _, field := range collection.Schema.Fields() {
+		field.InitOptions()
+
+		method := inflector.Pascalcase(field.Name)
+		if method == "" || method == "Record" || !token.IsIdentifier(method) {
+			method = "Field" + method
+		}
+
+		goType, getter := fieldGoType(field)
+		if goType == "types.DateTime" {
+			usesTypes = true
+		}
+
+		fmt.Fprintf(body, "\n// %s returns the %q field value.\n", method, field.Name)
+		fmt.Fprintf(body, "func (m *%s) %s() %s {\n", name, method, goType)
+		fmt.Fprintf(body, "\treturn m.Record.%s(%q)\n}\n", getter, field.Name)
+
+		// view collections are read-only
+		if !collection.IsView() {
+			fmt.Fprintf(body, "\n// Set%s sets the %q field value.\n", method, field.Name)
+			fmt.Fprintf(body, "func (m *%s) Set%s(value %s) {\n", name, method, goType)
+			fmt.Fprintf(body, "\tm.Record.Set(%q, value)\n}\n", field.Name)
+		}
+
+		// relation helpers
+		if field.Type == schema.FieldTypeRelation {
+			options, _ := field.Options.(*schema.RelationOptions)
+			if options == nil {
+				continue
+			}
+
+			relName, ok := structNames[options.CollectionId]
+			if !ok {
+				continue
+			}
+
+			if options.IsMultiple() {
+				fmt.Fprintf(body, "\n// Expanded%s returns the already expanded %q relation records.\n", method, field.Name)
+				fmt.Fprintf(body, "func (m *%s) Expanded%s() []*%s {\n", name, method, relName)
+				fmt.Fprintf(body, "\trecords := m.Record.ExpandedAll(%q)\n", field.Name)
+				fmt.Fprintf(body, "\tresult := make([]*%s, len(records))\n", relName)
+				fmt.Fprintf(body, "\tfor i, r := range records {\n\t\tresult[i] = New%s(r)\n\t}\n", relName)
+				fmt.Fprintf(body, "\treturn result\n}\n")
+			} else {
+				fmt.Fprintf(body, "\n// Expanded%s returns the already expanded %q relation record (if any).\n", method, field.Name)
+				fmt.Fprintf(body, "func (m *%s) Expanded%s() *%s {\n", name, method, relName)
+				fmt.Fprintf(body, "\tif r := m.Record.ExpandedOne(%q); r != nil {\n", field.Name)
+				fmt.Fprintf(body, "\t\treturn New%s(r)\n\t}\n", relName)
+				fmt.Fprintf(body, "\treturn nil\n}\n")
+			}
+		}
+	}
+
+	src := new(bytes.Buffer)
+
+	fmt.Fprintf(src, "// Code generated by \"pocketbase generate models\". DO NOT EDIT.\n\n")
+	fmt.Fprintf(src, "package %s\n\n", pkg)
+	fmt.Fprintf(src, "import (\n\t\"github.com/pocketbase/pocketbase/models\"\n")
+	if usesTypes {
+		fmt.Fprintf(src, "\t\"github.com/pocketbase/pocketbase/tools/types\"\n")
+	}
+	fmt.Fprintf(src, ")\n\n")
+
+	fmt.Fprintf(src, "// %sCollectionName is the name of the %q collection.\n", name, collection.Name)
+	fmt.Fprintf(src, "const %sCollectionName = %q\n\n", name, collection.Name)
+
+	fmt.Fprintf(src, "// %s is a typed proxy for the %q collection records.\n", name, collection.Name)
+	fmt.Fprintf(src, "type %s struct {\n\t*models.Record\n}\n\n", name)
+
+	fmt.Fprintf(src, "// New%s wraps the provided record into a new %s proxy.\n", name, name)
+	fmt.Fprintf(src, "func New%s(record *models.Record) *%s {\n\treturn &%s{Record: record}\n}\n", name, name, name)
+
+	src.Write(body.Bytes())
+
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("%v\n%s", err, strings.TrimSpace(src.String()))
+	}
+
+	return formatted, nil
+}
+
+// fieldGoType returns the Go type and the models.Record getter
+// method name that should be used for the provided schema field.
+func fieldGoType(field *schema.SchemaField) (goType string, getter string) {
+	// there is no dedicated record getter for a list of numbers
+	if options, ok := field.Options.(*schema.ListOptions); ok && options.ElementType == schema.ListElementTypeNumber {
+		return "any", "Get"
+	}
+
+	if opt, ok := field.Options.(schema.MultiValuer); ok && opt.IsMultiple() {
+		return "[]string", "GetStringSlice"
+	}
+
+	switch field.Type {
+	case schema.FieldTypeNumber, schema.FieldTypePosition:
+		return "float64", "GetFloat"
+	case schema.FieldTypeCurrency:
+		return "int", "GetInt"
+	case schema.FieldTypeBool:
+		return "bool", "GetBool"
+	case schema.FieldTypeDate:
+		return "types.DateTime", "GetDateTime"
+	case schema.FieldTypeJson, schema.FieldTypeMarkdown:
+		return "any", "Get"
+	default:
+		return "string", "GetString"
+	}
+}
@@ -0,0 +1,98 @@
+package cmd_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/cmd"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestGenerateModelsCommand(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	dir := t.TempDir()
+
+	// invalid package name
+	{
+		command := cmd.NewGenerateCommand(app)
+		command.SetArgs([]string{"models", "--dir", dir, "--package", "invalid-name"})
+
+		if err := command.Execute(); err == nil {
+			t.Fatal("Expected error, got nil")
+		}
+	}
+
+	command := cmd.NewGenerateCommand(app)
+	command.SetArgs([]string{"models", "--dir", dir, "--package", "test"})
+
+	if err := command.Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		file     string
+		expected []string
+	}{
+		{
+			"demo1.go",
+			[]string{
+				"package test",
+				`const Demo1CollectionName = "demo1"`,
+				"type Demo1 struct {",
+				"func NewDemo1(record *models.Record) *Demo1 {",
+				"func (m *Demo1) Text() string {",
+				`return m.Record.GetString("text")`,
+				"func (m *Demo1) SetText(value string) {",
+				"func (m *Demo1) Bool() bool {",
+				"func (m *Demo1) Number() float64 {",
+				"func (m *Demo1) SelectOne() string {",
+				"func (m *Demo1) SelectMany() []string {",
+				"func (m *Demo1) FileMany() []string {",
+				"func (m *Demo1) Datetime() types.DateTime {",
+				"func (m *Demo1) Json() any {",
+				"func (m *Demo1) ExpandedRelOne() *Demo1 {",
+				"func (m *Demo1) ExpandedRelMany() []*Users {",
+			},
+		},
+		{
+			"users.go",
+			[]string{
+				"type Users struct {",
+				"func (m *Users) Name() string {",
+				"func (m *Users) File() []string {",
+			},
+		},
+		{
+			"view1.go",
+			[]string{
+				"type View1 struct {",
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		raw, err := os.ReadFile(filepath.Join(dir, s.file))
+		if err != nil {
+			t.Errorf("[%s] Failed to read the generated file: %v", s.file, err)
+			continue
+		}
+
+		content := string(raw)
+
+		for _, str := range s.expected {
+			if !strings.Contains(content, str) {
+				t.Errorf("[%s] Missing %q in\n%s", s.file, str, content)
+			}
+		}
+	}
+
+	// view collections shouldn't have setters
+	raw, _ := os.ReadFile(filepath.Join(dir, "view1.go"))
+	if strings.Contains(string(raw), ") Set") {
+		t.Errorf("Expected no setters for the view collection, got\n%s", raw)
+	}
+}
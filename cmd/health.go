@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/spf13/cobra"
+)
+
+// NewHealthCommand creates and returns new command for checking the app health.
+func NewHealthCommand(app core.App) *cobra.Command {
+	command := &cobra.Command{
+		Use:     "health",
+		Example: "health --remote=https://example.com",
+		Short:   "Prints the app health status as JSON",
+		// prevents printing the error log twice
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(command *cobra.Command, args []string) error {
+			remote, err := NewRemoteClient(command)
+			if err != nil {
+				return err
+			}
+
+			result := map[string]any{}
+
+			if remote != nil {
+				if err := remote.Send(http.MethodGet, "/api/health", nil, &result); err != nil {
+					return fmt.Errorf("The remote instance is not healthy: %v", err)
+				}
+			} else {
+				if err := app.DB().DB().Ping(); err != nil {
+					return fmt.Errorf("Failed to connect to the app database: %v", err)
+				}
+
+				result["code"] = http.StatusOK
+				result["message"] = "API is healthy."
+				result["data"] = map[string]any{
+					"canBackup": !app.Store().Has(core.StoreKeyActiveBackup),
+				}
+			}
+
+			return printJson(command, result)
+		},
+	}
+
+	AddRemoteFlags(command)
+
+	return command
+}
@@ -0,0 +1,53 @@
+package cmd_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/cmd"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestHealthCommand(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/health" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Write([]byte(`{"code":200,"message":"API is healthy.","data":{"canBackup":false}}`))
+	}))
+	defer server.Close()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		name            string
+		args            []string
+		expectedContent string
+	}{
+		{"local", nil, `"canBackup": true`},
+		{"remote", []string{"--remote", server.URL, "--token", "test_token"}, `"canBackup": false`},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			var out bytes.Buffer
+
+			command := cmd.NewHealthCommand(app)
+			command.SetOut(&out)
+			command.SetArgs(s.args)
+			if err := command.Execute(); err != nil {
+				t.Fatal(err)
+			}
+
+			if !strings.Contains(out.String(), s.expectedContent) {
+				t.Fatalf("Expected %q in\n%s", s.expectedContent, out.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/spf13/cobra"
+)
+
+// NewRecordsCommand creates and returns new command for reading
+// and writing single collection records.
+func NewRecordsCommand(app core.App) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "records",
+		Short: "Reads and writes single collection records",
+	}
+
+	command.AddCommand(recordsGetCommand(app))
+	command.AddCommand(recordsSetCommand(app))
+
+	AddRemoteFlags(command)
+
+	return command
+}
+
+func recordsGetCommand(app core.App) *cobra.Command {
+	command := &cobra.Command{
+		Use:     "get",
+		Example: "records get posts RECORD_ID",
+		Short:   "Prints a single collection record as JSON",
+		// prevents printing the error log twice
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(command *cobra.Command, args []string) error {
+			if len(args) != 2 || args[0] == "" || args[1] == "" {
+				return errors.New("Missing collection and record id arguments.")
+			}
+
+			remote, err := NewRemoteClient(command)
+			if err != nil {
+				return err
+			}
+
+			if remote != nil {
+				result := map[string]any{}
+				if err := remote.Send(http.MethodGet, remoteRecordPath(args[0], args[1]), nil, &result); err != nil {
+					return fmt.Errorf("Failed to fetch the remote record: %v", err)
+				}
+				return printJson(command, result)
+			}
+
+			record, err := app.Dao().FindRecordById(args[0], args[1])
+			if err != nil {
+				return fmt.Errorf("Failed to find record %q in collection %q.", args[1], args[0])
+			}
+			record.IgnoreEmailVisibility(true)
+
+			return printJson(command, record)
+		},
+	}
+
+	return command
+}
+
+func recordsSetCommand(app core.App) *cobra.Command {
+	command := &cobra.Command{
+		Use:     "set",
+		Example: `records set posts RECORD_ID title="Hello world" published=true`,
+		Short:   "Creates or updates a single collection record with the provided key=value pairs",
+		// prevents printing the error log twice
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(command *cobra.Command, args []string) error {
+			if len(args) < 3 || args[0] == "" || args[1] == "" {
+				return errors.New("Missing collection, record id and key=value arguments.")
+			}
+
+			data, err := parseKeyValuePairs(args[2:])
+			if err != nil {
+				return err
+			}
+
+			remote, err := NewRemoteClient(command)
+			if err != nil {
+				return err
+			}
+
+			if remote != nil {
+				result := map[string]any{}
+
+				err := remote.Send(http.MethodPatch, remoteRecordPath(args[0], args[1]), data, &result)
+				if isRemoteNotFound(err) {
+					data["id"] = args[1]
+					err = remote.Send(http.MethodPost, remoteRecordPath(args[0], ""), data, &result)
+				}
+				if err != nil {
+					return fmt.Errorf("Failed to save the remote record: %v", err)
+				}
+
+				return printJson(command, result)
+			}
+
+			collection, err := app.Dao().FindCollectionByNameOrId(args[0])
+			if err != nil {
+				return fmt.Errorf("Collection %q doesn't exist.", args[0])
+			}
+
+			record, err := app.Dao().FindRecordById(collection.Id, args[1])
+			if err != nil {
+				record = models.NewRecord(collection)
+				data["id"] = args[1]
+			}
+
+			form := forms.NewRecordUpsert(app, record)
+			form.SetFullManageAccess(true)
+
+			if err := form.LoadData(data); err != nil {
+				return fmt.Errorf("Failed to load the record data: %v", err)
+			}
+
+			if err := form.Submit(); err != nil {
+				return fmt.Errorf("Failed to save the record: %v", err)
+			}
+
+			record.IgnoreEmailVisibility(true)
+
+			return printJson(command, record)
+		},
+	}
+
+	return command
+}
+
+func remoteRecordPath(collection string, id string) string {
+	path := "/api/collections/" + url.PathEscape(collection) + "/records"
+	if id != "" {
+		path += "/" + url.PathEscape(id)
+	}
+	return path
+}
+
+// parseKeyValuePairs parses the provided "key=value" arguments into a map.
+//
+// Values that are valid JSON (eg. numbers, booleans, arrays) are decoded,
+// otherwise they are used as plain strings.
+func parseKeyValuePairs(args []string) (map[string]any, error) {
+	result := make(map[string]any, len(args))
+
+	for _, arg := range args {
+		key, rawValue, ok := strings.Cut(arg, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("Invalid key=value argument %q.", arg)
+		}
+
+		var value any
+		if err := json.Unmarshal([]byte(rawValue), &value); err != nil {
+			value = rawValue
+		}
+
+		result[key] = value
+	}
+
+	return result, nil
+}
@@ -0,0 +1,85 @@
+package cmd_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/cmd"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestRecordsGetCommand(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	// missing record
+	{
+		command := cmd.NewRecordsCommand(app)
+		command.SetArgs([]string{"get", "demo2", "missing"})
+		if err := command.Execute(); err == nil {
+			t.Fatal("Expected error, got nil")
+		}
+	}
+
+	out := new(bytes.Buffer)
+
+	command := cmd.NewRecordsCommand(app)
+	command.SetOut(out)
+	command.SetArgs([]string{"get", "demo2", "llvuca81nly1qls"})
+	if err := command.Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(out.String(), `"title": "test1"`) {
+		t.Fatalf("Missing the record title in\n%s", out.String())
+	}
+}
+
+func TestRecordsSetCommand(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		name        string
+		args        []string
+		expectError bool
+	}{
+		{"missing key=value pairs", []string{"set", "demo2", "llvuca81nly1qls"}, true},
+		{"invalid key=value pair", []string{"set", "demo2", "llvuca81nly1qls", "title"}, true},
+		{"missing collection", []string{"set", "missing", "llvuca81nly1qls", "title=abc"}, true},
+		{"invalid data", []string{"set", "demo2", "llvuca81nly1qls", "title=a"}, true},
+		{"update existing", []string{"set", "demo2", "llvuca81nly1qls", "title=updated", "active=true"}, false},
+		{"create new", []string{"set", "demo2", "abcdefghijklmno", "title=created"}, false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			command := cmd.NewRecordsCommand(app)
+			command.SetOut(new(bytes.Buffer))
+			command.SetArgs(s.args)
+
+			err := command.Execute()
+
+			if hasErr := err != nil; hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+		})
+	}
+
+	updated, err := app.Dao().FindRecordById("demo2", "llvuca81nly1qls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.GetString("title") != "updated" || !updated.GetBool("active") {
+		t.Fatalf("Expected the record to be updated, got %v", updated.PublicExport())
+	}
+
+	created, err := app.Dao().FindRecordById("demo2", "abcdefghijklmno")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.GetString("title") != "created" {
+		t.Fatalf("Expected title %q, got %q", "created", created.GetString("title"))
+	}
+}
@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// remoteTokenEnv is the name of the env variable that could be used
+// as alternative to the --token flag.
+const remoteTokenEnv = "PB_ADMIN_TOKEN"
+
+// remoteError defines a failed remote api request error.
+type remoteError struct {
+	Status  int            `json:"code"`
+	Message string         `json:"message"`
+	Data    map[string]any `json:"data"`
+}
+
+// Error implements the [error] interface.
+func (e *remoteError) Error() string {
+	msg := fmt.Sprintf("%d %s", e.Status, e.Message)
+
+	if len(e.Data) > 0 {
+		raw, _ := json.Marshal(e.Data)
+		msg += " " + string(raw)
+	}
+
+	return msg
+}
+
+// RemoteClient is a minimal admin api client used by the CLI commands
+// to manage a remote PocketBase instance.
+type RemoteClient struct {
+	baseUrl string
+	token   string
+	client  *http.Client
+}
+
+// AddRemoteFlags registers the --remote and --token persistent flags to the provided command.
+func AddRemoteFlags(command *cobra.Command) {
+	command.PersistentFlags().String(
+		"remote",
+		"",
+		"the url of a remote PocketBase instance to manage instead of the local data dir (eg. https://example.com)",
+	)
+	command.PersistentFlags().String(
+		"token",
+		"",
+		"the admin auth token used with --remote (or "+remoteTokenEnv+" env variable)",
+	)
+}
+
+// NewRemoteClient creates a new RemoteClient based on the command
+// --remote and --token flags.
+//
+// Returns nil, nil if the command doesn't have a --remote flag value.
+func NewRemoteClient(command *cobra.Command) (*RemoteClient, error) {
+	remote, _ := command.Flags().GetString("remote")
+	if remote == "" {
+		return nil, nil
+	}
+
+	token, _ := command.Flags().GetString("token")
+	if token == "" {
+		token = os.Getenv(remoteTokenEnv)
+	}
+
+	if token == "" {
+		return nil, fmt.Errorf("Missing admin token for the remote instance (use --token or %s).", remoteTokenEnv)
+	}
+
+	if !strings.HasPrefix(remote, "http://") && !strings.HasPrefix(remote, "https://") {
+		return nil, errors.New("The remote url must start with http:// or https://.")
+	}
+
+	return &RemoteClient{
+		baseUrl: strings.TrimRight(remote, "/"),
+		token:   token,
+		client:  &http.Client{Timeout: 5 * time.Minute},
+	}, nil
+}
+
+// Send sends a new json request to the remote instance and
+// decodes the json response into result (if not nil).
+func (c *RemoteClient) Send(method string, path string, body any, result any) error {
+	var reqBody io.Reader
+	if body != nil {
+		raw, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(raw)
+	}
+
+	req, err := http.NewRequest(method, c.baseUrl+path, reqBody)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", c.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 400 {
+		apiErr := &remoteError{}
+		if err := json.NewDecoder(res.Body).Decode(apiErr); err != nil || apiErr.Message == "" {
+			apiErr.Message = http.StatusText(res.StatusCode)
+		}
+		apiErr.Status = res.StatusCode
+		return apiErr
+	}
+
+	if result == nil || res.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	return json.NewDecoder(res.Body).Decode(result)
+}
+
+// isRemoteNotFound checks whether the provided error is a remote 404 error.
+func isRemoteNotFound(err error) bool {
+	var apiErr *remoteError
+	return errors.As(err, &apiErr) && apiErr.Status == http.StatusNotFound
+}
+
+// printJson prints the provided value as indented json to the command output.
+func printJson(command *cobra.Command, value any) error {
+	raw, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(command.OutOrStdout(), string(raw))
+
+	return err
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/spf13/cobra"
+)
+
+// NewRulesCommand creates and returns new command for inspecting the collections API rules.
+func NewRulesCommand(app core.App) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "rules",
+		Short: "Inspects the collections API rules",
+	}
+
+	command.AddCommand(rulesLintCommand(app))
+
+	return command
+}
+
+func rulesLintCommand(app core.App) *cobra.Command {
+	var strict bool
+
+	command := &cobra.Command{
+		Use:     "lint",
+		Example: "rules lint posts comments --strict",
+		Short:   "Checks the API rules of all (or the specified) collections for errors and common pitfalls",
+		// prevents printing the error log twice
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(command *cobra.Command, args []string) error {
+			collections := []*models.Collection{}
+
+			if len(args) == 0 {
+				if err := app.Dao().CollectionQuery().OrderBy("created ASC").All(&collections); err != nil {
+					return fmt.Errorf("Failed to fetch the collections: %v", err)
+				}
+			} else {
+				for _, nameOrId := range args {
+					collection, err := app.Dao().FindCollectionByNameOrId(nameOrId)
+					if err != nil {
+						return fmt.Errorf("Collection %q doesn't exist.", nameOrId)
+					}
+					collections = append(collections, collection)
+				}
+			}
+
+			var totalErrors, totalWarnings int
+
+			for _, collection := range collections {
+				for _, issue := range app.Dao().LintCollectionRules(collection) {
+					if issue.Severity == daos.RuleLintSeverityError {
+						totalErrors++
+					} else {
+						totalWarnings++
+					}
+
+					fmt.Fprintln(command.OutOrStdout(), issue.String())
+				}
+			}
+
+			if totalErrors > 0 || (strict && totalWarnings > 0) {
+				return fmt.Errorf("Found %d error(s) and %d warning(s).", totalErrors, totalWarnings)
+			}
+
+			color.Green("Checked %d collection(s) - %d error(s) and %d warning(s).", len(collections), totalErrors, totalWarnings)
+			return nil
+		},
+	}
+
+	command.Flags().BoolVar(&strict, "strict", false, "fail also on warnings")
+
+	return command
+}
@@ -0,0 +1,47 @@
+package cmd_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/cmd"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestRulesLintCommand(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		name            string
+		args            []string
+		expectError     bool
+		expectedContent string
+	}{
+		{"missing collection", []string{"lint", "missing"}, true, ""},
+		{"no issues", []string{"lint", "demo2"}, false, ""},
+		{"warnings", []string{"lint", "view1"}, false, `[warning] view1.listRule: field "bool"`},
+		{"warnings in strict mode", []string{"lint", "view1", "--strict"}, true, `[warning] view1.viewRule: field "bool"`},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			var out bytes.Buffer
+
+			command := cmd.NewRulesCommand(app)
+			command.SetOut(&out)
+			command.SetArgs(s.args)
+
+			err := command.Execute()
+
+			if hasErr := err != nil; hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if !strings.Contains(out.String(), s.expectedContent) {
+				t.Fatalf("Expected %q in\n%s", s.expectedContent, out.String())
+			}
+		})
+	}
+}
@@ -15,6 +15,7 @@ func NewServeCommand(app core.App, showStartBanner bool) *cobra.Command {
 	var allowedOrigins []string
 	var httpAddr string
 	var httpsAddr string
+	var disableAdminUI bool
 
 	command := &cobra.Command{
 		Use:   "serve [domain(s)]",
@@ -41,6 +42,7 @@ func NewServeCommand(app core.App, showStartBanner bool) *cobra.Command {
 				ShowStartBanner:    showStartBanner,
 				AllowedOrigins:     allowedOrigins,
 				CertificateDomains: args,
+				DisableAdminUI:     disableAdminUI,
 			})
 
 			if err != http.ErrServerClosed {
@@ -70,5 +72,12 @@ func NewServeCommand(app core.App, showStartBanner bool) *cobra.Command {
 		"TCP address to listen for the HTTPS server\n(if domain args are specified - default to 0.0.0.0:443, otherwise - default to empty string, aka. no TLS)\nThe incoming HTTP traffic also will be auto redirected to the HTTPS version",
 	)
 
+	command.PersistentFlags().BoolVar(
+		&disableAdminUI,
+		"disableAdminUI",
+		false,
+		"disable serving the bundled admin UI (regardless of the app settings)",
+	)
+
 	return command
 }
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/spf13/cobra"
+)
+
+// NewSettingsCommand creates and returns new command for managing the app settings.
+func NewSettingsCommand(app core.App) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "settings",
+		Short: "Manages the app settings",
+	}
+
+	command.AddCommand(settingsGetCommand(app))
+	command.AddCommand(settingsSetCommand(app))
+
+	AddRemoteFlags(command)
+
+	return command
+}
+
+func settingsGetCommand(app core.App) *cobra.Command {
+	command := &cobra.Command{
+		Use:     "get",
+		Example: "settings get",
+		Short:   "Prints the app settings as JSON (the secrets are redacted)",
+		// prevents printing the error log twice
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(command *cobra.Command, args []string) error {
+			remote, err := NewRemoteClient(command)
+			if err != nil {
+				return err
+			}
+
+			if remote != nil {
+				result := map[string]any{}
+				if err := remote.Send(http.MethodGet, "/api/settings", nil, &result); err != nil {
+					return fmt.Errorf("Failed to fetch the remote settings: %v", err)
+				}
+				return printJson(command, result)
+			}
+
+			settings, err := app.Settings().RedactClone()
+			if err != nil {
+				return err
+			}
+
+			return printJson(command, settings)
+		},
+	}
+
+	return command
+}
+
+func settingsSetCommand(app core.App) *cobra.Command {
+	command := &cobra.Command{
+		Use:     "set",
+		Example: `settings set meta.appName="My app" logs.maxDays=7`,
+		Short:   "Updates the app settings with the provided key=value pairs (nested keys are separated with dot)",
+		// prevents printing the error log twice
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		RunE: func(command *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return errors.New("Missing key=value arguments.")
+			}
+
+			pairs, err := parseKeyValuePairs(args)
+			if err != nil {
+				return err
+			}
+
+			data := expandDottedKeys(pairs)
+
+			remote, err := NewRemoteClient(command)
+			if err != nil {
+				return err
+			}
+
+			if remote != nil {
+				if err := remote.Send(http.MethodPatch, "/api/settings", data, nil); err != nil {
+					return fmt.Errorf("Failed to update the remote settings: %v", err)
+				}
+			} else {
+				form := forms.NewSettingsUpsert(app)
+
+				raw, err := json.Marshal(data)
+				if err != nil {
+					return err
+				}
+
+				if err := json.Unmarshal(raw, form); err != nil {
+					return fmt.Errorf("Invalid settings value: %v", err)
+				}
+
+				if err := form.Submit(); err != nil {
+					return fmt.Errorf("Failed to update the settings: %v", err)
+				}
+			}
+
+			color.Green("Successfully updated the app settings!")
+			return nil
+		},
+	}
+
+	return command
+}
+
+// expandDottedKeys converts the provided flat "a.b.c" keys map into a nested one.
+func expandDottedKeys(data map[string]any) map[string]any {
+	result := map[string]any{}
+
+	for key, value := range data {
+		parts := strings.Split(key, ".")
+
+		current := result
+		for _, part := range parts[:len(parts)-1] {
+			next, ok := current[part].(map[string]any)
+			if !ok {
+				next = map[string]any{}
+				current[part] = next
+			}
+			current = next
+		}
+
+		current[parts[len(parts)-1]] = value
+	}
+
+	return result
+}
@@ -0,0 +1,68 @@
+package cmd_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/cmd"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestSettingsSetCommand(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		name        string
+		args        []string
+		expectError bool
+	}{
+		{"missing key=value pairs", []string{"set"}, true},
+		{"invalid value type", []string{"set", "logs.maxDays=abc"}, true},
+		{"invalid value", []string{"set", "meta.appName="}, true},
+		{"valid values", []string{"set", "meta.appName=new app", "logs.maxDays=7"}, false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			command := cmd.NewSettingsCommand(app)
+			command.SetArgs(s.args)
+
+			err := command.Execute()
+
+			if hasErr := err != nil; hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+		})
+	}
+
+	if app.Settings().Meta.AppName != "new app" {
+		t.Fatalf("Expected app name %q, got %q", "new app", app.Settings().Meta.AppName)
+	}
+
+	if app.Settings().Logs.MaxDays != 7 {
+		t.Fatalf("Expected logs max days %d, got %d", 7, app.Settings().Logs.MaxDays)
+	}
+}
+
+func TestSettingsGetCommand(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	var out bytes.Buffer
+
+	command := cmd.NewSettingsCommand(app)
+	command.SetOut(&out)
+	command.SetArgs([]string{"get"})
+	if err := command.Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	expectations := []string{`"appName": "acme_test"`, `"secret": "******"`}
+	for _, e := range expectations {
+		if !strings.Contains(out.String(), e) {
+			t.Fatalf("Expected %q in\n%s", e, out.String())
+		}
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/pocketbase/pocketbase/tools/filesystem"
 	"github.com/pocketbase/pocketbase/tools/hook"
 	"github.com/pocketbase/pocketbase/tools/mailer"
+	"github.com/pocketbase/pocketbase/tools/secrets"
 	"github.com/pocketbase/pocketbase/tools/store"
 	"github.com/pocketbase/pocketbase/tools/subscriptions"
 )
@@ -62,6 +63,9 @@ type App interface {
 	// IsDev returns whether the app is in dev mode.
 	IsDev() bool
 
+	// Version returns the app version (could be empty).
+	Version() string
+
 	// Settings returns the loaded app settings.
 	Settings() *settings.Settings
 
@@ -74,6 +78,10 @@ type App interface {
 	// SubscriptionsBroker returns the app realtime subscriptions broker instance.
 	SubscriptionsBroker() *subscriptions.Broker
 
+	// AuthLockouts returns the app failed password auth attempts
+	// tracker used for the account lockout.
+	AuthLockouts() *AuthLockouts
+
 	// NewMailClient creates and returns a configured app mail client.
 	NewMailClient() mailer.Mailer
 
@@ -91,6 +99,40 @@ type App interface {
 	// after you are done working with it.
 	NewBackupsFilesystem() (*filesystem.System, error)
 
+	// TenantKeyProvider returns the active per-tenant data encryption keys provider.
+	TenantKeyProvider() TenantKeyProvider
+
+	// SetTenantKeyProvider replaces the active tenant keys provider
+	// (eg. with one backed by an external KMS).
+	SetTenantKeyProvider(provider TenantKeyProvider)
+
+	// ShredTenant makes the data of the specified tenant unrecoverable
+	// by permanently deleting its encryption key and the tenant files
+	// storage prefix of the tenant aware collections.
+	ShredTenant(tenantId string) error
+
+	// SecretsBackend returns the active secrets backend used to encrypt
+	// the stored app settings.
+	//
+	// Returns nil if the app settings are encrypted with the EncryptionEnv key (or not encrypted at all).
+	SecretsBackend() secrets.Backend
+
+	// SetSecretsBackend replaces the active settings secrets backend.
+	//
+	// The optional previous backends are used only for decrypting
+	// settings that were sealed before the backend change
+	// (they are automatically re-encrypted with the active backend on RefreshSettings()).
+	SetSecretsBackend(backend secrets.Backend, previous ...secrets.Backend)
+
+	// ResolveSecret resolves the provided settings value if it is an env
+	// or file secret reference (eg. "$env:SMTP_PASSWORD"), otherwise
+	// returns it as it is.
+	ResolveSecret(value string) string
+
+	// ReloadSettings reloads the stored app settings and re-resolves
+	// the env and file referenced settings secrets without restarting the app.
+	ReloadSettings() error
+
 	// RefreshSettings reinitializes and reloads the stored application settings.
 	RefreshSettings() error
 
@@ -116,6 +158,20 @@ type App interface {
 	// for details on the backup procedures.
 	CreateBackup(ctx context.Context, name string) error
 
+	// CreateDataDump creates a new lightweight data-only backup with the
+	// records of the specified collections (or all non-view collections
+	// if none are specified), excluding the app settings, logs and files.
+	//
+	// Data dumps are stored together with the regular backups and
+	// could be restored with RestoreBackup.
+	CreateDataDump(ctx context.Context, name string, collections ...string) error
+
+	// CreateMaskedDataDump creates a new data-only backup similar to
+	// CreateDataDump but with the sensitive record values (emails,
+	// names, tokens, etc.) masked so that it could be safely
+	// restored in a non-production environment.
+	CreateMaskedDataDump(ctx context.Context, name string, collections ...string) error
+
 	// RestoreBackup restores the backup with the specified name and restarts
 	// the current running application process.
 	//
@@ -215,6 +271,31 @@ type App interface {
 	// will be triggered and called only if their event data origin matches the tags.
 	OnModelAfterDelete(tags ...string) *hook.TaggedHook[*ModelEvent]
 
+	// OnRecordAfterAnyWrite hook is triggered after successfully creating,
+	// updating or deleting a Record model in the DB (aka. it is a wildcard
+	// for the Record specific OnModelAfterCreate, OnModelAfterUpdate
+	// and OnModelAfterDelete events).
+	//
+	// The performed write operation is available via [RecordWriteEvent.Action].
+	//
+	// If the optional "tags" list (Collection ids or names) is specified,
+	// then all event handlers registered via the created hook will be
+	// triggered and called only if their event data origin matches the tags.
+	OnRecordAfterAnyWrite(tags ...string) *hook.TaggedHook[*RecordWriteEvent]
+
+	// ---------------------------------------------------------------
+	// Security event hooks
+	// ---------------------------------------------------------------
+
+	// OnSecurityEvent hook is triggered on each structured security event
+	// (eg. admin login, failed auth attempt, API rule change, etc.).
+	//
+	// If the settings security events are enabled, the hook is also
+	// triggered for the detected anomalies (eg. failed auth bursts,
+	// admin login from a new ip, impossible travel) and each event
+	// is sent to the configured webhook sink.
+	OnSecurityEvent() *hook.Hook[*SecurityEvent]
+
 	// ---------------------------------------------------------------
 	// Mailer event hooks
 	// ---------------------------------------------------------------
@@ -279,6 +360,23 @@ type App interface {
 	// triggered and called only if their event data origin matches the tags.
 	OnMailerAfterRecordChangeEmailSend(tags ...string) *hook.TaggedHook[*MailerRecordEvent]
 
+	// OnMailerBeforeRecordOtpSend hook is triggered right before
+	// sending a one-time password email to an auth record, allowing
+	// you to inspect and customize the email message that is being sent.
+	//
+	// If the optional "tags" list (Collection ids or names) is specified,
+	// then all event handlers registered via the created hook will be
+	// triggered and called only if their event data origin matches the tags.
+	OnMailerBeforeRecordOtpSend(tags ...string) *hook.TaggedHook[*MailerRecordEvent]
+
+	// OnMailerAfterRecordOtpSend hook is triggered after a
+	// one-time password email was successfully sent to an auth record.
+	//
+	// If the optional "tags" list (Collection ids or names) is specified,
+	// then all event handlers registered via the created hook will be
+	// triggered and called only if their event data origin matches the tags.
+	OnMailerAfterRecordOtpSend(tags ...string) *hook.TaggedHook[*MailerRecordEvent]
+
 	// ---------------------------------------------------------------
 	// Realtime API event hooks
 	// ---------------------------------------------------------------
@@ -416,6 +514,13 @@ type App interface {
 	// authenticated admin data and token.
 	OnAdminAuthRequest() *hook.Hook[*AdminAuthEvent]
 
+	// OnAdminAuthTokenIssue hook is triggered right before signing
+	// each newly issued Admin auth token.
+	//
+	// Could be used to inject custom claims into the token
+	// (the built-in "id", "type" and "exp" claims cannot be changed).
+	OnAdminAuthTokenIssue() *hook.Hook[*AdminAuthTokenIssueEvent]
+
 	// OnAdminBeforeAuthWithPasswordRequest hook is triggered before each Admin
 	// auth with password API request (after request data load and before password validation).
 	//
@@ -475,6 +580,20 @@ type App interface {
 	// triggered and called only if their event data origin matches the tags.
 	OnRecordAuthRequest(tags ...string) *hook.TaggedHook[*RecordAuthEvent]
 
+	// OnRecordAuthTokenIssue hook is triggered right before signing
+	// each newly issued auth record token.
+	//
+	// Could be used to inject custom claims (roles, tenant id, plan, etc.)
+	// into the token so that downstream services could authorize the
+	// request without extra lookups (the built-in "id", "type",
+	// "collectionId", "exp" and the reserved "impersonator" and "sid"
+	// claims cannot be changed).
+	//
+	// If the optional "tags" list (Collection ids or names) is specified,
+	// then all event handlers registered via the created hook will be
+	// triggered and called only if their event data origin matches the tags.
+	OnRecordAuthTokenIssue(tags ...string) *hook.TaggedHook[*RecordAuthTokenIssueEvent]
+
 	// OnRecordBeforeAuthWithPasswordRequest hook is triggered before each Record
 	// auth with password API request (after request data load and before password validation).
 	//
@@ -756,6 +875,17 @@ type App interface {
 	// triggered and called only if their event data origin matches the tags.
 	OnRecordAfterDeleteRequest(tags ...string) *hook.TaggedHook[*RecordDeleteEvent]
 
+	// OnRecordSyncConflict hook is triggered on each API Record sync
+	// conflict for collections with "custom" sync strategy.
+	//
+	// Could be used to decide whether to apply the client mutation
+	// (by setting e.AcceptClient) or to merge the client and server changes.
+	//
+	// If the optional "tags" list (Collection ids or names) is specified,
+	// then all event handlers registered via the created hook will be
+	// triggered and called only if their event data origin matches the tags.
+	OnRecordSyncConflict(tags ...string) *hook.TaggedHook[*RecordSyncConflictEvent]
+
 	// ---------------------------------------------------------------
 	// Collection API event hooks
 	// ---------------------------------------------------------------
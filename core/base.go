@@ -4,11 +4,13 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"log"
 	"log/slog"
 	"os"
 	"path/filepath"
 	"runtime"
+	"sync"
 	"syscall"
 	"time"
 
@@ -22,6 +24,8 @@ import (
 	"github.com/pocketbase/pocketbase/tools/logger"
 	"github.com/pocketbase/pocketbase/tools/mailer"
 	"github.com/pocketbase/pocketbase/tools/routine"
+	"github.com/pocketbase/pocketbase/tools/search"
+	"github.com/pocketbase/pocketbase/tools/secrets"
 	"github.com/pocketbase/pocketbase/tools/security"
 	"github.com/pocketbase/pocketbase/tools/store"
 	"github.com/pocketbase/pocketbase/tools/subscriptions"
@@ -38,6 +42,14 @@ const (
 	LocalStorageDirName string = "storage"
 	LocalBackupsDirName string = "backups"
 	LocalTempDirName    string = ".pb_temp_to_delete" // temp pb_data sub directory that will be deleted on each app.Bootstrap()
+
+	// DefaultHookIdDeleteStorageFiles is the id of the default OnModelAfterDelete
+	// handler that deletes the storage files of the deleted model.
+	//
+	// It could be used to remove or replace the default handler, eg.:
+	//
+	//	app.OnModelAfterDelete().Remove(core.DefaultHookIdDeleteStorageFiles)
+	DefaultHookIdDeleteStorageFiles string = "@deleteStorageFiles"
 )
 
 var _ App = (*BaseApp)(nil)
@@ -47,6 +59,7 @@ type BaseApp struct {
 	// @todo consider introducing a mutex to allow safe concurrent config changes during runtime
 
 	// configurable parameters
+	version          string
 	isDev            bool
 	dataDir          string
 	encryptionEnv    string
@@ -62,6 +75,13 @@ type BaseApp struct {
 	logsDao             *daos.Dao
 	subscriptionsBroker *subscriptions.Broker
 	logger              *slog.Logger
+	tenantKeyProvider   TenantKeyProvider
+	secretsBackend      secrets.Backend
+	prevSecretsBackends []secrets.Backend
+	resolvedSecrets     *store.Store[string]
+	authLockouts        *AuthLockouts
+
+	recordSchedulesMux sync.Mutex
 
 	// app event hooks
 	onBeforeBootstrap *hook.Hook[*BootstrapEvent]
@@ -79,6 +99,12 @@ type BaseApp struct {
 	onModelBeforeDelete *hook.Hook[*ModelEvent]
 	onModelAfterDelete  *hook.Hook[*ModelEvent]
 
+	// record write event hooks
+	onRecordAfterAnyWrite *hook.Hook[*RecordWriteEvent]
+
+	// security event hooks
+	onSecurityEvent *hook.Hook[*SecurityEvent]
+
 	// mailer event hooks
 	onMailerBeforeAdminResetPasswordSend  *hook.Hook[*MailerAdminEvent]
 	onMailerAfterAdminResetPasswordSend   *hook.Hook[*MailerAdminEvent]
@@ -88,6 +114,8 @@ type BaseApp struct {
 	onMailerAfterRecordVerificationSend   *hook.Hook[*MailerRecordEvent]
 	onMailerBeforeRecordChangeEmailSend   *hook.Hook[*MailerRecordEvent]
 	onMailerAfterRecordChangeEmailSend    *hook.Hook[*MailerRecordEvent]
+	onMailerBeforeRecordOtpSend           *hook.Hook[*MailerRecordEvent]
+	onMailerAfterRecordOtpSend            *hook.Hook[*MailerRecordEvent]
 
 	// realtime api event hooks
 	onRealtimeConnectRequest         *hook.Hook[*RealtimeConnectEvent]
@@ -117,6 +145,7 @@ type BaseApp struct {
 	onAdminBeforeDeleteRequest               *hook.Hook[*AdminDeleteEvent]
 	onAdminAfterDeleteRequest                *hook.Hook[*AdminDeleteEvent]
 	onAdminAuthRequest                       *hook.Hook[*AdminAuthEvent]
+	onAdminAuthTokenIssue                    *hook.Hook[*AdminAuthTokenIssueEvent]
 	onAdminBeforeAuthWithPasswordRequest     *hook.Hook[*AdminAuthWithPasswordEvent]
 	onAdminAfterAuthWithPasswordRequest      *hook.Hook[*AdminAuthWithPasswordEvent]
 	onAdminBeforeAuthRefreshRequest          *hook.Hook[*AdminAuthRefreshEvent]
@@ -128,6 +157,7 @@ type BaseApp struct {
 
 	// record auth API event hooks
 	onRecordAuthRequest                       *hook.Hook[*RecordAuthEvent]
+	onRecordAuthTokenIssue                    *hook.Hook[*RecordAuthTokenIssueEvent]
 	onRecordBeforeAuthWithPasswordRequest     *hook.Hook[*RecordAuthWithPasswordEvent]
 	onRecordAfterAuthWithPasswordRequest      *hook.Hook[*RecordAuthWithPasswordEvent]
 	onRecordBeforeAuthWithOAuth2Request       *hook.Hook[*RecordAuthWithOAuth2Event]
@@ -159,6 +189,7 @@ type BaseApp struct {
 	onRecordAfterUpdateRequest  *hook.Hook[*RecordUpdateEvent]
 	onRecordBeforeDeleteRequest *hook.Hook[*RecordDeleteEvent]
 	onRecordAfterDeleteRequest  *hook.Hook[*RecordDeleteEvent]
+	onRecordSyncConflict        *hook.Hook[*RecordSyncConflictEvent]
 
 	// collection API event hooks
 	onCollectionsListRequest         *hook.Hook[*CollectionsListEvent]
@@ -175,6 +206,7 @@ type BaseApp struct {
 
 // BaseAppConfig defines a BaseApp configuration option
 type BaseAppConfig struct {
+	Version          string
 	IsDev            bool
 	DataDir          string
 	EncryptionEnv    string
@@ -190,6 +222,7 @@ type BaseAppConfig struct {
 // To initialize the app, you need to call `app.Bootstrap()`.
 func NewBaseApp(config BaseAppConfig) *BaseApp {
 	app := &BaseApp{
+		version:             config.Version,
 		isDev:               config.IsDev,
 		dataDir:             config.DataDir,
 		encryptionEnv:       config.EncryptionEnv,
@@ -198,6 +231,7 @@ func NewBaseApp(config BaseAppConfig) *BaseApp {
 		logsMaxOpenConns:    config.LogsMaxOpenConns,
 		logsMaxIdleConns:    config.LogsMaxIdleConns,
 		store:               store.New[any](nil),
+		resolvedSecrets:     store.New[string](nil),
 		settings:            settings.New(),
 		subscriptionsBroker: subscriptions.NewBroker(),
 
@@ -217,6 +251,12 @@ func NewBaseApp(config BaseAppConfig) *BaseApp {
 		onModelBeforeDelete: &hook.Hook[*ModelEvent]{},
 		onModelAfterDelete:  &hook.Hook[*ModelEvent]{},
 
+		// record write event hooks
+		onRecordAfterAnyWrite: &hook.Hook[*RecordWriteEvent]{},
+
+		// security event hooks
+		onSecurityEvent: &hook.Hook[*SecurityEvent]{},
+
 		// mailer event hooks
 		onMailerBeforeAdminResetPasswordSend:  &hook.Hook[*MailerAdminEvent]{},
 		onMailerAfterAdminResetPasswordSend:   &hook.Hook[*MailerAdminEvent]{},
@@ -226,6 +266,8 @@ func NewBaseApp(config BaseAppConfig) *BaseApp {
 		onMailerAfterRecordVerificationSend:   &hook.Hook[*MailerRecordEvent]{},
 		onMailerBeforeRecordChangeEmailSend:   &hook.Hook[*MailerRecordEvent]{},
 		onMailerAfterRecordChangeEmailSend:    &hook.Hook[*MailerRecordEvent]{},
+		onMailerBeforeRecordOtpSend:           &hook.Hook[*MailerRecordEvent]{},
+		onMailerAfterRecordOtpSend:            &hook.Hook[*MailerRecordEvent]{},
 
 		// realtime API event hooks
 		onRealtimeConnectRequest:         &hook.Hook[*RealtimeConnectEvent]{},
@@ -255,6 +297,7 @@ func NewBaseApp(config BaseAppConfig) *BaseApp {
 		onAdminBeforeDeleteRequest:               &hook.Hook[*AdminDeleteEvent]{},
 		onAdminAfterDeleteRequest:                &hook.Hook[*AdminDeleteEvent]{},
 		onAdminAuthRequest:                       &hook.Hook[*AdminAuthEvent]{},
+		onAdminAuthTokenIssue:                    &hook.Hook[*AdminAuthTokenIssueEvent]{},
 		onAdminBeforeAuthWithPasswordRequest:     &hook.Hook[*AdminAuthWithPasswordEvent]{},
 		onAdminAfterAuthWithPasswordRequest:      &hook.Hook[*AdminAuthWithPasswordEvent]{},
 		onAdminBeforeAuthRefreshRequest:          &hook.Hook[*AdminAuthRefreshEvent]{},
@@ -266,6 +309,7 @@ func NewBaseApp(config BaseAppConfig) *BaseApp {
 
 		// record auth API event hooks
 		onRecordAuthRequest:                       &hook.Hook[*RecordAuthEvent]{},
+		onRecordAuthTokenIssue:                    &hook.Hook[*RecordAuthTokenIssueEvent]{},
 		onRecordBeforeAuthWithPasswordRequest:     &hook.Hook[*RecordAuthWithPasswordEvent]{},
 		onRecordAfterAuthWithPasswordRequest:      &hook.Hook[*RecordAuthWithPasswordEvent]{},
 		onRecordBeforeAuthWithOAuth2Request:       &hook.Hook[*RecordAuthWithOAuth2Event]{},
@@ -297,6 +341,7 @@ func NewBaseApp(config BaseAppConfig) *BaseApp {
 		onRecordAfterUpdateRequest:  &hook.Hook[*RecordUpdateEvent]{},
 		onRecordBeforeDeleteRequest: &hook.Hook[*RecordDeleteEvent]{},
 		onRecordAfterDeleteRequest:  &hook.Hook[*RecordDeleteEvent]{},
+		onRecordSyncConflict:        &hook.Hook[*RecordSyncConflictEvent]{},
 
 		// collection API event hooks
 		onCollectionsListRequest:         &hook.Hook[*CollectionsListEvent]{},
@@ -311,6 +356,11 @@ func NewBaseApp(config BaseAppConfig) *BaseApp {
 		onCollectionsAfterImportRequest:  &hook.Hook[*CollectionsImportEvent]{},
 	}
 
+	app.authLockouts = &AuthLockouts{
+		app:      app,
+		failures: map[string][]time.Time{},
+	}
+
 	app.registerDefaultHooks()
 
 	return app
@@ -461,6 +511,44 @@ func (app *BaseApp) EncryptionEnv() string {
 	return app.encryptionEnv
 }
 
+// TenantKeyProvider returns the active per-tenant data encryption keys provider.
+//
+// If not explicitly set, defaults to a provider that stores random
+// generated keys in the app params table (aka. in the same db and
+// backups as the encrypted data).
+func (app *BaseApp) TenantKeyProvider() TenantKeyProvider {
+	if app.tenantKeyProvider == nil {
+		app.tenantKeyProvider = &paramsTenantKeyProvider{app: app}
+	}
+
+	return app.tenantKeyProvider
+}
+
+// SetTenantKeyProvider replaces the active tenant keys provider
+// (eg. with one backed by an external KMS so that shredding a tenant
+// also makes its data unrecoverable from the existing backups).
+func (app *BaseApp) SetTenantKeyProvider(provider TenantKeyProvider) {
+	app.tenantKeyProvider = provider
+}
+
+// SecretsBackend returns the active secrets backend used to encrypt
+// the stored app settings.
+//
+// Returns nil if the app settings are encrypted with the EncryptionEnv key (or not encrypted at all).
+func (app *BaseApp) SecretsBackend() secrets.Backend {
+	return app.secretsBackend
+}
+
+// SetSecretsBackend replaces the active settings secrets backend.
+//
+// The optional previous backends are used only for decrypting
+// settings that were sealed before the backend change
+// (they are automatically re-encrypted with the active backend on RefreshSettings()).
+func (app *BaseApp) SetSecretsBackend(backend secrets.Backend, previous ...secrets.Backend) {
+	app.secretsBackend = backend
+	app.prevSecretsBackends = previous
+}
+
 // IsDev returns whether the app is in dev mode.
 //
 // When enabled logs, executed sql statements, etc. are printed to the stderr.
@@ -468,6 +556,11 @@ func (app *BaseApp) IsDev() bool {
 	return app.isDev
 }
 
+// Version returns the app version (if set via the BaseAppConfig).
+func (app *BaseApp) Version() string {
+	return app.version
+}
+
 // Settings returns the loaded app settings.
 func (app *BaseApp) Settings() *settings.Settings {
 	return app.settings
@@ -489,6 +582,11 @@ func (app *BaseApp) SubscriptionsBroker() *subscriptions.Broker {
 	return app.subscriptionsBroker
 }
 
+// AuthLockouts returns the app failed password auth attempts tracker.
+func (app *BaseApp) AuthLockouts() *AuthLockouts {
+	return app.authLockouts
+}
+
 // NewMailClient creates and returns a new SMTP or Sendmail client
 // based on the current app settings.
 func (app *BaseApp) NewMailClient() mailer.Mailer {
@@ -497,7 +595,7 @@ func (app *BaseApp) NewMailClient() mailer.Mailer {
 			Host:       app.Settings().Smtp.Host,
 			Port:       app.Settings().Smtp.Port,
 			Username:   app.Settings().Smtp.Username,
-			Password:   app.Settings().Smtp.Password,
+			Password:   app.ResolveSecret(app.Settings().Smtp.Password),
 			Tls:        app.Settings().Smtp.Tls,
 			AuthMethod: app.Settings().Smtp.AuthMethod,
 			LocalName:  app.Settings().Smtp.LocalName,
@@ -519,8 +617,8 @@ func (app *BaseApp) NewFilesystem() (*filesystem.System, error) {
 			app.settings.S3.Bucket,
 			app.settings.S3.Region,
 			app.settings.S3.Endpoint,
-			app.settings.S3.AccessKey,
-			app.settings.S3.Secret,
+			app.ResolveSecret(app.settings.S3.AccessKey),
+			app.ResolveSecret(app.settings.S3.Secret),
 			app.settings.S3.ForcePathStyle,
 		)
 	}
@@ -540,8 +638,8 @@ func (app *BaseApp) NewBackupsFilesystem() (*filesystem.System, error) {
 			app.settings.Backups.S3.Bucket,
 			app.settings.Backups.S3.Region,
 			app.settings.Backups.S3.Endpoint,
-			app.settings.Backups.S3.AccessKey,
-			app.settings.Backups.S3.Secret,
+			app.ResolveSecret(app.settings.Backups.S3.AccessKey),
+			app.ResolveSecret(app.settings.Backups.S3.Secret),
 			app.settings.Backups.S3.ForcePathStyle,
 		)
 	}
@@ -582,16 +680,20 @@ func (app *BaseApp) RefreshSettings() error {
 		app.settings = settings.New()
 	}
 
-	encryptionKey := os.Getenv(app.EncryptionEnv())
+	backends := append([]secrets.Backend{app.secretsBackend}, app.prevSecretsBackends...)
 
-	storedSettings, err := app.Dao().FindSettings(encryptionKey)
+	storedSettings, storedBackend, err := app.Dao().FindSettingsWithBackends(
+		context.Background(),
+		os.Getenv(app.EncryptionEnv()),
+		backends...,
+	)
 	if err != nil && !errors.Is(err, sql.ErrNoRows) {
 		return err
 	}
 
 	// no settings were previously stored
 	if storedSettings == nil {
-		return app.Dao().SaveSettings(app.settings, encryptionKey)
+		return app.saveSettings(app.settings)
 	}
 
 	// load the settings from the stored param into the app ones
@@ -599,6 +701,17 @@ func (app *BaseApp) RefreshSettings() error {
 		return err
 	}
 
+	// the secrets backend has changed -> re-encrypt the stored settings
+	var activeBackend string
+	if app.secretsBackend != nil {
+		activeBackend = app.secretsBackend.Name()
+	}
+	if storedBackend != activeBackend {
+		if err := app.saveSettings(app.settings); err != nil {
+			return fmt.Errorf("failed to re-encrypt the app settings: %w", err)
+		}
+	}
+
 	// reload handler level (if initialized and not in dev mode)
 	if !app.IsDev() && app.Logger() != nil {
 		if h, ok := app.Logger().Handler().(*logger.BatchHandler); ok {
@@ -606,9 +719,29 @@ func (app *BaseApp) RefreshSettings() error {
 		}
 	}
 
+	search.SetStrictMode(app.settings.FilterStrictMode.Enabled, func(filter string, reason string) {
+		if logger := app.Logger(); logger != nil {
+			logger.Warn(
+				"Suspicious filter expression",
+				slog.String("filter", filter),
+				slog.String("reason", reason),
+			)
+		}
+	})
+
 	return nil
 }
 
+// saveSettings persists the provided settings encrypted with the
+// active secrets backend (or with the EncryptionEnv key if not set).
+func (app *BaseApp) saveSettings(s *settings.Settings) error {
+	if app.secretsBackend != nil {
+		return app.Dao().SaveSettingsWithBackend(context.Background(), s, app.secretsBackend)
+	}
+
+	return app.Dao().SaveSettings(s, os.Getenv(app.EncryptionEnv()))
+}
+
 // -------------------------------------------------------------------
 // App event hooks
 // -------------------------------------------------------------------
@@ -665,6 +798,18 @@ func (app *BaseApp) OnModelAfterDelete(tags ...string) *hook.TaggedHook[*ModelEv
 	return hook.NewTaggedHook(app.onModelAfterDelete, tags...)
 }
 
+func (app *BaseApp) OnRecordAfterAnyWrite(tags ...string) *hook.TaggedHook[*RecordWriteEvent] {
+	return hook.NewTaggedHook(app.onRecordAfterAnyWrite, tags...)
+}
+
+// -------------------------------------------------------------------
+// Security event hooks
+// -------------------------------------------------------------------
+
+func (app *BaseApp) OnSecurityEvent() *hook.Hook[*SecurityEvent] {
+	return app.onSecurityEvent
+}
+
 // -------------------------------------------------------------------
 // Mailer event hooks
 // -------------------------------------------------------------------
@@ -701,6 +846,14 @@ func (app *BaseApp) OnMailerAfterRecordChangeEmailSend(tags ...string) *hook.Tag
 	return hook.NewTaggedHook(app.onMailerAfterRecordChangeEmailSend, tags...)
 }
 
+func (app *BaseApp) OnMailerBeforeRecordOtpSend(tags ...string) *hook.TaggedHook[*MailerRecordEvent] {
+	return hook.NewTaggedHook(app.onMailerBeforeRecordOtpSend, tags...)
+}
+
+func (app *BaseApp) OnMailerAfterRecordOtpSend(tags ...string) *hook.TaggedHook[*MailerRecordEvent] {
+	return hook.NewTaggedHook(app.onMailerAfterRecordOtpSend, tags...)
+}
+
 // -------------------------------------------------------------------
 // Realtime API event hooks
 // -------------------------------------------------------------------
@@ -801,6 +954,10 @@ func (app *BaseApp) OnAdminAuthRequest() *hook.Hook[*AdminAuthEvent] {
 	return app.onAdminAuthRequest
 }
 
+func (app *BaseApp) OnAdminAuthTokenIssue() *hook.Hook[*AdminAuthTokenIssueEvent] {
+	return app.onAdminAuthTokenIssue
+}
+
 func (app *BaseApp) OnAdminBeforeAuthWithPasswordRequest() *hook.Hook[*AdminAuthWithPasswordEvent] {
 	return app.onAdminBeforeAuthWithPasswordRequest
 }
@@ -841,6 +998,10 @@ func (app *BaseApp) OnRecordAuthRequest(tags ...string) *hook.TaggedHook[*Record
 	return hook.NewTaggedHook(app.onRecordAuthRequest, tags...)
 }
 
+func (app *BaseApp) OnRecordAuthTokenIssue(tags ...string) *hook.TaggedHook[*RecordAuthTokenIssueEvent] {
+	return hook.NewTaggedHook(app.onRecordAuthTokenIssue, tags...)
+}
+
 func (app *BaseApp) OnRecordBeforeAuthWithPasswordRequest(tags ...string) *hook.TaggedHook[*RecordAuthWithPasswordEvent] {
 	return hook.NewTaggedHook(app.onRecordBeforeAuthWithPasswordRequest, tags...)
 }
@@ -961,6 +1122,10 @@ func (app *BaseApp) OnRecordAfterDeleteRequest(tags ...string) *hook.TaggedHook[
 	return hook.NewTaggedHook(app.onRecordAfterDeleteRequest, tags...)
 }
 
+func (app *BaseApp) OnRecordSyncConflict(tags ...string) *hook.TaggedHook[*RecordSyncConflictEvent] {
+	return hook.NewTaggedHook(app.onRecordSyncConflict, tags...)
+}
+
 // -------------------------------------------------------------------
 // Collection API event hooks
 // -------------------------------------------------------------------
@@ -1100,7 +1265,11 @@ func (app *BaseApp) createDaoWithHooks(concurrentDB, nonconcurrentDB dbx.Builder
 		e.Dao = eventDao
 		e.Model = m
 
-		return app.OnModelAfterCreate().Trigger(e)
+		if err := app.OnModelAfterCreate().Trigger(e); err != nil {
+			return err
+		}
+
+		return app.triggerRecordAfterAnyWrite(eventDao, m, RecordWriteActionCreate)
 	}
 
 	dao.BeforeUpdateFunc = func(eventDao *daos.Dao, m models.Model, action func() error) error {
@@ -1118,7 +1287,11 @@ func (app *BaseApp) createDaoWithHooks(concurrentDB, nonconcurrentDB dbx.Builder
 		e.Dao = eventDao
 		e.Model = m
 
-		return app.OnModelAfterUpdate().Trigger(e)
+		if err := app.OnModelAfterUpdate().Trigger(e); err != nil {
+			return err
+		}
+
+		return app.triggerRecordAfterAnyWrite(eventDao, m, RecordWriteActionUpdate)
 	}
 
 	dao.BeforeDeleteFunc = func(eventDao *daos.Dao, m models.Model, action func() error) error {
@@ -1136,12 +1309,35 @@ func (app *BaseApp) createDaoWithHooks(concurrentDB, nonconcurrentDB dbx.Builder
 		e.Dao = eventDao
 		e.Model = m
 
-		return app.OnModelAfterDelete().Trigger(e)
+		if err := app.OnModelAfterDelete().Trigger(e); err != nil {
+			return err
+		}
+
+		return app.triggerRecordAfterAnyWrite(eventDao, m, RecordWriteActionDelete)
 	}
 
+	dao.DecryptRecordsFunc = app.decryptTenantRecords
+
 	return dao
 }
 
+// triggerRecordAfterAnyWrite triggers the OnRecordAfterAnyWrite hook
+// if the provided model is a Record.
+func (app *BaseApp) triggerRecordAfterAnyWrite(eventDao *daos.Dao, m models.Model, action string) error {
+	record, ok := m.(*models.Record)
+	if !ok {
+		return nil
+	}
+
+	e := new(RecordWriteEvent)
+	e.Dao = eventDao
+	e.Collection = record.Collection()
+	e.Record = record
+	e.Action = action
+
+	return app.OnRecordAfterAnyWrite().Trigger(e)
+}
+
 func (app *BaseApp) registerDefaultHooks() {
 	deletePrefix := func(prefix string) error {
 		fs, err := app.NewFilesystem()
@@ -1159,7 +1355,7 @@ func (app *BaseApp) registerDefaultHooks() {
 	}
 
 	// try to delete the storage files from deleted Collection, Records, etc. model
-	app.OnModelAfterDelete().Add(func(e *ModelEvent) error {
+	app.OnModelAfterDelete().AddWithOptions(func(e *ModelEvent) error {
 		if m, ok := e.Model.(models.FilesManager); ok && m.BaseFilesPath() != "" {
 			prefix := m.BaseFilesPath()
 
@@ -1177,11 +1373,45 @@ func (app *BaseApp) registerDefaultHooks() {
 		}
 
 		return nil
-	})
+	}, hook.HandlerOptions{Id: DefaultHookIdDeleteStorageFiles})
 
 	if err := app.initAutobackupHooks(); err != nil {
 		app.Logger().Error("Failed to init auto backup hooks", slog.String("error", err.Error()))
 	}
+
+	if err := app.initEventBridgeHooks(); err != nil {
+		app.Logger().Error("Failed to init event bridge hooks", slog.String("error", err.Error()))
+	}
+
+	if err := app.initNotificationsHooks(); err != nil {
+		app.Logger().Error("Failed to init notifications hooks", slog.String("error", err.Error()))
+	}
+
+	if err := app.initDigestHooks(); err != nil {
+		app.Logger().Error("Failed to init digest hooks", slog.String("error", err.Error()))
+	}
+
+	if err := app.initRecordSchedulesHooks(); err != nil {
+		app.Logger().Error("Failed to init record schedules hooks", slog.String("error", err.Error()))
+	}
+
+	if err := app.initSecurityEventsHooks(); err != nil {
+		app.Logger().Error("Failed to init security events hooks", slog.String("error", err.Error()))
+	}
+
+	if err := app.initRecordsFullTextHooks(); err != nil {
+		app.Logger().Error("Failed to init records full-text hooks", slog.String("error", err.Error()))
+	}
+
+	if err := app.initStatsHooks(); err != nil {
+		app.Logger().Error("Failed to init stats hooks", slog.String("error", err.Error()))
+	}
+
+	if err := app.initRecordHistoryHooks(); err != nil {
+		app.Logger().Error("Failed to init record history hooks", slog.String("error", err.Error()))
+	}
+
+	app.initTenantEncryptionHooks()
 }
 
 func (app *BaseApp) initLogger() error {
@@ -1252,7 +1482,7 @@ func (app *BaseApp) initLogger() error {
 			lastLogsDeletedAt := cast.ToTime(app.Store().Get("lastLogsDeletedAt"))
 			daysDiff := now.Sub(lastLogsDeletedAt).Hours() * 24
 			if daysDiff > float64(logsMaxDays) {
-				deleteErr := app.LogsDao().DeleteOldLogs(now.AddDate(0, 0, -1*logsMaxDays))
+				deleteErr := app.LogsDao().DeleteExpiredLogs(app.Settings().Logs, now)
 				if deleteErr == nil {
 					app.Store().Set("lastLogsDeletedAt", now)
 				} else {
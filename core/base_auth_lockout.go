@@ -0,0 +1,165 @@
+package core
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AuthLockout describes a single locked identity or ip.
+type AuthLockout struct {
+	Key         string    `json:"key"`
+	Attempts    int       `json:"attempts"`
+	LockedUntil time.Time `json:"lockedUntil"`
+}
+
+// AuthLockouts keeps the in-memory state of the failed password auth
+// attempts used for the account lockout (see [settings.LockoutConfig]).
+//
+// Note that the state is not persisted, aka. all lockouts are cleared on app restart.
+type AuthLockouts struct {
+	app *BaseApp
+
+	mux sync.Mutex
+
+	// key -> failed auth attempts times
+	failures map[string][]time.Time
+}
+
+// AuthLockoutIdentityKey returns the lockout key of the provided
+// identity (eg. email or username) within the specified scope
+// (eg. "_admins" or the auth collection id).
+func AuthLockoutIdentityKey(scope string, identity string) string {
+	return "identity:" + scope + ":" + strings.ToLower(identity)
+}
+
+// AuthLockoutIpKey returns the lockout key of the provided ip.
+func AuthLockoutIpKey(ip string) string {
+	return "ip:" + ip
+}
+
+// IsLocked reports whether at least one of the provided keys has
+// reached the max allowed failed attempts within the lockout window.
+//
+// Always returns false if the lockout is disabled.
+func (l *AuthLockouts) IsLocked(keys ...string) bool {
+	config := l.app.Settings().Lockout
+	if !config.Enabled {
+		return false
+	}
+
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	window := time.Duration(config.Duration) * time.Second
+
+	for _, key := range keys {
+		if len(l.recentFailures(key, window)) >= config.MaxAttempts {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RegisterFailure registers a new failed auth attempt for each of the provided keys.
+//
+// It is no-op if the lockout is disabled.
+func (l *AuthLockouts) RegisterFailure(keys ...string) {
+	config := l.app.Settings().Lockout
+	if !config.Enabled {
+		return
+	}
+
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	window := time.Duration(config.Duration) * time.Second
+
+	now := time.Now()
+
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+		l.failures[key] = append(l.recentFailures(key, window), now)
+	}
+
+	// prevent the failures map from growing indefinitely
+	if len(l.failures) > 10000 {
+		l.failures = map[string][]time.Time{}
+	}
+}
+
+// Reset clears the failed auth attempts of the provided keys.
+func (l *AuthLockouts) Reset(keys ...string) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	for _, key := range keys {
+		delete(l.failures, key)
+	}
+}
+
+// ResetAll clears the failed auth attempts of all keys.
+func (l *AuthLockouts) ResetAll() {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	l.failures = map[string][]time.Time{}
+}
+
+// List returns all currently locked identities and ips sorted by their key.
+func (l *AuthLockouts) List() []*AuthLockout {
+	config := l.app.Settings().Lockout
+
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	window := time.Duration(config.Duration) * time.Second
+
+	result := []*AuthLockout{}
+
+	for key := range l.failures {
+		failures := l.recentFailures(key, window)
+		if len(failures) == 0 || len(failures) < config.MaxAttempts {
+			continue
+		}
+
+		result = append(result, &AuthLockout{
+			Key:         key,
+			Attempts:    len(failures),
+			LockedUntil: failures[len(failures)-1].Add(window).UTC(),
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Key < result[j].Key
+	})
+
+	return result
+}
+
+// recentFailures returns the failures of the specified key within
+// the provided window (the expired ones are removed from the state).
+//
+// Note that the caller must hold the mutex lock.
+func (l *AuthLockouts) recentFailures(key string, window time.Duration) []time.Time {
+	now := time.Now()
+
+	failures := l.failures[key][:0]
+	for _, t := range l.failures[key] {
+		if now.Sub(t) < window {
+			failures = append(failures, t)
+		}
+	}
+
+	if len(failures) == 0 {
+		delete(l.failures, key)
+	} else {
+		l.failures[key] = failures
+	}
+
+	return failures
+}
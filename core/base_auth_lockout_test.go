@@ -0,0 +1,86 @@
+package core_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestAuthLockoutKeys(t *testing.T) {
+	if key := core.AuthLockoutIdentityKey("_admins", "Test@Example.com"); key != "identity:_admins:test@example.com" {
+		t.Fatalf("Unexpected identity key %q", key)
+	}
+
+	if key := core.AuthLockoutIpKey("127.0.0.1"); key != "ip:127.0.0.1" {
+		t.Fatalf("Unexpected ip key %q", key)
+	}
+}
+
+func TestAuthLockoutsDisabled(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	app.Settings().Lockout.Enabled = false
+	app.Settings().Lockout.MaxAttempts = 1
+
+	lockouts := app.AuthLockouts()
+
+	lockouts.RegisterFailure("test")
+	lockouts.RegisterFailure("test")
+
+	if lockouts.IsLocked("test") {
+		t.Fatal("Expected the key to not be locked while the lockout is disabled")
+	}
+
+	if total := len(lockouts.List()); total != 0 {
+		t.Fatalf("Expected no lockouts, got %d", total)
+	}
+}
+
+func TestAuthLockouts(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	app.Settings().Lockout.Enabled = true
+	app.Settings().Lockout.MaxAttempts = 2
+	app.Settings().Lockout.Duration = 60
+
+	lockouts := app.AuthLockouts()
+
+	lockouts.RegisterFailure("a", "b")
+	if lockouts.IsLocked("a") || lockouts.IsLocked("b") {
+		t.Fatal("Expected the keys to not be locked before reaching the max attempts")
+	}
+
+	lockouts.RegisterFailure("a", "c")
+	if !lockouts.IsLocked("a") {
+		t.Fatal("Expected key a to be locked")
+	}
+	if lockouts.IsLocked("b") || lockouts.IsLocked("c") {
+		t.Fatal("Expected keys b and c to not be locked")
+	}
+	if !lockouts.IsLocked("missing", "a") {
+		t.Fatal("Expected to be locked if at least one of the keys is locked")
+	}
+
+	list := lockouts.List()
+	if len(list) != 1 || list[0].Key != "a" || list[0].Attempts != 2 || list[0].LockedUntil.IsZero() {
+		t.Fatalf("Unexpected lockouts list %v", list)
+	}
+
+	lockouts.Reset("a")
+	if lockouts.IsLocked("a") {
+		t.Fatal("Expected key a to be unlocked after reset")
+	}
+
+	lockouts.RegisterFailure("b", "c")
+	if !lockouts.IsLocked("b") || !lockouts.IsLocked("c") {
+		t.Fatal("Expected keys b and c to be locked")
+	}
+
+	lockouts.ResetAll()
+	if total := len(lockouts.List()); total != 0 {
+		t.Fatalf("Expected no lockouts after ResetAll, got %d", total)
+	}
+}
@@ -133,11 +133,10 @@ func (app *BaseApp) CreateBackup(ctx context.Context, name string) error {
 //
 // If a failure occure during the restore process the dir changes are reverted.
 // If for whatever reason the revert is not possible, it panics.
+//
+// Data-only backups (see [BaseApp.CreateDataDump]) are restored directly
+// in the current database within a single transaction and without restarting the app.
 func (app *BaseApp) RestoreBackup(ctx context.Context, name string) error {
-	if runtime.GOOS == "windows" {
-		return errors.New("restore is not supported on windows")
-	}
-
 	if app.Store().Has(StoreKeyActiveBackup) {
 		return errors.New("try again later - another backup/restore operation has already been started")
 	}
@@ -178,6 +177,15 @@ func (app *BaseApp) RestoreBackup(ctx context.Context, name string) error {
 		return err
 	}
 
+	// data-only backups are restored directly in the current db
+	if isDataDumpArchive(tempZip.Name()) {
+		return app.restoreDataDumpArchive(tempZip.Name())
+	}
+
+	if runtime.GOOS == "windows" {
+		return errors.New("restore is not supported on windows")
+	}
+
 	extractedDataDir := filepath.Join(localTempDir, "pb_restore_"+security.PseudorandomString(4))
 	defer os.RemoveAll(extractedDataDir)
 	if err := archive.Extract(tempZip.Name(), extractedDataDir); err != nil {
@@ -0,0 +1,344 @@
+package core
+
+import (
+	"archive/zip"
+	"bufio"
+	"compress/flate"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/filesystem"
+	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// DataDumpManifestName is the name of the manifest file stored in
+// the root of each data dump archive (see [BaseApp.CreateDataDump]).
+const DataDumpManifestName = "pb_data_dump.json"
+
+// dataDumpManifest describes the content of a data dump archive.
+type dataDumpManifest struct {
+	Created     types.DateTime           `json:"created"`
+	Masked      bool                     `json:"masked,omitempty"`
+	Collections []dataDumpManifestRecord `json:"collections"`
+}
+
+type dataDumpManifestRecord struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+	File string `json:"file"`
+}
+
+// CreateDataDump creates a new lightweight data-only backup
+// with the records of the specified collections (or all non-view
+// collections if none are specified).
+//
+// If name is empty, it will be autogenerated.
+// If backup with the same name exists, the new backup file will replace it.
+//
+// The generated zip archive contains only the collection records
+// (one NDJSON file per collection) and a "pb_data_dump.json" manifest,
+// meaning that the app settings, logs and uploaded files are not included.
+//
+// The data dump is stored in the backups filesystem and could be
+// restored with [BaseApp.RestoreBackup] without restarting the app.
+func (app *BaseApp) CreateDataDump(ctx context.Context, name string, collections ...string) error {
+	return app.createDataDump(ctx, name, false, collections)
+}
+
+// CreateMaskedDataDump creates a new data-only backup similar to
+// [BaseApp.CreateDataDump] but with the sensitive record values
+// masked, allowing production data to be safely copied to a
+// staging or development environment.
+//
+// The following masking rules are applied to the dumped rows:
+//   - auth record emails and email fields are replaced with "{id}@example.com"
+//   - auth record usernames are replaced with "u{id}"
+//   - text fields containing "name" in their name are randomized
+//   - text, editor, url and json fields containing "token", "secret"
+//     or "password" in their name are cleared
+//   - auth record password hashes and token keys are regenerated,
+//     invalidating all existing passwords and auth tokens
+func (app *BaseApp) CreateMaskedDataDump(ctx context.Context, name string, collections ...string) error {
+	return app.createDataDump(ctx, name, true, collections)
+}
+
+func (app *BaseApp) createDataDump(ctx context.Context, name string, masked bool, collections []string) error {
+	if app.Store().Has(StoreKeyActiveBackup) {
+		return errors.New("try again later - another backup/restore operation has already been started")
+	}
+
+	if name == "" {
+		name = app.generateBackupName("pb_data_dump_")
+	}
+
+	app.Store().Set(StoreKeyActiveBackup, name)
+	defer app.Store().Remove(StoreKeyActiveBackup)
+
+	// make sure that the special temp directory exists
+	localTempDir := filepath.Join(app.DataDir(), LocalTempDirName)
+	if err := os.MkdirAll(localTempDir, os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create a temp dir: %w", err)
+	}
+
+	tempPath := filepath.Join(localTempDir, "pb_data_dump_"+security.PseudorandomString(4))
+	defer os.Remove(tempPath)
+
+	// run in transaction to ensure that all collections are dumped from the same db state
+	createErr := app.Dao().RunInTransaction(func(txDao *daos.Dao) error {
+		return createDataDumpArchive(txDao, tempPath, collections, masked)
+	})
+	if createErr != nil {
+		return createErr
+	}
+
+	fsys, err := app.NewBackupsFilesystem()
+	if err != nil {
+		return err
+	}
+	defer fsys.Close()
+
+	fsys.SetContext(ctx)
+
+	file, err := filesystem.NewFileFromPath(tempPath)
+	if err != nil {
+		return err
+	}
+	file.OriginalName = name
+	file.Name = file.OriginalName
+
+	return fsys.UploadFile(file, file.Name)
+}
+
+func createDataDumpArchive(dao *daos.Dao, dest string, collectionNames []string, masked bool) error {
+	collections := []*models.Collection{}
+	if err := dao.CollectionQuery().OrderBy("created ASC").All(&collections); err != nil {
+		return err
+	}
+
+	zf, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	defer zf.Close()
+
+	zw := zip.NewWriter(zf)
+	defer zw.Close()
+
+	zw.RegisterCompressor(zip.Deflate, func(out io.Writer) (io.WriteCloser, error) {
+		return flate.NewWriter(out, flate.BestSpeed)
+	})
+
+	manifest := dataDumpManifest{
+		Created:     types.NowDateTime(),
+		Masked:      masked,
+		Collections: []dataDumpManifestRecord{},
+	}
+
+	for _, collection := range collections {
+		if collection.IsView() {
+			continue
+		}
+
+		if len(collectionNames) > 0 &&
+			!list.ExistInSlice(collection.Name, collectionNames) &&
+			!list.ExistInSlice(collection.Id, collectionNames) {
+			continue
+		}
+
+		file := collection.Id + ".jsonl"
+
+		w, err := zw.Create(file)
+		if err != nil {
+			return err
+		}
+
+		if err := dumpCollectionRows(dao, collection, w, masked); err != nil {
+			return fmt.Errorf("failed to dump collection %q: %w", collection.Name, err)
+		}
+
+		manifest.Collections = append(manifest.Collections, dataDumpManifestRecord{
+			Id:   collection.Id,
+			Name: collection.Name,
+			File: file,
+		})
+	}
+
+	w, err := zw.Create(DataDumpManifestName)
+	if err != nil {
+		return err
+	}
+
+	return json.NewEncoder(w).Encode(manifest)
+}
+
+// dumpCollectionRows writes the raw collection table rows
+// in the provided writer as newline delimited json objects.
+//
+// If masked is set, the sensitive row values are masked before writing.
+func dumpCollectionRows(dao *daos.Dao, collection *models.Collection, w io.Writer, masked bool) error {
+	rows, err := dao.DB().Select("*").From(collection.Name).Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	encoder := json.NewEncoder(w)
+
+	for rows.Next() {
+		row := dbx.NullStringMap{}
+		if err := rows.ScanMap(row); err != nil {
+			return err
+		}
+
+		data := make(map[string]any, len(row))
+		for k, v := range row {
+			if v.Valid {
+				data[k] = v.String
+			} else {
+				data[k] = nil
+			}
+		}
+
+		if masked {
+			maskDataDumpRow(collection, data)
+		}
+
+		if err := encoder.Encode(data); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// isDataDumpArchive checks whether the zip archive at the specified path is a data dump.
+func isDataDumpArchive(path string) bool {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return false
+	}
+	defer zr.Close()
+
+	for _, f := range zr.File {
+		if f.Name == DataDumpManifestName {
+			return true
+		}
+	}
+
+	return false
+}
+
+// restoreDataDumpArchive replaces the current records of the dumped
+// collections with the ones from the data dump archive at the specified path.
+//
+// Dumped collections that no longer exist are skipped and
+// dumped columns that are no longer part of the collection table are ignored.
+func (app *BaseApp) restoreDataDumpArchive(path string) error {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	manifest := dataDumpManifest{}
+	if err := readZipJson(files[DataDumpManifestName], &manifest); err != nil {
+		return fmt.Errorf("invalid data dump manifest: %w", err)
+	}
+
+	return app.Dao().RunInTransaction(func(txDao *daos.Dao) error {
+		for _, item := range manifest.Collections {
+			collection, err := txDao.FindCollectionByNameOrId(item.Id)
+			if err != nil {
+				collection, err = txDao.FindCollectionByNameOrId(item.Name)
+			}
+			if err != nil || collection.IsView() {
+				continue // missing or incompatible collection
+			}
+
+			if err := restoreCollectionRows(txDao, collection, files[item.File]); err != nil {
+				return fmt.Errorf("failed to restore collection %q: %w", collection.Name, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func restoreCollectionRows(dao *daos.Dao, collection *models.Collection, file *zip.File) error {
+	if file == nil {
+		return errors.New("missing data file")
+	}
+
+	columns, err := dao.TableColumns(collection.Name)
+	if err != nil {
+		return err
+	}
+
+	if _, err := dao.DB().Delete(collection.Name, nil).Execute(); err != nil {
+		return err
+	}
+
+	r, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 100<<20) // max 100MB per row
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		row := map[string]any{}
+		if err := json.Unmarshal([]byte(line), &row); err != nil {
+			return err
+		}
+
+		params := dbx.Params{}
+		for k, v := range row {
+			if list.ExistInSlice(k, columns) {
+				params[k] = v
+			}
+		}
+
+		if _, err := dao.DB().Insert(collection.Name, params).Execute(); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
+
+func readZipJson(file *zip.File, result any) error {
+	if file == nil {
+		return errors.New("missing file")
+	}
+
+	r, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	return json.NewDecoder(r).Decode(result)
+}
@@ -0,0 +1,75 @@
+package core
+
+import (
+	"strings"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+const maskedNameAlphabet = "abcdefghijklmnopqrstuvwxyz"
+
+// maskedSecretKeywords lists the field name keywords
+// whose values are cleared in a masked data dump.
+var maskedSecretKeywords = []string{"token", "secret", "password"}
+
+// maskDataDumpRow replaces in place the sensitive values of the
+// provided raw collection row (see [BaseApp.CreateMaskedDataDump]).
+func maskDataDumpRow(collection *models.Collection, row map[string]any) {
+	id, _ := row[schema.FieldNameId].(string)
+
+	if collection.IsAuth() {
+		if v, _ := row[schema.FieldNameEmail].(string); v != "" {
+			row[schema.FieldNameEmail] = maskedEmail(id)
+		}
+		row[schema.FieldNameUsername] = "u" + id
+		row[schema.FieldNamePasswordHash] = security.RandomString(60)
+		row[schema.FieldNameTokenKey] = security.RandomString(50)
+	}
+
+	for _, field := range collection.Schema.Fields() {
+		v, ok := row[field.Name]
+		if !ok || v == nil || v == "" {
+			continue
+		}
+
+		name := strings.ToLower(field.Name)
+
+		switch field.Type {
+		case schema.FieldTypeEmail:
+			row[field.Name] = maskedEmail(id)
+			continue
+		case schema.FieldTypeText:
+			if strings.Contains(name, "name") {
+				row[field.Name] = security.PseudorandomStringWithAlphabet(10, maskedNameAlphabet)
+				continue
+			}
+		}
+
+		if !isMaskedSecretField(name) {
+			continue
+		}
+
+		switch field.Type {
+		case schema.FieldTypeText, schema.FieldTypeEditor, schema.FieldTypeUrl:
+			row[field.Name] = ""
+		case schema.FieldTypeJson:
+			row[field.Name] = nil
+		}
+	}
+}
+
+func isMaskedSecretField(name string) bool {
+	for _, keyword := range maskedSecretKeywords {
+		if strings.Contains(name, keyword) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func maskedEmail(id string) string {
+	return id + "@example.com"
+}
@@ -0,0 +1,164 @@
+package core_test
+
+import (
+	"archive/zip"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestCreateAndRestoreDataDump(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	// test pending error
+	app.Store().Set(core.StoreKeyActiveBackup, "")
+	if err := app.CreateDataDump(context.Background(), "test.zip"); err == nil {
+		t.Fatal("Expected pending error, got nil")
+	}
+	app.Store().Remove(core.StoreKeyActiveBackup)
+
+	if err := app.CreateDataDump(context.Background(), "dump.zip", "demo2", "view1"); err != nil {
+		t.Fatal(err)
+	}
+
+	// check the archive content
+	// ---
+	zr, err := zip.OpenReader(filepath.Join(app.DataDir(), core.LocalBackupsDirName, "dump.zip"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names := make([]string, 0, len(zr.File))
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+	zr.Close()
+
+	demo2, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedNames := []string{demo2.Id + ".jsonl", core.DataDumpManifestName}
+	if len(names) != len(expectedNames) {
+		t.Fatalf("Expected archive files %v, got %v", expectedNames, names)
+	}
+	for i, name := range expectedNames {
+		if names[i] != name {
+			t.Fatalf("Expected archive files %v, got %v", expectedNames, names)
+		}
+	}
+
+	// change the db state
+	// ---
+	record, err := app.Dao().FindRecordById("demo2", "llvuca81nly1qls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	record.Set("title", "changed")
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	toDelete, err := app.Dao().FindRecordById("demo2", "achvryl401bhse3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := app.Dao().DeleteRecord(toDelete); err != nil {
+		t.Fatal(err)
+	}
+
+	// restore and verify
+	// ---
+	if err := app.RestoreBackup(context.Background(), "dump.zip"); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := app.Dao().FindRecordsByExpr("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("Expected 3 restored records, got %d", len(records))
+	}
+
+	restored, err := app.Dao().FindRecordById("demo2", "llvuca81nly1qls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if title := restored.GetString("title"); title != "test1" {
+		t.Fatalf("Expected the restored title to be %q, got %q", "test1", title)
+	}
+	if restored.GetBool("active") {
+		t.Fatal("Expected the restored active field to be false")
+	}
+}
+
+func TestCreateAndRestoreMaskedDataDump(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	original, err := app.Dao().FindRecordById("users", "4q1xlclmfloku33")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.CreateMaskedDataDump(context.Background(), "masked.zip", "users"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.RestoreBackup(context.Background(), "masked.zip"); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := app.Dao().FindRecordsByExpr("users")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("Expected 3 restored records, got %d", len(records))
+	}
+
+	record, err := app.Dao().FindRecordById("users", original.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if email := record.Email(); email != original.Id+"@example.com" {
+		t.Fatalf("Expected masked email, got %q", email)
+	}
+
+	if username := record.Username(); username != "u"+original.Id {
+		t.Fatalf("Expected masked username, got %q", username)
+	}
+
+	if name := record.GetString("name"); name == "" || name == original.GetString("name") {
+		t.Fatalf("Expected randomized name, got %q", name)
+	}
+
+	if record.TokenKey() == original.TokenKey() {
+		t.Fatal("Expected the token key to be regenerated")
+	}
+
+	if record.ValidatePassword("1234567890") {
+		t.Fatal("Expected the original password to be no longer valid")
+	}
+
+	// unmasked fields should remain unchanged
+	if record.Verified() != original.Verified() {
+		t.Fatalf("Expected verified %v, got %v", original.Verified(), record.Verified())
+	}
+
+	// empty values should remain empty
+	empty, err := app.Dao().FindRecordById("users", "oap640cot4yru2s")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name := empty.GetString("name"); name != "" {
+		t.Fatalf("Expected the empty name to remain empty, got %q", name)
+	}
+}
@@ -0,0 +1,301 @@
+package core
+
+import (
+	"fmt"
+	"html"
+	"log/slog"
+	"net/mail"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/cron"
+	"github.com/pocketbase/pocketbase/tools/mailer"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// DigestPeriod is the time period covered by the admins digest.
+const DigestPeriod = 24 * time.Hour
+
+// DigestMassDelete defines a single collection mass records delete digest entry.
+type DigestMassDelete struct {
+	CollectionName string `db:"collectionName" json:"collectionName"`
+	Total          int    `db:"total" json:"total"`
+}
+
+// Digest defines the admins activity summary of a single digest period.
+type Digest struct {
+	From types.DateTime `json:"from"`
+	To   types.DateTime `json:"to"`
+
+	// SchemaChanges and SettingsChanges are the collections and
+	// settings changes audit log entries (oldest first).
+	SchemaChanges   []*models.AuditLog `json:"schemaChanges"`
+	SettingsChanges []*models.AuditLog `json:"settingsChanges"`
+
+	// MassDeletes lists the collections with deleted records
+	// count above the configured threshold.
+	MassDeletes []DigestMassDelete `json:"massDeletes"`
+
+	// FailedLogins is the number of the failed logins
+	// (0 if it is below the configured threshold).
+	FailedLogins int `json:"failedLogins"`
+}
+
+// IsEmpty reports whether there is nothing to report in the digest.
+func (d *Digest) IsEmpty() bool {
+	return len(d.SchemaChanges) == 0 &&
+		len(d.SettingsChanges) == 0 &&
+		len(d.MassDeletes) == 0 &&
+		d.FailedLogins == 0
+}
+
+// BuildDigest collects the admins digest entries for the DigestPeriod
+// ending now, based on the audit logs and the request logs.
+//
+// Note that the collections and settings changes and the mass deletes
+// are available only if the audit logs are enabled.
+func BuildDigest(app App) (*Digest, error) {
+	config := app.Settings().Digest
+
+	now := time.Now()
+
+	digest := &Digest{
+		SchemaChanges:   []*models.AuditLog{},
+		SettingsChanges: []*models.AuditLog{},
+		MassDeletes:     []DigestMassDelete{},
+	}
+	digest.From, _ = types.ParseDateTime(now.Add(-DigestPeriod))
+	digest.To, _ = types.ParseDateTime(now)
+
+	periodExpr := dbx.NewExp("[[created]] >= {:from}", dbx.Params{"from": digest.From.String()})
+
+	err := app.LogsDao().AuditLogQuery().
+		AndWhere(periodExpr).
+		AndWhere(dbx.In(
+			"action",
+			models.AuditActionCollectionCreate,
+			models.AuditActionCollectionUpdate,
+			models.AuditActionCollectionDelete,
+		)).
+		OrderBy("created ASC", "rowid ASC").
+		All(&digest.SchemaChanges)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the collections changes: %w", err)
+	}
+
+	err = app.LogsDao().AuditLogQuery().
+		AndWhere(periodExpr).
+		AndWhere(dbx.HashExp{"action": models.AuditActionSettingsUpdate}).
+		OrderBy("created ASC", "rowid ASC").
+		All(&digest.SettingsChanges)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the settings changes: %w", err)
+	}
+
+	if config.MassDeleteThreshold > 0 {
+		err = app.LogsDao().AuditLogQuery().
+			Select("collectionName", "count(*) as total").
+			AndWhere(periodExpr).
+			AndWhere(dbx.HashExp{"action": models.AuditActionDelete}).
+			GroupBy("collectionName").
+			Having(dbx.NewExp("count(*) >= {:threshold}", dbx.Params{"threshold": config.MassDeleteThreshold})).
+			OrderBy("total DESC", "collectionName ASC").
+			All(&digest.MassDeletes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the mass deletes: %w", err)
+		}
+	}
+
+	if config.FailedLoginsThreshold > 0 {
+		failedLogins, err := failedLoginsMetric(app, DigestPeriod)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count the failed logins: %w", err)
+		}
+
+		if int(failedLogins) >= config.FailedLoginsThreshold {
+			digest.FailedLogins = int(failedLogins)
+		}
+	}
+
+	return digest, nil
+}
+
+// SendDigest builds and emails the admins digest
+// using the provided app mail client.
+//
+// The email is not sent if there is nothing to report.
+func SendDigest(app App) error {
+	if !app.Settings().Digest.Enabled {
+		return nil
+	}
+
+	digest, err := BuildDigest(app)
+	if err != nil {
+		return err
+	}
+
+	if digest.IsEmpty() {
+		return nil
+	}
+
+	admins := []*models.Admin{}
+	if err := app.Dao().AdminQuery().All(&admins); err != nil {
+		return err
+	}
+
+	if len(admins) == 0 {
+		return nil
+	}
+
+	to := make([]mail.Address, len(admins))
+	for i, admin := range admins {
+		to[i] = mail.Address{Address: admin.Email}
+	}
+
+	appName := app.Settings().Meta.AppName
+
+	return app.NewMailClient().Send(&mailer.Message{
+		From: mail.Address{
+			Name:    app.Settings().Meta.SenderName,
+			Address: app.Settings().Meta.SenderAddress,
+		},
+		To:      to,
+		Subject: fmt.Sprintf("[%s] Daily digest", appName),
+		HTML:    digestEmailBody(appName, app.Settings().Meta.BaseUrl(), digest),
+	})
+}
+
+func digestEmailBody(appName string, appUrl string, digest *Digest) string {
+	var b strings.Builder
+
+	fmt.Fprintf(
+		&b,
+		"<p>Activity summary of %s between %s and %s UTC.</p>",
+		html.EscapeString(appName),
+		digest.From.Time().Format("2006-01-02 15:04"),
+		digest.To.Time().Format("2006-01-02 15:04"),
+	)
+
+	if len(digest.SchemaChanges) > 0 {
+		b.WriteString("<h3>Collections changes</h3><ul>")
+		for _, log := range digest.SchemaChanges {
+			fmt.Fprintf(
+				&b,
+				"<li>%s <strong>%s</strong>%s (%s)</li>",
+				strings.TrimPrefix(log.Action, "collection"),
+				html.EscapeString(log.CollectionName),
+				digestChangedFields(log),
+				digestActor(log),
+			)
+		}
+		b.WriteString("</ul>")
+	}
+
+	if len(digest.SettingsChanges) > 0 {
+		b.WriteString("<h3>Settings changes</h3><ul>")
+		for _, log := range digest.SettingsChanges {
+			fmt.Fprintf(&b, "<li>Update%s (%s)</li>", digestChangedFields(log), digestActor(log))
+		}
+		b.WriteString("</ul>")
+	}
+
+	if len(digest.MassDeletes) > 0 || digest.FailedLogins > 0 {
+		b.WriteString("<h3>Unusual activity</h3><ul>")
+		for _, item := range digest.MassDeletes {
+			fmt.Fprintf(
+				&b,
+				"<li><strong>%d</strong> deleted records from <strong>%s</strong></li>",
+				item.Total,
+				html.EscapeString(item.CollectionName),
+			)
+		}
+		if digest.FailedLogins > 0 {
+			fmt.Fprintf(&b, "<li><strong>%d</strong> failed logins</li>", digest.FailedLogins)
+		}
+		b.WriteString("</ul>")
+	}
+
+	fmt.Fprintf(&b, "<p><a href=\"%s\">%s</a></p>", html.EscapeString(appUrl), html.EscapeString(appUrl))
+
+	return b.String()
+}
+
+// digestChangedFields returns the sorted changed fields of an update audit log entry.
+func digestChangedFields(log *models.AuditLog) string {
+	if len(log.After) == 0 || len(log.Before) == 0 {
+		return ""
+	}
+
+	fields := make([]string, 0, len(log.After))
+	for field := range log.After {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	return " of " + html.EscapeString(strings.Join(fields, ", "))
+}
+
+func digestActor(log *models.AuditLog) string {
+	actor := log.ActorType
+	if log.ActorId != "" {
+		actor += " " + log.ActorId
+	}
+
+	return fmt.Sprintf("%s by %s", log.Created.Time().Format("15:04"), html.EscapeString(actor))
+}
+
+// initDigestHooks registers the admins digest cron app hooks.
+func (app *BaseApp) initDigestHooks() error {
+	c := cron.New()
+	isServe := false
+
+	loadJob := func() {
+		c.Stop()
+
+		config := app.Settings().Digest
+		if !config.Enabled || config.Cron == "" || !isServe || !app.IsBootstrapped() {
+			return
+		}
+
+		c.Add("@digest", config.Cron, func() {
+			if err := SendDigest(app); err != nil {
+				app.Logger().Error(
+					"[Digest cron] Failed to send the admins digest",
+					slog.String("error", err.Error()),
+				)
+			}
+		})
+
+		// restart the ticker
+		c.Start()
+	}
+
+	// load on app serve
+	app.OnBeforeServe().Add(func(e *ServeEvent) error {
+		isServe = true
+		loadJob()
+		return nil
+	})
+
+	// stop the ticker on app termination
+	app.OnTerminate().Add(func(e *TerminateEvent) error {
+		c.Stop()
+		return nil
+	})
+
+	// reload on app settings change
+	app.OnModelAfterUpdate((&models.Param{}).TableName()).Add(func(e *ModelEvent) error {
+		if !isAppSettingsParam(e.Model) {
+			return nil
+		}
+
+		loadJob()
+
+		return nil
+	})
+
+	return nil
+}
@@ -0,0 +1,175 @@
+package core_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestBuildDigest(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	app.Settings().Digest.MassDeleteThreshold = 3
+	app.Settings().Digest.FailedLoginsThreshold = 2
+
+	entries := []*models.AuditLog{
+		{
+			Action:         models.AuditActionCollectionUpdate,
+			ActorType:      models.AuditActorAdmin,
+			ActorId:        "sywbhecnh46rhm0",
+			CollectionId:   "wsmn24bux7wo113",
+			CollectionName: "demo1",
+			Before:         types.JsonMap{"listRule": nil},
+			After:          types.JsonMap{"listRule": ""},
+		},
+		{
+			Action:    models.AuditActionSettingsUpdate,
+			ActorType: models.AuditActorAdmin,
+			ActorId:   "sywbhecnh46rhm0",
+			Before:    types.JsonMap{"meta": map[string]any{"appName": "old"}},
+			After:     types.JsonMap{"meta": map[string]any{"appName": "new"}},
+		},
+	}
+
+	// mass deletes (demo2 is below the threshold)
+	for i := 0; i < 3; i++ {
+		entries = append(entries, &models.AuditLog{
+			Action:         models.AuditActionDelete,
+			ActorType:      models.AuditActorGuest,
+			CollectionName: "demo1",
+		})
+	}
+	entries = append(entries, &models.AuditLog{
+		Action:         models.AuditActionDelete,
+		ActorType:      models.AuditActorGuest,
+		CollectionName: "demo2",
+	})
+
+	// old entry outside of the digest period
+	old := &models.AuditLog{
+		Action:         models.AuditActionCollectionDelete,
+		ActorType:      models.AuditActorAdmin,
+		CollectionName: "old",
+	}
+	old.Created, _ = types.ParseDateTime("2022-01-01 00:00:00.000Z")
+	old.Updated = old.Created
+	entries = append(entries, old)
+
+	for _, entry := range entries {
+		if err := app.LogsDao().Save(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// mock failed login request logs
+	for _, status := range []int{400, 400, 200} {
+		log := &models.Log{
+			Message: "POST /api/collections/users/auth-with-password",
+			Data: types.JsonMap{
+				"type":   "request",
+				"url":    "/api/collections/users/auth-with-password",
+				"status": status,
+			},
+		}
+		if err := app.LogsDao().SaveLog(log); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	digest, err := core.BuildDigest(app)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(digest.SchemaChanges) != 1 || digest.SchemaChanges[0].CollectionName != "demo1" {
+		t.Fatalf("Expected only the demo1 collection change, got %v", digest.SchemaChanges)
+	}
+
+	if len(digest.SettingsChanges) != 1 {
+		t.Fatalf("Expected 1 settings change, got %d", len(digest.SettingsChanges))
+	}
+
+	if len(digest.MassDeletes) != 1 || digest.MassDeletes[0].CollectionName != "demo1" || digest.MassDeletes[0].Total != 3 {
+		t.Fatalf("Expected only the demo1 mass delete, got %v", digest.MassDeletes)
+	}
+
+	if digest.FailedLogins != 2 {
+		t.Fatalf("Expected 2 failed logins, got %d", digest.FailedLogins)
+	}
+
+	if digest.IsEmpty() {
+		t.Fatal("Expected non-empty digest")
+	}
+
+	// below the failed logins threshold
+	app.Settings().Digest.FailedLoginsThreshold = 3
+	digest, err = core.BuildDigest(app)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if digest.FailedLogins != 0 {
+		t.Fatalf("Expected the failed logins to not be reported, got %d", digest.FailedLogins)
+	}
+}
+
+func TestSendDigest(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	entry := &models.AuditLog{
+		Action:         models.AuditActionCollectionCreate,
+		ActorType:      models.AuditActorAdmin,
+		ActorId:        "sywbhecnh46rhm0",
+		CollectionId:   "test_id",
+		CollectionName: "<b>test</b>",
+		After:          types.JsonMap{"name": "<b>test</b>"},
+	}
+	if err := app.LogsDao().Save(entry); err != nil {
+		t.Fatal(err)
+	}
+
+	// disabled
+	if err := core.SendDigest(app); err != nil {
+		t.Fatal(err)
+	}
+	if app.TestMailer.TotalSend != 0 {
+		t.Fatalf("Expected no emails, got %d", app.TestMailer.TotalSend)
+	}
+
+	app.Settings().Digest.Enabled = true
+
+	if err := core.SendDigest(app); err != nil {
+		t.Fatal(err)
+	}
+
+	if app.TestMailer.TotalSend != 1 {
+		t.Fatalf("Expected 1 email, got %d", app.TestMailer.TotalSend)
+	}
+
+	if total := len(app.TestMailer.LastMessage.To); total != 3 {
+		t.Fatalf("Expected the email to be sent to all 3 admins, got %d", total)
+	}
+
+	body := app.TestMailer.LastMessage.HTML
+	if !strings.Contains(body, "Create <strong>&lt;b&gt;test&lt;/b&gt;</strong>") {
+		t.Fatalf("Expected the escaped collection change in the email body, got %s", body)
+	}
+
+	// nothing to report
+	if err := app.LogsDao().DeleteOldAuditLogs(entry.Created.Time().Add(1)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := core.SendDigest(app); err != nil {
+		t.Fatal(err)
+	}
+
+	if app.TestMailer.TotalSend != 1 {
+		t.Fatalf("Expected no new emails, got %d", app.TestMailer.TotalSend)
+	}
+}
@@ -0,0 +1,208 @@
+package core
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/pocketbase/pocketbase/tools/eventbridge"
+	"github.com/pocketbase/pocketbase/tools/routine"
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+// eventBridge publishes the app record events to the
+// external message queues configured in the app settings.
+type eventBridge struct {
+	app *BaseApp
+
+	mux         sync.Mutex
+	loaded      bool
+	targets     []settings.EventBridgeTarget
+	dispatchers []*eventbridge.Dispatcher
+}
+
+func (app *BaseApp) initEventBridgeHooks() error {
+	bridge := &eventBridge{app: app}
+
+	app.OnModelAfterCreate().Add(func(e *ModelEvent) error {
+		if isAppSettingsParam(e.Model) {
+			bridge.reset(false)
+			return nil
+		}
+
+		bridge.publish(settings.EventBridgeEventCreate, e.Model)
+		return nil
+	})
+
+	app.OnModelAfterUpdate().Add(func(e *ModelEvent) error {
+		// reload the dispatchers on app settings change
+		if isAppSettingsParam(e.Model) {
+			bridge.reset(false)
+			return nil
+		}
+
+		bridge.publish(settings.EventBridgeEventUpdate, e.Model)
+		return nil
+	})
+
+	app.OnModelAfterDelete().Add(func(e *ModelEvent) error {
+		bridge.publish(settings.EventBridgeEventDelete, e.Model)
+		return nil
+	})
+
+	// deliver the queued events on app termination
+	app.OnTerminate().Add(func(e *TerminateEvent) error {
+		bridge.reset(true)
+		return nil
+	})
+
+	return nil
+}
+
+// publish serializes and queues the provided model event
+// for all matching targets (if the model is a Record).
+func (b *eventBridge) publish(action string, model models.Model) {
+	record, ok := model.(*models.Record)
+	if !ok || record == nil {
+		return
+	}
+
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	if !b.loaded {
+		b.load()
+	}
+
+	if len(b.dispatchers) == 0 {
+		return
+	}
+
+	collection := record.Collection()
+
+	exportRecord := record.CleanCopy()
+	exportRecord.IgnoreEmailVisibility(true)
+
+	event := &eventbridge.Event{
+		Id:           security.RandomString(20),
+		Action:       action,
+		Collection:   collection.Name,
+		CollectionId: collection.Id,
+		RecordId:     record.Id,
+		Record:       exportRecord.PublicExport(),
+		Time:         time.Now().UTC(),
+	}
+
+	for i, target := range b.targets {
+		if !target.HasEvent(collection.Id, collection.Name, action) {
+			continue
+		}
+
+		data, err := event.Marshal(target.Format)
+		if err != nil {
+			b.app.Logger().Error(
+				"[EventBridge] Failed to serialize record event",
+				slog.String("target", target.Name),
+				slog.String("recordId", record.Id),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+
+		b.dispatchers[i].Dispatch(record.Id, data)
+	}
+}
+
+// load initializes the dispatchers for the configured event bridge targets.
+//
+// Note that the caller is responsible for locking the bridge.
+func (b *eventBridge) load() {
+	b.loaded = true
+	b.targets = nil
+	b.dispatchers = nil
+
+	config := b.app.Settings().EventBridge
+	if !config.Enabled {
+		return
+	}
+
+	for _, target := range config.Targets {
+		publisher, err := newEventBridgePublisher(target)
+		if err != nil {
+			b.app.Logger().Error(
+				"[EventBridge] Failed to initialize target publisher",
+				slog.String("target", target.Name),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+
+		targetName := target.Name
+
+		dispatcher := eventbridge.NewDispatcher(publisher, target.Delivery, target.MaxRetries)
+		dispatcher.OnError = func(err error, key string) {
+			b.app.Logger().Error(
+				"[EventBridge] Failed to publish record event",
+				slog.String("target", targetName),
+				slog.String("recordId", key),
+				slog.String("error", err.Error()),
+			)
+		}
+
+		b.targets = append(b.targets, target)
+		b.dispatchers = append(b.dispatchers, dispatcher)
+	}
+}
+
+// reset closes the current dispatchers so that they can be
+// reinitialized with the latest settings on the next event.
+//
+// If wait is false, the old dispatchers are drained in the background.
+func (b *eventBridge) reset(wait bool) {
+	b.mux.Lock()
+	old := b.dispatchers
+	b.loaded = false
+	b.targets = nil
+	b.dispatchers = nil
+	b.mux.Unlock()
+
+	closeAll := func() {
+		for _, d := range old {
+			if err := d.Close(); err != nil {
+				b.app.Logger().Debug("[EventBridge] Failed to close dispatcher", slog.String("error", err.Error()))
+			}
+		}
+	}
+
+	if wait {
+		closeAll()
+	} else {
+		routine.FireAndForget(closeAll)
+	}
+}
+
+func isAppSettingsParam(model models.Model) bool {
+	p, ok := model.(*models.Param)
+
+	return ok && p != nil && p.Key == models.ParamAppSettings
+}
+
+func newEventBridgePublisher(target settings.EventBridgeTarget) (eventbridge.Publisher, error) {
+	switch target.Provider {
+	case settings.EventBridgeProviderNats:
+		return eventbridge.NewNatsPublisher(target.Url, target.Topic, target.Username, target.Password)
+	case settings.EventBridgeProviderRabbitMQ:
+		return eventbridge.NewRabbitMQPublisher(target.Url, target.Topic, target.RoutingKey, target.Username, target.Password)
+	case settings.EventBridgeProviderKafka:
+		return eventbridge.NewKafkaPublisher(target.Url, target.Topic, target.Username, target.Password)
+	case settings.EventBridgeProviderSNS:
+		return eventbridge.NewSNSPublisher(target.Region, target.Url, target.Username, target.Password, target.Topic)
+	case settings.EventBridgeProviderSQS:
+		return eventbridge.NewSQSPublisher(target.Region, target.Url, target.Username, target.Password, target.Topic)
+	default:
+		return nil, fmt.Errorf("unsupported provider %q", target.Provider)
+	}
+}
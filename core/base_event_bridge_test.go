@@ -0,0 +1,113 @@
+package core_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/eventbridge"
+)
+
+func TestEventBridge(t *testing.T) {
+	var mux sync.Mutex
+	received := []map[string]any{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := io.ReadAll(r.Body)
+
+		body := struct {
+			Records []struct {
+				Key   string         `json:"key"`
+				Value map[string]any `json:"value"`
+			} `json:"records"`
+		}{}
+		json.Unmarshal(raw, &body)
+
+		mux.Lock()
+		for _, record := range body.Records {
+			received = append(received, record.Value)
+		}
+		mux.Unlock()
+
+		w.Write([]byte(`{"offsets":[{"partition":0,"offset":1}]}`))
+	}))
+	defer server.Close()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	// disabled bridge
+	// ---
+	record, err := app.Dao().FindRecordById("demo2", "llvuca81nly1qls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	// enable the bridge for demo2 updates only
+	// ---
+	s := app.Settings()
+	s.EventBridge = settings.EventBridgeConfig{
+		Enabled: true,
+		Targets: []settings.EventBridgeTarget{
+			{
+				Name:        "test",
+				Provider:    settings.EventBridgeProviderKafka,
+				Url:         server.URL,
+				Topic:       "events",
+				Collections: []string{"demo2"},
+				Events:      []string{settings.EventBridgeEventUpdate},
+				Format:      eventbridge.FormatCloudEvents,
+				Delivery:    eventbridge.DeliveryRetry,
+			},
+		},
+	}
+	if err := app.Dao().SaveSettings(s); err != nil {
+		t.Fatal(err)
+	}
+
+	record.Set("title", "bridge_test")
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	// not matching collection
+	demo1, err := app.Dao().FindRecordById("demo1", "84nmscqy84lsi1t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := app.Dao().SaveRecord(demo1); err != nil {
+		t.Fatal(err)
+	}
+
+	// wait for the queued events to be delivered
+	if err := app.OnTerminate().Trigger(&core.TerminateEvent{App: app}); err != nil {
+		t.Fatal(err)
+	}
+
+	mux.Lock()
+	defer mux.Unlock()
+
+	if len(received) != 1 {
+		t.Fatalf("Expected 1 published event, got %d: %v", len(received), received)
+	}
+
+	event := received[0]
+
+	if event["type"] != "pocketbase.record.update" || event["subject"] != record.Id {
+		t.Fatalf("Unexpected event %v", event)
+	}
+
+	data, _ := event["data"].(map[string]any)
+	if data["title"] != "bridge_test" {
+		t.Fatalf("Expected the event data to contain the updated record, got %v", data)
+	}
+}
@@ -0,0 +1,353 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"net/mail"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/pocketbase/pocketbase/tools/cron"
+	"github.com/pocketbase/pocketbase/tools/mailer"
+	"github.com/pocketbase/pocketbase/tools/types"
+	"github.com/spf13/cast"
+)
+
+// NotificationMetricFunc defines a notification rule metric resolver
+// that returns the current metric value for the specified time window.
+type NotificationMetricFunc func(app App, window time.Duration) (float64, error)
+
+var notificationMetricsMu sync.RWMutex
+
+// notificationsMux guards the notification rules cooldown checks.
+var notificationsMux sync.Mutex
+
+// storeKeyNotificationLastSent is the app store key prefix
+// of the last time a notification rule was sent.
+const storeKeyNotificationLastSent = "@notificationLastSent_"
+
+var notificationMetrics = map[string]NotificationMetricFunc{
+	settings.NotificationMetricDataSize:     dataSizeMetric,
+	settings.NotificationMetricErrorRate:    errorRateMetric,
+	settings.NotificationMetricFailedLogins: failedLoginsMetric,
+}
+
+// RegisterNotificationMetric registers a new (or replaces an existing)
+// notification rule metric, eg. for a custom mail queue:
+//
+//	core.RegisterNotificationMetric("mailQueue", func(app core.App, window time.Duration) (float64, error) {
+//		return float64(myQueue.Len()), nil
+//	})
+func RegisterNotificationMetric(name string, fn NotificationMetricFunc) {
+	notificationMetricsMu.Lock()
+	defer notificationMetricsMu.Unlock()
+
+	notificationMetrics[name] = fn
+}
+
+func findNotificationMetric(name string) NotificationMetricFunc {
+	notificationMetricsMu.RLock()
+	defer notificationMetricsMu.RUnlock()
+
+	return notificationMetrics[name]
+}
+
+// Notification defines the payload of a triggered notification rule
+// (it is also the json body of the rule webhook request).
+type Notification struct {
+	Rule      string         `json:"rule"`
+	Metric    string         `json:"metric"`
+	Value     float64        `json:"value"`
+	Threshold float64        `json:"threshold"`
+	Window    int            `json:"window"`
+	AppName   string         `json:"appName"`
+	AppUrl    string         `json:"appUrl"`
+	Time      types.DateTime `json:"time"`
+}
+
+// CheckNotificationRules evaluates all configured notification rules
+// and sends the notifications for the ones with exceeded threshold.
+//
+// Rules that are still in their cooldown period are skipped.
+func CheckNotificationRules(app App) error {
+	config := app.Settings().Notifications
+	if !config.Enabled {
+		return nil
+	}
+
+	notificationsMux.Lock()
+	defer notificationsMux.Unlock()
+
+	var errs []error
+
+	for _, rule := range config.Rules {
+		lastSent := cast.ToTime(app.Store().Get(storeKeyNotificationLastSent + rule.Name))
+		if !lastSent.IsZero() && time.Since(lastSent) < time.Duration(rule.Cooldown)*time.Minute {
+			continue
+		}
+
+		metricFunc := findNotificationMetric(rule.Metric)
+		if metricFunc == nil {
+			errs = append(errs, fmt.Errorf("rule %q: unknown metric %q", rule.Name, rule.Metric))
+			continue
+		}
+
+		window := rule.Window
+		if window <= 0 {
+			window = 60
+		}
+
+		value, err := metricFunc(app, time.Duration(window)*time.Minute)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("rule %q: failed to resolve metric: %w", rule.Name, err))
+			continue
+		}
+
+		if value <= rule.Threshold {
+			continue
+		}
+
+		notification := &Notification{
+			Rule:      rule.Name,
+			Metric:    rule.Metric,
+			Value:     value,
+			Threshold: rule.Threshold,
+			Window:    window,
+			AppName:   app.Settings().Meta.AppName,
+			AppUrl:    app.Settings().Meta.BaseUrl(),
+			Time:      types.NowDateTime(),
+		}
+
+		if err := sendNotification(app, rule, notification); err != nil {
+			errs = append(errs, fmt.Errorf("rule %q: %w", rule.Name, err))
+			continue
+		}
+
+		app.Store().Set(storeKeyNotificationLastSent+rule.Name, time.Now())
+	}
+
+	return errors.Join(errs...)
+}
+
+func sendNotification(app App, rule settings.NotificationRule, notification *Notification) error {
+	var errs []error
+
+	if rule.NotifyAdmins {
+		if err := sendNotificationEmail(app, notification); err != nil {
+			errs = append(errs, fmt.Errorf("failed to send email: %w", err))
+		}
+	}
+
+	if rule.WebhookUrl != "" {
+		if err := sendNotificationWebhook(rule.WebhookUrl, notification); err != nil {
+			errs = append(errs, fmt.Errorf("failed to send webhook: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func sendNotificationEmail(app App, notification *Notification) error {
+	admins := []*models.Admin{}
+	if err := app.Dao().AdminQuery().All(&admins); err != nil {
+		return err
+	}
+
+	if len(admins) == 0 {
+		return nil
+	}
+
+	to := make([]mail.Address, len(admins))
+	for i, admin := range admins {
+		to[i] = mail.Address{Address: admin.Email}
+	}
+
+	body := fmt.Sprintf(
+		"<p>The notification rule <strong>%s</strong> of %s was triggered.</p>"+
+			"<p>Metric <strong>%s</strong> has value <strong>%g</strong> (threshold %g, window %d min).</p>"+
+			"<p><a href=\"%s\">%s</a></p>",
+		html.EscapeString(notification.Rule),
+		html.EscapeString(notification.AppName),
+		html.EscapeString(notification.Metric),
+		notification.Value,
+		notification.Threshold,
+		notification.Window,
+		html.EscapeString(notification.AppUrl),
+		html.EscapeString(notification.AppUrl),
+	)
+
+	return app.NewMailClient().Send(&mailer.Message{
+		From: mail.Address{
+			Name:    app.Settings().Meta.SenderName,
+			Address: app.Settings().Meta.SenderAddress,
+		},
+		To:      to,
+		Subject: fmt.Sprintf("[%s] Alert: %s", notification.AppName, notification.Rule),
+		HTML:    body,
+	})
+}
+
+func sendNotificationWebhook(url string, notification *Notification) error {
+	raw, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+
+	res, err := client.Post(url, "application/json", bytes.NewReader(raw))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("unexpected response status %d", res.StatusCode)
+	}
+
+	return nil
+}
+
+// initNotificationsHooks registers the notification rules cron app hooks.
+func (app *BaseApp) initNotificationsHooks() error {
+	c := cron.New()
+	isServe := false
+
+	loadJob := func() {
+		c.Stop()
+
+		config := app.Settings().Notifications
+		if !config.Enabled || config.Cron == "" || !isServe || !app.IsBootstrapped() {
+			return
+		}
+
+		c.Add("@notifications", config.Cron, func() {
+			if err := CheckNotificationRules(app); err != nil {
+				app.Logger().Error(
+					"[Notifications cron] Failed to check notification rules",
+					slog.String("error", err.Error()),
+				)
+			}
+		})
+
+		// restart the ticker
+		c.Start()
+	}
+
+	// load on app serve
+	app.OnBeforeServe().Add(func(e *ServeEvent) error {
+		isServe = true
+		loadJob()
+		return nil
+	})
+
+	// stop the ticker on app termination
+	app.OnTerminate().Add(func(e *TerminateEvent) error {
+		c.Stop()
+		return nil
+	})
+
+	// reload on app settings change
+	app.OnModelAfterUpdate((&models.Param{}).TableName()).Add(func(e *ModelEvent) error {
+		if !isAppSettingsParam(e.Model) {
+			return nil
+		}
+
+		loadJob()
+
+		return nil
+	})
+
+	return nil
+}
+
+// -------------------------------------------------------------------
+
+// dataSizeMetric returns the total size of the app data dir in MB.
+func dataSizeMetric(app App, window time.Duration) (float64, error) {
+	total, err := dirSize(app.DataDir())
+
+	return float64(total) / (1024 * 1024), err
+}
+
+// dirSize returns the total size in bytes of all files in the specified dir.
+func dirSize(dir string) (int64, error) {
+	var total int64
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		total += info.Size()
+
+		return nil
+	})
+
+	return total, err
+}
+
+// errorRateMetric returns the percentage of the logged
+// requests with 5xx status within the specified window.
+func errorRateMetric(app App, window time.Duration) (float64, error) {
+	result := struct {
+		Total  int `db:"total"`
+		Failed int `db:"failed"`
+	}{}
+
+	err := app.LogsDao().LogQuery().
+		Select(
+			"count(*) as total",
+			"count(case when json_extract(data, '$.status') >= 500 then 1 end) as failed",
+		).
+		AndWhere(requestLogsWindowExpr(window)).
+		One(&result)
+	if err != nil || result.Total == 0 {
+		return 0, err
+	}
+
+	return float64(result.Failed) * 100 / float64(result.Total), nil
+}
+
+// failedLoginsMetric returns the number of the logged failed
+// admin and auth record password logins within the specified window.
+func failedLoginsMetric(app App, window time.Duration) (float64, error) {
+	var total int
+
+	err := app.LogsDao().LogQuery().
+		Select("count(*)").
+		AndWhere(requestLogsWindowExpr(window)).
+		AndWhere(dbx.NewExp("json_extract(data, '$.url') LIKE '%/auth-with-password%'")).
+		AndWhere(dbx.NewExp("json_extract(data, '$.status') = 400")).
+		Row(&total)
+
+	return float64(total), err
+}
+
+func requestLogsWindowExpr(window time.Duration) dbx.Expression {
+	from, _ := types.ParseDateTime(time.Now().Add(-window))
+
+	return dbx.And(
+		dbx.NewExp("json_extract(data, '$.type') = 'request'"),
+		dbx.NewExp("created >= {:from}", dbx.Params{"from": from.String()}),
+	)
+}
@@ -0,0 +1,151 @@
+package core_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestCheckNotificationRules(t *testing.T) {
+	var mux sync.Mutex
+	received := []map[string]any{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := io.ReadAll(r.Body)
+
+		data := map[string]any{}
+		json.Unmarshal(raw, &data)
+
+		mux.Lock()
+		received = append(received, data)
+		mux.Unlock()
+	}))
+	defer server.Close()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	// mock failed login request logs
+	for _, status := range []int{400, 400, 400, 200} {
+		log := &models.Log{
+			Message: "POST /api/admins/auth-with-password",
+			Data: types.JsonMap{
+				"type":   "request",
+				"url":    "/api/admins/auth-with-password",
+				"status": status,
+			},
+		}
+		if err := app.LogsDao().SaveLog(log); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	app.Settings().Notifications.Rules = []settings.NotificationRule{
+		{
+			Name:         "too many failed logins",
+			Metric:       settings.NotificationMetricFailedLogins,
+			Threshold:    2,
+			Cooldown:     60,
+			NotifyAdmins: true,
+			WebhookUrl:   server.URL,
+		},
+		{
+			Name:         "not exceeded",
+			Metric:       settings.NotificationMetricFailedLogins,
+			Threshold:    3,
+			NotifyAdmins: true,
+		},
+	}
+
+	// disabled
+	if err := core.CheckNotificationRules(app); err != nil {
+		t.Fatal(err)
+	}
+	if app.TestMailer.TotalSend != 0 || len(received) != 0 {
+		t.Fatalf("Expected no notifications, got %d emails and %d webhooks", app.TestMailer.TotalSend, len(received))
+	}
+
+	app.Settings().Notifications.Enabled = true
+
+	if err := core.CheckNotificationRules(app); err != nil {
+		t.Fatal(err)
+	}
+
+	if app.TestMailer.TotalSend != 1 {
+		t.Fatalf("Expected 1 email, got %d", app.TestMailer.TotalSend)
+	}
+
+	if total := len(app.TestMailer.LastMessage.To); total != 3 {
+		t.Fatalf("Expected the email to be sent to all 3 admins, got %d", total)
+	}
+
+	if !strings.Contains(app.TestMailer.LastMessage.Subject, "too many failed logins") {
+		t.Fatalf("Expected the rule name in the subject, got %q", app.TestMailer.LastMessage.Subject)
+	}
+
+	if len(received) != 1 {
+		t.Fatalf("Expected 1 webhook, got %d", len(received))
+	}
+
+	if received[0]["rule"] != "too many failed logins" || received[0]["value"] != 3.0 || received[0]["threshold"] != 2.0 {
+		t.Fatalf("Unexpected webhook payload %v", received[0])
+	}
+
+	// cooldown
+	if err := core.CheckNotificationRules(app); err != nil {
+		t.Fatal(err)
+	}
+	if app.TestMailer.TotalSend != 1 || len(received) != 1 {
+		t.Fatalf("Expected no new notifications during the cooldown, got %d emails and %d webhooks", app.TestMailer.TotalSend, len(received))
+	}
+}
+
+func TestCheckNotificationRulesCustomMetric(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	var calledWindow time.Duration
+	core.RegisterNotificationMetric("testMetric", func(app core.App, window time.Duration) (float64, error) {
+		calledWindow = window
+		return 10, nil
+	})
+
+	app.Settings().Notifications.Enabled = true
+	app.Settings().Notifications.Rules = []settings.NotificationRule{
+		{
+			Name:         "custom",
+			Metric:       "testMetric",
+			Threshold:    5,
+			NotifyAdmins: true,
+		},
+	}
+
+	if err := core.CheckNotificationRules(app); err != nil {
+		t.Fatal(err)
+	}
+
+	if calledWindow != 60*time.Minute {
+		t.Fatalf("Expected the default 60 minutes window, got %v", calledWindow)
+	}
+
+	if app.TestMailer.TotalSend != 1 {
+		t.Fatalf("Expected 1 email, got %d", app.TestMailer.TotalSend)
+	}
+
+	// unknown metric
+	app.Settings().Notifications.Rules[0].Metric = "missing"
+	if err := core.CheckNotificationRules(app); err == nil {
+		t.Fatal("Expected unknown metric error, got nil")
+	}
+}
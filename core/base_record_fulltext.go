@@ -0,0 +1,54 @@
+package core
+
+import (
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// initRecordsFullTextHooks registers the hooks that keep the
+// collections full-text index tables in sync with their records.
+//
+// Note that records saved or deleted without hooks
+// (eg. via Dao().WithoutHooks()) are not reindexed.
+func (app *BaseApp) initRecordsFullTextHooks() error {
+	collectionsTable := (&models.Collection{}).TableName()
+
+	// create/rebuild/drop the collection index on options change
+	syncIndex := func(e *ModelEvent) error {
+		collection, ok := e.Model.(*models.Collection)
+		if !ok || collection.IsView() {
+			return nil
+		}
+
+		return e.Dao.SyncRecordsFullTextIndex(collection)
+	}
+	app.OnModelAfterCreate(collectionsTable).Add(syncIndex)
+	app.OnModelAfterUpdate(collectionsTable).Add(syncIndex)
+
+	app.OnModelAfterDelete(collectionsTable).Add(func(e *ModelEvent) error {
+		return e.Dao.DeleteTable(daos.FullTextTableName(e.Model.GetId()))
+	})
+
+	// reindex the changed records
+	saveRecord := func(e *ModelEvent) error {
+		record, ok := e.Model.(*models.Record)
+		if !ok {
+			return nil
+		}
+
+		return e.Dao.SaveRecordFullText(record)
+	}
+	app.OnModelAfterCreate().Add(saveRecord)
+	app.OnModelAfterUpdate().Add(saveRecord)
+
+	app.OnModelAfterDelete().Add(func(e *ModelEvent) error {
+		record, ok := e.Model.(*models.Record)
+		if !ok {
+			return nil
+		}
+
+		return e.Dao.DeleteRecordFullText(record)
+	})
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package core_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestRecordsFullTextHooks(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	if !app.Dao().HasFullTextSupport() {
+		t.Skip("the SQLite build doesn't support FTS5")
+	}
+
+	collection, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tableName := daos.FullTextTableName(collection.Id)
+
+	countMatches := func(q string) int {
+		var total int
+		app.Dao().DB().Select("count(*)").
+			From(tableName).
+			AndWhere(dbx.NewExp("{{"+tableName+"}} MATCH {:q}", dbx.Params{"q": q})).
+			Row(&total)
+		return total
+	}
+
+	// enable the index
+	options := collection.BaseOptions()
+	options.FullTextFields = []string{"title"}
+	collection.SetOptions(options)
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+	if total := countMatches(`"test"*`); total != 3 {
+		t.Fatalf("Expected 3 indexed records, got %d", total)
+	}
+
+	// create
+	record := models.NewRecord(collection)
+	record.Set("title", "hooks_create")
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+	if total := countMatches(`"hooks_create"`); total != 1 {
+		t.Fatalf("Expected the created record to be indexed, got %d", total)
+	}
+
+	// update
+	record.Set("title", "hooks_update")
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+	if total := countMatches(`"hooks_create"`); total != 0 {
+		t.Fatalf("Expected the old record title to be removed from the index, got %d", total)
+	}
+	if total := countMatches(`"hooks_update"`); total != 1 {
+		t.Fatalf("Expected the updated record to be indexed, got %d", total)
+	}
+
+	// delete
+	if err := app.Dao().DeleteRecord(record); err != nil {
+		t.Fatal(err)
+	}
+	if total := countMatches(`"hooks_update"`); total != 0 {
+		t.Fatalf("Expected the deleted record to be removed from the index, got %d", total)
+	}
+
+	// disable the index
+	options.FullTextFields = nil
+	collection.SetOptions(options)
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+	if app.Dao().HasTable(tableName) {
+		t.Fatalf("Expected table %s to be deleted", tableName)
+	}
+}
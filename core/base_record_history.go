@@ -0,0 +1,57 @@
+package core
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/spf13/cast"
+)
+
+// initRecordHistoryHooks registers the hooks that apply the
+// settings retention to the stored records versions.
+//
+// The versions themselves are created by the Dao on record update/delete.
+func (app *BaseApp) initRecordHistoryHooks() error {
+	applyRetention := func(e *ModelEvent) error {
+		record, ok := e.Model.(*models.Record)
+		if !ok || !record.Collection().HistoryEnabled() {
+			return nil
+		}
+
+		config := app.Settings().History
+
+		if err := e.Dao.TrimRecordVersions(record.Collection(), record.Id, config.MaxVersions); err != nil {
+			app.Logger().Error(
+				"Failed to trim the record versions",
+				slog.String("collectionId", record.Collection().Id),
+				slog.String("recordId", record.Id),
+				slog.String("error", err.Error()),
+			)
+		}
+
+		if config.MaxDays <= 0 {
+			return nil
+		}
+
+		// delete the expired versions at most once per day
+		now := time.Now()
+		lastDeletedAt := cast.ToTime(app.Store().Get("lastHistoryDeletedAt"))
+		if now.Sub(lastDeletedAt) < 24*time.Hour {
+			return nil
+		}
+
+		if err := e.Dao.DeleteOldRecordVersions(now.AddDate(0, 0, -config.MaxDays)); err != nil {
+			app.Logger().Error("Failed to delete the expired record versions", slog.String("error", err.Error()))
+		} else {
+			app.Store().Set("lastHistoryDeletedAt", now)
+		}
+
+		return nil
+	}
+
+	app.OnModelAfterUpdate().Add(applyRetention)
+	app.OnModelAfterDelete().Add(applyRetention)
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package core_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestRecordHistoryHooks(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	options := collection.BaseOptions()
+	options.History = true
+	collection.SetOptions(options)
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	record, err := app.Dao().FindRecordById(collection.Id, "llvuca81nly1qls")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	countVersions := func() int {
+		versions, err := app.Dao().FindRecordVersions(collection, record.Id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return len(versions)
+	}
+
+	// max versions
+	// ---
+	app.Settings().History.MaxDays = 0
+	app.Settings().History.MaxVersions = 2
+
+	for _, title := range []string{"a", "b", "c"} {
+		record.Set("title", title)
+		if err := app.Dao().SaveRecord(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if total := countVersions(); total != 2 {
+		t.Fatalf("Expected 2 versions, got %d", total)
+	}
+
+	// max days
+	// ---
+	app.Settings().History.MaxDays = 1
+	app.Settings().History.MaxVersions = 0
+
+	_, err = app.Dao().DB().Update(
+		(&models.RecordVersion{}).TableName(),
+		dbx.Params{"created": "2022-01-01 00:00:00.000Z"},
+		dbx.HashExp{"recordId": record.Id},
+	).Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	record.Set("title", "d")
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	if total := countVersions(); total != 1 {
+		t.Fatalf("Expected only the new version to remain, got %d", total)
+	}
+}
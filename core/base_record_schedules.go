@@ -0,0 +1,169 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/cron"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// recordSchedulesBatchSize is the max number of due records loaded at once.
+const recordSchedulesBatchSize = 100
+
+// RecordScheduleWebhook defines the json body of a "webhook" collection schedule request.
+type RecordScheduleWebhook struct {
+	Schedule   string         `json:"schedule"`
+	Collection string         `json:"collection"`
+	Record     *models.Record `json:"record"`
+	Time       types.DateTime `json:"time"`
+}
+
+// RunRecordSchedules executes all due collection schedules.
+//
+// Because the executions are tracked per record trigger time, the
+// records that were missed (eg. during the app downtime) are also processed.
+// Failed record schedules are retried on the next run.
+func (app *BaseApp) RunRecordSchedules() error {
+	app.recordSchedulesMux.Lock()
+	defer app.recordSchedulesMux.Unlock()
+
+	// note: the dao is loaded once because the schedules could
+	// be still running while the app bootstrap state is reset
+	dao := app.Dao()
+	if dao == nil {
+		return errors.New("the app is not bootstrapped")
+	}
+
+	collections := []*models.Collection{}
+	err := dao.CollectionQuery().
+		AndWhere(dbx.In("type", models.CollectionTypeBase, models.CollectionTypeAuth)).
+		All(&collections)
+	if err != nil {
+		return err
+	}
+
+	now := types.NowDateTime()
+
+	var errs []error
+
+	for _, collection := range collections {
+		for _, schedule := range collection.Schedules() {
+			if err := runRecordSchedule(dao, collection, schedule, now); err != nil {
+				errs = append(errs, fmt.Errorf("schedule %s.%s: %w", collection.Name, schedule.Name, err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func runRecordSchedule(dao *daos.Dao, collection *models.Collection, schedule models.CollectionSchedule, now types.DateTime) error {
+	var errs []error
+
+	// exclude the failed records from the subsequent batches of the current run
+	failed := []string{}
+
+	for {
+		records, err := dao.FindDueScheduledRecords(collection, schedule, now, recordSchedulesBatchSize, failed...)
+		if err != nil {
+			return err
+		}
+
+		for _, record := range records {
+			if err := executeRecordSchedule(dao, record, schedule); err != nil {
+				failed = append(failed, record.Id)
+				errs = append(errs, fmt.Errorf("record %q: %w", record.Id, err))
+			}
+		}
+
+		if len(records) < recordSchedulesBatchSize {
+			break
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func executeRecordSchedule(dao *daos.Dao, record *models.Record, schedule models.CollectionSchedule) error {
+	if schedule.Action == models.ScheduleActionWebhook {
+		payload := &RecordScheduleWebhook{
+			Schedule:   schedule.Name,
+			Collection: record.Collection().Name,
+			Record:     record,
+			Time:       types.NowDateTime(),
+		}
+
+		if err := sendRecordScheduleWebhook(schedule.WebhookUrl, payload); err != nil {
+			return fmt.Errorf("failed to send webhook: %w", err)
+		}
+	}
+
+	return dao.RunRecordSchedule(record, schedule)
+}
+
+func sendRecordScheduleWebhook(url string, payload *RecordScheduleWebhook) error {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+
+	res, err := client.Post(url, "application/json", bytes.NewReader(raw))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("unexpected response status %d", res.StatusCode)
+	}
+
+	return nil
+}
+
+// initRecordSchedulesHooks registers the collection schedules cron app hooks.
+func (app *BaseApp) initRecordSchedulesHooks() error {
+	c := cron.New()
+
+	run := func() {
+		if !app.IsBootstrapped() {
+			return
+		}
+
+		if err := app.RunRecordSchedules(); err != nil {
+			app.Logger().Error(
+				"[Record schedules cron] Failed to run the record schedules",
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+
+	c.MustAdd("@recordSchedules", "* * * * *", run)
+
+	// start on app serve
+	app.OnBeforeServe().Add(func(e *ServeEvent) error {
+		// catch up with the schedules missed during the app downtime
+		go run()
+
+		c.Start()
+		return nil
+	})
+
+	// stop the ticker on app termination
+	app.OnTerminate().Add(func(e *TerminateEvent) error {
+		c.Stop()
+		return nil
+	})
+
+	return nil
+}
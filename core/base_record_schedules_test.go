@@ -0,0 +1,154 @@
+package core_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestRunRecordSchedules(t *testing.T) {
+	var mux sync.Mutex
+	received := []map[string]any{}
+	failWebhook := true
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mux.Lock()
+		defer mux.Unlock()
+
+		if failWebhook {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		raw, _ := io.ReadAll(r.Body)
+
+		data := map[string]any{}
+		json.Unmarshal(raw, &data)
+
+		received = append(received, data)
+	}))
+	defer server.Close()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	collection.Schema.AddField(&schema.SchemaField{Name: "publishAt", Type: schema.FieldTypeDate})
+	collection.Schema.AddField(&schema.SchemaField{Name: "notifyAt", Type: schema.FieldTypeDate})
+	collection.SetOptions(models.CollectionBaseOptions{
+		Schedules: []models.CollectionSchedule{
+			{
+				Name:   "publish",
+				Field:  "publishAt",
+				Action: models.ScheduleActionUpdate,
+				Data:   map[string]any{"active": true},
+			},
+			{
+				Name:       "notify",
+				Field:      "notifyAt",
+				Action:     models.ScheduleActionWebhook,
+				WebhookUrl: server.URL,
+			},
+		},
+	})
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	// simulate a missed trigger time (eg. during downtime) and a future one
+	past := time.Now().Add(-24 * time.Hour)
+	future := time.Now().Add(24 * time.Hour)
+
+	dates := map[string]time.Time{
+		"llvuca81nly1qls": past,
+		"achvryl401bhse3": future,
+	}
+	for id, date := range dates {
+		record, err := app.Dao().FindRecordById(collection.Id, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		record.Set("active", false)
+		record.Set("publishAt", date)
+		record.Set("notifyAt", date)
+		if err := app.Dao().SaveRecord(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// first run with failing webhook
+	if err := app.RunRecordSchedules(); err == nil {
+		t.Fatal("Expected the failed webhook error, got nil")
+	}
+
+	ensureActive := func(t *testing.T, id string, expected bool) {
+		record, err := app.Dao().FindRecordById(collection.Id, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if v := record.GetBool("active"); v != expected {
+			t.Fatalf("Expected record %q active to be %v, got %v", id, expected, v)
+		}
+	}
+
+	ensureActive(t, "llvuca81nly1qls", true)
+	ensureActive(t, "achvryl401bhse3", false)
+
+	if len(received) != 0 {
+		t.Fatalf("Expected no received webhooks, got %d", len(received))
+	}
+
+	// the failed webhook should be retried on the next run
+	mux.Lock()
+	failWebhook = false
+	mux.Unlock()
+
+	if err := app.RunRecordSchedules(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(received) != 1 {
+		t.Fatalf("Expected 1 received webhook, got %d", len(received))
+	}
+	record, _ := received[0]["record"].(map[string]any)
+	if received[0]["schedule"] != "notify" || received[0]["collection"] != "demo2" || record["id"] != "llvuca81nly1qls" {
+		t.Fatalf("Unexpected webhook payload %v", received[0])
+	}
+
+	// already executed schedules shouldn't be repeated
+	if err := app.RunRecordSchedules(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(received) != 1 {
+		t.Fatalf("Expected the webhook to not be resent, got %d", len(received))
+	}
+
+	// manually revert the update action to ensure that it is not executed again
+	updated, err := app.Dao().FindRecordById(collection.Id, "llvuca81nly1qls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	updated.Set("active", false)
+	if err := app.Dao().SaveRecord(updated); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.RunRecordSchedules(); err != nil {
+		t.Fatal(err)
+	}
+
+	ensureActive(t, "llvuca81nly1qls", false)
+}
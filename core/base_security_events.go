@@ -0,0 +1,215 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/pocketbase/pocketbase/tools/hook"
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+// securityEventsDetector keeps the in-memory state
+// used for the security anomalies detection.
+//
+// Note that the state is not persisted, aka. the first admin
+// login after an app restart is never reported as anomaly.
+type securityEventsDetector struct {
+	app *BaseApp
+
+	mux sync.Mutex
+
+	// ip -> failed auth attempts times
+	failures map[string][]time.Time
+
+	// admin id -> known ips
+	adminIps map[string]map[string]struct{}
+
+	// admin id -> last login event
+	adminLastLogin map[string]*SecurityEvent
+}
+
+// initSecurityEventsHooks registers the security anomalies
+// detection and the security events webhook sink hooks.
+func (app *BaseApp) initSecurityEventsHooks() error {
+	detector := &securityEventsDetector{
+		app:            app,
+		failures:       map[string][]time.Time{},
+		adminIps:       map[string]map[string]struct{}{},
+		adminLastLogin: map[string]*SecurityEvent{},
+	}
+
+	app.OnSecurityEvent().PreAdd(func(e *SecurityEvent) error {
+		if e.Id == "" {
+			e.Id = security.RandomString(20)
+		}
+
+		if e.Time.IsZero() {
+			e.Time = time.Now().UTC()
+		}
+
+		config := app.Settings().SecurityEvents
+		if !config.Enabled {
+			return nil
+		}
+
+		for _, anomaly := range detector.detect(e, config) {
+			if err := app.OnSecurityEvent().Trigger(anomaly); err != nil {
+				app.Logger().Debug(
+					"Failed to trigger security anomaly event",
+					slog.String("type", anomaly.Type),
+					slog.String("error", err.Error()),
+				)
+			}
+		}
+
+		return nil
+	})
+
+	app.OnSecurityEvent().AddWithOptions(func(e *SecurityEvent) error {
+		config := app.Settings().SecurityEvents
+		if !config.Enabled || config.WebhookUrl == "" {
+			return nil
+		}
+
+		return sendSecurityEventWebhook(config.WebhookUrl, config.WebhookSecret, e)
+	}, hook.HandlerOptions{
+		Async:           true,
+		AsyncMaxRetries: 2,
+		AsyncRetryDelay: 3 * time.Second,
+		OnAsyncError: func(err error) {
+			app.Logger().Error("Failed to send security event webhook", slog.String("error", err.Error()))
+		},
+	})
+
+	return nil
+}
+
+// detect checks the provided security event for anomalies and
+// returns the anomaly events that should be emitted (if any).
+func (d *securityEventsDetector) detect(e *SecurityEvent, config settings.SecurityEventsConfig) []*SecurityEvent {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+
+	var result []*SecurityEvent
+
+	switch e.Type {
+	case SecurityEventAuthFailure:
+		if e.Ip == "" {
+			break
+		}
+
+		window := time.Duration(config.FailedAuthWindow) * time.Second
+
+		// keep only the failures within the window
+		failures := d.failures[e.Ip][:0]
+		for _, t := range d.failures[e.Ip] {
+			if e.Time.Sub(t) < window {
+				failures = append(failures, t)
+			}
+		}
+		failures = append(failures, e.Time)
+
+		// report once per burst and start counting again
+		if len(failures) >= config.FailedAuthThreshold {
+			result = append(result, newSecurityAnomaly(SecurityEventAuthFailureBurst, e, map[string]any{
+				"attempts": len(failures),
+				"window":   config.FailedAuthWindow,
+			}))
+			delete(d.failures, e.Ip)
+		} else {
+			d.failures[e.Ip] = failures
+		}
+
+		// prevent the failures map from growing indefinitely
+		if len(d.failures) > 10000 {
+			d.failures = map[string][]time.Time{}
+		}
+	case SecurityEventAdminLogin:
+		if e.ActorId == "" {
+			break
+		}
+
+		knownIps, hasPreviousLogins := d.adminIps[e.ActorId]
+		if !hasPreviousLogins {
+			knownIps = map[string]struct{}{}
+			d.adminIps[e.ActorId] = knownIps
+		}
+
+		if _, ok := knownIps[e.Ip]; !ok && hasPreviousLogins && e.Ip != "" {
+			result = append(result, newSecurityAnomaly(SecurityEventAdminNewIp, e, nil))
+		}
+		knownIps[e.Ip] = struct{}{}
+
+		last := d.adminLastLogin[e.ActorId]
+		if last != nil &&
+			last.Country != "" &&
+			e.Country != "" &&
+			last.Country != e.Country &&
+			e.Time.Sub(last.Time) < time.Duration(config.ImpossibleTravelWindow)*time.Second {
+			result = append(result, newSecurityAnomaly(SecurityEventImpossibleTravel, e, map[string]any{
+				"previousIp":      last.Ip,
+				"previousCountry": last.Country,
+				"previousTime":    last.Time,
+			}))
+		}
+		d.adminLastLogin[e.ActorId] = e
+	}
+
+	return result
+}
+
+// newSecurityAnomaly creates a new anomaly event from the provided source event.
+func newSecurityAnomaly(anomalyType string, source *SecurityEvent, data map[string]any) *SecurityEvent {
+	if data == nil {
+		data = map[string]any{}
+	}
+	data["sourceEventId"] = source.Id
+
+	return &SecurityEvent{
+		Type:      anomalyType,
+		Time:      source.Time,
+		Ip:        source.Ip,
+		Country:   source.Country,
+		ActorType: source.ActorType,
+		ActorId:   source.ActorId,
+		Data:      data,
+	}
+}
+
+func sendSecurityEventWebhook(url string, secret string, e *SecurityEvent) error {
+	raw, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(raw))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	if secret != "" {
+		req.Header.Set(settings.SecurityEventsSignatureHeader, security.HS256(string(raw), secret))
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("unexpected response status %d", res.StatusCode)
+	}
+
+	return nil
+}
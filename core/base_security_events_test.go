@@ -0,0 +1,172 @@
+package core_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+func TestSecurityEventsDetection(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	var captured []*core.SecurityEvent
+	app.OnSecurityEvent().Add(func(e *core.SecurityEvent) error {
+		captured = append(captured, e)
+		return nil
+	})
+
+	countTypes := func() map[string]int {
+		result := map[string]int{}
+		for _, e := range captured {
+			result[e.Type]++
+		}
+		return result
+	}
+
+	now := time.Now().UTC()
+
+	failure := func(ip string, offset time.Duration) *core.SecurityEvent {
+		return &core.SecurityEvent{
+			Type: core.SecurityEventAuthFailure,
+			Ip:   ip,
+			Time: now.Add(offset),
+		}
+	}
+
+	login := func(ip string, country string, offset time.Duration) *core.SecurityEvent {
+		return &core.SecurityEvent{
+			Type:      core.SecurityEventAdminLogin,
+			Ip:        ip,
+			Country:   country,
+			Time:      now.Add(offset),
+			ActorType: core.SecurityActorAdmin,
+			ActorId:   "sywbhecnh46rhm0",
+		}
+	}
+
+	// disabled detection
+	// ---
+	for i := 0; i < 5; i++ {
+		app.OnSecurityEvent().Trigger(failure("1.1.1.1", 0))
+	}
+	if total := countTypes()[core.SecurityEventAuthFailureBurst]; total != 0 {
+		t.Fatalf("Expected no anomalies while disabled, got %d", total)
+	}
+	for _, e := range captured {
+		if e.Id == "" {
+			t.Fatal("Expected the security event id to be set")
+		}
+	}
+
+	// enabled detection
+	// ---
+	app.Settings().SecurityEvents = settings.SecurityEventsConfig{
+		Enabled:                true,
+		FailedAuthThreshold:    3,
+		FailedAuthWindow:       60,
+		ImpossibleTravelWindow: 3600,
+	}
+	captured = nil
+
+	app.OnSecurityEvent().Trigger(failure("1.1.1.1", 0))
+	app.OnSecurityEvent().Trigger(failure("1.1.1.1", 10*time.Second))
+	app.OnSecurityEvent().Trigger(failure("2.2.2.2", 10*time.Second))  // different ip
+	app.OnSecurityEvent().Trigger(failure("1.1.1.1", 100*time.Second)) // outside of the window
+	app.OnSecurityEvent().Trigger(failure("1.1.1.1", 110*time.Second))
+	if total := countTypes()[core.SecurityEventAuthFailureBurst]; total != 0 {
+		t.Fatalf("Expected no auth failure bursts, got %d", total)
+	}
+
+	app.OnSecurityEvent().Trigger(failure("1.1.1.1", 120*time.Second))
+	if total := countTypes()[core.SecurityEventAuthFailureBurst]; total != 1 {
+		t.Fatalf("Expected 1 auth failure burst, got %d", total)
+	}
+
+	// the counter should start again after the reported burst
+	app.OnSecurityEvent().Trigger(failure("1.1.1.1", 130*time.Second))
+	if total := countTypes()[core.SecurityEventAuthFailureBurst]; total != 1 {
+		t.Fatalf("Expected still 1 auth failure burst, got %d", total)
+	}
+
+	captured = nil
+
+	app.OnSecurityEvent().Trigger(login("1.1.1.1", "BG", 0)) // first login
+	app.OnSecurityEvent().Trigger(login("1.1.1.1", "BG", time.Minute))
+	if types := countTypes(); types[core.SecurityEventAdminNewIp] != 0 || types[core.SecurityEventImpossibleTravel] != 0 {
+		t.Fatalf("Expected no admin login anomalies, got %v", types)
+	}
+
+	app.OnSecurityEvent().Trigger(login("2.2.2.2", "BG", 2*time.Minute))
+	if types := countTypes(); types[core.SecurityEventAdminNewIp] != 1 || types[core.SecurityEventImpossibleTravel] != 0 {
+		t.Fatalf("Expected 1 admin new ip anomaly, got %v", types)
+	}
+
+	app.OnSecurityEvent().Trigger(login("3.3.3.3", "US", 3*time.Minute))
+	if types := countTypes(); types[core.SecurityEventAdminNewIp] != 2 || types[core.SecurityEventImpossibleTravel] != 1 {
+		t.Fatalf("Expected 2 admin new ip and 1 impossible travel anomalies, got %v", types)
+	}
+
+	// outside of the impossible travel window
+	app.OnSecurityEvent().Trigger(login("3.3.3.3", "BG", 3*time.Hour))
+	if types := countTypes(); types[core.SecurityEventAdminNewIp] != 2 || types[core.SecurityEventImpossibleTravel] != 1 {
+		t.Fatalf("Expected no new anomalies, got %v", types)
+	}
+
+	for _, e := range captured {
+		if e.Type == core.SecurityEventImpossibleTravel {
+			if e.Data["previousCountry"] != "BG" || e.Data["sourceEventId"] == "" {
+				t.Fatalf("Unexpected impossible travel data %v", e.Data)
+			}
+		}
+	}
+}
+
+func TestSecurityEventsWebhook(t *testing.T) {
+	var mux sync.Mutex
+	var bodies []string
+	var signatures []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		raw, _ := io.ReadAll(r.Body)
+
+		mux.Lock()
+		bodies = append(bodies, string(raw))
+		signatures = append(signatures, r.Header.Get(settings.SecurityEventsSignatureHeader))
+		mux.Unlock()
+	}))
+	defer server.Close()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	// missing webhook url
+	app.Settings().SecurityEvents.Enabled = true
+	app.OnSecurityEvent().Trigger(&core.SecurityEvent{Type: core.SecurityEventRuleChange})
+	app.OnSecurityEvent().Wait()
+
+	// with webhook url
+	app.Settings().SecurityEvents.WebhookUrl = server.URL
+	app.Settings().SecurityEvents.WebhookSecret = "test_secret"
+	app.OnSecurityEvent().Trigger(&core.SecurityEvent{Type: core.SecurityEventRuleChange})
+	app.OnSecurityEvent().Wait()
+
+	mux.Lock()
+	defer mux.Unlock()
+
+	if len(bodies) != 1 {
+		t.Fatalf("Expected 1 webhook request, got %d", len(bodies))
+	}
+
+	if expected := security.HS256(bodies[0], "test_secret"); signatures[0] != expected {
+		t.Fatalf("Expected signature %q, got %q", expected, signatures[0])
+	}
+}
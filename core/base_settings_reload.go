@@ -0,0 +1,131 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// Settings secret reference prefixes.
+//
+// Settings secret values (eg. the SMTP password and the S3 keys)
+// could reference an environment variable or a file instead of
+// storing the plain secret, eg. "$env:SMTP_PASSWORD" or
+// "$file:/run/secrets/smtp_password" (see [BaseApp.ResolveSecret]).
+const (
+	SecretRefEnvPrefix  = "$env:"
+	SecretRefFilePrefix = "$file:"
+)
+
+// ResolveSecret resolves the provided settings value if it is an
+// env or file secret reference, otherwise returns it as it is.
+//
+// The resolved values are cached until the next [BaseApp.ReloadSettings] call.
+//
+// Unresolvable references (missing env variable or file) result in empty string.
+func (app *BaseApp) ResolveSecret(value string) string {
+	if !isSecretRef(value) {
+		return value
+	}
+
+	if app.resolvedSecrets != nil && app.resolvedSecrets.Has(value) {
+		return app.resolvedSecrets.Get(value)
+	}
+
+	resolved, err := resolveSecretRef(value)
+	if err != nil {
+		if logger := app.Logger(); logger != nil {
+			logger.Warn(
+				"Failed to resolve settings secret reference",
+				slog.String("ref", value),
+				slog.String("error", err.Error()),
+			)
+		}
+		return ""
+	}
+
+	if app.resolvedSecrets != nil {
+		app.resolvedSecrets.Set(value, resolved)
+	}
+
+	return resolved
+}
+
+// ReloadSettings reloads the stored app settings and re-resolves
+// the env and file referenced settings secrets without restarting
+// the app (eg. after a secret rotation in a container).
+//
+// The mail client and the filesystems are created on demand from the
+// current settings, meaning that they will use the reloaded values
+// on their next initialization.
+//
+// Returns an error if the settings couldn't be reloaded or
+// some of the settings secret references couldn't be resolved.
+func (app *BaseApp) ReloadSettings() error {
+	if err := app.RefreshSettings(); err != nil {
+		return err
+	}
+
+	if app.resolvedSecrets != nil {
+		app.resolvedSecrets.RemoveAll()
+	}
+
+	s := app.Settings()
+
+	refs := []struct {
+		field string
+		value string
+	}{
+		{"smtp.password", s.Smtp.Password},
+		{"s3.accessKey", s.S3.AccessKey},
+		{"s3.secret", s.S3.Secret},
+		{"backups.s3.accessKey", s.Backups.S3.AccessKey},
+		{"backups.s3.secret", s.Backups.S3.Secret},
+	}
+
+	var errs []error
+	for _, ref := range refs {
+		if !isSecretRef(ref.value) {
+			continue
+		}
+
+		resolved, err := resolveSecretRef(ref.value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", ref.field, err))
+			continue
+		}
+
+		if app.resolvedSecrets != nil {
+			app.resolvedSecrets.Set(ref.value, resolved)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func isSecretRef(value string) bool {
+	return strings.HasPrefix(value, SecretRefEnvPrefix) || strings.HasPrefix(value, SecretRefFilePrefix)
+}
+
+func resolveSecretRef(ref string) (string, error) {
+	if name, ok := strings.CutPrefix(ref, SecretRefEnvPrefix); ok {
+		value, exists := os.LookupEnv(name)
+		if !exists {
+			return "", fmt.Errorf("missing env variable %q", name)
+		}
+		return value, nil
+	}
+
+	if path, ok := strings.CutPrefix(ref, SecretRefFilePrefix); ok {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		// trim the trailing new line usually added by the secret files editors
+		return strings.TrimRight(string(content), "\r\n"), nil
+	}
+
+	return ref, nil
+}
@@ -0,0 +1,80 @@
+package core_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestResolveSecret(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	t.Setenv("PB_TEST_SECRET", "env_secret")
+
+	secretFile := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(secretFile, []byte("file_secret\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		value    string
+		expected string
+	}{
+		{"", ""},
+		{"plain", "plain"},
+		{"$env:PB_TEST_SECRET", "env_secret"},
+		{"$env:PB_TEST_SECRET_MISSING", ""},
+		{"$file:" + secretFile, "file_secret"},
+		{"$file:" + secretFile + "_missing", ""},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.value, func(t *testing.T) {
+			if result := app.ResolveSecret(s.value); result != s.expected {
+				t.Fatalf("Expected %q, got %q", s.expected, result)
+			}
+		})
+	}
+}
+
+func TestReloadSettings(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	t.Setenv("PB_TEST_SMTP_PASSWORD", "old")
+
+	ref := "$env:PB_TEST_SMTP_PASSWORD"
+
+	if v := app.ResolveSecret(ref); v != "old" {
+		t.Fatalf("Expected %q, got %q", "old", v)
+	}
+
+	t.Setenv("PB_TEST_SMTP_PASSWORD", "new")
+
+	// the resolved secret should be cached until reload
+	if v := app.ResolveSecret(ref); v != "old" {
+		t.Fatalf("Expected the cached %q, got %q", "old", v)
+	}
+
+	if err := app.ReloadSettings(); err != nil {
+		t.Fatal(err)
+	}
+
+	if v := app.ResolveSecret(ref); v != "new" {
+		t.Fatalf("Expected the reloaded %q, got %q", "new", v)
+	}
+
+	// store an unresolvable reference
+	s := app.Settings()
+	s.Smtp.Password = "$env:PB_TEST_SMTP_PASSWORD_MISSING"
+	if err := app.Dao().SaveSettings(s); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.ReloadSettings(); err == nil {
+		t.Fatal("Expected reload error for the unresolvable smtp password reference")
+	}
+}
@@ -1,10 +1,13 @@
 package core_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/pocketbase/pocketbase/models"
 	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/secrets"
+	"github.com/pocketbase/pocketbase/tools/security"
 	"github.com/pocketbase/pocketbase/tools/types"
 )
 
@@ -61,3 +64,64 @@ func testEventCalls(t *testing.T, app *tests.TestApp, events map[string]int) {
 		}
 	}
 }
+
+func TestBaseAppRefreshSettingsSecretsBackendChange(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	backend1, _ := secrets.NewLocalBackend(security.PseudorandomString(32), "test1")
+	backend2, _ := secrets.NewLocalBackend(security.PseudorandomString(32), "test2")
+
+	app.Settings().Meta.AppName = "secrets_test"
+	if err := app.Dao().SaveSettings(app.Settings()); err != nil {
+		t.Fatal(err)
+	}
+
+	// switch from plain to backend1
+	app.SetSecretsBackend(backend1)
+	if err := app.RefreshSettings(); err != nil {
+		t.Fatal(err)
+	}
+
+	param, err := app.Dao().FindParamByKey(models.ParamAppSettings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(param.Value), "pbsecret:test1:") {
+		t.Fatalf("Expected the settings to be sealed with test1, got %q", param.Value)
+	}
+
+	// switch to backend2 without the previous backend
+	app.SetSecretsBackend(backend2)
+	if err := app.RefreshSettings(); err == nil {
+		t.Fatal("Expected refresh error without the previous backend")
+	}
+
+	// switch to backend2 with backend1 as previous backend
+	app.SetSecretsBackend(backend2, backend1)
+	if err := app.RefreshSettings(); err != nil {
+		t.Fatal(err)
+	}
+
+	param, err = app.Dao().FindParamByKey(models.ParamAppSettings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(param.Value), "pbsecret:test2:") {
+		t.Fatalf("Expected the settings to be re-encrypted with test2, got %q", param.Value)
+	}
+
+	// switch back to plain settings
+	app.SetSecretsBackend(nil, backend2)
+	if err := app.RefreshSettings(); err != nil {
+		t.Fatal(err)
+	}
+
+	stored, err := app.Dao().FindSettings()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Meta.AppName != "secrets_test" {
+		t.Fatalf("Expected app name %q, got %q", "secrets_test", stored.Meta.AppName)
+	}
+}
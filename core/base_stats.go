@@ -0,0 +1,243 @@
+package core
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/cron"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// StatsDateLayout is the date layout of the daily stats rollups.
+const StatsDateLayout = "2006-01-02"
+
+// collectionsUrlPrefix is the url prefix of the collection scoped api requests.
+const collectionsUrlPrefix = "/api/collections/"
+
+// RollupDailyStats calculates and stores in the logs db the
+// daily stats of the UTC day of the specified time.
+//
+// The snapshot metrics (the storage size and the realtime clients peak)
+// are stored only if the day is the current one.
+//
+// It is safe to call the method multiple times for the same day
+// (the existing rollup values are replaced).
+func (app *BaseApp) RollupDailyStats(day time.Time) error {
+	day = day.UTC()
+	start := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 1)
+	date := start.Format(StatsDateLayout)
+
+	from, _ := types.ParseDateTime(start)
+	to, _ := types.ParseDateTime(end)
+
+	if err := app.rollupSignupsStats(date, from, to); err != nil {
+		return fmt.Errorf("signups rollup failed: %w", err)
+	}
+
+	if err := app.rollupRequestsStats(date, from, to); err != nil {
+		return fmt.Errorf("requests rollup failed: %w", err)
+	}
+
+	if date != time.Now().UTC().Format(StatsDateLayout) {
+		return nil
+	}
+
+	// snapshot metrics
+	// ---
+	dataSize, err := dirSize(app.DataDir())
+	if err != nil {
+		return fmt.Errorf("data dir size calculation failed: %w", err)
+	}
+	if err := app.LogsDao().SaveStatsDaily(date, models.StatsMetricStorage, "data", float64(dataSize)); err != nil {
+		return err
+	}
+
+	// the local storage is part of the data dir
+	if app.Settings().S3.Enabled {
+		filesSize, err := app.storageFilesSize()
+		if err != nil {
+			return fmt.Errorf("storage files size calculation failed: %w", err)
+		}
+		if err := app.LogsDao().SaveStatsDaily(date, models.StatsMetricStorage, "files", float64(filesSize)); err != nil {
+			return err
+		}
+	}
+
+	totalClients := len(app.SubscriptionsBroker().Clients())
+
+	return app.LogsDao().SaveStatsDailyMax(date, models.StatsMetricRealtimeClients, "", float64(totalClients))
+}
+
+// rollupSignupsStats stores the number of the created records
+// of each auth collection within the specified period.
+func (app *BaseApp) rollupSignupsStats(date string, from types.DateTime, to types.DateTime) error {
+	collections, err := app.Dao().FindCollectionsByType(models.CollectionTypeAuth)
+	if err != nil {
+		return err
+	}
+
+	for _, collection := range collections {
+		var total int
+
+		err := app.Dao().RecordQuery(collection).
+			Select("count(*)").
+			AndWhere(dbx.NewExp(
+				fmt.Sprintf("[[%s.created]] >= {:from} AND [[%s.created]] < {:to}", collection.Name, collection.Name),
+				dbx.Params{"from": from.String(), "to": to.String()},
+			)).
+			Row(&total)
+		if err != nil {
+			return err
+		}
+
+		if err := app.LogsDao().SaveStatsDaily(date, models.StatsMetricSignups, collection.Name, float64(total)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// rollupRequestsStats stores the total number of the logged requests
+// and of the ones with 5xx status within the specified period
+// (both globally and for each collection).
+func (app *BaseApp) rollupRequestsStats(date string, from types.DateTime, to types.DateTime) error {
+	rows := []struct {
+		Url    string `db:"url"`
+		Total  int    `db:"total"`
+		Failed int    `db:"failed"`
+	}{}
+
+	// group the collection scoped requests by their url collection segment
+	err := app.LogsDao().DB().NewQuery(`
+		SELECT
+			CASE WHEN [[url]] LIKE {:prefix} || '%'
+				THEN substr([[url]], 1, length({:prefix}) + instr(substr([[url]], length({:prefix}) + 1) || '/', '/') - 1)
+				ELSE ''
+			END AS [[url]],
+			count(*) AS [[total]],
+			count(CASE WHEN [[status]] >= 500 THEN 1 END) AS [[failed]]
+		FROM (
+			SELECT
+				json_extract([[data]], '$.url') AS [[url]],
+				json_extract([[data]], '$.status') AS [[status]]
+			FROM {{_logs}}
+			WHERE json_extract([[data]], '$.type') = 'request' AND [[created]] >= {:from} AND [[created]] < {:to}
+		)
+		GROUP BY 1
+	`).Bind(dbx.Params{
+		"prefix": collectionsUrlPrefix,
+		"from":   from.String(),
+		"to":     to.String(),
+	}).All(&rows)
+	if err != nil {
+		return err
+	}
+
+	var total, failed int
+	collectionsTotal := map[string]int{}
+	collectionsFailed := map[string]int{}
+
+	for _, row := range rows {
+		total += row.Total
+		failed += row.Failed
+
+		nameOrId, _, _ := strings.Cut(strings.TrimPrefix(row.Url, collectionsUrlPrefix), "?")
+		if nameOrId == "" {
+			continue
+		}
+
+		// normalize the collection identifier (it could be the id or the name)
+		collection, err := app.Dao().FindCollectionByNameOrId(nameOrId)
+		if err != nil {
+			continue // missing or deleted collection
+		}
+
+		collectionsTotal[collection.Name] += row.Total
+		collectionsFailed[collection.Name] += row.Failed
+	}
+
+	if err := app.LogsDao().SaveStatsDaily(date, models.StatsMetricRequests, "", float64(total)); err != nil {
+		return err
+	}
+
+	if err := app.LogsDao().SaveStatsDaily(date, models.StatsMetricErrors, "", float64(failed)); err != nil {
+		return err
+	}
+
+	for name, total := range collectionsTotal {
+		if err := app.LogsDao().SaveStatsDaily(date, models.StatsMetricRequests, name, float64(total)); err != nil {
+			return err
+		}
+
+		if err := app.LogsDao().SaveStatsDaily(date, models.StatsMetricErrors, name, float64(collectionsFailed[name])); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// storageFilesSize returns the total size in bytes of the storage files.
+func (app *BaseApp) storageFilesSize() (int64, error) {
+	fsys, err := app.NewFilesystem()
+	if err != nil {
+		return 0, err
+	}
+	defer fsys.Close()
+
+	objects, err := fsys.List("")
+	if err != nil {
+		return 0, err
+	}
+
+	var total int64
+	for _, obj := range objects {
+		total += obj.Size
+	}
+
+	return total, nil
+}
+
+// initStatsHooks registers the hourly daily stats rollup cron app hooks.
+func (app *BaseApp) initStatsHooks() error {
+	c := cron.New()
+
+	rollup := func() {
+		now := time.Now().UTC()
+
+		// finalize the previous day rollup after midnight
+		days := []time.Time{now}
+		if now.Hour() == 0 {
+			days = append(days, now.AddDate(0, 0, -1))
+		}
+
+		for _, day := range days {
+			if err := app.RollupDailyStats(day); err != nil {
+				app.Logger().Error(
+					"[Stats cron] Failed to rollup the daily stats",
+					slog.String("date", day.Format(StatsDateLayout)),
+					slog.String("error", err.Error()),
+				)
+			}
+		}
+	}
+
+	app.OnBeforeServe().Add(func(e *ServeEvent) error {
+		c.Add("@statsRollup", "@hourly", rollup)
+		c.Start()
+		return nil
+	})
+
+	app.OnTerminate().Add(func(e *TerminateEvent) error {
+		c.Stop()
+		return nil
+	})
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package core_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestRollupDailyStats(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	_, err := app.LogsDB().NewQuery(`
+		delete from {{_logs}};
+
+		insert into {{_logs}} ([[id]], [[level]], [[message]], [[data]], [[created]], [[updated]])
+		values
+		("log1", 0, "test", '{"type":"request","url":"/api/collections/demo2/records","status":200}', "2022-10-10 10:00:00.000Z", "2022-10-10 10:00:00.000Z"),
+		("log2", 0, "test", '{"type":"request","url":"/api/collections/sz5l5z67tg7gku0/records/test","status":500}', "2022-10-10 11:00:00.000Z", "2022-10-10 11:00:00.000Z"),
+		("log3", 0, "test", '{"type":"request","url":"/api/collections/users?page=2","status":400}', "2022-10-10 12:00:00.000Z", "2022-10-10 12:00:00.000Z"),
+		("log4", 0, "test", '{"type":"request","url":"/api/health","status":503}', "2022-10-10 13:00:00.000Z", "2022-10-10 13:00:00.000Z"),
+		("log5", 0, "test", '{"type":"request","url":"/api/collections/missing/records","status":404}', "2022-10-10 14:00:00.000Z", "2022-10-10 14:00:00.000Z"),
+		("log6", 0, "test", '{"type":"recording","url":"/api/collections/demo2/records","status":200}', "2022-10-10 15:00:00.000Z", "2022-10-10 15:00:00.000Z"),
+		("log7", 0, "test", '{"type":"request","url":"/api/collections/demo2/records","status":200}', "2022-10-11 10:00:00.000Z", "2022-10-11 10:00:00.000Z");
+	`).Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	day, _ := time.Parse(time.RFC3339, "2022-10-10T18:00:00Z")
+
+	// run twice to ensure that the rollup values are replaced
+	for i := 0; i < 2; i++ {
+		if err := app.RollupDailyStats(day); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	items, err := app.LogsDao().FindStatsDaily("2022-10-01", "2022-10-31")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := map[string]float64{}
+	for _, item := range items {
+		if item.Date != "2022-10-10" {
+			t.Fatalf("Unexpected rollup date %q", item.Date)
+		}
+		result[item.Metric+"."+item.Key] = item.Value
+	}
+
+	expected := map[string]float64{
+		models.StatsMetricSignups + ".users":   3,
+		models.StatsMetricSignups + ".clients": 0,
+		models.StatsMetricSignups + ".nologin": 0,
+		models.StatsMetricRequests + ".":       5,
+		models.StatsMetricErrors + ".":         2,
+		models.StatsMetricRequests + ".demo2":  2,
+		models.StatsMetricErrors + ".demo2":    1,
+		models.StatsMetricRequests + ".users":  1,
+		models.StatsMetricErrors + ".users":    0,
+	}
+
+	rawExpected, _ := json.Marshal(expected)
+	rawResult, _ := json.Marshal(result)
+	if string(rawExpected) != string(rawResult) {
+		t.Fatalf("Expected stats\n%s\ngot\n%s", rawExpected, rawResult)
+	}
+}
+
+func TestRollupDailyStatsSnapshotMetrics(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	now := time.Now().UTC()
+
+	if err := app.RollupDailyStats(now); err != nil {
+		t.Fatal(err)
+	}
+
+	date := now.Format("2006-01-02")
+
+	items, err := app.LogsDao().FindStatsDaily(date, date, models.StatsMetricStorage, models.StatsMetricRealtimeClients)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(items) != 2 {
+		t.Fatalf("Expected 2 snapshot stats, got %d", len(items))
+	}
+
+	for _, item := range items {
+		switch item.Metric {
+		case models.StatsMetricStorage:
+			if item.Key != "data" || item.Value <= 0 {
+				t.Fatalf("Invalid storage stats %v", item)
+			}
+		case models.StatsMetricRealtimeClients:
+			if item.Value != 0 {
+				t.Fatalf("Expected 0 realtime clients, got %v", item.Value)
+			}
+		}
+	}
+}
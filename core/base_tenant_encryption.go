@@ -0,0 +1,334 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tools/routine"
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+// encryptedValuePrefix is the prefix of the stored encrypted field values.
+const encryptedValuePrefix = "enc:"
+
+func (app *BaseApp) initTenantEncryptionHooks() {
+	encrypt := func(e *ModelEvent) error {
+		record, ok := e.Model.(*models.Record)
+		if !ok {
+			return nil
+		}
+
+		return app.encryptTenantRecord(e.Dao, record)
+	}
+
+	app.OnModelBeforeCreate().Add(encrypt)
+	app.OnModelBeforeUpdate().Add(encrypt)
+
+	// copy the record files to the new tenant files path
+	// (before the new files upload) on tenant change
+	app.OnModelBeforeUpdate().Add(func(e *ModelEvent) error {
+		record, ok := e.Model.(*models.Record)
+		if !ok {
+			return nil
+		}
+
+		oldPath, newPath := changedTenantFilesPaths(record)
+		if oldPath == newPath {
+			return nil
+		}
+
+		fs, err := app.NewFilesystem()
+		if err != nil {
+			return err
+		}
+		defer fs.Close()
+
+		files, err := fs.List(oldPath + "/")
+		if err != nil {
+			return err
+		}
+
+		for _, file := range files {
+			if err := fs.Copy(file.Key, newPath+"/"+strings.TrimPrefix(file.Key, oldPath+"/")); err != nil {
+				return fmt.Errorf("failed to copy the record file %q to the new tenant files path: %w", file.Key, err)
+			}
+		}
+
+		return nil
+	})
+
+	// delete the old tenant record files after the tenant change was persisted
+	app.OnModelAfterUpdate().Add(func(e *ModelEvent) error {
+		record, ok := e.Model.(*models.Record)
+		if !ok {
+			return nil
+		}
+
+		oldPath, newPath := changedTenantFilesPaths(record)
+		if oldPath == newPath {
+			return nil
+		}
+
+		// run in the background for "optimistic" delete to avoid
+		// blocking the update transaction
+		routine.FireAndForget(func() {
+			fs, err := app.NewFilesystem()
+			if err != nil {
+				app.Logger().Error("Failed to init the filesystem", slog.String("error", err.Error()))
+				return
+			}
+			defer fs.Close()
+
+			if failed := fs.DeletePrefix(oldPath + "/"); len(failed) > 0 {
+				app.Logger().Error(
+					"Failed to delete the old tenant record files",
+					slog.String("prefix", oldPath),
+					slog.String("error", errors.Join(failed...).Error()),
+				)
+			}
+		})
+
+		return nil
+	})
+}
+
+// changedTenantFilesPaths returns the old and the new storage dir paths
+// of the provided existing record with file fields.
+//
+// The returned paths are different only if the record tenant was changed.
+func changedTenantFilesPaths(record *models.Record) (string, string) {
+	if record.IsNew() || record.Collection().TenantField() == "" || !hasFileFields(record.Collection()) {
+		return "", ""
+	}
+
+	return record.OriginalCopy().BaseFilesPath(), record.BaseFilesPath()
+}
+
+// encryptedFields returns the collection text fields marked as encrypted.
+func encryptedFields(collection *models.Collection) []*schema.SchemaField {
+	var result []*schema.SchemaField
+
+	for _, f := range collection.Schema.Fields() {
+		if opt, ok := f.Options.(*schema.TextOptions); ok && opt.Encrypted {
+			result = append(result, f)
+		}
+	}
+
+	return result
+}
+
+// encryptTenantRecord encrypts the record encrypted fields
+// with the key of the record tenant.
+//
+// Values with the reserved encrypted prefix are left as they are only if
+// they are unchanged from the persisted ones or they are valid ciphertexts
+// of the record tenant, otherwise an error is returned.
+//
+// If the tenant of an existing record was changed, its unchanged persisted
+// ciphertexts are decrypted with the old tenant key and encrypted again
+// with the new one.
+func (app *BaseApp) encryptTenantRecord(dao *daos.Dao, record *models.Record) error {
+	fields := encryptedFields(record.Collection())
+	if len(fields) == 0 {
+		return nil
+	}
+
+	tenantField := record.Collection().TenantField()
+
+	var original *models.Record
+	if !record.IsNew() {
+		original = record.OriginalCopy()
+	}
+
+	var key string
+
+	loadKey := func(f *schema.SchemaField) error {
+		if key != "" {
+			return nil
+		}
+
+		tenantId := record.GetString(tenantField)
+		if tenantId == "" {
+			return fmt.Errorf("missing tenant for the encrypted field %q", f.Name)
+		}
+
+		var err error
+		key, err = app.TenantKeyProvider().TenantKey(dao, tenantId, true)
+		if err != nil {
+			return fmt.Errorf("failed to load the tenant key: %w", err)
+		}
+
+		return nil
+	}
+
+	var oldKey string
+
+	loadOldKey := func(f *schema.SchemaField) error {
+		if oldKey != "" {
+			return nil
+		}
+
+		var err error
+		oldKey, err = app.TenantKeyProvider().TenantKey(dao, original.GetString(tenantField), false)
+		if err != nil {
+			return fmt.Errorf("failed to load the old tenant key of the encrypted field %q: %w", f.Name, err)
+		}
+
+		return nil
+	}
+
+	for _, f := range fields {
+		value := record.GetString(f.Name)
+		if value == "" {
+			continue
+		}
+
+		if strings.HasPrefix(value, encryptedValuePrefix) {
+			if original == nil || value != original.GetString(f.Name) {
+				if err := loadKey(f); err != nil {
+					return err
+				}
+
+				if _, err := security.Decrypt(strings.TrimPrefix(value, encryptedValuePrefix), key); err != nil {
+					return fmt.Errorf("the encrypted field %q value cannot start with the reserved %q prefix", f.Name, encryptedValuePrefix)
+				}
+
+				continue // already encrypted
+			}
+
+			if record.GetString(tenantField) == original.GetString(tenantField) {
+				continue // unchanged persisted ciphertext
+			}
+
+			// the tenant was changed -> decrypt with the old tenant key
+			// so that the value could be encrypted with the new one
+			if err := loadOldKey(f); err != nil {
+				return err
+			}
+
+			decrypted, err := security.Decrypt(strings.TrimPrefix(value, encryptedValuePrefix), oldKey)
+			if err != nil {
+				return fmt.Errorf("failed to decrypt the encrypted field %q with the old tenant key: %w", f.Name, err)
+			}
+
+			value = string(decrypted)
+		}
+
+		if err := loadKey(f); err != nil {
+			return err
+		}
+
+		encrypted, err := security.Encrypt([]byte(value), key)
+		if err != nil {
+			return err
+		}
+
+		record.Set(f.Name, encryptedValuePrefix+encrypted)
+	}
+
+	return nil
+}
+
+// decryptTenantRecords decrypts the encrypted fields of the provided records.
+//
+// Values that cannot be decrypted (eg. because the tenant was shredded) are cleared.
+func (app *BaseApp) decryptTenantRecords(dao *daos.Dao, records []*models.Record) error {
+	// cache the keys in case of multiple records from the same tenant
+	keys := map[string]string{}
+
+	for _, record := range records {
+		fields := encryptedFields(record.Collection())
+		if len(fields) == 0 {
+			continue
+		}
+
+		tenantId := record.GetString(record.Collection().TenantField())
+
+		key, ok := keys[tenantId]
+		if !ok && tenantId != "" {
+			var err error
+			key, err = app.TenantKeyProvider().TenantKey(dao, tenantId, false)
+			if err != nil && !errors.Is(err, ErrTenantKeyNotFound) {
+				return err
+			}
+			keys[tenantId] = key
+		}
+
+		for _, f := range fields {
+			value := record.GetString(f.Name)
+			if !strings.HasPrefix(value, encryptedValuePrefix) {
+				continue // not encrypted
+			}
+
+			if key == "" {
+				record.Set(f.Name, "")
+				continue
+			}
+
+			decrypted, err := security.Decrypt(strings.TrimPrefix(value, encryptedValuePrefix), key)
+			if err != nil {
+				record.Set(f.Name, "")
+				continue
+			}
+
+			record.Set(f.Name, string(decrypted))
+		}
+	}
+
+	return nil
+}
+
+// ShredTenant makes the data of the specified tenant unrecoverable
+// by permanently deleting its encryption key and the tenant files
+// storage prefix of the tenant aware collections.
+//
+// The tenant records are not deleted but their encrypted field
+// values will be no longer readable.
+func (app *BaseApp) ShredTenant(tenantId string) error {
+	if tenantId == "" {
+		return errors.New("missing tenant id")
+	}
+
+	collections := []*models.Collection{}
+	if err := app.Dao().CollectionQuery().
+		AndWhere(dbx.NewExp("[[type]] != {:view}", dbx.Params{"view": models.CollectionTypeView})).
+		All(&collections); err != nil {
+		return err
+	}
+
+	fs, err := app.NewFilesystem()
+	if err != nil {
+		return err
+	}
+	defer fs.Close()
+
+	for _, collection := range collections {
+		if collection.TenantField() == "" || !hasFileFields(collection) {
+			continue
+		}
+
+		prefix := collection.TenantFilesPath(tenantId) + "/"
+
+		if failed := fs.DeletePrefix(prefix); len(failed) > 0 {
+			return fmt.Errorf("failed to delete the tenant files at %q: %w", prefix, errors.Join(failed...))
+		}
+	}
+
+	return app.TenantKeyProvider().DeleteTenantKey(app.Dao(), tenantId)
+}
+
+func hasFileFields(collection *models.Collection) bool {
+	for _, f := range collection.Schema.Fields() {
+		if f.IsFile() {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,284 @@
+package core_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+type mockTenantKeyProvider struct {
+	keys map[string]string
+}
+
+func (p *mockTenantKeyProvider) TenantKey(dao *daos.Dao, tenantId string, generate bool) (string, error) {
+	key, ok := p.keys[tenantId]
+	if !ok {
+		if !generate {
+			return "", core.ErrTenantKeyNotFound
+		}
+		key = strings.Repeat(tenantId[:1], 32)
+		p.keys[tenantId] = key
+	}
+	return key, nil
+}
+
+func (p *mockTenantKeyProvider) DeleteTenantKey(dao *daos.Dao, tenantId string) error {
+	delete(p.keys, tenantId)
+	return nil
+}
+
+func createTenantCollection(t *testing.T, app *tests.TestApp) *models.Collection {
+	collection := &models.Collection{
+		Name: "tenant_notes",
+		Type: models.CollectionTypeBase,
+		Schema: schema.NewSchema(
+			&schema.SchemaField{
+				Name:    "tenant",
+				Type:    schema.FieldTypeText,
+				Options: &schema.TextOptions{},
+			},
+			&schema.SchemaField{
+				Name:    "secret",
+				Type:    schema.FieldTypeText,
+				Options: &schema.TextOptions{Encrypted: true},
+			},
+			&schema.SchemaField{
+				Name:    "file",
+				Type:    schema.FieldTypeFile,
+				Options: &schema.FileOptions{MaxSelect: 1, MaxSize: 100},
+			},
+		),
+	}
+	collection.SetOptions(models.CollectionBaseOptions{TenantField: "tenant"})
+
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	return collection
+}
+
+func TestTenantFieldsEncryption(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := createTenantCollection(t, app)
+
+	record := models.NewRecord(collection)
+	record.Set("tenant", "t1")
+	record.Set("secret", "test")
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	// missing tenant
+	invalid := models.NewRecord(collection)
+	invalid.Set("secret", "test")
+	if err := app.Dao().SaveRecord(invalid); err == nil {
+		t.Fatal("Expected missing tenant error, got nil")
+	}
+
+	stored, err := app.Dao().FindRecordById(collection.Id, record.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	storedSecret := stored.GetString("secret")
+	if !strings.HasPrefix(storedSecret, "enc:") || strings.Contains(storedSecret, "test") {
+		t.Fatalf("Expected the stored secret to be encrypted, got %q", storedSecret)
+	}
+
+	// resave shouldn't double encrypt
+	if err := app.Dao().SaveRecord(stored); err != nil {
+		t.Fatal(err)
+	}
+	if v := stored.GetString("secret"); v != storedSecret {
+		t.Fatalf("Expected the secret to remain %q, got %q", storedSecret, v)
+	}
+
+	// plain value with the reserved encrypted prefix
+	prefixed := models.NewRecord(collection)
+	prefixed.Set("tenant", "t1")
+	prefixed.Set("secret", "enc:test")
+	if err := app.Dao().SaveRecord(prefixed); err == nil {
+		t.Fatal("Expected the reserved prefix value to be rejected, got nil")
+	}
+
+	// ciphertext copied from another tenant
+	otherTenant := models.NewRecord(collection)
+	otherTenant.Set("tenant", "t2")
+	otherTenant.Set("secret", storedSecret)
+	if err := app.Dao().SaveRecord(otherTenant); err == nil {
+		t.Fatal("Expected the other tenant ciphertext to be rejected, got nil")
+	}
+
+	// ciphertext copied from the same tenant
+	sameTenant := models.NewRecord(collection)
+	sameTenant.Set("tenant", "t1")
+	sameTenant.Set("secret", storedSecret)
+	if err := app.Dao().SaveRecord(sameTenant); err != nil {
+		t.Fatalf("Expected the same tenant ciphertext to be saved, got %v", err)
+	}
+	if v := sameTenant.GetString("secret"); v != storedSecret {
+		t.Fatalf("Expected the copied secret to remain %q, got %q", storedSecret, v)
+	}
+
+	if err := app.Dao().DecryptRecords([]*models.Record{stored}); err != nil {
+		t.Fatal(err)
+	}
+	if v := stored.GetString("secret"); v != "test" {
+		t.Fatalf("Expected the decrypted secret to be %q, got %q", "test", v)
+	}
+
+	// shred the tenant and check whether the secret is still readable
+	if err := app.ShredTenant("t1"); err != nil {
+		t.Fatal(err)
+	}
+
+	shredded, err := app.Dao().FindRecordById(collection.Id, record.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := app.Dao().DecryptRecords([]*models.Record{shredded}); err != nil {
+		t.Fatal(err)
+	}
+	if v := shredded.GetString("secret"); v != "" {
+		t.Fatalf("Expected the shredded secret to be empty, got %q", v)
+	}
+}
+
+func TestTenantChange(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := createTenantCollection(t, app)
+
+	fs, err := app.NewFilesystem()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fs.Close()
+
+	record := models.NewRecord(collection)
+	record.RefreshId()
+	record.Set("tenant", "t1")
+	record.Set("secret", "test")
+	record.Set("file", "test.txt")
+	if err := fs.Upload([]byte("test"), record.BaseFilesPath()+"/test.txt"); err != nil {
+		t.Fatal(err)
+	}
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	oldFilesPath := record.BaseFilesPath()
+	if !strings.HasPrefix(oldFilesPath, collection.TenantFilesPath("t1")+"/") {
+		t.Fatalf("Expected the record files path to be under the tenant files path, got %q", oldFilesPath)
+	}
+
+	// change the tenant of the persisted record
+	stored, err := app.Dao().FindRecordById(collection.Id, record.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored.Set("tenant", "t2")
+	if err := app.Dao().SaveRecord(stored); err != nil {
+		t.Fatal(err)
+	}
+
+	// the secret should be reencrypted with the new tenant key
+	moved, err := app.Dao().FindRecordById(collection.Id, record.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := app.Dao().DecryptRecords([]*models.Record{moved}); err != nil {
+		t.Fatal(err)
+	}
+	if v := moved.GetString("secret"); v != "test" {
+		t.Fatalf("Expected the reencrypted secret to be %q, got %q", "test", v)
+	}
+
+	// the files should be moved to the new tenant files path
+	newFilesPath := moved.BaseFilesPath()
+	if !strings.HasPrefix(newFilesPath, collection.TenantFilesPath("t2")+"/") {
+		t.Fatalf("Expected the record files path to be under the new tenant files path, got %q", newFilesPath)
+	}
+	if exists, _ := fs.Exists(newFilesPath + "/test.txt"); !exists {
+		t.Fatal("Expected the file to be copied to the new tenant files path")
+	}
+
+	// wait for the async delete of the old files
+	for i := 0; i < 50; i++ {
+		if exists, _ := fs.Exists(oldFilesPath + "/test.txt"); !exists {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if exists, _ := fs.Exists(oldFilesPath + "/test.txt"); exists {
+		t.Fatal("Expected the old tenant file to be deleted")
+	}
+
+	// shredding the new tenant should delete its files
+	if err := app.ShredTenant("t2"); err != nil {
+		t.Fatal(err)
+	}
+	if exists, _ := fs.Exists(newFilesPath + "/test.txt"); exists {
+		t.Fatal("Expected the shredded tenant file to be deleted")
+	}
+
+	// changing the tenant of a shredded tenant record
+	// (aka. the old ciphertexts can no longer be decrypted)
+	shredded, err := app.Dao().FindRecordById(collection.Id, record.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	shredded.Set("tenant", "t3")
+	if err := app.Dao().SaveRecord(shredded); err == nil {
+		t.Fatal("Expected the shredded tenant record change to fail")
+	}
+}
+
+func TestSetTenantKeyProvider(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	provider := &mockTenantKeyProvider{keys: map[string]string{}}
+	app.SetTenantKeyProvider(provider)
+
+	if app.TenantKeyProvider() != provider {
+		t.Fatal("Expected the custom tenant key provider to be set")
+	}
+
+	collection := createTenantCollection(t, app)
+
+	record := models.NewRecord(collection)
+	record.Set("tenant", "abc")
+	record.Set("secret", "test")
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := provider.keys["abc"]; !ok {
+		t.Fatal("Expected the tenant key to be generated by the custom provider")
+	}
+
+	if err := app.Dao().DecryptRecords([]*models.Record{record}); err != nil {
+		t.Fatal(err)
+	}
+	if v := record.GetString("secret"); v != "test" {
+		t.Fatalf("Expected the decrypted secret to be %q, got %q", "test", v)
+	}
+
+	if err := app.ShredTenant("abc"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := provider.keys["abc"]; ok {
+		t.Fatal("Expected the tenant key to be deleted")
+	}
+}
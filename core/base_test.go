@@ -22,11 +22,16 @@ func TestNewBaseApp(t *testing.T) {
 	defer os.RemoveAll(testDataDir)
 
 	app := NewBaseApp(BaseAppConfig{
+		Version:       "v1.2.3",
 		DataDir:       testDataDir,
 		EncryptionEnv: "test_env",
 		IsDev:         true,
 	})
 
+	if app.Version() != "v1.2.3" {
+		t.Fatalf("expected version v1.2.3, got %q", app.Version())
+	}
+
 	if app.dataDir != testDataDir {
 		t.Fatalf("expected dataDir %q, got %q", testDataDir, app.dataDir)
 	}
@@ -503,6 +508,80 @@ func TestBaseAppLoggerLevelDevPrint(t *testing.T) {
 	}
 }
 
+func TestBaseAppOnRecordAfterAnyWrite(t *testing.T) {
+	app, cleanup, err := initTestBaseApp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cleanup()
+
+	actions := []string{}
+
+	app.OnRecordAfterAnyWrite("users").Add(func(e *RecordWriteEvent) error {
+		if e.Record == nil || e.Dao == nil {
+			t.Fatalf("Expected non-nil Record and Dao, got %v and %v", e.Record, e.Dao)
+		}
+		actions = append(actions, e.Action)
+		return nil
+	})
+
+	app.OnRecordAfterAnyWrite("missing").Add(func(e *RecordWriteEvent) error {
+		t.Fatal("The missing tagged handler shouldn't be called")
+		return nil
+	})
+
+	collection, err := app.Dao().FindCollectionByNameOrId("users")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	record := models.NewRecord(collection)
+	record.SetUsername("test_any_write")
+	record.SetEmail("test_any_write@example.com")
+	record.SetPassword("1234567890")
+
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.Dao().DeleteRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	// non-record models shouldn't trigger the hook
+	if err := app.Dao().SaveParam("test_any_write", 1); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{RecordWriteActionCreate, RecordWriteActionUpdate, RecordWriteActionDelete}
+
+	if len(actions) != len(expected) {
+		t.Fatalf("Expected actions %v, got %v", expected, actions)
+	}
+
+	for i, a := range expected {
+		if actions[i] != a {
+			t.Fatalf("Expected actions %v, got %v", expected, actions)
+		}
+	}
+}
+
+func TestBaseAppDefaultHookIds(t *testing.T) {
+	app, cleanup, err := initTestBaseApp()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cleanup()
+
+	if !app.onModelAfterDelete.Has(DefaultHookIdDeleteStorageFiles) {
+		t.Fatalf("Expected %q handler to be registered", DefaultHookIdDeleteStorageFiles)
+	}
+}
+
 // -------------------------------------------------------------------
 
 // note: make sure to call `defer cleanup()` when the app is no longer needed.
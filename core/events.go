@@ -13,6 +13,7 @@ import (
 	"github.com/pocketbase/pocketbase/tools/filesystem"
 	"github.com/pocketbase/pocketbase/tools/hook"
 	"github.com/pocketbase/pocketbase/tools/mailer"
+	"github.com/pocketbase/pocketbase/tools/router"
 	"github.com/pocketbase/pocketbase/tools/search"
 	"github.com/pocketbase/pocketbase/tools/subscriptions"
 	"golang.org/x/crypto/acme/autocert"
@@ -75,8 +76,17 @@ type TerminateEvent struct {
 }
 
 type ServeEvent struct {
-	App         App
-	Router      *echo.Echo
+	App App
+
+	// Router is the underlying echo router instance.
+	//
+	// For new routes prefer HttpRouter since echo is going to be
+	// replaced with a net/http based implementation.
+	Router *echo.Echo
+
+	// HttpRouter is a framework agnostic wrapper of the app router.
+	HttpRouter router.Router
+
 	Server      *http.Server
 	CertManager *autocert.Manager
 }
@@ -96,6 +106,58 @@ type ModelEvent struct {
 	Dao *daos.Dao
 }
 
+// -------------------------------------------------------------------
+// Record write events data
+// -------------------------------------------------------------------
+
+const (
+	RecordWriteActionCreate = "create"
+	RecordWriteActionUpdate = "update"
+	RecordWriteActionDelete = "delete"
+)
+
+type RecordWriteEvent struct {
+	BaseCollectionEvent
+
+	Dao    *daos.Dao
+	Record *models.Record
+	Action string // create, update or delete
+}
+
+// -------------------------------------------------------------------
+// Security events data
+// -------------------------------------------------------------------
+
+const (
+	SecurityEventAdminLogin          = "admin_login"
+	SecurityEventAuthFailure         = "auth_failure"
+	SecurityEventRuleChange          = "rule_change"
+	SecurityEventTokenSecretRotation = "token_secret_rotation"
+
+	// anomaly events (emitted only if the security events are enabled)
+	SecurityEventAuthFailureBurst = "auth_failure_burst"
+	SecurityEventAdminNewIp       = "admin_new_ip"
+	SecurityEventImpossibleTravel = "impossible_travel"
+)
+
+const (
+	SecurityActorAdmin      = "admin"
+	SecurityActorAuthRecord = "authRecord"
+)
+
+// SecurityEvent defines a single structured security event
+// (it is also the json body of the security events webhook request).
+type SecurityEvent struct {
+	Id        string         `json:"id"`
+	Type      string         `json:"type"`
+	Time      time.Time      `json:"time"`
+	Ip        string         `json:"ip"`
+	Country   string         `json:"country"`
+	ActorType string         `json:"actorType"` // admin, authRecord or empty for guests
+	ActorId   string         `json:"actorId"`
+	Data      map[string]any `json:"data"`
+}
+
 // -------------------------------------------------------------------
 // Mailer events data
 // -------------------------------------------------------------------
@@ -200,6 +262,26 @@ type RecordDeleteEvent struct {
 	Record      *models.Record
 }
 
+type RecordSyncConflictEvent struct {
+	BaseCollectionEvent
+
+	HttpContext echo.Context
+
+	// Record is the current server state of the conflicting record.
+	Record *models.Record
+
+	// Data is the client mutation data (could be modified, eg. to merge
+	// the client and server changes before accepting it).
+	Data map[string]any
+
+	// Deleted indicates whether the client mutation is a delete (aka. tombstone).
+	Deleted bool
+
+	// AcceptClient indicates whether the client mutation should be applied
+	// (default to false, aka. the server state wins).
+	AcceptClient bool
+}
+
 // -------------------------------------------------------------------
 // Auth Record API events data
 // -------------------------------------------------------------------
@@ -213,6 +295,13 @@ type RecordAuthEvent struct {
 	Meta        any
 }
 
+type RecordAuthTokenIssueEvent struct {
+	BaseCollectionEvent
+
+	Record *models.Record
+	Claims map[string]any
+}
+
 type RecordAuthWithPasswordEvent struct {
 	BaseCollectionEvent
 
@@ -334,6 +423,11 @@ type AdminAuthEvent struct {
 	Token       string
 }
 
+type AdminAuthTokenIssueEvent struct {
+	Admin  *models.Admin
+	Claims map[string]any
+}
+
 type AdminAuthWithPasswordEvent struct {
 	HttpContext echo.Context
 	Admin       *models.Admin
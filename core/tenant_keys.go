@@ -0,0 +1,118 @@
+package core
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"os"
+
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+// ErrTenantKeyNotFound is returned when the requested tenant key doesn't exist
+// (eg. because it was never generated or the tenant data was shredded).
+var ErrTenantKeyNotFound = errors.New("tenant key not found")
+
+// tenantKeyParamPrefix is the prefix of the [models.Param] keys
+// used by the default tenant key provider.
+const tenantKeyParamPrefix = "tenantKey_"
+
+// TenantKeyProvider defines the storage of the per-tenant data
+// encryption keys used for the encrypted collection fields.
+//
+// Implement it in order to use an external KMS (eg. AWS KMS, Vault, etc.)
+// and register it with app.SetTenantKeyProvider().
+//
+// The dao argument is the Dao of the current operation (eg. the
+// transactional Dao when called from a model hook) and should be used
+// for any app db access instead of app.Dao().
+type TenantKeyProvider interface {
+	// TenantKey returns the 32 characters encryption key of the specified tenant.
+	//
+	// If the key doesn't exist and generate is true, a new key is created,
+	// otherwise ErrTenantKeyNotFound is returned.
+	TenantKey(dao *daos.Dao, tenantId string, generate bool) (string, error)
+
+	// DeleteTenantKey permanently deletes the key of the specified tenant,
+	// making the tenant encrypted data unrecoverable.
+	DeleteTenantKey(dao *daos.Dao, tenantId string) error
+}
+
+// paramsTenantKeyProvider is the default [TenantKeyProvider] that stores
+// random generated tenant keys in the app params table.
+//
+// The keys are stored encrypted with the app EncryptionEnv secret (if set).
+//
+// Note that the keys live in the same database (and therefore in the same
+// backups) as the encrypted data, so deleting a key doesn't shred the tenant
+// data from the already existing backups. Register a provider backed by an
+// external KMS if you need crypto-shredding guarantees.
+type paramsTenantKeyProvider struct {
+	app App
+}
+
+type storedTenantKey struct {
+	Key string `json:"key"`
+}
+
+// TenantKey implements [TenantKeyProvider.TenantKey].
+func (p *paramsTenantKeyProvider) TenantKey(dao *daos.Dao, tenantId string, generate bool) (string, error) {
+	if tenantId == "" {
+		return "", errors.New("missing tenant id")
+	}
+
+	encryptionKey := os.Getenv(p.app.EncryptionEnv())
+
+	param, err := dao.FindParamByKey(tenantKeyParamPrefix + tenantId)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return "", err
+	}
+
+	if param != nil {
+		stored := storedTenantKey{}
+
+		// try first without decryption
+		if plainErr := json.Unmarshal(param.Value, &stored); plainErr != nil {
+			if encryptionKey == "" {
+				return "", errors.New("failed to load the tenant key - missing or invalid encryption key")
+			}
+
+			decrypted, err := security.Decrypt(string(param.Value), encryptionKey)
+			if err != nil {
+				return "", err
+			}
+
+			if err := json.Unmarshal(decrypted, &stored); err != nil {
+				return "", err
+			}
+		}
+
+		return stored.Key, nil
+	}
+
+	if !generate {
+		return "", ErrTenantKeyNotFound
+	}
+
+	stored := storedTenantKey{Key: security.RandomString(32)}
+
+	if err := dao.SaveParam(tenantKeyParamPrefix+tenantId, stored, encryptionKey); err != nil {
+		return "", err
+	}
+
+	return stored.Key, nil
+}
+
+// DeleteTenantKey implements [TenantKeyProvider.DeleteTenantKey].
+func (p *paramsTenantKeyProvider) DeleteTenantKey(dao *daos.Dao, tenantId string) error {
+	param, err := dao.FindParamByKey(tenantKeyParamPrefix + tenantId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil // already deleted
+		}
+		return err
+	}
+
+	return dao.DeleteParam(param)
+}
@@ -48,8 +48,11 @@ func (dao *Dao) FindAdminByEmail(email string) (*models.Admin, error) {
 
 // FindAdminByToken finds the admin associated with the provided JWT token.
 //
+// The optional fallbackBaseTokenKeys are tried in case the token signature
+// doesn't match with baseTokenKey (eg. previous secrets during rotation).
+//
 // Returns an error if the JWT token is invalid or expired.
-func (dao *Dao) FindAdminByToken(token string, baseTokenKey string) (*models.Admin, error) {
+func (dao *Dao) FindAdminByToken(token string, baseTokenKey string, fallbackBaseTokenKeys ...string) (*models.Admin, error) {
 	// @todo consider caching the unverified claims
 	unverifiedClaims, err := security.ParseUnverifiedJWT(token)
 	if err != nil {
@@ -67,16 +70,30 @@ func (dao *Dao) FindAdminByToken(token string, baseTokenKey string) (*models.Adm
 		return nil, err
 	}
 
-	verificationKey := admin.TokenKey + baseTokenKey
-
 	// verify token signature
-	if _, err := security.ParseJWT(token, verificationKey); err != nil {
+	if err := verifyTokenSignature(token, admin.TokenKey, baseTokenKey, fallbackBaseTokenKeys...); err != nil {
 		return nil, err
 	}
 
 	return admin, nil
 }
 
+// verifyTokenSignature verifies the JWT token signature with the
+// model tokenKey and the first matching base token key.
+func verifyTokenSignature(token string, tokenKey string, baseTokenKey string, fallbackBaseTokenKeys ...string) error {
+	_, err := security.ParseJWT(token, tokenKey+baseTokenKey)
+
+	for _, fallback := range fallbackBaseTokenKeys {
+		if err == nil {
+			break
+		}
+
+		_, err = security.ParseJWT(token, tokenKey+fallback)
+	}
+
+	return err
+}
+
 // TotalAdmins returns the number of existing admin records.
 func (dao *Dao) TotalAdmins() (int, error) {
 	var total int
@@ -0,0 +1,56 @@
+package daos
+
+import (
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+// ApiKeyQuery returns a new ApiKey select query.
+func (dao *Dao) ApiKeyQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.ApiKey{})
+}
+
+// FindApiKeyById returns a single ApiKey model by its id.
+func (dao *Dao) FindApiKeyById(id string) (*models.ApiKey, error) {
+	model := &models.ApiKey{}
+
+	err := dao.ApiKeyQuery().
+		AndWhere(dbx.HashExp{"id": id}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// FindApiKeyByKey returns the ApiKey model matching the provided plain api key.
+//
+// Note that the returned key could be expired (see [models.ApiKey.IsExpired()]).
+func (dao *Dao) FindApiKeyByKey(key string) (*models.ApiKey, error) {
+	model := &models.ApiKey{}
+
+	err := dao.ApiKeyQuery().
+		AndWhere(dbx.HashExp{"keyHash": security.SHA256(key)}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// SaveApiKey upserts the provided ApiKey model.
+func (dao *Dao) SaveApiKey(model *models.ApiKey) error {
+	return dao.Save(model)
+}
+
+// DeleteApiKey deletes the provided ApiKey model.
+func (dao *Dao) DeleteApiKey(model *models.ApiKey) error {
+	return dao.Delete(model)
+}
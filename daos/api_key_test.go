@@ -0,0 +1,139 @@
+package daos_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestApiKeyQuery(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	expected := "SELECT {{_apiKeys}}.* FROM `_apiKeys`"
+
+	sql := app.Dao().ApiKeyQuery().Build().SQL()
+	if sql != expected {
+		t.Errorf("Expected sql %s, got %s", expected, sql)
+	}
+}
+
+func TestFindApiKeyById(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		id          string
+		expectError bool
+	}{
+		{"", true},
+		{"missing", true},
+		{"ap1k3yf1xtur001", false},
+	}
+
+	for i, s := range scenarios {
+		apiKey, err := app.Dao().FindApiKeyById(s.id)
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("(%d) Expected hasErr %v, got %v (%v)", i, s.expectError, hasErr, err)
+			continue
+		}
+
+		if apiKey != nil && apiKey.Id != s.id {
+			t.Errorf("(%d) Expected api key with id %s, got %s", i, s.id, apiKey.Id)
+		}
+	}
+}
+
+func TestFindApiKeyByKey(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		key         string
+		expectedId  string
+		expectError bool
+	}{
+		{"", "", true},
+		{"pbk_missing", "", true},
+		{"pbk_test", "", true}, // the key hint
+		{"pbk_test1234567890", "ap1k3yf1xtur001", false},
+		{"pbk_expired1234567890", "ap1k3yf1xtur002", false},
+	}
+
+	for i, s := range scenarios {
+		apiKey, err := app.Dao().FindApiKeyByKey(s.key)
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("(%d) Expected hasErr %v, got %v (%v)", i, s.expectError, hasErr, err)
+			continue
+		}
+
+		if apiKey != nil && apiKey.Id != s.expectedId {
+			t.Errorf("(%d) Expected api key with id %s, got %s", i, s.expectedId, apiKey.Id)
+		}
+	}
+}
+
+func TestSaveAndDeleteApiKey(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	apiKey := &models.ApiKey{
+		AdminId: "sywbhecnh46rhm0",
+		Name:    "test",
+		Scopes: []models.ApiKeyScope{
+			{Collection: "demo1", Actions: []string{models.ApiKeyActionList}},
+		},
+	}
+	apiKey.SetKey("pbk_new1234567890")
+
+	if err := app.Dao().SaveApiKey(apiKey); err != nil {
+		t.Fatal(err)
+	}
+
+	saved, err := app.Dao().FindApiKeyByKey("pbk_new1234567890")
+	if err != nil {
+		t.Fatalf("Expected the api key to be saved, got %v", err)
+	}
+
+	if len(saved.Scopes) != 1 || saved.Scopes[0].Collection != "demo1" {
+		t.Fatalf("Expected the api key scopes to be saved, got %v", saved.Scopes)
+	}
+
+	// duplicated key
+	duplicated := &models.ApiKey{AdminId: "sywbhecnh46rhm0", Name: "test2"}
+	duplicated.SetKey("pbk_new1234567890")
+	if err := app.Dao().SaveApiKey(duplicated); err == nil {
+		t.Fatal("Expected the duplicated key to fail")
+	}
+
+	if err := app.Dao().DeleteApiKey(apiKey); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Dao().FindApiKeyById(apiKey.Id); err == nil {
+		t.Fatal("Expected the api key to be deleted")
+	}
+}
+
+func TestDeleteAdminApiKeys(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	admin, err := app.Dao().FindAdminById("sywbhecnh46rhm0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.Dao().DeleteAdmin(admin); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Dao().FindApiKeyById("ap1k3yf1xtur001"); err == nil {
+		t.Fatal("Expected the admin api keys to be deleted")
+	}
+}
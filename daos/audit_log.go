@@ -0,0 +1,42 @@
+package daos
+
+import (
+	"time"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// AuditLogQuery returns a new AuditLog select query.
+func (dao *Dao) AuditLogQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.AuditLog{})
+}
+
+// FindAuditLogById finds a single AuditLog entry by its id.
+func (dao *Dao) FindAuditLogById(id string) (*models.AuditLog, error) {
+	model := &models.AuditLog{}
+
+	err := dao.AuditLogQuery().
+		AndWhere(dbx.HashExp{"id": id}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// DeleteOldAuditLogs deletes all audit log entries created before createdBefore.
+func (dao *Dao) DeleteOldAuditLogs(createdBefore time.Time) error {
+	formattedDate := createdBefore.UTC().Format(types.DefaultDateLayout)
+
+	_, err := dao.NonconcurrentDB().Delete(
+		(&models.AuditLog{}).TableName(),
+		dbx.NewExp("[[created]] <= {:date}", dbx.Params{"date": formattedDate}),
+	).Execute()
+
+	return err
+}
@@ -0,0 +1,86 @@
+package daos
+
+import (
+	"time"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// AuthSessionQuery returns a new AuthSession select query.
+func (dao *Dao) AuthSessionQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.AuthSession{})
+}
+
+// FindAuthSessionById returns a single AuthSession model by its id.
+func (dao *Dao) FindAuthSessionById(id string) (*models.AuthSession, error) {
+	model := &models.AuthSession{}
+
+	err := dao.AuthSessionQuery().
+		AndWhere(dbx.HashExp{"id": id}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// FindAllAuthSessionsByRecord returns all tracked sessions of the
+// provided auth record ordered by their last activity (newest first).
+func (dao *Dao) FindAllAuthSessionsByRecord(authRecord *models.Record) ([]*models.AuthSession, error) {
+	sessions := []*models.AuthSession{}
+
+	err := dao.AuthSessionQuery().
+		AndWhere(dbx.HashExp{
+			"collectionId": authRecord.Collection().Id,
+			"recordId":     authRecord.Id,
+		}).
+		OrderBy("lastSeen DESC", "created DESC").
+		All(&sessions)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// SaveAuthSession upserts the provided AuthSession model.
+func (dao *Dao) SaveAuthSession(model *models.AuthSession) error {
+	return dao.Save(model)
+}
+
+// DeleteAuthSession deletes (aka. revokes) the provided AuthSession model.
+func (dao *Dao) DeleteAuthSession(model *models.AuthSession) error {
+	return dao.Delete(model)
+}
+
+// DeleteAllAuthSessionsByRecord deletes all tracked sessions of the provided auth record.
+func (dao *Dao) DeleteAllAuthSessionsByRecord(authRecord *models.Record) error {
+	_, err := dao.NonconcurrentDB().Delete((&models.AuthSession{}).TableName(), dbx.HashExp{
+		"collectionId": authRecord.Collection().Id,
+		"recordId":     authRecord.Id,
+	}).Execute()
+
+	return err
+}
+
+// DeleteOldAuthSessions deletes all sessions of the specified
+// collection that were last seen before lastSeenBefore.
+func (dao *Dao) DeleteOldAuthSessions(collectionId string, lastSeenBefore time.Time) error {
+	formattedDate := lastSeenBefore.UTC().Format(types.DefaultDateLayout)
+
+	_, err := dao.NonconcurrentDB().Delete(
+		(&models.AuthSession{}).TableName(),
+		dbx.And(
+			dbx.HashExp{"collectionId": collectionId},
+			dbx.NewExp("[[lastSeen]] <= {:date}", dbx.Params{"date": formattedDate}),
+		),
+	).Execute()
+
+	return err
+}
@@ -0,0 +1,145 @@
+package daos_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func createTestAuthSession(t *testing.T, app *tests.TestApp, recordId string, lastSeen time.Time) *models.AuthSession {
+	session := &models.AuthSession{
+		CollectionId: "_pb_users_auth_",
+		RecordId:     recordId,
+		UserAgent:    "test",
+		Ip:           "127.0.0.1",
+	}
+	session.LastSeen, _ = types.ParseDateTime(lastSeen)
+
+	if err := app.Dao().SaveAuthSession(session); err != nil {
+		t.Fatal(err)
+	}
+
+	return session
+}
+
+func TestAuthSessionQuery(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	expected := "SELECT {{_authSessions}}.* FROM `_authSessions`"
+
+	sql := app.Dao().AuthSessionQuery().Build().SQL()
+	if sql != expected {
+		t.Errorf("Expected sql %s, got %s", expected, sql)
+	}
+}
+
+func TestFindAuthSessionById(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	session := createTestAuthSession(t, app, "4q1xlclmfloku33", time.Now())
+
+	scenarios := []struct {
+		id          string
+		expectError bool
+	}{
+		{"", true},
+		{"missing", true},
+		{session.Id, false},
+	}
+
+	for i, s := range scenarios {
+		result, err := app.Dao().FindAuthSessionById(s.id)
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Fatalf("[%d] Expected hasErr %v, got %v (%v)", i, s.expectError, hasErr, err)
+		}
+
+		if !hasErr && result.Id != s.id {
+			t.Fatalf("[%d] Expected session %q, got %q", i, s.id, result.Id)
+		}
+	}
+}
+
+func TestFindAllAuthSessionsByRecord(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	s1 := createTestAuthSession(t, app, "4q1xlclmfloku33", time.Now().Add(-time.Hour))
+	s2 := createTestAuthSession(t, app, "4q1xlclmfloku33", time.Now())
+	createTestAuthSession(t, app, "oap640cot4yru2s", time.Now())
+
+	user, err := app.Dao().FindRecordById("users", "4q1xlclmfloku33")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sessions, err := app.Dao().FindAllAuthSessionsByRecord(user)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedIds := []string{s2.Id, s1.Id}
+
+	if len(sessions) != len(expectedIds) {
+		t.Fatalf("Expected %d sessions, got %d", len(expectedIds), len(sessions))
+	}
+
+	for i, id := range expectedIds {
+		if sessions[i].Id != id {
+			t.Fatalf("[%d] Expected session %q, got %q", i, id, sessions[i].Id)
+		}
+	}
+}
+
+func TestDeleteAllAuthSessionsByRecord(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	createTestAuthSession(t, app, "4q1xlclmfloku33", time.Now())
+	createTestAuthSession(t, app, "4q1xlclmfloku33", time.Now())
+	other := createTestAuthSession(t, app, "oap640cot4yru2s", time.Now())
+
+	user, err := app.Dao().FindRecordById("users", "4q1xlclmfloku33")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.Dao().DeleteAllAuthSessionsByRecord(user); err != nil {
+		t.Fatal(err)
+	}
+
+	sessions, _ := app.Dao().FindAllAuthSessionsByRecord(user)
+	if len(sessions) != 0 {
+		t.Fatalf("Expected all user sessions to be deleted, got %d", len(sessions))
+	}
+
+	if _, err := app.Dao().FindAuthSessionById(other.Id); err != nil {
+		t.Fatalf("Expected the other record session to remain, got %v", err)
+	}
+}
+
+func TestDeleteOldAuthSessions(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	old := createTestAuthSession(t, app, "4q1xlclmfloku33", time.Now().Add(-2*time.Hour))
+	recent := createTestAuthSession(t, app, "4q1xlclmfloku33", time.Now())
+
+	if err := app.Dao().DeleteOldAuthSessions("_pb_users_auth_", time.Now().Add(-time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Dao().FindAuthSessionById(old.Id); err == nil {
+		t.Fatal("Expected the old session to be deleted")
+	}
+
+	if _, err := app.Dao().FindAuthSessionById(recent.Id); err != nil {
+		t.Fatalf("Expected the recent session to remain, got %v", err)
+	}
+}
@@ -4,6 +4,7 @@
 package daos
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"time"
@@ -52,6 +53,21 @@ type Dao struct {
 	AfterUpdateFunc  func(eventDao *Dao, m models.Model) error
 	BeforeDeleteFunc func(eventDao *Dao, m models.Model, action func() error) error
 	AfterDeleteFunc  func(eventDao *Dao, m models.Model) error
+
+	// read hooks
+	DecryptRecordsFunc func(eventDao *Dao, records []*models.Record) error
+}
+
+// DecryptRecords decrypts the encrypted fields of the provided records
+// with the registered DecryptRecordsFunc (if any).
+//
+// It is usually called before exporting the records (eg. in the api responses).
+func (dao *Dao) DecryptRecords(records []*models.Record) error {
+	if dao.DecryptRecordsFunc == nil || len(records) == 0 {
+		return nil
+	}
+
+	return dao.DecryptRecordsFunc(dao, records)
 }
 
 // DB returns the default dao db builder (*dbx.DB or *dbx.TX).
@@ -84,6 +100,25 @@ func (dao *Dao) Clone() *Dao {
 	return &clone
 }
 
+// WithContext returns a new Dao with the same configuration options
+// as the current one, but with its db builders bound to the provided context
+// (eg. to cancel the running queries when a request is canceled or its deadline exceeds).
+//
+// Transaction builders (*dbx.Tx) are left unchanged.
+func (dao *Dao) WithContext(ctx context.Context) *Dao {
+	new := dao.Clone()
+
+	if db, ok := new.concurrentDB.(*dbx.DB); ok {
+		new.concurrentDB = db.WithContext(ctx)
+	}
+
+	if db, ok := new.nonconcurrentDB.(*dbx.DB); ok {
+		new.nonconcurrentDB = db.WithContext(ctx)
+	}
+
+	return new
+}
+
 // WithoutHooks returns a new Dao with the same configuration options
 // as the current one, but without create/update/delete hooks.
 func (dao *Dao) WithoutHooks() *Dao {
@@ -143,6 +178,7 @@ func (dao *Dao) RunInTransaction(fn func(txDao *Dao) error) error {
 		txDao.AfterCreateFunc = dao.AfterCreateFunc
 		txDao.AfterUpdateFunc = dao.AfterUpdateFunc
 		txDao.AfterDeleteFunc = dao.AfterDeleteFunc
+		txDao.DecryptRecordsFunc = dao.DecryptRecordsFunc
 
 		return fn(txDao)
 	case *dbx.DB:
@@ -150,6 +186,7 @@ func (dao *Dao) RunInTransaction(fn func(txDao *Dao) error) error {
 
 		txError := txOrDB.Transactional(func(tx *dbx.Tx) error {
 			txDao := New(tx)
+			txDao.DecryptRecordsFunc = dao.DecryptRecordsFunc
 
 			if dao.BeforeCreateFunc != nil {
 				txDao.BeforeCreateFunc = func(eventDao *Dao, m models.Model, action func() error) error {
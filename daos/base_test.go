@@ -1,6 +1,7 @@
 package daos_test
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -195,6 +196,49 @@ func TestDaoWithoutHooks(t *testing.T) {
 	}
 }
 
+func TestDaoWithContext(t *testing.T) {
+	testApp, _ := tests.NewTestApp()
+	defer testApp.Cleanup()
+
+	hookCalls := 0
+
+	dao := daos.NewMultiDB(testApp.Dao().ConcurrentDB(), testApp.Dao().NonconcurrentDB())
+	dao.MaxLockRetries = 1
+	dao.BeforeCreateFunc = func(eventDao *daos.Dao, m models.Model, action func() error) error {
+		hookCalls++
+		return action()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	new := dao.WithContext(ctx)
+
+	if new.MaxLockRetries != dao.MaxLockRetries {
+		t.Fatalf("Expected MaxLockRetries %d, got %d", dao.MaxLockRetries, new.MaxLockRetries)
+	}
+
+	if new.BeforeCreateFunc == nil {
+		t.Fatal("Expected BeforeCreateFunc to be set")
+	}
+
+	m := &models.Admin{}
+
+	if err := new.ModelQuery(m).One(m); err != nil {
+		t.Fatalf("Failed to execute control query: %v", err)
+	}
+
+	cancel()
+
+	if err := new.ModelQuery(m).One(m); err == nil {
+		t.Fatal("Expected to be cancelled, got nil")
+	}
+
+	// the original dao shouldn't be affected
+	if err := dao.ModelQuery(m).One(m); err != nil {
+		t.Fatalf("Expected the original dao query to succeed, got %v", err)
+	}
+}
+
 func TestDaoModelQuery(t *testing.T) {
 	testApp, _ := tests.NewTestApp()
 	defer testApp.Cleanup()
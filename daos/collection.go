@@ -351,6 +351,7 @@ func (dao *Dao) ImportCollections(
 
 // saveViewCollection persists the provided View collection changes:
 //   - deletes the old related SQL view (if any)
+//   - regenerates newCollection.Options.Query from the feed sources (if any)
 //   - creates a new SQL view with the latest newCollection.Options.Query
 //   - generates a new schema based on newCollection.Options.Query
 //   - updates newCollection.Schema based on the generated view table info and query
@@ -363,7 +364,19 @@ func (dao *Dao) saveViewCollection(newCollection, oldCollection *models.Collecti
 	}
 
 	return dao.RunInTransaction(func(txDao *Dao) error {
-		query := newCollection.ViewOptions().Query
+		options := newCollection.ViewOptions()
+
+		// (re)generate the feed view query
+		if len(options.Feed) > 0 {
+			feedQuery, err := txDao.CreateFeedViewQuery(options.Feed)
+			if err != nil {
+				return err
+			}
+			options.Query = feedQuery
+			newCollection.SetOptions(options)
+		}
+
+		query := options.Query
 
 		// generate collection schema from the query
 		viewSchema, err := txDao.CreateViewSchema(query)
@@ -0,0 +1,92 @@
+package daos
+
+import (
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// CommentQuery returns a new Comment select query.
+func (dao *Dao) CommentQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.Comment{})
+}
+
+// FindCommentById finds a single Comment model by its id.
+func (dao *Dao) FindCommentById(id string) (*models.Comment, error) {
+	model := &models.Comment{}
+
+	err := dao.CommentQuery().
+		AndWhere(dbx.HashExp{"id": id}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// FindRecordComments returns all comments of the provided record
+// ordered by their creation date.
+func (dao *Dao) FindRecordComments(record *models.Record) ([]*models.Comment, error) {
+	comments := []*models.Comment{}
+
+	err := dao.RecordCommentsQuery(record).
+		OrderBy("created ASC", "rowid ASC").
+		All(&comments)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
+
+// RecordCommentsQuery returns a new Comment select query
+// filtered to the comments of the provided record.
+func (dao *Dao) RecordCommentsQuery(record *models.Record) *dbx.SelectQuery {
+	return dao.CommentQuery().AndWhere(dbx.HashExp{
+		"collectionId": record.Collection().Id,
+		"recordId":     record.Id,
+	})
+}
+
+// SaveComment upserts the provided Comment model.
+func (dao *Dao) SaveComment(comment *models.Comment) error {
+	return dao.Save(comment)
+}
+
+// DeleteComment deletes the provided Comment model
+// together with all of its replies.
+func (dao *Dao) DeleteComment(comment *models.Comment) error {
+	return dao.RunInTransaction(func(txDao *Dao) error {
+		replies := []*models.Comment{}
+
+		err := txDao.CommentQuery().
+			AndWhere(dbx.HashExp{"parentId": comment.Id}).
+			All(&replies)
+		if err != nil {
+			return err
+		}
+
+		for _, reply := range replies {
+			if err := txDao.DeleteComment(reply); err != nil {
+				return err
+			}
+		}
+
+		return txDao.Delete(comment)
+	})
+}
+
+// deleteRecordComments deletes all comments of the provided record.
+//
+// The comments are deleted without triggering the model hooks.
+func (dao *Dao) deleteRecordComments(record *models.Record) error {
+	_, err := dao.NonconcurrentDB().Delete((&models.Comment{}).TableName(), dbx.HashExp{
+		"collectionId": record.Collection().Id,
+		"recordId":     record.Id,
+	}).Execute()
+
+	return err
+}
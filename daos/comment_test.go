@@ -0,0 +1,150 @@
+package daos_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func createComment(t *testing.T, dao *daos.Dao, record *models.Record, parentId string, message string) *models.Comment {
+	comment := &models.Comment{
+		CollectionId: record.Collection().Id,
+		RecordId:     record.Id,
+		ParentId:     parentId,
+		AuthorType:   models.CommentAuthorTypeAdmin,
+		AuthorId:     "sywbhecnh46rhm0",
+		Message:      message,
+	}
+	if err := dao.SaveComment(comment); err != nil {
+		t.Fatal(err)
+	}
+	return comment
+}
+
+func TestCommentQuery(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	expected := "SELECT {{_comments}}.* FROM `_comments`"
+
+	sql := app.Dao().CommentQuery().Build().SQL()
+	if sql != expected {
+		t.Errorf("Expected sql %s, got %s", expected, sql)
+	}
+}
+
+func TestFindCommentById(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	record, err := app.Dao().FindRecordById("demo2", "llvuca81nly1qls")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	comment := createComment(t, app.Dao(), record, "", "test")
+
+	if _, err := app.Dao().FindCommentById("missing"); err == nil {
+		t.Fatal("Expected error for missing comment, got nil")
+	}
+
+	found, err := app.Dao().FindCommentById(comment.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.Message != "test" || found.RecordId != record.Id {
+		t.Fatalf("Unexpected comment %v", found)
+	}
+}
+
+func TestFindRecordComments(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	record1, err := app.Dao().FindRecordById("demo2", "llvuca81nly1qls")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	record2, err := app.Dao().FindRecordById("demo2", "achvryl401bhse3")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c1 := createComment(t, app.Dao(), record1, "", "c1")
+	createComment(t, app.Dao(), record2, "", "other")
+	c2 := createComment(t, app.Dao(), record1, c1.Id, "c2")
+
+	comments, err := app.Dao().FindRecordComments(record1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(comments) != 2 {
+		t.Fatalf("Expected 2 comments, got %d", len(comments))
+	}
+
+	if comments[0].Id != c1.Id || comments[1].Id != c2.Id {
+		t.Fatalf("Expected comments [%s, %s], got [%s, %s]", c1.Id, c2.Id, comments[0].Id, comments[1].Id)
+	}
+}
+
+func TestDeleteComment(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	record, err := app.Dao().FindRecordById("demo2", "llvuca81nly1qls")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c1 := createComment(t, app.Dao(), record, "", "c1")
+	c2 := createComment(t, app.Dao(), record, c1.Id, "c2")
+	createComment(t, app.Dao(), record, c2.Id, "c3")
+	c4 := createComment(t, app.Dao(), record, "", "c4")
+
+	if err := app.Dao().DeleteComment(c1); err != nil {
+		t.Fatal(err)
+	}
+
+	comments, err := app.Dao().FindRecordComments(record)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(comments) != 1 || comments[0].Id != c4.Id {
+		t.Fatalf("Expected only comment %s to remain, got %v", c4.Id, comments)
+	}
+}
+
+func TestDeleteRecordComments(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	record, err := app.Dao().FindRecordById("demo2", "llvuca81nly1qls")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	other, err := app.Dao().FindRecordById("demo2", "achvryl401bhse3")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	createComment(t, app.Dao(), record, "", "c1")
+	createComment(t, app.Dao(), other, "", "c2")
+
+	if err := app.Dao().DeleteRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	total := 0
+	if err := app.Dao().CommentQuery().Select("count(*)").Row(&total); err != nil {
+		t.Fatal(err)
+	}
+	if total != 1 {
+		t.Fatalf("Expected only the other record comment to remain, got %d comments", total)
+	}
+}
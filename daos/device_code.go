@@ -0,0 +1,90 @@
+package daos
+
+import (
+	"time"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// DeviceCodeQuery returns a new DeviceCode select query.
+func (dao *Dao) DeviceCodeQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.DeviceCode{})
+}
+
+// FindDeviceCodeByCode returns a single DeviceCode model by its plain device code.
+//
+// Note that the returned device code could be expired (see [models.DeviceCode.HasExpired()]).
+func (dao *Dao) FindDeviceCodeByCode(collectionId string, code string) (*models.DeviceCode, error) {
+	model := &models.DeviceCode{}
+
+	err := dao.DeviceCodeQuery().
+		AndWhere(dbx.HashExp{
+			"collectionId": collectionId,
+			"codeHash":     security.SHA256(code),
+		}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// FindDeviceCodeByUserCode returns a single DeviceCode model by its user code.
+//
+// The user code is normalized before the lookup (see [models.NormalizeUserCode()]).
+func (dao *Dao) FindDeviceCodeByUserCode(collectionId string, userCode string) (*models.DeviceCode, error) {
+	model := &models.DeviceCode{}
+
+	err := dao.DeviceCodeQuery().
+		AndWhere(dbx.HashExp{
+			"collectionId": collectionId,
+			"userCode":     models.NormalizeUserCode(userCode),
+		}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// SaveDeviceCode upserts the provided DeviceCode model.
+func (dao *Dao) SaveDeviceCode(model *models.DeviceCode) error {
+	return dao.Save(model)
+}
+
+// DeleteDeviceCode deletes the provided DeviceCode model.
+func (dao *Dao) DeleteDeviceCode(model *models.DeviceCode) error {
+	return dao.Delete(model)
+}
+
+// DeleteAllDeviceCodesByRecord deletes all device codes approved
+// or denied by the provided auth record.
+func (dao *Dao) DeleteAllDeviceCodesByRecord(authRecord *models.Record) error {
+	_, err := dao.NonconcurrentDB().Delete((&models.DeviceCode{}).TableName(), dbx.HashExp{
+		"collectionId": authRecord.Collection().Id,
+		"recordId":     authRecord.Id,
+	}).Execute()
+
+	return err
+}
+
+// DeleteOldDeviceCodes deletes all device codes created before createdBefore.
+func (dao *Dao) DeleteOldDeviceCodes(createdBefore time.Time) error {
+	formattedDate := createdBefore.UTC().Format(types.DefaultDateLayout)
+
+	_, err := dao.NonconcurrentDB().Delete(
+		(&models.DeviceCode{}).TableName(),
+		dbx.NewExp("[[created]] <= {:date}", dbx.Params{"date": formattedDate}),
+	).Execute()
+
+	return err
+}
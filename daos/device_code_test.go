@@ -0,0 +1,190 @@
+package daos_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func createTestDeviceCode(t *testing.T, app *tests.TestApp, code string, userCode string, recordId string) *models.DeviceCode {
+	deviceCode := &models.DeviceCode{
+		CollectionId: "_pb_users_auth_",
+		RecordId:     recordId,
+		UserCode:     userCode,
+		Status:       models.DeviceCodeStatusPending,
+	}
+	deviceCode.SetCode(code)
+
+	if err := app.Dao().SaveDeviceCode(deviceCode); err != nil {
+		t.Fatal(err)
+	}
+
+	return deviceCode
+}
+
+func TestDeviceCodeQuery(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	expected := "SELECT {{_deviceCodes}}.* FROM `_deviceCodes`"
+
+	sql := app.Dao().DeviceCodeQuery().Build().SQL()
+	if sql != expected {
+		t.Errorf("Expected sql %s, got %s", expected, sql)
+	}
+}
+
+func TestFindDeviceCodeByCode(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	deviceCode := createTestDeviceCode(t, app, "test_code", "BCDFGHJK", "")
+
+	scenarios := []struct {
+		collectionId string
+		code         string
+		expectError  bool
+	}{
+		{"", "", true},
+		{"_pb_users_auth_", "missing", true},
+		{"v851q4r790rhknl", "test_code", true},
+		{"_pb_users_auth_", deviceCode.CodeHash, true},
+		{"_pb_users_auth_", "test_code", false},
+	}
+
+	for i, s := range scenarios {
+		result, err := app.Dao().FindDeviceCodeByCode(s.collectionId, s.code)
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("(%d) Expected hasErr %v, got %v (%v)", i, s.expectError, hasErr, err)
+			continue
+		}
+
+		if result != nil && result.Id != deviceCode.Id {
+			t.Errorf("(%d) Expected device code %s, got %s", i, deviceCode.Id, result.Id)
+		}
+	}
+}
+
+func TestFindDeviceCodeByUserCode(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	deviceCode := createTestDeviceCode(t, app, "test_code", "BCDFGHJK", "")
+
+	scenarios := []struct {
+		collectionId string
+		userCode     string
+		expectError  bool
+	}{
+		{"", "", true},
+		{"_pb_users_auth_", "missing", true},
+		{"v851q4r790rhknl", "BCDFGHJK", true},
+		{"_pb_users_auth_", "BCDFGHJK", false},
+		{"_pb_users_auth_", "bcdf-ghjk", false},
+	}
+
+	for i, s := range scenarios {
+		result, err := app.Dao().FindDeviceCodeByUserCode(s.collectionId, s.userCode)
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("(%d) Expected hasErr %v, got %v (%v)", i, s.expectError, hasErr, err)
+			continue
+		}
+
+		if result != nil && result.Id != deviceCode.Id {
+			t.Errorf("(%d) Expected device code %s, got %s", i, deviceCode.Id, result.Id)
+		}
+	}
+}
+
+func TestSaveAndDeleteDeviceCode(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	deviceCode := createTestDeviceCode(t, app, "test_code", "BCDFGHJK", "")
+
+	// duplicated user code
+	duplicated := &models.DeviceCode{
+		CollectionId: "_pb_users_auth_",
+		UserCode:     deviceCode.UserCode,
+		Status:       models.DeviceCodeStatusPending,
+	}
+	duplicated.SetCode("test_code2")
+	if err := app.Dao().SaveDeviceCode(duplicated); err == nil {
+		t.Fatal("Expected the duplicated user code to fail")
+	}
+
+	if err := app.Dao().DeleteDeviceCode(deviceCode); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Dao().FindDeviceCodeByCode(deviceCode.CollectionId, "test_code"); err == nil {
+		t.Fatal("Expected the device code to be deleted")
+	}
+}
+
+func TestDeleteOldDeviceCodes(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	old := &models.DeviceCode{
+		CollectionId: "_pb_users_auth_",
+		UserCode:     "BCDFGHJK",
+		Status:       models.DeviceCodeStatusPending,
+	}
+	old.SetCode("old_code")
+	old.Created, _ = types.ParseDateTime(time.Now().Add(-2 * time.Hour))
+	if err := app.Dao().SaveDeviceCode(old); err != nil {
+		t.Fatal(err)
+	}
+
+	fresh := createTestDeviceCode(t, app, "fresh_code", "LMNPQRST", "")
+
+	if err := app.Dao().DeleteOldDeviceCodes(time.Now().Add(-time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Dao().FindDeviceCodeByCode(old.CollectionId, "old_code"); err == nil {
+		t.Fatal("Expected the old device code to be deleted")
+	}
+
+	if _, err := app.Dao().FindDeviceCodeByCode(fresh.CollectionId, "fresh_code"); err != nil {
+		t.Fatalf("Expected the fresh device code to remain, got %v", err)
+	}
+}
+
+func TestDeleteRecordDeviceCodes(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	createTestDeviceCode(t, app, "test_code", "BCDFGHJK", "4q1xlclmfloku33")
+
+	user, err := app.Dao().FindRecordById("users", "4q1xlclmfloku33")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.Dao().DeleteRecord(user); err != nil {
+		t.Fatal(err)
+	}
+
+	var total int
+	err = app.Dao().DeviceCodeQuery().
+		Select("count(*)").
+		AndWhere(dbx.HashExp{"recordId": user.Id}).
+		Row(&total)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if total != 0 {
+		t.Fatalf("Expected the record device codes to be deleted, found %d", total)
+	}
+}
@@ -0,0 +1,109 @@
+package daos
+
+import (
+	"errors"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// FollowQuery returns a new Follow select query.
+func (dao *Dao) FollowQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.Follow{})
+}
+
+// FindFollow returns the follow edge between the provided
+// follower auth record and the target record.
+func (dao *Dao) FindFollow(follower *models.Record, target *models.Record) (*models.Follow, error) {
+	model := &models.Follow{}
+
+	err := dao.FollowQuery().
+		AndWhere(dbx.HashExp{
+			"followerCollectionId": follower.Collection().Id,
+			"followerId":           follower.Id,
+			"targetCollectionId":   target.Collection().Id,
+			"targetId":             target.Id,
+		}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// CountFollowers returns the total number of followers of the provided record.
+func (dao *Dao) CountFollowers(target *models.Record) (int, error) {
+	var total int
+
+	err := dao.FollowQuery().
+		Select("count(*)").
+		AndWhere(dbx.HashExp{
+			"targetCollectionId": target.Collection().Id,
+			"targetId":           target.Id,
+		}).
+		Row(&total)
+
+	return total, err
+}
+
+// FollowRecord creates a new follow edge between the provided
+// follower auth record and the target record.
+//
+// Following an already followed record is a no-op.
+func (dao *Dao) FollowRecord(follower *models.Record, target *models.Record) error {
+	if !follower.Collection().IsAuth() {
+		return errors.New("only auth records can follow")
+	}
+
+	if follower.Collection().Id == target.Collection().Id && follower.Id == target.Id {
+		return errors.New("a record cannot follow itself")
+	}
+
+	if existing, _ := dao.FindFollow(follower, target); existing != nil {
+		return nil // already followed
+	}
+
+	return dao.Save(&models.Follow{
+		FollowerCollectionId: follower.Collection().Id,
+		FollowerId:           follower.Id,
+		TargetCollectionId:   target.Collection().Id,
+		TargetId:             target.Id,
+	})
+}
+
+// UnfollowRecord deletes the follow edge between the provided
+// follower auth record and the target record (if any).
+func (dao *Dao) UnfollowRecord(follower *models.Record, target *models.Record) error {
+	existing, _ := dao.FindFollow(follower, target)
+	if existing == nil {
+		return nil // not followed
+	}
+
+	return dao.Delete(existing)
+}
+
+// deleteRecordFollows deletes all follow edges to and
+// from (in case of an auth record) the provided record.
+//
+// The follows are deleted without triggering the model hooks.
+func (dao *Dao) deleteRecordFollows(record *models.Record) error {
+	expr := dbx.HashExp{
+		"targetCollectionId": record.Collection().Id,
+		"targetId":           record.Id,
+	}
+
+	var cond dbx.Expression = expr
+	if record.Collection().IsAuth() {
+		cond = dbx.Or(expr, dbx.HashExp{
+			"followerCollectionId": record.Collection().Id,
+			"followerId":           record.Id,
+		})
+	}
+
+	_, err := dao.NonconcurrentDB().Delete((&models.Follow{}).TableName(), cond).Execute()
+
+	return err
+}
@@ -0,0 +1,146 @@
+package daos_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestFollowQuery(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	expected := "SELECT {{_follows}}.* FROM `_follows`"
+
+	sql := app.Dao().FollowQuery().Build().SQL()
+	if sql != expected {
+		t.Errorf("Expected sql %s, got %s", expected, sql)
+	}
+}
+
+func TestFollowAndUnfollowRecord(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	user1, err := app.Dao().FindRecordById("users", "4q1xlclmfloku33")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user2, err := app.Dao().FindRecordById("users", "oap640cot4yru2s")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	record, err := app.Dao().FindRecordById("demo2", "llvuca81nly1qls")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// non auth follower
+	if err := app.Dao().FollowRecord(record, user1); err == nil {
+		t.Fatal("Expected non auth follower error, got nil")
+	}
+
+	// self follow
+	if err := app.Dao().FollowRecord(user1, user1); err == nil {
+		t.Fatal("Expected self follow error, got nil")
+	}
+
+	// follow twice to ensure that it is idempotent
+	for i := 0; i < 2; i++ {
+		if err := app.Dao().FollowRecord(user1, record); err != nil {
+			t.Fatalf("[%d] %v", i, err)
+		}
+	}
+	if err := app.Dao().FollowRecord(user2, record); err != nil {
+		t.Fatal(err)
+	}
+
+	if total, _ := app.Dao().CountFollowers(record); total != 2 {
+		t.Fatalf("Expected 2 followers, got %d", total)
+	}
+
+	if follow, _ := app.Dao().FindFollow(user1, record); follow == nil {
+		t.Fatal("Expected user1 to follow the record")
+	}
+
+	// unfollow twice to ensure that it is idempotent
+	for i := 0; i < 2; i++ {
+		if err := app.Dao().UnfollowRecord(user1, record); err != nil {
+			t.Fatalf("[%d] %v", i, err)
+		}
+	}
+
+	if follow, _ := app.Dao().FindFollow(user1, record); follow != nil {
+		t.Fatal("Expected user1 to no longer follow the record")
+	}
+
+	if total, _ := app.Dao().CountFollowers(record); total != 1 {
+		t.Fatalf("Expected 1 follower, got %d", total)
+	}
+}
+
+func TestDeleteRecordFollows(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	user1, err := app.Dao().FindRecordById("users", "4q1xlclmfloku33")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user2, err := app.Dao().FindRecordById("users", "oap640cot4yru2s")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	record1, err := app.Dao().FindRecordById("demo2", "llvuca81nly1qls")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	record2, err := app.Dao().FindRecordById("demo2", "achvryl401bhse3")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	follows := [][2]*models.Record{
+		{user1, record1},
+		{user1, record2},
+		{user2, record1},
+		{user2, record2},
+		{user1, user2},
+	}
+	for i, f := range follows {
+		if err := app.Dao().FollowRecord(f[0], f[1]); err != nil {
+			t.Fatalf("[%d] %v", i, err)
+		}
+	}
+
+	// deleting the followed record
+	if err := app.Dao().DeleteRecord(record1); err != nil {
+		t.Fatal(err)
+	}
+
+	total := 0
+	if err := app.Dao().FollowQuery().Select("count(*)").Row(&total); err != nil {
+		t.Fatal(err)
+	}
+	if total != 3 {
+		t.Fatalf("Expected 3 follows after the record delete, got %d", total)
+	}
+
+	// deleting the follower (and followed) user
+	if err := app.Dao().DeleteRecord(user2); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.Dao().FollowQuery().Select("count(*)").Row(&total); err != nil {
+		t.Fatal(err)
+	}
+	if total != 1 {
+		t.Fatalf("Expected 1 follow after the user delete, got %d", total)
+	}
+}
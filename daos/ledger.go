@@ -0,0 +1,268 @@
+package daos
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// ErrLedgerRecordImmutable is returned when trying to
+// update or delete a ledger collection record.
+var ErrLedgerRecordImmutable = errors.New("the ledger collection records cannot be updated or deleted")
+
+// ledgerVerifyBatchSize is the number of ledger entries
+// loaded at once during the ledger verification.
+const ledgerVerifyBatchSize = 500
+
+// LedgerVerifyResult defines the result of a collection ledger verification.
+type LedgerVerifyResult struct {
+	// Valid indicates whether the ledger hash chain is intact.
+	Valid bool `json:"valid"`
+
+	// Total is the number of the verified ledger entries.
+	Total int `json:"total"`
+
+	// LastHash is the hash of the last valid ledger entry.
+	LastHash string `json:"lastHash"`
+
+	// FailedSeq is the sequence number of the first invalid entry (if any).
+	FailedSeq int `json:"failedSeq,omitempty"`
+
+	// FailedRecordId is the record id of the first invalid entry (if any).
+	FailedRecordId string `json:"failedRecordId,omitempty"`
+
+	// Reason is a short description of the verification failure (if any).
+	Reason string `json:"reason,omitempty"`
+}
+
+// LedgerEntryQuery returns a new LedgerEntry select query.
+func (dao *Dao) LedgerEntryQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.LedgerEntry{})
+}
+
+// FindLedgerEntries returns the ledger entries of the provided
+// collection ordered by their sequence number.
+//
+// Set limit to 0 to return all entries after the afterSeq one.
+func (dao *Dao) FindLedgerEntries(collectionId string, afterSeq int, limit int) ([]*models.LedgerEntry, error) {
+	entries := []*models.LedgerEntry{}
+
+	query := dao.LedgerEntryQuery().
+		AndWhere(dbx.HashExp{"collectionId": collectionId}).
+		AndWhere(dbx.NewExp("[[seq]] > {:seq}", dbx.Params{"seq": afterSeq})).
+		OrderBy("seq ASC")
+
+	if limit > 0 {
+		query.Limit(int64(limit))
+	}
+
+	if err := query.All(&entries); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// FindLedgerEntryByRecord returns the ledger entry of the provided record.
+func (dao *Dao) FindLedgerEntryByRecord(record *models.Record) (*models.LedgerEntry, error) {
+	entry := &models.LedgerEntry{}
+
+	err := dao.LedgerEntryQuery().
+		AndWhere(dbx.HashExp{
+			"collectionId": record.Collection().Id,
+			"recordId":     record.Id,
+		}).
+		Limit(1).
+		One(entry)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// findLastLedgerEntry returns the last ledger entry of the provided collection.
+//
+// Returns nil entry if the collection ledger is empty.
+func (dao *Dao) findLastLedgerEntry(collectionId string) (*models.LedgerEntry, error) {
+	entry := &models.LedgerEntry{}
+
+	err := dao.LedgerEntryQuery().
+		AndWhere(dbx.HashExp{"collectionId": collectionId}).
+		OrderBy("seq DESC").
+		Limit(1).
+		One(entry)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// appendLedgerEntry chains the provided new ledger record
+// to the last entry of its collection ledger.
+//
+// The method is expected to be called in the same transaction as the record create.
+func (dao *Dao) appendLedgerEntry(record *models.Record) error {
+	last, err := dao.findLastLedgerEntry(record.Collection().Id)
+	if err != nil {
+		return err
+	}
+
+	entry := &models.LedgerEntry{
+		CollectionId: record.Collection().Id,
+		RecordId:     record.Id,
+		Seq:          1,
+	}
+
+	if last != nil {
+		entry.Seq = last.Seq + 1
+		entry.PrevHash = last.Hash
+	}
+
+	entry.Hash, err = models.LedgerHash(entry.PrevHash, record)
+	if err != nil {
+		return err
+	}
+
+	return dao.Save(entry)
+}
+
+// syncLedgerTriggers creates (or drops) the triggers that prevent
+// the ledger collection records table rows updates and deletes.
+func (dao *Dao) syncLedgerTriggers(collection *models.Collection) error {
+	updateTrigger := "_ledger_" + collection.Id + "_update"
+	deleteTrigger := "_ledger_" + collection.Id + "_delete"
+
+	var query string
+	if collection.LedgerEnabled() {
+		query = fmt.Sprintf(
+			`
+			CREATE TRIGGER IF NOT EXISTS [[%s]] BEFORE UPDATE ON {{%s}}
+			BEGIN
+				SELECT RAISE(ABORT, 'ledger records cannot be updated');
+			END;
+			CREATE TRIGGER IF NOT EXISTS [[%s]] BEFORE DELETE ON {{%s}}
+			BEGIN
+				SELECT RAISE(ABORT, 'ledger records cannot be deleted');
+			END;
+			`,
+			updateTrigger, collection.Name,
+			deleteTrigger, collection.Name,
+		)
+	} else {
+		query = fmt.Sprintf(
+			"DROP TRIGGER IF EXISTS [[%s]]; DROP TRIGGER IF EXISTS [[%s]];",
+			updateTrigger,
+			deleteTrigger,
+		)
+	}
+
+	_, err := dao.DB().NewQuery(query).Execute()
+
+	return err
+}
+
+// VerifyLedger recomputes the hash chain of the provided ledger
+// collection and compares it with the stored ledger entries.
+//
+// The ledger is considered invalid if there is a sequence gap,
+// a broken previous hash link, a record which data doesn't match
+// with its entry hash or a record without ledger entry.
+func (dao *Dao) VerifyLedger(collection *models.Collection) (*LedgerVerifyResult, error) {
+	if !collection.LedgerEnabled() {
+		return nil, errors.New("the collection is not a ledger")
+	}
+
+	result := &LedgerVerifyResult{Valid: true}
+
+	fail := func(entry *models.LedgerEntry, reason string) (*LedgerVerifyResult, error) {
+		result.Valid = false
+		result.FailedSeq = entry.Seq
+		result.FailedRecordId = entry.RecordId
+		result.Reason = reason
+		return result, nil
+	}
+
+	lastSeq := 0
+	for {
+		entries, err := dao.FindLedgerEntries(collection.Id, lastSeq, ledgerVerifyBatchSize)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(entries) == 0 {
+			break
+		}
+
+		ids := make([]any, len(entries))
+		for i, entry := range entries {
+			ids[i] = entry.RecordId
+		}
+
+		records := []*models.Record{}
+		err = dao.RecordQuery(collection).
+			AndWhere(dbx.In(collection.Name+".id", ids...)).
+			All(&records)
+		if err != nil {
+			return nil, err
+		}
+
+		recordsMap := make(map[string]*models.Record, len(records))
+		for _, r := range records {
+			recordsMap[r.Id] = r
+		}
+
+		for _, entry := range entries {
+			if entry.Seq != lastSeq+1 {
+				return fail(entry, "missing ledger entry")
+			}
+
+			if entry.PrevHash != result.LastHash {
+				return fail(entry, "broken previous hash link")
+			}
+
+			record, ok := recordsMap[entry.RecordId]
+			if !ok {
+				return fail(entry, "missing ledger record")
+			}
+
+			hash, err := models.LedgerHash(entry.PrevHash, record)
+			if err != nil {
+				return nil, err
+			}
+
+			if hash != entry.Hash {
+				return fail(entry, "record data hash mismatch")
+			}
+
+			lastSeq = entry.Seq
+			result.LastHash = entry.Hash
+			result.Total++
+		}
+	}
+
+	// check for records without ledger entry
+	var totalRecords int
+	err := dao.RecordQuery(collection).
+		Select("count(*)").
+		Row(&totalRecords)
+	if err != nil {
+		return nil, err
+	}
+
+	if totalRecords != result.Total {
+		result.Valid = false
+		result.Reason = "records without ledger entry"
+	}
+
+	return result, nil
+}
@@ -0,0 +1,384 @@
+package daos_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func createTestLedgerCollection(t *testing.T, app *tests.TestApp) *models.Collection {
+	collection := &models.Collection{
+		Name: "test_ledger",
+		Type: models.CollectionTypeBase,
+		Schema: schema.NewSchema(
+			&schema.SchemaField{
+				Name: "title",
+				Type: schema.FieldTypeText,
+			},
+			&schema.SchemaField{
+				Name: "amount",
+				Type: schema.FieldTypeNumber,
+			},
+			&schema.SchemaField{
+				Name:    "tags",
+				Type:    schema.FieldTypeSelect,
+				Options: &schema.SelectOptions{MaxSelect: 2, Values: []string{"a", "b"}},
+			},
+		),
+	}
+	collection.SetOptions(models.CollectionBaseOptions{Ledger: true})
+
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	return collection
+}
+
+func createTestLedgerRecords(t *testing.T, app *tests.TestApp, collection *models.Collection, total int) []*models.Record {
+	records := make([]*models.Record, total)
+
+	for i := 0; i < total; i++ {
+		record := models.NewRecord(collection)
+		record.Set("title", "test"+strconv.Itoa(i))
+		record.Set("amount", i*10)
+		if i%2 == 0 {
+			record.Set("tags", []string{"a", "b"})
+		}
+
+		if err := app.Dao().SaveRecord(record); err != nil {
+			t.Fatal(err)
+		}
+
+		records[i] = record
+	}
+
+	return records
+}
+
+func TestLedgerEntryQuery(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	expected := "SELECT {{_ledgerEntries}}.* FROM `_ledgerEntries`"
+
+	sql := app.Dao().LedgerEntryQuery().Build().SQL()
+	if sql != expected {
+		t.Errorf("Expected sql %s, got %s", expected, sql)
+	}
+}
+
+func TestSaveLedgerRecord(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := createTestLedgerCollection(t, app)
+	records := createTestLedgerRecords(t, app, collection, 3)
+
+	entries, err := app.Dao().FindLedgerEntries(collection.Id, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != len(records) {
+		t.Fatalf("Expected %d ledger entries, got %d", len(records), len(entries))
+	}
+
+	prevHash := ""
+	for i, entry := range entries {
+		if entry.Seq != i+1 {
+			t.Errorf("(%d) Expected seq %d, got %d", i, i+1, entry.Seq)
+		}
+
+		if entry.RecordId != records[i].Id {
+			t.Errorf("(%d) Expected record %q, got %q", i, records[i].Id, entry.RecordId)
+		}
+
+		if entry.PrevHash != prevHash {
+			t.Errorf("(%d) Expected prevHash %q, got %q", i, prevHash, entry.PrevHash)
+		}
+
+		if entry.Hash == "" {
+			t.Errorf("(%d) Expected non-empty hash", i)
+		}
+
+		prevHash = entry.Hash
+	}
+
+	// update attempt
+	records[0].Set("title", "changed")
+	if err := app.Dao().SaveRecord(records[0]); !errors.Is(err, daos.ErrLedgerRecordImmutable) {
+		t.Fatalf("Expected ErrLedgerRecordImmutable, got %v", err)
+	}
+
+	// delete attempt
+	if err := app.Dao().DeleteRecord(records[0]); !errors.Is(err, daos.ErrLedgerRecordImmutable) {
+		t.Fatalf("Expected ErrLedgerRecordImmutable, got %v", err)
+	}
+
+	// the records of a non-ledger collection shouldn't have ledger entries
+	demo, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	demoRecord := models.NewRecord(demo)
+	demoRecord.Set("title", "test")
+	if err := app.Dao().SaveRecord(demoRecord); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := app.Dao().FindLedgerEntryByRecord(demoRecord); err == nil {
+		t.Fatal("Expected no ledger entry for the non-ledger record")
+	}
+}
+
+func TestFindLedgerEntryByRecord(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := createTestLedgerCollection(t, app)
+	records := createTestLedgerRecords(t, app, collection, 2)
+
+	entry, err := app.Dao().FindLedgerEntryByRecord(records[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if entry.Seq != 2 || entry.RecordId != records[1].Id {
+		t.Fatalf("Expected the second record entry, got %v", entry)
+	}
+}
+
+func TestFindLedgerEntries(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := createTestLedgerCollection(t, app)
+	createTestLedgerRecords(t, app, collection, 5)
+
+	scenarios := []struct {
+		afterSeq    int
+		limit       int
+		expectedSeq []int
+	}{
+		{0, 0, []int{1, 2, 3, 4, 5}},
+		{0, 2, []int{1, 2}},
+		{3, 0, []int{4, 5}},
+		{3, 1, []int{4}},
+		{5, 0, []int{}},
+	}
+
+	for i, s := range scenarios {
+		entries, err := app.Dao().FindLedgerEntries(collection.Id, s.afterSeq, s.limit)
+		if err != nil {
+			t.Errorf("(%d) %v", i, err)
+			continue
+		}
+
+		if len(entries) != len(s.expectedSeq) {
+			t.Errorf("(%d) Expected %d entries, got %d", i, len(s.expectedSeq), len(entries))
+			continue
+		}
+
+		for j, seq := range s.expectedSeq {
+			if entries[j].Seq != seq {
+				t.Errorf("(%d) Expected entry %d seq %d, got %d", i, j, seq, entries[j].Seq)
+			}
+		}
+	}
+}
+
+func TestLedgerTableTriggers(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := createTestLedgerCollection(t, app)
+	records := createTestLedgerRecords(t, app, collection, 1)
+
+	// raw update
+	_, err := app.Dao().DB().Update(
+		collection.Name,
+		dbx.Params{"title": "changed"},
+		dbx.HashExp{"id": records[0].Id},
+	).Execute()
+	if err == nil {
+		t.Fatal("Expected the raw update to fail")
+	}
+
+	// raw delete
+	_, err = app.Dao().DB().Delete(collection.Name, dbx.HashExp{"id": records[0].Id}).Execute()
+	if err == nil {
+		t.Fatal("Expected the raw delete to fail")
+	}
+
+	// the triggers should continue to work after a collection rename and schema change
+	collection.Name = "test_ledger_renamed"
+	collection.Schema.AddField(&schema.SchemaField{
+		Name: "extra",
+		Type: schema.FieldTypeBool,
+	})
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = app.Dao().DB().Update(
+		collection.Name,
+		dbx.Params{"extra": true},
+		dbx.HashExp{"id": records[0].Id},
+	).Execute()
+	if err == nil {
+		t.Fatal("Expected the raw update after rename to fail")
+	}
+
+	// the new field shouldn't break the existing ledger
+	result, err := app.Dao().VerifyLedger(collection)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Valid {
+		t.Fatalf("Expected valid ledger, got %v", result)
+	}
+
+	// the ledger collection should be still deletable
+	if err := app.Dao().DeleteCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	total := 0
+	app.Dao().LedgerEntryQuery().
+		Select("count(*)").
+		AndWhere(dbx.HashExp{"collectionId": collection.Id}).
+		Row(&total)
+	if total != 0 {
+		t.Fatalf("Expected the collection ledger entries to be deleted, got %d", total)
+	}
+}
+
+func TestVerifyLedger(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		tamper         func(t *testing.T, app *tests.TestApp, collection *models.Collection, records []*models.Record)
+		expectValid    bool
+		expectTotal    int
+		expectedFailed int
+	}{
+		{
+			name:        "intact ledger",
+			expectValid: true,
+			expectTotal: 4,
+		},
+		{
+			name: "changed record data",
+			tamper: func(t *testing.T, app *tests.TestApp, collection *models.Collection, records []*models.Record) {
+				execTamperQueries(t, app,
+					"DROP TRIGGER [[_ledger_"+collection.Id+"_update]]",
+					"UPDATE {{"+collection.Name+"}} SET [[amount]] = 999 WHERE [[id]] = '"+records[2].Id+"'",
+				)
+			},
+			expectTotal:    2,
+			expectedFailed: 3,
+		},
+		{
+			name: "deleted record",
+			tamper: func(t *testing.T, app *tests.TestApp, collection *models.Collection, records []*models.Record) {
+				execTamperQueries(t, app,
+					"DROP TRIGGER [[_ledger_"+collection.Id+"_delete]]",
+					"DELETE FROM {{"+collection.Name+"}} WHERE [[id]] = '"+records[1].Id+"'",
+				)
+			},
+			expectTotal:    1,
+			expectedFailed: 2,
+		},
+		{
+			name: "deleted ledger entry",
+			tamper: func(t *testing.T, app *tests.TestApp, collection *models.Collection, records []*models.Record) {
+				execTamperQueries(t, app,
+					"DELETE FROM {{_ledgerEntries}} WHERE [[recordId]] = '"+records[1].Id+"'",
+				)
+			},
+			expectTotal:    1,
+			expectedFailed: 3,
+		},
+		{
+			name: "rewritten ledger entry hash",
+			tamper: func(t *testing.T, app *tests.TestApp, collection *models.Collection, records []*models.Record) {
+				execTamperQueries(t, app,
+					"DROP TRIGGER [[_ledgerEntries_no_update]]",
+					"UPDATE {{_ledgerEntries}} SET [[hash]] = 'abc' WHERE [[recordId]] = '"+records[0].Id+"'",
+				)
+			},
+			expectTotal:    0,
+			expectedFailed: 1,
+		},
+		{
+			name: "record without ledger entry",
+			tamper: func(t *testing.T, app *tests.TestApp, collection *models.Collection, records []*models.Record) {
+				execTamperQueries(t, app,
+					"INSERT INTO {{"+collection.Name+"}} ([[id]], [[title]]) VALUES ('untracked123456', 'test')",
+				)
+			},
+			expectTotal: 4,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			collection := createTestLedgerCollection(t, app)
+			records := createTestLedgerRecords(t, app, collection, 4)
+
+			if s.tamper != nil {
+				s.tamper(t, app, collection, records)
+			}
+
+			result, err := app.Dao().VerifyLedger(collection)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if result.Valid != s.expectValid {
+				t.Fatalf("Expected valid %v, got %v (%v)", s.expectValid, result.Valid, result.Reason)
+			}
+
+			if result.Total != s.expectTotal {
+				t.Fatalf("Expected total %d, got %d", s.expectTotal, result.Total)
+			}
+
+			if result.FailedSeq != s.expectedFailed {
+				t.Fatalf("Expected failed seq %d, got %d", s.expectedFailed, result.FailedSeq)
+			}
+
+			if !s.expectValid && result.Reason == "" {
+				t.Fatal("Expected non-empty failure reason")
+			}
+		})
+	}
+
+	// non-ledger collection
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	demo, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Dao().VerifyLedger(demo); err == nil {
+		t.Fatal("Expected error for non-ledger collection")
+	}
+}
+
+func execTamperQueries(t *testing.T, app *tests.TestApp, queries ...string) {
+	for _, q := range queries {
+		if _, err := app.Dao().DB().NewQuery(q).Execute(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
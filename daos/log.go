@@ -1,10 +1,13 @@
 package daos
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/pocketbase/dbx"
 	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/settings"
 	"github.com/pocketbase/pocketbase/tools/types"
 )
 
@@ -52,15 +55,64 @@ func (dao *Dao) LogsStats(expr dbx.Expression) ([]*LogsStatsItem, error) {
 }
 
 // DeleteOldLogs delete all requests that are created before createdBefore.
-func (dao *Dao) DeleteOldLogs(createdBefore time.Time) error {
+//
+// The logs with data.category from the optional excludeCategories list
+// are skipped (eg. because they have their own retention period).
+func (dao *Dao) DeleteOldLogs(createdBefore time.Time, excludeCategories ...string) error {
 	formattedDate := createdBefore.UTC().Format(types.DefaultDateLayout)
 	expr := dbx.NewExp("[[created]] <= {:date}", dbx.Params{"date": formattedDate})
 
+	if len(excludeCategories) > 0 {
+		params := make(dbx.Params, len(excludeCategories))
+		placeholders := make([]string, len(excludeCategories))
+		for i, category := range excludeCategories {
+			key := fmt.Sprintf("category%d", i)
+			params[key] = category
+			placeholders[i] = "{:" + key + "}"
+		}
+
+		expr = dbx.And(expr, dbx.NewExp(
+			"COALESCE(json_extract([[data]], '$.category'), '') NOT IN ("+strings.Join(placeholders, ",")+")",
+			params,
+		))
+	}
+
 	_, err := dao.NonconcurrentDB().Delete((&models.Log{}).TableName(), expr).Execute()
 
 	return err
 }
 
+// DeleteOldCategoryLogs deletes all logs with the specified
+// data.category that are created before createdBefore.
+func (dao *Dao) DeleteOldCategoryLogs(category string, createdBefore time.Time) error {
+	formattedDate := createdBefore.UTC().Format(types.DefaultDateLayout)
+	expr := dbx.NewExp(
+		"[[created]] <= {:date} AND json_extract([[data]], '$.category') = {:category}",
+		dbx.Params{"date": formattedDate, "category": category},
+	)
+
+	_, err := dao.NonconcurrentDB().Delete((&models.Log{}).TableName(), expr).Execute()
+
+	return err
+}
+
+// DeleteExpiredLogs deletes the logs that are outside of the retention
+// periods of the provided logs config (including the logs groups ones).
+func (dao *Dao) DeleteExpiredLogs(config settings.LogsConfig, now time.Time) error {
+	retention := config.CategoriesRetention()
+
+	categories := make([]string, 0, len(retention))
+	for category, maxDays := range retention {
+		categories = append(categories, category)
+
+		if err := dao.DeleteOldCategoryLogs(category, now.AddDate(0, 0, -1*maxDays)); err != nil {
+			return err
+		}
+	}
+
+	return dao.DeleteOldLogs(now.AddDate(0, 0, -1*config.MaxDays), categories...)
+}
+
 // SaveLog upserts the provided Log model.
 func (dao *Dao) SaveLog(log *models.Log) error {
 	return dao.Save(log)
@@ -2,11 +2,13 @@ package daos_test
 
 import (
 	"encoding/json"
+	"slices"
 	"testing"
 	"time"
 
 	"github.com/pocketbase/dbx"
 	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/settings"
 	"github.com/pocketbase/pocketbase/tests"
 	"github.com/pocketbase/pocketbase/tools/types"
 )
@@ -114,6 +116,91 @@ func TestDeleteOldLogs(t *testing.T) {
 	}
 }
 
+func TestDeleteOldLogsWithExcludedCategories(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	mockCategoryLogs(t, app)
+
+	date, _ := time.Parse(types.DefaultDateLayout, "2022-05-04 11:00:00.000Z")
+
+	if err := app.LogsDao().DeleteOldLogs(date, "a", "b"); err != nil {
+		t.Fatal(err)
+	}
+
+	checkRemainingLogs(t, app, []string{"log_a", "log_b"})
+}
+
+func TestDeleteOldCategoryLogs(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	mockCategoryLogs(t, app)
+
+	date, _ := time.Parse(types.DefaultDateLayout, "2022-05-04 11:00:00.000Z")
+
+	if err := app.LogsDao().DeleteOldCategoryLogs("a", date); err != nil {
+		t.Fatal(err)
+	}
+
+	checkRemainingLogs(t, app, []string{"log_none", "log_b"})
+}
+
+func TestDeleteExpiredLogs(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	mockCategoryLogs(t, app)
+
+	now, _ := time.Parse(types.DefaultDateLayout, "2022-05-04 11:00:00.000Z")
+
+	config := settings.LogsConfig{
+		MaxDays: 1,
+		Groups: []settings.LogsGroupConfig{
+			{Name: "a", MaxDays: 10}, // longer retention
+			{Name: "b"},              // default retention
+		},
+	}
+
+	if err := app.LogsDao().DeleteExpiredLogs(config, now); err != nil {
+		t.Fatal(err)
+	}
+
+	checkRemainingLogs(t, app, []string{"log_a"})
+}
+
+func mockCategoryLogs(t *testing.T, app *tests.TestApp) {
+	_, err := app.LogsDB().NewQuery(`
+		delete from {{_logs}};
+
+		insert into {{_logs}} ([[id]], [[level]], [[message]], [[data]], [[created]], [[updated]])
+		values
+		("log_none", 0, "test", '{"status":200}', "2022-05-01 10:00:00.123Z", "2022-05-01 10:00:00.123Z"),
+		("log_a", 0, "test", '{"status":200,"category":"a"}', "2022-05-01 10:00:00.123Z", "2022-05-01 10:00:00.123Z"),
+		("log_b", 0, "test", '{"status":200,"category":"b"}', "2022-05-01 10:00:00.123Z", "2022-05-01 10:00:00.123Z");
+	`).Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func checkRemainingLogs(t *testing.T, app *tests.TestApp, expectedIds []string) {
+	var ids []string
+	if err := app.LogsDao().LogQuery().Select("id").Column(&ids); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(ids) != len(expectedIds) {
+		t.Fatalf("Expected remaining logs %v, got %v", expectedIds, ids)
+	}
+
+	for _, id := range expectedIds {
+		if !slices.Contains(ids, id) {
+			t.Fatalf("Missing expected log %q in %v", id, ids)
+		}
+	}
+}
+
 func TestSaveLog(t *testing.T) {
 	app, _ := tests.NewTestApp()
 	defer app.Cleanup()
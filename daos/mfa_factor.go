@@ -0,0 +1,88 @@
+package daos
+
+import (
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// MfaFactorQuery returns a new MfaFactor select query.
+func (dao *Dao) MfaFactorQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.MfaFactor{})
+}
+
+// FindMfaFactorById returns a single MfaFactor model by its id.
+func (dao *Dao) FindMfaFactorById(id string) (*models.MfaFactor, error) {
+	model := &models.MfaFactor{}
+
+	err := dao.MfaFactorQuery().
+		AndWhere(dbx.HashExp{"id": id}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// FindMfaFactor returns the MFA factor with the specified type
+// of the provided auth record.
+func (dao *Dao) FindMfaFactor(authRecord *models.Record, factorType string) (*models.MfaFactor, error) {
+	model := &models.MfaFactor{}
+
+	err := dao.MfaFactorQuery().
+		AndWhere(dbx.HashExp{
+			"collectionId": authRecord.Collection().Id,
+			"recordId":     authRecord.Id,
+			"type":         factorType,
+		}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// FindEnabledMfaFactors returns all enabled MFA factors of the provided auth record.
+func (dao *Dao) FindEnabledMfaFactors(authRecord *models.Record) ([]*models.MfaFactor, error) {
+	result := []*models.MfaFactor{}
+
+	err := dao.MfaFactorQuery().
+		AndWhere(dbx.HashExp{
+			"collectionId": authRecord.Collection().Id,
+			"recordId":     authRecord.Id,
+			"enabled":      true,
+		}).
+		OrderBy("created ASC").
+		All(&result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// SaveMfaFactor upserts the provided MfaFactor model.
+func (dao *Dao) SaveMfaFactor(model *models.MfaFactor) error {
+	return dao.Save(model)
+}
+
+// DeleteMfaFactor deletes the provided MfaFactor model.
+func (dao *Dao) DeleteMfaFactor(model *models.MfaFactor) error {
+	return dao.Delete(model)
+}
+
+// deleteRecordMfaFactors deletes all MFA factors of the provided auth record.
+func (dao *Dao) deleteRecordMfaFactors(authRecord *models.Record) error {
+	_, err := dao.NonconcurrentDB().Delete((&models.MfaFactor{}).TableName(), dbx.HashExp{
+		"collectionId": authRecord.Collection().Id,
+		"recordId":     authRecord.Id,
+	}).Execute()
+
+	return err
+}
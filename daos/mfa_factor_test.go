@@ -0,0 +1,150 @@
+package daos_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestMfaFactorQuery(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	expected := "SELECT {{_mfaFactors}}.* FROM `_mfaFactors`"
+
+	sql := app.Dao().MfaFactorQuery().Build().SQL()
+	if sql != expected {
+		t.Errorf("Expected sql %s, got %s", expected, sql)
+	}
+}
+
+func TestFindMfaFactorById(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		id          string
+		expectError bool
+	}{
+		{"", true},
+		{"missing", true},
+		{"t0tpf4ct0r00001", false},
+	}
+
+	for i, s := range scenarios {
+		factor, err := app.Dao().FindMfaFactorById(s.id)
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("(%d) Expected hasErr %v, got %v (%v)", i, s.expectError, hasErr, err)
+			continue
+		}
+
+		if factor != nil && factor.Id != s.id {
+			t.Errorf("(%d) Expected factor with id %s, got %s", i, s.id, factor.Id)
+		}
+	}
+}
+
+func TestFindMfaFactor(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	user1, err := app.Dao().FindRecordById("users", "4q1xlclmfloku33")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user2, err := app.Dao().FindRecordById("users", "oap640cot4yru2s")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Dao().FindMfaFactor(user1, models.MfaFactorTypeTOTP); err == nil {
+		t.Fatal("Expected not found error, got nil")
+	}
+
+	if _, err := app.Dao().FindMfaFactor(user2, "missing"); err == nil {
+		t.Fatal("Expected not found error for missing factor type, got nil")
+	}
+
+	factor, err := app.Dao().FindMfaFactor(user2, models.MfaFactorTypeTOTP)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if factor.Id != "t0tpf4ct0r00001" || !factor.Enabled {
+		t.Fatalf("Expected the enabled t0tpf4ct0r00001 factor, got %v", factor)
+	}
+}
+
+func TestFindEnabledMfaFactors(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	user1, err := app.Dao().FindRecordById("users", "4q1xlclmfloku33")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// pending (not enabled) factor
+	pending := &models.MfaFactor{
+		CollectionId: user1.Collection().Id,
+		RecordId:     user1.Id,
+		Type:         models.MfaFactorTypeTOTP,
+		Secret:       "test",
+	}
+	if err := app.Dao().SaveMfaFactor(pending); err != nil {
+		t.Fatal(err)
+	}
+
+	factors, err := app.Dao().FindEnabledMfaFactors(user1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(factors) != 0 {
+		t.Fatalf("Expected no enabled factors, got %d", len(factors))
+	}
+
+	user2, err := app.Dao().FindRecordById("users", "oap640cot4yru2s")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	factors, err = app.Dao().FindEnabledMfaFactors(user2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(factors) != 1 || factors[0].Id != "t0tpf4ct0r00001" {
+		t.Fatalf("Expected the t0tpf4ct0r00001 factor, got %v", factors)
+	}
+}
+
+func TestDeleteRecordMfaFactors(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	user, err := app.Dao().FindRecordById("users", "oap640cot4yru2s")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.Dao().DeleteRecord(user); err != nil {
+		t.Fatal(err)
+	}
+
+	var total int
+	err = app.Dao().MfaFactorQuery().
+		Select("count(*)").
+		AndWhere(dbx.HashExp{"recordId": user.Id}).
+		Row(&total)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if total != 0 {
+		t.Fatalf("Expected the record MFA factors to be deleted, found %d", total)
+	}
+}
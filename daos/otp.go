@@ -0,0 +1,64 @@
+package daos
+
+import (
+	"time"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// OtpQuery returns a new Otp select query.
+func (dao *Dao) OtpQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.Otp{})
+}
+
+// FindOtpById returns a single Otp model by its id.
+//
+// Note that the returned otp could be expired (see [models.Otp.HasExpired()]).
+func (dao *Dao) FindOtpById(id string) (*models.Otp, error) {
+	model := &models.Otp{}
+
+	err := dao.OtpQuery().
+		AndWhere(dbx.HashExp{"id": id}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// SaveOtp upserts the provided Otp model.
+func (dao *Dao) SaveOtp(model *models.Otp) error {
+	return dao.Save(model)
+}
+
+// DeleteOtp deletes the provided Otp model.
+func (dao *Dao) DeleteOtp(model *models.Otp) error {
+	return dao.Delete(model)
+}
+
+// DeleteAllOtpsByRecord deletes all one-time passwords of the provided auth record.
+func (dao *Dao) DeleteAllOtpsByRecord(authRecord *models.Record) error {
+	_, err := dao.NonconcurrentDB().Delete((&models.Otp{}).TableName(), dbx.HashExp{
+		"collectionId": authRecord.Collection().Id,
+		"recordId":     authRecord.Id,
+	}).Execute()
+
+	return err
+}
+
+// DeleteOldOtps deletes all one-time passwords created before createdBefore.
+func (dao *Dao) DeleteOldOtps(createdBefore time.Time) error {
+	formattedDate := createdBefore.UTC().Format(types.DefaultDateLayout)
+
+	_, err := dao.NonconcurrentDB().Delete(
+		(&models.Otp{}).TableName(),
+		dbx.NewExp("[[created]] <= {:date}", dbx.Params{"date": formattedDate}),
+	).Execute()
+
+	return err
+}
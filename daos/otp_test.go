@@ -0,0 +1,153 @@
+package daos_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestOtpQuery(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	expected := "SELECT {{_otps}}.* FROM `_otps`"
+
+	sql := app.Dao().OtpQuery().Build().SQL()
+	if sql != expected {
+		t.Errorf("Expected sql %s, got %s", expected, sql)
+	}
+}
+
+func TestSaveFindAndDeleteOtp(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	otp := &models.Otp{
+		CollectionId: "_pb_users_auth_",
+		RecordId:     "oap640cot4yru2s",
+	}
+	otp.SetPassword("123456")
+
+	if err := app.Dao().SaveOtp(otp); err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := app.Dao().FindOtpById(otp.Id)
+	if err != nil {
+		t.Fatalf("Expected the otp to be saved, got %v", err)
+	}
+
+	if !found.ValidatePassword("123456") {
+		t.Fatal("Expected the saved otp password to be valid")
+	}
+
+	if _, err := app.Dao().FindOtpById("missing"); err == nil {
+		t.Fatal("Expected error for missing otp")
+	}
+
+	if err := app.Dao().DeleteOtp(found); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Dao().FindOtpById(otp.Id); err == nil {
+		t.Fatal("Expected the otp to be deleted")
+	}
+}
+
+func TestDeleteAllOtpsByRecord(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	for _, recordId := range []string{"oap640cot4yru2s", "oap640cot4yru2s", "bgs820n361vj1qd"} {
+		otp := &models.Otp{CollectionId: "_pb_users_auth_", RecordId: recordId}
+		otp.SetPassword("123456")
+		if err := app.Dao().SaveOtp(otp); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	user, err := app.Dao().FindRecordById("users", "oap640cot4yru2s")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.Dao().DeleteAllOtpsByRecord(user); err != nil {
+		t.Fatal(err)
+	}
+
+	var total int
+	if err := app.Dao().OtpQuery().Select("count(*)").Row(&total); err != nil {
+		t.Fatal(err)
+	}
+
+	if total != 1 {
+		t.Fatalf("Expected only 1 otp to remain, found %d", total)
+	}
+}
+
+func TestDeleteOldOtps(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	old := &models.Otp{CollectionId: "_pb_users_auth_", RecordId: "oap640cot4yru2s"}
+	old.SetPassword("123456")
+	old.Created, _ = types.ParseDateTime(time.Now().Add(-2 * time.Hour))
+	if err := app.Dao().SaveOtp(old); err != nil {
+		t.Fatal(err)
+	}
+
+	fresh := &models.Otp{CollectionId: "_pb_users_auth_", RecordId: "oap640cot4yru2s"}
+	fresh.SetPassword("123456")
+	if err := app.Dao().SaveOtp(fresh); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.Dao().DeleteOldOtps(time.Now().Add(-time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Dao().FindOtpById(old.Id); err == nil {
+		t.Fatal("Expected the old otp to be deleted")
+	}
+
+	if _, err := app.Dao().FindOtpById(fresh.Id); err != nil {
+		t.Fatalf("Expected the fresh otp to remain, got %v", err)
+	}
+}
+
+func TestDeleteRecordOtps(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	otp := &models.Otp{CollectionId: "_pb_users_auth_", RecordId: "4q1xlclmfloku33"}
+	otp.SetPassword("123456")
+	if err := app.Dao().SaveOtp(otp); err != nil {
+		t.Fatal(err)
+	}
+
+	user, err := app.Dao().FindRecordById("users", "4q1xlclmfloku33")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.Dao().DeleteRecord(user); err != nil {
+		t.Fatal(err)
+	}
+
+	var total int
+	err = app.Dao().OtpQuery().
+		Select("count(*)").
+		AndWhere(dbx.HashExp{"recordId": user.Id}).
+		Row(&total)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if total != 0 {
+		t.Fatalf("Expected the record otps to be deleted, found %d", total)
+	}
+}
@@ -0,0 +1,86 @@
+package daos
+
+import (
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// PasskeyQuery returns a new Passkey select query.
+func (dao *Dao) PasskeyQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.Passkey{})
+}
+
+// FindPasskeyById returns a single Passkey model by its id.
+func (dao *Dao) FindPasskeyById(id string) (*models.Passkey, error) {
+	model := &models.Passkey{}
+
+	err := dao.PasskeyQuery().
+		AndWhere(dbx.HashExp{"id": id}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// FindPasskeyByCredentialId returns the passkey of the specified
+// auth collection with the provided base64url encoded credential id.
+func (dao *Dao) FindPasskeyByCredentialId(collectionId string, credentialId string) (*models.Passkey, error) {
+	model := &models.Passkey{}
+
+	err := dao.PasskeyQuery().
+		AndWhere(dbx.HashExp{
+			"collectionId": collectionId,
+			"credentialId": credentialId,
+		}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// FindAllPasskeysByRecord returns all passkeys linked to the provided auth record.
+func (dao *Dao) FindAllPasskeysByRecord(authRecord *models.Record) ([]*models.Passkey, error) {
+	result := []*models.Passkey{}
+
+	err := dao.PasskeyQuery().
+		AndWhere(dbx.HashExp{
+			"collectionId": authRecord.Collection().Id,
+			"recordId":     authRecord.Id,
+		}).
+		OrderBy("created ASC").
+		All(&result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// SavePasskey upserts the provided Passkey model.
+func (dao *Dao) SavePasskey(model *models.Passkey) error {
+	return dao.Save(model)
+}
+
+// DeletePasskey deletes the provided Passkey model.
+func (dao *Dao) DeletePasskey(model *models.Passkey) error {
+	return dao.Delete(model)
+}
+
+// deleteRecordPasskeys deletes all passkeys of the provided auth record.
+func (dao *Dao) deleteRecordPasskeys(authRecord *models.Record) error {
+	_, err := dao.NonconcurrentDB().Delete((&models.Passkey{}).TableName(), dbx.HashExp{
+		"collectionId": authRecord.Collection().Id,
+		"recordId":     authRecord.Id,
+	}).Execute()
+
+	return err
+}
@@ -0,0 +1,182 @@
+package daos_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestPasskeyQuery(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	expected := "SELECT {{_passkeys}}.* FROM `_passkeys`"
+
+	sql := app.Dao().PasskeyQuery().Build().SQL()
+	if sql != expected {
+		t.Errorf("Expected sql %s, got %s", expected, sql)
+	}
+}
+
+func TestFindPasskeyById(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		id          string
+		expectError bool
+	}{
+		{"", true},
+		{"missing", true},
+		{"p4ssk3yf1xtur01", false},
+	}
+
+	for i, s := range scenarios {
+		passkey, err := app.Dao().FindPasskeyById(s.id)
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("(%d) Expected hasErr %v, got %v (%v)", i, s.expectError, hasErr, err)
+			continue
+		}
+
+		if passkey != nil && passkey.Id != s.id {
+			t.Errorf("(%d) Expected passkey with id %s, got %s", i, s.id, passkey.Id)
+		}
+	}
+}
+
+func TestFindPasskeyByCredentialId(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		collectionId string
+		credentialId string
+		expectError  bool
+	}{
+		{"", "", true},
+		{"_pb_users_auth_", "missing", true},
+		{"v851q4r790rhknl", "dGVzdF9jcmVkZW50aWFs", true},
+		{"_pb_users_auth_", "dGVzdF9jcmVkZW50aWFs", false},
+	}
+
+	for i, s := range scenarios {
+		passkey, err := app.Dao().FindPasskeyByCredentialId(s.collectionId, s.credentialId)
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("(%d) Expected hasErr %v, got %v (%v)", i, s.expectError, hasErr, err)
+			continue
+		}
+
+		if passkey != nil && passkey.Id != "p4ssk3yf1xtur01" {
+			t.Errorf("(%d) Expected the p4ssk3yf1xtur01 passkey, got %s", i, passkey.Id)
+		}
+	}
+}
+
+func TestFindAllPasskeysByRecord(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		userId      string
+		expectedIds []string
+	}{
+		{"oap640cot4yru2s", nil},
+		{"4q1xlclmfloku33", []string{"p4ssk3yf1xtur01"}},
+	}
+
+	for i, s := range scenarios {
+		user, err := app.Dao().FindRecordById("users", s.userId)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		passkeys, err := app.Dao().FindAllPasskeysByRecord(user)
+		if err != nil {
+			t.Errorf("(%d) Unexpected error %v", i, err)
+			continue
+		}
+
+		if len(passkeys) != len(s.expectedIds) {
+			t.Errorf("(%d) Expected %d passkeys, got %d", i, len(s.expectedIds), len(passkeys))
+			continue
+		}
+
+		for j, id := range s.expectedIds {
+			if passkeys[j].Id != id {
+				t.Errorf("(%d) Expected passkey %s, got %s", i, id, passkeys[j].Id)
+			}
+		}
+	}
+}
+
+func TestSaveAndDeletePasskey(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	passkey := &models.Passkey{
+		CollectionId: "_pb_users_auth_",
+		RecordId:     "oap640cot4yru2s",
+		CredentialId: "bmV3X2NyZWRlbnRpYWw",
+		PublicKey:    "test",
+	}
+
+	if err := app.Dao().SavePasskey(passkey); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Dao().FindPasskeyById(passkey.Id); err != nil {
+		t.Fatalf("Expected the passkey to be saved, got %v", err)
+	}
+
+	// duplicated credential id
+	duplicated := &models.Passkey{
+		CollectionId: "_pb_users_auth_",
+		RecordId:     "bgs820n361vj1qd",
+		CredentialId: passkey.CredentialId,
+		PublicKey:    "test",
+	}
+	if err := app.Dao().SavePasskey(duplicated); err == nil {
+		t.Fatal("Expected the duplicated credential id to fail")
+	}
+
+	if err := app.Dao().DeletePasskey(passkey); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Dao().FindPasskeyById(passkey.Id); err == nil {
+		t.Fatal("Expected the passkey to be deleted")
+	}
+}
+
+func TestDeleteRecordPasskeys(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	user, err := app.Dao().FindRecordById("users", "4q1xlclmfloku33")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.Dao().DeleteRecord(user); err != nil {
+		t.Fatal(err)
+	}
+
+	var total int
+	err = app.Dao().PasskeyQuery().
+		Select("count(*)").
+		AndWhere(dbx.HashExp{"recordId": user.Id}).
+		Row(&total)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if total != 0 {
+		t.Fatalf("Expected the record passkeys to be deleted, found %d", total)
+	}
+}
@@ -0,0 +1,188 @@
+package daos
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/inflector"
+)
+
+// ReactionQuery returns a new Reaction select query.
+func (dao *Dao) ReactionQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.Reaction{})
+}
+
+// FindUserReactionTypes returns the reaction types of the provided
+// auth record to the specified collection record.
+func (dao *Dao) FindUserReactionTypes(record *models.Record, authRecord *models.Record) ([]string, error) {
+	result := []string{}
+
+	err := dao.ReactionQuery().
+		Select("type").
+		AndWhere(dbx.HashExp{
+			"collectionId":     record.Collection().Id,
+			"recordId":         record.Id,
+			"userCollectionId": authRecord.Collection().Id,
+			"userId":           authRecord.Id,
+		}).
+		OrderBy("created ASC", "rowid ASC").
+		Column(&result)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ToggleRecordReaction adds the auth record reaction of the specified
+// type to the provided record, or removes it if it already exists.
+//
+// The record reactions counters field is recalculated from the stored
+// reactions in the same transaction, so concurrent toggles can't
+// double count.
+//
+// Returns whether the reaction exists after the toggle.
+func (dao *Dao) ToggleRecordReaction(record *models.Record, authRecord *models.Record, reactionType string) (bool, error) {
+	collection := record.Collection()
+
+	if !slices.Contains(collection.ReactionTypes(), reactionType) {
+		return false, fmt.Errorf("%q is not an allowed reaction type", reactionType)
+	}
+
+	if !authRecord.Collection().IsAuth() {
+		return false, errors.New("only auth records can react")
+	}
+
+	var active bool
+
+	err := dao.RunInTransaction(func(txDao *Dao) error {
+		existing := &models.Reaction{}
+
+		findErr := txDao.ReactionQuery().
+			AndWhere(dbx.HashExp{
+				"collectionId":     collection.Id,
+				"recordId":         record.Id,
+				"userCollectionId": authRecord.Collection().Id,
+				"userId":           authRecord.Id,
+				"type":             reactionType,
+			}).
+			Limit(1).
+			One(existing)
+
+		if findErr == nil {
+			if err := txDao.Delete(existing); err != nil {
+				return err
+			}
+		} else {
+			reaction := &models.Reaction{
+				CollectionId:     collection.Id,
+				RecordId:         record.Id,
+				UserCollectionId: authRecord.Collection().Id,
+				UserId:           authRecord.Id,
+				Type:             reactionType,
+			}
+			if err := txDao.Save(reaction); err != nil {
+				return err
+			}
+			active = true
+		}
+
+		return txDao.syncRecordReactionsCounters(collection, record.Id)
+	})
+
+	return active, err
+}
+
+// syncRecordReactionsCounters recalculates the reactions
+// counters field of the specified collection record.
+func (dao *Dao) syncRecordReactionsCounters(collection *models.Collection, recordId string) error {
+	field := collection.ReactionsField()
+	if field == "" || collection.Schema.GetFieldByName(field) == nil {
+		return nil // no counters cache
+	}
+
+	_, err := dao.NonconcurrentDB().NewQuery(fmt.Sprintf(
+		`UPDATE {{%s}} SET [[%s]] = (
+			SELECT json_group_object([[type]], [[total]]) FROM (
+				SELECT [[type]], COUNT(*) as [[total]] FROM {{%s}}
+				WHERE [[collectionId]] = {:collectionId} AND [[recordId]] = {:recordId}
+				GROUP BY [[type]]
+				ORDER BY [[type]]
+			)
+		)
+		WHERE [[id]] = {:recordId}`,
+		collection.Name,
+		inflector.Columnify(field),
+		(&models.Reaction{}).TableName(),
+	)).Bind(dbx.Params{
+		"collectionId": collection.Id,
+		"recordId":     recordId,
+	}).Execute()
+
+	return err
+}
+
+// deleteRecordReactions deletes all reactions to the provided record
+// and, in case of an auth record, all reactions created by it.
+//
+// The reactions are deleted without triggering the model hooks.
+func (dao *Dao) deleteRecordReactions(record *models.Record) error {
+	reactionsTable := (&models.Reaction{}).TableName()
+
+	_, err := dao.NonconcurrentDB().Delete(reactionsTable, dbx.HashExp{
+		"collectionId": record.Collection().Id,
+		"recordId":     record.Id,
+	}).Execute()
+	if err != nil {
+		return err
+	}
+
+	if !record.Collection().IsAuth() {
+		return nil
+	}
+
+	userExp := dbx.HashExp{
+		"userCollectionId": record.Collection().Id,
+		"userId":           record.Id,
+	}
+
+	targets := []struct {
+		CollectionId string `db:"collectionId"`
+		RecordId     string `db:"recordId"`
+	}{}
+
+	err = dao.ReactionQuery().
+		Select("collectionId", "recordId").
+		Distinct(true).
+		AndWhere(userExp).
+		All(&targets)
+	if err != nil {
+		return err
+	}
+
+	if len(targets) == 0 {
+		return nil
+	}
+
+	if _, err := dao.NonconcurrentDB().Delete(reactionsTable, userExp).Execute(); err != nil {
+		return err
+	}
+
+	// refresh the counters of the reacted records
+	for _, target := range targets {
+		collection, err := dao.FindCollectionByNameOrId(target.CollectionId)
+		if err != nil {
+			continue // the collection was deleted
+		}
+
+		if err := dao.syncRecordReactionsCounters(collection, target.RecordId); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,188 @@
+package daos_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+// enableReactions enables the "like" and "heart" demo2 reactions.
+func enableReactions(t *testing.T, dao *daos.Dao) *models.Collection {
+	collection, err := dao.FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	collection.Schema.AddField(&schema.SchemaField{
+		Name:    "reactions",
+		Type:    schema.FieldTypeJson,
+		Options: &schema.JsonOptions{MaxSize: 2000},
+	})
+	collection.SetOptions(models.CollectionBaseOptions{
+		Reactions:      []string{"like", "heart"},
+		ReactionsField: "reactions",
+	})
+	if err := dao.SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	return collection
+}
+
+func TestReactionQuery(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	expected := "SELECT {{_reactions}}.* FROM `_reactions`"
+
+	sql := app.Dao().ReactionQuery().Build().SQL()
+	if sql != expected {
+		t.Errorf("Expected sql %s, got %s", expected, sql)
+	}
+}
+
+func TestToggleRecordReaction(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := enableReactions(t, app.Dao())
+
+	user1, err := app.Dao().FindRecordById("users", "4q1xlclmfloku33")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user2, err := app.Dao().FindRecordById("users", "oap640cot4yru2s")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	record, err := app.Dao().FindRecordById(collection.Id, "llvuca81nly1qls")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// invalid type
+	if _, err := app.Dao().ToggleRecordReaction(record, user1, "missing"); err == nil {
+		t.Fatal("Expected invalid reaction type error, got nil")
+	}
+
+	// non auth record
+	if _, err := app.Dao().ToggleRecordReaction(record, record, "like"); err == nil {
+		t.Fatal("Expected non auth record error, got nil")
+	}
+
+	scenarios := []struct {
+		user           *models.Record
+		reactionType   string
+		expectedActive bool
+		expectedCounts string
+	}{
+		{user1, "like", true, `{"like":1}`},
+		{user2, "like", true, `{"like":2}`},
+		{user1, "heart", true, `{"heart":1,"like":2}`},
+		{user1, "like", false, `{"heart":1,"like":1}`},
+		{user1, "like", true, `{"heart":1,"like":2}`},
+		{user1, "heart", false, `{"like":2}`},
+	}
+
+	for i, s := range scenarios {
+		active, err := app.Dao().ToggleRecordReaction(record, s.user, s.reactionType)
+		if err != nil {
+			t.Fatalf("[%d] %v", i, err)
+		}
+
+		if active != s.expectedActive {
+			t.Fatalf("[%d] Expected active %v, got %v", i, s.expectedActive, active)
+		}
+
+		refreshed, err := app.Dao().FindRecordById(collection.Id, record.Id)
+		if err != nil {
+			t.Fatalf("[%d] %v", i, err)
+		}
+
+		if counts := refreshed.GetString("reactions"); counts != s.expectedCounts {
+			t.Fatalf("[%d] Expected counts %s, got %s", i, s.expectedCounts, counts)
+		}
+	}
+
+	own, err := app.Dao().FindUserReactionTypes(record, user1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(own, []string{"like"}) {
+		t.Fatalf("Expected user1 reactions [like], got %v", own)
+	}
+}
+
+func TestDeleteRecordReactions(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := enableReactions(t, app.Dao())
+
+	user1, err := app.Dao().FindRecordById("users", "4q1xlclmfloku33")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user2, err := app.Dao().FindRecordById("users", "oap640cot4yru2s")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	record1, err := app.Dao().FindRecordById(collection.Id, "llvuca81nly1qls")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	record2, err := app.Dao().FindRecordById(collection.Id, "achvryl401bhse3")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, r := range []*models.Record{record1, record2} {
+		for _, u := range []*models.Record{user1, user2} {
+			if _, err := app.Dao().ToggleRecordReaction(r, u, "like"); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	// deleting the reacted record
+	if err := app.Dao().DeleteRecord(record1); err != nil {
+		t.Fatal(err)
+	}
+
+	total := 0
+	if err := app.Dao().ReactionQuery().Select("count(*)").Row(&total); err != nil {
+		t.Fatal(err)
+	}
+	if total != 2 {
+		t.Fatalf("Expected 2 reactions after the record delete, got %d", total)
+	}
+
+	// deleting the reacting user
+	if err := app.Dao().DeleteRecord(user2); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.Dao().ReactionQuery().Select("count(*)").Row(&total); err != nil {
+		t.Fatal(err)
+	}
+	if total != 1 {
+		t.Fatalf("Expected 1 reaction after the user delete, got %d", total)
+	}
+
+	refreshed, err := app.Dao().FindRecordById(collection.Id, record2.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if counts := refreshed.GetString("reactions"); counts != `{"like":1}` {
+		t.Fatalf("Expected the counters to be updated, got %s", counts)
+	}
+}
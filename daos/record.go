@@ -15,6 +15,7 @@ import (
 	"github.com/pocketbase/pocketbase/tools/list"
 	"github.com/pocketbase/pocketbase/tools/search"
 	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/pocketbase/pocketbase/tools/similarity"
 	"github.com/pocketbase/pocketbase/tools/types"
 	"github.com/spf13/cast"
 )
@@ -361,6 +362,9 @@ func (dao *Dao) FindFirstRecordByFilter(
 // For correctness, if the collection is "auth" and the key is "username",
 // the unique check will be case insensitive.
 //
+// If the collection is "auth" and the key is "email", the emails are
+// compared by their canonical form using the collection EmailCanonicalization policy.
+//
 // NB! Array values (eg. from multiple select fields) are matched
 // as a serialized json strings (eg. `["a","b"]`), so the value uniqueness
 // depends on the elements order. Or in other words the following values
@@ -381,6 +385,10 @@ func (dao *Dao) IsRecordValueUnique(
 		expr = dbx.NewExp("LOWER([["+schema.FieldNameUsername+"]])={:username}", dbx.Params{
 			"username": strings.ToLower(cast.ToString(value)),
 		})
+	} else if collection.IsAuth() &&
+		key == schema.FieldNameEmail &&
+		collection.AuthOptions().EmailCanonicalization != models.EmailCanonicalizationNone {
+		expr = canonicalEmailExp(collection.AuthOptions().EmailCanonicalization, cast.ToString(value))
 	} else {
 		var normalizedVal any
 		switch val := value.(type) {
@@ -409,10 +417,44 @@ func (dao *Dao) IsRecordValueUnique(
 	return query.Row(&exists) == nil && !exists
 }
 
+// canonicalEmailExp returns an expression that matches the auth record
+// emails with the same canonical form as the provided one.
+//
+// The canonicalization is reproduced with the SQLite string functions
+// (see also [similarity.NormalizeEmail]).
+func canonicalEmailExp(policy string, email string) dbx.Expression {
+	lower := "LOWER(TRIM([[" + schema.FieldNameEmail + "]]))"
+
+	if policy != models.EmailCanonicalizationFull {
+		return dbx.NewExp(lower+" = {:email}", dbx.Params{
+			"email": strings.ToLower(strings.TrimSpace(email)),
+		})
+	}
+
+	canonical := similarity.NormalizeEmail(email)
+	if canonical == "" {
+		// not a valid email - fallback to case-insensitive match
+		return canonicalEmailExp(models.EmailCanonicalizationLowercase, email)
+	}
+
+	local := "SUBSTR(" + lower + ", 1, INSTR(" + lower + ", '@') - 1)"
+	untagged := "SUBSTR(" + local + ", 1, INSTR(" + local + " || '+', '+') - 1)"
+	domain := "SUBSTR(" + lower + ", INSTR(" + lower + ", '@') + 1)"
+
+	expr := "(CASE WHEN " + domain + " IN ('gmail.com', 'googlemail.com')" +
+		" THEN REPLACE(" + untagged + ", '.', '') || '@gmail.com'" +
+		" ELSE " + untagged + " || '@' || " + domain + " END)"
+
+	return dbx.NewExp(expr+" = {:email}", dbx.Params{"email": canonical})
+}
+
 // FindAuthRecordByToken finds the auth record associated with the provided JWT token.
 //
+// The optional fallbackBaseTokenKeys are tried in case the token signature
+// doesn't match with baseTokenKey (eg. previous secrets during rotation).
+//
 // Returns an error if the JWT token is invalid, expired or not associated to an auth collection record.
-func (dao *Dao) FindAuthRecordByToken(token string, baseTokenKey string) (*models.Record, error) {
+func (dao *Dao) FindAuthRecordByToken(token string, baseTokenKey string, fallbackBaseTokenKeys ...string) (*models.Record, error) {
 	unverifiedClaims, err := security.ParseUnverifiedJWT(token)
 	if err != nil {
 		return nil, err
@@ -434,10 +476,8 @@ func (dao *Dao) FindAuthRecordByToken(token string, baseTokenKey string) (*model
 		return nil, errors.New("The token is not associated to an auth collection record.")
 	}
 
-	verificationKey := record.TokenKey() + baseTokenKey
-
 	// verify token signature
-	if _, err := security.ParseJWT(token, verificationKey); err != nil {
+	if err := verifyTokenSignature(token, record.TokenKey(), baseTokenKey, fallbackBaseTokenKeys...); err != nil {
 		return nil, err
 	}
 
@@ -584,7 +624,45 @@ func (dao *Dao) CanAccessRecord(record *models.Record, requestInfo *models.Reque
 //
 // If record.IsNew() is true, the method will perform a create, otherwise an update.
 // To explicitly mark a record for update you can use record.MarkAsNotNew().
+//
+// If the record collection has enabled history, the persisted
+// record state before the update is stored as record version.
+//
+// The ledger collection records could be only created and each new
+// record is chained to the previous one with a new ledger entry.
 func (dao *Dao) SaveRecord(record *models.Record) error {
+	if record.Collection().LedgerEnabled() {
+		if !record.IsNew() {
+			return ErrLedgerRecordImmutable
+		}
+
+		return dao.RunInTransaction(func(txDao *Dao) error {
+			if err := txDao.saveRecord(record); err != nil {
+				return err
+			}
+
+			return txDao.appendLedgerEntry(record)
+		})
+	}
+
+	if !record.IsNew() && record.Collection().HistoryEnabled() {
+		// note: the select is outside of the transaction to minimize
+		// SQLITE_BUSY errors when mixing read&write in a single transaction
+		if old, _ := dao.FindRecordById(record.Collection().Id, record.Id); old != nil {
+			return dao.RunInTransaction(func(txDao *Dao) error {
+				if err := txDao.saveRecord(record); err != nil {
+					return err
+				}
+
+				return txDao.saveRecordVersion(old, models.RecordVersionActionUpdate)
+			})
+		}
+	}
+
+	return dao.saveRecord(record)
+}
+
+func (dao *Dao) saveRecord(record *models.Record) error {
 	if record.Collection().IsAuth() {
 		if record.Username() == "" {
 			return errors.New("unable to save auth record without username")
@@ -605,6 +683,46 @@ func (dao *Dao) SaveRecord(record *models.Record) error {
 				return errors.New("the auth record ID must be unique across all auth collections")
 			}
 		}
+
+		// track the username changes (if cooldown is enabled)
+		if !record.IsNew() && record.Collection().AuthOptions().UsernameChangeCooldown > 0 {
+			oldUsername := record.OriginalCopy().Username()
+			if oldUsername != "" && oldUsername != record.Username() {
+				return dao.RunInTransaction(func(txDao *Dao) error {
+					if err := txDao.Save(record); err != nil {
+						return err
+					}
+
+					return txDao.saveUsernameChange(record, oldUsername)
+				})
+			}
+		}
+	}
+
+	// note: the select is outside of the transaction to minimize
+	// SQLITE_BUSY errors when mixing read&write in a single transaction
+	throughRefs, err := dao.FindCollectionThroughReferences(record.Collection())
+	if err != nil {
+		return err
+	}
+
+	if len(throughRefs) > 0 {
+		joinRecords := []*models.Record{record}
+
+		// load the persisted join record state to sync also the old source (if changed)
+		if !record.IsNew() {
+			if old, _ := dao.FindRecordById(record.Collection().Id, record.Id); old != nil {
+				joinRecords = append(joinRecords, old)
+			}
+		}
+
+		return dao.RunInTransaction(func(txDao *Dao) error {
+			if err := txDao.Save(record); err != nil {
+				return err
+			}
+
+			return txDao.syncThroughRelations(throughRefs, joinRecords...)
+		})
 	}
 
 	return dao.Save(record)
@@ -617,7 +735,13 @@ func (dao *Dao) SaveRecord(record *models.Record) error {
 //
 // The delete operation may fail if the record is part of a required
 // reference in another record (aka. cannot be deleted or unset).
+//
+// The ledger collection records cannot be deleted.
 func (dao *Dao) DeleteRecord(record *models.Record) error {
+	if record.Collection().LedgerEnabled() {
+		return ErrLedgerRecordImmutable
+	}
+
 	// fetch rel references (if any)
 	//
 	// note: the select is outside of the transaction to minimize
@@ -627,6 +751,11 @@ func (dao *Dao) DeleteRecord(record *models.Record) error {
 		return err
 	}
 
+	throughRefs, err := dao.FindCollectionThroughReferences(record.Collection())
+	if err != nil {
+		return err
+	}
+
 	return dao.RunInTransaction(func(txDao *Dao) error {
 		// manually trigger delete on any linked external auth to ensure
 		// that the `OnModel*` hooks are triggered
@@ -650,7 +779,65 @@ func (dao *Dao) DeleteRecord(record *models.Record) error {
 			return err
 		}
 
-		return txDao.cascadeRecordDelete(record, refs)
+		if err := txDao.saveRecordTombstone(record); err != nil {
+			return err
+		}
+
+		if err := txDao.saveRecordVersion(record, models.RecordVersionActionDelete); err != nil {
+			return err
+		}
+
+		if err := txDao.deleteRecordComments(record); err != nil {
+			return err
+		}
+
+		if err := txDao.deleteRecordReactions(record); err != nil {
+			return err
+		}
+
+		if err := txDao.deleteRecordFollows(record); err != nil {
+			return err
+		}
+
+		if err := txDao.deleteRecordScheduleRuns(record); err != nil {
+			return err
+		}
+
+		if record.Collection().IsAuth() {
+			if err := txDao.deleteRecordMfaFactors(record); err != nil {
+				return err
+			}
+
+			if err := txDao.deleteRecordPasskeys(record); err != nil {
+				return err
+			}
+
+			if err := txDao.deleteRecordRoles(record); err != nil {
+				return err
+			}
+
+			if err := txDao.DeleteAllOtpsByRecord(record); err != nil {
+				return err
+			}
+
+			if err := txDao.DeleteAllDeviceCodesByRecord(record); err != nil {
+				return err
+			}
+
+			if err := txDao.DeleteAllRefreshTokensByRecord(record); err != nil {
+				return err
+			}
+
+			if err := txDao.DeleteAllAuthSessionsByRecord(record); err != nil {
+				return err
+			}
+		}
+
+		if err := txDao.cascadeRecordDelete(record, refs); err != nil {
+			return err
+		}
+
+		return txDao.syncThroughRelations(throughRefs, record)
 	})
 }
 
@@ -0,0 +1,321 @@
+package daos
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/spf13/cast"
+)
+
+// DefaultAggregateLimit is the default max number of returned aggregation groups.
+const DefaultAggregateLimit = 100
+
+// Supported records aggregate functions.
+const (
+	AggregateSum = "sum"
+	AggregateAvg = "avg"
+	AggregateMin = "min"
+	AggregateMax = "max"
+)
+
+// RecordsAggregateOptions defines the records aggregation query options.
+type RecordsAggregateOptions struct {
+	// GroupBy is a list of field names to group the records by
+	// (empty means a single group with all records).
+	GroupBy []string
+
+	// Sum, Avg, Min and Max are the names of the fields to
+	// apply the related aggregate function on.
+	Sum []string
+	Avg []string
+	Min []string
+	Max []string
+
+	// Sort is a list of the groups sort fields - "count"
+	// or one of the GroupBy fields (default to GroupBy ASC).
+	Sort []AggregateSortField
+
+	// Limit is the max number of returned groups (default to DefaultAggregateLimit).
+	Limit int
+}
+
+// AggregateSortField defines a single records aggregation sort field.
+type AggregateSortField struct {
+	Name string
+	Desc bool
+}
+
+// RecordsAggregateItem defines a single records aggregation group result.
+type RecordsAggregateItem struct {
+	Group map[string]any `json:"group"`
+	Count int            `json:"count"`
+	Sum   map[string]any `json:"sum,omitempty"`
+	Avg   map[string]any `json:"avg,omitempty"`
+	Min   map[string]any `json:"min,omitempty"`
+	Max   map[string]any `json:"max,omitempty"`
+}
+
+// AggregateRecords groups the collection records and calculates
+// the configured aggregate functions for each of the groups.
+//
+// The optional filters are applied on the collection records
+// before the aggregation (eg. for the collection list rule).
+func (dao *Dao) AggregateRecords(
+	collection *models.Collection,
+	options RecordsAggregateOptions,
+	filters ...func(q *dbx.SelectQuery) error,
+) ([]*RecordsAggregateItem, error) {
+	if collection.IsView() {
+		return nil, errors.New("view collections cannot be aggregated")
+	}
+
+	table := collection.Name
+	col := func(name string) string {
+		return fmt.Sprintf("[[%s.%s]]", table, name)
+	}
+
+	// build the select expressions
+	// ---
+	selects := make([]string, 0, len(options.GroupBy)+1)
+	groupBy := make([]string, 0, len(options.GroupBy))
+	groupAliases := map[string]string{}
+
+	for i, name := range options.GroupBy {
+		if _, ok := groupAliases[name]; ok {
+			return nil, fmt.Errorf("duplicated group by field %q", name)
+		}
+
+		if err := checkAggregateField(collection, name, isGroupableField); err != nil {
+			return nil, err
+		}
+
+		alias := fmt.Sprintf("group%d", i)
+		groupAliases[name] = alias
+		selects = append(selects, fmt.Sprintf("%s AS [[%s]]", col(name), alias))
+		groupBy = append(groupBy, col(name))
+	}
+
+	selects = append(selects, "COUNT(*) AS [[count]]")
+
+	type aggregate struct {
+		fn    string
+		field string
+		alias string
+	}
+	aggregateFields := []struct {
+		fn     string
+		fields []string
+		check  func(f *schema.SchemaField) bool
+	}{
+		{AggregateSum, options.Sum, isNumericField},
+		{AggregateAvg, options.Avg, isNumericField},
+		{AggregateMin, options.Min, isComparableField},
+		{AggregateMax, options.Max, isComparableField},
+	}
+
+	aggregates := []aggregate{}
+
+	for _, af := range aggregateFields {
+		for _, name := range af.fields {
+			if err := checkAggregateField(collection, name, af.check); err != nil {
+				return nil, fmt.Errorf("%s: %w", af.fn, err)
+			}
+
+			a := aggregate{
+				fn:    af.fn,
+				field: name,
+				alias: fmt.Sprintf("%s%d", af.fn, len(aggregates)),
+			}
+			aggregates = append(aggregates, a)
+
+			selects = append(selects, fmt.Sprintf("%s(%s) AS [[%s]]", strings.ToUpper(a.fn), col(name), a.alias))
+		}
+	}
+
+	// build the filtered records subquery
+	// (aggregated separately to avoid the duplicated rows from the filter joins)
+	// ---
+	idsQuery := dao.RecordQuery(collection).Select(col(schema.FieldNameId)).Distinct(true)
+	for _, filter := range filters {
+		if filter == nil {
+			continue
+		}
+		if err := filter(idsQuery); err != nil {
+			return nil, err
+		}
+	}
+	builtIdsQuery := idsQuery.Build()
+
+	query := dao.DB().Select(selects...).
+		From(table).
+		AndWhere(dbx.NewExp(
+			col(schema.FieldNameId)+" IN ("+builtIdsQuery.SQL()+")",
+			builtIdsQuery.Params(),
+		))
+
+	if len(groupBy) > 0 {
+		query.GroupBy(groupBy...)
+	}
+
+	// sort
+	// ---
+	orderBy := make([]string, 0, len(options.Sort)+len(groupBy))
+	for _, s := range options.Sort {
+		alias := "count"
+		if s.Name != "count" {
+			alias = groupAliases[s.Name]
+		}
+		if alias == "" {
+			return nil, fmt.Errorf("sort field %q must be count or one of the group by fields", s.Name)
+		}
+
+		if s.Desc {
+			orderBy = append(orderBy, "[["+alias+"]] DESC")
+		} else {
+			orderBy = append(orderBy, "[["+alias+"]] ASC")
+		}
+	}
+	// always sort by the group fields to guarantee a stable order
+	for _, name := range options.GroupBy {
+		orderBy = append(orderBy, "[["+groupAliases[name]+"]] ASC")
+	}
+	if len(orderBy) > 0 {
+		query.OrderBy(orderBy...)
+	}
+
+	limit := options.Limit
+	if limit <= 0 {
+		limit = DefaultAggregateLimit
+	}
+	query.Limit(int64(limit))
+
+	// execute and scan the groups
+	// ---
+	rows, err := query.Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []*RecordsAggregateItem{}
+
+	for rows.Next() {
+		values := make([]any, len(selects))
+		pointers := make([]any, len(selects))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, err
+		}
+
+		item := &RecordsAggregateItem{Group: make(map[string]any, len(options.GroupBy))}
+
+		for i, name := range options.GroupBy {
+			value := normalizeAggregateValue(values[i])
+
+			if field := collection.Schema.GetFieldByName(name); field != nil && field.Type == schema.FieldTypeBool {
+				// the returned driver value could be either a bool or an int
+				value = cast.ToBool(value)
+			}
+
+			item.Group[name] = value
+		}
+
+		item.Count, _ = normalizeAggregateValue(values[len(options.GroupBy)]).(int)
+
+		for i, a := range aggregates {
+			value := normalizeAggregateValue(values[len(options.GroupBy)+1+i])
+
+			var target *map[string]any
+			switch a.fn {
+			case AggregateSum:
+				target = &item.Sum
+			case AggregateAvg:
+				target = &item.Avg
+			case AggregateMin:
+				target = &item.Min
+			case AggregateMax:
+				target = &item.Max
+			}
+
+			if *target == nil {
+				*target = map[string]any{}
+			}
+			(*target)[a.field] = value
+		}
+
+		result = append(result, item)
+	}
+
+	return result, rows.Err()
+}
+
+func checkAggregateField(collection *models.Collection, name string, check func(f *schema.SchemaField) bool) error {
+	field := collection.Schema.GetFieldByName(name)
+	if field == nil {
+		return fmt.Errorf("unknown field %q", name)
+	}
+
+	field.InitOptions()
+
+	if !check(field) {
+		return fmt.Errorf("field %q cannot be used for this aggregation", name)
+	}
+
+	return nil
+}
+
+func isNumericField(f *schema.SchemaField) bool {
+	switch f.Type {
+	case schema.FieldTypeNumber,
+		schema.FieldTypeCurrency,
+		schema.FieldTypeDuration,
+		schema.FieldTypePosition:
+		return true
+	}
+
+	return false
+}
+
+func isComparableField(f *schema.SchemaField) bool {
+	return isNumericField(f) || f.Type == schema.FieldTypeDate
+}
+
+func isGroupableField(f *schema.SchemaField) bool {
+	switch f.Type {
+	case schema.FieldTypeEditor,
+		schema.FieldTypeJson,
+		schema.FieldTypeMarkdown,
+		schema.FieldTypeFile,
+		schema.FieldTypeAvatar:
+		return false
+	case schema.FieldTypeText:
+		// the encrypted values are unique per record
+		if opt, ok := f.Options.(*schema.TextOptions); ok && opt.Encrypted {
+			return false
+		}
+	}
+
+	if opt, ok := f.Options.(schema.MultiValuer); ok && opt.IsMultiple() {
+		return false
+	}
+
+	return true
+}
+
+func normalizeAggregateValue(v any) any {
+	switch val := v.(type) {
+	case []byte:
+		return string(val)
+	case int64:
+		return int(val)
+	}
+
+	return v
+}
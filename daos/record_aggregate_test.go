@@ -0,0 +1,144 @@
+package daos_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestAggregateRecords(t *testing.T) {
+	t.Parallel()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		name        string
+		collection  string
+		options     daos.RecordsAggregateOptions
+		filter      func(q *dbx.SelectQuery) error
+		expectError bool
+		expected    string
+	}{
+		{
+			"view collection",
+			"view1",
+			daos.RecordsAggregateOptions{},
+			nil,
+			true,
+			"",
+		},
+		{
+			"unknown group by field",
+			"demo1",
+			daos.RecordsAggregateOptions{GroupBy: []string{"missing"}},
+			nil,
+			true,
+			"",
+		},
+		{
+			"duplicated group by field",
+			"demo1",
+			daos.RecordsAggregateOptions{GroupBy: []string{"bool", "bool"}},
+			nil,
+			true,
+			"",
+		},
+		{
+			"non groupable field",
+			"demo1",
+			daos.RecordsAggregateOptions{GroupBy: []string{"select_many"}},
+			nil,
+			true,
+			"",
+		},
+		{
+			"non numeric sum field",
+			"demo1",
+			daos.RecordsAggregateOptions{Sum: []string{"text"}},
+			nil,
+			true,
+			"",
+		},
+		{
+			"invalid sort field",
+			"demo1",
+			daos.RecordsAggregateOptions{GroupBy: []string{"bool"}, Sort: []daos.AggregateSortField{{Name: "number"}}},
+			nil,
+			true,
+			"",
+		},
+		{
+			"no group by",
+			"demo1",
+			daos.RecordsAggregateOptions{
+				Sum: []string{"number"},
+				Avg: []string{"number"},
+				Min: []string{"number", "datetime"},
+				Max: []string{"number"},
+			},
+			nil,
+			false,
+			`[{"group":{},"count":3,"sum":{"number":123912},"avg":{"number":41304},"min":{"datetime":"","number":0},"max":{"number":123456}}]`,
+		},
+		{
+			"group by bool",
+			"demo1",
+			daos.RecordsAggregateOptions{GroupBy: []string{"bool"}, Sum: []string{"number"}},
+			nil,
+			false,
+			`[{"group":{"bool":false},"count":2,"sum":{"number":456}},{"group":{"bool":true},"count":1,"sum":{"number":123456}}]`,
+		},
+		{
+			"group by with sort and limit",
+			"demo1",
+			daos.RecordsAggregateOptions{
+				GroupBy: []string{"select_one"},
+				Sort:    []daos.AggregateSortField{{Name: "count", Desc: true}},
+				Limit:   1,
+			},
+			nil,
+			false,
+			`[{"group":{"select_one":"optionB"},"count":2}]`,
+		},
+		{
+			"with filter",
+			"demo1",
+			daos.RecordsAggregateOptions{GroupBy: []string{"select_one"}},
+			func(q *dbx.SelectQuery) error {
+				q.AndWhere(dbx.HashExp{"bool": false})
+				return nil
+			},
+			false,
+			`[{"group":{"select_one":""},"count":1},{"group":{"select_one":"optionB"},"count":1}]`,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			collection, err := app.Dao().FindCollectionByNameOrId(s.collection)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			items, err := app.Dao().AggregateRecords(collection, s.options, s.filter)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if hasErr {
+				return
+			}
+
+			raw, _ := json.Marshal(items)
+			if str := string(raw); str != s.expected {
+				t.Fatalf("Expected\n%s\ngot\n%s", s.expected, str)
+			}
+		})
+	}
+}
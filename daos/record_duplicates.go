@@ -0,0 +1,288 @@
+package daos
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tools/similarity"
+)
+
+// Supported records duplicates field match methods.
+const (
+	DuplicatesMatchExact   = "exact"
+	DuplicatesMatchTrigram = "trigram"
+	DuplicatesMatchEmail   = "email"
+	DuplicatesMatchPhone   = "phone"
+)
+
+// DefaultDuplicatesThreshold is the default min trigram similarity
+// of two field values to be considered as duplicates.
+const DefaultDuplicatesThreshold = 0.5
+
+// DuplicatesMaxRecords is the max number of the compared
+// collection records by a single duplicates detection.
+const DuplicatesMaxRecords = 5000
+
+// DefaultDuplicatesLimit is the default max number of returned duplicate pairs.
+const DefaultDuplicatesLimit = 100
+
+// RecordDuplicatesRule defines a single field similarity rule
+// of the records duplicates detection.
+type RecordDuplicatesRule struct {
+	// Field is the name of the compared record field.
+	Field string `form:"field" json:"field"`
+
+	// Match is the field values compare method
+	// ("exact", "trigram", "email" or "phone").
+	Match string `form:"match" json:"match"`
+
+	// Threshold is the min similarity (between 0 and 1) of the field
+	// values for the "trigram" match (default to DefaultDuplicatesThreshold).
+	Threshold float64 `form:"threshold" json:"threshold"`
+}
+
+// RecordDuplicatesItem defines a single pair of probable duplicate records.
+type RecordDuplicatesItem struct {
+	// RecordIds is the ids of the 2 duplicate records
+	// (the first one is the oldest record).
+	RecordIds []string `json:"recordIds"`
+
+	// Score is the average similarity of all rule fields.
+	Score float64 `json:"score"`
+
+	// Fields holds the similarity of each rule field.
+	Fields map[string]float64 `json:"fields"`
+}
+
+// duplicatesValue defines the normalized record value of a single rule.
+type duplicatesValue struct {
+	key      string
+	trigrams map[string]struct{}
+}
+
+// FindRecordDuplicates finds the probable duplicate pairs of the
+// collection records that match ALL of the provided field rules.
+//
+// Only the first DuplicatesMaxRecords records (ordered by their creation date)
+// are compared and max limit pairs are returned (ordered by their score DESC).
+//
+// The optional filters are applied on the compared collection records.
+func (dao *Dao) FindRecordDuplicates(
+	collection *models.Collection,
+	rules []RecordDuplicatesRule,
+	limit int,
+	filters ...func(q *dbx.SelectQuery) error,
+) ([]*RecordDuplicatesItem, error) {
+	if collection.IsView() {
+		return nil, errors.New("view collections duplicates cannot be checked")
+	}
+
+	if len(rules) == 0 {
+		return nil, errors.New("at least one duplicates rule is required")
+	}
+
+	if limit <= 0 {
+		limit = DefaultDuplicatesLimit
+	}
+
+	table := collection.Name
+	col := func(name string) string {
+		return fmt.Sprintf("[[%s.%s]]", table, name)
+	}
+
+	selects := []string{col(schema.FieldNameId) + " AS [[id]]"}
+
+	for i, rule := range rules {
+		if !IsDuplicatesField(collection, rule.Field) {
+			return nil, fmt.Errorf("invalid duplicates field %q", rule.Field)
+		}
+
+		switch rule.Match {
+		case DuplicatesMatchExact, DuplicatesMatchTrigram, DuplicatesMatchEmail, DuplicatesMatchPhone:
+		default:
+			return nil, fmt.Errorf("invalid duplicates match %q", rule.Match)
+		}
+
+		selects = append(selects, fmt.Sprintf("%s AS [[rule%d]]", col(rule.Field), i))
+	}
+
+	query := dao.RecordQuery(collection).
+		Select(selects...).
+		Distinct(true).
+		OrderBy(col(schema.FieldNameCreated)+" ASC", col(schema.FieldNameId)+" ASC").
+		Limit(DuplicatesMaxRecords)
+
+	for _, filter := range filters {
+		if filter == nil {
+			continue
+		}
+		if err := filter(query); err != nil {
+			return nil, err
+		}
+	}
+
+	rows := []dbx.NullStringMap{}
+	if err := query.All(&rows); err != nil {
+		return nil, err
+	}
+
+	// normalize the records rule values
+	// ---
+	ids := make([]string, len(rows))
+	values := make([][]duplicatesValue, len(rows))
+
+	for i, row := range rows {
+		ids[i] = row["id"].String
+		values[i] = make([]duplicatesValue, len(rules))
+
+		for j, rule := range rules {
+			raw := strings.TrimSpace(row[fmt.Sprintf("rule%d", j)].String)
+
+			switch rule.Match {
+			case DuplicatesMatchExact:
+				values[i][j].key = raw
+			case DuplicatesMatchEmail:
+				values[i][j].key = similarity.NormalizeEmail(raw)
+			case DuplicatesMatchPhone:
+				values[i][j].key = similarity.NormalizePhone(raw)
+			case DuplicatesMatchTrigram:
+				values[i][j].trigrams = similarity.Trigrams(raw)
+			}
+		}
+	}
+
+	// group the candidate records by the first key based rule value
+	// (or compare all records with each other if there are only trigram rules)
+	// ---
+	buckets := [][]int{}
+
+	keyRule := -1
+	for j, rule := range rules {
+		if rule.Match != DuplicatesMatchTrigram {
+			keyRule = j
+			break
+		}
+	}
+
+	if keyRule >= 0 {
+		keyBuckets := map[string][]int{}
+		keys := []string{}
+		for i := range values {
+			key := values[i][keyRule].key
+			if key == "" {
+				continue
+			}
+			if _, ok := keyBuckets[key]; !ok {
+				keys = append(keys, key)
+			}
+			keyBuckets[key] = append(keyBuckets[key], i)
+		}
+		for _, key := range keys {
+			buckets = append(buckets, keyBuckets[key])
+		}
+	} else {
+		all := make([]int, len(values))
+		for i := range all {
+			all[i] = i
+		}
+		buckets = append(buckets, all)
+	}
+
+	// compare the candidate pairs
+	// ---
+	result := []*RecordDuplicatesItem{}
+
+	for _, bucket := range buckets {
+		for a := 0; a < len(bucket); a++ {
+			for b := a + 1; b < len(bucket); b++ {
+				item := compareDuplicates(rules, values[bucket[a]], values[bucket[b]])
+				if item == nil {
+					continue
+				}
+
+				item.RecordIds = []string{ids[bucket[a]], ids[bucket[b]]}
+				result = append(result, item)
+			}
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Score > result[j].Score
+	})
+
+	if len(result) > limit {
+		result = result[:limit]
+	}
+
+	return result, nil
+}
+
+// compareDuplicates checks whether the normalized values of 2 records
+// match all rules and returns the related duplicates item (or nil on mismatch).
+func compareDuplicates(rules []RecordDuplicatesRule, a []duplicatesValue, b []duplicatesValue) *RecordDuplicatesItem {
+	item := &RecordDuplicatesItem{
+		Fields: make(map[string]float64, len(rules)),
+	}
+
+	var total float64
+
+	for i, rule := range rules {
+		var score float64
+
+		if rule.Match == DuplicatesMatchTrigram {
+			threshold := rule.Threshold
+			if threshold <= 0 {
+				threshold = DefaultDuplicatesThreshold
+			}
+
+			score = similarity.TrigramSimilarity(a[i].trigrams, b[i].trigrams)
+			if score < threshold {
+				return nil
+			}
+		} else {
+			if a[i].key == "" || a[i].key != b[i].key {
+				return nil
+			}
+			score = 1
+		}
+
+		item.Fields[rule.Field] = score
+		total += score
+	}
+
+	item.Score = total / float64(len(rules))
+
+	return item
+}
+
+// IsDuplicatesField checks whether the named collection field
+// could be used as records duplicates rule field
+// (the auth email and username or a non-encrypted plain value field).
+func IsDuplicatesField(collection *models.Collection, name string) bool {
+	if collection.IsAuth() && (name == schema.FieldNameEmail || name == schema.FieldNameUsername) {
+		return true
+	}
+
+	field := collection.Schema.GetFieldByName(name)
+	if field == nil {
+		return false
+	}
+
+	switch field.Type {
+	case schema.FieldTypeText:
+		// the encrypted values cannot be compared
+		opt, _ := field.Options.(*schema.TextOptions)
+		return opt == nil || !opt.Encrypted
+	case schema.FieldTypeEmail, schema.FieldTypeUrl, schema.FieldTypeEditor, schema.FieldTypeMarkdown,
+		schema.FieldTypeNumber, schema.FieldTypeBool, schema.FieldTypeSelect, schema.FieldTypeDate, schema.FieldTypeRelation,
+		schema.FieldTypePhone:
+		return true
+	}
+
+	return false
+}
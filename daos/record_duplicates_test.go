@@ -0,0 +1,192 @@
+package daos_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestFindRecordDuplicates(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	demo2, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, data := range []map[string]any{
+		{"id": "dup000000000001", "title": "John Smith", "active": true},
+		{"id": "dup000000000002", "title": "john  SMITH", "active": false},
+		{"id": "dup000000000003", "title": "Jon Smith", "active": true},
+		{"id": "dup000000000004", "title": "Jane Doe", "active": true},
+	} {
+		record := models.NewRecord(demo2)
+		record.Load(data)
+		if err := app.Dao().WithoutHooks().SaveRecord(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	onlyNew := func(q *dbx.SelectQuery) error {
+		q.AndWhere(dbx.Like("demo2.id", "dup").Match(false, true))
+		return nil
+	}
+
+	view1, err := app.Dao().FindCollectionByNameOrId("view1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		name        string
+		collection  *models.Collection
+		rules       []daos.RecordDuplicatesRule
+		limit       int
+		filter      func(q *dbx.SelectQuery) error
+		expectError bool
+		expected    []string
+	}{
+		{
+			"view collection",
+			view1,
+			[]daos.RecordDuplicatesRule{{Field: "text", Match: daos.DuplicatesMatchExact}},
+			0,
+			nil,
+			true,
+			nil,
+		},
+		{
+			"no rules",
+			demo2,
+			nil,
+			0,
+			nil,
+			true,
+			nil,
+		},
+		{
+			"unknown field",
+			demo2,
+			[]daos.RecordDuplicatesRule{{Field: "missing", Match: daos.DuplicatesMatchExact}},
+			0,
+			nil,
+			true,
+			nil,
+		},
+		{
+			"unknown match",
+			demo2,
+			[]daos.RecordDuplicatesRule{{Field: "title", Match: "missing"}},
+			0,
+			nil,
+			true,
+			nil,
+		},
+		{
+			"exact match",
+			demo2,
+			[]daos.RecordDuplicatesRule{{Field: "title", Match: daos.DuplicatesMatchExact}},
+			0,
+			onlyNew,
+			false,
+			[]string{},
+		},
+		{
+			"trigram match with default threshold",
+			demo2,
+			[]daos.RecordDuplicatesRule{{Field: "title", Match: daos.DuplicatesMatchTrigram}},
+			0,
+			onlyNew,
+			false,
+			[]string{
+				"dup000000000001,dup000000000002:1.00",
+				"dup000000000001,dup000000000003:0.62", // 8 shared out of 13 unique trigrams
+				"dup000000000002,dup000000000003:0.62",
+			},
+		},
+		{
+			"trigram match with custom threshold and limit",
+			demo2,
+			[]daos.RecordDuplicatesRule{{Field: "title", Match: daos.DuplicatesMatchTrigram, Threshold: 0.5}},
+			1,
+			onlyNew,
+			false,
+			[]string{"dup000000000001,dup000000000002:1.00"},
+		},
+		{
+			"multiple rules",
+			demo2,
+			[]daos.RecordDuplicatesRule{
+				{Field: "title", Match: daos.DuplicatesMatchTrigram},
+				{Field: "active", Match: daos.DuplicatesMatchExact},
+			},
+			0,
+			onlyNew,
+			false,
+			[]string{"dup000000000001,dup000000000003:0.81"},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			items, err := app.Dao().FindRecordDuplicates(s.collection, s.rules, s.limit, s.filter)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if hasErr {
+				return
+			}
+
+			result := make([]string, len(items))
+			for i, item := range items {
+				result[i] = fmt.Sprintf("%s:%.2f", strings.Join(item.RecordIds, ","), item.Score)
+			}
+
+			if strings.Join(result, "|") != strings.Join(s.expected, "|") {
+				t.Fatalf("Expected %v, got %v", s.expected, result)
+			}
+		})
+	}
+}
+
+func TestFindRecordDuplicatesEmailAndPhone(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	users, err := app.Dao().FindCollectionByNameOrId("users")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	record, err := app.Dao().FindRecordById(users.Id, "oap640cot4yru2s")
+	if err != nil {
+		t.Fatal(err)
+	}
+	record.SetEmail("Test+dup@example.com")
+	if err := app.Dao().WithoutHooks().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	items, err := app.Dao().FindRecordDuplicates(users, []daos.RecordDuplicatesRule{
+		{Field: "email", Match: daos.DuplicatesMatchEmail},
+	}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	raw, _ := json.Marshal(items)
+	expected := `[{"recordIds":["4q1xlclmfloku33","oap640cot4yru2s"],"score":1,"fields":{"email":1}}]`
+	if string(raw) != expected {
+		t.Fatalf("Expected\n%s\ngot\n%s", expected, raw)
+	}
+}
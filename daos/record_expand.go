@@ -192,6 +192,22 @@ func (dao *Dao) expandRecords(records []*models.Record, expandPath string, fetch
 		indexedRels[rel.GetId()] = rel
 	}
 
+	// fetch the join records of the "through" relation (if any)
+	var throughCollection *models.Collection
+	var throughRels map[string]map[string]*models.Record
+	if relFieldOptions.Through != nil {
+		throughCollection, _ = dao.FindCollectionByNameOrId(relFieldOptions.Through.CollectionId)
+		if throughCollection == nil {
+			return fmt.Errorf("Couldn't find through collection %q.", relFieldOptions.Through.CollectionId)
+		}
+
+		var err error
+		throughRels, err = dao.findThroughExpandRecords(records, throughCollection, relFieldOptions.Through, fetchFunc)
+		if err != nil {
+			return err
+		}
+	}
+
 	for _, model := range records {
 		relIds := model.GetStringSlice(relField.Name)
 
@@ -231,6 +247,22 @@ func (dao *Dao) expandRecords(records []*models.Record, expandPath string, fetch
 			}
 		}
 
+		// attach the join record to a copy of each rel record
+		// (the same rel record could be linked with different join records)
+		if throughRels != nil {
+			for i, rel := range validRels {
+				joinRecord := throughRels[model.Id][rel.Id]
+				if joinRecord == nil {
+					continue // missing or not accessible join record
+				}
+
+				relCopy := rel.CleanCopy()
+				relCopy.SetExpand(rel.Expand())
+				relCopy.MergeExpand(map[string]any{throughCollection.Name: joinRecord})
+				validRels[i] = relCopy
+			}
+		}
+
 		// update the expanded data
 		if relFieldOptions.MaxSelect != nil && *relFieldOptions.MaxSelect <= 1 {
 			expandData[relField.Name] = validRels[0]
@@ -244,6 +276,46 @@ func (dao *Dao) expandRecords(records []*models.Record, expandPath string, fetch
 	return nil
 }
 
+// findThroughExpandRecords returns the join records of the provided
+// source records grouped by their source and target record ids.
+func (dao *Dao) findThroughExpandRecords(
+	records []*models.Record,
+	throughCollection *models.Collection,
+	through *schema.RelationThrough,
+	fetchFunc ExpandFetchFunc,
+) (map[string]map[string]*models.Record, error) {
+	recordIds := make([]any, len(records))
+	for i, record := range records {
+		recordIds[i] = record.Id
+	}
+
+	joinIds := []string{}
+	err := dao.RecordQuery(throughCollection).
+		Select("id").
+		AndWhere(dbx.In(inflector.Columnify(through.SourceField), recordIds...)).
+		Column(&joinIds)
+	if err != nil {
+		return nil, err
+	}
+
+	// fetch the join records with the fetchFunc to apply the same access checks
+	joinRecords, err := fetchFunc(throughCollection, joinIds)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]map[string]*models.Record, len(records))
+	for _, joinRecord := range joinRecords {
+		sourceId := joinRecord.GetString(through.SourceField)
+		if result[sourceId] == nil {
+			result[sourceId] = map[string]*models.Record{}
+		}
+		result[sourceId][joinRecord.GetString(through.TargetField)] = joinRecord
+	}
+
+	return result, nil
+}
+
 // normalizeExpands normalizes expand strings and merges self containing paths
 // (eg. ["a.b.c", "a.b", "   test  ", "  ", "test"] -> ["a.b.c", "test"]).
 func normalizeExpands(paths []string) []string {
@@ -0,0 +1,202 @@
+package daos
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"golang.org/x/net/html"
+)
+
+// fullTextRebuildBatchSize is the max number of records loaded at once
+// while (re)building a collection full-text index.
+const fullTextRebuildBatchSize = 500
+
+// fullTextColumnPrefix is the prefix of the full-text index field columns
+// (it is used to prevent collisions with the FTS5 reserved column names).
+const fullTextColumnPrefix = "f_"
+
+// FullTextTableName returns the name of the FTS5 virtual table
+// that holds the full-text index of the specified collection.
+//
+// The table is named after the collection id so that it is not
+// affected by the collection renames.
+func FullTextTableName(collectionId string) string {
+	return "_fts_" + collectionId
+}
+
+// HasFullTextSupport reports whether the FTS5 extension
+// is available in the current SQLite build.
+//
+// The pure Go driver always includes it, while for the cgo driver
+// the app must be compiled with the "sqlite_fts5" build tag.
+func (dao *Dao) HasFullTextSupport() bool {
+	var enabled bool
+
+	err := dao.DB().NewQuery("SELECT sqlite_compileoption_used('ENABLE_FTS5')").Row(&enabled)
+
+	return err == nil && enabled
+}
+
+// SyncRecordsFullTextIndex creates, rebuilds or drops the collection
+// full-text index table based on the collection full-text fields.
+//
+// The index is rebuilt only if the list of the indexed fields was changed.
+//
+// Note that the FTS5 extension must be available in the SQLite build
+// (see [Dao.HasFullTextSupport]).
+func (dao *Dao) SyncRecordsFullTextIndex(collection *models.Collection) error {
+	tableName := FullTextTableName(collection.Id)
+
+	fields := collection.FullTextFields()
+	if len(fields) == 0 {
+		return dao.DeleteTable(tableName)
+	}
+
+	if !dao.HasFullTextSupport() {
+		return errors.New("the full-text index requires a SQLite build with the FTS5 extension (eg. the \"sqlite_fts5\" build tag for the cgo driver)")
+	}
+
+	columns := make([]string, 0, len(fields)+1)
+	columns = append(columns, "recordId")
+	for _, name := range fields {
+		columns = append(columns, fullTextColumnPrefix+name)
+	}
+
+	if dao.HasTable(tableName) {
+		existingColumns, err := dao.TableColumns(tableName)
+		if err != nil {
+			return err
+		}
+
+		if slices.Equal(existingColumns, columns) {
+			return nil // no changes
+		}
+	}
+
+	return dao.RunInTransaction(func(txDao *Dao) error {
+		if err := txDao.DeleteTable(tableName); err != nil {
+			return err
+		}
+
+		definitions := make([]string, len(columns))
+		for i, col := range columns {
+			definitions[i] = fmt.Sprintf("[[%s]]", col)
+		}
+		definitions[0] += " UNINDEXED"
+
+		_, err := txDao.DB().NewQuery(fmt.Sprintf(
+			"CREATE VIRTUAL TABLE {{%s}} USING fts5(%s, tokenize = 'unicode61 remove_diacritics 2')",
+			tableName,
+			strings.Join(definitions, ", "),
+		)).Execute()
+		if err != nil {
+			return err
+		}
+
+		// populate the new index with the existing records
+		lastId := ""
+		for {
+			records := []*models.Record{}
+
+			err := txDao.RecordQuery(collection).
+				AndWhere(dbx.NewExp("[[id]] > {:lastId}", dbx.Params{"lastId": lastId})).
+				OrderBy("id ASC").
+				Limit(fullTextRebuildBatchSize).
+				All(&records)
+			if err != nil {
+				return err
+			}
+
+			for _, record := range records {
+				if err := txDao.insertRecordFullText(tableName, record); err != nil {
+					return err
+				}
+				lastId = record.Id
+			}
+
+			if len(records) < fullTextRebuildBatchSize {
+				return nil
+			}
+		}
+	})
+}
+
+// SaveRecordFullText (re)indexes the provided record in its
+// collection full-text index.
+//
+// This method is a no-op if the record collection doesn't have full-text fields.
+func (dao *Dao) SaveRecordFullText(record *models.Record) error {
+	if len(record.Collection().FullTextFields()) == 0 {
+		return nil
+	}
+
+	tableName := FullTextTableName(record.Collection().Id)
+
+	return dao.RunInTransaction(func(txDao *Dao) error {
+		if err := txDao.deleteRecordFullText(tableName, record.Id); err != nil {
+			return err
+		}
+
+		return txDao.insertRecordFullText(tableName, record)
+	})
+}
+
+// DeleteRecordFullText removes the provided record from its
+// collection full-text index.
+//
+// This method is a no-op if the record collection doesn't have full-text fields.
+func (dao *Dao) DeleteRecordFullText(record *models.Record) error {
+	if len(record.Collection().FullTextFields()) == 0 {
+		return nil
+	}
+
+	return dao.deleteRecordFullText(FullTextTableName(record.Collection().Id), record.Id)
+}
+
+func (dao *Dao) deleteRecordFullText(tableName string, recordId string) error {
+	_, err := dao.DB().Delete(tableName, dbx.HashExp{"recordId": recordId}).Execute()
+
+	return err
+}
+
+func (dao *Dao) insertRecordFullText(tableName string, record *models.Record) error {
+	data := dbx.Params{"recordId": record.Id}
+
+	for _, name := range record.Collection().FullTextFields() {
+		value := record.GetString(name)
+
+		if field := record.Collection().Schema.GetFieldByName(name); field != nil && field.Type == schema.FieldTypeEditor {
+			value = htmlToPlainText(value)
+		}
+
+		data[fullTextColumnPrefix+name] = value
+	}
+
+	_, err := dao.DB().Insert(tableName, data).Execute()
+
+	return err
+}
+
+// htmlToPlainText returns the text content of the provided html string.
+func htmlToPlainText(raw string) string {
+	var sb strings.Builder
+
+	tokenizer := html.NewTokenizer(strings.NewReader(raw))
+
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return strings.TrimSpace(sb.String())
+		case html.TextToken:
+			sb.Write(tokenizer.Text())
+		default:
+			// separate the text of the adjacent elements
+			sb.WriteString(" ")
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package daos_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestSyncRecordsFullTextIndex(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tableName := daos.FullTextTableName(collection.Id)
+
+	// no full-text fields
+	if err := app.Dao().SyncRecordsFullTextIndex(collection); err != nil {
+		t.Fatal(err)
+	}
+	if app.Dao().HasTable(tableName) {
+		t.Fatalf("Expected table %s to not exist", tableName)
+	}
+
+	// with full-text fields
+	options := collection.BaseOptions()
+	options.FullTextFields = []string{"title"}
+	collection.SetOptions(options)
+
+	if !app.Dao().HasFullTextSupport() {
+		if err := app.Dao().SyncRecordsFullTextIndex(collection); err == nil {
+			t.Fatal("Expected error for SQLite build without FTS5 support")
+		}
+		t.Skip("the SQLite build doesn't support FTS5")
+	}
+
+	if err := app.Dao().SyncRecordsFullTextIndex(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	columns, err := app.Dao().TableColumns(tableName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(columns) != 2 || columns[0] != "recordId" || columns[1] != "f_title" {
+		t.Fatalf("Unexpected index columns %v", columns)
+	}
+
+	var total int
+	app.Dao().DB().Select("count(*)").From(tableName).Row(&total)
+	if total != 3 {
+		t.Fatalf("Expected all 3 demo2 records to be indexed, got %d", total)
+	}
+
+	var matchId string
+	app.Dao().DB().Select("recordId").
+		From(tableName).
+		AndWhere(dbx.NewExp("{{"+tableName+"}} MATCH {:q}", dbx.Params{"q": `"test2"`})).
+		Row(&matchId)
+	if matchId != "achvryl401bhse3" {
+		t.Fatalf("Expected to match achvryl401bhse3, got %q", matchId)
+	}
+
+	// drop on disabled index
+	options.FullTextFields = nil
+	collection.SetOptions(options)
+
+	if err := app.Dao().SyncRecordsFullTextIndex(collection); err != nil {
+		t.Fatal(err)
+	}
+	if app.Dao().HasTable(tableName) {
+		t.Fatalf("Expected table %s to be deleted", tableName)
+	}
+}
+
+func TestSaveAndDeleteRecordFullText(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	if !app.Dao().HasFullTextSupport() {
+		t.Skip("the SQLite build doesn't support FTS5")
+	}
+
+	collection, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	options := collection.BaseOptions()
+	options.FullTextFields = []string{"title"}
+	collection.SetOptions(options)
+
+	if err := app.Dao().SyncRecordsFullTextIndex(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	tableName := daos.FullTextTableName(collection.Id)
+
+	findMatches := func(q string) []string {
+		ids := []string{}
+		app.Dao().DB().Select("recordId").
+			From(tableName).
+			AndWhere(dbx.NewExp("{{"+tableName+"}} MATCH {:q}", dbx.Params{"q": q})).
+			Column(&ids)
+		return ids
+	}
+
+	record := models.NewRecord(collection)
+	record.Id = "fts000000000001"
+	record.Set("title", "Brown fox")
+
+	if err := app.Dao().SaveRecordFullText(record); err != nil {
+		t.Fatal(err)
+	}
+	if ids := findMatches(`"fox"`); len(ids) != 1 || ids[0] != record.Id {
+		t.Fatalf("Expected the record to be indexed, got %v", ids)
+	}
+
+	// reindex
+	record.Set("title", "Lazy dog")
+	if err := app.Dao().SaveRecordFullText(record); err != nil {
+		t.Fatal(err)
+	}
+	if ids := findMatches(`"fox"`); len(ids) != 0 {
+		t.Fatalf("Expected the old record values to be removed from the index, got %v", ids)
+	}
+	if ids := findMatches(`"dog"`); len(ids) != 1 {
+		t.Fatalf("Expected the record to be reindexed, got %v", ids)
+	}
+
+	if err := app.Dao().DeleteRecordFullText(record); err != nil {
+		t.Fatal(err)
+	}
+	if ids := findMatches(`"dog"`); len(ids) != 0 {
+		t.Fatalf("Expected the record to be removed from the index, got %v", ids)
+	}
+}
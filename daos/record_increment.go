@@ -0,0 +1,174 @@
+package daos
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tools/inflector"
+)
+
+// ErrIncrementOutOfBounds is returned when a record fields increment
+// would result in a value outside of the number field min/max bounds.
+var ErrIncrementOutOfBounds = errors.New("the incremented value is out of the field bounds")
+
+// IncrementRecordFields atomically adds the provided deltas
+// (negative for decrement) to the related record number fields.
+//
+// All fields are updated with a single conditional UPDATE statement
+// that also checks the number fields min/max bounds, so concurrent
+// increments don't need a read-modify-write cycle and either all
+// deltas are applied or none of them (eg. to move a value from one
+// field to another).
+//
+// On success the record fields are reloaded with their persisted values.
+func (dao *Dao) IncrementRecordFields(record *models.Record, deltas map[string]float64) error {
+	if record.IsNew() {
+		return errors.New("cannot increment the fields of a new record")
+	}
+
+	if len(deltas) == 0 {
+		return errors.New("at least one field delta is required")
+	}
+
+	collection := record.Collection()
+
+	if collection.LedgerEnabled() {
+		return ErrLedgerRecordImmutable
+	}
+
+	// sort the names for deterministic statements
+	names := make([]string, 0, len(deltas))
+	for name := range deltas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	record.RefreshUpdated()
+
+	params := dbx.Params{
+		"id":      record.Id,
+		"updated": record.Updated.String(),
+	}
+	sets := make([]string, 0, len(names)+1)
+	conditions := []string{"[[id]] = {:id}"}
+
+	for i, name := range names {
+		field, err := numberField(collection, name)
+		if err != nil {
+			return err
+		}
+
+		delta := deltas[name]
+		if math.IsNaN(delta) || math.IsInf(delta, 0) {
+			return fmt.Errorf("invalid %q field delta", name)
+		}
+
+		options, _ := field.Options.(*schema.NumberOptions)
+
+		if options != nil && options.NoDecimal && delta != math.Trunc(delta) {
+			return fmt.Errorf("the %q field delta must be an integer", name)
+		}
+
+		column := inflector.Columnify(field.Name)
+		deltaParam := "delta" + strconv.Itoa(i)
+		result := fmt.Sprintf("(COALESCE([[%s]], 0) + {:%s})", column, deltaParam)
+
+		params[deltaParam] = delta
+		sets = append(sets, fmt.Sprintf("[[%s]] = %s", column, result))
+
+		if options != nil && options.Min != nil {
+			minParam := "min" + strconv.Itoa(i)
+			params[minParam] = *options.Min
+			conditions = append(conditions, fmt.Sprintf("%s >= {:%s}", result, minParam))
+		}
+
+		if options != nil && options.Max != nil {
+			maxParam := "max" + strconv.Itoa(i)
+			params[maxParam] = *options.Max
+			conditions = append(conditions, fmt.Sprintf("%s <= {:%s}", result, maxParam))
+		}
+	}
+
+	sets = append(sets, "[[updated]] = {:updated}")
+
+	query := fmt.Sprintf(
+		"UPDATE {{%s}} SET %s WHERE %s",
+		collection.Name,
+		strings.Join(sets, ", "),
+		strings.Join(conditions, " AND "),
+	)
+
+	if collection.HistoryEnabled() {
+		// note: similar to SaveRecord, the select is outside of the transaction
+		// to minimize SQLITE_BUSY errors when mixing read&write in a single transaction
+		if old, _ := dao.FindRecordById(collection.Id, record.Id); old != nil {
+			return dao.RunInTransaction(func(txDao *Dao) error {
+				if err := txDao.incrementRecordFields(record, names, query, params); err != nil {
+					return err
+				}
+
+				return txDao.saveRecordVersion(old, models.RecordVersionActionUpdate)
+			})
+		}
+	}
+
+	return dao.incrementRecordFields(record, names, query, params)
+}
+
+func (dao *Dao) incrementRecordFields(record *models.Record, names []string, query string, params dbx.Params) error {
+	action := func() error {
+		result, err := dao.NonconcurrentDB().NewQuery(query).Bind(params).Execute()
+		if err != nil {
+			return err
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		fresh, err := dao.FindRecordById(record.Collection().Id, record.Id)
+		if err != nil {
+			return err // missing or deleted record
+		}
+
+		if affected == 0 {
+			// the record exists but the bounds condition didn't match
+			return ErrIncrementOutOfBounds
+		}
+
+		for _, name := range names {
+			record.Set(name, fresh.Get(name))
+		}
+
+		if dao.AfterUpdateFunc != nil {
+			return dao.AfterUpdateFunc(dao, record)
+		}
+
+		return nil
+	}
+
+	if dao.BeforeUpdateFunc != nil {
+		return dao.BeforeUpdateFunc(dao, record, action)
+	}
+
+	return action()
+}
+
+func numberField(collection *models.Collection, fieldName string) (*schema.SchemaField, error) {
+	field := collection.Schema.GetFieldByName(fieldName)
+	if field == nil || field.Type != schema.FieldTypeNumber {
+		return nil, fmt.Errorf("%q is not a number field", fieldName)
+	}
+
+	field.InitOptions()
+
+	return field, nil
+}
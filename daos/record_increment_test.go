@@ -0,0 +1,228 @@
+package daos_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// createIncrementCollection creates an "accounts" collection with
+// a single "acc000000000001" record with the following number fields:
+//
+//	balance (min 0): 50
+//	savings (min 0, max 100): 20
+//	votes (no decimal): 0
+func createIncrementCollection(t *testing.T, dao *daos.Dao) *models.Record {
+	collection := &models.Collection{}
+	collection.Name = "accounts"
+	collection.Type = models.CollectionTypeBase
+	collection.Schema = schema.NewSchema(
+		&schema.SchemaField{
+			Name: "title",
+			Type: schema.FieldTypeText,
+		},
+		&schema.SchemaField{
+			Name:    "balance",
+			Type:    schema.FieldTypeNumber,
+			Options: &schema.NumberOptions{Min: types.Pointer(0.0)},
+		},
+		&schema.SchemaField{
+			Name:    "savings",
+			Type:    schema.FieldTypeNumber,
+			Options: &schema.NumberOptions{Min: types.Pointer(0.0), Max: types.Pointer(100.0)},
+		},
+		&schema.SchemaField{
+			Name:    "votes",
+			Type:    schema.FieldTypeNumber,
+			Options: &schema.NumberOptions{NoDecimal: true},
+		},
+	)
+	if err := dao.SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	record := models.NewRecord(collection)
+	record.Id = "acc000000000001"
+	record.Set("title", "test")
+	record.Set("balance", 50)
+	record.Set("savings", 20)
+	if err := dao.SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	return record
+}
+
+func TestIncrementRecordFields(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		deltas         map[string]float64
+		expectError    bool
+		expectedValues map[string]float64
+	}{
+		{
+			"no deltas",
+			nil,
+			true,
+			map[string]float64{"balance": 50, "savings": 20, "votes": 0},
+		},
+		{
+			"non number field",
+			map[string]float64{"title": 1},
+			true,
+			map[string]float64{"balance": 50, "savings": 20, "votes": 0},
+		},
+		{
+			"missing field",
+			map[string]float64{"missing": 1},
+			true,
+			map[string]float64{"balance": 50, "savings": 20, "votes": 0},
+		},
+		{
+			"decimal delta for no decimal field",
+			map[string]float64{"votes": 1.5},
+			true,
+			map[string]float64{"balance": 50, "savings": 20, "votes": 0},
+		},
+		{
+			"below min",
+			map[string]float64{"balance": -51},
+			true,
+			map[string]float64{"balance": 50, "savings": 20, "votes": 0},
+		},
+		{
+			"above max",
+			map[string]float64{"savings": 81},
+			true,
+			map[string]float64{"balance": 50, "savings": 20, "votes": 0},
+		},
+		{
+			"one of multiple fields out of bounds",
+			map[string]float64{"balance": -50, "savings": 90},
+			true,
+			map[string]float64{"balance": 50, "savings": 20, "votes": 0},
+		},
+		{
+			"single field increment",
+			map[string]float64{"votes": 3},
+			false,
+			map[string]float64{"balance": 50, "savings": 20, "votes": 3},
+		},
+		{
+			"multiple fields at the bounds",
+			map[string]float64{"balance": -50, "savings": 80},
+			false,
+			map[string]float64{"balance": 0, "savings": 100, "votes": 0},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			record := createIncrementCollection(t, app.Dao())
+			oldUpdated := record.Updated
+			time.Sleep(1 * time.Millisecond)
+
+			err := app.Dao().IncrementRecordFields(record, s.deltas)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr to be %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			persisted, err := app.Dao().FindRecordById(record.Collection().Id, record.Id)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for name, expected := range s.expectedValues {
+				if v := persisted.GetFloat(name); v != expected {
+					t.Errorf("Expected persisted %s %v, got %v", name, expected, v)
+				}
+
+				if !hasErr {
+					if v := record.GetFloat(name); v != expected {
+						t.Errorf("Expected record %s %v, got %v", name, expected, v)
+					}
+				}
+			}
+
+			if !hasErr && persisted.Updated.String() == oldUpdated.String() {
+				t.Fatal("Expected the updated date to be changed")
+			}
+		})
+	}
+}
+
+func TestIncrementRecordFieldsOutOfBoundsError(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	record := createIncrementCollection(t, app.Dao())
+
+	err := app.Dao().IncrementRecordFields(record, map[string]float64{"balance": -100})
+	if !errors.Is(err, daos.ErrIncrementOutOfBounds) {
+		t.Fatalf("Expected ErrIncrementOutOfBounds, got %v", err)
+	}
+}
+
+func TestIncrementRecordFieldsConcurrent(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	record := createIncrementCollection(t, app.Dao())
+
+	// simulate multiple clients working with the same stale record copy
+	for i := 0; i < 10; i++ {
+		stale := record.CleanCopy()
+		if err := app.Dao().IncrementRecordFields(stale, map[string]float64{"balance": -5, "votes": 1}); err != nil {
+			t.Fatalf("[%d] Failed to increment: %v", i, err)
+		}
+	}
+
+	if err := app.Dao().IncrementRecordFields(record.CleanCopy(), map[string]float64{"balance": -5}); !errors.Is(err, daos.ErrIncrementOutOfBounds) {
+		t.Fatalf("Expected ErrIncrementOutOfBounds, got %v", err)
+	}
+
+	persisted, err := app.Dao().FindRecordById(record.Collection().Id, record.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v := persisted.GetFloat("balance"); v != 0 {
+		t.Fatalf("Expected balance 0, got %v", v)
+	}
+
+	if v := persisted.GetFloat("votes"); v != 10 {
+		t.Fatalf("Expected votes 10, got %v", v)
+	}
+}
+
+func TestIncrementRecordFieldsHooks(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	record := createIncrementCollection(t, app.Dao())
+
+	app.ResetEventCalls()
+
+	if err := app.Dao().IncrementRecordFields(record, map[string]float64{"votes": 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	if total := app.EventCalls["OnModelBeforeUpdate"]; total != 1 {
+		t.Fatalf("Expected 1 OnModelBeforeUpdate call, got %d", total)
+	}
+
+	if total := app.EventCalls["OnModelAfterUpdate"]; total != 1 {
+		t.Fatalf("Expected 1 OnModelAfterUpdate call, got %d", total)
+	}
+}
@@ -0,0 +1,196 @@
+package daos
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tools/inflector"
+)
+
+const (
+	// RecordPositionGap is the distance between two consecutive
+	// appended (or rebalanced) record positions.
+	RecordPositionGap float64 = 1000
+
+	// recordPositionMinGap is the min distance between two neighbour
+	// positions before rebalancing the entire scope.
+	recordPositionMinGap float64 = 1e-6
+)
+
+// NextRecordPosition returns the position for appending the provided
+// record at the end of its position field scope.
+func (dao *Dao) NextRecordPosition(record *models.Record, fieldName string) (float64, error) {
+	field, err := positionField(record.Collection(), fieldName)
+	if err != nil {
+		return 0, err
+	}
+
+	var last float64
+
+	err = dao.positionScopeQuery(record, field).
+		Select("COALESCE(MAX([[" + inflector.Columnify(field.Name) + "]]), 0)").
+		AndWhere(dbx.Not(dbx.HashExp{"id": record.Id})).
+		Row(&last)
+	if err != nil {
+		return 0, err
+	}
+
+	return last + RecordPositionGap, nil
+}
+
+// MovedRecordPosition returns the new position value that the provided
+// record will have if moved right before (or after) the target record
+// from the same position field scope (see [Dao.MoveRecordPosition]).
+func (dao *Dao) MovedRecordPosition(record *models.Record, fieldName string, targetId string, placeBefore bool) (float64, error) {
+	field, err := positionField(record.Collection(), fieldName)
+	if err != nil {
+		return 0, err
+	}
+
+	scoped, insertIndex, err := dao.positionMoveScope(record, field, targetId, placeBefore)
+	if err != nil {
+		return 0, err
+	}
+
+	position, _ := movedPosition(scoped, field, insertIndex)
+
+	return position, nil
+}
+
+// MoveRecordPosition atomically moves the provided record right before
+// (or after) the target record from the same position field scope.
+//
+// The new position is the middle between the target record and its
+// neighbour (or a gap away when moved at the start or the end of the scope).
+// If there is no longer enough space between them, all positions of the
+// scope are rebalanced.
+func (dao *Dao) MoveRecordPosition(record *models.Record, fieldName string, targetId string, placeBefore bool) error {
+	field, err := positionField(record.Collection(), fieldName)
+	if err != nil {
+		return err
+	}
+
+	// note: the scope is loaded inside the transaction to
+	// prevent concurrent reorders working with stale positions
+	return dao.RunInTransaction(func(txDao *Dao) error {
+		scoped, insertIndex, err := txDao.positionMoveScope(record, field, targetId, placeBefore)
+		if err != nil {
+			return err
+		}
+
+		position, rebalance := movedPosition(scoped, field, insertIndex)
+		if !rebalance {
+			record.Set(field.Name, position)
+			return txDao.SaveRecord(record)
+		}
+
+		ordered := slices.Insert(scoped, insertIndex, record)
+		for i, r := range ordered {
+			position := float64(i+1) * RecordPositionGap
+			if r != record && r.GetFloat(field.Name) == position {
+				continue // already at the right place
+			}
+
+			r.Set(field.Name, position)
+			if err := txDao.SaveRecord(r); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// positionMoveScope loads the position field scope records (without
+// the moved record) and returns them together with the moved record insert index.
+func (dao *Dao) positionMoveScope(
+	record *models.Record,
+	field *schema.SchemaField,
+	targetId string,
+	placeBefore bool,
+) ([]*models.Record, int, error) {
+	if targetId == record.Id {
+		return nil, 0, errors.New("the record cannot be moved relative to itself")
+	}
+
+	column := inflector.Columnify(field.Name)
+
+	scoped := []*models.Record{}
+
+	err := dao.positionScopeQuery(record, field).
+		AndWhere(dbx.Not(dbx.HashExp{"id": record.Id})).
+		OrderBy(column+" ASC", "rowid ASC").
+		All(&scoped)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	targetIndex := slices.IndexFunc(scoped, func(r *models.Record) bool {
+		return r.Id == targetId
+	})
+	if targetIndex == -1 {
+		return nil, 0, errors.New("the target record is missing or it is not from the same scope")
+	}
+
+	insertIndex := targetIndex
+	if !placeBefore {
+		insertIndex++
+	}
+
+	return scoped, insertIndex, nil
+}
+
+// movedPosition returns the position of a record inserted at insertIndex
+// in the provided sorted scope records.
+//
+// If there is no longer enough space at the insert index, it reports that
+// the scope needs to be rebalanced (aka. the returned position is the
+// rebalanced one).
+func movedPosition(scoped []*models.Record, field *schema.SchemaField, insertIndex int) (float64, bool) {
+	switch insertIndex {
+	case 0:
+		return scoped[0].GetFloat(field.Name) - RecordPositionGap, false
+	case len(scoped):
+		return scoped[len(scoped)-1].GetFloat(field.Name) + RecordPositionGap, false
+	}
+
+	low := scoped[insertIndex-1].GetFloat(field.Name)
+	high := scoped[insertIndex].GetFloat(field.Name)
+	if high-low > recordPositionMinGap {
+		return low + (high-low)/2, false
+	}
+
+	return float64(insertIndex+1) * RecordPositionGap, true
+}
+
+// positionScopeQuery returns a records query that matches
+// all records from the same position field scope.
+func (dao *Dao) positionScopeQuery(record *models.Record, field *schema.SchemaField) *dbx.SelectQuery {
+	query := dao.RecordQuery(record.Collection())
+
+	options, _ := field.Options.(*schema.PositionOptions)
+	if options == nil {
+		return query
+	}
+
+	for _, name := range options.Scope {
+		query.AndWhere(dbx.HashExp{inflector.Columnify(name): record.Get(name)})
+	}
+
+	return query
+}
+
+func positionField(collection *models.Collection, fieldName string) (*schema.SchemaField, error) {
+	field := collection.Schema.GetFieldByName(fieldName)
+	if field == nil || field.Type != schema.FieldTypePosition {
+		return nil, fmt.Errorf("%q is not a position field", fieldName)
+	}
+
+	field.InitOptions()
+
+	return field, nil
+}
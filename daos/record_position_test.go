@@ -0,0 +1,215 @@
+package daos_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+// createPositionCollection creates a "cards" collection with
+// a "position" field scoped by "board" and the following records:
+//
+//	board "a": card0000000001a (1000), card0000000002a (2000), card0000000003a (2000.0000001)
+//	board "b": card0000000001b (5)
+func createPositionCollection(t *testing.T, dao *daos.Dao) *models.Collection {
+	collection := &models.Collection{}
+	collection.Name = "cards"
+	collection.Type = models.CollectionTypeBase
+	collection.Schema = schema.NewSchema(
+		&schema.SchemaField{
+			Name: "board",
+			Type: schema.FieldTypeText,
+		},
+		&schema.SchemaField{
+			Name:    "position",
+			Type:    schema.FieldTypePosition,
+			Options: &schema.PositionOptions{Scope: []string{"board"}},
+		},
+	)
+	if err := dao.SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	cards := []struct {
+		id       string
+		board    string
+		position float64
+	}{
+		{"card0000000001a", "a", 1000},
+		{"card0000000002a", "a", 2000},
+		{"card0000000003a", "a", 2000.0000001},
+		{"card0000000001b", "b", 5},
+	}
+	for _, c := range cards {
+		record := models.NewRecord(collection)
+		record.Id = c.id
+		record.Set("board", c.board)
+		record.Set("position", c.position)
+		if err := dao.SaveRecord(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return collection
+}
+
+func TestNextRecordPosition(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := createPositionCollection(t, app.Dao())
+
+	scenarios := []struct {
+		board    string
+		field    string
+		expected float64
+	}{
+		{"a", "position", 2000.0000001 + daos.RecordPositionGap},
+		{"b", "position", 5 + daos.RecordPositionGap},
+		{"c", "position", daos.RecordPositionGap},
+	}
+
+	for _, s := range scenarios {
+		record := models.NewRecord(collection)
+		record.Set("board", s.board)
+
+		position, err := app.Dao().NextRecordPosition(record, s.field)
+		if err != nil {
+			t.Fatalf("[%s] %v", s.board, err)
+		}
+
+		if position != s.expected {
+			t.Fatalf("[%s] Expected position %v, got %v", s.board, s.expected, position)
+		}
+	}
+
+	if _, err := app.Dao().NextRecordPosition(models.NewRecord(collection), "board"); err == nil {
+		t.Fatal("Expected non position field error, got nil")
+	}
+}
+
+func TestMoveRecordPosition(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		id          string
+		targetId    string
+		placeBefore bool
+		expectError bool
+		expected    map[string]float64
+	}{
+		{
+			name:        "moving relative to itself",
+			id:          "card0000000001a",
+			targetId:    "card0000000001a",
+			expectError: true,
+		},
+		{
+			name:        "target from another scope",
+			id:          "card0000000001a",
+			targetId:    "card0000000001b",
+			expectError: true,
+		},
+		{
+			name:        "missing target",
+			id:          "card0000000001a",
+			targetId:    "missing",
+			expectError: true,
+		},
+		{
+			name:     "after the last",
+			id:       "card0000000001a",
+			targetId: "card0000000003a",
+			expected: map[string]float64{
+				"card0000000001a": 2000.0000001 + daos.RecordPositionGap,
+				"card0000000002a": 2000,
+				"card0000000003a": 2000.0000001,
+			},
+		},
+		{
+			name:        "before the first",
+			id:          "card0000000002a",
+			targetId:    "card0000000001a",
+			placeBefore: true,
+			expected: map[string]float64{
+				"card0000000001a": 1000,
+				"card0000000002a": 1000 - daos.RecordPositionGap,
+				"card0000000003a": 2000.0000001,
+			},
+		},
+		{
+			name:        "in the middle",
+			id:          "card0000000003a",
+			targetId:    "card0000000002a",
+			placeBefore: true,
+			expected: map[string]float64{
+				"card0000000001a": 1000,
+				"card0000000002a": 2000,
+				"card0000000003a": 1500,
+			},
+		},
+		{
+			name:     "in the middle with rebalance",
+			id:       "card0000000001a",
+			targetId: "card0000000002a",
+			expected: map[string]float64{
+				"card0000000002a": 1 * daos.RecordPositionGap,
+				"card0000000001a": 2 * daos.RecordPositionGap,
+				"card0000000003a": 3 * daos.RecordPositionGap,
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			collection := createPositionCollection(t, app.Dao())
+
+			record, err := app.Dao().FindRecordById(collection.Id, s.id)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			movedPosition, movedErr := app.Dao().MovedRecordPosition(record, "position", s.targetId, s.placeBefore)
+
+			err = app.Dao().MoveRecordPosition(record, "position", s.targetId, s.placeBefore)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if hasMovedErr := movedErr != nil; hasMovedErr != s.expectError {
+				t.Fatalf("Expected MovedRecordPosition hasErr %v, got %v (%v)", s.expectError, hasMovedErr, movedErr)
+			}
+
+			if expected, ok := s.expected[s.id]; ok && movedPosition != expected {
+				t.Fatalf("Expected MovedRecordPosition %v, got %v", expected, movedPosition)
+			}
+
+			for id, expected := range s.expected {
+				r, err := app.Dao().FindRecordById(collection.Id, id)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if position := r.GetFloat("position"); position != expected {
+					t.Fatalf("Expected %s position %v, got %v", id, expected, position)
+				}
+			}
+
+			// the other scope should remain unchanged
+			other, err := app.Dao().FindRecordById(collection.Id, "card0000000001b")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if position := other.GetFloat("position"); position != 5 {
+				t.Fatalf("Expected the other scope position to be unchanged, got %v", position)
+			}
+		})
+	}
+}
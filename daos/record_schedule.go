@@ -0,0 +1,108 @@
+package daos
+
+import (
+	"fmt"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/inflector"
+	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// RecordScheduleRunQuery returns a new RecordScheduleRun select query.
+func (dao *Dao) RecordScheduleRunQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.RecordScheduleRun{})
+}
+
+// FindDueScheduledRecords returns up to limit records of the provided
+// collection whose schedule field datetime is reached (aka. <= now)
+// and that were not processed for their current field value yet.
+//
+// The records are sorted by their schedule field datetime.
+func (dao *Dao) FindDueScheduledRecords(
+	collection *models.Collection,
+	schedule models.CollectionSchedule,
+	now types.DateTime,
+	limit int,
+	excludeIds ...string,
+) ([]*models.Record, error) {
+	tableAlias := inflector.Columnify(collection.Name)
+	column := tableAlias + "." + inflector.Columnify(schedule.Field)
+
+	query := dao.RecordQuery(collection).
+		AndWhere(dbx.NewExp(fmt.Sprintf("[[%s]] != ''", column))).
+		AndWhere(dbx.NewExp(fmt.Sprintf("[[%s]] <= {:now}", column), dbx.Params{"now": now.String()})).
+		AndWhere(dbx.NewExp(
+			fmt.Sprintf(
+				"NOT EXISTS (SELECT 1 FROM {{%s}} [[__sr]] WHERE [[__sr.collectionId]] = {:collectionId} AND [[__sr.recordId]] = [[%s.id]] AND [[__sr.schedule]] = {:schedule} AND [[__sr.scheduledAt]] = [[%s]])",
+				(&models.RecordScheduleRun{}).TableName(),
+				tableAlias,
+				column,
+			),
+			dbx.Params{
+				"collectionId": collection.Id,
+				"schedule":     schedule.Name,
+			},
+		)).
+		OrderBy(fmt.Sprintf("[[%s]] ASC", column), "[["+tableAlias+".id]] ASC").
+		Limit(int64(limit))
+
+	if len(excludeIds) > 0 {
+		query.AndWhere(dbx.NotIn(tableAlias+".id", list.ToInterfaceSlice(excludeIds)...))
+	}
+
+	records := []*models.Record{}
+	if err := query.All(&records); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// RunRecordSchedule executes the "update" or "delete" schedule action
+// for the provided record and marks the schedule as executed for the
+// current record trigger time in a single transaction.
+//
+// For the "webhook" schedule action only the run is saved
+// (the webhook is expected to be sent by the caller beforehand).
+//
+// Note that the "update" action data is set directly to the record
+// without running the record upsert form validations.
+func (dao *Dao) RunRecordSchedule(record *models.Record, schedule models.CollectionSchedule) error {
+	return dao.RunInTransaction(func(txDao *Dao) error {
+		run := &models.RecordScheduleRun{
+			CollectionId: record.Collection().Id,
+			RecordId:     record.Id,
+			Schedule:     schedule.Name,
+			ScheduledAt:  record.GetDateTime(schedule.Field),
+		}
+		if err := txDao.Save(run); err != nil {
+			return err
+		}
+
+		switch schedule.Action {
+		case models.ScheduleActionUpdate:
+			for k, v := range schedule.Data {
+				record.Set(k, v)
+			}
+			return txDao.SaveRecord(record)
+		case models.ScheduleActionDelete:
+			return txDao.DeleteRecord(record)
+		}
+
+		return nil
+	})
+}
+
+// deleteRecordScheduleRuns deletes all schedule runs of the provided record.
+//
+// The runs are deleted without triggering the model hooks.
+func (dao *Dao) deleteRecordScheduleRuns(record *models.Record) error {
+	_, err := dao.NonconcurrentDB().Delete((&models.RecordScheduleRun{}).TableName(), dbx.HashExp{
+		"collectionId": record.Collection().Id,
+		"recordId":     record.Id,
+	}).Execute()
+
+	return err
+}
@@ -0,0 +1,200 @@
+package daos_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// enableSchedules adds a "publishAt" date field to demo2 and sets
+// the llvuca81nly1qls and achvryl401bhse3 records trigger times in the past.
+func enableSchedules(t *testing.T, dao *daos.Dao, schedules ...models.CollectionSchedule) *models.Collection {
+	collection, err := dao.FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	collection.Schema.AddField(&schema.SchemaField{
+		Name: "publishAt",
+		Type: schema.FieldTypeDate,
+	})
+	collection.SetOptions(models.CollectionBaseOptions{Schedules: schedules})
+	if err := dao.SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	past := map[string]time.Duration{
+		"llvuca81nly1qls": -2 * time.Hour,
+		"achvryl401bhse3": -1 * time.Hour,
+		"0yxhwia2amd8gec": 1 * time.Hour, // future
+	}
+	for id, d := range past {
+		record, err := dao.FindRecordById(collection.Id, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		record.Set("publishAt", time.Now().Add(d))
+		if err := dao.SaveRecord(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return collection
+}
+
+func TestRecordScheduleRunQuery(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	expected := "SELECT {{_recordScheduleRuns}}.* FROM `_recordScheduleRuns`"
+
+	sql := app.Dao().RecordScheduleRunQuery().Build().SQL()
+	if sql != expected {
+		t.Errorf("Expected sql %s, got %s", expected, sql)
+	}
+}
+
+func TestFindDueScheduledRecords(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	schedule := models.CollectionSchedule{
+		Name:   "publish",
+		Field:  "publishAt",
+		Action: models.ScheduleActionUpdate,
+		Data:   map[string]any{"active": true},
+	}
+
+	collection := enableSchedules(t, app.Dao(), schedule)
+
+	now := types.NowDateTime()
+
+	scenarios := []struct {
+		name       string
+		limit      int
+		excludeIds []string
+		expected   []string
+	}{
+		{"all due", 10, nil, []string{"llvuca81nly1qls", "achvryl401bhse3"}},
+		{"with limit", 1, nil, []string{"llvuca81nly1qls"}},
+		{"with excluded ids", 10, []string{"llvuca81nly1qls"}, []string{"achvryl401bhse3"}},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			records, err := app.Dao().FindDueScheduledRecords(collection, schedule, now, s.limit, s.excludeIds...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(records) != len(s.expected) {
+				t.Fatalf("Expected %d records, got %d", len(s.expected), len(records))
+			}
+
+			for i, id := range s.expected {
+				if records[i].Id != id {
+					t.Fatalf("Expected record %d to be %q, got %q", i, id, records[i].Id)
+				}
+			}
+		})
+	}
+
+	// execute the first record schedule
+	record, err := app.Dao().FindRecordById(collection.Id, "llvuca81nly1qls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := app.Dao().RunRecordSchedule(record, schedule); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := app.Dao().FindDueScheduledRecords(collection, schedule, now, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || records[0].Id != "achvryl401bhse3" {
+		t.Fatalf("Expected only the achvryl401bhse3 record to be due, got %v", records)
+	}
+
+	// changing the trigger time should make the record due again
+	record.Set("publishAt", time.Now().Add(-30*time.Minute))
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err = app.Dao().FindDueScheduledRecords(collection, schedule, types.NowDateTime(), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 due records after the trigger time change, got %d", len(records))
+	}
+}
+
+func TestRunRecordSchedule(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	publish := models.CollectionSchedule{
+		Name:   "publish",
+		Field:  "publishAt",
+		Action: models.ScheduleActionUpdate,
+		Data:   map[string]any{"active": true},
+	}
+
+	expire := models.CollectionSchedule{
+		Name:   "expire",
+		Field:  "publishAt",
+		Action: models.ScheduleActionDelete,
+	}
+
+	collection := enableSchedules(t, app.Dao(), publish, expire)
+
+	record, err := app.Dao().FindRecordById(collection.Id, "llvuca81nly1qls")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// update
+	if err := app.Dao().RunRecordSchedule(record, publish); err != nil {
+		t.Fatal(err)
+	}
+
+	updated, err := app.Dao().FindRecordById(collection.Id, record.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !updated.GetBool("active") {
+		t.Fatal("Expected the record to be updated")
+	}
+
+	total := 0
+	if err := app.Dao().RecordScheduleRunQuery().Select("count(*)").Row(&total); err != nil {
+		t.Fatal(err)
+	}
+	if total != 1 {
+		t.Fatalf("Expected 1 schedule run, got %d", total)
+	}
+
+	// delete
+	if err := app.Dao().RunRecordSchedule(updated, expire); err != nil {
+		t.Fatal(err)
+	}
+
+	if r, _ := app.Dao().FindRecordById(collection.Id, record.Id); r != nil {
+		t.Fatal("Expected the record to be deleted")
+	}
+
+	// the runs of the deleted record should be also deleted
+	if err := app.Dao().RecordScheduleRunQuery().Select("count(*)").Row(&total); err != nil {
+		t.Fatal(err)
+	}
+	if total != 0 {
+		t.Fatalf("Expected 0 schedule runs, got %d", total)
+	}
+}
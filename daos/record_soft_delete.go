@@ -0,0 +1,136 @@
+package daos
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+var errSoftDeleteDisabled = errors.New("the collection soft delete is not enabled")
+
+// NotSoftDeletedExpr returns an expression that excludes
+// the soft deleted records of the provided collection.
+//
+// Returns nil if the collection soft delete is not enabled.
+func NotSoftDeletedExpr(collection *models.Collection) dbx.Expression {
+	field := collection.SoftDeleteField()
+	if field == "" {
+		return nil
+	}
+
+	return dbx.NewExp(fmt.Sprintf(
+		"([[%s.%s]] = '' OR [[%s.%s]] IS NULL)",
+		collection.Name, field, collection.Name, field,
+	))
+}
+
+// SoftDeletedExpr returns an expression that matches only
+// the soft deleted records of the provided collection.
+//
+// Returns nil if the collection soft delete is not enabled.
+func SoftDeletedExpr(collection *models.Collection) dbx.Expression {
+	field := collection.SoftDeleteField()
+	if field == "" {
+		return nil
+	}
+
+	return dbx.NewExp(fmt.Sprintf("[[%s.%s]] != ''", collection.Name, field))
+}
+
+// SoftDeleteRecord marks the provided record as deleted by setting
+// its collection soft delete field to the current date.
+func (dao *Dao) SoftDeleteRecord(record *models.Record) error {
+	field := record.Collection().SoftDeleteField()
+	if field == "" {
+		return errSoftDeleteDisabled
+	}
+
+	record.Set(field, types.NowDateTime())
+
+	return dao.SaveRecord(record)
+}
+
+// RestoreSoftDeletedRecord unmarks the provided soft deleted record
+// by clearing its collection soft delete field.
+func (dao *Dao) RestoreSoftDeletedRecord(record *models.Record) error {
+	field := record.Collection().SoftDeleteField()
+	if field == "" {
+		return errSoftDeleteDisabled
+	}
+
+	record.Set(field, "")
+
+	return dao.SaveRecord(record)
+}
+
+// FindSoftDeletedRecordIds returns the ids of the collection
+// records soft deleted after the specified datetime.
+func (dao *Dao) FindSoftDeletedRecordIds(collection *models.Collection, since types.DateTime) ([]string, error) {
+	field := collection.SoftDeleteField()
+	if field == "" {
+		return []string{}, nil
+	}
+
+	ids := []string{}
+
+	err := dao.RecordQuery(collection).
+		Select(fmt.Sprintf("{{%s}}.[[id]]", collection.Name)).
+		AndWhere(dbx.NewExp(
+			fmt.Sprintf("[[%s.%s]] > {:since}", collection.Name, field),
+			dbx.Params{"since": since.String()},
+		)).
+		OrderBy(fmt.Sprintf("[[%s.%s]] ASC", collection.Name, field)).
+		Column(&ids)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// PurgeSoftDeletedRecords permanently deletes (in a single transaction)
+// all soft deleted collection records that were deleted before the
+// specified datetime (zero value means all soft deleted records).
+//
+// Returns the ids of the purged records.
+func (dao *Dao) PurgeSoftDeletedRecords(collection *models.Collection, before types.DateTime) ([]string, error) {
+	field := collection.SoftDeleteField()
+	if field == "" {
+		return nil, errSoftDeleteDisabled
+	}
+
+	query := dao.RecordQuery(collection).AndWhere(SoftDeletedExpr(collection))
+
+	if !before.IsZero() {
+		query.AndWhere(dbx.NewExp(
+			fmt.Sprintf("[[%s.%s]] < {:before}", collection.Name, field),
+			dbx.Params{"before": before.String()},
+		))
+	}
+
+	records := []*models.Record{}
+	if err := query.All(&records); err != nil {
+		return nil, err
+	}
+
+	purged := make([]string, 0, len(records))
+
+	err := dao.RunInTransaction(func(txDao *Dao) error {
+		for _, record := range records {
+			if err := txDao.DeleteRecord(record); err != nil {
+				return fmt.Errorf("failed to purge record %q: %w", record.Id, err)
+			}
+			purged = append(purged, record.Id)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return purged, nil
+}
@@ -0,0 +1,173 @@
+package daos_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// enableDemo2SoftDelete adds a "deleted" date field to the demo2
+// collection and enables its soft delete mode.
+func enableDemo2SoftDelete(t *testing.T, dao *daos.Dao) *models.Collection {
+	collection, err := dao.FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	collection.Schema.AddField(&schema.SchemaField{
+		Name: "deleted",
+		Type: schema.FieldTypeDate,
+	})
+	collection.SetOptions(models.CollectionBaseOptions{SoftDeleteField: "deleted"})
+
+	if err := dao.SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	return collection
+}
+
+func TestSoftDeleteRecordDisabled(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	record, err := app.Dao().FindRecordById("demo2", "llvuca81nly1qls")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.Dao().SoftDeleteRecord(record); err == nil {
+		t.Fatal("Expected soft delete error, got nil")
+	}
+
+	if err := app.Dao().RestoreSoftDeletedRecord(record); err == nil {
+		t.Fatal("Expected restore error, got nil")
+	}
+
+	if _, err := app.Dao().PurgeSoftDeletedRecords(record.Collection(), types.DateTime{}); err == nil {
+		t.Fatal("Expected purge error, got nil")
+	}
+
+	if expr := daos.NotSoftDeletedExpr(record.Collection()); expr != nil {
+		t.Fatalf("Expected nil expression, got %v", expr)
+	}
+}
+
+func TestSoftDeleteAndRestoreRecord(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := enableDemo2SoftDelete(t, app.Dao())
+
+	before, _ := types.ParseDateTime(time.Now().Add(-1 * time.Second))
+
+	record, err := app.Dao().FindRecordById(collection.Id, "llvuca81nly1qls")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.Dao().SoftDeleteRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	// the row must be still available
+	record, err = app.Dao().FindRecordById(collection.Id, "llvuca81nly1qls")
+	if err != nil {
+		t.Fatalf("Expected the soft deleted record to be found, got %v", err)
+	}
+	if !record.IsSoftDeleted() {
+		t.Fatal("Expected the record to be marked as deleted")
+	}
+
+	var total int
+	if err := app.Dao().RecordQuery(collection).
+		Select("count(*)").
+		AndWhere(daos.NotSoftDeletedExpr(collection)).
+		Row(&total); err != nil {
+		t.Fatal(err)
+	}
+	if total != 2 {
+		t.Fatalf("Expected 2 not deleted records, got %d", total)
+	}
+
+	ids, err := app.Dao().FindSoftDeletedRecordIds(collection, before)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(ids, []string{"llvuca81nly1qls"}) {
+		t.Fatalf("Expected the soft deleted record id, got %v", ids)
+	}
+
+	if err := app.Dao().RestoreSoftDeletedRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	record, err = app.Dao().FindRecordById(collection.Id, "llvuca81nly1qls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record.IsSoftDeleted() {
+		t.Fatal("Expected the record to be restored")
+	}
+
+	ids, err = app.Dao().FindSoftDeletedRecordIds(collection, before)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 0 {
+		t.Fatalf("Expected no soft deleted records, got %v", ids)
+	}
+}
+
+func TestPurgeSoftDeletedRecords(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := enableDemo2SoftDelete(t, app.Dao())
+
+	old, err := app.Dao().FindRecordById(collection.Id, "llvuca81nly1qls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	old.Set("deleted", "2022-01-01 00:00:00.000Z")
+	if err := app.Dao().SaveRecord(old); err != nil {
+		t.Fatal(err)
+	}
+
+	recent, err := app.Dao().FindRecordById(collection.Id, "achvryl401bhse3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := app.Dao().SoftDeleteRecord(recent); err != nil {
+		t.Fatal(err)
+	}
+
+	before, _ := types.ParseDateTime("2023-01-01 00:00:00.000Z")
+
+	purged, err := app.Dao().PurgeSoftDeletedRecords(collection, before)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(purged, []string{"llvuca81nly1qls"}) {
+		t.Fatalf("Expected only the old record to be purged, got %v", purged)
+	}
+
+	purged, err = app.Dao().PurgeSoftDeletedRecords(collection, types.DateTime{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(purged, []string{"achvryl401bhse3"}) {
+		t.Fatalf("Expected the remaining soft deleted record to be purged, got %v", purged)
+	}
+
+	// the not deleted record must be untouched
+	if _, err := app.Dao().FindRecordById(collection.Id, "0yxhwia2amd8gec"); err != nil {
+		t.Fatalf("Expected the not deleted record to be found, got %v", err)
+	}
+}
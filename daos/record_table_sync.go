@@ -74,7 +74,11 @@ func (dao *Dao) SyncRecordTableSchema(newCollection *models.Collection, oldColle
 				}
 			}
 
-			return txDao.createCollectionIndexes(newCollection)
+			if err := txDao.createCollectionIndexes(newCollection); err != nil {
+				return err
+			}
+
+			return txDao.syncLedgerTriggers(newCollection)
 		}
 
 		// update
@@ -158,7 +162,11 @@ func (dao *Dao) SyncRecordTableSchema(newCollection *models.Collection, oldColle
 			return err
 		}
 
-		return txDao.createCollectionIndexes(newCollection)
+		if err := txDao.createCollectionIndexes(newCollection); err != nil {
+			return err
+		}
+
+		return txDao.syncLedgerTriggers(newCollection)
 	})
 }
 
@@ -307,10 +315,26 @@ func (dao *Dao) dropCollectionIndex(collection *models.Collection) error {
 			}
 		}
 
+		// drop the list fields length indexes
+		for _, field := range collection.Schema.Fields() {
+			if field.Type != schema.FieldTypeList {
+				continue
+			}
+
+			if _, err := txDao.DB().NewQuery(fmt.Sprintf("DROP INDEX IF EXISTS [[%s]]", listLengthIndexName(collection, field))).Execute(); err != nil {
+				return err
+			}
+		}
+
 		return nil
 	})
 }
 
+// listLengthIndexName returns the name of the collection list field length index.
+func listLengthIndexName(collection *models.Collection, field *schema.SchemaField) string {
+	return "_" + collection.Id + "_" + field.Name + "_length_idx"
+}
+
 func (dao *Dao) createCollectionIndexes(collection *models.Collection) error {
 	if collection.IsView() {
 		return nil // views don't have indexes
@@ -355,6 +379,25 @@ func (dao *Dao) createCollectionIndexes(collection *models.Collection) error {
 			return validation.Errors{"indexes": errs}
 		}
 
+		// create the indexed list fields length indexes
+		// (the expression must match with the one used by the ":length" filter modifier)
+		for _, field := range collection.Schema.Fields() {
+			options, ok := field.Options.(*schema.ListOptions)
+			if !ok || !options.Indexed {
+				continue
+			}
+
+			_, err := txDao.DB().NewQuery(fmt.Sprintf(
+				"CREATE INDEX [[%s]] ON {{%s}} (json_array_length([[%s]]))",
+				listLengthIndexName(collection, field),
+				collection.Name,
+				field.Name,
+			)).Execute()
+			if err != nil {
+				return err
+			}
+		}
+
 		return nil
 	})
 }
@@ -862,6 +862,61 @@ func TestIsRecordValueUnique(t *testing.T) {
 	}
 }
 
+func TestIsRecordValueUniqueCanonicalEmail(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	users, err := app.Dao().FindCollectionByNameOrId("users")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	record, err := app.Dao().FindRecordById(users.Id, "oap640cot4yru2s")
+	if err != nil {
+		t.Fatal(err)
+	}
+	record.SetEmail("John.Doe+news@GoogleMail.com")
+	if err := app.Dao().WithoutHooks().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		policy     string
+		value      string
+		excludeIds []string
+		expected   bool
+	}{
+		{models.EmailCanonicalizationNone, "TEST@example.com", nil, true},
+		{models.EmailCanonicalizationNone, "johndoe@gmail.com", nil, true},
+		{models.EmailCanonicalizationLowercase, "TEST@example.com", nil, false},
+		{models.EmailCanonicalizationLowercase, "TEST@example.com", []string{"4q1xlclmfloku33"}, true},
+		{models.EmailCanonicalizationLowercase, "test+1@example.com", nil, true},
+		{models.EmailCanonicalizationLowercase, "john.doe+news@googlemail.com", nil, false},
+		{models.EmailCanonicalizationLowercase, "johndoe@gmail.com", nil, true},
+		{models.EmailCanonicalizationFull, "test+1@example.com", nil, false},
+		{models.EmailCanonicalizationFull, "te.st@example.com", nil, true},
+		{models.EmailCanonicalizationFull, "johndoe@gmail.com", nil, false},
+		{models.EmailCanonicalizationFull, "J.O.H.N.D.O.E+other@gmail.com", nil, false},
+		{models.EmailCanonicalizationFull, "johndoe@gmail.com", []string{"oap640cot4yru2s"}, true},
+		{models.EmailCanonicalizationFull, "johndoe@example.com", nil, true},
+		{models.EmailCanonicalizationFull, "invalid", nil, true},
+	}
+
+	for i, s := range scenarios {
+		options := users.AuthOptions()
+		options.EmailCanonicalization = s.policy
+		users.SetOptions(options)
+		if err := app.Dao().WithoutHooks().SaveCollection(users); err != nil {
+			t.Fatal(err)
+		}
+
+		result := app.Dao().IsRecordValueUnique(users.Id, schema.FieldNameEmail, s.value, s.excludeIds...)
+		if result != s.expected {
+			t.Errorf("(%d) Expected %v, got %v", i, s.expected, result)
+		}
+	}
+}
+
 func TestFindAuthRecordByToken(t *testing.T) {
 	app, _ := tests.NewTestApp()
 	defer app.Cleanup()
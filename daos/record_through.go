@@ -0,0 +1,111 @@
+package daos
+
+import (
+	"slices"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tools/inflector"
+	"github.com/pocketbase/pocketbase/tools/list"
+)
+
+// FindCollectionThroughReferences returns information for all
+// "through" relation schema fields that use the provided collection
+// as their join collection.
+func (dao *Dao) FindCollectionThroughReferences(joinCollection *models.Collection) (map[*models.Collection][]*schema.SchemaField, error) {
+	collections := []*models.Collection{}
+
+	// the join collection id is always part of the referencing collections schema
+	err := dao.CollectionQuery().
+		AndWhere(dbx.Like("schema", joinCollection.Id)).
+		All(&collections)
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[*models.Collection][]*schema.SchemaField{}
+
+	for _, c := range collections {
+		if c.IsView() {
+			continue
+		}
+
+		for _, f := range c.Schema.Fields() {
+			if f.Type != schema.FieldTypeRelation {
+				continue
+			}
+			f.InitOptions()
+			options, _ := f.Options.(*schema.RelationOptions)
+			if options != nil && options.Through != nil && options.Through.CollectionId == joinCollection.Id {
+				result[c] = append(result[c], f)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// FindThroughRelationIds returns the ids of the related records of
+// the provided "through" relation field source record id
+// (in the order of their join records creation).
+func (dao *Dao) FindThroughRelationIds(through *schema.RelationThrough, sourceId string) ([]string, error) {
+	ids := []string{}
+
+	targetCol := inflector.Columnify(through.TargetField)
+
+	err := dao.RecordQuery(through.CollectionId).
+		Select("[["+targetCol+"]]").
+		AndWhere(dbx.HashExp{inflector.Columnify(through.SourceField): sourceId}).
+		AndWhere(dbx.NewExp("[["+targetCol+"]] != ''")).
+		OrderBy("created ASC", "rowid ASC").
+		Column(&ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return list.ToUniqueStringSlice(ids), nil
+}
+
+// syncThroughRelations refreshes the "through" relation field values
+// of the source records referenced by the provided join records.
+//
+// NB! This method is expected to be called inside a transaction.
+func (dao *Dao) syncThroughRelations(refs map[*models.Collection][]*schema.SchemaField, joinRecords ...*models.Record) error {
+	for refCollection, fields := range refs {
+		for _, field := range fields {
+			options, _ := field.Options.(*schema.RelationOptions)
+			if options == nil || options.Through == nil {
+				continue
+			}
+
+			sourceIds := make([]string, 0, len(joinRecords))
+			for _, joinRecord := range joinRecords {
+				sourceIds = append(sourceIds, joinRecord.GetString(options.Through.SourceField))
+			}
+
+			for _, sourceId := range list.NonzeroUniques(sourceIds) {
+				source, err := dao.FindRecordById(refCollection.Id, sourceId)
+				if err != nil {
+					continue // missing or already deleted source record
+				}
+
+				ids, err := dao.FindThroughRelationIds(options.Through, sourceId)
+				if err != nil {
+					return err
+				}
+
+				if slices.Equal(ids, source.GetStringSlice(field.Name)) {
+					continue // no changes
+				}
+
+				source.Set(field.Name, field.PrepareValue(ids))
+				if err := dao.SaveRecord(source); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
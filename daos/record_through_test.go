@@ -0,0 +1,223 @@
+package daos_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// createThroughRelation creates a "memberships" join collection
+// and a "users.items" through relation field to "demo2".
+func createThroughRelation(t *testing.T, dao *daos.Dao) (*models.Collection, *models.Collection) {
+	users, err := dao.FindCollectionByNameOrId("users")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	demo2, err := dao.FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	memberships := &models.Collection{
+		Name: "memberships",
+		Type: models.CollectionTypeBase,
+		Schema: schema.NewSchema(
+			&schema.SchemaField{
+				Name: "user",
+				Type: schema.FieldTypeRelation,
+				Options: &schema.RelationOptions{
+					CollectionId:  users.Id,
+					MaxSelect:     types.Pointer(1),
+					CascadeDelete: true,
+				},
+			},
+			&schema.SchemaField{
+				Name: "item",
+				Type: schema.FieldTypeRelation,
+				Options: &schema.RelationOptions{
+					CollectionId:  demo2.Id,
+					MaxSelect:     types.Pointer(1),
+					CascadeDelete: true,
+				},
+			},
+			&schema.SchemaField{
+				Name: "role",
+				Type: schema.FieldTypeText,
+			},
+		),
+	}
+	if err := dao.SaveCollection(memberships); err != nil {
+		t.Fatal(err)
+	}
+
+	users.Schema.AddField(&schema.SchemaField{
+		Name: "items",
+		Type: schema.FieldTypeRelation,
+		Options: &schema.RelationOptions{
+			CollectionId: demo2.Id,
+			Through: &schema.RelationThrough{
+				CollectionId: memberships.Id,
+				SourceField:  "user",
+				TargetField:  "item",
+			},
+		},
+	})
+	if err := dao.SaveCollection(users); err != nil {
+		t.Fatal(err)
+	}
+
+	return users, memberships
+}
+
+func createMembership(t *testing.T, dao *daos.Dao, memberships *models.Collection, user, item, role string) *models.Record {
+	record := models.NewRecord(memberships)
+	record.Set("user", user)
+	record.Set("item", item)
+	record.Set("role", role)
+	if err := dao.SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+	return record
+}
+
+func TestFindCollectionThroughReferences(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	users, memberships := createThroughRelation(t, app.Dao())
+
+	// join collection
+	result, err := app.Dao().FindCollectionThroughReferences(memberships)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result) != 1 {
+		t.Fatalf("Expected 1 collection, got %d: %v", len(result), result)
+	}
+	for col, fields := range result {
+		if col.Id != users.Id {
+			t.Fatalf("Expected collection %q, got %q", users.Id, col.Id)
+		}
+		if len(fields) != 1 || fields[0].Name != "items" {
+			t.Fatalf("Expected the items field, got %v", fields)
+		}
+	}
+
+	// regular relation collection
+	result, err = app.Dao().FindCollectionThroughReferences(users)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result) != 0 {
+		t.Fatalf("Expected no references, got %v", result)
+	}
+}
+
+func TestThroughRelationSync(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	users, memberships := createThroughRelation(t, app.Dao())
+
+	assertItems := func(expected ...string) {
+		t.Helper()
+
+		user, err := app.Dao().FindRecordById(users.Id, "4q1xlclmfloku33")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		items := user.GetStringSlice("items")
+		if !slices.Equal(items, expected) {
+			t.Fatalf("Expected items %v, got %v", expected, items)
+		}
+	}
+
+	m1 := createMembership(t, app.Dao(), memberships, "4q1xlclmfloku33", "llvuca81nly1qls", "admin")
+	assertItems("llvuca81nly1qls")
+
+	m2 := createMembership(t, app.Dao(), memberships, "4q1xlclmfloku33", "achvryl401bhse3", "editor")
+	assertItems("llvuca81nly1qls", "achvryl401bhse3")
+
+	// membership for another user
+	createMembership(t, app.Dao(), memberships, "oap640cot4yru2s", "0yxhwia2amd8gec", "admin")
+	assertItems("llvuca81nly1qls", "achvryl401bhse3")
+
+	// change the target
+	m2.Set("item", "0yxhwia2amd8gec")
+	if err := app.Dao().SaveRecord(m2); err != nil {
+		t.Fatal(err)
+	}
+	assertItems("llvuca81nly1qls", "0yxhwia2amd8gec")
+
+	// change the source
+	m1.Set("user", "oap640cot4yru2s")
+	if err := app.Dao().SaveRecord(m1); err != nil {
+		t.Fatal(err)
+	}
+	assertItems("0yxhwia2amd8gec")
+
+	other, err := app.Dao().FindRecordById(users.Id, "oap640cot4yru2s")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if items := other.GetStringSlice("items"); !slices.Equal(items, []string{"llvuca81nly1qls", "0yxhwia2amd8gec"}) {
+		t.Fatalf("Expected the other user items to be updated, got %v", items)
+	}
+
+	// delete
+	if err := app.Dao().DeleteRecord(m2); err != nil {
+		t.Fatal(err)
+	}
+	assertItems()
+}
+
+func TestExpandThroughRelation(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	users, memberships := createThroughRelation(t, app.Dao())
+
+	createMembership(t, app.Dao(), memberships, "4q1xlclmfloku33", "llvuca81nly1qls", "admin")
+	createMembership(t, app.Dao(), memberships, "4q1xlclmfloku33", "achvryl401bhse3", "editor")
+	createMembership(t, app.Dao(), memberships, "oap640cot4yru2s", "llvuca81nly1qls", "viewer")
+
+	records, err := app.Dao().FindRecordsByIds(users.Id, []string{"4q1xlclmfloku33", "oap640cot4yru2s"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if failed := app.Dao().ExpandRecords(records, []string{"items"}, nil); len(failed) > 0 {
+		t.Fatalf("Expected no failed expands, got %v", failed)
+	}
+
+	expected := map[string]map[string]string{
+		"4q1xlclmfloku33": {"llvuca81nly1qls": "admin", "achvryl401bhse3": "editor"},
+		"oap640cot4yru2s": {"llvuca81nly1qls": "viewer"},
+	}
+
+	for _, record := range records {
+		items := record.ExpandedAll("items")
+
+		if len(items) != len(expected[record.Id]) {
+			t.Fatalf("[%s] Expected %d expanded items, got %d", record.Id, len(expected[record.Id]), len(items))
+		}
+
+		for _, item := range items {
+			membership, _ := item.Expand()["memberships"].(*models.Record)
+			if membership == nil {
+				t.Fatalf("[%s] Missing membership expand for item %s", record.Id, item.Id)
+			}
+
+			if role := membership.GetString("role"); role != expected[record.Id][item.Id] {
+				t.Fatalf("[%s] Expected item %s role %q, got %q", record.Id, item.Id, expected[record.Id][item.Id], role)
+			}
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package daos
+
+import (
+	"fmt"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/inflector"
+)
+
+// TreeNode defines a single tree collection record position
+// relative to another record from the same tree.
+type TreeNode struct {
+	Id    string `db:"id" json:"id"`
+	Depth int    `db:"depth" json:"depth"`
+}
+
+// FindTreeAncestorNodes returns the ancestors of the specified tree
+// collection record, starting from its direct parent (depth 1) up to
+// the tree root or the maxDepth limit.
+func (dao *Dao) FindTreeAncestorNodes(collection *models.Collection, recordId string, maxDepth int) ([]TreeNode, error) {
+	treeField := collection.TreeField()
+	if treeField == "" {
+		return nil, fmt.Errorf("Collection %q is not a tree.", collection.Name)
+	}
+
+	parentCol := inflector.Columnify(treeField)
+
+	// note: UNION with depth limit to prevent infinite loops in case of a cycle
+	query := fmt.Sprintf(
+		`WITH RECURSIVE {{__tree}}([[id]], [[parent]], [[depth]]) AS (
+			SELECT [[id]], [[%[2]s]], 0 FROM {{%[1]s}} WHERE [[id]] = {:id}
+			UNION
+			SELECT [[t.id]], [[t.%[2]s]], [[tree.depth]] + 1
+			FROM {{%[1]s}} [[t]]
+			INNER JOIN {{__tree}} [[tree]] ON [[t.id]] = [[tree.parent]]
+			WHERE [[tree.depth]] < {:maxDepth}
+		)
+		SELECT [[id]], MIN([[depth]]) as [[depth]] FROM {{__tree}}
+		WHERE [[depth]] > 0 AND [[id]] != {:id}
+		GROUP BY [[id]]
+		ORDER BY [[depth]] ASC`,
+		collection.Name,
+		parentCol,
+	)
+
+	nodes := []TreeNode{}
+
+	err := dao.DB().NewQuery(query).Bind(dbx.Params{
+		"id":       recordId,
+		"maxDepth": maxDepth,
+	}).All(&nodes)
+
+	return nodes, err
+}
+
+// FindTreeDescendantNodes returns the descendants (aka. subtree) of the
+// specified tree collection record ordered by their depth (1 for the
+// direct children) and limited to maxDepth levels.
+func (dao *Dao) FindTreeDescendantNodes(collection *models.Collection, recordId string, maxDepth int) ([]TreeNode, error) {
+	treeField := collection.TreeField()
+	if treeField == "" {
+		return nil, fmt.Errorf("Collection %q is not a tree.", collection.Name)
+	}
+
+	parentCol := inflector.Columnify(treeField)
+
+	// note: UNION with depth limit to prevent infinite loops in case of a cycle
+	query := fmt.Sprintf(
+		`WITH RECURSIVE {{__tree}}([[id]], [[depth]]) AS (
+			SELECT {:id}, 0
+			UNION
+			SELECT [[t.id]], [[tree.depth]] + 1
+			FROM {{%[1]s}} [[t]]
+			INNER JOIN {{__tree}} [[tree]] ON [[t.%[2]s]] = [[tree.id]]
+			WHERE [[tree.depth]] < {:maxDepth}
+		)
+		SELECT [[tree.id]], MIN([[tree.depth]]) as [[depth]] FROM {{__tree}} [[tree]]
+		INNER JOIN {{%[1]s}} [[t]] ON [[t.id]] = [[tree.id]]
+		WHERE [[tree.depth]] > 0 AND [[tree.id]] != {:id}
+		GROUP BY [[tree.id]]
+		ORDER BY [[depth]] ASC, MIN([[t.created]]) ASC, MIN([[t.rowid]]) ASC`,
+		collection.Name,
+		parentCol,
+	)
+
+	nodes := []TreeNode{}
+
+	err := dao.DB().NewQuery(query).Bind(dbx.Params{
+		"id":       recordId,
+		"maxDepth": maxDepth,
+	}).All(&nodes)
+
+	return nodes, err
+}
+
+// FindRecordAncestors returns the ancestor records of the provided tree
+// collection record ordered from the tree root to its direct parent.
+//
+// The optional filters could be used to restrict the returned
+// ancestors (eg. to apply the collection access rules).
+func (dao *Dao) FindRecordAncestors(
+	record *models.Record,
+	maxDepth int,
+	optFilters ...func(q *dbx.SelectQuery) error,
+) ([]*models.Record, error) {
+	nodes, err := dao.FindTreeAncestorNodes(record.Collection(), record.Id, maxDepth)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := dao.findTreeRecords(record.Collection(), nodes, optFilters...)
+	if err != nil {
+		return nil, err
+	}
+
+	// reverse to start from the root
+	for i, j := 0, len(records)-1; i < j; i, j = i+1, j-1 {
+		records[i], records[j] = records[j], records[i]
+	}
+
+	return records, nil
+}
+
+// FindRecordDescendants returns the descendant records of the provided
+// tree collection record ordered by their depth.
+//
+// The optional filters could be used to restrict the returned
+// descendants (eg. to apply the collection access rules).
+func (dao *Dao) FindRecordDescendants(
+	record *models.Record,
+	maxDepth int,
+	optFilters ...func(q *dbx.SelectQuery) error,
+) ([]*models.Record, error) {
+	nodes, err := dao.FindTreeDescendantNodes(record.Collection(), record.Id, maxDepth)
+	if err != nil {
+		return nil, err
+	}
+
+	return dao.findTreeRecords(record.Collection(), nodes, optFilters...)
+}
+
+// findTreeRecords fetches the records of the provided tree nodes
+// preserving the nodes order.
+func (dao *Dao) findTreeRecords(
+	collection *models.Collection,
+	nodes []TreeNode,
+	optFilters ...func(q *dbx.SelectQuery) error,
+) ([]*models.Record, error) {
+	if len(nodes) == 0 {
+		return []*models.Record{}, nil
+	}
+
+	ids := make([]string, len(nodes))
+	for i, node := range nodes {
+		ids[i] = node.Id
+	}
+
+	records, err := dao.FindRecordsByIds(collection.Id, ids, optFilters...)
+	if err != nil {
+		return nil, err
+	}
+
+	indexed := make(map[string]*models.Record, len(records))
+	for _, r := range records {
+		indexed[r.Id] = r
+	}
+
+	result := make([]*models.Record, 0, len(records))
+	for _, id := range ids {
+		if r, ok := indexed[id]; ok {
+			result = append(result, r)
+		}
+	}
+
+	return result, nil
+}
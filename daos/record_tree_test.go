@@ -0,0 +1,187 @@
+package daos_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// createTreeCollection creates a "categories" tree collection
+// with the following records:
+//
+//	tree0000000000a
+//	└── tree0000000000b
+//	    ├── tree0000000000c
+//	    │   └── tree0000000000d
+//	    └── tree0000000000e
+func createTreeCollection(t *testing.T, dao *daos.Dao) *models.Collection {
+	collection := &models.Collection{}
+	collection.Id = "categories00000"
+	collection.Name = "categories"
+	collection.Type = models.CollectionTypeBase
+	collection.Schema = schema.NewSchema(
+		&schema.SchemaField{
+			Name: "parent",
+			Type: schema.FieldTypeRelation,
+			Options: &schema.RelationOptions{
+				CollectionId: collection.Id,
+				MaxSelect:    types.Pointer(1),
+			},
+		},
+	)
+	collection.SetOptions(models.CollectionBaseOptions{TreeField: "parent", TreeMaxDepth: 4})
+	if err := dao.SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	records := [][2]string{
+		{"tree0000000000a", ""},
+		{"tree0000000000b", "tree0000000000a"},
+		{"tree0000000000c", "tree0000000000b"},
+		{"tree0000000000d", "tree0000000000c"},
+		{"tree0000000000e", "tree0000000000b"},
+	}
+	for _, r := range records {
+		record := models.NewRecord(collection)
+		record.Id = r[0]
+		record.Set("parent", r[1])
+		if err := dao.SaveRecord(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return collection
+}
+
+func recordIds(records []*models.Record) []string {
+	ids := make([]string, len(records))
+	for i, r := range records {
+		ids[i] = r.Id
+	}
+	return ids
+}
+
+func TestFindRecordAncestors(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := createTreeCollection(t, app.Dao())
+
+	scenarios := []struct {
+		id       string
+		maxDepth int
+		expected []string
+	}{
+		{"tree0000000000a", 10, []string{}},
+		{"tree0000000000d", 10, []string{"tree0000000000a", "tree0000000000b", "tree0000000000c"}},
+		{"tree0000000000d", 2, []string{"tree0000000000b", "tree0000000000c"}},
+		{"tree0000000000e", 10, []string{"tree0000000000a", "tree0000000000b"}},
+	}
+
+	for _, s := range scenarios {
+		record, err := app.Dao().FindRecordById(collection.Id, s.id)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ancestors, err := app.Dao().FindRecordAncestors(record, s.maxDepth)
+		if err != nil {
+			t.Fatalf("[%s:%d] %v", s.id, s.maxDepth, err)
+		}
+
+		if ids := recordIds(ancestors); !slices.Equal(ids, s.expected) {
+			t.Fatalf("[%s:%d] Expected ancestors %v, got %v", s.id, s.maxDepth, s.expected, ids)
+		}
+	}
+}
+
+func TestFindRecordDescendants(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := createTreeCollection(t, app.Dao())
+
+	scenarios := []struct {
+		id       string
+		maxDepth int
+		expected []string
+	}{
+		{"tree0000000000d", 10, []string{}},
+		{"tree0000000000a", 10, []string{"tree0000000000b", "tree0000000000c", "tree0000000000e", "tree0000000000d"}},
+		{"tree0000000000a", 2, []string{"tree0000000000b", "tree0000000000c", "tree0000000000e"}},
+		{"tree0000000000b", 1, []string{"tree0000000000c", "tree0000000000e"}},
+	}
+
+	for _, s := range scenarios {
+		record, err := app.Dao().FindRecordById(collection.Id, s.id)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		descendants, err := app.Dao().FindRecordDescendants(record, s.maxDepth)
+		if err != nil {
+			t.Fatalf("[%s:%d] %v", s.id, s.maxDepth, err)
+		}
+
+		if ids := recordIds(descendants); !slices.Equal(ids, s.expected) {
+			t.Fatalf("[%s:%d] Expected descendants %v, got %v", s.id, s.maxDepth, s.expected, ids)
+		}
+	}
+}
+
+func TestFindTreeNodesWithCycle(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := createTreeCollection(t, app.Dao())
+
+	// manually create a cycle bypassing the record validations
+	_, err := app.Dao().DB().Update(
+		collection.Name,
+		dbx.Params{"parent": "tree0000000000d"},
+		dbx.HashExp{"id": "tree0000000000a"},
+	).Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ancestors, err := app.Dao().FindTreeAncestorNodes(collection, "tree0000000000c", 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ancestors) != 3 {
+		t.Fatalf("Expected 3 ancestors, got %v", ancestors)
+	}
+
+	descendants, err := app.Dao().FindTreeDescendantNodes(collection, "tree0000000000c", 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(descendants) != 4 {
+		t.Fatalf("Expected 4 descendants, got %v", descendants)
+	}
+}
+
+func TestFindTreeNodesNonTreeCollection(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Dao().FindTreeAncestorNodes(collection, "llvuca81nly1qls", 10); err == nil {
+		t.Fatal("Expected ancestors error, got nil")
+	}
+
+	if _, err := app.Dao().FindTreeDescendantNodes(collection, "llvuca81nly1qls", 10); err == nil {
+		t.Fatal("Expected descendants error, got nil")
+	}
+}
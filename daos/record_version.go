@@ -0,0 +1,126 @@
+package daos
+
+import (
+	"time"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// RecordVersionQuery returns a new RecordVersion select query.
+func (dao *Dao) RecordVersionQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.RecordVersion{})
+}
+
+// FindRecordVersionById finds a single RecordVersion model by its id.
+func (dao *Dao) FindRecordVersionById(id string) (*models.RecordVersion, error) {
+	model := &models.RecordVersion{}
+
+	err := dao.RecordVersionQuery().
+		AndWhere(dbx.HashExp{"id": id}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// FindRecordVersions returns all stored versions of the specified
+// collection record ordered by their creation date (newest first).
+func (dao *Dao) FindRecordVersions(collection *models.Collection, recordId string) ([]*models.RecordVersion, error) {
+	versions := []*models.RecordVersion{}
+
+	err := dao.RecordVersionsQuery(collection, recordId).
+		OrderBy("created DESC", "rowid DESC").
+		All(&versions)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+// RecordVersionsQuery returns a new RecordVersion select query
+// filtered to the versions of the specified collection record.
+//
+// Note that the record id is used as plain string because
+// the versions of the deleted records are also stored.
+func (dao *Dao) RecordVersionsQuery(collection *models.Collection, recordId string) *dbx.SelectQuery {
+	return dao.RecordVersionQuery().AndWhere(dbx.HashExp{
+		"collectionId": collection.Id,
+		"recordId":     recordId,
+	})
+}
+
+// DeleteOldRecordVersions deletes all record versions
+// created before the specified date.
+func (dao *Dao) DeleteOldRecordVersions(createdBefore time.Time) error {
+	formattedDate := createdBefore.UTC().Format(types.DefaultDateLayout)
+	expr := dbx.NewExp("[[created]] <= {:date}", dbx.Params{"date": formattedDate})
+
+	_, err := dao.NonconcurrentDB().Delete((&models.RecordVersion{}).TableName(), expr).Execute()
+
+	return err
+}
+
+// TrimRecordVersions deletes the oldest versions of the specified
+// collection record keeping only the latest maxVersions ones.
+func (dao *Dao) TrimRecordVersions(collection *models.Collection, recordId string, maxVersions int) error {
+	if maxVersions <= 0 {
+		return nil // no limit
+	}
+
+	ids := []string{}
+
+	err := dao.RecordVersionsQuery(collection, recordId).
+		Select("id").
+		OrderBy("created DESC", "rowid DESC").
+		Offset(int64(maxVersions)).
+		Column(&ids)
+	if err != nil || len(ids) == 0 {
+		return err
+	}
+
+	_, err = dao.NonconcurrentDB().Delete((&models.RecordVersion{}).TableName(), dbx.In("id", list.ToInterfaceSlice(ids)...)).Execute()
+
+	return err
+}
+
+// saveRecordVersion stores a snapshot of the provided record state.
+//
+// The auth records password hash and token key are not part of the snapshot.
+//
+// The version is saved without triggering the model hooks.
+func (dao *Dao) saveRecordVersion(record *models.Record, action string) error {
+	if !record.Collection().HistoryEnabled() {
+		return nil
+	}
+
+	data := record.SchemaData()
+	data[schema.FieldNameId] = record.Id
+	data[schema.FieldNameCreated] = record.Created
+	data[schema.FieldNameUpdated] = record.Updated
+
+	if record.Collection().IsAuth() {
+		data[schema.FieldNameUsername] = record.Username()
+		data[schema.FieldNameEmail] = record.Email()
+		data[schema.FieldNameEmailVisibility] = record.EmailVisibility()
+		data[schema.FieldNameVerified] = record.Verified()
+	}
+
+	version := &models.RecordVersion{
+		CollectionId: record.Collection().Id,
+		RecordId:     record.Id,
+		Action:       action,
+		Data:         data,
+	}
+
+	return dao.WithoutHooks().Save(version)
+}
@@ -0,0 +1,273 @@
+package daos_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func enableCollectionHistory(t *testing.T, dao *daos.Dao, nameOrId string) *models.Collection {
+	collection, err := dao.FindCollectionByNameOrId(nameOrId)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if collection.IsAuth() {
+		options := collection.AuthOptions()
+		options.History = true
+		collection.SetOptions(options)
+	} else {
+		options := collection.BaseOptions()
+		options.History = true
+		collection.SetOptions(options)
+	}
+
+	if err := dao.SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	return collection
+}
+
+func TestRecordVersionQuery(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	expected := "SELECT {{_history}}.* FROM `_history`"
+
+	sql := app.Dao().RecordVersionQuery().Build().SQL()
+	if sql != expected {
+		t.Errorf("Expected sql %s, got %s", expected, sql)
+	}
+}
+
+func TestRecordVersionsWithDisabledHistory(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	record, err := app.Dao().FindRecordById("demo2", "llvuca81nly1qls")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	record.Set("title", "new")
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.Dao().DeleteRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	versions, err := app.Dao().FindRecordVersions(record.Collection(), record.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != 0 {
+		t.Fatalf("Expected no versions, got %d", len(versions))
+	}
+}
+
+func TestSaveAndDeleteRecordVersions(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := enableCollectionHistory(t, app.Dao(), "demo2")
+
+	// create (no version)
+	record := models.NewRecord(collection)
+	record.Set("title", "v1")
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	// update
+	record.Set("title", "v2")
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	// delete
+	if err := app.Dao().DeleteRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	versions, err := app.Dao().FindRecordVersions(collection, record.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []struct {
+		action string
+		title  string
+	}{
+		{models.RecordVersionActionDelete, "v2"},
+		{models.RecordVersionActionUpdate, "v1"},
+	}
+
+	if len(versions) != len(expected) {
+		t.Fatalf("Expected %d versions, got %d", len(expected), len(versions))
+	}
+
+	for i, e := range expected {
+		v := versions[i]
+		if v.Action != e.action || v.Data["title"] != e.title || v.Data["id"] != record.Id {
+			t.Errorf("(%d) Expected %s version with title %q, got %s %v", i, e.action, e.title, v.Action, v.Data)
+		}
+	}
+}
+
+func TestAuthRecordVersionsExcludeCredentials(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := enableCollectionHistory(t, app.Dao(), "users")
+
+	record, err := app.Dao().FindRecordById(collection.Id, "4q1xlclmfloku33")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	record.Set("name", "new")
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	versions, err := app.Dao().FindRecordVersions(collection, record.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != 1 {
+		t.Fatalf("Expected 1 version, got %d", len(versions))
+	}
+
+	data := versions[0].Data
+
+	if data["email"] != record.Email() || data["username"] != record.Username() {
+		t.Fatalf("Expected the auth fields to be stored, got %v", data)
+	}
+
+	for _, field := range []string{"passwordHash", "tokenKey"} {
+		if _, ok := data[field]; ok {
+			t.Fatalf("Expected %q to be excluded, got %v", field, data)
+		}
+	}
+}
+
+func TestFindRecordVersionById(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := enableCollectionHistory(t, app.Dao(), "demo2")
+
+	record, err := app.Dao().FindRecordById(collection.Id, "llvuca81nly1qls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	record.Set("title", "new")
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	versions, err := app.Dao().FindRecordVersions(collection, record.Id)
+	if err != nil || len(versions) != 1 {
+		t.Fatalf("Expected 1 version, got %d (%v)", len(versions), err)
+	}
+
+	if _, err := app.Dao().FindRecordVersionById("missing"); err == nil {
+		t.Fatal("Expected error for missing version, got nil")
+	}
+
+	found, err := app.Dao().FindRecordVersionById(versions[0].Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.RecordId != record.Id || found.Data["title"] != "test1" {
+		t.Fatalf("Unexpected version %v", found)
+	}
+}
+
+func TestTrimRecordVersions(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := enableCollectionHistory(t, app.Dao(), "demo2")
+
+	record, err := app.Dao().FindRecordById(collection.Id, "llvuca81nly1qls")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, title := range []string{"a", "b", "c"} {
+		record.Set("title", title)
+		if err := app.Dao().WithoutHooks().SaveRecord(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// no limit
+	if err := app.Dao().TrimRecordVersions(collection, record.Id, 0); err != nil {
+		t.Fatal(err)
+	}
+	versions, _ := app.Dao().FindRecordVersions(collection, record.Id)
+	if len(versions) != 3 {
+		t.Fatalf("Expected 3 versions, got %d", len(versions))
+	}
+
+	if err := app.Dao().TrimRecordVersions(collection, record.Id, 2); err != nil {
+		t.Fatal(err)
+	}
+	versions, _ = app.Dao().FindRecordVersions(collection, record.Id)
+	if len(versions) != 2 {
+		t.Fatalf("Expected 2 versions, got %d", len(versions))
+	}
+	if versions[0].Data["title"] != "b" || versions[1].Data["title"] != "a" {
+		t.Fatalf("Expected the latest versions to remain, got %v and %v", versions[0].Data, versions[1].Data)
+	}
+}
+
+func TestDeleteOldRecordVersions(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := enableCollectionHistory(t, app.Dao(), "demo2")
+
+	for _, id := range []string{"llvuca81nly1qls", "achvryl401bhse3"} {
+		record, err := app.Dao().FindRecordById(collection.Id, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		record.Set("title", "new_"+id)
+		if err := app.Dao().WithoutHooks().SaveRecord(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// make the first record version old
+	_, err := app.Dao().DB().Update(
+		"_history",
+		dbx.Params{"created": "2022-01-01 00:00:00.000Z"},
+		dbx.HashExp{"recordId": "llvuca81nly1qls"},
+	).Execute()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.Dao().DeleteOldRecordVersions(time.Now().AddDate(0, 0, -1)); err != nil {
+		t.Fatal(err)
+	}
+
+	var total int
+	app.Dao().RecordVersionQuery().Select("count(*)").Row(&total)
+	if total != 1 {
+		t.Fatalf("Expected 1 remaining version, got %d", total)
+	}
+
+	if versions, _ := app.Dao().FindRecordVersions(collection, "achvryl401bhse3"); len(versions) != 1 {
+		t.Fatalf("Expected the new version to remain, got %d", len(versions))
+	}
+}
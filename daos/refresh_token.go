@@ -0,0 +1,94 @@
+package daos
+
+import (
+	"time"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// RefreshTokenQuery returns a new RefreshToken select query.
+func (dao *Dao) RefreshTokenQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.RefreshToken{})
+}
+
+// FindRefreshTokenByToken returns a single RefreshToken model by its plain token.
+//
+// Note that the returned refresh token could be expired or already rotated
+// (see [models.RefreshToken.HasExpired()] and [models.RefreshToken.IsRotated()]).
+func (dao *Dao) FindRefreshTokenByToken(collectionId string, token string) (*models.RefreshToken, error) {
+	model := &models.RefreshToken{}
+
+	err := dao.RefreshTokenQuery().
+		AndWhere(dbx.HashExp{
+			"collectionId": collectionId,
+			"tokenHash":    security.SHA256(token),
+		}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// FindAllRefreshTokensByRecord returns all refresh tokens
+// (including the rotated ones) of the provided auth record.
+func (dao *Dao) FindAllRefreshTokensByRecord(authRecord *models.Record) ([]*models.RefreshToken, error) {
+	tokens := []*models.RefreshToken{}
+
+	err := dao.RefreshTokenQuery().
+		AndWhere(dbx.HashExp{
+			"collectionId": authRecord.Collection().Id,
+			"recordId":     authRecord.Id,
+		}).
+		OrderBy("created ASC").
+		All(&tokens)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+// SaveRefreshToken upserts the provided RefreshToken model.
+func (dao *Dao) SaveRefreshToken(model *models.RefreshToken) error {
+	return dao.Save(model)
+}
+
+// DeleteRefreshToken deletes the provided RefreshToken model.
+func (dao *Dao) DeleteRefreshToken(model *models.RefreshToken) error {
+	return dao.Delete(model)
+}
+
+// DeleteAllRefreshTokensByRecord deletes (aka. revokes)
+// all refresh tokens of the provided auth record.
+func (dao *Dao) DeleteAllRefreshTokensByRecord(authRecord *models.Record) error {
+	_, err := dao.NonconcurrentDB().Delete((&models.RefreshToken{}).TableName(), dbx.HashExp{
+		"collectionId": authRecord.Collection().Id,
+		"recordId":     authRecord.Id,
+	}).Execute()
+
+	return err
+}
+
+// DeleteOldRefreshTokens deletes all refresh tokens of the
+// specified collection that were created before createdBefore.
+func (dao *Dao) DeleteOldRefreshTokens(collectionId string, createdBefore time.Time) error {
+	formattedDate := createdBefore.UTC().Format(types.DefaultDateLayout)
+
+	_, err := dao.NonconcurrentDB().Delete(
+		(&models.RefreshToken{}).TableName(),
+		dbx.And(
+			dbx.HashExp{"collectionId": collectionId},
+			dbx.NewExp("[[created]] <= {:date}", dbx.Params{"date": formattedDate}),
+		),
+	).Execute()
+
+	return err
+}
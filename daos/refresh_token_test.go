@@ -0,0 +1,157 @@
+package daos_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func createTestRefreshToken(t *testing.T, app *tests.TestApp, token string, recordId string, created time.Time) *models.RefreshToken {
+	refreshToken := &models.RefreshToken{
+		CollectionId: "_pb_users_auth_",
+		RecordId:     recordId,
+	}
+	refreshToken.SetToken(token)
+	refreshToken.SetRecordKey("test_key")
+	refreshToken.Created, _ = types.ParseDateTime(created)
+
+	if err := app.Dao().SaveRefreshToken(refreshToken); err != nil {
+		t.Fatal(err)
+	}
+
+	return refreshToken
+}
+
+func TestRefreshTokenQuery(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	expected := "SELECT {{_refreshTokens}}.* FROM `_refreshTokens`"
+
+	sql := app.Dao().RefreshTokenQuery().Build().SQL()
+	if sql != expected {
+		t.Errorf("Expected sql %s, got %s", expected, sql)
+	}
+}
+
+func TestFindRefreshTokenByToken(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	refreshToken := createTestRefreshToken(t, app, "test_token", "4q1xlclmfloku33", time.Now())
+
+	scenarios := []struct {
+		collectionId string
+		token        string
+		expectError  bool
+	}{
+		{"", "", true},
+		{"_pb_users_auth_", "missing", true},
+		{"v851q4r790rhknl", "test_token", true},
+		{"_pb_users_auth_", refreshToken.TokenHash, true},
+		{"_pb_users_auth_", "test_token", false},
+	}
+
+	for i, s := range scenarios {
+		result, err := app.Dao().FindRefreshTokenByToken(s.collectionId, s.token)
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("(%d) Expected hasErr %v, got %v (%v)", i, s.expectError, hasErr, err)
+			continue
+		}
+
+		if result != nil && result.Id != refreshToken.Id {
+			t.Errorf("(%d) Expected refresh token %s, got %s", i, refreshToken.Id, result.Id)
+		}
+	}
+}
+
+func TestFindAndDeleteAllRefreshTokensByRecord(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	createTestRefreshToken(t, app, "test_token1", "4q1xlclmfloku33", time.Now())
+	createTestRefreshToken(t, app, "test_token2", "4q1xlclmfloku33", time.Now())
+	other := createTestRefreshToken(t, app, "test_token3", "oap640cot4yru2s", time.Now())
+
+	user, err := app.Dao().FindRecordById("users", "4q1xlclmfloku33")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := app.Dao().FindAllRefreshTokensByRecord(user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 refresh tokens, got %d", len(result))
+	}
+
+	if err := app.Dao().DeleteAllRefreshTokensByRecord(user); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err = app.Dao().FindAllRefreshTokensByRecord(user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result) != 0 {
+		t.Fatalf("Expected the record refresh tokens to be deleted, found %d", len(result))
+	}
+
+	if _, err := app.Dao().FindRefreshTokenByToken(other.CollectionId, "test_token3"); err != nil {
+		t.Fatalf("Expected the other record refresh token to remain, got %v", err)
+	}
+}
+
+func TestDeleteOldRefreshTokens(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	createTestRefreshToken(t, app, "old_token", "4q1xlclmfloku33", time.Now().Add(-2*time.Hour))
+	createTestRefreshToken(t, app, "fresh_token", "4q1xlclmfloku33", time.Now())
+
+	// different collection
+	if err := app.Dao().DeleteOldRefreshTokens("v851q4r790rhknl", time.Now().Add(-time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := app.Dao().FindRefreshTokenByToken("_pb_users_auth_", "old_token"); err != nil {
+		t.Fatalf("Expected the old refresh token of the other collection to remain, got %v", err)
+	}
+
+	if err := app.Dao().DeleteOldRefreshTokens("_pb_users_auth_", time.Now().Add(-time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Dao().FindRefreshTokenByToken("_pb_users_auth_", "old_token"); err == nil {
+		t.Fatal("Expected the old refresh token to be deleted")
+	}
+
+	if _, err := app.Dao().FindRefreshTokenByToken("_pb_users_auth_", "fresh_token"); err != nil {
+		t.Fatalf("Expected the fresh refresh token to remain, got %v", err)
+	}
+}
+
+func TestDeleteRecordRefreshTokens(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	createTestRefreshToken(t, app, "test_token", "4q1xlclmfloku33", time.Now())
+
+	user, err := app.Dao().FindRecordById("users", "4q1xlclmfloku33")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.Dao().DeleteRecord(user); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Dao().FindRefreshTokenByToken("_pb_users_auth_", "test_token"); err == nil {
+		t.Fatal("Expected the record refresh tokens to be deleted")
+	}
+}
@@ -0,0 +1,157 @@
+package daos
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/list"
+)
+
+// RoleQuery returns a new Role select query.
+func (dao *Dao) RoleQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.Role{})
+}
+
+// FindRoleByNameOrId returns a single Role model by its name or id.
+func (dao *Dao) FindRoleByNameOrId(nameOrId string) (*models.Role, error) {
+	model := &models.Role{}
+
+	err := dao.RoleQuery().
+		AndWhere(dbx.Or(
+			dbx.HashExp{"id": nameOrId},
+			dbx.HashExp{"name": nameOrId},
+		)).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// IsRoleNameUnique checks that there is no existing role
+// with the provided name (case insensitive!).
+func (dao *Dao) IsRoleNameUnique(name string, excludeIds ...string) bool {
+	if name == "" {
+		return false
+	}
+
+	query := dao.RoleQuery().
+		Select("count(*)").
+		AndWhere(dbx.NewExp("LOWER([[name]])={:name}", dbx.Params{"name": strings.ToLower(name)})).
+		Limit(1)
+
+	if uniqueExcludeIds := list.NonzeroUniques(excludeIds); len(uniqueExcludeIds) > 0 {
+		query.AndWhere(dbx.NotIn("id", list.ToInterfaceSlice(uniqueExcludeIds)...))
+	}
+
+	var exists bool
+
+	return query.Row(&exists) == nil && !exists
+}
+
+// SaveRole upserts the provided Role model.
+func (dao *Dao) SaveRole(model *models.Role) error {
+	return dao.Save(model)
+}
+
+// DeleteRole deletes the provided Role model
+// (its record assignments are deleted on cascade).
+func (dao *Dao) DeleteRole(model *models.Role) error {
+	return dao.Delete(model)
+}
+
+// RecordRoleQuery returns a new RecordRole select query.
+func (dao *Dao) RecordRoleQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.RecordRole{})
+}
+
+// FindRecordRoles returns all roles assigned to the provided auth record
+// (ordered by their name).
+func (dao *Dao) FindRecordRoles(authRecord *models.Record) ([]*models.Role, error) {
+	roles := []*models.Role{}
+
+	err := dao.RoleQuery().
+		InnerJoin(
+			"{{_recordRoles}} rr",
+			dbx.NewExp("[[rr.roleId]] = [[_roles.id]]"),
+		).
+		AndWhere(dbx.HashExp{
+			"rr.collectionId": authRecord.Collection().Id,
+			"rr.recordId":     authRecord.Id,
+		}).
+		OrderBy("_roles.name ASC").
+		All(&roles)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+// FindRecordRole returns the assignment of the provided
+// role to the auth record.
+func (dao *Dao) FindRecordRole(authRecord *models.Record, role *models.Role) (*models.RecordRole, error) {
+	model := &models.RecordRole{}
+
+	err := dao.RecordRoleQuery().
+		AndWhere(dbx.HashExp{
+			"roleId":       role.Id,
+			"collectionId": authRecord.Collection().Id,
+			"recordId":     authRecord.Id,
+		}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// AssignRecordRole assigns the provided role to the auth record.
+//
+// Assigning an already assigned role is a no-op.
+func (dao *Dao) AssignRecordRole(authRecord *models.Record, role *models.Role) error {
+	if !authRecord.Collection().IsAuth() {
+		return errors.New("roles could be assigned only to auth records")
+	}
+
+	if existing, _ := dao.FindRecordRole(authRecord, role); existing != nil {
+		return nil // already assigned
+	}
+
+	return dao.Save(&models.RecordRole{
+		RoleId:       role.Id,
+		CollectionId: authRecord.Collection().Id,
+		RecordId:     authRecord.Id,
+	})
+}
+
+// UnassignRecordRole removes the provided role from the auth record (if assigned).
+func (dao *Dao) UnassignRecordRole(authRecord *models.Record, role *models.Role) error {
+	existing, _ := dao.FindRecordRole(authRecord, role)
+	if existing == nil {
+		return nil // not assigned
+	}
+
+	return dao.Delete(existing)
+}
+
+// deleteRecordRoles deletes all role assignments of the provided auth record.
+//
+// The assignments are deleted without triggering the model hooks.
+func (dao *Dao) deleteRecordRoles(authRecord *models.Record) error {
+	_, err := dao.NonconcurrentDB().Delete((&models.RecordRole{}).TableName(), dbx.HashExp{
+		"collectionId": authRecord.Collection().Id,
+		"recordId":     authRecord.Id,
+	}).Execute()
+
+	return err
+}
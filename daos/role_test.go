@@ -0,0 +1,189 @@
+package daos_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestFindRoleByNameOrId(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		nameOrId    string
+		expectError bool
+	}{
+		{"", true},
+		{"missing", true},
+		{"r0l3ed1t0r00001", false},
+		{"moderator", false},
+	}
+
+	for _, s := range scenarios {
+		role, err := app.Dao().FindRoleByNameOrId(s.nameOrId)
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("[%s] Expected hasErr %v, got %v (%v)", s.nameOrId, s.expectError, hasErr, err)
+			continue
+		}
+
+		if role != nil && role.Id != s.nameOrId && role.Name != s.nameOrId {
+			t.Errorf("[%s] Expected role with matching id or name, got %v", s.nameOrId, role)
+		}
+	}
+}
+
+func TestIsRoleNameUnique(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		name      string
+		excludeId string
+		expected  bool
+	}{
+		{"", "", false},
+		{"editor", "", false},
+		{"EDITOR", "", false},
+		{"new", "", true},
+		{"editor", "r0l3ed1t0r00001", true},
+	}
+
+	for i, s := range scenarios {
+		result := app.Dao().IsRoleNameUnique(s.name, s.excludeId)
+		if result != s.expected {
+			t.Errorf("(%d) Expected %v, got %v", i, s.expected, result)
+		}
+	}
+}
+
+func TestFindRecordRoles(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		recordId      string
+		expectedNames []string
+	}{
+		{"4q1xlclmfloku33", []string{"editor"}},
+		{"oap640cot4yru2s", []string{}},
+	}
+
+	for _, s := range scenarios {
+		record, err := app.Dao().FindRecordById("users", s.recordId)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		roles, err := app.Dao().FindRecordRoles(record)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(roles) != len(s.expectedNames) {
+			t.Fatalf("[%s] Expected %d roles, got %d", s.recordId, len(s.expectedNames), len(roles))
+		}
+
+		for i, name := range s.expectedNames {
+			if roles[i].Name != name {
+				t.Fatalf("[%s] Expected role %q, got %q", s.recordId, name, roles[i].Name)
+			}
+		}
+	}
+}
+
+func TestAssignAndUnassignRecordRole(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	role, err := app.Dao().FindRoleByNameOrId("moderator")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// non-auth record
+	demo, err := app.Dao().FindRecordById("demo2", "achvryl401bhse3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := app.Dao().AssignRecordRole(demo, role); err == nil {
+		t.Fatal("Expected error for non-auth record")
+	}
+
+	record, err := app.Dao().FindRecordById("users", "4q1xlclmfloku33")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// assign twice to ensure that it is a no-op
+	for i := 0; i < 2; i++ {
+		if err := app.Dao().AssignRecordRole(record, role); err != nil {
+			t.Fatalf("(%d) Failed to assign role: %v", i, err)
+		}
+	}
+
+	roles, _ := app.Dao().FindRecordRoles(record)
+	if len(roles) != 2 {
+		t.Fatalf("Expected 2 roles, got %d", len(roles))
+	}
+
+	if err := app.Dao().UnassignRecordRole(record, role); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Dao().FindRecordRole(record, role); err == nil {
+		t.Fatal("Expected the role to be unassigned")
+	}
+
+	// unassign missing is a no-op
+	if err := app.Dao().UnassignRecordRole(record, role); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDeleteRoleAndRecordCascade(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	role, err := app.Dao().FindRoleByNameOrId("editor")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.Dao().DeleteRole(role); err != nil {
+		t.Fatal(err)
+	}
+
+	var total int
+	app.Dao().RecordRoleQuery().Select("count(*)").Row(&total)
+	if total != 0 {
+		t.Fatalf("Expected the role assignments to be deleted, got %d", total)
+	}
+
+	// record delete
+	// ---
+	newRole := &models.Role{Name: "test"}
+	if err := app.Dao().SaveRole(newRole); err != nil {
+		t.Fatal(err)
+	}
+
+	record, err := app.Dao().FindRecordById("users", "oap640cot4yru2s")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.Dao().AssignRecordRole(record, newRole); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.Dao().DeleteRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Dao().FindRecordRole(record, newRole); err == nil {
+		t.Fatal("Expected the record role assignments to be deleted")
+	}
+}
@@ -0,0 +1,214 @@
+package daos
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ganigeorgiev/fexpr"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/resolvers"
+	"github.com/pocketbase/pocketbase/tools/dbutils"
+	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/search"
+	"github.com/spf13/cast"
+)
+
+// EvaluateRule checks whether the specified collection record
+// satisfies the provided rule for the given request info.
+//
+// It is similar to [Dao.CanAccessRecord] but accepts plain
+// arguments, making it convenient for rule unit tests (both in Go and JS):
+//
+//	requestInfo := &models.RequestInfo{AuthRecord: user}
+//	ok, err := app.Dao().EvaluateRule("posts", "RECORD_ID", requestInfo, "author = @request.auth.id")
+func (dao *Dao) EvaluateRule(
+	collectionNameOrId string,
+	recordId string,
+	requestInfo *models.RequestInfo,
+	rule string,
+) (bool, error) {
+	record, err := dao.FindRecordById(collectionNameOrId, recordId)
+	if err != nil {
+		return false, err
+	}
+
+	if requestInfo == nil {
+		requestInfo = &models.RequestInfo{}
+	}
+
+	return dao.CanAccessRecord(record, requestInfo, &rule)
+}
+
+// Rule lint issue severities.
+const (
+	RuleLintSeverityError   = "error"
+	RuleLintSeverityWarning = "warning"
+)
+
+// RuleLintIssue defines a single collection rule lint issue.
+type RuleLintIssue struct {
+	Collection string `json:"collection"`
+	Rule       string `json:"rule"`
+	Severity   string `json:"severity"`
+	Message    string `json:"message"`
+}
+
+// String implements the [fmt.Stringer] interface.
+func (issue RuleLintIssue) String() string {
+	return fmt.Sprintf("[%s] %s.%s: %s", issue.Severity, issue.Collection, issue.Rule, issue.Message)
+}
+
+// LintCollectionRules parses the API rules of the provided collection
+// and reports:
+//   - unparsable rules and references to nonexistent fields or relations (errors)
+//   - direct field comparisons with literals of incompatible type (warnings)
+//   - direct field comparisons on fields that are not part of an index (warnings)
+func (dao *Dao) LintCollectionRules(collection *models.Collection) []RuleLintIssue {
+	rules := []struct {
+		name  string
+		value *string
+	}{
+		{"listRule", collection.ListRule},
+		{"viewRule", collection.ViewRule},
+		{"createRule", collection.CreateRule},
+		{"updateRule", collection.UpdateRule},
+		{"deleteRule", collection.DeleteRule},
+	}
+	if collection.IsAuth() {
+		rules = append(rules, struct {
+			name  string
+			value *string
+		}{"options.manageRule", collection.AuthOptions().ManageRule})
+	}
+
+	indexedFields := collectionIndexedFields(collection)
+
+	issues := []RuleLintIssue{}
+
+	for _, rule := range rules {
+		if rule.value == nil || *rule.value == "" {
+			continue // nothing to lint
+		}
+
+		addIssue := func(severity string, message string) {
+			issues = append(issues, RuleLintIssue{
+				Collection: collection.Name,
+				Rule:       rule.name,
+				Severity:   severity,
+				Message:    message,
+			})
+		}
+
+		groups, err := search.FilterData(*rule.value).Parse()
+		if err != nil {
+			addIssue(RuleLintSeverityError, "invalid rule syntax: "+err.Error())
+			continue
+		}
+
+		resolver := resolvers.NewRecordFieldResolver(dao, collection, &models.RequestInfo{}, true)
+		if _, err := search.FilterData(*rule.value).BuildExpr(resolver); err != nil {
+			addIssue(RuleLintSeverityError, err.Error())
+			continue
+		}
+
+		reported := map[string]struct{}{}
+
+		walkRuleExprs(groups, func(expr fexpr.Expr) {
+			for _, pair := range [][2]fexpr.Token{{expr.Left, expr.Right}, {expr.Right, expr.Left}} {
+				field := lintedSchemaField(collection, pair[0])
+				if field == nil {
+					continue
+				}
+
+				if msg := ruleTypeMismatch(field, pair[1]); msg != "" {
+					addIssue(RuleLintSeverityWarning, msg)
+				}
+
+				if _, ok := reported[field.Name]; ok || list.ExistInSlice(field.Name, indexedFields) {
+					continue
+				}
+				reported[field.Name] = struct{}{}
+
+				addIssue(
+					RuleLintSeverityWarning,
+					fmt.Sprintf("field %q is used in a comparison but it is not the first column of any index", field.Name),
+				)
+			}
+		})
+	}
+
+	return issues
+}
+
+// walkRuleExprs calls fn for each single expression of the parsed rule groups.
+func walkRuleExprs(groups []fexpr.ExprGroup, fn func(expr fexpr.Expr)) {
+	for _, group := range groups {
+		switch item := group.Item.(type) {
+		case fexpr.Expr:
+			fn(item)
+		case fexpr.ExprGroup:
+			walkRuleExprs([]fexpr.ExprGroup{item}, fn)
+		case []fexpr.ExprGroup:
+			walkRuleExprs(item, fn)
+		}
+	}
+}
+
+// lintedSchemaField returns the collection schema field directly referenced
+// by the provided token (aka. without relation path or modifiers).
+func lintedSchemaField(collection *models.Collection, token fexpr.Token) *schema.SchemaField {
+	if token.Type != fexpr.TokenIdentifier || strings.ContainsAny(token.Literal, ".:@") {
+		return nil
+	}
+
+	if token.Literal == schema.FieldNameCreated || token.Literal == schema.FieldNameUpdated {
+		return &schema.SchemaField{Name: token.Literal, Type: schema.FieldTypeDate}
+	}
+
+	return collection.Schema.GetFieldByName(token.Literal)
+}
+
+// ruleTypeMismatch returns a non-empty message if the provided
+// operand token is incompatible with the field type.
+func ruleTypeMismatch(field *schema.SchemaField, operand fexpr.Token) string {
+	var mismatch bool
+
+	switch field.Type {
+	case schema.FieldTypeNumber:
+		if operand.Type == fexpr.TokenText {
+			_, err := cast.ToFloat64E(operand.Literal)
+			mismatch = err != nil
+		}
+	case schema.FieldTypeBool:
+		mismatch = operand.Type == fexpr.TokenText ||
+			(operand.Type == fexpr.TokenNumber && operand.Literal != "0" && operand.Literal != "1")
+	case schema.FieldTypeDate:
+		mismatch = operand.Type == fexpr.TokenNumber
+	}
+
+	if !mismatch {
+		return ""
+	}
+
+	return fmt.Sprintf("%s field %q is compared with incompatible value %q", field.Type, field.Name, operand.Literal)
+}
+
+// collectionIndexedFields returns the names of the fields that are the
+// first column of at least one of the collection indexes.
+func collectionIndexedFields(collection *models.Collection) []string {
+	result := []string{schema.FieldNameId}
+
+	if collection.IsAuth() {
+		result = append(result, schema.FieldNameUsername, schema.FieldNameEmail, schema.FieldNameTokenKey)
+	}
+
+	for _, raw := range collection.Indexes {
+		idx := dbutils.ParseIndex(raw)
+		if len(idx.Columns) > 0 {
+			result = append(result, idx.Columns[0].Name)
+		}
+	}
+
+	return result
+}
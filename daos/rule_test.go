@@ -0,0 +1,126 @@
+package daos_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestEvaluateRule(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	user, err := app.Dao().FindRecordById("users", "4q1xlclmfloku33")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		name        string
+		collection  string
+		recordId    string
+		requestInfo *models.RequestInfo
+		rule        string
+		expected    bool
+		expectError bool
+	}{
+		{"missing record", "demo2", "missing", nil, "", false, true},
+		{"invalid rule", "demo2", "llvuca81nly1qls", nil, "missing = 1", false, true},
+		{"empty rule", "demo2", "llvuca81nly1qls", nil, "", true, false},
+		{"not satisfied rule", "demo2", "llvuca81nly1qls", nil, "active = true", false, false},
+		{"satisfied rule", "demo2", "achvryl401bhse3", nil, "active = true", true, false},
+		{"guest auth rule", "users", "4q1xlclmfloku33", nil, "id = @request.auth.id", false, false},
+		{"auth rule", "users", "4q1xlclmfloku33", &models.RequestInfo{AuthRecord: user}, "id = @request.auth.id", true, false},
+		{"invalid subquery rule", "demo2", "achvryl401bhse3", nil, "id in (@collection.demo2.missing = true)", false, true},
+		{"not satisfied subquery rule", "demo2", "llvuca81nly1qls", nil, "id in (@collection.demo2.active = true)", false, false},
+		{"satisfied subquery rule", "demo2", "achvryl401bhse3", nil, "id in (@collection.demo2.active = true)", true, false},
+		{"satisfied subquery rule with projection", "users", "4q1xlclmfloku33", &models.RequestInfo{AuthRecord: user}, "id in (@collection.users.id where @collection.users.id = @request.auth.id)", true, false},
+		{"invalid date function rule", "demo2", "achvryl401bhse3", nil, "created > dateAdd(created, 'invalid')", false, true},
+		{"not satisfied date function rule", "demo2", "achvryl401bhse3", nil, "created >= dateSub(now(), '7 days')", false, false},
+		{"satisfied date function rule", "demo2", "achvryl401bhse3", nil, "created < dateAdd(dateTrunc('day', created), '1 day') && dateDiff('day', created, now()) > 7", true, false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result, err := app.Dao().EvaluateRule(s.collection, s.recordId, s.requestInfo, s.rule)
+
+			if hasErr := err != nil; hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if result != s.expected {
+				t.Fatalf("Expected %v, got %v", s.expected, result)
+			}
+		})
+	}
+}
+
+func TestLintCollectionRules(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	demo2, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	demo2.ListRule = types.Pointer("missing = 1")
+	demo2.ViewRule = types.Pointer("title = 'a' && (active = 'yes' || created > 123)")
+	demo2.CreateRule = types.Pointer("(title = ")
+	demo2.UpdateRule = types.Pointer("@request.auth.id != '' && title != ''")
+	demo2.DeleteRule = types.Pointer("id in (@collection.demo2.active = true)")
+
+	view1, err := app.Dao().FindCollectionByNameOrId("view1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		collection *models.Collection
+		expected   []string
+	}{
+		{
+			demo2,
+			[]string{
+				daos.RuleLintSeverityError + " listRule",
+				daos.RuleLintSeverityWarning + ` viewRule bool field "active" is compared with incompatible value "yes"`,
+				daos.RuleLintSeverityWarning + ` viewRule date field "created" is compared with incompatible value "123"`,
+				daos.RuleLintSeverityError + " createRule",
+			},
+		},
+		{
+			view1,
+			[]string{
+				daos.RuleLintSeverityWarning + ` listRule field "bool" is used in a comparison but it is not the first column of any index`,
+				daos.RuleLintSeverityWarning + ` viewRule field "bool" is used in a comparison but it is not the first column of any index`,
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.collection.Name, func(t *testing.T) {
+			issues := app.Dao().LintCollectionRules(s.collection)
+
+			if len(issues) != len(s.expected) {
+				t.Fatalf("Expected %d issues, got %d: %v", len(s.expected), len(issues), issues)
+			}
+
+			for i, issue := range issues {
+				if issue.Collection != s.collection.Name {
+					t.Fatalf("Expected issue collection %q, got %q", s.collection.Name, issue.Collection)
+				}
+
+				summary := issue.Severity + " " + issue.Rule
+				if issue.Severity == daos.RuleLintSeverityWarning {
+					summary += " " + issue.Message
+				}
+
+				if summary != s.expected[i] {
+					t.Fatalf("Expected issue %d to be %q, got %q", i, s.expected[i], summary)
+				}
+			}
+		})
+	}
+}
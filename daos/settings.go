@@ -1,11 +1,13 @@
 package daos
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 
 	"github.com/pocketbase/pocketbase/models"
 	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/pocketbase/pocketbase/tools/secrets"
 	"github.com/pocketbase/pocketbase/tools/security"
 )
 
@@ -61,3 +63,52 @@ func (dao *Dao) FindSettings(optEncryptionKey ...string) (*settings.Settings, er
 func (dao *Dao) SaveSettings(newSettings *settings.Settings, optEncryptionKey ...string) error {
 	return dao.SaveParam(models.ParamAppSettings, newSettings, optEncryptionKey...)
 }
+
+// FindSettingsWithBackends is similar to FindSettings but also supports
+// settings sealed with one of the provided secrets backends.
+//
+// Returns the decoded settings and the name of the secrets backend
+// used to seal them (empty string for plain or optEncryptionKey encrypted settings).
+func (dao *Dao) FindSettingsWithBackends(
+	ctx context.Context,
+	optEncryptionKey string,
+	backends ...secrets.Backend,
+) (*settings.Settings, string, error) {
+	param, err := dao.FindParamByKey(models.ParamAppSettings)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if !secrets.IsSealed(string(param.Value)) {
+		result, err := dao.FindSettings(optEncryptionKey)
+		return result, "", err
+	}
+
+	decrypted, backendName, err := secrets.Open(ctx, string(param.Value), backends...)
+	if err != nil {
+		return nil, backendName, err
+	}
+
+	result := settings.New()
+	if err := json.Unmarshal(decrypted, result); err != nil {
+		return nil, backendName, err
+	}
+
+	return result, backendName, nil
+}
+
+// SaveSettingsWithBackend persists the specified settings configuration
+// sealed with the provided secrets backend.
+func (dao *Dao) SaveSettingsWithBackend(ctx context.Context, newSettings *settings.Settings, backend secrets.Backend) error {
+	encoded, err := json.Marshal(newSettings)
+	if err != nil {
+		return err
+	}
+
+	sealed, err := secrets.Seal(ctx, backend, encoded)
+	if err != nil {
+		return err
+	}
+
+	return dao.SaveParam(models.ParamAppSettings, sealed)
+}
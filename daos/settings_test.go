@@ -1,9 +1,12 @@
 package daos_test
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/secrets"
 	"github.com/pocketbase/pocketbase/tools/security"
 )
 
@@ -48,3 +51,47 @@ func TestSaveAndFindSettings(t *testing.T) {
 		t.Fatalf("Expected settings to be changed with app name %q, got \n%v", "save_encrypted", s3)
 	}
 }
+
+func TestSaveAndFindSettingsWithBackends(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	backend1, _ := secrets.NewLocalBackend(security.PseudorandomString(32), "test1")
+	backend2, _ := secrets.NewLocalBackend(security.PseudorandomString(32), "test2")
+
+	// plain settings
+	s1, name, err := app.Dao().FindSettingsWithBackends(context.Background(), "", backend1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "" {
+		t.Fatalf("Expected empty backend name, got %q", name)
+	}
+	if s1 == nil {
+		t.Fatal("Expected non-nil settings")
+	}
+
+	app.Settings().Meta.AppName = "save_sealed"
+	if err := app.Dao().SaveSettingsWithBackend(context.Background(), app.Settings(), backend1); err != nil {
+		t.Fatal(err)
+	}
+
+	// the sealed settings shouldn't be loadable without the backend
+	if _, err := app.Dao().FindSettings(); err == nil {
+		t.Fatal("Expected FindSettings to fail for sealed settings")
+	}
+	if _, _, err := app.Dao().FindSettingsWithBackends(context.Background(), "", backend2); !errors.Is(err, secrets.ErrUnknownBackend) {
+		t.Fatalf("Expected ErrUnknownBackend, got %v", err)
+	}
+
+	s2, name, err := app.Dao().FindSettingsWithBackends(context.Background(), "", backend2, backend1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "test1" {
+		t.Fatalf("Expected backend name %q, got %q", "test1", name)
+	}
+	if s2.Meta.AppName != "save_sealed" {
+		t.Fatalf("Expected app name %q, got %q", "save_sealed", s2.Meta.AppName)
+	}
+}
@@ -0,0 +1,63 @@
+package daos
+
+import (
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/list"
+)
+
+// StatsDailyQuery returns a new StatsDaily select query.
+func (dao *Dao) StatsDailyQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.StatsDaily{})
+}
+
+// FindStatsDaily returns the daily stats between the from and to
+// dates (inclusive, in YYYY-MM-DD format) ordered by their date.
+//
+// The result could be further limited to the specified metrics.
+func (dao *Dao) FindStatsDaily(from string, to string, metrics ...string) ([]*models.StatsDaily, error) {
+	query := dao.StatsDailyQuery().
+		AndWhere(dbx.Between("date", from, to)).
+		OrderBy("date ASC", "metric ASC", "key ASC")
+
+	if len(metrics) > 0 {
+		query.AndWhere(dbx.In("metric", list.ToInterfaceSlice(metrics)...))
+	}
+
+	result := []*models.StatsDaily{}
+
+	if err := query.All(&result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// SaveStatsDaily creates or replaces the value of a single daily stats metric.
+func (dao *Dao) SaveStatsDaily(date string, metric string, key string, value float64) error {
+	return dao.upsertStatsDaily(date, metric, key, value, "{:value}")
+}
+
+// SaveStatsDailyMax is similar to [Dao.SaveStatsDaily] but replaces
+// the existing daily stats value only if the new one is bigger
+// (eg. for tracking the daily peak of a snapshot metric).
+func (dao *Dao) SaveStatsDailyMax(date string, metric string, key string, value float64) error {
+	return dao.upsertStatsDaily(date, metric, key, value, "MAX([[value]], {:value})")
+}
+
+func (dao *Dao) upsertStatsDaily(date string, metric string, key string, value float64, updateValueExpr string) error {
+	_, err := dao.NonconcurrentDB().NewQuery(`
+		INSERT INTO {{_statsDaily}} ([[date]], [[metric]], [[key]], [[value]])
+		VALUES ({:date}, {:metric}, {:key}, {:value})
+		ON CONFLICT ([[date]], [[metric]], [[key]]) DO UPDATE SET
+			[[value]] = ` + updateValueExpr + `,
+			[[updated]] = strftime('%Y-%m-%d %H:%M:%fZ')
+	`).Bind(dbx.Params{
+		"date":   date,
+		"metric": metric,
+		"key":    key,
+		"value":  value,
+	}).Execute()
+
+	return err
+}
@@ -0,0 +1,133 @@
+package daos_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestSaveStatsDaily(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	dao := app.LogsDao()
+
+	if err := dao.SaveStatsDaily("2024-01-01", models.StatsMetricSignups, "users", 5); err != nil {
+		t.Fatal(err)
+	}
+
+	// replace
+	if err := dao.SaveStatsDaily("2024-01-01", models.StatsMetricSignups, "users", 3); err != nil {
+		t.Fatal(err)
+	}
+
+	// different key
+	if err := dao.SaveStatsDaily("2024-01-01", models.StatsMetricSignups, "clients", 1); err != nil {
+		t.Fatal(err)
+	}
+
+	items, err := dao.FindStatsDaily("2024-01-01", "2024-01-01")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkStatsDaily(t, items, `[{"date":"2024-01-01","metric":"signups","key":"clients","value":1},{"date":"2024-01-01","metric":"signups","key":"users","value":3}]`)
+}
+
+func TestSaveStatsDailyMax(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	dao := app.LogsDao()
+
+	for _, v := range []float64{2, 5, 3} {
+		if err := dao.SaveStatsDailyMax("2024-01-01", models.StatsMetricRealtimeClients, "", v); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	items, err := dao.FindStatsDaily("2024-01-01", "2024-01-01")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkStatsDaily(t, items, `[{"date":"2024-01-01","metric":"realtimeClients","key":"","value":5}]`)
+}
+
+func TestFindStatsDaily(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	dao := app.LogsDao()
+
+	for _, date := range []string{"2024-01-01", "2024-01-02", "2024-01-03"} {
+		if err := dao.SaveStatsDaily(date, models.StatsMetricRequests, "", 10); err != nil {
+			t.Fatal(err)
+		}
+		if err := dao.SaveStatsDaily(date, models.StatsMetricErrors, "", 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	scenarios := []struct {
+		name     string
+		from     string
+		to       string
+		metrics  []string
+		expected string
+	}{
+		{
+			"no matching dates",
+			"2023-01-01",
+			"2023-12-31",
+			nil,
+			`[]`,
+		},
+		{
+			"date range",
+			"2024-01-02",
+			"2024-01-03",
+			nil,
+			`[{"date":"2024-01-02","metric":"errors","key":"","value":1},{"date":"2024-01-02","metric":"requests","key":"","value":10},{"date":"2024-01-03","metric":"errors","key":"","value":1},{"date":"2024-01-03","metric":"requests","key":"","value":10}]`,
+		},
+		{
+			"date range with metrics filter",
+			"2024-01-01",
+			"2024-01-02",
+			[]string{models.StatsMetricErrors},
+			`[{"date":"2024-01-01","metric":"errors","key":"","value":1},{"date":"2024-01-02","metric":"errors","key":"","value":1}]`,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			items, err := dao.FindStatsDaily(s.from, s.to, s.metrics...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			checkStatsDaily(t, items, s.expected)
+		})
+	}
+}
+
+func checkStatsDaily(t *testing.T, items []*models.StatsDaily, expected string) {
+	type item struct {
+		Date   string  `json:"date"`
+		Metric string  `json:"metric"`
+		Key    string  `json:"key"`
+		Value  float64 `json:"value"`
+	}
+
+	result := make([]item, len(items))
+	for i, v := range items {
+		result[i] = item{v.Date, v.Metric, v.Key, v.Value}
+	}
+
+	raw, _ := json.Marshal(result)
+	if str := string(raw); str != expected {
+		t.Fatalf("Expected\n%s\ngot\n%s", expected, str)
+	}
+}
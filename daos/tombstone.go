@@ -0,0 +1,60 @@
+package daos
+
+import (
+	"fmt"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// TombstoneQuery returns a new Tombstone select query.
+func (dao *Dao) TombstoneQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.Tombstone{})
+}
+
+// FindRecordTombstones returns the ids of the deleted collection
+// records after the specified datetime (ordered by their deletion date).
+//
+// Tombstones of records that were recreated with the same id are ignored.
+func (dao *Dao) FindRecordTombstones(collection *models.Collection, since types.DateTime) ([]string, error) {
+	ids := []string{}
+
+	tombstoneTable := (&models.Tombstone{}).TableName()
+
+	err := dao.TombstoneQuery().
+		Select("{{" + tombstoneTable + "}}.[[recordId]]").
+		Distinct(true).
+		AndWhere(dbx.HashExp{"collectionId": collection.Id}).
+		AndWhere(dbx.NewExp("[[created]] > {:since}", dbx.Params{"since": since.String()})).
+		AndWhere(dbx.NewExp(fmt.Sprintf(
+			"NOT EXISTS (SELECT 1 FROM {{%s}} WHERE {{%s}}.[[id]] = {{%s}}.[[recordId]])",
+			collection.Name,
+			collection.Name,
+			tombstoneTable,
+		))).
+		OrderBy("created ASC").
+		Column(&ids)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// saveRecordTombstone creates a new tombstone for the provided deleted record.
+//
+// The tombstone is saved without triggering the model hooks.
+func (dao *Dao) saveRecordTombstone(record *models.Record) error {
+	if record.Collection().IsView() {
+		return nil // views are readonly
+	}
+
+	tombstone := &models.Tombstone{
+		CollectionId: record.Collection().Id,
+		RecordId:     record.Id,
+	}
+
+	return dao.WithoutHooks().Save(tombstone)
+}
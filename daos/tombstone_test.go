@@ -0,0 +1,81 @@
+package daos_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestTombstoneQuery(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	expected := "SELECT {{_tombstones}}.* FROM `_tombstones`"
+
+	sql := app.Dao().TombstoneQuery().Build().SQL()
+	if sql != expected {
+		t.Errorf("Expected sql %s, got %s", expected, sql)
+	}
+}
+
+func TestDeleteRecordTombstone(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	since, _ := types.ParseDateTime("2022-10-12 00:00:00.000Z")
+
+	ids, err := app.Dao().FindRecordTombstones(collection, since)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 1 || ids[0] != "deleted00000001" {
+		t.Fatalf("Expected only the initial tombstone, got %v", ids)
+	}
+
+	record, err := app.Dao().FindRecordById(collection.Id, "llvuca81nly1qls")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.Dao().DeleteRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	ids, err = app.Dao().FindRecordTombstones(collection, since)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 2 || ids[0] != "deleted00000001" || ids[1] != "llvuca81nly1qls" {
+		t.Fatalf("Expected the deleted record tombstone, got %v", ids)
+	}
+
+	// recreated records shouldn't be reported as deleted
+	record.MarkAsNew()
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	ids, err = app.Dao().FindRecordTombstones(collection, since)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 1 || ids[0] != "deleted00000001" {
+		t.Fatalf("Expected the recreated record to be excluded, got %v", ids)
+	}
+
+	// tombstones after the since date only
+	since = types.NowDateTime()
+	ids, err = app.Dao().FindRecordTombstones(collection, since)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 0 {
+		t.Fatalf("Expected no tombstones, got %v", ids)
+	}
+}
@@ -0,0 +1,89 @@
+package daos
+
+import (
+	"fmt"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tools/confusables"
+	"github.com/pocketbase/pocketbase/tools/list"
+)
+
+// UsernameChangeQuery returns a new UsernameChange select query.
+func (dao *Dao) UsernameChangeQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.UsernameChange{})
+}
+
+// FindLastUsernameChange returns the latest username change of the provided auth record.
+func (dao *Dao) FindLastUsernameChange(authRecord *models.Record) (*models.UsernameChange, error) {
+	model := &models.UsernameChange{}
+
+	err := dao.UsernameChangeQuery().
+		AndWhere(dbx.HashExp{
+			"collectionId": authRecord.Collection().Id,
+			"recordId":     authRecord.Id,
+		}).
+		OrderBy("created DESC").
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// FindConfusableUsername returns the first username from the specified
+// auth collection that is visually confusable with the provided one
+// (see [confusables.Skeleton]).
+//
+// Returns an empty string if there is no such username.
+func (dao *Dao) FindConfusableUsername(collection *models.Collection, username string, excludeIds ...string) (string, error) {
+	// reproduce the skeleton normalization with nested REPLACE calls
+	// (the stored usernames are limited to ASCII characters so
+	// the non-latin homoglyphs are not relevant here)
+	expr := "LOWER([[" + schema.FieldNameUsername + "]])"
+	params := dbx.Params{"skeleton": confusables.Skeleton(username)}
+	for i, r := range confusables.Replacements {
+		fromParam := fmt.Sprintf("from%d", i)
+		toParam := fmt.Sprintf("to%d", i)
+		params[fromParam] = r.From
+		params[toParam] = r.To
+		expr = "REPLACE(" + expr + ", {:" + fromParam + "}, {:" + toParam + "})"
+	}
+
+	query := dao.RecordQuery(collection).
+		Select(schema.FieldNameUsername).
+		AndWhere(dbx.NewExp(expr+" = {:skeleton}", params)).
+		Limit(1)
+
+	if uniqueExcludeIds := list.NonzeroUniques(excludeIds); len(uniqueExcludeIds) > 0 {
+		query.AndWhere(dbx.NotIn(collection.Name+".id", list.ToInterfaceSlice(uniqueExcludeIds)...))
+	}
+
+	var result []string
+	if err := query.Column(&result); err != nil {
+		return "", err
+	}
+
+	if len(result) == 0 {
+		return "", nil
+	}
+
+	return result[0], nil
+}
+
+// saveUsernameChange stores the previous username of the provided auth record.
+//
+// The change is saved without triggering the model hooks.
+func (dao *Dao) saveUsernameChange(authRecord *models.Record, oldUsername string) error {
+	change := &models.UsernameChange{
+		CollectionId: authRecord.Collection().Id,
+		RecordId:     authRecord.Id,
+		OldUsername:  oldUsername,
+	}
+
+	return dao.WithoutHooks().Save(change)
+}
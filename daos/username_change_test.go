@@ -0,0 +1,97 @@
+package daos_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestUsernameChangeQuery(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	expected := "SELECT {{_usernameChanges}}.* FROM `_usernameChanges`"
+
+	sql := app.Dao().UsernameChangeQuery().Build().SQL()
+	if sql != expected {
+		t.Errorf("Expected sql %s, got %s", expected, sql)
+	}
+}
+
+func TestFindConfusableUsername(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection, err := app.Dao().FindCollectionByNameOrId("users")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		username   string
+		excludeIds []string
+		expected   string
+	}{
+		{"missing", nil, ""},
+		{"test2_username", nil, "test2_username"},
+		{"TEST2.usernarne", nil, "test2_username"},
+		{"te5t2-username", nil, "test2_username"},
+		{"test2_username", []string{"oap640cot4yru2s"}, ""},
+		{"users75657", []string{"oap640cot4yru2s"}, "users75657"},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.username, func(t *testing.T) {
+			result, err := app.Dao().FindConfusableUsername(collection, s.username, s.excludeIds...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if result != s.expected {
+				t.Fatalf("Expected %q, got %q", s.expected, result)
+			}
+		})
+	}
+}
+
+func TestSaveRecordUsernameChange(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	record, err := app.Dao().FindRecordById("users", "4q1xlclmfloku33")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// without cooldown
+	record.SetUsername("new_username1")
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+	if change, _ := app.Dao().FindLastUsernameChange(record); change != nil {
+		t.Fatalf("Expected no tracked username change, got %v", change)
+	}
+
+	// with cooldown
+	record, err = app.Dao().FindRecordById("users", "4q1xlclmfloku33")
+	if err != nil {
+		t.Fatal(err)
+	}
+	options := record.Collection().AuthOptions()
+	options.UsernameChangeCooldown = 60
+	record.Collection().SetOptions(options)
+
+	record.SetUsername("new_username2")
+	if err := app.Dao().SaveRecord(record); err != nil {
+		t.Fatal(err)
+	}
+
+	change, err := app.Dao().FindLastUsernameChange(record)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if change.OldUsername != "new_username1" || change.RecordId != record.Id {
+		t.Fatalf("Expected the previous username to be tracked, got %v", change)
+	}
+}
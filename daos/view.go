@@ -477,6 +477,7 @@ func (p *identifiersParser) parse(selectQuery string) error {
 	var fromParts strings.Builder
 	var joinParts strings.Builder
 
+loop:
 	for {
 		token, err := tk.Scan()
 		if err != nil {
@@ -512,6 +513,10 @@ func (p *identifiersParser) parse(selectQuery string) error {
 		case "_discard_":
 			// skip following tokens
 			skip = true
+		case "union", "intersect", "except":
+			// the result columns of a compound select
+			// are determined only by its first select
+			break loop
 		default:
 			isJoin := partType == "join"
 
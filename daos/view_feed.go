@@ -0,0 +1,107 @@
+package daos
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tools/list"
+)
+
+// CreateFeedViewQuery builds a view select query that merges the
+// records of the provided feed sources into a single list with the
+// shared "id", "type", "created", "updated", "title" and "link" columns.
+//
+// Returns an error if some of the source collections or mapped fields are missing.
+func (dao *Dao) CreateFeedViewQuery(sources []models.CollectionFeedSource) (string, error) {
+	if len(sources) == 0 {
+		return "", errors.New("at least one feed source is required")
+	}
+
+	selects := make([]string, 0, len(sources))
+
+	for i, source := range sources {
+		collection, err := dao.FindCollectionByNameOrId(source.Collection)
+		if err != nil {
+			return "", fmt.Errorf("%d: missing collection %q", i, source.Collection)
+		}
+
+		if collection.IsView() {
+			return "", fmt.Errorf("%d: view collections cannot be used as feed source", i)
+		}
+
+		if !isFeedSourceField(collection, source.Title) {
+			return "", fmt.Errorf("%d: missing or invalid title field %q", i, source.Title)
+		}
+
+		if source.Link != "" && !isFeedSourceField(collection, source.Link) {
+			return "", fmt.Errorf("%d: missing or invalid link field %q", i, source.Link)
+		}
+
+		feedType := source.Type
+		if feedType == "" {
+			feedType = collection.Name
+		}
+
+		table := "`" + collection.Name + "`"
+
+		link := "''"
+		if source.Link != "" {
+			link = table + ".`" + source.Link + "`"
+		}
+
+		// note: the columns are explicitly casted because the feed view
+		// schema is generated only from the first select statement
+		selects = append(selects, fmt.Sprintf(
+			"SELECT %s.`id` AS `id`, CAST('%s' AS TEXT) AS `type`, %s.`created` AS `created`, %s.`updated` AS `updated`, CAST(%s.`%s` AS TEXT) AS `title`, CAST(%s AS TEXT) AS `link` FROM %s",
+			table,
+			strings.ReplaceAll(feedType, "'", "''"),
+			table,
+			table,
+			table,
+			source.Title,
+			link,
+			table,
+		))
+	}
+
+	return strings.Join(selects, " UNION ALL "), nil
+}
+
+// FindFeedCollectionsBySource returns all feed view collections
+// that have the provided collection as one of their sources.
+func (dao *Dao) FindFeedCollectionsBySource(collection *models.Collection) ([]*models.Collection, error) {
+	views, err := dao.FindCollectionsByType(models.CollectionTypeView)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*models.Collection, 0, len(views))
+
+	for _, view := range views {
+		for _, source := range view.ViewOptions().Feed {
+			if source.Collection == collection.Id || strings.EqualFold(source.Collection, collection.Name) {
+				result = append(result, view)
+				break
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// isFeedSourceField checks whether the provided field name
+// could be mapped as feed item field.
+func isFeedSourceField(collection *models.Collection, name string) bool {
+	if collection.Schema.GetFieldByName(name) != nil {
+		return true
+	}
+
+	if list.ExistInSlice(name, schema.BaseModelFieldNames()) {
+		return true
+	}
+
+	return collection.IsAuth() && (name == schema.FieldNameUsername || name == schema.FieldNameEmail)
+}
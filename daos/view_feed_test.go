@@ -0,0 +1,177 @@
+package daos_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestCreateFeedViewQuery(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		name        string
+		sources     []models.CollectionFeedSource
+		expectError bool
+	}{
+		{"no sources", nil, true},
+		{
+			"missing collection",
+			[]models.CollectionFeedSource{{Collection: "missing", Title: "title"}},
+			true,
+		},
+		{
+			"view collection",
+			[]models.CollectionFeedSource{{Collection: "view1", Title: "id"}},
+			true,
+		},
+		{
+			"missing title field",
+			[]models.CollectionFeedSource{{Collection: "demo2", Title: "missing"}},
+			true,
+		},
+		{
+			"missing link field",
+			[]models.CollectionFeedSource{{Collection: "demo2", Title: "title", Link: "missing"}},
+			true,
+		},
+		{
+			"hidden auth field",
+			[]models.CollectionFeedSource{{Collection: "users", Title: schema.FieldNamePasswordHash}},
+			true,
+		},
+		{
+			"valid sources",
+			[]models.CollectionFeedSource{
+				{Collection: "demo2", Type: "post", Title: "title"},
+				{Collection: "users", Title: "username", Link: "id"},
+			},
+			false,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			query, err := app.Dao().CreateFeedViewQuery(s.sources)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if hasErr {
+				return
+			}
+
+			total := 0
+			err = app.Dao().DB().NewQuery("SELECT count(*) FROM (" + query + ")").Row(&total)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if total != 6 {
+				t.Fatalf("Expected 6 feed rows, got %d", total)
+			}
+		})
+	}
+}
+
+func TestSaveFeedViewCollection(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := &models.Collection{
+		Name: "feed",
+		Type: models.CollectionTypeView,
+	}
+	collection.SetOptions(models.CollectionViewOptions{
+		Feed: []models.CollectionFeedSource{
+			{Collection: "demo2", Type: "post", Title: "title"},
+			{Collection: "demo3", Title: "title", Link: "id"},
+		},
+	})
+
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	if collection.ViewOptions().Query == "" {
+		t.Fatal("Expected the view query to be generated from the feed sources")
+	}
+
+	for _, name := range []string{"type", "title", "link"} {
+		field := collection.Schema.GetFieldByName(name)
+		if field == nil || field.Type != schema.FieldTypeText {
+			t.Fatalf("Expected %q text field, got %v", name, field)
+		}
+	}
+
+	records, err := app.Dao().FindRecordsByFilter(collection.Id, "type = 'post'", "-created", 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(records) != 3 {
+		t.Fatalf("Expected 3 post feed records, got %d", len(records))
+	}
+
+	demo3Records, err := app.Dao().FindRecordsByFilter(collection.Id, "type = 'demo3' && link = id", "", 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(demo3Records) != 4 {
+		t.Fatalf("Expected 4 demo3 feed records, got %d", len(demo3Records))
+	}
+}
+
+func TestFindFeedCollectionsBySource(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	feed := &models.Collection{
+		Name: "feed",
+		Type: models.CollectionTypeView,
+	}
+	feed.SetOptions(models.CollectionViewOptions{
+		Feed: []models.CollectionFeedSource{
+			{Collection: "demo2", Title: "title"},
+		},
+	})
+	if err := app.Dao().SaveCollection(feed); err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		collection  string
+		expectedIds []string
+	}{
+		{"demo1", nil},
+		{"demo2", []string{feed.Id}},
+	}
+
+	for _, s := range scenarios {
+		collection, err := app.Dao().FindCollectionByNameOrId(s.collection)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		feeds, err := app.Dao().FindFeedCollectionsBySource(collection)
+		if err != nil {
+			t.Fatalf("[%s] Unexpected error %v", s.collection, err)
+		}
+
+		if len(feeds) != len(s.expectedIds) {
+			t.Fatalf("[%s] Expected %d feeds, got %d", s.collection, len(s.expectedIds), len(feeds))
+		}
+
+		for i, id := range s.expectedIds {
+			if feeds[i].Id != id {
+				t.Fatalf("[%s] Expected feed %q, got %q", s.collection, id, feeds[i].Id)
+			}
+		}
+	}
+}
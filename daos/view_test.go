@@ -446,6 +446,23 @@ func TestCreateViewSchema(t *testing.T) {
 				"alias4": schema.FieldTypeText,
 			},
 		},
+		{
+			"compound select query",
+			`select
+				a.id,
+				a.text as title,
+				a.number as num
+			from demo1 a
+			where a.bool = true
+			union all
+			select b.id, b.title, 123 from demo2 b
+			order by title`,
+			false,
+			map[string]string{
+				"title": schema.FieldTypeText,
+				"num":   schema.FieldTypeNumber,
+			},
+		},
 	}
 
 	for _, s := range scenarios {
@@ -0,0 +1,57 @@
+package daos
+
+import (
+	"time"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// WalletNonceQuery returns a new WalletNonce select query.
+func (dao *Dao) WalletNonceQuery() *dbx.SelectQuery {
+	return dao.ModelQuery(&models.WalletNonce{})
+}
+
+// FindWalletNonce returns a single WalletNonce model by its collection and nonce value.
+//
+// Note that the returned nonce could be expired (see [models.WalletNonce.HasExpired()]).
+func (dao *Dao) FindWalletNonce(collectionId string, nonce string) (*models.WalletNonce, error) {
+	model := &models.WalletNonce{}
+
+	err := dao.WalletNonceQuery().
+		AndWhere(dbx.HashExp{
+			"collectionId": collectionId,
+			"nonce":        nonce,
+		}).
+		Limit(1).
+		One(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// SaveWalletNonce upserts the provided WalletNonce model.
+func (dao *Dao) SaveWalletNonce(model *models.WalletNonce) error {
+	return dao.Save(model)
+}
+
+// DeleteWalletNonce deletes the provided WalletNonce model.
+func (dao *Dao) DeleteWalletNonce(model *models.WalletNonce) error {
+	return dao.Delete(model)
+}
+
+// DeleteOldWalletNonces deletes all wallet nonces created before createdBefore.
+func (dao *Dao) DeleteOldWalletNonces(createdBefore time.Time) error {
+	formattedDate := createdBefore.UTC().Format(types.DefaultDateLayout)
+
+	_, err := dao.NonconcurrentDB().Delete(
+		(&models.WalletNonce{}).TableName(),
+		dbx.NewExp("[[created]] <= {:date}", dbx.Params{"date": formattedDate}),
+	).Execute()
+
+	return err
+}
@@ -0,0 +1,103 @@
+package daos_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func createTestWalletNonce(t *testing.T, app *tests.TestApp, nonce string, created time.Time) *models.WalletNonce {
+	model := &models.WalletNonce{
+		CollectionId: "_pb_users_auth_",
+		Nonce:        nonce,
+	}
+	model.Created, _ = types.ParseDateTime(created)
+
+	if err := app.Dao().SaveWalletNonce(model); err != nil {
+		t.Fatal(err)
+	}
+
+	return model
+}
+
+func TestWalletNonceQuery(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	expected := "SELECT {{_walletNonces}}.* FROM `_walletNonces`"
+
+	sql := app.Dao().WalletNonceQuery().Build().SQL()
+	if sql != expected {
+		t.Errorf("Expected sql %s, got %s", expected, sql)
+	}
+}
+
+func TestFindWalletNonce(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	nonce := createTestWalletNonce(t, app, "testnonce123", time.Now())
+
+	scenarios := []struct {
+		collectionId string
+		nonce        string
+		expectError  bool
+	}{
+		{"", "", true},
+		{"_pb_users_auth_", "missing", true},
+		{"v851q4r790rhknl", "testnonce123", true},
+		{"_pb_users_auth_", "testnonce123", false},
+	}
+
+	for i, s := range scenarios {
+		result, err := app.Dao().FindWalletNonce(s.collectionId, s.nonce)
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("(%d) Expected hasErr %v, got %v (%v)", i, s.expectError, hasErr, err)
+			continue
+		}
+
+		if !hasErr && result.Id != nonce.Id {
+			t.Errorf("(%d) Expected nonce %q, got %q", i, nonce.Id, result.Id)
+		}
+	}
+}
+
+func TestDeleteWalletNonce(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	nonce := createTestWalletNonce(t, app, "testnonce123", time.Now())
+
+	if err := app.Dao().DeleteWalletNonce(nonce); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Dao().FindWalletNonce(nonce.CollectionId, nonce.Nonce); err == nil {
+		t.Fatal("Expected the wallet nonce to be deleted")
+	}
+}
+
+func TestDeleteOldWalletNonces(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	old := createTestWalletNonce(t, app, "oldnonce123", time.Now().Add(-2*time.Hour))
+	fresh := createTestWalletNonce(t, app, "freshnonce123", time.Now())
+
+	if err := app.Dao().DeleteOldWalletNonces(time.Now().Add(-time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.Dao().FindWalletNonce(old.CollectionId, old.Nonce); err == nil {
+		t.Fatal("Expected the old wallet nonce to be deleted")
+	}
+
+	if _, err := app.Dao().FindWalletNonce(fresh.CollectionId, fresh.Nonce); err != nil {
+		t.Fatalf("Expected the fresh wallet nonce to remain, got %v", err)
+	}
+}
@@ -53,7 +53,11 @@ func (form *AdminPasswordResetConfirm) checkToken(value any) error {
 		return nil // nothing to check
 	}
 
-	admin, err := form.dao.FindAdminByToken(v, form.app.Settings().AdminPasswordResetToken.Secret)
+	admin, err := form.dao.FindAdminByToken(
+		v,
+		form.app.Settings().AdminPasswordResetToken.Secret,
+		form.app.Settings().AdminPasswordResetToken.PreviousSecrets()...,
+	)
 	if err != nil || admin == nil {
 		return validation.NewError("validation_invalid_token", "Invalid or expired token.")
 	}
@@ -74,6 +78,7 @@ func (form *AdminPasswordResetConfirm) Submit(interceptors ...InterceptorFunc[*m
 	admin, err := form.dao.FindAdminByToken(
 		form.Token,
 		form.app.Settings().AdminPasswordResetToken.Secret,
+		form.app.Settings().AdminPasswordResetToken.PreviousSecrets()...,
 	)
 	if err != nil {
 		return nil, err
@@ -50,7 +50,7 @@ func (form *AdminUpsert) SetDao(dao *daos.Dao) {
 
 // Validate makes the form validatable by implementing [validation.Validatable] interface.
 func (form *AdminUpsert) Validate() error {
-	return validation.ValidateStruct(form,
+	err := validation.ValidateStruct(form,
 		validation.Field(
 			&form.Id,
 			validation.When(
@@ -83,6 +83,13 @@ func (form *AdminUpsert) Validate() error {
 			validation.By(validators.Compare(form.Password)),
 		),
 	)
+
+	return AdminUpsertValidators.Validate(form, err)
+}
+
+// Admin returns the admin model associated with the form.
+func (form *AdminUpsert) Admin() *models.Admin {
+	return form.admin
 }
 
 func (form *AdminUpsert) checkUniqueEmail(value any) error {
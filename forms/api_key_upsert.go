@@ -0,0 +1,147 @@
+package forms
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// ApiKeyUpsert is a [models.ApiKey] upsert (create/update) form.
+type ApiKeyUpsert struct {
+	app    core.App
+	dao    *daos.Dao
+	apiKey *models.ApiKey
+
+	Name    string               `form:"name" json:"name"`
+	Scopes  []models.ApiKeyScope `form:"scopes" json:"scopes"`
+	Expires types.DateTime       `form:"expires" json:"expires"`
+}
+
+// NewApiKeyUpsert creates a new [ApiKeyUpsert] form with initializer
+// config created from the provided [core.App] and [models.ApiKey] instances
+// (for create the api key is expected to have its admin and key hash already set).
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewApiKeyUpsert(app core.App, apiKey *models.ApiKey) *ApiKeyUpsert {
+	form := &ApiKeyUpsert{
+		app:    app,
+		dao:    app.Dao(),
+		apiKey: apiKey,
+	}
+
+	// load defaults
+	form.Name = apiKey.Name
+	form.Scopes = append([]models.ApiKeyScope{}, apiKey.Scopes...)
+	form.Expires = apiKey.Expires
+
+	return form
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *ApiKeyUpsert) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *ApiKeyUpsert) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(
+			&form.Name,
+			validation.Required,
+			validation.Length(1, 100),
+		),
+		validation.Field(
+			&form.Scopes,
+			validation.Required,
+			validation.Each(validation.By(form.checkScope)),
+		),
+		validation.Field(
+			&form.Expires,
+			validation.When(
+				form.Expires.String() != form.apiKey.Expires.String(),
+				validation.By(form.checkExpires),
+			),
+		),
+	)
+}
+
+func (form *ApiKeyUpsert) checkScope(value any) error {
+	v, _ := value.(models.ApiKeyScope)
+
+	return validation.ValidateStruct(&v,
+		validation.Field(
+			&v.Collection,
+			validation.Required,
+			validation.By(form.checkCollection),
+		),
+		validation.Field(
+			&v.Actions,
+			validation.Required,
+			validation.By(checkApiKeyActions),
+		),
+	)
+}
+
+func (form *ApiKeyUpsert) checkCollection(value any) error {
+	v, _ := value.(string)
+	if v == "" || v == models.ApiKeyScopeAllCollections {
+		return nil
+	}
+
+	if collection, _ := form.dao.FindCollectionByNameOrId(v); collection == nil {
+		return validation.NewError("validation_missing_collection", "Missing or invalid collection.")
+	}
+
+	return nil
+}
+
+func checkApiKeyActions(value any) error {
+	v, _ := value.([]string)
+
+	allowed := models.ApiKeyActions()
+
+	for _, action := range v {
+		if !list.ExistInSlice(action, allowed) {
+			return validation.NewError("validation_invalid_api_key_action", "Invalid api key action "+action+".")
+		}
+	}
+
+	return nil
+}
+
+func (form *ApiKeyUpsert) checkExpires(value any) error {
+	v, _ := value.(types.DateTime)
+	if v.IsZero() {
+		return nil // never expires
+	}
+
+	if v.Time().Before(time.Now()) {
+		return validation.NewError("validation_date_in_the_past", "The expiration date must be in the future.")
+	}
+
+	return nil
+}
+
+// Submit validates the form and upserts the form api key model.
+//
+// You can optionally provide a list of InterceptorFunc to further
+// modify the form behavior before persisting it.
+func (form *ApiKeyUpsert) Submit(interceptors ...InterceptorFunc[*models.ApiKey]) error {
+	if err := form.Validate(); err != nil {
+		return err
+	}
+
+	form.apiKey.Name = form.Name
+	form.apiKey.Scopes = form.Scopes
+	form.apiKey.Expires = form.Expires
+
+	return runInterceptors(form.apiKey, func(apiKey *models.ApiKey) error {
+		return form.dao.SaveApiKey(apiKey)
+	}, interceptors...)
+}
@@ -0,0 +1,194 @@
+package forms_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestApiKeyUpsertValidateAndSubmit(t *testing.T) {
+	future := time.Now().Add(time.Hour).UTC().Format("2006-01-02 15:04:05.000Z")
+
+	scenarios := []struct {
+		name           string
+		id             string // empty for new api key
+		jsonData       string
+		expectedErrors []string
+	}{
+		{
+			"create with empty data",
+			"",
+			`{}`,
+			[]string{"name", "scopes"},
+		},
+		{
+			"create with invalid data",
+			"",
+			`{
+				"name":"",
+				"scopes":[
+					{"collection":"", "actions":["list"]},
+					{"collection":"missing", "actions":["list"]},
+					{"collection":"demo1", "actions":[]},
+					{"collection":"demo1", "actions":["list", "invalid"]}
+				],
+				"expires":"2022-01-01 00:00:00.000Z"
+			}`,
+			[]string{"name", "scopes", "expires"},
+		},
+		{
+			"create with valid data",
+			"",
+			`{
+				"name":"test",
+				"scopes":[
+					{"collection":"demo1", "actions":["list", "view"]},
+					{"collection":"*", "actions":["create"]}
+				],
+				"expires":"` + future + `"
+			}`,
+			[]string{},
+		},
+		{
+			"update with the existing past expires date",
+			"ap1k3yf1xtur002",
+			`{"name":"updated"}`,
+			[]string{},
+		},
+		{
+			"update with a new past expires date",
+			"ap1k3yf1xtur001",
+			`{"expires":"2022-01-01 00:00:00.000Z"}`,
+			[]string{"expires"},
+		},
+		{
+			"update with valid data",
+			"ap1k3yf1xtur001",
+			`{
+				"name":"updated",
+				"scopes":[{"collection":"wsmn24bux7wo113", "actions":["delete"]}],
+				"expires":""
+			}`,
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			apiKey := &models.ApiKey{AdminId: "sywbhecnh46rhm0"}
+			apiKey.SetKey(models.NewApiKeySecret())
+			if s.id != "" {
+				var err error
+				apiKey, err = app.Dao().FindApiKeyById(s.id)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			form := forms.NewApiKeyUpsert(app, apiKey)
+
+			if err := json.Unmarshal([]byte(s.jsonData), form); err != nil {
+				t.Fatalf("Failed to load form data: %v", err)
+			}
+
+			interceptorCalls := 0
+			interceptor := func(next forms.InterceptorNextFunc[*models.ApiKey]) forms.InterceptorNextFunc[*models.ApiKey] {
+				return func(m *models.ApiKey) error {
+					interceptorCalls++
+					return next(m)
+				}
+			}
+
+			err := form.Submit(interceptor)
+
+			// parse errors
+			errs, ok := err.(validation.Errors)
+			if !ok && err != nil {
+				t.Fatalf("Failed to parse errors %v", err)
+			}
+
+			if len(errs) != len(s.expectedErrors) {
+				t.Fatalf("Expected error keys %v, got %v", s.expectedErrors, errs)
+			}
+			for _, k := range s.expectedErrors {
+				if _, ok := errs[k]; !ok {
+					t.Fatalf("Missing expected error key %q in %v", k, errs)
+				}
+			}
+
+			if len(s.expectedErrors) > 0 {
+				if interceptorCalls != 0 {
+					t.Fatalf("Expected the interceptor to not be called, got %d calls", interceptorCalls)
+				}
+				return
+			}
+
+			if interceptorCalls != 1 {
+				t.Fatalf("Expected the interceptor to be called once, got %d calls", interceptorCalls)
+			}
+
+			saved, err := app.Dao().FindApiKeyById(apiKey.Id)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if saved.Name != form.Name {
+				t.Fatalf("Expected name %q, got %q", form.Name, saved.Name)
+			}
+
+			if len(saved.Scopes) != len(form.Scopes) {
+				t.Fatalf("Expected scopes %v, got %v", form.Scopes, saved.Scopes)
+			}
+
+			if saved.Expires.String() != form.Expires.String() {
+				t.Fatalf("Expected expires %q, got %q", form.Expires.String(), saved.Expires.String())
+			}
+		})
+	}
+}
+
+func TestApiKeyUpsertSubmitInterceptors(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	apiKey, err := app.Dao().FindApiKeyById("ap1k3yf1xtur001")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	form := forms.NewApiKeyUpsert(app, apiKey)
+	form.Name = "updated"
+
+	testErr := errors.New("test_error")
+
+	interceptor := func(next forms.InterceptorNextFunc[*models.ApiKey]) forms.InterceptorNextFunc[*models.ApiKey] {
+		return func(m *models.ApiKey) error {
+			if m.Name != "updated" {
+				t.Fatalf("Expected the model to be updated before the interceptors, got %q", m.Name)
+			}
+			return testErr
+		}
+	}
+
+	if err := form.Submit(interceptor); err != testErr {
+		t.Fatalf("Expected error %v, got %v", testErr, err)
+	}
+
+	saved, err := app.Dao().FindApiKeyById(apiKey.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if saved.Name == "updated" {
+		t.Fatal("Expected the api key to not be persisted")
+	}
+}
@@ -3,6 +3,7 @@ package forms
 import (
 	"context"
 	"regexp"
+	"strconv"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/pocketbase/pocketbase/core"
@@ -16,6 +17,18 @@ type BackupCreate struct {
 	ctx context.Context
 
 	Name string `form:"name" json:"name"`
+
+	// DataOnly indicates whether to create a lightweight data-only
+	// backup containing only the collections records.
+	DataOnly bool `form:"dataOnly" json:"dataOnly"`
+
+	// Collections is an optional list with the collection names or ids
+	// to include in the data-only backup (all by default).
+	Collections []string `form:"collections" json:"collections"`
+
+	// Masked indicates whether to mask the sensitive records data
+	// (emails, names, tokens, etc.) of the data-only backup.
+	Masked bool `form:"masked" json:"masked"`
 }
 
 // NewBackupCreate creates new BackupCreate request form.
@@ -40,9 +53,32 @@ func (form *BackupCreate) Validate() error {
 			validation.Match(backupNameRegex),
 			validation.By(form.checkUniqueName),
 		),
+		validation.Field(
+			&form.Collections,
+			validation.When(!form.DataOnly, validation.Empty),
+			validation.By(form.checkCollections),
+		),
+		validation.Field(
+			&form.Masked,
+			validation.When(!form.DataOnly, validation.Empty),
+		),
 	)
 }
 
+func (form *BackupCreate) checkCollections(value any) error {
+	v, _ := value.([]string)
+
+	for i, nameOrId := range v {
+		if _, err := form.app.Dao().FindCollectionByNameOrId(nameOrId); err != nil {
+			return validation.Errors{
+				strconv.Itoa(i): validation.NewError("validation_missing_collection", "Missing collection."),
+			}
+		}
+	}
+
+	return nil
+}
+
 func (form *BackupCreate) checkUniqueName(value any) error {
 	v, _ := value.(string)
 	if v == "" {
@@ -74,6 +110,14 @@ func (form *BackupCreate) Submit(interceptors ...InterceptorFunc[string]) error
 	}
 
 	return runInterceptors(form.Name, func(name string) error {
+		if form.DataOnly && form.Masked {
+			return form.app.CreateMaskedDataDump(form.ctx, name, form.Collections...)
+		}
+
+		if form.DataOnly {
+			return form.app.CreateDataDump(form.ctx, name, form.Collections...)
+		}
+
 		return form.app.CreateBackup(form.ctx, name)
 	}, interceptors...)
 }
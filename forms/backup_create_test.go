@@ -100,3 +100,85 @@ func TestBackupCreateValidateAndSubmit(t *testing.T) {
 		})
 	}
 }
+
+func TestBackupCreateDataOnly(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		dataOnly       bool
+		masked         bool
+		collections    []string
+		expectedErrors []string
+	}{
+		{
+			"collections without dataOnly",
+			false,
+			false,
+			[]string{"demo1"},
+			[]string{"collections"},
+		},
+		{
+			"masked without dataOnly",
+			false,
+			true,
+			nil,
+			[]string{"masked"},
+		},
+		{
+			"missing collection",
+			true,
+			false,
+			[]string{"demo1", "missing"},
+			[]string{"collections"},
+		},
+		{
+			"all collections",
+			true,
+			false,
+			nil,
+			[]string{},
+		},
+		{
+			"specific collections",
+			true,
+			false,
+			[]string{"demo1", "users"},
+			[]string{},
+		},
+		{
+			"masked specific collections",
+			true,
+			true,
+			[]string{"users"},
+			[]string{},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			form := forms.NewBackupCreate(app)
+			form.Name = "test.zip"
+			form.DataOnly = s.dataOnly
+			form.Masked = s.masked
+			form.Collections = s.collections
+
+			result := form.Submit()
+
+			errs, ok := result.(validation.Errors)
+			if !ok && result != nil {
+				t.Fatalf("Failed to parse errors %v", result)
+			}
+
+			if len(errs) != len(s.expectedErrors) {
+				t.Fatalf("Expected error keys %v, got %v", s.expectedErrors, errs)
+			}
+			for _, k := range s.expectedErrors {
+				if _, ok := errs[k]; !ok {
+					t.Fatalf("Missing expected error key %q in %v", k, errs)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,266 @@
+package forms
+
+import (
+	"errors"
+	"strconv"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+const (
+	BatchActionCreate = "create"
+	BatchActionUpdate = "update"
+	BatchActionDelete = "delete"
+)
+
+// BatchRequestMaxItems is the max number of operations allowed in a single batch request.
+const BatchRequestMaxItems = 50
+
+// BatchAccessFunc defines a batch operation access check function.
+//
+// It is called within the batch transaction with the persisted record model
+// right after its creation (for create operations) or right before
+// its change (for update and delete operations).
+// Returning an error aborts and rollbacks the entire batch.
+type BatchAccessFunc func(txDao *daos.Dao, item *BatchRequestItem, record *models.Record) error
+
+// BatchRequest is a form that executes multiple record create, update
+// and delete operations within a single transaction.
+//
+// Only json data is supported (aka. no file uploads).
+type BatchRequest struct {
+	app              core.App
+	dao              *daos.Dao
+	accessFunc       BatchAccessFunc
+	fullManageAccess bool
+
+	Requests []*BatchRequestItem `form:"requests" json:"requests"`
+}
+
+// BatchRequestItem defines a single batch record operation.
+type BatchRequestItem struct {
+	// Action is the operation type - "create", "update" or "delete".
+	Action string `form:"action" json:"action"`
+
+	// Collection is the name or id of the record collection.
+	Collection string `form:"collection" json:"collection"`
+
+	// Id is the id of the record to update or delete.
+	// For create operations it could be used to specify a custom record id.
+	Id string `form:"id" json:"id"`
+
+	// Data is the record data to create or update.
+	Data map[string]any `form:"data" json:"data"`
+}
+
+// BatchResult defines the result of a single batch operation.
+type BatchResult struct {
+	Action     string         `json:"action"`
+	Collection string         `json:"collection"`
+	Id         string         `json:"id"`
+	Record     *models.Record `json:"record,omitempty"`
+}
+
+// Validate makes the batch item validatable by implementing [validation.Validatable] interface.
+func (item *BatchRequestItem) Validate() error {
+	if item == nil {
+		return validation.ErrRequired
+	}
+
+	return validation.ValidateStruct(item,
+		validation.Field(
+			&item.Action,
+			validation.Required,
+			validation.In(BatchActionCreate, BatchActionUpdate, BatchActionDelete),
+		),
+		validation.Field(&item.Collection, validation.Required),
+		validation.Field(&item.Id, validation.When(item.Action != BatchActionCreate, validation.Required)),
+		validation.Field(&item.Data, validation.When(item.Action == BatchActionDelete, validation.Empty)),
+	)
+}
+
+// NewBatchRequest creates a new [BatchRequest] form initialized with
+// from the provided [core.App] instance.
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewBatchRequest(app core.App) *BatchRequest {
+	return &BatchRequest{
+		app: app,
+		dao: app.Dao(),
+	}
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *BatchRequest) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// SetAccessFunc sets the function that checks whether each batch operation is allowed.
+func (form *BatchRequest) SetAccessFunc(accessFunc BatchAccessFunc) {
+	form.accessFunc = accessFunc
+}
+
+// SetFullManageAccess sets the manage access of all upserted records
+// (see [RecordUpsert.SetFullManageAccess()]).
+func (form *BatchRequest) SetFullManageAccess(fullManageAccess bool) {
+	form.fullManageAccess = fullManageAccess
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *BatchRequest) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(
+			&form.Requests,
+			validation.Required,
+			validation.Length(1, BatchRequestMaxItems),
+		),
+	)
+}
+
+// Submit validates the form and executes all batch operations in a
+// single transaction that is rollbacked on the first encountered error.
+//
+// On success returns the list with the result of each operation
+// (in the same order as the form requests).
+//
+// You can optionally provide a list of InterceptorFunc to further
+// modify the form behavior before persisting it.
+func (form *BatchRequest) Submit(interceptors ...InterceptorFunc[[]*BatchRequestItem]) ([]*BatchResult, error) {
+	if err := form.Validate(); err != nil {
+		return nil, err
+	}
+
+	var results []*BatchResult
+
+	err := runInterceptors(form.Requests, func(items []*BatchRequestItem) error {
+		return form.dao.RunInTransaction(func(txDao *daos.Dao) error {
+			results = make([]*BatchResult, 0, len(items))
+
+			for i, item := range items {
+				result, err := form.process(txDao, item)
+				if err != nil {
+					return validation.Errors{"requests": validation.Errors{
+						strconv.Itoa(i): form.prepareError(err),
+					}}
+				}
+
+				results = append(results, result)
+			}
+
+			return nil
+		})
+	}, interceptors...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (form *BatchRequest) process(txDao *daos.Dao, item *BatchRequestItem) (*BatchResult, error) {
+	collection, err := txDao.FindCollectionByNameOrId(item.Collection)
+	if err != nil || collection == nil {
+		return nil, validation.Errors{"collection": validation.NewError(
+			"validation_missing_batch_collection",
+			"Missing or invalid collection.",
+		)}
+	}
+
+	if collection.IsView() {
+		return nil, validation.Errors{"collection": validation.NewError(
+			"validation_batch_view_collection",
+			"View collection records cannot be modified.",
+		)}
+	}
+
+	result := &BatchResult{
+		Action:     item.Action,
+		Collection: collection.Name,
+		Id:         item.Id,
+	}
+
+	var record *models.Record
+
+	if item.Action == BatchActionCreate {
+		record = models.NewRecord(collection)
+	} else {
+		record, err = txDao.FindRecordById(collection.Id, item.Id)
+		if err != nil || record == nil {
+			return nil, validation.Errors{"id": validation.NewError(
+				"validation_missing_batch_record",
+				"Missing or invalid record.",
+			)}
+		}
+
+		if err := form.checkAccess(txDao, item, record); err != nil {
+			return nil, err
+		}
+	}
+
+	if item.Action == BatchActionDelete {
+		if err := txDao.DeleteRecord(record); err != nil {
+			return nil, err
+		}
+
+		return result, nil
+	}
+
+	data := make(map[string]any, len(item.Data)+1)
+	for k, v := range item.Data {
+		data[k] = v
+	}
+	if item.Action == BatchActionCreate && item.Id != "" {
+		data["id"] = item.Id
+	}
+
+	upsert := NewRecordUpsert(form.app, record)
+	upsert.SetDao(txDao)
+	upsert.SetFullManageAccess(form.fullManageAccess)
+	if err := upsert.LoadData(data); err != nil {
+		return nil, err
+	}
+
+	if err := upsert.Submit(); err != nil {
+		return nil, err
+	}
+
+	if item.Action == BatchActionCreate {
+		if err := form.checkAccess(txDao, item, record); err != nil {
+			return nil, err
+		}
+	}
+
+	result.Id = record.Id
+	result.Record = record
+
+	return result, nil
+}
+
+func (form *BatchRequest) checkAccess(txDao *daos.Dao, item *BatchRequestItem, record *models.Record) error {
+	if form.accessFunc == nil {
+		return nil
+	}
+
+	return form.accessFunc(txDao, item, record)
+}
+
+// prepareError normalizes the batch operation error
+// so that it could be returned as part of the form errors.
+func (form *BatchRequest) prepareError(err error) error {
+	var validationErrs validation.Errors
+	if errors.As(err, &validationErrs) {
+		return validationErrs
+	}
+
+	var validationErr validation.Error
+	if errors.As(err, &validationErr) {
+		return validationErr
+	}
+
+	return validation.NewError("batch_operation_failure", "Failed to process the operation: "+err.Error())
+}
@@ -0,0 +1,214 @@
+package forms_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestBatchRequestValidate(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	tooMany := make([]*forms.BatchRequestItem, forms.BatchRequestMaxItems+1)
+	for i := range tooMany {
+		tooMany[i] = &forms.BatchRequestItem{Action: forms.BatchActionCreate, Collection: "demo2"}
+	}
+
+	scenarios := []struct {
+		name           string
+		requests       []*forms.BatchRequestItem
+		expectedErrors []string
+	}{
+		{"empty", nil, []string{"requests"}},
+		{"too many", tooMany, []string{"requests"}},
+		{
+			"invalid items",
+			[]*forms.BatchRequestItem{
+				{},
+				{Action: "missing", Collection: "demo2"},
+				{Action: forms.BatchActionUpdate, Collection: "demo2"},
+				{Action: forms.BatchActionDelete, Collection: "demo2", Id: "llvuca81nly1qls", Data: map[string]any{"title": "test"}},
+			},
+			[]string{"requests"},
+		},
+		{
+			"valid items",
+			[]*forms.BatchRequestItem{
+				{Action: forms.BatchActionCreate, Collection: "demo2"},
+				{Action: forms.BatchActionUpdate, Collection: "demo2", Id: "llvuca81nly1qls"},
+				{Action: forms.BatchActionDelete, Collection: "demo2", Id: "llvuca81nly1qls"},
+			},
+			nil,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			form := forms.NewBatchRequest(app)
+			form.Requests = s.requests
+
+			err := form.Validate()
+
+			errs, _ := err.(validation.Errors)
+			if len(errs) != len(s.expectedErrors) {
+				t.Fatalf("Expected errors %v, got %v", s.expectedErrors, errs)
+			}
+			for _, k := range s.expectedErrors {
+				if _, ok := errs[k]; !ok {
+					t.Fatalf("Missing expected %q error in %v", k, errs)
+				}
+			}
+		})
+	}
+}
+
+func TestBatchRequestSubmit(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	form := forms.NewBatchRequest(app)
+	form.Requests = []*forms.BatchRequestItem{
+		{Action: forms.BatchActionCreate, Collection: "demo2", Id: "batch0000000001", Data: map[string]any{"title": "batch1"}},
+		{Action: forms.BatchActionUpdate, Collection: "demo2", Id: "achvryl401bhse3", Data: map[string]any{"title": "batch2"}},
+		{Action: forms.BatchActionDelete, Collection: "sz5l5z67tg7gku0", Id: "llvuca81nly1qls"},
+	}
+
+	results, err := form.Submit()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	raw, _ := json.Marshal(results)
+	rawStr := string(raw)
+
+	expectations := []string{
+		`{"action":"create","collection":"demo2","id":"batch0000000001","record":{`,
+		`"title":"batch1"`,
+		`{"action":"update","collection":"demo2","id":"achvryl401bhse3","record":{`,
+		`"title":"batch2"`,
+		`{"action":"delete","collection":"demo2","id":"llvuca81nly1qls"}`,
+	}
+	for _, str := range expectations {
+		if !strings.Contains(rawStr, str) {
+			t.Fatalf("Couldn't find %s in \n%v", str, rawStr)
+		}
+	}
+
+	if r, _ := app.Dao().FindRecordById("demo2", "batch0000000001"); r == nil || r.GetString("title") != "batch1" {
+		t.Fatalf("Expected the batch record to be created, got %v", r)
+	}
+
+	if r, _ := app.Dao().FindRecordById("demo2", "achvryl401bhse3"); r == nil || r.GetString("title") != "batch2" {
+		t.Fatalf("Expected the batch record to be updated, got %v", r)
+	}
+
+	if r, _ := app.Dao().FindRecordById("demo2", "llvuca81nly1qls"); r != nil {
+		t.Fatal("Expected the batch record to be deleted")
+	}
+}
+
+func TestBatchRequestSubmitRollback(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		requests      []*forms.BatchRequestItem
+		accessFunc    forms.BatchAccessFunc
+		expectedField string // empty for operation level error
+		expectedCode  string
+	}{
+		{
+			"invalid record data",
+			[]*forms.BatchRequestItem{
+				{Action: forms.BatchActionCreate, Collection: "demo2", Data: map[string]any{"title": "batch1"}},
+				{Action: forms.BatchActionCreate, Collection: "demo2", Data: map[string]any{"title": ""}},
+			},
+			nil,
+			"title", "validation_required",
+		},
+		{
+			"missing collection",
+			[]*forms.BatchRequestItem{
+				{Action: forms.BatchActionCreate, Collection: "demo2", Data: map[string]any{"title": "batch1"}},
+				{Action: forms.BatchActionCreate, Collection: "missing", Data: map[string]any{"title": "batch2"}},
+			},
+			nil,
+			"collection", "validation_missing_batch_collection",
+		},
+		{
+			"view collection",
+			[]*forms.BatchRequestItem{
+				{Action: forms.BatchActionCreate, Collection: "demo2", Data: map[string]any{"title": "batch1"}},
+				{Action: forms.BatchActionCreate, Collection: "view2"},
+			},
+			nil,
+			"collection", "validation_batch_view_collection",
+		},
+		{
+			"missing record",
+			[]*forms.BatchRequestItem{
+				{Action: forms.BatchActionCreate, Collection: "demo2", Data: map[string]any{"title": "batch1"}},
+				{Action: forms.BatchActionDelete, Collection: "demo2", Id: "missing"},
+			},
+			nil,
+			"id", "validation_missing_batch_record",
+		},
+		{
+			"access failure",
+			[]*forms.BatchRequestItem{
+				{Action: forms.BatchActionCreate, Collection: "demo2", Data: map[string]any{"title": "batch1"}},
+				{Action: forms.BatchActionCreate, Collection: "demo2", Data: map[string]any{"title": "batch2"}},
+			},
+			func(txDao *daos.Dao, item *forms.BatchRequestItem, record *models.Record) error {
+				if record.GetString("title") == "batch2" {
+					return errors.New("test")
+				}
+				return nil
+			},
+			"", "batch_operation_failure",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			form := forms.NewBatchRequest(app)
+			form.Requests = s.requests
+			form.SetAccessFunc(s.accessFunc)
+
+			_, err := form.Submit()
+			if err == nil {
+				t.Fatal("Expected error, got nil")
+			}
+
+			// all scenarios fail at the second operation
+			errs, _ := err.(validation.Errors)
+			requestsErrs, _ := errs["requests"].(validation.Errors)
+			opErr := requestsErrs["1"]
+			if s.expectedField != "" {
+				fieldErrs, _ := opErr.(validation.Errors)
+				opErr = fieldErrs[s.expectedField]
+			}
+			if vErr, ok := opErr.(validation.Error); !ok || vErr.Code() != s.expectedCode {
+				t.Fatalf("Expected %q error with code %q, got %v", s.expectedField, s.expectedCode, err)
+			}
+
+			// ensure that the changes from the successful operations were rollbacked
+			total := 0
+			if err := app.Dao().RecordQuery("demo2").Select("count(*)").Row(&total); err != nil {
+				t.Fatal(err)
+			}
+			if total != 3 {
+				t.Fatalf("Expected 3 demo2 records, got %d", total)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package forms
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
 	"regexp"
 	"strconv"
 
@@ -65,7 +66,8 @@ func NewCollectionUpsert(app core.App, collection *models.Collection) *Collectio
 	form.CreateRule = form.collection.CreateRule
 	form.UpdateRule = form.collection.UpdateRule
 	form.DeleteRule = form.collection.DeleteRule
-	form.Options = form.collection.Options
+	// shallow copy to prevent modifying the original collection options on data load
+	form.Options = maps.Clone(form.collection.Options)
 
 	if form.Type == "" {
 		form.Type = models.CollectionTypeBase
@@ -97,6 +99,15 @@ func (form *CollectionUpsert) Validate() error {
 		if err := decodeOptions(form.Options, &options); err != nil {
 			return err
 		}
+
+		// generate the query from the feed sources (if any)
+		if len(options.Feed) > 0 {
+			if feedQuery, err := form.dao.CreateFeedViewQuery(options.Feed); err == nil {
+				options.Query = feedQuery
+				form.Options["query"] = feedQuery
+			}
+		}
+
 		form.Schema, _ = form.dao.CreateViewSchema(options.Query)
 	}
 
@@ -138,7 +149,10 @@ func (form *CollectionUpsert) Validate() error {
 			validation.By(form.checkMinSchemaFields),
 			validation.By(form.ensureNoSystemFieldsChange),
 			validation.By(form.ensureNoFieldsTypeChange),
+			validation.By(form.ensureNoLedgerFieldsChange),
 			validation.By(form.checkRelationFields),
+			validation.By(form.checkPositionFields),
+			validation.By(form.checkFieldsValidationRules),
 			validation.When(isAuth, validation.By(form.ensureNoAuthFieldName)),
 		),
 		validation.Field(&form.ListRule, validation.By(form.checkRule)),
@@ -226,6 +240,50 @@ func (form *CollectionUpsert) ensureNoFieldsTypeChange(value any) error {
 	return nil
 }
 
+// ensureNoLedgerFieldsChange prevents the existing ledger collection
+// fields changes that would alter the stored records data and
+// therefore break the ledger hash chain.
+func (form *CollectionUpsert) ensureNoLedgerFieldsChange(value any) error {
+	v, _ := value.(schema.Schema)
+
+	if !form.collection.LedgerEnabled() {
+		return nil // not a ledger
+	}
+
+	for _, oldField := range form.collection.Schema.Fields() {
+		newField := v.GetFieldById(oldField.Id)
+		if newField == nil {
+			return validation.NewError(
+				"validation_ledger_field_delete",
+				fmt.Sprintf("The ledger field %q cannot be deleted.", oldField.Name),
+			)
+		}
+
+		if newField.Name != oldField.Name {
+			return validation.NewError(
+				"validation_ledger_field_rename",
+				fmt.Sprintf("The ledger field %q cannot be renamed.", oldField.Name),
+			)
+		}
+
+		var isOldMultiple, isNewMultiple bool
+		if opt, ok := oldField.Options.(schema.MultiValuer); ok {
+			isOldMultiple = opt.IsMultiple()
+		}
+		if opt, ok := newField.Options.(schema.MultiValuer); ok {
+			isNewMultiple = opt.IsMultiple()
+		}
+		if isOldMultiple != isNewMultiple {
+			return validation.NewError(
+				"validation_ledger_field_multiple_change",
+				fmt.Sprintf("The ledger field %q cannot be changed from single to multiple (or vice versa).", oldField.Name),
+			)
+		}
+	}
+
+	return nil
+}
+
 func (form *CollectionUpsert) checkRelationFields(value any) error {
 	v, _ := value.(schema.Schema)
 
@@ -286,6 +344,156 @@ func (form *CollectionUpsert) checkRelationFields(value any) error {
 				}},
 			}
 		}
+
+		if options.Through != nil {
+			if err := form.checkRelationThrough(field, options); err != nil {
+				return validation.Errors{fmt.Sprint(i): err}
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkPositionFields checks whether the position fields
+// scope consists only of existing single value fields.
+func (form *CollectionUpsert) checkPositionFields(value any) error {
+	v, _ := value.(schema.Schema)
+
+	for i, field := range v.Fields() {
+		if field.Type != schema.FieldTypePosition {
+			continue
+		}
+
+		options, _ := field.Options.(*schema.PositionOptions)
+		if options == nil {
+			continue
+		}
+
+		for j, name := range options.Scope {
+			scopeField := v.GetFieldByName(name)
+
+			isValid := scopeField != nil && scopeField.Type != schema.FieldTypePosition && !scopeField.IsFile()
+			if isValid {
+				if opt, ok := scopeField.Options.(schema.MultiValuer); ok && opt.IsMultiple() {
+					isValid = false
+				}
+			}
+
+			if !isValid {
+				return validation.Errors{fmt.Sprint(i): validation.Errors{
+					"options": validation.Errors{
+						"scope": validation.Errors{fmt.Sprint(j): validation.NewError(
+							"validation_invalid_position_scope_field",
+							fmt.Sprintf("The scope field %q must be an existing single value field.", name),
+						)},
+					}},
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkRelationThrough validates the join collection
+// configuration of a "through" relation field.
+func (form *CollectionUpsert) checkRelationThrough(field *schema.SchemaField, options *schema.RelationOptions) error {
+	throughErr := func(key string, err error) error {
+		return validation.Errors{"options": validation.Errors{
+			"through": validation.Errors{key: err},
+		}}
+	}
+
+	if form.Type == models.CollectionTypeView {
+		return throughErr("collectionId", validation.NewError(
+			"validation_field_view_through_relation",
+			"View collections cannot have through relations.",
+		))
+	}
+
+	// the field value is managed by the join records
+	if field.Required {
+		return validation.Errors{"required": validation.NewError(
+			"validation_field_through_required",
+			"Through relation fields cannot be required.",
+		)}
+	}
+
+	joinCollection, _ := form.dao.FindCollectionByNameOrId(options.Through.CollectionId)
+	if joinCollection == nil || joinCollection.Id != options.Through.CollectionId || !joinCollection.IsBase() {
+		return throughErr("collectionId", validation.NewError(
+			"validation_field_invalid_through_collection",
+			"The through collection must be an existing base collection.",
+		))
+	}
+
+	if !isSingleRelationTo(joinCollection.Schema.GetFieldByName(options.Through.SourceField), form.Id) {
+		return throughErr("sourceField", validation.NewError(
+			"validation_field_invalid_through_source_field",
+			"The source field must be a single relation field referencing the current collection.",
+		))
+	}
+
+	if !isSingleRelationTo(joinCollection.Schema.GetFieldByName(options.Through.TargetField), options.CollectionId) {
+		return throughErr("targetField", validation.NewError(
+			"validation_field_invalid_through_target_field",
+			"The target field must be a single relation field referencing the related collection.",
+		))
+	}
+
+	return nil
+}
+
+func isSingleRelationTo(field *schema.SchemaField, collectionId string) bool {
+	if field == nil || field.Type != schema.FieldTypeRelation || collectionId == "" {
+		return false
+	}
+
+	field.InitOptions()
+	options, _ := field.Options.(*schema.RelationOptions)
+
+	return options != nil && options.CollectionId == collectionId && !options.IsMultiple()
+}
+
+func (form *CollectionUpsert) checkFieldsValidationRules(value any) error {
+	v, _ := value.(schema.Schema)
+
+	dummy := *form.collection
+	dummy.Type = form.Type
+	dummy.Schema = v
+
+	r := resolvers.NewRecordDataResolver(&dummy, nil)
+
+	errs := validation.Errors{}
+	for i, field := range v.Fields() {
+		fieldErrs := validation.Errors{}
+
+		if field.RequiredIf != "" {
+			if _, err := search.FilterData(field.RequiredIf).BuildExpr(r); err != nil {
+				fieldErrs["requiredIf"] = validation.NewError(
+					"validation_invalid_rule",
+					"Invalid field required condition. Raw error: "+err.Error(),
+				)
+			}
+		}
+
+		if field.ValidationRule != "" {
+			if _, err := search.FilterData(field.ValidationRule).BuildExpr(r); err != nil {
+				fieldErrs["validationRule"] = validation.NewError(
+					"validation_invalid_rule",
+					"Invalid field validation rule. Raw error: "+err.Error(),
+				)
+			}
+		}
+
+		if len(fieldErrs) > 0 {
+			errs[fmt.Sprint(i)] = fieldErrs
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
 	}
 
 	return nil
@@ -418,6 +626,53 @@ func (form *CollectionUpsert) checkOptions(value any) error {
 	v, _ := value.(types.JsonMap)
 
 	switch form.Type {
+	case models.CollectionTypeBase:
+		options := models.CollectionBaseOptions{}
+		if err := decodeOptions(v, &options); err != nil {
+			return err
+		}
+
+		// check the generic validations
+		if err := options.Validate(); err != nil {
+			return err
+		}
+
+		// additional form specific validations
+		if err := form.checkLedger(options.Ledger); err != nil {
+			return validation.Errors{"ledger": err}
+		}
+
+		if err := form.checkProfiles(options.Profiles); err != nil {
+			return validation.Errors{"profiles": err}
+		}
+
+		if err := form.checkTenantField(options.TenantField); err != nil {
+			return validation.Errors{"tenantField": err}
+		}
+
+		if err := form.checkTreeField(options.TreeField); err != nil {
+			return validation.Errors{"treeField": err}
+		}
+
+		if err := form.checkReactionsField(options.ReactionsField); err != nil {
+			return validation.Errors{"reactionsField": err}
+		}
+
+		if err := form.checkSoftDeleteField(options.SoftDeleteField); err != nil {
+			return validation.Errors{"softDeleteField": err}
+		}
+
+		if err := form.checkSchedules(options.Schedules); err != nil {
+			return validation.Errors{"schedules": err}
+		}
+
+		if err := form.checkListDefaultSort(options.ListDefaultSort); err != nil {
+			return validation.Errors{"listDefaultSort": err}
+		}
+
+		if err := form.checkFullTextFields(options.FullTextFields); err != nil {
+			return validation.Errors{"fullTextFields": err}
+		}
 	case models.CollectionTypeAuth:
 		options := models.CollectionAuthOptions{}
 		if err := decodeOptions(v, &options); err != nil {
@@ -433,6 +688,38 @@ func (form *CollectionUpsert) checkOptions(value any) error {
 		if err := form.checkRule(options.ManageRule); err != nil {
 			return validation.Errors{"manageRule": err}
 		}
+
+		if err := form.checkProfiles(options.Profiles); err != nil {
+			return validation.Errors{"profiles": err}
+		}
+
+		if err := form.checkTenantField(options.TenantField); err != nil {
+			return validation.Errors{"tenantField": err}
+		}
+
+		if err := form.checkTreeField(options.TreeField); err != nil {
+			return validation.Errors{"treeField": err}
+		}
+
+		if err := form.checkReactionsField(options.ReactionsField); err != nil {
+			return validation.Errors{"reactionsField": err}
+		}
+
+		if err := form.checkSoftDeleteField(options.SoftDeleteField); err != nil {
+			return validation.Errors{"softDeleteField": err}
+		}
+
+		if err := form.checkSchedules(options.Schedules); err != nil {
+			return validation.Errors{"schedules": err}
+		}
+
+		if err := form.checkListDefaultSort(options.ListDefaultSort); err != nil {
+			return validation.Errors{"listDefaultSort": err}
+		}
+
+		if err := form.checkFullTextFields(options.FullTextFields); err != nil {
+			return validation.Errors{"fullTextFields": err}
+		}
 	case models.CollectionTypeView:
 		options := models.CollectionViewOptions{}
 		if err := decodeOptions(v, &options); err != nil {
@@ -444,6 +731,18 @@ func (form *CollectionUpsert) checkOptions(value any) error {
 			return err
 		}
 
+		// check the feed sources
+		if len(options.Feed) > 0 {
+			if _, err := form.dao.CreateFeedViewQuery(options.Feed); err != nil {
+				return validation.Errors{
+					"feed": validation.NewError(
+						"validation_invalid_feed",
+						fmt.Sprintf("Invalid feed - %s", err.Error()),
+					),
+				}
+			}
+		}
+
 		// check the query option
 		if _, err := form.dao.CreateViewSchema(options.Query); err != nil {
 			return validation.Errors{
@@ -453,6 +752,262 @@ func (form *CollectionUpsert) checkOptions(value any) error {
 				),
 			}
 		}
+
+		if err := form.checkListDefaultSort(options.ListDefaultSort); err != nil {
+			return validation.Errors{"listDefaultSort": err}
+		}
+	}
+
+	return nil
+}
+
+// checkListDefaultSort checks whether the default list sort fields are resolvable.
+func (form *CollectionUpsert) checkListDefaultSort(sort string) error {
+	if sort == "" {
+		return nil // nothing to check
+	}
+
+	dummy := *form.collection
+	dummy.Type = form.Type
+	dummy.Schema = form.Schema
+	dummy.System = form.System
+	dummy.Options = form.Options
+
+	r := resolvers.NewRecordFieldResolver(form.dao, &dummy, nil, true)
+
+	for _, field := range search.ParseSortFromString(sort) {
+		if _, err := field.BuildExpr(r); err != nil {
+			return validation.NewError("validation_invalid_sort", "Invalid sort expression. Raw error: "+err.Error())
+		}
+	}
+
+	return nil
+}
+
+// checkProfiles checks the profiles rules and
+// whether their fields exist in the collection.
+func (form *CollectionUpsert) checkProfiles(profiles []models.CollectionProfile) error {
+	allowedFields := make([]string, 0, len(form.Schema.Fields())+4)
+	for _, f := range form.Schema.Fields() {
+		allowedFields = append(allowedFields, f.Name)
+	}
+	if form.Type == models.CollectionTypeAuth {
+		allowedFields = append(
+			allowedFields,
+			schema.FieldNameUsername,
+			schema.FieldNameEmail,
+			schema.FieldNameEmailVisibility,
+			schema.FieldNameVerified,
+		)
+	}
+
+	for i, profile := range profiles {
+		if err := form.checkRule(profile.Rule); err != nil {
+			return validation.Errors{strconv.Itoa(i): validation.Errors{"rule": err}}
+		}
+
+		for j, field := range profile.Fields {
+			if !list.ExistInSlice(field, allowedFields) {
+				return validation.Errors{strconv.Itoa(i): validation.Errors{
+					"fields": validation.Errors{strconv.Itoa(j): validation.NewError(
+						"validation_unknown_profile_field",
+						fmt.Sprintf("Unknown field %q.", field),
+					)},
+				}}
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkTreeField checks whether the tree field is
+// a single relation field referencing the collection itself.
+func (form *CollectionUpsert) checkTreeField(treeField string) error {
+	if treeField == "" {
+		return nil // not a tree
+	}
+
+	if !isSingleRelationTo(form.Schema.GetFieldByName(treeField), form.Id) {
+		return validation.NewError(
+			"validation_invalid_tree_field",
+			"The tree field must be a single relation field referencing the current collection.",
+		)
+	}
+
+	return nil
+}
+
+// checkSoftDeleteField checks whether the soft delete
+// field is an existing non-required date field.
+func (form *CollectionUpsert) checkSoftDeleteField(softDeleteField string) error {
+	if softDeleteField == "" {
+		return nil // disabled soft delete
+	}
+
+	field := form.Schema.GetFieldByName(softDeleteField)
+	if field == nil || field.Type != schema.FieldTypeDate || field.Required {
+		return validation.NewError(
+			"validation_invalid_soft_delete_field",
+			"The soft delete field must be an existing non-required date field.",
+		)
+	}
+
+	return nil
+}
+
+// checkLedger ensures that the ledger mode is not changed after the collection creation.
+func (form *CollectionUpsert) checkLedger(ledger bool) error {
+	if !form.collection.IsNew() && form.collection.LedgerEnabled() != ledger {
+		return validation.NewError(
+			"validation_ledger_change",
+			"The ledger mode could be set only on the collection creation.",
+		)
+	}
+
+	return nil
+}
+
+// checkSchedules checks whether the schedules trigger fields are
+// existing date fields and whether the "update" data fields exist.
+func (form *CollectionUpsert) checkSchedules(schedules []models.CollectionSchedule) error {
+	for i, schedule := range schedules {
+		field := form.Schema.GetFieldByName(schedule.Field)
+		if field == nil || field.Type != schema.FieldTypeDate {
+			return validation.Errors{strconv.Itoa(i): validation.Errors{
+				"field": validation.NewError(
+					"validation_invalid_schedule_field",
+					"The schedule field must be an existing date field.",
+				),
+			}}
+		}
+
+		for name := range schedule.Data {
+			if form.Schema.GetFieldByName(name) == nil {
+				return validation.Errors{strconv.Itoa(i): validation.Errors{
+					"data": validation.NewError(
+						"validation_unknown_schedule_data_field",
+						fmt.Sprintf("Unknown field %q.", name),
+					),
+				}}
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkFullTextFields checks whether the full-text index fields
+// are existing plain (aka. not encrypted) text-like fields
+// and whether the SQLite build supports full-text indexes.
+func (form *CollectionUpsert) checkFullTextFields(names []string) error {
+	if len(names) > 0 && !form.dao.HasFullTextSupport() {
+		return validation.NewError(
+			"validation_full_text_unavailable",
+			"The full-text search requires a SQLite build with the FTS5 extension.",
+		)
+	}
+
+	for i, name := range names {
+		field := form.Schema.GetFieldByName(name)
+
+		isValid := field != nil
+		if isValid {
+			switch field.Type {
+			case schema.FieldTypeText:
+				opt, _ := field.Options.(*schema.TextOptions)
+				isValid = opt == nil || !opt.Encrypted
+			case schema.FieldTypeEditor, schema.FieldTypeMarkdown, schema.FieldTypeEmail, schema.FieldTypeUrl:
+			default:
+				isValid = false
+			}
+		}
+
+		if !isValid {
+			return validation.Errors{strconv.Itoa(i): validation.NewError(
+				"validation_invalid_full_text_field",
+				"The full-text field must be an existing non-encrypted text, editor, markdown, email or url field.",
+			)}
+		}
+	}
+
+	return nil
+}
+
+// checkReactionsField checks whether the reactions
+// counters field is an existing json field.
+func (form *CollectionUpsert) checkReactionsField(reactionsField string) error {
+	if reactionsField == "" {
+		return nil // no reactions
+	}
+
+	field := form.Schema.GetFieldByName(reactionsField)
+	if field == nil || field.Type != schema.FieldTypeJson {
+		return validation.NewError(
+			"validation_invalid_reactions_field",
+			"The reactions field must be an existing json field.",
+		)
+	}
+
+	return nil
+}
+
+// checkTenantField checks whether the tenant field exists in the collection,
+// whether it is set in case the collection has encrypted fields and
+// whether it is unchanged in case of existing tenant dependent records.
+func (form *CollectionUpsert) checkTenantField(tenantField string) error {
+	hasEncryptedFields := false
+	hasFileFields := false
+	for _, f := range form.Schema.Fields() {
+		if opt, ok := f.Options.(*schema.TextOptions); ok && opt.Encrypted {
+			hasEncryptedFields = true
+		}
+		if f.IsFile() {
+			hasFileFields = true
+		}
+	}
+
+	// the tenant keys and files path of the existing records depend on the tenant field
+	if !form.collection.IsNew() &&
+		form.collection.TenantField() != tenantField &&
+		(hasEncryptedFields || hasFileFields) {
+		var exists bool
+		err := form.dao.RecordQuery(form.collection).Select("(1)").Limit(1).Row(&exists)
+		if err == nil && exists {
+			return validation.NewError(
+				"validation_tenant_field_change",
+				"The tenant field cannot be changed for collections with existing records and encrypted or file fields.",
+			)
+		}
+	}
+
+	if tenantField == "" {
+		if hasEncryptedFields {
+			return validation.NewError(
+				"validation_missing_tenant_field",
+				"The tenant field is required when the collection has encrypted fields.",
+			)
+		}
+		return nil
+	}
+
+	if tenantField == schema.FieldNameId {
+		return nil
+	}
+
+	field := form.Schema.GetFieldByName(tenantField)
+	if field == nil {
+		return validation.NewError(
+			"validation_unknown_tenant_field",
+			fmt.Sprintf("Unknown field %q.", tenantField),
+		)
+	}
+
+	if opt, ok := field.Options.(*schema.TextOptions); ok && opt.Encrypted {
+		return validation.NewError(
+			"validation_encrypted_tenant_field",
+			"The tenant field cannot be encrypted.",
+		)
 	}
 
 	return nil
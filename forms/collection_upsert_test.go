@@ -3,6 +3,7 @@ package forms_test
 import (
 	"encoding/json"
 	"errors"
+	"strings"
 	"testing"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
@@ -12,6 +13,7 @@ import (
 	"github.com/pocketbase/pocketbase/tests"
 	"github.com/pocketbase/pocketbase/tools/dbutils"
 	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/pocketbase/pocketbase/tools/types"
 	"github.com/spf13/cast"
 )
 
@@ -521,6 +523,60 @@ func TestCollectionUpsertValidateAndSubmit(t *testing.T) {
 			}`,
 			[]string{},
 		},
+		{
+			"encrypted fields without tenant field",
+			"",
+			`{
+				"name": "tenant_test1",
+				"schema": [{"name":"secret","type":"text","options":{"encrypted":true}}]
+			}`,
+			[]string{"options"},
+		},
+		{
+			"unknown tenant field",
+			"",
+			`{
+				"name": "tenant_test2",
+				"schema": [{"name":"secret","type":"text","options":{"encrypted":true}}],
+				"options": {"tenantField": "missing"}
+			}`,
+			[]string{"options"},
+		},
+		{
+			"encrypted tenant field",
+			"",
+			`{
+				"name": "tenant_test3",
+				"schema": [{"name":"secret","type":"text","options":{"encrypted":true}}],
+				"options": {"tenantField": "secret"}
+			}`,
+			[]string{"options"},
+		},
+		{
+			"valid tenant field",
+			"",
+			`{
+				"name": "tenant_test4",
+				"schema": [
+					{"name":"tenant","type":"text"},
+					{"name":"secret","type":"text","options":{"encrypted":true}}
+				],
+				"options": {"tenantField": "tenant"}
+			}`,
+			[]string{},
+		},
+		{
+			"changing the tenant field of a collection with records and file fields",
+			"demo1",
+			`{"options": {"tenantField": "text"}}`,
+			[]string{"options"},
+		},
+		{
+			"changing the tenant field of a collection with records and no encrypted or file fields",
+			"demo2",
+			`{"options": {"tenantField": "title"}}`,
+			[]string{},
+		},
 	}
 
 	for _, s := range scenarios {
@@ -806,3 +862,597 @@ func TestCollectionUpsertWithCustomId(t *testing.T) {
 		}
 	}
 }
+
+func TestCollectionUpsertRelationThrough(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	users, _ := app.Dao().FindCollectionByNameOrId("users")
+	demo2, _ := app.Dao().FindCollectionByNameOrId("demo2")
+
+	memberships := &models.Collection{}
+	memberships.Name = "memberships"
+	memberships.Schema = schema.NewSchema(
+		&schema.SchemaField{
+			Name:    "user",
+			Type:    schema.FieldTypeRelation,
+			Options: &schema.RelationOptions{CollectionId: users.Id, MaxSelect: types.Pointer(1)},
+		},
+		&schema.SchemaField{
+			Name:    "item",
+			Type:    schema.FieldTypeRelation,
+			Options: &schema.RelationOptions{CollectionId: demo2.Id, MaxSelect: types.Pointer(1)},
+		},
+		&schema.SchemaField{
+			Name:    "items",
+			Type:    schema.FieldTypeRelation,
+			Options: &schema.RelationOptions{CollectionId: demo2.Id},
+		},
+	)
+	if err := app.Dao().SaveCollection(memberships); err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		name          string
+		required      bool
+		through       schema.RelationThrough
+		expectedError string
+	}{
+		{
+			"required through field",
+			true,
+			schema.RelationThrough{CollectionId: memberships.Id, SourceField: "user", TargetField: "item"},
+			"Through relation fields cannot be required",
+		},
+		{
+			"missing join collection",
+			false,
+			schema.RelationThrough{CollectionId: "missing", SourceField: "user", TargetField: "item"},
+			"The through collection must be an existing base collection",
+		},
+		{
+			"source field referencing another collection",
+			false,
+			schema.RelationThrough{CollectionId: memberships.Id, SourceField: "item", TargetField: "user"},
+			"The source field must be",
+		},
+		{
+			"multiple target field",
+			false,
+			schema.RelationThrough{CollectionId: memberships.Id, SourceField: "user", TargetField: "items"},
+			"The target field must be",
+		},
+		{
+			"valid through field",
+			false,
+			schema.RelationThrough{CollectionId: memberships.Id, SourceField: "user", TargetField: "item"},
+			"",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			through := s.through
+
+			form := forms.NewCollectionUpsert(app, users)
+			form.Schema.AddField(&schema.SchemaField{
+				Name:     "memberships",
+				Type:     schema.FieldTypeRelation,
+				Required: s.required,
+				Options: &schema.RelationOptions{
+					CollectionId: demo2.Id,
+					Through:      &through,
+				},
+			})
+
+			err := form.Submit()
+
+			if s.expectedError == "" {
+				if err != nil {
+					t.Fatalf("Expected no error, got %v", err)
+				}
+				return
+			}
+
+			raw, _ := json.Marshal(err)
+			if !strings.Contains(string(raw), s.expectedError) {
+				t.Fatalf("Expected error %q, got %s", s.expectedError, raw)
+			}
+		})
+	}
+}
+
+func TestCollectionUpsertTreeField(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	demo2, _ := app.Dao().FindCollectionByNameOrId("demo2")
+
+	collection := &models.Collection{}
+	collection.Id = "categories00000"
+	collection.Name = "categories"
+	collection.Schema = schema.NewSchema(
+		&schema.SchemaField{
+			Name: "title",
+			Type: schema.FieldTypeText,
+		},
+		&schema.SchemaField{
+			Name:    "parent",
+			Type:    schema.FieldTypeRelation,
+			Options: &schema.RelationOptions{CollectionId: collection.Id, MaxSelect: types.Pointer(1)},
+		},
+		&schema.SchemaField{
+			Name:    "parents",
+			Type:    schema.FieldTypeRelation,
+			Options: &schema.RelationOptions{CollectionId: collection.Id},
+		},
+		&schema.SchemaField{
+			Name:    "other",
+			Type:    schema.FieldTypeRelation,
+			Options: &schema.RelationOptions{CollectionId: demo2.Id, MaxSelect: types.Pointer(1)},
+		},
+	)
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		name          string
+		options       string
+		expectedError bool
+	}{
+		{"no tree", `{}`, false},
+		{"missing field", `{"treeField":"missing"}`, true},
+		{"non relation field", `{"treeField":"title"}`, true},
+		{"multiple relation field", `{"treeField":"parents"}`, true},
+		{"relation field to another collection", `{"treeField":"other"}`, true},
+		{"negative max depth", `{"treeField":"parent","treeMaxDepth":-1}`, true},
+		{"valid tree field", `{"treeField":"parent","treeMaxDepth":10}`, false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			form := forms.NewCollectionUpsert(app, collection)
+			if err := json.Unmarshal([]byte(`{"options":`+s.options+`}`), form); err != nil {
+				t.Fatal(err)
+			}
+
+			err := form.Validate()
+
+			errs, _ := err.(validation.Errors)
+			_, hasOptionsErr := errs["options"]
+			if hasOptionsErr != s.expectedError {
+				t.Fatalf("Expected options error %v, got %v", s.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestCollectionUpsertReactionsField(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	collection.Schema.AddField(&schema.SchemaField{
+		Name:    "reactions",
+		Type:    schema.FieldTypeJson,
+		Options: &schema.JsonOptions{MaxSize: 2000},
+	})
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		name          string
+		options       string
+		expectedError bool
+	}{
+		{"no reactions", `{}`, false},
+		{"missing reactions field", `{"reactions":["like"]}`, true},
+		{"nonexisting reactions field", `{"reactions":["like"],"reactionsField":"missing"}`, true},
+		{"non json reactions field", `{"reactions":["like"],"reactionsField":"title"}`, true},
+		{"valid reactions field", `{"reactions":["like","heart"],"reactionsField":"reactions"}`, false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			form := forms.NewCollectionUpsert(app, collection)
+			if err := json.Unmarshal([]byte(`{"options":`+s.options+`}`), form); err != nil {
+				t.Fatal(err)
+			}
+
+			err := form.Validate()
+
+			errs, _ := err.(validation.Errors)
+			_, hasOptionsErr := errs["options"]
+			if hasOptionsErr != s.expectedError {
+				t.Fatalf("Expected options error %v, got %v", s.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestCollectionUpsertSoftDeleteField(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	collection.Schema.AddField(&schema.SchemaField{
+		Name: "deleted",
+		Type: schema.FieldTypeDate,
+	})
+	collection.Schema.AddField(&schema.SchemaField{
+		Name:     "requiredDate",
+		Type:     schema.FieldTypeDate,
+		Required: true,
+	})
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		name          string
+		options       string
+		expectedError bool
+	}{
+		{"no soft delete field", `{}`, false},
+		{"nonexisting soft delete field", `{"softDeleteField":"missing"}`, true},
+		{"non date soft delete field", `{"softDeleteField":"title"}`, true},
+		{"required date soft delete field", `{"softDeleteField":"requiredDate"}`, true},
+		{"valid soft delete field", `{"softDeleteField":"deleted"}`, false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			form := forms.NewCollectionUpsert(app, collection)
+			if err := json.Unmarshal([]byte(`{"options":`+s.options+`}`), form); err != nil {
+				t.Fatal(err)
+			}
+
+			err := form.Validate()
+
+			errs, _ := err.(validation.Errors)
+			_, hasOptionsErr := errs["options"]
+			if hasOptionsErr != s.expectedError {
+				t.Fatalf("Expected options error %v, got %v", s.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestCollectionUpsertSchedules(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	collection.Schema.AddField(&schema.SchemaField{
+		Name: "publishAt",
+		Type: schema.FieldTypeDate,
+	})
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		name          string
+		options       string
+		expectedError bool
+	}{
+		{"no schedules", `{}`, false},
+		{"nonexisting schedule field", `{"schedules":[{"name":"test","field":"missing","action":"delete"}]}`, true},
+		{"non date schedule field", `{"schedules":[{"name":"test","field":"title","action":"delete"}]}`, true},
+		{"unknown data field", `{"schedules":[{"name":"test","field":"publishAt","action":"update","data":{"missing":true}}]}`, true},
+		{"valid schedules", `{"schedules":[{"name":"publish","field":"publishAt","action":"update","data":{"active":true}},{"name":"expire","field":"publishAt","action":"delete"}]}`, false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			form := forms.NewCollectionUpsert(app, collection)
+			if err := json.Unmarshal([]byte(`{"options":`+s.options+`}`), form); err != nil {
+				t.Fatal(err)
+			}
+
+			err := form.Validate()
+
+			errs, _ := err.(validation.Errors)
+			_, hasOptionsErr := errs["options"]
+			if hasOptionsErr != s.expectedError {
+				t.Fatalf("Expected options error %v, got %v", s.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestCollectionUpsertListDefaultSort(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		name          string
+		collection    string
+		options       string
+		expectedError bool
+	}{
+		{"no default sort", "demo2", `{}`, false},
+		{"unknown sort field", "demo2", `{"listDefaultSort":"-missing"}`, true},
+		{"valid base sort", "demo2", `{"listDefaultSort":"-created,title"}`, false},
+		{"valid auth sort", "users", `{"listDefaultSort":"-username"}`, false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			collection, err := app.Dao().FindCollectionByNameOrId(s.collection)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			form := forms.NewCollectionUpsert(app, collection)
+
+			// merge with the existing options
+			options := map[string]any{}
+			if err := json.Unmarshal([]byte(s.options), &options); err != nil {
+				t.Fatal(err)
+			}
+			for k, v := range options {
+				form.Options[k] = v
+			}
+
+			err = form.Validate()
+
+			errs, _ := err.(validation.Errors)
+			_, hasOptionsErr := errs["options"]
+			if hasOptionsErr != s.expectedError {
+				t.Fatalf("Expected options error %v, got %v", s.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestCollectionUpsertFullTextFields(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	// the valid fields are rejected if the SQLite build doesn't support FTS5
+	unsupported := !app.Dao().HasFullTextSupport()
+
+	scenarios := []struct {
+		name          string
+		collection    string
+		options       string
+		expectedError bool
+	}{
+		{"no full-text fields", "demo2", `{}`, false},
+		{"unknown field", "demo2", `{"fullTextFields":["missing"]}`, true},
+		{"non text field", "demo2", `{"fullTextFields":["active"]}`, true},
+		{"duplicated field", "demo2", `{"fullTextFields":["title","title"]}`, true},
+		{"valid base fields", "demo2", `{"fullTextFields":["title"]}`, unsupported},
+		{"valid auth fields", "users", `{"fullTextFields":["name"]}`, unsupported},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			collection, err := app.Dao().FindCollectionByNameOrId(s.collection)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			form := forms.NewCollectionUpsert(app, collection)
+
+			// merge with the existing options
+			options := map[string]any{}
+			if err := json.Unmarshal([]byte(s.options), &options); err != nil {
+				t.Fatal(err)
+			}
+			for k, v := range options {
+				form.Options[k] = v
+			}
+
+			err = form.Validate()
+
+			errs, _ := err.(validation.Errors)
+			_, hasOptionsErr := errs["options"]
+			if hasOptionsErr != s.expectedError {
+				t.Fatalf("Expected options error %v, got %v", s.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestCollectionUpsertFeed(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		options       string
+		expectedError bool
+	}{
+		{"no feed and query", `{}`, true},
+		{"invalid source type", `{"feed":[{"collection":"demo2","type":"in valid","title":"title"}]}`, true},
+		{"missing source collection", `{"feed":[{"collection":"missing","title":"title"}]}`, true},
+		{"missing source field", `{"feed":[{"collection":"demo2","title":"missing"}]}`, true},
+		{"view source collection", `{"feed":[{"collection":"view1","title":"id"}]}`, true},
+		{"valid feed", `{"feed":[{"collection":"demo2","type":"post","title":"title"},{"collection":"users","title":"username","link":"id"}]}`, false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			form := forms.NewCollectionUpsert(app, &models.Collection{})
+			form.Name = "feed"
+			form.Type = models.CollectionTypeView
+			form.Options = types.JsonMap{}
+			if err := json.Unmarshal([]byte(s.options), &form.Options); err != nil {
+				t.Fatal(err)
+			}
+
+			err := form.Submit()
+
+			errs, _ := err.(validation.Errors)
+			_, hasOptionsErr := errs["options"]
+			if hasOptionsErr != s.expectedError {
+				t.Fatalf("Expected options error %v, got %v", s.expectedError, err)
+			}
+
+			if hasOptionsErr {
+				return
+			}
+
+			collection, err := app.Dao().FindCollectionByNameOrId("feed")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !strings.Contains(collection.ViewOptions().Query, "UNION ALL") {
+				t.Fatalf("Expected generated union query, got %q", collection.ViewOptions().Query)
+			}
+
+			var total int
+			if err := app.Dao().RecordQuery(collection).Select("count(*)").Row(&total); err != nil {
+				t.Fatal(err)
+			}
+
+			if total != 6 {
+				t.Fatalf("Expected 6 feed records, got %d", total)
+			}
+
+			for _, name := range []string{"type", "title", "link"} {
+				if collection.Schema.GetFieldByName(name) == nil {
+					t.Fatalf("Expected feed field %q", name)
+				}
+			}
+		})
+	}
+}
+
+func TestCollectionUpsertLedger(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	ledger := &models.Collection{
+		Name: "ledger",
+		Type: models.CollectionTypeBase,
+		Schema: schema.NewSchema(
+			&schema.SchemaField{Name: "title", Type: schema.FieldTypeText},
+			&schema.SchemaField{
+				Name:    "tags",
+				Type:    schema.FieldTypeSelect,
+				Options: &schema.SelectOptions{MaxSelect: 2, Values: []string{"a", "b"}},
+			},
+		),
+	}
+	ledger.SetOptions(models.CollectionBaseOptions{Ledger: true})
+	if err := app.Dao().SaveCollection(ledger); err != nil {
+		t.Fatal(err)
+	}
+
+	demo2, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		name           string
+		collection     *models.Collection
+		options        string
+		modify         func(s *schema.Schema)
+		expectedErrors []string
+	}{
+		{
+			name:       "new ledger collection",
+			collection: &models.Collection{},
+			options:    `{"ledger":true}`,
+		},
+		{
+			name:           "new ledger collection with history",
+			collection:     &models.Collection{},
+			options:        `{"ledger":true,"history":true}`,
+			expectedErrors: []string{"options"},
+		},
+		{
+			name:           "enable ledger for existing collection",
+			collection:     demo2,
+			options:        `{"ledger":true}`,
+			expectedErrors: []string{"options"},
+		},
+		{
+			name:           "disable ledger",
+			collection:     ledger,
+			options:        `{"ledger":false}`,
+			expectedErrors: []string{"options"},
+		},
+		{
+			name:       "add new ledger field",
+			collection: ledger,
+			options:    `{"ledger":true}`,
+			modify: func(s *schema.Schema) {
+				s.AddField(&schema.SchemaField{Name: "extra", Type: schema.FieldTypeText})
+			},
+		},
+		{
+			name:       "delete ledger field",
+			collection: ledger,
+			options:    `{"ledger":true}`,
+			modify: func(s *schema.Schema) {
+				s.RemoveField(s.GetFieldByName("title").Id)
+			},
+			expectedErrors: []string{"schema"},
+		},
+		{
+			name:       "rename ledger field",
+			collection: ledger,
+			options:    `{"ledger":true}`,
+			modify: func(s *schema.Schema) {
+				s.GetFieldByName("title").Name = "title2"
+			},
+			expectedErrors: []string{"schema"},
+		},
+		{
+			name:       "change ledger field from multiple to single",
+			collection: ledger,
+			options:    `{"ledger":true}`,
+			modify: func(s *schema.Schema) {
+				s.GetFieldByName("tags").Options = &schema.SelectOptions{MaxSelect: 1, Values: []string{"a", "b"}}
+			},
+			expectedErrors: []string{"schema"},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			form := forms.NewCollectionUpsert(app, s.collection)
+			if s.collection.IsNew() {
+				form.Name = "new_ledger"
+				form.Schema.AddField(&schema.SchemaField{Name: "title", Type: schema.FieldTypeText})
+			}
+			form.Options = types.JsonMap{}
+			if err := json.Unmarshal([]byte(s.options), &form.Options); err != nil {
+				t.Fatal(err)
+			}
+			if s.modify != nil {
+				s.modify(&form.Schema)
+			}
+
+			err := form.Validate()
+
+			errs, _ := err.(validation.Errors)
+			if len(errs) != len(s.expectedErrors) {
+				t.Fatalf("Expected error keys %v, got %v", s.expectedErrors, err)
+			}
+			for _, k := range s.expectedErrors {
+				if _, ok := errs[k]; !ok {
+					t.Fatalf("Missing expected error key %q in %v", k, errs)
+				}
+			}
+		})
+	}
+}
@@ -3,11 +3,16 @@ package forms
 import (
 	"encoding/json"
 	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/pocketbase/pocketbase/core"
 	"github.com/pocketbase/pocketbase/daos"
 	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/settings"
+	"github.com/pocketbase/pocketbase/tools/secretscan"
 )
 
 // CollectionsImport is a form model to bulk import
@@ -40,10 +45,78 @@ func (form *CollectionsImport) SetDao(dao *daos.Dao) {
 // Validate makes the form validatable by implementing [validation.Validatable] interface.
 func (form *CollectionsImport) Validate() error {
 	return validation.ValidateStruct(form,
-		validation.Field(&form.Collections, validation.Required),
+		validation.Field(&form.Collections, validation.Required, validation.By(form.checkSecrets)),
 	)
 }
 
+// ScanSecrets scans the form collections for embedded credentials
+// (eg. AWS keys in rules or field options) and returns the found
+// secrets grouped by collection name.
+//
+// The line numbers of the findings are relative to the
+// 2 spaces indented json representation of each collection.
+func (form *CollectionsImport) ScanSecrets() map[string][]secretscan.Finding {
+	result := map[string][]secretscan.Finding{}
+
+	for _, collection := range form.Collections {
+		if collection == nil {
+			continue
+		}
+
+		raw, err := json.MarshalIndent(collection, "", "  ")
+		if err != nil {
+			continue
+		}
+
+		if findings := secretscan.Scan(string(raw)); len(findings) > 0 {
+			result[collection.Name] = findings
+		}
+	}
+
+	return result
+}
+
+func (form *CollectionsImport) checkSecrets(value any) error {
+	policy := form.app.Settings().SecretsScan.Policy
+	if policy == "" || policy == settings.SecretsScanPolicyOff {
+		return nil
+	}
+
+	found := form.ScanSecrets()
+	if len(found) == 0 {
+		return nil
+	}
+
+	if policy == settings.SecretsScanPolicyBlock {
+		return validation.NewError(
+			"validation_secrets_detected",
+			"Possible embedded secrets detected in "+describeSecretFindings(found)+".",
+		)
+	}
+
+	// warn only
+	form.app.Logger().Warn(
+		"Possible embedded secrets detected in the imported collections",
+		slog.String("findings", describeSecretFindings(found)),
+	)
+
+	return nil
+}
+
+func describeSecretFindings(found map[string][]secretscan.Finding) string {
+	parts := make([]string, 0, len(found))
+
+	for name, findings := range found {
+		for _, f := range findings {
+			parts = append(parts, fmt.Sprintf("%s (%s, line %d)", name, f.Rule, f.Line))
+		}
+	}
+
+	sort.Strings(parts)
+
+	return strings.Join(parts, ", ")
+}
+
 // Submit applies the import, aka.:
 // - imports the form collections (create or replace)
 // - sync the collection changes with their related records table
@@ -0,0 +1,137 @@
+package forms
+
+import (
+	"regexp"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/list"
+)
+
+// mentionRegex matches the "@username" comment mentions.
+var mentionRegex = regexp.MustCompile(`(?:^|[^\w@])@(\w[\w.\-]*)`)
+
+// CommentUpsert is a [models.Comment] upsert (create/update) form.
+type CommentUpsert struct {
+	app     core.App
+	dao     *daos.Dao
+	comment *models.Comment
+
+	Message string `form:"message" json:"message"`
+	Parent  string `form:"parent" json:"parent"`
+}
+
+// NewCommentUpsert creates a new [CommentUpsert] form with initializer
+// config created from the provided [core.App] and [models.Comment] instances
+// (for create the comment is expected to have its record and author fields already set).
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewCommentUpsert(app core.App, comment *models.Comment) *CommentUpsert {
+	form := &CommentUpsert{
+		app:     app,
+		dao:     app.Dao(),
+		comment: comment,
+	}
+
+	// load defaults
+	form.Message = comment.Message
+	form.Parent = comment.ParentId
+
+	return form
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *CommentUpsert) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *CommentUpsert) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(
+			&form.Message,
+			validation.Required,
+			validation.Length(1, 5000),
+		),
+		validation.Field(
+			&form.Parent,
+			validation.When(
+				form.comment.IsNew(),
+				validation.By(form.checkParent),
+			).Else(validation.By(form.checkParentUnchanged)),
+		),
+	)
+}
+
+func (form *CommentUpsert) checkParent(value any) error {
+	v, _ := value.(string)
+	if v == "" {
+		return nil // thread root
+	}
+
+	parent, _ := form.dao.FindCommentById(v)
+	if parent == nil ||
+		parent.CollectionId != form.comment.CollectionId ||
+		parent.RecordId != form.comment.RecordId {
+		return validation.NewError("validation_invalid_comment_parent", "Missing or invalid parent comment.")
+	}
+
+	return nil
+}
+
+func (form *CommentUpsert) checkParentUnchanged(value any) error {
+	v, _ := value.(string)
+	if v != form.comment.ParentId {
+		return validation.NewError("validation_comment_parent_change", "The parent of an existing comment cannot be changed.")
+	}
+
+	return nil
+}
+
+// Submit validates the form and upserts the form comment model.
+//
+// You can optionally provide a list of InterceptorFunc to further
+// modify the form behavior before persisting it.
+func (form *CommentUpsert) Submit(interceptors ...InterceptorFunc[*models.Comment]) error {
+	if err := form.Validate(); err != nil {
+		return err
+	}
+
+	form.comment.Message = form.Message
+	form.comment.ParentId = form.Parent
+	form.comment.Mentions = form.resolveMentions()
+
+	return runInterceptors(form.comment, func(comment *models.Comment) error {
+		return form.dao.SaveComment(comment)
+	}, interceptors...)
+}
+
+// resolveMentions returns the ids of the "@username" mentioned auth
+// records from the same auth collection as the comment author.
+//
+// Unknown usernames are ignored.
+func (form *CommentUpsert) resolveMentions() []string {
+	ids := []string{}
+
+	if form.comment.AuthorCollectionId == "" {
+		return ids // admins are not part of an auth collection
+	}
+
+	usernames := []string{}
+	for _, match := range mentionRegex.FindAllStringSubmatch(form.Message, -1) {
+		usernames = append(usernames, strings.TrimRight(match[1], ".-"))
+	}
+
+	for _, username := range list.NonzeroUniques(usernames) {
+		record, _ := form.dao.FindAuthRecordByUsername(form.comment.AuthorCollectionId, username)
+		if record != nil {
+			ids = append(ids, record.Id)
+		}
+	}
+
+	return list.NonzeroUniques(ids)
+}
@@ -0,0 +1,145 @@
+package forms_test
+
+import (
+	"slices"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestCommentUpsertValidateAndSubmit(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	demo2, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newComment := func(recordId string) *models.Comment {
+		return &models.Comment{
+			CollectionId: demo2.Id,
+			RecordId:     recordId,
+			AuthorType:   models.CommentAuthorTypeAdmin,
+			AuthorId:     "sywbhecnh46rhm0",
+		}
+	}
+
+	root := newComment("llvuca81nly1qls")
+	if err := forms.NewCommentUpsert(app, root).Submit(); err == nil {
+		t.Fatal("Expected empty message error, got nil")
+	}
+
+	form := forms.NewCommentUpsert(app, root)
+	form.Message = "root"
+	if err := form.Submit(); err != nil {
+		t.Fatal(err)
+	}
+
+	// parent from another record
+	otherRecordComment := newComment("achvryl401bhse3")
+	form = forms.NewCommentUpsert(app, otherRecordComment)
+	form.Message = "reply"
+	form.Parent = root.Id
+	err = form.Submit()
+	errs, _ := err.(validation.Errors)
+	if errs["parent"] == nil {
+		t.Fatalf("Expected parent error, got %v", err)
+	}
+
+	// valid reply
+	reply := newComment("llvuca81nly1qls")
+	form = forms.NewCommentUpsert(app, reply)
+	form.Message = "reply"
+	form.Parent = root.Id
+	if err := form.Submit(); err != nil {
+		t.Fatal(err)
+	}
+	if reply.ParentId != root.Id {
+		t.Fatalf("Expected parent %q, got %q", root.Id, reply.ParentId)
+	}
+
+	// the parent of an existing comment cannot be changed
+	form = forms.NewCommentUpsert(app, reply)
+	form.Message = "updated"
+	form.Parent = ""
+	err = form.Submit()
+	errs, _ = err.(validation.Errors)
+	if errs["parent"] == nil {
+		t.Fatalf("Expected parent error on update, got %v", err)
+	}
+
+	form = forms.NewCommentUpsert(app, reply)
+	form.Message = "updated"
+	if err := form.Submit(); err != nil {
+		t.Fatal(err)
+	}
+
+	refreshed, err := app.Dao().FindCommentById(reply.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if refreshed.Message != "updated" || refreshed.ParentId != root.Id {
+		t.Fatalf("Unexpected updated comment %v", refreshed)
+	}
+}
+
+func TestCommentUpsertMentions(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		name       string
+		authorType string
+		message    string
+		expected   []string
+	}{
+		{
+			"admin author",
+			models.CommentAuthorTypeAdmin,
+			"hi @test2_username",
+			[]string{},
+		},
+		{
+			"no mentions",
+			models.CommentAuthorTypeAuthRecord,
+			"hi test2_username, email@users75657",
+			[]string{},
+		},
+		{
+			"existing, duplicated and missing mentions",
+			models.CommentAuthorTypeAuthRecord,
+			"@test2_username and @missing, @users75657. Also @test2_username!",
+			[]string{"oap640cot4yru2s", "4q1xlclmfloku33"},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			comment := &models.Comment{
+				CollectionId: "sz5l5z67tg7gku0",
+				RecordId:     "llvuca81nly1qls",
+				AuthorType:   s.authorType,
+				AuthorId:     "4q1xlclmfloku33",
+			}
+			if s.authorType == models.CommentAuthorTypeAdmin {
+				comment.AuthorId = "sywbhecnh46rhm0"
+			} else {
+				comment.AuthorCollectionId = "_pb_users_auth_"
+			}
+
+			form := forms.NewCommentUpsert(app, comment)
+			form.Message = s.message
+			if err := form.Submit(); err != nil {
+				t.Fatal(err)
+			}
+
+			if !slices.Equal(comment.Mentions, s.expected) {
+				t.Fatalf("Expected mentions %v, got %v", s.expected, comment.Mentions)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package forms
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// Registries with the custom validators of the builtin upsert forms, eg.:
+//
+//	forms.RecordUpsertValidators.Register("noFooTitle", func(form *forms.RecordUpsert) error {
+//		if form.Record().Collection().Name != "articles" {
+//			return nil
+//		}
+//
+//		if form.Data()["title"] == "foo" {
+//			return validation.Errors{
+//				"title": validation.NewError("validation_foo_title", "The title must not be foo."),
+//			}
+//		}
+//
+//		return nil
+//	})
+var (
+	RecordUpsertValidators   = NewValidatorsRegistry[*RecordUpsert]()
+	AdminUpsertValidators    = NewValidatorsRegistry[*AdminUpsert]()
+	SettingsUpsertValidators = NewValidatorsRegistry[*SettingsUpsert]()
+)
+
+// ValidatorFunc defines a custom form validator function.
+//
+// The returned [validation.Errors] are merged by field name with the
+// builtin form validation errors. Any other returned error is stored
+// under the validator name (validation.Error) or returned as it is (internal error).
+type ValidatorFunc[T any] func(form T) error
+
+// ValidatorsRegistry holds the named custom validators of a single form type.
+type ValidatorsRegistry[T any] struct {
+	mux        sync.RWMutex
+	validators map[string]ValidatorFunc[T]
+}
+
+// NewValidatorsRegistry creates a new empty ValidatorsRegistry.
+func NewValidatorsRegistry[T any]() *ValidatorsRegistry[T] {
+	return &ValidatorsRegistry[T]{
+		validators: map[string]ValidatorFunc[T]{},
+	}
+}
+
+// Register registers a new (or replaces an existing) named validator.
+func (r *ValidatorsRegistry[T]) Register(name string, fn ValidatorFunc[T]) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	r.validators[name] = fn
+}
+
+// Unregister removes the named validator (if exists).
+func (r *ValidatorsRegistry[T]) Unregister(name string) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	delete(r.validators, name)
+}
+
+// Names returns the sorted names of the registered validators.
+func (r *ValidatorsRegistry[T]) Names() []string {
+	r.mux.RLock()
+	defer r.mux.RUnlock()
+
+	names := make([]string, 0, len(r.validators))
+	for name := range r.validators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Validate runs all registered validators (sorted by name) against the
+// provided form and merges their errors with the builtin validation error.
+//
+// The custom validators are executed even if the builtin validation
+// failed so that all errors are reported in a single pass.
+// Internal (non-validation) errors are returned as they are.
+func (r *ValidatorsRegistry[T]) Validate(form T, builtinErr error) error {
+	r.mux.RLock()
+	validators := make(map[string]ValidatorFunc[T], len(r.validators))
+	for name, fn := range r.validators {
+		validators[name] = fn
+	}
+	r.mux.RUnlock()
+
+	if len(validators) == 0 {
+		return builtinErr
+	}
+
+	names := make([]string, 0, len(validators))
+	for name := range validators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := validation.Errors{}
+
+	if builtinErr != nil {
+		var builtinErrs validation.Errors
+		if !errors.As(builtinErr, &builtinErrs) {
+			return builtinErr // internal error
+		}
+
+		for k, v := range builtinErrs {
+			result[k] = v
+		}
+	}
+
+	for _, name := range names {
+		err := validators[name](form)
+		if err == nil {
+			continue
+		}
+
+		var fieldErrs validation.Errors
+		var singleErr validation.Error
+
+		switch {
+		case errors.As(err, &fieldErrs):
+			for k, v := range fieldErrs {
+				if v == nil {
+					continue
+				}
+				// the builtin and the earlier registered errors have priority
+				if _, ok := result[k]; !ok {
+					result[k] = v
+				}
+			}
+		case errors.As(err, &singleErr):
+			result[name] = singleErr
+		default:
+			return err // internal error
+		}
+	}
+
+	return result.Filter()
+}
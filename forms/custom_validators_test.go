@@ -0,0 +1,188 @@
+package forms_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestValidatorsRegistry(t *testing.T) {
+	r := forms.NewValidatorsRegistry[string]()
+
+	// no validators
+	builtinErr := validation.Errors{"a": validation.ErrRequired}
+	if err := r.Validate("test", builtinErr); err == nil || err.Error() != builtinErr.Error() {
+		t.Fatalf("Expected the builtin error to be returned as it is, got %v", err)
+	}
+
+	r.Register("v2", func(form string) error {
+		return validation.Errors{
+			"a": validation.NewError("v2_a", "v2 a"),
+			"b": validation.NewError("v2_b", "v2 b"),
+		}
+	})
+	r.Register("v1", func(form string) error {
+		return validation.Errors{
+			"b": validation.NewError("v1_b", "v1 b"),
+			"c": nil,
+		}
+	})
+	r.Register("v3", func(form string) error {
+		return validation.NewError("v3", "v3 "+form)
+	})
+	r.Register("tmp", func(form string) error {
+		return errors.New("internal")
+	})
+
+	if names := strings.Join(r.Names(), ","); names != "tmp,v1,v2,v3" {
+		t.Fatalf("Expected names tmp,v1,v2,v3, got %s", names)
+	}
+
+	// internal error
+	if err := r.Validate("test", nil); err == nil || err.Error() != "internal" {
+		t.Fatalf("Expected internal error, got %v", err)
+	}
+
+	r.Unregister("tmp")
+
+	err := r.Validate("test", builtinErr)
+
+	var errs validation.Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Expected validation.Errors, got %v", err)
+	}
+
+	expectedCodes := map[string]string{
+		"a":  "validation_required", // builtin
+		"b":  "v1_b",                // the first registered by name
+		"v3": "v3",                  // non field validation error
+	}
+
+	if len(errs) != len(expectedCodes) {
+		t.Fatalf("Expected errors %v, got %v", expectedCodes, errs)
+	}
+
+	for k, code := range expectedCodes {
+		vErr, ok := errs[k].(validation.Error)
+		if !ok || vErr.Code() != code {
+			t.Fatalf("Expected %q error with code %q, got %v", k, code, errs[k])
+		}
+	}
+
+	// non validation builtin error
+	internalErr := errors.New("builtin_internal")
+	if err := r.Validate("test", internalErr); err != internalErr {
+		t.Fatalf("Expected the builtin internal error, got %v", err)
+	}
+}
+
+func TestRecordUpsertCustomValidators(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	forms.RecordUpsertValidators.Register("testNoForbiddenTitle", func(form *forms.RecordUpsert) error {
+		if form.Record().Collection().Name == "demo2" && form.Data()["title"] == "forbidden" {
+			return validation.Errors{
+				"title": validation.NewError("validation_forbidden_title", "Forbidden title."),
+			}
+		}
+		return nil
+	})
+	defer forms.RecordUpsertValidators.Unregister("testNoForbiddenTitle")
+
+	collection, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		title       string
+		expectError bool
+	}{
+		{"allowed", false},
+		{"forbidden", true},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.title, func(t *testing.T) {
+			form := forms.NewRecordUpsert(app, models.NewRecord(collection))
+			form.LoadData(map[string]any{"title": s.title})
+
+			err := form.Submit()
+
+			hasErr := err != nil && strings.Contains(err.Error(), "Forbidden title")
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+		})
+	}
+}
+
+func TestAdminUpsertCustomValidators(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	forms.AdminUpsertValidators.Register("testAvatar", func(form *forms.AdminUpsert) error {
+		if form.Avatar == 1 {
+			return validation.Errors{
+				"avatar": validation.NewError("validation_avatar", "Avatar 1 is not allowed."),
+			}
+		}
+		return nil
+	})
+	defer forms.AdminUpsertValidators.Unregister("testAvatar")
+
+	form := forms.NewAdminUpsert(app, &models.Admin{})
+	form.Avatar = 1
+
+	err := form.Validate()
+
+	var errs validation.Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Expected validation.Errors, got %v", err)
+	}
+
+	// builtin + custom errors
+	for _, k := range []string{"email", "password", "avatar"} {
+		if _, ok := errs[k]; !ok {
+			t.Errorf("Missing expected %q error in %v", k, errs)
+		}
+	}
+}
+
+func TestSettingsUpsertCustomValidators(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	forms.SettingsUpsertValidators.Register("testAppName", func(form *forms.SettingsUpsert) error {
+		if form.Meta.AppName == "forbidden" {
+			return validation.NewError("validation_forbidden_app_name", "Forbidden app name.")
+		}
+		return nil
+	})
+	defer forms.SettingsUpsertValidators.Unregister("testAppName")
+
+	form := forms.NewSettingsUpsert(app)
+
+	if err := form.Validate(); err != nil {
+		t.Fatalf("Expected no errors, got %v", err)
+	}
+
+	form.Meta.AppName = "forbidden"
+
+	err := form.Validate()
+
+	var errs validation.Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Expected validation.Errors, got %v", err)
+	}
+
+	if _, ok := errs["testAppName"]; !ok || len(errs) != 1 {
+		t.Fatalf("Expected only testAppName error, got %v", errs)
+	}
+}
@@ -0,0 +1,82 @@
+package forms
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+const (
+	// mfaTOTPSkew is the number of the accepted TOTP time steps
+	// before and after the current one (to allow small clock drifts).
+	mfaTOTPSkew = 1
+
+	// mfaRecoveryCodesCount is the number of the generated recovery codes.
+	mfaRecoveryCodesCount = 10
+
+	mfaRecoveryCodeAlphabet = "abcdefghijkmnpqrstuvwxyz23456789"
+)
+
+// ErrMfaSetupRequired is returned on password authentication when the
+// auth collection requires MFA but the auth record has no enabled factor.
+var ErrMfaSetupRequired = errors.New("MFA setup is required.")
+
+var errInvalidMfaCode = validation.NewError("validation_invalid_mfa_code", "Invalid or expired MFA code.")
+
+// verifyMfaCode checks whether the provided code is a valid TOTP or
+// unused recovery code of the provided factor.
+//
+// On success the factor is updated to prevent the same code reuse.
+func verifyMfaCode(dao *daos.Dao, factor *models.MfaFactor, code string) (bool, error) {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return false, nil
+	}
+
+	step := security.ValidateTOTPCode(factor.Secret, code, time.Now(), mfaTOTPSkew)
+	if step > factor.LastUsedStep {
+		factor.LastUsedStep = step
+		return true, dao.SaveMfaFactor(factor)
+	}
+
+	hash := security.SHA256(normalizeMfaRecoveryCode(code))
+	for i, h := range factor.RecoveryCodes {
+		if security.Equal(h, hash) {
+			factor.RecoveryCodes = append(factor.RecoveryCodes[:i:i], factor.RecoveryCodes[i+1:]...)
+			return true, dao.SaveMfaFactor(factor)
+		}
+	}
+
+	return false, nil
+}
+
+// newMfaRecoveryCodes generates a new set of MFA recovery codes.
+//
+// Returns the plain codes (to be shown once to the user)
+// and their sha256 hashes (to be stored in the factor).
+func newMfaRecoveryCodes() ([]string, types.JsonArray[string]) {
+	codes := make([]string, mfaRecoveryCodesCount)
+	hashes := make(types.JsonArray[string], mfaRecoveryCodesCount)
+
+	for i := range codes {
+		code := security.RandomStringWithAlphabet(10, mfaRecoveryCodeAlphabet)
+		codes[i] = code[:5] + "-" + code[5:]
+		hashes[i] = security.SHA256(code)
+	}
+
+	return codes, hashes
+}
+
+// normalizeMfaRecoveryCode removes the recovery code separators
+// and lowercases the result.
+func normalizeMfaRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	code = strings.ReplaceAll(code, "-", "")
+	return strings.ReplaceAll(code, " ", "")
+}
@@ -0,0 +1,166 @@
+package forms
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/url"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tokens"
+	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/pocketbase/pocketbase/tools/webauthn"
+	"github.com/spf13/cast"
+)
+
+var errPasskeyAuthDisabled = errors.New("The collection is not configured to allow passkeys.")
+
+var errInvalidPasskeyChallenge = validation.NewError("validation_invalid_passkey_challenge", "Invalid or expired passkey challenge.")
+
+var errInvalidPasskeyCredential = validation.NewError("validation_invalid_passkey_credential", "Invalid passkey credential.")
+
+// PasskeyConfig returns the WebAuthn relying party config of the provided
+// auth collection (fallbacks to the app url when not explicitly set).
+func PasskeyConfig(app core.App, collection *models.Collection) webauthn.Config {
+	options := collection.AuthOptions()
+
+	config := webauthn.Config{
+		RPId:    options.PasskeyRPId,
+		Origins: options.PasskeyOrigins,
+	}
+
+	if appUrl, err := url.Parse(app.Settings().Meta.AppUrl); err == nil {
+		if config.RPId == "" {
+			config.RPId = appUrl.Hostname()
+		}
+
+		if len(config.Origins) == 0 {
+			config.Origins = []string{appUrl.Scheme + "://" + appUrl.Host}
+		}
+	}
+
+	return config
+}
+
+// PasskeyAttestation defines the relevant fields of the serialized
+// "navigator.credentials.create()" PublicKeyCredential response.
+type PasskeyAttestation struct {
+	Id       string                     `form:"id" json:"id"`
+	Response PasskeyAttestationResponse `form:"response" json:"response"`
+}
+
+// Validate makes the struct validatable by implementing [validation.Validatable] interface.
+func (a PasskeyAttestation) Validate() error {
+	return validation.ValidateStruct(&a,
+		validation.Field(&a.Id, validation.Required, validation.By(checkBase64)),
+		validation.Field(&a.Response),
+	)
+}
+
+// PasskeyAttestationResponse defines the base64url encoded
+// AuthenticatorAttestationResponse fields.
+type PasskeyAttestationResponse struct {
+	ClientDataJSON    string `form:"clientDataJSON" json:"clientDataJSON"`
+	AttestationObject string `form:"attestationObject" json:"attestationObject"`
+}
+
+// Validate makes the struct validatable by implementing [validation.Validatable] interface.
+func (r PasskeyAttestationResponse) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.ClientDataJSON, validation.Required, validation.By(checkBase64)),
+		validation.Field(&r.AttestationObject, validation.Required, validation.By(checkBase64)),
+	)
+}
+
+// PasskeyAssertion defines the relevant fields of the serialized
+// "navigator.credentials.get()" PublicKeyCredential response.
+type PasskeyAssertion struct {
+	Id       string                   `form:"id" json:"id"`
+	Response PasskeyAssertionResponse `form:"response" json:"response"`
+}
+
+// Validate makes the struct validatable by implementing [validation.Validatable] interface.
+func (a PasskeyAssertion) Validate() error {
+	return validation.ValidateStruct(&a,
+		validation.Field(&a.Id, validation.Required, validation.By(checkBase64)),
+		validation.Field(&a.Response),
+	)
+}
+
+// PasskeyAssertionResponse defines the base64url encoded
+// AuthenticatorAssertionResponse fields.
+type PasskeyAssertionResponse struct {
+	ClientDataJSON    string `form:"clientDataJSON" json:"clientDataJSON"`
+	AuthenticatorData string `form:"authenticatorData" json:"authenticatorData"`
+	Signature         string `form:"signature" json:"signature"`
+	UserHandle        string `form:"userHandle" json:"userHandle"`
+}
+
+// Validate makes the struct validatable by implementing [validation.Validatable] interface.
+func (r PasskeyAssertionResponse) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.ClientDataJSON, validation.Required, validation.By(checkBase64)),
+		validation.Field(&r.AuthenticatorData, validation.Required, validation.By(checkBase64)),
+		validation.Field(&r.Signature, validation.Required, validation.By(checkBase64)),
+		validation.Field(&r.UserHandle, validation.By(checkBase64)),
+	)
+}
+
+func checkBase64(value any) error {
+	v, _ := value.(string)
+	if v == "" {
+		return nil // nothing to check
+	}
+
+	if _, err := webauthn.DecodeBase64(v); err != nil {
+		return validation.NewError("validation_invalid_base64", "Must be a valid base64url encoded string.")
+	}
+
+	return nil
+}
+
+// normalizePasskeyCredentialId returns the unpadded
+// base64url representation of the provided credential id.
+func normalizePasskeyCredentialId(id string) string {
+	raw, err := webauthn.DecodeBase64(id)
+	if err != nil {
+		return id
+	}
+
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// passkeyChallengeFromToken verifies the provided passkey challenge token
+// (see [tokens.NewPasskeyChallengeToken]) and returns its challenge.
+//
+// The record argument is expected to be set only for the registration tokens.
+func passkeyChallengeFromToken(
+	app core.App,
+	collection *models.Collection,
+	record *models.Record,
+	token string,
+) (string, error) {
+	claims, err := security.ParseJWT(token, collection.Id+app.Settings().RecordAuthToken.Secret)
+	if err != nil {
+		return "", errInvalidPasskeyChallenge
+	}
+
+	var expectedRecordId string
+	if record != nil {
+		expectedRecordId = record.Id
+	}
+
+	if cast.ToString(claims["type"]) != tokens.TypePasskeyChallenge ||
+		cast.ToString(claims["collectionId"]) != collection.Id ||
+		cast.ToString(claims["recordId"]) != expectedRecordId {
+		return "", errInvalidPasskeyChallenge
+	}
+
+	challenge := cast.ToString(claims["challenge"])
+	if challenge == "" {
+		return "", errInvalidPasskeyChallenge
+	}
+
+	return challenge, nil
+}
@@ -0,0 +1,126 @@
+package forms
+
+import (
+	"errors"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// Device authorization grant token exchange errors
+// (see https://datatracker.ietf.org/doc/html/rfc8628#section-3.5).
+var (
+	ErrDeviceAuthPending  = errors.New("The device authorization request is still pending.")
+	ErrDeviceAuthSlowDown = errors.New("The device authorization request is polled too frequently.")
+	ErrDeviceAuthExpired  = errors.New("The device code has expired.")
+	ErrDeviceAuthDenied   = errors.New("The device authorization request was denied.")
+)
+
+// RecordDeviceLogin is an auth record OAuth2 device authorization grant
+// token exchange (aka. polling) form.
+type RecordDeviceLogin struct {
+	app        core.App
+	dao        *daos.Dao
+	collection *models.Collection
+
+	DeviceCode string `form:"deviceCode" json:"deviceCode"`
+}
+
+// NewRecordDeviceLogin creates a new [RecordDeviceLogin] form initialized
+// with from the provided [core.App] and [models.Collection] instance.
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewRecordDeviceLogin(app core.App, collection *models.Collection) *RecordDeviceLogin {
+	return &RecordDeviceLogin{
+		app:        app,
+		dao:        app.Dao(),
+		collection: collection,
+	}
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *RecordDeviceLogin) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *RecordDeviceLogin) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(&form.DeviceCode, validation.Required, validation.Length(1, 255)),
+	)
+}
+
+// Submit validates and submits the form.
+//
+// While the device code is not approved yet, Submit returns
+// [ErrDeviceAuthPending] (or [ErrDeviceAuthSlowDown] if the form is
+// submitted more often than [models.DeviceCodePollInterval]).
+// Expired and denied device codes are deleted and result in
+// [ErrDeviceAuthExpired] and [ErrDeviceAuthDenied] respectively.
+//
+// The approved device code could be exchanged only once.
+//
+// You can optionally provide a list of InterceptorFunc to
+// further modify the form behavior before persisting it.
+//
+// On success returns the auth record that approved the device code.
+func (form *RecordDeviceLogin) Submit(interceptors ...InterceptorFunc[*models.Record]) (*models.Record, error) {
+	if err := form.Validate(); err != nil {
+		return nil, err
+	}
+
+	if !form.collection.AuthOptions().AllowDeviceAuth {
+		return nil, errors.New("The collection is not configured to allow device authorization.")
+	}
+
+	deviceCode, err := form.dao.FindDeviceCodeByCode(form.collection.Id, form.DeviceCode)
+	if err != nil {
+		return nil, ErrDeviceAuthExpired
+	}
+
+	if deviceCode.HasExpired() {
+		form.dao.DeleteDeviceCode(deviceCode)
+		return nil, ErrDeviceAuthExpired
+	}
+
+	switch deviceCode.Status {
+	case models.DeviceCodeStatusDenied:
+		form.dao.DeleteDeviceCode(deviceCode)
+		return nil, ErrDeviceAuthDenied
+	case models.DeviceCodeStatusApproved:
+		// continue with the token exchange
+	default:
+		pollErr := ErrDeviceAuthPending
+		if deviceCode.IsPollTooFrequent() {
+			pollErr = ErrDeviceAuthSlowDown
+		}
+
+		deviceCode.LastPolledAt = types.NowDateTime()
+		if err := form.dao.SaveDeviceCode(deviceCode); err != nil {
+			return nil, err
+		}
+
+		return nil, pollErr
+	}
+
+	authRecord, err := form.dao.FindRecordById(form.collection.Id, deviceCode.RecordId)
+	if err != nil {
+		form.dao.DeleteDeviceCode(deviceCode)
+		return nil, ErrDeviceAuthExpired
+	}
+
+	interceptorsErr := runInterceptors(authRecord, func(m *models.Record) error {
+		authRecord = m
+		return form.dao.DeleteDeviceCode(deviceCode)
+	}, interceptors...)
+
+	if interceptorsErr != nil {
+		return nil, interceptorsErr
+	}
+
+	return authRecord, nil
+}
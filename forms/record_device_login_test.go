@@ -0,0 +1,150 @@
+package forms_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestRecordDeviceLoginSubmit(t *testing.T) {
+	scenarios := []struct {
+		name         string
+		allowDevice  bool
+		status       string
+		recordId     string
+		createdAgo   time.Duration
+		lastPolled   time.Duration // 0 for never polled
+		code         string
+		expectError  error // nil for no error, forms.ErrDevice* or any other error
+		expectDelete bool
+	}{
+		{
+			name:        "empty device code",
+			allowDevice: true,
+			status:      models.DeviceCodeStatusPending,
+			expectError: errors.New("validation"),
+		},
+		{
+			name:        "disabled device auth",
+			status:      models.DeviceCodeStatusApproved,
+			recordId:    "4q1xlclmfloku33",
+			code:        "test_code",
+			expectError: errors.New("disabled"),
+		},
+		{
+			name:        "missing device code",
+			allowDevice: true,
+			status:      models.DeviceCodeStatusPending,
+			code:        "missing",
+			expectError: forms.ErrDeviceAuthExpired,
+		},
+		{
+			name:        "pending device code",
+			allowDevice: true,
+			status:      models.DeviceCodeStatusPending,
+			code:        "test_code",
+			expectError: forms.ErrDeviceAuthPending,
+		},
+		{
+			name:        "pending device code polled too frequently",
+			allowDevice: true,
+			status:      models.DeviceCodeStatusPending,
+			lastPolled:  time.Second,
+			code:        "test_code",
+			expectError: forms.ErrDeviceAuthSlowDown,
+		},
+		{
+			name:         "expired device code",
+			allowDevice:  true,
+			status:       models.DeviceCodeStatusApproved,
+			recordId:     "4q1xlclmfloku33",
+			createdAgo:   time.Hour,
+			code:         "test_code",
+			expectError:  forms.ErrDeviceAuthExpired,
+			expectDelete: true,
+		},
+		{
+			name:         "denied device code",
+			allowDevice:  true,
+			status:       models.DeviceCodeStatusDenied,
+			recordId:     "4q1xlclmfloku33",
+			code:         "test_code",
+			expectError:  forms.ErrDeviceAuthDenied,
+			expectDelete: true,
+		},
+		{
+			name:         "approved device code",
+			allowDevice:  true,
+			status:       models.DeviceCodeStatusApproved,
+			recordId:     "4q1xlclmfloku33",
+			code:         "test_code",
+			expectDelete: true,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			testApp, _ := tests.NewTestApp()
+			defer testApp.Cleanup()
+
+			collection, err := testApp.Dao().FindCollectionByNameOrId("users")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			options := collection.AuthOptions()
+			options.AllowDeviceAuth = s.allowDevice
+			collection.SetOptions(options)
+
+			deviceCode := &models.DeviceCode{
+				CollectionId: collection.Id,
+				RecordId:     s.recordId,
+				UserCode:     "BCDFGHJK",
+				Status:       s.status,
+			}
+			deviceCode.SetCode("test_code")
+			deviceCode.Created, _ = types.ParseDateTime(time.Now().Add(-s.createdAgo))
+			if s.lastPolled > 0 {
+				deviceCode.LastPolledAt, _ = types.ParseDateTime(time.Now().Add(-s.lastPolled))
+			}
+			if err := testApp.Dao().SaveDeviceCode(deviceCode); err != nil {
+				t.Fatal(err)
+			}
+
+			form := forms.NewRecordDeviceLogin(testApp, collection)
+			form.DeviceCode = s.code
+
+			record, err := form.Submit()
+
+			hasErr := err != nil
+			if hasErr != (s.expectError != nil) {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError != nil, hasErr, err)
+			}
+
+			switch s.expectError {
+			case forms.ErrDeviceAuthPending, forms.ErrDeviceAuthSlowDown, forms.ErrDeviceAuthExpired, forms.ErrDeviceAuthDenied:
+				if !errors.Is(err, s.expectError) {
+					t.Fatalf("Expected error %v, got %v", s.expectError, err)
+				}
+			}
+
+			_, findErr := testApp.Dao().FindDeviceCodeByCode(collection.Id, "test_code")
+			if isDeleted := findErr != nil; isDeleted != s.expectDelete {
+				t.Fatalf("Expected the device code to be deleted %v, got %v", s.expectDelete, isDeleted)
+			}
+
+			if hasErr {
+				return
+			}
+
+			if record.Id != s.recordId {
+				t.Fatalf("Expected record %q, got %q", s.recordId, record.Id)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package forms
+
+import (
+	"errors"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// RecordDeviceVerify is an auth record form for approving
+// or denying a pending OAuth2 device authorization request.
+type RecordDeviceVerify struct {
+	app    core.App
+	dao    *daos.Dao
+	record *models.Record
+
+	// UserCode is the code displayed on the device (eg. "BCDF-GHJK").
+	UserCode string `form:"userCode" json:"userCode"`
+
+	// Deny rejects the device authorization request instead of approving it.
+	Deny bool `form:"deny" json:"deny"`
+}
+
+// NewRecordDeviceVerify creates a new [RecordDeviceVerify] form
+// initialized with from the provided [core.App] and [models.Record] instances.
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewRecordDeviceVerify(app core.App, record *models.Record) *RecordDeviceVerify {
+	return &RecordDeviceVerify{
+		app:    app,
+		dao:    app.Dao(),
+		record: record,
+	}
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *RecordDeviceVerify) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *RecordDeviceVerify) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(&form.UserCode, validation.Required, validation.Length(1, 50)),
+	)
+}
+
+// Submit validates and submits the form.
+//
+// On success the device code is marked as approved (or denied)
+// by the form auth record.
+//
+// You can optionally provide a list of InterceptorFunc to
+// further modify the form behavior before persisting it.
+func (form *RecordDeviceVerify) Submit(interceptors ...InterceptorFunc[*models.DeviceCode]) (*models.DeviceCode, error) {
+	if err := form.Validate(); err != nil {
+		return nil, err
+	}
+
+	collection := form.record.Collection()
+
+	if !collection.AuthOptions().AllowDeviceAuth {
+		return nil, errors.New("The collection is not configured to allow device authorization.")
+	}
+
+	deviceCode, err := form.dao.FindDeviceCodeByUserCode(collection.Id, form.UserCode)
+	if err != nil || deviceCode.Status != models.DeviceCodeStatusPending || deviceCode.HasExpired() {
+		return nil, validation.Errors{"userCode": validation.NewError(
+			"validation_invalid_user_code",
+			"Invalid or expired user code.",
+		)}
+	}
+
+	deviceCode.RecordId = form.record.Id
+	if form.Deny {
+		deviceCode.Status = models.DeviceCodeStatusDenied
+	} else {
+		deviceCode.Status = models.DeviceCodeStatusApproved
+	}
+
+	interceptorsErr := runInterceptors(deviceCode, func(m *models.DeviceCode) error {
+		deviceCode = m
+		return form.dao.SaveDeviceCode(m)
+	}, interceptors...)
+
+	if interceptorsErr != nil {
+		return nil, interceptorsErr
+	}
+
+	return deviceCode, nil
+}
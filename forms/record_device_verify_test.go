@@ -0,0 +1,145 @@
+package forms_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestRecordDeviceVerifySubmit(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		allowDevice    bool
+		status         string
+		createdAgo     time.Duration
+		userCode       string
+		deny           bool
+		expectError    bool
+		expectedStatus string
+	}{
+		{
+			name:        "empty user code",
+			allowDevice: true,
+			status:      models.DeviceCodeStatusPending,
+			expectError: true,
+		},
+		{
+			name:        "disabled device auth",
+			status:      models.DeviceCodeStatusPending,
+			userCode:    "BCDF-GHJK",
+			expectError: true,
+		},
+		{
+			name:        "missing user code",
+			allowDevice: true,
+			status:      models.DeviceCodeStatusPending,
+			userCode:    "LMNP-QRST",
+			expectError: true,
+		},
+		{
+			name:        "already approved device code",
+			allowDevice: true,
+			status:      models.DeviceCodeStatusApproved,
+			userCode:    "BCDF-GHJK",
+			expectError: true,
+		},
+		{
+			name:        "expired device code",
+			allowDevice: true,
+			status:      models.DeviceCodeStatusPending,
+			createdAgo:  time.Hour,
+			userCode:    "BCDF-GHJK",
+			expectError: true,
+		},
+		{
+			name:           "approve",
+			allowDevice:    true,
+			status:         models.DeviceCodeStatusPending,
+			userCode:       "bcdf-ghjk",
+			expectedStatus: models.DeviceCodeStatusApproved,
+		},
+		{
+			name:           "deny",
+			allowDevice:    true,
+			status:         models.DeviceCodeStatusPending,
+			userCode:       "BCDFGHJK",
+			deny:           true,
+			expectedStatus: models.DeviceCodeStatusDenied,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			testApp, _ := tests.NewTestApp()
+			defer testApp.Cleanup()
+
+			record, err := testApp.Dao().FindRecordById("users", "4q1xlclmfloku33")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			options := record.Collection().AuthOptions()
+			options.AllowDeviceAuth = s.allowDevice
+			record.Collection().SetOptions(options)
+
+			deviceCode := &models.DeviceCode{
+				CollectionId: record.Collection().Id,
+				UserCode:     "BCDFGHJK",
+				Status:       s.status,
+			}
+			deviceCode.SetCode("test_code")
+			deviceCode.Created, _ = types.ParseDateTime(time.Now().Add(-s.createdAgo))
+			if err := testApp.Dao().SaveDeviceCode(deviceCode); err != nil {
+				t.Fatal(err)
+			}
+
+			form := forms.NewRecordDeviceVerify(testApp, record)
+			form.UserCode = s.userCode
+			form.Deny = s.deny
+
+			interceptorCalls := 0
+			interceptor := func(next forms.InterceptorNextFunc[*models.DeviceCode]) forms.InterceptorNextFunc[*models.DeviceCode] {
+				return func(m *models.DeviceCode) error {
+					interceptorCalls++
+					return next(m)
+				}
+			}
+
+			result, err := form.Submit(interceptor)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			expectInterceptorCalls := 1
+			if s.expectError {
+				expectInterceptorCalls = 0
+			}
+			if interceptorCalls != expectInterceptorCalls {
+				t.Fatalf("Expected interceptor to be called %d, got %d", expectInterceptorCalls, interceptorCalls)
+			}
+
+			if hasErr {
+				return
+			}
+
+			saved, err := testApp.Dao().FindDeviceCodeByCode(result.CollectionId, "test_code")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if saved.Status != s.expectedStatus {
+				t.Fatalf("Expected status %q, got %q", s.expectedStatus, saved.Status)
+			}
+
+			if saved.RecordId != record.Id {
+				t.Fatalf("Expected record id %q, got %q", record.Id, saved.RecordId)
+			}
+		})
+	}
+}
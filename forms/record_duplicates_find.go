@@ -0,0 +1,125 @@
+package forms
+
+import (
+	"strconv"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/resolvers"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+// RecordDuplicatesFind is a form that finds the probable
+// duplicate records of a single collection (aka. the review list).
+type RecordDuplicatesFind struct {
+	app        core.App
+	dao        *daos.Dao
+	collection *models.Collection
+
+	Rules  []daos.RecordDuplicatesRule `form:"rules" json:"rules"`
+	Filter string                      `form:"filter" json:"filter"`
+	Limit  int                         `form:"limit" json:"limit"`
+}
+
+// NewRecordDuplicatesFind creates a new [RecordDuplicatesFind] form
+// initialized with from the provided [core.App] instance and collection.
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewRecordDuplicatesFind(app core.App, collection *models.Collection) *RecordDuplicatesFind {
+	return &RecordDuplicatesFind{
+		app:        app,
+		dao:        app.Dao(),
+		collection: collection,
+	}
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *RecordDuplicatesFind) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *RecordDuplicatesFind) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(
+			&form.Rules,
+			validation.Required,
+			validation.Length(1, 10),
+			validation.By(form.checkRules),
+		),
+		validation.Field(&form.Filter, validation.Length(0, 3500)),
+		validation.Field(&form.Limit, validation.Min(0), validation.Max(search.MaxPerPage)),
+	)
+}
+
+func (form *RecordDuplicatesFind) checkRules(value any) error {
+	rules, _ := value.([]daos.RecordDuplicatesRule)
+
+	for i, rule := range rules {
+		err := validation.ValidateStruct(&rule,
+			validation.Field(
+				&rule.Field,
+				validation.Required,
+				validation.By(func(value any) error {
+					v, _ := value.(string)
+					if v != "" && !daos.IsDuplicatesField(form.collection, v) {
+						return validation.NewError(
+							"validation_invalid_duplicates_field",
+							"The field must be an existing non-encrypted plain value field.",
+						)
+					}
+					return nil
+				}),
+			),
+			validation.Field(
+				&rule.Match,
+				validation.Required,
+				validation.In(
+					daos.DuplicatesMatchExact,
+					daos.DuplicatesMatchTrigram,
+					daos.DuplicatesMatchEmail,
+					daos.DuplicatesMatchPhone,
+				),
+			),
+			validation.Field(&rule.Threshold, validation.Min(0.0), validation.Max(1.0)),
+		)
+		if err != nil {
+			return validation.Errors{strconv.Itoa(i): err}
+		}
+	}
+
+	return nil
+}
+
+// Submit validates the form and returns the found duplicate record
+// pairs ordered by their similarity score.
+//
+// The form filter is applied on the compared records.
+func (form *RecordDuplicatesFind) Submit() ([]*daos.RecordDuplicatesItem, error) {
+	if err := form.Validate(); err != nil {
+		return nil, err
+	}
+
+	var filterFunc func(q *dbx.SelectQuery) error
+	if form.Filter != "" {
+		filterFunc = func(q *dbx.SelectQuery) error {
+			resolver := resolvers.NewRecordFieldResolver(form.dao, form.collection, nil, true)
+
+			expr, err := search.FilterData(form.Filter).BuildExpr(resolver)
+			if err != nil {
+				return err
+			}
+
+			resolver.UpdateQuery(q)
+			q.AndWhere(expr)
+
+			return nil
+		}
+	}
+
+	return form.dao.FindRecordDuplicates(form.collection, form.Rules, form.Limit, filterFunc)
+}
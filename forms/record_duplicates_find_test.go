@@ -0,0 +1,102 @@
+package forms_test
+
+import (
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestRecordDuplicatesFindValidate(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection, err := app.Dao().FindCollectionByNameOrId("users")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		name           string
+		rules          []daos.RecordDuplicatesRule
+		limit          int
+		expectedErrors []string
+	}{
+		{"no rules", nil, 0, []string{"rules"}},
+		{"missing rule field", []daos.RecordDuplicatesRule{{Match: daos.DuplicatesMatchExact}}, 0, []string{"rules"}},
+		{"unknown rule field", []daos.RecordDuplicatesRule{{Field: "missing", Match: daos.DuplicatesMatchExact}}, 0, []string{"rules"}},
+		{"non plain rule field", []daos.RecordDuplicatesRule{{Field: "avatar", Match: daos.DuplicatesMatchExact}}, 0, []string{"rules"}},
+		{"invalid rule match", []daos.RecordDuplicatesRule{{Field: "name", Match: "missing"}}, 0, []string{"rules"}},
+		{"invalid rule threshold", []daos.RecordDuplicatesRule{{Field: "name", Match: daos.DuplicatesMatchTrigram, Threshold: 2}}, 0, []string{"rules"}},
+		{"invalid limit", []daos.RecordDuplicatesRule{{Field: "name", Match: daos.DuplicatesMatchTrigram}}, -1, []string{"limit"}},
+		{
+			"valid",
+			[]daos.RecordDuplicatesRule{
+				{Field: "email", Match: daos.DuplicatesMatchEmail},
+				{Field: "name", Match: daos.DuplicatesMatchTrigram, Threshold: 0.7},
+			},
+			10,
+			nil,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			form := forms.NewRecordDuplicatesFind(app, collection)
+			form.Rules = s.rules
+			form.Limit = s.limit
+
+			err := form.Validate()
+
+			errs, _ := err.(validation.Errors)
+			if len(errs) != len(s.expectedErrors) {
+				t.Fatalf("Expected errors %v, got %v", s.expectedErrors, errs)
+			}
+			for _, k := range s.expectedErrors {
+				if _, ok := errs[k]; !ok {
+					t.Fatalf("Missing expected %q error in %v", k, errs)
+				}
+			}
+		})
+	}
+}
+
+func TestRecordDuplicatesFindSubmit(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	form := forms.NewRecordDuplicatesFind(app, collection)
+	form.Rules = []daos.RecordDuplicatesRule{{Field: "title", Match: daos.DuplicatesMatchTrigram}}
+
+	// "test1" and "test2" share 4 out of 8 unique trigrams
+	items, err := form.Submit()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 3 {
+		t.Fatalf("Expected 3 duplicate pairs, got %d", len(items))
+	}
+
+	// with filter
+	form.Filter = "active = true"
+	items, err = form.Submit()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 1 || items[0].RecordIds[0] == "llvuca81nly1qls" || items[0].RecordIds[1] == "llvuca81nly1qls" {
+		t.Fatalf("Expected 1 duplicate pair of the active records, got %v", items)
+	}
+
+	// invalid filter
+	form.Filter = "missing = true"
+	if _, err := form.Submit(); err == nil {
+		t.Fatal("Expected invalid filter error, got nil")
+	}
+}
@@ -103,6 +103,7 @@ func (form *RecordEmailChangeConfirm) parseToken(token string) (*models.Record,
 	authRecord, err := form.dao.FindAuthRecordByToken(
 		token,
 		form.app.Settings().RecordEmailChangeToken.Secret,
+		form.app.Settings().RecordEmailChangeToken.PreviousSecrets()...,
 	)
 	if err != nil || authRecord == nil {
 		return nil, "", validation.NewError("validation_invalid_token", "Invalid or expired token.")
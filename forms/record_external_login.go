@@ -0,0 +1,148 @@
+package forms
+
+import (
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+// externalIdentity defines an authenticated identity of an
+// external (non OAuth2) provider, eg. a directory entry or a SAML assertion.
+type externalIdentity struct {
+	provider   string
+	providerId string
+	email      string
+	username   string
+
+	// fieldsData holds the mapped auth record schema fields data.
+	fieldsData map[string]any
+}
+
+// mapExternalFields resolves the provided fields mapping (field -> attribute)
+// into auth record schema fields data.
+//
+// The system auth fields are not allowed to be mapped.
+func mapExternalFields(collection *models.Collection, mapping map[string]string, attribute func(name string) (string, bool)) map[string]any {
+	data := map[string]any{}
+
+	for field, attr := range mapping {
+		if collection.Schema.GetFieldByName(field) == nil {
+			continue
+		}
+		if value, ok := attribute(attr); ok {
+			data[field] = value
+		}
+	}
+
+	return data
+}
+
+// findExternalIdentityRecord returns the auth record linked to the provided
+// identity or the auth record with the identity email (if any).
+func findExternalIdentityRecord(dao *daos.Dao, collection *models.Collection, identity *externalIdentity) (*models.Record, *models.ExternalAuth, error) {
+	rel, _ := dao.FindFirstExternalAuthByExpr(dbx.HashExp{
+		"collectionId": collection.Id,
+		"provider":     identity.provider,
+		"providerId":   identity.providerId,
+	})
+
+	if rel != nil {
+		record, err := dao.FindRecordById(collection.Id, rel.RecordId)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return record, rel, nil
+	}
+
+	if identity.email != "" {
+		record, _ := dao.FindAuthRecordByEmail(collection.Id, identity.email)
+
+		return record, nil, nil
+	}
+
+	return nil, nil, nil
+}
+
+// saveExternalIdentityRecord creates a new verified auth record for the
+// provided identity (or syncs the mapped fields of the existing one)
+// and links it with the identity if the relation is missing.
+//
+// The external provider is considered the source of truth for the identity email.
+func saveExternalIdentityRecord(
+	app core.App,
+	dao *daos.Dao,
+	collection *models.Collection,
+	identity *externalIdentity,
+	record *models.Record,
+	rel *models.ExternalAuth,
+) (*models.Record, *models.ExternalAuth, error) {
+	err := dao.RunInTransaction(func(txDao *daos.Dao) error {
+		if record == nil {
+			record = models.NewRecord(collection)
+			record.RefreshId()
+			record.MarkAsNew()
+			createForm := NewRecordUpsert(app, record)
+			createForm.SetFullManageAccess(true)
+			createForm.SetDao(txDao)
+
+			username := identity.username
+			if len(username) >= 3 && len(username) <= 150 && usernameRegex.MatchString(username) {
+				createForm.Username = txDao.SuggestUniqueAuthRecordUsername(collection.Id, username)
+			}
+
+			createForm.LoadData(identity.fieldsData)
+			createForm.Email = identity.email
+			createForm.Verified = identity.email != ""
+			createForm.Password = security.RandomString(30)
+			createForm.PasswordConfirm = createForm.Password
+
+			if err := createForm.Submit(); err != nil {
+				return err
+			}
+		} else {
+			changed := len(identity.fieldsData) > 0
+
+			// sync the mapped fields with the external identity
+			for field, value := range identity.fieldsData {
+				record.Set(field, value)
+			}
+
+			// update the existing auth record verified state
+			// (only if its email match with the identity one)
+			if !record.Verified() && identity.email != "" && record.Email() == identity.email {
+				record.SetVerified(true)
+				changed = true
+			}
+
+			if changed {
+				if err := txDao.SaveRecord(record); err != nil {
+					return err
+				}
+			}
+		}
+
+		// create ExternalAuth relation if missing
+		if rel == nil {
+			rel = &models.ExternalAuth{
+				CollectionId: record.Collection().Id,
+				RecordId:     record.Id,
+				Provider:     identity.provider,
+				ProviderId:   identity.providerId,
+			}
+			if err := txDao.SaveExternalAuth(rel); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return record, rel, nil
+}
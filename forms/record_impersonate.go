@@ -0,0 +1,69 @@
+package forms
+
+import (
+	"errors"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tokens"
+)
+
+// RecordImpersonate is an admin form for issuing
+// an impersonation auth token for an auth record.
+type RecordImpersonate struct {
+	app    core.App
+	admin  *models.Admin
+	record *models.Record
+
+	// Duration is the token lifetime in seconds
+	// (default to tokens.DefaultImpersonateTokenDuration).
+	Duration int64 `form:"duration" json:"duration"`
+}
+
+// NewRecordImpersonate creates a new [RecordImpersonate] form initialized
+// with from the provided [core.App], [models.Admin] and [models.Record] instances.
+func NewRecordImpersonate(app core.App, admin *models.Admin, record *models.Record) *RecordImpersonate {
+	return &RecordImpersonate{
+		app:      app,
+		admin:    admin,
+		record:   record,
+		Duration: tokens.DefaultImpersonateTokenDuration,
+	}
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *RecordImpersonate) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(&form.Duration, validation.Required, validation.Min(1), validation.Max(tokens.MaxImpersonateTokenDuration)),
+	)
+}
+
+// Submit validates and submits the form.
+// On success returns the generated impersonation auth token.
+//
+// You can optionally provide a list of InterceptorFunc to
+// further modify the form behavior before generating the token.
+func (form *RecordImpersonate) Submit(interceptors ...InterceptorFunc[*models.Record]) (string, error) {
+	if err := form.Validate(); err != nil {
+		return "", err
+	}
+
+	if form.admin == nil {
+		return "", errors.New("Only admins can impersonate auth records.")
+	}
+
+	var token string
+
+	interceptorsErr := runInterceptors(form.record, func(m *models.Record) error {
+		var err error
+		token, err = tokens.NewRecordImpersonateToken(form.app, m, form.admin, form.Duration)
+		return err
+	}, interceptors...)
+
+	if interceptorsErr != nil {
+		return "", interceptorsErr
+	}
+
+	return token, nil
+}
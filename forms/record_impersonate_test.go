@@ -0,0 +1,67 @@
+package forms_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tokens"
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+func TestRecordImpersonateSubmit(t *testing.T) {
+	testApp, _ := tests.NewTestApp()
+	defer testApp.Cleanup()
+
+	record, err := testApp.Dao().FindAuthRecordByEmail("users", "test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	admin, err := testApp.Dao().FindAdminByEmail("test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		name        string
+		admin       *models.Admin
+		duration    int64
+		expectError bool
+	}{
+		{"missing admin", nil, 60, true},
+		{"zero duration", admin, 0, true},
+		{"negative duration", admin, -10, true},
+		{"duration greater than the max allowed", admin, tokens.MaxImpersonateTokenDuration + 1, true},
+		{"valid duration", admin, 60, false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			form := forms.NewRecordImpersonate(testApp, s.admin, record)
+
+			if form.Duration != tokens.DefaultImpersonateTokenDuration {
+				t.Fatalf("Expected default duration %d, got %d", tokens.DefaultImpersonateTokenDuration, form.Duration)
+			}
+
+			form.Duration = s.duration
+
+			token, err := form.Submit()
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if hasErr {
+				return
+			}
+
+			claims, _ := security.ParseUnverifiedJWT(token)
+			if claims["id"] != record.Id || claims["impersonator"] != admin.Id {
+				t.Fatalf("Unexpected token claims %v", claims)
+			}
+		})
+	}
+}
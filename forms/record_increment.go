@@ -0,0 +1,109 @@
+package forms
+
+import (
+	"errors"
+	"math"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+)
+
+// RecordIncrement is a [models.Record] form that atomically
+// increments (or decrements) one or more record number fields.
+type RecordIncrement struct {
+	app    core.App
+	dao    *daos.Dao
+	record *models.Record
+
+	// Fields is a map with the number field names and
+	// their deltas (negative values decrement the field).
+	Fields map[string]float64 `form:"fields" json:"fields"`
+}
+
+// NewRecordIncrement creates a new [RecordIncrement] form initialized with
+// from the provided [core.App] instance and [models.Record] model.
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewRecordIncrement(app core.App, record *models.Record) *RecordIncrement {
+	return &RecordIncrement{
+		app:    app,
+		dao:    app.Dao(),
+		record: record,
+	}
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *RecordIncrement) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *RecordIncrement) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(
+			&form.Fields,
+			validation.Required,
+			validation.By(form.checkFields),
+		),
+	)
+}
+
+func (form *RecordIncrement) checkFields(value any) error {
+	v, _ := value.(map[string]float64)
+
+	errs := validation.Errors{}
+
+	for name, delta := range v {
+		field := form.record.Collection().Schema.GetFieldByName(name)
+		if field == nil || field.Type != schema.FieldTypeNumber {
+			errs[name] = validation.NewError("validation_not_number_field", "Missing or invalid number field.")
+			continue
+		}
+
+		if delta == 0 || math.IsNaN(delta) || math.IsInf(delta, 0) {
+			errs[name] = validation.NewError("validation_invalid_increment_delta", "The delta must be a non-zero number.")
+			continue
+		}
+
+		field.InitOptions()
+		options, _ := field.Options.(*schema.NumberOptions)
+		if options != nil && options.NoDecimal && delta != math.Trunc(delta) {
+			errs[name] = validation.NewError("validation_no_decimal_constraint", "Decimal numbers are not allowed.")
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// Submit validates and submits the form.
+// On success the record number fields are updated with the incremented values.
+//
+// You can optionally provide a list of InterceptorFunc to
+// further modify the form behavior before persisting it.
+func (form *RecordIncrement) Submit(interceptors ...InterceptorFunc[*models.Record]) error {
+	if err := form.Validate(); err != nil {
+		return err
+	}
+
+	return runInterceptors(form.record, func(record *models.Record) error {
+		err := form.dao.IncrementRecordFields(record, form.Fields)
+		if errors.Is(err, daos.ErrIncrementOutOfBounds) {
+			return validation.Errors{
+				"fields": validation.NewError(
+					"validation_increment_out_of_bounds",
+					"The incremented values must be within the fields min/max bounds.",
+				),
+			}
+		}
+
+		return err
+	}, interceptors...)
+}
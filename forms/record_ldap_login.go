@@ -0,0 +1,155 @@
+package forms
+
+import (
+	"errors"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/ldapauth"
+)
+
+// LdapProvider is the external auth provider name of the directory linked auth records.
+const LdapProvider = "ldap"
+
+// LdapAuthenticator defines a directory user authenticator
+// (usually [ldapauth.Client]).
+type LdapAuthenticator interface {
+	Authenticate(identity string, password string) (*ldapauth.Entry, error)
+}
+
+// RecordLdapLoginData defines the RecordLdapLogin interceptors data.
+type RecordLdapLoginData struct {
+	ExternalAuth *models.ExternalAuth
+	Record       *models.Record
+	Entry        *ldapauth.Entry
+}
+
+// RecordLdapLogin is an auth record LDAP (aka. directory bind) login form.
+type RecordLdapLogin struct {
+	app           core.App
+	dao           *daos.Dao
+	collection    *models.Collection
+	authenticator LdapAuthenticator
+
+	Identity string `form:"identity" json:"identity"`
+	Password string `form:"password" json:"password"`
+}
+
+// NewRecordLdapLogin creates a new [RecordLdapLogin] form initialized
+// with from the provided [core.App] and [models.Collection] instance.
+//
+// The directory is queried with a client created from the app LDAP settings
+// (you can replace it with [SetAuthenticator()]).
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewRecordLdapLogin(app core.App, collection *models.Collection) *RecordLdapLogin {
+	return &RecordLdapLogin{
+		app:           app,
+		dao:           app.Dao(),
+		collection:    collection,
+		authenticator: app.Settings().Ldap.NewClient(),
+	}
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *RecordLdapLogin) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// SetAuthenticator replaces the default directory authenticator with the provided one.
+func (form *RecordLdapLogin) SetAuthenticator(authenticator LdapAuthenticator) {
+	form.authenticator = authenticator
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *RecordLdapLogin) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(&form.Identity, validation.Required, validation.Length(1, 255)),
+		validation.Field(&form.Password, validation.Required, validation.Length(1, 255)),
+	)
+}
+
+// Submit validates and submits the form.
+//
+// The identity and password are verified against the configured directory.
+// If there is no auth record linked to the directory entry, the form
+// links the auth record with the same email or creates a new one.
+//
+// You can optionally provide a list of InterceptorFunc to
+// further modify the form behavior before persisting it.
+//
+// On success returns the authorized auth record model.
+func (form *RecordLdapLogin) Submit(interceptors ...InterceptorFunc[*RecordLdapLoginData]) (*models.Record, error) {
+	if err := form.Validate(); err != nil {
+		return nil, err
+	}
+
+	config := form.app.Settings().Ldap
+	if !config.Enabled || !config.IsCollection(form.collection.Id, form.collection.Name) {
+		return nil, errors.New("LDAP authentication is not enabled for the auth collection.")
+	}
+
+	entry, err := form.authenticator.Authenticate(form.Identity, form.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	authRecord, rel, err := findExternalIdentityRecord(form.dao, form.collection, form.identity(entry))
+	if err != nil {
+		return nil, err
+	}
+
+	interceptorData := &RecordLdapLoginData{
+		ExternalAuth: rel,
+		Record:       authRecord,
+		Entry:        entry,
+	}
+
+	interceptorsErr := runInterceptors(interceptorData, func(newData *RecordLdapLoginData) error {
+		return form.submit(newData)
+	}, interceptors...)
+
+	if interceptorsErr != nil {
+		return nil, interceptorsErr
+	}
+
+	return interceptorData.Record, nil
+}
+
+func (form *RecordLdapLogin) submit(data *RecordLdapLoginData) error {
+	record, rel, err := saveExternalIdentityRecord(
+		form.app,
+		form.dao,
+		form.collection,
+		form.identity(data.Entry),
+		data.Record,
+		data.ExternalAuth,
+	)
+	if err != nil {
+		return err
+	}
+
+	data.Record = record
+	data.ExternalAuth = rel
+
+	return nil
+}
+
+// identity returns the external identity of the provided directory entry.
+func (form *RecordLdapLogin) identity(entry *ldapauth.Entry) *externalIdentity {
+	config := form.app.Settings().Ldap
+
+	return &externalIdentity{
+		provider:   LdapProvider,
+		providerId: entry.DN,
+		email:      entry.Attributes[config.EmailAttribute],
+		username:   entry.Attributes[config.UsernameAttribute],
+		fieldsData: mapExternalFields(form.collection, config.FieldsMapping, func(name string) (string, bool) {
+			value, ok := entry.Attributes[name]
+			return value, ok
+		}),
+	}
+}
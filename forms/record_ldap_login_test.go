@@ -0,0 +1,275 @@
+package forms_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/ldapauth"
+)
+
+type mockLdapAuthenticator struct {
+	entry *ldapauth.Entry
+	err   error
+}
+
+func (m *mockLdapAuthenticator) Authenticate(identity string, password string) (*ldapauth.Entry, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	return m.entry, nil
+}
+
+func TestRecordLdapLoginSubmit(t *testing.T) {
+	scenarios := []struct {
+		name             string
+		collection       string
+		ldapEnabled      bool
+		ldapCollection   string
+		identity         string
+		password         string
+		authenticator    *mockLdapAuthenticator
+		expectError      bool
+		expectRecordId   string // empty for new records
+		expectedEmail    string
+		expectedUsername string
+		expectedName     string
+	}{
+		{
+			name:           "empty data",
+			collection:     "users",
+			ldapEnabled:    true,
+			ldapCollection: "users",
+			authenticator:  &mockLdapAuthenticator{entry: &ldapauth.Entry{DN: "uid=test,dc=example"}},
+			expectError:    true,
+		},
+		{
+			name:           "disabled ldap auth",
+			collection:     "users",
+			ldapCollection: "users",
+			identity:       "test",
+			password:       "1234567890",
+			authenticator:  &mockLdapAuthenticator{entry: &ldapauth.Entry{DN: "uid=test,dc=example"}},
+			expectError:    true,
+		},
+		{
+			name:           "different ldap collection",
+			collection:     "users",
+			ldapEnabled:    true,
+			ldapCollection: "clients",
+			identity:       "test",
+			password:       "1234567890",
+			authenticator:  &mockLdapAuthenticator{entry: &ldapauth.Entry{DN: "uid=test,dc=example"}},
+			expectError:    true,
+		},
+		{
+			name:           "invalid directory credentials",
+			collection:     "users",
+			ldapEnabled:    true,
+			ldapCollection: "users",
+			identity:       "test",
+			password:       "1234567890",
+			authenticator:  &mockLdapAuthenticator{err: ldapauth.ErrInvalidCredentials},
+			expectError:    true,
+		},
+		{
+			name:           "new directory user",
+			collection:     "users",
+			ldapEnabled:    true,
+			ldapCollection: "_pb_users_auth_",
+			identity:       "ldap_user",
+			password:       "1234567890",
+			authenticator: &mockLdapAuthenticator{entry: &ldapauth.Entry{
+				DN: "uid=ldap_user,dc=example",
+				Attributes: map[string]string{
+					"uid":         "ldap_user",
+					"mail":        "ldap_user@example.com",
+					"displayName": "LDAP User",
+				},
+			}},
+			expectedEmail:    "ldap_user@example.com",
+			expectedUsername: "ldap_user",
+			expectedName:     "LDAP User",
+		},
+		{
+			name:           "existing auth record with the entry email",
+			collection:     "users",
+			ldapEnabled:    true,
+			ldapCollection: "users",
+			identity:       "test",
+			password:       "1234567890",
+			authenticator: &mockLdapAuthenticator{entry: &ldapauth.Entry{
+				DN: "uid=test,dc=example",
+				Attributes: map[string]string{
+					"uid":         "test",
+					"mail":        "test@example.com",
+					"displayName": "Synced name",
+				},
+			}},
+			expectRecordId:   "4q1xlclmfloku33",
+			expectedEmail:    "test@example.com",
+			expectedUsername: "users75657",
+			expectedName:     "Synced name",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			testApp, _ := tests.NewTestApp()
+			defer testApp.Cleanup()
+
+			testApp.Settings().Ldap.Enabled = s.ldapEnabled
+			testApp.Settings().Ldap.Collection = s.ldapCollection
+			testApp.Settings().Ldap.FieldsMapping = map[string]string{"name": "displayName"}
+
+			collection, err := testApp.Dao().FindCollectionByNameOrId(s.collection)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			form := forms.NewRecordLdapLogin(testApp, collection)
+			form.SetAuthenticator(s.authenticator)
+			form.Identity = s.identity
+			form.Password = s.password
+
+			record, err := form.Submit()
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if hasErr {
+				return
+			}
+
+			if s.expectRecordId != "" && record.Id != s.expectRecordId {
+				t.Fatalf("Expected record %q, got %q", s.expectRecordId, record.Id)
+			}
+
+			if record.Email() != s.expectedEmail {
+				t.Fatalf("Expected email %q, got %q", s.expectedEmail, record.Email())
+			}
+
+			if record.Username() != s.expectedUsername {
+				t.Fatalf("Expected username %q, got %q", s.expectedUsername, record.Username())
+			}
+
+			if !record.Verified() {
+				t.Fatal("Expected the record to be verified")
+			}
+
+			// check the persisted state
+			saved, err := testApp.Dao().FindRecordById(collection.Id, record.Id)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if name := saved.GetString("name"); name != s.expectedName {
+				t.Fatalf("Expected name %q, got %q", s.expectedName, name)
+			}
+
+			rel, err := testApp.Dao().FindFirstExternalAuthByExpr(dbx.HashExp{
+				"collectionId": collection.Id,
+				"recordId":     record.Id,
+				"provider":     forms.LdapProvider,
+				"providerId":   s.authenticator.entry.DN,
+			})
+			if err != nil || rel == nil {
+				t.Fatalf("Expected the directory entry to be linked, got %v", err)
+			}
+
+			// subsequent logins should resolve the same auth record
+			// through the external auth relation
+			s.authenticator.entry.Attributes["mail"] = "changed@example.com"
+
+			form2 := forms.NewRecordLdapLogin(testApp, collection)
+			form2.SetAuthenticator(s.authenticator)
+			form2.Identity = s.identity
+			form2.Password = s.password
+
+			record2, err := form2.Submit()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if record2.Id != record.Id {
+				t.Fatalf("Expected record %q, got %q", record.Id, record2.Id)
+			}
+		})
+	}
+}
+
+func TestRecordLdapLoginInterceptors(t *testing.T) {
+	testApp, _ := tests.NewTestApp()
+	defer testApp.Cleanup()
+
+	testApp.Settings().Ldap.Enabled = true
+	testApp.Settings().Ldap.Collection = "users"
+
+	collection, err := testApp.Dao().FindCollectionByNameOrId("users")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	form := forms.NewRecordLdapLogin(testApp, collection)
+	form.SetAuthenticator(&mockLdapAuthenticator{entry: &ldapauth.Entry{
+		DN:         "uid=test,dc=example",
+		Attributes: map[string]string{"mail": "test@example.com"},
+	}})
+	form.Identity = "test"
+	form.Password = "1234567890"
+
+	var interceptorData *forms.RecordLdapLoginData
+	testErr := errors.New("test_error")
+
+	interceptor1Called := false
+	interceptor1 := func(next forms.InterceptorNextFunc[*forms.RecordLdapLoginData]) forms.InterceptorNextFunc[*forms.RecordLdapLoginData] {
+		return func(data *forms.RecordLdapLoginData) error {
+			interceptor1Called = true
+			return next(data)
+		}
+	}
+
+	interceptor2Called := false
+	interceptor2 := func(next forms.InterceptorNextFunc[*forms.RecordLdapLoginData]) forms.InterceptorNextFunc[*forms.RecordLdapLoginData] {
+		return func(data *forms.RecordLdapLoginData) error {
+			interceptorData = data
+			interceptor2Called = true
+			return testErr
+		}
+	}
+
+	_, submitErr := form.Submit(interceptor1, interceptor2)
+	if submitErr != testErr {
+		t.Fatalf("Expected submitError %v, got %v", testErr, submitErr)
+	}
+
+	if !interceptor1Called {
+		t.Fatalf("Expected interceptor1 to be called")
+	}
+
+	if !interceptor2Called {
+		t.Fatalf("Expected interceptor2 to be called")
+	}
+
+	if interceptorData == nil || interceptorData.Record == nil || interceptorData.Record.Id != "4q1xlclmfloku33" {
+		t.Fatalf("Expected auth record 4q1xlclmfloku33, got %v", interceptorData)
+	}
+
+	if interceptorData.ExternalAuth != nil {
+		t.Fatalf("Expected nil ExternalAuth, got %v", interceptorData.ExternalAuth)
+	}
+
+	// the relation shouldn't be created if the interceptors chain fails
+	rel, _ := testApp.Dao().FindFirstExternalAuthByExpr(dbx.HashExp{
+		"collectionId": collection.Id,
+		"provider":     forms.LdapProvider,
+	})
+	if rel != nil {
+		t.Fatalf("Expected no ldap external auth relation, got %v", rel)
+	}
+}
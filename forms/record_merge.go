@@ -0,0 +1,437 @@
+package forms
+
+import (
+	"fmt"
+	"sort"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tools/inflector"
+	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// RecordMerge is a record form that merges a duplicated
+// (source) record into the form target record.
+//
+// On submit:
+// - all relation references to the source record are re-pointed to the target
+// - the empty target fields are filled with the source values (if FillEmpty is set)
+// - the source external auths are moved to the target (if the provider is not already linked)
+// - the source files are copied to the target (up to the file field max select limit)
+// - the source auth record is deactivated (new random password, refreshed token key and unverified email)
+// - the source base record is deleted
+type RecordMerge struct {
+	app    core.App
+	dao    *daos.Dao
+	target *models.Record
+
+	Source    string `form:"source" json:"source"`
+	DryRun    bool   `form:"dryRun" json:"dryRun"`
+	FillEmpty bool   `form:"fillEmpty" json:"fillEmpty"`
+}
+
+// RecordMergeReport defines the changes applied
+// (or that will be applied in dry-run mode) by a records merge.
+type RecordMergeReport struct {
+	DryRun               bool                   `json:"dryRun"`
+	Relations            []*RecordMergeRelation `json:"relations"`
+	ExternalAuths        []string               `json:"externalAuths"`
+	SkippedExternalAuths []string               `json:"skippedExternalAuths"`
+	Files                []string               `json:"files"`
+	Fields               []string               `json:"fields,omitempty"`
+}
+
+// RecordMergeRelation defines the records of a single relation
+// field that were re-pointed from the source to the target record.
+type RecordMergeRelation struct {
+	Collection string   `json:"collection"`
+	Field      string   `json:"field"`
+	Records    []string `json:"records"`
+}
+
+// NewRecordMerge creates a new [RecordMerge] form initialized with
+// from the provided [core.App] instance and target record.
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewRecordMerge(app core.App, target *models.Record) *RecordMerge {
+	return &RecordMerge{
+		app:    app,
+		dao:    app.Dao(),
+		target: target,
+	}
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *RecordMerge) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *RecordMerge) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(
+			&form.Source,
+			validation.Required,
+			validation.NotIn(form.target.Id).Error("The source and target records must be different."),
+			validation.By(form.checkSource),
+		),
+	)
+}
+
+func (form *RecordMerge) checkSource(value any) error {
+	v, _ := value.(string)
+	if v == "" {
+		return nil // nothing to check
+	}
+
+	if form.target.Collection().IsView() {
+		return validation.NewError("validation_view_collection", "View records could not be merged.")
+	}
+
+	if _, err := form.dao.FindRecordById(form.target.Collection().Id, v); err != nil {
+		return validation.NewError("validation_missing_source", "Missing or invalid source record.")
+	}
+
+	return nil
+}
+
+// Submit validates and submits the form.
+//
+// All changes are applied in a single transaction. If form.DryRun
+// is set, nothing is changed and only the report is returned.
+//
+// You can optionally provide a list of InterceptorFunc to further
+// modify the form behavior before persisting it (the interceptors
+// receive the source record).
+func (form *RecordMerge) Submit(interceptors ...InterceptorFunc[*models.Record]) (*RecordMergeReport, error) {
+	if err := form.Validate(); err != nil {
+		return nil, err
+	}
+
+	source, err := form.dao.FindRecordById(form.target.Collection().Id, form.Source)
+	if err != nil {
+		return nil, err
+	}
+
+	// note: the select is outside of the transaction to prevent SQLITE_LOCKED error when mixing read&write in a single transaction
+	refs, err := form.dao.FindCollectionReferences(form.target.Collection())
+	if err != nil {
+		return nil, err
+	}
+
+	report := &RecordMergeReport{
+		DryRun:               form.DryRun,
+		Relations:            []*RecordMergeRelation{},
+		ExternalAuths:        []string{},
+		SkippedExternalAuths: []string{},
+		Files:                []string{},
+	}
+
+	var copiedFiles []string
+
+	interceptorsErr := runInterceptors(source, func(source *models.Record) error {
+		return form.dao.RunInTransaction(func(txDao *daos.Dao) error {
+			if err := form.mergeRelations(txDao, source, refs, report); err != nil {
+				return err
+			}
+
+			if form.FillEmpty {
+				form.mergeFields(source, report)
+			}
+
+			if form.target.Collection().IsAuth() {
+				if err := form.mergeExternalAuths(txDao, source, report); err != nil {
+					return err
+				}
+			}
+
+			copied, err := form.mergeFiles(source, report)
+			copiedFiles = copied
+			if err != nil {
+				return err
+			}
+
+			if form.DryRun {
+				return nil
+			}
+
+			if !source.Collection().IsAuth() {
+				// delete the source first to release its unique field values
+				if err := txDao.DeleteRecord(source); err != nil {
+					return err
+				}
+
+				return txDao.SaveRecord(form.target)
+			}
+
+			if err := txDao.SaveRecord(form.target); err != nil {
+				return err
+			}
+
+			// deactivate the source auth record
+			if err := source.SetPassword(security.RandomString(30)); err != nil {
+				return err
+			}
+			if err := source.SetVerified(false); err != nil {
+				return err
+			}
+
+			return txDao.SaveRecord(source)
+		})
+	}, interceptors...)
+
+	if interceptorsErr != nil {
+		form.deleteFiles(copiedFiles)
+		return nil, interceptorsErr
+	}
+
+	sort.Slice(report.Relations, func(i, j int) bool {
+		a, b := report.Relations[i], report.Relations[j]
+		if a.Collection == b.Collection {
+			return a.Field < b.Field
+		}
+		return a.Collection < b.Collection
+	})
+
+	return report, nil
+}
+
+// mergeRelations re-points all relation references from the source to the target record.
+func (form *RecordMerge) mergeRelations(
+	txDao *daos.Dao,
+	source *models.Record,
+	refs map[*models.Collection][]*schema.SchemaField,
+	report *RecordMergeReport,
+) error {
+	uniqueJsonEachAlias := "__je__" + security.PseudorandomString(4)
+
+	for refCollection, fields := range refs {
+		if refCollection.IsView() {
+			continue // skip view collections
+		}
+
+		for _, field := range fields {
+			recordTableName := inflector.Columnify(refCollection.Name)
+			prefixedFieldName := recordTableName + "." + inflector.Columnify(field.Name)
+
+			query := txDao.RecordQuery(refCollection).Distinct(true)
+
+			if opt, ok := field.Options.(schema.MultiValuer); !ok || !opt.IsMultiple() {
+				query.AndWhere(dbx.HashExp{prefixedFieldName: source.Id})
+			} else {
+				query.InnerJoin(fmt.Sprintf(
+					`json_each(CASE WHEN json_valid([[%s]]) THEN [[%s]] ELSE json_array([[%s]]) END) as {{%s}}`,
+					prefixedFieldName, prefixedFieldName, prefixedFieldName, uniqueJsonEachAlias,
+				), dbx.HashExp{uniqueJsonEachAlias + ".value": source.Id})
+			}
+
+			rows := []dbx.NullStringMap{}
+			if err := query.All(&rows); err != nil {
+				return err
+			}
+
+			if len(rows) == 0 {
+				continue
+			}
+
+			relation := &RecordMergeRelation{
+				Collection: refCollection.Name,
+				Field:      field.Name,
+				Records:    make([]string, 0, len(rows)),
+			}
+			report.Relations = append(report.Relations, relation)
+
+			for _, refRecord := range models.NewRecordsFromNullStringMaps(refCollection, rows) {
+				relation.Records = append(relation.Records, refRecord.Id)
+
+				// the merged records are saved separately
+				isMergedRecord := false
+				switch refRecord.Id {
+				case source.Id:
+					refRecord = source
+					isMergedRecord = true
+				case form.target.Id:
+					refRecord = form.target
+					isMergedRecord = true
+				}
+
+				ids := refRecord.GetStringSlice(field.Name)
+				for i, id := range ids {
+					if id == source.Id {
+						ids[i] = form.target.Id
+					}
+				}
+				refRecord.Set(field.Name, list.ToUniqueStringSlice(ids))
+
+				if form.DryRun || isMergedRecord {
+					continue
+				}
+
+				if err := txDao.SaveRecord(refRecord); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// mergeFields fills the empty target schema fields with the source values
+// (the bool fields are skipped and the file fields are handled separately by mergeFiles).
+func (form *RecordMerge) mergeFields(source *models.Record, report *RecordMergeReport) {
+	for _, field := range form.target.Collection().Schema.Fields() {
+		if field.IsFile() || field.Type == schema.FieldTypeBool {
+			continue
+		}
+
+		sourceValue := source.Get(field.Name)
+
+		if !isEmptyMergeValue(form.target.Get(field.Name)) || isEmptyMergeValue(sourceValue) {
+			continue
+		}
+
+		report.Fields = append(report.Fields, field.Name)
+
+		form.target.Set(field.Name, sourceValue)
+	}
+}
+
+// isEmptyMergeValue checks whether the provided record field value is empty.
+func isEmptyMergeValue(value any) bool {
+	if dt, ok := value.(types.DateTime); ok {
+		return dt.IsZero()
+	}
+
+	return validation.IsEmpty(value)
+}
+
+// mergeExternalAuths moves the source external auths to the target record.
+//
+// External auths whose provider is already linked to the target are skipped.
+func (form *RecordMerge) mergeExternalAuths(txDao *daos.Dao, source *models.Record, report *RecordMergeReport) error {
+	sourceAuths, err := txDao.FindAllExternalAuthsByRecord(source)
+	if err != nil {
+		return err
+	}
+
+	targetAuths, err := txDao.FindAllExternalAuthsByRecord(form.target)
+	if err != nil {
+		return err
+	}
+
+	linked := make(map[string]struct{}, len(targetAuths))
+	for _, ea := range targetAuths {
+		linked[ea.Provider] = struct{}{}
+	}
+
+	for _, ea := range sourceAuths {
+		if _, ok := linked[ea.Provider]; ok {
+			report.SkippedExternalAuths = append(report.SkippedExternalAuths, ea.Provider)
+			continue
+		}
+
+		report.ExternalAuths = append(report.ExternalAuths, ea.Provider)
+
+		if form.DryRun {
+			continue
+		}
+
+		ea.RecordId = form.target.Id
+		if err := txDao.SaveExternalAuth(ea); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// mergeFiles copies the source record files to the target record
+// (up to the file field max select limit).
+//
+// Returns the list with the copied file keys.
+func (form *RecordMerge) mergeFiles(source *models.Record, report *RecordMergeReport) ([]string, error) {
+	type fileCopy struct {
+		field string
+		names []string
+	}
+
+	var copies []fileCopy
+
+	for _, field := range form.target.Collection().Schema.Fields() {
+		if !field.IsFile() {
+			continue
+		}
+
+		field.InitOptions()
+		options := field.FileOptions()
+
+		sourceNames := source.GetStringSlice(field.Name)
+		targetNames := form.target.GetStringSlice(field.Name)
+
+		available := max(options.MaxSelect, 1) - len(targetNames)
+		if available <= 0 || len(sourceNames) == 0 {
+			continue
+		}
+
+		names := sourceNames[:min(available, len(sourceNames))]
+		for _, name := range names {
+			report.Files = append(report.Files, field.Name+"/"+name)
+		}
+
+		copies = append(copies, fileCopy{field.Name, names})
+	}
+
+	if form.DryRun || len(copies) == 0 {
+		return nil, nil
+	}
+
+	fs, err := form.app.NewFilesystem()
+	if err != nil {
+		return nil, err
+	}
+	defer fs.Close()
+
+	var copied []string
+
+	for _, c := range copies {
+		for _, name := range c.names {
+			dstKey := form.target.BaseFilesPath() + "/" + name
+			if err := fs.Copy(source.BaseFilesPath()+"/"+name, dstKey); err != nil {
+				return copied, fmt.Errorf("failed to copy file %q: %w", name, err)
+			}
+			copied = append(copied, dstKey)
+		}
+
+		form.target.Set(c.field, append(form.target.GetStringSlice(c.field), c.names...))
+	}
+
+	return copied, nil
+}
+
+// deleteFiles removes the specified file keys (eg. on failed merge).
+func (form *RecordMerge) deleteFiles(keys []string) {
+	if len(keys) == 0 {
+		return
+	}
+
+	fs, err := form.app.NewFilesystem()
+	if err != nil {
+		form.app.Logger().Error("Failed to init the filesystem for deleting the merged files", "error", err)
+		return
+	}
+	defer fs.Close()
+
+	for _, key := range keys {
+		if err := fs.Delete(key); err != nil {
+			form.app.Logger().Error("Failed to delete merged file", "key", key, "error", err)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package forms_test
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestRecordMergeValidate(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	target, err := app.Dao().FindRecordById("users", "oap640cot4yru2s")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		source         string
+		expectedErrors []string
+	}{
+		{"", []string{"source"}},
+		{"oap640cot4yru2s", []string{"source"}}, // same record
+		{"missing", []string{"source"}},
+		{"84nmscqy84lsi1t", []string{"source"}}, // different collection
+		{"4q1xlclmfloku33", nil},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.source, func(t *testing.T) {
+			form := forms.NewRecordMerge(app, target)
+			form.Source = s.source
+
+			err := form.Validate()
+
+			errs, _ := err.(validation.Errors)
+			if len(errs) != len(s.expectedErrors) {
+				t.Fatalf("Expected errors %v, got %v", s.expectedErrors, errs)
+			}
+			for _, k := range s.expectedErrors {
+				if _, ok := errs[k]; !ok {
+					t.Fatalf("Missing expected %q error in %v", k, errs)
+				}
+			}
+		})
+	}
+}
+
+func TestRecordMergeSubmit(t *testing.T) {
+	for _, dryRun := range []bool{true, false} {
+		name := "merge"
+		if dryRun {
+			name = "dry-run"
+		}
+
+		t.Run(name, func(t *testing.T) {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			target, err := app.Dao().FindRecordById("users", "oap640cot4yru2s")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			form := forms.NewRecordMerge(app, target)
+			form.Source = "4q1xlclmfloku33"
+			form.DryRun = dryRun
+
+			report, err := form.Submit()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			sort.Strings(report.ExternalAuths)
+
+			rawReport, _ := json.Marshal(report)
+			expectedReport := `{"dryRun":` + map[bool]string{true: "true", false: "false"}[dryRun] +
+				`,"relations":[{"collection":"demo1","field":"rel_many","records":["al1h9ijdeojtsjy"]}]` +
+				`,"externalAuths":["gitlab","google"],"skippedExternalAuths":[],"files":["avatar/300_1SEi6Q6U72.png"]}`
+			if string(rawReport) != expectedReport {
+				t.Fatalf("Expected report\n%s\ngot\n%s", expectedReport, rawReport)
+			}
+
+			// reload the records
+			target, _ = app.Dao().FindRecordById("users", "oap640cot4yru2s")
+			source, _ := app.Dao().FindRecordById("users", "4q1xlclmfloku33")
+			demo1, _ := app.Dao().FindRecordById("demo1", "al1h9ijdeojtsjy")
+
+			rels := strings.Join(demo1.GetStringSlice("rel_many"), ",")
+			targetAuths, _ := app.Dao().FindAllExternalAuthsByRecord(target)
+
+			fs, err := app.NewFilesystem()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer fs.Close()
+			fileExists, _ := fs.Exists(target.BaseFilesPath() + "/300_1SEi6Q6U72.png")
+
+			if dryRun {
+				if rels != "bgs820n361vj1qd,4q1xlclmfloku33,oap640cot4yru2s" {
+					t.Fatalf("Expected unchanged relations, got %s", rels)
+				}
+				if len(targetAuths) != 0 {
+					t.Fatalf("Expected no moved external auths, got %d", len(targetAuths))
+				}
+				if fileExists || target.GetString("avatar") != "" {
+					t.Fatal("Expected the avatar to not be copied")
+				}
+				if !source.ValidatePassword("1234567890") {
+					t.Fatal("Expected the source record to remain active")
+				}
+				return
+			}
+
+			if rels != "bgs820n361vj1qd,oap640cot4yru2s" {
+				t.Fatalf("Expected re-pointed relations, got %s", rels)
+			}
+			if len(targetAuths) != 2 {
+				t.Fatalf("Expected 2 moved external auths, got %d", len(targetAuths))
+			}
+			if !fileExists || target.GetString("avatar") != "300_1SEi6Q6U72.png" {
+				t.Fatal("Expected the avatar to be copied")
+			}
+			if source.ValidatePassword("1234567890") || source.Verified() {
+				t.Fatal("Expected the source record to be deactivated")
+			}
+		})
+	}
+}
+
+func TestRecordMergeSubmitBaseRecord(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	target, err := app.Dao().FindRecordById("demo2", "achvryl401bhse3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	target.Set("title", "")
+	if err := app.Dao().WithoutHooks().SaveRecord(target); err != nil {
+		t.Fatal(err)
+	}
+
+	form := forms.NewRecordMerge(app, target)
+	form.Source = "llvuca81nly1qls"
+	form.FillEmpty = true
+
+	report, err := form.Submit()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rawReport, _ := json.Marshal(report)
+	expectedReport := `{"dryRun":false` +
+		`,"relations":[{"collection":"users","field":"rel","records":["4q1xlclmfloku33"]}]` +
+		`,"externalAuths":[],"skippedExternalAuths":[],"files":[],"fields":["title"]}`
+	if string(rawReport) != expectedReport {
+		t.Fatalf("Expected report\n%s\ngot\n%s", expectedReport, rawReport)
+	}
+
+	target, _ = app.Dao().FindRecordById("demo2", "achvryl401bhse3")
+	if title := target.GetString("title"); title != "test1" {
+		t.Fatalf("Expected the empty target title to be filled, got %q", title)
+	}
+	if !target.GetBool("active") {
+		t.Fatal("Expected the non-empty target active field to remain unchanged")
+	}
+
+	user, _ := app.Dao().FindRecordById("users", "4q1xlclmfloku33")
+	if rel := user.GetString("rel"); rel != target.Id {
+		t.Fatalf("Expected re-pointed relation, got %q", rel)
+	}
+
+	if _, err := app.Dao().FindRecordById("demo2", "llvuca81nly1qls"); err == nil {
+		t.Fatal("Expected the source base record to be deleted")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
@@ -12,6 +13,7 @@ import (
 	"github.com/pocketbase/pocketbase/daos"
 	"github.com/pocketbase/pocketbase/models"
 	"github.com/pocketbase/pocketbase/tools/auth"
+	"github.com/pocketbase/pocketbase/tools/list"
 	"github.com/pocketbase/pocketbase/tools/security"
 	"golang.org/x/oauth2"
 )
@@ -95,8 +97,7 @@ func (form *RecordOAuth2Login) Validate() error {
 func (form *RecordOAuth2Login) checkProviderName(value any) error {
 	name, _ := value.(string)
 
-	config, ok := form.app.Settings().NamedAuthProviderConfigs()[name]
-	if !ok || !config.Enabled {
+	if !list.ExistInSlice(name, form.app.Settings().EnabledAuthProviderNames()) {
 		return validation.NewError("validation_invalid_provider", fmt.Sprintf("%q is missing or is not enabled.", name))
 	}
 
@@ -124,7 +125,8 @@ func (form *RecordOAuth2Login) Submit(
 		return nil, nil, errors.New("OAuth2 authentication is not allowed for the auth collection.")
 	}
 
-	provider, err := auth.NewProviderByName(form.Provider)
+	// init the provider with its settings configuration
+	provider, err := form.app.Settings().NewAuthProvider(form.Provider)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -134,12 +136,6 @@ func (form *RecordOAuth2Login) Submit(
 
 	provider.SetContext(ctx)
 
-	// load provider configuration
-	providerConfig := form.app.Settings().NamedAuthProviderConfigs()[form.Provider]
-	if err := providerConfig.SetupProvider(provider); err != nil {
-		return nil, nil, err
-	}
-
 	provider.SetRedirectUrl(form.RedirectUrl)
 
 	var opts []oauth2.AuthCodeOption
@@ -190,7 +186,7 @@ func (form *RecordOAuth2Login) Submit(
 	}
 
 	interceptorsErr := runInterceptors(interceptorData, func(newData *RecordOAuth2LoginData) error {
-		return form.submit(newData)
+		return form.submit(newData, token)
 	}, interceptors...)
 
 	if interceptorsErr != nil {
@@ -200,7 +196,7 @@ func (form *RecordOAuth2Login) Submit(
 	return interceptorData.Record, interceptorData.OAuth2User, nil
 }
 
-func (form *RecordOAuth2Login) submit(data *RecordOAuth2LoginData) error {
+func (form *RecordOAuth2Login) submit(data *RecordOAuth2LoginData, token *oauth2.Token) error {
 	return form.dao.RunInTransaction(func(txDao *daos.Dao) error {
 		if data.Record == nil {
 			data.Record = models.NewRecord(form.collection)
@@ -280,6 +276,40 @@ func (form *RecordOAuth2Login) submit(data *RecordOAuth2LoginData) error {
 			}
 		}
 
+		// store the provider token (if enabled)
+		config, _ := form.app.Settings().FindAuthProviderConfig(form.Provider)
+		if config.StoreTokens && token != nil {
+			if err := form.storeToken(txDao, data.ExternalAuth, token); err != nil {
+				return err
+			}
+		}
+
 		return nil
 	})
 }
+
+// storeToken persists the provider token of the linked external auth.
+//
+// Some providers return the refresh token only on the first
+// authorization, so the previously stored one is kept if missing.
+func (form *RecordOAuth2Login) storeToken(txDao *daos.Dao, externalAuth *models.ExternalAuth, token *oauth2.Token) error {
+	encryptionKey := os.Getenv(form.app.EncryptionEnv())
+
+	newToken := &oauth2.Token{
+		AccessToken:  token.AccessToken,
+		RefreshToken: token.RefreshToken,
+		Expiry:       token.Expiry,
+	}
+
+	if newToken.RefreshToken == "" {
+		if oldToken, _ := externalAuth.Token(encryptionKey); oldToken != nil {
+			newToken.RefreshToken = oldToken.RefreshToken
+		}
+	}
+
+	if err := externalAuth.SetToken(newToken, encryptionKey); err != nil {
+		return err
+	}
+
+	return txDao.SaveExternalAuth(externalAuth)
+}
@@ -0,0 +1,126 @@
+package forms
+
+import (
+	"errors"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+var errInvalidOtp = errors.New("Invalid or expired one-time password.")
+
+// RecordOtpLogin is an auth record email one-time password login form.
+type RecordOtpLogin struct {
+	app        core.App
+	dao        *daos.Dao
+	collection *models.Collection
+
+	OtpId    string `form:"otpId" json:"otpId"`
+	Password string `form:"password" json:"password"`
+
+	// MfaCode is the TOTP or recovery code of the auth record
+	// enabled MFA factor (required only if the auth record has one).
+	MfaCode string `form:"mfaCode" json:"mfaCode"`
+}
+
+// NewRecordOtpLogin creates a new [RecordOtpLogin] form initialized
+// with from the provided [core.App] and [models.Collection] instance.
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewRecordOtpLogin(app core.App, collection *models.Collection) *RecordOtpLogin {
+	return &RecordOtpLogin{
+		app:        app,
+		dao:        app.Dao(),
+		collection: collection,
+	}
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *RecordOtpLogin) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *RecordOtpLogin) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(&form.OtpId, validation.Required, validation.Length(1, 255)),
+		validation.Field(&form.Password, validation.Required, validation.Length(1, 255)),
+		validation.Field(&form.MfaCode, validation.Length(0, 100)),
+	)
+}
+
+// Submit validates and submits the form.
+//
+// The one-time password could be used only once and it is discarded
+// after [models.OtpMaxAttempts] failed attempts. Because the password
+// was sent to the auth record email, a successful login also marks
+// the auth record as verified.
+//
+// You can optionally provide a list of InterceptorFunc to
+// further modify the form behavior before persisting it.
+//
+// On success returns the authorized record model.
+func (form *RecordOtpLogin) Submit(interceptors ...InterceptorFunc[*models.Record]) (*models.Record, error) {
+	if err := form.Validate(); err != nil {
+		return nil, err
+	}
+
+	if !form.collection.AuthOptions().AllowOtpAuth {
+		return nil, errors.New("The collection is not configured to allow OTP authentication.")
+	}
+
+	otp, err := form.dao.FindOtpById(form.OtpId)
+	if err != nil || otp.CollectionId != form.collection.Id {
+		return nil, errInvalidOtp
+	}
+
+	if otp.HasExpired(form.collection.OtpDuration()) {
+		form.dao.DeleteOtp(otp)
+		return nil, errInvalidOtp
+	}
+
+	if !otp.ValidatePassword(form.Password) {
+		otp.Attempts++
+		if err := form.dao.SaveOtp(otp); err != nil {
+			return nil, err
+		}
+		return nil, errInvalidOtp
+	}
+
+	authRecord, err := form.dao.FindRecordById(form.collection.Id, otp.RecordId)
+	if err != nil {
+		return nil, errInvalidOtp
+	}
+
+	interceptorsErr := runInterceptors(authRecord, func(m *models.Record) error {
+		authRecord = m
+
+		if err := checkRecordMfa(form.dao, form.collection, authRecord, "mfaCode", form.MfaCode); err != nil {
+			return err
+		}
+
+		return form.dao.RunInTransaction(func(txDao *daos.Dao) error {
+			if err := txDao.DeleteOtp(otp); err != nil {
+				return err
+			}
+
+			if !authRecord.Verified() {
+				authRecord.SetVerified(true)
+				if err := txDao.SaveRecord(authRecord); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+	}, interceptors...)
+
+	if interceptorsErr != nil {
+		return nil, interceptorsErr
+	}
+
+	return authRecord, nil
+}
@@ -0,0 +1,192 @@
+package forms_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestRecordOtpLoginSubmit(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		collection  string
+		allowOtp    bool
+		otpId       string
+		password    string
+		attempts    int
+		createdAgo  time.Duration
+		expectError bool
+		expectOtp   bool // whether the otp should remain after the submit
+	}{
+		{
+			name:        "empty data",
+			collection:  "users",
+			allowOtp:    true,
+			expectError: true,
+			expectOtp:   true,
+		},
+		{
+			name:        "disabled otp auth",
+			collection:  "users",
+			otpId:       "test_otp_id_123",
+			password:    "123456",
+			expectError: true,
+			expectOtp:   true,
+		},
+		{
+			name:        "missing otp",
+			collection:  "users",
+			allowOtp:    true,
+			otpId:       "missing",
+			password:    "123456",
+			expectError: true,
+			expectOtp:   true,
+		},
+		{
+			name:        "otp from different collection",
+			collection:  "clients",
+			allowOtp:    true,
+			otpId:       "test_otp_id_123",
+			password:    "123456",
+			expectError: true,
+			expectOtp:   true,
+		},
+		{
+			name:        "invalid password",
+			collection:  "users",
+			allowOtp:    true,
+			otpId:       "test_otp_id_123",
+			password:    "654321",
+			expectError: true,
+			expectOtp:   true,
+		},
+		{
+			name:        "otp older than the collection otp duration",
+			collection:  "users",
+			allowOtp:    true,
+			otpId:       "test_otp_id_123",
+			password:    "123456",
+			createdAgo:  10 * time.Minute,
+			expectError: true,
+		},
+		{
+			name:        "otp with too many failed attempts",
+			collection:  "users",
+			allowOtp:    true,
+			otpId:       "test_otp_id_123",
+			password:    "123456",
+			attempts:    models.OtpMaxAttempts,
+			expectError: true,
+		},
+		{
+			name:       "valid otp",
+			collection: "users",
+			allowOtp:   true,
+			otpId:      "test_otp_id_123",
+			password:   "123456",
+			attempts:   models.OtpMaxAttempts - 1,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			testApp, _ := tests.NewTestApp()
+			defer testApp.Cleanup()
+
+			collection, err := testApp.Dao().FindCollectionByNameOrId(s.collection)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			options := collection.AuthOptions()
+			options.AllowOtpAuth = s.allowOtp
+			collection.SetOptions(options)
+
+			otp := &models.Otp{
+				CollectionId: "_pb_users_auth_",
+				RecordId:     "4q1xlclmfloku33",
+				Attempts:     s.attempts,
+			}
+			otp.Id = "test_otp_id_123"
+			otp.MarkAsNew()
+			otp.SetPassword("123456")
+			otp.Created, _ = types.ParseDateTime(time.Now().Add(-s.createdAgo))
+			if err := testApp.Dao().SaveOtp(otp); err != nil {
+				t.Fatal(err)
+			}
+
+			form := forms.NewRecordOtpLogin(testApp, collection)
+			form.OtpId = s.otpId
+			form.Password = s.password
+
+			record, err := form.Submit()
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			_, otpErr := testApp.Dao().FindOtpById(otp.Id)
+			if hasOtp := otpErr == nil; hasOtp != s.expectOtp {
+				t.Fatalf("Expected the otp to remain %v, got %v", s.expectOtp, hasOtp)
+			}
+
+			if hasErr {
+				return
+			}
+
+			if record.Id != otp.RecordId {
+				t.Fatalf("Expected record %q, got %q", otp.RecordId, record.Id)
+			}
+
+			if !record.Verified() {
+				t.Fatal("Expected the record to be marked as verified")
+			}
+		})
+	}
+}
+
+func TestRecordOtpLoginFailedAttempts(t *testing.T) {
+	testApp, _ := tests.NewTestApp()
+	defer testApp.Cleanup()
+
+	collection, err := testApp.Dao().FindCollectionByNameOrId("users")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	options := collection.AuthOptions()
+	options.AllowOtpAuth = true
+	collection.SetOptions(options)
+
+	otp := &models.Otp{CollectionId: collection.Id, RecordId: "4q1xlclmfloku33"}
+	otp.SetPassword("123456")
+	if err := testApp.Dao().SaveOtp(otp); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < models.OtpMaxAttempts; i++ {
+		form := forms.NewRecordOtpLogin(testApp, collection)
+		form.OtpId = otp.Id
+		form.Password = "000000"
+		if _, err := form.Submit(); err == nil {
+			t.Fatalf("(%d) Expected invalid password error", i)
+		}
+	}
+
+	// the correct password should be no longer accepted
+	form := forms.NewRecordOtpLogin(testApp, collection)
+	form.OtpId = otp.Id
+	form.Password = "123456"
+	if _, err := form.Submit(); err == nil {
+		t.Fatal("Expected the otp to be rejected after too many failed attempts")
+	}
+
+	if _, err := testApp.Dao().FindOtpById(otp.Id); err == nil {
+		t.Fatal("Expected the exhausted otp to be deleted")
+	}
+}
@@ -0,0 +1,131 @@
+package forms
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/mails"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+// RecordOtpRequest is an auth record email one-time password request form.
+type RecordOtpRequest struct {
+	app             core.App
+	dao             *daos.Dao
+	collection      *models.Collection
+	resendThreshold float64 // in seconds
+
+	Email string `form:"email" json:"email"`
+}
+
+// NewRecordOtpRequest creates a new [RecordOtpRequest]
+// form initialized with from the provided [core.App] instance.
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewRecordOtpRequest(app core.App, collection *models.Collection) *RecordOtpRequest {
+	return &RecordOtpRequest{
+		app:             app,
+		dao:             app.Dao(),
+		collection:      collection,
+		resendThreshold: 30,
+	}
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *RecordOtpRequest) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+//
+// This method doesn't checks whether auth record with `form.Email` exists (this is done on Submit).
+func (form *RecordOtpRequest) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(
+			&form.Email,
+			validation.Required,
+			validation.Length(1, 255),
+			is.EmailFormat,
+		),
+	)
+}
+
+// Submit validates and submits the form.
+//
+// On success, creates a new one-time password (invalidating the
+// previous ones) and sends it to the `form.Email` auth record.
+//
+// You can optionally provide a list of InterceptorFunc to further
+// modify the form behavior before persisting it.
+func (form *RecordOtpRequest) Submit(interceptors ...InterceptorFunc[*models.Record]) (*models.Otp, error) {
+	if err := form.Validate(); err != nil {
+		return nil, err
+	}
+
+	if !form.collection.AuthOptions().AllowOtpAuth {
+		return nil, errors.New("The collection is not configured to allow OTP authentication.")
+	}
+
+	authRecord, err := form.dao.FindAuthRecordByEmail(form.collection.Id, form.Email)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to fetch %s record with email %s: %w", form.collection.Id, form.Email, err)
+	}
+
+	lastOtp := &models.Otp{}
+	err = form.dao.OtpQuery().
+		AndWhere(dbx.HashExp{
+			"collectionId": authRecord.Collection().Id,
+			"recordId":     authRecord.Id,
+		}).
+		OrderBy("created DESC").
+		Limit(1).
+		One(lastOtp)
+	if err == nil && time.Since(lastOtp.Created.Time()).Seconds() < form.resendThreshold {
+		return nil, errors.New("You've already requested a one-time password.")
+	}
+
+	otp := &models.Otp{}
+
+	interceptorsErr := runInterceptors(authRecord, func(m *models.Record) error {
+		password := security.RandomStringWithAlphabet(form.collection.OtpLength(), "0123456789")
+
+		otp.CollectionId = m.Collection().Id
+		otp.RecordId = m.Id
+		if err := otp.SetPassword(password); err != nil {
+			return err
+		}
+
+		txErr := form.dao.RunInTransaction(func(txDao *daos.Dao) error {
+			// cleanup the expired otps
+			if err := txDao.DeleteOldOtps(time.Now().Add(-models.MaxOtpDuration * time.Second)); err != nil {
+				return err
+			}
+
+			// only the last requested otp is valid
+			if err := txDao.DeleteAllOtpsByRecord(m); err != nil {
+				return err
+			}
+
+			return txDao.SaveOtp(otp)
+		})
+		if txErr != nil {
+			return txErr
+		}
+
+		return mails.SendRecordOtp(form.app, m, otp.Id, password)
+	}, interceptors...)
+
+	if interceptorsErr != nil {
+		return nil, interceptorsErr
+	}
+
+	return otp, nil
+}
@@ -0,0 +1,166 @@
+package forms_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestRecordOtpRequestSubmit(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		jsonData      string
+		allowOtp      bool
+		lastOtpAge    time.Duration // 0 for no previous otp
+		expectError   bool
+		expectedEmail string
+	}{
+		{
+			name:        "empty data",
+			jsonData:    `{}`,
+			allowOtp:    true,
+			expectError: true,
+		},
+		{
+			name:        "invalid email format",
+			jsonData:    `{"email":"invalid"}`,
+			allowOtp:    true,
+			expectError: true,
+		},
+		{
+			name:        "disabled otp auth",
+			jsonData:    `{"email":"test@example.com"}`,
+			expectError: true,
+		},
+		{
+			name:        "missing auth record",
+			jsonData:    `{"email":"missing@example.com"}`,
+			allowOtp:    true,
+			expectError: true,
+		},
+		{
+			name:        "recently requested otp",
+			jsonData:    `{"email":"test@example.com"}`,
+			allowOtp:    true,
+			lastOtpAge:  10 * time.Second,
+			expectError: true,
+		},
+		{
+			name:          "previous otp after the resend threshold",
+			jsonData:      `{"email":"test@example.com"}`,
+			allowOtp:      true,
+			lastOtpAge:    time.Minute,
+			expectedEmail: "test@example.com",
+		},
+		{
+			name:          "valid email",
+			jsonData:      `{"email":"test@example.com"}`,
+			allowOtp:      true,
+			expectedEmail: "test@example.com",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			testApp, _ := tests.NewTestApp()
+			defer testApp.Cleanup()
+
+			collection, err := testApp.Dao().FindCollectionByNameOrId("users")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			options := collection.AuthOptions()
+			options.AllowOtpAuth = s.allowOtp
+			collection.SetOptions(options)
+
+			var lastOtp *models.Otp
+			if s.lastOtpAge > 0 {
+				lastOtp = &models.Otp{CollectionId: collection.Id, RecordId: "4q1xlclmfloku33"}
+				lastOtp.SetPassword("123456")
+				lastOtp.Created, _ = types.ParseDateTime(time.Now().Add(-s.lastOtpAge))
+				if err := testApp.Dao().SaveOtp(lastOtp); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var password string
+			testApp.OnMailerBeforeRecordOtpSend().Add(func(e *core.MailerRecordEvent) error {
+				password, _ = e.Meta["password"].(string)
+				return nil
+			})
+
+			form := forms.NewRecordOtpRequest(testApp, collection)
+
+			// load data
+			loadErr := json.Unmarshal([]byte(s.jsonData), form)
+			if loadErr != nil {
+				t.Fatalf("Failed to load form data: %v", loadErr)
+			}
+
+			interceptorCalls := 0
+			interceptor := func(next forms.InterceptorNextFunc[*models.Record]) forms.InterceptorNextFunc[*models.Record] {
+				return func(r *models.Record) error {
+					interceptorCalls++
+					return next(r)
+				}
+			}
+
+			otp, err := form.Submit(interceptor)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			expectInterceptorCalls := 1
+			if s.expectError {
+				expectInterceptorCalls = 0
+			}
+			if interceptorCalls != expectInterceptorCalls {
+				t.Fatalf("Expected interceptor to be called %d, got %d", expectInterceptorCalls, interceptorCalls)
+			}
+
+			if hasErr {
+				if testApp.TestMailer.TotalSend != 0 {
+					t.Fatalf("Expected no sent emails, got %d", testApp.TestMailer.TotalSend)
+				}
+				return
+			}
+
+			if testApp.TestMailer.TotalSend != 1 {
+				t.Fatalf("Expected 1 sent email, got %d", testApp.TestMailer.TotalSend)
+			}
+
+			if to := testApp.TestMailer.LastMessage.To[0].Address; to != s.expectedEmail {
+				t.Fatalf("Expected email to be sent to %q, got %q", s.expectedEmail, to)
+			}
+
+			if len(password) != collection.OtpLength() {
+				t.Fatalf("Expected password with length %d, got %q", collection.OtpLength(), password)
+			}
+
+			saved, err := testApp.Dao().FindOtpById(otp.Id)
+			if err != nil {
+				t.Fatalf("Expected the otp to be persisted, got %v", err)
+			}
+
+			if !saved.ValidatePassword(password) {
+				t.Fatal("Expected the sent password to match the persisted otp")
+			}
+
+			// the previous otp should be invalidated
+			if lastOtp != nil {
+				if _, err := testApp.Dao().FindOtpById(lastOtp.Id); err == nil {
+					t.Fatal("Expected the previous otp to be deleted")
+				}
+			}
+		})
+	}
+}
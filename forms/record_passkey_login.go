@@ -0,0 +1,151 @@
+package forms
+
+import (
+	"database/sql"
+	"encoding/base64"
+	"errors"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/types"
+	"github.com/pocketbase/pocketbase/tools/webauthn"
+)
+
+// RecordPasskeyLogin is an auth record passkey login form.
+type RecordPasskeyLogin struct {
+	app        core.App
+	dao        *daos.Dao
+	collection *models.Collection
+
+	// Token is the authentication challenge token
+	// (see [tokens.NewPasskeyChallengeToken]).
+	Token string `form:"token" json:"token"`
+
+	Credential PasskeyAssertion `form:"credential" json:"credential"`
+}
+
+// NewRecordPasskeyLogin creates a new [RecordPasskeyLogin] form initialized
+// with from the provided [core.App] and [models.Collection] instance.
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewRecordPasskeyLogin(app core.App, collection *models.Collection) *RecordPasskeyLogin {
+	return &RecordPasskeyLogin{
+		app:        app,
+		dao:        app.Dao(),
+		collection: collection,
+	}
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *RecordPasskeyLogin) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *RecordPasskeyLogin) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(&form.Token, validation.Required),
+		validation.Field(&form.Credential),
+	)
+}
+
+// Submit validates and submits the form.
+// On success returns the authorized record model.
+//
+// You can optionally provide a list of InterceptorFunc to
+// further modify the form behavior before persisting it.
+func (form *RecordPasskeyLogin) Submit(interceptors ...InterceptorFunc[*models.Record]) (*models.Record, error) {
+	if err := form.Validate(); err != nil {
+		return nil, err
+	}
+
+	if !form.collection.AuthOptions().AllowPasskeyAuth {
+		return nil, errPasskeyAuthDisabled
+	}
+
+	challenge, err := passkeyChallengeFromToken(form.app, form.collection, nil, form.Token)
+	if err != nil {
+		return nil, validation.Errors{"token": err}
+	}
+
+	var authRecord *models.Record
+
+	passkey, fetchErr := form.dao.FindPasskeyByCredentialId(
+		form.collection.Id,
+		normalizePasskeyCredentialId(form.Credential.Id),
+	)
+	if fetchErr == nil {
+		authRecord, fetchErr = form.dao.FindRecordById(form.collection.Id, passkey.RecordId)
+	}
+
+	// ignore not found errors to allow custom fetch implementations
+	if fetchErr != nil && !errors.Is(fetchErr, sql.ErrNoRows) {
+		return nil, fetchErr
+	}
+
+	interceptorsErr := runInterceptors(authRecord, func(m *models.Record) error {
+		authRecord = m
+
+		if authRecord == nil || passkey == nil || passkey.RecordId != authRecord.Id {
+			return errors.New("Invalid login credentials.")
+		}
+
+		return form.verifyAssertion(passkey, challenge)
+	}, interceptors...)
+
+	if interceptorsErr != nil {
+		return nil, interceptorsErr
+	}
+
+	return authRecord, nil
+}
+
+// verifyAssertion checks the form credential signature with the
+// provided passkey public key and updates its usage state.
+func (form *RecordPasskeyLogin) verifyAssertion(passkey *models.Passkey, challenge string) error {
+	invalidErr := errors.New("Invalid login credentials.")
+
+	// the user handle is the auth record id (if returned by the authenticator)
+	if form.Credential.Response.UserHandle != "" {
+		userHandle, _ := webauthn.DecodeBase64(form.Credential.Response.UserHandle)
+		if string(userHandle) != passkey.RecordId {
+			return invalidErr
+		}
+	}
+
+	publicKey, err := base64.RawURLEncoding.DecodeString(passkey.PublicKey)
+	if err != nil {
+		return invalidErr
+	}
+
+	clientDataJSON, _ := webauthn.DecodeBase64(form.Credential.Response.ClientDataJSON)
+	authenticatorData, _ := webauthn.DecodeBase64(form.Credential.Response.AuthenticatorData)
+	signature, _ := webauthn.DecodeBase64(form.Credential.Response.Signature)
+
+	authData, err := PasskeyConfig(form.app, form.collection).VerifyAssertion(
+		challenge,
+		clientDataJSON,
+		authenticatorData,
+		signature,
+		publicKey,
+	)
+	if err != nil {
+		return invalidErr
+	}
+
+	// a non-increasing signature counter indicates a possibly cloned
+	// authenticator (zero counters are allowed for authenticators
+	// that doesn't support them, eg. the synced passkeys)
+	signCount := int64(authData.SignCount)
+	if (signCount > 0 || passkey.SignCount > 0) && signCount <= passkey.SignCount {
+		return invalidErr
+	}
+
+	passkey.SignCount = signCount
+	passkey.LastUsed = types.NowDateTime()
+
+	return form.dao.SavePasskey(passkey)
+}
@@ -0,0 +1,116 @@
+package forms
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/pocketbase/pocketbase/tools/webauthn"
+)
+
+// RecordPasskeyRegister is an auth record passkey registration confirmation form.
+type RecordPasskeyRegister struct {
+	app    core.App
+	dao    *daos.Dao
+	record *models.Record
+
+	// Token is the registration challenge token
+	// (see [tokens.NewPasskeyChallengeToken]).
+	Token string `form:"token" json:"token"`
+
+	// Name is an optional user friendly label of the new passkey.
+	Name string `form:"name" json:"name"`
+
+	Credential PasskeyAttestation `form:"credential" json:"credential"`
+}
+
+// NewRecordPasskeyRegister creates a new [RecordPasskeyRegister] form
+// initialized with from the provided [core.App] and [models.Record] instances.
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewRecordPasskeyRegister(app core.App, record *models.Record) *RecordPasskeyRegister {
+	return &RecordPasskeyRegister{
+		app:    app,
+		dao:    app.Dao(),
+		record: record,
+	}
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *RecordPasskeyRegister) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *RecordPasskeyRegister) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(&form.Token, validation.Required),
+		validation.Field(&form.Name, validation.Length(0, 100)),
+		validation.Field(&form.Credential),
+	)
+}
+
+// Submit validates and submits the form.
+// On success returns the newly registered passkey.
+//
+// You can optionally provide a list of InterceptorFunc to
+// further modify the form behavior before persisting it.
+func (form *RecordPasskeyRegister) Submit(interceptors ...InterceptorFunc[*models.Passkey]) (*models.Passkey, error) {
+	if err := form.Validate(); err != nil {
+		return nil, err
+	}
+
+	collection := form.record.Collection()
+
+	if !collection.AuthOptions().AllowPasskeyAuth {
+		return nil, errPasskeyAuthDisabled
+	}
+
+	challenge, err := passkeyChallengeFromToken(form.app, collection, form.record, form.Token)
+	if err != nil {
+		return nil, validation.Errors{"token": err}
+	}
+
+	clientDataJSON, _ := webauthn.DecodeBase64(form.Credential.Response.ClientDataJSON)
+	attestationObject, _ := webauthn.DecodeBase64(form.Credential.Response.AttestationObject)
+
+	credential, err := PasskeyConfig(form.app, collection).VerifyRegistration(challenge, clientDataJSON, attestationObject)
+	if err != nil {
+		return nil, validation.Errors{"credential": errInvalidPasskeyCredential}
+	}
+
+	credentialId := base64.RawURLEncoding.EncodeToString(credential.Id)
+	if !security.Equal(credentialId, normalizePasskeyCredentialId(form.Credential.Id)) {
+		return nil, validation.Errors{"credential": errInvalidPasskeyCredential}
+	}
+
+	if _, err := form.dao.FindPasskeyByCredentialId(collection.Id, credentialId); err == nil {
+		return nil, errors.New("The passkey is already registered.")
+	}
+
+	passkey := &models.Passkey{
+		CollectionId: collection.Id,
+		RecordId:     form.record.Id,
+		Name:         form.Name,
+		CredentialId: credentialId,
+		PublicKey:    base64.RawURLEncoding.EncodeToString(credential.PublicKey),
+		SignCount:    int64(credential.SignCount),
+		AAGUID:       hex.EncodeToString(credential.AAGUID),
+	}
+
+	interceptorsErr := runInterceptors(passkey, func(m *models.Passkey) error {
+		return form.dao.SavePasskey(m)
+	}, interceptors...)
+
+	if interceptorsErr != nil {
+		return nil, interceptorsErr
+	}
+
+	return passkey, nil
+}
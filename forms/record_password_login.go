@@ -8,6 +8,7 @@ import (
 	"github.com/go-ozzo/ozzo-validation/v4/is"
 	"github.com/pocketbase/pocketbase/core"
 	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/forms/validators"
 	"github.com/pocketbase/pocketbase/models"
 )
 
@@ -19,6 +20,10 @@ type RecordPasswordLogin struct {
 
 	Identity string `form:"identity" json:"identity"`
 	Password string `form:"password" json:"password"`
+
+	// Otp is the TOTP or recovery code of the auth record
+	// enabled MFA factor (required only if the auth record has one).
+	Otp string `form:"otp" json:"otp"`
 }
 
 // NewRecordPasswordLogin creates a new [RecordPasswordLogin] form initialized
@@ -44,6 +49,7 @@ func (form *RecordPasswordLogin) Validate() error {
 	return validation.ValidateStruct(form,
 		validation.Field(&form.Identity, validation.Required, validation.Length(1, 255)),
 		validation.Field(&form.Password, validation.Required, validation.Length(1, 255)),
+		validation.Field(&form.Otp, validation.Length(0, 100)),
 	)
 }
 
@@ -84,7 +90,11 @@ func (form *RecordPasswordLogin) Submit(interceptors ...InterceptorFunc[*models.
 			return errors.New("Invalid login credentials.")
 		}
 
-		return nil
+		if err := form.checkPasswordPolicy(authOptions); err != nil {
+			return err
+		}
+
+		return form.checkMfa(authRecord)
 	}, interceptors...)
 
 	if interceptorsErr != nil {
@@ -93,3 +103,66 @@ func (form *RecordPasswordLogin) Submit(interceptors ...InterceptorFunc[*models.
 
 	return authRecord, nil
 }
+
+// checkPasswordPolicy checks the form password against the collection
+// password policy when it is configured to be enforced on login
+// (eg. to force the auth records with weak passwords to reset them).
+func (form *RecordPasswordLogin) checkPasswordPolicy(authOptions models.CollectionAuthOptions) error {
+	if !authOptions.PasswordCheckOnLogin {
+		return nil
+	}
+
+	if err := validators.PasswordPolicy(authOptions)(form.Password); err != nil {
+		return validation.Errors{"password": err}
+	}
+
+	return nil
+}
+
+// checkMfa verifies the form Otp code against the enabled MFA factors
+// of the provided auth record (if the collection MFA is enabled).
+//
+// Returns [ErrMfaSetupRequired] if the collection requires MFA
+// but the auth record doesn't have an enabled factor.
+func (form *RecordPasswordLogin) checkMfa(authRecord *models.Record) error {
+	return checkRecordMfa(form.dao, form.collection, authRecord, "otp", form.Otp)
+}
+
+// checkRecordMfa verifies the provided code against the enabled MFA factors
+// of the auth record (if the collection MFA is enabled).
+//
+// The field argument is the name of the form field reported in the validation errors.
+func checkRecordMfa(dao *daos.Dao, collection *models.Collection, authRecord *models.Record, field string, code string) error {
+	mode := collection.AuthOptions().MfaMode
+	if mode == models.MfaModeDisabled {
+		return nil
+	}
+
+	factors, err := dao.FindEnabledMfaFactors(authRecord)
+	if err != nil {
+		return err
+	}
+
+	if len(factors) == 0 {
+		if mode == models.MfaModeRequired {
+			return ErrMfaSetupRequired
+		}
+		return nil
+	}
+
+	if code == "" {
+		return validation.Errors{field: validation.NewError("validation_mfa_required", "Missing MFA code.")}
+	}
+
+	for _, factor := range factors {
+		valid, err := verifyMfaCode(dao, factor, code)
+		if err != nil {
+			return err
+		}
+		if valid {
+			return nil
+		}
+	}
+
+	return validation.Errors{field: errInvalidMfaCode}
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"testing"
 
+	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/pocketbase/pocketbase/forms"
 	"github.com/pocketbase/pocketbase/models"
 	"github.com/pocketbase/pocketbase/tests"
@@ -180,3 +181,48 @@ func TestRecordPasswordLoginInterceptors(t *testing.T) {
 		t.Fatalf("Expected auth Record model with email %s, got %v", form.Identity, interceptorRecord)
 	}
 }
+
+func TestRecordPasswordLoginPasswordPolicy(t *testing.T) {
+	scenarios := []struct {
+		name         string
+		checkOnLogin bool
+		expectError  bool
+	}{
+		{"policy not enforced on login", false, false},
+		{"policy enforced on login", true, true},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			testApp, _ := tests.NewTestApp()
+			defer testApp.Cleanup()
+
+			authCollection, err := testApp.Dao().FindCollectionByNameOrId("users")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// the test user password "1234567890" doesn't have an uppercase letter
+			options := authCollection.AuthOptions()
+			options.PasswordRequireUppercase = true
+			options.PasswordCheckOnLogin = s.checkOnLogin
+			authCollection.SetOptions(options)
+
+			form := forms.NewRecordPasswordLogin(testApp, authCollection)
+			form.Identity = "test@example.com"
+			form.Password = "1234567890"
+
+			_, err = form.Submit()
+
+			errs, _ := err.(validation.Errors)
+			_, hasErr := errs["password"]
+			if hasErr != s.expectError {
+				t.Fatalf("Expected password error %v, got %v", s.expectError, err)
+			}
+
+			if !s.expectError && err != nil {
+				t.Fatalf("Expected nil error, got %v", err)
+			}
+		})
+	}
+}
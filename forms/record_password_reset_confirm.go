@@ -39,11 +39,16 @@ func (form *RecordPasswordResetConfirm) SetDao(dao *daos.Dao) {
 
 // Validate makes the form validatable by implementing [validation.Validatable] interface.
 func (form *RecordPasswordResetConfirm) Validate() error {
-	minPasswordLength := form.collection.AuthOptions().MinPasswordLength
+	authOptions := form.collection.AuthOptions()
 
 	return validation.ValidateStruct(form,
 		validation.Field(&form.Token, validation.Required, validation.By(form.checkToken)),
-		validation.Field(&form.Password, validation.Required, validation.Length(minPasswordLength, 100)),
+		validation.Field(
+			&form.Password,
+			validation.Required,
+			validation.Length(authOptions.MinPasswordLength, 100),
+			validation.By(validators.PasswordPolicy(authOptions)),
+		),
 		validation.Field(&form.PasswordConfirm, validation.Required, validation.By(validators.Compare(form.Password))),
 	)
 }
@@ -57,6 +62,7 @@ func (form *RecordPasswordResetConfirm) checkToken(value any) error {
 	record, err := form.dao.FindAuthRecordByToken(
 		v,
 		form.app.Settings().RecordPasswordResetToken.Secret,
+		form.app.Settings().RecordPasswordResetToken.PreviousSecrets()...,
 	)
 	if err != nil || record == nil {
 		return validation.NewError("validation_invalid_token", "Invalid or expired token.")
@@ -82,6 +88,7 @@ func (form *RecordPasswordResetConfirm) Submit(interceptors ...InterceptorFunc[*
 	authRecord, err := form.dao.FindAuthRecordByToken(
 		form.Token,
 		form.app.Settings().RecordPasswordResetToken.Secret,
+		form.app.Settings().RecordPasswordResetToken.PreviousSecrets()...,
 	)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,422 @@
+package forms
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/forms/validators"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tokens"
+	"github.com/pocketbase/pocketbase/tools/filesystem"
+	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/pocketbase/pocketbase/tools/types"
+	"github.com/spf13/cast"
+)
+
+var errInvalidUploadToken = validation.NewError("validation_invalid_upload_token", "Invalid or expired upload token.")
+
+// PresignedUpload defines a pre-signed direct storage upload.
+type PresignedUpload struct {
+	// Url is the pre-signed storage url to which the file should be uploaded.
+	Url string `json:"url"`
+
+	// Method is the upload request HTTP method.
+	Method string `json:"method"`
+
+	// Headers are the headers that the upload request must have.
+	Headers map[string]string `json:"headers"`
+
+	// Filename is the name with which the file will be stored.
+	Filename string `json:"filename"`
+
+	// Token is the upload token that must be submitted after the
+	// upload completes in order to attach the file to the record.
+	Token string `json:"token"`
+
+	// Expires is the upload url and token expiration date.
+	Expires types.DateTime `json:"expires"`
+}
+
+// RecordPresignedUploadRequest is a record file field
+// direct storage (aka. pre-signed) upload request form.
+type RecordPresignedUploadRequest struct {
+	app    core.App
+	record *models.Record
+
+	Field       string `form:"field" json:"field"`
+	Filename    string `form:"filename" json:"filename"`
+	ContentType string `form:"contentType" json:"contentType"`
+	Size        int64  `form:"size" json:"size"`
+}
+
+// NewRecordPresignedUploadRequest creates a new [RecordPresignedUploadRequest]
+// form initialized with from the provided [core.App] and [models.Record] instances.
+func NewRecordPresignedUploadRequest(app core.App, record *models.Record) *RecordPresignedUploadRequest {
+	return &RecordPresignedUploadRequest{
+		app:    app,
+		record: record,
+	}
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *RecordPresignedUploadRequest) Validate() error {
+	options := presignedUploadFieldOptions(form.record, form.Field)
+
+	return validation.ValidateStruct(form,
+		validation.Field(
+			&form.Field,
+			validation.Required,
+			validation.By(form.checkField(options)),
+		),
+		validation.Field(&form.Filename, validation.Required, validation.Length(1, 255)),
+		validation.Field(
+			&form.ContentType,
+			validation.Required,
+			validation.Length(1, 255),
+			validation.When(options != nil, validation.By(form.checkContentType(options))),
+		),
+		validation.Field(
+			&form.Size,
+			validation.Required,
+			validation.Min(int64(1)),
+			validation.When(options != nil, validation.By(form.checkSize(options))),
+		),
+	)
+}
+
+func (form *RecordPresignedUploadRequest) checkField(options *schema.FileOptions) validation.RuleFunc {
+	return func(value any) error {
+		if options == nil {
+			return validation.NewError("validation_invalid_file_field", "Missing or invalid file field.")
+		}
+
+		return checkPresignedUploadQuota(form.record, form.Field, options)
+	}
+}
+
+func (form *RecordPresignedUploadRequest) checkContentType(options *schema.FileOptions) validation.RuleFunc {
+	return func(value any) error {
+		v, _ := value.(string)
+
+		if len(options.MimeTypes) > 0 && !list.ExistInSlice(v, options.MimeTypes) {
+			return validation.NewError("validation_invalid_mime_type", "Unsupported file type.")
+		}
+
+		if list.ExistInSlice(v, options.DeniedMimeTypes) {
+			return validation.NewError("validation_invalid_mime_type", "Unsupported file type.")
+		}
+
+		return nil
+	}
+}
+
+func (form *RecordPresignedUploadRequest) checkSize(options *schema.FileOptions) validation.RuleFunc {
+	return func(value any) error {
+		v, _ := value.(int64)
+
+		if v > int64(options.MaxSize) {
+			return validation.NewError(
+				"validation_file_size_limit",
+				fmt.Sprintf("The maximum allowed file size is %v bytes.", options.MaxSize),
+			)
+		}
+
+		return nil
+	}
+}
+
+// Submit validates the form and generates a new pre-signed upload url
+// and upload token for the record file field.
+//
+// Note that the app storage must support pre-signed urls (aka. S3).
+func (form *RecordPresignedUploadRequest) Submit() (*PresignedUpload, error) {
+	if err := form.Validate(); err != nil {
+		return nil, err
+	}
+
+	filename := filesystem.NewFileName(form.Filename)
+
+	fs, err := form.app.NewFilesystem()
+	if err != nil {
+		return nil, err
+	}
+	defer fs.Close()
+
+	expiry := tokens.RecordUploadTokenDuration * time.Second
+
+	url, err := fs.SignedUploadUrl(form.record.BaseFilesPath()+"/"+filename, form.ContentType, expiry)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := tokens.NewRecordUploadToken(
+		form.app,
+		form.record,
+		form.Field,
+		filename,
+		form.ContentType,
+		form.Size,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	expires, _ := types.ParseDateTime(time.Now().Add(expiry))
+
+	return &PresignedUpload{
+		Url:      url,
+		Method:   http.MethodPut,
+		Headers:  map[string]string{"Content-Type": form.ContentType},
+		Filename: filename,
+		Token:    token,
+		Expires:  expires,
+	}, nil
+}
+
+// -------------------------------------------------------------------
+
+// RecordPresignedUploadFinalize is a record file field
+// direct storage (aka. pre-signed) upload finalize form.
+type RecordPresignedUploadFinalize struct {
+	app    core.App
+	dao    *daos.Dao
+	record *models.Record
+
+	Token string `form:"token" json:"token"`
+}
+
+// NewRecordPresignedUploadFinalize creates a new [RecordPresignedUploadFinalize]
+// form initialized with from the provided [core.App] and [models.Record] instances.
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewRecordPresignedUploadFinalize(app core.App, record *models.Record) *RecordPresignedUploadFinalize {
+	return &RecordPresignedUploadFinalize{
+		app:    app,
+		dao:    app.Dao(),
+		record: record,
+	}
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *RecordPresignedUploadFinalize) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *RecordPresignedUploadFinalize) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(&form.Token, validation.Required, validation.By(form.checkToken)),
+	)
+}
+
+func (form *RecordPresignedUploadFinalize) checkToken(value any) error {
+	v, _ := value.(string)
+	if v == "" {
+		return nil // nothing to check
+	}
+
+	if _, err := form.parseToken(v); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// presignedUploadClaims defines the verified upload token claims.
+type presignedUploadClaims struct {
+	field    string
+	filename string
+	size     int64
+}
+
+func (form *RecordPresignedUploadFinalize) parseToken(token string) (*presignedUploadClaims, error) {
+	collection := form.record.Collection()
+
+	claims, err := security.ParseJWT(token, collection.Id+form.app.Settings().RecordFileToken.Secret)
+	if err != nil {
+		return nil, errInvalidUploadToken
+	}
+
+	result := &presignedUploadClaims{
+		field:    cast.ToString(claims["field"]),
+		filename: cast.ToString(claims["filename"]),
+		size:     cast.ToInt64(claims["size"]),
+	}
+
+	if cast.ToString(claims["type"]) != tokens.TypeRecordUpload ||
+		cast.ToString(claims["collectionId"]) != collection.Id ||
+		cast.ToString(claims["recordId"]) != form.record.Id ||
+		result.field == "" ||
+		result.filename == "" {
+		return nil, errInvalidUploadToken
+	}
+
+	return result, nil
+}
+
+// Submit validates the uploaded file against the token
+// and field options and attaches it to the record.
+//
+// On validation failure the uploaded file is deleted.
+//
+// You can optionally provide a list of InterceptorFunc to
+// further modify the form behavior before persisting it.
+func (form *RecordPresignedUploadFinalize) Submit(interceptors ...InterceptorFunc[*models.Record]) error {
+	if err := form.Validate(); err != nil {
+		return err
+	}
+
+	claims, err := form.parseToken(form.Token)
+	if err != nil {
+		return err
+	}
+
+	fs, err := form.app.NewFilesystem()
+	if err != nil {
+		return err
+	}
+	defer fs.Close()
+
+	fileKey := form.record.BaseFilesPath() + "/" + claims.filename
+
+	if list.ExistInSlice(claims.filename, form.record.GetStringSlice(claims.field)) {
+		return validation.Errors{"token": validation.NewError(
+			"validation_upload_already_finalized",
+			"The upload is already finalized.",
+		)}
+	}
+
+	attrs, err := fs.Attributes(fileKey)
+	if err != nil {
+		return validation.Errors{"token": validation.NewError(
+			"validation_missing_upload",
+			"Missing uploaded file.",
+		)}
+	}
+
+	file := &filesystem.File{
+		Reader:       &storageFileReader{fs: fs, key: fileKey},
+		Name:         claims.filename,
+		OriginalName: claims.filename,
+		Size:         attrs.Size,
+	}
+
+	if err := form.checkFile(file, claims); err != nil {
+		if deleteErr := fs.Delete(fileKey); deleteErr != nil {
+			form.app.Logger().Warn(
+				"Failed to delete the invalid pre-signed upload",
+				slog.String("key", fileKey),
+				slog.String("error", deleteErr.Error()),
+			)
+		}
+
+		return validation.Errors{"token": err}
+	}
+
+	return runInterceptors(form.record, func(record *models.Record) error {
+		options := presignedUploadFieldOptions(record, claims.field)
+
+		var replaced []string
+
+		if options.MaxSelect <= 1 {
+			replaced = list.SubtractSlice(record.GetStringSlice(claims.field), []string{claims.filename})
+			record.Set(claims.field, claims.filename)
+		} else {
+			record.Set(claims.field, append(record.GetStringSlice(claims.field), claims.filename))
+		}
+
+		if err := form.dao.SaveRecord(record); err != nil {
+			return err
+		}
+
+		// cleanup the replaced single file (if any)
+		for _, name := range replaced {
+			if err := fs.Delete(record.BaseFilesPath() + "/" + name); err != nil {
+				form.app.Logger().Warn(
+					"Failed to delete the replaced record file",
+					slog.String("recordId", record.Id),
+					slog.String("file", name),
+					slog.String("error", err.Error()),
+				)
+				continue
+			}
+
+			// try to delete the related file thumbs (if any)
+			fs.DeletePrefix(record.BaseFilesPath() + "/thumbs_" + name + "/")
+		}
+
+		return nil
+	}, interceptors...)
+}
+
+func (form *RecordPresignedUploadFinalize) checkFile(file *filesystem.File, claims *presignedUploadClaims) error {
+	options := presignedUploadFieldOptions(form.record, claims.field)
+	if options == nil {
+		return validation.NewError("validation_invalid_file_field", "Missing or invalid file field.")
+	}
+
+	if file.Size != claims.size {
+		return validation.NewError(
+			"validation_upload_size_mismatch",
+			"The uploaded file size doesn't match with the requested one.",
+		)
+	}
+
+	if err := checkPresignedUploadQuota(form.record, claims.field, options); err != nil {
+		return err
+	}
+
+	if err := validators.UploadedFileSize(options.MaxSize)(file); err != nil {
+		return err
+	}
+
+	return validators.UploadedFileContent(options)(file)
+}
+
+// presignedUploadFieldOptions returns the options of the
+// specified record file field or nil if it is not a file field.
+func presignedUploadFieldOptions(record *models.Record, fieldName string) *schema.FileOptions {
+	field := record.Collection().Schema.GetFieldByName(fieldName)
+	if field == nil || field.Type != schema.FieldTypeFile {
+		return nil
+	}
+
+	field.InitOptions()
+
+	options, _ := field.Options.(*schema.FileOptions)
+
+	return options
+}
+
+// checkPresignedUploadQuota checks whether the record file field can
+// store one more file (single file fields are always replaced).
+func checkPresignedUploadQuota(record *models.Record, fieldName string, options *schema.FileOptions) error {
+	if options.MaxSelect > 1 && len(record.GetStringSlice(fieldName)) >= options.MaxSelect {
+		return validation.NewError(
+			"validation_max_files_limit",
+			fmt.Sprintf("The field already has the maximum allowed %d files.", options.MaxSelect),
+		)
+	}
+
+	return nil
+}
+
+// storageFileReader implements [filesystem.FileReader] for an already stored file.
+type storageFileReader struct {
+	fs  *filesystem.System
+	key string
+}
+
+// Open implements [filesystem.FileReader] interface method.
+func (r *storageFileReader) Open() (io.ReadSeekCloser, error) {
+	return r.fs.GetFile(r.key)
+}
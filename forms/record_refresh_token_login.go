@@ -0,0 +1,107 @@
+package forms
+
+import (
+	"errors"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// RecordRefreshTokenLogin is an auth record refresh token exchange form.
+type RecordRefreshTokenLogin struct {
+	app        core.App
+	dao        *daos.Dao
+	collection *models.Collection
+
+	RefreshToken string `form:"refreshToken" json:"refreshToken"`
+}
+
+// NewRecordRefreshTokenLogin creates a new [RecordRefreshTokenLogin] form
+// initialized with from the provided [core.App] and [models.Collection] instance.
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewRecordRefreshTokenLogin(app core.App, collection *models.Collection) *RecordRefreshTokenLogin {
+	return &RecordRefreshTokenLogin{
+		app:        app,
+		dao:        app.Dao(),
+		collection: collection,
+	}
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *RecordRefreshTokenLogin) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *RecordRefreshTokenLogin) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(&form.RefreshToken, validation.Required, validation.Length(1, 255)),
+	)
+}
+
+// Submit validates and submits the form.
+//
+// On success the refresh token is marked as rotated (aka. it could be
+// exchanged only once) and the refresh token auth record is returned.
+//
+// Submitting an already rotated refresh token is treated as a token
+// theft and revokes all refresh tokens of the auth record.
+//
+// You can optionally provide a list of InterceptorFunc to
+// further modify the form behavior before persisting it.
+func (form *RecordRefreshTokenLogin) Submit(interceptors ...InterceptorFunc[*models.Record]) (*models.Record, error) {
+	if err := form.Validate(); err != nil {
+		return nil, err
+	}
+
+	if !form.collection.AuthOptions().AllowRefreshTokens {
+		return nil, errors.New("The collection is not configured to allow refresh tokens.")
+	}
+
+	invalidErr := validation.Errors{"refreshToken": validation.NewError(
+		"validation_invalid_refresh_token",
+		"Invalid or expired refresh token.",
+	)}
+
+	refreshToken, err := form.dao.FindRefreshTokenByToken(form.collection.Id, form.RefreshToken)
+	if err != nil {
+		return nil, invalidErr
+	}
+
+	authRecord, err := form.dao.FindRecordById(form.collection.Id, refreshToken.RecordId)
+	if err != nil {
+		form.dao.DeleteRefreshToken(refreshToken)
+		return nil, invalidErr
+	}
+
+	if refreshToken.IsRotated() {
+		// reused refresh token -> revoke the entire record tokens chain
+		if err := form.dao.DeleteAllRefreshTokensByRecord(authRecord); err != nil {
+			return nil, err
+		}
+		return nil, invalidErr
+	}
+
+	if refreshToken.HasExpired(form.collection.RefreshTokenDuration()) ||
+		!refreshToken.ValidateRecordKey(authRecord.TokenKey()) {
+		form.dao.DeleteRefreshToken(refreshToken)
+		return nil, invalidErr
+	}
+
+	interceptorsErr := runInterceptors(authRecord, func(m *models.Record) error {
+		authRecord = m
+		refreshToken.RotatedAt = types.NowDateTime()
+		return form.dao.SaveRefreshToken(refreshToken)
+	}, interceptors...)
+
+	if interceptorsErr != nil {
+		return nil, interceptorsErr
+	}
+
+	return authRecord, nil
+}
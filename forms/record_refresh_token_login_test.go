@@ -0,0 +1,169 @@
+package forms_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestRecordRefreshTokenLoginSubmit(t *testing.T) {
+	scenarios := []struct {
+		name              string
+		collection        string
+		allowRefresh      bool
+		token             string
+		rotated           bool
+		recordKey         string // empty for the current record token key
+		createdAgo        time.Duration
+		expectError       bool
+		expectOtherTokens bool // whether the other record refresh tokens should remain
+	}{
+		{
+			name:              "empty data",
+			collection:        "users",
+			allowRefresh:      true,
+			expectError:       true,
+			expectOtherTokens: true,
+		},
+		{
+			name:              "disabled refresh tokens",
+			collection:        "users",
+			token:             "test_token",
+			expectError:       true,
+			expectOtherTokens: true,
+		},
+		{
+			name:              "missing refresh token",
+			collection:        "users",
+			allowRefresh:      true,
+			token:             "missing",
+			expectError:       true,
+			expectOtherTokens: true,
+		},
+		{
+			name:              "refresh token from different collection",
+			collection:        "clients",
+			allowRefresh:      true,
+			token:             "test_token",
+			expectError:       true,
+			expectOtherTokens: true,
+		},
+		{
+			name:              "expired refresh token",
+			collection:        "users",
+			allowRefresh:      true,
+			token:             "test_token",
+			createdAgo:        (models.DefaultRefreshTokenDuration + 10) * time.Second,
+			expectError:       true,
+			expectOtherTokens: true,
+		},
+		{
+			name:              "changed record token key",
+			collection:        "users",
+			allowRefresh:      true,
+			token:             "test_token",
+			recordKey:         "old_key",
+			expectError:       true,
+			expectOtherTokens: true,
+		},
+		{
+			name:         "reused (already rotated) refresh token",
+			collection:   "users",
+			allowRefresh: true,
+			token:        "test_token",
+			rotated:      true,
+			expectError:  true,
+		},
+		{
+			name:              "valid refresh token",
+			collection:        "users",
+			allowRefresh:      true,
+			token:             "test_token",
+			expectOtherTokens: true,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			testApp, _ := tests.NewTestApp()
+			defer testApp.Cleanup()
+
+			collection, err := testApp.Dao().FindCollectionByNameOrId(s.collection)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			options := collection.AuthOptions()
+			options.AllowRefreshTokens = s.allowRefresh
+			collection.SetOptions(options)
+
+			user, err := testApp.Dao().FindRecordById("users", "4q1xlclmfloku33")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			recordKey := s.recordKey
+			if recordKey == "" {
+				recordKey = user.TokenKey()
+			}
+
+			refreshToken := &models.RefreshToken{CollectionId: user.Collection().Id, RecordId: user.Id}
+			refreshToken.SetToken("test_token")
+			refreshToken.SetRecordKey(recordKey)
+			refreshToken.Created, _ = types.ParseDateTime(time.Now().Add(-s.createdAgo))
+			if s.rotated {
+				refreshToken.RotatedAt = types.NowDateTime()
+			}
+			if err := testApp.Dao().SaveRefreshToken(refreshToken); err != nil {
+				t.Fatal(err)
+			}
+
+			otherToken := &models.RefreshToken{CollectionId: user.Collection().Id, RecordId: user.Id}
+			otherToken.SetToken("other_token")
+			otherToken.SetRecordKey(user.TokenKey())
+			if err := testApp.Dao().SaveRefreshToken(otherToken); err != nil {
+				t.Fatal(err)
+			}
+
+			form := forms.NewRecordRefreshTokenLogin(testApp, collection)
+			form.RefreshToken = s.token
+
+			record, err := form.Submit()
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			_, otherErr := testApp.Dao().FindRefreshTokenByToken(otherToken.CollectionId, "other_token")
+			if hasOther := otherErr == nil; hasOther != s.expectOtherTokens {
+				t.Fatalf("Expected the other refresh tokens to remain %v, got %v", s.expectOtherTokens, hasOther)
+			}
+
+			if hasErr {
+				return
+			}
+
+			if record.Id != user.Id {
+				t.Fatalf("Expected record %q, got %q", user.Id, record.Id)
+			}
+
+			rotated, err := testApp.Dao().FindRefreshTokenByToken(refreshToken.CollectionId, "test_token")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !rotated.IsRotated() {
+				t.Fatal("Expected the refresh token to be marked as rotated")
+			}
+
+			// the same refresh token shouldn't be accepted twice
+			if _, err := form.Submit(); err == nil {
+				t.Fatal("Expected the rotated refresh token to be rejected")
+			}
+		})
+	}
+}
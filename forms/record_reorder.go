@@ -0,0 +1,134 @@
+package forms
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tools/list"
+)
+
+// RecordReorder is a [models.Record] form that moves a single record
+// right before or after another record from the same position field scope.
+type RecordReorder struct {
+	app    core.App
+	dao    *daos.Dao
+	record *models.Record
+
+	// Field is the name of the record position field.
+	Field string `form:"field" json:"field"`
+
+	// Before is the id of the record that the moved record should be placed before.
+	Before string `form:"before" json:"before"`
+
+	// After is the id of the record that the moved record should be placed after.
+	After string `form:"after" json:"after"`
+}
+
+// NewRecordReorder creates a new [RecordReorder] form initialized with
+// from the provided [core.App] instance and [models.Record] model.
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewRecordReorder(app core.App, record *models.Record) *RecordReorder {
+	return &RecordReorder{
+		app:    app,
+		dao:    app.Dao(),
+		record: record,
+	}
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *RecordReorder) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *RecordReorder) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(
+			&form.Field,
+			validation.Required,
+			validation.In(list.ToInterfaceSlice(form.positionFieldNames())...),
+		),
+		validation.Field(
+			&form.Before,
+			validation.When(form.After == "", validation.Required),
+			validation.NotIn(form.record.Id).Error("The record cannot be moved relative to itself."),
+			validation.By(form.checkTarget),
+		),
+		validation.Field(
+			&form.After,
+			validation.When(form.Before != "", validation.Empty),
+			validation.NotIn(form.record.Id).Error("The record cannot be moved relative to itself."),
+			validation.By(form.checkTarget),
+		),
+	)
+}
+
+func (form *RecordReorder) checkTarget(value any) error {
+	v, _ := value.(string)
+	if v == "" {
+		return nil // nothing to check
+	}
+
+	target, _ := form.dao.FindRecordById(form.record.Collection().Id, v)
+	if target == nil {
+		return validation.NewError("validation_missing_reorder_target", "Missing or invalid target record.")
+	}
+
+	return nil
+}
+
+func (form *RecordReorder) positionFieldNames() []string {
+	names := []string{}
+
+	for _, field := range form.record.Collection().Schema.Fields() {
+		if field.Type == schema.FieldTypePosition {
+			names = append(names, field.Name)
+		}
+	}
+
+	return names
+}
+
+// MovedPosition validates the form and returns the new position field
+// value of the moved record without persisting it
+// (eg. to check the collection UpdateRule with the written value).
+func (form *RecordReorder) MovedPosition() (float64, error) {
+	if err := form.Validate(); err != nil {
+		return 0, err
+	}
+
+	targetId, placeBefore := form.target()
+
+	return form.dao.MovedRecordPosition(form.record, form.Field, targetId, placeBefore)
+}
+
+// target returns the target record id and whether
+// the record should be placed before it.
+func (form *RecordReorder) target() (string, bool) {
+	if form.After != "" {
+		return form.After, false
+	}
+
+	return form.Before, true
+}
+
+// Submit validates and submits the form.
+// On success the moved record position is updated.
+//
+// You can optionally provide a list of InterceptorFunc to
+// further modify the form behavior before persisting it.
+func (form *RecordReorder) Submit(interceptors ...InterceptorFunc[*models.Record]) error {
+	if err := form.Validate(); err != nil {
+		return err
+	}
+
+	targetId, placeBefore := form.target()
+
+	return runInterceptors(form.record, func(record *models.Record) error {
+		return form.dao.MoveRecordPosition(record, form.Field, targetId, placeBefore)
+	}, interceptors...)
+}
@@ -0,0 +1,226 @@
+package forms
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/saml"
+)
+
+// SamlProvider is the external auth provider name of the SAML linked auth records.
+const SamlProvider = "saml"
+
+// samlAssertionStorePrefix is the app store key prefix of the already used assertion ids.
+const samlAssertionStorePrefix = "@samlAssertion_"
+
+var samlAssertionsMu sync.Mutex
+
+// SamlResponseParser defines a SAML response verifier
+// (usually [saml.ServiceProvider]).
+type SamlResponseParser interface {
+	ParseResponse(encodedResponse string) (*saml.Assertion, error)
+}
+
+// SamlServiceProvider returns the SAML service provider of the provided
+// auth collection configured from the app SAML settings.
+//
+// The service provider entity id fallbacks to the collection SAML metadata url.
+func SamlServiceProvider(app core.App, collection *models.Collection) (*saml.ServiceProvider, error) {
+	config := app.Settings().Saml
+
+	idp, err := saml.ParseMetadata([]byte(config.IdpMetadata))
+	if err != nil {
+		return nil, err
+	}
+
+	baseUrl := strings.TrimRight(app.Settings().Meta.AppUrl, "/") + "/api/collections/" + url.PathEscape(collection.Name)
+
+	sp := &saml.ServiceProvider{
+		EntityId:         config.EntityId,
+		AcsUrl:           baseUrl + "/auth-with-saml",
+		IdentityProvider: idp,
+	}
+
+	if sp.EntityId == "" {
+		sp.EntityId = baseUrl + "/saml-metadata"
+	}
+
+	return sp, nil
+}
+
+// RecordSamlLoginData defines the RecordSamlLogin interceptors data.
+type RecordSamlLoginData struct {
+	ExternalAuth *models.ExternalAuth
+	Record       *models.Record
+	Assertion    *saml.Assertion
+}
+
+// RecordSamlLogin is an auth record SAML 2.0 (HTTP-POST binding) login form.
+type RecordSamlLogin struct {
+	app        core.App
+	dao        *daos.Dao
+	collection *models.Collection
+	parser     SamlResponseParser
+
+	SAMLResponse string `form:"SAMLResponse" json:"SAMLResponse"`
+}
+
+// NewRecordSamlLogin creates a new [RecordSamlLogin] form initialized
+// with from the provided [core.App] and [models.Collection] instance.
+//
+// The SAML response is verified with the service provider created from
+// the app SAML settings (you can replace it with [SetParser()]).
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewRecordSamlLogin(app core.App, collection *models.Collection) *RecordSamlLogin {
+	form := &RecordSamlLogin{
+		app:        app,
+		dao:        app.Dao(),
+		collection: collection,
+	}
+
+	if sp, err := SamlServiceProvider(app, collection); err == nil {
+		form.parser = sp
+	}
+
+	return form
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *RecordSamlLogin) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// SetParser replaces the default SAML response parser with the provided one.
+func (form *RecordSamlLogin) SetParser(parser SamlResponseParser) {
+	form.parser = parser
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *RecordSamlLogin) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(&form.SAMLResponse, validation.Required),
+	)
+}
+
+// Submit validates and submits the form.
+//
+// The SAML response assertion is verified and could be used only once.
+// If there is no auth record linked to the assertion subject, the form
+// links the auth record with the same email or creates a new one.
+//
+// You can optionally provide a list of InterceptorFunc to
+// further modify the form behavior before persisting it.
+//
+// On success returns the authorized auth record model.
+func (form *RecordSamlLogin) Submit(interceptors ...InterceptorFunc[*RecordSamlLoginData]) (*models.Record, error) {
+	if err := form.Validate(); err != nil {
+		return nil, err
+	}
+
+	config := form.app.Settings().Saml
+	if !config.Enabled || !config.IsCollection(form.collection.Id, form.collection.Name) || form.parser == nil {
+		return nil, errors.New("SAML authentication is not enabled for the auth collection.")
+	}
+
+	assertion, err := form.parser.ParseResponse(form.SAMLResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	if !markSamlAssertionAsUsed(form.app, assertion) {
+		return nil, errors.New("The SAML assertion was already used.")
+	}
+
+	authRecord, rel, err := findExternalIdentityRecord(form.dao, form.collection, form.identity(assertion))
+	if err != nil {
+		return nil, err
+	}
+
+	interceptorData := &RecordSamlLoginData{
+		ExternalAuth: rel,
+		Record:       authRecord,
+		Assertion:    assertion,
+	}
+
+	interceptorsErr := runInterceptors(interceptorData, func(newData *RecordSamlLoginData) error {
+		return form.submit(newData)
+	}, interceptors...)
+
+	if interceptorsErr != nil {
+		return nil, interceptorsErr
+	}
+
+	return interceptorData.Record, nil
+}
+
+func (form *RecordSamlLogin) submit(data *RecordSamlLoginData) error {
+	record, rel, err := saveExternalIdentityRecord(
+		form.app,
+		form.dao,
+		form.collection,
+		form.identity(data.Assertion),
+		data.Record,
+		data.ExternalAuth,
+	)
+	if err != nil {
+		return err
+	}
+
+	data.Record = record
+	data.ExternalAuth = rel
+
+	return nil
+}
+
+// identity returns the external identity of the provided assertion subject.
+func (form *RecordSamlLogin) identity(assertion *saml.Assertion) *externalIdentity {
+	config := form.app.Settings().Saml
+
+	return &externalIdentity{
+		provider:   SamlProvider,
+		providerId: assertion.NameId,
+		email:      assertion.Email(config.EmailAttribute),
+		username:   assertion.Attribute(config.UsernameAttribute),
+		fieldsData: mapExternalFields(form.collection, config.FieldsMapping, func(name string) (string, bool) {
+			values, ok := assertion.Attributes[name]
+			if !ok || len(values) == 0 {
+				return "", false
+			}
+			return values[0], true
+		}),
+	}
+}
+
+// markSamlAssertionAsUsed stores the provided assertion id until its expiration
+// and reports whether the assertion wasn't already used.
+func markSamlAssertionAsUsed(app core.App, assertion *saml.Assertion) bool {
+	samlAssertionsMu.Lock()
+	defer samlAssertionsMu.Unlock()
+
+	now := time.Now()
+
+	// cleanup the expired assertion ids
+	for key, value := range app.Store().GetAll() {
+		if expiresAt, ok := value.(time.Time); ok && strings.HasPrefix(key, samlAssertionStorePrefix) && now.After(expiresAt) {
+			app.Store().Remove(key)
+		}
+	}
+
+	key := samlAssertionStorePrefix + assertion.Id
+	if app.Store().Has(key) {
+		return false
+	}
+
+	app.Store().Set(key, assertion.ExpiresAt)
+
+	return true
+}
@@ -0,0 +1,279 @@
+package forms_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/saml"
+)
+
+type mockSamlParser struct {
+	assertion *saml.Assertion
+	err       error
+}
+
+func (m *mockSamlParser) ParseResponse(encodedResponse string) (*saml.Assertion, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	return m.assertion, nil
+}
+
+func TestRecordSamlLoginSubmit(t *testing.T) {
+	newAssertion := func(id string, attributes map[string][]string) *saml.Assertion {
+		return &saml.Assertion{
+			Id:           id,
+			NameId:       "test_name_id",
+			NameIdFormat: saml.NameIdFormatUnspecified,
+			ExpiresAt:    time.Now().Add(5 * time.Minute),
+			Attributes:   attributes,
+		}
+	}
+
+	scenarios := []struct {
+		name             string
+		collection       string
+		samlEnabled      bool
+		samlCollection   string
+		response         string
+		parser           *mockSamlParser
+		expectError      bool
+		expectRecordId   string // empty for new records
+		expectedEmail    string
+		expectedUsername string
+		expectedName     string
+	}{
+		{
+			name:           "empty data",
+			collection:     "users",
+			samlEnabled:    true,
+			samlCollection: "users",
+			parser:         &mockSamlParser{assertion: newAssertion("a1", nil)},
+			expectError:    true,
+		},
+		{
+			name:           "disabled saml auth",
+			collection:     "users",
+			samlCollection: "users",
+			response:       "test",
+			parser:         &mockSamlParser{assertion: newAssertion("a1", nil)},
+			expectError:    true,
+		},
+		{
+			name:           "different saml collection",
+			collection:     "users",
+			samlEnabled:    true,
+			samlCollection: "clients",
+			response:       "test",
+			parser:         &mockSamlParser{assertion: newAssertion("a1", nil)},
+			expectError:    true,
+		},
+		{
+			name:           "invalid response",
+			collection:     "users",
+			samlEnabled:    true,
+			samlCollection: "users",
+			response:       "test",
+			parser:         &mockSamlParser{err: saml.ErrInvalidResponse},
+			expectError:    true,
+		},
+		{
+			name:           "new identity provider user",
+			collection:     "users",
+			samlEnabled:    true,
+			samlCollection: "_pb_users_auth_",
+			response:       "test",
+			parser: &mockSamlParser{assertion: newAssertion("a1", map[string][]string{
+				"email":       {"saml_user@example.com"},
+				"username":    {"saml_user"},
+				"displayName": {"SAML User"},
+			})},
+			expectedEmail:    "saml_user@example.com",
+			expectedUsername: "saml_user",
+			expectedName:     "SAML User",
+		},
+		{
+			name:           "existing auth record with the assertion email",
+			collection:     "users",
+			samlEnabled:    true,
+			samlCollection: "users",
+			response:       "test",
+			parser: &mockSamlParser{assertion: newAssertion("a1", map[string][]string{
+				"email":       {"test@example.com"},
+				"username":    {"test"},
+				"displayName": {"Synced name"},
+			})},
+			expectRecordId:   "4q1xlclmfloku33",
+			expectedEmail:    "test@example.com",
+			expectedUsername: "users75657",
+			expectedName:     "Synced name",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			testApp, _ := tests.NewTestApp()
+			defer testApp.Cleanup()
+
+			testApp.Settings().Saml.Enabled = s.samlEnabled
+			testApp.Settings().Saml.Collection = s.samlCollection
+			testApp.Settings().Saml.UsernameAttribute = "username"
+			testApp.Settings().Saml.FieldsMapping = map[string]string{"name": "displayName"}
+
+			collection, err := testApp.Dao().FindCollectionByNameOrId(s.collection)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			form := forms.NewRecordSamlLogin(testApp, collection)
+			form.SetParser(s.parser)
+			form.SAMLResponse = s.response
+
+			record, err := form.Submit()
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if hasErr {
+				return
+			}
+
+			if s.expectRecordId != "" && record.Id != s.expectRecordId {
+				t.Fatalf("Expected record %q, got %q", s.expectRecordId, record.Id)
+			}
+
+			if record.Email() != s.expectedEmail {
+				t.Fatalf("Expected email %q, got %q", s.expectedEmail, record.Email())
+			}
+
+			if record.Username() != s.expectedUsername {
+				t.Fatalf("Expected username %q, got %q", s.expectedUsername, record.Username())
+			}
+
+			if !record.Verified() {
+				t.Fatal("Expected the record to be verified")
+			}
+
+			// check the persisted state
+			saved, err := testApp.Dao().FindRecordById(collection.Id, record.Id)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if name := saved.GetString("name"); name != s.expectedName {
+				t.Fatalf("Expected name %q, got %q", s.expectedName, name)
+			}
+
+			rel, err := testApp.Dao().FindFirstExternalAuthByExpr(dbx.HashExp{
+				"collectionId": collection.Id,
+				"recordId":     record.Id,
+				"provider":     forms.SamlProvider,
+				"providerId":   "test_name_id",
+			})
+			if err != nil || rel == nil {
+				t.Fatalf("Expected the assertion subject to be linked, got %v", err)
+			}
+
+			// the same assertion can't be used twice
+			replayForm := forms.NewRecordSamlLogin(testApp, collection)
+			replayForm.SetParser(s.parser)
+			replayForm.SAMLResponse = s.response
+			if _, err := replayForm.Submit(); err == nil {
+				t.Fatal("Expected the replayed assertion to be rejected")
+			}
+
+			// subsequent logins should resolve the same auth record
+			// through the external auth relation
+			s.parser.assertion.Id = "a2"
+			s.parser.assertion.Attributes["email"] = []string{"changed@example.com"}
+
+			form2 := forms.NewRecordSamlLogin(testApp, collection)
+			form2.SetParser(s.parser)
+			form2.SAMLResponse = s.response
+
+			record2, err := form2.Submit()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if record2.Id != record.Id {
+				t.Fatalf("Expected record %q, got %q", record.Id, record2.Id)
+			}
+		})
+	}
+}
+
+func TestRecordSamlLoginInterceptors(t *testing.T) {
+	testApp, _ := tests.NewTestApp()
+	defer testApp.Cleanup()
+
+	testApp.Settings().Saml.Enabled = true
+	testApp.Settings().Saml.Collection = "users"
+
+	collection, err := testApp.Dao().FindCollectionByNameOrId("users")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	form := forms.NewRecordSamlLogin(testApp, collection)
+	form.SetParser(&mockSamlParser{assertion: &saml.Assertion{
+		Id:         "test",
+		NameId:     "test_name_id",
+		ExpiresAt:  time.Now().Add(5 * time.Minute),
+		Attributes: map[string][]string{"email": {"test@example.com"}},
+	}})
+	form.SAMLResponse = "test"
+
+	var interceptorData *forms.RecordSamlLoginData
+	testErr := errors.New("test_error")
+
+	interceptor1Called := false
+	interceptor1 := func(next forms.InterceptorNextFunc[*forms.RecordSamlLoginData]) forms.InterceptorNextFunc[*forms.RecordSamlLoginData] {
+		return func(data *forms.RecordSamlLoginData) error {
+			interceptor1Called = true
+			return next(data)
+		}
+	}
+
+	interceptor2Called := false
+	interceptor2 := func(next forms.InterceptorNextFunc[*forms.RecordSamlLoginData]) forms.InterceptorNextFunc[*forms.RecordSamlLoginData] {
+		return func(data *forms.RecordSamlLoginData) error {
+			interceptorData = data
+			interceptor2Called = true
+			return testErr
+		}
+	}
+
+	_, submitErr := form.Submit(interceptor1, interceptor2)
+	if submitErr != testErr {
+		t.Fatalf("Expected submitError %v, got %v", testErr, submitErr)
+	}
+
+	if !interceptor1Called {
+		t.Fatalf("Expected interceptor1 to be called")
+	}
+
+	if !interceptor2Called {
+		t.Fatalf("Expected interceptor2 to be called")
+	}
+
+	if interceptorData == nil || interceptorData.Record == nil || interceptorData.Record.Id != "4q1xlclmfloku33" {
+		t.Fatalf("Expected auth record 4q1xlclmfloku33, got %v", interceptorData)
+	}
+
+	// the relation shouldn't be created if the interceptors chain fails
+	rel, _ := testApp.Dao().FindFirstExternalAuthByExpr(dbx.HashExp{
+		"collectionId": collection.Id,
+		"provider":     forms.SamlProvider,
+	})
+	if rel != nil {
+		t.Fatalf("Expected no saml external auth relation, got %v", rel)
+	}
+}
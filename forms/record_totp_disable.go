@@ -0,0 +1,74 @@
+package forms
+
+import (
+	"errors"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// RecordTOTPDisable is an auth record TOTP factor removal form.
+type RecordTOTPDisable struct {
+	app    core.App
+	dao    *daos.Dao
+	record *models.Record
+
+	// Code is a valid TOTP or unused recovery code.
+	Code string `form:"code" json:"code"`
+}
+
+// NewRecordTOTPDisable creates a new [RecordTOTPDisable] form
+// initialized with from the provided [core.App] and [models.Record] instances.
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewRecordTOTPDisable(app core.App, record *models.Record) *RecordTOTPDisable {
+	return &RecordTOTPDisable{
+		app:    app,
+		dao:    app.Dao(),
+		record: record,
+	}
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *RecordTOTPDisable) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *RecordTOTPDisable) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(&form.Code, validation.Required, validation.Length(1, 100)),
+	)
+}
+
+// Submit validates and submits the form.
+//
+// On success deletes the enabled TOTP factor of the form auth record.
+//
+// You can optionally provide a list of InterceptorFunc to
+// further modify the form behavior before persisting it.
+func (form *RecordTOTPDisable) Submit(interceptors ...InterceptorFunc[*models.MfaFactor]) error {
+	if err := form.Validate(); err != nil {
+		return err
+	}
+
+	factor, err := form.dao.FindMfaFactor(form.record, models.MfaFactorTypeTOTP)
+	if err != nil || !factor.Enabled {
+		return errors.New("The TOTP factor is not enabled.")
+	}
+
+	valid, err := verifyMfaCode(form.dao, factor, form.Code)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return validation.Errors{"code": errInvalidMfaCode}
+	}
+
+	return runInterceptors(factor, func(m *models.MfaFactor) error {
+		return form.dao.DeleteMfaFactor(m)
+	}, interceptors...)
+}
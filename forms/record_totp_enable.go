@@ -0,0 +1,89 @@
+package forms
+
+import (
+	"errors"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+// RecordTOTPEnable is an auth record TOTP factor enrollment confirmation form.
+type RecordTOTPEnable struct {
+	app    core.App
+	dao    *daos.Dao
+	record *models.Record
+
+	Code string `form:"code" json:"code"`
+}
+
+// NewRecordTOTPEnable creates a new [RecordTOTPEnable] form
+// initialized with from the provided [core.App] and [models.Record] instances.
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewRecordTOTPEnable(app core.App, record *models.Record) *RecordTOTPEnable {
+	return &RecordTOTPEnable{
+		app:    app,
+		dao:    app.Dao(),
+		record: record,
+	}
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *RecordTOTPEnable) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *RecordTOTPEnable) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(&form.Code, validation.Required, validation.Length(security.TOTPDigits, security.TOTPDigits)),
+	)
+}
+
+// Submit validates and submits the form.
+//
+// On success enables the pending TOTP factor of the form auth record
+// and returns its newly generated plain recovery codes.
+//
+// You can optionally provide a list of InterceptorFunc to
+// further modify the form behavior before persisting it.
+func (form *RecordTOTPEnable) Submit(interceptors ...InterceptorFunc[*models.MfaFactor]) ([]string, error) {
+	if err := form.Validate(); err != nil {
+		return nil, err
+	}
+
+	factor, err := form.dao.FindMfaFactor(form.record, models.MfaFactorTypeTOTP)
+	if err != nil {
+		return nil, errors.New("Missing TOTP factor setup.")
+	}
+
+	if factor.Enabled {
+		return nil, errors.New("The TOTP factor is already enabled.")
+	}
+
+	step := security.ValidateTOTPCode(factor.Secret, form.Code, time.Now(), mfaTOTPSkew)
+	if step < 0 {
+		return nil, validation.Errors{"code": errInvalidMfaCode}
+	}
+
+	codes, hashes := newMfaRecoveryCodes()
+
+	factor.Enabled = true
+	factor.LastUsedStep = step
+	factor.RecoveryCodes = hashes
+
+	interceptorsErr := runInterceptors(factor, func(m *models.MfaFactor) error {
+		return form.dao.SaveMfaFactor(m)
+	}, interceptors...)
+
+	if interceptorsErr != nil {
+		return nil, interceptorsErr
+	}
+
+	return codes, nil
+}
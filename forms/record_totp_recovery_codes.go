@@ -0,0 +1,85 @@
+package forms
+
+import (
+	"errors"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+// RecordTOTPRecoveryCodes is an auth record TOTP factor recovery codes regeneration form.
+type RecordTOTPRecoveryCodes struct {
+	app    core.App
+	dao    *daos.Dao
+	record *models.Record
+
+	// Code is a valid TOTP code.
+	Code string `form:"code" json:"code"`
+}
+
+// NewRecordTOTPRecoveryCodes creates a new [RecordTOTPRecoveryCodes] form
+// initialized with from the provided [core.App] and [models.Record] instances.
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewRecordTOTPRecoveryCodes(app core.App, record *models.Record) *RecordTOTPRecoveryCodes {
+	return &RecordTOTPRecoveryCodes{
+		app:    app,
+		dao:    app.Dao(),
+		record: record,
+	}
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *RecordTOTPRecoveryCodes) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *RecordTOTPRecoveryCodes) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(&form.Code, validation.Required, validation.Length(security.TOTPDigits, security.TOTPDigits)),
+	)
+}
+
+// Submit validates and submits the form.
+//
+// On success replaces the recovery codes of the enabled TOTP factor
+// of the form auth record and returns the new plain codes.
+//
+// You can optionally provide a list of InterceptorFunc to
+// further modify the form behavior before persisting it.
+func (form *RecordTOTPRecoveryCodes) Submit(interceptors ...InterceptorFunc[*models.MfaFactor]) ([]string, error) {
+	if err := form.Validate(); err != nil {
+		return nil, err
+	}
+
+	factor, err := form.dao.FindMfaFactor(form.record, models.MfaFactorTypeTOTP)
+	if err != nil || !factor.Enabled {
+		return nil, errors.New("The TOTP factor is not enabled.")
+	}
+
+	// only TOTP codes are accepted because the recovery codes are replaced anyway
+	step := security.ValidateTOTPCode(factor.Secret, form.Code, time.Now(), mfaTOTPSkew)
+	if step <= factor.LastUsedStep {
+		return nil, validation.Errors{"code": errInvalidMfaCode}
+	}
+
+	codes, hashes := newMfaRecoveryCodes()
+	factor.LastUsedStep = step
+	factor.RecoveryCodes = hashes
+
+	interceptorsErr := runInterceptors(factor, func(m *models.MfaFactor) error {
+		return form.dao.SaveMfaFactor(m)
+	}, interceptors...)
+
+	if interceptorsErr != nil {
+		return nil, interceptorsErr
+	}
+
+	return codes, nil
+}
@@ -0,0 +1,77 @@
+package forms
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+// RecordTOTPSetup is an auth record TOTP factor enrollment form.
+type RecordTOTPSetup struct {
+	app    core.App
+	dao    *daos.Dao
+	record *models.Record
+}
+
+// NewRecordTOTPSetup creates a new [RecordTOTPSetup] form
+// initialized with from the provided [core.App] and [models.Record] instances.
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewRecordTOTPSetup(app core.App, record *models.Record) *RecordTOTPSetup {
+	return &RecordTOTPSetup{
+		app:    app,
+		dao:    app.Dao(),
+		record: record,
+	}
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *RecordTOTPSetup) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Submit generates a new pending (aka. not enabled) TOTP factor
+// with a random secret for the form auth record, replacing
+// the previous pending one (if any).
+//
+// The factor must be enabled with [RecordTOTPEnable] in order
+// to be required on authentication.
+//
+// You can optionally provide a list of InterceptorFunc to
+// further modify the form behavior before persisting it.
+func (form *RecordTOTPSetup) Submit(interceptors ...InterceptorFunc[*models.MfaFactor]) (*models.MfaFactor, error) {
+	factor, err := form.dao.FindMfaFactor(form.record, models.MfaFactorTypeTOTP)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	if factor == nil {
+		factor = &models.MfaFactor{
+			CollectionId: form.record.Collection().Id,
+			RecordId:     form.record.Id,
+			Type:         models.MfaFactorTypeTOTP,
+		}
+	} else if factor.Enabled {
+		return nil, errors.New("The TOTP factor is already enabled.")
+	}
+
+	factor.Secret = security.NewTOTPSecret()
+	factor.LastUsedStep = 0
+	factor.RecoveryCodes = nil
+
+	interceptorsErr := runInterceptors(factor, func(m *models.MfaFactor) error {
+		factor = m
+		return form.dao.SaveMfaFactor(m)
+	}, interceptors...)
+
+	if interceptorsErr != nil {
+		return nil, interceptorsErr
+	}
+
+	return factor, nil
+}
@@ -7,7 +7,9 @@ import (
 	"log/slog"
 	"net/http"
 	"regexp"
+	"slices"
 	"strings"
+	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/go-ozzo/ozzo-validation/v4/is"
@@ -17,10 +19,13 @@ import (
 	"github.com/pocketbase/pocketbase/forms/validators"
 	"github.com/pocketbase/pocketbase/models"
 	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tools/confusables"
+	"github.com/pocketbase/pocketbase/tools/dbutils"
 	"github.com/pocketbase/pocketbase/tools/filesystem"
 	"github.com/pocketbase/pocketbase/tools/list"
 	"github.com/pocketbase/pocketbase/tools/rest"
 	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/pocketbase/pocketbase/tools/types"
 	"github.com/spf13/cast"
 )
 
@@ -34,8 +39,9 @@ type RecordUpsert struct {
 	manageAccess bool
 	record       *models.Record
 
-	filesToUpload map[string][]*filesystem.File
-	filesToDelete []string // names list
+	filesToUpload     map[string][]*filesystem.File
+	filesToDelete     []string // names list
+	filesToQuarantine []*filesystem.File
 
 	// base model fields
 	Id string `json:"id"`
@@ -79,6 +85,12 @@ func (form *RecordUpsert) Data() map[string]any {
 	return form.data
 }
 
+// Record returns the record model associated with the form
+// (the form data is applied to it only after a successful validation).
+func (form *RecordUpsert) Record() *models.Record {
+	return form.record
+}
+
 // SetFullManageAccess sets the manageAccess bool flag of the current
 // form to enable/disable directly changing some system record fields
 // (often used with auth collection records).
@@ -91,6 +103,122 @@ func (form *RecordUpsert) SetDao(dao *daos.Dao) {
 	form.dao = dao
 }
 
+// FindUpsertRecord returns the existing record that should be updated
+// when submitting the provided create data in "upsert" (aka. create-or-update) mode.
+//
+// The upsert key fields must be either "id" or the columns of a collection
+// unique index (or the "username"/"email" field for auth collections).
+// If no key fields are specified, the first key with all values
+// present in data is used (starting with "id").
+//
+// Returns nil if there is no matching record (aka. a new one should be created).
+func FindUpsertRecord(
+	dao *daos.Dao,
+	collection *models.Collection,
+	data map[string]any,
+	keyFields ...string,
+) (*models.Record, error) {
+	var key []string
+
+	candidates := upsertKeys(collection)
+
+	if len(keyFields) == 0 {
+		for _, candidate := range candidates {
+			if hasUpsertKeyValues(data, candidate) {
+				key = candidate
+				break
+			}
+		}
+
+		if key == nil {
+			return nil, nil // nothing to match
+		}
+	} else {
+		for _, candidate := range candidates {
+			if len(candidate) == len(keyFields) && len(list.SubtractSlice(candidate, keyFields)) == 0 {
+				key = candidate
+				break
+			}
+		}
+
+		if key == nil {
+			return nil, validation.Errors{"upsert": validation.NewError(
+				"validation_invalid_upsert_key",
+				"The upsert key must be the id or the fields of a unique index.",
+			)}
+		}
+
+		if !hasUpsertKeyValues(data, key) {
+			return nil, validation.Errors{"upsert": validation.NewError(
+				"validation_missing_upsert_key_value",
+				fmt.Sprintf("Missing upsert key value(s) for %s.", strings.Join(key, ", ")),
+			)}
+		}
+	}
+
+	// normalize the key values the same way as the record fields
+	dummy := models.NewRecord(collection)
+	exp := dbx.HashExp{}
+	for _, field := range key {
+		dummy.Set(field, data[field])
+		exp[collection.Name+"."+field] = dummy.Get(field)
+	}
+
+	records, err := dao.FindRecordsByExpr(collection.Id, exp)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	return records[0], nil
+}
+
+// upsertKeys returns the list of the field groups that uniquely identify a collection record.
+func upsertKeys(collection *models.Collection) [][]string {
+	result := [][]string{{schema.FieldNameId}}
+
+	if collection.IsAuth() {
+		result = append(result, []string{schema.FieldNameUsername}, []string{schema.FieldNameEmail})
+	}
+
+	for _, raw := range collection.Indexes {
+		idx := dbutils.ParseIndex(raw)
+
+		// partial indexes doesn't guarantee uniqueness for all records
+		if !idx.Unique || idx.Where != "" {
+			continue
+		}
+
+		fields := make([]string, 0, len(idx.Columns))
+		for _, col := range idx.Columns {
+			if collection.Schema.GetFieldByName(col.Name) == nil {
+				fields = nil // expression or unknown column
+				break
+			}
+			fields = append(fields, col.Name)
+		}
+
+		if len(fields) > 0 {
+			result = append(result, fields)
+		}
+	}
+
+	return result
+}
+
+func hasUpsertKeyValues(data map[string]any, key []string) bool {
+	for _, field := range key {
+		if v, ok := data[field]; !ok || v == nil || v == "" {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (form *RecordUpsert) loadFormDefaults() {
 	form.Id = form.record.Id
 
@@ -188,7 +316,7 @@ func (form *RecordUpsert) extractMultipartFormData(
 
 	// load uploaded files (if any)
 	for _, field := range form.record.Collection().Schema.Fields() {
-		if field.Type != schema.FieldTypeFile {
+		if !field.IsFile() {
 			continue // not a file field
 		}
 
@@ -265,12 +393,12 @@ func (form *RecordUpsert) FilesToDelete() []string {
 //	form.AddFiles("documents", f1, f2)
 func (form *RecordUpsert) AddFiles(key string, files ...*filesystem.File) error {
 	field := form.record.Collection().Schema.GetFieldByName(key)
-	if field == nil || field.Type != schema.FieldTypeFile {
+	if field == nil || !field.IsFile() {
 		return errors.New("invalid field key")
 	}
 
-	options, ok := field.Options.(*schema.FileOptions)
-	if !ok {
+	options := field.FileOptions()
+	if options == nil {
 		return errors.New("failed to initilize field options")
 	}
 
@@ -319,7 +447,7 @@ func (form *RecordUpsert) AddFiles(key string, files ...*filesystem.File) error
 //	form.AddFiles("documents")
 func (form *RecordUpsert) RemoveFiles(key string, toDelete ...string) error {
 	field := form.record.Collection().Schema.GetFieldByName(key)
-	if field == nil || field.Type != schema.FieldTypeFile {
+	if field == nil || !field.IsFile() {
 		return errors.New("invalid field key")
 	}
 
@@ -387,6 +515,12 @@ func (form *RecordUpsert) LoadData(requestData map[string]any) error {
 	// replace modifiers (if any)
 	requestData = form.record.ReplaceModifers(requestData)
 
+	if form.record.Collection().IsStrict() {
+		if err := form.checkUnknownFields(requestData); err != nil {
+			return err
+		}
+	}
+
 	// create a shallow copy of form.data
 	var extendedData = make(map[string]any, len(form.data))
 	for k, v := range form.data {
@@ -402,11 +536,31 @@ func (form *RecordUpsert) LoadData(requestData map[string]any) error {
 		return err
 	}
 
+	reactionsField := form.record.Collection().ReactionsField()
+
 	for _, field := range form.record.Collection().Schema.Fields() {
 		key := field.Name
 		value := field.PrepareValue(extendedData[key])
 
-		if field.Type != schema.FieldTypeFile {
+		// sanitize the editor html with the field policy
+		if options, ok := field.Options.(*schema.EditorOptions); ok {
+			value = options.SanitizePolicy().Sanitize(cast.ToString(value))
+		}
+
+		// the position and the reactions counters are maintained only
+		// by the server (see the reorder and the reactions apis)
+		if field.Type == schema.FieldTypePosition || key == reactionsField {
+			value = field.PrepareValue(form.record.Get(key))
+		}
+
+		// regenerate the markdown html (ignoring the submitted one, if any)
+		if options, ok := field.Options.(*schema.MarkdownOptions); ok {
+			if data, ok := value.(types.JsonMap); ok && len(data) > 0 {
+				data["html"] = options.Render(cast.ToString(data["source"]))
+			}
+		}
+
+		if !field.IsFile() {
 			form.data[key] = value
 			continue
 		}
@@ -458,6 +612,34 @@ func (form *RecordUpsert) LoadData(requestData map[string]any) error {
 	return nil
 }
 
+// checkUnknownFields returns a validation error for each data key that
+// doesn't match a known record field (used with strict mode collections).
+func (form *RecordUpsert) checkUnknownFields(data map[string]any) error {
+	collection := form.record.Collection()
+
+	known := append(schema.BaseModelFieldNames(), schema.SystemFieldNames()...)
+	if collection.IsAuth() {
+		known = append(known, schema.AuthFieldNames()...)
+		known = append(known, "password", "passwordConfirm", "oldPassword")
+	}
+
+	errs := validation.Errors{}
+
+	for key := range data {
+		if list.ExistInSlice(key, known) || collection.Schema.GetFieldByName(key) != nil {
+			continue
+		}
+
+		errs[key] = validation.NewError("validation_unknown_field", "Unknown field.")
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
 // Validate makes the form validatable by implementing [validation.Validatable] interface.
 func (form *RecordUpsert) Validate() error {
 	// base form fields validator
@@ -483,6 +665,7 @@ func (form *RecordUpsert) Validate() error {
 				validation.Length(3, 150),
 				validation.Match(usernameRegex),
 				validation.By(form.checkUniqueUsername),
+				validation.By(form.checkUsernamePolicy),
 			),
 			validation.Field(
 				&form.Email,
@@ -517,6 +700,7 @@ func (form *RecordUpsert) Validate() error {
 					validation.Required,
 				),
 				validation.Length(form.record.Collection().AuthOptions().MinPasswordLength, 72),
+				validation.By(validators.PasswordPolicy(form.record.Collection().AuthOptions())),
 			),
 			validation.Field(
 				&form.PasswordConfirm,
@@ -540,16 +724,18 @@ func (form *RecordUpsert) Validate() error {
 		)
 	}
 
-	if err := validation.ValidateStruct(form, baseFieldsRules...); err != nil {
-		return err
+	err := validation.ValidateStruct(form, baseFieldsRules...)
+
+	if err == nil {
+		// record data validator
+		err = validators.NewRecordDataValidator(
+			form.dao,
+			form.record,
+			form.filesToUpload,
+		).Validate(form.data)
 	}
 
-	// record data validator
-	return validators.NewRecordDataValidator(
-		form.dao,
-		form.record,
-		form.filesToUpload,
-	).Validate(form.data)
+	return RecordUpsertValidators.Validate(form, err)
 }
 
 func (form *RecordUpsert) checkUniqueUsername(value any) error {
@@ -571,6 +757,53 @@ func (form *RecordUpsert) checkUniqueUsername(value any) error {
 	return nil
 }
 
+// checkUsernamePolicy checks the changed username against the
+// auth collection reserved names, confusables and change cooldown options.
+func (form *RecordUpsert) checkUsernamePolicy(value any) error {
+	v, _ := value.(string)
+	if v == "" || (!form.record.IsNew() && strings.EqualFold(v, form.record.Username())) {
+		return nil // nothing to check
+	}
+
+	options := form.record.Collection().AuthOptions()
+
+	compare := strings.ToLower
+	if options.UsernameConfusables {
+		compare = confusables.Skeleton
+	}
+
+	if !form.manageAccess && len(options.ReservedUsernames) > 0 {
+		normalized := compare(v)
+		for _, reserved := range options.ReservedUsernames {
+			if compare(reserved) == normalized {
+				return validation.NewError("validation_reserved_username", "The username is reserved.")
+			}
+		}
+	}
+
+	if options.UsernameConfusables {
+		existing, err := form.dao.FindConfusableUsername(form.record.Collection(), v, form.record.Id)
+		if err != nil || existing != "" {
+			return validation.NewError("validation_confusable_username", "The username is too similar to an existing one.")
+		}
+	}
+
+	if !form.manageAccess && !form.record.IsNew() && options.UsernameChangeCooldown > 0 {
+		lastChange, _ := form.dao.FindLastUsernameChange(form.record)
+		if lastChange != nil {
+			nextAllowed := lastChange.Created.Time().Add(time.Duration(options.UsernameChangeCooldown) * time.Second)
+			if time.Now().Before(nextAllowed) {
+				return validation.NewError(
+					"validation_username_change_cooldown",
+					"The username was changed recently. Please try again later.",
+				)
+			}
+		}
+	}
+
+	return nil
+}
+
 func (form *RecordUpsert) checkUniqueEmail(value any) error {
 	v, _ := value.(string)
 	if v == "" {
@@ -627,6 +860,8 @@ func (form *RecordUpsert) checkOldPassword(value any) error {
 }
 
 func (form *RecordUpsert) ValidateAndFill() error {
+	form.quarantineFiles()
+
 	if err := form.Validate(); err != nil {
 		return err
 	}
@@ -647,6 +882,10 @@ func (form *RecordUpsert) ValidateAndFill() error {
 			form.Username = form.dao.SuggestUniqueAuthRecordUsername(form.record.Collection().Id, baseUsername)
 		}
 
+		if form.record.Collection().AuthOptions().UsernameLowercase {
+			form.Username = strings.ToLower(form.Username)
+		}
+
 		if form.Username != "" {
 			if err := form.record.SetUsername(form.Username); err != nil {
 				return err
@@ -679,6 +918,22 @@ func (form *RecordUpsert) ValidateAndFill() error {
 	// bulk load the remaining form data
 	form.record.Load(form.data)
 
+	// append the new record at the end of its position fields scope
+	if form.record.IsNew() {
+		for _, field := range form.record.Collection().Schema.Fields() {
+			if field.Type != schema.FieldTypePosition {
+				continue
+			}
+
+			position, err := form.dao.NextRecordPosition(form.record, field.Name)
+			if err != nil {
+				return err
+			}
+
+			form.record.Set(field.Name, position)
+		}
+	}
+
 	return nil
 }
 
@@ -809,8 +1064,86 @@ func (form *RecordUpsert) Submit(interceptors ...InterceptorFunc[*models.Record]
 	}, interceptors...)
 }
 
+// quarantineFiles detaches the new files of the quarantine enabled
+// file fields that doesn't pass the content type checks
+// (they are later uploaded to the quarantine storage directory).
+func (form *RecordUpsert) quarantineFiles() {
+	for key, files := range form.filesToUpload {
+		field := form.record.Collection().Schema.GetFieldByName(key)
+		if field == nil || field.Type != schema.FieldTypeFile {
+			continue
+		}
+
+		options := field.FileOptions()
+		if options == nil || !options.Quarantine {
+			continue
+		}
+
+		check := validators.UploadedFileContent(options)
+
+		names := list.ToUniqueStringSlice(form.data[key])
+
+		for i := len(files) - 1; i >= 0; i-- {
+			if check(files[i]) == nil {
+				continue
+			}
+
+			form.filesToQuarantine = append(form.filesToQuarantine, files[i])
+			names = slices.DeleteFunc(names, func(name string) bool {
+				return name == files[i].Name
+			})
+			files = append(files[:i], files[i+1:]...)
+		}
+
+		form.filesToUpload[key] = files
+		form.data[key] = field.PrepareValue(names)
+	}
+}
+
+// FilesToQuarantine returns the parsed request files that failed
+// the content type checks of their quarantine enabled file field.
+func (form *RecordUpsert) FilesToQuarantine() []*filesystem.File {
+	return form.filesToQuarantine
+}
+
+func (form *RecordUpsert) processFilesToQuarantine() {
+	if len(form.filesToQuarantine) == 0 {
+		return
+	}
+
+	fs, err := form.app.NewFilesystem()
+	if err != nil {
+		form.app.Logger().Warn("Failed to initialize the quarantine filesystem", slog.String("error", err.Error()))
+		return
+	}
+	defer fs.Close()
+
+	basePath := "_quarantine/" + form.record.Collection().Id + "/" + form.record.Id + "/"
+
+	for _, file := range form.filesToQuarantine {
+		if err := fs.UploadFile(file, basePath+file.Name); err != nil {
+			form.app.Logger().Warn(
+				"Failed to quarantine file",
+				slog.String("file", file.OriginalName),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+
+		form.app.Logger().Warn(
+			"Uploaded file was quarantined due to mismatched content type",
+			slog.String("collectionId", form.record.Collection().Id),
+			slog.String("recordId", form.record.Id),
+			slog.String("file", file.OriginalName),
+			slog.String("path", basePath+file.Name),
+		)
+	}
+
+	form.filesToQuarantine = nil
+}
+
 func (form *RecordUpsert) processFilesToUpload() error {
-	if len(form.filesToUpload) == 0 {
+	if len(form.filesToUpload) == 0 && len(form.filesToQuarantine) == 0 {
 		return nil // no parsed file fields
 	}
 
@@ -818,6 +1151,9 @@ func (form *RecordUpsert) processFilesToUpload() error {
 		return errors.New("the record doesn't have an id")
 	}
 
+	// quarantine errors are only logged and doesn't fail the record save
+	form.processFilesToQuarantine()
+
 	fs, err := form.app.NewFilesystem()
 	if err != nil {
 		return err
@@ -828,7 +1164,24 @@ func (form *RecordUpsert) processFilesToUpload() error {
 	var uploaded []string    // list of uploaded file paths
 
 	for fieldKey := range form.filesToUpload {
+		field := form.record.Collection().Schema.GetFieldByName(fieldKey)
+
 		for i, file := range form.filesToUpload[fieldKey] {
+			// normalize the avatar image (square crop and strip metadata)
+			if field != nil && field.Type == schema.FieldTypeAvatar {
+				options, _ := field.Options.(*schema.AvatarOptions)
+				if options == nil {
+					options = &schema.AvatarOptions{}
+				}
+
+				normalized, err := filesystem.NewSquareImageFile(file, slices.Max(options.AvatarSizes()))
+				if err != nil {
+					uploadErrors = append(uploadErrors, fmt.Errorf("file %d: failed to process the avatar image: %v", i, err))
+					continue
+				}
+				file = normalized
+			}
+
 			path := form.record.BaseFilesPath() + "/" + file.Name
 			if err := fs.UploadFile(file, path); err == nil {
 				// keep track of the already uploaded file
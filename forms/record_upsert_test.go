@@ -231,6 +231,57 @@ func TestRecordUpsertLoadData(t *testing.T) {
 	}
 }
 
+func TestRecordUpsertLoadDataStrictMode(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	record, err := app.Dao().FindRecordById("demo2", "llvuca81nly1qls")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := map[string]any{
+		"id":         record.Id,
+		"title":      "test_new",
+		"active":     true,
+		"titel":      "typo",
+		"unknown":    123,
+		"collection": record.Collection().Name,
+	}
+
+	// non-strict collection
+	{
+		form := forms.NewRecordUpsert(app, record)
+		if err := form.LoadData(data); err != nil {
+			t.Fatalf("Expected the unknown fields to be ignored, got %v", err)
+		}
+	}
+
+	// strict collection
+	{
+		record.Collection().Options["strictMode"] = true
+
+		form := forms.NewRecordUpsert(app, record)
+
+		loadErr := form.LoadData(data)
+
+		errs, ok := loadErr.(validation.Errors)
+		if !ok {
+			t.Fatalf("Expected validation.Errors, got %v", loadErr)
+		}
+
+		expectedKeys := []string{"titel", "unknown", "collection"}
+		if len(errs) != len(expectedKeys) {
+			t.Fatalf("Expected %d errors, got %d (%v)", len(expectedKeys), len(errs), errs)
+		}
+		for _, k := range expectedKeys {
+			if _, ok := errs[k]; !ok {
+				t.Errorf("Missing expected %q error in %v", k, errs)
+			}
+		}
+	}
+}
+
 func TestRecordUpsertDrySubmitFailure(t *testing.T) {
 	app, _ := tests.NewTestApp()
 	defer app.Cleanup()
@@ -1056,6 +1107,388 @@ func TestRecordUpsertUniqueValidator(t *testing.T) {
 	}
 }
 
+func TestRecordUpsertUsernamePolicy(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection, err := app.Dao().FindCollectionByNameOrId("users")
+	if err != nil {
+		t.Fatal(err)
+	}
+	options := collection.AuthOptions()
+	options.UsernameLowercase = true
+	options.UsernameConfusables = true
+	options.ReservedUsernames = []string{"admin"}
+	options.UsernameChangeCooldown = 3600
+	collection.SetOptions(options)
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	// note: the scenarios are executed in order on the same record
+	scenarios := []struct {
+		name             string
+		username         string
+		manageAccess     bool
+		expectError      bool
+		expectedUsername string
+	}{
+		{"reserved", "Adm1n", false, true, ""},
+		{"confusable with existing", "te5t2-username", false, true, ""},
+		{"same username with different case", "USERS75657", false, false, "users75657"},
+		{"first change", "NewName", false, false, "newname"},
+		{"change during cooldown", "othername", false, true, ""},
+		{"change during cooldown with manage access", "othername", true, false, "othername"},
+		{"reserved with manage access", "admin", true, false, "admin"},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			record, err := app.Dao().FindRecordById("users", "4q1xlclmfloku33")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			form := forms.NewRecordUpsert(app, record)
+			form.SetFullManageAccess(s.manageAccess)
+			if err := form.LoadData(map[string]any{"username": s.username}); err != nil {
+				t.Fatal(err)
+			}
+
+			err = form.Submit()
+
+			errs, _ := err.(validation.Errors)
+			_, hasErr := errs["username"]
+			if hasErr != s.expectError {
+				t.Fatalf("Expected username error %v, got %v", s.expectError, err)
+			}
+
+			if !s.expectError && record.Username() != s.expectedUsername {
+				t.Fatalf("Expected username %q, got %q", s.expectedUsername, record.Username())
+			}
+		})
+	}
+}
+
+func TestRecordUpsertPasswordPolicy(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection, err := app.Dao().FindCollectionByNameOrId("users")
+	if err != nil {
+		t.Fatal(err)
+	}
+	options := collection.AuthOptions()
+	options.PasswordRequireUppercase = true
+	options.PasswordRequireDigit = true
+	options.PasswordRequireSymbol = true
+	collection.SetOptions(options)
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		name        string
+		password    string
+		expectError bool
+	}{
+		{"missing uppercase", "abcdef123!", true},
+		{"missing digit", "Abcdefghi!", true},
+		{"missing symbol", "Abcdef1234", true},
+		{"valid password", "Abcdef123!", false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			record, err := app.Dao().FindRecordById("users", "4q1xlclmfloku33")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			form := forms.NewRecordUpsert(app, record)
+			form.SetFullManageAccess(true)
+			if err := form.LoadData(map[string]any{
+				"password":        s.password,
+				"passwordConfirm": s.password,
+			}); err != nil {
+				t.Fatal(err)
+			}
+
+			err = form.Submit()
+
+			errs, _ := err.(validation.Errors)
+			_, hasErr := errs["password"]
+			if hasErr != s.expectError {
+				t.Fatalf("Expected password error %v, got %v", s.expectError, err)
+			}
+
+			if !s.expectError && !record.ValidatePassword(s.password) {
+				t.Fatal("Expected the record password to be changed")
+			}
+		})
+	}
+}
+
+func TestRecordUpsertEditorSanitization(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := addEditorField(t, app, "demo2", &schema.EditorOptions{
+		AllowedTags: []string{"p", "a"},
+		LinkRel:     []string{"noopener"},
+		IframeHosts: []string{"www.youtube.com"},
+	})
+
+	record := models.NewRecord(collection)
+
+	form := forms.NewRecordUpsert(app, record)
+	form.LoadData(map[string]any{
+		"title": "test",
+		"content": `<p onclick="alert(1)"><strong>a</strong><a href="javascript:alert(1)">b</a></p>` +
+			`<iframe src="https://www.youtube.com/embed/abc"></iframe><iframe src="https://example.com"></iframe>`,
+	})
+
+	if err := form.Submit(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `<p>a<a rel="noopener">b</a></p><iframe src="https://www.youtube.com/embed/abc"></iframe>`
+	if v := record.GetString("content"); v != expected {
+		t.Fatalf("Expected content %q, got %q", expected, v)
+	}
+}
+
+func TestRecordUpsertMarkdown(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	collection.Schema.AddField(&schema.SchemaField{
+		Name:    "content",
+		Type:    schema.FieldTypeMarkdown,
+		Options: &schema.MarkdownOptions{Max: types.Pointer(20)},
+	})
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	record := models.NewRecord(collection)
+
+	// max constraint
+	form := forms.NewRecordUpsert(app, record)
+	form.LoadData(map[string]any{
+		"title":   "test",
+		"content": strings.Repeat("a", 21),
+	})
+	if err := form.Submit(); err == nil {
+		t.Fatal("Expected max constraint error, got nil")
+	}
+
+	// submitted html is ignored
+	form = forms.NewRecordUpsert(app, record)
+	form.LoadData(map[string]any{
+		"title": "test",
+		"content": map[string]any{
+			"source": "# Hello",
+			"html":   "<script>alert(1)</script>",
+		},
+	})
+	if err := form.Submit(); err != nil {
+		t.Fatal(err)
+	}
+
+	refreshed, err := app.Dao().FindRecordById(collection.Id, record.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	raw, _ := json.Marshal(refreshed.Get("content"))
+	expected := `{"html":"\u003ch1\u003eHello\u003c/h1\u003e\n","source":"# Hello"}`
+	if string(raw) != expected {
+		t.Fatalf("Expected content %s, got %s", expected, raw)
+	}
+}
+
+func TestRecordUpsertPosition(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	collection.Schema.AddField(&schema.SchemaField{
+		Name: "position",
+		Type: schema.FieldTypePosition,
+	})
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	submit := func(record *models.Record, data map[string]any) {
+		form := forms.NewRecordUpsert(app, record)
+		form.LoadData(data)
+		if err := form.Submit(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// new records are appended ignoring the submitted position
+	first := models.NewRecord(collection)
+	submit(first, map[string]any{"title": "first", "position": -10})
+	if v := first.GetFloat("position"); v != daos.RecordPositionGap {
+		t.Fatalf("Expected the first record position %v, got %v", daos.RecordPositionGap, v)
+	}
+
+	second := models.NewRecord(collection)
+	submit(second, map[string]any{"title": "second"})
+	if v := second.GetFloat("position"); v != 2*daos.RecordPositionGap {
+		t.Fatalf("Expected the second record position %v, got %v", 2*daos.RecordPositionGap, v)
+	}
+
+	// updates preserve the current position
+	submit(first, map[string]any{"title": "first_updated", "position": 5000})
+	refreshed, err := app.Dao().FindRecordById(collection.Id, first.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := refreshed.GetFloat("position"); v != daos.RecordPositionGap {
+		t.Fatalf("Expected the updated record position %v, got %v", daos.RecordPositionGap, v)
+	}
+}
+
+func TestRecordUpsertReactionsField(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection, err := app.Dao().FindCollectionByNameOrId("demo2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	collection.Schema.AddField(&schema.SchemaField{
+		Name:    "reactions",
+		Type:    schema.FieldTypeJson,
+		Options: &schema.JsonOptions{MaxSize: 2000},
+	})
+	collection.SetOptions(models.CollectionBaseOptions{
+		Reactions:      []string{"like"},
+		ReactionsField: "reactions",
+	})
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	record, err := app.Dao().FindRecordById(collection.Id, "llvuca81nly1qls")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	form := forms.NewRecordUpsert(app, record)
+	form.LoadData(map[string]any{
+		"title":     "updated",
+		"reactions": map[string]any{"like": 100},
+	})
+	if err := form.Submit(); err != nil {
+		t.Fatal(err)
+	}
+
+	refreshed, err := app.Dao().FindRecordById(collection.Id, record.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if refreshed.GetString("title") != "updated" {
+		t.Fatalf("Expected the title to be updated, got %q", refreshed.GetString("title"))
+	}
+
+	if v := refreshed.GetString("reactions"); strings.Contains(v, "100") {
+		t.Fatalf("Expected the submitted reactions counters to be ignored, got %s", v)
+	}
+}
+
+func TestRecordUpsertEncryptedTenantFields(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := &models.Collection{
+		Name: "tenant_notes",
+		Type: models.CollectionTypeBase,
+		Schema: schema.NewSchema(
+			&schema.SchemaField{
+				Name:    "tenant",
+				Type:    schema.FieldTypeText,
+				Options: &schema.TextOptions{},
+			},
+			&schema.SchemaField{
+				Name:    "secret",
+				Type:    schema.FieldTypeText,
+				Options: &schema.TextOptions{Encrypted: true},
+			},
+		),
+	}
+	collection.SetOptions(models.CollectionBaseOptions{TenantField: "tenant"})
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	// create a record for a new tenant as part of a transaction
+	// (aka. the tenant key must be generated with the transaction dao)
+	record := models.NewRecord(collection)
+	txErr := app.Dao().RunInTransaction(func(txDao *daos.Dao) error {
+		form := forms.NewRecordUpsert(app, record)
+		form.SetDao(txDao)
+		form.LoadData(map[string]any{
+			"tenant": "new_tenant",
+			"secret": "test",
+		})
+		return form.Submit()
+	})
+	if txErr != nil {
+		t.Fatal(txErr)
+	}
+
+	stored, err := app.Dao().FindRecordById(collection.Id, record.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	storedSecret := stored.GetString("secret")
+	if !strings.HasPrefix(storedSecret, "enc:") || strings.Contains(storedSecret, "test") {
+		t.Fatalf("Expected the stored secret to be encrypted, got %q", storedSecret)
+	}
+
+	// update another field without changing the secret
+	updateForm := forms.NewRecordUpsert(app, stored)
+	updateForm.LoadData(map[string]any{"tenant": "new_tenant"})
+	if err := updateForm.Submit(); err != nil {
+		t.Fatal(err)
+	}
+	if v := stored.GetString("secret"); v != storedSecret {
+		t.Fatalf("Expected the secret to remain %q, got %q", storedSecret, v)
+	}
+
+	// plain value with the reserved encrypted prefix
+	invalidForm := forms.NewRecordUpsert(app, stored)
+	invalidForm.LoadData(map[string]any{"secret": "enc:test"})
+	if err := invalidForm.Submit(); err == nil {
+		t.Fatal("Expected the reserved prefix value to be rejected, got nil")
+	}
+
+	refreshed, err := app.Dao().FindRecordById(collection.Id, record.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := app.Dao().DecryptRecords([]*models.Record{refreshed}); err != nil {
+		t.Fatal(err)
+	}
+	if v := refreshed.GetString("secret"); v != "test" {
+		t.Fatalf("Expected the decrypted secret to be %q, got %q", "test", v)
+	}
+}
+
 func TestRecordUpsertAddAndRemoveFiles(t *testing.T) {
 	app, _ := tests.NewTestApp()
 	defer app.Cleanup()
@@ -1210,3 +1643,53 @@ func TestRecordUpsertUploadFailure(t *testing.T) {
 		}
 	}
 }
+
+func TestFindUpsertRecord(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	scenarios := []struct {
+		name          string
+		collection    string
+		data          map[string]any
+		keyFields     []string
+		expectError   bool
+		expectedMatch string
+	}{
+		{"auto key without key values", "demo2", map[string]any{"active": true}, nil, false, ""},
+		{"auto key with existing id", "demo2", map[string]any{"id": "llvuca81nly1qls", "title": "new"}, nil, false, "llvuca81nly1qls"},
+		{"auto key with missing id", "demo2", map[string]any{"id": "missing", "title": "test2"}, nil, false, ""},
+		{"auto key with existing unique index value", "demo2", map[string]any{"title": "test2"}, nil, false, "achvryl401bhse3"},
+		{"auto key with new unique index value", "demo2", map[string]any{"title": "new"}, nil, false, ""},
+		{"explicit non unique key", "demo2", map[string]any{"active": true}, []string{"active"}, true, ""},
+		{"explicit key with missing value", "demo2", map[string]any{"active": true}, []string{"title"}, true, ""},
+		{"explicit unique index key", "demo2", map[string]any{"id": "llvuca81nly1qls", "title": "test3"}, []string{"title"}, false, "0yxhwia2amd8gec"},
+		{"explicit auth email key", "users", map[string]any{"email": "test2@example.com"}, []string{"email"}, false, "oap640cot4yru2s"},
+		{"explicit auth username key", "users", map[string]any{"username": "users75657"}, []string{"username"}, false, "4q1xlclmfloku33"},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			collection, err := app.Dao().FindCollectionByNameOrId(s.collection)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			record, err := forms.FindUpsertRecord(app.Dao(), collection, s.data, s.keyFields...)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			var id string
+			if record != nil {
+				id = record.Id
+			}
+
+			if id != s.expectedMatch {
+				t.Fatalf("Expected matched record %q, got %q", s.expectedMatch, id)
+			}
+		})
+	}
+}
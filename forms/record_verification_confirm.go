@@ -58,6 +58,7 @@ func (form *RecordVerificationConfirm) checkToken(value any) error {
 	record, err := form.dao.FindAuthRecordByToken(
 		v,
 		form.app.Settings().RecordVerificationToken.Secret,
+		form.app.Settings().RecordVerificationToken.PreviousSecrets()...,
 	)
 	if err != nil || record == nil {
 		return validation.NewError("validation_invalid_token", "Invalid or expired token.")
@@ -87,6 +88,7 @@ func (form *RecordVerificationConfirm) Submit(interceptors ...InterceptorFunc[*m
 	record, err := form.dao.FindAuthRecordByToken(
 		form.Token,
 		form.app.Settings().RecordVerificationToken.Secret,
+		form.app.Settings().RecordVerificationToken.PreviousSecrets()...,
 	)
 	if err != nil {
 		return nil, err
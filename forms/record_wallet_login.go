@@ -0,0 +1,219 @@
+package forms
+
+import (
+	"errors"
+	"net/url"
+	"regexp"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/siwe"
+)
+
+// WalletProvider is the external auth provider name of the wallet linked auth records.
+const WalletProvider = "wallet"
+
+var walletSignatureRegex = regexp.MustCompile(`^(0x)?[0-9a-fA-F]{130}$`)
+
+var errInvalidWalletNonce = validation.NewError("validation_invalid_wallet_nonce", "Invalid or expired nonce.")
+
+var errInvalidWalletSignature = validation.NewError("validation_invalid_wallet_signature", "Invalid wallet signature.")
+
+// WalletDomains returns the allowed Sign-In with Ethereum message domains
+// of the provided auth collection (fallbacks to the app url host when not explicitly set).
+func WalletDomains(app core.App, collection *models.Collection) []string {
+	domains := collection.AuthOptions().WalletDomains
+
+	if len(domains) == 0 {
+		if appUrl, err := url.Parse(app.Settings().Meta.AppUrl); err == nil && appUrl.Host != "" {
+			domains = []string{appUrl.Host}
+		}
+	}
+
+	return domains
+}
+
+// RecordWalletLoginData defines the RecordWalletLogin interceptors data.
+type RecordWalletLoginData struct {
+	ExternalAuth *models.ExternalAuth
+	Record       *models.Record
+	Message      *siwe.Message
+}
+
+// RecordWalletLogin is an auth record Sign-In with Ethereum (EIP-4361) login form.
+type RecordWalletLogin struct {
+	app        core.App
+	dao        *daos.Dao
+	collection *models.Collection
+
+	// Optional auth record that will be linked with the wallet
+	// if there is no existing wallet relation (if it is from the same collection).
+	loggedAuthRecord *models.Record
+
+	// Message is the raw EIP-4361 message signed by the wallet.
+	Message string `form:"message" json:"message"`
+
+	// Signature is the hex encoded "personal_sign" message signature.
+	Signature string `form:"signature" json:"signature"`
+}
+
+// NewRecordWalletLogin creates a new [RecordWalletLogin] form initialized
+// with from the provided [core.App] and [models.Collection] instance.
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewRecordWalletLogin(app core.App, collection *models.Collection, optAuthRecord *models.Record) *RecordWalletLogin {
+	return &RecordWalletLogin{
+		app:              app,
+		dao:              app.Dao(),
+		collection:       collection,
+		loggedAuthRecord: optAuthRecord,
+	}
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *RecordWalletLogin) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+//
+// Note that the message nonce and signature are checked on submit.
+func (form *RecordWalletLogin) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(&form.Message, validation.Required, validation.Length(1, 5000), validation.By(form.checkMessage)),
+		validation.Field(&form.Signature, validation.Required, validation.Match(walletSignatureRegex)),
+	)
+}
+
+func (form *RecordWalletLogin) checkMessage(value any) error {
+	v, _ := value.(string)
+
+	message, err := siwe.ParseMessage(v)
+	if err != nil {
+		return validation.NewError("validation_invalid_wallet_message", "Invalid Sign-In with Ethereum message.")
+	}
+
+	if !list.ExistInSlice(message.Domain, WalletDomains(form.app, form.collection)) {
+		return validation.NewError("validation_invalid_wallet_domain", "The message domain is not allowed.")
+	}
+
+	chainIds := form.collection.AuthOptions().WalletChainIds
+	if len(chainIds) > 0 && !list.ExistInSlice(message.ChainId, chainIds) {
+		return validation.NewError("validation_invalid_wallet_chain", "The message chain id is not allowed.")
+	}
+
+	if err := message.ValidateAt(time.Now()); err != nil {
+		return validation.NewError("validation_invalid_wallet_message_time", "The message has expired or is not valid yet.")
+	}
+
+	return nil
+}
+
+// Submit validates and submits the form.
+//
+// The message nonce must be issued for the form collection and
+// could be used only once (it is deleted on submit regardless of
+// the signature verification outcome).
+//
+// If there is no auth record linked to the wallet address, the form
+// links the logged auth record (if any) or creates a new one.
+//
+// You can optionally provide a list of InterceptorFunc to
+// further modify the form behavior before persisting it.
+//
+// On success returns the authorized auth record model.
+func (form *RecordWalletLogin) Submit(interceptors ...InterceptorFunc[*RecordWalletLoginData]) (*models.Record, error) {
+	if err := form.Validate(); err != nil {
+		return nil, err
+	}
+
+	if !form.collection.AuthOptions().AllowWalletAuth {
+		return nil, errors.New("The collection is not configured to allow wallet authentication.")
+	}
+
+	message, err := siwe.ParseMessage(form.Message)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := form.dao.FindWalletNonce(form.collection.Id, message.Nonce)
+	if err != nil {
+		return nil, validation.Errors{"message": errInvalidWalletNonce}
+	}
+
+	if err := form.dao.DeleteWalletNonce(nonce); err != nil {
+		return nil, err
+	}
+
+	if nonce.HasExpired() {
+		return nil, validation.Errors{"message": errInvalidWalletNonce}
+	}
+
+	if err := siwe.VerifySignature(form.Message, form.Signature, message.Address); err != nil {
+		return nil, validation.Errors{"signature": errInvalidWalletSignature}
+	}
+
+	identity, err := walletIdentity(message.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	authRecord, rel, err := findExternalIdentityRecord(form.dao, form.collection, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	if authRecord == nil && form.loggedAuthRecord != nil && form.loggedAuthRecord.Collection().Id == form.collection.Id {
+		authRecord = form.loggedAuthRecord
+	}
+
+	interceptorData := &RecordWalletLoginData{
+		ExternalAuth: rel,
+		Record:       authRecord,
+		Message:      message,
+	}
+
+	interceptorsErr := runInterceptors(interceptorData, func(newData *RecordWalletLoginData) error {
+		record, rel, err := saveExternalIdentityRecord(
+			form.app,
+			form.dao,
+			form.collection,
+			identity,
+			newData.Record,
+			newData.ExternalAuth,
+		)
+		if err != nil {
+			return err
+		}
+
+		newData.Record = record
+		newData.ExternalAuth = rel
+
+		return nil
+	}, interceptors...)
+
+	if interceptorsErr != nil {
+		return nil, interceptorsErr
+	}
+
+	return interceptorData.Record, nil
+}
+
+// walletIdentity returns the external identity of the provided wallet address
+// (the EIP-55 checksum address is used as provider id).
+func walletIdentity(address string) (*externalIdentity, error) {
+	checksumAddress, err := siwe.ChecksumAddress(address)
+	if err != nil {
+		return nil, err
+	}
+
+	return &externalIdentity{
+		provider:   WalletProvider,
+		providerId: checksumAddress,
+	}, nil
+}
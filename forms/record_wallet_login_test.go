@@ -0,0 +1,317 @@
+package forms_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+// EIP-4361 messages signed with the 0x1 private key (address 0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf)
+const (
+	testWalletAddress   = "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf"
+	testWalletMessage   = "localhost:8090 wants you to sign in with your Ethereum account:\n0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf\n\nSign in to the test app.\n\nURI: http://localhost:8090\nVersion: 1\nChain ID: 1\nNonce: 32891756aBcDeF01\nIssued At: 2023-12-01T10:00:00Z"
+	testWalletSignature = "0xa33a3e0146175b80b553f83def4d77e75cf8627599c4a9c80d7988846897c55c14bce9ea27b18fea8b88a567ba8438ad5bfd72b8607bd34b3ee972d149dba5fe1b"
+	testWalletNonce     = "32891756aBcDeF01"
+
+	// signature of a different message
+	testWalletOtherSignature = "0x1a4c0566e998960e1ef4790558b9573552a80ee583074f70755e2cc2b7860d9f47bfa4e23b0fb451710a4ea8af1819ca0c2cb6eb7bf02fe251eae7d0054e77b91b"
+)
+
+func TestRecordWalletLoginValidateAndSubmit(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		collection     string
+		options        func(options *models.CollectionAuthOptions)
+		nonce          string
+		nonceCreated   time.Time
+		message        string
+		signature      string
+		loggedRecordId string
+		linkedRecordId string
+		expectError    bool
+		expectedErrors []string
+		expectRecordId string // empty for new records
+	}{
+		{
+			name:           "empty data",
+			collection:     "users",
+			expectError:    true,
+			expectedErrors: []string{"message", "signature"},
+		},
+		{
+			name:        "disabled wallet auth",
+			collection:  "users",
+			options:     func(options *models.CollectionAuthOptions) { options.AllowWalletAuth = false },
+			nonce:       testWalletNonce,
+			message:     testWalletMessage,
+			signature:   testWalletSignature,
+			expectError: true,
+		},
+		{
+			name:           "invalid message and signature format",
+			collection:     "users",
+			nonce:          testWalletNonce,
+			message:        "invalid",
+			signature:      "0x123",
+			expectError:    true,
+			expectedErrors: []string{"message", "signature"},
+		},
+		{
+			name:       "not allowed message domain",
+			collection: "users",
+			options: func(options *models.CollectionAuthOptions) {
+				options.WalletDomains = []string{"example.com"}
+			},
+			nonce:          testWalletNonce,
+			message:        testWalletMessage,
+			signature:      testWalletSignature,
+			expectError:    true,
+			expectedErrors: []string{"message"},
+		},
+		{
+			name:       "not allowed message chain id",
+			collection: "users",
+			options: func(options *models.CollectionAuthOptions) {
+				options.WalletChainIds = []int{137}
+			},
+			nonce:          testWalletNonce,
+			message:        testWalletMessage,
+			signature:      testWalletSignature,
+			expectError:    true,
+			expectedErrors: []string{"message"},
+		},
+		{
+			name:           "missing nonce",
+			collection:     "users",
+			message:        testWalletMessage,
+			signature:      testWalletSignature,
+			expectError:    true,
+			expectedErrors: []string{"message"},
+		},
+		{
+			name:           "expired nonce",
+			collection:     "users",
+			nonce:          testWalletNonce,
+			nonceCreated:   time.Now().Add(-(models.WalletNonceDuration + 10) * time.Second),
+			message:        testWalletMessage,
+			signature:      testWalletSignature,
+			expectError:    true,
+			expectedErrors: []string{"message"},
+		},
+		{
+			name:           "invalid signature",
+			collection:     "users",
+			nonce:          testWalletNonce,
+			message:        testWalletMessage,
+			signature:      testWalletOtherSignature,
+			expectError:    true,
+			expectedErrors: []string{"signature"},
+		},
+		{
+			name:       "new wallet",
+			collection: "users",
+			nonce:      testWalletNonce,
+			message:    testWalletMessage,
+			signature:  testWalletSignature,
+		},
+		{
+			name:           "new wallet with logged auth record",
+			collection:     "users",
+			nonce:          testWalletNonce,
+			message:        testWalletMessage,
+			signature:      testWalletSignature,
+			loggedRecordId: "oap640cot4yru2s",
+			expectRecordId: "oap640cot4yru2s",
+		},
+		{
+			name:           "linked wallet",
+			collection:     "users",
+			nonce:          testWalletNonce,
+			message:        testWalletMessage,
+			signature:      testWalletSignature,
+			loggedRecordId: "oap640cot4yru2s",
+			linkedRecordId: "4q1xlclmfloku33",
+			expectRecordId: "4q1xlclmfloku33",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			testApp, _ := tests.NewTestApp()
+			defer testApp.Cleanup()
+
+			collection, err := testApp.Dao().FindCollectionByNameOrId(s.collection)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			options := collection.AuthOptions()
+			options.AllowWalletAuth = true
+			if s.options != nil {
+				s.options(&options)
+			}
+			collection.SetOptions(options)
+			if err := testApp.Dao().SaveCollection(collection); err != nil {
+				t.Fatal(err)
+			}
+
+			if s.nonce != "" {
+				nonce := &models.WalletNonce{CollectionId: collection.Id, Nonce: s.nonce}
+				if !s.nonceCreated.IsZero() {
+					nonce.Created, _ = types.ParseDateTime(s.nonceCreated)
+				}
+				if err := testApp.Dao().SaveWalletNonce(nonce); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if s.linkedRecordId != "" {
+				rel := &models.ExternalAuth{
+					CollectionId: collection.Id,
+					RecordId:     s.linkedRecordId,
+					Provider:     forms.WalletProvider,
+					ProviderId:   testWalletAddress,
+				}
+				if err := testApp.Dao().SaveExternalAuth(rel); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var loggedRecord *models.Record
+			if s.loggedRecordId != "" {
+				loggedRecord, err = testApp.Dao().FindRecordById(collection.Id, s.loggedRecordId)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			form := forms.NewRecordWalletLogin(testApp, collection, loggedRecord)
+			form.Message = s.message
+			form.Signature = s.signature
+
+			record, submitErr := form.Submit()
+
+			hasErr := submitErr != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, submitErr)
+			}
+
+			if len(s.expectedErrors) > 0 {
+				errs, ok := submitErr.(validation.Errors)
+				if !ok || len(errs) != len(s.expectedErrors) {
+					t.Fatalf("Expected error keys %v, got errors \n%v", s.expectedErrors, submitErr)
+				}
+
+				for _, k := range s.expectedErrors {
+					if _, ok := errs[k]; !ok {
+						t.Fatalf("Missing expected error key %q in %v", k, errs)
+					}
+				}
+			}
+
+			if hasErr {
+				return
+			}
+
+			if s.expectRecordId != "" && record.Id != s.expectRecordId {
+				t.Fatalf("Expected record %q, got %q", s.expectRecordId, record.Id)
+			}
+
+			rel, err := testApp.Dao().FindFirstExternalAuthByExpr(dbx.HashExp{
+				"collectionId": collection.Id,
+				"recordId":     record.Id,
+				"provider":     forms.WalletProvider,
+				"providerId":   testWalletAddress,
+			})
+			if err != nil || rel == nil {
+				t.Fatalf("Expected the wallet to be linked, got %v", err)
+			}
+
+			// the nonce should be usable only once
+			form2 := forms.NewRecordWalletLogin(testApp, collection, nil)
+			form2.Message = s.message
+			form2.Signature = s.signature
+			if _, err := form2.Submit(); err == nil {
+				t.Fatal("Expected the nonce reuse to fail")
+			}
+		})
+	}
+}
+
+func TestRecordWalletLoginInterceptors(t *testing.T) {
+	testApp, _ := tests.NewTestApp()
+	defer testApp.Cleanup()
+
+	collection, err := testApp.Dao().FindCollectionByNameOrId("users")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	options := collection.AuthOptions()
+	options.AllowWalletAuth = true
+	collection.SetOptions(options)
+	if err := testApp.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	nonce := &models.WalletNonce{CollectionId: collection.Id, Nonce: testWalletNonce}
+	if err := testApp.Dao().SaveWalletNonce(nonce); err != nil {
+		t.Fatal(err)
+	}
+
+	form := forms.NewRecordWalletLogin(testApp, collection, nil)
+	form.Message = testWalletMessage
+	form.Signature = testWalletSignature
+
+	var interceptorData *forms.RecordWalletLoginData
+	testErr := errors.New("test_error")
+
+	interceptor1Called := false
+	interceptor1 := func(next forms.InterceptorNextFunc[*forms.RecordWalletLoginData]) forms.InterceptorNextFunc[*forms.RecordWalletLoginData] {
+		return func(data *forms.RecordWalletLoginData) error {
+			interceptor1Called = true
+			return next(data)
+		}
+	}
+
+	interceptor2Called := false
+	interceptor2 := func(next forms.InterceptorNextFunc[*forms.RecordWalletLoginData]) forms.InterceptorNextFunc[*forms.RecordWalletLoginData] {
+		return func(data *forms.RecordWalletLoginData) error {
+			interceptorData = data
+			interceptor2Called = true
+			return testErr
+		}
+	}
+
+	_, submitErr := form.Submit(interceptor1, interceptor2)
+	if submitErr != testErr {
+		t.Fatalf("Expected submitError %v, got %v", testErr, submitErr)
+	}
+
+	if !interceptor1Called {
+		t.Fatalf("Expected interceptor1 to be called")
+	}
+
+	if !interceptor2Called {
+		t.Fatalf("Expected interceptor2 to be called")
+	}
+
+	if interceptorData == nil || interceptorData.Record != nil || interceptorData.Message.Address != testWalletAddress {
+		t.Fatalf("Expected new wallet interceptor data, got %v", interceptorData)
+	}
+
+	// the interceptor error should prevent the auth record creation
+	if _, err := testApp.Dao().FindFirstExternalAuthByExpr(dbx.HashExp{
+		"provider":   forms.WalletProvider,
+		"providerId": testWalletAddress,
+	}); err == nil {
+		t.Fatal("Expected the wallet to not be linked")
+	}
+}
@@ -0,0 +1,168 @@
+package forms
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tools/list"
+)
+
+// recordsSanitizeBatchSize is the max number of records that are loaded at once.
+const recordsSanitizeBatchSize = 200
+
+// RecordsSanitize is a collection form that re-sanitizes the
+// existing editor field values of all collection records with
+// their current field policy (eg. after a policy change).
+type RecordsSanitize struct {
+	app        core.App
+	dao        *daos.Dao
+	collection *models.Collection
+
+	// Fields is an optional list of editor field names to sanitize
+	// (if empty, all collection editor fields are sanitized).
+	Fields []string `form:"fields" json:"fields"`
+}
+
+// NewRecordsSanitize creates a new [RecordsSanitize] form initialized with
+// from the provided [core.App] instance and collection model.
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewRecordsSanitize(app core.App, collection *models.Collection) *RecordsSanitize {
+	return &RecordsSanitize{
+		app:        app,
+		dao:        app.Dao(),
+		collection: collection,
+	}
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *RecordsSanitize) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *RecordsSanitize) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(
+			&form.Fields,
+			validation.By(form.checkCollection),
+			validation.Each(validation.In(list.ToInterfaceSlice(form.editorFieldNames())...)),
+		),
+	)
+}
+
+func (form *RecordsSanitize) checkCollection(value any) error {
+	if form.collection.IsView() {
+		return validation.NewError("validation_view_collection", "View collection records cannot be updated.")
+	}
+
+	return nil
+}
+
+func (form *RecordsSanitize) editorFieldNames() []string {
+	names := []string{}
+
+	for _, field := range form.collection.Schema.Fields() {
+		if field.Type == schema.FieldTypeEditor {
+			names = append(names, field.Name)
+		}
+	}
+
+	return names
+}
+
+// Submit validates and submits the form.
+//
+// On success returns the number of the updated records
+// (records whose values are already sanitized are not saved).
+func (form *RecordsSanitize) Submit() (int, error) {
+	if err := form.Validate(); err != nil {
+		return 0, err
+	}
+
+	names := form.Fields
+	if len(names) == 0 {
+		names = form.editorFieldNames()
+	}
+
+	fields := make([]*schema.SchemaField, 0, len(names))
+	for _, name := range names {
+		if field := form.collection.Schema.GetFieldByName(name); field != nil {
+			fields = append(fields, field)
+		}
+	}
+
+	if len(fields) == 0 {
+		return 0, nil // nothing to sanitize
+	}
+
+	var updated int
+
+	for offset := 0; ; offset += recordsSanitizeBatchSize {
+		// note: the select is outside of the transaction to prevent SQLITE_LOCKED error when mixing read&write in a single transaction
+		records := []*models.Record{}
+		err := form.dao.RecordQuery(form.collection).
+			OrderBy("id ASC").
+			Offset(int64(offset)).
+			Limit(recordsSanitizeBatchSize).
+			All(&records)
+		if err != nil {
+			return updated, err
+		}
+
+		changed := []*models.Record{}
+		for _, record := range records {
+			if form.sanitizeRecord(record, fields) {
+				changed = append(changed, record)
+			}
+		}
+
+		if len(changed) > 0 {
+			txErr := form.dao.RunInTransaction(func(txDao *daos.Dao) error {
+				for _, record := range changed {
+					if err := txDao.SaveRecord(record); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+			if txErr != nil {
+				return updated, txErr
+			}
+
+			updated += len(changed)
+		}
+
+		if len(records) < recordsSanitizeBatchSize {
+			break
+		}
+	}
+
+	return updated, nil
+}
+
+// sanitizeRecord sanitizes the specified record editor fields
+// and reports whether any of the values was changed.
+func (form *RecordsSanitize) sanitizeRecord(record *models.Record, fields []*schema.SchemaField) bool {
+	var changed bool
+
+	for _, field := range fields {
+		options, ok := field.Options.(*schema.EditorOptions)
+		if !ok {
+			continue
+		}
+
+		original := record.GetString(field.Name)
+		sanitized := options.SanitizePolicy().Sanitize(original)
+
+		if sanitized != original {
+			record.Set(field.Name, sanitized)
+			changed = true
+		}
+	}
+
+	return changed
+}
@@ -0,0 +1,128 @@
+package forms_test
+
+import (
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func addEditorField(t *testing.T, app *tests.TestApp, collectionName string, options *schema.EditorOptions) *models.Collection {
+	collection, err := app.Dao().FindCollectionByNameOrId(collectionName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	collection.Schema.AddField(&schema.SchemaField{
+		Name:    "content",
+		Type:    schema.FieldTypeEditor,
+		Options: options,
+	})
+
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	return collection
+}
+
+func TestRecordsSanitizeValidate(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	demo2 := addEditorField(t, app, "demo2", &schema.EditorOptions{})
+
+	view, err := app.Dao().FindCollectionByNameOrId("view1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		name           string
+		collection     *models.Collection
+		fields         []string
+		expectedErrors []string
+	}{
+		{"view collection", view, nil, []string{"fields"}},
+		{"non editor field", demo2, []string{"title"}, []string{"fields"}},
+		{"missing field", demo2, []string{"missing"}, []string{"fields"}},
+		{"all editor fields", demo2, nil, nil},
+		{"explicit editor field", demo2, []string{"content"}, nil},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			form := forms.NewRecordsSanitize(app, s.collection)
+			form.Fields = s.fields
+
+			err := form.Validate()
+
+			errs, _ := err.(validation.Errors)
+			if len(errs) != len(s.expectedErrors) {
+				t.Fatalf("Expected errors %v, got %v", s.expectedErrors, errs)
+			}
+			for _, k := range s.expectedErrors {
+				if _, ok := errs[k]; !ok {
+					t.Fatalf("Missing expected %q error in %v", k, errs)
+				}
+			}
+		})
+	}
+}
+
+func TestRecordsSanitizeSubmit(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := addEditorField(t, app, "demo2", &schema.EditorOptions{
+		LinkRel: []string{"nofollow"},
+	})
+
+	values := map[string]string{
+		"llvuca81nly1qls": `<p onclick="alert(1)">a</p><script>alert(2)</script>`,
+		"achvryl401bhse3": `<p>b</p>`, // already sanitized
+		"0yxhwia2amd8gec": `<a href="https://example.com">c</a>`,
+	}
+
+	for id, value := range values {
+		record, err := app.Dao().FindRecordById(collection.Id, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		record.Set("content", value)
+		if err := app.Dao().WithoutHooks().SaveRecord(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	form := forms.NewRecordsSanitize(app, collection)
+
+	updated, err := form.Submit()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if updated != 2 {
+		t.Fatalf("Expected 2 updated records, got %d", updated)
+	}
+
+	expected := map[string]string{
+		"llvuca81nly1qls": `<p>a</p>`,
+		"achvryl401bhse3": `<p>b</p>`,
+		"0yxhwia2amd8gec": `<a href="https://example.com" rel="nofollow">c</a>`,
+	}
+
+	for id, value := range expected {
+		record, err := app.Dao().FindRecordById(collection.Id, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if v := record.GetString("content"); v != value {
+			t.Errorf("Expected record %q content %q, got %q", id, value, v)
+		}
+	}
+}
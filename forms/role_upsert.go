@@ -0,0 +1,88 @@
+package forms
+
+import (
+	"regexp"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/daos"
+	"github.com/pocketbase/pocketbase/models"
+)
+
+var roleNameRegex = regexp.MustCompile(`^[\w\-]+$`)
+
+// RoleUpsert is a [models.Role] upsert (create/update) form.
+type RoleUpsert struct {
+	app  core.App
+	dao  *daos.Dao
+	role *models.Role
+
+	Name        string `form:"name" json:"name"`
+	Description string `form:"description" json:"description"`
+}
+
+// NewRoleUpsert creates a new [RoleUpsert] form with initializer
+// config created from the provided [core.App] and [models.Role] instances
+// (for create you could pass a pointer to an empty Role - `&models.Role{}`).
+//
+// If you want to submit the form as part of a transaction,
+// you can change the default Dao via [SetDao()].
+func NewRoleUpsert(app core.App, role *models.Role) *RoleUpsert {
+	form := &RoleUpsert{
+		app:  app,
+		dao:  app.Dao(),
+		role: role,
+	}
+
+	// load defaults
+	form.Name = role.Name
+	form.Description = role.Description
+
+	return form
+}
+
+// SetDao replaces the default form Dao instance with the provided one.
+func (form *RoleUpsert) SetDao(dao *daos.Dao) {
+	form.dao = dao
+}
+
+// Validate makes the form validatable by implementing [validation.Validatable] interface.
+func (form *RoleUpsert) Validate() error {
+	return validation.ValidateStruct(form,
+		validation.Field(
+			&form.Name,
+			validation.Required,
+			validation.Length(1, 100),
+			validation.Match(roleNameRegex),
+			validation.By(form.checkUniqueName),
+		),
+		validation.Field(&form.Description, validation.Length(0, 1000)),
+	)
+}
+
+func (form *RoleUpsert) checkUniqueName(value any) error {
+	v, _ := value.(string)
+
+	if !form.dao.IsRoleNameUnique(v, form.role.Id) {
+		return validation.NewError("validation_role_name_exists", "Role name already exists.")
+	}
+
+	return nil
+}
+
+// Submit validates the form and upserts the form role model.
+//
+// You can optionally provide a list of InterceptorFunc to further
+// modify the form behavior before persisting it.
+func (form *RoleUpsert) Submit(interceptors ...InterceptorFunc[*models.Role]) error {
+	if err := form.Validate(); err != nil {
+		return err
+	}
+
+	form.role.Name = form.Name
+	form.role.Description = form.Description
+
+	return runInterceptors(form.role, func(role *models.Role) error {
+		return form.dao.SaveRole(role)
+	}, interceptors...)
+}
@@ -0,0 +1,129 @@
+package forms_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/forms"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestRoleUpsertValidateAndSubmit(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		id             string // empty for new role
+		jsonData       string
+		expectedErrors []string
+	}{
+		{
+			"create with empty data",
+			"",
+			`{}`,
+			[]string{"name"},
+		},
+		{
+			"create with invalid data",
+			"",
+			`{"name":"invalid name!", "description":"` + strings.Repeat("a", 1001) + `"}`,
+			[]string{"name", "description"},
+		},
+		{
+			"create with existing name (case insensitive)",
+			"",
+			`{"name":"EDITOR"}`,
+			[]string{"name"},
+		},
+		{
+			"create with valid data",
+			"",
+			`{"name":"content-manager", "description":"test"}`,
+			[]string{},
+		},
+		{
+			"update with the same name",
+			"r0l3ed1t0r00001",
+			`{"name":"editor", "description":"updated"}`,
+			[]string{},
+		},
+		{
+			"update with existing name",
+			"r0l3ed1t0r00001",
+			`{"name":"moderator"}`,
+			[]string{"name"},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			app, _ := tests.NewTestApp()
+			defer app.Cleanup()
+
+			role := &models.Role{}
+			if s.id != "" {
+				var err error
+				role, err = app.Dao().FindRoleByNameOrId(s.id)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			form := forms.NewRoleUpsert(app, role)
+
+			if err := json.Unmarshal([]byte(s.jsonData), form); err != nil {
+				t.Fatalf("Failed to load form data: %v", err)
+			}
+
+			interceptorCalls := 0
+			interceptor := func(next forms.InterceptorNextFunc[*models.Role]) forms.InterceptorNextFunc[*models.Role] {
+				return func(m *models.Role) error {
+					interceptorCalls++
+					return next(m)
+				}
+			}
+
+			err := form.Submit(interceptor)
+
+			// parse errors
+			errs, ok := err.(validation.Errors)
+			if !ok && err != nil {
+				t.Fatalf("Failed to parse errors %v", err)
+			}
+
+			if len(errs) != len(s.expectedErrors) {
+				t.Fatalf("Expected error keys %v, got %v", s.expectedErrors, errs)
+			}
+			for _, k := range s.expectedErrors {
+				if _, ok := errs[k]; !ok {
+					t.Fatalf("Missing expected error key %q in %v", k, errs)
+				}
+			}
+
+			if len(s.expectedErrors) > 0 {
+				if interceptorCalls != 0 {
+					t.Fatalf("Expected the interceptor to not be called, got %d calls", interceptorCalls)
+				}
+				return
+			}
+
+			if interceptorCalls != 1 {
+				t.Fatalf("Expected the interceptor to be called once, got %d calls", interceptorCalls)
+			}
+
+			saved, err := app.Dao().FindRoleByNameOrId(role.Id)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if saved.Name != form.Name {
+				t.Fatalf("Expected name %q, got %q", form.Name, saved.Name)
+			}
+
+			if saved.Description != form.Description {
+				t.Fatalf("Expected description %q, got %q", form.Description, saved.Description)
+			}
+		})
+	}
+}
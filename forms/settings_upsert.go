@@ -1,6 +1,7 @@
 package forms
 
 import (
+	"context"
 	"os"
 	"time"
 
@@ -9,7 +10,6 @@ import (
 	"github.com/pocketbase/pocketbase/daos"
 	"github.com/pocketbase/pocketbase/models"
 	"github.com/pocketbase/pocketbase/models/settings"
-	"github.com/pocketbase/pocketbase/tools/types"
 )
 
 // SettingsUpsert is a [settings.Settings] upsert (create/update) form.
@@ -44,7 +44,7 @@ func (form *SettingsUpsert) SetDao(dao *daos.Dao) {
 
 // Validate makes the form validatable by implementing [validation.Validatable] interface.
 func (form *SettingsUpsert) Validate() error {
-	return form.Settings.Validate()
+	return SettingsUpsertValidators.Validate(form, form.Settings.Validate())
 }
 
 // Submit validates the form and upserts the loaded settings.
@@ -58,13 +58,26 @@ func (form *SettingsUpsert) Submit(interceptors ...InterceptorFunc[*settings.Set
 		return err
 	}
 
+	// keep the rotated token secrets valid during their grace period
+	oldTokenConfigs := form.app.Settings().NamedTokenConfigs()
+	for name, config := range form.Settings.NamedTokenConfigs() {
+		if old, ok := oldTokenConfigs[name]; ok {
+			config.ApplySecretRotation(*old)
+		}
+	}
+
 	return runInterceptors(form.Settings, func(s *settings.Settings) error {
 		form.Settings = s
 
 		// persists settings change
-		encryptionKey := os.Getenv(form.app.EncryptionEnv())
-		if err := form.dao.SaveSettings(form.Settings, encryptionKey); err != nil {
-			return err
+		var saveErr error
+		if backend := form.app.SecretsBackend(); backend != nil {
+			saveErr = form.dao.SaveSettingsWithBackend(context.Background(), form.Settings, backend)
+		} else {
+			saveErr = form.dao.SaveSettings(form.Settings, os.Getenv(form.app.EncryptionEnv()))
+		}
+		if saveErr != nil {
+			return saveErr
 		}
 
 		// reload app settings
@@ -73,9 +86,8 @@ func (form *SettingsUpsert) Submit(interceptors ...InterceptorFunc[*settings.Set
 		}
 
 		// try to clear old logs not matching the new settings
-		createdBefore := time.Now().AddDate(0, 0, -1*form.Settings.Logs.MaxDays).UTC().Format(types.DefaultDateLayout)
-		expr := dbx.NewExp("[[created]] <= {:date} OR [[level]] < {:level}", dbx.Params{
-			"date":  createdBefore,
+		form.app.LogsDao().DeleteExpiredLogs(form.Settings.Logs, time.Now())
+		expr := dbx.NewExp("[[level]] < {:level}", dbx.Params{
 			"level": form.Settings.Logs.MinLevel,
 		})
 		form.app.LogsDao().NonconcurrentDB().Delete((&models.Log{}).TableName(), expr).Execute()
@@ -164,3 +164,48 @@ func TestSettingsUpsertSubmitInterceptors(t *testing.T) {
 		t.Fatalf("Expected interceptor2 to be called")
 	}
 }
+
+func TestSettingsUpsertSubmitTokenSecretRotation(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	oldAdminSecret := app.Settings().AdminAuthToken.Secret
+	oldRecordSecret := app.Settings().RecordAuthToken.Secret
+
+	form := forms.NewSettingsUpsert(app)
+	form.AdminAuthToken.Secret = security.RandomString(50)
+	form.AdminAuthToken.RotationGrace = 3600
+	form.RecordAuthToken.Secret = security.RandomString(50)
+	form.RecordAuthToken.PreviousSecret = "tampered" // should be ignored
+
+	if err := form.Submit(); err != nil {
+		t.Fatal(err)
+	}
+
+	adminSecrets := app.Settings().AdminAuthToken.PreviousSecrets()
+	if len(adminSecrets) != 1 || adminSecrets[0] != oldAdminSecret {
+		t.Fatalf("Expected the old admin auth secret to be still valid, got %v", adminSecrets)
+	}
+
+	recordSecrets := app.Settings().RecordAuthToken.PreviousSecrets()
+	if len(recordSecrets) != 0 {
+		t.Fatalf("Expected no previous record auth secrets, got %v", recordSecrets)
+	}
+	if app.Settings().RecordAuthToken.Secret == oldRecordSecret {
+		t.Fatal("Expected the record auth secret to be changed")
+	}
+
+	// resubmit without secret change (should preserve the rotation state)
+	form = forms.NewSettingsUpsert(app)
+	form.Meta.AppName = "rotation_test"
+	form.AdminAuthToken.PreviousSecret = ""
+
+	if err := form.Submit(); err != nil {
+		t.Fatal(err)
+	}
+
+	adminSecrets = app.Settings().AdminAuthToken.PreviousSecrets()
+	if len(adminSecrets) != 1 || adminSecrets[0] != oldAdminSecret {
+		t.Fatalf("Expected the old admin auth secret to be preserved, got %v", adminSecrets)
+	}
+}
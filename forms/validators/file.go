@@ -2,13 +2,54 @@ package validators
 
 import (
 	"fmt"
+	"mime"
+	"path/filepath"
 	"strings"
 
 	"github.com/gabriel-vasile/mimetype"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/models/schema"
 	"github.com/pocketbase/pocketbase/tools/filesystem"
 )
 
+// extensionMimeTypes contains the content types of the common file extensions
+// that are missing from the builtin [mime.TypeByExtension] table.
+var extensionMimeTypes = map[string]string{
+	".txt":  "text/plain",
+	".csv":  "text/csv",
+	".md":   "text/markdown",
+	".rtf":  "text/rtf",
+	".sh":   "text/x-shellscript",
+	".bmp":  "image/bmp",
+	".ico":  "image/x-icon",
+	".tif":  "image/tiff",
+	".tiff": "image/tiff",
+	".heic": "image/heic",
+	".mp3":  "audio/mpeg",
+	".wav":  "audio/wav",
+	".flac": "audio/flac",
+	".ogg":  "application/ogg",
+	".mp4":  "video/mp4",
+	".mov":  "video/quicktime",
+	".webm": "video/webm",
+	".zip":  "application/zip",
+	".gz":   "application/gzip",
+	".tar":  "application/x-tar",
+	".7z":   "application/x-7z-compressed",
+	".rar":  "application/x-rar-compressed",
+	".doc":  "application/x-ole-storage",
+	".xls":  "application/x-ole-storage",
+	".ppt":  "application/x-ole-storage",
+	".docx": "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	".xlsx": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	".pptx": "application/vnd.openxmlformats-officedocument.presentationml.presentation",
+	".odt":  "application/vnd.oasis.opendocument.text",
+	".ods":  "application/vnd.oasis.opendocument.spreadsheet",
+	".exe":  "application/vnd.microsoft.portable-executable",
+	".dll":  "application/vnd.microsoft.portable-executable",
+	".apk":  "application/vnd.android.package-archive",
+}
+
 // UploadedFileSize checks whether the validated `rest.UploadedFile`
 // size is no more than the provided maxBytes.
 //
@@ -83,3 +124,142 @@ func UploadedFileMimeType(validTypes []string) validation.RuleFunc {
 		)
 	}
 }
+
+// UploadedFileDeniedMimeType checks whether the validated `rest.UploadedFile`
+// sniffed mimetype is NOT within the provided denied mime types.
+//
+// Example:
+//
+//	deniedMimeTypes := []string{"application/vnd.microsoft.portable-executable"}
+//	validation.Field(&form.File, validation.By(validators.UploadedFileDeniedMimeType(deniedMimeTypes)))
+func UploadedFileDeniedMimeType(deniedTypes []string) validation.RuleFunc {
+	return func(value any) error {
+		v, _ := value.(*filesystem.File)
+		if v == nil || len(deniedTypes) == 0 {
+			return nil // nothing to validate
+		}
+
+		baseErr := validation.NewError(
+			"validation_invalid_mime_type",
+			fmt.Sprintf("Failed to upload %q due to unsupported file type.", v.OriginalName),
+		)
+
+		filetype, err := detectUploadedFileMimeType(v)
+		if err != nil {
+			return baseErr
+		}
+
+		for m := filetype; m != nil; m = m.Parent() {
+			for _, t := range deniedTypes {
+				if m.Is(t) {
+					return baseErr
+				}
+			}
+		}
+
+		return nil
+	}
+}
+
+// UploadedFileExtension checks whether the validated `rest.UploadedFile`
+// extension matches with its sniffed content mimetype
+// (eg. an executable file renamed to ".png" is rejected).
+//
+// Files without extension or with an extension of unknown content type are
+// always accepted, because there is nothing to compare the sniffed type with.
+//
+// Example:
+//
+//	validation.Field(&form.File, validation.By(validators.UploadedFileExtension()))
+func UploadedFileExtension() validation.RuleFunc {
+	return func(value any) error {
+		v, _ := value.(*filesystem.File)
+		if v == nil {
+			return nil // nothing to validate
+		}
+
+		ext := strings.ToLower(filepath.Ext(v.OriginalName))
+
+		filetype, err := detectUploadedFileMimeType(v)
+		if err != nil || !extensionMatchesMimeType(ext, filetype) {
+			return validation.NewError(
+				"validation_mime_type_extension_mismatch",
+				fmt.Sprintf("Failed to upload %q - the file extension doesn't match its content.", v.OriginalName),
+			)
+		}
+
+		return nil
+	}
+}
+
+// UploadedFileContent checks the validated `rest.UploadedFile` sniffed
+// content type against the content related options of the provided file
+// field (the allowed and denied mime types and the strict extension match).
+func UploadedFileContent(options *schema.FileOptions) validation.RuleFunc {
+	return func(value any) error {
+		if len(options.MimeTypes) > 0 {
+			if err := UploadedFileMimeType(options.MimeTypes)(value); err != nil {
+				return err
+			}
+		}
+
+		if err := UploadedFileDeniedMimeType(options.DeniedMimeTypes)(value); err != nil {
+			return err
+		}
+
+		if options.StrictExtension {
+			if err := UploadedFileExtension()(value); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+func detectUploadedFileMimeType(file *filesystem.File) (*mimetype.MIME, error) {
+	f, err := file.Reader.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return mimetype.DetectReader(f)
+}
+
+// extensionMatchesMimeType checks whether the provided lowercased
+// file extension is compatible with the sniffed content mimetype
+// (or any of its parent types, eg. ".zip" for a ".docx" file).
+func extensionMatchesMimeType(ext string, filetype *mimetype.MIME) bool {
+	if ext == "" {
+		return true
+	}
+
+	claimed, _, _ := mime.ParseMediaType(mime.TypeByExtension(ext))
+	if claimed == "" {
+		claimed = extensionMimeTypes[ext]
+	}
+
+	isText := false
+
+	for m := filetype; m != nil; m = m.Parent() {
+		if m.Extension() == ext || (ext == ".jpeg" && m.Extension() == ".jpg") {
+			return true
+		}
+
+		if claimed != "" && m.Is(claimed) {
+			return true
+		}
+
+		if m.Is("text/plain") {
+			isText = true
+		}
+	}
+
+	if claimed == "" {
+		return true // unknown extension
+	}
+
+	// generic text content with a text based extension (eg. ".md", ".css")
+	return isText && strings.HasPrefix(claimed, "text/")
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/pocketbase/pocketbase/forms/validators"
+	"github.com/pocketbase/pocketbase/models/schema"
 	"github.com/pocketbase/pocketbase/tests"
 	"github.com/pocketbase/pocketbase/tools/filesystem"
 	"github.com/pocketbase/pocketbase/tools/rest"
@@ -91,3 +92,96 @@ func TestUploadedFileMimeType(t *testing.T) {
 		}
 	}
 }
+
+func TestUploadedFileDeniedMimeType(t *testing.T) {
+	txt, _ := filesystem.NewFileFromBytes([]byte("test"), "test.txt")
+	exe, _ := filesystem.NewFileFromBytes(append([]byte("MZ"), make([]byte, 100)...), "test.png")
+
+	scenarios := []struct {
+		types       []string
+		file        *filesystem.File
+		expectError bool
+	}{
+		{nil, nil, false},
+		{[]string{"text/plain"}, nil, false},
+		{nil, txt, false},
+		{[]string{"image/png"}, txt, false},
+		{[]string{"text/plain"}, txt, true},
+		{[]string{"image/png"}, exe, false},
+		{[]string{"application/vnd.microsoft.portable-executable"}, exe, true},
+		// parent type
+		{[]string{"application/octet-stream"}, exe, true},
+	}
+
+	for i, s := range scenarios {
+		err := validators.UploadedFileDeniedMimeType(s.types)(s.file)
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("(%d) Expected hasErr to be %v, got %v (%v)", i, s.expectError, hasErr, err)
+		}
+	}
+}
+
+func TestUploadedFileExtension(t *testing.T) {
+	pngContent := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	exeContent := append([]byte("MZ"), make([]byte, 100)...)
+
+	scenarios := []struct {
+		name        string
+		content     []byte
+		expectError bool
+	}{
+		{"test.txt", []byte("test"), false},
+		{"test.md", []byte("# test"), false},
+		{"test.json", []byte(`{"a":1}`), false},
+		{"test.png", pngContent, false},
+		{"test.PNG", pngContent, false},
+		{"test.jpg", pngContent, true},
+		{"test.png", []byte("test"), true},
+		{"test.png", exeContent, true},
+		{"test.exe", exeContent, false},
+		// no or unknown extension
+		{"test", exeContent, false},
+		{"test.unknown", exeContent, false},
+	}
+
+	for i, s := range scenarios {
+		file, err := filesystem.NewFileFromBytes(s.content, s.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = validators.UploadedFileExtension()(file)
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("(%d) Expected hasErr to be %v, got %v (%v)", i, s.expectError, hasErr, err)
+		}
+	}
+}
+
+func TestUploadedFileContent(t *testing.T) {
+	exe, _ := filesystem.NewFileFromBytes(append([]byte("MZ"), make([]byte, 100)...), "test.png")
+
+	scenarios := []struct {
+		options     *schema.FileOptions
+		file        *filesystem.File
+		expectError bool
+	}{
+		{&schema.FileOptions{}, nil, false},
+		{&schema.FileOptions{}, exe, false},
+		{&schema.FileOptions{MimeTypes: []string{"image/png"}}, exe, true},
+		{&schema.FileOptions{DeniedMimeTypes: []string{"application/vnd.microsoft.portable-executable"}}, exe, true},
+		{&schema.FileOptions{StrictExtension: true}, exe, true},
+	}
+
+	for i, s := range scenarios {
+		err := validators.UploadedFileContent(s.options)(s.file)
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("(%d) Expected hasErr to be %v, got %v (%v)", i, s.expectError, hasErr, err)
+		}
+	}
+}
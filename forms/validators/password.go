@@ -0,0 +1,68 @@
+package validators
+
+import (
+	"context"
+	"unicode"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/pwned"
+)
+
+// PasswordPolicy checks whether the validated password satisfies the
+// character classes and breach check requirements of the provided
+// auth collection options.
+//
+// The breach check fails open, aka. the password is accepted if
+// the Pwned Passwords API is unavailable.
+//
+// Example:
+//
+//	validation.Field(&form.Password, validation.By(validators.PasswordPolicy(collection.AuthOptions())))
+func PasswordPolicy(options models.CollectionAuthOptions) validation.RuleFunc {
+	return func(value any) error {
+		v, _ := value.(string)
+		if v == "" {
+			return nil // nothing to check
+		}
+
+		var hasUpper, hasLower, hasDigit, hasSymbol bool
+		for _, r := range v {
+			switch {
+			case unicode.IsUpper(r):
+				hasUpper = true
+			case unicode.IsLower(r):
+				hasLower = true
+			case unicode.IsDigit(r):
+				hasDigit = true
+			case !unicode.IsLetter(r) && !unicode.IsSpace(r):
+				hasSymbol = true
+			}
+		}
+
+		if options.PasswordRequireUppercase && !hasUpper {
+			return validation.NewError("validation_password_uppercase_required", "The password must contain at least one uppercase letter.")
+		}
+
+		if options.PasswordRequireLowercase && !hasLower {
+			return validation.NewError("validation_password_lowercase_required", "The password must contain at least one lowercase letter.")
+		}
+
+		if options.PasswordRequireDigit && !hasDigit {
+			return validation.NewError("validation_password_digit_required", "The password must contain at least one digit.")
+		}
+
+		if options.PasswordRequireSymbol && !hasSymbol {
+			return validation.NewError("validation_password_symbol_required", "The password must contain at least one symbol.")
+		}
+
+		if options.PasswordBreachCheck {
+			count, err := pwned.Count(context.Background(), v)
+			if err == nil && count > 0 {
+				return validation.NewError("validation_password_breached", "The password was found in a known data breach, please choose a different one.")
+			}
+		}
+
+		return nil
+	}
+}
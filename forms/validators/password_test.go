@@ -0,0 +1,135 @@
+package validators_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pocketbase/pocketbase/forms/validators"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/pwned"
+)
+
+func TestPasswordPolicy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// sha1("password") = 5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8
+		fmt.Fprint(w, "1E4C9B93F3F0682250B6CF8331B7EE68FD8:3861493\r\n")
+	}))
+	defer server.Close()
+
+	originalRangeUrl := pwned.DefaultClient.RangeUrl
+	pwned.DefaultClient.RangeUrl = server.URL + "/range/"
+	defer func() {
+		pwned.DefaultClient.RangeUrl = originalRangeUrl
+	}()
+
+	scenarios := []struct {
+		name          string
+		options       models.CollectionAuthOptions
+		value         string
+		expectedError string
+	}{
+		{
+			"empty value",
+			models.CollectionAuthOptions{PasswordRequireUppercase: true, PasswordBreachCheck: true},
+			"",
+			"",
+		},
+		{
+			"no policy",
+			models.CollectionAuthOptions{},
+			"password",
+			"",
+		},
+		{
+			"missing uppercase",
+			models.CollectionAuthOptions{PasswordRequireUppercase: true},
+			"abc123!",
+			"validation_password_uppercase_required",
+		},
+		{
+			"missing lowercase",
+			models.CollectionAuthOptions{PasswordRequireLowercase: true},
+			"ABC123!",
+			"validation_password_lowercase_required",
+		},
+		{
+			"missing digit",
+			models.CollectionAuthOptions{PasswordRequireDigit: true},
+			"Abcdef!",
+			"validation_password_digit_required",
+		},
+		{
+			"missing symbol",
+			models.CollectionAuthOptions{PasswordRequireSymbol: true},
+			"Abc 123",
+			"validation_password_symbol_required",
+		},
+		{
+			"all character classes",
+			models.CollectionAuthOptions{
+				PasswordRequireUppercase: true,
+				PasswordRequireLowercase: true,
+				PasswordRequireDigit:     true,
+				PasswordRequireSymbol:    true,
+			},
+			"Abc123!",
+			"",
+		},
+		{
+			"breached password",
+			models.CollectionAuthOptions{PasswordBreachCheck: true},
+			"password",
+			"validation_password_breached",
+		},
+		{
+			"non breached password",
+			models.CollectionAuthOptions{PasswordBreachCheck: true},
+			"1234567890",
+			"",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			err := validators.PasswordPolicy(s.options)(s.value)
+
+			if s.expectedError == "" {
+				if err != nil {
+					t.Fatalf("Expected no error, got %v", err)
+				}
+				return
+			}
+
+			vErr, ok := err.(validation.Error)
+			if !ok {
+				t.Fatalf("Expected validation.Error, got %v", err)
+			}
+
+			if vErr.Code() != s.expectedError {
+				t.Fatalf("Expected error code %q, got %q", s.expectedError, vErr.Code())
+			}
+		})
+	}
+}
+
+func TestPasswordPolicyBreachCheckFailOpen(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	originalRangeUrl := pwned.DefaultClient.RangeUrl
+	pwned.DefaultClient.RangeUrl = server.URL + "/range/"
+	defer func() {
+		pwned.DefaultClient.RangeUrl = originalRangeUrl
+	}()
+
+	options := models.CollectionAuthOptions{PasswordBreachCheck: true}
+
+	if err := validators.PasswordPolicy(options)("password"); err != nil {
+		t.Fatalf("Expected the password to be accepted on range api error, got %v", err)
+	}
+}
@@ -1,6 +1,8 @@
 package validators
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
 	"net/url"
 	"regexp"
@@ -12,13 +14,18 @@ import (
 	"github.com/pocketbase/pocketbase/daos"
 	"github.com/pocketbase/pocketbase/models"
 	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/resolvers"
 	"github.com/pocketbase/pocketbase/tools/filesystem"
+	"github.com/pocketbase/pocketbase/tools/inflector"
 	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/search"
 	"github.com/pocketbase/pocketbase/tools/types"
 )
 
 var requiredErr = validation.NewError("validation_required", "Missing required value")
 
+var requiredIfErr = validation.NewError("validation_required_if", "Missing conditionally required value")
+
 // NewRecordDataValidator creates new [models.Record] data validator
 // using the provided record constraints and schema.
 //
@@ -81,11 +88,115 @@ func (validator *RecordDataValidator) Validate(data map[string]any) error {
 			continue
 		}
 
+		// check conditional required constraint
+		if field.RequiredIf != "" && validation.Required.Validate(value) != nil {
+			match, err := validator.matchExpression(field.RequiredIf, data)
+			if err != nil {
+				errs[key] = validation.NewError("validation_invalid_rule", "Failed to evaluate the field required condition.")
+				continue
+			}
+			if match {
+				errs[key] = requiredIfErr
+				continue
+			}
+		}
+
 		// validate field value by its field type
 		if err := validator.checkFieldValue(field, value); err != nil {
 			errs[key] = err
 			continue
 		}
+
+		// check the tree parent constraints
+		if key == validator.record.Collection().TreeField() {
+			if err := validator.checkTreeParent(value); err != nil {
+				errs[key] = err
+				continue
+			}
+		}
+	}
+
+	// check the "through" relations integrity (if the record is a join record)
+	if len(errs) == 0 {
+		if err := validator.checkThroughIntegrity(data); err != nil {
+			return err
+		}
+	}
+
+	// check the fields validation rules
+	if len(errs) == 0 {
+		for key, field := range keyedSchema {
+			if err := validator.checkValidationRule(field, data); err != nil {
+				errs[key] = err
+			}
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errs
+}
+
+// checkThroughIntegrity checks whether the provided join record data
+// doesn't break the integrity of the "through" relations that use the
+// validator record collection as their join collection.
+func (validator *RecordDataValidator) checkThroughIntegrity(data map[string]any) error {
+	joinCollection := validator.record.Collection()
+
+	refs, err := validator.dao.FindCollectionThroughReferences(joinCollection)
+	if err != nil {
+		return validation.NewError("validation_through_references", "Failed to load the through relation references.")
+	}
+
+	errs := validation.Errors{}
+
+	for _, fields := range refs {
+		for _, field := range fields {
+			options, _ := field.Options.(*schema.RelationOptions)
+			through := options.Through
+
+			sourceId := validator.singleRelationValue(through.SourceField, data)
+			targetId := validator.singleRelationValue(through.TargetField, data)
+			if sourceId == "" || targetId == "" {
+				continue // nothing to check
+			}
+
+			sourceCol := inflector.Columnify(through.SourceField)
+			targetCol := inflector.Columnify(through.TargetField)
+
+			// check for duplicated join records
+			var total int
+			validator.dao.RecordQuery(joinCollection).
+				Select("count(*)").
+				AndWhere(dbx.HashExp{sourceCol: sourceId, targetCol: targetId}).
+				AndWhere(dbx.Not(dbx.HashExp{"id": validator.record.Id})).
+				Row(&total)
+			if total > 0 {
+				errs[through.TargetField] = validation.NewError(
+					"validation_through_duplicated_relation",
+					"The relation already exists.",
+				)
+				continue
+			}
+
+			// check the max select constraint of the "through" field
+			if options.MaxSelect != nil {
+				var totalLinked int
+				validator.dao.RecordQuery(joinCollection).
+					Select("count(*)").
+					AndWhere(dbx.HashExp{sourceCol: sourceId}).
+					AndWhere(dbx.Not(dbx.HashExp{"id": validator.record.Id})).
+					Row(&totalLinked)
+				if totalLinked+1 > *options.MaxSelect {
+					errs[through.TargetField] = validation.NewError(
+						"validation_through_too_many_values",
+						fmt.Sprintf("The %q relation cannot have more than %d records.", field.Name, *options.MaxSelect),
+					)
+				}
+			}
+		}
 	}
 
 	if len(errs) == 0 {
@@ -95,6 +206,130 @@ func (validator *RecordDataValidator) Validate(data map[string]any) error {
 	return errs
 }
 
+// checkTreeParent checks whether the provided tree parent
+// doesn't create a cycle or exceed the tree max depth.
+func (validator *RecordDataValidator) checkTreeParent(value any) error {
+	ids := list.ToUniqueStringSlice(value)
+	if len(ids) == 0 {
+		return nil // root record
+	}
+	parentId := ids[0]
+
+	collection := validator.record.Collection()
+	maxDepth := collection.TreeMaxDepth()
+
+	cycleErr := validation.NewError(
+		"validation_tree_cycle",
+		"The parent cannot be the record itself or one of its descendants.",
+	)
+
+	if validator.record.Id != "" && parentId == validator.record.Id {
+		return cycleErr
+	}
+
+	ancestors, err := validator.dao.FindTreeAncestorNodes(collection, parentId, maxDepth)
+	if err != nil {
+		return validation.NewError("validation_tree_ancestors", "Failed to load the parent ancestors.")
+	}
+
+	for _, ancestor := range ancestors {
+		if ancestor.Id == validator.record.Id {
+			return cycleErr
+		}
+	}
+
+	// the record depth is the number of its ancestors (0 for the roots)
+	depth := len(ancestors) + 1
+
+	// include the subtree height of the record (if already persisted)
+	if !validator.record.IsNew() {
+		descendants, err := validator.dao.FindTreeDescendantNodes(collection, validator.record.Id, maxDepth)
+		if err != nil {
+			return validation.NewError("validation_tree_descendants", "Failed to load the record descendants.")
+		}
+		if total := len(descendants); total > 0 {
+			depth += descendants[total-1].Depth
+		}
+	}
+
+	if depth > maxDepth {
+		return validation.NewError(
+			"validation_tree_max_depth",
+			fmt.Sprintf("The tree cannot be deeper than %d levels.", maxDepth),
+		)
+	}
+
+	return nil
+}
+
+// singleRelationValue returns the normalized single relation id
+// of the provided field name from the validated data.
+func (validator *RecordDataValidator) singleRelationValue(fieldName string, data map[string]any) string {
+	field := validator.record.Collection().Schema.GetFieldByName(fieldName)
+	if field == nil {
+		return ""
+	}
+
+	ids := list.ToUniqueStringSlice(field.PrepareValue(data[fieldName]))
+	if len(ids) == 0 {
+		return ""
+	}
+
+	return ids[0]
+}
+
+// checkValidationRule evaluates the field validation rule expression
+// (if any) against the provided record data.
+func (validator *RecordDataValidator) checkValidationRule(field *schema.SchemaField, data map[string]any) error {
+	if field.ValidationRule == "" {
+		return nil // nothing to check
+	}
+
+	match, err := validator.matchExpression(field.ValidationRule, data)
+	if err != nil {
+		return validation.NewError("validation_invalid_rule", "Failed to evaluate the field validation rule.")
+	}
+
+	if match {
+		return nil
+	}
+
+	message := field.ValidationMessage
+	if message == "" {
+		message = "Invalid value."
+	}
+
+	return validation.NewError("validation_rule_failed", message)
+}
+
+// matchExpression checks whether the provided filter expression
+// is satisfied by the record data.
+func (validator *RecordDataValidator) matchExpression(rule string, data map[string]any) (bool, error) {
+	// normalize the data values and add the base model fields
+	resolverData := make(map[string]any, len(data)+3)
+	for _, f := range validator.record.Collection().Schema.Fields() {
+		resolverData[f.Name] = f.PrepareValue(data[f.Name])
+	}
+	resolverData[schema.FieldNameId] = validator.record.Id
+	resolverData[schema.FieldNameCreated] = validator.record.Created
+	resolverData[schema.FieldNameUpdated] = validator.record.Updated
+
+	resolver := resolvers.NewRecordDataResolver(validator.record.Collection(), resolverData)
+
+	expr, err := search.FilterData(rule).BuildExpr(resolver)
+	if err != nil {
+		return false, err
+	}
+
+	var exists int
+	err = validator.dao.DB().Select("(1)").AndWhere(expr).Limit(1).Row(&exists)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return false, err
+	}
+
+	return exists > 0, nil
+}
+
 func (validator *RecordDataValidator) checkFieldValue(field *schema.SchemaField, value any) error {
 	switch field.Type {
 	case schema.FieldTypeText:
@@ -109,13 +344,25 @@ func (validator *RecordDataValidator) checkFieldValue(field *schema.SchemaField,
 		return validator.checkUrlValue(field, value)
 	case schema.FieldTypeEditor:
 		return validator.checkEditorValue(field, value)
+	case schema.FieldTypeMarkdown:
+		return validator.checkMarkdownValue(field, value)
+	case schema.FieldTypeColor:
+		return validator.checkColorValue(field, value)
+	case schema.FieldTypeCurrency:
+		return validator.checkCurrencyValue(field, value)
+	case schema.FieldTypeDuration:
+		return validator.checkDurationValue(field, value)
+	case schema.FieldTypePhone:
+		return validator.checkPhoneValue(field, value)
+	case schema.FieldTypeList:
+		return validator.checkListValue(field, value)
 	case schema.FieldTypeDate:
 		return validator.checkDateValue(field, value)
 	case schema.FieldTypeSelect:
 		return validator.checkSelectValue(field, value)
 	case schema.FieldTypeJson:
 		return validator.checkJsonValue(field, value)
-	case schema.FieldTypeFile:
+	case schema.FieldTypeFile, schema.FieldTypeAvatar:
 		return validator.checkFileValue(field, value)
 	case schema.FieldTypeRelation:
 		return validator.checkRelationValue(field, value)
@@ -239,6 +486,142 @@ func (validator *RecordDataValidator) checkEditorValue(field *schema.SchemaField
 	return nil
 }
 
+func (validator *RecordDataValidator) checkMarkdownValue(field *schema.SchemaField, value any) error {
+	val, _ := value.(types.JsonMap)
+	source, _ := val["source"].(string)
+	if source == "" {
+		return nil // nothing to check
+	}
+
+	options, _ := field.Options.(*schema.MarkdownOptions)
+
+	// note: casted to []rune to count multi-byte chars as one
+	if options.Max != nil && len([]rune(source)) > *options.Max {
+		return validation.NewError("validation_max_text_constraint", fmt.Sprintf("Must be less than %d character(s)", *options.Max))
+	}
+
+	return nil
+}
+
+func (validator *RecordDataValidator) checkColorValue(field *schema.SchemaField, value any) error {
+	val, _ := value.(string)
+	if val == "" {
+		return nil // nothing to check
+	}
+
+	color, err := types.ParseColor(val)
+	if err != nil {
+		return validation.NewError("validation_invalid_color", "Must be a valid hex or rgb(a) color.")
+	}
+
+	options, _ := field.Options.(*schema.ColorOptions)
+
+	if !options.AllowAlpha && len(color) != 7 {
+		return validation.NewError("validation_color_alpha_not_allowed", "Colors with transparency are not allowed.")
+	}
+
+	return nil
+}
+
+func (validator *RecordDataValidator) checkCurrencyValue(field *schema.SchemaField, value any) error {
+	val, _ := value.(int64)
+	if val == 0 {
+		return nil // nothing to check (skip zero-defaults)
+	}
+
+	options, _ := field.Options.(*schema.CurrencyOptions)
+
+	if options.Min != nil && val < *options.Min {
+		return validation.NewError("validation_min_number_constraint", fmt.Sprintf("Must be larger than %d", *options.Min))
+	}
+
+	if options.Max != nil && val > *options.Max {
+		return validation.NewError("validation_max_number_constraint", fmt.Sprintf("Must be less than %d", *options.Max))
+	}
+
+	return nil
+}
+
+func (validator *RecordDataValidator) checkPhoneValue(field *schema.SchemaField, value any) error {
+	val, ok := value.(types.Phone)
+	if !ok {
+		return validation.NewError("validation_invalid_phone", "Must be a valid phone number.")
+	}
+
+	if val.IsZero() {
+		if field.Required {
+			return requiredErr
+		}
+		return nil // nothing to check
+	}
+
+	options, _ := field.Options.(*schema.PhoneOptions)
+
+	if len(options.AllowedCountryCodes) > 0 && !list.ExistInSlice(val.CountryCode(), options.AllowedCountryCodes) {
+		return validation.NewError("validation_phone_country_not_allowed", "The phone number country code is not allowed.")
+	}
+
+	return nil
+}
+
+func (validator *RecordDataValidator) checkListValue(field *schema.SchemaField, value any) error {
+	var size int
+
+	switch v := value.(type) {
+	case []string:
+		size = len(v)
+	case []float64:
+		size = len(v)
+	default:
+		return validation.NewError("validation_invalid_list", "Must be a list of numbers.")
+	}
+
+	if size == 0 {
+		if field.Required {
+			return requiredErr
+		}
+		return nil // nothing to check
+	}
+
+	options, _ := field.Options.(*schema.ListOptions)
+
+	if options.MinSize != nil && size < *options.MinSize {
+		return validation.NewError("validation_too_few_values", fmt.Sprintf("Must contain at least %d items", *options.MinSize))
+	}
+
+	if options.MaxSize != nil && size > *options.MaxSize {
+		return validation.NewError("validation_too_many_values", fmt.Sprintf("Must contain no more than %d items", *options.MaxSize))
+	}
+
+	return nil
+}
+
+func (validator *RecordDataValidator) checkDurationValue(field *schema.SchemaField, value any) error {
+	val, ok := value.(types.Duration)
+	if !ok {
+		return validation.NewError("validation_invalid_duration", "Must be a valid ISO-8601 duration (years and months are not supported).")
+	}
+
+	if val.IsZero() {
+		if field.Required {
+			return requiredErr
+		}
+		return nil // nothing to check
+	}
+
+	options, _ := field.Options.(*schema.DurationOptions)
+
+	if options.Min != nil && val.Seconds() < options.Min.Seconds() {
+		return validation.NewError("validation_min_duration_constraint", fmt.Sprintf("Must be at least %s", options.Min.String()))
+	}
+
+	if options.Max != nil && val.Seconds() > options.Max.Seconds() {
+		return validation.NewError("validation_max_duration_constraint", fmt.Sprintf("Must be at most %s", options.Max.String()))
+	}
+
+	return nil
+}
+
 func (validator *RecordDataValidator) checkDateValue(field *schema.SchemaField, value any) error {
 	val, _ := value.(types.DateTime)
 	if val.IsZero() {
@@ -322,7 +705,7 @@ func (validator *RecordDataValidator) checkFileValue(field *schema.SchemaField,
 		return requiredErr
 	}
 
-	options, _ := field.Options.(*schema.FileOptions)
+	options := field.FileOptions()
 
 	if len(names) > options.MaxSelect {
 		return validation.NewError("validation_too_many_values", fmt.Sprintf("Select no more than %d", options.MaxSelect))
@@ -342,11 +725,9 @@ func (validator *RecordDataValidator) checkFileValue(field *schema.SchemaField,
 			return err
 		}
 
-		// check type
-		if len(options.MimeTypes) > 0 {
-			if err := UploadedFileMimeType(options.MimeTypes)(file); err != nil {
-				return err
-			}
+		// check the sniffed content type
+		if err := UploadedFileContent(options)(file); err != nil {
+			return err
 		}
 	}
 
@@ -355,6 +736,20 @@ func (validator *RecordDataValidator) checkFileValue(field *schema.SchemaField,
 
 func (validator *RecordDataValidator) checkRelationValue(field *schema.SchemaField, value any) error {
 	ids := list.ToUniqueStringSlice(value)
+
+	options, _ := field.Options.(*schema.RelationOptions)
+
+	// "through" relations are managed only via the join collection records
+	if options.Through != nil {
+		oldIds := list.ToUniqueStringSlice(validator.record.OriginalCopy().Get(field.Name))
+		if len(list.SubtractSlice(ids, oldIds)) > 0 || len(list.SubtractSlice(oldIds, ids)) > 0 {
+			return validation.NewError(
+				"validation_through_relation_readonly",
+				"The relation can be changed only through its join collection records.",
+			)
+		}
+	}
+
 	if len(ids) == 0 {
 		if field.Required {
 			return requiredErr
@@ -362,8 +757,6 @@ func (validator *RecordDataValidator) checkRelationValue(field *schema.SchemaFie
 		return nil // nothing to check
 	}
 
-	options, _ := field.Options.(*schema.RelationOptions)
-
 	if options.MinSelect != nil && len(ids) < *options.MinSelect {
 		return validation.NewError("validation_not_enough_values", fmt.Sprintf("Select at least %d", *options.MinSelect))
 	}
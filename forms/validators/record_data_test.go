@@ -1289,6 +1289,718 @@ func TestRecordDataValidatorValidateRelation(t *testing.T) {
 	checkValidatorErrors(t, app.Dao(), models.NewRecord(collection), scenarios)
 }
 
+func TestRecordDataValidatorValidateColor(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	// create new test collection
+	collection := &models.Collection{}
+	collection.Name = "validate_test"
+	collection.Schema = schema.NewSchema(
+		&schema.SchemaField{
+			Name:    "field1",
+			Type:    schema.FieldTypeColor,
+			Options: &schema.ColorOptions{},
+		},
+		&schema.SchemaField{
+			Name:     "field2",
+			Required: true,
+			Type:     schema.FieldTypeColor,
+			Options:  &schema.ColorOptions{AllowAlpha: true},
+		},
+	)
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []testDataFieldScenario{
+		{
+			"(color) check required constraint",
+			map[string]any{
+				"field1": nil,
+				"field2": "",
+			},
+			nil,
+			[]string{"field2"},
+		},
+		{
+			"(color) check invalid colors",
+			map[string]any{
+				"field1": "#ggg",
+				"field2": "rgb(300, 0, 0)",
+			},
+			nil,
+			[]string{"field1", "field2"},
+		},
+		{
+			"(color) check alpha constraint",
+			map[string]any{
+				"field1": "rgba(0, 0, 0, 0.5)",
+				"field2": "rgba(0, 0, 0, 0.5)",
+			},
+			nil,
+			[]string{"field1"},
+		},
+		{
+			"(color) valid data",
+			map[string]any{
+				"field1": "#ABC",
+				"field2": "#aabbcc80",
+			},
+			nil,
+			[]string{},
+		},
+	}
+
+	checkValidatorErrors(t, app.Dao(), models.NewRecord(collection), scenarios)
+}
+
+func TestRecordDataValidatorValidateCurrency(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	// create new test collection
+	collection := &models.Collection{}
+	collection.Name = "validate_test"
+	collection.Schema = schema.NewSchema(
+		&schema.SchemaField{
+			Name:    "field1",
+			Type:    schema.FieldTypeCurrency,
+			Options: &schema.CurrencyOptions{Code: "USD"},
+		},
+		&schema.SchemaField{
+			Name:     "field2",
+			Required: true,
+			Type:     schema.FieldTypeCurrency,
+			Options: &schema.CurrencyOptions{
+				Code: "EUR",
+				Min:  types.Pointer[int64](100),
+				Max:  types.Pointer[int64](1000),
+			},
+		},
+	)
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []testDataFieldScenario{
+		{
+			"(currency) check required constraint",
+			map[string]any{
+				"field1": nil,
+				"field2": 0,
+			},
+			nil,
+			[]string{"field2"},
+		},
+		{
+			"(currency) check min constraint",
+			map[string]any{
+				"field1": -10,
+				"field2": 99,
+			},
+			nil,
+			[]string{"field2"},
+		},
+		{
+			"(currency) check max constraint",
+			map[string]any{
+				"field2": "1001",
+			},
+			nil,
+			[]string{"field2"},
+		},
+		{
+			"(currency) valid data",
+			map[string]any{
+				"field1": 1999,
+				"field2": 1000,
+			},
+			nil,
+			[]string{},
+		},
+	}
+
+	checkValidatorErrors(t, app.Dao(), models.NewRecord(collection), scenarios)
+}
+
+func TestRecordDataValidatorValidateDuration(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	min, _ := types.ParseDuration("PT1M")
+	max, _ := types.ParseDuration("PT1H")
+
+	// create new test collection
+	collection := &models.Collection{}
+	collection.Name = "validate_test"
+	collection.Schema = schema.NewSchema(
+		&schema.SchemaField{
+			Name:    "field1",
+			Type:    schema.FieldTypeDuration,
+			Options: &schema.DurationOptions{},
+		},
+		&schema.SchemaField{
+			Name:     "field2",
+			Required: true,
+			Type:     schema.FieldTypeDuration,
+			Options: &schema.DurationOptions{
+				Min: &min,
+				Max: &max,
+			},
+		},
+	)
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []testDataFieldScenario{
+		{
+			"(duration) check required constraint",
+			map[string]any{
+				"field1": nil,
+				"field2": "PT0S",
+			},
+			nil,
+			[]string{"field2"},
+		},
+		{
+			"(duration) check invalid and unsupported durations",
+			map[string]any{
+				"field1": "P1Y",
+				"field2": "invalid",
+			},
+			nil,
+			[]string{"field1", "field2"},
+		},
+		{
+			"(duration) check min constraint",
+			map[string]any{
+				"field2": "PT59S",
+			},
+			nil,
+			[]string{"field2"},
+		},
+		{
+			"(duration) check max constraint",
+			map[string]any{
+				"field2": 3601,
+			},
+			nil,
+			[]string{"field2"},
+		},
+		{
+			"(duration) valid data",
+			map[string]any{
+				"field1": "P1W",
+				"field2": "PT1H",
+			},
+			nil,
+			[]string{},
+		},
+	}
+
+	checkValidatorErrors(t, app.Dao(), models.NewRecord(collection), scenarios)
+}
+
+func TestRecordDataValidatorValidatePhone(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	// create new test collection
+	collection := &models.Collection{}
+	collection.Name = "validate_test"
+	collection.Schema = schema.NewSchema(
+		&schema.SchemaField{
+			Name:    "field1",
+			Type:    schema.FieldTypePhone,
+			Options: &schema.PhoneOptions{},
+		},
+		&schema.SchemaField{
+			Name:     "field2",
+			Required: true,
+			Type:     schema.FieldTypePhone,
+			Options: &schema.PhoneOptions{
+				DefaultRegion:       "US",
+				AllowedCountryCodes: []string{"1", "44"},
+			},
+		},
+	)
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []testDataFieldScenario{
+		{
+			"(phone) check required constraint",
+			map[string]any{
+				"field1": nil,
+				"field2": "",
+			},
+			nil,
+			[]string{"field2"},
+		},
+		{
+			"(phone) check invalid numbers",
+			map[string]any{
+				"field1": "(415) 555-2671",
+				"field2": "invalid",
+			},
+			nil,
+			[]string{"field1", "field2"},
+		},
+		{
+			"(phone) check allowed country codes",
+			map[string]any{
+				"field2": "+359 88 123 4567",
+			},
+			nil,
+			[]string{"field2"},
+		},
+		{
+			"(phone) valid data",
+			map[string]any{
+				"field1": "+359 88 123 4567",
+				"field2": "(415) 555-2671",
+			},
+			nil,
+			[]string{},
+		},
+	}
+
+	checkValidatorErrors(t, app.Dao(), models.NewRecord(collection), scenarios)
+}
+
+func TestRecordDataValidatorValidateList(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	// create new test collection
+	collection := &models.Collection{}
+	collection.Name = "validate_test"
+	collection.Schema = schema.NewSchema(
+		&schema.SchemaField{
+			Name:    "field1",
+			Type:    schema.FieldTypeList,
+			Options: &schema.ListOptions{},
+		},
+		&schema.SchemaField{
+			Name:     "field2",
+			Required: true,
+			Type:     schema.FieldTypeList,
+			Options: &schema.ListOptions{
+				ElementType: schema.ListElementTypeNumber,
+				MinSize:     types.Pointer(2),
+				MaxSize:     types.Pointer(3),
+				Indexed:     true,
+			},
+		},
+	)
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []testDataFieldScenario{
+		{
+			"(list) check required constraint",
+			map[string]any{
+				"field1": nil,
+				"field2": []string{},
+			},
+			nil,
+			[]string{"field2"},
+		},
+		{
+			"(list) check invalid numbers",
+			map[string]any{
+				"field1": []any{"a", 1},
+				"field2": []any{1, "a"},
+			},
+			nil,
+			[]string{"field2"},
+		},
+		{
+			"(list) check min size constraint",
+			map[string]any{
+				"field2": []any{1},
+			},
+			nil,
+			[]string{"field2"},
+		},
+		{
+			"(list) check max size constraint",
+			map[string]any{
+				"field2": "[1,2,3,4]",
+			},
+			nil,
+			[]string{"field2"},
+		},
+		{
+			"(list) valid data",
+			map[string]any{
+				"field1": []string{"a", "a", "b"},
+				"field2": []any{1, "2.5", 1},
+			},
+			nil,
+			[]string{},
+		},
+	}
+
+	checkValidatorErrors(t, app.Dao(), models.NewRecord(collection), scenarios)
+}
+
+func TestRecordDataValidatorValidateThroughRelation(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	users, _ := app.Dao().FindCollectionByNameOrId("users")
+	demo2, _ := app.Dao().FindCollectionByNameOrId("demo2")
+
+	memberships := &models.Collection{}
+	memberships.Name = "memberships"
+	memberships.Schema = schema.NewSchema(
+		&schema.SchemaField{
+			Name: "user",
+			Type: schema.FieldTypeRelation,
+			Options: &schema.RelationOptions{
+				CollectionId: users.Id,
+				MaxSelect:    types.Pointer(1),
+			},
+		},
+		&schema.SchemaField{
+			Name: "item",
+			Type: schema.FieldTypeRelation,
+			Options: &schema.RelationOptions{
+				CollectionId: demo2.Id,
+				MaxSelect:    types.Pointer(1),
+			},
+		},
+		&schema.SchemaField{
+			Name: "role",
+			Type: schema.FieldTypeText,
+		},
+	)
+	if err := app.Dao().SaveCollection(memberships); err != nil {
+		t.Fatal(err)
+	}
+
+	users.Schema.AddField(&schema.SchemaField{
+		Name: "items",
+		Type: schema.FieldTypeRelation,
+		Options: &schema.RelationOptions{
+			CollectionId: demo2.Id,
+			MaxSelect:    types.Pointer(2),
+			Through: &schema.RelationThrough{
+				CollectionId: memberships.Id,
+				SourceField:  "user",
+				TargetField:  "item",
+			},
+		},
+	})
+	if err := app.Dao().SaveCollection(users); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, item := range []string{"llvuca81nly1qls", "achvryl401bhse3"} {
+		membership := models.NewRecord(memberships)
+		membership.Set("user", "4q1xlclmfloku33")
+		membership.Set("item", item)
+		if err := app.Dao().SaveRecord(membership); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("join records", func(t *testing.T) {
+		scenarios := []testDataFieldScenario{
+			{
+				"new relation",
+				map[string]any{
+					"user": "oap640cot4yru2s",
+					"item": "0yxhwia2amd8gec",
+				},
+				nil,
+				[]string{},
+			},
+			{
+				"duplicated relation",
+				map[string]any{
+					"user": "4q1xlclmfloku33",
+					"item": "llvuca81nly1qls",
+				},
+				nil,
+				[]string{"item"},
+			},
+			{
+				"max select constraint",
+				map[string]any{
+					"user": "4q1xlclmfloku33",
+					"item": "0yxhwia2amd8gec",
+				},
+				nil,
+				[]string{"item"},
+			},
+		}
+
+		checkValidatorErrors(t, app.Dao(), models.NewRecord(memberships), scenarios)
+	})
+
+	t.Run("through field", func(t *testing.T) {
+		user, err := app.Dao().FindRecordById(users.Id, "4q1xlclmfloku33")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		scenarios := []testDataFieldScenario{
+			{
+				"changed relation",
+				map[string]any{
+					"items": []string{"llvuca81nly1qls"},
+				},
+				nil,
+				[]string{"items"},
+			},
+			{
+				"unchanged relation (different order)",
+				map[string]any{
+					"items": []string{"achvryl401bhse3", "llvuca81nly1qls"},
+				},
+				nil,
+				[]string{},
+			},
+		}
+
+		checkValidatorErrors(t, app.Dao(), user, scenarios)
+	})
+}
+
+func TestRecordDataValidatorValidateTreeParent(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	// a -> b -> c -> d
+	//        -> e
+	collection := &models.Collection{}
+	collection.Id = "categories00000"
+	collection.Name = "categories"
+	collection.Schema = schema.NewSchema(
+		&schema.SchemaField{
+			Name: "parent",
+			Type: schema.FieldTypeRelation,
+			Options: &schema.RelationOptions{
+				CollectionId: collection.Id,
+				MaxSelect:    types.Pointer(1),
+			},
+		},
+	)
+	collection.SetOptions(models.CollectionBaseOptions{TreeField: "parent", TreeMaxDepth: 3})
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, r := range [][2]string{
+		{"tree0000000000a", ""},
+		{"tree0000000000b", "tree0000000000a"},
+		{"tree0000000000c", "tree0000000000b"},
+		{"tree0000000000d", "tree0000000000c"},
+		{"tree0000000000e", "tree0000000000b"},
+	} {
+		record := models.NewRecord(collection)
+		record.Id = r[0]
+		record.Set("parent", r[1])
+		if err := app.Dao().SaveRecord(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	findRecord := func(id string) *models.Record {
+		record, err := app.Dao().FindRecordById(collection.Id, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return record
+	}
+
+	t.Run("new record", func(t *testing.T) {
+		scenarios := []testDataFieldScenario{
+			{"root", map[string]any{"parent": ""}, nil, []string{}},
+			{"max depth", map[string]any{"parent": "tree0000000000c"}, nil, []string{}},
+			{"too deep", map[string]any{"parent": "tree0000000000d"}, nil, []string{"parent"}},
+		}
+
+		checkValidatorErrors(t, app.Dao(), models.NewRecord(collection), scenarios)
+	})
+
+	t.Run("existing record with descendants", func(t *testing.T) {
+		scenarios := []testDataFieldScenario{
+			{"self parent", map[string]any{"parent": "tree0000000000b"}, nil, []string{"parent"}},
+			{"direct child parent", map[string]any{"parent": "tree0000000000c"}, nil, []string{"parent"}},
+			{"nested child parent", map[string]any{"parent": "tree0000000000d"}, nil, []string{"parent"}},
+			{"root", map[string]any{"parent": ""}, nil, []string{}},
+		}
+
+		checkValidatorErrors(t, app.Dao(), findRecord("tree0000000000b"), scenarios)
+	})
+
+	t.Run("moving a subtree", func(t *testing.T) {
+		scenarios := []testDataFieldScenario{
+			{"too deep subtree", map[string]any{"parent": "tree0000000000e"}, nil, []string{"parent"}},
+			{"same level", map[string]any{"parent": "tree0000000000b"}, nil, []string{}},
+		}
+
+		checkValidatorErrors(t, app.Dao(), findRecord("tree0000000000c"), scenarios)
+	})
+}
+
+func TestRecordDataValidatorValidationRules(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	// create new test collection
+	collection := &models.Collection{}
+	collection.Name = "validate_test"
+	collection.Schema = schema.NewSchema(
+		&schema.SchemaField{
+			Name: "start",
+			Type: schema.FieldTypeNumber,
+		},
+		&schema.SchemaField{
+			Name:              "end",
+			Type:              schema.FieldTypeNumber,
+			ValidationRule:    "end > start",
+			ValidationMessage: "Must be after start.",
+		},
+		&schema.SchemaField{
+			Name:           "title",
+			Type:           schema.FieldTypeText,
+			ValidationRule: "title:length > 5 || draft = true",
+		},
+		&schema.SchemaField{
+			Name: "draft",
+			Type: schema.FieldTypeBool,
+		},
+		&schema.SchemaField{
+			Name:           "tags",
+			Type:           schema.FieldTypeSelect,
+			ValidationRule: "tags:length <= 2",
+			Options: &schema.SelectOptions{
+				MaxSelect: 3,
+				Values:    []string{"a", "b", "c"},
+			},
+		},
+	)
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []testDataFieldScenario{
+		{
+			"all rules failing",
+			map[string]any{
+				"start": 10,
+				"end":   5,
+				"title": "abc",
+				"tags":  []string{"a", "b", "c"},
+			},
+			nil,
+			[]string{"end", "title", "tags"},
+		},
+		{
+			"draft bypass",
+			map[string]any{
+				"start": 10,
+				"end":   5,
+				"title": "abc",
+				"draft": true,
+			},
+			nil,
+			[]string{"end"},
+		},
+		{
+			"all rules passing",
+			map[string]any{
+				"start": 1,
+				"end":   5,
+				"title": "abcdef",
+				"tags":  []string{"a", "b"},
+			},
+			nil,
+			[]string{},
+		},
+	}
+
+	checkValidatorErrors(t, app.Dao(), models.NewRecord(collection), scenarios)
+
+	// custom error message
+	err := validators.NewRecordDataValidator(app.Dao(), models.NewRecord(collection), nil).Validate(map[string]any{
+		"start": 2,
+		"end":   1,
+		"title": "abcdef",
+	})
+	if errs, ok := err.(validation.Errors); !ok || errs["end"] == nil || errs["end"].Error() != "Must be after start." {
+		t.Fatalf("Expected custom end error message, got %v", err)
+	}
+}
+
+func TestRecordDataValidatorRequiredIf(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	// create new test collection
+	collection := &models.Collection{}
+	collection.Name = "validate_test"
+	collection.Schema = schema.NewSchema(
+		&schema.SchemaField{
+			Name: "type",
+			Type: schema.FieldTypeText,
+		},
+		&schema.SchemaField{
+			Name:       "company_name",
+			Type:       schema.FieldTypeText,
+			RequiredIf: "type = 'business'",
+		},
+	)
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []testDataFieldScenario{
+		{
+			"condition not matching",
+			map[string]any{
+				"type": "personal",
+			},
+			nil,
+			[]string{},
+		},
+		{
+			"condition matching with missing value",
+			map[string]any{
+				"type": "business",
+			},
+			nil,
+			[]string{"company_name"},
+		},
+		{
+			"condition matching with value",
+			map[string]any{
+				"type":         "business",
+				"company_name": "test",
+			},
+			nil,
+			[]string{},
+		},
+	}
+
+	checkValidatorErrors(t, app.Dao(), models.NewRecord(collection), scenarios)
+
+	err := validators.NewRecordDataValidator(app.Dao(), models.NewRecord(collection), nil).Validate(map[string]any{
+		"type": "business",
+	})
+	if errs, ok := err.(validation.Errors); !ok || errs["company_name"].(validation.Error).Code() != "validation_required_if" {
+		t.Fatalf("Expected validation_required_if error, got %v", err)
+	}
+}
+
 func checkValidatorErrors(t *testing.T, dao *daos.Dao, record *models.Record, scenarios []testDataFieldScenario) {
 	for i, s := range scenarios {
 		prefix := s.name
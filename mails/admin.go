@@ -20,8 +20,9 @@ func SendAdminPasswordReset(app core.App, admin *models.Admin) error {
 	}
 
 	actionUrl, urlErr := rest.NormalizeUrl(fmt.Sprintf(
-		"%s/_/#/confirm-password-reset/%s",
-		app.Settings().Meta.AppUrl,
+		"%s%s#/confirm-password-reset/%s",
+		app.Settings().Meta.BaseUrl(),
+		app.Settings().AdminUI.TrailedPath(),
 		token,
 	))
 	if urlErr != nil {
@@ -36,7 +37,7 @@ func SendAdminPasswordReset(app core.App, admin *models.Admin) error {
 		ActionUrl string
 	}{
 		AppName:   app.Settings().Meta.AppName,
-		AppUrl:    app.Settings().Meta.AppUrl,
+		AppUrl:    app.Settings().Meta.BaseUrl(),
 		Admin:     admin,
 		Token:     token,
 		ActionUrl: actionUrl,
@@ -3,6 +3,7 @@ package mails
 import (
 	"html/template"
 	"net/mail"
+	"strings"
 
 	"github.com/pocketbase/pocketbase/core"
 	"github.com/pocketbase/pocketbase/mails/templates"
@@ -135,6 +136,45 @@ func SendRecordChangeEmail(app core.App, record *models.Record, newEmail string)
 	})
 }
 
+// SendRecordOtp sends an email with the provided one-time password to the specified auth record.
+func SendRecordOtp(app core.App, authRecord *models.Record, otpId string, password string) error {
+	mailClient := app.NewMailClient()
+
+	emailTemplate := app.Settings().Meta.OtpTemplate
+	emailTemplate.Body = strings.ReplaceAll(emailTemplate.Body, settings.EmailPlaceholderOtp, password)
+	emailTemplate.ActionUrl = strings.ReplaceAll(emailTemplate.ActionUrl, settings.EmailPlaceholderOtp, password)
+
+	subject, body, err := resolveEmailTemplate(app, otpId, emailTemplate)
+	if err != nil {
+		return err
+	}
+
+	message := &mailer.Message{
+		From: mail.Address{
+			Name:    app.Settings().Meta.SenderName,
+			Address: app.Settings().Meta.SenderAddress,
+		},
+		To:      []mail.Address{{Address: authRecord.Email()}},
+		Subject: subject,
+		HTML:    body,
+	}
+
+	event := new(core.MailerRecordEvent)
+	event.MailClient = mailClient
+	event.Message = message
+	event.Collection = authRecord.Collection()
+	event.Record = authRecord
+	event.Meta = map[string]any{"otpId": otpId, "password": password}
+
+	return app.OnMailerBeforeRecordOtpSend().Trigger(event, func(e *core.MailerRecordEvent) error {
+		if err := e.MailClient.Send(e.Message); err != nil {
+			return err
+		}
+
+		return app.OnMailerAfterRecordOtpSend().Trigger(e)
+	})
+}
+
 func resolveEmailTemplate(
 	app core.App,
 	token string,
@@ -142,7 +182,7 @@ func resolveEmailTemplate(
 ) (subject string, body string, err error) {
 	subject, rawBody, _ := emailTemplate.Resolve(
 		app.Settings().Meta.AppName,
-		app.Settings().Meta.AppUrl,
+		app.Settings().Meta.BaseUrl(),
 		token,
 	)
 
@@ -85,3 +85,29 @@ func TestSendRecordChangeEmail(t *testing.T) {
 		}
 	}
 }
+
+func TestSendRecordOtp(t *testing.T) {
+	testApp, _ := tests.NewTestApp()
+	defer testApp.Cleanup()
+
+	user, _ := testApp.Dao().FindFirstRecordByData("users", "email", "test@example.com")
+
+	err := mails.SendRecordOtp(testApp, user, "test_otp_id", "123456")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if testApp.TestMailer.TotalSend != 1 {
+		t.Fatalf("Expected one email to be sent, got %d", testApp.TestMailer.TotalSend)
+	}
+
+	expectedParts := []string{
+		"<strong>123456</strong>",
+		"http://localhost:8090/_/#/auth/confirm-otp/test_otp_id/123456",
+	}
+	for _, part := range expectedParts {
+		if !strings.Contains(testApp.TestMailer.LastMessage.HTML, part) {
+			t.Fatalf("Couldn't find %s \nin\n %s", part, testApp.TestMailer.LastMessage.HTML)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Adds the _usernameChanges table used to track the auth records
+// username changes for the username change cooldown.
+func init() {
+	AppMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.NewQuery(`
+			CREATE TABLE {{_usernameChanges}} (
+				[[id]]           TEXT PRIMARY KEY NOT NULL,
+				[[collectionId]] TEXT NOT NULL,
+				[[recordId]]     TEXT NOT NULL,
+				[[oldUsername]]  TEXT DEFAULT '' NOT NULL,
+				[[created]]      TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]]      TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				---
+				FOREIGN KEY ([[collectionId]]) REFERENCES {{_collections}} ([[id]]) ON UPDATE CASCADE ON DELETE CASCADE
+			);
+
+			CREATE INDEX _usernameChanges_record_created_idx on {{_usernameChanges}} ([[collectionId]], [[recordId]], [[created]]);
+		`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		_, err := db.DropTable("_usernameChanges").Execute()
+
+		return err
+	})
+}
@@ -0,0 +1,37 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Adds the _comments table used to store the collection records comments.
+func init() {
+	AppMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.NewQuery(`
+			CREATE TABLE {{_comments}} (
+				[[id]]                 TEXT PRIMARY KEY NOT NULL,
+				[[collectionId]]       TEXT NOT NULL,
+				[[recordId]]           TEXT NOT NULL,
+				[[parentId]]           TEXT DEFAULT '' NOT NULL,
+				[[authorType]]         TEXT NOT NULL,
+				[[authorCollectionId]] TEXT DEFAULT '' NOT NULL,
+				[[authorId]]           TEXT NOT NULL,
+				[[message]]            TEXT DEFAULT '' NOT NULL,
+				[[mentions]]           JSON DEFAULT '[]' NOT NULL,
+				[[created]]            TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]]            TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				---
+				FOREIGN KEY ([[collectionId]]) REFERENCES {{_collections}} ([[id]]) ON UPDATE CASCADE ON DELETE CASCADE
+			);
+
+			CREATE INDEX _comments_record_created_idx on {{_comments}} ([[collectionId]], [[recordId]], [[created]]);
+			CREATE INDEX _comments_parentId_idx on {{_comments}} ([[parentId]]);
+		`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		_, err := db.DropTable("_comments").Execute()
+
+		return err
+	})
+}
@@ -0,0 +1,34 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Adds the _reactions table used to store the collection records reactions.
+func init() {
+	AppMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.NewQuery(`
+			CREATE TABLE {{_reactions}} (
+				[[id]]               TEXT PRIMARY KEY NOT NULL,
+				[[collectionId]]     TEXT NOT NULL,
+				[[recordId]]         TEXT NOT NULL,
+				[[userCollectionId]] TEXT NOT NULL,
+				[[userId]]           TEXT NOT NULL,
+				[[type]]             TEXT NOT NULL,
+				[[created]]          TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]]          TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				---
+				FOREIGN KEY ([[collectionId]]) REFERENCES {{_collections}} ([[id]]) ON UPDATE CASCADE ON DELETE CASCADE
+			);
+
+			CREATE UNIQUE INDEX _reactions_record_user_type_idx on {{_reactions}} ([[collectionId]], [[recordId]], [[userCollectionId]], [[userId]], [[type]]);
+			CREATE INDEX _reactions_user_idx on {{_reactions}} ([[userCollectionId]], [[userId]]);
+		`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		_, err := db.DropTable("_reactions").Execute()
+
+		return err
+	})
+}
@@ -0,0 +1,35 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Adds the _follows table used to store the records follow graph.
+func init() {
+	AppMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.NewQuery(`
+			CREATE TABLE {{_follows}} (
+				[[id]]                   TEXT PRIMARY KEY NOT NULL,
+				[[followerCollectionId]] TEXT NOT NULL,
+				[[followerId]]           TEXT NOT NULL,
+				[[targetCollectionId]]   TEXT NOT NULL,
+				[[targetId]]             TEXT NOT NULL,
+				[[created]]              TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]]              TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				---
+				FOREIGN KEY ([[followerCollectionId]]) REFERENCES {{_collections}} ([[id]]) ON UPDATE CASCADE ON DELETE CASCADE,
+				FOREIGN KEY ([[targetCollectionId]]) REFERENCES {{_collections}} ([[id]]) ON UPDATE CASCADE ON DELETE CASCADE
+			);
+
+			-- covers the "@following(field)" filter lookups
+			CREATE UNIQUE INDEX _follows_follower_target_idx on {{_follows}} ([[followerCollectionId]], [[followerId]], [[targetCollectionId]], [[targetId]]);
+			CREATE INDEX _follows_target_idx on {{_follows}} ([[targetCollectionId]], [[targetId]]);
+		`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		_, err := db.DropTable("_follows").Execute()
+
+		return err
+	})
+}
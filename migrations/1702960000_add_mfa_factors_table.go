@@ -0,0 +1,36 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Adds the _mfaFactors table used to store the
+// auth records multi-factor authentication factors.
+func init() {
+	AppMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.NewQuery(`
+			CREATE TABLE {{_mfaFactors}} (
+				[[id]]            TEXT PRIMARY KEY NOT NULL,
+				[[collectionId]]  TEXT NOT NULL,
+				[[recordId]]      TEXT NOT NULL,
+				[[type]]          TEXT NOT NULL,
+				[[enabled]]       BOOLEAN DEFAULT FALSE NOT NULL,
+				[[secret]]        TEXT DEFAULT '' NOT NULL,
+				[[recoveryCodes]] JSON DEFAULT '[]' NOT NULL,
+				[[lastUsedStep]]  INTEGER DEFAULT 0 NOT NULL,
+				[[created]]       TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]]       TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				---
+				FOREIGN KEY ([[collectionId]]) REFERENCES {{_collections}} ([[id]]) ON UPDATE CASCADE ON DELETE CASCADE
+			);
+
+			CREATE UNIQUE INDEX _mfaFactors_record_type_idx on {{_mfaFactors}} ([[collectionId]], [[recordId]], [[type]]);
+		`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		_, err := db.DropTable("_mfaFactors").Execute()
+
+		return err
+	})
+}
@@ -0,0 +1,38 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Adds the _passkeys table used to store the
+// auth records WebAuthn credentials.
+func init() {
+	AppMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.NewQuery(`
+			CREATE TABLE {{_passkeys}} (
+				[[id]]           TEXT PRIMARY KEY NOT NULL,
+				[[collectionId]] TEXT NOT NULL,
+				[[recordId]]     TEXT NOT NULL,
+				[[name]]         TEXT DEFAULT '' NOT NULL,
+				[[credentialId]] TEXT NOT NULL,
+				[[publicKey]]    TEXT NOT NULL,
+				[[signCount]]    INTEGER DEFAULT 0 NOT NULL,
+				[[aaguid]]       TEXT DEFAULT '' NOT NULL,
+				[[lastUsed]]     TEXT DEFAULT '' NOT NULL,
+				[[created]]      TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]]      TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				---
+				FOREIGN KEY ([[collectionId]]) REFERENCES {{_collections}} ([[id]]) ON UPDATE CASCADE ON DELETE CASCADE
+			);
+
+			CREATE UNIQUE INDEX _passkeys_credentialId_idx on {{_passkeys}} ([[credentialId]]);
+			CREATE INDEX _passkeys_record_idx on {{_passkeys}} ([[collectionId]], [[recordId]]);
+		`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		_, err := db.DropTable("_passkeys").Execute()
+
+		return err
+	})
+}
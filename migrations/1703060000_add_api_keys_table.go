@@ -0,0 +1,37 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Adds the _apiKeys table used to store the
+// admin created scoped api keys (aka. machine tokens).
+func init() {
+	AppMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.NewQuery(`
+			CREATE TABLE {{_apiKeys}} (
+				[[id]]       TEXT PRIMARY KEY NOT NULL,
+				[[adminId]]  TEXT NOT NULL,
+				[[name]]     TEXT NOT NULL,
+				[[keyHash]]  TEXT NOT NULL,
+				[[keyHint]]  TEXT DEFAULT '' NOT NULL,
+				[[scopes]]   JSON DEFAULT '[]' NOT NULL,
+				[[expires]]  TEXT DEFAULT '' NOT NULL,
+				[[lastUsed]] TEXT DEFAULT '' NOT NULL,
+				[[created]]  TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]]  TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				---
+				FOREIGN KEY ([[adminId]]) REFERENCES {{_admins}} ([[id]]) ON UPDATE CASCADE ON DELETE CASCADE
+			);
+
+			CREATE UNIQUE INDEX _apiKeys_keyHash_idx on {{_apiKeys}} ([[keyHash]]);
+			CREATE INDEX _apiKeys_adminId_idx on {{_apiKeys}} ([[adminId]]);
+		`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		_, err := db.DropTable("_apiKeys").Execute()
+
+		return err
+	})
+}
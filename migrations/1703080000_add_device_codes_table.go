@@ -0,0 +1,38 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Adds the _deviceCodes table used to store the
+// auth records OAuth2 device authorization grant requests.
+func init() {
+	AppMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.NewQuery(`
+			CREATE TABLE {{_deviceCodes}} (
+				[[id]]           TEXT PRIMARY KEY NOT NULL,
+				[[collectionId]] TEXT NOT NULL,
+				[[recordId]]     TEXT DEFAULT '' NOT NULL,
+				[[codeHash]]     TEXT NOT NULL,
+				[[userCode]]     TEXT NOT NULL,
+				[[status]]       TEXT DEFAULT 'pending' NOT NULL,
+				[[lastPolledAt]] TEXT DEFAULT '' NOT NULL,
+				[[created]]      TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]]      TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				---
+				FOREIGN KEY ([[collectionId]]) REFERENCES {{_collections}} ([[id]]) ON UPDATE CASCADE ON DELETE CASCADE
+			);
+
+			CREATE UNIQUE INDEX _deviceCodes_codeHash_idx on {{_deviceCodes}} ([[codeHash]]);
+			CREATE UNIQUE INDEX _deviceCodes_userCode_idx on {{_deviceCodes}} ([[collectionId]], [[userCode]]);
+			CREATE INDEX _deviceCodes_record_idx on {{_deviceCodes}} ([[collectionId]], [[recordId]]);
+			CREATE INDEX _deviceCodes_created_idx on {{_deviceCodes}} ([[created]]);
+		`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		_, err := db.DropTable("_deviceCodes").Execute()
+
+		return err
+	})
+}
@@ -0,0 +1,36 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Adds the _refreshTokens table used to store
+// the auth records rotating refresh tokens.
+func init() {
+	AppMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.NewQuery(`
+			CREATE TABLE {{_refreshTokens}} (
+				[[id]]            TEXT PRIMARY KEY NOT NULL,
+				[[collectionId]]  TEXT NOT NULL,
+				[[recordId]]      TEXT NOT NULL,
+				[[tokenHash]]     TEXT NOT NULL,
+				[[recordKeyHash]] TEXT NOT NULL,
+				[[rotatedAt]]     TEXT DEFAULT '' NOT NULL,
+				[[created]]       TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]]       TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				---
+				FOREIGN KEY ([[collectionId]]) REFERENCES {{_collections}} ([[id]]) ON UPDATE CASCADE ON DELETE CASCADE
+			);
+
+			CREATE UNIQUE INDEX _refreshTokens_tokenHash_idx on {{_refreshTokens}} ([[tokenHash]]);
+			CREATE INDEX _refreshTokens_record_idx on {{_refreshTokens}} ([[collectionId]], [[recordId]]);
+			CREATE INDEX _refreshTokens_created_idx on {{_refreshTokens}} ([[created]]);
+		`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		_, err := db.DropTable("_refreshTokens").Execute()
+
+		return err
+	})
+}
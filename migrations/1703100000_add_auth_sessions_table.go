@@ -0,0 +1,35 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Adds the _authSessions table used to track
+// the issued auth records tokens as sessions.
+func init() {
+	AppMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.NewQuery(`
+			CREATE TABLE {{_authSessions}} (
+				[[id]]           TEXT PRIMARY KEY NOT NULL,
+				[[collectionId]] TEXT NOT NULL,
+				[[recordId]]     TEXT NOT NULL,
+				[[userAgent]]    TEXT DEFAULT '' NOT NULL,
+				[[ip]]           TEXT DEFAULT '' NOT NULL,
+				[[lastSeen]]     TEXT DEFAULT '' NOT NULL,
+				[[created]]      TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]]      TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				---
+				FOREIGN KEY ([[collectionId]]) REFERENCES {{_collections}} ([[id]]) ON UPDATE CASCADE ON DELETE CASCADE
+			);
+
+			CREATE INDEX _authSessions_record_idx on {{_authSessions}} ([[collectionId]], [[recordId]]);
+			CREATE INDEX _authSessions_lastSeen_idx on {{_authSessions}} ([[lastSeen]]);
+		`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		_, err := db.DropTable("_authSessions").Execute()
+
+		return err
+	})
+}
@@ -0,0 +1,31 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Adds the _externalAuths columns used to store
+// the linked OAuth2 provider tokens.
+func init() {
+	AppMigrations.Register(func(db dbx.Builder) error {
+		columns := []string{"accessToken", "refreshToken", "tokenExpiry"}
+
+		for _, column := range columns {
+			if _, err := db.AddColumn("_externalAuths", column, `TEXT DEFAULT "" NOT NULL`).Execute(); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}, func(db dbx.Builder) error {
+		columns := []string{"accessToken", "refreshToken", "tokenExpiry"}
+
+		for _, column := range columns {
+			if _, err := db.DropColumn("_externalAuths", column).Execute(); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,49 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Adds the _roles and _recordRoles tables used to store the
+// role definitions and their auth records assignments.
+func init() {
+	AppMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.NewQuery(`
+			CREATE TABLE {{_roles}} (
+				[[id]]          TEXT PRIMARY KEY NOT NULL,
+				[[name]]        TEXT NOT NULL,
+				[[description]] TEXT DEFAULT "" NOT NULL,
+				[[created]]     TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]]     TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL
+			);
+
+			CREATE UNIQUE INDEX _roles_name_idx on {{_roles}} ([[name]]);
+
+			CREATE TABLE {{_recordRoles}} (
+				[[id]]           TEXT PRIMARY KEY NOT NULL,
+				[[roleId]]       TEXT NOT NULL,
+				[[collectionId]] TEXT NOT NULL,
+				[[recordId]]     TEXT NOT NULL,
+				[[created]]      TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]]      TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				---
+				FOREIGN KEY ([[roleId]]) REFERENCES {{_roles}} ([[id]]) ON UPDATE CASCADE ON DELETE CASCADE,
+				FOREIGN KEY ([[collectionId]]) REFERENCES {{_collections}} ([[id]]) ON UPDATE CASCADE ON DELETE CASCADE
+			);
+
+			-- covers the "@request.auth.roles" filter lookups
+			CREATE UNIQUE INDEX _recordRoles_record_role_idx on {{_recordRoles}} ([[collectionId]], [[recordId]], [[roleId]]);
+			CREATE INDEX _recordRoles_roleId_idx on {{_recordRoles}} ([[roleId]]);
+		`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		if _, err := db.DropTable("_recordRoles").Execute(); err != nil {
+			return err
+		}
+
+		_, err := db.DropTable("_roles").Execute()
+
+		return err
+	})
+}
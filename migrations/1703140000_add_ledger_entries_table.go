@@ -0,0 +1,40 @@
+package migrations
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Adds the _ledgerEntries table used to store the
+// ledger collections records hash chain.
+func init() {
+	AppMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.NewQuery(`
+			CREATE TABLE {{_ledgerEntries}} (
+				[[id]]           TEXT PRIMARY KEY NOT NULL,
+				[[collectionId]] TEXT NOT NULL,
+				[[recordId]]     TEXT NOT NULL,
+				[[seq]]          INTEGER NOT NULL,
+				[[prevHash]]     TEXT DEFAULT '' NOT NULL,
+				[[hash]]         TEXT NOT NULL,
+				[[created]]      TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]]      TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				---
+				FOREIGN KEY ([[collectionId]]) REFERENCES {{_collections}} ([[id]]) ON UPDATE CASCADE ON DELETE CASCADE
+			);
+
+			CREATE UNIQUE INDEX _ledgerEntries_seq_idx on {{_ledgerEntries}} ([[collectionId]], [[seq]]);
+			CREATE UNIQUE INDEX _ledgerEntries_record_idx on {{_ledgerEntries}} ([[collectionId]], [[recordId]]);
+
+			CREATE TRIGGER _ledgerEntries_no_update BEFORE UPDATE ON {{_ledgerEntries}}
+			BEGIN
+				SELECT RAISE(ABORT, 'ledger entries are immutable');
+			END;
+		`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		_, err := db.DropTable("_ledgerEntries").Execute()
+
+		return err
+	})
+}
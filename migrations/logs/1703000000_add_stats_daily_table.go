@@ -0,0 +1,30 @@
+package logs
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Adds the _statsDaily table used to store the daily admin stats rollups.
+func init() {
+	LogsMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.NewQuery(`
+			CREATE TABLE {{_statsDaily}} (
+				[[id]]      TEXT PRIMARY KEY DEFAULT ('r'||lower(hex(randomblob(7)))) NOT NULL,
+				[[date]]    TEXT NOT NULL,
+				[[metric]]  TEXT NOT NULL,
+				[[key]]     TEXT DEFAULT "" NOT NULL,
+				[[value]]   NUMERIC DEFAULT 0 NOT NULL,
+				[[created]] TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]] TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL
+			);
+
+			CREATE UNIQUE INDEX _statsDaily_date_metric_key_idx on {{_statsDaily}} ([[date]], [[metric]], [[key]]);
+		`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		_, err := db.DropTable("_statsDaily").Execute()
+
+		return err
+	})
+}
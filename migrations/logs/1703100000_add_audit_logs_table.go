@@ -0,0 +1,36 @@
+package logs
+
+import (
+	"github.com/pocketbase/dbx"
+)
+
+// Adds the _auditLogs table used to store the records mutations audit trail.
+func init() {
+	LogsMigrations.Register(func(db dbx.Builder) error {
+		_, err := db.NewQuery(`
+			CREATE TABLE {{_auditLogs}} (
+				[[id]]             TEXT PRIMARY KEY DEFAULT ('r'||lower(hex(randomblob(7)))) NOT NULL,
+				[[action]]         TEXT NOT NULL,
+				[[actorType]]      TEXT DEFAULT "" NOT NULL,
+				[[actorId]]        TEXT DEFAULT "" NOT NULL,
+				[[collectionId]]   TEXT DEFAULT "" NOT NULL,
+				[[collectionName]] TEXT DEFAULT "" NOT NULL,
+				[[recordId]]       TEXT DEFAULT "" NOT NULL,
+				[[before]]         JSON DEFAULT "{}" NOT NULL,
+				[[after]]          JSON DEFAULT "{}" NOT NULL,
+				[[created]]        TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL,
+				[[updated]]        TEXT DEFAULT (strftime('%Y-%m-%d %H:%M:%fZ')) NOT NULL
+			);
+
+			CREATE INDEX _auditLogs_created_idx on {{_auditLogs}} ([[created]]);
+			CREATE INDEX _auditLogs_record_idx on {{_auditLogs}} ([[collectionId]], [[recordId]]);
+			CREATE INDEX _auditLogs_actor_idx on {{_auditLogs}} ([[actorType]], [[actorId]]);
+		`).Execute()
+
+		return err
+	}, func(db dbx.Builder) error {
+		_, err := db.DropTable("_auditLogs").Execute()
+
+		return err
+	})
+}
@@ -0,0 +1,124 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+var _ Model = (*ApiKey)(nil)
+
+// ApiKeyPrefix is the common prefix of all generated api keys
+// (used to distinguish them from the regular JWT auth tokens).
+const ApiKeyPrefix = "pbk_"
+
+// ApiKeyScopeAllCollections is the ApiKeyScope.Collection value that matches all collections.
+const ApiKeyScopeAllCollections = "*"
+
+// list with the supported api key scope actions
+const (
+	ApiKeyActionList   = "list"
+	ApiKeyActionView   = "view"
+	ApiKeyActionCreate = "create"
+	ApiKeyActionUpdate = "update"
+	ApiKeyActionDelete = "delete"
+)
+
+// ApiKeyActions returns all supported api key scope actions.
+func ApiKeyActions() []string {
+	return []string{
+		ApiKeyActionList,
+		ApiKeyActionView,
+		ApiKeyActionCreate,
+		ApiKeyActionUpdate,
+		ApiKeyActionDelete,
+	}
+}
+
+// ApiKeyScope defines the actions allowed by an api key for a single collection.
+type ApiKeyScope struct {
+	// Collection is the scoped collection name or id
+	// (or ApiKeyScopeAllCollections to match all collections).
+	Collection string `json:"collection"`
+
+	// Actions is the list of the allowed collection actions (see ApiKeyActions()).
+	Actions []string `json:"actions"`
+}
+
+// ApiKey defines a long-lived admin created machine token
+// that grants access only to the actions listed in its scopes.
+type ApiKey struct {
+	BaseModel
+
+	// AdminId is the id of the admin that created the key
+	// (the key requests are performed on its behalf).
+	AdminId string `db:"adminId" json:"adminId"`
+
+	Name string `db:"name" json:"name"`
+
+	// KeyHash is the SHA256 hash of the plain api key
+	// (the plain key is returned only once on create).
+	KeyHash string `db:"keyHash" json:"-"`
+
+	// KeyHint is the first few characters of the plain key
+	// (used to help identifying the key).
+	KeyHint string `db:"keyHint" json:"keyHint"`
+
+	Scopes types.JsonArray[ApiKeyScope] `db:"scopes" json:"scopes"`
+
+	// Expires is the optional key expiration date (zero for never).
+	Expires types.DateTime `db:"expires" json:"expires"`
+
+	// LastUsed is the date of the last authorized request with the key.
+	LastUsed types.DateTime `db:"lastUsed" json:"lastUsed"`
+}
+
+// TableName returns the ApiKey model SQL table name.
+func (m *ApiKey) TableName() string {
+	return "_apiKeys"
+}
+
+// SetKey updates the model key hash and hint from the provided plain api key.
+func (m *ApiKey) SetKey(key string) {
+	m.KeyHash = security.SHA256(key)
+
+	m.KeyHint = key
+	if hintLength := len(ApiKeyPrefix) + 4; len(m.KeyHint) > hintLength {
+		m.KeyHint = m.KeyHint[:hintLength]
+	}
+}
+
+// IsExpired reports whether the api key has an expiration date in the past.
+func (m *ApiKey) IsExpired() bool {
+	return !m.Expires.IsZero() && m.Expires.Time().Before(time.Now())
+}
+
+// Allows reports whether the api key scopes allow the
+// specified action on the provided collection.
+func (m *ApiKey) Allows(collection *Collection, action string) bool {
+	if collection == nil {
+		return false
+	}
+
+	for _, scope := range m.Scopes {
+		if scope.Collection != ApiKeyScopeAllCollections &&
+			scope.Collection != collection.Id &&
+			!strings.EqualFold(scope.Collection, collection.Name) {
+			continue
+		}
+
+		if list.ExistInSlice(action, scope.Actions) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NewApiKeySecret generates a new random plain api key.
+func NewApiKeySecret() string {
+	return ApiKeyPrefix + security.RandomString(40)
+}
@@ -0,0 +1,103 @@
+package models_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestApiKeyTableName(t *testing.T) {
+	m := models.ApiKey{}
+	if m.TableName() != "_apiKeys" {
+		t.Fatalf("Unexpected table name, got %q", m.TableName())
+	}
+}
+
+func TestNewApiKeySecret(t *testing.T) {
+	key1 := models.NewApiKeySecret()
+	key2 := models.NewApiKeySecret()
+
+	if !strings.HasPrefix(key1, models.ApiKeyPrefix) {
+		t.Fatalf("Expected the key to start with %q, got %q", models.ApiKeyPrefix, key1)
+	}
+
+	if len(key1) != len(models.ApiKeyPrefix)+40 {
+		t.Fatalf("Expected key with length %d, got %d", len(models.ApiKeyPrefix)+40, len(key1))
+	}
+
+	if key1 == key2 {
+		t.Fatalf("Expected different keys, got %q", key1)
+	}
+}
+
+func TestApiKeySetKey(t *testing.T) {
+	m := models.ApiKey{}
+	m.SetKey("pbk_test1234567890")
+
+	if m.KeyHash != security.SHA256("pbk_test1234567890") {
+		t.Fatalf("Unexpected key hash %q", m.KeyHash)
+	}
+
+	if m.KeyHint != "pbk_test" {
+		t.Fatalf("Expected key hint %q, got %q", "pbk_test", m.KeyHint)
+	}
+}
+
+func TestApiKeyIsExpired(t *testing.T) {
+	past, _ := types.ParseDateTime(time.Now().Add(-time.Minute))
+	future, _ := types.ParseDateTime(time.Now().Add(time.Minute))
+
+	scenarios := []struct {
+		expires  types.DateTime
+		expected bool
+	}{
+		{types.DateTime{}, false},
+		{past, true},
+		{future, false},
+	}
+
+	for i, s := range scenarios {
+		m := models.ApiKey{Expires: s.expires}
+
+		if v := m.IsExpired(); v != s.expected {
+			t.Errorf("(%d) Expected %v, got %v", i, s.expected, v)
+		}
+	}
+}
+
+func TestApiKeyAllows(t *testing.T) {
+	collection := &models.Collection{Name: "posts"}
+	collection.Id = "posts_id"
+
+	m := models.ApiKey{
+		Scopes: types.JsonArray[models.ApiKeyScope]{
+			{Collection: "Posts", Actions: []string{models.ApiKeyActionList}},
+			{Collection: "posts_id", Actions: []string{models.ApiKeyActionView}},
+			{Collection: "other", Actions: []string{models.ApiKeyActionDelete}},
+			{Collection: models.ApiKeyScopeAllCollections, Actions: []string{models.ApiKeyActionCreate}},
+		},
+	}
+
+	scenarios := []struct {
+		collection *models.Collection
+		action     string
+		expected   bool
+	}{
+		{nil, models.ApiKeyActionList, false},
+		{collection, models.ApiKeyActionList, true},
+		{collection, models.ApiKeyActionView, true},
+		{collection, models.ApiKeyActionCreate, true},
+		{collection, models.ApiKeyActionUpdate, false},
+		{collection, models.ApiKeyActionDelete, false},
+	}
+
+	for i, s := range scenarios {
+		if v := m.Allows(s.collection, s.action); v != s.expected {
+			t.Errorf("(%d) Expected %v, got %v", i, s.expected, v)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package models
+
+import "github.com/pocketbase/pocketbase/tools/types"
+
+var _ Model = (*AuditLog)(nil)
+
+// Audit log actions.
+const (
+	AuditActionCreate = "create"
+	AuditActionUpdate = "update"
+	AuditActionDelete = "delete"
+
+	AuditActionCollectionCreate = "collectionCreate"
+	AuditActionCollectionUpdate = "collectionUpdate"
+	AuditActionCollectionDelete = "collectionDelete"
+
+	AuditActionSettingsUpdate = "settingsUpdate"
+
+	AuditActionImpersonate = "impersonate"
+)
+
+// Audit log actor types.
+const (
+	AuditActorAdmin      = "admin"
+	AuditActorAuthRecord = "authRecord"
+	AuditActorGuest      = "guest"
+	AuditActorSystem     = "system"
+)
+
+// AuditLog defines a single record, collection or settings change audit trail entry.
+type AuditLog struct {
+	BaseModel
+
+	// Action is the record operation (create, update or delete),
+	// the collection and settings change (eg. collectionUpdate)
+	// or the auth record impersonation.
+	//
+	// The settings changes don't have associated collection and record.
+	Action string `db:"action" json:"action"`
+
+	// ActorType is the type of the mutation initiator
+	// (admin, authRecord, guest or system for the non-request changes).
+	ActorType string `db:"actorType" json:"actorType"`
+	ActorId   string `db:"actorId" json:"actorId"`
+
+	CollectionId   string `db:"collectionId" json:"collectionId"`
+	CollectionName string `db:"collectionName" json:"collectionName"`
+	RecordId       string `db:"recordId" json:"recordId"`
+
+	// Before and After contains the record fields (or the collection and
+	// settings top level fields) state before and after the operation
+	// (for updates only the changed fields).
+	Before types.JsonMap `db:"before" json:"before"`
+	After  types.JsonMap `db:"after" json:"after"`
+}
+
+func (m *AuditLog) TableName() string {
+	return "_auditLogs"
+}
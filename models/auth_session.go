@@ -0,0 +1,24 @@
+package models
+
+import (
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+var _ Model = (*AuthSession)(nil)
+
+// AuthSession defines a single tracked auth record session
+// (aka. a device or client authenticated with an issued auth token).
+type AuthSession struct {
+	BaseModel
+
+	CollectionId string         `db:"collectionId" json:"collectionId"`
+	RecordId     string         `db:"recordId" json:"recordId"`
+	UserAgent    string         `db:"userAgent" json:"userAgent"`
+	Ip           string         `db:"ip" json:"ip"`
+	LastSeen     types.DateTime `db:"lastSeen" json:"lastSeen"`
+}
+
+// TableName returns the AuthSession model SQL table name.
+func (m *AuthSession) TableName() string {
+	return "_authSessions"
+}
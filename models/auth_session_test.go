@@ -0,0 +1,14 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/models"
+)
+
+func TestAuthSessionTableName(t *testing.T) {
+	m := models.AuthSession{}
+	if m.TableName() != "_authSessions" {
+		t.Fatalf("Unexpected table name, got %q", m.TableName())
+	}
+}
@@ -2,10 +2,15 @@ package models
 
 import (
 	"encoding/json"
+	"regexp"
+	"strconv"
+	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/go-ozzo/ozzo-validation/v4/is"
 	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tools/search"
+	"github.com/pocketbase/pocketbase/tools/security"
 	"github.com/pocketbase/pocketbase/tools/types"
 )
 
@@ -14,12 +19,83 @@ var (
 	_ FilesManager = (*Collection)(nil)
 )
 
+var profileNameRegex = regexp.MustCompile(`^\w+$`)
+
+var reactionTypeRegex = regexp.MustCompile(`^[\w\-]+$`)
+
+var feedTypeRegex = regexp.MustCompile(`^[\w\-]+$`)
+
+// walletDomainRegex matches a host with an optional port (eg. "example.com:8090").
+var walletDomainRegex = regexp.MustCompile(`^[\w\-\.]+(:\d+)?$`)
+
+var syncStrategies = []any{SyncStrategyLastWriteWins, SyncStrategyServerWins, SyncStrategyCustom}
+
+var emailCanonicalizations = []any{EmailCanonicalizationLowercase, EmailCanonicalizationFull}
+
+var mfaModes = []any{MfaModeOptional, MfaModeRequired}
+
 const (
 	CollectionTypeBase = "base"
 	CollectionTypeAuth = "auth"
 	CollectionTypeView = "view"
 )
 
+// Supported collection sync conflict resolution strategies.
+const (
+	SyncStrategyLastWriteWins = "lastWriteWins"
+	SyncStrategyServerWins    = "serverWins"
+	SyncStrategyCustom        = "custom"
+)
+
+// Supported auth collection email canonicalization policies.
+const (
+	// EmailCanonicalizationNone compares the emails as they are (default).
+	EmailCanonicalizationNone = ""
+
+	// EmailCanonicalizationLowercase compares the emails case-insensitively.
+	EmailCanonicalizationLowercase = "lowercase"
+
+	// EmailCanonicalizationFull compares the emails case-insensitively,
+	// without their "+tag" suffix and without the gmail local part dots
+	// (aka. "John.Doe+news@gmail.com" is the same as "johndoe@gmail.com").
+	EmailCanonicalizationFull = "full"
+)
+
+// Supported auth collection multi-factor authentication modes.
+const (
+	// MfaModeDisabled disables the MFA (default).
+	MfaModeDisabled = ""
+
+	// MfaModeOptional allows the auth records to enroll an MFA factor
+	// that is required on password authentication once enabled.
+	MfaModeOptional = "optional"
+
+	// MfaModeRequired is similar to MfaModeOptional but additionally
+	// requires all auth records to enroll an MFA factor before
+	// being able to authenticate with password.
+	MfaModeRequired = "required"
+)
+
+// DefaultTreeMaxDepth is the default max depth of the tree collection
+// records (used when the collection TreeMaxDepth option is not set).
+const DefaultTreeMaxDepth = 100
+
+// Auth collection email OTP defaults
+// (used when the collection OtpDuration and OtpLength options are not set)
+// and the max allowed OTP lifetime.
+const (
+	DefaultOtpDuration = 180   // in seconds
+	MaxOtpDuration     = 86400 // in seconds
+	DefaultOtpLength   = 6
+)
+
+// Auth collection refresh tokens defaults (used when the collection
+// AccessTokenDuration and RefreshTokenDuration options are not set).
+const (
+	DefaultAccessTokenDuration  = 900     // in seconds
+	DefaultRefreshTokenDuration = 2592000 // in seconds
+)
+
 type Collection struct {
 	BaseModel
 
@@ -49,6 +125,14 @@ func (m *Collection) BaseFilesPath() string {
 	return m.Id
 }
 
+// TenantFilesPath returns the storage dir path used by
+// the collection records of the specified tenant.
+//
+// The tenant id is hashed to ensure that it is a safe path segment.
+func (m *Collection) TenantFilesPath(tenantId string) string {
+	return m.BaseFilesPath() + "/tenant_" + security.MD5(tenantId)
+}
+
 // IsBase checks if the current collection has "base" type.
 func (m *Collection) IsBase() bool {
 	return m.Type == CollectionTypeBase
@@ -97,6 +181,310 @@ func (m *Collection) ViewOptions() CollectionViewOptions {
 	return result
 }
 
+// IsStrict checks whether the collection records create/update
+// payloads with unknown fields should be rejected (see the "strictMode" option).
+//
+// View collections are never strict since they are read-only.
+func (m *Collection) IsStrict() bool {
+	switch m.Type {
+	case CollectionTypeBase:
+		return m.BaseOptions().StrictMode
+	case CollectionTypeAuth:
+		return m.AuthOptions().StrictMode
+	default:
+		return false
+	}
+}
+
+// Profiles returns the record serialization profiles defined
+// in the collection options (if any).
+func (m *Collection) Profiles() []CollectionProfile {
+	switch m.Type {
+	case CollectionTypeBase:
+		return m.BaseOptions().Profiles
+	case CollectionTypeAuth:
+		return m.AuthOptions().Profiles
+	default:
+		return nil
+	}
+}
+
+// SyncStrategy returns the conflict resolution strategy used when
+// syncing offline client mutations (default to SyncStrategyLastWriteWins).
+func (m *Collection) SyncStrategy() string {
+	var strategy string
+
+	switch m.Type {
+	case CollectionTypeBase:
+		strategy = m.BaseOptions().SyncStrategy
+	case CollectionTypeAuth:
+		strategy = m.AuthOptions().SyncStrategy
+	}
+
+	if strategy == "" {
+		return SyncStrategyLastWriteWins
+	}
+
+	return strategy
+}
+
+// TenantField returns the name of the record field that holds the
+// tenant identifier (used for the encrypted fields keys).
+//
+// Returns empty string if the collection is not tenant aware.
+func (m *Collection) TenantField() string {
+	switch m.Type {
+	case CollectionTypeBase:
+		return m.BaseOptions().TenantField
+	case CollectionTypeAuth:
+		return m.AuthOptions().TenantField
+	}
+
+	return ""
+}
+
+// TreeField returns the name of the self-referencing single relation
+// field that holds the parent of the record in the collection tree.
+//
+// Returns empty string if the collection is not a tree.
+func (m *Collection) TreeField() string {
+	switch m.Type {
+	case CollectionTypeBase:
+		return m.BaseOptions().TreeField
+	case CollectionTypeAuth:
+		return m.AuthOptions().TreeField
+	}
+
+	return ""
+}
+
+// TreeMaxDepth returns the max allowed depth of the collection tree records
+// (fallbacks to DefaultTreeMaxDepth if not set).
+func (m *Collection) TreeMaxDepth() int {
+	var depth int
+
+	switch m.Type {
+	case CollectionTypeBase:
+		depth = m.BaseOptions().TreeMaxDepth
+	case CollectionTypeAuth:
+		depth = m.AuthOptions().TreeMaxDepth
+	}
+
+	if depth <= 0 {
+		return DefaultTreeMaxDepth
+	}
+
+	return depth
+}
+
+// OtpDuration returns the lifetime of the auth collection email OTPs
+// (fallbacks to DefaultOtpDuration if not set).
+func (m *Collection) OtpDuration() time.Duration {
+	seconds := m.AuthOptions().OtpDuration
+	if seconds <= 0 {
+		seconds = DefaultOtpDuration
+	}
+
+	return time.Duration(seconds) * time.Second
+}
+
+// OtpLength returns the number of digits of the auth collection email OTPs
+// (fallbacks to DefaultOtpLength if not set).
+func (m *Collection) OtpLength() int {
+	length := m.AuthOptions().OtpLength
+	if length <= 0 {
+		return DefaultOtpLength
+	}
+
+	return length
+}
+
+// AccessTokenDuration returns the lifetime of the auth collection access
+// tokens when refresh tokens are enabled (fallbacks to DefaultAccessTokenDuration if not set).
+func (m *Collection) AccessTokenDuration() time.Duration {
+	seconds := m.AuthOptions().AccessTokenDuration
+	if seconds <= 0 {
+		seconds = DefaultAccessTokenDuration
+	}
+
+	return time.Duration(seconds) * time.Second
+}
+
+// RefreshTokenDuration returns the lifetime of the auth collection
+// refresh tokens (fallbacks to DefaultRefreshTokenDuration if not set).
+func (m *Collection) RefreshTokenDuration() time.Duration {
+	seconds := m.AuthOptions().RefreshTokenDuration
+	if seconds <= 0 {
+		seconds = DefaultRefreshTokenDuration
+	}
+
+	return time.Duration(seconds) * time.Second
+}
+
+// CommentsEnabled checks whether the built-in records comments
+// are enabled for the collection.
+func (m *Collection) CommentsEnabled() bool {
+	switch m.Type {
+	case CollectionTypeBase:
+		return m.BaseOptions().Comments
+	case CollectionTypeAuth:
+		return m.AuthOptions().Comments
+	}
+
+	return false
+}
+
+// HistoryEnabled checks whether the record versions history
+// is enabled for the collection.
+func (m *Collection) HistoryEnabled() bool {
+	switch m.Type {
+	case CollectionTypeBase:
+		return m.BaseOptions().History
+	case CollectionTypeAuth:
+		return m.AuthOptions().History
+	}
+
+	return false
+}
+
+// LedgerEnabled checks whether the collection is an append-only
+// ledger (only base collections could be ledgers).
+func (m *Collection) LedgerEnabled() bool {
+	return m.Type == CollectionTypeBase && m.BaseOptions().Ledger
+}
+
+// SoftDeleteField returns the name of the date field
+// that marks the soft deleted collection records.
+//
+// Returns empty string if the collection soft delete is not enabled.
+func (m *Collection) SoftDeleteField() string {
+	switch m.Type {
+	case CollectionTypeBase:
+		return m.BaseOptions().SoftDeleteField
+	case CollectionTypeAuth:
+		return m.AuthOptions().SoftDeleteField
+	}
+
+	return ""
+}
+
+// ReactionTypes returns the allowed collection record reaction types
+// (empty if the collection reactions are not enabled).
+func (m *Collection) ReactionTypes() []string {
+	switch m.Type {
+	case CollectionTypeBase:
+		return m.BaseOptions().Reactions
+	case CollectionTypeAuth:
+		return m.AuthOptions().Reactions
+	}
+
+	return nil
+}
+
+// ReactionsField returns the name of the json field
+// that holds the record reactions counters.
+func (m *Collection) ReactionsField() string {
+	switch m.Type {
+	case CollectionTypeBase:
+		return m.BaseOptions().ReactionsField
+	case CollectionTypeAuth:
+		return m.AuthOptions().ReactionsField
+	}
+
+	return ""
+}
+
+// Schedules returns the collection scheduled record mutations (if any).
+func (m *Collection) Schedules() []CollectionSchedule {
+	switch m.Type {
+	case CollectionTypeBase:
+		return m.BaseOptions().Schedules
+	case CollectionTypeAuth:
+		return m.AuthOptions().Schedules
+	}
+
+	return nil
+}
+
+// ListDefaultSort returns the records list sort expression
+// used when the client doesn't specify one (if any).
+func (m *Collection) ListDefaultSort() string {
+	switch m.Type {
+	case CollectionTypeBase:
+		return m.BaseOptions().ListDefaultSort
+	case CollectionTypeAuth:
+		return m.AuthOptions().ListDefaultSort
+	case CollectionTypeView:
+		return m.ViewOptions().ListDefaultSort
+	}
+
+	return ""
+}
+
+// ListMaxPerPage returns the max allowed records list page size
+// (fallbacks to search.MaxPerPage if not set).
+func (m *Collection) ListMaxPerPage() int {
+	var limit int
+
+	switch m.Type {
+	case CollectionTypeBase:
+		limit = m.BaseOptions().ListMaxPerPage
+	case CollectionTypeAuth:
+		limit = m.AuthOptions().ListMaxPerPage
+	case CollectionTypeView:
+		limit = m.ViewOptions().ListMaxPerPage
+	}
+
+	if limit <= 0 {
+		return search.MaxPerPage
+	}
+
+	return limit
+}
+
+// ListMaxFilterComplexity returns the max allowed complexity score
+// of the records list filter (0 means no limit).
+func (m *Collection) ListMaxFilterComplexity() int {
+	switch m.Type {
+	case CollectionTypeBase:
+		return m.BaseOptions().ListMaxFilterComplexity
+	case CollectionTypeAuth:
+		return m.AuthOptions().ListMaxFilterComplexity
+	case CollectionTypeView:
+		return m.ViewOptions().ListMaxFilterComplexity
+	}
+
+	return 0
+}
+
+// RuleLogSampleRate returns the fraction of the non-admin requests
+// whose API rule evaluation outcome is logged (0 means disabled).
+func (m *Collection) RuleLogSampleRate() float64 {
+	switch m.Type {
+	case CollectionTypeBase:
+		return m.BaseOptions().RuleLogSampleRate
+	case CollectionTypeAuth:
+		return m.AuthOptions().RuleLogSampleRate
+	case CollectionTypeView:
+		return m.ViewOptions().RuleLogSampleRate
+	}
+
+	return 0
+}
+
+// FullTextFields returns the names of the fields that are part
+// of the collection full-text search index (if any).
+func (m *Collection) FullTextFields() []string {
+	switch m.Type {
+	case CollectionTypeBase:
+		return m.BaseOptions().FullTextFields
+	case CollectionTypeAuth:
+		return m.AuthOptions().FullTextFields
+	}
+
+	return nil
+}
+
 // NormalizeOptions updates the current collection options with a
 // new normalized state based on the collection type.
 func (m *Collection) NormalizeOptions() error {
@@ -162,10 +550,127 @@ func (m *Collection) SetOptions(typedOptions any) error {
 
 // CollectionBaseOptions defines the "base" Collection.Options fields.
 type CollectionBaseOptions struct {
+	StrictMode   bool                `form:"strictMode" json:"strictMode,omitempty"`
+	Profiles     []CollectionProfile `form:"profiles" json:"profiles,omitempty"`
+	SyncStrategy string              `form:"syncStrategy" json:"syncStrategy,omitempty"`
+	TenantField  string              `form:"tenantField" json:"tenantField,omitempty"`
+
+	// UsernameLowercase stores the auth record usernames in lowercase.
+	UsernameLowercase bool `form:"usernameLowercase" json:"usernameLowercase,omitempty"`
+
+	// UsernameConfusables rejects usernames that are visually confusable
+	// with an existing or a reserved username (eg. "adm1n" vs "admin").
+	UsernameConfusables bool `form:"usernameConfusables" json:"usernameConfusables,omitempty"`
+
+	// ReservedUsernames is a list of usernames that could be set
+	// only by admins or auth records with manage access.
+	ReservedUsernames []string `form:"reservedUsernames" json:"reservedUsernames,omitempty"`
+
+	// UsernameChangeCooldown is the minimum number of seconds between
+	// two username changes of the same auth record (0 means no limit).
+	UsernameChangeCooldown int `form:"usernameChangeCooldown" json:"usernameChangeCooldown,omitempty"`
+
+	// TreeField is the name of the self-referencing single relation
+	// field that marks the collection records as a tree (aka. the parent field).
+	TreeField string `form:"treeField" json:"treeField,omitempty"`
+
+	// TreeMaxDepth is the max allowed depth of the tree records
+	// (0 means DefaultTreeMaxDepth).
+	TreeMaxDepth int `form:"treeMaxDepth" json:"treeMaxDepth,omitempty"`
+
+	// Comments enables the built-in records comments.
+	Comments bool `form:"comments" json:"comments,omitempty"`
+
+	// History enables storing a snapshot of the collection
+	// records before each update or delete (aka. record versions).
+	History bool `form:"history" json:"history,omitempty"`
+
+	// SoftDeleteField is the name of the date field that marks the
+	// soft deleted records with their deletion date (aka. the records
+	// are not removed on delete). Empty string means disabled soft delete.
+	SoftDeleteField string `form:"softDeleteField" json:"softDeleteField,omitempty"`
+
+	// Reactions is the list of the allowed record reaction types
+	// (eg. "like", "heart"). Empty list means disabled reactions.
+	Reactions []string `form:"reactions" json:"reactions,omitempty"`
+
+	// ReactionsField is the name of the json field that holds
+	// the record reactions counters (aka. {"like": 10, "heart": 2}).
+	ReactionsField string `form:"reactionsField" json:"reactionsField,omitempty"`
+
+	// Schedules is a list of record mutations that are executed
+	// by the app cron when the records date field value is reached.
+	Schedules []CollectionSchedule `form:"schedules" json:"schedules,omitempty"`
+
+	// ListDefaultSort is the records list sort expression applied
+	// when the client doesn't specify one (eg. "-created,id").
+	ListDefaultSort string `form:"listDefaultSort" json:"listDefaultSort,omitempty"`
+
+	// ListMaxPerPage is the max allowed records list page size
+	// for non-admin requests (0 means search.MaxPerPage).
+	ListMaxPerPage int `form:"listMaxPerPage" json:"listMaxPerPage,omitempty"`
+
+	// ListMaxFilterComplexity is the max allowed complexity score of the
+	// records list filter for non-admin requests (0 means no limit).
+	//
+	// See [search.FilterData.Complexity()] for how the score is calculated.
+	ListMaxFilterComplexity int `form:"listMaxFilterComplexity" json:"listMaxFilterComplexity,omitempty"`
+
+	// RuleLogSampleRate is the fraction (0-1] of the non-admin requests
+	// whose API rule evaluation outcome is logged with debug level
+	// (0 disables the rule outcome logging).
+	RuleLogSampleRate float64 `form:"ruleLogSampleRate" json:"ruleLogSampleRate,omitempty"`
+
+	// FullTextFields is the list of the text fields that are part of
+	// the collection full-text search index (empty means disabled index).
+	FullTextFields []string `form:"fullTextFields" json:"fullTextFields,omitempty"`
+
+	// Ledger marks the collection as append-only hash chained ledger
+	// (aka. its records cannot be updated or deleted).
+	//
+	// It could be set only on the collection creation.
+	Ledger bool `form:"ledger" json:"ledger,omitempty"`
 }
 
 // Validate implements [validation.Validatable] interface.
 func (o CollectionBaseOptions) Validate() error {
+	return validation.ValidateStruct(&o,
+		validation.Field(&o.Profiles, validation.By(checkUniqueProfileNames)),
+		validation.Field(&o.SyncStrategy, validation.In(syncStrategies...)),
+		validation.Field(&o.ReservedUsernames, validation.Each(validation.Required, validation.Length(1, 150))),
+		validation.Field(&o.UsernameChangeCooldown, validation.Min(0)),
+		validation.Field(&o.TreeMaxDepth, validation.Min(0), validation.Max(1000)),
+		validation.Field(&o.Reactions, validation.By(checkReactionTypes), validation.When(o.Ledger, validation.Empty)),
+		validation.Field(&o.ReactionsField, validation.When(len(o.Reactions) > 0, validation.Required)),
+		validation.Field(&o.Schedules, validation.By(checkUniqueScheduleNames), validation.When(o.Ledger, validation.By(checkLedgerSchedules))),
+		validation.Field(&o.ListMaxPerPage, validation.Min(0), validation.Max(search.MaxPerPage)),
+		validation.Field(&o.ListMaxFilterComplexity, validation.Min(0)),
+		validation.Field(&o.RuleLogSampleRate, validation.Min(0.0), validation.Max(1.0)),
+		validation.Field(&o.FullTextFields, validation.By(checkUniqueFullTextFields)),
+		// the ledger records cannot be mutated
+		validation.Field(&o.History, validation.When(o.Ledger, validation.Empty)),
+		validation.Field(&o.SoftDeleteField, validation.When(o.Ledger, validation.Empty)),
+	)
+}
+
+// checkLedgerSchedules ensures that the ledger collection schedules
+// don't mutate the records (aka. only webhook schedules are allowed).
+func checkLedgerSchedules(value any) error {
+	v, _ := value.([]CollectionSchedule)
+
+	for i, s := range v {
+		if s.Action != ScheduleActionWebhook {
+			return validation.Errors{
+				strconv.Itoa(i): validation.Errors{
+					"action": validation.NewError(
+						"validation_ledger_schedule_action",
+						"Only webhook schedules are allowed for ledger collections.",
+					),
+				},
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -182,6 +687,170 @@ type CollectionAuthOptions struct {
 	OnlyVerified       bool     `form:"onlyVerified" json:"onlyVerified"`
 	OnlyEmailDomains   []string `form:"onlyEmailDomains" json:"onlyEmailDomains"`
 	MinPasswordLength  int      `form:"minPasswordLength" json:"minPasswordLength"`
+	StrictMode         bool     `form:"strictMode" json:"strictMode,omitempty"`
+
+	Profiles     []CollectionProfile `form:"profiles" json:"profiles,omitempty"`
+	SyncStrategy string              `form:"syncStrategy" json:"syncStrategy,omitempty"`
+	TenantField  string              `form:"tenantField" json:"tenantField,omitempty"`
+
+	// UsernameLowercase stores the auth record usernames in lowercase.
+	UsernameLowercase bool `form:"usernameLowercase" json:"usernameLowercase,omitempty"`
+
+	// UsernameConfusables rejects usernames that are visually confusable
+	// with an existing or a reserved username (eg. "adm1n" vs "admin").
+	UsernameConfusables bool `form:"usernameConfusables" json:"usernameConfusables,omitempty"`
+
+	// ReservedUsernames is a list of usernames that could be set
+	// only by admins or auth records with manage access.
+	ReservedUsernames []string `form:"reservedUsernames" json:"reservedUsernames,omitempty"`
+
+	// UsernameChangeCooldown is the minimum number of seconds between
+	// two username changes of the same auth record (0 means no limit).
+	UsernameChangeCooldown int `form:"usernameChangeCooldown" json:"usernameChangeCooldown,omitempty"`
+
+	// EmailCanonicalization is the policy used to canonicalize the auth
+	// record emails for the uniqueness checks (the original email is preserved).
+	EmailCanonicalization string `form:"emailCanonicalization" json:"emailCanonicalization,omitempty"`
+
+	// PasswordRequireUppercase requires the auth record passwords
+	// to have at least one uppercase letter.
+	PasswordRequireUppercase bool `form:"passwordRequireUppercase" json:"passwordRequireUppercase,omitempty"`
+
+	// PasswordRequireLowercase requires the auth record passwords
+	// to have at least one lowercase letter.
+	PasswordRequireLowercase bool `form:"passwordRequireLowercase" json:"passwordRequireLowercase,omitempty"`
+
+	// PasswordRequireDigit requires the auth record passwords
+	// to have at least one digit.
+	PasswordRequireDigit bool `form:"passwordRequireDigit" json:"passwordRequireDigit,omitempty"`
+
+	// PasswordRequireSymbol requires the auth record passwords to have
+	// at least one symbol (aka. non letter and non digit character).
+	PasswordRequireSymbol bool `form:"passwordRequireSymbol" json:"passwordRequireSymbol,omitempty"`
+
+	// PasswordBreachCheck rejects the auth record passwords that were
+	// found in known data breaches (using the Pwned Passwords k-anonymity API).
+	PasswordBreachCheck bool `form:"passwordBreachCheck" json:"passwordBreachCheck,omitempty"`
+
+	// PasswordCheckOnLogin enforces the password policy also on
+	// password login, rejecting the valid credentials of the
+	// auth records whose password no longer satisfies the policy.
+	PasswordCheckOnLogin bool `form:"passwordCheckOnLogin" json:"passwordCheckOnLogin,omitempty"`
+
+	// MfaMode is the auth records multi-factor authentication enforcement mode.
+	MfaMode string `form:"mfaMode" json:"mfaMode,omitempty"`
+
+	// AllowPasskeyAuth enables the auth records WebAuthn passkeys
+	// registration and authentication.
+	AllowPasskeyAuth bool `form:"allowPasskeyAuth" json:"allowPasskeyAuth,omitempty"`
+
+	// PasskeyRPId is the WebAuthn relying party id
+	// (default to the app url hostname).
+	PasskeyRPId string `form:"passkeyRPId" json:"passkeyRPId,omitempty"`
+
+	// PasskeyOrigins is the list of the allowed WebAuthn client origins
+	// (default to the app url origin).
+	PasskeyOrigins []string `form:"passkeyOrigins" json:"passkeyOrigins,omitempty"`
+
+	// AllowOtpAuth enables the passwordless authentication with
+	// a one-time password sent to the auth record email
+	// (as code or as part of a magic link).
+	AllowOtpAuth bool `form:"allowOtpAuth" json:"allowOtpAuth,omitempty"`
+
+	// OtpDuration is the lifetime of the sent one-time passwords
+	// in seconds (0 means DefaultOtpDuration).
+	OtpDuration int `form:"otpDuration" json:"otpDuration,omitempty"`
+
+	// OtpLength is the number of digits of the generated
+	// one-time passwords (0 means DefaultOtpLength).
+	OtpLength int `form:"otpLength" json:"otpLength,omitempty"`
+
+	// AllowDeviceAuth enables the OAuth2 device authorization grant
+	// (RFC 8628) for input constrained clients like CLI and TV apps.
+	AllowDeviceAuth bool `form:"allowDeviceAuth" json:"allowDeviceAuth,omitempty"`
+
+	// AllowWalletAuth enables the Sign-In with Ethereum (EIP-4361)
+	// wallet signature authentication.
+	AllowWalletAuth bool `form:"allowWalletAuth" json:"allowWalletAuth,omitempty"`
+
+	// WalletDomains is the list of the allowed EIP-4361 message domains
+	// (default to the app url host).
+	WalletDomains []string `form:"walletDomains" json:"walletDomains,omitempty"`
+
+	// WalletChainIds is the list of the allowed EIP-155 chain ids
+	// (empty means any chain).
+	WalletChainIds []int `form:"walletChainIds" json:"walletChainIds,omitempty"`
+
+	// AllowRefreshTokens enables the short-lived access tokens
+	// and server-side stored rotating refresh tokens scheme.
+	AllowRefreshTokens bool `form:"allowRefreshTokens" json:"allowRefreshTokens,omitempty"`
+
+	// AccessTokenDuration is the lifetime of the issued auth tokens in seconds
+	// when refresh tokens are enabled (0 means DefaultAccessTokenDuration).
+	AccessTokenDuration int `form:"accessTokenDuration" json:"accessTokenDuration,omitempty"`
+
+	// RefreshTokenDuration is the lifetime of the issued
+	// refresh tokens in seconds (0 means DefaultRefreshTokenDuration).
+	RefreshTokenDuration int `form:"refreshTokenDuration" json:"refreshTokenDuration,omitempty"`
+
+	// TrackSessions enables tracking the issued auth tokens as
+	// sessions that could be listed and individually revoked.
+	TrackSessions bool `form:"trackSessions" json:"trackSessions,omitempty"`
+
+	// TreeField is the name of the self-referencing single relation
+	// field that marks the collection records as a tree (aka. the parent field).
+	TreeField string `form:"treeField" json:"treeField,omitempty"`
+
+	// TreeMaxDepth is the max allowed depth of the tree records
+	// (0 means DefaultTreeMaxDepth).
+	TreeMaxDepth int `form:"treeMaxDepth" json:"treeMaxDepth,omitempty"`
+
+	// Comments enables the built-in records comments.
+	Comments bool `form:"comments" json:"comments,omitempty"`
+
+	// History enables storing a snapshot of the collection
+	// records before each update or delete (aka. record versions).
+	History bool `form:"history" json:"history,omitempty"`
+
+	// SoftDeleteField is the name of the date field that marks the
+	// soft deleted records with their deletion date (aka. the records
+	// are not removed on delete). Empty string means disabled soft delete.
+	SoftDeleteField string `form:"softDeleteField" json:"softDeleteField,omitempty"`
+
+	// Reactions is the list of the allowed record reaction types
+	// (eg. "like", "heart"). Empty list means disabled reactions.
+	Reactions []string `form:"reactions" json:"reactions,omitempty"`
+
+	// ReactionsField is the name of the json field that holds
+	// the record reactions counters (aka. {"like": 10, "heart": 2}).
+	ReactionsField string `form:"reactionsField" json:"reactionsField,omitempty"`
+
+	// Schedules is a list of record mutations that are executed
+	// by the app cron when the records date field value is reached.
+	Schedules []CollectionSchedule `form:"schedules" json:"schedules,omitempty"`
+
+	// ListDefaultSort is the records list sort expression applied
+	// when the client doesn't specify one (eg. "-created,id").
+	ListDefaultSort string `form:"listDefaultSort" json:"listDefaultSort,omitempty"`
+
+	// ListMaxPerPage is the max allowed records list page size
+	// for non-admin requests (0 means search.MaxPerPage).
+	ListMaxPerPage int `form:"listMaxPerPage" json:"listMaxPerPage,omitempty"`
+
+	// ListMaxFilterComplexity is the max allowed complexity score of the
+	// records list filter for non-admin requests (0 means no limit).
+	//
+	// See [search.FilterData.Complexity()] for how the score is calculated.
+	ListMaxFilterComplexity int `form:"listMaxFilterComplexity" json:"listMaxFilterComplexity,omitempty"`
+
+	// RuleLogSampleRate is the fraction (0-1] of the non-admin requests
+	// whose API rule evaluation outcome is logged with debug level
+	// (0 disables the rule outcome logging).
+	RuleLogSampleRate float64 `form:"ruleLogSampleRate" json:"ruleLogSampleRate,omitempty"`
+
+	// FullTextFields is the list of the text fields that are part of
+	// the collection full-text search index (empty means disabled index).
+	FullTextFields []string `form:"fullTextFields" json:"fullTextFields,omitempty"`
 }
 
 // Validate implements [validation.Validatable] interface.
@@ -202,6 +871,214 @@ func (o CollectionAuthOptions) Validate() error {
 			validation.Min(5),
 			validation.Max(72),
 		),
+		validation.Field(&o.Profiles, validation.By(checkUniqueProfileNames)),
+		validation.Field(&o.SyncStrategy, validation.In(syncStrategies...)),
+		validation.Field(&o.ReservedUsernames, validation.Each(validation.Required, validation.Length(1, 150))),
+		validation.Field(&o.UsernameChangeCooldown, validation.Min(0)),
+		validation.Field(&o.EmailCanonicalization, validation.In(emailCanonicalizations...)),
+		validation.Field(&o.MfaMode, validation.In(mfaModes...)),
+		validation.Field(&o.PasskeyRPId, validation.Length(0, 255), is.Host),
+		validation.Field(&o.PasskeyOrigins, validation.Each(validation.Required, is.URL)),
+		validation.Field(&o.OtpDuration, validation.Min(0), validation.Max(MaxOtpDuration)),
+		validation.Field(&o.OtpLength, validation.When(o.OtpLength != 0, validation.Min(4), validation.Max(12))),
+		validation.Field(&o.WalletDomains, validation.Each(validation.Required, validation.Length(1, 255), validation.Match(walletDomainRegex))),
+		validation.Field(&o.WalletChainIds, validation.Each(validation.Required, validation.Min(1))),
+		validation.Field(&o.AccessTokenDuration, validation.Min(0)),
+		validation.Field(&o.RefreshTokenDuration, validation.Min(0)),
+		validation.Field(&o.TreeMaxDepth, validation.Min(0), validation.Max(1000)),
+		validation.Field(&o.Reactions, validation.By(checkReactionTypes)),
+		validation.Field(&o.ReactionsField, validation.When(len(o.Reactions) > 0, validation.Required)),
+		validation.Field(&o.Schedules, validation.By(checkUniqueScheduleNames)),
+		validation.Field(&o.ListMaxPerPage, validation.Min(0), validation.Max(search.MaxPerPage)),
+		validation.Field(&o.ListMaxFilterComplexity, validation.Min(0)),
+		validation.Field(&o.RuleLogSampleRate, validation.Min(0.0), validation.Max(1.0)),
+		validation.Field(&o.FullTextFields, validation.By(checkUniqueFullTextFields)),
+	)
+}
+
+// -------------------------------------------------------------------
+
+// CollectionProfile defines a named record serialization profile
+// that restricts the exported record fields.
+type CollectionProfile struct {
+	// Name is the unique profile identifier (eg. "public", "owner").
+	Name string `form:"name" json:"name"`
+
+	// Rule is the filter expression that the record and the request
+	// must satisfy in order the profile to be applied
+	// (nil means admins only and empty string - everyone).
+	Rule *string `form:"rule" json:"rule"`
+
+	// Fields is the list of the schema (and auth) field names exposed by the profile.
+	Fields []string `form:"fields" json:"fields"`
+}
+
+// Validate implements [validation.Validatable] interface.
+func (p CollectionProfile) Validate() error {
+	return validation.ValidateStruct(&p,
+		validation.Field(&p.Name, validation.Required, validation.Length(1, 100), validation.Match(profileNameRegex)),
+		validation.Field(&p.Fields, validation.Each(validation.Required)),
+	)
+}
+
+func checkUniqueProfileNames(value any) error {
+	v, _ := value.([]CollectionProfile)
+
+	names := make(map[string]struct{}, len(v))
+
+	for i, p := range v {
+		if _, ok := names[p.Name]; ok {
+			return validation.Errors{
+				strconv.Itoa(i): validation.Errors{
+					"name": validation.NewError("validation_duplicated_profile_name", "Duplicated profile name."),
+				},
+			}
+		}
+		names[p.Name] = struct{}{}
+	}
+
+	return nil
+}
+
+const (
+	// ScheduleActionUpdate updates the record with the schedule data.
+	ScheduleActionUpdate = "update"
+
+	// ScheduleActionDelete deletes the record.
+	ScheduleActionDelete = "delete"
+
+	// ScheduleActionWebhook sends the record to the schedule webhook url.
+	ScheduleActionWebhook = "webhook"
+)
+
+// CollectionSchedule defines a single scheduled record mutation
+// that is triggered when the record Field datetime is reached
+// (eg. publish-at, expire-at).
+type CollectionSchedule struct {
+	// Name is the unique schedule identifier (eg. "publish").
+	Name string `form:"name" json:"name"`
+
+	// Field is the name of the date field that holds the record trigger time.
+	Field string `form:"field" json:"field"`
+
+	// Action is the mutation to execute - "update", "delete" or "webhook".
+	Action string `form:"action" json:"action"`
+
+	// Data is the record data to set with the "update" action (eg. {"status": "published"}).
+	Data map[string]any `form:"data" json:"data,omitempty"`
+
+	// WebhookUrl is the url where the record is POST-ed with the "webhook" action.
+	WebhookUrl string `form:"webhookUrl" json:"webhookUrl,omitempty"`
+}
+
+// Validate implements [validation.Validatable] interface.
+func (s CollectionSchedule) Validate() error {
+	return validation.ValidateStruct(&s,
+		validation.Field(&s.Name, validation.Required, validation.Length(1, 100), validation.Match(profileNameRegex)),
+		validation.Field(&s.Field, validation.Required),
+		validation.Field(
+			&s.Action,
+			validation.Required,
+			validation.In(ScheduleActionUpdate, ScheduleActionDelete, ScheduleActionWebhook),
+		),
+		validation.Field(
+			&s.Data,
+			validation.When(s.Action == ScheduleActionUpdate, validation.Required).Else(validation.Empty),
+		),
+		validation.Field(
+			&s.WebhookUrl,
+			validation.When(s.Action == ScheduleActionWebhook, validation.Required, is.URL).Else(validation.Empty),
+		),
+	)
+}
+
+func checkUniqueScheduleNames(value any) error {
+	v, _ := value.([]CollectionSchedule)
+
+	names := make(map[string]struct{}, len(v))
+
+	for i, s := range v {
+		if _, ok := names[s.Name]; ok {
+			return validation.Errors{
+				strconv.Itoa(i): validation.Errors{
+					"name": validation.NewError("validation_duplicated_schedule_name", "Duplicated schedule name."),
+				},
+			}
+		}
+		names[s.Name] = struct{}{}
+	}
+
+	return nil
+}
+
+func checkReactionTypes(value any) error {
+	v, _ := value.([]string)
+
+	types := make(map[string]struct{}, len(v))
+
+	for i, t := range v {
+		if err := validation.Validate(t, validation.Required, validation.Length(1, 50), validation.Match(reactionTypeRegex)); err != nil {
+			return validation.Errors{strconv.Itoa(i): err}
+		}
+
+		if _, ok := types[t]; ok {
+			return validation.Errors{
+				strconv.Itoa(i): validation.NewError("validation_duplicated_reaction_type", "Duplicated reaction type."),
+			}
+		}
+		types[t] = struct{}{}
+	}
+
+	return nil
+}
+
+func checkUniqueFullTextFields(value any) error {
+	v, _ := value.([]string)
+
+	names := make(map[string]struct{}, len(v))
+
+	for i, name := range v {
+		if name == "" {
+			return validation.Errors{strconv.Itoa(i): validation.ErrRequired}
+		}
+
+		if _, ok := names[name]; ok {
+			return validation.Errors{
+				strconv.Itoa(i): validation.NewError("validation_duplicated_full_text_field", "Duplicated full-text field."),
+			}
+		}
+		names[name] = struct{}{}
+	}
+
+	return nil
+}
+
+// -------------------------------------------------------------------
+
+// CollectionFeedSource defines a single feed view source collection
+// and how its fields are mapped to the shared feed item fields.
+type CollectionFeedSource struct {
+	// Collection is the source collection name or id.
+	Collection string `form:"collection" json:"collection"`
+
+	// Type is the feed item "type" value of the source records
+	// (fallbacks to the source collection name if empty).
+	Type string `form:"type" json:"type,omitempty"`
+
+	// Title is the source field mapped to the feed item "title".
+	Title string `form:"title" json:"title"`
+
+	// Link is the source field mapped to the feed item "link" (optional).
+	Link string `form:"link" json:"link,omitempty"`
+}
+
+// Validate implements [validation.Validatable] interface.
+func (s CollectionFeedSource) Validate() error {
+	return validation.ValidateStruct(&s,
+		validation.Field(&s.Collection, validation.Required, validation.Length(1, 255)),
+		validation.Field(&s.Type, validation.Length(0, 100), validation.Match(feedTypeRegex)),
+		validation.Field(&s.Title, validation.Required, validation.Length(1, 255)),
+		validation.Field(&s.Link, validation.Length(0, 255)),
 	)
 }
 
@@ -210,11 +1087,41 @@ func (o CollectionAuthOptions) Validate() error {
 // CollectionViewOptions defines the "view" Collection.Options fields.
 type CollectionViewOptions struct {
 	Query string `form:"query" json:"query"`
+
+	// ListDefaultSort is the records list sort expression applied
+	// when the client doesn't specify one (eg. "-created,id").
+	ListDefaultSort string `form:"listDefaultSort" json:"listDefaultSort,omitempty"`
+
+	// ListMaxPerPage is the max allowed records list page size
+	// for non-admin requests (0 means search.MaxPerPage).
+	ListMaxPerPage int `form:"listMaxPerPage" json:"listMaxPerPage,omitempty"`
+
+	// ListMaxFilterComplexity is the max allowed complexity score of the
+	// records list filter for non-admin requests (0 means no limit).
+	//
+	// See [search.FilterData.Complexity()] for how the score is calculated.
+	ListMaxFilterComplexity int `form:"listMaxFilterComplexity" json:"listMaxFilterComplexity,omitempty"`
+
+	// RuleLogSampleRate is the fraction (0-1] of the non-admin requests
+	// whose API rule evaluation outcome is logged with debug level
+	// (0 disables the rule outcome logging).
+	RuleLogSampleRate float64 `form:"ruleLogSampleRate" json:"ruleLogSampleRate,omitempty"`
+
+	// Feed is an optional list of source collections that are merged
+	// into a single union view with shared "type", "title" and "link"
+	// fields (plus the source records "id", "created" and "updated").
+	//
+	// When set, Query is generated from the feed sources.
+	Feed []CollectionFeedSource `form:"feed" json:"feed,omitempty"`
 }
 
 // Validate implements [validation.Validatable] interface.
 func (o CollectionViewOptions) Validate() error {
 	return validation.ValidateStruct(&o,
-		validation.Field(&o.Query, validation.Required),
+		validation.Field(&o.Query, validation.When(len(o.Feed) == 0, validation.Required)),
+		validation.Field(&o.Feed),
+		validation.Field(&o.ListMaxPerPage, validation.Min(0), validation.Max(search.MaxPerPage)),
+		validation.Field(&o.ListMaxFilterComplexity, validation.Min(0)),
+		validation.Field(&o.RuleLogSampleRate, validation.Min(0.0), validation.Max(1.0)),
 	)
 }
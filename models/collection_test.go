@@ -2,11 +2,13 @@ package models_test
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/pocketbase/pocketbase/models"
 	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/search"
 	"github.com/pocketbase/pocketbase/tools/types"
 )
 
@@ -28,6 +30,23 @@ func TestCollectionBaseFilesPath(t *testing.T) {
 	}
 }
 
+func TestCollectionTenantFilesPath(t *testing.T) {
+	m := models.Collection{}
+
+	m.RefreshId()
+
+	path1 := m.TenantFilesPath("t1")
+	path2 := m.TenantFilesPath("../t2")
+
+	if !strings.HasPrefix(path1, m.BaseFilesPath()+"/tenant_") {
+		t.Fatalf("Expected path %s to be under the collection tenants path", path1)
+	}
+
+	if path1 == path2 || strings.Count(path2, "/") != 1 || strings.Contains(path2, "..") {
+		t.Fatalf("Expected different and safe tenant paths, got %s and %s", path1, path2)
+	}
+}
+
 func TestCollectionIsBase(t *testing.T) {
 	scenarios := []struct {
 		collection models.Collection
@@ -66,6 +85,107 @@ func TestCollectionIsAuth(t *testing.T) {
 	}
 }
 
+func TestCollectionIsStrict(t *testing.T) {
+	scenarios := []struct {
+		collection models.Collection
+		expected   bool
+	}{
+		{models.Collection{}, false},
+		{models.Collection{Type: "unknown", Options: types.JsonMap{"strictMode": true}}, false},
+		{models.Collection{Type: models.CollectionTypeBase}, false},
+		{models.Collection{Type: models.CollectionTypeBase, Options: types.JsonMap{"strictMode": true}}, true},
+		{models.Collection{Type: models.CollectionTypeAuth, Options: types.JsonMap{"strictMode": true}}, true},
+		{models.Collection{Type: models.CollectionTypeView, Options: types.JsonMap{"strictMode": true}}, false},
+	}
+
+	for i, s := range scenarios {
+		result := s.collection.IsStrict()
+		if result != s.expected {
+			t.Errorf("(%d) Expected %v, got %v", i, s.expected, result)
+		}
+	}
+}
+
+func TestCollectionSyncStrategy(t *testing.T) {
+	scenarios := []struct {
+		collection models.Collection
+		expected   string
+	}{
+		{models.Collection{}, models.SyncStrategyLastWriteWins},
+		{models.Collection{Type: models.CollectionTypeBase}, models.SyncStrategyLastWriteWins},
+		{models.Collection{Type: models.CollectionTypeBase, Options: types.JsonMap{"syncStrategy": "serverWins"}}, models.SyncStrategyServerWins},
+		{models.Collection{Type: models.CollectionTypeAuth, Options: types.JsonMap{"syncStrategy": "custom"}}, models.SyncStrategyCustom},
+		{models.Collection{Type: models.CollectionTypeView, Options: types.JsonMap{"syncStrategy": "custom"}}, models.SyncStrategyLastWriteWins},
+	}
+
+	for i, s := range scenarios {
+		result := s.collection.SyncStrategy()
+		if result != s.expected {
+			t.Errorf("(%d) Expected %q, got %q", i, s.expected, result)
+		}
+	}
+}
+
+func TestCollectionCommentsEnabled(t *testing.T) {
+	scenarios := []struct {
+		collection models.Collection
+		expected   bool
+	}{
+		{models.Collection{}, false},
+		{models.Collection{Type: models.CollectionTypeBase}, false},
+		{models.Collection{Type: models.CollectionTypeBase, Options: types.JsonMap{"comments": true}}, true},
+		{models.Collection{Type: models.CollectionTypeAuth, Options: types.JsonMap{"comments": true}}, true},
+		{models.Collection{Type: models.CollectionTypeView, Options: types.JsonMap{"comments": true}}, false},
+	}
+
+	for i, s := range scenarios {
+		result := s.collection.CommentsEnabled()
+		if result != s.expected {
+			t.Errorf("(%d) Expected %v, got %v", i, s.expected, result)
+		}
+	}
+}
+
+func TestCollectionHistoryEnabled(t *testing.T) {
+	scenarios := []struct {
+		collection models.Collection
+		expected   bool
+	}{
+		{models.Collection{}, false},
+		{models.Collection{Type: models.CollectionTypeBase}, false},
+		{models.Collection{Type: models.CollectionTypeBase, Options: types.JsonMap{"history": true}}, true},
+		{models.Collection{Type: models.CollectionTypeAuth, Options: types.JsonMap{"history": true}}, true},
+		{models.Collection{Type: models.CollectionTypeView, Options: types.JsonMap{"history": true}}, false},
+	}
+
+	for i, s := range scenarios {
+		result := s.collection.HistoryEnabled()
+		if result != s.expected {
+			t.Errorf("(%d) Expected %v, got %v", i, s.expected, result)
+		}
+	}
+}
+
+func TestCollectionLedgerEnabled(t *testing.T) {
+	scenarios := []struct {
+		collection models.Collection
+		expected   bool
+	}{
+		{models.Collection{}, false},
+		{models.Collection{Type: models.CollectionTypeBase}, false},
+		{models.Collection{Type: models.CollectionTypeBase, Options: types.JsonMap{"ledger": true}}, true},
+		{models.Collection{Type: models.CollectionTypeAuth, Options: types.JsonMap{"ledger": true}}, false},
+		{models.Collection{Type: models.CollectionTypeView, Options: types.JsonMap{"ledger": true}}, false},
+	}
+
+	for i, s := range scenarios {
+		result := s.collection.LedgerEnabled()
+		if result != s.expected {
+			t.Errorf("(%d) Expected %v, got %v", i, s.expected, result)
+		}
+	}
+}
+
 func TestCollectionMarshalJSON(t *testing.T) {
 	scenarios := []struct {
 		name       string
@@ -357,9 +477,187 @@ func TestSetOptions(t *testing.T) {
 }
 
 func TestCollectionBaseOptionsValidate(t *testing.T) {
-	opt := models.CollectionBaseOptions{}
-	if err := opt.Validate(); err != nil {
-		t.Fatal(err)
+	scenarios := []struct {
+		name        string
+		options     models.CollectionBaseOptions
+		expectError bool
+	}{
+		{
+			"empty",
+			models.CollectionBaseOptions{},
+			false,
+		},
+		{
+			"invalid profile name",
+			models.CollectionBaseOptions{Profiles: []models.CollectionProfile{{Name: "a b"}}},
+			true,
+		},
+		{
+			"duplicated profile names",
+			models.CollectionBaseOptions{Profiles: []models.CollectionProfile{{Name: "a"}, {Name: "a"}}},
+			true,
+		},
+		{
+			"valid profiles",
+			models.CollectionBaseOptions{Profiles: []models.CollectionProfile{
+				{Name: "public", Rule: types.Pointer(""), Fields: []string{"title"}},
+				{Name: "admin"},
+			}},
+			false,
+		},
+		{
+			"invalid sync strategy",
+			models.CollectionBaseOptions{SyncStrategy: "invalid"},
+			true,
+		},
+		{
+			"valid sync strategy",
+			models.CollectionBaseOptions{SyncStrategy: models.SyncStrategyServerWins},
+			false,
+		},
+		{
+			"reactions without reactions field",
+			models.CollectionBaseOptions{Reactions: []string{"like"}},
+			true,
+		},
+		{
+			"invalid reaction type",
+			models.CollectionBaseOptions{Reactions: []string{"a b"}, ReactionsField: "reactions"},
+			true,
+		},
+		{
+			"duplicated reaction types",
+			models.CollectionBaseOptions{Reactions: []string{"like", "like"}, ReactionsField: "reactions"},
+			true,
+		},
+		{
+			"valid reactions",
+			models.CollectionBaseOptions{Reactions: []string{"like", "thumbs_up"}, ReactionsField: "reactions"},
+			false,
+		},
+		{
+			"invalid schedule",
+			models.CollectionBaseOptions{Schedules: []models.CollectionSchedule{
+				{Name: "publish", Field: "publishAt", Action: "invalid"},
+			}},
+			true,
+		},
+		{
+			"update schedule without data",
+			models.CollectionBaseOptions{Schedules: []models.CollectionSchedule{
+				{Name: "publish", Field: "publishAt", Action: models.ScheduleActionUpdate},
+			}},
+			true,
+		},
+		{
+			"webhook schedule with invalid url",
+			models.CollectionBaseOptions{Schedules: []models.CollectionSchedule{
+				{Name: "notify", Field: "publishAt", Action: models.ScheduleActionWebhook, WebhookUrl: "invalid"},
+			}},
+			true,
+		},
+		{
+			"duplicated schedule names",
+			models.CollectionBaseOptions{Schedules: []models.CollectionSchedule{
+				{Name: "expire", Field: "expireAt", Action: models.ScheduleActionDelete},
+				{Name: "expire", Field: "publishAt", Action: models.ScheduleActionDelete},
+			}},
+			true,
+		},
+		{
+			"valid schedules",
+			models.CollectionBaseOptions{Schedules: []models.CollectionSchedule{
+				{Name: "publish", Field: "publishAt", Action: models.ScheduleActionUpdate, Data: map[string]any{"status": "published"}},
+				{Name: "expire", Field: "expireAt", Action: models.ScheduleActionDelete},
+				{Name: "notify", Field: "publishAt", Action: models.ScheduleActionWebhook, WebhookUrl: "https://example.com/hook"},
+			}},
+			false,
+		},
+		{
+			"ledger with mutating options",
+			models.CollectionBaseOptions{Ledger: true, History: true},
+			true,
+		},
+		{
+			"ledger with soft delete",
+			models.CollectionBaseOptions{Ledger: true, SoftDeleteField: "deleted"},
+			true,
+		},
+		{
+			"ledger with reactions",
+			models.CollectionBaseOptions{Ledger: true, Reactions: []string{"like"}, ReactionsField: "reactions"},
+			true,
+		},
+		{
+			"ledger with delete schedule",
+			models.CollectionBaseOptions{Ledger: true, Schedules: []models.CollectionSchedule{
+				{Name: "expire", Field: "expireAt", Action: models.ScheduleActionDelete},
+			}},
+			true,
+		},
+		{
+			"valid ledger",
+			models.CollectionBaseOptions{Ledger: true, Comments: true, Schedules: []models.CollectionSchedule{
+				{Name: "notify", Field: "publishAt", Action: models.ScheduleActionWebhook, WebhookUrl: "https://example.com/hook"},
+			}},
+			false,
+		},
+		{
+			"negative list limits",
+			models.CollectionBaseOptions{ListMaxPerPage: -1, ListMaxFilterComplexity: -1},
+			true,
+		},
+		{
+			"list max perPage greater than search.MaxPerPage",
+			models.CollectionBaseOptions{ListMaxPerPage: 501},
+			true,
+		},
+		{
+			"valid list options",
+			models.CollectionBaseOptions{ListDefaultSort: "-created,id", ListMaxPerPage: 50, ListMaxFilterComplexity: 10},
+			false,
+		},
+		{
+			"rule log sample rate out of range",
+			models.CollectionBaseOptions{RuleLogSampleRate: 1.5},
+			true,
+		},
+		{
+			"valid rule log sample rate",
+			models.CollectionBaseOptions{RuleLogSampleRate: 0.25},
+			false,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			err := s.options.Validate()
+
+			if hasErr := err != nil; hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+		})
+	}
+}
+
+func TestCollectionProfiles(t *testing.T) {
+	profiles := []any{map[string]any{"name": "test", "fields": []string{"a"}}}
+
+	scenarios := []struct {
+		collection models.Collection
+		expected   int
+	}{
+		{models.Collection{}, 0},
+		{models.Collection{Type: models.CollectionTypeBase}, 0},
+		{models.Collection{Type: models.CollectionTypeBase, Options: types.JsonMap{"profiles": profiles}}, 1},
+		{models.Collection{Type: models.CollectionTypeAuth, Options: types.JsonMap{"profiles": profiles}}, 1},
+		{models.Collection{Type: models.CollectionTypeView, Options: types.JsonMap{"profiles": profiles}}, 0},
+	}
+
+	for i, s := range scenarios {
+		if result := s.collection.Profiles(); len(result) != s.expected {
+			t.Errorf("(%d) Expected %d profiles, got %d", i, s.expected, len(result))
+		}
 	}
 }
 
@@ -411,6 +709,60 @@ func TestCollectionAuthOptionsValidate(t *testing.T) {
 			},
 			[]string{},
 		},
+		{
+			"invalid email canonicalization",
+			models.CollectionAuthOptions{EmailCanonicalization: "invalid"},
+			[]string{"emailCanonicalization"},
+		},
+		{
+			"valid email canonicalization",
+			models.CollectionAuthOptions{EmailCanonicalization: models.EmailCanonicalizationFull},
+			[]string{},
+		},
+		{
+			"invalid mfa mode",
+			models.CollectionAuthOptions{MfaMode: "invalid"},
+			[]string{"mfaMode"},
+		},
+		{
+			"valid mfa mode",
+			models.CollectionAuthOptions{MfaMode: models.MfaModeRequired},
+			[]string{},
+		},
+		{
+			"invalid passkey relying party and origins",
+			models.CollectionAuthOptions{
+				PasskeyRPId:    "invalid host!",
+				PasskeyOrigins: []string{"", "invalid url"},
+			},
+			[]string{"passkeyRPId", "passkeyOrigins"},
+		},
+		{
+			"valid passkey relying party and origins",
+			models.CollectionAuthOptions{
+				AllowPasskeyAuth: true,
+				PasskeyRPId:      "example.com",
+				PasskeyOrigins:   []string{"https://example.com", "http://localhost:8090"},
+			},
+			[]string{},
+		},
+		{
+			"invalid wallet domains and chain ids",
+			models.CollectionAuthOptions{
+				WalletDomains:  []string{"", "https://example.com"},
+				WalletChainIds: []int{0, -1},
+			},
+			[]string{"walletDomains", "walletChainIds"},
+		},
+		{
+			"valid wallet domains and chain ids",
+			models.CollectionAuthOptions{
+				AllowWalletAuth: true,
+				WalletDomains:   []string{"example.com", "localhost:8090"},
+				WalletChainIds:  []int{1, 137},
+			},
+			[]string{},
+		},
 		{
 			"all fields with valid data",
 			models.CollectionAuthOptions{
@@ -492,3 +844,98 @@ func TestCollectionViewOptionsValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestCollectionListOptions(t *testing.T) {
+	scenarios := []struct {
+		name                  string
+		collection            func() *models.Collection
+		expectedSort          string
+		expectedMaxPerPage    int
+		expectedMaxComplexity int
+	}{
+		{
+			"base with empty options",
+			func() *models.Collection {
+				c := &models.Collection{Type: models.CollectionTypeBase}
+				c.SetOptions(models.CollectionBaseOptions{})
+				return c
+			},
+			"",
+			search.MaxPerPage,
+			0,
+		},
+		{
+			"base with list options",
+			func() *models.Collection {
+				c := &models.Collection{Type: models.CollectionTypeBase}
+				c.SetOptions(models.CollectionBaseOptions{ListDefaultSort: "-created", ListMaxPerPage: 10, ListMaxFilterComplexity: 5})
+				return c
+			},
+			"-created",
+			10,
+			5,
+		},
+		{
+			"auth with list options",
+			func() *models.Collection {
+				c := &models.Collection{Type: models.CollectionTypeAuth}
+				c.SetOptions(models.CollectionAuthOptions{ListDefaultSort: "username", ListMaxPerPage: 20, ListMaxFilterComplexity: 6})
+				return c
+			},
+			"username",
+			20,
+			6,
+		},
+		{
+			"view with list options",
+			func() *models.Collection {
+				c := &models.Collection{Type: models.CollectionTypeView}
+				c.SetOptions(models.CollectionViewOptions{Query: "select 1", ListDefaultSort: "id", ListMaxPerPage: 30, ListMaxFilterComplexity: 7})
+				return c
+			},
+			"id",
+			30,
+			7,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			c := s.collection()
+
+			if v := c.ListDefaultSort(); v != s.expectedSort {
+				t.Fatalf("Expected default sort %q, got %q", s.expectedSort, v)
+			}
+
+			if v := c.ListMaxPerPage(); v != s.expectedMaxPerPage {
+				t.Fatalf("Expected max perPage %d, got %d", s.expectedMaxPerPage, v)
+			}
+
+			if v := c.ListMaxFilterComplexity(); v != s.expectedMaxComplexity {
+				t.Fatalf("Expected max filter complexity %d, got %d", s.expectedMaxComplexity, v)
+			}
+		})
+	}
+}
+
+func TestCollectionRuleLogSampleRate(t *testing.T) {
+	scenarios := []struct {
+		collectionType string
+		options        any
+		expected       float64
+	}{
+		{models.CollectionTypeBase, models.CollectionBaseOptions{}, 0},
+		{models.CollectionTypeBase, models.CollectionBaseOptions{RuleLogSampleRate: 0.5}, 0.5},
+		{models.CollectionTypeAuth, models.CollectionAuthOptions{RuleLogSampleRate: 1}, 1},
+		{models.CollectionTypeView, models.CollectionViewOptions{Query: "select 1", RuleLogSampleRate: 0.1}, 0.1},
+	}
+
+	for i, s := range scenarios {
+		collection := &models.Collection{Type: s.collectionType}
+		collection.SetOptions(s.options)
+
+		if rate := collection.RuleLogSampleRate(); rate != s.expected {
+			t.Errorf("(%d) Expected %v, got %v", i, s.expected, rate)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package models
+
+import "github.com/pocketbase/pocketbase/tools/types"
+
+var _ Model = (*Comment)(nil)
+
+const (
+	CommentAuthorTypeAdmin      = "admin"
+	CommentAuthorTypeAuthRecord = "authRecord"
+)
+
+// Comment defines a single collection record comment (aka. annotation).
+type Comment struct {
+	BaseModel
+
+	CollectionId string `db:"collectionId" json:"collectionId"`
+	RecordId     string `db:"recordId" json:"recordId"`
+
+	// ParentId is the id of the replied comment (empty for the thread root comments).
+	ParentId string `db:"parentId" json:"parentId"`
+
+	// AuthorType is the type of the comment author
+	// (CommentAuthorTypeAdmin or CommentAuthorTypeAuthRecord).
+	AuthorType string `db:"authorType" json:"authorType"`
+
+	// AuthorCollectionId is the auth collection of the comment author
+	// (empty for admins).
+	AuthorCollectionId string `db:"authorCollectionId" json:"authorCollectionId"`
+	AuthorId           string `db:"authorId" json:"authorId"`
+
+	Message string `db:"message" json:"message"`
+
+	// Mentions is the list of the mentioned auth record ids
+	// (from the author's auth collection).
+	Mentions types.JsonArray[string] `db:"mentions" json:"mentions"`
+}
+
+func (m *Comment) TableName() string {
+	return "_comments"
+}
+
+// IsAuthoredBy checks whether the comment is created by the provided
+// admin or auth record (it is safe to pass nil for any of them).
+func (m *Comment) IsAuthoredBy(admin *Admin, authRecord *Record) bool {
+	switch m.AuthorType {
+	case CommentAuthorTypeAdmin:
+		return admin != nil && admin.Id == m.AuthorId
+	case CommentAuthorTypeAuthRecord:
+		return authRecord != nil &&
+			authRecord.Id == m.AuthorId &&
+			authRecord.Collection().Id == m.AuthorCollectionId
+	}
+
+	return false
+}
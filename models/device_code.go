@@ -0,0 +1,110 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+var _ Model = (*DeviceCode)(nil)
+
+const (
+	// DeviceCodeDuration is the lifetime of the issued device codes in seconds.
+	DeviceCodeDuration = 600
+
+	// DeviceCodePollInterval is the min allowed interval in seconds
+	// between two device code token exchange requests.
+	DeviceCodePollInterval = 5
+)
+
+// list with the supported device code statuses
+const (
+	DeviceCodeStatusPending  = "pending"
+	DeviceCodeStatusApproved = "approved"
+	DeviceCodeStatusDenied   = "denied"
+)
+
+// userCodeAlphabet is the alphabet of the generated user codes
+// (consonants only to avoid ambiguous characters and accidental words).
+const userCodeAlphabet = "BCDFGHJKLMNPQRSTVWXZ"
+
+// DeviceCode defines a single OAuth2 device authorization grant request.
+type DeviceCode struct {
+	BaseModel
+
+	CollectionId string `db:"collectionId" json:"collectionId"`
+
+	// RecordId is the id of the auth record that approved
+	// or denied the request (empty while pending).
+	RecordId string `db:"recordId" json:"recordId"`
+
+	// CodeHash is the SHA256 hash of the plain device code
+	// (the plain code is returned only once to the device).
+	CodeHash string `db:"codeHash" json:"-"`
+
+	// UserCode is the normalized short code that the user
+	// enters on the verification page (see NormalizeUserCode()).
+	UserCode string `db:"userCode" json:"userCode"`
+
+	Status string `db:"status" json:"status"`
+
+	// LastPolledAt is the date of the last token exchange request.
+	LastPolledAt types.DateTime `db:"lastPolledAt" json:"-"`
+}
+
+// TableName returns the DeviceCode model SQL table name.
+func (m *DeviceCode) TableName() string {
+	return "_deviceCodes"
+}
+
+// SetCode sets the hash of the provided plain device code.
+func (m *DeviceCode) SetCode(code string) {
+	m.CodeHash = security.SHA256(code)
+}
+
+// HasExpired checks whether the device code lifetime has passed.
+func (m *DeviceCode) HasExpired() bool {
+	return time.Since(m.Created.Time()) > DeviceCodeDuration*time.Second
+}
+
+// IsPollTooFrequent checks whether the last token exchange request
+// was made less than DeviceCodePollInterval ago.
+func (m *DeviceCode) IsPollTooFrequent() bool {
+	return !m.LastPolledAt.IsZero() && time.Since(m.LastPolledAt.Time()) < DeviceCodePollInterval*time.Second
+}
+
+// NewUserCode generates a new random normalized user code.
+func NewUserCode() string {
+	return security.RandomStringWithAlphabet(8, userCodeAlphabet)
+}
+
+// NormalizeUserCode uppercases the provided user code
+// and strips all of its non alphanumeric characters
+// (eg. "bcdf-ghjk" -> "BCDFGHJK").
+func NormalizeUserCode(code string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' {
+			return r - 'a' + 'A'
+		}
+
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+
+		return -1
+	}, code)
+}
+
+// FormatUserCode formats the provided normalized user code
+// for display by splitting it in two halves (eg. "BCDF-GHJK").
+func FormatUserCode(code string) string {
+	if len(code) < 2 {
+		return code
+	}
+
+	half := len(code) / 2
+
+	return code[:half] + "-" + code[half:]
+}
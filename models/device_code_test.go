@@ -0,0 +1,112 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestDeviceCodeTableName(t *testing.T) {
+	m := models.DeviceCode{}
+	if m.TableName() != "_deviceCodes" {
+		t.Fatalf("Unexpected table name, got %q", m.TableName())
+	}
+}
+
+func TestDeviceCodeSetCode(t *testing.T) {
+	m := models.DeviceCode{}
+	m.SetCode("test")
+
+	if m.CodeHash != security.SHA256("test") {
+		t.Fatalf("Unexpected code hash %q", m.CodeHash)
+	}
+}
+
+func TestDeviceCodeHasExpired(t *testing.T) {
+	now, _ := types.ParseDateTime(time.Now())
+	old, _ := types.ParseDateTime(time.Now().Add(-(models.DeviceCodeDuration + 10) * time.Second))
+
+	scenarios := []struct {
+		created  types.DateTime
+		expected bool
+	}{
+		{now, false},
+		{old, true},
+	}
+
+	for i, s := range scenarios {
+		m := models.DeviceCode{}
+		m.Created = s.created
+
+		if v := m.HasExpired(); v != s.expected {
+			t.Errorf("(%d) Expected %v, got %v", i, s.expected, v)
+		}
+	}
+}
+
+func TestDeviceCodeIsPollTooFrequent(t *testing.T) {
+	recent, _ := types.ParseDateTime(time.Now().Add(-time.Second))
+	old, _ := types.ParseDateTime(time.Now().Add(-(models.DeviceCodePollInterval + 1) * time.Second))
+
+	scenarios := []struct {
+		lastPolledAt types.DateTime
+		expected     bool
+	}{
+		{types.DateTime{}, false},
+		{recent, true},
+		{old, false},
+	}
+
+	for i, s := range scenarios {
+		m := models.DeviceCode{LastPolledAt: s.lastPolledAt}
+
+		if v := m.IsPollTooFrequent(); v != s.expected {
+			t.Errorf("(%d) Expected %v, got %v", i, s.expected, v)
+		}
+	}
+}
+
+func TestNewUserCode(t *testing.T) {
+	code1 := models.NewUserCode()
+	code2 := models.NewUserCode()
+
+	if len(code1) != 8 {
+		t.Fatalf("Expected user code with length 8, got %q", code1)
+	}
+
+	if code1 == code2 {
+		t.Fatalf("Expected different user codes, got %q", code1)
+	}
+
+	if models.NormalizeUserCode(code1) != code1 {
+		t.Fatalf("Expected the generated user code to be normalized, got %q", code1)
+	}
+}
+
+func TestNormalizeAndFormatUserCode(t *testing.T) {
+	scenarios := []struct {
+		code       string
+		normalized string
+		formatted  string
+	}{
+		{"", "", ""},
+		{"b", "B", "B"},
+		{"bcdf-ghjk", "BCDFGHJK", "BCDF-GHJK"},
+		{" Bc Df-GH jk ", "BCDFGHJK", "BCDF-GHJK"},
+	}
+
+	for i, s := range scenarios {
+		normalized := models.NormalizeUserCode(s.code)
+		if normalized != s.normalized {
+			t.Errorf("(%d) Expected normalized %q, got %q", i, s.normalized, normalized)
+		}
+
+		formatted := models.FormatUserCode(normalized)
+		if formatted != s.formatted {
+			t.Errorf("(%d) Expected formatted %q, got %q", i, s.formatted, formatted)
+		}
+	}
+}
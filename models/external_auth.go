@@ -1,5 +1,11 @@
 package models
 
+import (
+	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/pocketbase/pocketbase/tools/types"
+	"golang.org/x/oauth2"
+)
+
 var _ Model = (*ExternalAuth)(nil)
 
 type ExternalAuth struct {
@@ -9,8 +15,98 @@ type ExternalAuth struct {
 	RecordId     string `db:"recordId" json:"recordId"`
 	Provider     string `db:"provider" json:"provider"`
 	ProviderId   string `db:"providerId" json:"providerId"`
+
+	// AccessToken, RefreshToken and TokenExpiry are the stored linked
+	// OAuth2 provider tokens (see [ExternalAuth.SetToken()]).
+	AccessToken  string         `db:"accessToken" json:"-"`
+	RefreshToken string         `db:"refreshToken" json:"-"`
+	TokenExpiry  types.DateTime `db:"tokenExpiry" json:"-"`
 }
 
 func (m *ExternalAuth) TableName() string {
 	return "_externalAuths"
 }
+
+// HasToken checks whether the model has a stored OAuth2 provider token.
+func (m *ExternalAuth) HasToken() bool {
+	return m.AccessToken != "" || m.RefreshToken != ""
+}
+
+// SetToken stores the provided OAuth2 provider token into the model.
+//
+// The token values are encrypted if encryptionKey is not empty.
+// Pass nil token to clear the stored one.
+func (m *ExternalAuth) SetToken(token *oauth2.Token, encryptionKey string) error {
+	if token == nil {
+		m.AccessToken = ""
+		m.RefreshToken = ""
+		m.TokenExpiry = types.DateTime{}
+		return nil
+	}
+
+	accessToken, err := encryptExternalAuthToken(token.AccessToken, encryptionKey)
+	if err != nil {
+		return err
+	}
+
+	refreshToken, err := encryptExternalAuthToken(token.RefreshToken, encryptionKey)
+	if err != nil {
+		return err
+	}
+
+	m.AccessToken = accessToken
+	m.RefreshToken = refreshToken
+	m.TokenExpiry = types.DateTime{}
+	if !token.Expiry.IsZero() {
+		m.TokenExpiry, _ = types.ParseDateTime(token.Expiry)
+	}
+
+	return nil
+}
+
+// Token returns the stored OAuth2 provider token
+// (or nil if the model doesn't have one).
+//
+// The encryptionKey must be the same as the one used in [ExternalAuth.SetToken()].
+func (m *ExternalAuth) Token(encryptionKey string) (*oauth2.Token, error) {
+	if !m.HasToken() {
+		return nil, nil
+	}
+
+	accessToken, err := decryptExternalAuthToken(m.AccessToken, encryptionKey)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := decryptExternalAuthToken(m.RefreshToken, encryptionKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &oauth2.Token{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		Expiry:       m.TokenExpiry.Time(),
+	}, nil
+}
+
+func encryptExternalAuthToken(value string, encryptionKey string) (string, error) {
+	if value == "" || encryptionKey == "" {
+		return value, nil
+	}
+
+	return security.Encrypt([]byte(value), encryptionKey)
+}
+
+func decryptExternalAuthToken(value string, encryptionKey string) (string, error) {
+	if value == "" || encryptionKey == "" {
+		return value, nil
+	}
+
+	decrypted, err := security.Decrypt(value, encryptionKey)
+	if err != nil {
+		return "", err
+	}
+
+	return string(decrypted), nil
+}
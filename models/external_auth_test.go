@@ -2,8 +2,10 @@ package models_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/pocketbase/pocketbase/models"
+	"golang.org/x/oauth2"
 )
 
 func TestExternalAuthTableName(t *testing.T) {
@@ -12,3 +14,62 @@ func TestExternalAuthTableName(t *testing.T) {
 		t.Fatalf("Unexpected table name, got %q", m.TableName())
 	}
 }
+
+func TestExternalAuthToken(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		encryptionKey string
+	}{
+		{"without encryption", ""},
+		{"with encryption", "abcdabcdabcdabcdabcdabcdabcdabcd"},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			m := models.ExternalAuth{}
+
+			if m.HasToken() {
+				t.Fatal("Expected HasToken false for the zero model")
+			}
+
+			if token, err := m.Token(s.encryptionKey); err != nil || token != nil {
+				t.Fatalf("Expected nil token and error, got %v (%v)", token, err)
+			}
+
+			expiry := time.Now().Add(time.Hour).UTC().Truncate(time.Millisecond)
+
+			err := m.SetToken(&oauth2.Token{
+				AccessToken:  "test_access",
+				RefreshToken: "test_refresh",
+				Expiry:       expiry,
+			}, s.encryptionKey)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !m.HasToken() {
+				t.Fatal("Expected HasToken true")
+			}
+
+			isEncrypted := m.AccessToken != "test_access" && m.RefreshToken != "test_refresh"
+			if isEncrypted != (s.encryptionKey != "") {
+				t.Fatalf("Expected encrypted %v, got %v", s.encryptionKey != "", isEncrypted)
+			}
+
+			token, err := m.Token(s.encryptionKey)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if token.AccessToken != "test_access" || token.RefreshToken != "test_refresh" || !token.Expiry.Equal(expiry) {
+				t.Fatalf("Unexpected token %v", token)
+			}
+
+			// clear
+			m.SetToken(nil, s.encryptionKey)
+			if m.HasToken() || !m.TokenExpiry.IsZero() {
+				t.Fatalf("Expected the token to be cleared, got %v", m)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package models
+
+var _ Model = (*Follow)(nil)
+
+// Follow defines a single directed follow edge between an auth record
+// (the follower) and another collection record (the target).
+type Follow struct {
+	BaseModel
+
+	FollowerCollectionId string `db:"followerCollectionId" json:"followerCollectionId"`
+	FollowerId           string `db:"followerId" json:"followerId"`
+	TargetCollectionId   string `db:"targetCollectionId" json:"targetCollectionId"`
+	TargetId             string `db:"targetId" json:"targetId"`
+}
+
+func (m *Follow) TableName() string {
+	return "_follows"
+}
@@ -0,0 +1,98 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+var _ Model = (*LedgerEntry)(nil)
+
+// LedgerEntry defines a single hash chained ledger collection record entry.
+type LedgerEntry struct {
+	BaseModel
+
+	CollectionId string `db:"collectionId" json:"collectionId"`
+	RecordId     string `db:"recordId" json:"recordId"`
+
+	// Seq is the 1-based position of the record in the collection ledger.
+	Seq int `db:"seq" json:"seq"`
+
+	// PrevHash is the hash of the previous ledger entry
+	// (empty string for the first entry).
+	PrevHash string `db:"prevHash" json:"prevHash"`
+
+	// Hash is the SHA256 hash of the previous entry hash
+	// and the record data (see [LedgerHash()]).
+	Hash string `db:"hash" json:"hash"`
+}
+
+// TableName returns the LedgerEntry model SQL table name.
+func (m *LedgerEntry) TableName() string {
+	return "_ledgerEntries"
+}
+
+// LedgerHash returns the hex encoded SHA256 hash of the
+// provided previous entry hash and the record data.
+//
+// The record data is serialized as canonical JSON object with the
+// record id, created date and its non-empty schema fields values
+// (the empty values are skipped so that adding new schema fields
+// doesn't change the hash of the existing records).
+func LedgerHash(prevHash string, record *Record) (string, error) {
+	data := map[string]any{
+		"collectionId": record.Collection().Id,
+		"id":           record.Id,
+		"created":      record.Created.String(),
+	}
+
+	for _, field := range record.Collection().Schema.Fields() {
+		raw, err := json.Marshal(record.Get(field.Name))
+		if err != nil {
+			return "", err
+		}
+
+		// normalize the raw json value (eg. whitespaces, keys order)
+		var value any
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return "", err
+		}
+
+		if isEmptyLedgerValue(value) {
+			continue
+		}
+
+		data[field.Name] = value
+	}
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	h.Write([]byte(prevHash))
+	h.Write([]byte{'\n'})
+	h.Write(payload)
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func isEmptyLedgerValue(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case float64:
+		return v == 0
+	case bool:
+		return !v
+	case []any:
+		return len(v) == 0
+	case map[string]any:
+		return len(v) == 0
+	}
+
+	return false
+}
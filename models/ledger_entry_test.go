@@ -0,0 +1,69 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+)
+
+func TestLedgerEntryTableName(t *testing.T) {
+	m := models.LedgerEntry{}
+	if m.TableName() != "_ledgerEntries" {
+		t.Fatalf("Unexpected table name, got %q", m.TableName())
+	}
+}
+
+func TestLedgerHash(t *testing.T) {
+	collection := &models.Collection{
+		Name: "test",
+		Schema: schema.NewSchema(
+			&schema.SchemaField{Name: "amount", Type: schema.FieldTypeNumber},
+			&schema.SchemaField{Name: "note", Type: schema.FieldTypeText},
+			&schema.SchemaField{Name: "meta", Type: schema.FieldTypeJson},
+		),
+	}
+	collection.Id = "test_id"
+
+	record := models.NewRecord(collection)
+	record.Id = "record_id"
+	record.Set("amount", 10)
+	record.Set("meta", `{"b": 2, "a": 1}`)
+
+	hash1, err := models.LedgerHash("", record)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(hash1) != 64 {
+		t.Fatalf("Expected 64 characters hex hash, got %q", hash1)
+	}
+
+	// same data -> same hash
+	if hash, _ := models.LedgerHash("", record); hash != hash1 {
+		t.Fatalf("Expected the same hash %q, got %q", hash1, hash)
+	}
+
+	// different previous hash
+	if hash, _ := models.LedgerHash("abc", record); hash == hash1 {
+		t.Fatal("Expected different hash for different previous hash")
+	}
+
+	// json value formatting and keys order
+	record.Set("meta", `{"a":1,"b":2}`)
+	if hash, _ := models.LedgerHash("", record); hash != hash1 {
+		t.Fatalf("Expected the same hash for the normalized json value, got %q", hash)
+	}
+
+	// new schema field with empty value
+	collection.Schema.AddField(&schema.SchemaField{Name: "extra", Type: schema.FieldTypeBool})
+	if hash, _ := models.LedgerHash("", record); hash != hash1 {
+		t.Fatalf("Expected the same hash after adding an empty field, got %q", hash)
+	}
+
+	// changed data
+	record.Set("amount", 11)
+	if hash, _ := models.LedgerHash("", record); hash == hash1 {
+		t.Fatal("Expected different hash for changed record data")
+	}
+}
@@ -0,0 +1,38 @@
+package models
+
+import "github.com/pocketbase/pocketbase/tools/types"
+
+var _ Model = (*MfaFactor)(nil)
+
+const (
+	// MfaFactorTypeTOTP is the time-based one-time password (RFC 6238) factor type.
+	MfaFactorTypeTOTP = "totp"
+)
+
+// MfaFactor defines a single auth record multi-factor authentication factor.
+type MfaFactor struct {
+	BaseModel
+
+	CollectionId string `db:"collectionId" json:"collectionId"`
+	RecordId     string `db:"recordId" json:"recordId"`
+	Type         string `db:"type" json:"type"`
+
+	// Enabled indicates whether the factor enrollment was confirmed
+	// (aka. whether the factor is required on authentication).
+	Enabled bool `db:"enabled" json:"enabled"`
+
+	// Secret is the factor shared secret (eg. the base32 encoded TOTP key).
+	Secret string `db:"secret" json:"-"`
+
+	// RecoveryCodes is the list with the sha256 hashes
+	// of the still unused factor recovery codes.
+	RecoveryCodes types.JsonArray[string] `db:"recoveryCodes" json:"-"`
+
+	// LastUsedStep is the time step counter of the last accepted
+	// TOTP code (used to prevent the same code reuse).
+	LastUsedStep int64 `db:"lastUsedStep" json:"-"`
+}
+
+func (m *MfaFactor) TableName() string {
+	return "_mfaFactors"
+}
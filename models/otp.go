@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+var _ Model = (*Otp)(nil)
+
+// OtpMaxAttempts is the max number of failed password checks
+// after which the one-time password is no longer accepted.
+const OtpMaxAttempts = 5
+
+// Otp defines a single auth record email one-time password.
+type Otp struct {
+	BaseModel
+
+	CollectionId string `db:"collectionId" json:"collectionId"`
+	RecordId     string `db:"recordId" json:"recordId"`
+	PasswordHash string `db:"passwordHash" json:"-"`
+
+	// Attempts is the number of the failed password checks.
+	Attempts int `db:"attempts" json:"-"`
+}
+
+func (m *Otp) TableName() string {
+	return "_otps"
+}
+
+// SetPassword sets the hash of the provided plain one-time password.
+func (m *Otp) SetPassword(password string) error {
+	// hash the password with a lower cost since the otp is short lived
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	if err != nil {
+		return err
+	}
+
+	m.PasswordHash = string(hashedPassword)
+
+	return nil
+}
+
+// ValidatePassword validates a plain password against the otp password hash.
+func (m *Otp) ValidatePassword(password string) bool {
+	err := bcrypt.CompareHashAndPassword([]byte(m.PasswordHash), []byte(password))
+
+	return err == nil
+}
+
+// HasExpired checks whether the otp was created more than maxElapsed time ago
+// or it has reached the max allowed failed attempts.
+func (m *Otp) HasExpired(maxElapsed time.Duration) bool {
+	return m.Attempts >= OtpMaxAttempts || time.Since(m.Created.Time()) > maxElapsed
+}
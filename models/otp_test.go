@@ -0,0 +1,66 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestOtpTableName(t *testing.T) {
+	m := models.Otp{}
+	if m.TableName() != "_otps" {
+		t.Fatalf("Unexpected table name, got %q", m.TableName())
+	}
+}
+
+func TestOtpSetAndValidatePassword(t *testing.T) {
+	m := models.Otp{}
+
+	if m.ValidatePassword("") {
+		t.Fatal("Expected empty password hash to be invalid")
+	}
+
+	if err := m.SetPassword("123456"); err != nil {
+		t.Fatal(err)
+	}
+
+	if m.PasswordHash == "" || m.PasswordHash == "123456" {
+		t.Fatalf("Expected the password to be hashed, got %q", m.PasswordHash)
+	}
+
+	if m.ValidatePassword("654321") {
+		t.Fatal("Expected 654321 to be invalid password")
+	}
+
+	if !m.ValidatePassword("123456") {
+		t.Fatal("Expected 123456 to be valid password")
+	}
+}
+
+func TestOtpHasExpired(t *testing.T) {
+	now, _ := types.ParseDateTime(time.Now())
+	old, _ := types.ParseDateTime(time.Now().Add(-5 * time.Minute))
+
+	scenarios := []struct {
+		created  types.DateTime
+		attempts int
+		expected bool
+	}{
+		{now, 0, false},
+		{now, models.OtpMaxAttempts - 1, false},
+		{now, models.OtpMaxAttempts, true},
+		{old, 0, true},
+	}
+
+	for i, s := range scenarios {
+		m := models.Otp{Attempts: s.attempts}
+		m.Created = s.created
+
+		result := m.HasExpired(3 * time.Minute)
+		if result != s.expected {
+			t.Errorf("(%d) Expected %v, got %v", i, s.expected, result)
+		}
+	}
+}
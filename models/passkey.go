@@ -0,0 +1,36 @@
+package models
+
+import "github.com/pocketbase/pocketbase/tools/types"
+
+var _ Model = (*Passkey)(nil)
+
+// Passkey defines a single auth record WebAuthn credential (aka. passkey).
+type Passkey struct {
+	BaseModel
+
+	CollectionId string `db:"collectionId" json:"collectionId"`
+	RecordId     string `db:"recordId" json:"recordId"`
+
+	// Name is an optional user friendly label of the passkey (eg. "My phone").
+	Name string `db:"name" json:"name"`
+
+	// CredentialId is the base64url encoded WebAuthn credential id.
+	CredentialId string `db:"credentialId" json:"credentialId"`
+
+	// PublicKey is the base64url encoded COSE credential public key.
+	PublicKey string `db:"publicKey" json:"-"`
+
+	// SignCount is the last known authenticator signature counter
+	// (used to detect cloned authenticators).
+	SignCount int64 `db:"signCount" json:"-"`
+
+	// AAGUID is the hex encoded authenticator model identifier.
+	AAGUID string `db:"aaguid" json:"aaguid"`
+
+	// LastUsed is the date of the last successful passkey authentication.
+	LastUsed types.DateTime `db:"lastUsed" json:"lastUsed"`
+}
+
+func (m *Passkey) TableName() string {
+	return "_passkeys"
+}
@@ -0,0 +1,23 @@
+package models
+
+var _ Model = (*Reaction)(nil)
+
+// Reaction defines a single auth record reaction (eg. "like")
+// to a collection record.
+type Reaction struct {
+	BaseModel
+
+	CollectionId string `db:"collectionId" json:"collectionId"`
+	RecordId     string `db:"recordId" json:"recordId"`
+
+	// UserCollectionId and UserId identify the reacting auth record.
+	UserCollectionId string `db:"userCollectionId" json:"userCollectionId"`
+	UserId           string `db:"userId" json:"userId"`
+
+	// Type is the reaction type (eg. "like", "heart").
+	Type string `db:"type" json:"type"`
+}
+
+func (m *Reaction) TableName() string {
+	return "_reactions"
+}
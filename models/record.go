@@ -29,8 +29,9 @@ type Record struct {
 
 	collection *Collection
 
-	exportUnknown         bool // whether to export unknown fields
-	ignoreEmailVisibility bool // whether to ignore the emailVisibility flag for auth collections
+	exportUnknown         bool     // whether to export unknown fields
+	visibleFields         []string // restricts the exported schema and auth fields (nil means no restriction)
+	ignoreEmailVisibility bool     // whether to ignore the emailVisibility flag for auth collections
 	loaded                bool
 	originalData          map[string]any    // the original (aka. first loaded) model data
 	expand                *store.Store[any] // expanded relations
@@ -115,6 +116,15 @@ func (m *Record) Collection() *Collection {
 	return m.collection
 }
 
+// IsSoftDeleted checks whether the record is marked as deleted
+// (aka. the record collection has enabled soft delete and the
+// soft delete field of the record is set).
+func (m *Record) IsSoftDeleted() bool {
+	field := m.collection.SoftDeleteField()
+
+	return field != "" && !m.GetDateTime(field).IsZero()
+}
+
 // OriginalCopy returns a copy of the current record model populated
 // with its ORIGINAL data state (aka. the initially loaded) and
 // everything else reset to the defaults.
@@ -285,6 +295,26 @@ func (m *Record) WithUnknownData(state bool) {
 	m.exportUnknown = state
 }
 
+// SetVisibleFields restricts the schema, auth and unknown data fields
+// exported by PublicExport to the provided list (pass nil to reset).
+//
+// The id, created, updated, collectionId, collectionName and expand
+// fields are always exported.
+func (m *Record) SetVisibleFields(fields []string) {
+	m.visibleFields = fields
+}
+
+// VisibleFields returns the exported fields restriction
+// set with SetVisibleFields (nil means no restriction).
+func (m *Record) VisibleFields() []string {
+	return m.visibleFields
+}
+
+// isVisibleField checks whether the specified field is allowed to be exported.
+func (m *Record) isVisibleField(name string) bool {
+	return m.visibleFields == nil || list.ExistInSlice(name, m.visibleFields)
+}
+
 // Set sets the provided key-value data pair for the current Record model.
 //
 // If the record collection has field with name matching the provided "key",
@@ -457,7 +487,16 @@ func (m *Record) UnmarshalJSONField(key string, result any) error {
 }
 
 // BaseFilesPath returns the storage dir path used by the record.
+//
+// The files of the records with a tenant are stored
+// under their collection tenant files path.
 func (m *Record) BaseFilesPath() string {
+	if tenantField := m.Collection().TenantField(); tenantField != "" {
+		if tenantId := m.GetString(tenantField); tenantId != "" {
+			return fmt.Sprintf("%s/%s", m.Collection().TenantFilesPath(tenantId), m.Id)
+		}
+	}
+
 	return fmt.Sprintf("%s/%s", m.Collection().BaseFilesPath(), m.Id)
 }
 
@@ -465,7 +504,7 @@ func (m *Record) BaseFilesPath() string {
 // any of the record's data contains the provided filename.
 func (m *Record) FindFileFieldByFile(filename string) *schema.SchemaField {
 	for _, field := range m.Collection().Schema.Fields() {
-		if field.Type == schema.FieldTypeFile {
+		if field.IsFile() {
 			names := m.GetStringSlice(field.Name)
 			if list.ExistInSlice(filename, names) {
 				return field
@@ -521,21 +560,31 @@ func (m *Record) PublicExport() map[string]any {
 	// export unknown data fields if allowed
 	if m.exportUnknown {
 		for k, v := range m.UnknownData() {
-			result[k] = v
+			if m.isVisibleField(k) {
+				result[k] = v
+			}
 		}
 	}
 
 	// export schema field values
 	for _, field := range m.collection.Schema.Fields() {
-		result[field.Name] = m.Get(field.Name)
+		if m.isVisibleField(field.Name) {
+			result[field.Name] = m.Get(field.Name)
+		}
 	}
 
 	// export some of the safe auth collection fields
 	if m.collection.IsAuth() {
-		result[schema.FieldNameVerified] = m.Verified()
-		result[schema.FieldNameUsername] = m.Username()
-		result[schema.FieldNameEmailVisibility] = m.EmailVisibility()
-		if m.ignoreEmailVisibility || m.EmailVisibility() {
+		if m.isVisibleField(schema.FieldNameVerified) {
+			result[schema.FieldNameVerified] = m.Verified()
+		}
+		if m.isVisibleField(schema.FieldNameUsername) {
+			result[schema.FieldNameUsername] = m.Username()
+		}
+		if m.isVisibleField(schema.FieldNameEmailVisibility) {
+			result[schema.FieldNameEmailVisibility] = m.EmailVisibility()
+		}
+		if (m.ignoreEmailVisibility || m.EmailVisibility()) && m.isVisibleField(schema.FieldNameEmail) {
 			result[schema.FieldNameEmail] = m.Email()
 		}
 	}
@@ -628,7 +677,7 @@ func (m *Record) ReplaceModifers(data map[string]any) map[string]any {
 			}
 		}
 
-		if field.Type != schema.FieldTypeFile {
+		if !field.IsFile() {
 			continue
 		}
 
@@ -0,0 +1,23 @@
+package models
+
+import "github.com/pocketbase/pocketbase/tools/types"
+
+var _ Model = (*RecordScheduleRun)(nil)
+
+// RecordScheduleRun defines a single executed collection schedule
+// for a record and its trigger time.
+//
+// It is used to ensure that each schedule is executed only
+// once per record trigger time value.
+type RecordScheduleRun struct {
+	BaseModel
+
+	CollectionId string         `db:"collectionId" json:"collectionId"`
+	RecordId     string         `db:"recordId" json:"recordId"`
+	Schedule     string         `db:"schedule" json:"schedule"`
+	ScheduledAt  types.DateTime `db:"scheduledAt" json:"scheduledAt"`
+}
+
+func (m *RecordScheduleRun) TableName() string {
+	return "_recordScheduleRuns"
+}
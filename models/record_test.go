@@ -332,6 +332,35 @@ func TestRecordCollection(t *testing.T) {
 	}
 }
 
+func TestRecordIsSoftDeleted(t *testing.T) {
+	collection := &models.Collection{
+		Type: models.CollectionTypeBase,
+		Schema: schema.NewSchema(
+			&schema.SchemaField{Name: "deleted", Type: schema.FieldTypeDate},
+		),
+	}
+
+	m := models.NewRecord(collection)
+	m.Set("deleted", "2023-01-01 00:00:00.000Z")
+
+	// disabled soft delete
+	if m.IsSoftDeleted() {
+		t.Fatal("Expected false with disabled soft delete")
+	}
+
+	collection.SetOptions(models.CollectionBaseOptions{SoftDeleteField: "deleted"})
+
+	if !m.IsSoftDeleted() {
+		t.Fatal("Expected true with set soft delete field")
+	}
+
+	m.Set("deleted", "")
+
+	if m.IsSoftDeleted() {
+		t.Fatal("Expected false with empty soft delete field")
+	}
+}
+
 func TestRecordOriginalCopy(t *testing.T) {
 	m := models.NewRecord(&models.Collection{})
 	m.Load(map[string]any{"f": "123"})
@@ -1099,6 +1128,23 @@ func TestRecordBaseFilesPath(t *testing.T) {
 	if result != expected {
 		t.Fatalf("Expected %q, got %q", expected, result)
 	}
+
+	// tenant aware collection
+	collection.Type = models.CollectionTypeBase
+	collection.Schema.AddField(&schema.SchemaField{Name: "tenant", Type: schema.FieldTypeText})
+	collection.SetOptions(models.CollectionBaseOptions{TenantField: "tenant"})
+
+	// without tenant
+	if result := m.BaseFilesPath(); result != expected {
+		t.Fatalf("Expected %q, got %q", expected, result)
+	}
+
+	m.Set("tenant", "t1")
+
+	expected = collection.TenantFilesPath("t1") + "/" + m.Id
+	if result := m.BaseFilesPath(); result != expected {
+		t.Fatalf("Expected %q, got %q", expected, result)
+	}
 }
 
 func TestRecordFindFileFieldByFile(t *testing.T) {
@@ -1462,6 +1508,64 @@ func TestRecordPublicExportAndMarshalJSON(t *testing.T) {
 	}
 }
 
+func TestRecordSetVisibleFields(t *testing.T) {
+	collection := &models.Collection{
+		Name: "c_name",
+		Type: models.CollectionTypeAuth,
+		Schema: schema.NewSchema(
+			&schema.SchemaField{Name: "field1", Type: schema.FieldTypeText},
+			&schema.SchemaField{Name: "field2", Type: schema.FieldTypeText},
+		),
+	}
+	collection.Id = "c_id"
+
+	scenarios := []struct {
+		fields       []string
+		expectedJson string
+	}{
+		{
+			nil,
+			`{"collectionId":"c_id","collectionName":"c_name","created":"","email":"test_email","emailVisibility":true,"field1":"a","field2":"b","id":"test_id","updated":"","username":"test_username","verified":false}`,
+		},
+		{
+			[]string{},
+			`{"collectionId":"c_id","collectionName":"c_name","created":"","id":"test_id","updated":""}`,
+		},
+		{
+			[]string{"field2", "email", "missing"},
+			`{"collectionId":"c_id","collectionName":"c_name","created":"","email":"test_email","field2":"b","id":"test_id","updated":""}`,
+		},
+	}
+
+	m := models.NewRecord(collection)
+	m.Load(map[string]any{
+		"id":              "test_id",
+		"field1":          "a",
+		"field2":          "b",
+		"email":           "test_email",
+		"emailVisibility": true,
+		"username":        "test_username",
+	})
+
+	for i, s := range scenarios {
+		m.SetVisibleFields(s.fields)
+
+		if v := m.VisibleFields(); len(v) != len(s.fields) || (v == nil) != (s.fields == nil) {
+			t.Errorf("(%d) Expected visible fields %v, got %v", i, s.fields, v)
+		}
+
+		raw, err := m.MarshalJSON()
+		if err != nil {
+			t.Errorf("(%d) Unexpected error %v", i, err)
+			continue
+		}
+
+		if str := string(raw); str != s.expectedJson {
+			t.Errorf("(%d) Expected json \n%v \ngot \n%v", i, s.expectedJson, str)
+		}
+	}
+}
+
 func TestRecordUnmarshalJSON(t *testing.T) {
 	collection := &models.Collection{
 		Schema: schema.NewSchema(
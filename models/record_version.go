@@ -0,0 +1,30 @@
+package models
+
+import "github.com/pocketbase/pocketbase/tools/types"
+
+var _ Model = (*RecordVersion)(nil)
+
+const (
+	RecordVersionActionUpdate = "update"
+	RecordVersionActionDelete = "delete"
+)
+
+// RecordVersion defines a single stored collection record snapshot
+// (aka. the record state before an update or delete).
+type RecordVersion struct {
+	BaseModel
+
+	CollectionId string `db:"collectionId" json:"collectionId"`
+	RecordId     string `db:"recordId" json:"recordId"`
+
+	// Action is the record operation that created the version
+	// (RecordVersionActionUpdate or RecordVersionActionDelete).
+	Action string `db:"action" json:"action"`
+
+	// Data is the record data snapshot.
+	Data types.JsonMap `db:"data" json:"data"`
+}
+
+func (m *RecordVersion) TableName() string {
+	return "_history"
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+var _ Model = (*RefreshToken)(nil)
+
+// RefreshToken defines a single server-side stored auth record refresh token.
+type RefreshToken struct {
+	BaseModel
+
+	CollectionId string `db:"collectionId" json:"collectionId"`
+	RecordId     string `db:"recordId" json:"recordId"`
+
+	// TokenHash is the SHA256 hash of the plain refresh token
+	// (the plain token is returned only once to the client).
+	TokenHash string `db:"tokenHash" json:"-"`
+
+	// RecordKeyHash is the SHA256 hash of the auth record token key at
+	// the time of the token creation (used to invalidate the refresh
+	// token when the record token key changes, eg. on password change).
+	RecordKeyHash string `db:"recordKeyHash" json:"-"`
+
+	// RotatedAt is the date when the refresh token was exchanged
+	// for a new one (empty while the token is still active).
+	RotatedAt types.DateTime `db:"rotatedAt" json:"rotatedAt"`
+}
+
+// TableName returns the RefreshToken model SQL table name.
+func (m *RefreshToken) TableName() string {
+	return "_refreshTokens"
+}
+
+// SetToken sets the hash of the provided plain refresh token.
+func (m *RefreshToken) SetToken(token string) {
+	m.TokenHash = security.SHA256(token)
+}
+
+// SetRecordKey sets the hash of the provided auth record token key.
+func (m *RefreshToken) SetRecordKey(tokenKey string) {
+	m.RecordKeyHash = security.SHA256(tokenKey)
+}
+
+// ValidateRecordKey checks whether the provided auth record
+// token key is the same as the one at the token creation.
+func (m *RefreshToken) ValidateRecordKey(tokenKey string) bool {
+	return m.RecordKeyHash == security.SHA256(tokenKey)
+}
+
+// IsRotated checks whether the refresh token was already exchanged.
+func (m *RefreshToken) IsRotated() bool {
+	return !m.RotatedAt.IsZero()
+}
+
+// HasExpired checks whether the refresh token was created more than maxElapsed time ago.
+func (m *RefreshToken) HasExpired(maxElapsed time.Duration) bool {
+	return time.Since(m.Created.Time()) > maxElapsed
+}
@@ -0,0 +1,83 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestRefreshTokenTableName(t *testing.T) {
+	m := models.RefreshToken{}
+	if m.TableName() != "_refreshTokens" {
+		t.Fatalf("Unexpected table name, got %q", m.TableName())
+	}
+}
+
+func TestRefreshTokenSetToken(t *testing.T) {
+	m := models.RefreshToken{}
+	m.SetToken("test")
+
+	if m.TokenHash != security.SHA256("test") {
+		t.Fatalf("Unexpected token hash %q", m.TokenHash)
+	}
+}
+
+func TestRefreshTokenValidateRecordKey(t *testing.T) {
+	m := models.RefreshToken{}
+	m.SetRecordKey("test")
+
+	scenarios := []struct {
+		key      string
+		expected bool
+	}{
+		{"", false},
+		{"test2", false},
+		{m.RecordKeyHash, false},
+		{"test", true},
+	}
+
+	for i, s := range scenarios {
+		if v := m.ValidateRecordKey(s.key); v != s.expected {
+			t.Errorf("(%d) Expected %v, got %v", i, s.expected, v)
+		}
+	}
+}
+
+func TestRefreshTokenIsRotated(t *testing.T) {
+	m := models.RefreshToken{}
+
+	if m.IsRotated() {
+		t.Fatal("Expected the token to not be rotated")
+	}
+
+	m.RotatedAt = types.NowDateTime()
+
+	if !m.IsRotated() {
+		t.Fatal("Expected the token to be rotated")
+	}
+}
+
+func TestRefreshTokenHasExpired(t *testing.T) {
+	now, _ := types.ParseDateTime(time.Now())
+	old, _ := types.ParseDateTime(time.Now().Add(-2 * time.Hour))
+
+	scenarios := []struct {
+		created  types.DateTime
+		expected bool
+	}{
+		{now, false},
+		{old, true},
+	}
+
+	for i, s := range scenarios {
+		m := models.RefreshToken{}
+		m.Created = s.created
+
+		if v := m.HasExpired(time.Hour); v != s.expected {
+			t.Errorf("(%d) Expected %v, got %v", i, s.expected, v)
+		}
+	}
+}
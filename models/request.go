@@ -9,6 +9,7 @@ const (
 	RequestAuthGuest  = "guest"
 	RequestAuthAdmin  = "admin"
 	RequestAuthRecord = "authRecord"
+	RequestAuthApiKey = "apiKey"
 )
 
 type Request struct {
@@ -15,6 +15,8 @@ type RequestInfo struct {
 	AuthRecord *Record        `json:"authRecord"`
 	Admin      *Admin         `json:"admin"`
 	Method     string         `json:"method"`
+	Ip         string         `json:"ip"`
+	Geo        map[string]any `json:"geo"`
 }
 
 // HasModifierDataKeys loosely checks if the current struct has any modifier Data keys.
@@ -0,0 +1,33 @@
+package models
+
+var (
+	_ Model = (*Role)(nil)
+	_ Model = (*RecordRole)(nil)
+)
+
+// Role defines a reusable named permission set that could be
+// assigned to auth records and checked in the collection API rules
+// with the "@request.auth.roles" accessor (eg. `@request.auth.roles ?= "editor"`).
+type Role struct {
+	BaseModel
+
+	Name        string `db:"name" json:"name"`
+	Description string `db:"description" json:"description"`
+}
+
+func (m *Role) TableName() string {
+	return "_roles"
+}
+
+// RecordRole defines a single role assignment to an auth record.
+type RecordRole struct {
+	BaseModel
+
+	RoleId       string `db:"roleId" json:"roleId"`
+	CollectionId string `db:"collectionId" json:"collectionId"`
+	RecordId     string `db:"recordId" json:"recordId"`
+}
+
+func (m *RecordRole) TableName() string {
+	return "_recordRoles"
+}
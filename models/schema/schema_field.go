@@ -3,19 +3,27 @@ package schema
 import (
 	"encoding/json"
 	"errors"
+	"math"
 	"regexp"
 	"strconv"
+	"strings"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/go-ozzo/ozzo-validation/v4/is"
 	"github.com/pocketbase/pocketbase/tools/filesystem"
 	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/markdown"
+	"github.com/pocketbase/pocketbase/tools/sanitizer"
 	"github.com/pocketbase/pocketbase/tools/types"
 	"github.com/spf13/cast"
 )
 
 var schemaFieldNameRegex = regexp.MustCompile(`^\w+$`)
 
+var editorTagRegex = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+var editorAttributeRegex = regexp.MustCompile(`^([a-z][a-z0-9-]*:)?[a-z][a-z0-9-]*$`)
+
 // field value modifiers
 const (
 	FieldValueModifierAdd      string = "+"
@@ -93,6 +101,14 @@ const (
 	FieldTypeJson     string = "json"
 	FieldTypeFile     string = "file"
 	FieldTypeRelation string = "relation"
+	FieldTypeAvatar   string = "avatar"
+	FieldTypeMarkdown string = "markdown"
+	FieldTypeColor    string = "color"
+	FieldTypeCurrency string = "currency"
+	FieldTypeDuration string = "duration"
+	FieldTypePosition string = "position"
+	FieldTypePhone    string = "phone"
+	FieldTypeList     string = "list"
 
 	// Deprecated: Will be removed in v0.9+
 	FieldTypeUser string = "user"
@@ -112,6 +128,14 @@ func FieldTypes() []string {
 		FieldTypeJson,
 		FieldTypeFile,
 		FieldTypeRelation,
+		FieldTypeAvatar,
+		FieldTypeMarkdown,
+		FieldTypeColor,
+		FieldTypeCurrency,
+		FieldTypeDuration,
+		FieldTypePosition,
+		FieldTypePhone,
+		FieldTypeList,
 	}
 }
 
@@ -121,6 +145,7 @@ func ArraybleFieldTypes() []string {
 		FieldTypeSelect,
 		FieldTypeFile,
 		FieldTypeRelation,
+		FieldTypeList,
 	}
 }
 
@@ -141,17 +166,58 @@ type SchemaField struct {
 	Unique bool `form:"unique" json:"unique"`
 
 	Options any `form:"options" json:"options"`
+
+	// RequiredIf is an optional filter expression that makes the field
+	// required only when it is satisfied by the submitted record data
+	// (eg. "type = 'business'").
+	RequiredIf string `form:"requiredIf" json:"requiredIf,omitempty"`
+
+	// ValidationRule is an optional filter expression evaluated against
+	// the submitted record data on create and update (eg. "end > start").
+	//
+	// The record value fails validation if the expression doesn't match.
+	ValidationRule string `form:"validationRule" json:"validationRule,omitempty"`
+
+	// ValidationMessage is an optional custom error message
+	// returned when the ValidationRule is not satisfied.
+	ValidationMessage string `form:"validationMessage" json:"validationMessage,omitempty"`
+}
+
+// IsFile checks whether the field stores uploaded files (aka. file and avatar fields).
+func (f *SchemaField) IsFile() bool {
+	return f.Type == FieldTypeFile || f.Type == FieldTypeAvatar
+}
+
+// FileOptions returns the file options of a file or avatar field
+// (for the avatar fields they are derived from the [AvatarOptions]).
+//
+// Returns nil for all other field types.
+func (f *SchemaField) FileOptions() *FileOptions {
+	switch options := f.Options.(type) {
+	case *FileOptions:
+		return options
+	case *AvatarOptions:
+		return options.FileOptions()
+	}
+
+	if f.IsFile() {
+		return &FileOptions{} // not initialized
+	}
+
+	return nil
 }
 
 // ColDefinition returns the field db column type definition as string.
 func (f *SchemaField) ColDefinition() string {
 	switch f.Type {
-	case FieldTypeNumber:
+	case FieldTypeNumber, FieldTypePosition:
 		return "NUMERIC DEFAULT 0 NOT NULL"
 	case FieldTypeBool:
 		return "BOOLEAN DEFAULT FALSE NOT NULL"
-	case FieldTypeJson:
+	case FieldTypeJson, FieldTypeMarkdown:
 		return "JSON DEFAULT NULL"
+	case FieldTypeCurrency, FieldTypeDuration:
+		return "INTEGER DEFAULT 0 NOT NULL"
 	default:
 		if opt, ok := f.Options.(MultiValuer); ok && opt.IsMultiple() {
 			return "JSON DEFAULT '[]' NOT NULL"
@@ -215,7 +281,8 @@ func (f SchemaField) Validate() error {
 		validation.Field(&f.Type, validation.Required, validation.In(list.ToInterfaceSlice(FieldTypes())...)),
 		// currently file fields cannot be unique because a proper
 		// hash/content check could cause performance issues
-		validation.Field(&f.Unique, validation.When(f.Type == FieldTypeFile, validation.Empty)),
+		validation.Field(&f.Unique, validation.When(f.IsFile(), validation.Empty)),
+		validation.Field(&f.ValidationMessage, validation.Length(0, 255)),
 	)
 }
 
@@ -265,6 +332,22 @@ func (f *SchemaField) InitOptions() error {
 		options = &FileOptions{}
 	case FieldTypeRelation:
 		options = &RelationOptions{}
+	case FieldTypeAvatar:
+		options = &AvatarOptions{}
+	case FieldTypeMarkdown:
+		options = &MarkdownOptions{}
+	case FieldTypeColor:
+		options = &ColorOptions{}
+	case FieldTypeCurrency:
+		options = &CurrencyOptions{}
+	case FieldTypeDuration:
+		options = &DurationOptions{}
+	case FieldTypePosition:
+		options = &PositionOptions{}
+	case FieldTypePhone:
+		options = &PhoneOptions{}
+	case FieldTypeList:
+		options = &ListOptions{}
 
 	// Deprecated: Will be removed in v0.9+
 	case FieldTypeUser:
@@ -323,7 +406,76 @@ func (f *SchemaField) PrepareValue(value any) any {
 
 		val, _ = types.ParseJsonRaw(val)
 		return val
-	case FieldTypeNumber:
+	case FieldTypeMarkdown:
+		data := types.JsonMap{}
+
+		switch v := value.(type) {
+		case types.JsonMap:
+			data = v
+		case map[string]any:
+			data = v
+		default:
+			str := cast.ToString(v)
+
+			// stored {"source":"...", "html":"..."} value
+			if strings.HasPrefix(str, "{") && json.Unmarshal([]byte(str), &data) == nil && data["source"] != nil {
+				break
+			}
+
+			data = types.JsonMap{"source": str}
+		}
+
+		source := cast.ToString(data["source"])
+		if source == "" {
+			return types.JsonMap{}
+		}
+
+		rendered := cast.ToString(data["html"])
+		if rendered == "" {
+			options, _ := f.Options.(*MarkdownOptions)
+			rendered = options.Render(source)
+		}
+
+		return types.JsonMap{"source": source, "html": rendered}
+	case FieldTypeColor:
+		str := cast.ToString(value)
+
+		// normalize valid colors (invalid ones are left as they are for the validators)
+		if color, err := types.ParseColor(str); err == nil {
+			return color
+		}
+
+		return str
+	case FieldTypeCurrency:
+		// integer amount in the currency minor units (eg. cents)
+		return int64(math.Round(cast.ToFloat64(value)))
+	case FieldTypeDuration:
+		val, err := types.ParseDuration(value)
+		if err != nil {
+			return cast.ToString(value) // left as it is for the validators
+		}
+
+		return val
+	case FieldTypePhone:
+		var defaultRegion string
+		if options, _ := f.Options.(*PhoneOptions); options != nil {
+			defaultRegion = options.DefaultRegion
+		}
+
+		val, err := types.ParsePhone(value, defaultRegion)
+		if err != nil {
+			return cast.ToString(value) // left as it is for the validators
+		}
+
+		return val
+	case FieldTypeList:
+		options, _ := f.Options.(*ListOptions)
+		if options != nil && options.ElementType == ListElementTypeNumber {
+			return toNumberListValue(value)
+		}
+
+		return toTextListValue(value)
+	case FieldTypeNumber, FieldTypePosition:
 		return cast.ToFloat64(value)
 	case FieldTypeBool:
 		return cast.ToBool(value)
@@ -342,11 +494,10 @@ func (f *SchemaField) PrepareValue(value any) any {
 		}
 
 		return val
-	case FieldTypeFile:
+	case FieldTypeFile, FieldTypeAvatar:
 		val := list.ToUniqueStringSlice(value)
 
-		options, _ := f.Options.(*FileOptions)
-		if !options.IsMultiple() {
+		if !f.FileOptions().IsMultiple() {
 			if len(val) > 0 {
 				return val[len(val)-1] // the last selected
 			}
@@ -397,7 +548,21 @@ func (f *SchemaField) PrepareValueWithModifier(baseValue any, modifier string, m
 				list.ToUniqueStringSlice(modifierValue),
 			)
 		}
-	case FieldTypeFile:
+	case FieldTypeList:
+		switch modifier {
+		case FieldValueModifierAdd:
+			resolvedValue = append(toListItems(baseValue), toListItems(modifierValue)...)
+		case FieldValueModifierSubtract:
+			subtract := toListItems(modifierValue)
+			items := []any{}
+			for _, item := range toListItems(baseValue) {
+				if !existInListItems(item, subtract) {
+					items = append(items, item)
+				}
+			}
+			resolvedValue = items
+		}
+	case FieldTypeFile, FieldTypeAvatar:
 		// note: file for now supports only the subtract modifier
 		if modifier == FieldValueModifierSubtract {
 			resolvedValue = list.SubtractSlice(
@@ -426,6 +591,12 @@ type TextOptions struct {
 	Min     *int   `form:"min" json:"min"`
 	Max     *int   `form:"max" json:"max"`
 	Pattern string `form:"pattern" json:"pattern"`
+
+	// Encrypted indicates whether the field value should be stored
+	// encrypted with the record tenant key (see the collection TenantField option).
+	//
+	// Note that encrypted fields cannot be used in filters, sorts and indexes.
+	Encrypted bool `form:"encrypted" json:"encrypted,omitempty"`
 }
 
 func (o TextOptions) Validate() error {
@@ -545,14 +716,356 @@ type EditorOptions struct {
 	//
 	// (see also https://www.tiny.cloud/docs/tinymce/6/url-handling/#convert_urls)
 	ConvertUrls bool `form:"convertUrls" json:"convertUrls"`
+
+	// AllowedTags is the list of the HTML elements that are preserved
+	// on write (fallbacks to sanitizer.DefaultAllowedTags if empty).
+	AllowedTags []string `form:"allowedTags" json:"allowedTags"`
+
+	// AllowedAttributes is the list of the element attributes that are
+	// preserved on write (fallbacks to sanitizer.DefaultAllowedAttributes if empty).
+	//
+	// Use the "tag:attr" format to allow an attribute only for a specific element (eg. "a:href").
+	AllowedAttributes []string `form:"allowedAttributes" json:"allowedAttributes"`
+
+	// LinkRel is an optional list of rel values that are enforced on
+	// all links (eg. "noopener", "nofollow").
+	LinkRel []string `form:"linkRel" json:"linkRel"`
+
+	// IframeHosts is the list of the hosts (eg. "www.youtube.com")
+	// whose iframes are allowed. All other iframes are removed.
+	IframeHosts []string `form:"iframeHosts" json:"iframeHosts"`
 }
 
 func (o EditorOptions) Validate() error {
+	return validation.ValidateStruct(&o,
+		validation.Field(
+			&o.AllowedTags,
+			validation.Each(
+				validation.Match(editorTagRegex),
+				validation.By(func(value any) error {
+					tag, _ := value.(string)
+					if list.ExistInSlice(tag, sanitizer.ForbiddenTags) {
+						return validation.NewError("validation_forbidden_tag", "The tag is not allowed for security reasons.")
+					}
+					return nil
+				}),
+			),
+		),
+		validation.Field(&o.AllowedAttributes, validation.Each(validation.Match(editorAttributeRegex))),
+		validation.Field(&o.LinkRel, validation.Each(validation.In(list.ToInterfaceSlice(sanitizer.AllowedLinkRels)...))),
+		validation.Field(&o.IframeHosts, validation.Each(is.Host)),
+	)
+}
+
+// SanitizePolicy returns the HTML sanitization policy of the editor field.
+func (o EditorOptions) SanitizePolicy() sanitizer.Policy {
+	return sanitizer.Policy{
+		AllowedTags:       o.AllowedTags,
+		AllowedAttributes: o.AllowedAttributes,
+		LinkRel:           o.LinkRel,
+		IframeHosts:       o.IframeHosts,
+	}
+}
+
+// -------------------------------------------------------------------
+
+// MarkdownOptions defines the options of a markdown field.
+//
+// The markdown field value stores the markdown source together with
+// its sanitized rendered HTML (regenerated on every write), eg.:
+//
+//	{"source": "# Hello", "html": "<h1>Hello</h1>\n"}
+type MarkdownOptions struct {
+	// Max is the optional max allowed markdown source characters.
+	Max *int `form:"max" json:"max"`
+
+	// LinkRel is an optional list of rel values that are
+	// enforced on all rendered links (eg. "noopener", "nofollow").
+	LinkRel []string `form:"linkRel" json:"linkRel"`
+}
+
+func (o MarkdownOptions) Validate() error {
+	return validation.ValidateStruct(&o,
+		validation.Field(&o.Max, validation.NilOrNotEmpty, validation.Min(1)),
+		validation.Field(&o.LinkRel, validation.Each(validation.In(list.ToInterfaceSlice(sanitizer.AllowedLinkRels)...))),
+	)
+}
+
+// Render renders the provided markdown source into sanitized HTML.
+func (o *MarkdownOptions) Render(source string) string {
+	var policy sanitizer.Policy
+	if o != nil {
+		policy.LinkRel = o.LinkRel
+	}
+
+	return policy.Sanitize(markdown.ToHTML(source))
+}
+
+// -------------------------------------------------------------------
+
+// ColorOptions defines the options of a hex/rgba color field.
+//
+// The color values are normalized and stored as lowercase
+// "#rrggbb" (or "#rrggbbaa" for the not opaque colors) hex strings.
+type ColorOptions struct {
+	// AllowAlpha allows colors with transparency.
+	AllowAlpha bool `form:"allowAlpha" json:"allowAlpha"`
+}
+
+func (o ColorOptions) Validate() error {
 	return nil
 }
 
 // -------------------------------------------------------------------
 
+var currencyCodeRegex = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// CurrencyOptions defines the options of a currency amount field.
+//
+// The amount is stored as integer in the currency minor units
+// (eg. 1999 for 19.99 USD), allowing exact arithmetic and numeric filtering.
+type CurrencyOptions struct {
+	// Code is the ISO 4217 currency code (eg. "USD", "EUR").
+	Code string `form:"code" json:"code"`
+
+	// Min is the optional min allowed amount (in minor units).
+	Min *int64 `form:"min" json:"min"`
+
+	// Max is the optional max allowed amount (in minor units).
+	Max *int64 `form:"max" json:"max"`
+}
+
+func (o CurrencyOptions) Validate() error {
+	maxRules := []validation.Rule{}
+	if o.Min != nil && o.Max != nil {
+		maxRules = append(maxRules, validation.Min(*o.Min))
+	}
+
+	return validation.ValidateStruct(&o,
+		validation.Field(&o.Code, validation.Required, validation.Match(currencyCodeRegex)),
+		validation.Field(&o.Max, maxRules...),
+	)
+}
+
+// -------------------------------------------------------------------
+
+// DurationOptions defines the options of an ISO-8601 duration field.
+//
+// The duration values are stored as number of seconds (see [types.Duration])
+// and therefore should be filtered and sorted by their seconds
+// (eg. "duration > 3600").
+type DurationOptions struct {
+	// Min is the optional min allowed duration.
+	Min *types.Duration `form:"min" json:"min"`
+
+	// Max is the optional max allowed duration.
+	Max *types.Duration `form:"max" json:"max"`
+}
+
+func (o DurationOptions) Validate() error {
+	return validation.ValidateStruct(&o,
+		validation.Field(&o.Max, validation.By(func(value any) error {
+			if o.Min != nil && o.Max != nil && o.Max.Seconds() < o.Min.Seconds() {
+				return validation.NewError("validation_min_greater_equal_than_max", "Max must be greater or equal to Min.")
+			}
+			return nil
+		})),
+	)
+}
+
+// -------------------------------------------------------------------
+
+// PositionOptions defines the options of a server maintained
+// record ordering field.
+//
+// New records are appended at the end of their scope and the
+// position could be changed only with the reorder api endpoint.
+type PositionOptions struct {
+	// Scope is an optional list of field names whose values split
+	// the collection records into separately ordered lists
+	// (eg. ["board"] for a kanban card position per board).
+	Scope []string `form:"scope" json:"scope"`
+}
+
+func (o PositionOptions) Validate() error {
+	return validation.ValidateStruct(&o,
+		validation.Field(&o.Scope, validation.Each(validation.Required)),
+	)
+}
+
+// -------------------------------------------------------------------
+
+var phoneCountryCodeRegex = regexp.MustCompile(`^[1-9]\d{0,2}$`)
+
+// PhoneOptions defines the options of a phone number field.
+//
+// The phone numbers are normalized and stored in E.164 format
+// (eg. "+14155552671") and serialized as object with their
+// "e164", "international", "national" and "countryCode" variants.
+type PhoneOptions struct {
+	// DefaultRegion is an optional ISO 3166-1 alpha-2 region code (eg. "US")
+	// used to parse the submitted numbers that are not in international format.
+	DefaultRegion string `form:"defaultRegion" json:"defaultRegion"`
+
+	// AllowedCountryCodes is an optional list with the allowed
+	// country calling codes without the "+" (eg. ["1", "44"]).
+	AllowedCountryCodes []string `form:"allowedCountryCodes" json:"allowedCountryCodes"`
+}
+
+func (o PhoneOptions) Validate() error {
+	return validation.ValidateStruct(&o,
+		validation.Field(&o.DefaultRegion, validation.In(list.ToInterfaceSlice(types.PhoneRegions())...)),
+		validation.Field(&o.AllowedCountryCodes, validation.Each(validation.Required, validation.Match(phoneCountryCodeRegex))),
+	)
+}
+
+// -------------------------------------------------------------------
+
+// List field element types.
+const (
+	ListElementTypeText   string = "text"
+	ListElementTypeNumber string = "number"
+)
+
+// ListOptions defines the options of a list field.
+//
+// Unlike the multiple select field, the list field values are not
+// restricted to a predefined set and could contain duplicates.
+// The list is stored as JSON array of strings or numbers.
+type ListOptions struct {
+	// ElementType specifies the list elements type ("text" or "number").
+	//
+	// Empty value fallbacks to "text".
+	ElementType string `form:"elementType" json:"elementType"`
+
+	MinSize *int `form:"minSize" json:"minSize"`
+	MaxSize *int `form:"maxSize" json:"maxSize"`
+
+	// Indexed indicates whether to create an expression index for the
+	// list length (used by the "field:length" and "length(field)" filters).
+	//
+	// Note that the elements membership checks (aka. "contains(field, v)"
+	// and "overlaps(field, a, b)") are always resolved by iterating the
+	// JSON array elements because SQLite doesn't support multi-valued indexes.
+	Indexed bool `form:"indexed" json:"indexed"`
+}
+
+func (o ListOptions) Validate() error {
+	minVal := 0
+	if o.MinSize != nil {
+		minVal = *o.MinSize
+	}
+
+	return validation.ValidateStruct(&o,
+		validation.Field(&o.ElementType, validation.In(ListElementTypeText, ListElementTypeNumber)),
+		validation.Field(&o.MinSize, validation.Min(0)),
+		validation.Field(&o.MaxSize, validation.NilOrNotEmpty, validation.Min(minVal)),
+	)
+}
+
+// IsMultiple implements MultiValuer interface.
+//
+// The list field always stores multiple values.
+func (o ListOptions) IsMultiple() bool {
+	return true
+}
+
+// toListItems normalizes the provided list field value into a slice of items.
+func toListItems(value any) []any {
+	switch v := value.(type) {
+	case nil:
+		return []any{}
+	case []any:
+		return v
+	case []string:
+		return list.ToInterfaceSlice(v)
+	case []float64:
+		return list.ToInterfaceSlice(v)
+	case []int:
+		return list.ToInterfaceSlice(v)
+	case string:
+		if v == "" {
+			return []any{}
+		}
+
+		// json encoded array
+		items := []any{}
+		if strings.HasPrefix(strings.TrimSpace(v), "[") && json.Unmarshal([]byte(v), &items) == nil {
+			return items
+		}
+
+		return []any{v}
+	case json.Marshaler: // eg. types.JsonRaw, types.JsonArray
+		raw, _ := v.MarshalJSON()
+
+		items := []any{}
+		if json.Unmarshal(raw, &items) == nil {
+			return items
+		}
+
+		var item any
+		if json.Unmarshal(raw, &item) == nil && item != nil {
+			return []any{item}
+		}
+
+		return []any{}
+	default:
+		return []any{value}
+	}
+}
+
+// toTextListValue normalizes the provided value into a list of non-empty strings.
+func toTextListValue(value any) []string {
+	result := []string{}
+
+	for _, item := range toListItems(value) {
+		if str := cast.ToString(item); str != "" {
+			result = append(result, str)
+		}
+	}
+
+	return result
+}
+
+// toNumberListValue normalizes the provided value into a list of numbers.
+//
+// If some of the items is not a valid number, the items are returned
+// as they are (aka. []any) so that they could be reported by the validators.
+func toNumberListValue(value any) any {
+	items := toListItems(value)
+
+	result := make([]float64, 0, len(items))
+
+	for _, item := range items {
+		if str, ok := item.(string); ok && strings.TrimSpace(str) == "" {
+			continue // skip empty form values
+		}
+
+		num, err := cast.ToFloat64E(item)
+		if err != nil {
+			return items
+		}
+
+		result = append(result, num)
+	}
+
+	return result
+}
+
+func existInListItems(item any, items []any) bool {
+	str := cast.ToString(item)
+
+	for _, v := range items {
+		if cast.ToString(v) == str {
+			return true
+		}
+	}
+
+	return false
+}
+
+// -------------------------------------------------------------------
+
 type DateOptions struct {
 	Min types.DateTime `form:"min" json:"min"`
 	Max types.DateTime `form:"max" json:"max"`
@@ -631,6 +1144,19 @@ type FileOptions struct {
 	MaxSelect int      `form:"maxSelect" json:"maxSelect"`
 	MaxSize   int      `form:"maxSize" json:"maxSize"`
 	Protected bool     `form:"protected" json:"protected"`
+
+	// DeniedMimeTypes is a list of the sniffed content mime types
+	// that are not allowed to be uploaded (eg. "application/vnd.microsoft.portable-executable").
+	DeniedMimeTypes []string `form:"deniedMimeTypes" json:"deniedMimeTypes,omitempty"`
+
+	// StrictExtension requires the uploaded files extension to match
+	// with their sniffed content type (eg. to reject an executable renamed to ".png").
+	StrictExtension bool `form:"strictExtension" json:"strictExtension,omitempty"`
+
+	// Quarantine moves the uploaded files that fail the content type checks
+	// to the storage "_quarantine" directory instead of rejecting the request
+	// (the quarantined files are not attached to the record).
+	Quarantine bool `form:"quarantine" json:"quarantine,omitempty"`
 }
 
 func (o FileOptions) Validate() error {
@@ -641,6 +1167,7 @@ func (o FileOptions) Validate() error {
 			validation.NotIn("0x0", "0x0t", "0x0b", "0x0f"),
 			validation.Match(filesystem.ThumbSizeRegex),
 		)),
+		validation.Field(&o.DeniedMimeTypes, validation.Each(validation.Required)),
 	)
 }
 
@@ -652,6 +1179,73 @@ func (o FileOptions) IsMultiple() bool {
 
 // -------------------------------------------------------------------
 
+// AvatarMimeTypes is the list of the supported avatar image mime types.
+var AvatarMimeTypes = []string{"image/jpeg", "image/png", "image/gif"}
+
+// DefaultAvatarSizes is the list of the standard avatar sizes
+// that are used when AvatarOptions.Sizes is not set.
+var DefaultAvatarSizes = []int{32, 64, 128, 256}
+
+// AvatarOptions defines the options of a single image "avatar" field.
+//
+// The uploaded avatar images are center cropped to a square and
+// re-encoded (which also strips their metadata), and the standard
+// square sizes are served as thumbs (eg. "?thumb=64x64").
+type AvatarOptions struct {
+	// MaxSize is the max allowed uploaded image size in bytes.
+	MaxSize int `form:"maxSize" json:"maxSize"`
+
+	// Sizes is the list of the standard avatar sizes in pixels
+	// (fallbacks to DefaultAvatarSizes if empty).
+	Sizes []int `form:"sizes" json:"sizes"`
+
+	// Protected requires a file token to access the avatar
+	// (the same as for the protected file fields).
+	Protected bool `form:"protected" json:"protected"`
+
+	// PlaceholderField is the optional name of the record field
+	// whose first letter is used for the placeholder avatar image
+	// (fallbacks to the auth record username or the record id).
+	PlaceholderField string `form:"placeholderField" json:"placeholderField"`
+}
+
+func (o AvatarOptions) Validate() error {
+	return validation.ValidateStruct(&o,
+		validation.Field(&o.MaxSize, validation.Required, validation.Min(1)),
+		validation.Field(&o.Sizes, validation.Each(validation.Min(1), validation.Max(2048))),
+		validation.Field(&o.PlaceholderField, validation.Match(schemaFieldNameRegex)),
+	)
+}
+
+// AvatarSizes returns the configured standard avatar sizes (or the default ones).
+func (o AvatarOptions) AvatarSizes() []int {
+	if len(o.Sizes) == 0 {
+		return DefaultAvatarSizes
+	}
+
+	return o.Sizes
+}
+
+// FileOptions returns the equivalent single file options of the avatar options.
+func (o AvatarOptions) FileOptions() *FileOptions {
+	sizes := o.AvatarSizes()
+
+	thumbs := make([]string, len(sizes))
+	for i, size := range sizes {
+		thumbs[i] = strconv.Itoa(size) + "x" + strconv.Itoa(size)
+	}
+
+	return &FileOptions{
+		MimeTypes: AvatarMimeTypes,
+		Thumbs:    thumbs,
+		MaxSelect: 1,
+		MaxSize:   o.MaxSize,
+		Protected: o.Protected,
+	}
+}
+
+// -------------------------------------------------------------------
+
 var _ MultiValuer = (*RelationOptions)(nil)
 
 type RelationOptions struct {
@@ -677,6 +1271,14 @@ type RelationOptions struct {
 	// Deprecated: This field is no-op and will be removed in future versions.
 	// Instead use the individula SchemaField.Presentable option for each field in the relation collection.
 	DisplayFields []string `form:"displayFields" json:"displayFields"`
+
+	// Through specifies an optional join collection for many-to-many
+	// relations with attributes (eg. "memberships" with a "role" field).
+	//
+	// When set, the relation field value is managed automatically
+	// from the join collection records and the related join record
+	// is attached to each expanded relation record.
+	Through *RelationThrough `form:"through" json:"through"`
 }
 
 func (o RelationOptions) Validate() error {
@@ -689,6 +1291,7 @@ func (o RelationOptions) Validate() error {
 		validation.Field(&o.CollectionId, validation.Required),
 		validation.Field(&o.MinSelect, validation.Min(0)),
 		validation.Field(&o.MaxSelect, validation.NilOrNotEmpty, validation.Min(minVal)),
+		validation.Field(&o.Through),
 	)
 }
 
@@ -698,6 +1301,33 @@ func (o RelationOptions) IsMultiple() bool {
 	return o.MaxSelect == nil || *o.MaxSelect > 1
 }
 
+// RelationThrough defines the join collection of a "through" relation field.
+type RelationThrough struct {
+	// CollectionId is the id of the join collection.
+	CollectionId string `form:"collectionId" json:"collectionId"`
+
+	// SourceField is the name of the join collection single relation
+	// field that references the collection of the "through" field.
+	SourceField string `form:"sourceField" json:"sourceField"`
+
+	// TargetField is the name of the join collection single relation
+	// field that references the "through" field related collection.
+	TargetField string `form:"targetField" json:"targetField"`
+}
+
+// Validate implements [validation.Validatable] interface.
+func (t RelationThrough) Validate() error {
+	return validation.ValidateStruct(&t,
+		validation.Field(&t.CollectionId, validation.Required),
+		validation.Field(&t.SourceField, validation.Required),
+		validation.Field(
+			&t.TargetField,
+			validation.Required,
+			validation.NotIn(t.SourceField).Error("The target field must be different from the source field."),
+		),
+	)
+}
+
 // -------------------------------------------------------------------
 
 // Deprecated: Will be removed in v0.9+
@@ -3,6 +3,7 @@ package schema_test
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -40,7 +41,7 @@ func TestAuthFieldNames(t *testing.T) {
 
 func TestFieldTypes(t *testing.T) {
 	result := schema.FieldTypes()
-	expected := 11
+	expected := 19
 
 	if len(result) != expected {
 		t.Fatalf("Expected %d types, got %d (%v)", expected, len(result), result)
@@ -49,7 +50,7 @@ func TestFieldTypes(t *testing.T) {
 
 func TestArraybleFieldTypes(t *testing.T) {
 	result := schema.ArraybleFieldTypes()
-	expected := 3
+	expected := 4
 
 	if len(result) != expected {
 		t.Fatalf("Expected %d arrayble types, got %d (%v)", expected, len(result), result)
@@ -93,6 +94,34 @@ func TestSchemaFieldColDefinition(t *testing.T) {
 			schema.SchemaField{Type: schema.FieldTypeJson, Name: "test"},
 			"JSON DEFAULT NULL",
 		},
+		{
+			schema.SchemaField{Type: schema.FieldTypeMarkdown, Name: "test"},
+			"JSON DEFAULT NULL",
+		},
+		{
+			schema.SchemaField{Type: schema.FieldTypeColor, Name: "test"},
+			"TEXT DEFAULT '' NOT NULL",
+		},
+		{
+			schema.SchemaField{Type: schema.FieldTypeCurrency, Name: "test"},
+			"INTEGER DEFAULT 0 NOT NULL",
+		},
+		{
+			schema.SchemaField{Type: schema.FieldTypeDuration, Name: "test"},
+			"INTEGER DEFAULT 0 NOT NULL",
+		},
+		{
+			schema.SchemaField{Type: schema.FieldTypePosition, Name: "test"},
+			"NUMERIC DEFAULT 0 NOT NULL",
+		},
+		{
+			schema.SchemaField{Type: schema.FieldTypePhone, Name: "test"},
+			"TEXT DEFAULT '' NOT NULL",
+		},
+		{
+			schema.SchemaField{Type: schema.FieldTypeList, Name: "test", Options: &schema.ListOptions{}},
+			"JSON DEFAULT '[]' NOT NULL",
+		},
 		{
 			schema.SchemaField{Type: schema.FieldTypeSelect, Name: "test"},
 			"TEXT DEFAULT '' NOT NULL",
@@ -505,7 +534,7 @@ func TestSchemaFieldInitOptions(t *testing.T) {
 		{
 			schema.SchemaField{Type: schema.FieldTypeEditor},
 			false,
-			`{"system":false,"id":"","name":"","type":"editor","required":false,"presentable":false,"unique":false,"options":{"convertUrls":false}}`,
+			`{"system":false,"id":"","name":"","type":"editor","required":false,"presentable":false,"unique":false,"options":{"convertUrls":false,"allowedTags":null,"allowedAttributes":null,"linkRel":null,"iframeHosts":null}}`,
 		},
 		{
 			schema.SchemaField{Type: schema.FieldTypeDate},
@@ -530,7 +559,7 @@ func TestSchemaFieldInitOptions(t *testing.T) {
 		{
 			schema.SchemaField{Type: schema.FieldTypeRelation},
 			false,
-			`{"system":false,"id":"","name":"","type":"relation","required":false,"presentable":false,"unique":false,"options":{"collectionId":"","cascadeDelete":false,"minSelect":null,"maxSelect":null,"displayFields":null}}`,
+			`{"system":false,"id":"","name":"","type":"relation","required":false,"presentable":false,"unique":false,"options":{"collectionId":"","cascadeDelete":false,"minSelect":null,"maxSelect":null,"displayFields":null,"through":null}}`,
 		},
 		{
 			schema.SchemaField{Type: schema.FieldTypeUser},
@@ -601,6 +630,65 @@ func TestSchemaFieldPrepareValue(t *testing.T) {
 		{schema.SchemaField{Type: schema.FieldTypeEditor}, "test", `"test"`},
 		{schema.SchemaField{Type: schema.FieldTypeEditor}, 123, `"123"`},
 
+		// markdown
+		{schema.SchemaField{Type: schema.FieldTypeMarkdown}, nil, `{}`},
+		{schema.SchemaField{Type: schema.FieldTypeMarkdown}, "", `{}`},
+		{schema.SchemaField{Type: schema.FieldTypeMarkdown}, "*test*", `{"html":"\u003cp\u003e\u003cem\u003etest\u003c/em\u003e\u003c/p\u003e\n","source":"*test*"}`},
+		{schema.SchemaField{Type: schema.FieldTypeMarkdown}, `{"a":1}`, `{"html":"\u003cp\u003e{\u0026#34;a\u0026#34;:1}\u003c/p\u003e\n","source":"{\"a\":1}"}`},
+		{schema.SchemaField{Type: schema.FieldTypeMarkdown}, `{"source":"a","html":"b"}`, `{"html":"b","source":"a"}`},
+		{schema.SchemaField{Type: schema.FieldTypeMarkdown}, map[string]any{"source": "a", "html": "b"}, `{"html":"b","source":"a"}`},
+		{schema.SchemaField{Type: schema.FieldTypeMarkdown}, map[string]any{"html": "b"}, `{}`},
+
+		// color
+		{schema.SchemaField{Type: schema.FieldTypeColor}, nil, `""`},
+		{schema.SchemaField{Type: schema.FieldTypeColor}, "invalid", `"invalid"`},
+		{schema.SchemaField{Type: schema.FieldTypeColor}, "#ABC", `"#aabbcc"`},
+		{schema.SchemaField{Type: schema.FieldTypeColor}, "rgba(0,0,0,0.5)", `"#00000080"`},
+
+		// currency
+		{schema.SchemaField{Type: schema.FieldTypeCurrency}, nil, "0"},
+		{schema.SchemaField{Type: schema.FieldTypeCurrency}, "invalid", "0"},
+		{schema.SchemaField{Type: schema.FieldTypeCurrency}, "1999", "1999"},
+		{schema.SchemaField{Type: schema.FieldTypeCurrency}, 10.6, "11"},
+
+		// duration
+		{schema.SchemaField{Type: schema.FieldTypeDuration}, nil, `"PT0S"`},
+		{schema.SchemaField{Type: schema.FieldTypeDuration}, "P1Y", `"P1Y"`},
+		{schema.SchemaField{Type: schema.FieldTypeDuration}, "PT90M", `"PT1H30M"`},
+		{schema.SchemaField{Type: schema.FieldTypeDuration}, 86400, `"P1D"`},
+
+		// position
+		{schema.SchemaField{Type: schema.FieldTypePosition}, nil, "0"},
+		{schema.SchemaField{Type: schema.FieldTypePosition}, "1500.5", "1500.5"},
+		{schema.SchemaField{Type: schema.FieldTypePosition}, -10, "-10"},
+
+		// phone
+		{schema.SchemaField{Type: schema.FieldTypePhone}, nil, `{"countryCode":"","e164":"","international":"","national":""}`},
+		{schema.SchemaField{Type: schema.FieldTypePhone}, "invalid", `"invalid"`},
+		{schema.SchemaField{Type: schema.FieldTypePhone}, "(415) 555-2671", `"(415) 555-2671"`},
+		{
+			schema.SchemaField{Type: schema.FieldTypePhone, Options: &schema.PhoneOptions{DefaultRegion: "US"}},
+			"(415) 555-2671",
+			`{"countryCode":"1","e164":"+14155552671","international":"+1 415-555-2671","national":"(415) 555-2671"}`,
+		},
+		{
+			schema.SchemaField{Type: schema.FieldTypePhone},
+			"+44 20 7183 8750",
+			`{"countryCode":"44","e164":"+442071838750","international":"+44 207 183 8750","national":"0207 183 8750"}`,
+		},
+
+		// list
+		{schema.SchemaField{Type: schema.FieldTypeList, Options: &schema.ListOptions{}}, nil, `[]`},
+		{schema.SchemaField{Type: schema.FieldTypeList, Options: &schema.ListOptions{}}, "", `[]`},
+		{schema.SchemaField{Type: schema.FieldTypeList, Options: &schema.ListOptions{}}, "test", `["test"]`},
+		{schema.SchemaField{Type: schema.FieldTypeList, Options: &schema.ListOptions{}}, []string{"a", "", "b", "a"}, `["a","b","a"]`},
+		{schema.SchemaField{Type: schema.FieldTypeList, Options: &schema.ListOptions{}}, `["a", 2, "a"]`, `["a","2","a"]`},
+		{schema.SchemaField{Type: schema.FieldTypeList, Options: &schema.ListOptions{}}, types.JsonRaw(`["a","b"]`), `["a","b"]`},
+		{schema.SchemaField{Type: schema.FieldTypeList, Options: &schema.ListOptions{ElementType: "number"}}, nil, `[]`},
+		{schema.SchemaField{Type: schema.FieldTypeList, Options: &schema.ListOptions{ElementType: "number"}}, []string{"1", "", "2.5", "1"}, `[1,2.5,1]`},
+		{schema.SchemaField{Type: schema.FieldTypeList, Options: &schema.ListOptions{ElementType: "number"}}, "[3,-1]", `[3,-1]`},
+		{schema.SchemaField{Type: schema.FieldTypeList, Options: &schema.ListOptions{ElementType: "number"}}, []any{1, "a"}, `[1,"a"]`},
+
 		// json
 		{schema.SchemaField{Type: schema.FieldTypeJson}, nil, "null"},
 		{schema.SchemaField{Type: schema.FieldTypeJson}, "null", "null"},
@@ -1355,6 +1443,48 @@ func TestSchemaFieldPrepareValueWithModifier(t *testing.T) {
 			`["a","b"]`,
 		},
 
+		// list
+		{
+			"list with '+' modifier (empty base)",
+			schema.SchemaField{Type: schema.FieldTypeList, Options: &schema.ListOptions{}},
+			nil,
+			"+",
+			"a",
+			`["a"]`,
+		},
+		{
+			"list with '+' modifier (duplicated values)",
+			schema.SchemaField{Type: schema.FieldTypeList, Options: &schema.ListOptions{}},
+			`["a","b"]`,
+			"+",
+			[]string{"a", "c"},
+			`["a","b","a","c"]`,
+		},
+		{
+			"list with '-' modifier",
+			schema.SchemaField{Type: schema.FieldTypeList, Options: &schema.ListOptions{}},
+			[]string{"a", "b", "a", "c"},
+			"-",
+			"a",
+			`["b","c"]`,
+		},
+		{
+			"number list with '+' modifier",
+			schema.SchemaField{Type: schema.FieldTypeList, Options: &schema.ListOptions{ElementType: "number"}},
+			`[1,2]`,
+			"+",
+			"3",
+			`[1,2,3]`,
+		},
+		{
+			"number list with '-' modifier",
+			schema.SchemaField{Type: schema.FieldTypeList, Options: &schema.ListOptions{ElementType: "number"}},
+			`[1,2,1,3]`,
+			"-",
+			[]string{"1", "3"},
+			`[2]`,
+		},
+
 		// single relation
 		{
 			"single relation with '+' modifier (empty base)",
@@ -1923,11 +2053,204 @@ func TestEditorOptionsValidate(t *testing.T) {
 			schema.EditorOptions{},
 			[]string{},
 		},
+		{
+			"invalid sanitization policy",
+			schema.EditorOptions{
+				AllowedTags:       []string{"p", "script", "<a>"},
+				AllowedAttributes: []string{"class", "a:href", "a::href"},
+				LinkRel:           []string{"nofollow", "invalid"},
+				IframeHosts:       []string{"www.youtube.com", "invalid host"},
+			},
+			[]string{"allowedTags", "allowedAttributes", "linkRel", "iframeHosts"},
+		},
+		{
+			"valid sanitization policy",
+			schema.EditorOptions{
+				AllowedTags:       []string{"p", "a", "iframe"},
+				AllowedAttributes: []string{"class", "a:href", "data-id"},
+				LinkRel:           []string{"noopener", "nofollow"},
+				IframeHosts:       []string{"www.youtube.com", "player.vimeo.com"},
+			},
+			[]string{},
+		},
+	}
+
+	checkFieldOptionsScenarios(t, scenarios)
+}
+
+func TestMarkdownOptionsValidate(t *testing.T) {
+	scenarios := []fieldOptionsScenario{
+		{
+			"empty",
+			schema.MarkdownOptions{},
+			[]string{},
+		},
+		{
+			"invalid options",
+			schema.MarkdownOptions{
+				Max:     types.Pointer(0),
+				LinkRel: []string{"invalid"},
+			},
+			[]string{"max", "linkRel"},
+		},
+		{
+			"valid options",
+			schema.MarkdownOptions{
+				Max:     types.Pointer(100),
+				LinkRel: []string{"nofollow"},
+			},
+			[]string{},
+		},
+	}
+
+	checkFieldOptionsScenarios(t, scenarios)
+}
+
+func TestMarkdownOptionsRender(t *testing.T) {
+	options := &schema.MarkdownOptions{LinkRel: []string{"nofollow"}}
+
+	result := options.Render("[a](javascript:alert(1)) [b](https://example.com) <script>")
+
+	expected := `<p><a rel="nofollow">a</a> <a href="https://example.com" rel="nofollow">b</a> &lt;script&gt;</p>` + "\n"
+	if result != expected {
+		t.Fatalf("Expected\n%q\ngot\n%q", expected, result)
+	}
+}
+
+func TestCurrencyOptionsValidate(t *testing.T) {
+	scenarios := []fieldOptionsScenario{
+		{
+			"empty",
+			schema.CurrencyOptions{},
+			[]string{"code"},
+		},
+		{
+			"invalid code and max < min",
+			schema.CurrencyOptions{
+				Code: "usd",
+				Min:  types.Pointer[int64](10),
+				Max:  types.Pointer[int64](9),
+			},
+			[]string{"code", "max"},
+		},
+		{
+			"valid options",
+			schema.CurrencyOptions{
+				Code: "USD",
+				Min:  types.Pointer[int64](10),
+				Max:  types.Pointer[int64](10),
+			},
+			[]string{},
+		},
+	}
+
+	checkFieldOptionsScenarios(t, scenarios)
+}
+
+func TestDurationOptionsValidate(t *testing.T) {
+	min, _ := types.ParseDuration("PT1H")
+	max, _ := types.ParseDuration("PT30M")
+
+	scenarios := []fieldOptionsScenario{
+		{
+			"empty",
+			schema.DurationOptions{},
+			[]string{},
+		},
+		{
+			"max < min",
+			schema.DurationOptions{Min: &min, Max: &max},
+			[]string{"max"},
+		},
+		{
+			"max > min",
+			schema.DurationOptions{Min: &max, Max: &min},
+			[]string{},
+		},
+	}
+
+	checkFieldOptionsScenarios(t, scenarios)
+}
+
+func TestPositionOptionsValidate(t *testing.T) {
+	scenarios := []fieldOptionsScenario{
+		{
+			"empty",
+			schema.PositionOptions{},
+			[]string{},
+		},
+		{
+			"empty scope field",
+			schema.PositionOptions{Scope: []string{"a", ""}},
+			[]string{"scope"},
+		},
+		{
+			"valid scope",
+			schema.PositionOptions{Scope: []string{"a", "b"}},
+			[]string{},
+		},
+	}
+
+	checkFieldOptionsScenarios(t, scenarios)
+}
+
+func TestPhoneOptionsValidate(t *testing.T) {
+	scenarios := []fieldOptionsScenario{
+		{
+			"empty",
+			schema.PhoneOptions{},
+			[]string{},
+		},
+		{
+			"invalid default region and country codes",
+			schema.PhoneOptions{DefaultRegion: "XX", AllowedCountryCodes: []string{"1", "", "+44", "0"}},
+			[]string{"defaultRegion", "allowedCountryCodes"},
+		},
+		{
+			"valid default region and country codes",
+			schema.PhoneOptions{DefaultRegion: "US", AllowedCountryCodes: []string{"1", "44", "359"}},
+			[]string{},
+		},
 	}
 
 	checkFieldOptionsScenarios(t, scenarios)
 }
 
+func TestListOptionsValidate(t *testing.T) {
+	scenarios := []fieldOptionsScenario{
+		{
+			"empty",
+			schema.ListOptions{},
+			[]string{},
+		},
+		{
+			"invalid element type and sizes",
+			schema.ListOptions{ElementType: "bool", MinSize: types.Pointer(-1), MaxSize: types.Pointer(0)},
+			[]string{"elementType", "minSize", "maxSize"},
+		},
+		{
+			"max size less than min size",
+			schema.ListOptions{ElementType: "text", MinSize: types.Pointer(2), MaxSize: types.Pointer(1)},
+			[]string{"maxSize"},
+		},
+		{
+			"valid options",
+			schema.ListOptions{ElementType: "number", MinSize: types.Pointer(1), MaxSize: types.Pointer(3), Indexed: true},
+			[]string{},
+		},
+	}
+
+	checkFieldOptionsScenarios(t, scenarios)
+}
+
+func TestListOptionsIsMultiple(t *testing.T) {
+	opt := schema.ListOptions{}
+
+	if !opt.IsMultiple() {
+		t.Fatal("Expected the list options to be always multiple")
+	}
+}
+
 func TestDateOptionsValidate(t *testing.T) {
 	date1 := types.NowDateTime()
 	date2, _ := types.ParseDateTime(date1.Time().AddDate(1, 0, 0))
@@ -2118,6 +2441,15 @@ func TestFileOptionsValidate(t *testing.T) {
 			},
 			[]string{"thumbs"},
 		},
+		{
+			"empty denied mime type",
+			schema.FileOptions{
+				MaxSize:         1,
+				MaxSelect:       2,
+				DeniedMimeTypes: []string{"image/png", ""},
+			},
+			[]string{"deniedMimeTypes"},
+		},
 		{
 			"valid thumbs format",
 			schema.FileOptions{
@@ -2157,6 +2489,76 @@ func TestFileOptionsIsMultiple(t *testing.T) {
 	}
 }
 
+func TestAvatarOptionsValidate(t *testing.T) {
+	scenarios := []fieldOptionsScenario{
+		{
+			"empty",
+			schema.AvatarOptions{},
+			[]string{"maxSize"},
+		},
+		{
+			"invalid sizes and placeholder field",
+			schema.AvatarOptions{
+				MaxSize:          1,
+				Sizes:            []int{0, 64, 5000},
+				PlaceholderField: "invalid name",
+			},
+			[]string{"sizes", "placeholderField"},
+		},
+		{
+			"valid options",
+			schema.AvatarOptions{
+				MaxSize:          1,
+				Sizes:            []int{48, 96},
+				PlaceholderField: "name",
+			},
+			[]string{},
+		},
+	}
+
+	checkFieldOptionsScenarios(t, scenarios)
+}
+
+func TestAvatarOptionsFileOptions(t *testing.T) {
+	scenarios := []struct {
+		options        schema.AvatarOptions
+		expectedThumbs []string
+	}{
+		{
+			schema.AvatarOptions{MaxSize: 10},
+			[]string{"32x32", "64x64", "128x128", "256x256"},
+		},
+		{
+			schema.AvatarOptions{MaxSize: 10, Sizes: []int{50, 100}, Protected: true},
+			[]string{"50x50", "100x100"},
+		},
+	}
+
+	for i, s := range scenarios {
+		result := s.options.FileOptions()
+
+		if result.MaxSelect != 1 {
+			t.Errorf("[%d] Expected MaxSelect 1, got %d", i, result.MaxSelect)
+		}
+
+		if result.MaxSize != s.options.MaxSize {
+			t.Errorf("[%d] Expected MaxSize %d, got %d", i, s.options.MaxSize, result.MaxSize)
+		}
+
+		if result.Protected != s.options.Protected {
+			t.Errorf("[%d] Expected Protected %v, got %v", i, s.options.Protected, result.Protected)
+		}
+
+		if strings.Join(result.Thumbs, ",") != strings.Join(s.expectedThumbs, ",") {
+			t.Errorf("[%d] Expected thumbs %v, got %v", i, s.expectedThumbs, result.Thumbs)
+		}
+
+		if err := result.Validate(); err != nil {
+			t.Errorf("[%d] Expected valid file options, got %v", i, err)
+		}
+	}
+}
+
 func TestRelationOptionsValidate(t *testing.T) {
 	scenarios := []fieldOptionsScenario{
 		{
@@ -2240,6 +2642,38 @@ func TestRelationOptionsValidate(t *testing.T) {
 			},
 			[]string{"maxSelect"},
 		},
+		{
+			"empty Through",
+			schema.RelationOptions{
+				CollectionId: "abc",
+				Through:      &schema.RelationThrough{},
+			},
+			[]string{"through"},
+		},
+		{
+			"Through with the same source and target fields",
+			schema.RelationOptions{
+				CollectionId: "abc",
+				Through: &schema.RelationThrough{
+					CollectionId: "def",
+					SourceField:  "a",
+					TargetField:  "a",
+				},
+			},
+			[]string{"through"},
+		},
+		{
+			"valid Through",
+			schema.RelationOptions{
+				CollectionId: "abc",
+				Through: &schema.RelationThrough{
+					CollectionId: "def",
+					SourceField:  "a",
+					TargetField:  "b",
+				},
+			},
+			[]string{},
+		},
 	}
 
 	checkFieldOptionsScenarios(t, scenarios)
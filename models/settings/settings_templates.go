@@ -6,6 +6,7 @@ const (
 	EmailPlaceholderAppUrl    string = "{APP_URL}"
 	EmailPlaceholderToken     string = "{TOKEN}"
 	EmailPlaceholderActionUrl string = "{ACTION_URL}"
+	EmailPlaceholderOtp       string = "{OTP}"
 )
 
 var defaultVerificationTemplate = EmailTemplate{
@@ -52,3 +53,19 @@ var defaultConfirmEmailChangeTemplate = EmailTemplate{
 </p>`,
 	ActionUrl: EmailPlaceholderAppUrl + "/_/#/auth/confirm-email-change/" + EmailPlaceholderToken,
 }
+
+var defaultOtpTemplate = EmailTemplate{
+	Subject: "Your " + EmailPlaceholderAppName + " one-time password",
+	Body: `<p>Hello,</p>
+<p>Your one-time password is: <strong>` + EmailPlaceholderOtp + `</strong></p>
+<p>You can also click on the button below to sign in.</p>
+<p>
+  <a class="btn" href="` + EmailPlaceholderActionUrl + `" target="_blank" rel="noopener">Sign in</a>
+</p>
+<p><i>If you didn't ask for the one-time password, you can ignore this email.</i></p>
+<p>
+  Thanks,<br/>
+  ` + EmailPlaceholderAppName + ` team
+</p>`,
+	ActionUrl: EmailPlaceholderAppUrl + "/_/#/auth/confirm-otp/" + EmailPlaceholderToken + "/" + EmailPlaceholderOtp,
+}
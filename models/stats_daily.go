@@ -0,0 +1,28 @@
+package models
+
+var _ Model = (*StatsDaily)(nil)
+
+// Builtin daily stats metrics.
+const (
+	StatsMetricSignups         = "signups"
+	StatsMetricRequests        = "requests"
+	StatsMetricErrors          = "errors"
+	StatsMetricStorage         = "storage"
+	StatsMetricRealtimeClients = "realtimeClients"
+)
+
+// StatsDaily defines a single daily stats rollup value
+// (eg. the signups of a single auth collection for a day).
+type StatsDaily struct {
+	BaseModel
+
+	// Date is the UTC day of the rollup in YYYY-MM-DD format.
+	Date   string  `db:"date" json:"date"`
+	Metric string  `db:"metric" json:"metric"`
+	Key    string  `db:"key" json:"key"`
+	Value  float64 `db:"value" json:"value"`
+}
+
+func (m *StatsDaily) TableName() string {
+	return "_statsDaily"
+}
@@ -0,0 +1,16 @@
+package models
+
+var _ Model = (*Tombstone)(nil)
+
+// Tombstone defines a marker for a deleted collection record
+// (used by the incremental record changes sync).
+type Tombstone struct {
+	BaseModel
+
+	CollectionId string `db:"collectionId" json:"collectionId"`
+	RecordId     string `db:"recordId" json:"recordId"`
+}
+
+func (m *Tombstone) TableName() string {
+	return "_tombstones"
+}
@@ -0,0 +1,19 @@
+package models
+
+var _ Model = (*UsernameChange)(nil)
+
+// UsernameChange defines a single auth record username change
+// (used for enforcing the auth collection username change cooldown).
+type UsernameChange struct {
+	BaseModel
+
+	CollectionId string `db:"collectionId" json:"collectionId"`
+	RecordId     string `db:"recordId" json:"recordId"`
+
+	// OldUsername is the username before the change.
+	OldUsername string `db:"oldUsername" json:"oldUsername"`
+}
+
+func (m *UsernameChange) TableName() string {
+	return "_usernameChanges"
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+)
+
+var _ Model = (*WalletNonce)(nil)
+
+// WalletNonceDuration is the lifetime of the issued wallet nonces in seconds.
+const WalletNonceDuration = 300
+
+// WalletNonce defines a single use Sign-In with Ethereum (EIP-4361) message nonce.
+type WalletNonce struct {
+	BaseModel
+
+	CollectionId string `db:"collectionId" json:"collectionId"`
+	Nonce        string `db:"nonce" json:"nonce"`
+}
+
+// TableName returns the WalletNonce model SQL table name.
+func (m *WalletNonce) TableName() string {
+	return "_walletNonces"
+}
+
+// HasExpired checks whether the nonce lifetime has passed.
+func (m *WalletNonce) HasExpired() bool {
+	return time.Since(m.Created.Time()) > WalletNonceDuration*time.Second
+}
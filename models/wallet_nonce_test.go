@@ -0,0 +1,38 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestWalletNonceTableName(t *testing.T) {
+	m := models.WalletNonce{}
+	if m.TableName() != "_walletNonces" {
+		t.Fatalf("Unexpected table name, got %q", m.TableName())
+	}
+}
+
+func TestWalletNonceHasExpired(t *testing.T) {
+	now, _ := types.ParseDateTime(time.Now())
+	old, _ := types.ParseDateTime(time.Now().Add(-(models.WalletNonceDuration + 10) * time.Second))
+
+	scenarios := []struct {
+		created  types.DateTime
+		expected bool
+	}{
+		{now, false},
+		{old, true},
+	}
+
+	for i, s := range scenarios {
+		m := models.WalletNonce{}
+		m.Created = s.created
+
+		if v := m.HasExpired(); v != s.expected {
+			t.Errorf("(%d) Expected %v, got %v", i, s.expected, v)
+		}
+	}
+}
@@ -1257,7 +1257,7 @@ func TestHooksBindsCount(t *testing.T) {
 	vm := goja.New()
 	hooksBinds(app, vm, nil)
 
-	testBindsCount(vm, "this", 88, t)
+	testBindsCount(vm, "this", 95, t)
 }
 
 func TestHooksBinds(t *testing.T) {
@@ -17,12 +17,15 @@ package migratecmd
 
 import (
 	"fmt"
+	"net/http"
 	"os"
 	"path"
 	"path/filepath"
 	"time"
 
 	"github.com/AlecAivazis/survey/v2"
+	"github.com/fatih/color"
+	pbcmd "github.com/pocketbase/pocketbase/cmd"
 	"github.com/pocketbase/pocketbase/core"
 	"github.com/pocketbase/pocketbase/migrations"
 	"github.com/pocketbase/pocketbase/models"
@@ -115,6 +118,8 @@ func (p *plugin) createCommand() *cobra.Command {
 - create name   - creates new blank migration template file
 - collections   - creates new migration file with snapshot of the local collections configuration
 - history-sync  - ensures that the _migrations history table doesn't have references to deleted migration files
+
+Only the "up" argument is supported when managing a remote instance with --remote.
 `
 
 	command := &cobra.Command{
@@ -129,6 +134,14 @@ func (p *plugin) createCommand() *cobra.Command {
 				cmd = args[0]
 			}
 
+			remote, err := pbcmd.NewRemoteClient(command)
+			if err != nil {
+				return err
+			}
+			if remote != nil {
+				return p.migrateRemoteHandler(remote, cmd)
+			}
+
 			switch cmd {
 			case "create":
 				if _, err := p.migrateCreateHandler("", args[1:], true); err != nil {
@@ -153,9 +166,39 @@ func (p *plugin) createCommand() *cobra.Command {
 		},
 	}
 
+	pbcmd.AddRemoteFlags(command)
+
 	return command
 }
 
+// migrateRemoteHandler applies the pending migrations of a remote instance.
+//
+// Only the "up" command is supported because the other commands
+// either operate on the local migration files or require interactive confirmation.
+func (p *plugin) migrateRemoteHandler(remote *pbcmd.RemoteClient, cmd string) error {
+	if cmd != "" && cmd != "up" {
+		return fmt.Errorf("The %q migrate command is not supported with --remote.", cmd)
+	}
+
+	result := struct {
+		Applied []string `json:"applied"`
+	}{}
+
+	if err := remote.Send(http.MethodPost, "/api/migrations/up", nil, &result); err != nil {
+		return fmt.Errorf("Failed to apply the remote migrations: %v", err)
+	}
+
+	if len(result.Applied) == 0 {
+		color.Green("No new migrations to apply.")
+	} else {
+		for _, file := range result.Applied {
+			color.Green("Applied %s", file)
+		}
+	}
+
+	return nil
+}
+
 func (p *plugin) migrateCreateHandler(template string, args []string, interactive bool) (string, error) {
 	if len(args) < 1 {
 		return "", fmt.Errorf("Missing migration file name")
@@ -2,6 +2,8 @@ package migratecmd_test
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
@@ -13,6 +15,7 @@ import (
 	"github.com/pocketbase/pocketbase/plugins/migratecmd"
 	"github.com/pocketbase/pocketbase/tests"
 	"github.com/pocketbase/pocketbase/tools/types"
+	"github.com/spf13/cobra"
 )
 
 func TestAutomigrateCollectionCreate(t *testing.T) {
@@ -823,3 +826,36 @@ func TestAutomigrateCollectionNoChanges(t *testing.T) {
 		}
 	}
 }
+
+func TestMigrateCommandRemote(t *testing.T) {
+	var called string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = r.Method + " " + r.URL.Path
+		w.Write([]byte(`{"applied":["1_test.go"]}`))
+	}))
+	defer server.Close()
+
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	rootCmd := &cobra.Command{Use: "test"}
+	migratecmd.MustRegister(app, rootCmd, migratecmd.Config{})
+
+	// unsupported remote command
+	rootCmd.SetArgs([]string{"migrate", "down", "--remote", server.URL, "--token", "test_token"})
+	if err := rootCmd.Execute(); err == nil {
+		t.Fatal("Expected unsupported remote command error, got nil")
+	}
+	if called != "" {
+		t.Fatalf("Expected no remote calls, got %q", called)
+	}
+
+	rootCmd.SetArgs([]string{"migrate", "up", "--remote", server.URL, "--token", "test_token"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if expected := "POST /api/migrations/up"; called != expected {
+		t.Fatalf("Expected remote call %q, got %q", expected, called)
+	}
+}
@@ -2,6 +2,7 @@ package pocketbase
 
 import (
 	"io"
+	"log/slog"
 	"os"
 	"os/signal"
 	"path/filepath"
@@ -116,6 +117,7 @@ func NewWithConfig(config Config) *PocketBase {
 
 	// initialize the app instance
 	pb.appWrapper = &appWrapper{core.NewBaseApp(core.BaseAppConfig{
+		Version:          Version,
 		IsDev:            pb.devFlag,
 		DataDir:          pb.dataDirFlag,
 		EncryptionEnv:    pb.encryptionEnvFlag,
@@ -137,6 +139,14 @@ func (pb *PocketBase) Start() error {
 	// register system commands
 	pb.RootCmd.AddCommand(cmd.NewAdminCommand(pb))
 	pb.RootCmd.AddCommand(cmd.NewServeCommand(pb, !pb.hideStartBanner))
+	pb.RootCmd.AddCommand(cmd.NewGenerateCommand(pb))
+	pb.RootCmd.AddCommand(cmd.NewCollectionsCommand(pb))
+	pb.RootCmd.AddCommand(cmd.NewRecordsCommand(pb))
+	pb.RootCmd.AddCommand(cmd.NewSettingsCommand(pb))
+	pb.RootCmd.AddCommand(cmd.NewBackupsCommand(pb))
+	pb.RootCmd.AddCommand(cmd.NewHealthCommand(pb))
+	pb.RootCmd.AddCommand(cmd.NewRulesCommand(pb))
+	pb.RootCmd.AddCommand(cmd.NewBenchCommand(pb))
 
 	return pb.Execute()
 }
@@ -164,6 +174,22 @@ func (pb *PocketBase) Execute() error {
 		done <- true
 	}()
 
+	// listen for hangup signal to reload the app settings
+	// (eg. after a secret rotation in a container)
+	go func() {
+		sigch := make(chan os.Signal, 1)
+		signal.Notify(sigch, syscall.SIGHUP)
+
+		for range sigch {
+			if err := pb.ReloadSettings(); err != nil {
+				pb.Logger().Error("Failed to reload the app settings", slog.String("error", err.Error()))
+				continue
+			}
+
+			pb.Logger().Info("Reloaded the app settings")
+		}
+	}()
+
 	// execute the root command
 	go func() {
 		// note: leave to the commands to decide whether to print their error
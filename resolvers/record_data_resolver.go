@@ -0,0 +1,100 @@
+package resolvers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
+	"github.com/pocketbase/pocketbase/tools/list"
+	"github.com/pocketbase/pocketbase/tools/search"
+	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/pocketbase/pocketbase/tools/types"
+	"github.com/spf13/cast"
+)
+
+// ensure that `search.FieldResolver` interface is implemented
+var _ search.FieldResolver = (*RecordDataResolver)(nil)
+
+// RecordDataResolver defines a search.FieldResolver that resolves the
+// record fields to their in-memory (aka. not yet persisted) values.
+//
+// It is used to evaluate field validation expressions (eg. "end > start")
+// against the submitted record data.
+//
+// Supported identifiers are the record base fields and schema fields
+// with an optional ":length" modifier for string and multi-valued fields.
+type RecordDataResolver struct {
+	collection *models.Collection
+	data       map[string]any
+}
+
+// NewRecordDataResolver creates and initializes a new RecordDataResolver
+// for the provided collection and record data.
+func NewRecordDataResolver(collection *models.Collection, data map[string]any) *RecordDataResolver {
+	return &RecordDataResolver{
+		collection: collection,
+		data:       data,
+	}
+}
+
+// UpdateQuery implements `search.FieldResolver` interface.
+func (r *RecordDataResolver) UpdateQuery(query *dbx.SelectQuery) error {
+	// nothing to update...
+	return nil
+}
+
+// Resolve implements `search.FieldResolver` interface.
+func (r *RecordDataResolver) Resolve(fieldName string) (*search.ResolverResult, error) {
+	name, modifier, _ := strings.Cut(fieldName, ":")
+
+	var field *schema.SchemaField
+	if !list.ExistInSlice(name, schema.BaseModelFieldNames()) {
+		field = r.collection.Schema.GetFieldByName(name)
+		if field == nil {
+			return nil, fmt.Errorf("unknown field %q", name)
+		}
+	}
+
+	value := r.data[name]
+
+	switch modifier {
+	case "":
+		value = normalizeDataValue(value)
+	case lengthModifier:
+		if field != nil && list.ExistInSlice(field.Type, schema.ArraybleFieldTypes()) {
+			value = len(list.ToUniqueStringSlice(value))
+		} else {
+			value = len([]rune(cast.ToString(value)))
+		}
+	default:
+		return nil, fmt.Errorf("unsupported modifier %q", modifier)
+	}
+
+	placeholder := "d" + security.PseudorandomString(5)
+
+	return &search.ResolverResult{
+		Identifier: "{:" + placeholder + "}",
+		Params:     dbx.Params{placeholder: value},
+	}, nil
+}
+
+// normalizeDataValue converts the provided field value into a db param value.
+func normalizeDataValue(value any) any {
+	switch v := value.(type) {
+	case nil, bool, string, int, int64, float64:
+		return v
+	case types.DateTime:
+		if v.IsZero() {
+			return ""
+		}
+		return v.String()
+	case []string, types.JsonArray[any], types.JsonMap, types.JsonRaw, []any, map[string]any:
+		raw, _ := json.Marshal(v)
+		return string(raw)
+	default:
+		return cast.ToString(v)
+	}
+}
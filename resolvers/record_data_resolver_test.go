@@ -0,0 +1,67 @@
+package resolvers_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/resolvers"
+	"github.com/pocketbase/pocketbase/tests"
+)
+
+func TestRecordDataResolverResolve(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection, err := app.Dao().FindCollectionByNameOrId("demo1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := resolvers.NewRecordDataResolver(collection, map[string]any{
+		"id":          "test_id",
+		"text":        "𝌆abc",
+		"number":      10.5,
+		"select_many": []string{"optionA", "optionB"},
+	})
+
+	scenarios := []struct {
+		field         string
+		expectError   bool
+		expectedValue any
+	}{
+		{"missing", true, nil},
+		{"text:unknown", true, nil},
+		{"@request.data.text", true, nil},
+		{"id", false, "test_id"},
+		{"text", false, "𝌆abc"},
+		{"text:length", false, 4},
+		{"number", false, 10.5},
+		{"select_many", false, `["optionA","optionB"]`},
+		{"select_many:length", false, 2},
+		{"bool", false, nil},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.field, func(t *testing.T) {
+			result, err := r.Resolve(s.field)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if hasErr {
+				return
+			}
+
+			if len(result.Params) != 1 {
+				t.Fatalf("Expected 1 param, got %v", result.Params)
+			}
+
+			for _, v := range result.Params {
+				if v != s.expectedValue {
+					t.Fatalf("Expected value %v, got %v", s.expectedValue, v)
+				}
+			}
+		})
+	}
+}
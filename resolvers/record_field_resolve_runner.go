@@ -63,6 +63,11 @@ func (r *runner) run() (*search.ResolverResult, error) {
 		return r.processCollectionField()
 	}
 
+	// check for @following field (aka. the expanded "@following(FIELD)" macro)
+	if r.activeProps[0] == "@following" {
+		return r.processFollowingField()
+	}
+
 	if r.activeProps[0] == "@request" {
 		if r.resolver.requestInfo == nil {
 			return &search.ResolverResult{Identifier: "NULL"}, nil
@@ -176,6 +181,75 @@ func (r *runner) processCollectionField() (*search.ResolverResult, error) {
 	return r.processActiveProps()
 }
 
+// processFollowingField resolves the "@following.FIELD" identifier into
+// a boolean expression that checks whether the FIELD record reference
+// (the record id or a relation field) is followed by the request auth record.
+func (r *runner) processFollowingField() (*search.ResolverResult, error) {
+	if len(r.activeProps) != 2 {
+		return nil, fmt.Errorf("invalid @following field path in %q", r.fieldName)
+	}
+
+	name := r.activeProps[1]
+	collection := r.resolver.baseCollection
+
+	var targetCollectionId string
+	var isMultiple bool
+
+	if name == schema.FieldNameId {
+		targetCollectionId = collection.Id
+	} else {
+		field := collection.Schema.GetFieldByName(name)
+		if field == nil || field.Type != schema.FieldTypeRelation {
+			return nil, fmt.Errorf("@following requires the id or a relation field, got %q", name)
+		}
+
+		field.InitOptions()
+		options, ok := field.Options.(*schema.RelationOptions)
+		if !ok {
+			return nil, fmt.Errorf("failed to initialize field %q options", name)
+		}
+
+		targetCollectionId = options.CollectionId
+		isMultiple = options.IsMultiple()
+	}
+
+	var followerCollectionId, followerId string
+	if r.resolver.requestInfo != nil && r.resolver.requestInfo.AuthRecord != nil {
+		followerCollectionId = r.resolver.requestInfo.AuthRecord.Collection().Id
+		followerId = r.resolver.requestInfo.AuthRecord.Id
+	}
+
+	suffix := security.PseudorandomString(5)
+	params := dbx.Params{
+		"fc" + suffix: followerCollectionId,
+		"fi" + suffix: followerId,
+		"tc" + suffix: targetCollectionId,
+	}
+
+	// note: the conditions match the follows table unique index
+	subquery := fmt.Sprintf(
+		"SELECT [[__fl.targetId]] FROM {{%s}} [[__fl]] WHERE [[__fl.followerCollectionId]] = {:fc%s} AND [[__fl.followerId]] = {:fi%s} AND [[__fl.targetCollectionId]] = {:tc%s}",
+		(&models.Follow{}).TableName(),
+		suffix,
+		suffix,
+		suffix,
+	)
+
+	column := r.activeTableAlias + "." + inflector.Columnify(name)
+
+	var identifier string
+	if isMultiple {
+		identifier = fmt.Sprintf("(EXISTS (SELECT 1 FROM %s [[__fle]] WHERE [[__fle.value]] IN (%s)))", jsonEach(column), subquery)
+	} else {
+		identifier = fmt.Sprintf("([[%s]] IN (%s))", column, subquery)
+	}
+
+	return &search.ResolverResult{
+		Identifier: identifier,
+		Params:     params,
+	}, nil
+}
+
 func (r *runner) processRequestAuthField() (*search.ResolverResult, error) {
 	// plain auth field
 	// ---
@@ -190,6 +264,14 @@ func (r *runner) processRequestAuthField() (*search.ResolverResult, error) {
 	}
 
 	collection := r.resolver.requestInfo.AuthRecord.Collection()
+
+	// assigned auth record roles
+	// (an auth collection field with the same name takes precedence for backward compatibility)
+	// ---
+	if r.fieldName == requestAuthRolesField && collection.Schema.GetFieldByName(r.activeProps[2]) == nil {
+		return r.processRequestAuthRolesField()
+	}
+
 	r.resolver.loadedCollections = append(r.resolver.loadedCollections, collection)
 
 	r.activeCollectionName = collection.Name
@@ -225,11 +307,84 @@ func (r *runner) processRequestAuthField() (*search.ResolverResult, error) {
 	return r.processActiveProps()
 }
 
+// processRequestAuthRolesField resolves the "@request.auth.roles"
+// identifier into the names of the roles assigned to the request auth record.
+//
+// Similar to the multiple relation fields, the identifier is
+// resolved with joins and a multi-match subquery (aka. "?=" checks
+// whether any of the roles matches, "=" whether all of them match).
+func (r *runner) processRequestAuthRolesField() (*search.ResolverResult, error) {
+	authRecord := r.resolver.requestInfo.AuthRecord
+
+	recordRolesTable := (&models.RecordRole{}).TableName()
+	rolesTable := (&models.Role{}).TableName()
+
+	recordRolesOn := func(alias string) dbx.Expression {
+		suffix := security.PseudorandomString(5)
+
+		return dbx.NewExp(
+			fmt.Sprintf(
+				"[[%s.collectionId]] = {:rc%s} AND [[%s.recordId]] = {:ri%s}",
+				alias,
+				suffix,
+				alias,
+				suffix,
+			),
+			dbx.Params{
+				"rc" + suffix: authRecord.Collection().Id,
+				"ri" + suffix: authRecord.Id,
+			},
+		)
+	}
+
+	// join the auth record roles to the main query
+	r.resolver.registerJoin(recordRolesTable, "__auth_record_roles", recordRolesOn("__auth_record_roles"))
+	r.resolver.registerJoin(
+		rolesTable,
+		"__auth_roles",
+		dbx.NewExp("[[__auth_roles.id]] = [[__auth_record_roles.roleId]]"),
+	)
+
+	// join the auth record roles to the multi-match subquery
+	r.multiMatch.joins = append(
+		r.multiMatch.joins,
+		&join{
+			tableName:  recordRolesTable,
+			tableAlias: "__mm__auth_record_roles",
+			on:         recordRolesOn("__mm__auth_record_roles"),
+		},
+		&join{
+			tableName:  rolesTable,
+			tableAlias: "__mm__auth_roles",
+			on:         dbx.NewExp("[[__mm__auth_roles.id]] = [[__mm__auth_record_roles.roleId]]"),
+		},
+	)
+	r.multiMatch.valueIdentifier = "[[__mm__auth_roles.name]]"
+
+	return &search.ResolverResult{
+		Identifier:         "[[__auth_roles.name]]",
+		MultiMatchSubQuery: r.multiMatch,
+	}, nil
+}
+
 func (r *runner) processRequestInfoLengthModifier(dataField *schema.SchemaField) (*search.ResolverResult, error) {
-	dataItems := list.ToUniqueStringSlice(r.resolver.requestInfo.Data[dataField.Name])
+	var total int
+	if dataField.Type == schema.FieldTypeList {
+		// the list items are not unique
+		switch v := dataField.PrepareValue(r.resolver.requestInfo.Data[dataField.Name]).(type) {
+		case []string:
+			total = len(v)
+		case []float64:
+			total = len(v)
+		case []any:
+			total = len(v)
+		}
+	} else {
+		total = len(list.ToUniqueStringSlice(r.resolver.requestInfo.Data[dataField.Name]))
+	}
 
 	result := &search.ResolverResult{
-		Identifier: fmt.Sprintf("%d", len(dataItems)),
+		Identifier: fmt.Sprintf("%d", total),
 	}
 
 	return result, nil
@@ -390,24 +545,31 @@ func (r *runner) processActiveProps() (*search.ResolverResult, error) {
 			// arrayble fields ":length" modifier
 			// -------------------------------------------------------
 			if modifier == lengthModifier && list.ExistInSlice(field.Type, schema.ArraybleFieldTypes()) {
+				lengthFunc := jsonArrayLength
+				if field.Type == schema.FieldTypeList {
+					// the list values are always stored as json array
+					// (the plain expression is also used by the list length index)
+					lengthFunc = listLength
+				}
+
 				jePair := r.activeTableAlias + "." + cleanFieldName
 
 				result := &search.ResolverResult{
-					Identifier: jsonArrayLength(jePair),
+					Identifier: lengthFunc(jePair),
 				}
 
 				if r.withMultiMatch {
 					jePair2 := r.multiMatchActiveTableAlias + "." + cleanFieldName
-					r.multiMatch.valueIdentifier = jsonArrayLength(jePair2)
+					r.multiMatch.valueIdentifier = lengthFunc(jePair2)
 					result.MultiMatchSubQuery = r.multiMatch
 				}
 
 				return result, nil
 			}
 
-			// select field with ":each" modifier
+			// select and list fields with ":each" modifier
 			// -------------------------------------------------------
-			if field.Type == schema.FieldTypeSelect && modifier == eachModifier {
+			if (field.Type == schema.FieldTypeSelect || field.Type == schema.FieldTypeList) && modifier == eachModifier {
 				jePair := r.activeTableAlias + "." + cleanFieldName
 				jeAlias := r.activeTableAlias + "_" + cleanFieldName + "_je"
 				r.resolver.registerJoin(jsonEach(jePair), jeAlias, nil)
@@ -417,13 +579,15 @@ func (r *runner) processActiveProps() (*search.ResolverResult, error) {
 				}
 
 				field.InitOptions()
-				options, ok := field.Options.(*schema.SelectOptions)
-				if !ok {
-					return nil, fmt.Errorf("failed to initialize field %q options", prop)
-				}
-
-				if options.MaxSelect != 1 {
+				switch options := field.Options.(type) {
+				case *schema.SelectOptions:
+					if options.MaxSelect != 1 {
+						r.withMultiMatch = true
+					}
+				case *schema.ListOptions:
 					r.withMultiMatch = true
+				default:
+					return nil, fmt.Errorf("failed to initialize field %q options", prop)
 				}
 
 				if r.withMultiMatch {
@@ -465,7 +629,8 @@ func (r *runner) processActiveProps() (*search.ResolverResult, error) {
 		}
 
 		// check if it is a json field
-		if field.Type == schema.FieldTypeJson {
+		// (markdown fields are also stored as json, eg. "content.source")
+		if field.Type == schema.FieldTypeJson || field.Type == schema.FieldTypeMarkdown {
 			var jsonPath strings.Builder
 			jsonPath.WriteString("$")
 			for _, p := range r.activeProps[i+1:] {
@@ -593,6 +758,10 @@ func jsonArrayLength(tableColumnPair string) string {
 	)
 }
 
+func listLength(tableColumnPair string) string {
+	return fmt.Sprintf("json_array_length([[%s]])", tableColumnPair)
+}
+
 func jsonEach(tableColumnPair string) string {
 	return fmt.Sprintf(
 		// note: the case is used to normalize value access for single and multiple relations.
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/pocketbase/dbx"
 	"github.com/pocketbase/pocketbase/models"
@@ -35,6 +36,10 @@ var plainRequestAuthFields = []string{
 	"@request.auth." + schema.FieldNameUpdated,
 }
 
+// requestAuthRolesField is the filter field with the names
+// of the roles assigned to the request auth record.
+const requestAuthRolesField = "@request.auth.roles"
+
 // ensure that `search.FieldResolver` interface is implemented
 var _ search.FieldResolver = (*RecordFieldResolver)(nil)
 
@@ -90,17 +95,24 @@ func NewRecordFieldResolver(
 		allowedFields: []string{
 			`^\w+[\w\.\:]*$`,
 			`^\@request\.method$`,
+			`^\@request\.ip$`,
+			`^\@request\.hour$`,
+			`^\@request\.geo\.\w+$`,
 			`^\@request\.auth\.[\w\.\:]*\w+$`,
 			`^\@request\.data\.[\w\.\:]*\w+$`,
 			`^\@request\.query\.[\w\.\:]*\w+$`,
 			`^\@request\.headers\.\w+$`,
 			`^\@collection\.\w+(\:\w+)?\.[\w\.\:]*\w+$`,
+			`^\@following\.\w+$`,
 		},
 	}
 
 	r.staticRequestInfo = map[string]any{}
 	if r.requestInfo != nil {
 		r.staticRequestInfo["method"] = r.requestInfo.Method
+		r.staticRequestInfo["ip"] = r.requestInfo.Ip
+		r.staticRequestInfo["geo"] = r.requestInfo.Geo
+		r.staticRequestInfo["hour"] = time.Now().UTC().Hour()
 		r.staticRequestInfo["query"] = r.requestInfo.Query
 		r.staticRequestInfo["headers"] = r.requestInfo.Headers
 		r.staticRequestInfo["data"] = r.requestInfo.Data
@@ -142,14 +154,19 @@ func (r *RecordFieldResolver) UpdateQuery(query *dbx.SelectQuery) error {
 //	someSelect.each
 //	project.screen.status
 //	@request.status
+//	@request.ip
+//	@request.hour
+//	@request.geo.country
 //	@request.query.filter
 //	@request.headers.x_token
 //	@request.auth.someRelation.name
+//	@request.auth.roles
 //	@request.data.someRelation.name
 //	@request.data.someField
 //	@request.data.someSelect:each
 //	@request.data.someField:isset
 //	@collection.product.name
+//	@following.author
 func (r *RecordFieldResolver) Resolve(fieldName string) (*search.ResolverResult, error) {
 	return parseAndRun(fieldName, r)
 }
@@ -2,11 +2,15 @@ package resolvers_test
 
 import (
 	"encoding/json"
+	"fmt"
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/pocketbase/dbx"
 	"github.com/pocketbase/pocketbase/models"
+	"github.com/pocketbase/pocketbase/models/schema"
 	"github.com/pocketbase/pocketbase/resolvers"
 	"github.com/pocketbase/pocketbase/tests"
 	"github.com/pocketbase/pocketbase/tools/list"
@@ -173,6 +177,13 @@ func TestRecordFieldResolverUpdateQuery(t *testing.T) {
 			false,
 			"SELECT DISTINCT `demo4`.* FROM `demo4` LEFT JOIN `users` `__auth_users` ON `__auth_users`.`id`={:TEST} LEFT JOIN `demo2` `__auth_users_rel` ON [[__auth_users_rel.id]] = [[__auth_users.rel]] WHERE ({:TEST} > 1 OR {:TEST} > 1 OR [[__auth_users_rel.title]] > 1 OR NULL > 1)",
 		},
+		{
+			"@request.auth.roles (opt/any and multi-match operators)",
+			"demo4",
+			"@request.auth.roles ?= 'editor' || @request.auth.roles = 'moderator'",
+			false,
+			"SELECT DISTINCT `demo4`.* FROM `demo4` LEFT JOIN `_recordRoles` `__auth_record_roles` ON [[__auth_record_roles.collectionId]] = {:rcTEST} AND [[__auth_record_roles.recordId]] = {:riTEST} LEFT JOIN `_roles` `__auth_roles` ON [[__auth_roles.id]] = [[__auth_record_roles.roleId]] WHERE ([[__auth_roles.name]] = {:TEST} OR (([[__auth_roles.name]] = {:TEST}) AND (NOT EXISTS (SELECT 1 FROM (SELECT [[__mm__auth_roles.name]] as [[multiMatchValue]] FROM `demo4` `__mm_demo4` LEFT JOIN `_recordRoles` `__mm__auth_record_roles` ON [[__mm__auth_record_roles.collectionId]] = {:rcTEST} AND [[__mm__auth_record_roles.recordId]] = {:riTEST} LEFT JOIN `_roles` `__mm__auth_roles` ON [[__mm__auth_roles.id]] = [[__mm__auth_record_roles.roleId]] WHERE `__mm_demo4`.`id` = `demo4`.`id`) {{__smTEST}} WHERE NOT ([[__smTEST.multiMatchValue]] = {:TEST})))))",
+		},
 		{
 			"hidden field with system filters (multi-match and ignore emailVisibility)",
 			"demo4",
@@ -296,6 +307,27 @@ func TestRecordFieldResolverUpdateQuery(t *testing.T) {
 			false,
 			"SELECT DISTINCT `demo4`.* FROM `demo4` LEFT JOIN `demo4` `__data_demo4` ON [[__data_demo4.id]]={:TEST} LEFT JOIN `demo3` `__data_demo3` ON [[__data_demo3.id]] IN ({:TEST}, {:TEST}) LEFT JOIN `demo4` `demo4_self_rel_one` ON [[demo4_self_rel_one.id]] = [[demo4.self_rel_one]] LEFT JOIN json_each(CASE WHEN json_valid([[demo4_self_rel_one.rel_many_cascade]]) THEN [[demo4_self_rel_one.rel_many_cascade]] ELSE json_array([[demo4_self_rel_one.rel_many_cascade]]) END) `demo4_self_rel_one_rel_many_cascade_je` LEFT JOIN `demo3` `demo4_self_rel_one_rel_many_cascade` ON [[demo4_self_rel_one_rel_many_cascade.id]] = [[demo4_self_rel_one_rel_many_cascade_je.value]] WHERE (json_array_length(CASE WHEN json_valid([[__data_demo4.self_rel_many]]) THEN [[__data_demo4.self_rel_many]] ELSE (CASE WHEN [[__data_demo4.self_rel_many]] = '' OR [[__data_demo4.self_rel_many]] IS NULL THEN json_array() ELSE json_array([[__data_demo4.self_rel_many]]) END) END) > {:TEST} AND json_array_length(CASE WHEN json_valid([[__data_demo4.self_rel_many]]) THEN [[__data_demo4.self_rel_many]] ELSE (CASE WHEN [[__data_demo4.self_rel_many]] = '' OR [[__data_demo4.self_rel_many]] IS NULL THEN json_array() ELSE json_array([[__data_demo4.self_rel_many]]) END) END) > {:TEST} AND json_array_length(CASE WHEN json_valid([[__data_demo3.files]]) THEN [[__data_demo3.files]] ELSE (CASE WHEN [[__data_demo3.files]] = '' OR [[__data_demo3.files]] IS NULL THEN json_array() ELSE json_array([[__data_demo3.files]]) END) END) < {:TEST} AND ((json_array_length(CASE WHEN json_valid([[__data_demo3.files]]) THEN [[__data_demo3.files]] ELSE (CASE WHEN [[__data_demo3.files]] = '' OR [[__data_demo3.files]] IS NULL THEN json_array() ELSE json_array([[__data_demo3.files]]) END) END) < {:TEST}) AND (NOT EXISTS (SELECT 1 FROM (SELECT json_array_length(CASE WHEN json_valid([[__data_mm_demo3.files]]) THEN [[__data_mm_demo3.files]] ELSE (CASE WHEN [[__data_mm_demo3.files]] = '' OR [[__data_mm_demo3.files]] IS NULL THEN json_array() ELSE json_array([[__data_mm_demo3.files]]) END) END) as [[multiMatchValue]] FROM `demo4` `__mm_demo4` LEFT JOIN `demo3` `__data_mm_demo3` ON `__data_mm_demo3`.`id` IN ({:TEST}, {:TEST}) WHERE `__mm_demo4`.`id` = `demo4`.`id`) {{__smTEST}} WHERE ((NOT ([[__smTEST.multiMatchValue]] < {:TEST})) OR ([[__smTEST.multiMatchValue]] IS NULL))))) AND json_array_length(CASE WHEN json_valid([[demo4_self_rel_one.self_rel_many]]) THEN [[demo4_self_rel_one.self_rel_many]] ELSE (CASE WHEN [[demo4_self_rel_one.self_rel_many]] = '' OR [[demo4_self_rel_one.self_rel_many]] IS NULL THEN json_array() ELSE json_array([[demo4_self_rel_one.self_rel_many]]) END) END) = {:TEST} AND json_array_length(CASE WHEN json_valid([[demo4_self_rel_one.self_rel_many]]) THEN [[demo4_self_rel_one.self_rel_many]] ELSE (CASE WHEN [[demo4_self_rel_one.self_rel_many]] = '' OR [[demo4_self_rel_one.self_rel_many]] IS NULL THEN json_array() ELSE json_array([[demo4_self_rel_one.self_rel_many]]) END) END) = {:TEST} AND ((json_array_length(CASE WHEN json_valid([[demo4_self_rel_one_rel_many_cascade.files]]) THEN [[demo4_self_rel_one_rel_many_cascade.files]] ELSE (CASE WHEN [[demo4_self_rel_one_rel_many_cascade.files]] = '' OR [[demo4_self_rel_one_rel_many_cascade.files]] IS NULL THEN json_array() ELSE json_array([[demo4_self_rel_one_rel_many_cascade.files]]) END) END) IS NOT {:TEST}) AND (NOT EXISTS (SELECT 1 FROM (SELECT json_array_length(CASE WHEN json_valid([[__mm_demo4_self_rel_one_rel_many_cascade.files]]) THEN [[__mm_demo4_self_rel_one_rel_many_cascade.files]] ELSE (CASE WHEN [[__mm_demo4_self_rel_one_rel_many_cascade.files]] = '' OR [[__mm_demo4_self_rel_one_rel_many_cascade.files]] IS NULL THEN json_array() ELSE json_array([[__mm_demo4_self_rel_one_rel_many_cascade.files]]) END) END) as [[multiMatchValue]] FROM `demo4` `__mm_demo4` LEFT JOIN `demo4` `__mm_demo4_self_rel_one` ON [[__mm_demo4_self_rel_one.id]] = [[__mm_demo4.self_rel_one]] LEFT JOIN json_each(CASE WHEN json_valid([[__mm_demo4_self_rel_one.rel_many_cascade]]) THEN [[__mm_demo4_self_rel_one.rel_many_cascade]] ELSE json_array([[__mm_demo4_self_rel_one.rel_many_cascade]]) END) `__mm_demo4_self_rel_one_rel_many_cascade_je` LEFT JOIN `demo3` `__mm_demo4_self_rel_one_rel_many_cascade` ON [[__mm_demo4_self_rel_one_rel_many_cascade.id]] = [[__mm_demo4_self_rel_one_rel_many_cascade_je.value]] WHERE `__mm_demo4`.`id` = `demo4`.`id`) {{__smTEST}} WHERE ((NOT ([[__smTEST.multiMatchValue]] IS NOT {:TEST})) OR ([[__smTEST.multiMatchValue]] IS NULL))))) AND json_array_length(CASE WHEN json_valid([[demo4_self_rel_one_rel_many_cascade.files]]) THEN [[demo4_self_rel_one_rel_many_cascade.files]] ELSE (CASE WHEN [[demo4_self_rel_one_rel_many_cascade.files]] = '' OR [[demo4_self_rel_one_rel_many_cascade.files]] IS NULL THEN json_array() ELSE json_array([[demo4_self_rel_one_rel_many_cascade.files]]) END) END) IS NOT {:TEST})",
 		},
+		{
+			"@following macro",
+			"demo4",
+			"@following(self_rel_one) && @following(self_rel_many)",
+			false,
+			"SELECT `demo4`.* FROM `demo4` WHERE ((([[demo4.self_rel_one]] IN (SELECT [[__fl.targetId]] FROM {{_follows}} [[__fl]] WHERE [[__fl.followerCollectionId]] = {:TEST} AND [[__fl.followerId]] = {:TEST} AND [[__fl.targetCollectionId]] = {:TEST})) = 1) AND ((EXISTS (SELECT 1 FROM json_each(CASE WHEN json_valid([[demo4.self_rel_many]]) THEN [[demo4.self_rel_many]] ELSE json_array([[demo4.self_rel_many]]) END) [[__fle]] WHERE [[__fle.value]] IN (SELECT [[__fl.targetId]] FROM {{_follows}} [[__fl]] WHERE [[__fl.followerCollectionId]] = {:TEST} AND [[__fl.followerId]] = {:TEST} AND [[__fl.targetCollectionId]] = {:TEST}))) = 1))",
+		},
+		{
+			"subquery with the default id projection",
+			"demo4",
+			"id in (@collection.demo1.text ?= @request.auth.id)",
+			false,
+			"SELECT `demo4`.* FROM `demo4` WHERE [[demo4.id]] IN (SELECT [[demo1.id]] FROM `demo1` WHERE [[demo1.text]] = {:TEST})",
+		},
+		{
+			"subquery with custom projection and relation join",
+			"demo4",
+			"id in (@collection.demo4.self_rel_one where @collection.demo4.self_rel_one.title = 'test' && @collection.demo4.title != '@collection.demo4.title')",
+			false,
+			"SELECT `demo4`.* FROM `demo4` WHERE ([[demo4.id]] IN (SELECT [[demo4.self_rel_one]] FROM `demo4` LEFT JOIN `demo4` `demo4_self_rel_one` ON [[demo4_self_rel_one.id]] = [[demo4.self_rel_one]] WHERE ([[demo4_self_rel_one.title]] = {:TEST} AND [[demo4.title]] IS NOT {:TEST})))",
+		},
 		{
 			"json_extract and json_array_length COALESCE equal normalizations",
 			"demo4",
@@ -341,6 +373,49 @@ func TestRecordFieldResolverUpdateQuery(t *testing.T) {
 	}
 }
 
+func TestRecordFieldResolverResolveSubquery(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection, err := app.Dao().FindCollectionByNameOrId("demo4")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := resolvers.NewRecordFieldResolver(app.Dao(), collection, nil, false)
+
+	scenarios := []struct {
+		subquery    string
+		expectError bool
+	}{
+		{"", true},
+		{"title = 'test'", true},
+		{"title = '@collection.demo1.text'", true},
+		{"@collection.missing.title = 'test'", true},
+		{"@collection.demo1.missing = 'test'", true},
+		{"@collection.demo1.missing where @collection.demo1.text = 'test'", true},
+		{"@collection.demo1.text = ", true},
+		{"@collection.demo1.text = 'test'", false},
+		{"@collection.demo1.text where text = 'test'", false},
+		{"@collection.demo1.text = 'test' && @collection.demo2.title = 'test'", false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.subquery, func(t *testing.T) {
+			expr, err := r.ResolveSubquery(s.subquery)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if !hasErr && expr == nil {
+				t.Fatal("Expected non-nil subquery expression")
+			}
+		})
+	}
+}
+
 func TestRecordFieldResolverResolveSchemaFields(t *testing.T) {
 	app, _ := tests.NewTestApp()
 	defer app.Cleanup()
@@ -386,6 +461,8 @@ func TestRecordFieldResolverResolveSchemaFields(t *testing.T) {
 		// @request.auth relation join:
 		{"@request.auth.rel", false, "[[__auth_users.rel]]"},
 		{"@request.auth.rel.title", false, "[[__auth_users_rel.title]]"},
+		// @request.auth roles:
+		{"@request.auth.roles", false, "[[__auth_roles.name]]"},
 		// @collection fieds:
 		{"@collect", true, ""},
 		{"collection.demo4.title", true, ""},
@@ -402,6 +479,11 @@ func TestRecordFieldResolverResolveSchemaFields(t *testing.T) {
 		{"@collection.demo4.updated", false, "[[__collection_demo4.updated]]"},
 		{"@collection.demo4.self_rel_many.missing", true, ""},
 		{"@collection.demo4.self_rel_many.self_rel_one.self_rel_many.self_rel_one.title", false, "[[__collection_demo4_self_rel_many_self_rel_one_self_rel_many_self_rel_one.title]]"},
+		// @following fields:
+		{"@following", true, ""},
+		{"@following.title", true, ""},
+		{"@following.missing", true, ""},
+		{"@following.self_rel_one.title", true, ""},
 	}
 
 	for _, s := range scenarios {
@@ -456,11 +538,17 @@ func TestRecordFieldResolverResolveStaticRequestInfoFields(t *testing.T) {
 		Headers: map[string]any{
 			"d": "789",
 		},
+		Ip: "127.0.0.1",
+		Geo: map[string]any{
+			"country": "BG",
+		},
 		AuthRecord: authRecord,
 	}
 
 	r := resolvers.NewRecordFieldResolver(app.Dao(), collection, requestInfo, true)
 
+	currentHour := fmt.Sprint(time.Now().UTC().Hour())
+
 	scenarios := []struct {
 		fieldName        string
 		expectError      bool
@@ -471,6 +559,11 @@ func TestRecordFieldResolverResolveStaticRequestInfoFields(t *testing.T) {
 		{"@request.invalid_format2!", true, ""},
 		{"@request.missing", true, ""},
 		{"@request.method", false, `"get"`},
+		{"@request.ip", false, `"127.0.0.1"`},
+		{"@request.hour", false, currentHour},
+		{"@request.geo", true, ``},
+		{"@request.geo.country", false, `"BG"`},
+		{"@request.geo.missing", false, ``},
 		{"@request.query", true, ``},
 		{"@request.query.a", false, `123`},
 		{"@request.query.a.missing", false, ``},
@@ -539,3 +632,90 @@ func TestRecordFieldResolverResolveStaticRequestInfoFields(t *testing.T) {
 		}
 	}
 }
+
+func TestRecordFieldResolverListFields(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection := &models.Collection{}
+	collection.Name = "lists_test"
+	collection.Type = models.CollectionTypeBase
+	collection.Schema = schema.NewSchema(
+		&schema.SchemaField{
+			Name:    "tags",
+			Type:    schema.FieldTypeList,
+			Options: &schema.ListOptions{Indexed: true},
+		},
+		&schema.SchemaField{
+			Name:    "scores",
+			Type:    schema.FieldTypeList,
+			Options: &schema.ListOptions{ElementType: schema.ListElementTypeNumber},
+		},
+	)
+	if err := app.Dao().SaveCollection(collection); err != nil {
+		t.Fatal(err)
+	}
+
+	// check the generated length index
+	var total int
+	err := app.Dao().DB().Select("count(*)").
+		From("sqlite_master").
+		AndWhere(dbx.HashExp{"type": "index", "name": "_" + collection.Id + "_tags_length_idx"}).
+		Row(&total)
+	if err != nil || total != 1 {
+		t.Fatalf("Expected the tags length index to be created, got %d (%v)", total, err)
+	}
+
+	data := []struct {
+		id     string
+		tags   []string
+		scores []float64
+	}{
+		{"list00000000001", []string{"a", "b"}, []float64{1, 2}},
+		{"list00000000002", []string{"b", "c", "c"}, []float64{3}},
+		{"list00000000003", []string{}, []float64{}},
+	}
+	for _, d := range data {
+		record := models.NewRecord(collection)
+		record.Id = d.id
+		record.MarkAsNew()
+		record.Set("tags", d.tags)
+		record.Set("scores", d.scores)
+		if err := app.Dao().SaveRecord(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	scenarios := []struct {
+		filter      string
+		expectedIds []string
+	}{
+		{"contains(tags, 'a')", []string{"list00000000001"}},
+		{"contains(tags, 'c')", []string{"list00000000002"}},
+		{"contains(tags, 'missing')", []string{}},
+		{"overlaps(tags, 'a', 'c')", []string{"list00000000001", "list00000000002"}},
+		{"length(tags) = 3", []string{"list00000000002"}},
+		{"tags:length = 0", []string{"list00000000003"}},
+		{"contains(scores, 3)", []string{"list00000000002"}},
+		{"scores:each > 1", []string{"list00000000002"}},
+		{"contains(tags, 'b') && length(scores) = 2", []string{"list00000000001"}},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.filter, func(t *testing.T) {
+			records, err := app.Dao().FindRecordsByFilter(collection.Id, s.filter, "id", 0, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ids := make([]string, len(records))
+			for i, r := range records {
+				ids[i] = r.Id
+			}
+
+			if strings.Join(ids, ",") != strings.Join(s.expectedIds, ",") {
+				t.Fatalf("Expected records %v, got %v", s.expectedIds, ids)
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package resolvers
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+// ensure that `search.SubqueryResolver` interface is implemented
+var _ search.SubqueryResolver = (*RecordFieldResolver)(nil)
+
+// subqueryProjectionRegex matches the optional subquery
+// "@collection.NAME.FIELD where FILTER" projection field.
+var subqueryProjectionRegex = regexp.MustCompile(`(?s)^(@collection\.\w+\.[\w\.\:]*\w+)\s+where\s+(.+)$`)
+
+// subqueryCollectionRegex matches the "@collection.NAME." identifier prefix.
+var subqueryCollectionRegex = regexp.MustCompile(`^@collection\.(\w+)\.`)
+
+// ResolveSubquery implements the `search.SubqueryResolver` interface.
+//
+// The subquery collection is the first "@collection.NAME" referenced in
+// the subquery filter and the subquery returns the ids of the collection
+// records matching the filter, eg.:
+//
+//	id in (@collection.memberships.user ?= @request.auth.id)
+//
+// Optionally another collection field could be returned by prepending
+// the filter with "@collection.NAME.FIELD where", eg.:
+//
+//	id in (@collection.memberships.team where @collection.memberships.user ?= @request.auth.id)
+//
+// The "@collection.NAME" fields in the subquery are resolved
+// against the subquery collection records (aka. without extra join).
+func (r *RecordFieldResolver) ResolveSubquery(subquery string) (dbx.Expression, error) {
+	filter := subquery
+	projection := ""
+	if match := subqueryProjectionRegex.FindStringSubmatch(subquery); match != nil {
+		projection = match[1]
+		filter = match[2]
+	}
+
+	collectionName := findSubqueryCollectionName(projection + " " + filter)
+	if collectionName == "" {
+		return nil, errors.New("the subquery must reference a @collection field")
+	}
+
+	collection, err := r.loadCollection(collectionName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load subquery collection %q: %w", collectionName, err)
+	}
+
+	prefix := "@collection." + collectionName + "."
+
+	resolver := NewRecordFieldResolver(r.dao, collection, r.requestInfo, r.allowHiddenFields)
+
+	valueField := "id"
+	if projection != "" {
+		valueField = strings.TrimPrefix(projection, prefix)
+	}
+
+	value, err := resolver.Resolve(valueField)
+	if err != nil {
+		return nil, fmt.Errorf("invalid subquery field %q: %w", valueField, err)
+	}
+
+	where, err := search.FilterData(replaceOutsideQuotes(filter, prefix, "")).BuildExpr(resolver)
+	if err != nil {
+		return nil, err
+	}
+
+	return &recordSubquery{
+		tableName:       collection.Name,
+		valueIdentifier: value.Identifier,
+		params:          value.Params,
+		joins:           resolver.joins,
+		where:           where,
+	}, nil
+}
+
+// findSubqueryCollectionName returns the name of the first
+// "@collection.NAME." identifier in the raw filter (ignoring the quoted text).
+func findSubqueryCollectionName(raw string) string {
+	var quote byte
+
+	for i := 0; i < len(raw); i++ {
+		c := raw[i]
+
+		if quote != 0 {
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+			continue
+		}
+
+		switch c {
+		case '\'', '"':
+			quote = c
+		case '@':
+			if match := subqueryCollectionRegex.FindStringSubmatch(raw[i:]); match != nil {
+				return match[1]
+			}
+		}
+	}
+
+	return ""
+}
+
+// replaceOutsideQuotes replaces all old occurrences in the raw
+// filter with the replacement string (ignoring the quoted text).
+func replaceOutsideQuotes(raw string, old string, replacement string) string {
+	var result strings.Builder
+	result.Grow(len(raw))
+
+	var quote byte
+
+	for i := 0; i < len(raw); i++ {
+		c := raw[i]
+
+		if quote != 0 {
+			result.WriteByte(c)
+			if c == '\\' && i+1 < len(raw) {
+				i++
+				result.WriteByte(raw[i])
+			} else if c == quote {
+				quote = 0
+			}
+			continue
+		}
+
+		if c == '\'' || c == '"' {
+			quote = c
+		} else if strings.HasPrefix(raw[i:], old) {
+			result.WriteString(replacement)
+			i += len(old) - 1
+			continue
+		}
+
+		result.WriteByte(c)
+	}
+
+	return result.String()
+}
+
+// -------------------------------------------------------------------
+
+var _ dbx.Expression = (*recordSubquery)(nil)
+
+// recordSubquery defines a single column records subquery expression.
+type recordSubquery struct {
+	tableName       string
+	valueIdentifier string
+	params          dbx.Params
+	joins           []*join
+	where           dbx.Expression
+}
+
+// Build converts the expression into a SQL fragment.
+//
+// Implements [dbx.Expression] interface.
+func (s *recordSubquery) Build(db *dbx.DB, params dbx.Params) string {
+	for k, v := range s.params {
+		params[k] = v
+	}
+
+	var sql strings.Builder
+
+	sql.WriteString("SELECT ")
+	sql.WriteString(s.valueIdentifier)
+	sql.WriteString(" FROM ")
+	sql.WriteString(db.QuoteTableName(s.tableName))
+
+	for _, j := range s.joins {
+		sql.WriteString(" LEFT JOIN ")
+		sql.WriteString(db.QuoteTableName(j.tableName))
+		sql.WriteString(" ")
+		sql.WriteString(db.QuoteTableName(j.tableAlias))
+		if j.on != nil {
+			sql.WriteString(" ON ")
+			sql.WriteString(j.on.Build(db, params))
+		}
+	}
+
+	if s.where != nil {
+		if where := s.where.Build(db, params); where != "" {
+			sql.WriteString(" WHERE ")
+			sql.WriteString(where)
+		}
+	}
+
+	return sql.String()
+}
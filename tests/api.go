@@ -81,8 +81,9 @@ func (scenario *ApiScenario) test(t *testing.T) {
 
 	// manually trigger the serve event to ensure that custom app routes and middlewares are registered
 	testApp.OnBeforeServe().Trigger(&core.ServeEvent{
-		App:    testApp,
-		Router: e,
+		App:        testApp,
+		Router:     e,
+		HttpRouter: apis.NewEchoRouter(e),
 	})
 
 	if scenario.BeforeTestFunc != nil {
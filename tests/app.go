@@ -186,6 +186,10 @@ func NewTestApp(optTestDataDir ...string) (*TestApp, error) {
 		return t.registerEventCall("OnRecordAfterDeleteRequest")
 	})
 
+	t.OnRecordSyncConflict().Add(func(e *core.RecordSyncConflictEvent) error {
+		return t.registerEventCall("OnRecordSyncConflict")
+	})
+
 	t.OnRecordAuthRequest().Add(func(e *core.RecordAuthEvent) error {
 		return t.registerEventCall("OnRecordAuthRequest")
 	})
@@ -306,6 +310,14 @@ func NewTestApp(optTestDataDir ...string) (*TestApp, error) {
 		return t.registerEventCall("OnMailerAfterRecordChangeEmailSend")
 	})
 
+	t.OnMailerBeforeRecordOtpSend().Add(func(e *core.MailerRecordEvent) error {
+		return t.registerEventCall("OnMailerBeforeRecordOtpSend")
+	})
+
+	t.OnMailerAfterRecordOtpSend().Add(func(e *core.MailerRecordEvent) error {
+		return t.registerEventCall("OnMailerAfterRecordOtpSend")
+	})
+
 	t.OnRealtimeConnectRequest().Add(func(e *core.RealtimeConnectEvent) error {
 		return t.registerEventCall("OnRealtimeConnectRequest")
 	})
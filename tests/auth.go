@@ -0,0 +1,71 @@
+package tests
+
+import (
+	"errors"
+
+	"github.com/pocketbase/pocketbase/models"
+)
+
+// NewTestAppWithAuthOptions creates and initializes a new test app
+// instance (see [NewTestApp]) and changes the auth options of the
+// specified auth collection with the provided update function.
+//
+// It is usually used as ApiScenario.TestAppFactory to enable an
+// optional auth collection feature, eg.:
+//
+//	app, err := tests.NewTestAppWithAuthOptions("users", func(options *models.CollectionAuthOptions) {
+//		options.AllowPasskeyAuth = true
+//	})
+func NewTestAppWithAuthOptions(
+	collectionNameOrId string,
+	update func(options *models.CollectionAuthOptions),
+	optTestDataDir ...string,
+) (*TestApp, error) {
+	app, err := NewTestApp(optTestDataDir...)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := UpdateAuthOptions(app, collectionNameOrId, update); err != nil {
+		app.Cleanup()
+		return nil, err
+	}
+
+	return app, nil
+}
+
+// UpdateAuthOptions changes the auth options of the specified test app
+// auth collection with the provided update function and returns the
+// updated collection.
+//
+// The collection is saved without triggering the app model hooks
+// so that the tested events count is not affected.
+func UpdateAuthOptions(
+	app *TestApp,
+	collectionNameOrId string,
+	update func(options *models.CollectionAuthOptions),
+) (*models.Collection, error) {
+	dao := app.Dao().WithoutHooks()
+
+	collection, err := dao.FindCollectionByNameOrId(collectionNameOrId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !collection.IsAuth() {
+		return nil, errors.New("the collection is not an auth collection")
+	}
+
+	options := collection.AuthOptions()
+	update(&options)
+
+	if err := collection.SetOptions(options); err != nil {
+		return nil, err
+	}
+
+	if err := dao.SaveCollection(collection); err != nil {
+		return nil, err
+	}
+
+	return collection, nil
+}
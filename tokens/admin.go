@@ -8,9 +8,21 @@ import (
 )
 
 // NewAdminAuthToken generates and returns a new admin authentication token.
+//
+// The OnAdminAuthTokenIssue app hook is triggered before signing the
+// token allowing custom claims to be injected in the token payload.
 func NewAdminAuthToken(app core.App, admin *models.Admin) (string, error) {
+	event := &core.AdminAuthTokenIssueEvent{
+		Admin:  admin,
+		Claims: map[string]any{},
+	}
+
+	if err := app.OnAdminAuthTokenIssue().Trigger(event); err != nil {
+		return "", err
+	}
+
 	return security.NewJWT(
-		jwt.MapClaims{"id": admin.Id, "type": TypeAdmin},
+		withCustomClaims(jwt.MapClaims{"id": admin.Id, "type": TypeAdmin}, event.Claims),
 		(admin.TokenKey + app.Settings().AdminAuthToken.Secret),
 		app.Settings().AdminAuthToken.Duration,
 	)
@@ -1,10 +1,14 @@
 package tokens_test
 
 import (
+	"errors"
 	"testing"
+	"time"
 
+	"github.com/pocketbase/pocketbase/core"
 	"github.com/pocketbase/pocketbase/tests"
 	"github.com/pocketbase/pocketbase/tokens"
+	"github.com/pocketbase/pocketbase/tools/security"
 )
 
 func TestNewAdminAuthToken(t *testing.T) {
@@ -30,6 +34,59 @@ func TestNewAdminAuthToken(t *testing.T) {
 	}
 }
 
+func TestNewAdminAuthTokenCustomClaims(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	admin, err := app.Dao().FindAdminByEmail("test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app.OnAdminAuthTokenIssue().Add(func(e *core.AdminAuthTokenIssueEvent) error {
+		e.Claims["role"] = "support"
+		e.Claims["id"] = "hijacked"
+		e.Claims["exp"] = 0
+		return nil
+	})
+
+	token, err := tokens.NewAdminAuthToken(app, admin)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claims, err := security.ParseUnverifiedJWT(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if claims["role"] != "support" {
+		t.Fatalf("Expected the custom role claim, got %v", claims)
+	}
+
+	if claims["id"] != admin.Id {
+		t.Fatalf("Expected the id claim to remain %q, got %v", admin.Id, claims["id"])
+	}
+
+	if exp, _ := claims["exp"].(float64); int64(exp) <= time.Now().Unix() {
+		t.Fatalf("Expected the exp claim to remain in the future, got %v", claims["exp"])
+	}
+
+	// the token should be still valid
+	if tokenAdmin, _ := app.Dao().FindAdminByToken(token, app.Settings().AdminAuthToken.Secret); tokenAdmin == nil {
+		t.Fatal("Expected the token to be valid")
+	}
+
+	// hook error
+	app.OnAdminAuthTokenIssue().Add(func(e *core.AdminAuthTokenIssueEvent) error {
+		return errors.New("test")
+	})
+
+	if _, err := tokens.NewAdminAuthToken(app, admin); err == nil {
+		t.Fatal("Expected the hook error to be returned")
+	}
+}
+
 func TestNewAdminResetPasswordToken(t *testing.T) {
 	app, _ := tests.NewTestApp()
 	defer app.Cleanup()
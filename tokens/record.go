@@ -10,22 +10,100 @@ import (
 )
 
 // NewRecordAuthToken generates and returns a new auth record authentication token.
+//
+// The OnRecordAuthTokenIssue app hook is triggered before signing the
+// token allowing custom claims to be injected in the token payload.
 func NewRecordAuthToken(app core.App, record *models.Record) (string, error) {
+	return NewRecordSessionAuthToken(app, record, "")
+}
+
+// NewRecordSessionAuthToken generates and returns a new auth record
+// authentication token bound to the provided tracked session id.
+//
+// The session id is stored in the "sid" token claim and
+// it is omitted if sessionId is empty.
+func NewRecordSessionAuthToken(app core.App, record *models.Record, sessionId string) (string, error) {
 	if !record.Collection().IsAuth() {
 		return "", errors.New("The record is not from an auth collection.")
 	}
 
+	claims := jwt.MapClaims{
+		"id":           record.Id,
+		"type":         TypeAuthRecord,
+		"collectionId": record.Collection().Id,
+	}
+
+	if sessionId != "" {
+		claims["sid"] = sessionId
+	}
+
+	return newRecordAuthToken(app, record, claims, RecordAuthTokenDuration(app, record.Collection()))
+}
+
+// DefaultImpersonateTokenDuration is the default
+// impersonation token duration in seconds.
+const DefaultImpersonateTokenDuration = 3600
+
+// MaxImpersonateTokenDuration is the max allowed
+// impersonation token duration in seconds.
+const MaxImpersonateTokenDuration = 86400
+
+// NewRecordImpersonateToken generates and returns a new short-lived
+// auth record authentication token issued on behalf of the provided admin.
+//
+// The impersonator admin id is stored in the "impersonator" token claim
+// and the token is not allowed to be refreshed.
+func NewRecordImpersonateToken(app core.App, record *models.Record, admin *models.Admin, duration int64) (string, error) {
+	if !record.Collection().IsAuth() {
+		return "", errors.New("The record is not from an auth collection.")
+	}
+
+	if duration <= 0 {
+		duration = DefaultImpersonateTokenDuration
+	}
+
+	claims := jwt.MapClaims{
+		"id":           record.Id,
+		"type":         TypeAuthRecord,
+		"collectionId": record.Collection().Id,
+		"impersonator": admin.Id,
+	}
+
+	return newRecordAuthToken(app, record, claims, duration)
+}
+
+// newRecordAuthToken triggers the OnRecordAuthTokenIssue app hook
+// and signs the auth record token with the provided builtin claims.
+func newRecordAuthToken(app core.App, record *models.Record, claims jwt.MapClaims, duration int64) (string, error) {
+	event := new(core.RecordAuthTokenIssueEvent)
+	event.Collection = record.Collection()
+	event.Record = record
+	event.Claims = map[string]any{}
+
+	if err := app.OnRecordAuthTokenIssue().Trigger(event); err != nil {
+		return "", err
+	}
+
 	return security.NewJWT(
-		jwt.MapClaims{
-			"id":           record.Id,
-			"type":         TypeAuthRecord,
-			"collectionId": record.Collection().Id,
-		},
+		withCustomClaims(claims, event.Claims),
 		(record.TokenKey() + app.Settings().RecordAuthToken.Secret),
-		app.Settings().RecordAuthToken.Duration,
+		duration,
 	)
 }
 
+// RecordAuthTokenDuration returns the lifetime in seconds of the
+// auth tokens issued for the records of the provided auth collection.
+//
+// Collections with enabled refresh tokens use their short-lived
+// access token duration instead of the app settings one.
+func RecordAuthTokenDuration(app core.App, collection *models.Collection) int64 {
+	if collection.AuthOptions().AllowRefreshTokens {
+		return int64(collection.AccessTokenDuration().Seconds())
+	}
+
+	return app.Settings().RecordAuthToken.Duration
+}
+
 // NewRecordVerifyToken generates and returns a new record verification token.
 func NewRecordVerifyToken(app core.App, record *models.Record) (string, error) {
 	if !record.Collection().IsAuth() {
@@ -93,3 +171,93 @@ func NewRecordFileToken(app core.App, record *models.Record) (string, error) {
 		app.Settings().RecordFileToken.Duration,
 	)
 }
+
+// MfaSetupTokenDuration is the MFA setup token duration in seconds.
+const MfaSetupTokenDuration = 600
+
+// NewRecordMfaSetupToken generates and returns a new short-lived auth
+// record token that could be used only for the MFA factor enrollment.
+func NewRecordMfaSetupToken(app core.App, record *models.Record) (string, error) {
+	if !record.Collection().IsAuth() {
+		return "", errors.New("The record is not from an auth collection.")
+	}
+
+	return security.NewJWT(
+		jwt.MapClaims{
+			"id":           record.Id,
+			"type":         TypeMfaSetup,
+			"collectionId": record.Collection().Id,
+		},
+		(record.TokenKey() + app.Settings().RecordAuthToken.Secret),
+		MfaSetupTokenDuration,
+	)
+}
+
+// PasskeyChallengeTokenDuration is the passkey challenge token duration in seconds.
+const PasskeyChallengeTokenDuration = 300
+
+// NewPasskeyChallengeToken generates and returns a new short-lived token
+// that holds the provided WebAuthn ceremony challenge.
+//
+// The record argument is optional and it is expected to be set
+// only for the passkey registration challenges.
+func NewPasskeyChallengeToken(
+	app core.App,
+	collection *models.Collection,
+	record *models.Record,
+	challenge string,
+) (string, error) {
+	if !collection.IsAuth() {
+		return "", errors.New("The collection is not an auth collection.")
+	}
+
+	claims := jwt.MapClaims{
+		"type":         TypePasskeyChallenge,
+		"collectionId": collection.Id,
+		"challenge":    challenge,
+	}
+
+	if record != nil {
+		claims["recordId"] = record.Id
+	}
+
+	return security.NewJWT(
+		claims,
+		(collection.Id + app.Settings().RecordAuthToken.Secret),
+		PasskeyChallengeTokenDuration,
+	)
+}
+
+// RecordUploadTokenDuration is the pre-signed record upload token
+// (and upload url) duration in seconds.
+const RecordUploadTokenDuration = 3600
+
+// NewRecordUploadToken generates and returns a new token that binds
+// a pending direct storage upload to the provided record file field.
+//
+// The token is expected to be exchanged after the upload completes
+// in order to attach the uploaded file to the record.
+func NewRecordUploadToken(
+	app core.App,
+	record *models.Record,
+	field string,
+	filename string,
+	contentType string,
+	size int64,
+) (string, error) {
+	collection := record.Collection()
+
+	return security.NewJWT(
+		jwt.MapClaims{
+			"type":         TypeRecordUpload,
+			"collectionId": collection.Id,
+			"recordId":     record.Id,
+			"field":        field,
+			"filename":     filename,
+			"contentType":  contentType,
+			"size":         size,
+		},
+		(collection.Id + app.Settings().RecordFileToken.Secret),
+		RecordUploadTokenDuration,
+	)
+}
@@ -1,10 +1,16 @@
 package tokens_test
 
 import (
+	"errors"
 	"testing"
+	"time"
 
+	"github.com/pocketbase/pocketbase/core"
+	"github.com/pocketbase/pocketbase/models"
 	"github.com/pocketbase/pocketbase/tests"
 	"github.com/pocketbase/pocketbase/tokens"
+	"github.com/pocketbase/pocketbase/tools/security"
+	"github.com/spf13/cast"
 )
 
 func TestNewRecordAuthToken(t *testing.T) {
@@ -30,6 +36,158 @@ func TestNewRecordAuthToken(t *testing.T) {
 	}
 }
 
+func TestNewRecordSessionAuthToken(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	user, err := app.Dao().FindAuthRecordByEmail("users", "test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		sessionId   string
+		expectedSid any
+	}{
+		{"", nil},
+		{"test_session", "test_session"},
+	}
+
+	for _, s := range scenarios {
+		token, err := tokens.NewRecordSessionAuthToken(app, user, s.sessionId)
+		if err != nil {
+			t.Fatalf("[%q] %v", s.sessionId, err)
+		}
+
+		claims, _ := security.ParseUnverifiedJWT(token)
+		if claims["sid"] != s.expectedSid {
+			t.Fatalf("[%q] Expected sid claim %v, got %v", s.sessionId, s.expectedSid, claims["sid"])
+		}
+
+		tokenRecord, _ := app.Dao().FindAuthRecordByToken(token, app.Settings().RecordAuthToken.Secret)
+		if tokenRecord == nil || tokenRecord.Id != user.Id {
+			t.Fatalf("[%q] Expected auth record %v, got %v", s.sessionId, user, tokenRecord)
+		}
+	}
+}
+
+func TestNewRecordImpersonateToken(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	user, err := app.Dao().FindAuthRecordByEmail("users", "test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	admin, err := app.Dao().FindAdminByEmail("test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		duration         int64
+		expectedDuration int64
+	}{
+		{0, tokens.DefaultImpersonateTokenDuration},
+		{60, 60},
+	}
+
+	for _, s := range scenarios {
+		token, err := tokens.NewRecordImpersonateToken(app, user, admin, s.duration)
+		if err != nil {
+			t.Fatalf("[%d] %v", s.duration, err)
+		}
+
+		claims, _ := security.ParseUnverifiedJWT(token)
+		if claims["impersonator"] != admin.Id {
+			t.Fatalf("[%d] Expected impersonator claim %q, got %v", s.duration, admin.Id, claims["impersonator"])
+		}
+
+		exp := int64(cast.ToFloat64(claims["exp"]))
+		expectedExp := time.Now().Unix() + s.expectedDuration
+		if exp < expectedExp-5 || exp > expectedExp+5 {
+			t.Fatalf("[%d] Expected exp around %d, got %d", s.duration, expectedExp, exp)
+		}
+
+		tokenRecord, _ := app.Dao().FindAuthRecordByToken(token, app.Settings().RecordAuthToken.Secret)
+		if tokenRecord == nil || tokenRecord.Id != user.Id {
+			t.Fatalf("[%d] Expected auth record %v, got %v", s.duration, user, tokenRecord)
+		}
+	}
+}
+
+func TestNewRecordAuthTokenCustomClaims(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	user, err := app.Dao().FindAuthRecordByEmail("users", "test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	app.OnRecordAuthTokenIssue().Add(func(e *core.RecordAuthTokenIssueEvent) error {
+		e.Claims["plan"] = "pro"
+		e.Claims["collectionId"] = "hijacked"
+		e.Claims["exp"] = 0
+		e.Claims["impersonator"] = "sywbhecnh46rhm0"
+		e.Claims["sid"] = "forged"
+		return nil
+	})
+
+	app.OnRecordAuthTokenIssue("clients").Add(func(e *core.RecordAuthTokenIssueEvent) error {
+		e.Claims["clients"] = true
+		return nil
+	})
+
+	token, err := tokens.NewRecordAuthToken(app, user)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claims, err := security.ParseUnverifiedJWT(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if claims["plan"] != "pro" {
+		t.Fatalf("Expected the custom plan claim, got %v", claims)
+	}
+
+	if _, ok := claims["clients"]; ok {
+		t.Fatalf("Expected the clients tagged hook to not be triggered, got %v", claims)
+	}
+
+	if claims["collectionId"] != user.Collection().Id {
+		t.Fatalf("Expected the collectionId claim to remain %q, got %v", user.Collection().Id, claims["collectionId"])
+	}
+
+	if exp, _ := claims["exp"].(float64); int64(exp) <= time.Now().Unix() {
+		t.Fatalf("Expected the exp claim to remain in the future, got %v", claims["exp"])
+	}
+
+	// the reserved claims should be always dropped
+	for _, reserved := range []string{"impersonator", "sid"} {
+		if _, ok := claims[reserved]; ok {
+			t.Fatalf("Expected the reserved %q claim to be dropped, got %v", reserved, claims)
+		}
+	}
+
+	// the token should be still valid
+	if tokenRecord, _ := app.Dao().FindAuthRecordByToken(token, app.Settings().RecordAuthToken.Secret); tokenRecord == nil {
+		t.Fatal("Expected the token to be valid")
+	}
+
+	// hook error
+	app.OnRecordAuthTokenIssue().Add(func(e *core.RecordAuthTokenIssueEvent) error {
+		return errors.New("test")
+	})
+
+	if _, err := tokens.NewRecordAuthToken(app, user); err == nil {
+		t.Fatal("Expected the hook error to be returned")
+	}
+}
+
 func TestNewRecordVerifyToken(t *testing.T) {
 	app, _ := tests.NewTestApp()
 	defer app.Cleanup()
@@ -121,3 +279,132 @@ func TestNewRecordFileToken(t *testing.T) {
 		t.Fatalf("Expected auth record %v, got %v", user, tokenRecord)
 	}
 }
+
+func TestNewRecordMfaSetupToken(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	user, err := app.Dao().FindAuthRecordByEmail("users", "test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := tokens.NewRecordMfaSetupToken(app, user)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claims, _ := security.ParseUnverifiedJWT(token)
+	if claims["type"] != tokens.TypeMfaSetup {
+		t.Fatalf("Expected %q token type, got %v", tokens.TypeMfaSetup, claims["type"])
+	}
+
+	tokenRecord, _ := app.Dao().FindAuthRecordByToken(
+		token,
+		app.Settings().RecordAuthToken.Secret,
+	)
+	if tokenRecord == nil || tokenRecord.Id != user.Id {
+		t.Fatalf("Expected auth record %v, got %v", user, tokenRecord)
+	}
+}
+
+func TestNewPasskeyChallengeToken(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	user, err := app.Dao().FindAuthRecordByEmail("users", "test@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		record           *models.Record
+		expectedRecordId any
+	}{
+		{nil, nil},
+		{user, user.Id},
+	}
+
+	for i, s := range scenarios {
+		token, err := tokens.NewPasskeyChallengeToken(app, user.Collection(), s.record, "test_challenge")
+		if err != nil {
+			t.Fatalf("(%d) %v", i, err)
+		}
+
+		claims, err := security.ParseJWT(token, user.Collection().Id+app.Settings().RecordAuthToken.Secret)
+		if err != nil {
+			t.Fatalf("(%d) Failed to verify the token: %v", i, err)
+		}
+
+		if claims["type"] != tokens.TypePasskeyChallenge {
+			t.Fatalf("(%d) Expected %q token type, got %v", i, tokens.TypePasskeyChallenge, claims["type"])
+		}
+
+		if claims["challenge"] != "test_challenge" {
+			t.Fatalf("(%d) Expected challenge claim %q, got %v", i, "test_challenge", claims["challenge"])
+		}
+
+		if claims["recordId"] != s.expectedRecordId {
+			t.Fatalf("(%d) Expected recordId claim %v, got %v", i, s.expectedRecordId, claims["recordId"])
+		}
+	}
+}
+
+func TestNewRecordUploadToken(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	record, err := app.Dao().FindRecordById("demo1", "84nmscqy84lsi1t")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := tokens.NewRecordUploadToken(app, record, "file_many", "test_abc.txt", "text/plain", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claims, err := security.ParseJWT(token, record.Collection().Id+app.Settings().RecordFileToken.Secret)
+	if err != nil {
+		t.Fatalf("Failed to verify the token: %v", err)
+	}
+
+	expectations := map[string]any{
+		"type":         tokens.TypeRecordUpload,
+		"collectionId": record.Collection().Id,
+		"recordId":     record.Id,
+		"field":        "file_many",
+		"filename":     "test_abc.txt",
+		"contentType":  "text/plain",
+		"size":         float64(10),
+	}
+
+	for k, v := range expectations {
+		if claims[k] != v {
+			t.Fatalf("Expected %s claim %v, got %v", k, v, claims[k])
+		}
+	}
+}
+
+func TestRecordAuthTokenDuration(t *testing.T) {
+	app, _ := tests.NewTestApp()
+	defer app.Cleanup()
+
+	collection, err := app.Dao().FindCollectionByNameOrId("users")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if d := tokens.RecordAuthTokenDuration(app, collection); d != app.Settings().RecordAuthToken.Duration {
+		t.Fatalf("Expected the settings auth token duration, got %d", d)
+	}
+
+	options := collection.AuthOptions()
+	options.AllowRefreshTokens = true
+	options.AccessTokenDuration = 60
+	collection.SetOptions(options)
+
+	if d := tokens.RecordAuthTokenDuration(app, collection); d != 60 {
+		t.Fatalf("Expected the collection access token duration, got %d", d)
+	}
+}
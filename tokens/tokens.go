@@ -1,7 +1,42 @@
 // Package tokens implements various user and admin tokens generation methods.
 package tokens
 
+import (
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/pocketbase/pocketbase/tools/list"
+)
+
 const (
 	TypeAdmin      = "admin"
 	TypeAuthRecord = "authRecord"
+	TypeMfaSetup   = "mfaSetup"
+
+	TypePasskeyChallenge = "passkeyChallenge"
+	TypeRecordUpload     = "recordUpload"
 )
+
+// reservedClaims lists the claims that are never set from the custom
+// token issue hook claims, even when they are not part of the builtin ones
+// (eg. to prevent forging an impersonation or a tracked session token).
+var reservedClaims = []string{"exp", "impersonator", "sid"}
+
+// withCustomClaims returns a new claims map with the custom claims
+// injected by the token issue hooks merged with the builtin ones.
+//
+// The builtin and the reserved claims cannot be overwritten.
+func withCustomClaims(builtin jwt.MapClaims, custom map[string]any) jwt.MapClaims {
+	result := make(jwt.MapClaims, len(builtin)+len(custom))
+
+	for k, v := range custom {
+		if list.ExistInSlice(k, reservedClaims) {
+			continue
+		}
+		result[k] = v
+	}
+
+	for k, v := range builtin {
+		result[k] = v
+	}
+
+	return result
+}
@@ -0,0 +1,152 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/pocketbase/pocketbase/tools/types"
+	"github.com/spf13/cast"
+	"golang.org/x/oauth2"
+)
+
+var _ Provider = (*Custom)(nil)
+
+// DefaultCustomFieldsMapping is the default [Custom] provider mapping
+// between the [AuthUser] fields and the raw user data paths.
+var DefaultCustomFieldsMapping = map[string]string{
+	"id":        "id",
+	"name":      "name",
+	"username":  "username",
+	"email":     "email",
+	"avatarUrl": "avatar_url",
+}
+
+// Custom allows authentication via a generic OAuth2 provider
+// configured entirely at runtime (eg. from the app settings).
+type Custom struct {
+	*baseProvider
+
+	fieldsMapping map[string]string
+	extraParams   map[string]string
+}
+
+// NewCustomProvider creates new generic OAuth2 provider instance with some defaults.
+func NewCustomProvider() *Custom {
+	return &Custom{
+		baseProvider: &baseProvider{
+			ctx:  context.Background(),
+			pkce: true,
+		},
+		fieldsMapping: map[string]string{},
+		extraParams:   map[string]string{},
+	}
+}
+
+// FieldsMapping returns the provider [AuthUser] fields mapping.
+func (p *Custom) FieldsMapping() map[string]string {
+	return p.fieldsMapping
+}
+
+// SetFieldsMapping sets the mapping between the [AuthUser] fields
+// (id, name, username, email, avatarUrl) and the user api response
+// data paths in dot-notation (eg. {"id": "data.user.sub"}).
+//
+// The not mapped fields fallback to [DefaultCustomFieldsMapping].
+func (p *Custom) SetFieldsMapping(mapping map[string]string) {
+	p.fieldsMapping = mapping
+}
+
+// ExtraParams returns the provider extra authorization url params.
+func (p *Custom) ExtraParams() map[string]string {
+	return p.extraParams
+}
+
+// SetExtraParams sets the extra query parameters that will be
+// appended to the provider authorization url (eg. {"prompt": "consent"}).
+func (p *Custom) SetExtraParams(params map[string]string) {
+	p.extraParams = params
+}
+
+// BuildAuthUrl implements Provider.BuildAuthUrl() interface method.
+func (p *Custom) BuildAuthUrl(state string, opts ...oauth2.AuthCodeOption) string {
+	for k, v := range p.extraParams {
+		opts = append(opts, oauth2.SetAuthURLParam(k, v))
+	}
+
+	return p.baseProvider.BuildAuthUrl(state, opts...)
+}
+
+// FetchAuthUser returns an AuthUser instance based on the
+// provider's user api and the configured fields mapping.
+func (p *Custom) FetchAuthUser(token *oauth2.Token) (*AuthUser, error) {
+	data, err := p.FetchRawUserData(token)
+	if err != nil {
+		return nil, err
+	}
+
+	rawUser := map[string]any{}
+	if err := json.Unmarshal(data, &rawUser); err != nil {
+		return nil, err
+	}
+
+	user := &AuthUser{
+		Id:           p.extract(rawUser, "id"),
+		Name:         p.extract(rawUser, "name"),
+		Username:     p.extract(rawUser, "username"),
+		Email:        p.extract(rawUser, "email"),
+		AvatarUrl:    p.extract(rawUser, "avatarUrl"),
+		RawUser:      rawUser,
+		AccessToken:  token.AccessToken,
+		RefreshToken: token.RefreshToken,
+	}
+
+	if user.Id == "" {
+		return nil, errors.New("failed to resolve the OAuth2 user id from the user api response")
+	}
+
+	user.Expiry, _ = types.ParseDateTime(token.Expiry)
+
+	return user, nil
+}
+
+// extract resolves the mapped raw user data value of the provided AuthUser field.
+func (p *Custom) extract(rawUser map[string]any, field string) string {
+	path := p.fieldsMapping[field]
+	if path == "" {
+		path = DefaultCustomFieldsMapping[field]
+	}
+
+	return cast.ToString(resolveDataPath(rawUser, path))
+}
+
+// resolveDataPath returns the value located at the dot-notation
+// path of the provided data (eg. "data.emails.0.value").
+//
+// Returns nil if the path doesn't exist.
+func resolveDataPath(data any, path string) any {
+	if path == "" {
+		return nil
+	}
+
+	current := data
+
+	for _, part := range strings.Split(path, ".") {
+		switch v := current.(type) {
+		case map[string]any:
+			current = v[part]
+		case []any:
+			i, err := strconv.Atoi(part)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil
+			}
+			current = v[i]
+		default:
+			return nil
+		}
+	}
+
+	return current
+}
@@ -0,0 +1,139 @@
+package auth_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/auth"
+	"golang.org/x/oauth2"
+)
+
+func TestCustomBuildAuthUrl(t *testing.T) {
+	p := auth.NewCustomProvider()
+	p.SetAuthUrl("https://example.com/oauth/authorize")
+	p.SetClientId("test_client")
+	p.SetScopes([]string{"profile", "email"})
+	p.SetExtraParams(map[string]string{"prompt": "consent", "audience": "api"})
+
+	raw := p.BuildAuthUrl("test_state", oauth2.SetAuthURLParam("code_challenge", "test_challenge"))
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedParams := map[string]string{
+		"client_id":      "test_client",
+		"state":          "test_state",
+		"scope":          "profile email",
+		"prompt":         "consent",
+		"audience":       "api",
+		"code_challenge": "test_challenge",
+	}
+
+	query := u.Query()
+	for k, v := range expectedParams {
+		if query.Get(k) != v {
+			t.Errorf("Expected %q query param %q, got %q", k, v, query.Get(k))
+		}
+	}
+}
+
+func TestCustomFetchAuthUser(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test_access_token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"data": {
+				"sub": 1234567,
+				"display": "Test User",
+				"emails": [{"value": "test@example.com"}]
+			},
+			"username": "test_username"
+		}`))
+	}))
+	defer server.Close()
+
+	token := &oauth2.Token{AccessToken: "test_access_token", RefreshToken: "test_refresh_token"}
+
+	scenarios := []struct {
+		name          string
+		mapping       map[string]string
+		expectError   bool
+		expectedId    string
+		expectedName  string
+		expectedEmail string
+	}{
+		{
+			"default mapping without id",
+			nil,
+			true,
+			"", "", "",
+		},
+		{
+			"custom mapping",
+			map[string]string{
+				"id":    "data.sub",
+				"name":  "data.display",
+				"email": "data.emails.0.value",
+			},
+			false,
+			"1234567", "Test User", "test@example.com",
+		},
+		{
+			"out of range index",
+			map[string]string{
+				"id":    "data.sub",
+				"email": "data.emails.1.value",
+			},
+			false,
+			"1234567", "", "",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			p := auth.NewCustomProvider()
+			p.SetUserApiUrl(server.URL)
+			p.SetFieldsMapping(s.mapping)
+
+			user, err := p.FetchAuthUser(token)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if hasErr {
+				return
+			}
+
+			if user.Id != s.expectedId {
+				t.Fatalf("Expected id %q, got %q", s.expectedId, user.Id)
+			}
+
+			if user.Name != s.expectedName {
+				t.Fatalf("Expected name %q, got %q", s.expectedName, user.Name)
+			}
+
+			if user.Email != s.expectedEmail {
+				t.Fatalf("Expected email %q, got %q", s.expectedEmail, user.Email)
+			}
+
+			// the not mapped fields fallback to the default mapping
+			if user.Username != "test_username" {
+				t.Fatalf("Expected username %q, got %q", "test_username", user.Username)
+			}
+
+			if user.AccessToken != token.AccessToken || user.RefreshToken != token.RefreshToken {
+				t.Fatalf("Expected the token to be assigned, got %v", user)
+			}
+		})
+	}
+}
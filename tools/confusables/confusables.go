@@ -0,0 +1,66 @@
+// Package confusables implements a minimal "skeleton" normalization
+// for detecting visually confusable identifiers (eg. "rnike" vs "mike").
+package confusables
+
+import "strings"
+
+// Replacement defines a single confusable sequence replacement.
+type Replacement struct {
+	From string
+	To   string
+}
+
+// Replacements is the ordered list of the ASCII confusable
+// sequence replacements applied on the lowercased identifier.
+//
+// The list is exported so that the same normalization could be
+// reproduced in other contexts (eg. as nested SQL REPLACE calls).
+var Replacements = []Replacement{
+	// separators
+	{"_", ""},
+	{"-", ""},
+	{".", ""},
+	// multi-character lookalikes
+	{"rn", "m"},
+	{"vv", "w"},
+	{"cl", "d"},
+	// digit and letter lookalikes
+	{"0", "o"},
+	{"1", "l"},
+	{"i", "l"},
+	{"3", "e"},
+	{"4", "a"},
+	{"5", "s"},
+	{"7", "t"},
+	{"8", "b"},
+}
+
+// homoglyphs maps common non-latin lookalike characters
+// (mostly Cyrillic and Greek) to their latin counterparts.
+var homoglyphs = map[rune]rune{
+	'а': 'a', 'е': 'e', 'о': 'o', 'р': 'p', 'с': 'c', 'у': 'y', 'х': 'x',
+	'і': 'i', 'ј': 'j', 'ѕ': 's', 'һ': 'h', 'ԁ': 'd', 'ԛ': 'q', 'ԝ': 'w',
+	'α': 'a', 'ο': 'o', 'ν': 'v', 'ρ': 'p', 'τ': 't', 'υ': 'u', 'κ': 'k',
+	'ı': 'i', 'ɡ': 'g', 'ｌ': 'l',
+}
+
+// Skeleton returns the normalized form of s that is used to compare
+// identifiers for visual similarity.
+//
+// Two identifiers with the same skeleton are considered confusable.
+func Skeleton(s string) string {
+	s = strings.ToLower(s)
+
+	s = strings.Map(func(r rune) rune {
+		if v, ok := homoglyphs[r]; ok {
+			return v
+		}
+		return r
+	}, s)
+
+	for _, r := range Replacements {
+		s = strings.ReplaceAll(s, r.From, r.To)
+	}
+
+	return s
+}
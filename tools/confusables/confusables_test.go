@@ -0,0 +1,38 @@
+package confusables_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/confusables"
+)
+
+func TestSkeleton(t *testing.T) {
+	scenarios := []struct {
+		a         string
+		b         string
+		confusing bool
+	}{
+		{"admin", "admin", true},
+		{"admin", "ADMIN", true},
+		{"admin", "adm1n", true},
+		{"admin", "admln", true},
+		{"admin", "ad_min", true},
+		{"admin", "a.d-min", true},
+		{"mike", "rnike", true},
+		{"will", "vvill", true},
+		{"bob", "808", true},
+		{"paypal", "раураl", true}, // cyrillic
+		{"test", "te5t", true},
+		{"admin", "admins", false},
+		{"alice", "bob", false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.a+"_"+s.b, func(t *testing.T) {
+			result := confusables.Skeleton(s.a) == confusables.Skeleton(s.b)
+			if result != s.confusing {
+				t.Fatalf("Expected confusing %v, got %v (%q vs %q)", s.confusing, result, confusables.Skeleton(s.a), confusables.Skeleton(s.b))
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package eventbridge
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/aws/aws-sdk-go/service/sqs"
+)
+
+var _ Publisher = (*SNSPublisher)(nil)
+var _ Publisher = (*SQSPublisher)(nil)
+
+// SNSPublisher publishes messages to an AWS SNS topic.
+type SNSPublisher struct {
+	topicArn string
+	client   *sns.SNS
+}
+
+// NewSNSPublisher creates a new AWS SNS publisher for the specified topic ARN.
+//
+// endpoint is optional and could be used for SNS compatible services (eg. LocalStack).
+func NewSNSPublisher(region string, endpoint string, accessKey string, secret string, topicArn string) (*SNSPublisher, error) {
+	if topicArn == "" {
+		return nil, errors.New("missing SNS topic ARN")
+	}
+
+	sess, err := newAwsSession(region, endpoint, accessKey, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SNSPublisher{
+		topicArn: topicArn,
+		client:   sns.New(sess),
+	}, nil
+}
+
+// Publish implements [Publisher.Publish].
+func (p *SNSPublisher) Publish(ctx context.Context, key string, data []byte) error {
+	_, err := p.client.PublishWithContext(ctx, &sns.PublishInput{
+		TopicArn: aws.String(p.topicArn),
+		Message:  aws.String(string(data)),
+	})
+
+	return err
+}
+
+// Close implements [Publisher.Close].
+func (p *SNSPublisher) Close() error {
+	return nil
+}
+
+// SQSPublisher sends messages to an AWS SQS queue.
+type SQSPublisher struct {
+	queueUrl string
+	client   *sqs.SQS
+}
+
+// NewSQSPublisher creates a new AWS SQS publisher for the specified queue url.
+//
+// endpoint is optional and could be used for SQS compatible services (eg. LocalStack).
+func NewSQSPublisher(region string, endpoint string, accessKey string, secret string, queueUrl string) (*SQSPublisher, error) {
+	if queueUrl == "" {
+		return nil, errors.New("missing SQS queue url")
+	}
+
+	sess, err := newAwsSession(region, endpoint, accessKey, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SQSPublisher{
+		queueUrl: queueUrl,
+		client:   sqs.New(sess),
+	}, nil
+}
+
+// Publish implements [Publisher.Publish].
+func (p *SQSPublisher) Publish(ctx context.Context, key string, data []byte) error {
+	_, err := p.client.SendMessageWithContext(ctx, &sqs.SendMessageInput{
+		QueueUrl:    aws.String(p.queueUrl),
+		MessageBody: aws.String(string(data)),
+	})
+
+	return err
+}
+
+// Close implements [Publisher.Close].
+func (p *SQSPublisher) Close() error {
+	return nil
+}
+
+func newAwsSession(region string, endpoint string, accessKey string, secret string) (*session.Session, error) {
+	config := &aws.Config{
+		Region: aws.String(region),
+	}
+
+	if endpoint != "" {
+		config.Endpoint = aws.String(endpoint)
+	}
+
+	// fallback to the default credentials chain (env, shared config, IAM role, etc.)
+	if accessKey != "" || secret != "" {
+		config.Credentials = credentials.NewStaticCredentials(accessKey, secret, "")
+	}
+
+	return session.NewSession(config)
+}
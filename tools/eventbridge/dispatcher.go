@@ -0,0 +1,146 @@
+package eventbridge
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Supported delivery modes.
+//
+// Note that the dispatcher queue is kept only in memory and the new
+// messages are dropped when it is full, so none of the modes guarantees
+// delivery (eg. the pending messages are lost on crash).
+const (
+	// DeliveryAtMostOnce tries to publish each message only once.
+	DeliveryAtMostOnce = "at-most-once"
+
+	// DeliveryRetry retries the failed publishes with exponential backoff.
+	DeliveryRetry = "retry"
+)
+
+const (
+	defaultQueueSize      = 1000
+	defaultPublishTimeout = 30 * time.Second
+	maxRetryBackoff       = 10 * time.Second
+)
+
+type message struct {
+	key  string
+	data []byte
+}
+
+// Dispatcher asynchronously delivers messages to a single Publisher.
+type Dispatcher struct {
+	// OnError is an optional callback that is invoked when a message
+	// couldn't be delivered (after all retries) or was dropped.
+	OnError func(err error, key string)
+
+	publisher  Publisher
+	delivery   string
+	maxRetries int
+	retryDelay time.Duration
+	queue      chan message
+	wg         sync.WaitGroup
+	closeOnce  sync.Once
+	mux        sync.RWMutex
+	closed     bool
+}
+
+// NewDispatcher creates a new Dispatcher and starts its background worker.
+//
+// maxRetries is used only with the DeliveryRetry mode.
+func NewDispatcher(publisher Publisher, delivery string, maxRetries int) *Dispatcher {
+	if delivery != DeliveryRetry {
+		delivery = DeliveryAtMostOnce
+		maxRetries = 0
+	}
+
+	d := &Dispatcher{
+		publisher:  publisher,
+		delivery:   delivery,
+		maxRetries: maxRetries,
+		retryDelay: 200 * time.Millisecond,
+		queue:      make(chan message, defaultQueueSize),
+	}
+
+	d.wg.Add(1)
+	go d.work()
+
+	return d
+}
+
+// Dispatch queues the provided message for delivery.
+//
+// It never blocks and returns false if the message was dropped
+// because the dispatcher is closed or the queue is full.
+func (d *Dispatcher) Dispatch(key string, data []byte) bool {
+	d.mux.RLock()
+	defer d.mux.RUnlock()
+
+	if d.closed {
+		return false
+	}
+
+	select {
+	case d.queue <- message{key: key, data: data}:
+		return true
+	default:
+		if d.OnError != nil {
+			d.OnError(ErrQueueFull, key)
+		}
+		return false
+	}
+}
+
+// Close stops accepting new messages, waits for the
+// queued ones to be processed and closes the publisher.
+func (d *Dispatcher) Close() error {
+	var err error
+
+	d.closeOnce.Do(func() {
+		d.mux.Lock()
+		d.closed = true
+		close(d.queue)
+		d.mux.Unlock()
+
+		d.wg.Wait()
+
+		err = d.publisher.Close()
+	})
+
+	return err
+}
+
+func (d *Dispatcher) work() {
+	defer d.wg.Done()
+
+	for msg := range d.queue {
+		if err := d.deliver(msg); err != nil && d.OnError != nil {
+			d.OnError(err, msg.key)
+		}
+	}
+}
+
+func (d *Dispatcher) deliver(msg message) error {
+	var err error
+
+	delay := d.retryDelay
+
+	for attempt := 0; attempt <= d.maxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(delay)
+			delay = min(delay*2, maxRetryBackoff)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), defaultPublishTimeout)
+		err = d.publisher.Publish(ctx, msg.key, msg.data)
+		cancel()
+
+		if err == nil {
+			return nil
+		}
+	}
+
+	return err
+}
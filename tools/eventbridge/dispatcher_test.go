@@ -0,0 +1,160 @@
+package eventbridge_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/eventbridge"
+)
+
+type testPublisher struct {
+	mux       sync.Mutex
+	failures  int
+	calls     int
+	published []string
+	closed    bool
+}
+
+func (p *testPublisher) Publish(ctx context.Context, key string, data []byte) error {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	p.calls++
+
+	if p.failures > 0 {
+		p.failures--
+		return errors.New("test publish error")
+	}
+
+	p.published = append(p.published, key+":"+string(data))
+
+	return nil
+}
+
+func (p *testPublisher) Close() error {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	p.closed = true
+
+	return nil
+}
+
+func TestDispatcherAtMostOnce(t *testing.T) {
+	publisher := &testPublisher{failures: 1}
+
+	var errs []string
+
+	d := eventbridge.NewDispatcher(publisher, eventbridge.DeliveryAtMostOnce, 5)
+	d.OnError = func(err error, key string) {
+		errs = append(errs, key)
+	}
+
+	d.Dispatch("a", []byte("1"))
+	d.Dispatch("b", []byte("2"))
+
+	if err := d.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if publisher.calls != 2 {
+		t.Fatalf("Expected 2 publish calls (no retries), got %d", publisher.calls)
+	}
+
+	if len(publisher.published) != 1 || publisher.published[0] != "b:2" {
+		t.Fatalf("Expected only b:2 to be published, got %v", publisher.published)
+	}
+
+	if len(errs) != 1 || errs[0] != "a" {
+		t.Fatalf("Expected OnError to be called for a, got %v", errs)
+	}
+
+	if !publisher.closed {
+		t.Fatal("Expected the publisher to be closed")
+	}
+
+	if d.Dispatch("c", []byte("3")) {
+		t.Fatal("Expected the dispatch after close to fail")
+	}
+}
+
+func TestDispatcherRetry(t *testing.T) {
+	publisher := &testPublisher{failures: 2}
+
+	var errs []string
+
+	d := eventbridge.NewDispatcher(publisher, eventbridge.DeliveryRetry, 2)
+	d.OnError = func(err error, key string) {
+		errs = append(errs, key)
+	}
+
+	d.Dispatch("a", []byte("1"))
+	d.Dispatch("b", []byte("2"))
+
+	if err := d.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if publisher.calls != 4 {
+		t.Fatalf("Expected 4 publish calls, got %d", publisher.calls)
+	}
+
+	if len(publisher.published) != 2 || publisher.published[0] != "a:1" || publisher.published[1] != "b:2" {
+		t.Fatalf("Expected a:1 and b:2 to be published, got %v", publisher.published)
+	}
+
+	if len(errs) != 0 {
+		t.Fatalf("Expected no errors, got %v", errs)
+	}
+}
+
+func TestDispatcherFullQueue(t *testing.T) {
+	block := make(chan struct{})
+
+	publisher := &blockingPublisher{block: block}
+
+	var mux sync.Mutex
+	var dropped int
+
+	d := eventbridge.NewDispatcher(publisher, eventbridge.DeliveryRetry, 2)
+	d.OnError = func(err error, key string) {
+		if errors.Is(err, eventbridge.ErrQueueFull) {
+			mux.Lock()
+			dropped++
+			mux.Unlock()
+		}
+	}
+
+	// the first message is taken by the worker and the rest fill the queue
+	var accepted int
+	for i := 0; i < 1010; i++ {
+		if d.Dispatch("a", []byte("1")) {
+			accepted++
+		}
+	}
+
+	close(block)
+
+	if err := d.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if accepted+dropped != 1010 || dropped < 9 {
+		t.Fatalf("Expected the overflowing messages to be dropped, got %d accepted and %d dropped", accepted, dropped)
+	}
+}
+
+type blockingPublisher struct {
+	block chan struct{}
+}
+
+func (p *blockingPublisher) Publish(ctx context.Context, key string, data []byte) error {
+	<-p.block
+	return nil
+}
+
+func (p *blockingPublisher) Close() error {
+	return nil
+}
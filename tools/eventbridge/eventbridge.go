@@ -0,0 +1,68 @@
+// Package eventbridge implements publishers for delivering app
+// events to external message queues (NATS, RabbitMQ, Kafka, AWS SNS and SQS).
+package eventbridge
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Supported event serialization formats.
+const (
+	FormatJson        = "json"
+	FormatCloudEvents = "cloudevents"
+)
+
+// Publisher defines a common interface for an external message queue publisher.
+type Publisher interface {
+	// Publish sends a single message to the external queue.
+	//
+	// key is an optional message key that some providers
+	// use for partitioning or ordering (eg. Kafka).
+	Publish(ctx context.Context, key string, data []byte) error
+
+	// Close releases the publisher resources (eg. open connections).
+	Close() error
+}
+
+// Event defines a single record change event.
+type Event struct {
+	Id           string    `json:"id"`
+	Action       string    `json:"action"`
+	Collection   string    `json:"collection"`
+	CollectionId string    `json:"collectionId"`
+	RecordId     string    `json:"recordId"`
+	Record       any       `json:"record"`
+	Time         time.Time `json:"time"`
+}
+
+// Marshal serializes the event in the specified format.
+//
+// The default FormatJson serializes the event struct as it is,
+// while FormatCloudEvents wraps the record in a CloudEvents v1.0
+// structured mode envelope.
+func (e *Event) Marshal(format string) ([]byte, error) {
+	switch format {
+	case "", FormatJson:
+		return json.Marshal(e)
+	case FormatCloudEvents:
+		return json.Marshal(map[string]any{
+			"specversion":     "1.0",
+			"id":              e.Id,
+			"source":          "/collections/" + e.Collection,
+			"type":            "pocketbase.record." + e.Action,
+			"subject":         e.RecordId,
+			"time":            e.Time.UTC().Format(time.RFC3339Nano),
+			"datacontenttype": "application/json",
+			"data":            e.Record,
+		})
+	default:
+		return nil, fmt.Errorf("unsupported event format %q", format)
+	}
+}
+
+// ErrQueueFull is reported when a message is dropped because the dispatcher queue is full.
+var ErrQueueFull = errors.New("the dispatcher queue is full")
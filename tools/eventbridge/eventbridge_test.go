@@ -0,0 +1,57 @@
+package eventbridge_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/eventbridge"
+)
+
+func TestEventMarshal(t *testing.T) {
+	event := &eventbridge.Event{
+		Id:           "test_id",
+		Action:       "create",
+		Collection:   "demo",
+		CollectionId: "demo_id",
+		RecordId:     "record_id",
+		Record:       map[string]any{"title": "test"},
+		Time:         time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+
+	scenarios := []struct {
+		format      string
+		expected    string
+		expectError bool
+	}{
+		{
+			"",
+			`{"id":"test_id","action":"create","collection":"demo","collectionId":"demo_id","recordId":"record_id","record":{"title":"test"},"time":"2023-01-02T03:04:05Z"}`,
+			false,
+		},
+		{
+			eventbridge.FormatJson,
+			`{"id":"test_id","action":"create","collection":"demo","collectionId":"demo_id","recordId":"record_id","record":{"title":"test"},"time":"2023-01-02T03:04:05Z"}`,
+			false,
+		},
+		{
+			eventbridge.FormatCloudEvents,
+			`{"data":{"title":"test"},"datacontenttype":"application/json","id":"test_id","source":"/collections/demo","specversion":"1.0","subject":"record_id","time":"2023-01-02T03:04:05Z","type":"pocketbase.record.create"}`,
+			false,
+		},
+		{"invalid", "", true},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.format, func(t *testing.T) {
+			raw, err := event.Marshal(s.format)
+
+			if hasErr := err != nil; hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if v := string(raw); v != s.expected {
+				t.Fatalf("Expected \n%s, \ngot \n%s", s.expected, v)
+			}
+		})
+	}
+}
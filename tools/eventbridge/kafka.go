@@ -0,0 +1,122 @@
+package eventbridge
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+var _ Publisher = (*KafkaPublisher)(nil)
+
+// KafkaPublisher publishes messages to a Kafka topic
+// through a Kafka REST Proxy (v2 API).
+type KafkaPublisher struct {
+	endpoint string
+	username string
+	password string
+	client   *http.Client
+}
+
+// NewKafkaPublisher creates a new Kafka REST Proxy publisher
+// for the specified proxy base url (eg. "https://kafka-rest.example.com:8082") and topic.
+//
+// The message key (aka. the record id) is used as Kafka record key.
+func NewKafkaPublisher(proxyUrl string, topic string, username string, password string) (*KafkaPublisher, error) {
+	if _, err := url.Parse(proxyUrl); err != nil {
+		return nil, err
+	}
+
+	if topic == "" {
+		return nil, errors.New("missing Kafka topic")
+	}
+
+	return &KafkaPublisher{
+		endpoint: strings.TrimRight(proxyUrl, "/") + "/topics/" + url.PathEscape(topic),
+		username: username,
+		password: password,
+		client:   &http.Client{},
+	}, nil
+}
+
+// Publish implements [Publisher.Publish].
+func (p *KafkaPublisher) Publish(ctx context.Context, key string, data []byte) error {
+	record := map[string]any{"value": json.RawMessage(data)}
+	if key != "" {
+		record["key"] = key
+	}
+
+	body := map[string]any{"records": []any{record}}
+
+	result := struct {
+		Offsets []struct {
+			ErrorCode *int   `json:"error_code"`
+			Error     string `json:"error"`
+		} `json:"offsets"`
+	}{}
+
+	err := sendJson(ctx, p.client, p.endpoint, "application/vnd.kafka.json.v2+json", p.username, p.password, body, &result)
+	if err != nil {
+		return err
+	}
+
+	for _, offset := range result.Offsets {
+		if offset.ErrorCode != nil || offset.Error != "" {
+			return fmt.Errorf("failed to produce the Kafka record: %s", offset.Error)
+		}
+	}
+
+	return nil
+}
+
+// Close implements [Publisher.Close].
+func (p *KafkaPublisher) Close() error {
+	return nil
+}
+
+// sendJson sends a POST json request and decodes the json response into result.
+func sendJson(
+	ctx context.Context,
+	client *http.Client,
+	endpoint string,
+	contentType string,
+	username string,
+	password string,
+	body any,
+	result any,
+) error {
+	rawBody, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(string(rawBody)))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Accept", "application/json")
+	if username != "" || password != "" {
+		req.SetBasicAuth(username, password)
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 400 {
+		return fmt.Errorf("unexpected %d response from %s", res.StatusCode, endpoint)
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.NewDecoder(res.Body).Decode(result)
+}
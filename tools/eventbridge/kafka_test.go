@@ -0,0 +1,59 @@
+package eventbridge_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/eventbridge"
+)
+
+func TestKafkaPublisherPublish(t *testing.T) {
+	var path, contentType, body string
+
+	response := `{"offsets":[{"partition":0,"offset":1,"error_code":null,"error":null}]}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		contentType = r.Header.Get("Content-Type")
+
+		raw, _ := io.ReadAll(r.Body)
+		body = string(raw)
+
+		w.Write([]byte(response))
+	}))
+	defer server.Close()
+
+	if _, err := eventbridge.NewKafkaPublisher(server.URL, "", "", ""); err == nil {
+		t.Fatal("Expected missing topic error, got nil")
+	}
+
+	p, err := eventbridge.NewKafkaPublisher(server.URL+"/", "events", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := p.Publish(context.Background(), "record_id", []byte(`{"a":1}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	if path != "/topics/events" {
+		t.Fatalf("Expected path %q, got %q", "/topics/events", path)
+	}
+
+	if contentType != "application/vnd.kafka.json.v2+json" {
+		t.Fatalf("Unexpected content type %q", contentType)
+	}
+
+	if expected := `{"records":[{"key":"record_id","value":{"a":1}}]}`; body != expected {
+		t.Fatalf("Expected body \n%s, \ngot \n%s", expected, body)
+	}
+
+	// record error
+	response = `{"offsets":[{"partition":null,"offset":null,"error_code":50002,"error":"test"}]}`
+	if err := p.Publish(context.Background(), "record_id", []byte(`{"a":1}`)); err == nil {
+		t.Fatal("Expected record error, got nil")
+	}
+}
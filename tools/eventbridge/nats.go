@@ -0,0 +1,217 @@
+package eventbridge
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+var _ Publisher = (*NatsPublisher)(nil)
+
+// NatsPublisher is a minimal core NATS protocol publisher.
+//
+// Each published message is followed by a PING to ensure
+// that it was processed by the server.
+type NatsPublisher struct {
+	address  string
+	useTls   bool
+	subject  string
+	username string
+	password string
+
+	mux    sync.Mutex
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// NewNatsPublisher creates a new NATS publisher for the specified server url
+// (eg. "nats://127.0.0.1:4222" or "tls://example.com:4222") and subject.
+//
+// If only username is set, it is used as auth token.
+func NewNatsPublisher(serverUrl string, subject string, username string, password string) (*NatsPublisher, error) {
+	u, err := url.Parse(serverUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme != "nats" && u.Scheme != "tls" {
+		return nil, fmt.Errorf("unsupported NATS url scheme %q", u.Scheme)
+	}
+
+	if subject == "" || strings.ContainsAny(subject, " \t\r\n") {
+		return nil, fmt.Errorf("invalid NATS subject %q", subject)
+	}
+
+	address := u.Host
+	if u.Port() == "" {
+		address = net.JoinHostPort(u.Hostname(), "4222")
+	}
+
+	if username == "" && u.User != nil {
+		username = u.User.Username()
+		password, _ = u.User.Password()
+	}
+
+	return &NatsPublisher{
+		address:  address,
+		useTls:   u.Scheme == "tls",
+		subject:  subject,
+		username: username,
+		password: password,
+	}, nil
+}
+
+// Publish implements [Publisher.Publish].
+func (p *NatsPublisher) Publish(ctx context.Context, key string, data []byte) error {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	if err := p.connect(ctx); err != nil {
+		return err
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		p.conn.SetDeadline(deadline)
+	} else {
+		p.conn.SetDeadline(time.Time{})
+	}
+
+	var payload strings.Builder
+	payload.WriteString(fmt.Sprintf("PUB %s %d\r\n", p.subject, len(data)))
+	payload.Write(data)
+	payload.WriteString("\r\nPING\r\n")
+
+	if _, err := p.conn.Write([]byte(payload.String())); err != nil {
+		p.reset()
+		return err
+	}
+
+	if err := p.waitPong(); err != nil {
+		p.reset()
+		return err
+	}
+
+	return nil
+}
+
+// Close implements [Publisher.Close].
+func (p *NatsPublisher) Close() error {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	return p.reset()
+}
+
+func (p *NatsPublisher) connect(ctx context.Context) error {
+	if p.conn != nil {
+		return nil
+	}
+
+	dialer := &net.Dialer{}
+
+	conn, err := dialer.DialContext(ctx, "tcp", p.address)
+	if err != nil {
+		return err
+	}
+
+	if p.useTls {
+		host, _, _ := net.SplitHostPort(p.address)
+		conn = tls.Client(conn, &tls.Config{ServerName: host})
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	p.conn = conn
+	p.reader = bufio.NewReader(conn)
+
+	// the server always starts with an INFO message
+	line, err := p.readLine()
+	if err != nil || !strings.HasPrefix(line, "INFO") {
+		p.reset()
+		return fmt.Errorf("unexpected NATS server greeting: %q (%v)", line, err)
+	}
+
+	options := map[string]any{
+		"verbose":  false,
+		"pedantic": false,
+		"name":     "pocketbase",
+		"lang":     "go",
+	}
+	if p.password != "" {
+		options["user"] = p.username
+		options["pass"] = p.password
+	} else if p.username != "" {
+		options["auth_token"] = p.username
+	}
+
+	rawOptions, err := json.Marshal(options)
+	if err != nil {
+		p.reset()
+		return err
+	}
+
+	if _, err := conn.Write([]byte("CONNECT " + string(rawOptions) + "\r\nPING\r\n")); err != nil {
+		p.reset()
+		return err
+	}
+
+	if err := p.waitPong(); err != nil {
+		p.reset()
+		return err
+	}
+
+	return nil
+}
+
+// waitPong reads the server messages until a PONG is received
+// (replying to the server PINGs and ignoring the INFO updates).
+func (p *NatsPublisher) waitPong() error {
+	for {
+		line, err := p.readLine()
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			if _, err := p.conn.Write([]byte("PONG\r\n")); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return errors.New("NATS server error: " + strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		}
+	}
+}
+
+func (p *NatsPublisher) readLine() (string, error) {
+	line, err := p.reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func (p *NatsPublisher) reset() error {
+	if p.conn == nil {
+		return nil
+	}
+
+	err := p.conn.Close()
+	p.conn = nil
+	p.reader = nil
+
+	return err
+}
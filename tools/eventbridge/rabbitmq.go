@@ -0,0 +1,619 @@
+package eventbridge
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+var _ Publisher = (*RabbitMQPublisher)(nil)
+
+// AMQP 0-9-1 frame types.
+const (
+	amqpFrameMethod = 1
+	amqpFrameHeader = 2
+	amqpFrameBody   = 3
+	amqpFrameEnd    = 0xCE
+)
+
+// AMQP 0-9-1 class and method ids (as single class<<16|method value).
+const (
+	amqpConnectionStart   = 10<<16 | 10
+	amqpConnectionStartOk = 10<<16 | 11
+	amqpConnectionTune    = 10<<16 | 30
+	amqpConnectionTuneOk  = 10<<16 | 31
+	amqpConnectionOpen    = 10<<16 | 40
+	amqpConnectionOpenOk  = 10<<16 | 41
+	amqpConnectionClose   = 10<<16 | 50
+	amqpConnectionCloseOk = 10<<16 | 51
+	amqpChannelOpen       = 20<<16 | 10
+	amqpChannelOpenOk     = 20<<16 | 11
+	amqpChannelClose      = 20<<16 | 40
+	amqpBasicPublish      = 60<<16 | 40
+	amqpBasicReturn       = 60<<16 | 50
+	amqpBasicAck          = 60<<16 | 80
+	amqpBasicNack         = 60<<16 | 120
+	amqpConfirmSelect     = 85<<16 | 10
+	amqpConfirmSelectOk   = 85<<16 | 11
+)
+
+const (
+	// amqpBasicClass is the content header class id of the published messages.
+	amqpBasicClass = 60
+
+	// amqpPublishChannel is the id of the single channel used for publishing.
+	amqpPublishChannel = 1
+
+	// amqpDefaultFrameMax is the max frame size negotiated with the broker
+	// (it could be lowered by the broker).
+	amqpDefaultFrameMax = 131072
+
+	// amqpFrameOverhead is the size of the frame header and frame end octet.
+	amqpFrameOverhead = 8
+)
+
+// RabbitMQPublisher is a minimal AMQP 0-9-1 publisher.
+//
+// The channel is in confirm mode and each published message waits
+// for the broker ack to ensure that it was routed and persisted.
+type RabbitMQPublisher struct {
+	address    string
+	useTls     bool
+	vhost      string
+	exchange   string
+	routingKey string
+	username   string
+	password   string
+
+	mux         sync.Mutex
+	conn        net.Conn
+	reader      *bufio.Reader
+	frameMax    int
+	deliveryTag uint64
+}
+
+// NewRabbitMQPublisher creates a new RabbitMQ publisher for the specified
+// broker url (eg. "amqp://127.0.0.1:5672" or "amqps://example.com/vhost") and exchange.
+//
+// The virtual host is the url path (default to "/").
+//
+// If routingKey is empty, the message key (aka. the record id) is used as routing key.
+func NewRabbitMQPublisher(brokerUrl string, exchange string, routingKey string, username string, password string) (*RabbitMQPublisher, error) {
+	u, err := url.Parse(brokerUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme != "amqp" && u.Scheme != "amqps" {
+		return nil, fmt.Errorf("unsupported RabbitMQ url scheme %q", u.Scheme)
+	}
+
+	if exchange == "" {
+		return nil, errors.New("missing RabbitMQ exchange")
+	}
+
+	if len(exchange) > 255 || len(routingKey) > 255 {
+		return nil, errors.New("the RabbitMQ exchange and routing key must be max 255 characters")
+	}
+
+	address := u.Host
+	if u.Port() == "" {
+		port := "5672"
+		if u.Scheme == "amqps" {
+			port = "5671"
+		}
+		address = net.JoinHostPort(u.Hostname(), port)
+	}
+
+	vhost := strings.TrimPrefix(u.Path, "/")
+	if vhost == "" {
+		vhost = "/"
+	}
+
+	if username == "" && u.User != nil {
+		username = u.User.Username()
+		password, _ = u.User.Password()
+	}
+
+	if username == "" {
+		username = "guest"
+		password = "guest"
+	}
+
+	return &RabbitMQPublisher{
+		address:    address,
+		useTls:     u.Scheme == "amqps",
+		vhost:      vhost,
+		exchange:   exchange,
+		routingKey: routingKey,
+		username:   username,
+		password:   password,
+	}, nil
+}
+
+// Publish implements [Publisher.Publish].
+func (p *RabbitMQPublisher) Publish(ctx context.Context, key string, data []byte) error {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	if err := p.connect(ctx); err != nil {
+		return err
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		p.conn.SetDeadline(deadline)
+	} else {
+		p.conn.SetDeadline(time.Time{})
+	}
+
+	routingKey := p.routingKey
+	if routingKey == "" {
+		routingKey = key
+	}
+
+	if len(routingKey) > 255 || len(key) > 255 {
+		return errors.New("the RabbitMQ routing key and message id must be max 255 characters")
+	}
+
+	if err := p.writePublish(routingKey, key, data); err != nil {
+		p.reset()
+		return err
+	}
+
+	p.deliveryTag++
+
+	if err := p.waitConfirm(p.deliveryTag); err != nil {
+		var returnedErr *amqpReturnedError
+		if !errors.As(err, &returnedErr) {
+			p.reset()
+		}
+		return err
+	}
+
+	return nil
+}
+
+// Close implements [Publisher.Close].
+func (p *RabbitMQPublisher) Close() error {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	if p.conn != nil {
+		// best-effort graceful close
+		p.conn.SetDeadline(time.Now().Add(time.Second))
+		args := &amqpWriter{}
+		args.short(200)
+		args.shortstr("bye")
+		args.short(0)
+		args.short(0)
+		if p.writeMethod(0, amqpConnectionClose, args) == nil {
+			p.readMethodUntil(0, amqpConnectionCloseOk)
+		}
+	}
+
+	return p.reset()
+}
+
+func (p *RabbitMQPublisher) connect(ctx context.Context) error {
+	if p.conn != nil {
+		return nil
+	}
+
+	dialer := &net.Dialer{}
+
+	conn, err := dialer.DialContext(ctx, "tcp", p.address)
+	if err != nil {
+		return err
+	}
+
+	if p.useTls {
+		host, _, _ := net.SplitHostPort(p.address)
+		conn = tls.Client(conn, &tls.Config{ServerName: host})
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	p.conn = conn
+	p.reader = bufio.NewReader(conn)
+	p.frameMax = amqpDefaultFrameMax
+	p.deliveryTag = 0
+
+	if err := p.handshake(); err != nil {
+		p.reset()
+		return fmt.Errorf("RabbitMQ handshake failed: %w", err)
+	}
+
+	return nil
+}
+
+func (p *RabbitMQPublisher) handshake() error {
+	if _, err := p.conn.Write([]byte("AMQP\x00\x00\x09\x01")); err != nil {
+		return err
+	}
+
+	// connection.start
+	if _, err := p.readMethodUntil(0, amqpConnectionStart); err != nil {
+		return err
+	}
+
+	startOk := &amqpWriter{}
+	startOk.table(map[string]string{"product": "pocketbase"})
+	startOk.shortstr("PLAIN")
+	startOk.longstr("\x00" + p.username + "\x00" + p.password)
+	startOk.shortstr("en_US")
+	if err := p.writeMethod(0, amqpConnectionStartOk, startOk); err != nil {
+		return err
+	}
+
+	// connection.tune
+	tune, err := p.readMethodUntil(0, amqpConnectionTune)
+	if err != nil {
+		return err
+	}
+	if len(tune) < 8 {
+		return errors.New("malformed connection.tune frame")
+	}
+
+	channelMax := binary.BigEndian.Uint16(tune[0:2])
+	if frameMax := int(binary.BigEndian.Uint32(tune[2:6])); frameMax > 0 && frameMax < p.frameMax {
+		p.frameMax = frameMax
+	}
+
+	tuneOk := &amqpWriter{}
+	tuneOk.short(channelMax)
+	tuneOk.long(uint32(p.frameMax))
+	tuneOk.short(0) // no heartbeats
+	if err := p.writeMethod(0, amqpConnectionTuneOk, tuneOk); err != nil {
+		return err
+	}
+
+	open := &amqpWriter{}
+	open.shortstr(p.vhost)
+	open.shortstr("")
+	open.octet(0)
+	if err := p.writeMethod(0, amqpConnectionOpen, open); err != nil {
+		return err
+	}
+	if _, err := p.readMethodUntil(0, amqpConnectionOpenOk); err != nil {
+		return err
+	}
+
+	channelOpen := &amqpWriter{}
+	channelOpen.shortstr("")
+	if err := p.writeMethod(amqpPublishChannel, amqpChannelOpen, channelOpen); err != nil {
+		return err
+	}
+	if _, err := p.readMethodUntil(amqpPublishChannel, amqpChannelOpenOk); err != nil {
+		return err
+	}
+
+	confirmSelect := &amqpWriter{}
+	confirmSelect.octet(0)
+	if err := p.writeMethod(amqpPublishChannel, amqpConfirmSelect, confirmSelect); err != nil {
+		return err
+	}
+	if _, err := p.readMethodUntil(amqpPublishChannel, amqpConfirmSelectOk); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// writePublish writes the basic.publish method frame followed
+// by the content header and the content body frames.
+func (p *RabbitMQPublisher) writePublish(routingKey string, messageId string, data []byte) error {
+	var buf bytes.Buffer
+
+	publish := &amqpWriter{}
+	publish.short(0)
+	publish.shortstr(p.exchange)
+	publish.shortstr(routingKey)
+	publish.octet(1) // mandatory (aka. return the unroutable messages)
+	writeAmqpFrame(&buf, amqpFrameMethod, amqpPublishChannel, amqpMethodPayload(amqpBasicPublish, publish))
+
+	header := &amqpWriter{}
+	header.short(amqpBasicClass)
+	header.short(0)
+	header.longlong(uint64(len(data)))
+	header.short(0x8000 | 0x1000 | 0x0080) // content-type, delivery-mode, message-id
+	header.shortstr("application/json")
+	header.octet(2) // persistent
+	header.shortstr(messageId)
+	writeAmqpFrame(&buf, amqpFrameHeader, amqpPublishChannel, header.Bytes())
+
+	chunkSize := p.frameMax - amqpFrameOverhead
+	for len(data) > 0 {
+		n := min(len(data), chunkSize)
+		writeAmqpFrame(&buf, amqpFrameBody, amqpPublishChannel, data[:n])
+		data = data[n:]
+	}
+
+	_, err := p.conn.Write(buf.Bytes())
+
+	return err
+}
+
+// waitConfirm reads the broker frames until the publish
+// of the specified delivery tag is acked or nacked.
+func (p *RabbitMQPublisher) waitConfirm(deliveryTag uint64) error {
+	var returnedErr error
+
+	for {
+		frameType, channel, payload, err := p.readFrame()
+		if err != nil {
+			return err
+		}
+
+		// skip the returned message content frames and the heartbeats
+		if frameType != amqpFrameMethod {
+			continue
+		}
+
+		method, args, err := parseAmqpMethod(payload)
+		if err != nil {
+			return err
+		}
+
+		if err := amqpCloseError(method, args); err != nil {
+			return err
+		}
+
+		if channel != amqpPublishChannel {
+			continue
+		}
+
+		switch method {
+		case amqpBasicReturn:
+			r := &amqpReader{data: args}
+			code := r.short()
+			text := r.shortstr()
+			returnedErr = &amqpReturnedError{code: code, text: text}
+		case amqpBasicAck, amqpBasicNack:
+			r := &amqpReader{data: args}
+			tag := r.longlong()
+			multiple := r.octet()&1 == 1
+			if r.err != nil {
+				return r.err
+			}
+
+			if tag != deliveryTag && !(multiple && tag >= deliveryTag) {
+				continue // older confirm
+			}
+
+			if method == amqpBasicNack {
+				return errors.New("the RabbitMQ message was rejected by the broker")
+			}
+
+			return returnedErr
+		}
+	}
+}
+
+// readMethodUntil reads the broker frames until the
+// specified method is received and returns its arguments.
+func (p *RabbitMQPublisher) readMethodUntil(expectedChannel uint16, expectedMethod uint32) ([]byte, error) {
+	for {
+		frameType, channel, payload, err := p.readFrame()
+		if err != nil {
+			return nil, err
+		}
+
+		if frameType != amqpFrameMethod {
+			continue
+		}
+
+		method, args, err := parseAmqpMethod(payload)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := amqpCloseError(method, args); err != nil {
+			return nil, err
+		}
+
+		if channel == expectedChannel && method == expectedMethod {
+			return args, nil
+		}
+	}
+}
+
+func (p *RabbitMQPublisher) readFrame() (byte, uint16, []byte, error) {
+	header := make([]byte, 7)
+	if _, err := io.ReadFull(p.reader, header); err != nil {
+		return 0, 0, nil, err
+	}
+
+	size := binary.BigEndian.Uint32(header[3:7])
+	if int(size) > max(p.frameMax, amqpDefaultFrameMax) {
+		return 0, 0, nil, fmt.Errorf("the AMQP frame size %d exceeds the max allowed", size)
+	}
+
+	payload := make([]byte, size+1)
+	if _, err := io.ReadFull(p.reader, payload); err != nil {
+		return 0, 0, nil, err
+	}
+
+	if payload[size] != amqpFrameEnd {
+		return 0, 0, nil, errors.New("invalid AMQP frame end")
+	}
+
+	return header[0], binary.BigEndian.Uint16(header[1:3]), payload[:size], nil
+}
+
+func (p *RabbitMQPublisher) writeMethod(channel uint16, method uint32, args *amqpWriter) error {
+	var buf bytes.Buffer
+
+	writeAmqpFrame(&buf, amqpFrameMethod, channel, amqpMethodPayload(method, args))
+
+	_, err := p.conn.Write(buf.Bytes())
+
+	return err
+}
+
+func (p *RabbitMQPublisher) reset() error {
+	if p.conn == nil {
+		return nil
+	}
+
+	err := p.conn.Close()
+	p.conn = nil
+	p.reader = nil
+
+	return err
+}
+
+// -------------------------------------------------------------------
+
+// amqpReturnedError is reported when the published message
+// couldn't be routed to any queue (aka. basic.return).
+type amqpReturnedError struct {
+	code uint16
+	text string
+}
+
+func (e *amqpReturnedError) Error() string {
+	return fmt.Sprintf("the RabbitMQ message was not routed to any queue: %d %s", e.code, e.text)
+}
+
+// amqpCloseError returns an error if the method is a connection or channel close.
+func amqpCloseError(method uint32, args []byte) error {
+	if method != amqpConnectionClose && method != amqpChannelClose {
+		return nil
+	}
+
+	r := &amqpReader{data: args}
+	code := r.short()
+	text := r.shortstr()
+
+	return fmt.Errorf("RabbitMQ closed the connection: %d %s", code, text)
+}
+
+func parseAmqpMethod(payload []byte) (uint32, []byte, error) {
+	if len(payload) < 4 {
+		return 0, nil, errors.New("malformed AMQP method frame")
+	}
+
+	return binary.BigEndian.Uint32(payload[0:4]), payload[4:], nil
+}
+
+func amqpMethodPayload(method uint32, args *amqpWriter) []byte {
+	payload := binary.BigEndian.AppendUint32(nil, method)
+
+	if args != nil {
+		payload = append(payload, args.Bytes()...)
+	}
+
+	return payload
+}
+
+func writeAmqpFrame(buf *bytes.Buffer, frameType byte, channel uint16, payload []byte) {
+	buf.WriteByte(frameType)
+	buf.Write(binary.BigEndian.AppendUint16(nil, channel))
+	buf.Write(binary.BigEndian.AppendUint32(nil, uint32(len(payload))))
+	buf.Write(payload)
+	buf.WriteByte(amqpFrameEnd)
+}
+
+// amqpWriter encodes the AMQP method arguments and content properties.
+type amqpWriter struct {
+	bytes.Buffer
+}
+
+func (w *amqpWriter) octet(v byte) {
+	w.WriteByte(v)
+}
+
+func (w *amqpWriter) short(v uint16) {
+	w.Write(binary.BigEndian.AppendUint16(nil, v))
+}
+
+func (w *amqpWriter) long(v uint32) {
+	w.Write(binary.BigEndian.AppendUint32(nil, v))
+}
+
+func (w *amqpWriter) longlong(v uint64) {
+	w.Write(binary.BigEndian.AppendUint64(nil, v))
+}
+
+func (w *amqpWriter) shortstr(v string) {
+	w.WriteByte(byte(len(v)))
+	w.WriteString(v)
+}
+
+func (w *amqpWriter) longstr(v string) {
+	w.long(uint32(len(v)))
+	w.WriteString(v)
+}
+
+// table writes a field table with long string values.
+func (w *amqpWriter) table(fields map[string]string) {
+	t := &amqpWriter{}
+	for k, v := range fields {
+		t.shortstr(k)
+		t.octet('S')
+		t.longstr(v)
+	}
+
+	w.long(uint32(t.Len()))
+	w.Write(t.Bytes())
+}
+
+// amqpReader decodes the AMQP method arguments
+// (the first decoding error is stored in err).
+type amqpReader struct {
+	data []byte
+	err  error
+}
+
+func (r *amqpReader) next(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+
+	if len(r.data) < n {
+		r.err = errors.New("malformed AMQP method arguments")
+		return nil
+	}
+
+	v := r.data[:n]
+	r.data = r.data[n:]
+
+	return v
+}
+
+func (r *amqpReader) octet() byte {
+	if v := r.next(1); v != nil {
+		return v[0]
+	}
+	return 0
+}
+
+func (r *amqpReader) short() uint16 {
+	if v := r.next(2); v != nil {
+		return binary.BigEndian.Uint16(v)
+	}
+	return 0
+}
+
+func (r *amqpReader) longlong() uint64 {
+	if v := r.next(8); v != nil {
+		return binary.BigEndian.Uint64(v)
+	}
+	return 0
+}
+
+func (r *amqpReader) shortstr() string {
+	return string(r.next(int(r.octet())))
+}
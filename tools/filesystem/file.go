@@ -169,12 +169,21 @@ func (r *bytesReadSeekCloser) Close() error {
 
 var extInvalidCharsRegex = regexp.MustCompile(`[^\w\.\*\-\+\=\#]+`)
 
+// NewFileName returns a new normalized and unique file name
+// from the provided original one (the same as the uploaded [File.Name]).
+//
+// Because there is no file content to inspect, the extension is
+// not auto detected if the original name doesn't have one.
+func NewFileName(originalName string) string {
+	return normalizeName(nil, originalName)
+}
+
 func normalizeName(fr FileReader, name string) string {
 	// extension
 	// ---
 	originalExt := filepath.Ext(name)
 	cleanExt := extInvalidCharsRegex.ReplaceAllString(originalExt, "")
-	if cleanExt == "" {
+	if cleanExt == "" && fr != nil {
 		// try to detect the extension from the file content
 		cleanExt, _ = detectExtension(fr)
 	}
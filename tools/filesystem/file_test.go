@@ -74,6 +74,31 @@ func TestNewFileFromBytes(t *testing.T) {
 	}
 }
 
+func TestNewFileName(t *testing.T) {
+	scenarios := []struct {
+		name    string
+		pattern string
+	}{
+		{"image_! noext", regexp.QuoteMeta("image_noext_") + `\w{10}$`},
+		{"Test File.PNG", regexp.QuoteMeta("test_file_") + `\w{10}` + regexp.QuoteMeta(".PNG")},
+		{"a.txt", `^a\w{10}_\w{10}` + regexp.QuoteMeta(".txt")},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result := filesystem.NewFileName(s.name)
+
+			if match, _ := regexp.MatchString(s.pattern, result); !match {
+				t.Fatalf("Expected name to match %v, got %q", s.pattern, result)
+			}
+
+			if result == filesystem.NewFileName(s.name) {
+				t.Fatalf("Expected the names to have random parts, got %q", result)
+			}
+		})
+	}
+}
+
 func TestNewFileFromMultipart(t *testing.T) {
 	formData, mp, err := tests.MockMultipartData(nil, "test")
 	if err != nil {
@@ -14,6 +14,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
@@ -165,6 +166,23 @@ func (s *System) Upload(content []byte, fileKey string) error {
 	return w.Close()
 }
 
+// SignedUploadUrl returns a pre-signed url that allows a direct
+// HTTP PUT upload of a single file to the fileKey location.
+//
+// If contentType is set, the upload request must have the same
+// Content-Type header, otherwise it must not have one.
+//
+// Note that the local filesystem doesn't support pre-signed urls and
+// in that case an error is returned.
+func (s *System) SignedUploadUrl(fileKey string, contentType string, expiry time.Duration) (string, error) {
+	return s.bucket.SignedURL(s.ctx, fileKey, &blob.SignedURLOptions{
+		Method:                   http.MethodPut,
+		Expiry:                   expiry,
+		ContentType:              contentType,
+		EnforceAbsentContentType: contentType == "",
+	})
+}
+
 // UploadFile uploads the provided multipart file to the fileKey location.
 func (s *System) UploadFile(file *File, fileKey string) error {
 	f, err := file.Reader.Open()
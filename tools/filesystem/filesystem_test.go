@@ -11,6 +11,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/pocketbase/pocketbase/tools/filesystem"
 )
@@ -82,6 +83,23 @@ func TestFileSystemAttributes(t *testing.T) {
 	}
 }
 
+func TestFileSystemSignedUploadUrl(t *testing.T) {
+	dir := createTestDir(t)
+	defer os.RemoveAll(dir)
+
+	fs, err := filesystem.NewLocal(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fs.Close()
+
+	// the local filesystem doesn't have an url signer
+	url, err := fs.SignedUploadUrl("test/new.txt", "text/plain", time.Minute)
+	if err == nil {
+		t.Fatalf("Expected error, got url %q", url)
+	}
+}
+
 func TestFileSystemDelete(t *testing.T) {
 	dir := createTestDir(t)
 	defer os.RemoveAll(dir)
@@ -0,0 +1,53 @@
+package filesystem
+
+import (
+	"bytes"
+
+	"github.com/disintegration/imaging"
+)
+
+// NewSquareImageFile creates a new square image File from the provided one
+// by center cropping it and downscaling it to maxDimension (if larger).
+//
+// The image is re-encoded in the format of the file name extension
+// (fallbacks to PNG) which also strips all of its metadata (EXIF, etc.).
+// The file name is preserved.
+//
+// For animated image formats only the first frame is kept.
+func NewSquareImageFile(file *File, maxDimension int) (*File, error) {
+	r, err := file.Reader.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	img, err := imaging.Decode(r, imaging.AutoOrientation(true))
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := img.Bounds()
+	size := min(bounds.Dx(), bounds.Dy())
+	if maxDimension > 0 && size > maxDimension {
+		size = maxDimension
+	}
+
+	squareImg := imaging.Fill(img, size, size, imaging.Center, imaging.Lanczos)
+
+	format, err := imaging.FormatFromFilename(file.Name)
+	if err != nil {
+		format = imaging.PNG
+	}
+
+	var buf bytes.Buffer
+	if err := imaging.Encode(&buf, squareImg, format); err != nil {
+		return nil, err
+	}
+
+	return &File{
+		Reader:       &BytesReader{buf.Bytes()},
+		Name:         file.Name,
+		OriginalName: file.OriginalName,
+		Size:         int64(buf.Len()),
+	}, nil
+}
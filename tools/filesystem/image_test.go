@@ -0,0 +1,78 @@
+package filesystem_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/filesystem"
+)
+
+func TestNewSquareImageFile(t *testing.T) {
+	scenarios := []struct {
+		name         string
+		width        int
+		height       int
+		maxDimension int
+		expectedSize int
+	}{
+		{"landscape", 40, 20, 0, 20},
+		{"portrait", 20, 40, 0, 20},
+		{"downscaled", 40, 30, 10, 10},
+		{"not upscaled", 10, 10, 50, 10},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			img := image.NewRGBA(image.Rect(0, 0, s.width, s.height))
+			img.Set(0, 0, color.RGBA{255, 0, 0, 255})
+
+			var buf bytes.Buffer
+			if err := png.Encode(&buf, img); err != nil {
+				t.Fatal(err)
+			}
+
+			original, err := filesystem.NewFileFromBytes(buf.Bytes(), "avatar.png")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			result, err := filesystem.NewSquareImageFile(original, s.maxDimension)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if result.Name != original.Name {
+				t.Fatalf("Expected Name %q, got %q", original.Name, result.Name)
+			}
+
+			r, err := result.Reader.Open()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Close()
+
+			config, _, err := image.DecodeConfig(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if config.Width != s.expectedSize || config.Height != s.expectedSize {
+				t.Fatalf("Expected %dx%d image, got %dx%d", s.expectedSize, s.expectedSize, config.Width, config.Height)
+			}
+		})
+	}
+}
+
+func TestNewSquareImageFileInvalidImage(t *testing.T) {
+	original, err := filesystem.NewFileFromBytes([]byte("test"), "avatar.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := filesystem.NewSquareImageFile(original, 0); err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+}
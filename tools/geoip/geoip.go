@@ -0,0 +1,403 @@
+// Package geoip implements a minimal read-only MaxMind DB (mmdb) reader
+// used for resolving the geolocation data of an IP address.
+//
+// Only the data types defined in the MaxMind DB format spec v2 are supported
+// (https://maxmind.github.io/MaxMind-DB/).
+package geoip
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"net"
+	"os"
+)
+
+var metadataStartMarker = []byte("\xAB\xCD\xEFMaxMind.com")
+
+// dataSectionSeparatorSize is the size of the 16 zero bytes
+// separator between the search tree and the data section.
+const dataSectionSeparatorSize = 16
+
+// ErrInvalidDatabase is returned when the database file is malformed.
+var ErrInvalidDatabase = errors.New("invalid MaxMind DB file")
+
+// Reader defines a MaxMind DB reader.
+//
+// It is safe for concurrent use.
+type Reader struct {
+	buf        []byte
+	data       []byte
+	nodeCount  uint
+	recordSize uint
+	ipVersion  uint
+	ipv4Start  uint
+}
+
+// Open loads the MaxMind DB file at the specified path into memory
+// and returns a new Reader for it.
+func Open(path string) (*Reader, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(buf)
+}
+
+// New creates a new Reader from the raw MaxMind DB file content.
+func New(buf []byte) (*Reader, error) {
+	metaStart := bytes.LastIndex(buf, metadataStartMarker)
+	if metaStart == -1 {
+		return nil, ErrInvalidDatabase
+	}
+	metaStart += len(metadataStartMarker)
+
+	d := &decoder{buf: buf[metaStart:]}
+	rawMeta, _, err := d.decode(0)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDatabase, err)
+	}
+
+	meta, ok := rawMeta.(map[string]any)
+	if !ok {
+		return nil, ErrInvalidDatabase
+	}
+
+	r := &Reader{
+		buf:        buf,
+		nodeCount:  toUint(meta["node_count"]),
+		recordSize: toUint(meta["record_size"]),
+		ipVersion:  toUint(meta["ip_version"]),
+	}
+
+	if r.recordSize != 24 && r.recordSize != 28 && r.recordSize != 32 {
+		return nil, fmt.Errorf("%w: unsupported record size %d", ErrInvalidDatabase, r.recordSize)
+	}
+
+	treeSize := r.nodeCount * r.recordSize / 4
+	if treeSize+dataSectionSeparatorSize > uint(metaStart) {
+		return nil, ErrInvalidDatabase
+	}
+
+	r.data = buf[treeSize+dataSectionSeparatorSize : metaStart-len(metadataStartMarker)]
+
+	// IPv4 addresses in IPv6 tree are stored in the ::/96 subnet
+	if r.ipVersion == 6 {
+		for i := 0; i < 96 && r.ipv4Start < r.nodeCount; i++ {
+			r.ipv4Start, err = r.readNode(r.ipv4Start, 0)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return r, nil
+}
+
+// Lookup returns the decoded data record associated with the provided ip
+// (usually a map[string]any).
+//
+// Returns nil if there is no record for the ip.
+func (r *Reader) Lookup(ip net.IP) (any, error) {
+	if ip == nil {
+		return nil, errors.New("invalid ip address")
+	}
+
+	node, bitCount, err := r.startNode(ip)
+	if err != nil {
+		return nil, err
+	}
+
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	} else {
+		ip = ip.To16()
+	}
+
+	for i := uint(0); i < bitCount && node < r.nodeCount; i++ {
+		bit := 1 & (ip[i>>3] >> (7 - (i % 8)))
+
+		node, err = r.readNode(node, uint(bit))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if node == r.nodeCount {
+		return nil, nil // not found
+	}
+
+	if node < r.nodeCount {
+		return nil, ErrInvalidDatabase
+	}
+
+	offset := node - r.nodeCount - dataSectionSeparatorSize
+	if offset >= uint(len(r.data)) {
+		return nil, ErrInvalidDatabase
+	}
+
+	d := &decoder{buf: r.data}
+	result, _, err := d.decode(offset)
+
+	return result, err
+}
+
+// Country returns the ISO 3166-1 country code associated with the
+// provided ip (or empty string if not found).
+func (r *Reader) Country(ip net.IP) string {
+	result, err := r.Lookup(ip)
+	if err != nil {
+		return ""
+	}
+
+	record, _ := result.(map[string]any)
+
+	for _, key := range []string{"country", "registered_country"} {
+		country, _ := record[key].(map[string]any)
+		if code, ok := country["iso_code"].(string); ok && code != "" {
+			return code
+		}
+	}
+
+	return ""
+}
+
+func (r *Reader) startNode(ip net.IP) (node uint, bitCount uint, err error) {
+	if ip.To4() == nil {
+		if r.ipVersion == 4 {
+			return 0, 0, errors.New("IPv6 address lookup in an IPv4-only database")
+		}
+		return 0, 128, nil
+	}
+
+	if r.ipVersion == 4 {
+		return 0, 32, nil
+	}
+
+	return r.ipv4Start, 32, nil
+}
+
+func (r *Reader) readNode(node uint, bit uint) (uint, error) {
+	size := r.recordSize / 4
+	offset := node * size
+
+	if offset+size > uint(len(r.buf)) {
+		return 0, ErrInvalidDatabase
+	}
+
+	b := r.buf[offset : offset+size]
+
+	switch r.recordSize {
+	case 24:
+		if bit == 0 {
+			return uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2]), nil
+		}
+		return uint(b[3])<<16 | uint(b[4])<<8 | uint(b[5]), nil
+	case 28:
+		if bit == 0 {
+			return (uint(b[3])&0xF0)<<20 | uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2]), nil
+		}
+		return (uint(b[3])&0x0F)<<24 | uint(b[4])<<16 | uint(b[5])<<8 | uint(b[6]), nil
+	default: // 32
+		if bit == 0 {
+			return uint(binary.BigEndian.Uint32(b[0:4])), nil
+		}
+		return uint(binary.BigEndian.Uint32(b[4:8])), nil
+	}
+}
+
+// -------------------------------------------------------------------
+
+const (
+	typeExtended  = 0
+	typePointer   = 1
+	typeString    = 2
+	typeDouble    = 3
+	typeBytes     = 4
+	typeUint16    = 5
+	typeUint32    = 6
+	typeMap       = 7
+	typeInt32     = 8
+	typeUint64    = 9
+	typeUint128   = 10
+	typeArray     = 11
+	typeContainer = 12
+	typeEnd       = 13
+	typeBool      = 14
+	typeFloat     = 15
+)
+
+// decoder decodes the MaxMind DB data section values.
+type decoder struct {
+	buf []byte
+}
+
+// decode decodes the value at the specified offset and returns
+// the decoded value and the offset of the next value.
+func (d *decoder) decode(offset uint) (any, uint, error) {
+	if offset >= uint(len(d.buf)) {
+		return nil, 0, ErrInvalidDatabase
+	}
+
+	ctrl := d.buf[offset]
+	offset++
+
+	dataType := uint(ctrl >> 5)
+
+	if dataType == typePointer {
+		pointer, next, err := d.decodePointer(ctrl, offset)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		value, _, err := d.decode(pointer)
+
+		return value, next, err
+	}
+
+	if dataType == typeExtended {
+		if offset >= uint(len(d.buf)) {
+			return nil, 0, ErrInvalidDatabase
+		}
+		dataType = 7 + uint(d.buf[offset])
+		offset++
+	}
+
+	size := uint(ctrl & 0x1f)
+	if size >= 29 {
+		n := size - 28 // number of the size bytes
+		if offset+n > uint(len(d.buf)) {
+			return nil, 0, ErrInvalidDatabase
+		}
+
+		extra := uint(0)
+		for _, b := range d.buf[offset : offset+n] {
+			extra = extra<<8 | uint(b)
+		}
+		offset += n
+
+		switch size {
+		case 29:
+			size = 29 + extra
+		case 30:
+			size = 285 + extra
+		default:
+			size = 65821 + extra
+		}
+	}
+
+	switch dataType {
+	case typeMap:
+		result := map[string]any{}
+		for i := uint(0); i < size; i++ {
+			key, next, err := d.decode(offset)
+			if err != nil {
+				return nil, 0, err
+			}
+
+			value, next, err := d.decode(next)
+			if err != nil {
+				return nil, 0, err
+			}
+
+			keyStr, _ := key.(string)
+			result[keyStr] = value
+			offset = next
+		}
+		return result, offset, nil
+	case typeArray:
+		result := []any{}
+		for i := uint(0); i < size; i++ {
+			value, next, err := d.decode(offset)
+			if err != nil {
+				return nil, 0, err
+			}
+			result = append(result, value)
+			offset = next
+		}
+		return result, offset, nil
+	case typeBool:
+		return size != 0, offset, nil
+	case typeEnd, typeContainer:
+		return nil, offset, nil
+	}
+
+	if offset+size > uint(len(d.buf)) {
+		return nil, 0, ErrInvalidDatabase
+	}
+
+	raw := d.buf[offset : offset+size]
+	next := offset + size
+
+	switch dataType {
+	case typeString:
+		return string(raw), next, nil
+	case typeBytes:
+		return append([]byte(nil), raw...), next, nil
+	case typeDouble:
+		if size != 8 {
+			return nil, 0, ErrInvalidDatabase
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(raw)), next, nil
+	case typeFloat:
+		if size != 4 {
+			return nil, 0, ErrInvalidDatabase
+		}
+		return math.Float32frombits(binary.BigEndian.Uint32(raw)), next, nil
+	case typeUint16, typeUint32, typeUint64:
+		v := uint64(0)
+		for _, b := range raw {
+			v = v<<8 | uint64(b)
+		}
+		return v, next, nil
+	case typeInt32:
+		v := int32(0)
+		for _, b := range raw {
+			v = v<<8 | int32(b)
+		}
+		return int64(v), next, nil
+	case typeUint128:
+		return new(big.Int).SetBytes(raw), next, nil
+	}
+
+	return nil, 0, fmt.Errorf("%w: unknown data type %d", ErrInvalidDatabase, dataType)
+}
+
+func (d *decoder) decodePointer(ctrl byte, offset uint) (uint, uint, error) {
+	size := uint((ctrl>>3)&0x3) + 1
+	if offset+size > uint(len(d.buf)) {
+		return 0, 0, ErrInvalidDatabase
+	}
+
+	b := d.buf[offset : offset+size]
+	vvv := uint(ctrl & 0x7)
+
+	var pointer uint
+	switch size {
+	case 1:
+		pointer = vvv<<8 | uint(b[0])
+	case 2:
+		pointer = (vvv<<16 | uint(b[0])<<8 | uint(b[1])) + 2048
+	case 3:
+		pointer = (vvv<<24 | uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2])) + 526336
+	default:
+		pointer = uint(binary.BigEndian.Uint32(b))
+	}
+
+	return pointer, offset + size, nil
+}
+
+func toUint(v any) uint {
+	switch n := v.(type) {
+	case uint64:
+		return uint(n)
+	case int64:
+		return uint(n)
+	}
+
+	return 0
+}
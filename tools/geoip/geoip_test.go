@@ -0,0 +1,122 @@
+package geoip_test
+
+import (
+	"bytes"
+	"net"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/geoip"
+)
+
+// mmdb encoding helpers (supports only short values)
+func encString(s string) []byte {
+	return append([]byte{byte(2<<5 | len(s))}, s...)
+}
+
+func encUint16(v uint16) []byte {
+	return []byte{byte(5<<5 | 2), byte(v >> 8), byte(v)}
+}
+
+func encUint32(v uint32) []byte {
+	return []byte{byte(6<<5 | 4), byte(v >> 24), byte(v >> 16), byte(v >> 8), byte(v)}
+}
+
+func encMap(pairs ...[]byte) []byte {
+	result := []byte{byte(7<<5 | len(pairs)/2)}
+	for _, p := range pairs {
+		result = append(result, p...)
+	}
+	return result
+}
+
+// newTestDB builds a single node IPv4 database where all addresses
+// from the 0.0.0.0/1 range are mapped to country "BG".
+func newTestDB(t *testing.T) *geoip.Reader {
+	var buf bytes.Buffer
+
+	// search tree (1 node with 24-bit records)
+	// left -> data at offset 0 (node_count + 16 + 0)
+	// right -> not found (node_count)
+	buf.Write([]byte{0, 0, 17, 0, 0, 1})
+
+	// data section separator
+	buf.Write(make([]byte, 16))
+
+	// data section
+	buf.Write(encMap(
+		encString("country"),
+		encMap(encString("iso_code"), encString("BG")),
+	))
+
+	// metadata
+	buf.Write([]byte("\xAB\xCD\xEFMaxMind.com"))
+	buf.Write(encMap(
+		encString("node_count"), encUint32(1),
+		encString("record_size"), encUint16(24),
+		encString("ip_version"), encUint16(4),
+	))
+
+	r, err := geoip.New(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return r
+}
+
+func TestNewInvalid(t *testing.T) {
+	if _, err := geoip.New([]byte("invalid")); err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+}
+
+func TestReaderCountry(t *testing.T) {
+	r := newTestDB(t)
+
+	scenarios := []struct {
+		ip       string
+		expected string
+	}{
+		{"1.2.3.4", "BG"},
+		{"127.0.0.1", "BG"},
+		{"128.0.0.1", ""},
+		{"192.168.1.1", ""},
+		{"::1", ""}, // ipv6 in ipv4-only database
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.ip, func(t *testing.T) {
+			result := r.Country(net.ParseIP(s.ip))
+			if result != s.expected {
+				t.Fatalf("Expected %q, got %q", s.expected, result)
+			}
+		})
+	}
+}
+
+func TestReaderLookup(t *testing.T) {
+	r := newTestDB(t)
+
+	if _, err := r.Lookup(nil); err == nil {
+		t.Fatal("Expected error for nil ip")
+	}
+
+	result, err := r.Lookup(net.ParseIP("128.0.0.1"))
+	if err != nil || result != nil {
+		t.Fatalf("Expected nil result and error, got %v, %v", result, err)
+	}
+
+	result, err = r.Lookup(net.ParseIP("1.1.1.1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, ok := result.(map[string]any)
+	if !ok {
+		t.Fatalf("Expected map result, got %T", result)
+	}
+
+	if _, ok := data["country"]; !ok {
+		t.Fatalf("Missing country key in %v", data)
+	}
+}
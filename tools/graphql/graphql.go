@@ -0,0 +1,760 @@
+// Package graphql implements a minimal GraphQL executable documents parser.
+//
+// It supports query, mutation and subscription operations with variables,
+// aliases, arguments, named fragments and inline fragments.
+// Directives and type system definitions are not supported.
+//
+// Example:
+//
+//	doc, err := graphql.Parse(`query Posts($limit: Int) { posts(perPage: $limit) { items { id title } } }`)
+//	if err != nil {
+//		return err
+//	}
+//
+//	op, err := doc.Operation("Posts")
+//	if err != nil {
+//		return err
+//	}
+//
+//	for _, field := range op.SelectionSet {
+//		args := field.ArgumentValues(map[string]any{"limit": 10})
+//		// ...
+//	}
+package graphql
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+const (
+	OperationQuery        = "query"
+	OperationMutation     = "mutation"
+	OperationSubscription = "subscription"
+)
+
+// Document is a parsed GraphQL executable document.
+type Document struct {
+	Operations []*Operation
+}
+
+// Operation is a single GraphQL document operation definition.
+//
+// Fragment spreads are resolved during parsing, aka. the
+// SelectionSet contains only regular fields.
+type Operation struct {
+	Type         string
+	Name         string
+	Variables    []*VariableDefinition
+	SelectionSet []*Field
+}
+
+// VariableDefinition is a single operation variable definition (eg. "$limit: Int = 10").
+type VariableDefinition struct {
+	Name         string
+	Type         string
+	DefaultValue any
+}
+
+// Field is a single selection set field.
+type Field struct {
+	Alias        string
+	Name         string
+	Arguments    map[string]any
+	SelectionSet []*Field
+}
+
+// Variable is a variable reference argument value (eg. "$limit").
+type Variable string
+
+// Enum is an enum argument value (eg. "DESC").
+type Enum string
+
+// ResponseKey returns the key under which the field result should be
+// returned in the response (aka. the field alias or its name).
+func (f *Field) ResponseKey() string {
+	if f.Alias != "" {
+		return f.Alias
+	}
+
+	return f.Name
+}
+
+// ArgumentValues returns the field arguments with
+// the variable references replaced with the provided variables.
+func (f *Field) ArgumentValues(variables map[string]any) map[string]any {
+	result := make(map[string]any, len(f.Arguments))
+
+	for k, v := range f.Arguments {
+		result[k] = ResolveValue(v, variables)
+	}
+
+	return result
+}
+
+// ResolveValue replaces recursively the [Variable] references
+// in the provided argument value with the corresponding variables.
+//
+// [Enum] values are converted to plain strings.
+func ResolveValue(value any, variables map[string]any) any {
+	switch v := value.(type) {
+	case Variable:
+		return variables[string(v)]
+	case Enum:
+		return string(v)
+	case []any:
+		result := make([]any, len(v))
+		for i, item := range v {
+			result[i] = ResolveValue(item, variables)
+		}
+		return result
+	case map[string]any:
+		result := make(map[string]any, len(v))
+		for k, item := range v {
+			result[k] = ResolveValue(item, variables)
+		}
+		return result
+	default:
+		return v
+	}
+}
+
+// Depth returns the max nesting level of the operation selection set
+// (eg. "{ a { b } }" has depth 2).
+func (op *Operation) Depth() int {
+	return selectionDepth(op.SelectionSet)
+}
+
+func selectionDepth(fields []*Field) int {
+	var max int
+
+	for _, f := range fields {
+		if d := selectionDepth(f.SelectionSet); d > max {
+			max = d
+		}
+	}
+
+	if len(fields) == 0 {
+		return 0
+	}
+
+	return max + 1
+}
+
+// ResolveVariables returns the operation variables normalized with the
+// declared defaults and checks whether the required (non-null) ones are set.
+func (op *Operation) ResolveVariables(variables map[string]any) (map[string]any, error) {
+	result := make(map[string]any, len(op.Variables))
+
+	for _, def := range op.Variables {
+		v, ok := variables[def.Name]
+		if !ok || v == nil {
+			v = ResolveValue(def.DefaultValue, nil)
+		}
+
+		if v == nil && def.Type != "" && def.Type[len(def.Type)-1] == '!' {
+			return nil, fmt.Errorf("missing required variable $%s", def.Name)
+		}
+
+		result[def.Name] = v
+	}
+
+	return result, nil
+}
+
+// Operation returns the document operation with the specified name.
+//
+// The name could be empty only if the document has a single operation.
+func (d *Document) Operation(name string) (*Operation, error) {
+	if name == "" {
+		if len(d.Operations) != 1 {
+			return nil, errors.New("the operation name is required for documents with multiple operations")
+		}
+		return d.Operations[0], nil
+	}
+
+	for _, op := range d.Operations {
+		if op.Name == name {
+			return op, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown operation %q", name)
+}
+
+// -------------------------------------------------------------------
+
+// Parse parses the provided GraphQL executable document source.
+func Parse(src string) (*Document, error) {
+	p := &parser{lexer: &lexer{src: src}, fragments: map[string]*fragment{}}
+
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	doc, err := p.parseDocument()
+	if err != nil {
+		return nil, err
+	}
+
+	// resolve the fragment spreads
+	for _, op := range doc.Operations {
+		fields, err := p.resolveSelections(p.selections[op], nil)
+		if err != nil {
+			return nil, err
+		}
+		op.SelectionSet = fields
+	}
+
+	return doc, nil
+}
+
+type fragment struct {
+	selections []selection
+}
+
+// selection is an unresolved selection set item
+// (only one of the fields is set).
+type selection struct {
+	field          *Field
+	fieldSelection []selection
+	spread         string
+	inline         []selection
+}
+
+type parser struct {
+	lexer     *lexer
+	current   token
+	fragments map[string]*fragment
+
+	// unresolved operations selections
+	selections map[*Operation][]selection
+}
+
+func (p *parser) advance() error {
+	t, err := p.lexer.next()
+	if err != nil {
+		return err
+	}
+	p.current = t
+	return nil
+}
+
+func (p *parser) is(kind tokenKind, value string) bool {
+	return p.current.kind == kind && (value == "" || p.current.value == value)
+}
+
+func (p *parser) expect(kind tokenKind, value string) (token, error) {
+	t := p.current
+
+	if !p.is(kind, value) {
+		expected := value
+		if expected == "" {
+			expected = kindName(kind)
+		}
+		return t, fmt.Errorf("expected %s, got %s at position %d", expected, t, t.pos)
+	}
+
+	return t, p.advance()
+}
+
+func (p *parser) parseDocument() (*Document, error) {
+	doc := &Document{}
+	p.selections = map[*Operation][]selection{}
+
+	for !p.is(tokenEOF, "") {
+		switch {
+		case p.is(tokenPunctuator, "{"):
+			// query shorthand
+			sel, err := p.parseSelectionSet()
+			if err != nil {
+				return nil, err
+			}
+			op := &Operation{Type: OperationQuery}
+			p.selections[op] = sel
+			doc.Operations = append(doc.Operations, op)
+		case p.is(tokenName, "fragment"):
+			if err := p.parseFragment(); err != nil {
+				return nil, err
+			}
+		case p.is(tokenName, OperationQuery), p.is(tokenName, OperationMutation), p.is(tokenName, OperationSubscription):
+			op, err := p.parseOperation()
+			if err != nil {
+				return nil, err
+			}
+			doc.Operations = append(doc.Operations, op)
+		default:
+			return nil, fmt.Errorf("unexpected %s at position %d", p.current, p.current.pos)
+		}
+	}
+
+	if len(doc.Operations) == 0 {
+		return nil, errors.New("the document must contain at least one operation")
+	}
+
+	names := map[string]struct{}{}
+	for _, op := range doc.Operations {
+		if op.Name == "" && len(doc.Operations) > 1 {
+			return nil, errors.New("anonymous operations must be the only defined operation")
+		}
+		if _, ok := names[op.Name]; ok {
+			return nil, fmt.Errorf("duplicated operation name %q", op.Name)
+		}
+		names[op.Name] = struct{}{}
+	}
+
+	return doc, nil
+}
+
+func (p *parser) parseOperation() (*Operation, error) {
+	op := &Operation{Type: p.current.value}
+
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	if p.is(tokenName, "") {
+		op.Name = p.current.value
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.is(tokenPunctuator, "(") {
+		vars, err := p.parseVariableDefinitions()
+		if err != nil {
+			return nil, err
+		}
+		op.Variables = vars
+	}
+
+	if err := p.checkNoDirectives(); err != nil {
+		return nil, err
+	}
+
+	sel, err := p.parseSelectionSet()
+	if err != nil {
+		return nil, err
+	}
+	p.selections[op] = sel
+
+	return op, nil
+}
+
+func (p *parser) parseVariableDefinitions() ([]*VariableDefinition, error) {
+	if _, err := p.expect(tokenPunctuator, "("); err != nil {
+		return nil, err
+	}
+
+	var result []*VariableDefinition
+
+	for !p.is(tokenPunctuator, ")") {
+		if _, err := p.expect(tokenPunctuator, "$"); err != nil {
+			return nil, err
+		}
+
+		name, err := p.expect(tokenName, "")
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := p.expect(tokenPunctuator, ":"); err != nil {
+			return nil, err
+		}
+
+		typ, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+
+		def := &VariableDefinition{Name: name.value, Type: typ}
+
+		if p.is(tokenPunctuator, "=") {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			def.DefaultValue, err = p.parseValue(true)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		result = append(result, def)
+	}
+
+	return result, p.advance()
+}
+
+func (p *parser) parseType() (string, error) {
+	var typ string
+
+	if p.is(tokenPunctuator, "[") {
+		if err := p.advance(); err != nil {
+			return "", err
+		}
+
+		inner, err := p.parseType()
+		if err != nil {
+			return "", err
+		}
+
+		if _, err := p.expect(tokenPunctuator, "]"); err != nil {
+			return "", err
+		}
+
+		typ = "[" + inner + "]"
+	} else {
+		name, err := p.expect(tokenName, "")
+		if err != nil {
+			return "", err
+		}
+		typ = name.value
+	}
+
+	if p.is(tokenPunctuator, "!") {
+		typ += "!"
+		return typ, p.advance()
+	}
+
+	return typ, nil
+}
+
+func (p *parser) parseFragment() error {
+	if err := p.advance(); err != nil { // "fragment"
+		return err
+	}
+
+	name, err := p.expect(tokenName, "")
+	if err != nil {
+		return err
+	}
+
+	if name.value == "on" {
+		return fmt.Errorf("invalid fragment name at position %d", name.pos)
+	}
+
+	if _, err := p.expect(tokenName, "on"); err != nil {
+		return err
+	}
+
+	if _, err := p.expect(tokenName, ""); err != nil {
+		return err
+	}
+
+	if err := p.checkNoDirectives(); err != nil {
+		return err
+	}
+
+	sel, err := p.parseSelectionSet()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := p.fragments[name.value]; ok {
+		return fmt.Errorf("duplicated fragment %q", name.value)
+	}
+
+	p.fragments[name.value] = &fragment{selections: sel}
+
+	return nil
+}
+
+func (p *parser) parseSelectionSet() ([]selection, error) {
+	if _, err := p.expect(tokenPunctuator, "{"); err != nil {
+		return nil, err
+	}
+
+	var result []selection
+
+	for !p.is(tokenPunctuator, "}") {
+		if p.is(tokenEOF, "") {
+			return nil, errors.New("unterminated selection set")
+		}
+
+		sel, err := p.parseSelection()
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, sel)
+	}
+
+	if len(result) == 0 {
+		return nil, fmt.Errorf("empty selection set at position %d", p.current.pos)
+	}
+
+	return result, p.advance()
+}
+
+func (p *parser) parseSelection() (selection, error) {
+	if p.is(tokenPunctuator, "...") {
+		if err := p.advance(); err != nil {
+			return selection{}, err
+		}
+
+		// inline fragment
+		if p.is(tokenName, "on") || p.is(tokenPunctuator, "{") {
+			if p.is(tokenName, "on") {
+				if err := p.advance(); err != nil {
+					return selection{}, err
+				}
+				if _, err := p.expect(tokenName, ""); err != nil {
+					return selection{}, err
+				}
+			}
+
+			if err := p.checkNoDirectives(); err != nil {
+				return selection{}, err
+			}
+
+			inline, err := p.parseSelectionSet()
+			if err != nil {
+				return selection{}, err
+			}
+
+			return selection{inline: inline}, nil
+		}
+
+		// fragment spread
+		name, err := p.expect(tokenName, "")
+		if err != nil {
+			return selection{}, err
+		}
+
+		return selection{spread: name.value}, p.checkNoDirectives()
+	}
+
+	name, err := p.expect(tokenName, "")
+	if err != nil {
+		return selection{}, err
+	}
+
+	field := &Field{Name: name.value}
+
+	if p.is(tokenPunctuator, ":") {
+		if err := p.advance(); err != nil {
+			return selection{}, err
+		}
+
+		realName, err := p.expect(tokenName, "")
+		if err != nil {
+			return selection{}, err
+		}
+
+		field.Alias = field.Name
+		field.Name = realName.value
+	}
+
+	if p.is(tokenPunctuator, "(") {
+		field.Arguments, err = p.parseArguments()
+		if err != nil {
+			return selection{}, err
+		}
+	}
+
+	if err := p.checkNoDirectives(); err != nil {
+		return selection{}, err
+	}
+
+	result := selection{field: field}
+
+	if p.is(tokenPunctuator, "{") {
+		result.fieldSelection, err = p.parseSelectionSet()
+		if err != nil {
+			return selection{}, err
+		}
+	}
+
+	return result, nil
+}
+
+func (p *parser) parseArguments() (map[string]any, error) {
+	if _, err := p.expect(tokenPunctuator, "("); err != nil {
+		return nil, err
+	}
+
+	result := map[string]any{}
+
+	for !p.is(tokenPunctuator, ")") {
+		name, err := p.expect(tokenName, "")
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := p.expect(tokenPunctuator, ":"); err != nil {
+			return nil, err
+		}
+
+		if _, ok := result[name.value]; ok {
+			return nil, fmt.Errorf("duplicated argument %q", name.value)
+		}
+
+		result[name.value], err = p.parseValue(false)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(result) == 0 {
+		return nil, fmt.Errorf("empty arguments list at position %d", p.current.pos)
+	}
+
+	return result, p.advance()
+}
+
+func (p *parser) parseValue(constOnly bool) (any, error) {
+	t := p.current
+
+	switch t.kind {
+	case tokenInt:
+		v, err := strconv.ParseInt(t.value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid int %q at position %d", t.value, t.pos)
+		}
+		return v, p.advance()
+	case tokenFloat:
+		v, err := strconv.ParseFloat(t.value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid float %q at position %d", t.value, t.pos)
+		}
+		return v, p.advance()
+	case tokenString:
+		return t.value, p.advance()
+	case tokenName:
+		var v any
+		switch t.value {
+		case "true":
+			v = true
+		case "false":
+			v = false
+		case "null":
+			v = nil
+		default:
+			v = Enum(t.value)
+		}
+		return v, p.advance()
+	case tokenPunctuator:
+		switch t.value {
+		case "$":
+			if constOnly {
+				return nil, fmt.Errorf("unexpected variable at position %d", t.pos)
+			}
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			name, err := p.expect(tokenName, "")
+			if err != nil {
+				return nil, err
+			}
+			return Variable(name.value), nil
+		case "[":
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			list := []any{}
+			for !p.is(tokenPunctuator, "]") {
+				if p.is(tokenEOF, "") {
+					return nil, errors.New("unterminated list value")
+				}
+				item, err := p.parseValue(constOnly)
+				if err != nil {
+					return nil, err
+				}
+				list = append(list, item)
+			}
+			return list, p.advance()
+		case "{":
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			obj := map[string]any{}
+			for !p.is(tokenPunctuator, "}") {
+				name, err := p.expect(tokenName, "")
+				if err != nil {
+					return nil, err
+				}
+				if _, err := p.expect(tokenPunctuator, ":"); err != nil {
+					return nil, err
+				}
+				obj[name.value], err = p.parseValue(constOnly)
+				if err != nil {
+					return nil, err
+				}
+			}
+			return obj, p.advance()
+		}
+	}
+
+	return nil, fmt.Errorf("unexpected %s at position %d", t, t.pos)
+}
+
+func (p *parser) checkNoDirectives() error {
+	if p.is(tokenPunctuator, "@") {
+		return fmt.Errorf("directives are not supported (position %d)", p.current.pos)
+	}
+
+	return nil
+}
+
+// resolveSelections converts the unresolved selections into a list of fields
+// by inlining the fragment spreads and the inline fragments.
+func (p *parser) resolveSelections(selections []selection, visited []string) ([]*Field, error) {
+	var result []*Field
+
+	for _, sel := range selections {
+		switch {
+		case sel.field != nil:
+			if len(sel.fieldSelection) > 0 {
+				sub, err := p.resolveSelections(sel.fieldSelection, visited)
+				if err != nil {
+					return nil, err
+				}
+				sel.field.SelectionSet = sub
+			}
+			result = append(result, sel.field)
+		case sel.inline != nil:
+			sub, err := p.resolveSelections(sel.inline, visited)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, sub...)
+		default:
+			for _, v := range visited {
+				if v == sel.spread {
+					return nil, fmt.Errorf("fragment %q cycle detected", sel.spread)
+				}
+			}
+
+			frag, ok := p.fragments[sel.spread]
+			if !ok {
+				return nil, fmt.Errorf("unknown fragment %q", sel.spread)
+			}
+
+			sub, err := p.resolveSelections(frag.selections, append(visited, sel.spread))
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, sub...)
+		}
+	}
+
+	return result, nil
+}
+
+func kindName(kind tokenKind) string {
+	switch kind {
+	case tokenName:
+		return "name"
+	case tokenInt:
+		return "int"
+	case tokenFloat:
+		return "float"
+	case tokenString:
+		return "string"
+	case tokenPunctuator:
+		return "punctuator"
+	default:
+		return "<EOF>"
+	}
+}
@@ -0,0 +1,210 @@
+package graphql_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/graphql"
+)
+
+func TestParse(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		src         string
+		expectError bool
+		expected    string // json serialized operations
+	}{
+		{"empty", "", true, ""},
+		{"empty selection set", "{}", true, ""},
+		{"unterminated selection set", "{ a", true, ""},
+		{"invalid character", "{ a ^ }", true, ""},
+		{"unterminated string", `{ a(b: "c) }`, true, ""},
+		{"invalid number", `{ a(b: 1.) }`, true, ""},
+		{"directives", `{ a @include(if: true) }`, true, ""},
+		{"unknown fragment", `{ ...missing }`, true, ""},
+		{"fragment cycle", `{ ...A } fragment A on T { ...B } fragment B on T { ...A }`, true, ""},
+		{"duplicated operation names", `query A { a } query A { b }`, true, ""},
+		{"multiple anonymous operations", `{ a } { b }`, true, ""},
+		{"duplicated argument", `{ a(b: 1, b: 2) }`, true, ""},
+		{
+			"shorthand query",
+			`{ a b { c } }`,
+			false,
+			`[{"Type":"query","Name":"","Variables":null,"SelectionSet":[{"Alias":"","Name":"a","Arguments":null,"SelectionSet":null},{"Alias":"","Name":"b","Arguments":null,"SelectionSet":[{"Alias":"","Name":"c","Arguments":null,"SelectionSet":null}]}]}]`,
+		},
+		{
+			"named operations with variables, aliases and arguments",
+			`
+			# comment
+			query Test($a: Int = 10, $b: [String!]!) {
+				x: a(int: -1, float: 1.5e2, str: "q\"A", bool: true, null: null, enum: DESC, list: [1, $b], obj: {c: $a})
+			}
+			mutation Update { b }
+			`,
+			false,
+			`[{"Type":"query","Name":"Test","Variables":[{"Name":"a","Type":"Int","DefaultValue":10},{"Name":"b","Type":"[String!]!","DefaultValue":null}],"SelectionSet":[{"Alias":"x","Name":"a","Arguments":{"bool":true,"enum":"DESC","float":150,"int":-1,"list":[1,"b"],"null":null,"obj":{"c":"a"},"str":"q\"A"},"SelectionSet":null}]},{"Type":"mutation","Name":"Update","Variables":null,"SelectionSet":[{"Alias":"","Name":"b","Arguments":null,"SelectionSet":null}]}]`,
+		},
+		{
+			"fragments",
+			`query { a { ...F ... on T { c } ... { d } } } fragment F on T { b }`,
+			false,
+			`[{"Type":"query","Name":"","Variables":null,"SelectionSet":[{"Alias":"","Name":"a","Arguments":null,"SelectionSet":[{"Alias":"","Name":"b","Arguments":null,"SelectionSet":null},{"Alias":"","Name":"c","Arguments":null,"SelectionSet":null},{"Alias":"","Name":"d","Arguments":null,"SelectionSet":null}]}]}]`,
+		},
+		{
+			"block string",
+			"{ a(b: \"\"\"\n    line1\n      line2\n    \"\"\") }",
+			false,
+			`[{"Type":"query","Name":"","Variables":null,"SelectionSet":[{"Alias":"","Name":"a","Arguments":{"b":"line1\n  line2"},"SelectionSet":null}]}]`,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			doc, err := graphql.Parse(s.src)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if hasErr {
+				return
+			}
+
+			raw, err := json.Marshal(doc.Operations)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if str := string(raw); str != s.expected {
+				t.Fatalf("Expected \n%s, \ngot \n%s", s.expected, str)
+			}
+		})
+	}
+}
+
+func TestDocumentOperation(t *testing.T) {
+	single, err := graphql.Parse(`query A { a }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	multiple, err := graphql.Parse(`query A { a } query B { b }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		name         string
+		doc          *graphql.Document
+		operation    string
+		expectError  bool
+		expectedName string
+	}{
+		{"single without name", single, "", false, "A"},
+		{"single with name", single, "A", false, "A"},
+		{"single with missing name", single, "B", true, ""},
+		{"multiple without name", multiple, "", true, ""},
+		{"multiple with name", multiple, "B", false, "B"},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			op, err := s.doc.Operation(s.operation)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if !hasErr && op.Name != s.expectedName {
+				t.Fatalf("Expected operation %q, got %q", s.expectedName, op.Name)
+			}
+		})
+	}
+}
+
+func TestOperationResolveVariables(t *testing.T) {
+	doc, err := graphql.Parse(`query ($a: Int = 1, $b: String!, $c: Boolean) { x }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	op, _ := doc.Operation("")
+
+	if _, err := op.ResolveVariables(nil); err == nil {
+		t.Fatal("Expected missing required variable error")
+	}
+
+	vars, err := op.ResolveVariables(map[string]any{"b": "test", "unknown": 123})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	raw, _ := json.Marshal(vars)
+	expected := `{"a":1,"b":"test","c":null}`
+	if str := string(raw); str != expected {
+		t.Fatalf("Expected %s, got %s", expected, str)
+	}
+}
+
+func TestFieldArgumentValues(t *testing.T) {
+	doc, err := graphql.Parse(`query ($a: Int) { x(a: $a, b: [$a, {c: $a}], d: ASC, e: $missing) }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	op, _ := doc.Operation("")
+
+	args := op.SelectionSet[0].ArgumentValues(map[string]any{"a": 5})
+
+	raw, _ := json.Marshal(args)
+	expected := `{"a":5,"b":[5,{"c":5}],"d":"ASC","e":null}`
+	if str := string(raw); str != expected {
+		t.Fatalf("Expected %s, got %s", expected, str)
+	}
+}
+
+func TestOperationDepth(t *testing.T) {
+	scenarios := []struct {
+		src      string
+		expected int
+	}{
+		{`{ a }`, 1},
+		{`{ a b { c } }`, 2},
+		{`{ a { b { c { d } } } e { f } }`, 4},
+		{`{ a { ...F } } fragment F on T { b { c } }`, 3},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.src, func(t *testing.T) {
+			doc, err := graphql.Parse(s.src)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			op, _ := doc.Operation("")
+
+			if d := op.Depth(); d != s.expected {
+				t.Fatalf("Expected depth %d, got %d", s.expected, d)
+			}
+		})
+	}
+}
+
+func TestFieldResponseKey(t *testing.T) {
+	doc, err := graphql.Parse(`{ a b: c }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	op, _ := doc.Operation("")
+
+	if k := op.SelectionSet[0].ResponseKey(); k != "a" {
+		t.Fatalf("Expected response key a, got %s", k)
+	}
+
+	if k := op.SelectionSet[1].ResponseKey(); k != "b" {
+		t.Fatalf("Expected response key b, got %s", k)
+	}
+}
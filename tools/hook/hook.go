@@ -3,7 +3,10 @@ package hook
 import (
 	"errors"
 	"fmt"
+	"log"
+	"runtime/debug"
 	"sync"
+	"time"
 
 	"github.com/pocketbase/pocketbase/tools/security"
 )
@@ -13,10 +16,77 @@ var StopPropagation = errors.New("Event hook propagation stopped")
 // Handler defines a hook handler function.
 type Handler[T any] func(e T) error
 
+// HandlerOptions defines the optional settings that could be
+// specified when registering a new hook handler.
+type HandlerOptions struct {
+	// Id is an optional unique handler identifier.
+	//
+	// If empty, a random id will be autogenerated.
+	//
+	// If a handler with the same id is already registered,
+	// it will be replaced with the new one.
+	Id string
+
+	// Priority specifies the handler execution order.
+	//
+	// Handlers with lower priority are executed first.
+	// Handlers with the same priority are executed in their registration order.
+	Priority int
+
+	// Async specifies whether the handler should be executed in a
+	// separate goroutine without blocking the hook Trigger call.
+	//
+	// Errors returned by async handlers don't interrupt the hook propagation
+	// and are only reported to OnAsyncError (panics are also recovered
+	// and reported as errors).
+	//
+	// Note that the event data is shared with the other handlers,
+	// so async handlers should avoid modifying it.
+	Async bool
+
+	// AsyncMaxRetries specifies how many times a failed async handler
+	// should be retried before reporting its error (default to 0, aka. no retries).
+	AsyncMaxRetries int
+
+	// AsyncRetryDelay specifies the delay between the async handler retries.
+	AsyncRetryDelay time.Duration
+
+	// OnAsyncError is an optional callback that is invoked with the last
+	// error of a failed async handler (after all retries).
+	//
+	// If not set, the error is printed with the standard logger.
+	OnAsyncError func(err error)
+}
+
+// HandlerInfo describes a single registered hook handler.
+type HandlerInfo struct {
+	Id       string `json:"id"`
+	Priority int    `json:"priority"`
+	Async    bool   `json:"async"`
+}
+
+// AsyncError defines the error reported by a failed async hook handler.
+type AsyncError struct {
+	HandlerId string
+	Attempts  int
+	Err       error
+}
+
+// Error implements the [error] interface.
+func (e *AsyncError) Error() string {
+	return fmt.Sprintf("async hook handler %q failed after %d attempt(s): %v", e.HandlerId, e.Attempts, e.Err)
+}
+
+// Unwrap returns the underlying handler error.
+func (e *AsyncError) Unwrap() error {
+	return e.Err
+}
+
 // handlerPair defines a pair of string id and Handler.
 type handlerPair[T any] struct {
 	id      string
 	handler Handler[T]
+	options HandlerOptions
 }
 
 // Hook defines a concurrent safe structure for handling event hooks
@@ -24,37 +94,46 @@ type handlerPair[T any] struct {
 type Hook[T any] struct {
 	mux      sync.RWMutex
 	handlers []*handlerPair[T]
+	asyncWg  sync.WaitGroup
 }
 
-// PreAdd registers a new handler to the hook by prepending it to the existing queue.
+// PreAdd registers a new handler to the hook by prepending it to the existing queue
+// (aka. before all other handlers with the same or higher priority).
 //
 // Returns an autogenerated hook id that could be used later to remove the hook with Hook.Remove(id).
 func (h *Hook[T]) PreAdd(fn Handler[T]) string {
-	h.mux.Lock()
-	defer h.mux.Unlock()
-
-	id := generateHookId()
-
-	// minimize allocations by shifting the slice
-	h.handlers = append(h.handlers, nil)
-	copy(h.handlers[1:], h.handlers)
-	h.handlers[0] = &handlerPair[T]{id, fn}
-
-	return id
+	return h.bind(&handlerPair[T]{handler: fn}, true)
 }
 
-// Add registers a new handler to the hook by appending it to the existing queue.
+// Add registers a new handler to the hook by appending it to the existing queue
+// (aka. after all other handlers with the same or lower priority).
 //
 // Returns an autogenerated hook id that could be used later to remove the hook with Hook.Remove(id).
 func (h *Hook[T]) Add(fn Handler[T]) string {
-	h.mux.Lock()
-	defer h.mux.Unlock()
-
-	id := generateHookId()
-
-	h.handlers = append(h.handlers, &handlerPair[T]{id, fn})
+	return h.bind(&handlerPair[T]{handler: fn}, false)
+}
 
-	return id
+// AddWithOptions registers a new handler to the hook with the
+// provided id and priority options.
+//
+// If options.Id matches with an already registered handler, the
+// existing handler is removed and the new one is added in its place
+// based on the new priority.
+//
+// Returns the handler id (either the one from options.Id or an autogenerated one).
+//
+// Example:
+//
+//	h.AddWithOptions(func(e *Event) error {
+//		// ...
+//		return nil
+//	}, hook.HandlerOptions{Id: "myHandler", Priority: -10})
+func (h *Hook[T]) AddWithOptions(fn Handler[T], options HandlerOptions) string {
+	return h.bind(&handlerPair[T]{
+		id:      options.Id,
+		handler: fn,
+		options: options,
+	}, false)
 }
 
 // Remove removes a single hook handler by its id.
@@ -62,12 +141,7 @@ func (h *Hook[T]) Remove(id string) {
 	h.mux.Lock()
 	defer h.mux.Unlock()
 
-	for i := len(h.handlers) - 1; i >= 0; i-- {
-		if h.handlers[i].id == id {
-			h.handlers = append(h.handlers[:i], h.handlers[i+1:]...)
-			return
-		}
-	}
+	h.remove(id)
 }
 
 // RemoveAll removes all registered handlers.
@@ -78,6 +152,42 @@ func (h *Hook[T]) RemoveAll() {
 	h.handlers = nil
 }
 
+// Has checks whether a handler with the specified id is registered.
+func (h *Hook[T]) Has(id string) bool {
+	h.mux.RLock()
+	defer h.mux.RUnlock()
+
+	for _, item := range h.handlers {
+		if item.id == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Length returns the total number of registered hook handlers.
+func (h *Hook[T]) Length() int {
+	h.mux.RLock()
+	defer h.mux.RUnlock()
+
+	return len(h.handlers)
+}
+
+// List returns information about all registered hook handlers
+// in their execution order.
+func (h *Hook[T]) List() []HandlerInfo {
+	h.mux.RLock()
+	defer h.mux.RUnlock()
+
+	result := make([]HandlerInfo, len(h.handlers))
+	for i, item := range h.handlers {
+		result[i] = HandlerInfo{Id: item.id, Priority: item.options.Priority, Async: item.options.Async}
+	}
+
+	return result
+}
+
 // Trigger executes all registered hook handlers one by one
 // with the specified `data` as an argument.
 //
@@ -87,6 +197,9 @@ func (h *Hook[T]) RemoveAll() {
 // The execution stops when:
 // - hook.StopPropagation is returned in one of the handlers
 // - any non-nil error is returned in one of the handlers
+//
+// Async handlers are started in the background and don't affect
+// the propagation (see [HandlerOptions.Async]).
 func (h *Hook[T]) Trigger(data T, oneOffHandlers ...Handler[T]) error {
 	h.mux.RLock()
 
@@ -106,6 +219,11 @@ func (h *Hook[T]) Trigger(data T, oneOffHandlers ...Handler[T]) error {
 	h.mux.RUnlock()
 
 	for _, item := range handlers {
+		if item.options.Async {
+			h.runAsync(item, data)
+			continue
+		}
+
 		err := item.handler(data)
 		if err == nil {
 			continue
@@ -121,6 +239,101 @@ func (h *Hook[T]) Trigger(data T, oneOffHandlers ...Handler[T]) error {
 	return nil
 }
 
+// Wait blocks until all currently running async handlers complete.
+func (h *Hook[T]) Wait() {
+	h.asyncWg.Wait()
+}
+
+// runAsync executes the provided async handler pair in a new goroutine,
+// retrying it on failure based on its options.
+func (h *Hook[T]) runAsync(item *handlerPair[T], data T) {
+	h.asyncWg.Add(1)
+
+	go func() {
+		defer h.asyncWg.Done()
+
+		var err error
+		attempts := 0
+
+		for attempts <= item.options.AsyncMaxRetries {
+			if attempts > 0 && item.options.AsyncRetryDelay > 0 {
+				time.Sleep(item.options.AsyncRetryDelay)
+			}
+
+			attempts++
+
+			err = safeCall(item.handler, data)
+			if err == nil || errors.Is(err, StopPropagation) {
+				return
+			}
+		}
+
+		asyncErr := &AsyncError{HandlerId: item.id, Attempts: attempts, Err: err}
+
+		if item.options.OnAsyncError != nil {
+			item.options.OnAsyncError(asyncErr)
+		} else {
+			log.Println(asyncErr.Error())
+		}
+	}()
+}
+
+// safeCall calls the handler and converts a panic into an error.
+func safeCall[T any](fn Handler[T], data T) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("recovered from panic: %v\n%s", r, debug.Stack())
+		}
+	}()
+
+	return fn(data)
+}
+
+// bind inserts the provided handler pair in the handlers queue
+// based on its priority.
+//
+// If prepend is set, the pair is inserted before the other handlers
+// with the same priority, otherwise - after them.
+func (h *Hook[T]) bind(pair *handlerPair[T], prepend bool) string {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+
+	if pair.id == "" {
+		pair.id = generateHookId()
+	} else {
+		// replace existing
+		h.remove(pair.id)
+	}
+
+	pos := len(h.handlers)
+	for i, item := range h.handlers {
+		if item.options.Priority > pair.options.Priority ||
+			(prepend && item.options.Priority == pair.options.Priority) {
+			pos = i
+			break
+		}
+	}
+
+	// minimize allocations by shifting the slice
+	h.handlers = append(h.handlers, nil)
+	copy(h.handlers[pos+1:], h.handlers[pos:])
+	h.handlers[pos] = pair
+
+	return pair.id
+}
+
+// remove removes the handler with the specified id.
+//
+// Note: must be called with an acquired lock.
+func (h *Hook[T]) remove(id string) {
+	for i := len(h.handlers) - 1; i >= 0; i-- {
+		if h.handlers[i].id == id {
+			h.handlers = append(h.handlers[:i], h.handlers[i+1:]...)
+			return
+		}
+	}
+}
+
 func generateHookId() string {
 	return security.PseudorandomString(8)
 }
@@ -2,6 +2,7 @@ package hook
 
 import (
 	"errors"
+	"sync/atomic"
 	"testing"
 )
 
@@ -36,6 +37,78 @@ func TestHookAddAndPreAdd(t *testing.T) {
 	}
 }
 
+func TestHookAddWithOptions(t *testing.T) {
+	h := Hook[int]{}
+
+	triggerSequence := ""
+
+	f1 := func(data int) error { triggerSequence += "f1"; return nil }
+	f2 := func(data int) error { triggerSequence += "f2"; return nil }
+	f3 := func(data int) error { triggerSequence += "f3"; return nil }
+	f4 := func(data int) error { triggerSequence += "f4"; return nil }
+	f5 := func(data int) error { triggerSequence += "f5"; return nil }
+	f6 := func(data int) error { triggerSequence += "f6"; return nil }
+
+	h.Add(f1)
+	h.AddWithOptions(f2, HandlerOptions{Priority: 10})
+	h.AddWithOptions(f3, HandlerOptions{Id: "test", Priority: -10})
+	h.PreAdd(f4)
+	h.AddWithOptions(f5, HandlerOptions{Priority: -10})
+
+	if id := h.AddWithOptions(f6, HandlerOptions{Id: "test", Priority: 5}); id != "test" {
+		t.Fatalf("Expected id %q, got %q", "test", id)
+	}
+
+	if total := len(h.handlers); total != 5 {
+		t.Fatalf("Expected %d handlers, found %d", 5, total)
+	}
+
+	h.Trigger(1)
+
+	expectedTriggerSequence := "f5f4f1f6f2"
+
+	if triggerSequence != expectedTriggerSequence {
+		t.Fatalf("Expected trigger sequence %s, got %s", expectedTriggerSequence, triggerSequence)
+	}
+}
+
+func TestHookListAndHas(t *testing.T) {
+	h := Hook[int]{}
+
+	if total := len(h.List()); total != 0 {
+		t.Fatalf("Expected empty list, got %d", total)
+	}
+
+	id1 := h.Add(func(data int) error { return nil })
+	h.AddWithOptions(func(data int) error { return nil }, HandlerOptions{Id: "test", Priority: -1})
+
+	list := h.List()
+
+	expected := []HandlerInfo{{Id: "test", Priority: -1}, {Id: id1, Priority: 0}}
+
+	if len(list) != len(expected) {
+		t.Fatalf("Expected %d items, got %d", len(expected), len(list))
+	}
+
+	for i, item := range expected {
+		if list[i] != item {
+			t.Fatalf("(%d) Expected %v, got %v", i, item, list[i])
+		}
+	}
+
+	if h.Length() != 2 {
+		t.Fatalf("Expected Length %d, got %d", 2, h.Length())
+	}
+
+	if !h.Has("test") || !h.Has(id1) {
+		t.Fatal("Expected both handlers to exist")
+	}
+
+	if h.Has("missing") {
+		t.Fatal("Expected the missing handler to not exist")
+	}
+}
+
 func TestHookRemove(t *testing.T) {
 	h := Hook[int]{}
 
@@ -177,3 +250,65 @@ func TestHookTriggerStopPropagation(t *testing.T) {
 		}
 	}
 }
+
+func TestHookAsyncHandlers(t *testing.T) {
+	h := Hook[int]{}
+
+	var asyncCalls atomic.Int32
+	var asyncErr error
+	errCalls := 0
+
+	h.AddWithOptions(func(data int) error {
+		asyncCalls.Add(1)
+		return errors.New("async_test")
+	}, HandlerOptions{
+		Id:              "async",
+		Async:           true,
+		AsyncMaxRetries: 2,
+		OnAsyncError: func(err error) {
+			errCalls++
+			asyncErr = err
+		},
+	})
+
+	h.AddWithOptions(func(data int) error {
+		panic("test")
+	}, HandlerOptions{
+		Async:        true,
+		OnAsyncError: func(err error) {},
+	})
+
+	syncCalled := false
+	h.Add(func(data int) error { syncCalled = true; return nil })
+
+	if err := h.Trigger(1); err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	h.Wait()
+
+	if !syncCalled {
+		t.Fatal("Expected the sync handler to be called")
+	}
+
+	if total := asyncCalls.Load(); total != 3 {
+		t.Fatalf("Expected %d async handler calls, got %d", 3, total)
+	}
+
+	if errCalls != 1 {
+		t.Fatalf("Expected OnAsyncError to be called once, got %d", errCalls)
+	}
+
+	var ae *AsyncError
+	if !errors.As(asyncErr, &ae) {
+		t.Fatalf("Expected AsyncError, got %v", asyncErr)
+	}
+
+	if ae.HandlerId != "async" || ae.Attempts != 3 || ae.Err.Error() != "async_test" {
+		t.Fatalf("Unexpected AsyncError %#v", ae)
+	}
+
+	if list := h.List(); !list[0].Async || list[2].Async {
+		t.Fatalf("Expected only the first 2 handlers to be async, got %v", list)
+	}
+}
@@ -72,3 +72,17 @@ func (h *TaggedHook[T]) Add(fn Handler[T]) string {
 		return nil
 	})
 }
+
+// AddWithOptions registers a new handler to the hook with the provided
+// id and priority options (see [Hook.AddWithOptions]).
+//
+// The fn handler will be called only if the event data tags satisfy h.CanTriggerOn.
+func (h *TaggedHook[T]) AddWithOptions(fn Handler[T], options HandlerOptions) string {
+	return h.mainHook.AddWithOptions(func(e T) error {
+		if h.CanTriggerOn(e.Tags()) {
+			return fn(e)
+		}
+
+		return nil
+	}, options)
+}
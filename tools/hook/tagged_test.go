@@ -67,3 +67,38 @@ func TestTaggedHook(t *testing.T) {
 		}
 	}
 }
+
+func TestTaggedHookAddWithOptions(t *testing.T) {
+	triggerSequence := ""
+
+	base := &Hook[mockTagsData]{}
+	base.Add(func(data mockTagsData) error { triggerSequence += "f0"; return nil })
+
+	h := NewTaggedHook(base, "a")
+	h.AddWithOptions(func(data mockTagsData) error { triggerSequence += "a1"; return nil }, HandlerOptions{Priority: -1})
+	h.AddWithOptions(func(data mockTagsData) error { triggerSequence += "a2"; return nil }, HandlerOptions{Id: "test"})
+
+	scenarios := []struct {
+		data             mockTagsData
+		expectedSequence string
+	}{
+		{mockTagsData{}, "f0"},
+		{mockTagsData{[]string{"a"}}, "a1f0a2"},
+	}
+
+	for i, s := range scenarios {
+		triggerSequence = "" // reset
+
+		if err := base.Trigger(s.data); err != nil {
+			t.Fatalf("[%d] Unexpected trigger error: %v", i, err)
+		}
+
+		if triggerSequence != s.expectedSequence {
+			t.Fatalf("[%d] Expected trigger sequence %s, got %s", i, s.expectedSequence, triggerSequence)
+		}
+	}
+
+	if !base.Has("test") {
+		t.Fatal("Expected handler with id test to be registered")
+	}
+}
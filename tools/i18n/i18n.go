@@ -0,0 +1,189 @@
+// Package i18n implements a minimal key based translator
+// with bundled and loadable JSON locale files.
+//
+// A locale file is a flat JSON object with the translation keys
+// (eg. validation error codes or english messages) and their translations:
+//
+//	{
+//		"validation_required": "Darf nicht leer sein.",
+//		"Failed to authenticate.": "Authentifizierung fehlgeschlagen."
+//	}
+//
+// The file name (without the .json extension) is used as locale (eg. "de.json", "pt-BR.json").
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//go:embed locales/*.json
+var bundledLocales embed.FS
+
+// Translator holds the loaded locale translations.
+type Translator struct {
+	mux     sync.RWMutex
+	locales map[string]map[string]string
+}
+
+// New creates a new Translator preloaded with the bundled locales.
+func New() *Translator {
+	t := &Translator{locales: map[string]map[string]string{}}
+
+	if err := t.Load(bundledLocales, "locales"); err != nil {
+		panic(err) // should never happen
+	}
+
+	return t
+}
+
+// Load loads all *.json locale files from the dir of the provided file system.
+//
+// Translations of already loaded locales are merged
+// (the newly loaded keys have priority).
+func (t *Translator) Load(fsys fs.FS, dir string) error {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+
+		raw, err := fs.ReadFile(fsys, path.Join(dir, name))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		translations := map[string]string{}
+		if err := json.Unmarshal(raw, &translations); err != nil {
+			errs = append(errs, errors.New(name+": "+err.Error()))
+			continue
+		}
+
+		t.Add(strings.TrimSuffix(name, ".json"), translations)
+	}
+
+	return errors.Join(errs...)
+}
+
+// Add registers (or merges) the translations of a single locale.
+func (t *Translator) Add(locale string, translations map[string]string) {
+	locale = normalizeLocale(locale)
+
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	if t.locales[locale] == nil {
+		t.locales[locale] = make(map[string]string, len(translations))
+	}
+
+	for k, v := range translations {
+		t.locales[locale][k] = v
+	}
+}
+
+// Locales returns the sorted list with the loaded locales.
+func (t *Translator) Locales() []string {
+	t.mux.RLock()
+	defer t.mux.RUnlock()
+
+	result := make([]string, 0, len(t.locales))
+	for locale := range t.locales {
+		result = append(result, locale)
+	}
+	sort.Strings(result)
+
+	return result
+}
+
+// Translate returns the translation of the provided key for the specified locale.
+//
+// If the key is not found in the locale, it fallbacks to the locale base
+// language (eg. "pt" for "pt-br").
+func (t *Translator) Translate(locale string, key string) (string, bool) {
+	locale = normalizeLocale(locale)
+
+	t.mux.RLock()
+	defer t.mux.RUnlock()
+
+	if v, ok := t.locales[locale][key]; ok {
+		return v, true
+	}
+
+	if base, _, ok := strings.Cut(locale, "-"); ok {
+		if v, ok := t.locales[base][key]; ok {
+			return v, true
+		}
+	}
+
+	return "", false
+}
+
+// Match returns the best loaded locale for the provided
+// Accept-Language header value (or empty string if none matches).
+func (t *Translator) Match(acceptLanguage string) string {
+	t.mux.RLock()
+	defer t.mux.RUnlock()
+
+	type candidate struct {
+		locale string
+		q      float64
+	}
+
+	candidates := []candidate{}
+
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		locale, params, _ := strings.Cut(part, ";")
+		locale = normalizeLocale(locale)
+		if locale == "" || locale == "*" {
+			continue
+		}
+
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+				q = parsed
+			}
+		}
+
+		if q > 0 {
+			candidates = append(candidates, candidate{locale, q})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].q > candidates[j].q
+	})
+
+	for _, c := range candidates {
+		if _, ok := t.locales[c.locale]; ok {
+			return c.locale
+		}
+
+		if base, _, ok := strings.Cut(c.locale, "-"); ok {
+			if _, ok := t.locales[base]; ok {
+				return base
+			}
+		}
+	}
+
+	return ""
+}
+
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
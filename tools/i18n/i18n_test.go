@@ -0,0 +1,96 @@
+package i18n_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/pocketbase/pocketbase/tools/i18n"
+)
+
+func TestNewBundledLocales(t *testing.T) {
+	tr := i18n.New()
+
+	for _, locale := range []string{"en", "de", "es", "fr"} {
+		if tr.Match(locale) != locale {
+			t.Fatalf("Expected bundled locale %q", locale)
+		}
+	}
+
+	if v, ok := tr.Translate("de", "validation_required"); !ok || v == "" {
+		t.Fatalf("Expected de validation_required translation, got %q", v)
+	}
+}
+
+func TestTranslatorLoad(t *testing.T) {
+	tr := i18n.New()
+
+	fsys := fstest.MapFS{
+		"locales/de.json":    &fstest.MapFile{Data: []byte(`{"validation_required":"custom","new_key":"new"}`)},
+		"locales/pt_BR.json": &fstest.MapFile{Data: []byte(`{"test":"pt-br"}`)},
+		"locales/pt.json":    &fstest.MapFile{Data: []byte(`{"test":"pt","base_only":"base"}`)},
+		"locales/ignored":    &fstest.MapFile{Data: []byte(`invalid`)},
+	}
+
+	if err := tr.Load(fsys, "locales"); err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		locale   string
+		key      string
+		expected string
+		found    bool
+	}{
+		{"de", "validation_required", "custom", true},
+		{"de", "new_key", "new", true},
+		{"DE", "new_key", "new", true},
+		{"de-AT", "new_key", "new", true},
+		{"de", "missing", "", false},
+		{"pt-br", "test", "pt-br", true},
+		{"pt_BR", "base_only", "base", true},
+		{"pt", "test", "pt", true},
+		{"missing", "test", "", false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.locale+"_"+s.key, func(t *testing.T) {
+			result, found := tr.Translate(s.locale, s.key)
+			if found != s.found || result != s.expected {
+				t.Fatalf("Expected (%q, %v), got (%q, %v)", s.expected, s.found, result, found)
+			}
+		})
+	}
+
+	if err := tr.Load(fstest.MapFS{"locales/invalid.json": &fstest.MapFile{Data: []byte(`[1,2]`)}}, "locales"); err == nil {
+		t.Fatal("Expected invalid locale file error, got nil")
+	}
+}
+
+func TestTranslatorMatch(t *testing.T) {
+	tr := i18n.New()
+	tr.Add("pt-BR", map[string]string{"test": "test"})
+
+	scenarios := []struct {
+		acceptLanguage string
+		expected       string
+	}{
+		{"", ""},
+		{"*", ""},
+		{"ja", ""},
+		{"de", "de"},
+		{"de-CH", "de"},
+		{"en-US,en;q=0.9,de;q=0.8", "en"},
+		{"ja,fr;q=0.5,de;q=0.8", "de"},
+		{"de;q=0,fr", "fr"},
+		{"pt-BR", "pt-br"},
+		{"pt_br", "pt-br"},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.acceptLanguage, func(t *testing.T) {
+			if result := tr.Match(s.acceptLanguage); result != s.expected {
+				t.Fatalf("Expected %q, got %q", s.expected, result)
+			}
+		})
+	}
+}
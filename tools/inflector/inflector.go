@@ -83,3 +83,18 @@ func Snakecase(str string) string {
 
 	return strings.ToLower(result.String())
 }
+
+// Pascalcase removes all non word characters and converts the string
+// into a PascalCase identifier, eg. "hello_world" will become "HelloWorld".
+func Pascalcase(str string) string {
+	var result strings.Builder
+
+	// split at any non word character and underscore
+	words := snakecaseSplitRegex.Split(str, -1)
+
+	for _, word := range words {
+		result.WriteString(UcFirst(word))
+	}
+
+	return result.String()
+}
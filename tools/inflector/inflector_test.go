@@ -132,3 +132,26 @@ func TestSnakecase(t *testing.T) {
 		}
 	}
 }
+
+func TestPascalcase(t *testing.T) {
+	scenarios := []struct {
+		val      string
+		expected string
+	}{
+		{"", ""},
+		{"  ", ""},
+		{"!@#$%^", ""},
+		{"_", ""},
+		{"John Doe", "JohnDoe"},
+		{"john_doe", "JohnDoe"},
+		{".a!b@c#d$e%123. ", "ABCDE123"},
+		{"helloWorld", "HelloWorld"},
+		{"TEST", "TEST"},
+	}
+
+	for i, scenario := range scenarios {
+		if result := inflector.Pascalcase(scenario.val); result != scenario.expected {
+			t.Errorf("(%d) Expected %q, got %q", i, scenario.expected, result)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+// Package ldapauth implements a minimal LDAP (and Active Directory)
+// search-and-bind user authentication client.
+package ldapauth
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// IdentityPlaceholder is the user filter placeholder
+// that is replaced with the escaped login identity.
+const IdentityPlaceholder = "{identity}"
+
+// DefaultUserFilter is the user filter used when none is specified.
+const DefaultUserFilter = "(uid=" + IdentityPlaceholder + ")"
+
+// DefaultTimeout is the default connection and request timeout.
+const DefaultTimeout = 10 * time.Second
+
+// ErrInvalidCredentials is returned when the user entry is not found,
+// is ambiguous or the directory rejects the user bind.
+var ErrInvalidCredentials = errors.New("invalid LDAP credentials")
+
+// Entry defines a single authenticated directory user entry.
+type Entry struct {
+	// DN is the distinguished name of the entry.
+	DN string
+
+	// Attributes holds the first value of each loaded entry attribute.
+	Attributes map[string]string
+}
+
+// Client defines a directory server authentication client.
+type Client struct {
+	// Url is the directory server url, eg. "ldaps://ldap.example.com:636".
+	Url string
+
+	// StartTLS upgrades the plain "ldap://" connection with the StartTLS command.
+	StartTLS bool
+
+	// InsecureSkipVerify disables the server certificate verification.
+	InsecureSkipVerify bool
+
+	// BindDn and BindPassword are the service account credentials used to
+	// search for the user entry (leave empty for anonymous search).
+	BindDn       string
+	BindPassword string
+
+	// BaseDn is the search base of the user entries, eg. "ou=users,dc=example,dc=com".
+	BaseDn string
+
+	// UserFilter is the user entry search filter with an "{identity}"
+	// placeholder (default to [DefaultUserFilter]).
+	UserFilter string
+
+	// Attributes is the list of the user entry attributes to load.
+	Attributes []string
+
+	// Timeout is the connection and request timeout (default to [DefaultTimeout]).
+	Timeout time.Duration
+}
+
+// Authenticate looks up the directory entry matching the provided
+// identity and verifies the password by binding as that entry.
+//
+// Returns [ErrInvalidCredentials] if the entry is missing
+// or the password is rejected by the directory server.
+func (c *Client) Authenticate(identity string, password string) (*Entry, error) {
+	// an empty password would result in an "unauthenticated" bind
+	// that most servers consider successful
+	if identity == "" || password == "" {
+		return nil, ErrInvalidCredentials
+	}
+
+	conn, err := c.dial()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if c.BindDn != "" {
+		if err := conn.Bind(c.BindDn, c.BindPassword); err != nil {
+			return nil, fmt.Errorf("failed to bind the service account: %w", err)
+		}
+	}
+
+	request := ldap.NewSearchRequest(
+		c.BaseDn,
+		ldap.ScopeWholeSubtree,
+		ldap.NeverDerefAliases,
+		2, // more than 1 result is treated as ambiguous
+		int(c.timeout().Seconds()),
+		false,
+		BuildFilter(c.UserFilter, identity),
+		c.Attributes,
+		nil,
+	)
+
+	result, err := conn.Search(request)
+	if err != nil && !ldap.IsErrorWithCode(err, ldap.LDAPResultSizeLimitExceeded) {
+		return nil, fmt.Errorf("failed to search for the user entry: %w", err)
+	}
+
+	if result == nil || len(result.Entries) != 1 {
+		return nil, ErrInvalidCredentials
+	}
+
+	found := result.Entries[0]
+
+	if err := conn.Bind(found.DN, password); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return nil, ErrInvalidCredentials
+		}
+		return nil, fmt.Errorf("failed to bind the user entry: %w", err)
+	}
+
+	entry := &Entry{
+		DN:         found.DN,
+		Attributes: make(map[string]string, len(found.Attributes)),
+	}
+	for _, attr := range found.Attributes {
+		if len(attr.Values) > 0 {
+			entry.Attributes[attr.Name] = attr.Values[0]
+		}
+	}
+
+	return entry, nil
+}
+
+func (c *Client) dial() (*ldap.Conn, error) {
+	timeout := c.timeout()
+
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: c.InsecureSkipVerify,
+	}
+
+	conn, err := ldap.DialURL(
+		c.Url,
+		ldap.DialWithDialer(&net.Dialer{Timeout: timeout}),
+		ldap.DialWithTLSConfig(tlsConfig),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.SetTimeout(timeout)
+
+	if c.StartTLS && !strings.HasPrefix(strings.ToLower(c.Url), "ldaps://") {
+		if u, err := url.Parse(c.Url); err == nil {
+			tlsConfig.ServerName = u.Hostname()
+		}
+
+		if err := conn.StartTLS(tlsConfig); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	return conn, nil
+}
+
+func (c *Client) timeout() time.Duration {
+	if c.Timeout <= 0 {
+		return DefaultTimeout
+	}
+
+	return c.Timeout
+}
+
+// BuildFilter replaces the [IdentityPlaceholder] in the provided
+// filter template with the escaped identity value.
+//
+// If filterTemplate is empty, [DefaultUserFilter] is used.
+func BuildFilter(filterTemplate string, identity string) string {
+	if filterTemplate == "" {
+		filterTemplate = DefaultUserFilter
+	}
+
+	return strings.ReplaceAll(filterTemplate, IdentityPlaceholder, ldap.EscapeFilter(identity))
+}
@@ -0,0 +1,62 @@
+package ldapauth_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/ldapauth"
+)
+
+func TestBuildFilter(t *testing.T) {
+	scenarios := []struct {
+		template string
+		identity string
+		expected string
+	}{
+		{"", "test", "(uid=test)"},
+		{"(sAMAccountName={identity})", "test", "(sAMAccountName=test)"},
+		{"(|(uid={identity})(mail={identity}))", "test@example.com", "(|(uid=test@example.com)(mail=test@example.com))"},
+		{"", "*)(uid=*", `(uid=\2a\29\28uid=\2a)`},
+		{"", `a\b`, `(uid=a\5cb)`},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.template+"_"+s.identity, func(t *testing.T) {
+			result := ldapauth.BuildFilter(s.template, s.identity)
+			if result != s.expected {
+				t.Fatalf("Expected %q, got %q", s.expected, result)
+			}
+		})
+	}
+}
+
+func TestClientAuthenticateEmptyCredentials(t *testing.T) {
+	client := &ldapauth.Client{Url: "ldap://127.0.0.1:1"}
+
+	scenarios := []struct {
+		identity string
+		password string
+	}{
+		{"", ""},
+		{"test", ""},
+		{"", "1234567890"},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.identity+"_"+s.password, func(t *testing.T) {
+			_, err := client.Authenticate(s.identity, s.password)
+			if !errors.Is(err, ldapauth.ErrInvalidCredentials) {
+				t.Fatalf("Expected ErrInvalidCredentials, got %v", err)
+			}
+		})
+	}
+}
+
+func TestClientAuthenticateUnreachableServer(t *testing.T) {
+	client := &ldapauth.Client{Url: "ldap://127.0.0.1:1"}
+
+	_, err := client.Authenticate("test", "1234567890")
+	if err == nil || errors.Is(err, ldapauth.ErrInvalidCredentials) {
+		t.Fatalf("Expected connection error, got %v", err)
+	}
+}
@@ -0,0 +1,643 @@
+// Package markdown implements a minimal Markdown to HTML renderer.
+//
+// It supports a pragmatic subset of CommonMark (ATX and setext headings,
+// paragraphs, block quotes, nested lists, fenced and indented code blocks,
+// thematic breaks, emphasis, strong emphasis, strikethrough, code spans,
+// links, images, autolinks and hard line breaks).
+//
+// Raw HTML is not supported and is always escaped.
+package markdown
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	headingRegex    = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	setextRegex     = regexp.MustCompile(`^ {0,3}(=+|-+)[ \t]*$`)
+	hrRegex         = regexp.MustCompile(`^ {0,3}(?:(?:\*[ \t]*){3,}|(?:-[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	fenceRegex      = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})[ \t]*([^`\\s]*)")
+	blockquoteRegex = regexp.MustCompile(`^ {0,3}> ?`)
+	listItemRegex   = regexp.MustCompile(`^( {0,3})([-+*]|(\d{1,9})[.)])(?:( +)(.*))?$`)
+	autolinkRegex   = regexp.MustCompile(`^<((?:https?://|mailto:)[^\s<>]+|[^\s<>@]+@[^\s<>@]+\.[^\s<>@]+)>`)
+)
+
+// ToHTML renders the provided markdown source into HTML.
+func ToHTML(source string) string {
+	source = strings.ReplaceAll(source, "\r\n", "\n")
+	source = strings.ReplaceAll(source, "\r", "\n")
+	source = strings.ReplaceAll(source, "\t", "    ")
+
+	var sb strings.Builder
+
+	renderBlocks(&sb, strings.Split(source, "\n"), false)
+
+	return sb.String()
+}
+
+// renderBlocks renders the block elements of the provided lines.
+//
+// In tight mode the paragraphs are rendered without the wrapping <p> tag (used for tight list items).
+func renderBlocks(sb *strings.Builder, lines []string, tight bool) {
+	var paragraph []string
+
+	flush := func() {
+		if len(paragraph) == 0 {
+			return
+		}
+
+		content := renderInline(strings.TrimSpace(strings.Join(paragraph, "\n")))
+		if tight {
+			sb.WriteString(content)
+			sb.WriteString("\n")
+		} else {
+			sb.WriteString("<p>")
+			sb.WriteString(content)
+			sb.WriteString("</p>\n")
+		}
+
+		paragraph = nil
+	}
+
+	for i := 0; i < len(lines); {
+		line := lines[i]
+
+		if strings.TrimSpace(line) == "" {
+			flush()
+			i++
+			continue
+		}
+
+		if m := fenceRegex.FindStringSubmatch(line); m != nil {
+			flush()
+			i = renderFencedCode(sb, lines, i, m[1], m[2])
+			continue
+		}
+
+		if m := headingRegex.FindStringSubmatch(line); m != nil {
+			flush()
+			level := strconv.Itoa(len(m[1]))
+			sb.WriteString("<h" + level + ">" + renderInline(strings.TrimSpace(m[2])) + "</h" + level + ">\n")
+			i++
+			continue
+		}
+
+		if len(paragraph) > 0 {
+			if m := setextRegex.FindStringSubmatch(line); m != nil {
+				level := "2"
+				if m[1][0] == '=' {
+					level = "1"
+				}
+				content := renderInline(strings.TrimSpace(strings.Join(paragraph, "\n")))
+				sb.WriteString("<h" + level + ">" + content + "</h" + level + ">\n")
+				paragraph = nil
+				i++
+				continue
+			}
+		}
+
+		if hrRegex.MatchString(line) {
+			flush()
+			sb.WriteString("<hr>\n")
+			i++
+			continue
+		}
+
+		if blockquoteRegex.MatchString(line) {
+			flush()
+			i = renderBlockquote(sb, lines, i)
+			continue
+		}
+
+		if listItemRegex.MatchString(line) {
+			flush()
+			i = renderList(sb, lines, i)
+			continue
+		}
+
+		if len(paragraph) == 0 && strings.HasPrefix(line, "    ") {
+			i = renderIndentedCode(sb, lines, i)
+			continue
+		}
+
+		paragraph = append(paragraph, line)
+		i++
+	}
+
+	flush()
+}
+
+func renderFencedCode(sb *strings.Builder, lines []string, start int, fence string, lang string) int {
+	code := []string{}
+
+	i := start + 1
+	for ; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" {
+			i++ // skip the closing fence
+			break
+		}
+		code = append(code, lines[i])
+	}
+
+	sb.WriteString("<pre><code")
+	if lang != "" {
+		sb.WriteString(` class="language-` + html.EscapeString(lang) + `"`)
+	}
+	sb.WriteString(">")
+	if len(code) > 0 {
+		sb.WriteString(html.EscapeString(strings.Join(code, "\n") + "\n"))
+	}
+	sb.WriteString("</code></pre>\n")
+
+	return i
+}
+
+func renderIndentedCode(sb *strings.Builder, lines []string, start int) int {
+	code := []string{}
+
+	i := start
+	for ; i < len(lines); i++ {
+		if strings.HasPrefix(lines[i], "    ") {
+			code = append(code, lines[i][4:])
+		} else if strings.TrimSpace(lines[i]) == "" {
+			code = append(code, "")
+		} else {
+			break
+		}
+	}
+
+	// trim trailing blank lines
+	for len(code) > 0 && code[len(code)-1] == "" {
+		code = code[:len(code)-1]
+	}
+
+	sb.WriteString("<pre><code>")
+	sb.WriteString(html.EscapeString(strings.Join(code, "\n") + "\n"))
+	sb.WriteString("</code></pre>\n")
+
+	return i
+}
+
+func renderBlockquote(sb *strings.Builder, lines []string, start int) int {
+	inner := []string{}
+
+	i := start
+	for ; i < len(lines); i++ {
+		loc := blockquoteRegex.FindStringIndex(lines[i])
+		if loc == nil {
+			break
+		}
+		inner = append(inner, lines[i][loc[1]:])
+	}
+
+	sb.WriteString("<blockquote>\n")
+	renderBlocks(sb, inner, false)
+	sb.WriteString("</blockquote>\n")
+
+	return i
+}
+
+func renderList(sb *strings.Builder, lines []string, start int) int {
+	first := listItemRegex.FindStringSubmatch(lines[start])
+	ordered := first[3] != ""
+	marker := first[2][len(first[2])-1:] // "-", "+", "*", "." or ")"
+
+	isSibling := func(line string) ([]string, bool) {
+		m := listItemRegex.FindStringSubmatch(line)
+		if m == nil || (m[3] != "") != ordered || m[2][len(m[2])-1:] != marker {
+			return nil, false
+		}
+		return m, true
+	}
+
+	var items [][]string
+	var loose bool
+
+	i := start
+	for i < len(lines) {
+		m, ok := isSibling(lines[i])
+		if !ok {
+			break
+		}
+
+		indent := len(m[1]) + len(m[2]) + len(m[4])
+		if len(m[4]) == 0 || len(m[4]) > 4 {
+			indent = len(m[1]) + len(m[2]) + 1
+		}
+
+		content := m[5]
+		if len(m[4]) > 4 {
+			content = m[4][1:] + content
+		}
+
+		item := []string{content}
+
+		i++
+		for i < len(lines) {
+			line := lines[i]
+
+			if strings.TrimSpace(line) == "" {
+				// find the next non-blank line
+				j := i
+				for j < len(lines) && strings.TrimSpace(lines[j]) == "" {
+					j++
+				}
+
+				if j < len(lines) && leadingSpaces(lines[j]) >= indent {
+					for ; i < j; i++ {
+						item = append(item, "")
+					}
+					loose = true
+					continue
+				}
+
+				if j < len(lines) {
+					if _, ok := isSibling(lines[j]); ok {
+						loose = true
+						i = j
+					}
+				}
+
+				break
+			}
+
+			if leadingSpaces(line) >= indent {
+				item = append(item, line[indent:])
+				i++
+				continue
+			}
+
+			// lazy paragraph continuation
+			if listItemRegex.MatchString(line) ||
+				hrRegex.MatchString(line) ||
+				headingRegex.MatchString(line) ||
+				fenceRegex.MatchString(line) ||
+				blockquoteRegex.MatchString(line) {
+				break
+			}
+
+			item = append(item, strings.TrimLeft(line, " "))
+			i++
+		}
+
+		items = append(items, item)
+	}
+
+	tag := "ul"
+	if ordered {
+		tag = "ol"
+	}
+
+	sb.WriteString("<" + tag)
+	if ordered {
+		if n, _ := strconv.Atoi(first[3]); n != 1 {
+			sb.WriteString(` start="` + strconv.Itoa(n) + `"`)
+		}
+	}
+	sb.WriteString(">\n")
+
+	for _, item := range items {
+		var itemSb strings.Builder
+		renderBlocks(&itemSb, item, !loose)
+
+		sb.WriteString("<li>")
+		sb.WriteString(strings.TrimSuffix(itemSb.String(), "\n"))
+		sb.WriteString("</li>\n")
+	}
+
+	sb.WriteString("</" + tag + ">\n")
+
+	return i
+}
+
+func leadingSpaces(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// -------------------------------------------------------------------
+// Inlines
+// -------------------------------------------------------------------
+
+func renderInline(s string) string {
+	var sb strings.Builder
+
+	for i := 0; i < len(s); {
+		c := s[i]
+
+		switch c {
+		case '\\':
+			if i+1 < len(s) {
+				if s[i+1] == '\n' {
+					sb.WriteString("<br>\n")
+					i += 2
+					continue
+				}
+				if isPunct(s[i+1]) {
+					sb.WriteString(html.EscapeString(s[i+1 : i+2]))
+					i += 2
+					continue
+				}
+			}
+		case ' ':
+			// hard line break (2+ trailing spaces)
+			j := i
+			for j < len(s) && s[j] == ' ' {
+				j++
+			}
+			if j < len(s) && s[j] == '\n' {
+				if j-i >= 2 {
+					sb.WriteString("<br>")
+				}
+				i = j
+				continue
+			}
+		case '`':
+			n := runLength(s, i, '`')
+			if end := findBacktickRun(s, i+n, n); end >= 0 {
+				code := strings.ReplaceAll(s[i+n:end], "\n", " ")
+				if len(code) > 2 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.TrimSpace(code) != "" {
+					code = code[1 : len(code)-1]
+				}
+				sb.WriteString("<code>" + html.EscapeString(code) + "</code>")
+				i = end + n
+				continue
+			}
+			sb.WriteString(s[i : i+n])
+			i += n
+			continue
+		case '!':
+			if i+1 < len(s) && s[i+1] == '[' {
+				if text, dest, title, end, ok := parseLink(s, i+1); ok {
+					sb.WriteString(`<img src="` + html.EscapeString(dest) + `" alt="` + html.EscapeString(plainText(text)) + `"`)
+					if title != "" {
+						sb.WriteString(` title="` + html.EscapeString(title) + `"`)
+					}
+					sb.WriteString(">")
+					i = end
+					continue
+				}
+			}
+		case '[':
+			if text, dest, title, end, ok := parseLink(s, i); ok {
+				sb.WriteString(`<a href="` + html.EscapeString(dest) + `"`)
+				if title != "" {
+					sb.WriteString(` title="` + html.EscapeString(title) + `"`)
+				}
+				sb.WriteString(">" + renderInline(text) + "</a>")
+				i = end
+				continue
+			}
+		case '<':
+			if m := autolinkRegex.FindStringSubmatch(s[i:]); m != nil {
+				href := m[1]
+				if !strings.Contains(href, ":") {
+					href = "mailto:" + href
+				}
+				sb.WriteString(`<a href="` + html.EscapeString(href) + `">` + html.EscapeString(m[1]) + "</a>")
+				i += len(m[0])
+				continue
+			}
+		case '*', '_', '~':
+			if rendered, end, ok := parseEmphasis(s, i); ok {
+				sb.WriteString(rendered)
+				i = end
+				continue
+			}
+			n := runLength(s, i, c)
+			sb.WriteString(s[i : i+n])
+			i += n
+			continue
+		}
+
+		sb.WriteString(html.EscapeString(s[i : i+1]))
+		i++
+	}
+
+	return sb.String()
+}
+
+// parseEmphasis parses the emphasis, strong emphasis or strikethrough
+// starting at s[start] and returns its rendered HTML and end position.
+func parseEmphasis(s string, start int) (string, int, bool) {
+	c := s[start]
+	n := runLength(s, start, c)
+
+	// intraword underscores are not treated as emphasis
+	if c == '_' && start > 0 && isWordChar(s[start-1]) {
+		return "", 0, false
+	}
+
+	var size int
+	var tag string
+	switch {
+	case c == '~' && n == 2:
+		size, tag = 2, "del"
+	case c == '~':
+		return "", 0, false
+	case n >= 2:
+		size, tag = 2, "strong"
+	default:
+		size, tag = 1, "em"
+	}
+
+	open := start + size
+	if open >= len(s) || isSpace(s[open]) {
+		return "", 0, false
+	}
+
+	delim := s[start : start+size]
+
+	for j := open + 1; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			j++ // skip the escaped character
+			continue
+		case '`':
+			// skip code spans
+			m := runLength(s, j, '`')
+			if end := findBacktickRun(s, j+m, m); end >= 0 {
+				j = end + m - 1
+			} else {
+				j += m - 1
+			}
+			continue
+		}
+
+		if !strings.HasPrefix(s[j:], delim) {
+			continue
+		}
+
+		run := runLength(s, j, c)
+
+		// a single delimiter doesn't close on a double delimiter run (eg. "*a **b** c*")
+		if size == 1 && run == 2 && c != '~' {
+			if end := strings.Index(s[j+2:], s[j:j+2]); end >= 0 {
+				j += 2 + end + 1
+			} else {
+				j++
+			}
+			continue
+		}
+
+		if isSpace(s[j-1]) {
+			continue
+		}
+
+		if c == '_' && j+size < len(s) && isWordChar(s[j+size]) {
+			continue
+		}
+
+		return "<" + tag + ">" + renderInline(s[open:j]) + "</" + tag + ">", j + size, true
+	}
+
+	if size == 2 && c != '~' {
+		// fallback to a single delimiter emphasis (eg. "**a*")
+		if rendered, end, ok := parseEmphasis(s[:start]+s[start+1:], start); ok {
+			return html.EscapeString(s[start:start+1]) + rendered, end + 1, true
+		}
+	}
+
+	return "", 0, false
+}
+
+// parseLink parses an inline link "[text](dest "title")" starting at s[start].
+func parseLink(s string, start int) (text, dest, title string, end int, ok bool) {
+	// find the matching closing bracket
+	depth := 0
+	closeBracket := -1
+	for j := start; j < len(s) && closeBracket < 0; j++ {
+		switch s[j] {
+		case '\\':
+			j++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				closeBracket = j
+			}
+		}
+	}
+
+	if closeBracket < 0 || closeBracket+1 >= len(s) || s[closeBracket+1] != '(' {
+		return
+	}
+
+	text = s[start+1 : closeBracket]
+
+	j := closeBracket + 2
+	for j < len(s) && isSpace(s[j]) {
+		j++
+	}
+
+	// destination
+	if j < len(s) && s[j] == '<' {
+		closeAngle := strings.IndexByte(s[j:], '>')
+		if closeAngle < 0 {
+			return
+		}
+		dest = s[j+1 : j+closeAngle]
+		j += closeAngle + 1
+	} else {
+		parens := 0
+		destStart := j
+		for ; j < len(s) && !isSpace(s[j]); j++ {
+			if s[j] == '(' {
+				parens++
+			} else if s[j] == ')' {
+				if parens == 0 {
+					break
+				}
+				parens--
+			}
+		}
+		dest = s[destStart:j]
+	}
+
+	for j < len(s) && isSpace(s[j]) {
+		j++
+	}
+
+	// optional title
+	if j < len(s) && (s[j] == '"' || s[j] == '\'') {
+		quote := s[j]
+		closeQuote := strings.IndexByte(s[j+1:], quote)
+		if closeQuote < 0 {
+			return
+		}
+		title = s[j+1 : j+1+closeQuote]
+		j += closeQuote + 2
+
+		for j < len(s) && isSpace(s[j]) {
+			j++
+		}
+	}
+
+	if j >= len(s) || s[j] != ')' {
+		return
+	}
+
+	return text, unescapePunct(dest), unescapePunct(title), j + 1, true
+}
+
+// plainText strips the basic inline markup characters (used for the image alt text).
+func plainText(s string) string {
+	return strings.NewReplacer("*", "", "_", "", "`", "", "~~", "").Replace(unescapePunct(s))
+}
+
+func unescapePunct(s string) string {
+	if !strings.Contains(s, "\\") {
+		return s
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) && isPunct(s[i+1]) {
+			i++
+		}
+		sb.WriteByte(s[i])
+	}
+
+	return sb.String()
+}
+
+func findBacktickRun(s string, from int, n int) int {
+	for j := from; j < len(s); {
+		if s[j] != '`' {
+			j++
+			continue
+		}
+
+		m := runLength(s, j, '`')
+		if m == n {
+			return j
+		}
+		j += m
+	}
+
+	return -1
+}
+
+func runLength(s string, start int, c byte) int {
+	n := 0
+	for start+n < len(s) && s[start+n] == c {
+		n++
+	}
+	return n
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\n'
+}
+
+func isWordChar(c byte) bool {
+	return c == '_' || (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80
+}
+
+func isPunct(c byte) bool {
+	return strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", c) >= 0
+}
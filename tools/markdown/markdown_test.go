@@ -0,0 +1,52 @@
+package markdown_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/markdown"
+)
+
+func TestToHTML(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		source   string
+		expected string
+	}{
+		{"empty", "", ""},
+		{"paragraphs", "a\nb\n\nc", "<p>a\nb</p>\n<p>c</p>\n"},
+		{"atx headings", "# a\n###### b ##\n#no", "<h1>a</h1>\n<h6>b</h6>\n<p>#no</p>\n"},
+		{"setext headings", "a\n===\nb\n---", "<h1>a</h1>\n<h2>b</h2>\n"},
+		{"thematic break", "a\n\n***\n- - -", "<p>a</p>\n<hr>\n<hr>\n"},
+		{"escaped html", "<script>alert(1)</script> & \"x\"", "<p>&lt;script&gt;alert(1)&lt;/script&gt; &amp; &#34;x&#34;</p>\n"},
+		{"emphasis", "*a* _b_ **c** __d__ ~~e~~ *f **g** h*", "<p><em>a</em> <em>b</em> <strong>c</strong> <strong>d</strong> <del>e</del> <em>f <strong>g</strong> h</em></p>\n"},
+		{"not emphasis", "a * b snake_case_name 2*3", "<p>a * b snake_case_name 2*3</p>\n"},
+		{"code span", "`a <b>` `` c`d ``", "<p><code>a &lt;b&gt;</code> <code>c`d</code></p>\n"},
+		{"escapes", `\*a\* \[b\]`, "<p>*a* [b]</p>\n"},
+		{"hard line break", "a  \nb\\\nc", "<p>a<br>\nb<br>\nc</p>\n"},
+		{
+			"links and images",
+			`[a *b*](https://example.com "t") ![c](/d.png) [e](<f g>) <https://h.com> <i@example.com>`,
+			`<p><a href="https://example.com" title="t">a <em>b</em></a> <img src="/d.png" alt="c"> <a href="f g">e</a> <a href="https://h.com">https://h.com</a> <a href="mailto:i@example.com">i@example.com</a></p>` + "\n",
+		},
+		{"not links", "[a] [b](c", "<p>[a] [b](c</p>\n"},
+		{"fenced code", "```go\nfunc() {\n\t<a>\n}\n```\nb", "<pre><code class=\"language-go\">func() {\n    &lt;a&gt;\n}\n</code></pre>\n<p>b</p>\n"},
+		{"unclosed fenced code", "~~~\na", "<pre><code>a\n</code></pre>\n"},
+		{"indented code", "    a\n\n    b\nc", "<pre><code>a\n\nb\n</code></pre>\n<p>c</p>\n"},
+		{"blockquote", "> a\n> > b\n\nc", "<blockquote>\n<p>a</p>\n<blockquote>\n<p>b</p>\n</blockquote>\n</blockquote>\n<p>c</p>\n"},
+		{"tight list", "- a\n- b\n  - c\n- d", "<ul>\n<li>a</li>\n<li>b\n<ul>\n<li>c</li>\n</ul></li>\n<li>d</li>\n</ul>\n"},
+		{"loose list", "1. a\n\n2. b", "<ol>\n<li><p>a</p></li>\n<li><p>b</p></li>\n</ol>\n"},
+		{"ordered list start", "3) a\n4) b", "<ol start=\"3\">\n<li>a</li>\n<li>b</li>\n</ol>\n"},
+		{"list with lazy continuation", "- a\nb\n\nc", "<ul>\n<li>a\nb</li>\n</ul>\n<p>c</p>\n"},
+		{"different list markers", "- a\n+ b", "<ul>\n<li>a</li>\n</ul>\n<ul>\n<li>b</li>\n</ul>\n"},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result := markdown.ToHTML(s.source)
+
+			if result != s.expected {
+				t.Fatalf("Expected\n%q\ngot\n%q", s.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+// Package pwned implements a minimal k-anonymity client for the
+// "Have I Been Pwned" Pwned Passwords range API.
+//
+// Only the first 5 characters of the password SHA-1 hash are sent
+// to the API, meaning that the checked password never leaves the app.
+package pwned
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultRangeUrl is the default Pwned Passwords range API url
+// (the 5 characters hash prefix is appended to it).
+const DefaultRangeUrl = "https://api.pwnedpasswords.com/range/"
+
+// Client defines a Pwned Passwords range API client.
+type Client struct {
+	// RangeUrl is the range API url (default to DefaultRangeUrl).
+	RangeUrl string
+
+	// HttpClient is the client used to send the range requests.
+	HttpClient *http.Client
+}
+
+// DefaultClient is the client used by the package level [Count] function.
+var DefaultClient = New()
+
+// New creates a new Client with the default range url and 10s timeout.
+func New() *Client {
+	return &Client{
+		RangeUrl:   DefaultRangeUrl,
+		HttpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Count returns the number of times the provided password was
+// found in the known data breaches using the [DefaultClient].
+func Count(ctx context.Context, password string) (int, error) {
+	return DefaultClient.Count(ctx, password)
+}
+
+// Count returns the number of times the provided password
+// was found in the known data breaches (0 means not found).
+func (c *Client) Count(ctx context.Context, password string) (int, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:5], hash[5:]
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.RangeUrl+prefix, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	// pad the response to prevent inferring the prefix from its size
+	req.Header.Set("Add-Padding", "true")
+
+	res, err := c.HttpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("unexpected range api response status %d", res.StatusCode)
+	}
+
+	// each line is in the format "SUFFIX:COUNT"
+	scanner := bufio.NewScanner(res.Body)
+	for scanner.Scan() {
+		lineSuffix, rawCount, ok := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if !ok || !strings.EqualFold(lineSuffix, suffix) {
+			continue
+		}
+
+		// the padding entries have 0 count
+		count, _ := strconv.Atoi(rawCount)
+
+		return count, nil
+	}
+
+	return 0, scanner.Err()
+}
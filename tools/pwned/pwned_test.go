@@ -0,0 +1,70 @@
+package pwned_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/pwned"
+)
+
+func TestClientCount(t *testing.T) {
+	var requestedPath string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedPath = r.URL.Path
+
+		if r.Header.Get("Add-Padding") != "true" {
+			t.Errorf("Expected the Add-Padding header to be set")
+		}
+
+		// sha1("password") = 5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8
+		fmt.Fprint(w, "003D68EB55068C33ACE09247EE4C639306B:3\r\n")
+		fmt.Fprint(w, "1E4C9B93F3F0682250B6CF8331B7EE68FD8:3861493\r\n")
+		fmt.Fprint(w, "FFFFF9B93F3F0682250B6CF8331B7EE68FD:0\r\n")
+	}))
+	defer server.Close()
+
+	client := pwned.New()
+	client.RangeUrl = server.URL + "/range/"
+
+	scenarios := []struct {
+		password      string
+		expectedPath  string
+		expectedCount int
+	}{
+		{"password", "/range/5BAA6", 3861493},
+		{"not-breached-123", "", 0},
+	}
+
+	for _, s := range scenarios {
+		count, err := client.Count(context.Background(), s.password)
+		if err != nil {
+			t.Fatalf("[%s] %v", s.password, err)
+		}
+
+		if count != s.expectedCount {
+			t.Fatalf("[%s] Expected count %d, got %d", s.password, s.expectedCount, count)
+		}
+
+		if s.expectedPath != "" && requestedPath != s.expectedPath {
+			t.Fatalf("[%s] Expected path %q, got %q", s.password, s.expectedPath, requestedPath)
+		}
+	}
+}
+
+func TestClientCountErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := pwned.New()
+	client.RangeUrl = server.URL + "/range/"
+
+	if _, err := client.Count(context.Background(), "password"); err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+}
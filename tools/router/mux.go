@@ -0,0 +1,213 @@
+package router
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+var _ Router = (*Mux)(nil)
+var _ http.Handler = (*Mux)(nil)
+
+// Mux is a minimal std net/http based [Router] implementation.
+type Mux struct {
+	*group
+
+	// ErrorHandler handles the errors returned by the route handlers
+	// (fallbacks to [DefaultErrorHandler] if not set).
+	ErrorHandler func(e *Event, err error)
+
+	routes []*route
+}
+
+// NewMux creates a new empty Mux router.
+func NewMux() *Mux {
+	mux := &Mux{}
+	mux.group = &group{mux: mux}
+
+	return mux
+}
+
+// ServeHTTP implements the [http.Handler] interface.
+func (m *Mux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	segments := splitPath(r.URL.Path)
+
+	var matched *route
+	var params map[string]string
+	var methodMismatch bool
+
+	for _, rt := range m.routes {
+		routeParams, ok := rt.match(segments)
+		if !ok {
+			continue
+		}
+
+		if rt.method != r.Method {
+			methodMismatch = true
+			continue
+		}
+
+		// prefer the routes with more static segments
+		if matched == nil || rt.staticCount > matched.staticCount {
+			matched = rt
+			params = routeParams
+		}
+	}
+
+	event := NewEvent(w, r, func(name string) string {
+		return params[name]
+	}, nil)
+
+	var handler HandlerFunc
+	if matched != nil {
+		handler = matched.handler
+		for i := len(matched.middlewares) - 1; i >= 0; i-- {
+			handler = matched.middlewares[i](handler)
+		}
+	} else if methodMismatch {
+		handler = func(e *Event) error {
+			return e.String(http.StatusMethodNotAllowed, http.StatusText(http.StatusMethodNotAllowed))
+		}
+	} else {
+		handler = func(e *Event) error {
+			return ErrNotFound
+		}
+	}
+
+	// apply the root middlewares to all requests (incl. not found)
+	for i := len(m.group.middlewares) - 1; i >= 0; i-- {
+		handler = m.group.middlewares[i](handler)
+	}
+
+	if err := handler(event); err != nil {
+		errorHandler := m.ErrorHandler
+		if errorHandler == nil {
+			errorHandler = DefaultErrorHandler
+		}
+		errorHandler(event, err)
+	}
+}
+
+// DefaultErrorHandler is the default [Mux] error handler.
+//
+// It responds with 404 for [ErrNotFound] and with 500 for all other errors.
+func DefaultErrorHandler(e *Event, err error) {
+	status := http.StatusInternalServerError
+	if errors.Is(err, ErrNotFound) {
+		status = http.StatusNotFound
+	}
+
+	e.JSON(status, map[string]any{
+		"code":    status,
+		"message": http.StatusText(status),
+		"data":    map[string]any{},
+	})
+}
+
+// -------------------------------------------------------------------
+
+type group struct {
+	mux         *Mux
+	parent      *group
+	prefix      string
+	middlewares []Middleware
+}
+
+// Use implements [Router.Use].
+func (g *group) Use(middlewares ...Middleware) {
+	g.middlewares = append(g.middlewares, middlewares...)
+}
+
+// Group implements [Router.Group].
+func (g *group) Group(prefix string, middlewares ...Middleware) Router {
+	return &group{
+		mux:         g.mux,
+		parent:      g,
+		prefix:      g.prefix + prefix,
+		middlewares: middlewares,
+	}
+}
+
+// Add implements [Router.Add].
+func (g *group) Add(method string, path string, handler HandlerFunc, middlewares ...Middleware) {
+	rt := &route{
+		method:   strings.ToUpper(method),
+		segments: splitPath(g.prefix + path),
+		handler:  handler,
+	}
+
+	for _, s := range rt.segments {
+		if s != "*" && !strings.HasPrefix(s, ":") {
+			rt.staticCount++
+		}
+	}
+
+	// resolve the group middlewares lazily so that middlewares
+	// registered after the route are also applied
+	// (the root group middlewares are applied directly by the Mux)
+	rt.middlewares = append(rt.middlewares, func(next HandlerFunc) HandlerFunc {
+		return func(e *Event) error {
+			h := next
+			for current := g; current != nil && current.parent != nil; current = current.parent {
+				for i := len(current.middlewares) - 1; i >= 0; i-- {
+					h = current.middlewares[i](h)
+				}
+			}
+			return h(e)
+		}
+	})
+	rt.middlewares = append(rt.middlewares, middlewares...)
+
+	g.mux.routes = append(g.mux.routes, rt)
+}
+
+// -------------------------------------------------------------------
+
+type route struct {
+	method      string
+	segments    []string
+	staticCount int
+	handler     HandlerFunc
+	middlewares []Middleware
+}
+
+// match checks whether the provided path segments match the route
+// and returns the resolved path params.
+func (rt *route) match(segments []string) (map[string]string, bool) {
+	params := map[string]string{}
+
+	for i, s := range rt.segments {
+		if s == "*" {
+			params["*"] = strings.Join(segments[min(i, len(segments)):], "/")
+			return params, true
+		}
+
+		if i >= len(segments) {
+			return nil, false
+		}
+
+		if strings.HasPrefix(s, ":") {
+			params[s[1:]] = segments[i]
+			continue
+		}
+
+		if s != segments[i] {
+			return nil, false
+		}
+	}
+
+	if len(segments) != len(rt.segments) {
+		return nil, false
+	}
+
+	return params, true
+}
+
+func splitPath(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return []string{}
+	}
+
+	return strings.Split(path, "/")
+}
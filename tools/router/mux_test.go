@@ -0,0 +1,148 @@
+package router_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/router"
+)
+
+func TestMuxRouting(t *testing.T) {
+	mux := router.NewMux()
+
+	router.GET(mux, "/a/b", func(e *router.Event) error {
+		return e.String(200, "static")
+	})
+	router.GET(mux, "/a/:id", func(e *router.Event) error {
+		return e.String(200, "param:"+e.PathParam("id"))
+	})
+	router.POST(mux, "/a/:id", func(e *router.Event) error {
+		return e.String(201, "post:"+e.PathParam("id"))
+	})
+	router.GET(mux, "/files/*", func(e *router.Event) error {
+		return e.String(200, "wildcard:"+e.PathParam("*"))
+	})
+	router.GET(mux, "/error", func(e *router.Event) error {
+		return errors.New("test")
+	})
+
+	scenarios := []struct {
+		method         string
+		url            string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{http.MethodGet, "/a/b", 200, "static"},
+		{http.MethodGet, "/a/b/", 200, "static"},
+		{http.MethodGet, "/a/test", 200, "param:test"},
+		{http.MethodPost, "/a/test", 201, "post:test"},
+		{http.MethodDelete, "/a/test", 405, "Method Not Allowed"},
+		{http.MethodGet, "/files", 200, "wildcard:"},
+		{http.MethodGet, "/files/a/b.txt", 200, "wildcard:a/b.txt"},
+		{http.MethodGet, "/a/b/c", 404, `"code":404`},
+		{http.MethodGet, "/missing", 404, `"code":404`},
+		{http.MethodGet, "/error", 500, `"code":500`},
+	}
+
+	for _, s := range scenarios {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(s.method, s.url, nil))
+
+		if rec.Code != s.expectedStatus {
+			t.Errorf("[%s %s] Expected status %d, got %d", s.method, s.url, s.expectedStatus, rec.Code)
+		}
+
+		if body := rec.Body.String(); !strings.Contains(body, s.expectedBody) {
+			t.Errorf("[%s %s] Expected body to contain %q, got %q", s.method, s.url, s.expectedBody, body)
+		}
+	}
+}
+
+func TestMuxMiddlewares(t *testing.T) {
+	calls := []string{}
+
+	newMiddleware := func(name string) router.Middleware {
+		return func(next router.HandlerFunc) router.HandlerFunc {
+			return func(e *router.Event) error {
+				calls = append(calls, name)
+				return next(e)
+			}
+		}
+	}
+
+	mux := router.NewMux()
+	mux.Use(newMiddleware("root"))
+
+	g1 := mux.Group("/g1", newMiddleware("g1"))
+	g2 := g1.Group("/g2", newMiddleware("g2"))
+
+	router.GET(g2, "/test", func(e *router.Event) error {
+		calls = append(calls, "handler")
+		return e.NoContent(204)
+	}, newMiddleware("route"))
+
+	// registered after the route
+	g1.Use(newMiddleware("g1_late"))
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/g1/g2/test", nil))
+
+	if rec.Code != 204 {
+		t.Fatalf("Expected status 204, got %d", rec.Code)
+	}
+
+	expected := "root,g1,g1_late,g2,route,handler"
+	if result := strings.Join(calls, ","); result != expected {
+		t.Fatalf("Expected calls %s, got %s", expected, result)
+	}
+
+	// root middlewares should be applied also for not found requests
+	calls = []string{}
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/missing", nil))
+	if rec.Code != 404 {
+		t.Fatalf("Expected status 404, got %d", rec.Code)
+	}
+	if result := strings.Join(calls, ","); result != "root" {
+		t.Fatalf("Expected calls root, got %s", result)
+	}
+}
+
+func TestMuxErrorHandler(t *testing.T) {
+	mux := router.NewMux()
+	mux.ErrorHandler = func(e *router.Event, err error) {
+		e.String(400, "custom:"+err.Error())
+	}
+
+	router.GET(mux, "/test", func(e *router.Event) error {
+		return errors.New("test")
+	})
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/test", nil))
+
+	if rec.Code != 400 || rec.Body.String() != "custom:test" {
+		t.Fatalf("Expected custom error response, got %d %q", rec.Code, rec.Body.String())
+	}
+}
+
+func TestEventStore(t *testing.T) {
+	e := router.NewEvent(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil), nil, nil)
+
+	if e.Get("missing") != nil {
+		t.Fatal("Expected nil value")
+	}
+
+	if e.PathParam("missing") != "" {
+		t.Fatal("Expected empty path param")
+	}
+
+	e.Set("test", 123)
+
+	if v, _ := e.Get("test").(int); v != 123 {
+		t.Fatalf("Expected 123, got %v", e.Get("test"))
+	}
+}
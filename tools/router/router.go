@@ -0,0 +1,166 @@
+// Package router defines a minimal net/http based routing abstraction
+// that is independent from the underlying HTTP framework.
+//
+// It allows registering custom routes without depending directly on the
+// current echo implementation, making it possible to swap the HTTP layer
+// (eg. with the std net/http ServeMux) without breaking the app routes.
+//
+// Route paths use the same syntax for both the echo adapter and the
+// builtin [Mux] implementation:
+//
+//	/api/static       - static segment
+//	/api/:id          - named path param
+//	/api/files/*      - wildcard (matches the rest of the path, accessible with PathParam("*"))
+package router
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// ErrNotFound is the error returned when no route matches the request.
+var ErrNotFound = errors.New("Not Found")
+
+// HandlerFunc defines a route handler function.
+type HandlerFunc func(e *Event) error
+
+// Middleware defines a route middleware function.
+type Middleware func(next HandlerFunc) HandlerFunc
+
+// Router defines the common interface for registering routes and middlewares.
+type Router interface {
+	// Use registers one or more middlewares for all routes of the current router.
+	Use(middlewares ...Middleware)
+
+	// Group creates a new sub-router with the specified path prefix and middlewares.
+	Group(prefix string, middlewares ...Middleware) Router
+
+	// Add registers a new route for the specified http method and path.
+	Add(method string, path string, handler HandlerFunc, middlewares ...Middleware)
+}
+
+// GET is a shorthand for registering a GET route to the provided router.
+func GET(r Router, path string, handler HandlerFunc, middlewares ...Middleware) {
+	r.Add(http.MethodGet, path, handler, middlewares...)
+}
+
+// POST is a shorthand for registering a POST route to the provided router.
+func POST(r Router, path string, handler HandlerFunc, middlewares ...Middleware) {
+	r.Add(http.MethodPost, path, handler, middlewares...)
+}
+
+// PUT is a shorthand for registering a PUT route to the provided router.
+func PUT(r Router, path string, handler HandlerFunc, middlewares ...Middleware) {
+	r.Add(http.MethodPut, path, handler, middlewares...)
+}
+
+// PATCH is a shorthand for registering a PATCH route to the provided router.
+func PATCH(r Router, path string, handler HandlerFunc, middlewares ...Middleware) {
+	r.Add(http.MethodPatch, path, handler, middlewares...)
+}
+
+// DELETE is a shorthand for registering a DELETE route to the provided router.
+func DELETE(r Router, path string, handler HandlerFunc, middlewares ...Middleware) {
+	r.Add(http.MethodDelete, path, handler, middlewares...)
+}
+
+// -------------------------------------------------------------------
+
+// Store defines a key-value store interface for sharing data
+// between the middlewares and handlers of a single request.
+type Store interface {
+	Get(key string) any
+	Set(key string, value any)
+}
+
+type mapStore map[string]any
+
+func (s mapStore) Get(key string) any {
+	return s[key]
+}
+
+func (s mapStore) Set(key string, value any) {
+	s[key] = value
+}
+
+// Event defines a single request-response routing event.
+type Event struct {
+	Response http.ResponseWriter
+	Request  *http.Request
+
+	pathParam func(name string) string
+	store     Store
+}
+
+// NewEvent creates a new routing Event.
+//
+// pathParam is the function used to resolve the route path params
+// and store is the request scoped key-value store (both could be nil).
+func NewEvent(
+	w http.ResponseWriter,
+	r *http.Request,
+	pathParam func(name string) string,
+	store Store,
+) *Event {
+	if store == nil {
+		store = mapStore{}
+	}
+
+	return &Event{
+		Response:  w,
+		Request:   r,
+		pathParam: pathParam,
+		store:     store,
+	}
+}
+
+// PathParam returns the value of the named route path param
+// (or empty string if missing).
+func (e *Event) PathParam(name string) string {
+	if e.pathParam == nil {
+		return ""
+	}
+
+	return e.pathParam(name)
+}
+
+// Store returns the underlying request scoped key-value store.
+func (e *Event) Store() Store {
+	return e.store
+}
+
+// Get returns a value from the event store.
+func (e *Event) Get(key string) any {
+	return e.store.Get(key)
+}
+
+// Set stores a single value in the event store.
+func (e *Event) Set(key string, value any) {
+	e.store.Set(key, value)
+}
+
+// JSON sends a json response with the specified status code.
+func (e *Event) JSON(status int, data any) error {
+	e.Response.Header().Set("Content-Type", "application/json")
+	e.Response.WriteHeader(status)
+
+	return json.NewEncoder(e.Response).Encode(data)
+}
+
+// String sends a plain text response with the specified status code.
+func (e *Event) String(status int, data string) error {
+	e.Response.Header().Set("Content-Type", "text/plain; charset=UTF-8")
+	e.Response.WriteHeader(status)
+
+	_, err := e.Response.Write([]byte(data))
+
+	return err
+}
+
+// NoContent sends a response without body and the specified status code.
+func (e *Event) NoContent(status int) error {
+	e.Response.WriteHeader(status)
+
+	return nil
+}
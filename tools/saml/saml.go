@@ -0,0 +1,522 @@
+// Package saml implements a minimal SAML 2.0 Web Browser SSO service provider
+// (HTTP-Redirect AuthnRequest binding and HTTP-POST Response binding).
+//
+// Encrypted assertions are not supported.
+package saml
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/beevik/etree"
+	"github.com/pocketbase/pocketbase/tools/security"
+	dsig "github.com/russellhaering/goxmldsig"
+)
+
+// SAML 2.0 namespaces, bindings and identifiers.
+const (
+	NamespaceMetadata  = "urn:oasis:names:tc:SAML:2.0:metadata"
+	NamespaceProtocol  = "urn:oasis:names:tc:SAML:2.0:protocol"
+	NamespaceAssertion = "urn:oasis:names:tc:SAML:2.0:assertion"
+
+	BindingHTTPRedirect = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect"
+	BindingHTTPPost     = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"
+
+	StatusSuccess = "urn:oasis:names:tc:SAML:2.0:status:Success"
+
+	NameIdFormatEmail       = "urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress"
+	NameIdFormatUnspecified = "urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified"
+
+	subjectConfirmationBearer = "urn:oasis:names:tc:SAML:2.0:cm:bearer"
+)
+
+// DefaultClockSkew is the default allowed time difference
+// between the service provider and the identity provider.
+const DefaultClockSkew = 3 * time.Minute
+
+// ErrInvalidResponse is returned when the SAML response
+// is malformed, unsigned or doesn't satisfy its conditions.
+var ErrInvalidResponse = errors.New("invalid SAML response")
+
+// IdentityProvider defines the identity provider
+// settings loaded from its metadata.
+type IdentityProvider struct {
+	// EntityId is the identity provider issuer identifier.
+	EntityId string
+
+	// SsoUrl is the HTTP-Redirect single sign-on service url.
+	SsoUrl string
+
+	// Certificates are the identity provider signing certificates.
+	Certificates []*x509.Certificate
+}
+
+type metadataKeyDescriptor struct {
+	Use          string   `xml:"use,attr"`
+	Certificates []string `xml:"KeyInfo>X509Data>X509Certificate"`
+}
+
+type metadataEndpoint struct {
+	Binding  string `xml:"Binding,attr"`
+	Location string `xml:"Location,attr"`
+}
+
+type metadataEntityDescriptor struct {
+	XMLName          xml.Name `xml:"urn:oasis:names:tc:SAML:2.0:metadata EntityDescriptor"`
+	EntityId         string   `xml:"entityID,attr"`
+	IDPSSODescriptor *struct {
+		KeyDescriptors      []metadataKeyDescriptor `xml:"urn:oasis:names:tc:SAML:2.0:metadata KeyDescriptor"`
+		SingleSignOnService []metadataEndpoint      `xml:"urn:oasis:names:tc:SAML:2.0:metadata SingleSignOnService"`
+	} `xml:"urn:oasis:names:tc:SAML:2.0:metadata IDPSSODescriptor"`
+}
+
+var whitespaceRegex = regexp.MustCompile(`\s+`)
+
+// ParseMetadata parses the provided identity provider EntityDescriptor metadata xml.
+func ParseMetadata(data []byte) (*IdentityProvider, error) {
+	descriptor := &metadataEntityDescriptor{}
+	if err := xml.Unmarshal(data, descriptor); err != nil {
+		return nil, fmt.Errorf("failed to parse the metadata: %w", err)
+	}
+
+	if descriptor.IDPSSODescriptor == nil {
+		return nil, errors.New("missing IDPSSODescriptor metadata element")
+	}
+
+	idp := &IdentityProvider{EntityId: descriptor.EntityId}
+
+	for _, endpoint := range descriptor.IDPSSODescriptor.SingleSignOnService {
+		if endpoint.Binding == BindingHTTPRedirect {
+			idp.SsoUrl = endpoint.Location
+			break
+		}
+	}
+
+	for _, key := range descriptor.IDPSSODescriptor.KeyDescriptors {
+		if key.Use != "" && key.Use != "signing" {
+			continue
+		}
+
+		for _, raw := range key.Certificates {
+			der, err := base64.StdEncoding.DecodeString(whitespaceRegex.ReplaceAllString(raw, ""))
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode the metadata certificate: %w", err)
+			}
+
+			cert, err := x509.ParseCertificate(der)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse the metadata certificate: %w", err)
+			}
+
+			idp.Certificates = append(idp.Certificates, cert)
+		}
+	}
+
+	if idp.EntityId == "" {
+		return nil, errors.New("missing identity provider entityID")
+	}
+
+	if idp.SsoUrl == "" {
+		return nil, errors.New("missing HTTP-Redirect SingleSignOnService")
+	}
+
+	if len(idp.Certificates) == 0 {
+		return nil, errors.New("missing identity provider signing certificate")
+	}
+
+	return idp, nil
+}
+
+// Assertion defines the verified subject of a SAML response assertion.
+type Assertion struct {
+	// Id is the unique assertion identifier.
+	Id string
+
+	NameId       string
+	NameIdFormat string
+	SessionIndex string
+
+	// ExpiresAt is the time after which the assertion can't be used.
+	ExpiresAt time.Time
+
+	// Attributes holds the assertion attribute values by their
+	// Name (and FriendlyName if it is set and different).
+	Attributes map[string][]string
+}
+
+// Attribute returns the first value of the specified assertion
+// attribute (or an empty string if the attribute is missing).
+func (a *Assertion) Attribute(name string) string {
+	if values := a.Attributes[name]; len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
+
+// Email returns the assertion subject email address, either from the
+// specified attribute or from the email formatted NameID.
+func (a *Assertion) Email(attribute string) string {
+	if email := a.Attribute(attribute); email != "" {
+		return email
+	}
+
+	if a.NameIdFormat == NameIdFormatEmail {
+		return a.NameId
+	}
+
+	return ""
+}
+
+// ServiceProvider defines a SAML service provider.
+type ServiceProvider struct {
+	// EntityId is the service provider identifier
+	// (used as Issuer and as expected assertions Audience).
+	EntityId string
+
+	// AcsUrl is the HTTP-POST assertion consumer service url.
+	AcsUrl string
+
+	IdentityProvider *IdentityProvider
+
+	// ClockSkew is the allowed time difference with the
+	// identity provider (default to [DefaultClockSkew]).
+	ClockSkew time.Duration
+}
+
+// Metadata returns the service provider EntityDescriptor metadata xml.
+func (sp *ServiceProvider) Metadata() ([]byte, error) {
+	doc := etree.NewDocument()
+	doc.CreateProcInst("xml", `version="1.0" encoding="UTF-8"`)
+
+	descriptor := doc.CreateElement("md:EntityDescriptor")
+	descriptor.CreateAttr("xmlns:md", NamespaceMetadata)
+	descriptor.CreateAttr("entityID", sp.EntityId)
+
+	spDescriptor := descriptor.CreateElement("md:SPSSODescriptor")
+	spDescriptor.CreateAttr("AuthnRequestsSigned", "false")
+	spDescriptor.CreateAttr("WantAssertionsSigned", "true")
+	spDescriptor.CreateAttr("protocolSupportEnumeration", NamespaceProtocol)
+
+	for _, format := range []string{NameIdFormatEmail, NameIdFormatUnspecified} {
+		spDescriptor.CreateElement("md:NameIDFormat").SetText(format)
+	}
+
+	acs := spDescriptor.CreateElement("md:AssertionConsumerService")
+	acs.CreateAttr("Binding", BindingHTTPPost)
+	acs.CreateAttr("Location", sp.AcsUrl)
+	acs.CreateAttr("index", "1")
+
+	doc.Indent(2)
+
+	return doc.WriteToBytes()
+}
+
+// AuthnRequestUrl returns the identity provider HTTP-Redirect
+// binding url for a new unsigned AuthnRequest.
+//
+// The optional relayState is returned unchanged by the identity
+// provider together with the SAML response.
+func (sp *ServiceProvider) AuthnRequestUrl(relayState string) (string, error) {
+	if sp.IdentityProvider == nil || sp.IdentityProvider.SsoUrl == "" {
+		return "", errors.New("missing identity provider SSO url")
+	}
+
+	doc := etree.NewDocument()
+
+	request := doc.CreateElement("samlp:AuthnRequest")
+	request.CreateAttr("xmlns:samlp", NamespaceProtocol)
+	request.CreateAttr("xmlns:saml", NamespaceAssertion)
+	request.CreateAttr("ID", "id-"+security.RandomString(32))
+	request.CreateAttr("Version", "2.0")
+	request.CreateAttr("IssueInstant", time.Now().UTC().Format(time.RFC3339))
+	request.CreateAttr("Destination", sp.IdentityProvider.SsoUrl)
+	request.CreateAttr("AssertionConsumerServiceURL", sp.AcsUrl)
+	request.CreateAttr("ProtocolBinding", BindingHTTPPost)
+
+	request.CreateElement("saml:Issuer").SetText(sp.EntityId)
+
+	policy := request.CreateElement("samlp:NameIDPolicy")
+	policy.CreateAttr("AllowCreate", "true")
+
+	raw, err := doc.WriteToBytes()
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+
+	w, err := flate.NewWriter(&buf, flate.BestCompression)
+	if err != nil {
+		return "", err
+	}
+	if _, err := w.Write(raw); err != nil {
+		return "", err
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+
+	query := url.Values{}
+	query.Set("SAMLRequest", base64.StdEncoding.EncodeToString(buf.Bytes()))
+	if relayState != "" {
+		query.Set("RelayState", relayState)
+	}
+
+	separator := "?"
+	if strings.Contains(sp.IdentityProvider.SsoUrl, "?") {
+		separator = "&"
+	}
+
+	return sp.IdentityProvider.SsoUrl + separator + query.Encode(), nil
+}
+
+// ParseResponse decodes and verifies the provided base64 encoded
+// HTTP-POST binding SAML response and returns its assertion.
+//
+// Either the response or its single assertion must be signed with
+// one of the identity provider certificates.
+//
+// Note that the InResponseTo attribute is not checked, aka.
+// identity provider initiated logins are also accepted.
+func (sp *ServiceProvider) ParseResponse(encodedResponse string) (*Assertion, error) {
+	if sp.IdentityProvider == nil {
+		return nil, errors.New("missing identity provider")
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(whitespaceRegex.ReplaceAllString(encodedResponse, ""))
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to decode the response", ErrInvalidResponse)
+	}
+
+	doc := etree.NewDocument()
+	if err := doc.ReadFromBytes(raw); err != nil {
+		return nil, fmt.Errorf("%w: failed to parse the response", ErrInvalidResponse)
+	}
+
+	response := doc.Root()
+	if !isElement(response, NamespaceProtocol, "Response") {
+		return nil, fmt.Errorf("%w: missing Response element", ErrInvalidResponse)
+	}
+
+	validationCtx := dsig.NewDefaultValidationContext(&dsig.MemoryX509CertificateStore{
+		Roots: sp.IdentityProvider.Certificates,
+	})
+	validationCtx.IdAttribute = "ID"
+
+	// use only the verified (aka. transformed) elements from now on
+	responseSigned := childElement(response, dsig.Namespace, "Signature") != nil
+	if responseSigned {
+		response, err = validationCtx.Validate(response)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid response signature: %v", ErrInvalidResponse, err)
+		}
+	}
+
+	if destination := response.SelectAttrValue("Destination", ""); destination != "" && destination != sp.AcsUrl {
+		return nil, fmt.Errorf("%w: unexpected destination %q", ErrInvalidResponse, destination)
+	}
+
+	statusCode := childElement(childElement(response, NamespaceProtocol, "Status"), NamespaceProtocol, "StatusCode")
+	if statusCode == nil || statusCode.SelectAttrValue("Value", "") != StatusSuccess {
+		return nil, fmt.Errorf("%w: unsuccessful response status", ErrInvalidResponse)
+	}
+
+	if childElement(response, NamespaceAssertion, "EncryptedAssertion") != nil {
+		return nil, fmt.Errorf("%w: encrypted assertions are not supported", ErrInvalidResponse)
+	}
+
+	assertions := childElements(response, NamespaceAssertion, "Assertion")
+	if len(assertions) != 1 {
+		return nil, fmt.Errorf("%w: expected exactly 1 assertion, got %d", ErrInvalidResponse, len(assertions))
+	}
+	assertion := assertions[0]
+
+	// the assertion is already covered by the verified response signature
+	// (its own signature can't be verified after the response transformation)
+	if !responseSigned {
+		if childElement(assertion, dsig.Namespace, "Signature") == nil {
+			return nil, fmt.Errorf("%w: missing signature", ErrInvalidResponse)
+		}
+
+		assertion, err = validationCtx.Validate(assertion)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid assertion signature: %v", ErrInvalidResponse, err)
+		}
+	}
+
+	return sp.parseAssertion(assertion)
+}
+
+func (sp *ServiceProvider) parseAssertion(el *etree.Element) (*Assertion, error) {
+	now := time.Now()
+
+	skew := sp.ClockSkew
+	if skew <= 0 {
+		skew = DefaultClockSkew
+	}
+
+	issuer := childElement(el, NamespaceAssertion, "Issuer")
+	if issuer == nil || strings.TrimSpace(issuer.Text()) != sp.IdentityProvider.EntityId {
+		return nil, fmt.Errorf("%w: unexpected assertion issuer", ErrInvalidResponse)
+	}
+
+	result := &Assertion{
+		Id:         el.SelectAttrValue("ID", ""),
+		Attributes: map[string][]string{},
+	}
+	if result.Id == "" {
+		return nil, fmt.Errorf("%w: missing assertion ID", ErrInvalidResponse)
+	}
+
+	// subject
+	// ---
+	subject := childElement(el, NamespaceAssertion, "Subject")
+
+	nameId := childElement(subject, NamespaceAssertion, "NameID")
+	if nameId == nil || strings.TrimSpace(nameId.Text()) == "" {
+		return nil, fmt.Errorf("%w: missing subject NameID", ErrInvalidResponse)
+	}
+	result.NameId = strings.TrimSpace(nameId.Text())
+	result.NameIdFormat = nameId.SelectAttrValue("Format", "")
+
+	var confirmed bool
+	for _, confirmation := range childElements(subject, NamespaceAssertion, "SubjectConfirmation") {
+		if confirmation.SelectAttrValue("Method", "") != subjectConfirmationBearer {
+			continue
+		}
+
+		data := childElement(confirmation, NamespaceAssertion, "SubjectConfirmationData")
+		if data == nil {
+			continue
+		}
+
+		if recipient := data.SelectAttrValue("Recipient", ""); recipient != "" && recipient != sp.AcsUrl {
+			continue
+		}
+
+		notOnOrAfter, err := parseTimeAttr(data, "NotOnOrAfter")
+		if err != nil || notOnOrAfter.IsZero() || !now.Before(notOnOrAfter.Add(skew)) {
+			continue
+		}
+
+		confirmed = true
+		result.ExpiresAt = notOnOrAfter.Add(skew)
+		break
+	}
+	if !confirmed {
+		return nil, fmt.Errorf("%w: missing valid bearer subject confirmation", ErrInvalidResponse)
+	}
+
+	// conditions
+	// ---
+	conditions := childElement(el, NamespaceAssertion, "Conditions")
+	if conditions == nil {
+		return nil, fmt.Errorf("%w: missing assertion conditions", ErrInvalidResponse)
+	}
+
+	notBefore, err := parseTimeAttr(conditions, "NotBefore")
+	if err != nil || (!notBefore.IsZero() && now.Add(skew).Before(notBefore)) {
+		return nil, fmt.Errorf("%w: the assertion is not valid yet", ErrInvalidResponse)
+	}
+
+	notOnOrAfter, err := parseTimeAttr(conditions, "NotOnOrAfter")
+	if err != nil || (!notOnOrAfter.IsZero() && !now.Before(notOnOrAfter.Add(skew))) {
+		return nil, fmt.Errorf("%w: the assertion has expired", ErrInvalidResponse)
+	}
+	if !notOnOrAfter.IsZero() && notOnOrAfter.Add(skew).Before(result.ExpiresAt) {
+		result.ExpiresAt = notOnOrAfter.Add(skew)
+	}
+
+	restrictions := childElements(conditions, NamespaceAssertion, "AudienceRestriction")
+	if len(restrictions) == 0 {
+		return nil, fmt.Errorf("%w: missing audience restriction", ErrInvalidResponse)
+	}
+	for _, restriction := range restrictions {
+		var allowed bool
+		for _, audience := range childElements(restriction, NamespaceAssertion, "Audience") {
+			if strings.TrimSpace(audience.Text()) == sp.EntityId {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return nil, fmt.Errorf("%w: the service provider is not in the assertion audience", ErrInvalidResponse)
+		}
+	}
+
+	// authn and attribute statements
+	// ---
+	if authn := childElement(el, NamespaceAssertion, "AuthnStatement"); authn != nil {
+		result.SessionIndex = authn.SelectAttrValue("SessionIndex", "")
+	}
+
+	for _, statement := range childElements(el, NamespaceAssertion, "AttributeStatement") {
+		for _, attr := range childElements(statement, NamespaceAssertion, "Attribute") {
+			values := []string{}
+			for _, value := range childElements(attr, NamespaceAssertion, "AttributeValue") {
+				values = append(values, strings.TrimSpace(value.Text()))
+			}
+
+			name := attr.SelectAttrValue("Name", "")
+			if name != "" {
+				result.Attributes[name] = append(result.Attributes[name], values...)
+			}
+
+			friendlyName := attr.SelectAttrValue("FriendlyName", "")
+			if friendlyName != "" && friendlyName != name {
+				result.Attributes[friendlyName] = append(result.Attributes[friendlyName], values...)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// -------------------------------------------------------------------
+
+func isElement(el *etree.Element, namespace string, tag string) bool {
+	return el != nil && el.Tag == tag && el.NamespaceURI() == namespace
+}
+
+func childElements(el *etree.Element, namespace string, tag string) []*etree.Element {
+	result := []*etree.Element{}
+
+	if el == nil {
+		return result
+	}
+
+	for _, child := range el.ChildElements() {
+		if isElement(child, namespace, tag) {
+			result = append(result, child)
+		}
+	}
+
+	return result
+}
+
+func childElement(el *etree.Element, namespace string, tag string) *etree.Element {
+	if children := childElements(el, namespace, tag); len(children) > 0 {
+		return children[0]
+	}
+
+	return nil
+}
+
+func parseTimeAttr(el *etree.Element, name string) (time.Time, error) {
+	value := el.SelectAttrValue(name, "")
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	return time.Parse(time.RFC3339, value)
+}
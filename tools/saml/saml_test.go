@@ -0,0 +1,416 @@
+package saml_test
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beevik/etree"
+	"github.com/pocketbase/pocketbase/tools/saml"
+	dsig "github.com/russellhaering/goxmldsig"
+)
+
+const (
+	testIdpEntityId = "https://idp.example.com/metadata"
+	testSsoUrl      = "https://idp.example.com/sso"
+	testSpEntityId  = "https://sp.example.com/metadata"
+	testAcsUrl      = "https://sp.example.com/acs"
+)
+
+type testResponseOptions struct {
+	signResponse  bool
+	signAssertion bool
+	keyStore      dsig.X509KeyStore
+	status        string
+	issuer        string
+	audience      string
+	recipient     string
+	notOnOrAfter  time.Time
+	assertions    int
+}
+
+func newTestKeyStore(t *testing.T) (dsig.X509KeyStore, *x509.Certificate) {
+	ks := dsig.RandomKeyStoreForTest()
+
+	_, der, err := ks.GetKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return ks, cert
+}
+
+func newTestServiceProvider(cert *x509.Certificate) *saml.ServiceProvider {
+	return &saml.ServiceProvider{
+		EntityId: testSpEntityId,
+		AcsUrl:   testAcsUrl,
+		IdentityProvider: &saml.IdentityProvider{
+			EntityId:     testIdpEntityId,
+			SsoUrl:       testSsoUrl,
+			Certificates: []*x509.Certificate{cert},
+		},
+	}
+}
+
+func newTestAssertion(id string, opts testResponseOptions) *etree.Element {
+	assertion := etree.NewElement("saml:Assertion")
+	assertion.CreateAttr("xmlns:saml", saml.NamespaceAssertion)
+	assertion.CreateAttr("ID", id)
+	assertion.CreateAttr("Version", "2.0")
+
+	assertion.CreateElement("saml:Issuer").SetText(opts.issuer)
+
+	subject := assertion.CreateElement("saml:Subject")
+	nameId := subject.CreateElement("saml:NameID")
+	nameId.CreateAttr("Format", saml.NameIdFormatUnspecified)
+	nameId.SetText("test_name_id")
+	confirmation := subject.CreateElement("saml:SubjectConfirmation")
+	confirmation.CreateAttr("Method", "urn:oasis:names:tc:SAML:2.0:cm:bearer")
+	confirmationData := confirmation.CreateElement("saml:SubjectConfirmationData")
+	confirmationData.CreateAttr("Recipient", opts.recipient)
+	confirmationData.CreateAttr("NotOnOrAfter", opts.notOnOrAfter.UTC().Format(time.RFC3339))
+
+	conditions := assertion.CreateElement("saml:Conditions")
+	conditions.CreateAttr("NotBefore", time.Now().Add(-time.Minute).UTC().Format(time.RFC3339))
+	conditions.CreateAttr("NotOnOrAfter", opts.notOnOrAfter.UTC().Format(time.RFC3339))
+	conditions.CreateElement("saml:AudienceRestriction").CreateElement("saml:Audience").SetText(opts.audience)
+
+	authn := assertion.CreateElement("saml:AuthnStatement")
+	authn.CreateAttr("SessionIndex", "test_session")
+
+	statement := assertion.CreateElement("saml:AttributeStatement")
+	emailAttr := statement.CreateElement("saml:Attribute")
+	emailAttr.CreateAttr("Name", "urn:oid:0.9.2342.19200300.100.1.3")
+	emailAttr.CreateAttr("FriendlyName", "email")
+	emailAttr.CreateElement("saml:AttributeValue").SetText("test@example.com")
+	groupsAttr := statement.CreateElement("saml:Attribute")
+	groupsAttr.CreateAttr("Name", "groups")
+	groupsAttr.CreateElement("saml:AttributeValue").SetText("a")
+	groupsAttr.CreateElement("saml:AttributeValue").SetText("b")
+
+	return assertion
+}
+
+func newTestResponse(t *testing.T, opts testResponseOptions) string {
+	signingCtx := dsig.NewDefaultSigningContext(opts.keyStore)
+
+	if opts.status == "" {
+		opts.status = saml.StatusSuccess
+	}
+	if opts.issuer == "" {
+		opts.issuer = testIdpEntityId
+	}
+	if opts.audience == "" {
+		opts.audience = testSpEntityId
+	}
+	if opts.recipient == "" {
+		opts.recipient = testAcsUrl
+	}
+	if opts.notOnOrAfter.IsZero() {
+		opts.notOnOrAfter = time.Now().Add(5 * time.Minute)
+	}
+	if opts.assertions == 0 {
+		opts.assertions = 1
+	}
+
+	response := etree.NewElement("samlp:Response")
+	response.CreateAttr("xmlns:samlp", saml.NamespaceProtocol)
+	response.CreateAttr("xmlns:saml", saml.NamespaceAssertion)
+	response.CreateAttr("ID", "test_response")
+	response.CreateAttr("Version", "2.0")
+	response.CreateAttr("Destination", testAcsUrl)
+
+	response.CreateElement("saml:Issuer").SetText(opts.issuer)
+	response.CreateElement("samlp:Status").CreateElement("samlp:StatusCode").CreateAttr("Value", opts.status)
+
+	for i := 0; i < opts.assertions; i++ {
+		assertion := newTestAssertion("test_assertion_"+string(rune('a'+i)), opts)
+
+		if opts.signAssertion {
+			signed, err := signingCtx.SignEnveloped(assertion)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertion = signed
+		}
+
+		response.AddChild(assertion)
+	}
+
+	if opts.signResponse {
+		signed, err := signingCtx.SignEnveloped(response)
+		if err != nil {
+			t.Fatal(err)
+		}
+		response = signed
+	}
+
+	doc := etree.NewDocument()
+	doc.SetRoot(response)
+
+	raw, err := doc.WriteToString()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return raw
+}
+
+func encode(raw string) string {
+	return base64.StdEncoding.EncodeToString([]byte(raw))
+}
+
+func TestParseMetadata(t *testing.T) {
+	_, cert := newTestKeyStore(t)
+	encodedCert := base64.StdEncoding.EncodeToString(cert.Raw)
+
+	metadata := func(sso string, keyUse string, certData string) string {
+		return `<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" xmlns:ds="http://www.w3.org/2000/09/xmldsig#" entityID="` + testIdpEntityId + `">` +
+			`<md:IDPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">` +
+			`<md:KeyDescriptor use="` + keyUse + `"><ds:KeyInfo><ds:X509Data><ds:X509Certificate>` + certData + `</ds:X509Certificate></ds:X509Data></ds:KeyInfo></md:KeyDescriptor>` +
+			sso +
+			`</md:IDPSSODescriptor></md:EntityDescriptor>`
+	}
+
+	redirectSso := `<md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST" Location="https://idp.example.com/post"/>` +
+		`<md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect" Location="` + testSsoUrl + `"/>`
+
+	scenarios := []struct {
+		name        string
+		metadata    string
+		expectError bool
+	}{
+		{"invalid xml", "<invalid", true},
+		{"non metadata xml", "<test></test>", true},
+		{"missing redirect sso", metadata(`<md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST" Location="https://idp.example.com/post"/>`, "signing", encodedCert), true},
+		{"missing signing certificate", metadata(redirectSso, "encryption", encodedCert), true},
+		{"invalid certificate", metadata(redirectSso, "signing", "aW52YWxpZA=="), true},
+		{"valid", metadata(redirectSso, "signing", encodedCert), false},
+		{"valid with certificate without use", metadata(redirectSso, "", "\n  "+encodedCert[:20]+"\n  "+encodedCert[20:]+"\n"), false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			idp, err := saml.ParseMetadata([]byte(s.metadata))
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if hasErr {
+				return
+			}
+
+			if idp.EntityId != testIdpEntityId {
+				t.Fatalf("Expected entity id %q, got %q", testIdpEntityId, idp.EntityId)
+			}
+
+			if idp.SsoUrl != testSsoUrl {
+				t.Fatalf("Expected sso url %q, got %q", testSsoUrl, idp.SsoUrl)
+			}
+
+			if len(idp.Certificates) != 1 || !idp.Certificates[0].Equal(cert) {
+				t.Fatalf("Expected the metadata certificate, got %v", idp.Certificates)
+			}
+		})
+	}
+}
+
+func TestServiceProviderMetadata(t *testing.T) {
+	_, cert := newTestKeyStore(t)
+
+	raw, err := newTestServiceProvider(cert).Metadata()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectations := []string{
+		`entityID="` + testSpEntityId + `"`,
+		`<md:SPSSODescriptor AuthnRequestsSigned="false" WantAssertionsSigned="true"`,
+		`Binding="` + saml.BindingHTTPPost + `" Location="` + testAcsUrl + `"`,
+	}
+
+	for _, expected := range expectations {
+		if !strings.Contains(string(raw), expected) {
+			t.Fatalf("Missing %q in\n%s", expected, raw)
+		}
+	}
+}
+
+func TestServiceProviderAuthnRequestUrl(t *testing.T) {
+	_, cert := newTestKeyStore(t)
+
+	sp := newTestServiceProvider(cert)
+
+	rawUrl, err := sp.AuthnRequestUrl("test_state")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(rawUrl, testSsoUrl+"?") {
+		t.Fatalf("Expected %q url prefix, got %q", testSsoUrl, rawUrl)
+	}
+
+	parsed, err := url.Parse(rawUrl)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if state := parsed.Query().Get("RelayState"); state != "test_state" {
+		t.Fatalf("Expected RelayState %q, got %q", "test_state", state)
+	}
+
+	compressed, err := base64.StdEncoding.DecodeString(parsed.Query().Get("SAMLRequest"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	raw, err := io.ReadAll(flate.NewReader(bytes.NewReader(compressed)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectations := []string{
+		`<samlp:AuthnRequest`,
+		`Destination="` + testSsoUrl + `"`,
+		`AssertionConsumerServiceURL="` + testAcsUrl + `"`,
+		`<saml:Issuer>` + testSpEntityId + `</saml:Issuer>`,
+	}
+
+	for _, expected := range expectations {
+		if !strings.Contains(string(raw), expected) {
+			t.Fatalf("Missing %q in\n%s", expected, raw)
+		}
+	}
+
+	// missing identity provider sso url
+	sp.IdentityProvider.SsoUrl = ""
+	if _, err := sp.AuthnRequestUrl(""); err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+}
+
+func TestServiceProviderParseResponse(t *testing.T) {
+	ks, cert := newTestKeyStore(t)
+	otherKs, _ := newTestKeyStore(t)
+
+	tampered := strings.Replace(
+		newTestResponse(t, testResponseOptions{keyStore: ks, signAssertion: true}),
+		"test_name_id",
+		"other_name_id",
+		1,
+	)
+
+	// move the signed assertion inside an unsigned wrapper
+	// and inject a forged one in its place
+	wrapped := strings.Replace(
+		newTestResponse(t, testResponseOptions{keyStore: ks, signAssertion: true}),
+		"<saml:Assertion ",
+		`<saml:Assertion xmlns:saml="`+saml.NamespaceAssertion+`" ID="forged"><saml:Issuer>`+testIdpEntityId+`</saml:Issuer></saml:Assertion><samlp:Extensions><saml:Assertion `,
+		1,
+	)
+	wrapped = strings.Replace(wrapped, "</saml:Assertion></samlp:Response>", "</saml:Assertion></samlp:Extensions></samlp:Response>", 1)
+
+	scenarios := []struct {
+		name        string
+		response    string
+		expectError bool
+	}{
+		{"invalid base64", "invalid!", true},
+		{"invalid xml", encode("<invalid"), true},
+		{"non response root", encode(`<test></test>`), true},
+		{"unsigned", encode(newTestResponse(t, testResponseOptions{keyStore: ks})), true},
+		{"signed with unknown key", encode(newTestResponse(t, testResponseOptions{keyStore: otherKs, signAssertion: true})), true},
+		{"tampered signed assertion", encode(tampered), true},
+		{"signature wrapping", encode(wrapped), true},
+		{"unsuccessful status", encode(newTestResponse(t, testResponseOptions{keyStore: ks, signResponse: true, status: "urn:oasis:names:tc:SAML:2.0:status:Requester"})), true},
+		{"unexpected issuer", encode(newTestResponse(t, testResponseOptions{keyStore: ks, signAssertion: true, issuer: "https://other.example.com"})), true},
+		{"unexpected audience", encode(newTestResponse(t, testResponseOptions{keyStore: ks, signAssertion: true, audience: "https://other.example.com"})), true},
+		{"unexpected recipient", encode(newTestResponse(t, testResponseOptions{keyStore: ks, signAssertion: true, recipient: "https://other.example.com/acs"})), true},
+		{"expired", encode(newTestResponse(t, testResponseOptions{keyStore: ks, signAssertion: true, notOnOrAfter: time.Now().Add(-10 * time.Minute)})), true},
+		{"multiple assertions", encode(newTestResponse(t, testResponseOptions{keyStore: ks, signResponse: true, assertions: 2})), true},
+		{"signed assertion", encode(newTestResponse(t, testResponseOptions{keyStore: ks, signAssertion: true})), false},
+		{"signed response", encode(newTestResponse(t, testResponseOptions{keyStore: ks, signResponse: true})), false},
+		{"signed response and assertion", encode(newTestResponse(t, testResponseOptions{keyStore: ks, signResponse: true, signAssertion: true})), false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			assertion, err := newTestServiceProvider(cert).ParseResponse(s.response)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if hasErr {
+				if !errors.Is(err, saml.ErrInvalidResponse) {
+					t.Fatalf("Expected ErrInvalidResponse, got %v", err)
+				}
+				return
+			}
+
+			if assertion.Id != "test_assertion_a" {
+				t.Fatalf("Expected assertion id %q, got %q", "test_assertion_a", assertion.Id)
+			}
+
+			if assertion.NameId != "test_name_id" || assertion.SessionIndex != "test_session" {
+				t.Fatalf("Unexpected assertion subject %q (session %q)", assertion.NameId, assertion.SessionIndex)
+			}
+
+			if email := assertion.Email("email"); email != "test@example.com" {
+				t.Fatalf("Expected email %q, got %q", "test@example.com", email)
+			}
+
+			if email := assertion.Attribute("urn:oid:0.9.2342.19200300.100.1.3"); email != "test@example.com" {
+				t.Fatalf("Expected the email to be accessible also by the attribute Name, got %q", email)
+			}
+
+			if groups := assertion.Attributes["groups"]; len(groups) != 2 || groups[0] != "a" || groups[1] != "b" {
+				t.Fatalf("Expected groups [a b], got %v", groups)
+			}
+
+			if assertion.ExpiresAt.Before(time.Now()) {
+				t.Fatalf("Expected future ExpiresAt, got %v", assertion.ExpiresAt)
+			}
+		})
+	}
+}
+
+func TestAssertionEmail(t *testing.T) {
+	scenarios := []struct {
+		name      string
+		assertion *saml.Assertion
+		expected  string
+	}{
+		{"empty", &saml.Assertion{}, ""},
+		{"non email NameID", &saml.Assertion{NameId: "test", NameIdFormat: saml.NameIdFormatUnspecified}, ""},
+		{"email NameID", &saml.Assertion{NameId: "test@example.com", NameIdFormat: saml.NameIdFormatEmail}, "test@example.com"},
+		{"email attribute", &saml.Assertion{
+			NameId:       "test@example.com",
+			NameIdFormat: saml.NameIdFormatEmail,
+			Attributes:   map[string][]string{"email": {"attr@example.com"}},
+		}, "attr@example.com"},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			if email := s.assertion.Email("email"); email != s.expected {
+				t.Fatalf("Expected %q, got %q", s.expected, email)
+			}
+		})
+	}
+}
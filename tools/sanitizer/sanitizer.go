@@ -0,0 +1,291 @@
+// Package sanitizer implements a simple allowlist based HTML sanitizer.
+package sanitizer
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// DefaultAllowedTags is the list of the HTML elements that are allowed
+// when Policy.AllowedTags is not set (roughly the output of the admin UI editor).
+var DefaultAllowedTags = []string{
+	"a", "abbr", "b", "blockquote", "br", "caption", "cite", "code", "col", "colgroup",
+	"dd", "del", "div", "dl", "dt", "em", "figcaption", "figure", "h1", "h2", "h3",
+	"h4", "h5", "h6", "hr", "i", "img", "ins", "kbd", "li", "mark", "ol", "p", "pre",
+	"q", "s", "small", "span", "strong", "sub", "sup", "table", "tbody", "td", "tfoot",
+	"th", "thead", "tr", "u", "ul",
+}
+
+// DefaultAllowedAttributes is the list of the element attributes that are
+// allowed when Policy.AllowedAttributes is not set.
+var DefaultAllowedAttributes = []string{
+	"class", "style", "title", "dir", "lang",
+	"a:href", "a:target", "a:rel",
+	"img:src", "img:alt", "img:width", "img:height",
+	"td:colspan", "td:rowspan", "th:colspan", "th:rowspan", "th:scope",
+	"ol:start", "ol:type", "col:span", "colgroup:span",
+	"iframe:width", "iframe:height", "iframe:allow", "iframe:allowfullscreen",
+}
+
+// ForbiddenTags is the list of the HTML elements that are always
+// removed (together with their content) regardless of the policy.
+var ForbiddenTags = []string{
+	"script", "style", "object", "embed", "applet", "base", "meta", "link",
+	"frame", "frameset", "noscript", "template", "svg", "math", "form",
+}
+
+// AllowedLinkRels is the list of the supported Policy.LinkRel values.
+var AllowedLinkRels = []string{"noopener", "noreferrer", "nofollow", "ugc", "sponsored", "external"}
+
+// urlAttributes is the list of the attributes that hold an url.
+var urlAttributes = []string{"href", "src", "cite", "poster", "action", "formaction", "background", "longdesc"}
+
+// safeSchemes is the list of the allowed url schemes (urls without a scheme are also allowed).
+var safeSchemes = []string{"http", "https", "mailto", "tel"}
+
+// Policy defines the sanitization rules of a single HTML document or fragment.
+type Policy struct {
+	// AllowedTags is the list of the allowed HTML elements
+	// (fallbacks to DefaultAllowedTags if empty).
+	//
+	// The content of the not allowed elements is preserved
+	// (except for the ForbiddenTags and the not allowed iframes).
+	AllowedTags []string
+
+	// AllowedAttributes is the list of the allowed element attributes
+	// (fallbacks to DefaultAllowedAttributes if empty).
+	//
+	// An attribute could be allowed for all elements (eg. "title")
+	// or only for a specific element using the "tag:attr" format (eg. "a:href").
+	//
+	// Event handler attributes (eg. "onclick") are always removed.
+	AllowedAttributes []string
+
+	// LinkRel is an optional list of rel values that are
+	// enforced on all links (eg. "noopener", "nofollow").
+	LinkRel []string
+
+	// IframeHosts is the list of the hosts (eg. "www.youtube.com")
+	// whose iframes are allowed. All other iframes are removed.
+	IframeHosts []string
+}
+
+// Sanitize returns a sanitized copy of the provided HTML string.
+func (p Policy) Sanitize(input string) string {
+	if input == "" {
+		return ""
+	}
+
+	allowedTags := p.AllowedTags
+	if len(allowedTags) == 0 {
+		allowedTags = DefaultAllowedTags
+	}
+
+	allowedAttrs := p.AllowedAttributes
+	if len(allowedAttrs) == 0 {
+		allowedAttrs = DefaultAllowedAttributes
+	}
+
+	var result strings.Builder
+
+	tokenizer := html.NewTokenizer(strings.NewReader(input))
+
+	// stack of the currently open (and written) elements
+	openTags := []string{}
+
+	// name and depth of the currently skipped element (if any)
+	skipTag := ""
+	skipDepth := 0
+
+	for {
+		tt := tokenizer.Next()
+		if tt == html.ErrorToken {
+			break // EOF or malformed input
+		}
+
+		token := tokenizer.Token()
+
+		if skipTag != "" {
+			if token.Data == skipTag {
+				switch tt {
+				case html.StartTagToken:
+					skipDepth++
+				case html.EndTagToken:
+					skipDepth--
+				}
+				if skipDepth == 0 {
+					skipTag = ""
+				}
+			}
+			continue
+		}
+
+		switch tt {
+		case html.TextToken:
+			result.WriteString(html.EscapeString(token.Data))
+		case html.StartTagToken, html.SelfClosingTagToken:
+			isIframe := token.Data == "iframe"
+
+			if slices.Contains(ForbiddenTags, token.Data) || (isIframe && !p.isAllowedIframe(token)) {
+				if tt == html.StartTagToken && !isVoidElement(token.Data) {
+					skipTag = token.Data
+					skipDepth = 1
+				}
+				continue
+			}
+
+			if !isIframe && !slices.Contains(allowedTags, token.Data) {
+				continue // strip only the tag
+			}
+
+			token.Attr = p.sanitizeAttributes(token.Data, token.Attr, allowedAttrs)
+
+			token.Type = html.StartTagToken
+			result.WriteString(token.String())
+
+			if !isVoidElement(token.Data) {
+				if tt == html.StartTagToken {
+					openTags = append(openTags, token.Data)
+				} else {
+					result.WriteString("</" + token.Data + ">")
+				}
+			}
+		case html.EndTagToken:
+			index := slices.Index(openTags, token.Data)
+			for i := len(openTags) - 1; index >= 0 && i >= index; i-- {
+				result.WriteString("</" + openTags[i] + ">")
+			}
+			if index >= 0 {
+				openTags = openTags[:index]
+			}
+		}
+		// comments and doctypes are always removed
+	}
+
+	// close the remaining open elements
+	for i := len(openTags) - 1; i >= 0; i-- {
+		result.WriteString("</" + openTags[i] + ">")
+	}
+
+	return result.String()
+}
+
+func (p Policy) sanitizeAttributes(tag string, attrs []html.Attribute, allowedAttrs []string) []html.Attribute {
+	result := make([]html.Attribute, 0, len(attrs))
+
+	for _, attr := range attrs {
+		key := strings.ToLower(attr.Key)
+
+		if attr.Namespace != "" || strings.HasPrefix(key, "on") {
+			continue
+		}
+
+		if tag == "iframe" && key == "src" {
+			result = append(result, attr) // already checked
+			continue
+		}
+
+		if !slices.Contains(allowedAttrs, key) && !slices.Contains(allowedAttrs, tag+":"+key) {
+			continue
+		}
+
+		if slices.Contains(urlAttributes, key) && !isSafeUrl(attr.Val) {
+			continue
+		}
+
+		if key == "style" && !isSafeStyle(attr.Val) {
+			continue
+		}
+
+		if tag == "a" && key == "rel" && len(p.LinkRel) > 0 {
+			continue // replaced below
+		}
+
+		result = append(result, attr)
+	}
+
+	if tag == "a" && len(p.LinkRel) > 0 {
+		rels := []string{}
+		for _, attr := range attrs {
+			if strings.EqualFold(attr.Key, "rel") && (slices.Contains(allowedAttrs, "rel") || slices.Contains(allowedAttrs, "a:rel")) {
+				rels = append(rels, strings.Fields(strings.ToLower(attr.Val))...)
+			}
+		}
+		for _, rel := range p.LinkRel {
+			if !slices.Contains(rels, rel) {
+				rels = append(rels, rel)
+			}
+		}
+		result = append(result, html.Attribute{Key: "rel", Val: strings.Join(rels, " ")})
+	}
+
+	return result
+}
+
+func (p Policy) isAllowedIframe(token html.Token) bool {
+	if len(p.IframeHosts) == 0 {
+		return false
+	}
+
+	for _, attr := range token.Attr {
+		if strings.ToLower(attr.Key) != "src" {
+			continue
+		}
+
+		u, err := url.Parse(strings.TrimSpace(attr.Val))
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") {
+			return false
+		}
+
+		return slices.Contains(p.IframeHosts, strings.ToLower(u.Hostname()))
+	}
+
+	return false
+}
+
+func isSafeUrl(rawUrl string) bool {
+	// remove whitespaces and control characters that browsers ignore (eg. "java\tscript:")
+	cleaned := strings.Map(func(r rune) rune {
+		if r <= ' ' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, rawUrl)
+
+	u, err := url.Parse(cleaned)
+	if err != nil {
+		return false
+	}
+
+	if u.Scheme == "" {
+		// prevent "javascript&colon;" like obfuscations
+		return !strings.Contains(strings.ToLower(cleaned), "script:")
+	}
+
+	return slices.Contains(safeSchemes, strings.ToLower(u.Scheme))
+}
+
+func isSafeStyle(style string) bool {
+	lower := strings.ToLower(style)
+
+	for _, s := range []string{"url(", "expression(", "javascript:", "@import", "behavior:"} {
+		if strings.Contains(lower, s) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isVoidElement(tag string) bool {
+	switch tag {
+	case "area", "base", "br", "col", "embed", "hr", "img", "input", "keygen",
+		"link", "meta", "param", "source", "track", "wbr":
+		return true
+	}
+
+	return false
+}
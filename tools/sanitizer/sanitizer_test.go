@@ -0,0 +1,131 @@
+package sanitizer_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/sanitizer"
+)
+
+func TestPolicySanitize(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		policy   sanitizer.Policy
+		input    string
+		expected string
+	}{
+		{
+			"empty",
+			sanitizer.Policy{},
+			"",
+			"",
+		},
+		{
+			"plain text",
+			sanitizer.Policy{},
+			"a < b & c",
+			"a &lt; b &amp; c",
+		},
+		{
+			"allowed markup",
+			sanitizer.Policy{},
+			`<p class="test">Hello <strong>world</strong><br/><img src="/a.png" alt="a"></p>`,
+			`<p class="test">Hello <strong>world</strong><br><img src="/a.png" alt="a"></p>`,
+		},
+		{
+			"forbidden tags with their content",
+			sanitizer.Policy{},
+			`<p>a<script>alert(1)</script><style>p{}</style>b</p>`,
+			`<p>ab</p>`,
+		},
+		{
+			"not allowed tags without their content",
+			sanitizer.Policy{},
+			`<section><p>a</p><custom>b</custom></section>`,
+			`<p>a</p>b`,
+		},
+		{
+			"event handlers and unsafe urls",
+			sanitizer.Policy{},
+			`<a href="javascript:alert(1)" onclick="alert(1)">a</a><a href=" java	script:alert(1)">b</a><img src="data:image/png;base64,abc">`,
+			`<a>a</a><a>b</a><img>`,
+		},
+		{
+			"unsafe style",
+			sanitizer.Policy{},
+			`<p style="background: url(javascript:alert(1))">a</p><p style="text-align: center">b</p>`,
+			`<p>a</p><p style="text-align: center">b</p>`,
+		},
+		{
+			"comments and unclosed elements",
+			sanitizer.Policy{},
+			`<!-- test --><p><em>a`,
+			`<p><em>a</em></p>`,
+		},
+		{
+			"mismatched end tags",
+			sanitizer.Policy{},
+			`<p><em>a</p></em>b`,
+			`<p><em>a</em></p>b`,
+		},
+		{
+			"custom allowed tags and attributes",
+			sanitizer.Policy{
+				AllowedTags:       []string{"p", "a"},
+				AllowedAttributes: []string{"a:href"},
+			},
+			`<p class="test"><a href="https://example.com" title="a">a</a><strong>b</strong></p>`,
+			`<p><a href="https://example.com">a</a>b</p>`,
+		},
+		{
+			"link rel policy",
+			sanitizer.Policy{
+				LinkRel: []string{"noopener", "nofollow"},
+			},
+			`<a href="https://example.com" rel="external noopener">a</a>`,
+			`<a href="https://example.com" rel="external noopener nofollow">a</a>`,
+		},
+		{
+			"iframes without allowlist",
+			sanitizer.Policy{},
+			`<iframe src="https://www.youtube.com/embed/abc">a</iframe><p>b</p>`,
+			`<p>b</p>`,
+		},
+		{
+			"iframes with allowlist",
+			sanitizer.Policy{
+				IframeHosts: []string{"www.youtube.com"},
+			},
+			`<iframe src="https://www.youtube.com/embed/abc" width="100" onload="alert(1)"></iframe><iframe src="https://example.com"></iframe>`,
+			`<iframe src="https://www.youtube.com/embed/abc" width="100"></iframe>`,
+		},
+		{
+			"escaped attribute values",
+			sanitizer.Policy{},
+			`<p title="a&quot;b">c</p>`,
+			`<p title="a&#34;b">c</p>`,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result := s.policy.Sanitize(s.input)
+
+			if result != s.expected {
+				t.Fatalf("Expected\n%s\ngot\n%s", s.expected, result)
+			}
+		})
+	}
+}
+
+func TestPolicySanitizeIdempotent(t *testing.T) {
+	policy := sanitizer.Policy{LinkRel: []string{"noopener"}}
+
+	input := `<p onclick="x"><a href="/a" rel="nofollow">a<b>b</p><script>x</script>`
+
+	first := policy.Sanitize(input)
+	second := policy.Sanitize(first)
+
+	if first != second {
+		t.Fatalf("Expected the sanitization to be idempotent, got\n%s\nvs\n%s", first, second)
+	}
+}
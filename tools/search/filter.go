@@ -19,6 +19,15 @@ import (
 // The filter string can also contain dbx placeholder parameters (eg. "title = {:name}"),
 // that will be safely replaced and properly quoted inplace with the placeholderReplacements values.
 //
+// The "@name(arg)" function macros are expanded to "(@name.arg = true)"
+// expressions, leaving the "@name.arg" identifier resolving to the field resolver.
+//
+// The "operand in (subquery)" expressions are resolved with the field resolver
+// [SubqueryResolver] implementation (if any), eg. "id in (@collection.example.user = 123)".
+//
+// The "now()", "dateAdd()", "dateSub()", "dateTrunc()" and "dateDiff()" date functions
+// could be used as expression operands, eg. "created >= dateSub(now(), '7 days')".
+//
 // Example:
 //
 //	var filter FilterData = "id = null || (name = 'test' && status = true) || (total >= {:min} && total <= {:max})"
@@ -34,6 +43,9 @@ var parsedFilterData = store.New(make(map[string][]fexpr.ExprGroup, 50))
 //
 // The filter string can also contain dbx placeholder parameters (eg. "title = {:name}"),
 // that will be safely replaced and properly quoted inplace with the placeholderReplacements values.
+//
+// If the strict mode is enabled (see [SetStrictMode]), the filter
+// is also checked against the strict mode rules.
 func (f FilterData) BuildExpr(
 	fieldResolver FieldResolver,
 	placeholderReplacements ...dbx.Params,
@@ -64,9 +76,93 @@ func (f FilterData) BuildExpr(
 		}
 	}
 
+	// expand the "@name(arg)" function macros (if any)
+	raw = expandFunctionMacros(raw)
+
+	parsedRaw, subqueries := extractSubqueries(raw)
+
+	parsedRaw, dateFunctions, err := extractDateFunctions(parsedRaw)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := parseFilter(parsedRaw)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := enforceStrictMode(raw, data, dateFunctions); err != nil {
+		return nil, err
+	}
+
+	if len(dateFunctions) > 0 {
+		fieldResolver = &dateFunctionsResolver{FieldResolver: fieldResolver, functions: dateFunctions}
+	}
+
+	return buildParsedFilterExpr(data, fieldResolver, subqueries)
+}
+
+// Parse parses the current filter data into expression groups.
+//
+// The function macros are expanded, the "operand in (subquery)"
+// expressions are replaced with "operand = @subquery.N" expressions
+// and the date function calls are replaced with "@datefunc.N" identifiers.
+func (f FilterData) Parse() ([]fexpr.ExprGroup, error) {
+	raw, _ := extractSubqueries(expandFunctionMacros(string(f)))
+
+	raw, _, err := extractDateFunctions(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseFilter(raw)
+}
+
+// Complexity returns the complexity score of the current filter data.
+//
+// The score is calculated as the sum of:
+//   - 1 for each expression (eg. "a = 1")
+//   - 1 for each nested expressions group (eg. "(a = 1 || b = 2)")
+//   - 1 for each relation/json path segment of an identifier operand (eg. "a.b.c" adds 2)
+//   - 1 for each "any-of" operator (eg. "?=", "?~")
+//   - 1 + the complexity of each "in (subquery)" filter
+//   - 1 for each date function call and for each path segment of its identifier arguments
+//
+// Placeholder params are not replaced and are scored as regular literals.
+func (f FilterData) Complexity() (int, error) {
+	raw, subqueries := extractSubqueries(expandFunctionMacros(string(f)))
+
+	raw, dateFunctions, err := extractDateFunctions(raw)
+	if err != nil {
+		return 0, err
+	}
+
+	data, err := parseFilter(raw)
+	if err != nil {
+		return 0, err
+	}
+
+	// note: the top level calls are already scored with their "@datefunc.N" identifier
+	total := groupsComplexity(data) + dateFunctionsComplexity(dateFunctions)
+
+	for _, subquery := range subqueries {
+		subTotal, err := FilterData(subquery).Complexity()
+		if err != nil {
+			return 0, err
+		}
+
+		total += subTotal
+	}
+
+	return total, nil
+}
+
+// parseFilter parses the raw filter string and caches the result.
+func parseFilter(raw string) ([]fexpr.ExprGroup, error) {
 	if parsedFilterData.Has(raw) {
-		return buildParsedFilterExpr(parsedFilterData.Get(raw), fieldResolver)
+		return parsedFilterData.Get(raw), nil
 	}
+
 	data, err := fexpr.Parse(raw)
 	if err != nil {
 		// depending on the users demand we may allow empty expressions
@@ -78,13 +174,48 @@ func (f FilterData) BuildExpr(
 
 		return nil, err
 	}
+
 	// store in cache
 	// (the limit size is arbitrary and it is there to prevent the cache growing too big)
 	parsedFilterData.SetIfLessThanLimit(raw, data, 500)
-	return buildParsedFilterExpr(data, fieldResolver)
+
+	return data, nil
+}
+
+func groupsComplexity(data []fexpr.ExprGroup) int {
+	var total int
+
+	for _, group := range data {
+		switch item := group.Item.(type) {
+		case fexpr.Expr:
+			total += exprComplexity(item)
+		case fexpr.ExprGroup:
+			total += 1 + groupsComplexity([]fexpr.ExprGroup{item})
+		case []fexpr.ExprGroup:
+			total += 1 + groupsComplexity(item)
+		}
+	}
+
+	return total
 }
 
-func buildParsedFilterExpr(data []fexpr.ExprGroup, fieldResolver FieldResolver) (dbx.Expression, error) {
+func exprComplexity(expr fexpr.Expr) int {
+	total := 1
+
+	if isAnyMatchOp(expr.Op) {
+		total++
+	}
+
+	for _, token := range []fexpr.Token{expr.Left, expr.Right} {
+		if token.Type == fexpr.TokenIdentifier {
+			total += strings.Count(token.Literal, ".")
+		}
+	}
+
+	return total
+}
+
+func buildParsedFilterExpr(data []fexpr.ExprGroup, fieldResolver FieldResolver, subqueries []string) (dbx.Expression, error) {
 	if len(data) == 0 {
 		return nil, errors.New("empty filter expression")
 	}
@@ -97,11 +228,15 @@ func buildParsedFilterExpr(data []fexpr.ExprGroup, fieldResolver FieldResolver)
 
 		switch item := group.Item.(type) {
 		case fexpr.Expr:
-			expr, exprErr = resolveTokenizedExpr(item, fieldResolver)
+			if isSubqueryExpr(item) {
+				expr, exprErr = resolveSubqueryExpr(item, fieldResolver, subqueries)
+			} else {
+				expr, exprErr = resolveTokenizedExpr(item, fieldResolver)
+			}
 		case fexpr.ExprGroup:
-			expr, exprErr = buildParsedFilterExpr([]fexpr.ExprGroup{item}, fieldResolver)
+			expr, exprErr = buildParsedFilterExpr([]fexpr.ExprGroup{item}, fieldResolver, subqueries)
 		case []fexpr.ExprGroup:
-			expr, exprErr = buildParsedFilterExpr(item, fieldResolver)
+			expr, exprErr = buildParsedFilterExpr(item, fieldResolver, subqueries)
 		default:
 			exprErr = errors.New("unsupported expression item")
 		}
@@ -0,0 +1,82 @@
+package search
+
+import "strings"
+
+// FilterClause defines a single top level clause of a filter expression.
+type FilterClause struct {
+	// Join is the operator joining the clause with the previous one
+	// ("&&" or "||"; empty for the first clause).
+	Join string `json:"join"`
+
+	// Expr is the raw clause expression (eg. "title = 'test'").
+	Expr string `json:"expr"`
+}
+
+// Clauses splits the filter data into its top level clauses,
+// leaving the parenthesized groups and the quoted strings intact.
+//
+// Example:
+//
+//	search.FilterData("a = 1 && (b = 2 || c = 3)").Clauses()
+//	// [{"", "a = 1"}, {"&&", "(b = 2 || c = 3)"}]
+func (f FilterData) Clauses() []FilterClause {
+	raw := string(f)
+
+	var (
+		result  []FilterClause
+		current strings.Builder
+		join    string
+		quote   byte
+		depth   int
+	)
+
+	flush := func(nextJoin string) {
+		if expr := strings.TrimSpace(current.String()); expr != "" {
+			result = append(result, FilterClause{Join: join, Expr: expr})
+		}
+		current.Reset()
+		join = nextJoin
+	}
+
+	for i := 0; i < len(raw); i++ {
+		ch := raw[i]
+
+		if quote != 0 {
+			current.WriteByte(ch)
+			if ch == '\\' && i+1 < len(raw) {
+				i++
+				current.WriteByte(raw[i])
+			} else if ch == quote {
+				quote = 0
+			}
+			continue
+		}
+
+		switch {
+		case ch == '\'' || ch == '"':
+			quote = ch
+		case ch == '(':
+			depth++
+		case ch == ')':
+			depth--
+		case ch == '/' && strings.HasPrefix(raw[i:], "//"):
+			// skip line comments
+			if end := strings.IndexByte(raw[i:], '\n'); end >= 0 {
+				i += end
+			} else {
+				i = len(raw)
+			}
+			continue
+		case depth == 0 && (strings.HasPrefix(raw[i:], "&&") || strings.HasPrefix(raw[i:], "||")):
+			flush(raw[i : i+2])
+			i++
+			continue
+		}
+
+		current.WriteByte(ch)
+	}
+
+	flush("")
+
+	return result
+}
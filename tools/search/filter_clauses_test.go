@@ -0,0 +1,41 @@
+package search_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+func TestFilterDataClauses(t *testing.T) {
+	scenarios := []struct {
+		filter   search.FilterData
+		expected []search.FilterClause
+	}{
+		{"", nil},
+		{"   ", nil},
+		{"a = 1", []search.FilterClause{{"", "a = 1"}}},
+		{
+			"a = 1 && (b = 2 || c = 3) || d != ''",
+			[]search.FilterClause{{"", "a = 1"}, {"&&", "(b = 2 || c = 3)"}, {"||", "d != ''"}},
+		},
+		{
+			`a = "x && y" && b = 'it\'s || z'`,
+			[]search.FilterClause{{"", `a = "x && y"`}, {"&&", `b = 'it\'s || z'`}},
+		},
+		{
+			"a = 1 // comment && b = 2\n&& c = 3",
+			[]search.FilterClause{{"", "a = 1"}, {"&&", "c = 3"}},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(string(s.filter), func(t *testing.T) {
+			result := s.filter.Clauses()
+
+			if !reflect.DeepEqual(result, s.expected) {
+				t.Fatalf("Expected \n%v, \ngot \n%v", s.expected, result)
+			}
+		})
+	}
+}
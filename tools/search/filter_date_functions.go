@@ -0,0 +1,517 @@
+package search
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ganigeorgiev/fexpr"
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+// dateFunctionIdentifierPrefix is the prefix of the identifiers that
+// replace the date function calls before parsing.
+const dateFunctionIdentifierPrefix = "@datefunc."
+
+// dateSQLFormat is the strftime format of the stored datetime values
+// (see [types.DateTime.String()]).
+const dateSQLFormat = "%Y-%m-%d %H:%M:%fZ"
+
+// dateFunctionRegex matches the beginning of a date function call (eg. "dateAdd(").
+var dateFunctionRegex = regexp.MustCompile(`^(now|dateAdd|dateSub|dateTrunc|dateDiff)\s*\(`)
+
+// dateFunctionNumberRegex matches a number date function argument.
+var dateFunctionNumberRegex = regexp.MustCompile(`^[-+]?\d+(\.\d+)?$`)
+
+// dateFunctionIdentifierRegex matches an identifier date function argument.
+var dateFunctionIdentifierRegex = regexp.MustCompile(`^@?\w[\w.:]*$`)
+
+// dateIntervalRegex matches a single "N unit" interval part (eg. "-7 days").
+var dateIntervalRegex = regexp.MustCompile(`^([-+]?\d+(?:\.\d+)?)\s*(second|minute|hour|day|week|month|year)s?$`)
+
+// dateTruncFormats defines the strftime formats of the dateTrunc units.
+var dateTruncFormats = map[string]string{
+	"second": "%Y-%m-%d %H:%M:%S.000Z",
+	"minute": "%Y-%m-%d %H:%M:00.000Z",
+	"hour":   "%Y-%m-%d %H:00:00.000Z",
+	"day":    "%Y-%m-%d 00:00:00.000Z",
+	"week":   "%Y-%m-%d 00:00:00.000Z", // combined with the "weekday 1" modifier
+	"month":  "%Y-%m-01 00:00:00.000Z",
+	"year":   "%Y-01-01 00:00:00.000Z",
+}
+
+// dateDiffMultipliers defines the julian days multipliers of the dateDiff units.
+var dateDiffMultipliers = map[string]string{
+	"second": "86400.0",
+	"minute": "1440.0",
+	"hour":   "24.0",
+	"day":    "1.0",
+	"week":   "(1.0 / 7)",
+}
+
+// dateFunction defines a single parsed date function call.
+type dateFunction struct {
+	name string
+	args []*dateFunctionArg
+
+	// modifiers holds the sqlite date modifiers of the dateAdd and dateSub interval
+	modifiers []string
+}
+
+// dateFunctionArg defines a single date function argument
+// (either a plain token or a nested date function call).
+type dateFunctionArg struct {
+	token fexpr.Token
+	call  *dateFunction
+}
+
+// extractDateFunctions replaces the date function calls with
+// "@datefunc.N" identifiers so that they could be parsed as regular
+// expression operands and returns the parsed calls in the order of their N index.
+//
+// The supported functions are:
+//   - "now()" - the current datetime
+//   - "dateAdd(date, interval)" - adds the interval to the date (eg. "dateAdd(created, '1 day 2 hours')")
+//   - "dateSub(date, interval)" - subtracts the interval from the date (eg. "dateSub(now(), '7 days')")
+//   - "dateTrunc(unit, date)" - truncates the date to the start of the unit (eg. "dateTrunc('month', created)")
+//   - "dateDiff(unit, start, end)" - the (fractional) number of units between two dates (eg. "dateDiff('hour', created, now())")
+//
+// Quoted text is left untouched.
+func extractDateFunctions(raw string) (string, []*dateFunction, error) {
+	if !strings.Contains(raw, "(") {
+		return raw, nil, nil // no functions
+	}
+
+	var result strings.Builder
+	result.Grow(len(raw))
+
+	var functions []*dateFunction
+	var quote byte
+
+	for i := 0; i < len(raw); i++ {
+		c := raw[i]
+
+		// inside quoted text
+		if quote != 0 {
+			result.WriteByte(c)
+			if c == '\\' && i+1 < len(raw) {
+				i++
+				result.WriteByte(raw[i])
+			} else if c == quote {
+				quote = 0
+			}
+			continue
+		}
+
+		if c == '\'' || c == '"' {
+			quote = c
+		} else if (i == 0 || !isIdentifierChar(raw[i-1])) && dateFunctionRegex.MatchString(raw[i:]) {
+			fn, n, err := parseDateFunction(raw[i:])
+			if err != nil {
+				return "", nil, err
+			}
+
+			functions = append(functions, fn)
+			result.WriteString(dateFunctionIdentifierPrefix + strconv.Itoa(len(functions)-1))
+			i += n - 1
+			continue
+		}
+
+		result.WriteByte(c)
+	}
+
+	return result.String(), functions, nil
+}
+
+// parseDateFunction parses the date function call at the beginning of
+// the raw string and returns the number of the consumed bytes
+// (including the closing parenthesis).
+func parseDateFunction(raw string) (*dateFunction, int, error) {
+	match := dateFunctionRegex.FindStringSubmatch(raw)
+	if match == nil {
+		return nil, 0, errors.New("invalid date function call")
+	}
+
+	fn := &dateFunction{name: match[1]}
+
+	pos := skipSpaces(raw, len(match[0]))
+
+	// no arguments
+	if pos < len(raw) && raw[pos] == ')' {
+		return fn, pos + 1, fn.validate()
+	}
+
+	for pos < len(raw) {
+		arg, n, err := parseDateFunctionArg(raw[pos:])
+		if err != nil {
+			return nil, 0, fmt.Errorf("%s: %w", fn.name, err)
+		}
+		fn.args = append(fn.args, arg)
+
+		pos = skipSpaces(raw, pos+n)
+		if pos >= len(raw) {
+			break
+		}
+
+		switch raw[pos] {
+		case ',':
+			pos = skipSpaces(raw, pos+1)
+		case ')':
+			return fn, pos + 1, fn.validate()
+		default:
+			return nil, 0, fmt.Errorf("%s: unexpected character %q", fn.name, raw[pos])
+		}
+	}
+
+	return nil, 0, fmt.Errorf("%s: missing closing parenthesis", fn.name)
+}
+
+// parseDateFunctionArg parses the single date function argument at the
+// beginning of the raw string and returns the number of the consumed bytes.
+func parseDateFunctionArg(raw string) (*dateFunctionArg, int, error) {
+	if raw == "" {
+		return nil, 0, errors.New("missing argument")
+	}
+
+	// quoted text
+	if quote := raw[0]; quote == '\'' || quote == '"' {
+		for i := 1; i < len(raw); i++ {
+			if raw[i] == '\\' {
+				i++
+			} else if raw[i] == quote {
+				literal := strings.ReplaceAll(raw[1:i], `\`+string(quote), string(quote))
+				return &dateFunctionArg{token: fexpr.Token{Type: fexpr.TokenText, Literal: literal}}, i + 1, nil
+			}
+		}
+
+		return nil, 0, errors.New("unterminated quoted argument")
+	}
+
+	// nested call
+	if dateFunctionRegex.MatchString(raw) {
+		fn, n, err := parseDateFunction(raw)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		return &dateFunctionArg{call: fn}, n, nil
+	}
+
+	end := 0
+	for end < len(raw) && (isIdentifierChar(raw[end]) || raw[end] == '-' || raw[end] == '+') {
+		end++
+	}
+
+	literal := raw[:end]
+
+	switch {
+	case dateFunctionNumberRegex.MatchString(literal):
+		return &dateFunctionArg{token: fexpr.Token{Type: fexpr.TokenNumber, Literal: literal}}, end, nil
+	case dateFunctionIdentifierRegex.MatchString(literal):
+		return &dateFunctionArg{token: fexpr.Token{Type: fexpr.TokenIdentifier, Literal: literal}}, end, nil
+	}
+
+	return nil, 0, fmt.Errorf("invalid argument %q", literal)
+}
+
+func skipSpaces(raw string, pos int) int {
+	for pos < len(raw) && isSpaceChar(raw[pos]) {
+		pos++
+	}
+
+	return pos
+}
+
+// validate checks the date function arguments and
+// initializes the dateAdd/dateSub interval modifiers.
+func (fn *dateFunction) validate() error {
+	var unit string
+	var dates []*dateFunctionArg
+
+	switch fn.name {
+	case "now":
+		if len(fn.args) != 0 {
+			return errors.New("now: expects no arguments")
+		}
+	case "dateAdd", "dateSub":
+		if len(fn.args) != 2 || fn.args[1].token.Type != fexpr.TokenText {
+			return fmt.Errorf("%s: expects a date and a quoted interval argument", fn.name)
+		}
+
+		modifiers, err := parseDateInterval(fn.args[1].token.Literal, fn.name == "dateSub")
+		if err != nil {
+			return fmt.Errorf("%s: %w", fn.name, err)
+		}
+
+		fn.modifiers = modifiers
+		dates = fn.args[:1]
+	case "dateTrunc":
+		if len(fn.args) != 2 || fn.args[0].token.Type != fexpr.TokenText {
+			return errors.New("dateTrunc: expects a quoted unit and a date argument")
+		}
+
+		unit = fn.args[0].token.Literal
+		if _, ok := dateTruncFormats[unit]; !ok {
+			return fmt.Errorf("dateTrunc: unsupported unit %q", unit)
+		}
+
+		dates = fn.args[1:]
+	case "dateDiff":
+		if len(fn.args) != 3 || fn.args[0].token.Type != fexpr.TokenText {
+			return errors.New("dateDiff: expects a quoted unit, a start and an end date arguments")
+		}
+
+		unit = fn.args[0].token.Literal
+		if _, ok := dateDiffMultipliers[unit]; !ok {
+			return fmt.Errorf("dateDiff: unsupported unit %q", unit)
+		}
+
+		dates = fn.args[1:]
+	default:
+		return fmt.Errorf("unknown date function %q", fn.name)
+	}
+
+	for _, arg := range dates {
+		if arg.call != nil {
+			if arg.call.name == "dateDiff" {
+				return fmt.Errorf("%s: dateDiff doesn't return a date", fn.name)
+			}
+		} else if arg.token.Type == fexpr.TokenNumber {
+			return fmt.Errorf("%s: invalid date argument %q", fn.name, arg.token.Literal)
+		}
+	}
+
+	return nil
+}
+
+// parseDateInterval converts the provided interval string
+// (eg. "1 day 2 hours") into sqlite date modifiers.
+func parseDateInterval(interval string, negate bool) ([]string, error) {
+	fields := strings.Fields(interval)
+	if len(fields) == 0 {
+		return nil, errors.New("empty interval")
+	}
+
+	modifiers := make([]string, 0, len(fields)/2)
+
+	for i := 0; i < len(fields); i++ {
+		part := fields[i]
+
+		// the unit is separated with space from the number (eg. "1 day")
+		if dateFunctionNumberRegex.MatchString(part) && i+1 < len(fields) {
+			i++
+			part += " " + fields[i]
+		}
+
+		match := dateIntervalRegex.FindStringSubmatch(part)
+		if match == nil {
+			return nil, fmt.Errorf("invalid interval %q", interval)
+		}
+
+		amount, err := strconv.ParseFloat(match[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid interval %q", interval)
+		}
+
+		unit := match[2]
+
+		switch unit {
+		case "week":
+			amount *= 7
+			unit = "day"
+		case "month", "year":
+			if amount != math.Trunc(amount) {
+				return nil, fmt.Errorf("the %s interval must be an integer", unit)
+			}
+		}
+
+		if negate {
+			amount = -amount
+		}
+
+		modifiers = append(modifiers, strconv.FormatFloat(amount, 'f', -1, 64)+" "+unit+"s")
+	}
+
+	return modifiers, nil
+}
+
+// dateFunctionsComplexity returns the complexity score of the date function arguments
+// (1 for each nested call and for each relation/json path segment of an identifier).
+func dateFunctionsComplexity(functions []*dateFunction) int {
+	var total int
+
+	for _, fn := range functions {
+		for _, arg := range fn.args {
+			if arg.call != nil {
+				total += 1 + dateFunctionsComplexity([]*dateFunction{arg.call})
+			} else if arg.token.Type == fexpr.TokenIdentifier {
+				total += strings.Count(arg.token.Literal, ".")
+			}
+		}
+	}
+
+	return total
+}
+
+// dateFunctionsTokens returns the plain tokens of all date
+// function arguments (including the nested calls arguments).
+func dateFunctionsTokens(functions []*dateFunction) []fexpr.Token {
+	var tokens []fexpr.Token
+
+	for _, fn := range functions {
+		for _, arg := range fn.args {
+			if arg.call != nil {
+				tokens = append(tokens, dateFunctionsTokens([]*dateFunction{arg.call})...)
+			} else {
+				tokens = append(tokens, arg.token)
+			}
+		}
+	}
+
+	return tokens
+}
+
+// -------------------------------------------------------------------
+
+var _ FieldResolver = (*dateFunctionsResolver)(nil)
+var _ SubqueryResolver = (*dateFunctionsResolver)(nil)
+
+// dateFunctionsResolver is a [FieldResolver] decorator that resolves
+// the "@datefunc.N" identifiers of the extracted date functions
+// and forwards everything else to the wrapped resolver.
+type dateFunctionsResolver struct {
+	FieldResolver
+
+	functions []*dateFunction
+}
+
+// Resolve implements the [FieldResolver] interface.
+func (r *dateFunctionsResolver) Resolve(field string) (*ResolverResult, error) {
+	if !strings.HasPrefix(field, dateFunctionIdentifierPrefix) {
+		return r.FieldResolver.Resolve(field)
+	}
+
+	index, err := strconv.Atoi(strings.TrimPrefix(field, dateFunctionIdentifierPrefix))
+	if err != nil || index < 0 || index >= len(r.functions) {
+		return nil, fmt.Errorf("invalid date function identifier %q", field)
+	}
+
+	return resolveDateFunction(r.functions[index], r.FieldResolver)
+}
+
+// ResolveSubquery implements the [SubqueryResolver] interface
+// by forwarding the call to the wrapped resolver (if supported).
+func (r *dateFunctionsResolver) ResolveSubquery(subquery string) (dbx.Expression, error) {
+	subqueryResolver, ok := r.FieldResolver.(SubqueryResolver)
+	if !ok {
+		return nil, errors.New("the field resolver doesn't support subqueries")
+	}
+
+	return subqueryResolver.ResolveSubquery(subquery)
+}
+
+// resolveDateFunction resolves a single date function call into a SQL expression.
+func resolveDateFunction(fn *dateFunction, fieldResolver FieldResolver) (*ResolverResult, error) {
+	if fn.name == "now" {
+		value, err := identifierMacros["@now"]()
+		if err != nil {
+			return nil, err
+		}
+
+		placeholder := "t" + security.PseudorandomString(5)
+
+		return &ResolverResult{
+			Identifier: "{:" + placeholder + "}",
+			Params:     dbx.Params{placeholder: value},
+		}, nil
+	}
+
+	result := &ResolverResult{Params: dbx.Params{}}
+
+	resolveArg := func(arg *dateFunctionArg) (string, error) {
+		var argResult *ResolverResult
+		var err error
+
+		if arg.call != nil {
+			argResult, err = resolveDateFunction(arg.call, fieldResolver)
+		} else {
+			argResult, err = resolveToken(arg.token, fieldResolver)
+		}
+		if err != nil || argResult.Identifier == "" {
+			return "", fmt.Errorf("%s: invalid argument - %v", fn.name, err)
+		}
+
+		if argResult.MultiMatchSubQuery != nil {
+			return "", fmt.Errorf("%s: multiple values arguments are not supported", fn.name)
+		}
+
+		for k, v := range argResult.Params {
+			result.Params[k] = v
+		}
+
+		if argResult.AfterBuild != nil {
+			if prev := result.AfterBuild; prev != nil {
+				result.AfterBuild = func(expr dbx.Expression) dbx.Expression {
+					return argResult.AfterBuild(prev(expr))
+				}
+			} else {
+				result.AfterBuild = argResult.AfterBuild
+			}
+		}
+
+		return argResult.Identifier, nil
+	}
+
+	switch fn.name {
+	case "dateAdd", "dateSub":
+		date, err := resolveArg(fn.args[0])
+		if err != nil {
+			return nil, err
+		}
+
+		parts := []string{"'" + dateSQLFormat + "'", date}
+		for _, modifier := range fn.modifiers {
+			placeholder := "t" + security.PseudorandomString(5)
+			result.Params[placeholder] = modifier
+			parts = append(parts, "{:"+placeholder+"}")
+		}
+
+		result.Identifier = "strftime(" + strings.Join(parts, ", ") + ")"
+	case "dateTrunc":
+		unit := fn.args[0].token.Literal
+
+		date, err := resolveArg(fn.args[1])
+		if err != nil {
+			return nil, err
+		}
+
+		if unit == "week" {
+			// move to the Monday on or before the date
+			result.Identifier = fmt.Sprintf("strftime('%s', %s, '-6 days', 'weekday 1')", dateTruncFormats[unit], date)
+		} else {
+			result.Identifier = fmt.Sprintf("strftime('%s', %s)", dateTruncFormats[unit], date)
+		}
+	case "dateDiff":
+		unit := fn.args[0].token.Literal
+
+		start, err := resolveArg(fn.args[1])
+		if err != nil {
+			return nil, err
+		}
+
+		end, err := resolveArg(fn.args[2])
+		if err != nil {
+			return nil, err
+		}
+
+		result.Identifier = fmt.Sprintf("((julianday(%s) - julianday(%s)) * %s)", end, start, dateDiffMultipliers[unit])
+	default:
+		return nil, fmt.Errorf("unknown date function %q", fn.name)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,233 @@
+package search
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/dbx"
+)
+
+func TestExtractDateFunctions(t *testing.T) {
+	scenarios := []struct {
+		raw               string
+		expectError       bool
+		expected          string
+		expectedFunctions []string
+	}{
+		{"", false, "", nil},
+		{"title = 'test'", false, "title = 'test'", nil},
+		{"title = 'now()'", false, "title = 'now()'", nil},
+		{"a > now()", false, "a > @datefunc.0", []string{"now"}},
+		{"a > now ( )", false, "a > @datefunc.0", []string{"now"}},
+		{"a > dateSub(now(), '7 days') && b < dateAdd(c, \"1 day\")", false, "a > @datefunc.0 && b < @datefunc.1", []string{"dateSub", "dateAdd"}},
+		{"(dateTrunc('day', a) = dateTrunc('day', @now))", false, "(@datefunc.0 = @datefunc.1)", []string{"dateTrunc", "dateTrunc"}},
+		{"dateDiff('hour', a.b, dateAdd(c, '1 hour')) >= 2", false, "@datefunc.0 >= 2", []string{"dateDiff"}},
+		{"anow() = 1", false, "anow() = 1", nil},
+		{"@now() = 1", false, "@now() = 1", nil},
+		{"a > now(1)", true, "", nil},
+		{"a > now(", true, "", nil},
+		{"a > dateAdd(b)", true, "", nil},
+		{"a > dateAdd(b, c)", true, "", nil},
+		{"a > dateAdd(b, 'invalid')", true, "", nil},
+		{"a > dateAdd(123, '1 day')", true, "", nil},
+		{"a > dateAdd(b, '1 day'", true, "", nil},
+		{"a > dateAdd(b, '1 day)", true, "", nil},
+		{"a > dateAdd(b c, '1 day')", true, "", nil},
+		{"a > dateAdd(dateDiff('day', b, c), '1 day')", true, "", nil},
+		{"a > dateTrunc('invalid', b)", true, "", nil},
+		{"a > dateTrunc(b, 'day')", true, "", nil},
+		{"a > dateDiff('month', b, c)", true, "", nil},
+		{"a > dateDiff('day', b)", true, "", nil},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.raw, func(t *testing.T) {
+			result, functions, err := extractDateFunctions(s.raw)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if hasErr {
+				return
+			}
+
+			if result != s.expected {
+				t.Fatalf("Expected %q, got %q", s.expected, result)
+			}
+
+			if len(functions) != len(s.expectedFunctions) {
+				t.Fatalf("Expected functions %v, got %v", s.expectedFunctions, functions)
+			}
+
+			for i, name := range s.expectedFunctions {
+				if functions[i].name != name {
+					t.Fatalf("Expected function %d to be %q, got %q", i, name, functions[i].name)
+				}
+			}
+		})
+	}
+}
+
+func TestParseDateInterval(t *testing.T) {
+	scenarios := []struct {
+		interval    string
+		negate      bool
+		expectError bool
+		expected    []string
+	}{
+		{"", false, true, nil},
+		{"invalid", false, true, nil},
+		{"1", false, true, nil},
+		{"1 decade", false, true, nil},
+		{"1.5 months", false, true, nil},
+		{"1 day", false, false, []string{"1 days"}},
+		{"-7days", false, false, []string{"-7 days"}},
+		{"2 weeks", false, false, []string{"14 days"}},
+		{"1 year 2 months 1.5 seconds", false, false, []string{"1 years", "2 months", "1.5 seconds"}},
+		{"+1 hour -30 minutes", true, false, []string{"-1 hours", "30 minutes"}},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.interval, func(t *testing.T) {
+			result, err := parseDateInterval(s.interval, s.negate)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if strings.Join(result, "|") != strings.Join(s.expected, "|") {
+				t.Fatalf("Expected modifiers %v, got %v", s.expected, result)
+			}
+		})
+	}
+}
+
+func TestFilterDataBuildExprWithDateFunctions(t *testing.T) {
+	originalTimeNow := timeNow
+	defer func() {
+		timeNow = originalTimeNow
+	}()
+
+	timeNow = func() time.Time {
+		return time.Date(2023, 2, 3, 4, 5, 6, 7, time.UTC)
+	}
+
+	simpleResolver := NewSimpleFieldResolver("a", "b", "c")
+
+	scenarios := []struct {
+		name           string
+		resolver       FieldResolver
+		filter         FilterData
+		expectError    bool
+		expectPattern  string
+		expectedParams []any
+	}{
+		{
+			"unknown argument field",
+			simpleResolver,
+			"a > dateAdd(missing, '1 day')",
+			true,
+			"",
+			nil,
+		},
+		{
+			"non existing date function identifier",
+			simpleResolver,
+			"a > @datefunc.0",
+			true,
+			"",
+			nil,
+		},
+		{
+			"subquery with resolver without subqueries support",
+			simpleResolver,
+			"a > now() && b in (c = 1)",
+			true,
+			"",
+			nil,
+		},
+		{
+			"now",
+			simpleResolver,
+			"a > now()",
+			false,
+			"[[a]] > {:TEST}",
+			[]any{"2023-02-03 04:05:06.000Z"},
+		},
+		{
+			"dateAdd and dateSub",
+			simpleResolver,
+			"a >= dateSub(now(), '7 days') && b < dateAdd(c, '1 day 2 hours')",
+			false,
+			"([[a]] >= strftime('%Y-%m-%d %H:%M:%fZ', {:TEST}, {:TEST}) AND [[b]] < strftime('%Y-%m-%d %H:%M:%fZ', [[c]], {:TEST}, {:TEST}))",
+			[]any{"2023-02-03 04:05:06.000Z", "-7 days", "1 days", "2 hours"},
+		},
+		{
+			"dateTrunc",
+			simpleResolver,
+			"dateTrunc('month', a) = dateTrunc('week', '2023-01-01 00:00:00.000Z')",
+			false,
+			"strftime('%Y-%m-01 00:00:00.000Z', [[a]]) = strftime('%Y-%m-%d 00:00:00.000Z', {:TEST}, '-6 days', 'weekday 1')",
+			[]any{"2023-01-01 00:00:00.000Z"},
+		},
+		{
+			"dateDiff",
+			simpleResolver,
+			"dateDiff('hour', a, @now) <= 24",
+			false,
+			"((julianday({:TEST}) - julianday([[a]])) * 24.0) <= {:TEST}",
+			[]any{"2023-02-03 04:05:06.000Z", float64(24)},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			expr, err := s.filter.BuildExpr(s.resolver)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if hasErr {
+				return
+			}
+
+			params := dbx.Params{}
+			rawSql := expr.Build(&dbx.DB{}, params)
+
+			// replace TEST placeholder with .+ regex pattern
+			expectPattern := strings.ReplaceAll(
+				"^"+regexp.QuoteMeta(s.expectPattern)+"$",
+				"TEST",
+				`\w+`,
+			)
+
+			if !regexp.MustCompile(expectPattern).MatchString(rawSql) {
+				t.Fatalf("Pattern %v don't match with expression: \n%v", expectPattern, rawSql)
+			}
+
+			if len(params) != len(s.expectedParams) {
+				t.Fatalf("Expected params %v, got %v", s.expectedParams, params)
+			}
+
+			for _, expected := range s.expectedParams {
+				var found bool
+				for _, v := range params {
+					if v == expected {
+						found = true
+						break
+					}
+				}
+				if !found {
+					t.Fatalf("Missing expected param %v in %v", expected, params)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package search_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/dbx"
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+var filterFuzzSeeds = []string{
+	"title = 'test'",
+	"title != null && total > 1.5",
+	"(title ~ 'a' || title !~ \"b\") && total <= -10",
+	"tags ?= 'a' || tags ?!= 'b' || tags ?~ 'c'",
+	"title = 'x'' OR ''1''=''1'",
+	`title = "x\" OR 1=1 --"`,
+	"title = 'a'; DROP TABLE demo; --",
+	"title = '1 UNION SELECT name FROM sqlite_master'",
+	"title = @now && total > @second",
+	"@strftime('%Y', created) = '2023'",
+	"title = 'a' // comment",
+	"((((title = 1))))",
+	"title = `backtick`",
+	"title = '\x00'",
+	"total = 1e10",
+	"title]] = 1",
+	"[[title]] = {:param}",
+}
+
+// sqlBoundariesRegex matches the parts of a built filter SQL
+// that may contain arbitrary (but safe) characters.
+var sqlBoundariesRegex = regexp.MustCompile(`\[\[[^\]]*\]\]|\{:\w+\}|ESCAPE '\\'|'%'|''`)
+
+var sqlIdentifierRegex = regexp.MustCompile(`\[\[([^\]]*)\]\]`)
+
+var fieldNameRegex = regexp.MustCompile(`^\w+$`)
+
+func FuzzFilterDataBuildExpr(f *testing.F) {
+	for _, seed := range filterFuzzSeeds {
+		f.Add(seed)
+	}
+
+	resolver := search.NewSimpleFieldResolver(`^\w+$`)
+	dummyDB := &dbx.DB{}
+
+	f.Fuzz(func(t *testing.T, filter string) {
+		expr, err := search.FilterData(filter).BuildExpr(resolver)
+		if err != nil {
+			return // invalid filters are expected to be rejected
+		}
+
+		rawSql := expr.Build(dummyDB, dbx.Params{})
+
+		// all user provided literals must be bound as params
+		// and all identifiers must be quoted
+		stripped := sqlBoundariesRegex.ReplaceAllString(rawSql, "")
+		if strings.ContainsAny(stripped, "'\";`") || strings.Contains(stripped, "--") || strings.Contains(stripped, "/*") {
+			t.Fatalf("Unsafe SQL generated for filter %q:\n%s", filter, rawSql)
+		}
+
+		for _, identifier := range sqlIdentifierRegex.FindAllStringSubmatch(rawSql, -1) {
+			if !fieldNameRegex.MatchString(identifier[1]) {
+				t.Fatalf("Unexpected identifier %q in the SQL generated for filter %q:\n%s", identifier[1], filter, rawSql)
+			}
+		}
+	})
+}
+
+func FuzzFilterDataCheckStrict(f *testing.F) {
+	for _, seed := range filterFuzzSeeds {
+		f.Add(seed)
+	}
+
+	resolver := search.NewSimpleFieldResolver(`^\w+$`)
+
+	f.Fuzz(func(t *testing.T, filter string) {
+		_, strictErr := search.FilterData(filter).CheckStrict()
+
+		_, complexityErr := search.FilterData(filter).Complexity()
+
+		// the strict mode and the complexity checks operate on the same parsed filter
+		if strictErr == nil && complexityErr != nil {
+			t.Fatalf("Expected the strictly accepted filter %q to be parsable, got %v", filter, complexityErr)
+		}
+
+		// the strict mode should never accept a filter with control characters
+		if strictErr == nil && strings.ContainsFunc(filter, func(r rune) bool {
+			return (r < 0x20 && r != '\t' && r != '\n' && r != '\r') || r == 0x7f
+		}) {
+			t.Fatalf("Expected the filter %q with control characters to be rejected", filter)
+		}
+
+		// the strict mode checks must not affect the regular filter building
+		if strictErr == nil {
+			search.FilterData(filter).BuildExpr(resolver)
+		}
+	})
+}
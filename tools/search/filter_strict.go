@@ -0,0 +1,219 @@
+package search
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/ganigeorgiev/fexpr"
+	"github.com/pocketbase/pocketbase/tools/list"
+)
+
+const (
+	// strictMaxGroupDepth is the max allowed nesting level of the
+	// filter expression groups when the strict mode is enabled.
+	strictMaxGroupDepth = 10
+
+	// strictMaxIdentifierLength is the max allowed length of a single
+	// filter identifier when the strict mode is enabled.
+	strictMaxIdentifierLength = 255
+
+	// strictMaxIdentifierSegments is the max allowed number of dot
+	// separated identifier segments when the strict mode is enabled.
+	strictMaxIdentifierSegments = 10
+)
+
+// NearMissFunc is the function that is called for each filter
+// expression that is accepted by the strict mode but that contains
+// a pattern commonly found in SQL injection probes.
+type NearMissFunc func(filter string, reason string)
+
+var strictMode struct {
+	mux        sync.RWMutex
+	enabled    bool
+	onNearMiss NearMissFunc
+}
+
+// nearMissPatterns is a list with the text literal and identifier patterns
+// that are harmless (the literals are always bound as query parameters)
+// but usually indicate an attempt to probe the filter expressions surface.
+var nearMissPatterns = []struct {
+	pattern *regexp.Regexp
+	reason  string
+}{
+	{regexp.MustCompile(`--|/\*|\*/`), "sql comment sequence"},
+	{regexp.MustCompile(`;`), "sql statement separator"},
+	{regexp.MustCompile(`(?i)['"]\s*(or|and)\s+['"\d]`), "quote breakout"},
+	{regexp.MustCompile(`(?i)\bunion\b[\s\S]*\bselect\b`), "union select"},
+	{regexp.MustCompile(`(?i)\bsqlite_(master|schema|temp_master|temp_schema)\b`), "sqlite schema table"},
+	{regexp.MustCompile(`(?i)\b(load_extension|randomblob|zeroblob|sqlite_version|pragma_\w+)\s*\(`), "sql function call"},
+}
+
+// SetStrictMode enables or disables the filter strict mode.
+//
+// When enabled, [FilterData.BuildExpr] rejects filter expressions with
+// control characters, too deeply nested groups or too long identifiers
+// and calls the optional onNearMiss function for the accepted
+// expressions that contain suspicious patterns.
+func SetStrictMode(enabled bool, onNearMiss NearMissFunc) {
+	strictMode.mux.Lock()
+	defer strictMode.mux.Unlock()
+
+	strictMode.enabled = enabled
+	strictMode.onNearMiss = onNearMiss
+}
+
+// IsStrictMode reports whether the filter strict mode is enabled.
+func IsStrictMode() bool {
+	strictMode.mux.RLock()
+	defer strictMode.mux.RUnlock()
+
+	return strictMode.enabled
+}
+
+// CheckStrict checks the current filter data against the strict mode rules
+// (regardless of whether the strict mode is enabled or not).
+//
+// It returns an error if the filter is rejected, otherwise the
+// list with the found near-miss reasons (if any).
+//
+// Placeholder params are not replaced and are checked as regular identifiers.
+func (f FilterData) CheckStrict() ([]string, error) {
+	raw := expandFunctionMacros(string(f))
+
+	parsedRaw, subqueries := extractSubqueries(raw)
+
+	parsedRaw, dateFunctions, err := extractDateFunctions(parsedRaw)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := parseFilter(parsedRaw)
+	if err != nil {
+		return nil, err
+	}
+
+	nearMisses, err := checkStrictFilter(raw, data, dateFunctions)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, subquery := range subqueries {
+		subNearMisses, err := FilterData(subquery).CheckStrict()
+		if err != nil {
+			return nil, err
+		}
+
+		nearMisses = append(nearMisses, subNearMisses...)
+	}
+
+	return nearMisses, nil
+}
+
+// enforceStrictMode checks the parsed filter if the strict mode is enabled.
+func enforceStrictMode(raw string, data []fexpr.ExprGroup, dateFunctions []*dateFunction) error {
+	strictMode.mux.RLock()
+	enabled := strictMode.enabled
+	onNearMiss := strictMode.onNearMiss
+	strictMode.mux.RUnlock()
+
+	if !enabled {
+		return nil
+	}
+
+	nearMisses, err := checkStrictFilter(raw, data, dateFunctions)
+	if err != nil {
+		return err
+	}
+
+	if onNearMiss != nil {
+		for _, reason := range nearMisses {
+			onNearMiss(raw, reason)
+		}
+	}
+
+	return nil
+}
+
+func checkStrictFilter(raw string, data []fexpr.ExprGroup, dateFunctions []*dateFunction) ([]string, error) {
+	for _, r := range raw {
+		if (r < 0x20 && r != '\t' && r != '\n') || r == 0x7f {
+			return nil, errors.New("strict mode: control characters are not allowed")
+		}
+	}
+
+	var nearMisses []string
+
+	if err := checkStrictGroups(data, 0, &nearMisses); err != nil {
+		return nil, err
+	}
+
+	for _, token := range dateFunctionsTokens(dateFunctions) {
+		if err := checkStrictToken(token, &nearMisses); err != nil {
+			return nil, err
+		}
+	}
+
+	return nearMisses, nil
+}
+
+func checkStrictGroups(data []fexpr.ExprGroup, depth int, nearMisses *[]string) error {
+	if depth > strictMaxGroupDepth {
+		return fmt.Errorf("strict mode: the filter groups nesting exceeds %d levels", strictMaxGroupDepth)
+	}
+
+	for _, group := range data {
+		var err error
+
+		switch item := group.Item.(type) {
+		case fexpr.Expr:
+			err = checkStrictExpr(item, nearMisses)
+		case fexpr.ExprGroup:
+			err = checkStrictGroups([]fexpr.ExprGroup{item}, depth+1, nearMisses)
+		case []fexpr.ExprGroup:
+			err = checkStrictGroups(item, depth+1, nearMisses)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func checkStrictExpr(expr fexpr.Expr, nearMisses *[]string) error {
+	for _, token := range []fexpr.Token{expr.Left, expr.Right} {
+		if err := checkStrictToken(token, nearMisses); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func checkStrictToken(token fexpr.Token, nearMisses *[]string) error {
+	if token.Type == fexpr.TokenIdentifier {
+		if len(token.Literal) > strictMaxIdentifierLength {
+			return fmt.Errorf("strict mode: identifier exceeds %d characters", strictMaxIdentifierLength)
+		}
+
+		if strings.Count(token.Literal, ".")+1 > strictMaxIdentifierSegments {
+			return fmt.Errorf("strict mode: identifier exceeds %d path segments", strictMaxIdentifierSegments)
+		}
+	}
+
+	if token.Type != fexpr.TokenIdentifier && token.Type != fexpr.TokenText {
+		return nil
+	}
+
+	for _, p := range nearMissPatterns {
+		if p.pattern.MatchString(token.Literal) && !list.ExistInSlice(p.reason, *nearMisses) {
+			*nearMisses = append(*nearMisses, p.reason)
+		}
+	}
+
+	return nil
+}
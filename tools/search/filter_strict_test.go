@@ -0,0 +1,104 @@
+package search_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+func TestFilterDataCheckStrict(t *testing.T) {
+	scenarios := []struct {
+		name               string
+		filter             search.FilterData
+		expectError        bool
+		expectedNearMisses []string
+	}{
+		{"invalid filter", "(a = 1", true, nil},
+		{"control character", "a = 'b\x00'", true, nil},
+		{"allowed whitespaces", "a = 1\n\t&& b = 2\n", false, nil},
+		{"too deeply nested groups", search.FilterData(strings.Repeat("(", 11) + "a = 1" + strings.Repeat(")", 11)), true, nil},
+		{"max nested groups", search.FilterData(strings.Repeat("(", 10) + "a = 1" + strings.Repeat(")", 10)), false, nil},
+		{"too long identifier", search.FilterData(strings.Repeat("a", 256) + " = 1"), true, nil},
+		{"too many identifier segments", "a.b.c.d.e.f.g.h.i.j.k = 1", true, nil},
+		{"max identifier segments", "a.b.c.d.e.f.g.h.i.j = 1", false, nil},
+		{"statement separator", "title ~ 'lorem; ipsum' || total > 10", false, []string{"sql statement separator"}},
+		{"comment sequence", "title = 'a' && title != 'b -- c'", false, []string{"sql comment sequence"}},
+		{
+			"multiple near-misses",
+			`title = "x' OR '1'='1" || title = "1 UNION ALL SELECT name FROM sqlite_master" || title = "randomblob (10)"`,
+			false,
+			[]string{"quote breakout", "union select", "sqlite schema table", "sql function call"},
+		},
+		{"numbers are not checked", "a = 1 && b = 2", false, nil},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			nearMisses, err := s.filter.CheckStrict()
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if len(nearMisses) != len(s.expectedNearMisses) {
+				t.Fatalf("Expected near-misses %v, got %v", s.expectedNearMisses, nearMisses)
+			}
+
+			for i, reason := range s.expectedNearMisses {
+				if nearMisses[i] != reason {
+					t.Fatalf("Expected near-misses %v, got %v", s.expectedNearMisses, nearMisses)
+				}
+			}
+		})
+	}
+}
+
+func TestSetStrictMode(t *testing.T) {
+	defer search.SetStrictMode(false, nil)
+
+	resolver := search.NewSimpleFieldResolver("title")
+
+	rejected := search.FilterData(strings.Repeat("(", 11) + "title = 1" + strings.Repeat(")", 11))
+	suspicious := search.FilterData("title = {:title}")
+
+	// disabled
+	if search.IsStrictMode() {
+		t.Fatal("Expected the strict mode to be disabled by default")
+	}
+	if _, err := rejected.BuildExpr(resolver); err != nil {
+		t.Fatalf("Expected nil error with disabled strict mode, got %v", err)
+	}
+
+	// enabled
+	var logged []string
+	search.SetStrictMode(true, func(filter string, reason string) {
+		logged = append(logged, filter+": "+reason)
+	})
+
+	if !search.IsStrictMode() {
+		t.Fatal("Expected the strict mode to be enabled")
+	}
+
+	if _, err := rejected.BuildExpr(resolver); err == nil {
+		t.Fatal("Expected error with enabled strict mode, got nil")
+	}
+
+	if _, err := suspicious.BuildExpr(resolver, map[string]any{"title": "a'; DROP TABLE demo; --"}); err != nil {
+		t.Fatalf("Expected the suspicious filter to be accepted, got %v", err)
+	}
+
+	expected := []string{
+		`title = "a'; DROP TABLE demo; --": sql comment sequence`,
+		`title = "a'; DROP TABLE demo; --": sql statement separator`,
+	}
+	if len(logged) != len(expected) {
+		t.Fatalf("Expected logged near-misses %v, got %v", expected, logged)
+	}
+	for i, v := range expected {
+		if logged[i] != v {
+			t.Fatalf("Expected logged near-misses %v, got %v", expected, logged)
+		}
+	}
+}
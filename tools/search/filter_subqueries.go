@@ -0,0 +1,206 @@
+package search
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ganigeorgiev/fexpr"
+	"github.com/pocketbase/dbx"
+)
+
+// subqueryIdentifierPrefix is the prefix of the identifiers that
+// replace the "operand in (subquery)" expressions before parsing.
+const subqueryIdentifierPrefix = "@subquery."
+
+// SubqueryResolver is an optional [FieldResolver] interface
+// for resolving the "operand in (subquery)" filter expressions.
+type SubqueryResolver interface {
+	// ResolveSubquery builds a new single column subquery
+	// expression from the provided raw subquery filter.
+	ResolveSubquery(subquery string) (dbx.Expression, error)
+}
+
+// extractSubqueries replaces the "operand in (subquery)" expressions
+// with "operand = @subquery.N" so that they could be parsed as regular
+// expressions and returns the raw subqueries in the order of their N index.
+//
+// Quoted text is left untouched.
+func extractSubqueries(raw string) (string, []string) {
+	if !strings.Contains(raw, "in") {
+		return raw, nil // no subqueries
+	}
+
+	var result strings.Builder
+	result.Grow(len(raw))
+
+	var subqueries []string
+	var quote byte
+
+	for i := 0; i < len(raw); i++ {
+		c := raw[i]
+
+		// inside quoted text
+		if quote != 0 {
+			result.WriteByte(c)
+			if c == '\\' && i+1 < len(raw) {
+				i++
+				result.WriteByte(raw[i])
+			} else if c == quote {
+				quote = 0
+			}
+			continue
+		}
+
+		if c == '\'' || c == '"' {
+			quote = c
+		} else if isSubqueryKeyword(raw, i) {
+			open := i + 2
+			for open < len(raw) && isSpaceChar(raw[open]) {
+				open++
+			}
+
+			if end, ok := findClosingParenthesis(raw, open); ok {
+				subqueries = append(subqueries, strings.TrimSpace(raw[open+1:end]))
+				result.WriteString("= " + subqueryIdentifierPrefix + strconv.Itoa(len(subqueries)-1))
+				i = end
+				continue
+			}
+		}
+
+		result.WriteByte(c)
+	}
+
+	return result.String(), subqueries
+}
+
+// isSubqueryKeyword checks whether raw[i:] starts with a standalone "in"
+// keyword that is preceded by an operand and followed by a parenthesis.
+func isSubqueryKeyword(raw string, i int) bool {
+	if !strings.HasPrefix(raw[i:], "in") || i == 0 || !isSpaceChar(raw[i-1]) {
+		return false
+	}
+
+	// the previous non-space char must be part of an operand
+	prev := i - 1
+	for prev >= 0 && isSpaceChar(raw[prev]) {
+		prev--
+	}
+	if prev < 0 || !(isIdentifierChar(raw[prev]) || raw[prev] == '\'' || raw[prev] == '"') {
+		return false
+	}
+
+	next := i + 2
+	for next < len(raw) && isSpaceChar(raw[next]) {
+		next++
+	}
+
+	return next < len(raw) && raw[next] == '('
+}
+
+// findClosingParenthesis returns the position of the parenthesis that
+// closes the one at the open position (ignoring the quoted text).
+func findClosingParenthesis(raw string, open int) (int, bool) {
+	var quote byte
+
+	depth := 0
+
+	for i := open; i < len(raw); i++ {
+		c := raw[i]
+
+		if quote != 0 {
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+			continue
+		}
+
+		switch c {
+		case '\'', '"':
+			quote = c
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i, true
+			}
+		}
+	}
+
+	return 0, false
+}
+
+func isSpaceChar(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+// isSubqueryExpr checks whether the provided parsed expression
+// is a replaced "operand in (subquery)" expression.
+func isSubqueryExpr(expr fexpr.Expr) bool {
+	return expr.Op == fexpr.SignEq &&
+		expr.Right.Type == fexpr.TokenIdentifier &&
+		strings.HasPrefix(expr.Right.Literal, subqueryIdentifierPrefix)
+}
+
+// resolveSubqueryExpr resolves a replaced "operand in (subquery)" expression
+// into an "operand IN (SELECT ...)" db expression.
+//
+// For multiple values operands it is enough at least one of the values to match.
+func resolveSubqueryExpr(expr fexpr.Expr, fieldResolver FieldResolver, subqueries []string) (dbx.Expression, error) {
+	index, err := strconv.Atoi(strings.TrimPrefix(expr.Right.Literal, subqueryIdentifierPrefix))
+	if err != nil || index < 0 || index >= len(subqueries) {
+		return nil, fmt.Errorf("invalid subquery identifier %q", expr.Right.Literal)
+	}
+
+	subqueryResolver, ok := fieldResolver.(SubqueryResolver)
+	if !ok {
+		return nil, errors.New("the field resolver doesn't support subqueries")
+	}
+
+	left, err := resolveToken(expr.Left, fieldResolver)
+	if err != nil || left.Identifier == "" {
+		return nil, fmt.Errorf("invalid left operand %q - %v", expr.Left.Literal, err)
+	}
+
+	subquery, err := subqueryResolver.ResolveSubquery(subqueries[index])
+	if err != nil {
+		return nil, fmt.Errorf("invalid subquery %q - %w", subqueries[index], err)
+	}
+
+	var result dbx.Expression = &inSubqueryExpr{operand: left, subquery: subquery}
+
+	if left.AfterBuild != nil {
+		result = left.AfterBuild(result)
+	}
+
+	return result, nil
+}
+
+// -------------------------------------------------------------------
+
+var _ dbx.Expression = (*inSubqueryExpr)(nil)
+
+// inSubqueryExpr defines an "operand IN (subquery)" db expression.
+type inSubqueryExpr struct {
+	operand  *ResolverResult
+	subquery dbx.Expression
+}
+
+// Build converts the expression into a SQL fragment.
+//
+// Implements [dbx.Expression] interface.
+func (e *inSubqueryExpr) Build(db *dbx.DB, params dbx.Params) string {
+	if e.operand == nil || e.subquery == nil {
+		return "0=1"
+	}
+
+	for k, v := range e.operand.Params {
+		params[k] = v
+	}
+
+	return fmt.Sprintf("%s IN (%s)", e.operand.Identifier, e.subquery.Build(db, params))
+}
@@ -0,0 +1,162 @@
+package search
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/dbx"
+)
+
+func TestExtractSubqueries(t *testing.T) {
+	scenarios := []struct {
+		raw                string
+		expected           string
+		expectedSubqueries []string
+	}{
+		{"", "", nil},
+		{"title = 'test'", "title = 'test'", nil},
+		{"id in (a = 1)", "id = @subquery.0", []string{"a = 1"}},
+		{"id  in  ( a = 1 || (b = 2) ) && c = 3", "id  = @subquery.0 && c = 3", []string{"a = 1 || (b = 2)"}},
+		{"id in(a = 1)", "id = @subquery.0", []string{"a = 1"}},
+		{"'a' in (a = ')') || b in (c = 2)", "'a' = @subquery.0 || b = @subquery.1", []string{"a = ')'", "c = 2"}},
+		{"a in (b in (c = 1))", "a = @subquery.0", []string{"b in (c = 1)"}},
+		{"title = 'a in (b = 1)'", "title = 'a in (b = 1)'", nil},
+		{"(in (a = 1))", "(in (a = 1))", nil},
+		{"a && in (a = 1)", "a && in (a = 1)", nil},
+		{"ain (a = 1)", "ain (a = 1)", nil},
+		{"a index (a = 1)", "a index (a = 1)", nil},
+		{"a in (a = 1", "a in (a = 1", nil},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.raw, func(t *testing.T) {
+			result, subqueries := extractSubqueries(s.raw)
+
+			if result != s.expected {
+				t.Fatalf("Expected %q, got %q", s.expected, result)
+			}
+
+			if len(subqueries) != len(s.expectedSubqueries) {
+				t.Fatalf("Expected subqueries %v, got %v", s.expectedSubqueries, subqueries)
+			}
+
+			for i, sub := range s.expectedSubqueries {
+				if subqueries[i] != sub {
+					t.Fatalf("Expected subquery %d to be %q, got %q", i, sub, subqueries[i])
+				}
+			}
+		})
+	}
+}
+
+// testSubqueryResolver is a SimpleFieldResolver that resolves the
+// subqueries as "SELECT id FROM sub WHERE ..." expressions.
+type testSubqueryResolver struct {
+	*SimpleFieldResolver
+}
+
+func (r *testSubqueryResolver) ResolveSubquery(subquery string) (dbx.Expression, error) {
+	if subquery == "error" {
+		return nil, errors.New("test")
+	}
+
+	where, err := FilterData(subquery).BuildExpr(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return dbx.NewExp("SELECT [[id]] FROM {{sub}} WHERE " + where.Build(&dbx.DB{}, dbx.Params{})), nil
+}
+
+func TestFilterDataBuildExprWithSubqueries(t *testing.T) {
+	simpleResolver := NewSimpleFieldResolver("a", "b", "c")
+	subqueryResolver := &testSubqueryResolver{simpleResolver}
+
+	scenarios := []struct {
+		name          string
+		resolver      FieldResolver
+		filter        FilterData
+		expectError   bool
+		expectPattern string
+	}{
+		{
+			"resolver without subqueries support",
+			simpleResolver,
+			"a in (b = 1)",
+			true,
+			"",
+		},
+		{
+			"subquery resolve error",
+			subqueryResolver,
+			"a in (error)",
+			true,
+			"",
+		},
+		{
+			"invalid subquery operand",
+			subqueryResolver,
+			"missing in (b = 1)",
+			true,
+			"",
+		},
+		{
+			"invalid subquery filter",
+			subqueryResolver,
+			"a in (missing = 1)",
+			true,
+			"",
+		},
+		{
+			"non existing subquery identifier",
+			subqueryResolver,
+			"a = @subquery.0",
+			true,
+			"",
+		},
+		{
+			"single subquery",
+			subqueryResolver,
+			"a in (b = 1)",
+			false,
+			"[[a]] IN (SELECT [[id]] FROM {{sub}} WHERE [[b]] = {:TEST})",
+		},
+		{
+			"multiple and nested subqueries",
+			subqueryResolver,
+			"a in (b = 1 && c in (a = 'x')) || 'test' in (c = 2)",
+			false,
+			"([[a]] IN (SELECT [[id]] FROM {{sub}} WHERE ([[b]] = {:TEST} AND [[c]] IN (SELECT [[id]] FROM {{sub}} WHERE [[a]] = {:TEST}))) OR {:TEST} IN (SELECT [[id]] FROM {{sub}} WHERE [[c]] = {:TEST}))",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			expr, err := s.filter.BuildExpr(s.resolver)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if hasErr {
+				return
+			}
+
+			rawSql := expr.Build(&dbx.DB{}, dbx.Params{})
+
+			// replace TEST placeholder with .+ regex pattern
+			expectPattern := strings.ReplaceAll(
+				"^"+regexp.QuoteMeta(s.expectPattern)+"$",
+				"TEST",
+				`\w+`,
+			)
+
+			if !regexp.MustCompile(expectPattern).MatchString(rawSql) {
+				t.Fatalf("Pattern %v don't match with expression: \n%v", expectPattern, rawSql)
+			}
+		})
+	}
+}
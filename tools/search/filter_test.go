@@ -232,3 +232,41 @@ func TestFilterDataBuildExprWithParams(t *testing.T) {
 		t.Fatalf("Expected query \n%s, \ngot \n%s", expectedQuery, calledQueries[0])
 	}
 }
+
+func TestFilterDataComplexity(t *testing.T) {
+	scenarios := []struct {
+		filter      search.FilterData
+		expectError bool
+		expected    int
+	}{
+		{"", true, 0},
+		{"invalid", true, 0},
+		{"a = 1", false, 1},
+		{"a = 'b.c' && d = 1.5", false, 2},
+		{"a.b.c = 1", false, 3},
+		{"a ?= 1 || b.c ?~ d", false, 5},
+		{"(a = 1 || b = 2) && c = 3", false, 4},
+		{"a = 1 && (b = 2 || (c = 3 && d.e = 4))", false, 7},
+		{"a in (b.c = 1 && d = 2) && e = 3", false, 6},
+		{"a in (b = 1 && c in (d.e = 2))", false, 7},
+		{"a in (invalid)", true, 0},
+		{"a > now()", false, 2},
+		{"a > dateSub(now(), '1 day') && b = dateTrunc('day', c.d)", false, 6},
+		{"a = dateAdd(b, 'invalid')", true, 0},
+	}
+
+	for _, s := range scenarios {
+		t.Run(string(s.filter), func(t *testing.T) {
+			result, err := s.filter.Complexity()
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if result != s.expected {
+				t.Fatalf("Expected complexity %d, got %d", s.expected, result)
+			}
+		})
+	}
+}
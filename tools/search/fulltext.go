@@ -0,0 +1,43 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// FullTextMatchQuery converts the provided user search text
+// into a safe FTS5 MATCH query expression.
+//
+// Each word of the text is quoted as a phrase so that the FTS5 query
+// syntax characters are treated literally and the last word is
+// matched as prefix to allow "search as you type" queries.
+//
+// Returns empty string if the text doesn't contain any word.
+func FullTextMatchQuery(text string) string {
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsControl(r)
+	})
+
+	phrases := make([]string, 0, len(words))
+
+	for _, word := range words {
+		// skip the punctuation only words since they don't produce any token
+		if strings.IndexFunc(word, isWordRune) == -1 {
+			continue
+		}
+
+		phrases = append(phrases, `"`+strings.ReplaceAll(word, `"`, `""`)+`"`)
+	}
+
+	if len(phrases) == 0 {
+		return ""
+	}
+
+	phrases[len(phrases)-1] += "*"
+
+	return strings.Join(phrases, " ")
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsNumber(r)
+}
@@ -0,0 +1,31 @@
+package search_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/search"
+)
+
+func TestFullTextMatchQuery(t *testing.T) {
+	scenarios := []struct {
+		text     string
+		expected string
+	}{
+		{"", ""},
+		{"   \t\n ", ""},
+		{"- * ( ) :", ""},
+		{"test", `"test"*`},
+		{"  hello   world ", `"hello" "world"*`},
+		{`a"b OR c* NEAR(d)`, `"a""b" "OR" "c*" "NEAR(d)"*`},
+		{"café - über", `"café" "über"*`},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.text, func(t *testing.T) {
+			result := search.FullTextMatchQuery(s.text)
+			if result != s.expected {
+				t.Fatalf("Expected %s, got %s", s.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package search
+
+import (
+	"regexp"
+	"strings"
+)
+
+// functionMacroRegex matches a single "@name(arg)" function macro.
+var functionMacroRegex = regexp.MustCompile(`^@(\w+)\(\s*(\w+)\s*\)`)
+
+// listFunctionRegex matches the beginning of a list function call (eg. "contains(").
+var listFunctionRegex = regexp.MustCompile(`^(contains|overlaps|length)\s*\(`)
+
+// listFunctionFieldRegex matches the field argument of a list function.
+var listFunctionFieldRegex = regexp.MustCompile(`^@?[\w.]+$`)
+
+// expandFunctionMacros rewrites the "@name(arg)" function macros into
+// the equivalent "(@name.arg = true)" expressions so that they could be
+// parsed as regular identifiers and handled by the field resolver
+// (eg. "@following(author)" -> "(@following.author = true)").
+//
+// It also rewrites the array field functions into their modifiers equivalent:
+//   - "length(field)" -> "field:length"
+//   - "contains(field, v)" -> "(field:each ?= v)"
+//   - "overlaps(field, a, b)" -> "(field:each ?= a || field:each ?= b)"
+//
+// Quoted text is left untouched.
+func expandFunctionMacros(raw string) string {
+	if !strings.Contains(raw, "(") {
+		return raw // no macros
+	}
+
+	var result strings.Builder
+	result.Grow(len(raw))
+
+	var quote byte
+
+	for i := 0; i < len(raw); i++ {
+		c := raw[i]
+
+		// inside quoted text
+		if quote != 0 {
+			result.WriteByte(c)
+			if c == '\\' && i+1 < len(raw) {
+				i++
+				result.WriteByte(raw[i])
+			} else if c == quote {
+				quote = 0
+			}
+			continue
+		}
+
+		switch {
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '@' && (i == 0 || !isIdentifierChar(raw[i-1])):
+			if match := functionMacroRegex.FindStringSubmatch(raw[i:]); match != nil {
+				result.WriteString("(@" + match[1] + "." + match[2] + " = true)")
+				i += len(match[0]) - 1
+				continue
+			}
+		case i == 0 || !isIdentifierChar(raw[i-1]):
+			if match := listFunctionRegex.FindStringSubmatch(raw[i:]); match != nil {
+				if args, n, ok := splitFunctionArgs(raw[i+len(match[0]):]); ok {
+					if expanded, ok := expandListFunction(match[1], args); ok {
+						result.WriteString(expanded)
+						i += len(match[0]) + n - 1
+						continue
+					}
+				}
+			}
+		}
+
+		result.WriteByte(c)
+	}
+
+	return result.String()
+}
+
+func isIdentifierChar(c byte) bool {
+	return c == '_' || c == '.' || c == ':' || c == '@' ||
+		(c >= 'a' && c <= 'z') ||
+		(c >= 'A' && c <= 'Z') ||
+		(c >= '0' && c <= '9')
+}
+
+// expandListFunction returns the modifiers equivalent of the provided list function call.
+func expandListFunction(name string, args []string) (string, bool) {
+	if len(args) == 0 || !listFunctionFieldRegex.MatchString(args[0]) {
+		return "", false
+	}
+
+	field := args[0]
+
+	switch name {
+	case "length":
+		if len(args) != 1 {
+			return "", false
+		}
+
+		return field + ":length", true
+	case "contains", "overlaps":
+		if len(args) < 2 || (name == "contains" && len(args) != 2) {
+			return "", false
+		}
+
+		parts := make([]string, 0, len(args)-1)
+		for _, v := range args[1:] {
+			if v == "" {
+				return "", false
+			}
+			parts = append(parts, field+":each ?= "+v)
+		}
+
+		return "(" + strings.Join(parts, " || ") + ")", true
+	}
+
+	return "", false
+}
+
+// splitFunctionArgs splits the comma separated function arguments until
+// the closing parenthesis of the call.
+//
+// Returns the trimmed arguments, the number of the consumed bytes
+// (including the closing parenthesis) and whether the call was properly closed.
+func splitFunctionArgs(raw string) ([]string, int, bool) {
+	var args []string
+	var quote byte
+
+	start := 0
+
+	for i := 0; i < len(raw); i++ {
+		c := raw[i]
+
+		if quote != 0 {
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+			continue
+		}
+
+		switch c {
+		case '\'', '"':
+			quote = c
+		case '(':
+			return nil, 0, false // nested calls are not supported
+		case ',', ')':
+			args = append(args, strings.TrimSpace(raw[start:i]))
+			start = i + 1
+
+			if c == ')' {
+				return args, i + 1, true
+			}
+		}
+	}
+
+	return nil, 0, false
+}
@@ -0,0 +1,46 @@
+package search
+
+import "testing"
+
+func TestExpandFunctionMacros(t *testing.T) {
+	scenarios := []struct {
+		raw      string
+		expected string
+	}{
+		{"", ""},
+		{"title = 'test'", "title = 'test'"},
+		{"@following(author)", "(@following.author = true)"},
+		{"@following( author ) && status = true", "(@following.author = true) && status = true"},
+		{"(a = 1 || @following(id)) && @following(b)", "(a = 1 || (@following.id = true)) && (@following.b = true)"},
+		{`title = "@following(author)"`, `title = "@following(author)"`},
+		{`title = 'a\'@following(author)' || @following(c)`, `title = 'a\'@following(author)' || (@following.c = true)`},
+		{"a@following(author)", "a@following(author)"},
+		{"@following(a.b)", "@following(a.b)"},
+		{"@following()", "@following()"},
+		{"length(tags) > 1", "tags:length > 1"},
+		{"length( tags )=0 && length(a.b)>0", "tags:length=0 && a.b:length>0"},
+		{"contains(tags, 'a')", "(tags:each ?= 'a')"},
+		{`contains(tags,"a,b)") && status = true`, `(tags:each ?= "a,b)") && status = true`},
+		{"contains(scores, 10) || contains(@request.auth.tags, title)", "(scores:each ?= 10) || (@request.auth.tags:each ?= title)"},
+		{"overlaps(tags, 'a', 'b', @request.data.tag)", "(tags:each ?= 'a' || tags:each ?= 'b' || tags:each ?= @request.data.tag)"},
+		{"overlaps(tags, 'a') && @following(author)", "(tags:each ?= 'a') && (@following.author = true)"},
+		{"title = 'contains(tags, 1)'", "title = 'contains(tags, 1)'"},
+		{"acontains(tags, 1)", "acontains(tags, 1)"},
+		{"contains(tags)", "contains(tags)"},
+		{"contains(tags, 1, 2)", "contains(tags, 1, 2)"},
+		{"contains(tags, )", "contains(tags, )"},
+		{"contains('tags', 1)", "contains('tags', 1)"},
+		{"contains(tags, length(a))", "contains(tags, a:length)"},
+		{"length(tags", "length(tags"},
+		{"length(a, b)", "length(a, b)"},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.raw, func(t *testing.T) {
+			result := expandFunctionMacros(s.raw)
+			if result != s.expected {
+				t.Fatalf("Expected\n%s\ngot\n%s", s.expected, result)
+			}
+		})
+	}
+}
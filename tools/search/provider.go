@@ -42,6 +42,7 @@ type Provider struct {
 	countCol      string
 	page          int
 	perPage       int
+	maxPerPage    int
 	sort          []SortField
 	filter        []FilterData
 }
@@ -63,6 +64,7 @@ func NewProvider(fieldResolver FieldResolver) *Provider {
 		countCol:      "id",
 		page:          1,
 		perPage:       DefaultPerPage,
+		maxPerPage:    MaxPerPage,
 		sort:          []SortField{},
 		filter:        []FilterData{},
 	}
@@ -105,6 +107,18 @@ func (s *Provider) PerPage(perPage int) *Provider {
 	return s
 }
 
+// PerPageLimit changes the max allowed `perPage` value of the current
+// search provider (default to MaxPerPage).
+//
+// Non-positive limit resets it to MaxPerPage.
+func (s *Provider) PerPageLimit(limit int) *Provider {
+	if limit <= 0 {
+		limit = MaxPerPage
+	}
+	s.maxPerPage = limit
+	return s
+}
+
 // Sort sets the `sort` field of the current search provider.
 func (s *Provider) Sort(sort []SortField) *Provider {
 	s.sort = sort
@@ -183,58 +197,17 @@ func (s *Provider) Parse(urlQuery string) error {
 // Exec executes the search provider and fills/scans
 // the provided `items` slice with the found models.
 func (s *Provider) Exec(items any) (*Result, error) {
-	if s.query == nil {
-		return nil, errors.New("query is not set")
-	}
-
-	// shallow clone the provider's query
-	modelsQuery := *s.query
-
-	// build filters
-	for _, f := range s.filter {
-		expr, err := f.BuildExpr(s.fieldResolver)
-		if err != nil {
-			return nil, err
-		}
-		if expr != nil {
-			modelsQuery.AndWhere(expr)
-		}
-	}
-
-	// apply sorting
-	for _, sortField := range s.sort {
-		expr, err := sortField.BuildExpr(s.fieldResolver)
-		if err != nil {
-			return nil, err
-		}
-		if expr != "" {
-			modelsQuery.AndOrderBy(expr)
-		}
-	}
-
-	// apply field resolver query modifications (if any)
-	if err := s.fieldResolver.UpdateQuery(&modelsQuery); err != nil {
+	modelsQuery, err := s.buildModelsQuery()
+	if err != nil {
 		return nil, err
 	}
 
-	// normalize page
-	if s.page <= 0 {
-		s.page = 1
-	}
-
-	// normalize perPage
-	if s.perPage <= 0 {
-		s.perPage = DefaultPerPage
-	} else if s.perPage > MaxPerPage {
-		s.perPage = MaxPerPage
-	}
-
 	// negative value to differentiate from the zero default
 	totalCount := -1
 	totalPages := -1
 
 	// prepare a count query from the base one
-	countQuery := modelsQuery // shallow clone
+	countQuery := *modelsQuery // shallow clone
 	countExec := func() error {
 		queryInfo := countQuery.Info()
 		countCol := s.countCol
@@ -290,6 +263,100 @@ func (s *Provider) Exec(items any) (*Result, error) {
 	return result, nil
 }
 
+// ExecRows is similar to [Provider.Exec] but instead of scanning the
+// found models into a slice, it calls onRow for each found row as it's
+// read from the db (eg. for streaming large pages without buffering them).
+//
+// The total count query is not executed (TotalItems and TotalPages
+// are always -1) and the returned result Items field is nil.
+func (s *Provider) ExecRows(onRow func(rows *dbx.Rows) error) (*Result, error) {
+	modelsQuery, err := s.buildModelsQuery()
+	if err != nil {
+		return nil, err
+	}
+
+	modelsQuery.Limit(int64(s.perPage))
+	modelsQuery.Offset(int64(s.perPage * (s.page - 1)))
+
+	rows, err := modelsQuery.Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := onRow(rows); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &Result{
+		Page:       s.page,
+		PerPage:    s.perPage,
+		TotalItems: -1,
+		TotalPages: -1,
+	}, nil
+}
+
+// buildModelsQuery returns a new shallow clone of the provider query
+// with applied filters, sorting and field resolver modifications.
+//
+// It also normalizes the provider page and perPage values.
+func (s *Provider) buildModelsQuery() (*dbx.SelectQuery, error) {
+	if s.query == nil {
+		return nil, errors.New("query is not set")
+	}
+
+	// shallow clone the provider's query
+	modelsQuery := *s.query
+
+	// build filters
+	for _, f := range s.filter {
+		expr, err := f.BuildExpr(s.fieldResolver)
+		if err != nil {
+			return nil, err
+		}
+		if expr != nil {
+			modelsQuery.AndWhere(expr)
+		}
+	}
+
+	// apply sorting
+	for _, sortField := range s.sort {
+		expr, err := sortField.BuildExpr(s.fieldResolver)
+		if err != nil {
+			return nil, err
+		}
+		if expr != "" {
+			modelsQuery.AndOrderBy(expr)
+		}
+	}
+
+	// apply field resolver query modifications (if any)
+	if err := s.fieldResolver.UpdateQuery(&modelsQuery); err != nil {
+		return nil, err
+	}
+
+	// normalize page
+	if s.page <= 0 {
+		s.page = 1
+	}
+
+	// normalize perPage
+	if s.perPage <= 0 {
+		s.perPage = DefaultPerPage
+	}
+	if s.perPage > s.maxPerPage {
+		s.perPage = s.maxPerPage
+	}
+
+	return &modelsQuery, nil
+}
+
 // ParseAndExec is a short convenient method to trigger both
 // `Parse()` and `Exec()` in a single call.
 func (s *Provider) ParseAndExec(urlQuery string, modelsSlice any) (*Result, error) {
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -88,6 +89,35 @@ func TestProviderPerPage(t *testing.T) {
 	}
 }
 
+func TestProviderPerPageLimit(t *testing.T) {
+	scenarios := []struct {
+		limit    int
+		perPage  int
+		expected int
+	}{
+		{0, 5555, MaxPerPage},
+		{-1, 5555, MaxPerPage},
+		{10, 5, 5},
+		{10, 20, 10},
+		{10, 0, 10}, // the default perPage is also limited
+	}
+
+	for i, s := range scenarios {
+		p := NewProvider(&testFieldResolver{}).
+			Query(dbx.NewFromDB(nil, "").Select("*").From("test")).
+			PerPageLimit(s.limit).
+			PerPage(s.perPage)
+
+		if _, err := p.buildModelsQuery(); err != nil {
+			t.Fatalf("[%d] %v", i, err)
+		}
+
+		if p.perPage != s.expected {
+			t.Fatalf("[%d] Expected perPage %d, got %d", i, s.expected, p.perPage)
+		}
+	}
+}
+
 func TestProviderSort(t *testing.T) {
 	initialSort := []SortField{{"test1", SortAsc}, {"test2", SortAsc}}
 	r := &testFieldResolver{}
@@ -427,6 +457,129 @@ func TestProviderExecNonEmptyQuery(t *testing.T) {
 	}
 }
 
+func TestProviderExecRows(t *testing.T) {
+	testDB, err := createTestDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer testDB.Close()
+
+	query := testDB.Select("*").
+		From("test").
+		Where(dbx.Not(dbx.HashExp{"test1": nil}))
+
+	// empty query
+	if _, err := NewProvider(&testFieldResolver{}).ExecRows(func(rows *dbx.Rows) error { return nil }); err == nil {
+		t.Fatal("Expected error with empty query, got nil")
+	}
+
+	scenarios := []struct {
+		name          string
+		page          int
+		perPage       int
+		filter        []FilterData
+		onRowErr      error
+		expectError   bool
+		expectResult  string
+		expectItems   []string
+		expectQueries []string
+	}{
+		{
+			"page and perPage normalization",
+			-1,
+			-1,
+			nil,
+			nil,
+			false,
+			`{"page":1,"perPage":30,"totalItems":-1,"totalPages":-1,"items":null}`,
+			[]string{"test2.2", "test2.1"},
+			[]string{
+				"SELECT * FROM `test` WHERE NOT (`test1` IS NULL) ORDER BY `test1` DESC LIMIT 30",
+			},
+		},
+		{
+			"filter and pagination",
+			2,
+			1,
+			[]FilterData{"test1 > 0"},
+			nil,
+			false,
+			`{"page":2,"perPage":1,"totalItems":-1,"totalPages":-1,"items":null}`,
+			[]string{"test2.1"},
+			[]string{
+				"SELECT * FROM `test` WHERE (NOT (`test1` IS NULL)) AND (test1 > 0) ORDER BY `test1` DESC LIMIT 1 OFFSET 1",
+			},
+		},
+		{
+			"onRow error",
+			1,
+			10,
+			nil,
+			errors.New("test"),
+			true,
+			"",
+			nil,
+			nil,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			testDB.CalledQueries = []string{} // reset
+
+			items := []string{}
+
+			result, err := NewProvider(&testFieldResolver{}).
+				Query(query).
+				Page(s.page).
+				PerPage(s.perPage).
+				Sort([]SortField{{"test1", SortDesc}}).
+				Filter(s.filter).
+				ExecRows(func(rows *dbx.Rows) error {
+					if s.onRowErr != nil {
+						return s.onRowErr
+					}
+
+					item := testTableStruct{}
+					if err := rows.ScanStruct(&item); err != nil {
+						return err
+					}
+					items = append(items, item.Test2)
+
+					return nil
+				})
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if hasErr {
+				return
+			}
+
+			encoded, _ := json.Marshal(result)
+			if string(encoded) != s.expectResult {
+				t.Fatalf("Expected result %v, got \n%v", s.expectResult, string(encoded))
+			}
+
+			if strings.Join(items, ",") != strings.Join(s.expectItems, ",") {
+				t.Fatalf("Expected items %v, got %v", s.expectItems, items)
+			}
+
+			if len(s.expectQueries) != len(testDB.CalledQueries) {
+				t.Fatalf("Expected %d queries, got %d: \n%v", len(s.expectQueries), len(testDB.CalledQueries), testDB.CalledQueries)
+			}
+
+			for i, q := range testDB.CalledQueries {
+				if q != s.expectQueries[i] {
+					t.Fatalf("Expected query \n%s, got \n%s", s.expectQueries[i], q)
+				}
+			}
+		})
+	}
+}
+
 func TestProviderParseAndExec(t *testing.T) {
 	testDB, err := createTestDB()
 	if err != nil {
@@ -0,0 +1,84 @@
+package secrets
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/kms"
+)
+
+var _ Backend = (*AwsKmsBackend)(nil)
+
+// AwsKmsBackend encrypts the secrets with an AWS KMS symmetric key.
+type AwsKmsBackend struct {
+	keyId  string
+	client *kms.KMS
+}
+
+// NewAwsKmsBackend creates a new AWS KMS backend for the specified key id or ARN.
+//
+// If accessKey and secret are not set, the default AWS credentials
+// chain is used (env, shared config, IAM role, etc.).
+func NewAwsKmsBackend(region string, accessKey string, secret string, keyId string) (*AwsKmsBackend, error) {
+	if keyId == "" {
+		return nil, errors.New("missing AWS KMS key id")
+	}
+
+	config := &aws.Config{
+		Region: aws.String(region),
+	}
+
+	if accessKey != "" || secret != "" {
+		config.Credentials = credentials.NewStaticCredentials(accessKey, secret, "")
+	}
+
+	sess, err := session.NewSession(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AwsKmsBackend{
+		keyId:  keyId,
+		client: kms.New(sess),
+	}, nil
+}
+
+// Name implements [Backend.Name].
+func (b *AwsKmsBackend) Name() string {
+	return "awskms"
+}
+
+// Encrypt implements [Backend.Encrypt].
+func (b *AwsKmsBackend) Encrypt(ctx context.Context, plaintext []byte) (string, error) {
+	out, err := b.client.EncryptWithContext(ctx, &kms.EncryptInput{
+		KeyId:     aws.String(b.keyId),
+		Plaintext: plaintext,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(out.CiphertextBlob), nil
+}
+
+// Decrypt implements [Backend.Decrypt].
+func (b *AwsKmsBackend) Decrypt(ctx context.Context, ciphertext string) ([]byte, error) {
+	blob, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := b.client.DecryptWithContext(ctx, &kms.DecryptInput{
+		KeyId:          aws.String(b.keyId),
+		CiphertextBlob: blob,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return out.Plaintext, nil
+}
@@ -0,0 +1,126 @@
+package secrets
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var _ Backend = (*GcpKmsBackend)(nil)
+
+// gcpMetadataTokenUrl is the GCE metadata server endpoint for
+// fetching an access token of the default service account.
+const gcpMetadataTokenUrl = "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/token"
+
+// GcpKmsBackend encrypts the secrets with a Google Cloud KMS symmetric key.
+type GcpKmsBackend struct {
+	// BaseUrl is the Cloud KMS api base url (default to "https://cloudkms.googleapis.com").
+	BaseUrl string
+
+	// TokenFunc returns the OAuth2 access token used to authorize the KMS requests.
+	//
+	// Default to fetching the default service account token from the GCE metadata server.
+	TokenFunc func(ctx context.Context) (string, error)
+
+	keyName string
+	client  *http.Client
+}
+
+// NewGcpKmsBackend creates a new Cloud KMS backend for the specified
+// key resource name (eg. "projects/p/locations/l/keyRings/r/cryptoKeys/k").
+func NewGcpKmsBackend(keyName string) (*GcpKmsBackend, error) {
+	if keyName == "" {
+		return nil, errors.New("missing Cloud KMS key name")
+	}
+
+	b := &GcpKmsBackend{
+		BaseUrl: "https://cloudkms.googleapis.com",
+		keyName: strings.Trim(keyName, "/"),
+		client:  &http.Client{},
+	}
+	b.TokenFunc = b.metadataToken
+
+	return b, nil
+}
+
+// Name implements [Backend.Name].
+func (b *GcpKmsBackend) Name() string {
+	return "gcpkms/" + b.keyName
+}
+
+// Encrypt implements [Backend.Encrypt].
+func (b *GcpKmsBackend) Encrypt(ctx context.Context, plaintext []byte) (string, error) {
+	body := map[string]any{"plaintext": base64.StdEncoding.EncodeToString(plaintext)}
+
+	result := struct {
+		Ciphertext string `json:"ciphertext"`
+	}{}
+
+	if err := b.send(ctx, "encrypt", body, &result); err != nil {
+		return "", err
+	}
+
+	if result.Ciphertext == "" {
+		return "", errors.New("empty Cloud KMS ciphertext")
+	}
+
+	return result.Ciphertext, nil
+}
+
+// Decrypt implements [Backend.Decrypt].
+func (b *GcpKmsBackend) Decrypt(ctx context.Context, ciphertext string) ([]byte, error) {
+	body := map[string]any{"ciphertext": ciphertext}
+
+	result := struct {
+		Plaintext string `json:"plaintext"`
+	}{}
+
+	if err := b.send(ctx, "decrypt", body, &result); err != nil {
+		return nil, err
+	}
+
+	return base64.StdEncoding.DecodeString(result.Plaintext)
+}
+
+func (b *GcpKmsBackend) send(ctx context.Context, action string, body any, result any) error {
+	token, err := b.TokenFunc(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve Cloud KMS access token: %w", err)
+	}
+
+	endpoint := strings.TrimRight(b.BaseUrl, "/") + "/v1/" + b.keyName + ":" + action
+
+	return sendJson(ctx, b.client, endpoint, map[string]string{"Authorization": "Bearer " + token}, body, result)
+}
+
+func (b *GcpKmsBackend) metadataToken(ctx context.Context) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, gcpMetadataTokenUrl, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+
+	res, err := b.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected %d metadata server response", res.StatusCode)
+	}
+
+	result := struct {
+		AccessToken string `json:"access_token"`
+	}{}
+
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return "", err
+	}
+
+	return result.AccessToken, nil
+}
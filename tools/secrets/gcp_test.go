@@ -0,0 +1,74 @@
+package secrets_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/secrets"
+)
+
+func TestGcpKmsBackend(t *testing.T) {
+	const keyName = "projects/p/locations/global/keyRings/r/cryptoKeys/k"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test_token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		body := map[string]string{}
+		json.NewDecoder(r.Body).Decode(&body)
+
+		switch r.URL.Path {
+		case "/v1/" + keyName + ":encrypt":
+			json.NewEncoder(w).Encode(map[string]any{"ciphertext": body["plaintext"]})
+		case "/v1/" + keyName + ":decrypt":
+			json.NewEncoder(w).Encode(map[string]any{"plaintext": body["ciphertext"]})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	if _, err := secrets.NewGcpKmsBackend(""); err == nil {
+		t.Fatal("Expected missing key name error")
+	}
+
+	b, err := secrets.NewGcpKmsBackend(keyName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.BaseUrl = server.URL
+	b.TokenFunc = func(ctx context.Context) (string, error) {
+		return "test_token", nil
+	}
+
+	if name := b.Name(); name != "gcpkms/"+keyName {
+		t.Fatalf("Expected name %q, got %q", "gcpkms/"+keyName, name)
+	}
+
+	ciphertext, err := b.Encrypt(context.Background(), []byte("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := b.Decrypt(context.Background(), ciphertext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "test" {
+		t.Fatalf("Expected %q, got %q", "test", data)
+	}
+
+	// token error
+	b.TokenFunc = func(ctx context.Context) (string, error) {
+		return "", errors.New("test")
+	}
+	if _, err := b.Encrypt(context.Background(), []byte("test")); err == nil {
+		t.Fatal("Expected token error")
+	}
+}
@@ -0,0 +1,48 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// sendJson sends a POST json request with the provided headers
+// and decodes the json response into result.
+func sendJson(
+	ctx context.Context,
+	client *http.Client,
+	endpoint string,
+	headers map[string]string,
+	body any,
+	result any,
+) error {
+	rawBody, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(rawBody))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 400 {
+		return fmt.Errorf("unexpected %d response from %s", res.StatusCode, endpoint)
+	}
+
+	return json.NewDecoder(res.Body).Decode(result)
+}
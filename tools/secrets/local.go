@@ -0,0 +1,48 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+var _ Backend = (*LocalBackend)(nil)
+
+// LocalBackend encrypts the secrets with a local AES-256 key.
+type LocalBackend struct {
+	name string
+	key  string
+}
+
+// NewLocalBackend creates a new local backend with the provided 32 characters key.
+//
+// name is optional and allows distinguishing multiple local keys
+// (default to "local").
+func NewLocalBackend(key string, name ...string) (*LocalBackend, error) {
+	if len(key) != 32 {
+		return nil, errors.New("the local secrets key must be exactly 32 characters")
+	}
+
+	b := &LocalBackend{name: "local", key: key}
+	if len(name) > 0 && name[0] != "" {
+		b.name = name[0]
+	}
+
+	return b, nil
+}
+
+// Name implements [Backend.Name].
+func (b *LocalBackend) Name() string {
+	return b.name
+}
+
+// Encrypt implements [Backend.Encrypt].
+func (b *LocalBackend) Encrypt(ctx context.Context, plaintext []byte) (string, error) {
+	return security.Encrypt(plaintext, b.key)
+}
+
+// Decrypt implements [Backend.Decrypt].
+func (b *LocalBackend) Decrypt(ctx context.Context, ciphertext string) ([]byte, error) {
+	return security.Decrypt(ciphertext, b.key)
+}
@@ -0,0 +1,94 @@
+// Package secrets implements pluggable backends for encrypting
+// sensitive app data (eg. the stored app settings) with an external
+// key management service instead of a single local encryption key.
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// sealedPrefix is the prefix of the values sealed with [Seal].
+const sealedPrefix = "pbsecret:"
+
+// ErrUnknownBackend is returned when trying to open a sealed value
+// without providing the backend used to seal it.
+var ErrUnknownBackend = errors.New("unknown secrets backend")
+
+// Backend defines a common interface for a secrets encryption backend.
+type Backend interface {
+	// Name returns the unique backend identifier that is stored
+	// together with the sealed value (it must not contain ":").
+	Name() string
+
+	// Encrypt encrypts the provided plaintext and returns the ciphertext.
+	Encrypt(ctx context.Context, plaintext []byte) (string, error)
+
+	// Decrypt decrypts the provided ciphertext.
+	Decrypt(ctx context.Context, ciphertext string) ([]byte, error)
+}
+
+// Seal encrypts data with the provided backend and returns a value
+// in the format "pbsecret:<backendName>:<ciphertext>".
+func Seal(ctx context.Context, backend Backend, data []byte) (string, error) {
+	name := backend.Name()
+	if name == "" || strings.Contains(name, ":") {
+		return "", fmt.Errorf("invalid secrets backend name %q", name)
+	}
+
+	ciphertext, err := backend.Encrypt(ctx, data)
+	if err != nil {
+		return "", err
+	}
+
+	return sealedPrefix + name + ":" + ciphertext, nil
+}
+
+// IsSealed checks whether the provided value was created with [Seal].
+func IsSealed(value string) bool {
+	_, _, ok := parseSealed(value)
+
+	return ok
+}
+
+// Open decrypts the provided sealed value with the matching backend
+// from the provided list and returns the decrypted data together
+// with the name of the backend used to seal the value.
+//
+// Returns ErrUnknownBackend if none of the backends match.
+func Open(ctx context.Context, value string, backends ...Backend) ([]byte, string, error) {
+	name, ciphertext, ok := parseSealed(value)
+	if !ok {
+		return nil, "", errors.New("the value is not sealed")
+	}
+
+	for _, backend := range backends {
+		if backend == nil || backend.Name() != name {
+			continue
+		}
+
+		data, err := backend.Decrypt(ctx, ciphertext)
+		if err != nil {
+			return nil, name, err
+		}
+
+		return data, name, nil
+	}
+
+	return nil, name, fmt.Errorf("%w %q", ErrUnknownBackend, name)
+}
+
+func parseSealed(value string) (string, string, bool) {
+	if !strings.HasPrefix(value, sealedPrefix) {
+		return "", "", false
+	}
+
+	name, ciphertext, ok := strings.Cut(value[len(sealedPrefix):], ":")
+	if !ok || name == "" || ciphertext == "" {
+		return "", "", false
+	}
+
+	return name, ciphertext, true
+}
@@ -0,0 +1,104 @@
+package secrets_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/secrets"
+)
+
+func TestSealAndOpen(t *testing.T) {
+	b1, err := secrets.NewLocalBackend(strings.Repeat("a", 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b2, err := secrets.NewLocalBackend(strings.Repeat("b", 32), "local2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sealed, err := secrets.Seal(context.Background(), b1, []byte("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(sealed, "pbsecret:local:") {
+		t.Fatalf("Expected pbsecret:local: prefix, got %q", sealed)
+	}
+
+	if !secrets.IsSealed(sealed) {
+		t.Fatalf("Expected %q to be sealed", sealed)
+	}
+
+	// missing backend
+	_, name, err := secrets.Open(context.Background(), sealed, b2)
+	if !errors.Is(err, secrets.ErrUnknownBackend) {
+		t.Fatalf("Expected ErrUnknownBackend, got %v", err)
+	}
+	if name != "local" {
+		t.Fatalf("Expected backend name %q, got %q", "local", name)
+	}
+
+	data, name, err := secrets.Open(context.Background(), sealed, b2, b1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "test" {
+		t.Fatalf("Expected %q, got %q", "test", data)
+	}
+	if name != "local" {
+		t.Fatalf("Expected backend name %q, got %q", "local", name)
+	}
+}
+
+func TestIsSealed(t *testing.T) {
+	scenarios := []struct {
+		value    string
+		expected bool
+	}{
+		{"", false},
+		{"test", false},
+		{"pbsecret:", false},
+		{"pbsecret:local", false},
+		{"pbsecret:local:", false},
+		{"pbsecret::abc", false},
+		{"pbsecret:local:abc", true},
+		{"pbsecret:vault/test:vault:v1:abc", true},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.value, func(t *testing.T) {
+			if result := secrets.IsSealed(s.value); result != s.expected {
+				t.Fatalf("Expected %v, got %v", s.expected, result)
+			}
+		})
+	}
+}
+
+func TestNewLocalBackend(t *testing.T) {
+	if _, err := secrets.NewLocalBackend("short"); err == nil {
+		t.Fatal("Expected invalid key length error")
+	}
+
+	b, err := secrets.NewLocalBackend(strings.Repeat("a", 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ciphertext, err := b.Encrypt(context.Background(), []byte("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := b.Decrypt(context.Background(), ciphertext)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(data) != "test" {
+		t.Fatalf("Expected %q, got %q", "test", data)
+	}
+}
@@ -0,0 +1,99 @@
+package secrets
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+var _ Backend = (*VaultTransitBackend)(nil)
+
+// VaultTransitBackend encrypts the secrets with the
+// Hashicorp Vault transit secrets engine.
+type VaultTransitBackend struct {
+	// Mount is the transit secrets engine mount path (default to "transit").
+	Mount string
+
+	addr    string
+	token   string
+	keyName string
+	client  *http.Client
+}
+
+// NewVaultTransitBackend creates a new Vault transit backend
+// for the specified Vault address (eg. "https://vault.example.com:8200"),
+// auth token and transit key name.
+func NewVaultTransitBackend(addr string, token string, keyName string) (*VaultTransitBackend, error) {
+	if _, err := url.Parse(addr); err != nil {
+		return nil, err
+	}
+
+	if keyName == "" {
+		return nil, errors.New("missing Vault transit key name")
+	}
+
+	return &VaultTransitBackend{
+		Mount:   "transit",
+		addr:    strings.TrimRight(addr, "/"),
+		token:   token,
+		keyName: keyName,
+		client:  &http.Client{},
+	}, nil
+}
+
+// Name implements [Backend.Name].
+func (b *VaultTransitBackend) Name() string {
+	return "vault/" + b.keyName
+}
+
+// Encrypt implements [Backend.Encrypt].
+func (b *VaultTransitBackend) Encrypt(ctx context.Context, plaintext []byte) (string, error) {
+	body := map[string]any{"plaintext": base64.StdEncoding.EncodeToString(plaintext)}
+
+	result := struct {
+		Data struct {
+			Ciphertext string `json:"ciphertext"`
+		} `json:"data"`
+	}{}
+
+	if err := b.send(ctx, "encrypt", body, &result); err != nil {
+		return "", err
+	}
+
+	if result.Data.Ciphertext == "" {
+		return "", errors.New("empty Vault ciphertext")
+	}
+
+	return result.Data.Ciphertext, nil
+}
+
+// Decrypt implements [Backend.Decrypt].
+func (b *VaultTransitBackend) Decrypt(ctx context.Context, ciphertext string) ([]byte, error) {
+	body := map[string]any{"ciphertext": ciphertext}
+
+	result := struct {
+		Data struct {
+			Plaintext string `json:"plaintext"`
+		} `json:"data"`
+	}{}
+
+	if err := b.send(ctx, "decrypt", body, &result); err != nil {
+		return nil, err
+	}
+
+	return base64.StdEncoding.DecodeString(result.Data.Plaintext)
+}
+
+func (b *VaultTransitBackend) send(ctx context.Context, action string, body any, result any) error {
+	mount := strings.Trim(b.Mount, "/")
+	if mount == "" {
+		mount = "transit"
+	}
+
+	endpoint := b.addr + "/v1/" + mount + "/" + action + "/" + url.PathEscape(b.keyName)
+
+	return sendJson(ctx, b.client, endpoint, map[string]string{"X-Vault-Token": b.token}, body, result)
+}
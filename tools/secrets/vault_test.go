@@ -0,0 +1,73 @@
+package secrets_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/secrets"
+)
+
+func TestVaultTransitBackend(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "test_token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		body := map[string]string{}
+		json.NewDecoder(r.Body).Decode(&body)
+
+		switch r.URL.Path {
+		case "/v1/transit/encrypt/test_key":
+			json.NewEncoder(w).Encode(map[string]any{
+				"data": map[string]any{"ciphertext": "vault:v1:" + body["plaintext"]},
+			})
+		case "/v1/transit/decrypt/test_key":
+			json.NewEncoder(w).Encode(map[string]any{
+				"data": map[string]any{"plaintext": strings.TrimPrefix(body["ciphertext"], "vault:v1:")},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	if _, err := secrets.NewVaultTransitBackend(server.URL, "test_token", ""); err == nil {
+		t.Fatal("Expected missing key name error")
+	}
+
+	b, err := secrets.NewVaultTransitBackend(server.URL, "test_token", "test_key")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if name := b.Name(); name != "vault/test_key" {
+		t.Fatalf("Expected name %q, got %q", "vault/test_key", name)
+	}
+
+	ciphertext, err := b.Encrypt(context.Background(), []byte("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(ciphertext, "vault:v1:") {
+		t.Fatalf("Expected vault ciphertext, got %q", ciphertext)
+	}
+
+	data, err := b.Decrypt(context.Background(), ciphertext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "test" {
+		t.Fatalf("Expected %q, got %q", "test", data)
+	}
+
+	// invalid token
+	invalid, _ := secrets.NewVaultTransitBackend(server.URL, "invalid", "test_key")
+	if _, err := invalid.Encrypt(context.Background(), []byte("test")); err == nil {
+		t.Fatal("Expected forbidden error")
+	}
+}
@@ -0,0 +1,77 @@
+// Package secretscan implements a simple pattern based scanner for
+// detecting embedded credentials (api keys, private keys, tokens, etc.).
+package secretscan
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Rule defines a single secret detection rule.
+type Rule struct {
+	// Name is a short human readable identifier of the rule (eg. "aws_access_key").
+	Name string
+
+	// Pattern is the regular expression that matches the secret.
+	Pattern *regexp.Regexp
+}
+
+// Finding defines a single detected secret.
+type Finding struct {
+	// Rule is the name of the matched rule.
+	Rule string `json:"rule"`
+
+	// Line is the 1-based line number of the match.
+	Line int `json:"line"`
+
+	// Match is the masked matched value (safe for logging).
+	Match string `json:"match"`
+}
+
+// DefaultRules is the list of the builtin secret detection rules.
+var DefaultRules = []Rule{
+	{"aws_access_key", regexp.MustCompile(`\b(?:AKIA|ASIA|ABIA|ACCA)[0-9A-Z]{16}\b`)},
+	{"aws_secret_key", regexp.MustCompile(`(?i)aws.{0,20}?['"][0-9a-zA-Z/+]{40}['"]`)},
+	{"private_key", regexp.MustCompile(`-----BEGIN (?:[A-Z]+ )?PRIVATE KEY-----`)},
+	{"github_token", regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36,}\b`)},
+	{"slack_token", regexp.MustCompile(`\bxox[baprs]-[A-Za-z0-9-]{10,}`)},
+	{"stripe_key", regexp.MustCompile(`\b[sr]k_live_[0-9a-zA-Z]{24,}\b`)},
+	{"google_api_key", regexp.MustCompile(`\bAIza[0-9A-Za-z\-_]{35}\b`)},
+	{"jwt", regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{10,}\.eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}`)},
+	{"url_credentials", regexp.MustCompile(`\b[a-zA-Z][a-zA-Z0-9+.-]*://[^/\s:@'"]+:[^/\s:@'"]+@`)},
+	{"generic_secret", regexp.MustCompile(`(?i)(?:password|passwd|secret|api_?key|access_?token)\\?['"]?\s*[:=]\s*\\?['"][^'"\s\\]{8,}\\?['"]`)},
+}
+
+// Scan scans the provided content and returns the found secrets.
+//
+// If no rules are specified, [DefaultRules] are used.
+func Scan(content string, rules ...Rule) []Finding {
+	if len(rules) == 0 {
+		rules = DefaultRules
+	}
+
+	var result []Finding
+
+	for _, rule := range rules {
+		for _, loc := range rule.Pattern.FindAllStringIndex(content, -1) {
+			result = append(result, Finding{
+				Rule:  rule.Name,
+				Line:  strings.Count(content[:loc[0]], "\n") + 1,
+				Match: mask(content[loc[0]:loc[1]]),
+			})
+		}
+	}
+
+	return result
+}
+
+// mask replaces all except the first 4 characters of s with asterisks.
+func mask(s string) string {
+	const visible = 4
+
+	if len(s) <= visible {
+		return strings.Repeat("*", len(s))
+	}
+
+	return s[:visible] + strings.Repeat("*", min(len(s)-visible, 16))
+}
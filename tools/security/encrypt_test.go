@@ -54,6 +54,7 @@ func TestDecrypt(t *testing.T) {
 		{"", "", true, ""},
 		{"123", "test", true, ""}, // key must be valid 32 char aes string
 		{"8kcEqilvvYKYcfnSr0aSC54gmnQCsB02SaB8ATlnA==", "abcdabcdabcdabcdabcdabcdabcdabcd", true, ""}, // illegal base64 encoded cipherText
+		{"dGVzdA==", "abcdabcdabcdabcdabcdabcdabcdabcd", true, ""},                                    // cipherText shorter than the nonce
 		{"8kcEqilvv+YKYcfnSr0aSC54gmnQCsB02SaB8ATlnA==", "abcdabcdabcdabcdabcdabcdabcdabcd", false, "123"},
 	}
 
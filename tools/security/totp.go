@@ -0,0 +1,118 @@
+package security
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// TOTPDigits is the number of digits of the generated TOTP codes.
+	TOTPDigits = 6
+
+	// TOTPPeriod is the TOTP time step duration in seconds.
+	TOTPPeriod = 30
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// NewTOTPSecret generates a new random base32 encoded
+// (without padding) 160-bit TOTP shared secret.
+//
+// It panics if for some reason rand.Read returns a non-nil error.
+func NewTOTPSecret() string {
+	b := make([]byte, 20)
+
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+
+	return totpEncoding.EncodeToString(b)
+}
+
+// TOTPStep returns the TOTP time step counter for the provided time.
+func TOTPStep(t time.Time) int64 {
+	return t.Unix() / TOTPPeriod
+}
+
+// TOTPCode generates the TOTP code of the provided base32 encoded secret
+// and time step counter as defined in [RFC 6238] (HMAC-SHA1, 6 digits).
+//
+// [RFC 6238]: https://datatracker.ietf.org/doc/html/rfc6238
+func TOTPCode(secret string, step int64) (string, error) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+	if err != nil {
+		return "", err
+	}
+
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(step))
+
+	h := hmac.New(sha1.New, key)
+	h.Write(msg)
+	sum := h.Sum(nil)
+
+	// dynamic truncation (RFC 4226 section 5.3)
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%0*d", TOTPDigits, value%1000000), nil
+}
+
+// ValidateTOTPCode checks whether the provided code is valid for the
+// secret at the specified time, allowing skew time steps of clock drift
+// in each direction.
+//
+// On success returns the matched time step counter (could be used to
+// prevent the code reuse), otherwise returns -1.
+func ValidateTOTPCode(secret string, code string, t time.Time, skew int) int64 {
+	code = strings.TrimSpace(code)
+	if len(code) != TOTPDigits {
+		return -1
+	}
+
+	current := TOTPStep(t)
+
+	for i := -skew; i <= skew; i++ {
+		step := current + int64(i)
+
+		expected, err := TOTPCode(secret, step)
+		if err != nil {
+			return -1
+		}
+
+		if Equal(expected, code) {
+			return step
+		}
+	}
+
+	return -1
+}
+
+// TOTPURI returns the "otpauth://" key URI of the provided TOTP secret
+// that is usually encoded as QR code for the authenticator apps.
+//
+// See https://github.com/google/google-authenticator/wiki/Key-Uri-Format.
+func TOTPURI(issuer string, account string, secret string) string {
+	label := url.PathEscape(account)
+	if issuer != "" {
+		label = url.PathEscape(issuer) + ":" + label
+	}
+
+	params := url.Values{}
+	params.Set("secret", secret)
+	if issuer != "" {
+		params.Set("issuer", issuer)
+	}
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprint(TOTPDigits))
+	params.Set("period", fmt.Sprint(TOTPPeriod))
+
+	return "otpauth://totp/" + label + "?" + params.Encode()
+}
@@ -0,0 +1,113 @@
+package security_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/security"
+)
+
+// RFC 6238 test secret ("12345678901234567890" base32 encoded)
+const testTOTPSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestNewTOTPSecret(t *testing.T) {
+	s1 := security.NewTOTPSecret()
+	s2 := security.NewTOTPSecret()
+
+	if len(s1) != 32 {
+		t.Fatalf("Expected 32 characters secret, got %q", s1)
+	}
+
+	if s1 == s2 {
+		t.Fatalf("Expected different secrets, got %q", s1)
+	}
+
+	if _, err := security.TOTPCode(s1, 1); err != nil {
+		t.Fatalf("Expected valid base32 secret, got error %v", err)
+	}
+}
+
+func TestTOTPCode(t *testing.T) {
+	scenarios := []struct {
+		secret      string
+		unix        int64
+		expected    string
+		expectError bool
+	}{
+		{"invalid!", 59, "", true},
+		{testTOTPSecret, 59, "287082", false},
+		{strings.ToLower(testTOTPSecret), 59, "287082", false},
+		{testTOTPSecret + "====", 59, "287082", false},
+		{testTOTPSecret, 1111111109, "081804", false},
+		{testTOTPSecret, 1111111111, "050471", false},
+		{testTOTPSecret, 1234567890, "005924", false},
+		{testTOTPSecret, 2000000000, "279037", false},
+	}
+
+	for i, s := range scenarios {
+		code, err := security.TOTPCode(s.secret, security.TOTPStep(time.Unix(s.unix, 0)))
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("(%d) Expected hasErr %v, got %v (%v)", i, s.expectError, hasErr, err)
+			continue
+		}
+
+		if code != s.expected {
+			t.Errorf("(%d) Expected %q, got %q", i, s.expected, code)
+		}
+	}
+}
+
+func TestValidateTOTPCode(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	step := security.TOTPStep(now)
+
+	scenarios := []struct {
+		code     string
+		skew     int
+		expected int64
+	}{
+		{"", 1, -1},
+		{"12345", 1, -1},
+		{"000000", 1, -1},
+		{"050471", 0, step},
+		{" 050471 ", 0, step},
+		{"081804", 0, -1}, // previous step
+		{"081804", 1, step - 1},
+	}
+
+	for i, s := range scenarios {
+		result := security.ValidateTOTPCode(testTOTPSecret, s.code, now, s.skew)
+		if result != s.expected {
+			t.Errorf("(%d) Expected %d, got %d", i, s.expected, result)
+		}
+	}
+}
+
+func TestTOTPURI(t *testing.T) {
+	scenarios := []struct {
+		issuer   string
+		account  string
+		expected string
+	}{
+		{
+			"",
+			"test@example.com",
+			"otpauth://totp/test@example.com?algorithm=SHA1&digits=6&period=30&secret=ABC",
+		},
+		{
+			"My App",
+			"test@example.com",
+			"otpauth://totp/My%20App:test@example.com?algorithm=SHA1&digits=6&issuer=My+App&period=30&secret=ABC",
+		},
+	}
+
+	for i, s := range scenarios {
+		result := security.TOTPURI(s.issuer, s.account, "ABC")
+		if result != s.expected {
+			t.Errorf("(%d) Expected \n%s, got \n%s", i, s.expected, result)
+		}
+	}
+}
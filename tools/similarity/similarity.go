@@ -0,0 +1,91 @@
+// Package similarity implements helpers for detecting
+// probable duplicate (aka. similar) text values.
+package similarity
+
+import (
+	"strings"
+	"unicode"
+)
+
+// gmailDomains lists the email domains that ignore the local part dots.
+var gmailDomains = map[string]struct{}{
+	"gmail.com":      {},
+	"googlemail.com": {},
+}
+
+// Trigrams returns the set of the 3-character sequences of the
+// normalized value words (similar to the PostgreSQL pg_trgm extension).
+//
+// The value is lowercased and each alphanumeric word is padded
+// with two spaces in front and one at the end.
+func Trigrams(value string) map[string]struct{} {
+	result := map[string]struct{}{}
+
+	words := strings.FieldsFunc(strings.ToLower(value), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	for _, word := range words {
+		runes := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(runes); i++ {
+			result[string(runes[i:i+3])] = struct{}{}
+		}
+	}
+
+	return result
+}
+
+// TrigramSimilarity returns the similarity of the 2 trigram
+// sets as a number between 0 (no shared trigrams) and 1 (same sets).
+func TrigramSimilarity(a, b map[string]struct{}) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	var shared int
+	for t := range a {
+		if _, ok := b[t]; ok {
+			shared++
+		}
+	}
+
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+// NormalizeEmail returns the canonical form of the provided email address
+// (lowercased, without the "+tag" suffix and the gmail local part dots).
+//
+// Returns an empty string if the value is not an email address.
+func NormalizeEmail(email string) string {
+	local, domain, ok := strings.Cut(strings.ToLower(strings.TrimSpace(email)), "@")
+	if !ok || local == "" || domain == "" {
+		return ""
+	}
+
+	local, _, _ = strings.Cut(local, "+")
+
+	if _, ok := gmailDomains[domain]; ok {
+		local = strings.ReplaceAll(local, ".", "")
+		domain = "gmail.com"
+	}
+
+	if local == "" {
+		return ""
+	}
+
+	return local + "@" + domain
+}
+
+// NormalizePhone returns only the digits of the provided phone number
+// (the international "00" prefix is removed).
+func NormalizePhone(phone string) string {
+	var sb strings.Builder
+
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			sb.WriteRune(r)
+		}
+	}
+
+	return strings.TrimPrefix(sb.String(), "00")
+}
@@ -0,0 +1,110 @@
+package similarity_test
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/similarity"
+)
+
+func TestTrigrams(t *testing.T) {
+	scenarios := []struct {
+		value    string
+		expected []string
+	}{
+		{"", nil},
+		{"  ", nil},
+		{"a", []string{"  a", " a "}},
+		{"Cat", []string{"  c", " ca", "at ", "cat"}},
+		{"ab-AB", []string{"  a", " ab", "ab "}},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.value, func(t *testing.T) {
+			result := []string{}
+			for trigram := range similarity.Trigrams(s.value) {
+				result = append(result, trigram)
+			}
+			sort.Strings(result)
+
+			if strings.Join(result, "|") != strings.Join(s.expected, "|") {
+				t.Fatalf("Expected %q, got %q", s.expected, result)
+			}
+		})
+	}
+}
+
+func TestTrigramSimilarity(t *testing.T) {
+	scenarios := []struct {
+		a        string
+		b        string
+		expected float64
+	}{
+		{"", "", 0},
+		{"test", "", 0},
+		{"test", "TEST", 1},
+		{"John Smith", "smith john", 1},
+		{"abc", "xyz", 0},
+		{"cat", "cap", 1.0 / 3}, // shared "  c" and " ca" out of 6 unique
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.a+"_"+s.b, func(t *testing.T) {
+			result := similarity.TrigramSimilarity(similarity.Trigrams(s.a), similarity.Trigrams(s.b))
+
+			if fmt.Sprintf("%.4f", result) != fmt.Sprintf("%.4f", s.expected) {
+				t.Fatalf("Expected %v, got %v", s.expected, result)
+			}
+		})
+	}
+}
+
+func TestNormalizeEmail(t *testing.T) {
+	scenarios := []struct {
+		email    string
+		expected string
+	}{
+		{"", ""},
+		{"invalid", ""},
+		{"@example.com", ""},
+		{"+tag@example.com", ""},
+		{" Test@Example.com ", "test@example.com"},
+		{"test+tag@example.com", "test@example.com"},
+		{"t.e.s.t@example.com", "t.e.s.t@example.com"},
+		{"T.e.s.t+tag@gmail.com", "test@gmail.com"},
+		{"test@googlemail.com", "test@gmail.com"},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.email, func(t *testing.T) {
+			result := similarity.NormalizeEmail(s.email)
+			if result != s.expected {
+				t.Fatalf("Expected %q, got %q", s.expected, result)
+			}
+		})
+	}
+}
+
+func TestNormalizePhone(t *testing.T) {
+	scenarios := []struct {
+		phone    string
+		expected string
+	}{
+		{"", ""},
+		{"abc", ""},
+		{"+1 (555) 123-4567", "15551234567"},
+		{"001 555 123 4567", "15551234567"},
+		{"555.123.4567", "5551234567"},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.phone, func(t *testing.T) {
+			result := similarity.NormalizePhone(s.phone)
+			if result != s.expected {
+				t.Fatalf("Expected %q, got %q", s.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,247 @@
+// Package siwe implements the Sign-In with Ethereum (EIP-4361)
+// message parsing and the wallet signature verification.
+package siwe
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	headerSuffix = " wants you to sign in with your Ethereum account:"
+
+	prefixURI            = "URI: "
+	prefixVersion        = "Version: "
+	prefixChainId        = "Chain ID: "
+	prefixNonce          = "Nonce: "
+	prefixIssuedAt       = "Issued At: "
+	prefixExpirationTime = "Expiration Time: "
+	prefixNotBefore      = "Not Before: "
+	prefixRequestId      = "Request ID: "
+	prefixResources      = "Resources:"
+	prefixResource       = "- "
+)
+
+var (
+	addressRegex = regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+	nonceRegex   = regexp.MustCompile(`^[a-zA-Z0-9]{8,}$`)
+)
+
+// Message defines a single EIP-4361 Sign-In with Ethereum message.
+//
+// The optional time fields are zero when not set.
+type Message struct {
+	Scheme         string
+	Domain         string
+	Address        string
+	Statement      string
+	URI            string
+	Version        string
+	ChainId        int
+	Nonce          string
+	IssuedAt       time.Time
+	ExpirationTime time.Time
+	NotBefore      time.Time
+	RequestId      string
+	Resources      []string
+}
+
+// ParseMessage parses and validates the format of the provided
+// raw EIP-4361 message.
+//
+// Note that the message signature, domain, nonce and time
+// constraints are not checked here (see [Message.ValidateAt]).
+func ParseMessage(raw string) (*Message, error) {
+	lines := strings.Split(raw, "\n")
+
+	m := &Message{}
+
+	next := func() (string, bool) {
+		if len(lines) == 0 {
+			return "", false
+		}
+		line := lines[0]
+		lines = lines[1:]
+		return line, true
+	}
+
+	// header
+	header, _ := next()
+	domain, ok := strings.CutSuffix(header, headerSuffix)
+	if !ok || domain == "" {
+		return nil, errors.New("siwe: invalid message header")
+	}
+	if scheme, rest, found := strings.Cut(domain, "://"); found {
+		m.Scheme = scheme
+		domain = rest
+	}
+	if domain == "" || strings.ContainsAny(domain, " /") {
+		return nil, errors.New("siwe: invalid message domain")
+	}
+	m.Domain = domain
+
+	// address
+	m.Address, _ = next()
+	if !IsAddress(m.Address) {
+		return nil, errors.New("siwe: invalid message address")
+	}
+
+	if line, _ := next(); line != "" {
+		return nil, errors.New("siwe: missing empty line after the address")
+	}
+
+	// optional statement
+	line, _ := next()
+	if line != "" {
+		m.Statement = line
+		if line, _ = next(); line != "" {
+			return nil, errors.New("siwe: missing empty line after the statement")
+		}
+	}
+
+	// required fields
+	required := []struct {
+		prefix string
+		value  *string
+	}{
+		{prefixURI, &m.URI},
+		{prefixVersion, &m.Version},
+	}
+	for _, field := range required {
+		line, _ = next()
+		value, ok := strings.CutPrefix(line, field.prefix)
+		if !ok || value == "" {
+			return nil, fmt.Errorf("siwe: missing or invalid %q field", strings.TrimSpace(field.prefix))
+		}
+		*field.value = value
+	}
+
+	if m.Version != "1" {
+		return nil, errors.New("siwe: unsupported message version")
+	}
+
+	line, _ = next()
+	rawChainId, ok := strings.CutPrefix(line, prefixChainId)
+	chainId, err := strconv.Atoi(rawChainId)
+	if !ok || err != nil || chainId <= 0 {
+		return nil, errors.New("siwe: missing or invalid \"Chain ID:\" field")
+	}
+	m.ChainId = chainId
+
+	line, _ = next()
+	m.Nonce, ok = strings.CutPrefix(line, prefixNonce)
+	if !ok || !nonceRegex.MatchString(m.Nonce) {
+		return nil, errors.New("siwe: missing or invalid \"Nonce:\" field")
+	}
+
+	line, _ = next()
+	rawIssuedAt, ok := strings.CutPrefix(line, prefixIssuedAt)
+	if m.IssuedAt, err = time.Parse(time.RFC3339, rawIssuedAt); !ok || err != nil {
+		return nil, errors.New("siwe: missing or invalid \"Issued At:\" field")
+	}
+
+	// optional fields (in the spec order)
+	line, ok = next()
+
+	if value, found := strings.CutPrefix(line, prefixExpirationTime); found {
+		if m.ExpirationTime, err = time.Parse(time.RFC3339, value); err != nil {
+			return nil, errors.New("siwe: invalid \"Expiration Time:\" field")
+		}
+		line, ok = next()
+	}
+
+	if value, found := strings.CutPrefix(line, prefixNotBefore); found {
+		if m.NotBefore, err = time.Parse(time.RFC3339, value); err != nil {
+			return nil, errors.New("siwe: invalid \"Not Before:\" field")
+		}
+		line, ok = next()
+	}
+
+	if value, found := strings.CutPrefix(line, prefixRequestId); found {
+		m.RequestId = value
+		line, ok = next()
+	}
+
+	if line == prefixResources {
+		for {
+			line, ok = next()
+			resource, found := strings.CutPrefix(line, prefixResource)
+			if !found {
+				break
+			}
+			m.Resources = append(m.Resources, resource)
+		}
+	}
+
+	// allow a single trailing new line
+	if ok && (line != "" || len(lines) > 0) {
+		return nil, fmt.Errorf("siwe: unexpected message line %q", line)
+	}
+
+	return m, nil
+}
+
+// String returns the EIP-4361 formatted message.
+func (m *Message) String() string {
+	var sb strings.Builder
+
+	if m.Scheme != "" {
+		sb.WriteString(m.Scheme)
+		sb.WriteString("://")
+	}
+	sb.WriteString(m.Domain)
+	sb.WriteString(headerSuffix)
+	sb.WriteString("\n")
+	sb.WriteString(m.Address)
+	sb.WriteString("\n\n")
+	if m.Statement != "" {
+		sb.WriteString(m.Statement)
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString(prefixURI + m.URI + "\n")
+	sb.WriteString(prefixVersion + m.Version + "\n")
+	sb.WriteString(prefixChainId + strconv.Itoa(m.ChainId) + "\n")
+	sb.WriteString(prefixNonce + m.Nonce + "\n")
+	sb.WriteString(prefixIssuedAt + m.IssuedAt.UTC().Format(time.RFC3339))
+
+	if !m.ExpirationTime.IsZero() {
+		sb.WriteString("\n" + prefixExpirationTime + m.ExpirationTime.UTC().Format(time.RFC3339))
+	}
+
+	if !m.NotBefore.IsZero() {
+		sb.WriteString("\n" + prefixNotBefore + m.NotBefore.UTC().Format(time.RFC3339))
+	}
+
+	if m.RequestId != "" {
+		sb.WriteString("\n" + prefixRequestId + m.RequestId)
+	}
+
+	if len(m.Resources) > 0 {
+		sb.WriteString("\n" + prefixResources)
+		for _, resource := range m.Resources {
+			sb.WriteString("\n" + prefixResource + resource)
+		}
+	}
+
+	return sb.String()
+}
+
+// ValidateAt checks whether the message time constraints
+// (expiration time and not before) are satisfied at the specified time.
+func (m *Message) ValidateAt(t time.Time) error {
+	if !m.ExpirationTime.IsZero() && !t.Before(m.ExpirationTime) {
+		return errors.New("siwe: the message has expired")
+	}
+
+	if !m.NotBefore.IsZero() && t.Before(m.NotBefore) {
+		return errors.New("siwe: the message is not valid yet")
+	}
+
+	return nil
+}
@@ -0,0 +1,153 @@
+package siwe_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/siwe"
+)
+
+func TestParseMessage(t *testing.T) {
+	full := strings.Join([]string{
+		"https://example.com wants you to sign in with your Ethereum account:",
+		"0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf",
+		"",
+		"I accept the Terms of Service.",
+		"",
+		"URI: https://example.com/login",
+		"Version: 1",
+		"Chain ID: 10",
+		"Nonce: 12345678abc",
+		"Issued At: 2023-12-01T10:00:00Z",
+		"Expiration Time: 2023-12-01T11:00:00Z",
+		"Not Before: 2023-12-01T10:05:00Z",
+		"Request ID: req-1",
+		"Resources:",
+		"- ipfs://bafybeiemxf5abjwjbikoz4mc3a3dla6ual3jsgpdr4cjr3oz3evfyavhwq/",
+		"- https://example.com/my-web2-claim.json",
+	}, "\n")
+
+	scenarios := []struct {
+		name        string
+		raw         string
+		expectError bool
+	}{
+		{"empty", "", true},
+		{"invalid header", strings.Replace(full, "wants you", "want you", 1), true},
+		{"missing domain", strings.Replace(full, "https://example.com wants", " wants", 1), true},
+		{"invalid address", strings.Replace(full, "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf", "0x123", 1), true},
+		{"missing uri", strings.Replace(full, "URI: https://example.com/login\n", "", 1), true},
+		{"invalid version", strings.Replace(full, "Version: 1", "Version: 2", 1), true},
+		{"invalid chain id", strings.Replace(full, "Chain ID: 10", "Chain ID: abc", 1), true},
+		{"short nonce", strings.Replace(full, "Nonce: 12345678abc", "Nonce: 1234", 1), true},
+		{"non alphanumeric nonce", strings.Replace(full, "Nonce: 12345678abc", "Nonce: 12345678-abc", 1), true},
+		{"invalid issued at", strings.Replace(full, "2023-12-01T10:00:00Z", "2023-12-01", 1), true},
+		{"invalid expiration time", strings.Replace(full, "2023-12-01T11:00:00Z", "tomorrow", 1), true},
+		{"unordered optional fields", strings.Replace(full, "Request ID: req-1\n", "", 1) + "\nRequest ID: req-1", true},
+		{"unknown trailing line", full + "\nunknown", true},
+		{"trailing new line", full + "\n", false},
+		{"full message", full, false},
+		{
+			"minimal message without statement",
+			strings.Join([]string{
+				"example.com wants you to sign in with your Ethereum account:",
+				"0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf",
+				"",
+				"",
+				"URI: https://example.com/login",
+				"Version: 1",
+				"Chain ID: 1",
+				"Nonce: 12345678abc",
+				"Issued At: 2023-12-01T10:00:00Z",
+			}, "\n"),
+			false,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			m, err := siwe.ParseMessage(s.raw)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if hasErr {
+				return
+			}
+
+			// the parsed message should be serialized back to the same raw string
+			if str := m.String(); str != strings.TrimSuffix(s.raw, "\n") {
+				t.Fatalf("Expected the serialized message to be\n%q\ngot\n%q", s.raw, str)
+			}
+		})
+	}
+
+	// fields check
+	m, err := siwe.ParseMessage(full)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checks := []struct {
+		name     string
+		value    any
+		expected any
+	}{
+		{"Scheme", m.Scheme, "https"},
+		{"Domain", m.Domain, "example.com"},
+		{"Address", m.Address, "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf"},
+		{"Statement", m.Statement, "I accept the Terms of Service."},
+		{"URI", m.URI, "https://example.com/login"},
+		{"Version", m.Version, "1"},
+		{"ChainId", m.ChainId, 10},
+		{"Nonce", m.Nonce, "12345678abc"},
+		{"IssuedAt", m.IssuedAt.Format(time.RFC3339), "2023-12-01T10:00:00Z"},
+		{"ExpirationTime", m.ExpirationTime.Format(time.RFC3339), "2023-12-01T11:00:00Z"},
+		{"NotBefore", m.NotBefore.Format(time.RFC3339), "2023-12-01T10:05:00Z"},
+		{"RequestId", m.RequestId, "req-1"},
+		{"Resources", len(m.Resources), 2},
+	}
+
+	for _, c := range checks {
+		if c.value != c.expected {
+			t.Errorf("Expected %s %v, got %v", c.name, c.expected, c.value)
+		}
+	}
+}
+
+func TestMessageValidateAt(t *testing.T) {
+	issuedAt, _ := time.Parse(time.RFC3339, "2023-12-01T10:00:00Z")
+
+	m := &siwe.Message{
+		IssuedAt:       issuedAt,
+		NotBefore:      issuedAt.Add(5 * time.Minute),
+		ExpirationTime: issuedAt.Add(time.Hour),
+	}
+
+	scenarios := []struct {
+		at          time.Time
+		expectError bool
+	}{
+		{issuedAt, true},
+		{issuedAt.Add(5 * time.Minute), false},
+		{issuedAt.Add(59 * time.Minute), false},
+		{issuedAt.Add(time.Hour), true},
+	}
+
+	for i, s := range scenarios {
+		err := m.ValidateAt(s.at)
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("(%d) Expected hasErr %v, got %v (%v)", i, s.expectError, hasErr, err)
+		}
+	}
+
+	// no time constraints
+	if err := (&siwe.Message{}).ValidateAt(time.Now()); err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+}
@@ -0,0 +1,112 @@
+package siwe
+
+import (
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"golang.org/x/crypto/sha3"
+)
+
+// IsAddress reports whether the provided string is a valid
+// 0x prefixed hex encoded Ethereum address.
+//
+// The address checksum case is not enforced.
+func IsAddress(address string) bool {
+	return addressRegex.MatchString(address)
+}
+
+// ChecksumAddress returns the EIP-55 mixed-case checksum
+// encoding of the provided Ethereum address.
+func ChecksumAddress(address string) (string, error) {
+	if !IsAddress(address) {
+		return "", errors.New("siwe: invalid address")
+	}
+
+	lower := strings.ToLower(address[2:])
+	hash := hex.EncodeToString(keccak256([]byte(lower)))
+
+	result := []byte(lower)
+	for i, c := range result {
+		if c >= 'a' && hash[i] >= '8' {
+			result[i] = c - 32
+		}
+	}
+
+	return "0x" + string(result), nil
+}
+
+// HashMessage returns the EIP-191 (aka. "personal_sign") hash
+// of the provided message.
+func HashMessage(message string) []byte {
+	prefix := "\x19Ethereum Signed Message:\n" + strconv.Itoa(len(message))
+
+	return keccak256([]byte(prefix), []byte(message))
+}
+
+// RecoverAddress recovers the EIP-55 checksum address of the account
+// that signed the provided message with the "personal_sign" method.
+//
+// The signature is expected to be the 65 bytes hex encoded r, s, v
+// concatenation (the v recovery id could be either 27/28 or 0/1).
+// Signatures with s value in the upper half of the curve order are rejected.
+func RecoverAddress(message string, signature string) (string, error) {
+	sig, err := hex.DecodeString(strings.TrimPrefix(signature, "0x"))
+	if err != nil || len(sig) != 65 {
+		return "", errors.New("siwe: invalid signature format")
+	}
+
+	v := sig[64]
+	if v >= 27 {
+		v -= 27
+	}
+	if v > 1 {
+		return "", errors.New("siwe: invalid signature recovery id")
+	}
+
+	var s secp256k1.ModNScalar
+	if overflow := s.SetByteSlice(sig[32:64]); overflow || s.IsOverHalfOrder() {
+		return "", errors.New("siwe: invalid signature s value")
+	}
+
+	// convert to the "<27 + recovery id><r><s>" compact signature format
+	compact := make([]byte, 65)
+	compact[0] = 27 + v
+	copy(compact[1:], sig[:64])
+
+	pub, _, err := ecdsa.RecoverCompact(compact, HashMessage(message))
+	if err != nil {
+		return "", errors.New("siwe: failed to recover the public key")
+	}
+
+	// uncompressed public key without the 0x04 prefix
+	raw := pub.SerializeUncompressed()[1:]
+
+	return ChecksumAddress("0x" + hex.EncodeToString(keccak256(raw)[12:]))
+}
+
+// VerifySignature checks whether the provided signature of the
+// message was created by the specified address.
+func VerifySignature(message string, signature string, address string) error {
+	recovered, err := RecoverAddress(message, signature)
+	if err != nil {
+		return err
+	}
+
+	if !strings.EqualFold(recovered, address) {
+		return errors.New("siwe: the signature doesn't match the address")
+	}
+
+	return nil
+}
+
+func keccak256(data ...[]byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	for _, d := range data {
+		h.Write(d)
+	}
+	return h.Sum(nil)
+}
@@ -0,0 +1,169 @@
+package siwe_test
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/siwe"
+)
+
+const (
+	testMessage1 = "localhost:8090 wants you to sign in with your Ethereum account:\n0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf\n\nSign in to the test app.\n\nURI: http://localhost:8090\nVersion: 1\nChain ID: 1\nNonce: 32891756aBcDeF01\nIssued At: 2023-12-01T10:00:00Z"
+	testSig1     = "0xa33a3e0146175b80b553f83def4d77e75cf8627599c4a9c80d7988846897c55c14bce9ea27b18fea8b88a567ba8438ad5bfd72b8607bd34b3ee972d149dba5fe1b"
+	testAddress1 = "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf"
+
+	testMessage2 = "localhost:8090 wants you to sign in with your Ethereum account:\n0x2c7536E3605D9C16a7a3D7b1898e529396a65c23\n\n\nURI: http://localhost:8090\nVersion: 1\nChain ID: 137\nNonce: n0nc3n0nc3n0nc3\nIssued At: 2023-12-01T10:00:00Z\nExpiration Time: 2100-01-01T00:00:00Z"
+	testSig2     = "0x1a4c0566e998960e1ef4790558b9573552a80ee583074f70755e2cc2b7860d9f47bfa4e23b0fb451710a4ea8af1819ca0c2cb6eb7bf02fe251eae7d0054e77b91b"
+	testAddress2 = "0x2c7536E3605D9C16a7a3D7b1898e529396a65c23"
+)
+
+// curveOrder is the hex encoded secp256k1 curve order (N).
+const curveOrder = "fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141"
+
+func TestIsAddress(t *testing.T) {
+	scenarios := []struct {
+		address  string
+		expected bool
+	}{
+		{"", false},
+		{"0x", false},
+		{"7E5F4552091A69125d5DfCb7b8C2659029395Bdf", false},
+		{"0x7E5F4552091A69125d5DfCb7b8C2659029395Bd", false},
+		{"0x7E5F4552091A69125d5DfCb7b8C2659029395BdfA", false},
+		{"0x7E5F4552091A69125d5DfCb7b8C2659029395Bdg", false},
+		{"0x7e5f4552091a69125d5dfcb7b8c2659029395bdf", true},
+		{"0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf", true},
+	}
+
+	for i, s := range scenarios {
+		result := siwe.IsAddress(s.address)
+		if result != s.expected {
+			t.Errorf("(%d) Expected %v, got %v", i, s.expected, result)
+		}
+	}
+}
+
+func TestChecksumAddress(t *testing.T) {
+	scenarios := []struct {
+		address     string
+		expected    string
+		expectError bool
+	}{
+		{"", "", true},
+		{"0x123", "", true},
+		{"0x7e5f4552091a69125d5dfcb7b8c2659029395bdf", testAddress1, false},
+		{"0x7E5F4552091A69125D5DFCB7B8C2659029395BDF", testAddress1, false},
+		// EIP-55 spec examples
+		{"0xfb6916095ca1df60bb79ce92ce3ea74c37c5d359", "0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359", false},
+		{"0x52908400098527886e0f7030069857d2e4169ee7", "0x52908400098527886E0F7030069857D2E4169EE7", false},
+		{"0xde709f2102306220921060314715629080e2fb77", "0xde709f2102306220921060314715629080e2fb77", false},
+	}
+
+	for i, s := range scenarios {
+		result, err := siwe.ChecksumAddress(s.address)
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("(%d) Expected hasErr %v, got %v (%v)", i, s.expectError, hasErr, err)
+			continue
+		}
+
+		if result != s.expected {
+			t.Errorf("(%d) Expected %q, got %q", i, s.expected, result)
+		}
+	}
+}
+
+func TestHashMessage(t *testing.T) {
+	result := hex.EncodeToString(siwe.HashMessage("hello"))
+
+	expected := "50b2c43fd39106bafbba0da34fc430e1f91e3c96ea2acee2bc34119f92b37750"
+
+	if result != expected {
+		t.Fatalf("Expected %q, got %q", expected, result)
+	}
+}
+
+func TestRecoverAddress(t *testing.T) {
+	// v as 0/1 recovery id
+	testSig1Short := testSig1[:len(testSig1)-2] + "00"
+
+	scenarios := []struct {
+		name        string
+		message     string
+		signature   string
+		expected    string
+		expectError bool
+	}{
+		{"empty signature", testMessage1, "", "", true},
+		{"invalid hex signature", testMessage1, "0xzz", "", true},
+		{"short signature", testMessage1, testSig1[:100], "", true},
+		{"invalid recovery id", testMessage1, testSig1[:len(testSig1)-2] + "1d", "", true},
+		{"v below 27", testMessage1, testSig1[:len(testSig1)-2] + "1a", "", true},
+		{"v as 2 recovery id", testMessage1, testSig1[:len(testSig1)-2] + "02", "", true},
+		{"zero r", testMessage1, "0x" + strings.Repeat("0", 64) + testSig1[66:], "", true},
+		{"zero s", testMessage1, testSig1[:66] + strings.Repeat("0", 64) + "1b", "", true},
+		{"r equal to the curve order", testMessage1, "0x" + curveOrder + testSig1[66:], "", true},
+		{"r above the curve order", testMessage1, "0x" + strings.Repeat("f", 64) + testSig1[66:], "", true},
+		{"s equal to the curve order", testMessage1, testSig1[:66] + curveOrder + "1b", "", true},
+		{"s above the curve order", testMessage1, testSig1[:66] + strings.Repeat("f", 64) + "1b", "", true},
+		// the malleable (N - s, flipped v) counterpart of testSig1
+		{"high s", testMessage1, testSig1[:66] + "eb431615d84e701574775a98457bc7515eb16a2e4eccccf080e8ebbb865a9b43" + "1c", "", true},
+		{"valid signature (27/28 v)", testMessage1, testSig1, testAddress1, false},
+		{"valid signature (0/1 v)", testMessage1, testSig1Short, testAddress1, false},
+		{"valid signature without 0x prefix", testMessage2, testSig2[2:], testAddress2, false},
+		{"different message", testMessage2, testSig1, "", false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result, err := siwe.RecoverAddress(s.message, s.signature)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if hasErr {
+				return
+			}
+
+			if s.expected == "" {
+				// a different (random) address is expected
+				if result == testAddress1 || result == testAddress2 {
+					t.Fatalf("Expected a different address, got %q", result)
+				}
+				return
+			}
+
+			if result != s.expected {
+				t.Fatalf("Expected %q, got %q", s.expected, result)
+			}
+		})
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	scenarios := []struct {
+		message     string
+		signature   string
+		address     string
+		expectError bool
+	}{
+		{testMessage1, testSig1, testAddress2, true},
+		{testMessage2, testSig1, testAddress1, true},
+		{testMessage1, "invalid", testAddress1, true},
+		{testMessage1, testSig1, testAddress1, false},
+		{testMessage2, testSig2, "0x2c7536e3605d9c16a7a3d7b1898e529396a65c23", false},
+	}
+
+	for i, s := range scenarios {
+		err := siwe.VerifySignature(s.message, s.signature, s.address)
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("(%d) Expected hasErr %v, got %v (%v)", i, s.expectError, hasErr, err)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	hexColorRegex = regexp.MustCompile(`^#([0-9a-f]{3}|[0-9a-f]{4}|[0-9a-f]{6}|[0-9a-f]{8})$`)
+	rgbColorRegex = regexp.MustCompile(`^rgba?\(\s*(\d{1,3})\s*,\s*(\d{1,3})\s*,\s*(\d{1,3})\s*(?:,\s*(\d*\.?\d+%?)\s*)?\)$`)
+)
+
+// ParseColor parses the provided hex ("#rgb", "#rgba", "#rrggbb", "#rrggbbaa")
+// or rgb(a) ("rgb(255, 0, 0)", "rgba(255, 0, 0, 0.5)") color string and
+// normalizes it into a lowercase "#rrggbb" hex string
+// (or "#rrggbbaa" if the color is not fully opaque).
+func ParseColor(str string) (string, error) {
+	str = strings.ToLower(strings.TrimSpace(str))
+
+	var r, g, b, a int
+
+	if m := hexColorRegex.FindStringSubmatch(str); m != nil {
+		hex := m[1]
+
+		// expand the short notation
+		if len(hex) <= 4 {
+			var expanded strings.Builder
+			for _, c := range hex {
+				expanded.WriteRune(c)
+				expanded.WriteRune(c)
+			}
+			hex = expanded.String()
+		}
+
+		if len(hex) == 6 {
+			hex += "ff"
+		}
+
+		parts := make([]int, 4)
+		for i := range parts {
+			n, _ := strconv.ParseUint(hex[i*2:i*2+2], 16, 8)
+			parts[i] = int(n)
+		}
+
+		r, g, b, a = parts[0], parts[1], parts[2], parts[3]
+	} else if m := rgbColorRegex.FindStringSubmatch(str); m != nil {
+		r, _ = strconv.Atoi(m[1])
+		g, _ = strconv.Atoi(m[2])
+		b, _ = strconv.Atoi(m[3])
+		if r > 255 || g > 255 || b > 255 {
+			return "", errors.New("the rgb channels must be between 0 and 255")
+		}
+
+		a = 255
+		if m[4] != "" {
+			alpha, percent := strings.CutSuffix(m[4], "%")
+
+			f, err := strconv.ParseFloat(alpha, 64)
+			if err != nil {
+				return "", err
+			}
+			if percent {
+				f /= 100
+			}
+			if f > 1 {
+				return "", errors.New("the alpha channel must be between 0 and 1")
+			}
+
+			a = int(math.Round(f * 255))
+		}
+	} else {
+		return "", errors.New("invalid hex or rgb(a) color")
+	}
+
+	if a == 255 {
+		return fmt.Sprintf("#%02x%02x%02x", r, g, b), nil
+	}
+
+	return fmt.Sprintf("#%02x%02x%02x%02x", r, g, b, a), nil
+}
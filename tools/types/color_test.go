@@ -0,0 +1,48 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestParseColor(t *testing.T) {
+	scenarios := []struct {
+		value       string
+		expected    string
+		expectError bool
+	}{
+		{"", "", true},
+		{"invalid", "", true},
+		{"#12", "", true},
+		{"#12345", "", true},
+		{"#gggggg", "", true},
+		{"rgb(256, 0, 0)", "", true},
+		{"rgba(0, 0, 0, 1.5)", "", true},
+		{"rgb(0, 0)", "", true},
+		{"#ABC", "#aabbcc", false},
+		{"#abcd", "#aabbccdd", false},
+		{" #AABBCC ", "#aabbcc", false},
+		{"#aabbccff", "#aabbcc", false},
+		{"#aabbcc80", "#aabbcc80", false},
+		{"rgb(255, 0, 10)", "#ff000a", false},
+		{"RGBA(255,0,10,0.5)", "#ff000a80", false},
+		{"rgba(255, 0, 10, 50%)", "#ff000a80", false},
+		{"rgba(255, 0, 10, 1)", "#ff000a", false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.value, func(t *testing.T) {
+			result, err := types.ParseColor(s.value)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if result != s.expected {
+				t.Fatalf("Expected %q, got %q", s.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cast"
+)
+
+var durationRegex = regexp.MustCompile(`^([-+])?P(?:(\d{1,12})W)?(?:(\d{1,12})D)?(?:T(?:(\d{1,12})H)?(?:(\d{1,12})M)?(?:(\d{1,12})S)?)?$`)
+
+// ParseDuration creates a new Duration from the provided value
+// (ISO-8601 duration string, number of seconds, [time.Duration], etc.).
+func ParseDuration(value any) (Duration, error) {
+	d := Duration{}
+	err := d.Scan(value)
+	return d, err
+}
+
+// Duration represents a fixed length ISO-8601 duration
+// (eg. "P1W", "P2DT3H", "PT1M30S") with a seconds precision.
+//
+// Years and months are not supported since they don't have a fixed length.
+//
+// Duration is stored in the db as total number of seconds (allowing
+// numeric comparisons and sorting) and serialized as ISO-8601 string.
+type Duration struct {
+	seconds int64
+}
+
+// Seconds returns the total number of seconds of the current duration.
+func (d Duration) Seconds() int64 {
+	return d.seconds
+}
+
+// Duration returns the current duration as [time.Duration].
+func (d Duration) Duration() time.Duration {
+	return time.Duration(d.seconds) * time.Second
+}
+
+// IsZero checks whether the current duration has zero length.
+func (d Duration) IsZero() bool {
+	return d.seconds == 0
+}
+
+// String serializes the current duration into an ISO-8601 duration string.
+//
+// The zero value is serialized as "PT0S".
+func (d Duration) String() string {
+	if d.seconds == 0 {
+		return "PT0S"
+	}
+
+	var sb strings.Builder
+
+	seconds := d.seconds
+	if seconds < 0 {
+		sb.WriteString("-")
+		seconds = -seconds
+	}
+
+	sb.WriteString("P")
+
+	if days := seconds / 86400; days > 0 {
+		sb.WriteString(strconv.FormatInt(days, 10) + "D")
+	}
+
+	seconds %= 86400
+	if seconds == 0 {
+		return sb.String()
+	}
+
+	sb.WriteString("T")
+
+	if hours := seconds / 3600; hours > 0 {
+		sb.WriteString(strconv.FormatInt(hours, 10) + "H")
+	}
+
+	if minutes := seconds % 3600 / 60; minutes > 0 {
+		sb.WriteString(strconv.FormatInt(minutes, 10) + "M")
+	}
+
+	if seconds%60 > 0 {
+		sb.WriteString(strconv.FormatInt(seconds%60, 10) + "S")
+	}
+
+	return sb.String()
+}
+
+// MarshalJSON implements the [json.Marshaler] interface.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + d.String() + `"`), nil
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler] interface.
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var raw any
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	return d.Scan(raw)
+}
+
+// Value implements the [driver.Valuer] interface.
+func (d Duration) Value() (driver.Value, error) {
+	return d.seconds, nil
+}
+
+// Scan implements [sql.Scanner] interface to scan the provided value
+// into the current Duration instance.
+func (d *Duration) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		d.seconds = 0
+	case Duration:
+		d.seconds = v.seconds
+	case *Duration:
+		d.seconds = v.seconds
+	case time.Duration:
+		d.seconds = int64(v / time.Second)
+	case int, int64, int32, uint, uint64, uint32:
+		d.seconds = cast.ToInt64(v)
+	case float64, float32:
+		d.seconds = int64(math.Round(cast.ToFloat64(v)))
+	case []byte:
+		return d.parse(string(v))
+	case string:
+		return d.parse(v)
+	default:
+		return errors.New("unsupported duration value")
+	}
+
+	return nil
+}
+
+func (d *Duration) parse(str string) error {
+	str = strings.TrimSpace(str)
+
+	if str == "" {
+		d.seconds = 0
+		return nil
+	}
+
+	// plain number of seconds (eg. when loaded from the db)
+	if n, err := strconv.ParseFloat(str, 64); err == nil {
+		d.seconds = int64(math.Round(n))
+		return nil
+	}
+
+	m := durationRegex.FindStringSubmatch(strings.ToUpper(str))
+	if m == nil || strings.HasSuffix(m[0], "P") || strings.HasSuffix(m[0], "T") {
+		return errors.New("invalid or unsupported ISO-8601 duration")
+	}
+
+	multipliers := []int64{604800, 86400, 3600, 60, 1}
+
+	var total int64
+	for i, multiplier := range multipliers {
+		if m[i+2] == "" {
+			continue
+		}
+
+		n, _ := strconv.ParseInt(m[i+2], 10, 64)
+		total += n * multiplier
+	}
+
+	if m[1] == "-" {
+		total = -total
+	}
+
+	d.seconds = total
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package types_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestParseDuration(t *testing.T) {
+	scenarios := []struct {
+		value           any
+		expectedSeconds int64
+		expectedString  string
+		expectError     bool
+	}{
+		{nil, 0, "PT0S", false},
+		{"", 0, "PT0S", false},
+		{"invalid", 0, "PT0S", true},
+		{"P", 0, "PT0S", true},
+		{"PT", 0, "PT0S", true},
+		{"P1DT", 0, "PT0S", true},
+		{"P1Y", 0, "PT0S", true},
+		{"P1M", 0, "PT0S", true},
+		{"PT1.5S", 0, "PT0S", true},
+		{[]int{1}, 0, "PT0S", true},
+		{"PT0S", 0, "PT0S", false},
+		{"PT90S", 90, "PT1M30S", false},
+		{"pt1h", 3600, "PT1H", false},
+		{"P1W", 604800, "P7D", false},
+		{"P1DT2H3M4S", 93784, "P1DT2H3M4S", false},
+		{"-P1D", -86400, "-P1D", false},
+		{"+PT2M", 120, "PT2M", false},
+		{"3600", 3600, "PT1H", false},
+		{[]byte("PT1M"), 60, "PT1M", false},
+		{int64(61), 61, "PT1M1S", false},
+		{1.6, 2, "PT2S", false},
+		{5 * time.Minute, 300, "PT5M", false},
+	}
+
+	for i, s := range scenarios {
+		d, err := types.ParseDuration(s.value)
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("(%d) Expected hasErr %v, got %v (%v)", i, s.expectError, hasErr, err)
+			continue
+		}
+
+		if d.Seconds() != s.expectedSeconds {
+			t.Errorf("(%d) Expected %d seconds, got %d", i, s.expectedSeconds, d.Seconds())
+		}
+
+		if d.String() != s.expectedString {
+			t.Errorf("(%d) Expected %q, got %q", i, s.expectedString, d.String())
+		}
+	}
+}
+
+func TestDurationMarshalJSON(t *testing.T) {
+	d, _ := types.ParseDuration("PT1H30M")
+
+	raw, err := json.Marshal(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(raw) != `"PT1H30M"` {
+		t.Fatalf("Expected %q, got %q", `"PT1H30M"`, raw)
+	}
+}
+
+func TestDurationUnmarshalJSON(t *testing.T) {
+	scenarios := []struct {
+		json            string
+		expectedSeconds int64
+		expectError     bool
+	}{
+		{`null`, 0, false},
+		{`"P1D"`, 86400, false},
+		{`120`, 120, false},
+		{`"P1Y"`, 0, true},
+		{`{}`, 0, true},
+	}
+
+	for i, s := range scenarios {
+		d := types.Duration{}
+
+		err := json.Unmarshal([]byte(s.json), &d)
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("(%d) Expected hasErr %v, got %v (%v)", i, s.expectError, hasErr, err)
+			continue
+		}
+
+		if d.Seconds() != s.expectedSeconds {
+			t.Errorf("(%d) Expected %d seconds, got %d", i, s.expectedSeconds, d.Seconds())
+		}
+	}
+}
+
+func TestDurationValue(t *testing.T) {
+	d, _ := types.ParseDuration("PT2M")
+
+	v, err := d.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v != int64(120) {
+		t.Fatalf("Expected 120, got %v", v)
+	}
+}
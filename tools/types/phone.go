@@ -0,0 +1,400 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cast"
+)
+
+// phoneRegion defines the basic numbering plan of a single region.
+type phoneRegion struct {
+	// countryCode is the region country calling code (without the "+").
+	countryCode string
+
+	// trunkPrefix is the national dialing prefix that is
+	// stripped from the national numbers (eg. "0").
+	trunkPrefix string
+
+	// min and max are the allowed national significant number lengths.
+	min int
+	max int
+}
+
+// phoneRegions contains the numbering plans of the supported
+// default regions (keyed by their ISO 3166-1 alpha-2 code).
+//
+// The list is intentionally not exhaustive - numbers in E.164 format
+// from the other regions are still accepted (with a generic length check).
+var phoneRegions = map[string]phoneRegion{
+	"AE": {"971", "0", 8, 9},
+	"AR": {"54", "0", 10, 10},
+	"AT": {"43", "0", 4, 13},
+	"AU": {"61", "0", 9, 9},
+	"BE": {"32", "0", 8, 9},
+	"BR": {"55", "0", 10, 11},
+	"CA": {"1", "1", 10, 10},
+	"CH": {"41", "0", 9, 9},
+	"CL": {"56", "", 9, 9},
+	"CN": {"86", "0", 7, 11},
+	"CO": {"57", "", 10, 10},
+	"CZ": {"420", "", 9, 9},
+	"DE": {"49", "0", 6, 13},
+	"DK": {"45", "", 8, 8},
+	"EG": {"20", "0", 9, 10},
+	"ES": {"34", "", 9, 9},
+	"FI": {"358", "0", 5, 12},
+	"FR": {"33", "0", 9, 9},
+	"GB": {"44", "0", 9, 10},
+	"GR": {"30", "", 10, 10},
+	"HK": {"852", "", 8, 8},
+	"HU": {"36", "06", 8, 9},
+	"ID": {"62", "0", 8, 12},
+	"IE": {"353", "0", 7, 10},
+	"IL": {"972", "0", 8, 9},
+	"IN": {"91", "0", 10, 10},
+	"IT": {"39", "", 6, 11},
+	"JP": {"81", "0", 9, 10},
+	"KE": {"254", "0", 9, 9},
+	"KR": {"82", "0", 8, 10},
+	"MA": {"212", "0", 9, 9},
+	"MX": {"52", "", 10, 10},
+	"MY": {"60", "0", 8, 10},
+	"NG": {"234", "0", 8, 10},
+	"NL": {"31", "0", 9, 9},
+	"NO": {"47", "", 8, 8},
+	"NZ": {"64", "0", 8, 10},
+	"PE": {"51", "0", 8, 9},
+	"PH": {"63", "0", 8, 10},
+	"PK": {"92", "0", 9, 10},
+	"PL": {"48", "", 9, 9},
+	"PT": {"351", "", 9, 9},
+	"RO": {"40", "0", 9, 9},
+	"RU": {"7", "8", 10, 10},
+	"SA": {"966", "0", 8, 9},
+	"SE": {"46", "0", 7, 13},
+	"SG": {"65", "", 8, 8},
+	"TH": {"66", "0", 8, 9},
+	"TR": {"90", "0", 10, 10},
+	"TW": {"886", "0", 8, 9},
+	"UA": {"380", "0", 9, 9},
+	"US": {"1", "1", 10, 10},
+	"VN": {"84", "0", 9, 10},
+	"ZA": {"27", "0", 9, 9},
+}
+
+// phoneMainRegions contains the region whose numbering plan is used
+// for the country calling codes that are shared by multiple regions.
+var phoneMainRegions = map[string]string{
+	"1": "US",
+	"7": "RU",
+}
+
+// phoneTwoDigitCodes contains all assigned 2 digit country calling codes.
+//
+// Since the country calling codes are prefix free, a code that is not
+// "1", "7" or one of the below is always 3 digits long.
+var phoneTwoDigitCodes = map[string]struct{}{
+	"20": {}, "27": {}, "30": {}, "31": {}, "32": {}, "33": {}, "34": {}, "36": {},
+	"39": {}, "40": {}, "41": {}, "43": {}, "44": {}, "45": {}, "46": {}, "47": {},
+	"48": {}, "49": {}, "51": {}, "52": {}, "53": {}, "54": {}, "55": {}, "56": {},
+	"57": {}, "58": {}, "60": {}, "61": {}, "62": {}, "63": {}, "64": {}, "65": {},
+	"66": {}, "81": {}, "82": {}, "84": {}, "86": {}, "90": {}, "91": {}, "92": {},
+	"93": {}, "94": {}, "95": {}, "98": {},
+}
+
+// PhoneRegions returns the sorted list with the supported phone default regions.
+func PhoneRegions() []string {
+	result := make([]string, 0, len(phoneRegions))
+	for region := range phoneRegions {
+		result = append(result, region)
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+// ParsePhone parses the provided phone number value and normalizes it in E.164 format.
+//
+// Numbers in international format (starting with "+" or "00") are parsed as they are.
+// National numbers (eg. "(415) 555-2671") are parsed using the numbering plan
+// of the defaultRegion (ISO 3166-1 alpha-2 code, eg. "US").
+func ParsePhone(value any, defaultRegion string) (Phone, error) {
+	p := Phone{}
+
+	switch v := value.(type) {
+	case nil:
+		return p, nil
+	case Phone:
+		return v, nil
+	case *Phone:
+		return *v, nil
+	case map[string]any, JsonMap:
+		return p, p.Scan(v)
+	}
+
+	err := p.parse(cast.ToString(value), defaultRegion)
+
+	return p, err
+}
+
+// Phone defines a phone number normalized in E.164 format.
+//
+// Phone is stored in the db as E.164 string (allowing exact matching and
+// unique indexes) and serialized as object with its formatted variants.
+type Phone struct {
+	countryCode string
+	number      string
+}
+
+// IsZero checks whether the current phone number is empty.
+func (p Phone) IsZero() bool {
+	return p.number == ""
+}
+
+// CountryCode returns the phone country calling code (eg. "1").
+func (p Phone) CountryCode() string {
+	return p.countryCode
+}
+
+// NationalNumber returns the phone national significant number digits.
+func (p Phone) NationalNumber() string {
+	return p.number
+}
+
+// E164 returns the phone number in E.164 format (eg. "+14155552671").
+//
+// Returns empty string for zero phone.
+func (p Phone) E164() string {
+	if p.IsZero() {
+		return ""
+	}
+
+	return "+" + p.countryCode + p.number
+}
+
+// International returns the phone number in human readable
+// international format (eg. "+1 415-555-2671").
+//
+// Returns empty string for zero phone.
+func (p Phone) International() string {
+	if p.IsZero() {
+		return ""
+	}
+
+	if p.countryCode == "1" && len(p.number) == 10 {
+		return "+1 " + p.number[:3] + "-" + p.number[3:6] + "-" + p.number[6:]
+	}
+
+	return "+" + p.countryCode + " " + groupPhoneDigits(p.number)
+}
+
+// National returns the phone number in human readable national
+// format (eg. "(415) 555-2671" or "0207 183 8750").
+//
+// Returns empty string for zero phone.
+func (p Phone) National() string {
+	if p.IsZero() {
+		return ""
+	}
+
+	if p.countryCode == "1" && len(p.number) == 10 {
+		return "(" + p.number[:3] + ") " + p.number[3:6] + "-" + p.number[6:]
+	}
+
+	var trunkPrefix string
+	if region, ok := phoneCountryRegion(p.countryCode); ok {
+		trunkPrefix = region.trunkPrefix
+	}
+
+	return trunkPrefix + groupPhoneDigits(p.number)
+}
+
+// String returns the phone number in E.164 format.
+func (p Phone) String() string {
+	return p.E164()
+}
+
+// MarshalJSON implements the [json.Marshaler] interface.
+func (p Phone) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]string{
+		"e164":          p.E164(),
+		"international": p.International(),
+		"national":      p.National(),
+		"countryCode":   p.countryCode,
+	})
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler] interface.
+func (p *Phone) UnmarshalJSON(b []byte) error {
+	var raw any
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	return p.Scan(raw)
+}
+
+// Value implements the [driver.Valuer] interface.
+func (p Phone) Value() (driver.Value, error) {
+	return p.E164(), nil
+}
+
+// Scan implements [sql.Scanner] interface to scan the provided value
+// into the current Phone instance.
+//
+// Only numbers in international format are accepted.
+func (p *Phone) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		*p = Phone{}
+	case Phone:
+		*p = v
+	case *Phone:
+		*p = *v
+	case map[string]any:
+		return p.parse(cast.ToString(v["e164"]), "")
+	case JsonMap:
+		return p.parse(cast.ToString(v["e164"]), "")
+	case []byte:
+		return p.parse(string(v), "")
+	case string:
+		return p.parse(v, "")
+	default:
+		return errors.New("unsupported phone value")
+	}
+
+	return nil
+}
+
+func (p *Phone) parse(str string, defaultRegion string) error {
+	*p = Phone{}
+
+	str = strings.TrimSpace(str)
+	if str == "" {
+		return nil
+	}
+
+	international := false
+	if strings.HasPrefix(str, "+") {
+		international = true
+		str = str[1:]
+	}
+
+	var digits strings.Builder
+	for _, c := range str {
+		switch {
+		case c >= '0' && c <= '9':
+			digits.WriteRune(c)
+		case c == ' ' || c == '-' || c == '.' || c == '(' || c == ')' || c == '/':
+			// common separators
+		default:
+			return errors.New("the phone number must contain only digits and separators")
+		}
+	}
+
+	number := digits.String()
+
+	if !international && strings.HasPrefix(number, "00") {
+		international = true
+		number = number[2:]
+	}
+
+	if international {
+		return p.parseInternational(number)
+	}
+
+	region, ok := phoneRegions[strings.ToUpper(defaultRegion)]
+	if !ok {
+		return errors.New("the phone number must be in international format (eg. +14155552671)")
+	}
+
+	// strip the national dialing prefix
+	if region.trunkPrefix != "" &&
+		strings.HasPrefix(number, region.trunkPrefix) &&
+		len(number)-len(region.trunkPrefix) >= region.min {
+		number = number[len(region.trunkPrefix):]
+	}
+
+	return p.setNumber(region.countryCode, number, region)
+}
+
+func (p *Phone) parseInternational(number string) error {
+	if len(number) < 2 || number[0] == '0' {
+		return errors.New("invalid country calling code")
+	}
+
+	codeLength := 3
+	if number[0] == '1' || number[0] == '7' {
+		codeLength = 1
+	} else if _, ok := phoneTwoDigitCodes[number[:2]]; ok {
+		codeLength = 2
+	}
+
+	if len(number) <= codeLength {
+		return errors.New("missing phone national number")
+	}
+
+	countryCode := number[:codeLength]
+
+	region, ok := phoneCountryRegion(countryCode)
+	if !ok {
+		// generic E.164 check (max 15 digits including the country code)
+		region = phoneRegion{countryCode: countryCode, min: 4, max: 15 - codeLength}
+	}
+
+	return p.setNumber(countryCode, number[codeLength:], region)
+}
+
+func (p *Phone) setNumber(countryCode string, number string, region phoneRegion) error {
+	if len(number) < region.min || len(number) > region.max {
+		return errors.New("invalid phone number length")
+	}
+
+	// North American Numbering Plan area code and exchange can't start with 0 or 1
+	if countryCode == "1" && (number[0] < '2' || number[3] < '2') {
+		return errors.New("invalid North American phone number")
+	}
+
+	p.countryCode = countryCode
+	p.number = number
+
+	return nil
+}
+
+// phoneCountryRegion returns the numbering plan of the
+// region with the provided country calling code.
+func phoneCountryRegion(countryCode string) (phoneRegion, bool) {
+	if name, ok := phoneMainRegions[countryCode]; ok {
+		return phoneRegions[name], true
+	}
+
+	for _, region := range phoneRegions {
+		if region.countryCode == countryCode {
+			return region, true
+		}
+	}
+
+	return phoneRegion{}, false
+}
+
+// groupPhoneDigits splits the provided digits in space separated
+// groups of 3 (the last group has between 2 and 4 digits).
+func groupPhoneDigits(digits string) string {
+	if len(digits) <= 4 {
+		return digits
+	}
+
+	groups := []string{}
+	for len(digits) > 4 {
+		groups = append(groups, digits[:3])
+		digits = digits[3:]
+	}
+
+	groups = append(groups, digits)
+
+	return strings.Join(groups, " ")
+}
@@ -0,0 +1,180 @@
+package types_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/types"
+)
+
+func TestParsePhone(t *testing.T) {
+	scenarios := []struct {
+		value         any
+		defaultRegion string
+		expectedE164  string
+		expectError   bool
+	}{
+		{nil, "", "", false},
+		{"", "US", "", false},
+		{"   ", "US", "", false},
+		{"invalid", "US", "", true},
+		{"+1 415 555 2671 ext. 1", "US", "", true},
+		{"(415) 555-2671", "", "", true},
+		{"(415) 555-2671", "missing", "", true},
+		{"(415) 555-2671", "US", "+14155552671", false},
+		{"(415) 555-2671", "us", "+14155552671", false},
+		{"1 415 555 2671", "US", "+14155552671", false},
+		{"415 555 267", "US", "", true},
+		{"(015) 555-2671", "US", "", true},
+		{"(415) 155-2671", "US", "", true},
+		{"+1 (415) 555-2671", "", "+14155552671", false},
+		{"+1 (415) 555-2671", "GB", "+14155552671", false},
+		{"001 415 555 2671", "", "+14155552671", false},
+		{"020 7183 8750", "GB", "+442071838750", false},
+		{"+44 (0)20 7183 8750", "", "", true},
+		{"+44 20 7183 8750", "", "+442071838750", false},
+		{"030 123456", "DE", "+4930123456", false},
+		{"06 30 123 4567", "HU", "+36301234567", false},
+		{"8 (912) 345-67-89", "RU", "+79123456789", false},
+		{"06 12 34 56 78", "IT", "+390612345678", false},
+		{"+0 123 456", "", "", true},
+		{"+1", "", "", true},
+		{"+359 88 123 4567", "", "+359881234567", false},
+		{"+359 1234567890123", "", "", true},
+		{[]byte("+14155552671"), "", "+14155552671", false},
+		{map[string]any{"e164": "+14155552671"}, "", "+14155552671", false},
+		{types.JsonMap{"e164": "+442071838750"}, "US", "+442071838750", false},
+	}
+
+	for i, s := range scenarios {
+		p, err := types.ParsePhone(s.value, s.defaultRegion)
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("(%d) Expected hasErr %v, got %v (%v)", i, s.expectError, hasErr, err)
+			continue
+		}
+
+		if p.E164() != s.expectedE164 {
+			t.Errorf("(%d) Expected %q, got %q", i, s.expectedE164, p.E164())
+		}
+	}
+}
+
+func TestPhoneFormats(t *testing.T) {
+	scenarios := []struct {
+		value                 string
+		expectedCountryCode   string
+		expectedInternational string
+		expectedNational      string
+	}{
+		{"", "", "", ""},
+		{"+14155552671", "1", "+1 415-555-2671", "(415) 555-2671"},
+		{"+442071838750", "44", "+44 207 183 8750", "0207 183 8750"},
+		{"+4930123456", "49", "+49 301 234 56", "0301 234 56"},
+		{"+4712345678", "47", "+47 123 456 78", "123 456 78"},
+		{"+359881234567", "359", "+359 881 234 567", "881 234 567"},
+	}
+
+	for i, s := range scenarios {
+		p, err := types.ParsePhone(s.value, "")
+		if err != nil {
+			t.Errorf("(%d) Unexpected error %v", i, err)
+			continue
+		}
+
+		if p.CountryCode() != s.expectedCountryCode {
+			t.Errorf("(%d) Expected country code %q, got %q", i, s.expectedCountryCode, p.CountryCode())
+		}
+
+		if p.International() != s.expectedInternational {
+			t.Errorf("(%d) Expected international %q, got %q", i, s.expectedInternational, p.International())
+		}
+
+		if p.National() != s.expectedNational {
+			t.Errorf("(%d) Expected national %q, got %q", i, s.expectedNational, p.National())
+		}
+
+		if p.String() != s.value {
+			t.Errorf("(%d) Expected string %q, got %q", i, s.value, p.String())
+		}
+	}
+}
+
+func TestPhoneJson(t *testing.T) {
+	p, err := types.ParsePhone("(415) 555-2671", "US")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	raw, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"countryCode":"1","e164":"+14155552671","international":"+1 415-555-2671","national":"(415) 555-2671"}`
+	if string(raw) != expected {
+		t.Fatalf("Expected %s, got %s", expected, raw)
+	}
+
+	// unmarshal the serialized object
+	unmarshaled := types.Phone{}
+	if err := json.Unmarshal(raw, &unmarshaled); err != nil {
+		t.Fatal(err)
+	}
+	if unmarshaled != p {
+		t.Fatalf("Expected %v, got %v", p, unmarshaled)
+	}
+
+	// unmarshal plain E.164 string
+	unmarshaled = types.Phone{}
+	if err := json.Unmarshal([]byte(`"+14155552671"`), &unmarshaled); err != nil {
+		t.Fatal(err)
+	}
+	if unmarshaled != p {
+		t.Fatalf("Expected %v, got %v", p, unmarshaled)
+	}
+
+	// national numbers are not allowed without default region
+	if err := json.Unmarshal([]byte(`"(415) 555-2671"`), &unmarshaled); err == nil {
+		t.Fatal("Expected unmarshal error, got nil")
+	}
+}
+
+func TestPhoneValue(t *testing.T) {
+	scenarios := []struct {
+		value    string
+		expected string
+	}{
+		{"", ""},
+		{"+14155552671", "+14155552671"},
+	}
+
+	for i, s := range scenarios {
+		p, _ := types.ParsePhone(s.value, "")
+
+		v, err := p.Value()
+		if err != nil {
+			t.Errorf("(%d) Unexpected error %v", i, err)
+			continue
+		}
+
+		if v != s.expected {
+			t.Errorf("(%d) Expected %q, got %v", i, s.expected, v)
+		}
+	}
+}
+
+func TestPhoneRegions(t *testing.T) {
+	regions := types.PhoneRegions()
+
+	if len(regions) == 0 {
+		t.Fatal("Expected non-empty regions list")
+	}
+
+	for i := 1; i < len(regions); i++ {
+		if regions[i-1] >= regions[i] {
+			t.Fatalf("Expected sorted unique regions, got %v", regions)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package webauthn
+
+import (
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/go-webauthn/webauthn/protocol/webauthncose"
+)
+
+// Supported COSE algorithm identifiers.
+//
+// See https://www.iana.org/assignments/cose/cose.xhtml#algorithms.
+const (
+	AlgES256 = int64(webauthncose.AlgES256)
+	AlgEdDSA = int64(webauthncose.AlgEdDSA)
+	AlgRS256 = int64(webauthncose.AlgRS256)
+)
+
+// SupportedAlgorithms is the list of the supported COSE
+// public key algorithms in order of preference.
+var SupportedAlgorithms = []int64{AlgES256, AlgEdDSA, AlgRS256}
+
+// coseCrvP256 is the COSE P-256 elliptic curve identifier.
+const coseCrvP256 = 1
+
+// PublicKey is a parsed COSE encoded credential public key.
+type PublicKey struct {
+	Alg int64
+
+	// Key is one of the go-webauthn webauthncose key data types.
+	Key any
+}
+
+// ParsePublicKey parses the provided COSE encoded public key.
+//
+// Only the [SupportedAlgorithms] keys are accepted and their
+// parameters are validated since webauthncose.ParsePublicKey
+// silently ignores the malformed key fields.
+func ParsePublicKey(coseKey []byte) (*PublicKey, error) {
+	if err := cbor.Valid(coseKey); err != nil {
+		return nil, err
+	}
+
+	parsed, err := webauthncose.ParsePublicKey(coseKey)
+	if err != nil {
+		return nil, err
+	}
+
+	switch key := parsed.(type) {
+	case webauthncose.EC2PublicKeyData:
+		if key.Algorithm != AlgES256 || key.Curve != coseCrvP256 || len(key.XCoord) != 32 || len(key.YCoord) != 32 {
+			return nil, errors.New("invalid EC2 public key parameters")
+		}
+
+		x := new(big.Int).SetBytes(key.XCoord)
+		y := new(big.Int).SetBytes(key.YCoord)
+		if !elliptic.P256().IsOnCurve(x, y) {
+			return nil, errors.New("the EC2 public key point is not on the curve")
+		}
+
+		return &PublicKey{Alg: key.Algorithm, Key: key}, nil
+	case webauthncose.OKPPublicKeyData:
+		if key.Algorithm != AlgEdDSA || len(key.XCoord) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid OKP public key parameters")
+		}
+
+		return &PublicKey{Alg: key.Algorithm, Key: key}, nil
+	case webauthncose.RSAPublicKeyData:
+		// note: webauthncose expects exactly 3 bytes exponent (usually 65537)
+		if key.Algorithm != AlgRS256 || len(key.Modulus) < 256 || len(key.Exponent) != 3 {
+			return nil, errors.New("invalid RSA public key parameters")
+		}
+
+		return &PublicKey{Alg: key.Algorithm, Key: key}, nil
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", parsed)
+	}
+}
+
+// Verify checks whether sig is a valid signature of data.
+func (k *PublicKey) Verify(data []byte, sig []byte) error {
+	valid, err := webauthncose.VerifySignature(k.Key, data, sig)
+	if err != nil || !valid {
+		return errors.New("invalid signature")
+	}
+
+	return nil
+}
@@ -0,0 +1,248 @@
+// Package webauthn implements the server side verification of the
+// WebAuthn (aka. passkeys) registration and authentication ceremonies
+// on top of the [github.com/go-webauthn/webauthn/protocol] package.
+//
+// The registration attestation statements are verified by the
+// go-webauthn attestation formats but there is no metadata
+// (aka. trusted authenticators) check, which is the common setup
+// for consumer passkeys.
+//
+// See https://www.w3.org/TR/webauthn-2/.
+package webauthn
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/protocol/webauthncbor"
+)
+
+// Client data types.
+const (
+	ClientDataTypeCreate = string(protocol.CreateCeremony)
+	ClientDataTypeGet    = string(protocol.AssertCeremony)
+)
+
+// Authenticator data flags.
+const (
+	FlagUserPresent            = byte(protocol.FlagUserPresent)
+	FlagUserVerified           = byte(protocol.FlagUserVerified)
+	FlagAttestedCredentialData = byte(protocol.FlagAttestedCredentialData)
+	FlagExtensionData          = byte(protocol.FlagHasExtensions)
+)
+
+// NewChallenge generates a new random base64url encoded 32 bytes challenge.
+//
+// It panics if for some reason rand.Read returns a non-nil error.
+func NewChallenge() string {
+	challenge, err := protocol.CreateChallenge()
+	if err != nil {
+		panic(err)
+	}
+
+	return challenge.String()
+}
+
+// DecodeBase64 decodes the provided base64url (or base64 std)
+// encoded string with or without padding.
+func DecodeBase64(s string) ([]byte, error) {
+	s = strings.TrimRight(s, "=")
+
+	if strings.ContainsAny(s, "+/") {
+		return base64.RawStdEncoding.DecodeString(s)
+	}
+
+	return base64.RawURLEncoding.DecodeString(s)
+}
+
+// AuthenticatorData defines the WebAuthn authenticator data structure.
+type AuthenticatorData struct {
+	RPIdHash  []byte
+	Flags     byte
+	SignCount uint32
+
+	// attested credential data (available only on registration)
+	AAGUID              []byte
+	CredentialId        []byte
+	CredentialPublicKey []byte // COSE encoded
+}
+
+// UserPresent reports whether the user presence flag is set.
+func (d *AuthenticatorData) UserPresent() bool {
+	return d.Flags&FlagUserPresent != 0
+}
+
+// UserVerified reports whether the user verification flag is set.
+func (d *AuthenticatorData) UserVerified() bool {
+	return d.Flags&FlagUserVerified != 0
+}
+
+// ParseAuthenticatorData parses the provided raw authenticator data.
+func ParseAuthenticatorData(raw []byte) (*AuthenticatorData, error) {
+	data := protocol.AuthenticatorData{}
+
+	if err := data.Unmarshal(raw); err != nil {
+		return nil, err
+	}
+
+	return newAuthenticatorData(data), nil
+}
+
+func newAuthenticatorData(data protocol.AuthenticatorData) *AuthenticatorData {
+	result := &AuthenticatorData{
+		RPIdHash:  data.RPIDHash,
+		Flags:     byte(data.Flags),
+		SignCount: data.Counter,
+	}
+
+	if data.Flags.HasAttestedCredentialData() {
+		result.AAGUID = data.AttData.AAGUID
+		result.CredentialId = data.AttData.CredentialID
+		result.CredentialPublicKey = data.AttData.CredentialPublicKey
+	}
+
+	return result
+}
+
+// Credential defines a verified newly registered WebAuthn credential.
+type Credential struct {
+	Id        []byte
+	PublicKey []byte // COSE encoded
+	SignCount uint32
+	AAGUID    []byte
+}
+
+// Config defines the relying party settings used to verify the ceremonies.
+type Config struct {
+	// RPId is the relying party id (usually the app domain).
+	RPId string
+
+	// Origins is the list of the allowed client origins
+	// (eg. "https://example.com").
+	Origins []string
+
+	// RequireUserVerification requires the authenticator to verify the
+	// user (eg. with biometrics or PIN) and not just their presence.
+	RequireUserVerification bool
+}
+
+// VerifyRegistration verifies the provided registration (aka. "navigator.credentials.create()")
+// response against the expected challenge and returns the new credential.
+func (c Config) VerifyRegistration(challenge string, clientDataJSON []byte, attestationObject []byte) (*Credential, error) {
+	if err := c.verifyClientData(protocol.CreateCeremony, challenge, clientDataJSON); err != nil {
+		return nil, err
+	}
+
+	obj := protocol.AttestationObject{}
+
+	if err := webauthncbor.Unmarshal(attestationObject, &obj); err != nil {
+		return nil, err
+	}
+
+	if err := obj.AuthData.Unmarshal(obj.RawAuthData); err != nil {
+		return nil, err
+	}
+
+	if !obj.AuthData.Flags.HasAttestedCredentialData() {
+		return nil, errors.New("missing attested credential data")
+	}
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+
+	if err := verifyAttestationObject(&obj, c.RPId, clientDataHash[:], c.RequireUserVerification); err != nil {
+		return nil, err
+	}
+
+	authData := newAuthenticatorData(obj.AuthData)
+
+	if _, err := ParsePublicKey(authData.CredentialPublicKey); err != nil {
+		return nil, err
+	}
+
+	return &Credential{
+		Id:        authData.CredentialId,
+		PublicKey: authData.CredentialPublicKey,
+		SignCount: authData.SignCount,
+		AAGUID:    authData.AAGUID,
+	}, nil
+}
+
+// VerifyAssertion verifies the provided authentication (aka. "navigator.credentials.get()")
+// response signature with the stored credential public key.
+//
+// On success returns the parsed authenticator data so that the caller
+// could check and update the stored credential signature counter.
+func (c Config) VerifyAssertion(
+	challenge string,
+	clientDataJSON []byte,
+	authenticatorData []byte,
+	signature []byte,
+	publicKey []byte,
+) (*AuthenticatorData, error) {
+	if err := c.verifyClientData(protocol.AssertCeremony, challenge, clientDataJSON); err != nil {
+		return nil, err
+	}
+
+	authData := protocol.AuthenticatorData{}
+
+	if err := authData.Unmarshal(authenticatorData); err != nil {
+		return nil, err
+	}
+
+	rpIdHash := sha256.Sum256([]byte(c.RPId))
+
+	if err := authData.Verify(rpIdHash[:], nil, c.RequireUserVerification); err != nil {
+		return nil, err
+	}
+
+	key, err := ParsePublicKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+
+	signed := make([]byte, 0, len(authenticatorData)+len(clientDataHash))
+	signed = append(signed, authenticatorData...)
+	signed = append(signed, clientDataHash[:]...)
+
+	if err := key.Verify(signed, signature); err != nil {
+		return nil, err
+	}
+
+	return newAuthenticatorData(authData), nil
+}
+
+// verifyAttestationObject verifies the attestation object authenticator data and statement.
+//
+// note: some of the go-webauthn attestation formats return errors that are not
+// *protocol.Error and panic on the AttestationObject.Verify error type assertion.
+func verifyAttestationObject(obj *protocol.AttestationObject, rpId string, clientDataHash []byte, requireUserVerification bool) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("invalid %q attestation statement: %v", obj.Format, r)
+		}
+	}()
+
+	return obj.Verify(rpId, clientDataHash, requireUserVerification)
+}
+
+func (c Config) verifyClientData(ceremony protocol.CeremonyType, challenge string, raw []byte) error {
+	// note: an empty challenge would otherwise match an empty client data challenge
+	if challenge == "" {
+		return errors.New("missing client data challenge")
+	}
+
+	clientData := protocol.CollectedClientData{}
+
+	if err := json.Unmarshal(raw, &clientData); err != nil {
+		return err
+	}
+
+	return clientData.Verify(challenge, ceremony, c.Origins)
+}
@@ -0,0 +1,458 @@
+package webauthn_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+
+	"github.com/pocketbase/pocketbase/tools/webauthn"
+)
+
+var testConfig = webauthn.Config{
+	RPId:    "example.com",
+	Origins: []string{"https://example.com"},
+}
+
+// cborHead encodes a CBOR data item head.
+func cborHead(major byte, n int) []byte {
+	switch {
+	case n < 24:
+		return []byte{major<<5 | byte(n)}
+	case n < 256:
+		return []byte{major<<5 | 24, byte(n)}
+	default:
+		return []byte{major<<5 | 25, byte(n >> 8), byte(n)}
+	}
+}
+
+func cborBytes(b []byte) []byte {
+	return append(cborHead(2, len(b)), b...)
+}
+
+func cborText(s string) []byte {
+	return append(cborHead(3, len(s)), s...)
+}
+
+func ec2CoseKey(key *ecdsa.PublicKey) []byte {
+	x := make([]byte, 32)
+	y := make([]byte, 32)
+	key.X.FillBytes(x)
+	key.Y.FillBytes(y)
+
+	// {1: 2, 3: -7, -1: 1, -2: x, -3: y}
+	result := []byte{0xa5, 0x01, 0x02, 0x03, 0x26, 0x20, 0x01, 0x21}
+	result = append(result, cborBytes(x)...)
+	result = append(result, 0x22)
+	result = append(result, cborBytes(y)...)
+
+	return result
+}
+
+func okpCoseKey(key ed25519.PublicKey) []byte {
+	// {1: 1, 3: -8, -1: 6, -2: x}
+	result := []byte{0xa4, 0x01, 0x01, 0x03, 0x27, 0x20, 0x06, 0x21}
+	return append(result, cborBytes(key)...)
+}
+
+func authData(rpId string, flags byte, signCount uint32, credentialId []byte, coseKey []byte) []byte {
+	rpIdHash := sha256.Sum256([]byte(rpId))
+
+	result := append([]byte{}, rpIdHash[:]...)
+	result = append(result, flags)
+	result = binary.BigEndian.AppendUint32(result, signCount)
+
+	if len(credentialId) > 0 {
+		result = append(result, make([]byte, 16)...) // aaguid
+		result = binary.BigEndian.AppendUint16(result, uint16(len(credentialId)))
+		result = append(result, credentialId...)
+		result = append(result, coseKey...)
+	}
+
+	return result
+}
+
+func attestationObject(authData []byte) []byte {
+	return attestationObjectWithFormat("none", authData)
+}
+
+func attestationObjectWithFormat(format string, authData []byte) []byte {
+	result := []byte{0xa3}
+	result = append(result, cborText("fmt")...)
+	result = append(result, cborText(format)...)
+	result = append(result, cborText("attStmt")...)
+	if format == "none" {
+		result = append(result, 0xa0)
+	} else {
+		// {"alg": -7, "sig": h'00'}
+		result = append(result, 0xa2)
+		result = append(result, cborText("alg")...)
+		result = append(result, 0x26)
+		result = append(result, cborText("sig")...)
+		result = append(result, cborBytes([]byte{0x00})...)
+	}
+	result = append(result, cborText("authData")...)
+	result = append(result, cborBytes(authData)...)
+	return result
+}
+
+func clientData(t *testing.T, typ, challenge, origin string) []byte {
+	raw, err := json.Marshal(map[string]any{
+		"type":      typ,
+		"challenge": challenge,
+		"origin":    origin,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return raw
+}
+
+func TestNewChallenge(t *testing.T) {
+	c1 := webauthn.NewChallenge()
+	c2 := webauthn.NewChallenge()
+
+	if c1 == c2 {
+		t.Fatalf("Expected different challenges, got %q", c1)
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(c1)
+	if err != nil || len(raw) != 32 {
+		t.Fatalf("Expected 32 bytes base64url challenge, got %q (%v)", c1, err)
+	}
+}
+
+func TestDecodeBase64(t *testing.T) {
+	scenarios := []struct {
+		value       string
+		expected    string
+		expectError bool
+	}{
+		{"", "", false},
+		{"dGVzdA", "test", false},
+		{"dGVzdA==", "test", false},
+		{"-_8", "\xfb\xff", false},
+		{"+/8=", "\xfb\xff", false},
+		{"!invalid", "", true},
+	}
+
+	for i, s := range scenarios {
+		result, err := webauthn.DecodeBase64(s.value)
+
+		hasErr := err != nil
+		if hasErr != s.expectError {
+			t.Errorf("(%d) Expected hasErr %v, got %v (%v)", i, s.expectError, hasErr, err)
+			continue
+		}
+
+		if string(result) != s.expected {
+			t.Errorf("(%d) Expected %q, got %q", i, s.expected, result)
+		}
+	}
+}
+
+func TestParseAuthenticatorData(t *testing.T) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	coseKey := ec2CoseKey(&key.PublicKey)
+
+	scenarios := []struct {
+		name        string
+		data        []byte
+		expectError bool
+	}{
+		{"empty", nil, true},
+		{"too short", make([]byte, 36), true},
+		{"without credential", authData("example.com", webauthn.FlagUserPresent, 1, nil, nil), false},
+		{"with credential", authData("example.com", webauthn.FlagUserPresent|webauthn.FlagAttestedCredentialData, 1, []byte("id"), coseKey), false},
+		{"missing credential", authData("example.com", webauthn.FlagAttestedCredentialData, 1, nil, nil), true},
+		{"trailing data", append(authData("example.com", webauthn.FlagUserPresent, 1, nil, nil), 0x01), true},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			data, err := webauthn.ParseAuthenticatorData(s.data)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if hasErr {
+				return
+			}
+
+			if data.SignCount != 1 || !data.UserPresent() || data.UserVerified() {
+				t.Fatalf("Unexpected authenticator data %v", data)
+			}
+
+			if data.CredentialId != nil && string(data.CredentialPublicKey) != string(coseKey) {
+				t.Fatalf("Expected public key %x, got %x", coseKey, data.CredentialPublicKey)
+			}
+		})
+	}
+}
+
+func TestParsePublicKey(t *testing.T) {
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	edKey, _, _ := ed25519.GenerateKey(rand.Reader)
+
+	invalidPoint := ec2CoseKey(&ecKey.PublicKey)
+	invalidPoint[len(invalidPoint)-1] ^= 0xff
+
+	scenarios := []struct {
+		name        string
+		key         []byte
+		expectedAlg int64
+		expectError bool
+	}{
+		{"empty", nil, 0, true},
+		{"not a map", []byte{0x01}, 0, true},
+		{"unsupported algorithm", []byte{0xa2, 0x01, 0x02, 0x03, 0x38, 0x22}, 0, true},
+		{"invalid EC2 point", invalidPoint, 0, true},
+		{"trailing data", append(ec2CoseKey(&ecKey.PublicKey), 0x00), 0, true},
+		{"EC2", ec2CoseKey(&ecKey.PublicKey), webauthn.AlgES256, false},
+		{"OKP", okpCoseKey(edKey), webauthn.AlgEdDSA, false},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			key, err := webauthn.ParsePublicKey(s.key)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if !hasErr && key.Alg != s.expectedAlg {
+				t.Fatalf("Expected alg %d, got %d", s.expectedAlg, key.Alg)
+			}
+		})
+	}
+}
+
+func TestVerifyRegistration(t *testing.T) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	coseKey := ec2CoseKey(&key.PublicKey)
+
+	flags := webauthn.FlagUserPresent | webauthn.FlagAttestedCredentialData
+
+	scenarios := []struct {
+		name        string
+		config      webauthn.Config
+		challenge   string
+		clientData  []byte
+		attestation []byte
+		expectError bool
+	}{
+		{
+			"invalid client data type",
+			testConfig,
+			"abc",
+			clientData(t, webauthn.ClientDataTypeGet, "abc", "https://example.com"),
+			attestationObject(authData("example.com", flags, 0, []byte("id"), coseKey)),
+			true,
+		},
+		{
+			"invalid challenge",
+			testConfig,
+			"abc",
+			clientData(t, webauthn.ClientDataTypeCreate, "xyz", "https://example.com"),
+			attestationObject(authData("example.com", flags, 0, []byte("id"), coseKey)),
+			true,
+		},
+		{
+			"invalid origin",
+			testConfig,
+			"abc",
+			clientData(t, webauthn.ClientDataTypeCreate, "abc", "https://evil.com"),
+			attestationObject(authData("example.com", flags, 0, []byte("id"), coseKey)),
+			true,
+		},
+		{
+			"invalid rp id",
+			testConfig,
+			"abc",
+			clientData(t, webauthn.ClientDataTypeCreate, "abc", "https://example.com"),
+			attestationObject(authData("evil.com", flags, 0, []byte("id"), coseKey)),
+			true,
+		},
+		{
+			"missing user presence",
+			testConfig,
+			"abc",
+			clientData(t, webauthn.ClientDataTypeCreate, "abc", "https://example.com"),
+			attestationObject(authData("example.com", webauthn.FlagAttestedCredentialData, 0, []byte("id"), coseKey)),
+			true,
+		},
+		{
+			"missing required user verification",
+			webauthn.Config{RPId: testConfig.RPId, Origins: testConfig.Origins, RequireUserVerification: true},
+			"abc",
+			clientData(t, webauthn.ClientDataTypeCreate, "abc", "https://example.com"),
+			attestationObject(authData("example.com", flags, 0, []byte("id"), coseKey)),
+			true,
+		},
+		{
+			"missing credential",
+			testConfig,
+			"abc",
+			clientData(t, webauthn.ClientDataTypeCreate, "abc", "https://example.com"),
+			attestationObject(authData("example.com", webauthn.FlagUserPresent, 0, nil, nil)),
+			true,
+		},
+		{
+			"unsupported attestation format",
+			testConfig,
+			"abc",
+			clientData(t, webauthn.ClientDataTypeCreate, "abc", "https://example.com"),
+			attestationObjectWithFormat("unknown", authData("example.com", flags, 0, []byte("id"), coseKey)),
+			true,
+		},
+		{
+			"invalid packed self attestation signature",
+			testConfig,
+			"abc",
+			clientData(t, webauthn.ClientDataTypeCreate, "abc", "https://example.com"),
+			attestationObjectWithFormat("packed", authData("example.com", flags, 0, []byte("id"), coseKey)),
+			true,
+		},
+		{
+			"valid registration",
+			testConfig,
+			"abc",
+			clientData(t, webauthn.ClientDataTypeCreate, "abc", "https://example.com"),
+			attestationObject(authData("example.com", flags, 0, []byte("id"), coseKey)),
+			false,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			credential, err := s.config.VerifyRegistration(s.challenge, s.clientData, s.attestation)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if hasErr {
+				return
+			}
+
+			if string(credential.Id) != "id" {
+				t.Fatalf("Expected credential id %q, got %q", "id", credential.Id)
+			}
+
+			if string(credential.PublicKey) != string(coseKey) {
+				t.Fatalf("Expected public key %x, got %x", coseKey, credential.PublicKey)
+			}
+		})
+	}
+}
+
+func TestVerifyAssertion(t *testing.T) {
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	edPublicKey, edPrivateKey, _ := ed25519.GenerateKey(rand.Reader)
+
+	data := authData("example.com", webauthn.FlagUserPresent, 10, nil, nil)
+	client := clientData(t, webauthn.ClientDataTypeGet, "abc", "https://example.com")
+
+	signed := func(data, client []byte) []byte {
+		hash := sha256.Sum256(client)
+		return append(append([]byte{}, data...), hash[:]...)
+	}
+
+	ecSign := func(data, client []byte) []byte {
+		hash := sha256.Sum256(signed(data, client))
+		sig, err := ecdsa.SignASN1(rand.Reader, ecKey, hash[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sig
+	}
+
+	scenarios := []struct {
+		name        string
+		challenge   string
+		clientData  []byte
+		authData    []byte
+		signature   []byte
+		publicKey   []byte
+		expectError bool
+	}{
+		{
+			"invalid client data type",
+			"abc",
+			clientData(t, webauthn.ClientDataTypeCreate, "abc", "https://example.com"),
+			data,
+			ecSign(data, clientData(t, webauthn.ClientDataTypeCreate, "abc", "https://example.com")),
+			ec2CoseKey(&ecKey.PublicKey),
+			true,
+		},
+		{
+			"invalid challenge",
+			"xyz",
+			client,
+			data,
+			ecSign(data, client),
+			ec2CoseKey(&ecKey.PublicKey),
+			true,
+		},
+		{
+			"invalid signature",
+			"abc",
+			client,
+			data,
+			ecSign(data, []byte("other")),
+			ec2CoseKey(&ecKey.PublicKey),
+			true,
+		},
+		{
+			"another key",
+			"abc",
+			client,
+			data,
+			ecSign(data, client),
+			okpCoseKey(edPublicKey),
+			true,
+		},
+		{
+			"valid ES256 signature",
+			"abc",
+			client,
+			data,
+			ecSign(data, client),
+			ec2CoseKey(&ecKey.PublicKey),
+			false,
+		},
+		{
+			"valid EdDSA signature",
+			"abc",
+			client,
+			data,
+			ed25519.Sign(edPrivateKey, signed(data, client)),
+			okpCoseKey(edPublicKey),
+			false,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			result, err := testConfig.VerifyAssertion(s.challenge, s.clientData, s.authData, s.signature, s.publicKey)
+
+			hasErr := err != nil
+			if hasErr != s.expectError {
+				t.Fatalf("Expected hasErr %v, got %v (%v)", s.expectError, hasErr, err)
+			}
+
+			if !hasErr && result.SignCount != 10 {
+				t.Fatalf("Expected sign count 10, got %d", result.SignCount)
+			}
+		})
+	}
+}